@@ -53,6 +53,14 @@ type SendMessageRequest struct {
 // SendMessageResponse 聊天完成响应结构
 type SendMessageResponse struct {
 	Choices []SendMessageChoice `json:"choices"`
+	Usage   Usage               `json:"usage"` // token用量，供应商未返回时为零值
+}
+
+// Usage 一次模型调用的token用量
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`     // 提示词token数
+	CompletionTokens int `json:"completion_tokens"` // 回复token数
+	TotalTokens      int `json:"total_tokens"`      // 总token数
 }
 
 // SendMessageChoice 聊天完成选择结构
@@ -62,8 +70,10 @@ type SendMessageChoice struct {
 }
 
 // SendMessageStreamResponse 流式聊天完成响应结构
+// 供应商在最后一个分块（完成原因之后，Choices 为空）返回本次调用的token用量，其他分块 Usage 为 nil
 type SendMessageStreamResponse struct {
 	Choices []SendMessageStreamChoice `json:"choices"`
+	Usage   *Usage                    `json:"usage,omitempty"`
 }
 
 // SendMessageStreamChoice 流式聊天完成选择结构
@@ -85,6 +95,7 @@ type SendMessageStream interface {
 }
 
 // LemonAiClient AI客户端接口
+// 供应商返回的错误转换为 *ProviderError，调用方可以按错误类型给出处理建议
 type LemonAiClient interface {
 	// SendMessage 发送消息
 	SendMessage(ctx context.Context, req SendMessageRequest) (*SendMessageResponse, error)
@@ -92,3 +103,49 @@ type LemonAiClient interface {
 	// SendMessageStream 发送流式消息
 	SendMessageStream(ctx context.Context, req SendMessageRequest) (SendMessageStream, error)
 }
+
+// CreateEmbeddingsRequest 文本嵌入请求结构
+type CreateEmbeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// CreateEmbeddingsResponse 文本嵌入响应结构
+// Embeddings 与请求的 Input 一一对应
+type CreateEmbeddingsResponse struct {
+	Embeddings  [][]float32 `json:"embeddings"`
+	TotalTokens int         `json:"total_tokens"`
+}
+
+// LemonAiEmbeddingClient 文本嵌入客户端接口
+type LemonAiEmbeddingClient interface {
+	// CreateEmbeddings 生成文本嵌入向量
+	CreateEmbeddings(ctx context.Context, req CreateEmbeddingsRequest) (*CreateEmbeddingsResponse, error)
+}
+
+// RerankRequest 重排请求结构
+type RerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+// RerankResult 重排结果结构
+// Index 为文档在请求 Documents 中的下标
+type RerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+// RerankResponse 重排响应结构
+// Results 按相关性倒序排列
+type RerankResponse struct {
+	Results []RerankResult `json:"results"`
+}
+
+// LemonAiRerankClient 重排客户端接口
+type LemonAiRerankClient interface {
+	// Rerank 按与查询的相关性对文档重新排序
+	Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error)
+}
@@ -0,0 +1,4 @@
+package al_client
+
+// 为全部AI客户端接口生成 gomock 模拟实现，供单元测试使用，接口变更后重新执行 go generate ./...
+//go:generate go tool mockgen -destination=mock_al_client/mock_al_client.go -package=mock_al_client lemon-tree-core/internal/al_client LemonAiClient,LemonAiEmbeddingClient,LemonAiRerankClient,SendMessageStream
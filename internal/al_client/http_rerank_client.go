@@ -0,0 +1,90 @@
+package al_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HttpRerankClient 重排客户端实现
+// 调用 Jina、Cohere、硅基流动、vLLM 等通用的 POST {api_url}/rerank 接口
+type HttpRerankClient struct {
+	apiUrl     string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHttpRerankClient 创建重排客户端
+// apiUrl 为提供商的 API 地址（如 https://api.jina.ai/v1），请求发送到该地址下的 /rerank
+func NewHttpRerankClient(apiUrl, apiKey string) *HttpRerankClient {
+	return &HttpRerankClient{
+		apiUrl:     strings.TrimRight(apiUrl, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// rerankApiRequest 重排接口请求体
+type rerankApiRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+// rerankApiResponse 重排接口响应体
+type rerankApiResponse struct {
+	Results []RerankResult `json:"results"`
+}
+
+// Rerank 按与查询的相关性对文档重新排序
+func (c *HttpRerankClient) Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error) {
+	body, err := json.Marshal(rerankApiRequest{
+		Model:     req.Model,
+		Query:     req.Query,
+		Documents: req.Documents,
+		TopN:      req.TopN,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiUrl+"/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("重排接口返回错误: %d %s", httpResp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var response rerankApiResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("解析重排结果失败: %w", err)
+	}
+	for _, result := range response.Results {
+		if result.Index < 0 || result.Index >= len(req.Documents) {
+			return nil, fmt.Errorf("重排结果索引无效: %d", result.Index)
+		}
+	}
+	return &RerankResponse{Results: response.Results}, nil
+}
@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lemon-tree-core/internal/al_client (interfaces: LemonAiClient,LemonAiEmbeddingClient,LemonAiRerankClient,SendMessageStream)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_al_client/mock_al_client.go -package=mock_al_client lemon-tree-core/internal/al_client LemonAiClient,LemonAiEmbeddingClient,LemonAiRerankClient,SendMessageStream
+//
+
+// Package mock_al_client is a generated GoMock package.
+package mock_al_client
+
+import (
+	context "context"
+	al_client "lemon-tree-core/internal/al_client"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLemonAiClient is a mock of LemonAiClient interface.
+type MockLemonAiClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLemonAiClientMockRecorder
+	isgomock struct{}
+}
+
+// MockLemonAiClientMockRecorder is the mock recorder for MockLemonAiClient.
+type MockLemonAiClientMockRecorder struct {
+	mock *MockLemonAiClient
+}
+
+// NewMockLemonAiClient creates a new mock instance.
+func NewMockLemonAiClient(ctrl *gomock.Controller) *MockLemonAiClient {
+	mock := &MockLemonAiClient{ctrl: ctrl}
+	mock.recorder = &MockLemonAiClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLemonAiClient) EXPECT() *MockLemonAiClientMockRecorder {
+	return m.recorder
+}
+
+// SendMessage mocks base method.
+func (m *MockLemonAiClient) SendMessage(ctx context.Context, req al_client.SendMessageRequest) (*al_client.SendMessageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", ctx, req)
+	ret0, _ := ret[0].(*al_client.SendMessageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockLemonAiClientMockRecorder) SendMessage(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockLemonAiClient)(nil).SendMessage), ctx, req)
+}
+
+// SendMessageStream mocks base method.
+func (m *MockLemonAiClient) SendMessageStream(ctx context.Context, req al_client.SendMessageRequest) (al_client.SendMessageStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessageStream", ctx, req)
+	ret0, _ := ret[0].(al_client.SendMessageStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessageStream indicates an expected call of SendMessageStream.
+func (mr *MockLemonAiClientMockRecorder) SendMessageStream(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageStream", reflect.TypeOf((*MockLemonAiClient)(nil).SendMessageStream), ctx, req)
+}
+
+// MockLemonAiEmbeddingClient is a mock of LemonAiEmbeddingClient interface.
+type MockLemonAiEmbeddingClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLemonAiEmbeddingClientMockRecorder
+	isgomock struct{}
+}
+
+// MockLemonAiEmbeddingClientMockRecorder is the mock recorder for MockLemonAiEmbeddingClient.
+type MockLemonAiEmbeddingClientMockRecorder struct {
+	mock *MockLemonAiEmbeddingClient
+}
+
+// NewMockLemonAiEmbeddingClient creates a new mock instance.
+func NewMockLemonAiEmbeddingClient(ctrl *gomock.Controller) *MockLemonAiEmbeddingClient {
+	mock := &MockLemonAiEmbeddingClient{ctrl: ctrl}
+	mock.recorder = &MockLemonAiEmbeddingClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLemonAiEmbeddingClient) EXPECT() *MockLemonAiEmbeddingClientMockRecorder {
+	return m.recorder
+}
+
+// CreateEmbeddings mocks base method.
+func (m *MockLemonAiEmbeddingClient) CreateEmbeddings(ctx context.Context, req al_client.CreateEmbeddingsRequest) (*al_client.CreateEmbeddingsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmbeddings", ctx, req)
+	ret0, _ := ret[0].(*al_client.CreateEmbeddingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmbeddings indicates an expected call of CreateEmbeddings.
+func (mr *MockLemonAiEmbeddingClientMockRecorder) CreateEmbeddings(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmbeddings", reflect.TypeOf((*MockLemonAiEmbeddingClient)(nil).CreateEmbeddings), ctx, req)
+}
+
+// MockLemonAiRerankClient is a mock of LemonAiRerankClient interface.
+type MockLemonAiRerankClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLemonAiRerankClientMockRecorder
+	isgomock struct{}
+}
+
+// MockLemonAiRerankClientMockRecorder is the mock recorder for MockLemonAiRerankClient.
+type MockLemonAiRerankClientMockRecorder struct {
+	mock *MockLemonAiRerankClient
+}
+
+// NewMockLemonAiRerankClient creates a new mock instance.
+func NewMockLemonAiRerankClient(ctrl *gomock.Controller) *MockLemonAiRerankClient {
+	mock := &MockLemonAiRerankClient{ctrl: ctrl}
+	mock.recorder = &MockLemonAiRerankClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLemonAiRerankClient) EXPECT() *MockLemonAiRerankClientMockRecorder {
+	return m.recorder
+}
+
+// Rerank mocks base method.
+func (m *MockLemonAiRerankClient) Rerank(ctx context.Context, req al_client.RerankRequest) (*al_client.RerankResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rerank", ctx, req)
+	ret0, _ := ret[0].(*al_client.RerankResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rerank indicates an expected call of Rerank.
+func (mr *MockLemonAiRerankClientMockRecorder) Rerank(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rerank", reflect.TypeOf((*MockLemonAiRerankClient)(nil).Rerank), ctx, req)
+}
+
+// MockSendMessageStream is a mock of SendMessageStream interface.
+type MockSendMessageStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockSendMessageStreamMockRecorder
+	isgomock struct{}
+}
+
+// MockSendMessageStreamMockRecorder is the mock recorder for MockSendMessageStream.
+type MockSendMessageStreamMockRecorder struct {
+	mock *MockSendMessageStream
+}
+
+// NewMockSendMessageStream creates a new mock instance.
+func NewMockSendMessageStream(ctrl *gomock.Controller) *MockSendMessageStream {
+	mock := &MockSendMessageStream{ctrl: ctrl}
+	mock.recorder = &MockSendMessageStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSendMessageStream) EXPECT() *MockSendMessageStreamMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSendMessageStream) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSendMessageStreamMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSendMessageStream)(nil).Close))
+}
+
+// Recv mocks base method.
+func (m *MockSendMessageStream) Recv() (*al_client.SendMessageStreamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*al_client.SendMessageStreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockSendMessageStreamMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockSendMessageStream)(nil).Recv))
+}
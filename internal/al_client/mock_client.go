@@ -0,0 +1,318 @@
+package al_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 模拟客户端的固定行为
+const (
+	mockEmbeddingDimension = 64                    // 模拟嵌入向量的维度
+	mockStreamChunkRunes   = 4                     // 流式输出每个分块的字符数
+	mockStreamChunkDelay   = 20 * time.Millisecond // 流式输出分块之间的间隔，便于前端观察逐字输出
+	mockToolResultPreview  = 200                   // 回答中展示的工具返回值最大字符数
+	mockToolCommand        = "/tool"               // 触发模拟工具调用的消息前缀
+	mockErrorCommand       = "/error"              // 触发模拟调用失败的消息前缀
+	mockContextCommand     = "/context"            // 模拟上下文长度限制的消息前缀
+)
+
+// MockClient 模拟AI客户端
+// 不调用任何外部接口，根据请求内容返回确定的回答，用于本地开发和集成测试：
+//   - 普通消息：回答 "[mock] 收到：<用户消息>"
+//   - 以 /tool 开头的消息：请求提供了工具时调用工具，"/tool 名称" 调用指定工具，否则调用第一个工具，参数为用户消息中的 JSON 对象（没有时为 {}）
+//   - 最后一条是工具返回值：回答工具名称和返回值摘要
+//   - 以 /error 开头的消息：返回调用失败，用于测试错误处理；"/error 错误类型"（如 /error auth）返回对应类型的供应商错误
+//   - "/context 消息数"：请求的消息数超过指定数量时返回超过上下文长度的错误，否则按普通消息回答，用于测试上下文超长的恢复
+//
+// token用量按字符数计算：提示词token数为全部消息内容和工具调用参数的字符数，回复token数为回答内容和工具调用参数的字符数
+//
+// 同时实现文本嵌入和重排接口：嵌入向量由词语哈希生成，重排按词语重合度评分
+type MockClient struct{}
+
+// NewMockClient 创建模拟AI客户端
+func NewMockClient() *MockClient {
+	return &MockClient{}
+}
+
+// SendMessage 发送消息
+func (c *MockClient) SendMessage(ctx context.Context, req SendMessageRequest) (*SendMessageResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	message, finishReason, err := mockReply(req)
+	if err != nil {
+		return nil, err
+	}
+	return &SendMessageResponse{
+		Choices: []SendMessageChoice{{Message: message, FinishReason: finishReason}},
+		Usage:   mockUsage(req, message),
+	}, nil
+}
+
+// SendMessageStream 发送流式消息
+// 回答按固定字符数分块输出，工具调用在一个分块中完整输出
+func (c *MockClient) SendMessageStream(ctx context.Context, req SendMessageRequest) (SendMessageStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	message, finishReason, err := mockReply(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []*SendMessageStreamResponse
+	if len(message.ToolCalls) > 0 {
+		chunks = append(chunks, &SendMessageStreamResponse{
+			Choices: []SendMessageStreamChoice{{Delta: SendMessageStreamDelta{ToolCalls: message.ToolCalls}}},
+		})
+	}
+	runes := []rune(message.Content)
+	for start := 0; start < len(runes); start += mockStreamChunkRunes {
+		end := min(start+mockStreamChunkRunes, len(runes))
+		chunks = append(chunks, &SendMessageStreamResponse{
+			Choices: []SendMessageStreamChoice{{Delta: SendMessageStreamDelta{Content: string(runes[start:end])}}},
+		})
+	}
+	usage := mockUsage(req, message)
+	chunks = append(chunks, &SendMessageStreamResponse{
+		Choices: []SendMessageStreamChoice{{FinishReason: finishReason}},
+	}, &SendMessageStreamResponse{Usage: &usage})
+	return &mockStream{ctx: ctx, chunks: chunks}, nil
+}
+
+// CreateEmbeddings 生成文本嵌入向量
+// 将文本中的词语哈希到固定维度并归一化，包含相同词语的文本向量相似
+func (c *MockClient) CreateEmbeddings(ctx context.Context, req CreateEmbeddingsRequest) (*CreateEmbeddingsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	response := &CreateEmbeddingsResponse{Embeddings: make([][]float32, len(req.Input))}
+	for i, text := range req.Input {
+		terms := mockTerms(text)
+		vector := make([]float32, mockEmbeddingDimension)
+		for _, term := range terms {
+			hash := fnv.New32a()
+			hash.Write([]byte(term))
+			vector[hash.Sum32()%mockEmbeddingDimension]++
+		}
+		var norm float64
+		for _, value := range vector {
+			norm += float64(value) * float64(value)
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for j := range vector {
+				vector[j] = float32(float64(vector[j]) / norm)
+			}
+		}
+		response.Embeddings[i] = vector
+		response.TotalTokens += len(terms)
+	}
+	return response, nil
+}
+
+// Rerank 按与查询的相关性对文档重新排序
+// 相关性为查询词语在文档中出现的比例
+func (c *MockClient) Rerank(ctx context.Context, req RerankRequest) (*RerankResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	queryTerms := mockTerms(req.Query)
+	results := make([]RerankResult, 0, len(req.Documents))
+	for i, document := range req.Documents {
+		documentTerms := make(map[string]bool)
+		for _, term := range mockTerms(document) {
+			documentTerms[term] = true
+		}
+		matched := 0
+		for _, term := range queryTerms {
+			if documentTerms[term] {
+				matched++
+			}
+		}
+		score := 0.0
+		if len(queryTerms) > 0 {
+			score = float64(matched) / float64(len(queryTerms))
+		}
+		results = append(results, RerankResult{Index: i, RelevanceScore: score})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RelevanceScore > results[j].RelevanceScore
+	})
+	if req.TopN > 0 && req.TopN < len(results) {
+		results = results[:req.TopN]
+	}
+	return &RerankResponse{Results: results}, nil
+}
+
+// mockStream 模拟流式响应
+type mockStream struct {
+	ctx    context.Context
+	chunks []*SendMessageStreamResponse
+	next   int
+}
+
+// Recv 接收流式数据
+// 上下文取消后直接结束流，与调用方按 io.EOF 结束读取的处理方式一致
+func (s *mockStream) Recv() (*SendMessageStreamResponse, error) {
+	if s.next >= len(s.chunks) {
+		return nil, io.EOF
+	}
+	if s.next > 0 {
+		select {
+		case <-s.ctx.Done():
+			s.next = len(s.chunks)
+			return nil, io.EOF
+		case <-time.After(mockStreamChunkDelay):
+		}
+	}
+	chunk := s.chunks[s.next]
+	s.next++
+	return chunk, nil
+}
+
+// Close 关闭流
+func (s *mockStream) Close() {
+	s.next = len(s.chunks)
+}
+
+// mockUsage 按字符数计算模拟的token用量
+func mockUsage(req SendMessageRequest, reply ChatMessage) Usage {
+	countRunes := func(message ChatMessage) int {
+		count := utf8.RuneCountInString(message.Content)
+		for _, toolCall := range message.ToolCalls {
+			count += utf8.RuneCountInString(toolCall.Function.Arguments)
+		}
+		return count
+	}
+	usage := Usage{CompletionTokens: countRunes(reply)}
+	for _, message := range req.Messages {
+		usage.PromptTokens += countRunes(message)
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage
+}
+
+// mockReply 根据请求生成确定的回答
+// 返回：助手消息、完成原因和模拟的调用错误
+func mockReply(req SendMessageRequest) (ChatMessage, string, error) {
+	if len(req.Messages) == 0 {
+		return ChatMessage{}, "", errors.New("mock: 消息列表为空")
+	}
+
+	last := req.Messages[len(req.Messages)-1]
+	if last.Role == "tool" {
+		toolName := ""
+		for i := len(req.Messages) - 2; i >= 0 && toolName == ""; i-- {
+			for _, toolCall := range req.Messages[i].ToolCalls {
+				if toolCall.ID == last.ToolCallID {
+					toolName = toolCall.Function.Name
+				}
+			}
+		}
+		result := []rune(last.Content)
+		if len(result) > mockToolResultPreview {
+			result = append(result[:mockToolResultPreview], []rune("...")...)
+		}
+		return ChatMessage{
+			Role:    "assistant",
+			Content: fmt.Sprintf("[mock] 工具 %s 返回：%s", toolName, string(result)),
+		}, "stop", nil
+	}
+
+	content := strings.TrimSpace(last.Content)
+	if strings.HasPrefix(content, mockErrorCommand) {
+		if kind := strings.TrimSpace(strings.TrimPrefix(content, mockErrorCommand)); kind != "" {
+			return ChatMessage{}, "", &ProviderError{Kind: ProviderErrorKind(kind), Message: "mock: 模拟供应商错误"}
+		}
+		return ChatMessage{}, "", errors.New("mock: 模拟调用失败")
+	}
+	if strings.HasPrefix(content, mockContextCommand) {
+		limit, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(content, mockContextCommand)))
+		if err == nil && len(req.Messages) > limit {
+			return ChatMessage{}, "", &ProviderError{
+				Kind:    ProviderErrorKindContextTooLong,
+				Message: fmt.Sprintf("mock: 消息数 %d 超过上下文长度 %d", len(req.Messages), limit),
+			}
+		}
+	}
+	if strings.HasPrefix(content, mockToolCommand) && len(req.Tools) > 0 {
+		if toolCall, ok := mockToolCall(req, strings.TrimSpace(strings.TrimPrefix(content, mockToolCommand))); ok {
+			return ChatMessage{Role: "assistant", ToolCalls: []ToolCall{toolCall}}, "tool_calls", nil
+		}
+	}
+	return ChatMessage{
+		Role:    "assistant",
+		Content: "[mock] 收到：" + content,
+	}, "stop", nil
+}
+
+// mockToolCall 根据 /tool 指令生成工具调用
+// 参数：argument - 指令后的内容，以工具名称开头时调用该工具，其中的 JSON 对象作为工具参数
+func mockToolCall(req SendMessageRequest, argument string) (ToolCall, bool) {
+	var tool *Tool
+	for i := range req.Tools {
+		if req.Tools[i].Function == nil {
+			continue
+		}
+		if tool == nil {
+			tool = &req.Tools[i]
+		}
+		if name := req.Tools[i].Function.Name; argument == name || strings.HasPrefix(argument, name+" ") {
+			tool = &req.Tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		return ToolCall{}, false
+	}
+
+	arguments := "{}"
+	if start, end := strings.Index(argument, "{"), strings.LastIndex(argument, "}"); start >= 0 && end > start {
+		arguments = argument[start : end+1]
+	}
+	return ToolCall{
+		// 同一会话中每次调用的ID不同且可复现
+		ID:   fmt.Sprintf("call_mock_%d", len(req.Messages)),
+		Type: "function",
+		Function: FunctionCall{
+			Name:      tool.Function.Name,
+			Arguments: arguments,
+		},
+	}, true
+}
+
+// mockTerms 将文本拆分为小写词语，中文等没有空格分隔的文字按单字拆分
+func mockTerms(text string) []string {
+	var terms []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			terms = append(terms, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			flush()
+			terms = append(terms, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return terms
+}
@@ -35,12 +35,13 @@ func (c *OpenAIChatCompletionsClient) SendMessage(ctx context.Context, req SendM
 	// 调用OpenAI API
 	response, err := c.client.CreateChatCompletion(ctx, openaiReq)
 	if err != nil {
-		return nil, err
+		return nil, classifyOpenAIError(err)
 	}
 
 	// 转换响应格式
 	return &SendMessageResponse{
 		Choices: convertToLemonChoices(response.Choices),
+		Usage:   convertToLemonUsage(response.Usage),
 	}, nil
 }
 
@@ -56,12 +57,14 @@ func (c *OpenAIChatCompletionsClient) SendMessageStream(ctx context.Context, req
 		TopP:        float32(req.TopP),
 		ToolChoice:  req.ToolChoice,
 		MaxTokens:   req.MaxTokens,
+		// 要求在流的最后返回token用量
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
 	}
 
 	// 调用OpenAI流式API
 	stream, err := c.client.CreateChatCompletionStream(ctx, openaiReq)
 	if err != nil {
-		return nil, err
+		return nil, classifyOpenAIError(err)
 	}
 
 	// 包装流式响应
@@ -77,12 +80,17 @@ type OpenAIStreamWrapper struct {
 func (w *OpenAIStreamWrapper) Recv() (*SendMessageStreamResponse, error) {
 	chunk, err := w.stream.Recv()
 	if err != nil {
-		return nil, err
+		return nil, classifyOpenAIError(err)
 	}
 
-	return &SendMessageStreamResponse{
+	response := &SendMessageStreamResponse{
 		Choices: convertToLemonStreamChoices(chunk.Choices),
-	}, nil
+	}
+	if chunk.Usage != nil {
+		usage := convertToLemonUsage(*chunk.Usage)
+		response.Usage = &usage
+	}
+	return response, nil
 }
 
 // Close 关闭流
@@ -174,6 +182,15 @@ func convertToLemonStreamChoices(choices []openai.ChatCompletionStreamChoice) []
 	return lemonChoices
 }
 
+// convertToLemonUsage 转换token用量格式
+func convertToLemonUsage(usage openai.Usage) Usage {
+	return Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+}
+
 // convertToOpenAIToolCalls 转换工具调用格式到OpenAI
 func convertToOpenAIToolCalls(toolCalls []ToolCall) []openai.ToolCall {
 	openaiToolCalls := make([]openai.ToolCall, len(toolCalls))
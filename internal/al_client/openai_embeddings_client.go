@@ -0,0 +1,36 @@
+package al_client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// CreateEmbeddings 生成文本嵌入向量
+func (c *OpenAIChatCompletionsClient) CreateEmbeddings(ctx context.Context, req CreateEmbeddingsRequest) (*CreateEmbeddingsResponse, error) {
+	response, err := c.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input: req.Input,
+		Model: openai.EmbeddingModel(req.Model),
+	})
+	if err != nil {
+		return nil, classifyOpenAIError(err)
+	}
+	if len(response.Data) != len(req.Input) {
+		return nil, fmt.Errorf("嵌入结果数量不匹配: 期望 %d，实际 %d", len(req.Input), len(response.Data))
+	}
+
+	// 按返回的索引排列，与请求的 Input 一一对应
+	embeddings := make([][]float32, len(response.Data))
+	for _, item := range response.Data {
+		if item.Index < 0 || item.Index >= len(embeddings) {
+			return nil, fmt.Errorf("嵌入结果索引无效: %d", item.Index)
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+
+	return &CreateEmbeddingsResponse{
+		Embeddings:  embeddings,
+		TotalTokens: response.Usage.TotalTokens,
+	}, nil
+}
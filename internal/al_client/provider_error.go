@@ -0,0 +1,165 @@
+package al_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// ProviderErrorKind 模型供应商错误类型
+type ProviderErrorKind string
+
+const (
+	ProviderErrorKindAuth           ProviderErrorKind = "auth"             // API Key 无效或没有权限
+	ProviderErrorKindQuota          ProviderErrorKind = "quota"            // 账户额度已用完
+	ProviderErrorKindRateLimit      ProviderErrorKind = "rate_limit"       // 请求过于频繁
+	ProviderErrorKindContextTooLong ProviderErrorKind = "context_too_long" // 消息超过模型的上下文长度
+	ProviderErrorKindContentFilter  ProviderErrorKind = "content_filter"   // 内容被供应商的安全策略拦截
+	ProviderErrorKindModelNotFound  ProviderErrorKind = "model_not_found"  // 模型不存在或没有使用权限
+	ProviderErrorKindUnavailable    ProviderErrorKind = "unavailable"      // 供应商服务暂不可用或网络不通
+	ProviderErrorKindInvalidRequest ProviderErrorKind = "invalid_request"  // 请求参数不被供应商接受
+	ProviderErrorKindUnknown        ProviderErrorKind = "unknown"          // 无法识别的错误
+)
+
+// contentFilterFinishReason 回答被供应商的安全策略拦截时的完成原因
+const contentFilterFinishReason = "content_filter"
+
+// ProviderError 模型供应商返回的错误
+// 按错误类型区分原因，Message 保留供应商返回的原始信息，用于日志和排查问题
+type ProviderError struct {
+	Kind       ProviderErrorKind // 错误类型
+	StatusCode int               // HTTP 状态码，没有响应时为 0
+	Message    string            // 供应商返回的原始错误信息
+	Err        error             // 原始错误
+}
+
+// Error 返回错误信息
+func (e *ProviderError) Error() string {
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("模型供应商错误（%s，状态码 %d）: %s", e.Kind, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("模型供应商错误（%s）: %s", e.Kind, e.Message)
+}
+
+// Unwrap 返回原始错误
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// NewContentFilterError 创建回答因安全策略被中止的错误
+// 供应商以 content_filter 完成原因结束回答时使用
+func NewContentFilterError() *ProviderError {
+	return &ProviderError{Kind: ProviderErrorKindContentFilter, Message: "finish_reason=" + contentFilterFinishReason}
+}
+
+// IsContentFilterFinishReason 判断完成原因是否为回答被安全策略拦截
+func IsContentFilterFinishReason(finishReason string) bool {
+	return finishReason == contentFilterFinishReason
+}
+
+// AsProviderError 从错误链中取出模型供应商错误
+// 返回：供应商错误和是否存在
+func AsProviderError(err error) (*ProviderError, bool) {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr, true
+	}
+	return nil, false
+}
+
+// classifyOpenAIError 将 OpenAI 兼容接口返回的错误转换为模型供应商错误
+// 流结束、上下文取消和超时原样返回，由调用方按正常结束、生成时间或客户端断开处理
+func classifyOpenAIError(err error) error {
+	if err == nil || errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		code := ""
+		if apiErr.Code != nil {
+			code = fmt.Sprint(apiErr.Code)
+		}
+		if apiErr.InnerError != nil && apiErr.InnerError.Code != "" {
+			code += " " + apiErr.InnerError.Code
+		}
+		return &ProviderError{
+			Kind:       classifyProviderError(apiErr.HTTPStatusCode, code+" "+apiErr.Type, apiErr.Message),
+			StatusCode: apiErr.HTTPStatusCode,
+			Message:    apiErr.Message,
+			Err:        err,
+		}
+	}
+
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		message := strings.TrimSpace(string(requestErr.Body))
+		if message == "" && requestErr.Err != nil {
+			message = requestErr.Err.Error()
+		}
+		return &ProviderError{
+			Kind:       classifyProviderError(requestErr.HTTPStatusCode, "", message),
+			StatusCode: requestErr.HTTPStatusCode,
+			Message:    message,
+			Err:        err,
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &ProviderError{Kind: ProviderErrorKindUnavailable, Message: err.Error(), Err: err}
+	}
+	return &ProviderError{Kind: ProviderErrorKindUnknown, Message: err.Error(), Err: err}
+}
+
+// classifyProviderError 根据状态码、错误码和错误信息判断错误类型
+// 各供应商的错误码不统一，先按错误码和信息中的关键词识别，再按状态码兜底
+func classifyProviderError(statusCode int, code, message string) ProviderErrorKind {
+	code = strings.ToLower(code)
+	message = strings.ToLower(message)
+	contains := func(keywords ...string) bool {
+		for _, keyword := range keywords {
+			if strings.Contains(code, keyword) || strings.Contains(message, keyword) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case contains("context_length_exceeded", "context length", "context window", "maximum context", "too many tokens", "prompt is too long", "input is too long"):
+		return ProviderErrorKindContextTooLong
+	case contains("content_filter", "content_policy", "content management policy", "safety", "sensitive"):
+		return ProviderErrorKindContentFilter
+	case contains("insufficient_quota", "quota", "billing", "balance", "余额", "欠费"):
+		return ProviderErrorKindQuota
+	case contains("invalid_api_key", "incorrect api key", "invalid api key", "authentication", "unauthorized"):
+		return ProviderErrorKindAuth
+	case contains("model_not_found") || (statusCode == http.StatusNotFound && contains("model")):
+		return ProviderErrorKindModelNotFound
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ProviderErrorKindAuth
+	case statusCode == http.StatusPaymentRequired:
+		return ProviderErrorKindQuota
+	case statusCode == http.StatusTooManyRequests:
+		return ProviderErrorKindRateLimit
+	case statusCode == http.StatusRequestEntityTooLarge:
+		return ProviderErrorKindContextTooLong
+	case statusCode >= http.StatusInternalServerError:
+		return ProviderErrorKindUnavailable
+	case statusCode == http.StatusNotFound:
+		return ProviderErrorKindModelNotFound
+	case statusCode >= http.StatusBadRequest:
+		return ProviderErrorKindInvalidRequest
+	}
+	return ProviderErrorKindUnknown
+}
@@ -0,0 +1,93 @@
+// Package apiversion 提供API版本管理功能
+// 同一组处理器同时服务多个API版本，响应DTO在输出前按版本映射为对应版本的结构
+package apiversion
+
+import (
+	"lemon-tree-core/internal/define"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	V1 = "v1" // 第一版API
+	V2 = "v2" // 第二版API
+)
+
+// Mapper 响应DTO映射函数
+// 将处理器输出的响应DTO（v1 结构）转换为指定版本的响应结构
+type Mapper func(payload interface{}) interface{}
+
+var (
+	mappersLock sync.RWMutex
+	mappers     = map[string]map[reflect.Type]Mapper{} // 版本 -> 响应DTO类型 -> 映射函数
+)
+
+// Middleware API版本中间件
+// 将路由组对应的API版本写入上下文
+// 参数：version - API版本
+// 返回 Gin 中间件函数
+func Middleware(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(define.AppContextKeyApiVersion, version)
+		c.Next()
+	}
+}
+
+// FromContext 获取当前请求的API版本
+// 参数：c - Gin 上下文
+// 返回：API版本，未设置时视为 v1
+func FromContext(c *gin.Context) string {
+	if version := c.GetString(define.AppContextKeyApiVersion); version != "" {
+		return version
+	}
+	return V1
+}
+
+// RegisterMapper 注册响应DTO映射函数
+// 参数：version - API版本；sample - 响应DTO样例（值或指针均可，仅用于确定类型）；mapper - 映射函数
+func RegisterMapper(version string, sample interface{}, mapper Mapper) {
+	mappersLock.Lock()
+	defer mappersLock.Unlock()
+
+	if mappers[version] == nil {
+		mappers[version] = map[reflect.Type]Mapper{}
+	}
+	mappers[version][indirectType(sample)] = mapper
+}
+
+// Map 按API版本映射响应DTO
+// 参数：version - API版本；payload - 响应DTO
+// 返回：映射后的响应结构，没有注册映射函数时原样返回
+func Map(version string, payload interface{}) interface{} {
+	if payload == nil {
+		return nil
+	}
+
+	mappersLock.RLock()
+	mapper, ok := mappers[version][indirectType(payload)]
+	mappersLock.RUnlock()
+	if !ok {
+		return payload
+	}
+
+	// 映射函数统一接收值类型
+	value := reflect.ValueOf(payload)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return payload
+		}
+		payload = value.Elem().Interface()
+	}
+	return mapper(payload)
+}
+
+// indirectType 获取去除指针后的类型
+func indirectType(v interface{}) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,134 @@
+// Package apperror 提供业务错误类型定义
+// 业务层通过错误码描述错误类别，由错误转换中间件统一转换为标准错误响应
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code 业务错误码
+type Code string
+
+const (
+	CodeInvalidArgument    Code = "INVALID_ARGUMENT"    // 请求参数错误
+	CodeUnauthorized       Code = "UNAUTHORIZED"        // 未认证或认证失败
+	CodeForbidden          Code = "FORBIDDEN"           // 无权访问
+	CodeNotFound           Code = "NOT_FOUND"           // 资源不存在
+	CodeConflict           Code = "CONFLICT"            // 资源冲突
+	CodeInvalidReference   Code = "INVALID_REFERENCE"   // 引用的关联资源不存在或不属于同一应用
+	CodePayloadTooLarge    Code = "PAYLOAD_TOO_LARGE"   // 请求内容过大
+	CodeTooManyRequests    Code = "TOO_MANY_REQUESTS"   // 请求过于频繁
+	CodeInternal           Code = "INTERNAL_ERROR"      // 服务器内部错误
+	CodeServiceUnavailable Code = "SERVICE_UNAVAILABLE" // 服务暂不可用
+)
+
+// codeHTTPStatus 错误码与HTTP状态码的对应关系
+var codeHTTPStatus = map[Code]int{
+	CodeInvalidArgument:    http.StatusBadRequest,
+	CodeUnauthorized:       http.StatusUnauthorized,
+	CodeForbidden:          http.StatusForbidden,
+	CodeNotFound:           http.StatusNotFound,
+	CodeConflict:           http.StatusConflict,
+	CodeInvalidReference:   http.StatusUnprocessableEntity,
+	CodePayloadTooLarge:    http.StatusRequestEntityTooLarge,
+	CodeTooManyRequests:    http.StatusTooManyRequests,
+	CodeInternal:           http.StatusInternalServerError,
+	CodeServiceUnavailable: http.StatusServiceUnavailable,
+}
+
+// Error 业务错误
+// 包含错误码、面向调用方的错误信息、可选的错误详情以及底层错误
+type Error struct {
+	Code    Code        // 错误码
+	Message string      // 错误信息
+	Details interface{} // 错误详情（可选）
+	Err     error       // 底层错误（可选，不直接返回给调用方）
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap 返回底层错误，支持 errors.Is / errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatus 返回错误码对应的HTTP状态码
+func (e *Error) HTTPStatus() int {
+	if status, ok := codeHTTPStatus[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithDetails 设置错误详情
+// 参数：details - 错误详情，会原样序列化到错误响应的 details 字段
+// 返回：错误本身，便于链式调用
+func (e *Error) WithDetails(details interface{}) *Error {
+	e.Details = details
+	return e
+}
+
+// WithCause 设置底层错误
+// 底层错误不会出现在错误信息中，仅用于日志记录和 errors.Is / errors.As 判断
+// 参数：err - 底层错误
+// 返回：错误本身，便于链式调用
+func (e *Error) WithCause(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// New 创建业务错误
+// 参数：code - 错误码；message - 错误信息
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf 按格式化字符串创建业务错误
+// 参数：code - 错误码；format - 格式化字符串；args - 格式化参数
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap 使用错误码包装底层错误
+// message 为空时直接使用底层错误信息，且底层错误已是业务错误时原样返回，保留其错误码；
+// message 不为空时错误信息为 "message: 底层错误信息"
+// 参数：code - 错误码；message - 错误信息（可选）；err - 底层错误
+func Wrap(code Code, message string, err error) *Error {
+	if err == nil {
+		return New(code, message)
+	}
+	if message == "" {
+		var appErr *Error
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return &Error{Code: code, Message: err.Error(), Err: err}
+	}
+	return &Error{Code: code, Message: fmt.Sprintf("%s: %v", message, err), Err: err}
+}
+
+// From 将任意错误转换为业务错误
+// 错误链中包含业务错误时沿用其错误码和详情（错误信息保留外层的上下文描述），否则视为服务器内部错误
+// 参数：err - 任意错误
+func From(err error) *Error {
+	if appErr, ok := err.(*Error); ok {
+		return appErr
+	}
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return &Error{Code: appErr.Code, Message: err.Error(), Details: appErr.Details, Err: err}
+	}
+	return &Error{Code: CodeInternal, Message: err.Error(), Err: err}
+}
+
+// Is 判断错误链中是否包含指定错误码的业务错误
+// 参数：err - 任意错误；code - 错误码
+func Is(err error, code Code) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Code == code
+}
@@ -3,23 +3,72 @@ package base
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // BaseRepository 基础仓库接口
 // 定义了通用的数据访问操作接口
 // 包含基本的增删改查功能和动态查询功能
+// 实体通过 BaseModel 的 gorm.DeletedAt 字段软删除，查询时由 GORM 自动排除已删除的记录
 type BaseRepository[T any] interface {
-	Create(ctx context.Context, entity *T) error           // 创建实体
-	Update(ctx context.Context, entity *T) error           // 更新实体
-	Save(ctx context.Context, entity *T) error             // 保存实体（upsert）
-	DeleteByID(ctx context.Context, id uuid.UUID) error    // 根据ID删除实体
-	ListAll(ctx context.Context) ([]*T, error)             // 获取所有实体
-	GetByID(ctx context.Context, id uuid.UUID) (*T, error) // 根据ID获取实体
-	Query(ctx context.Context, query *T) ([]*T, error)     // 动态查询实体
+	Create(ctx context.Context, entity *T) error                                 // 创建实体
+	Update(ctx context.Context, entity *T) error                                 // 更新实体
+	Save(ctx context.Context, entity *T) error                                   // 保存实体（upsert）
+	DeleteByID(ctx context.Context, id uuid.UUID) error                          // 根据ID删除实体（软删除）
+	HardDeleteByID(ctx context.Context, id uuid.UUID) error                      // 根据ID物理删除实体
+	ListAll(ctx context.Context) ([]*T, error)                                   // 获取所有实体
+	GetByID(ctx context.Context, id uuid.UUID) (*T, error)                       // 根据ID获取实体
+	Query(ctx context.Context, query *T) ([]*T, error)                           // 动态查询实体
+	QueryBySpec(ctx context.Context, spec *QuerySpec) ([]*T, error)              // 按查询规格查询实体
+	ListPage(ctx context.Context, query *PageQuery) ([]*T, error)                // 分页查询实体
+	Count(ctx context.Context, conditions map[string]interface{}) (int64, error) // 统计符合条件的实体数量
+}
+
+// QueryOperator 查询条件的比较方式
+type QueryOperator string
+
+// 支持的查询条件比较方式
+const (
+	QueryOperatorEq   QueryOperator = "eq"   // 等于
+	QueryOperatorIn   QueryOperator = "in"   // 在列表中，值为切片
+	QueryOperatorLike QueryOperator = "like" // 模糊匹配，值为包含通配符的字符串
+)
+
+// QuerySpec 查询规格
+// 条件之间为且关系
+type QuerySpec struct {
+	Conditions []QueryCondition // 查询条件列表
+}
+
+// QueryCondition 单个查询条件
+type QueryCondition struct {
+	Column   string        // 数据库字段名，也可使用结构体字段名
+	Operator QueryOperator // 比较方式，为空时表示等于
+	Value    interface{}   // 比较的值
+}
+
+// PageQuery 分页查询条件
+// 按创建时间排序，创建时间相同时按ID排序，保证翻页时顺序稳定
+// 设置 Cursor 时使用游标分页并忽略 Offset，否则使用偏移分页
+type PageQuery struct {
+	Conditions map[string]interface{} // 等值查询条件，键为数据库字段名，也可使用结构体字段名
+	Offset     int                    // 偏移量，偏移分页时使用
+	Limit      int                    // 返回的最大数量，0 表示不限制
+	Cursor     *PageCursor            // 游标，返回排在游标之后的实体（不含游标本身）
+	Ascending  bool                   // 是否按创建时间正序排列，默认倒序
+}
+
+// PageCursor 游标分页的游标，取自上一页最后一个实体
+type PageCursor struct {
+	CreatedAt time.Time // 游标实体的创建时间
+	ID        uuid.UUID // 游标实体的ID
 }
 
 // baseRepository 基础仓库实现
@@ -68,13 +117,21 @@ func (r *baseRepository[T]) DeleteByID(ctx context.Context, id uuid.UUID) error
 	return r.db.WithContext(ctx).Delete(new(T), "id = ?", id).Error
 }
 
+// HardDeleteByID 根据ID物理删除实体
+// 根据 UUID 从数据库中删除指定的实体，包括已软删除的，用于不需要保留的数据
+// 参数：ctx - 上下文，id - 要删除的实体 UUID
+// 返回：错误信息
+func (r *baseRepository[T]) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(new(T), "id = ?", id).Error
+}
+
 // ListAll 获取所有实体
 // 从数据库中获取所有实体的信息列表（排除已删除的）
 // 参数：ctx - 上下文
 // 返回：实体列表和错误信息
 func (r *baseRepository[T]) ListAll(ctx context.Context) ([]*T, error) {
 	var entities []*T
-	err := r.db.WithContext(ctx).Where("deleted_at IS NULL").Find(&entities).Error
+	err := r.db.WithContext(ctx).Find(&entities).Error
 	return entities, err
 }
 
@@ -84,7 +141,7 @@ func (r *baseRepository[T]) ListAll(ctx context.Context) ([]*T, error) {
 // 返回：实体对象和错误信息
 func (r *baseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
 	var entity T
-	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&entity).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&entity).Error
 	if err != nil {
 		return nil, err
 	}
@@ -97,82 +154,165 @@ func (r *baseRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, erro
 // 参数：ctx - 上下文，query - 查询条件对象
 // 返回：匹配的实体列表和错误信息
 func (r *baseRepository[T]) Query(ctx context.Context, query *T) ([]*T, error) {
-	var entities []*T
+	// 使用 GORM 解析的字段信息获取查询对象的非零值字段
+	queryMap, err := r.buildQueryMap(ctx, query)
+	if err != nil {
+		return nil, err
+	}
 
-	// 构建查询条件
-	db := r.db.WithContext(ctx).Where("deleted_at IS NULL")
+	spec := &QuerySpec{}
+	for column, value := range queryMap {
+		spec.Conditions = append(spec.Conditions, QueryCondition{Column: column, Operator: QueryOperatorEq, Value: value})
+	}
+	return r.QueryBySpec(ctx, spec)
+}
 
-	// 使用反射获取查询对象的非零值字段
-	queryMap := r.buildQueryMap(query)
+// QueryBySpec 按查询规格查询实体
+// 条件之间为且关系，字段名必须是实体的数据库字段，排除已删除的
+// 参数：ctx - 上下文，spec - 查询规格
+// 返回：匹配的实体列表和错误信息
+func (r *baseRepository[T]) QueryBySpec(ctx context.Context, spec *QuerySpec) ([]*T, error) {
+	var entities []*T
 
-	// 应用查询条件
-	for field, value := range queryMap {
-		db = db.Where(field+" = ?", value)
+	// 构建查询条件
+	db, err := r.applyConditions(r.db.WithContext(ctx), spec.Conditions)
+	if err != nil {
+		return nil, err
 	}
 
 	// 执行查询
-	err := db.Find(&entities).Error
+	err = db.Find(&entities).Error
 	return entities, err
 }
 
-// buildQueryMap 构建查询映射
-// 使用反射获取结构体中非零值的字段
-// 参数：query - 查询对象
-// 返回：字段名到值的映射
-func (r *baseRepository[T]) buildQueryMap(query *T) map[string]interface{} {
-	queryMap := make(map[string]interface{})
+// ListPage 分页查询实体
+// 按等值条件查询（排除已删除的），支持偏移分页和游标分页，条件的字段名必须是实体的数据库字段
+// 参数：ctx - 上下文，query - 分页查询条件
+// 返回：实体列表和错误信息
+func (r *baseRepository[T]) ListPage(ctx context.Context, query *PageQuery) ([]*T, error) {
+	var entities []*T
+
+	db, err := r.applyConditions(r.db.WithContext(ctx), equalConditions(query.Conditions))
+	if err != nil {
+		return nil, err
+	}
 
-	// 获取结构体的反射值
-	v := reflect.ValueOf(query).Elem()
-	t := v.Type()
+	// 处理排序方式和游标
+	if query.Ascending {
+		if query.Cursor != nil {
+			db = db.Where("created_at > ? OR (created_at = ? AND id > ?)", query.Cursor.CreatedAt, query.Cursor.CreatedAt, query.Cursor.ID)
+		}
+		db = db.Order("created_at ASC").Order("id ASC")
+	} else {
+		if query.Cursor != nil {
+			db = db.Where("created_at < ? OR (created_at = ? AND id < ?)", query.Cursor.CreatedAt, query.Cursor.CreatedAt, query.Cursor.ID)
+		}
+		db = db.Order("created_at DESC").Order("id DESC")
+	}
 
-	// 遍历结构体的所有字段
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
+	if query.Cursor == nil && query.Offset > 0 {
+		db = db.Offset(query.Offset)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
 
-		// 获取字段名（转换为数据库字段名）
-		fieldName := r.getDBFieldName(fieldType)
+	err = db.Find(&entities).Error
+	return entities, err
+}
 
-		// 检查字段是否为零值
-		if !field.IsZero() {
-			queryMap[fieldName] = field.Interface()
+// Count 统计符合条件的实体数量
+// 按等值条件统计（排除已删除的），条件的字段名必须是实体的数据库字段
+// 参数：ctx - 上下文，conditions - 等值查询条件，键为数据库字段名
+// 返回：数量和错误信息
+func (r *baseRepository[T]) Count(ctx context.Context, conditions map[string]interface{}) (int64, error) {
+	var count int64
+	db, err := r.applyConditions(r.db.WithContext(ctx).Model(new(T)), equalConditions(conditions))
+	if err != nil {
+		return 0, err
+	}
+	err = db.Count(&count).Error
+	return count, err
+}
+
+// applyConditions 将查询条件添加到查询中
+// 字段名通过 GORM 解析的字段信息转换为数据库字段名，不是实体字段的条件直接返回错误，不会拼接到 SQL 中
+// 参数：db - 查询，conditions - 查询条件列表
+// 返回：添加了查询条件的查询和错误信息
+func (r *baseRepository[T]) applyConditions(db *gorm.DB, conditions []QueryCondition) (*gorm.DB, error) {
+	if len(conditions) == 0 {
+		return db, nil
+	}
+
+	entitySchema, err := r.parseSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, condition := range conditions {
+		field := entitySchema.LookUpField(condition.Column)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("未知的查询字段: %s", condition.Column)
+		}
+		column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+		switch condition.Operator {
+		case "", QueryOperatorEq:
+			db = db.Where(clause.Eq{Column: column, Value: condition.Value})
+		case QueryOperatorIn:
+			db = db.Where("? IN ?", column, condition.Value)
+		case QueryOperatorLike:
+			db = db.Where(clause.Like{Column: column, Value: condition.Value})
+		default:
+			return nil, fmt.Errorf("不支持的查询比较方式: %s", condition.Operator)
 		}
 	}
+	return db, nil
+}
 
-	return queryMap
+// equalConditions 将等值条件映射转换为查询条件列表
+// 参数：conditions - 等值查询条件，键为字段名
+// 返回：查询条件列表
+func equalConditions(conditions map[string]interface{}) []QueryCondition {
+	result := make([]QueryCondition, 0, len(conditions))
+	for column, value := range conditions {
+		result = append(result, QueryCondition{Column: column, Operator: QueryOperatorEq, Value: value})
+	}
+	return result
 }
 
-// getDBFieldName 获取数据库字段名
-// 从结构体标签中提取数据库字段名
-// 参数：fieldType - 结构体字段类型
-// 返回：数据库字段名
-func (r *baseRepository[T]) getDBFieldName(fieldType reflect.StructField) string {
-	// 尝试从 gorm 标签中获取字段名
-	if gormTag := fieldType.Tag.Get("gorm"); gormTag != "" {
-		// 这里可以解析 gorm 标签，简化处理直接使用 json 标签
+// buildQueryMap 构建查询映射
+// 使用 GORM 解析的字段信息获取结构体中非零值的字段，包括嵌入结构体中的字段
+// 参数：ctx - 上下文，query - 查询对象
+// 返回：数据库字段名到值的映射和错误信息
+func (r *baseRepository[T]) buildQueryMap(ctx context.Context, query *T) (map[string]interface{}, error) {
+	queryMap := make(map[string]interface{})
+
+	entitySchema, err := r.parseSchema()
+	if err != nil {
+		return nil, err
 	}
 
-	// 使用 json 标签作为字段名
-	if jsonTag := fieldType.Tag.Get("json"); jsonTag != "" {
-		return jsonTag
+	// 遍历模型的所有数据库字段，跳过零值字段
+	value := reflect.ValueOf(query)
+	for _, field := range entitySchema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		fieldValue, isZero := field.ValueOf(ctx, value)
+		if !isZero {
+			queryMap[field.DBName] = fieldValue
+		}
 	}
 
-	// 如果没有标签，使用字段名（转换为下划线格式）
-	return r.camelToSnake(fieldType.Name)
+	return queryMap, nil
 }
 
-// camelToSnake 驼峰命名转下划线命名
-// 将驼峰命名的字符串转换为下划线命名
-// 参数：s - 驼峰命名字符串
-// 返回：下划线命名字符串
-func (r *baseRepository[T]) camelToSnake(s string) string {
-	var result string
-	for i, char := range s {
-		if i > 0 && char >= 'A' && char <= 'Z' {
-			result += "_"
-		}
-		result += string(char)
+// parseSchema 解析实体的 GORM 模型信息（字段名、字段类型等），解析结果由 GORM 缓存
+// 返回：模型信息和错误信息
+func (r *baseRepository[T]) parseSchema() (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
 	}
-	return result
+	return stmt.Schema, nil
 }
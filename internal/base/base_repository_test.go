@@ -0,0 +1,187 @@
+package base_test
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testEntity 基础仓库测试使用的实体
+type testEntity struct {
+	base.BaseModel
+	Name  string `gorm:"type:varchar(64);not null"`
+	Group string `gorm:"type:varchar(64);not null"`
+}
+
+// newTestRepository 创建使用 SQLite 内存数据库的基础仓库，并写入指定名称和分组的实体
+func newTestRepository(t *testing.T, entities ...*testEntity) (base.BaseRepository[testEntity], *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开内存数据库失败: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取数据库连接失败: %v", err)
+	}
+	// 内存数据库的每个连接都是独立的数据库，只保留一个连接
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(&testEntity{}); err != nil {
+		t.Fatalf("迁移内存数据库失败: %v", err)
+	}
+	repo := base.NewBaseRepository[testEntity](db)
+	for _, entity := range entities {
+		if err := repo.Create(context.Background(), entity); err != nil {
+			t.Fatalf("创建测试数据失败: %v", err)
+		}
+	}
+	return repo, db
+}
+
+func TestBaseRepositoryRejectsUnknownConditionColumns(t *testing.T) {
+	repo, _ := newTestRepository(t, &testEntity{Name: "alpha", Group: "a"})
+	ctx := context.Background()
+	injected := map[string]interface{}{"1=1 OR name": "x"}
+
+	if _, err := repo.ListPage(ctx, &base.PageQuery{Conditions: injected}); err == nil || !strings.Contains(err.Error(), "未知的查询字段") {
+		t.Errorf("ListPage() error = %v, want unknown column error", err)
+	}
+	if _, err := repo.Count(ctx, injected); err == nil || !strings.Contains(err.Error(), "未知的查询字段") {
+		t.Errorf("Count() error = %v, want unknown column error", err)
+	}
+
+	// 数据库字段名和结构体字段名都可以作为条件
+	for _, conditions := range []map[string]interface{}{{"name": "alpha"}, {"Name": "alpha"}} {
+		count, err := repo.Count(ctx, conditions)
+		if err != nil || count != 1 {
+			t.Errorf("Count(%v) = %d, %v, want 1", conditions, count, err)
+		}
+		entities, err := repo.ListPage(ctx, &base.PageQuery{Conditions: conditions})
+		if err != nil || len(entities) != 1 {
+			t.Errorf("ListPage(%v) = %d entities, %v, want 1", conditions, len(entities), err)
+		}
+	}
+}
+
+func TestBaseRepositoryQueryBySpec(t *testing.T) {
+	repo, _ := newTestRepository(t,
+		&testEntity{Name: "alpha", Group: "a"},
+		&testEntity{Name: "beta", Group: "a"},
+		&testEntity{Name: "gamma", Group: "b"},
+	)
+
+	tests := []struct {
+		name       string
+		conditions []base.QueryCondition
+		want       []string
+		wantErr    string
+	}{
+		{
+			name:       "eq",
+			conditions: []base.QueryCondition{{Column: "group", Operator: base.QueryOperatorEq, Value: "a"}},
+			want:       []string{"alpha", "beta"},
+		},
+		{
+			name:       "empty operator means eq",
+			conditions: []base.QueryCondition{{Column: "Name", Value: "gamma"}},
+			want:       []string{"gamma"},
+		},
+		{
+			name:       "in",
+			conditions: []base.QueryCondition{{Column: "name", Operator: base.QueryOperatorIn, Value: []string{"alpha", "gamma", "delta"}}},
+			want:       []string{"alpha", "gamma"},
+		},
+		{
+			name:       "like",
+			conditions: []base.QueryCondition{{Column: "name", Operator: base.QueryOperatorLike, Value: "%a"}},
+			want:       []string{"alpha", "beta", "gamma"},
+		},
+		{
+			name: "conditions are combined with and",
+			conditions: []base.QueryCondition{
+				{Column: "group", Value: "a"},
+				{Column: "name", Operator: base.QueryOperatorLike, Value: "b%"},
+			},
+			want: []string{"beta"},
+		},
+		{
+			name:       "unknown column",
+			conditions: []base.QueryCondition{{Column: "name = name OR 1", Value: "x"}},
+			wantErr:    "未知的查询字段",
+		},
+		{
+			name:       "unsupported operator",
+			conditions: []base.QueryCondition{{Column: "name", Operator: "gt", Value: "x"}},
+			wantErr:    "不支持的查询比较方式",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entities, err := repo.QueryBySpec(context.Background(), &base.QuerySpec{Conditions: tt.conditions})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("QueryBySpec() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryBySpec() error = %v", err)
+			}
+			var names []string
+			for _, entity := range entities {
+				names = append(names, entity.Name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("QueryBySpec() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseRepositoryDelete(t *testing.T) {
+	soft := &testEntity{Name: "soft"}
+	hard := &testEntity{Name: "hard"}
+	softThenHard := &testEntity{Name: "soft then hard"}
+	repo, db := newTestRepository(t, soft, hard, softThenHard)
+	ctx := context.Background()
+
+	if err := repo.DeleteByID(ctx, soft.ID); err != nil {
+		t.Fatalf("DeleteByID() error = %v", err)
+	}
+	if err := repo.HardDeleteByID(ctx, hard.ID); err != nil {
+		t.Fatalf("HardDeleteByID() error = %v", err)
+	}
+	// 已软删除的实体也可以物理删除
+	if err := repo.DeleteByID(ctx, softThenHard.ID); err != nil {
+		t.Fatalf("DeleteByID() error = %v", err)
+	}
+	if err := repo.HardDeleteByID(ctx, softThenHard.ID); err != nil {
+		t.Fatalf("HardDeleteByID() error = %v", err)
+	}
+
+	for _, entity := range []*testEntity{soft, hard, softThenHard} {
+		if _, err := repo.GetByID(ctx, entity.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("GetByID(%s) error = %v, want %v", entity.Name, err, gorm.ErrRecordNotFound)
+		}
+	}
+
+	// 软删除只标记删除时间，记录仍保留在表中
+	var remaining []string
+	if err := db.Unscoped().Model(&testEntity{}).Pluck("name", &remaining).Error; err != nil {
+		t.Fatalf("查询实体失败: %v", err)
+	}
+	if want := []string{"soft"}; !slices.Equal(remaining, want) {
+		t.Errorf("rows after delete = %v, want %v", remaining, want)
+	}
+}
@@ -0,0 +1,17 @@
+package base
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReadReplicaResolver 只读副本在 dbresolver 中注册的名称
+// 只读副本只注册为具名的解析器，未显式指定的查询和全部写操作仍在主库执行
+const ReadReplicaResolver = "read_replica"
+
+// UseReadReplica 将查询路由到只读副本，作为 GORM 的 Scopes 使用
+// 只用于可以容忍复制延迟的列表、搜索和统计查询，写入后需要立即读到结果的查询不能使用
+// 未配置只读副本或在事务中执行时仍在主库查询
+func UseReadReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(ReadReplicaResolver))
+}
@@ -0,0 +1,73 @@
+// Package broker 提供消息队列发布功能
+// 将会话事件发布到 NATS 或 Kafka（通过 Kafka REST Proxy），未配置消息队列时发布会返回 ErrNotConfigured
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured 未配置消息队列
+var ErrNotConfigured = errors.New("未配置消息队列")
+
+// 支持的消息队列类型
+const (
+	TypeNats  = "nats"  // NATS 核心协议
+	TypeKafka = "kafka" // Kafka REST Proxy v2 接口
+)
+
+// Message 待发布的消息
+type Message struct {
+	ID    string // 消息ID，消费方据此去重
+	Type  string // 事件类型，NATS 追加到主题前缀之后
+	Key   string // 消息键，Kafka 按消息键选择分区
+	Value []byte // 消息内容（JSON）
+}
+
+// Publisher 消息发布接口
+type Publisher interface {
+	// Enabled 是否已配置消息队列
+	Enabled() bool
+
+	// Publish 按顺序发布一批消息，全部发布成功时返回 nil
+	// 发布失败时部分消息可能已经发布，调用方重试时消费方会收到重复的消息
+	Publish(ctx context.Context, messages []Message) error
+}
+
+// NewPublisher 根据配置创建 Publisher
+// 参数：config - 应用程序配置
+// 返回：消息队列类型不支持或地址无效时返回错误
+func NewPublisher(config *config.Config) (Publisher, error) {
+	brokerConfig := config.EventBroker
+	timeout := time.Duration(brokerConfig.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	switch strings.ToLower(brokerConfig.Type) {
+	case "":
+		return disabledPublisher{}, nil
+	case TypeNats:
+		return newNatsPublisher(brokerConfig, timeout)
+	case TypeKafka:
+		return newKafkaRestPublisher(brokerConfig, timeout)
+	default:
+		return nil, fmt.Errorf("不支持的消息队列类型: %s", brokerConfig.Type)
+	}
+}
+
+// disabledPublisher 未配置消息队列时使用的 Publisher 实现
+type disabledPublisher struct{}
+
+// Enabled 是否已配置消息队列
+func (disabledPublisher) Enabled() bool {
+	return false
+}
+
+// Publish 发布消息，总是返回 ErrNotConfigured
+func (disabledPublisher) Publish(ctx context.Context, messages []Message) error {
+	return ErrNotConfigured
+}
@@ -0,0 +1,117 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/config"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// kafkaRestResponsePreview 发布失败时记录的响应内容长度
+const kafkaRestResponsePreview = 512
+
+// kafkaRestPublisher 通过 Kafka REST Proxy v2 接口发布消息的 Publisher 实现
+// 一批消息在一个请求中发布，消息键为 JSON 字符串，同一消息键的消息写入同一分区
+type kafkaRestPublisher struct {
+	endpoint   string       // 发布消息的接口地址 {url}/topics/{topic}
+	username   string       // HTTP Basic 认证用户名
+	password   string       // HTTP Basic 认证密码
+	httpClient *http.Client // 发布请求使用的 HTTP 客户端
+}
+
+// kafkaRestRecord 发布请求中的一条消息
+type kafkaRestRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaRestResponse 发布请求的响应，每条消息对应一个写入结果
+type kafkaRestResponse struct {
+	Offsets []struct {
+		Partition *int   `json:"partition"`
+		Offset    *int64 `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// newKafkaRestPublisher 根据配置创建 Kafka REST Proxy Publisher
+func newKafkaRestPublisher(brokerConfig config.EventBrokerConfig, timeout time.Duration) (Publisher, error) {
+	parsed, err := url.Parse(brokerConfig.Url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("Kafka REST Proxy 地址无效，应为 http 或 https 地址: %s", brokerConfig.Url)
+	}
+	if brokerConfig.Topic == "" {
+		return nil, fmt.Errorf("未配置Kafka主题名称")
+	}
+	return &kafkaRestPublisher{
+		endpoint:   strings.TrimSuffix(brokerConfig.Url, "/") + "/topics/" + url.PathEscape(brokerConfig.Topic),
+		username:   brokerConfig.Username,
+		password:   brokerConfig.Password,
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Enabled 是否已配置消息队列
+func (p *kafkaRestPublisher) Enabled() bool {
+	return true
+}
+
+// Publish 在一个请求中按顺序发布一批消息
+// 任意一条消息写入失败时返回错误
+func (p *kafkaRestPublisher) Publish(ctx context.Context, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	records := make([]kafkaRestRecord, 0, len(messages))
+	for _, message := range messages {
+		records = append(records, kafkaRestRecord{Key: message.Key, Value: json.RawMessage(message.Value)})
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return fmt.Errorf("序列化Kafka消息失败: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("创建Kafka发布请求失败: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	request.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if p.username != "" {
+		request.SetBasicAuth(p.username, p.password)
+	}
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("发布消息到Kafka失败: %w", err)
+	}
+	defer response.Body.Close()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("读取Kafka发布结果失败: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		if len(content) > kafkaRestResponsePreview {
+			content = content[:kafkaRestResponsePreview]
+		}
+		return fmt.Errorf("Kafka REST Proxy 返回 HTTP %d: %s", response.StatusCode, string(content))
+	}
+
+	var result kafkaRestResponse
+	if err := json.Unmarshal(content, &result); err != nil {
+		return fmt.Errorf("解析Kafka发布结果失败: %w", err)
+	}
+	for i, offset := range result.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("Kafka消息写入失败: index=%d error=%s", i, offset.Error)
+		}
+	}
+	return nil
+}
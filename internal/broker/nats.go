@@ -0,0 +1,216 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/config"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// natsDefaultPort NATS 服务器默认端口
+const natsDefaultPort = "4222"
+
+// natsPublisher 使用 NATS 核心协议发布消息的 Publisher 实现
+// 每次发布建立一个连接，发布完成后发送 PING 并等待 PONG，确认服务器已处理全部消息
+type natsPublisher struct {
+	address       string        // 服务器地址 host:port
+	serverName    string        // TLS 校验使用的服务器名称
+	useTLS        bool          // 是否使用 TLS 连接
+	subjectPrefix string        // 主题前缀
+	username      string        // 认证用户名
+	password      string        // 认证密码
+	token         string        // 认证令牌
+	timeout       time.Duration // 每次发布的超时时间
+}
+
+// natsServerInfo 服务器在连接建立后发送的 INFO 信息
+type natsServerInfo struct {
+	Headers     bool  `json:"headers"`      // 是否支持消息头
+	MaxPayload  int64 `json:"max_payload"`  // 单条消息内容的大小上限
+	TLSRequired bool  `json:"tls_required"` // 是否要求 TLS 连接
+}
+
+// natsConnectOptions 客户端发送的 CONNECT 选项
+type natsConnectOptions struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+	Protocol    int    `json:"protocol"`
+	Headers     bool   `json:"headers"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+}
+
+// newNatsPublisher 根据配置创建 NATS Publisher
+// 地址中的用户信息在未配置用户名时使用，只有用户名时作为令牌使用
+func newNatsPublisher(brokerConfig config.EventBrokerConfig, timeout time.Duration) (Publisher, error) {
+	parsed, err := url.Parse(brokerConfig.Url)
+	if err != nil || (parsed.Scheme != "nats" && parsed.Scheme != "tls") || parsed.Hostname() == "" {
+		return nil, fmt.Errorf("NATS服务器地址无效，应为 nats://host:port 或 tls://host:port: %s", brokerConfig.Url)
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = natsDefaultPort
+	}
+
+	username, password := brokerConfig.Username, brokerConfig.Password
+	if username == "" && parsed.User != nil {
+		username = parsed.User.Username()
+		password, _ = parsed.User.Password()
+	}
+	publisher := &natsPublisher{
+		address:       net.JoinHostPort(parsed.Hostname(), port),
+		serverName:    parsed.Hostname(),
+		useTLS:        parsed.Scheme == "tls",
+		subjectPrefix: strings.Trim(brokerConfig.Topic, "."),
+		timeout:       timeout,
+	}
+	if username != "" && password == "" {
+		publisher.token = username
+	} else {
+		publisher.username = username
+		publisher.password = password
+	}
+	return publisher, nil
+}
+
+// Enabled 是否已配置消息队列
+func (p *natsPublisher) Enabled() bool {
+	return true
+}
+
+// Publish 按顺序发布一批消息
+// 服务器支持消息头时附带 Nats-Msg-Id，JetStream 据此去重
+func (p *natsPublisher) Publish(ctx context.Context, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	conn, reader, info, err := p.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	writer := bufio.NewWriter(conn)
+	for _, message := range messages {
+		if info.MaxPayload > 0 && int64(len(message.Value)) > info.MaxPayload {
+			return fmt.Errorf("消息大小 %d 超过NATS服务器上限 %d: id=%s", len(message.Value), info.MaxPayload, message.ID)
+		}
+		subject := message.Type
+		if p.subjectPrefix != "" {
+			subject = p.subjectPrefix + "." + message.Type
+		}
+		if info.Headers && message.ID != "" {
+			header := "NATS/1.0\r\nNats-Msg-Id: " + message.ID + "\r\n\r\n"
+			fmt.Fprintf(writer, "HPUB %s %d %d\r\n%s", subject, len(header), len(header)+len(message.Value), header)
+		} else {
+			fmt.Fprintf(writer, "PUB %s %d\r\n", subject, len(message.Value))
+		}
+		writer.Write(message.Value)
+		writer.WriteString("\r\n")
+	}
+	writer.WriteString("PING\r\n")
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("发布消息到NATS失败: %w", err)
+	}
+	if err := natsWaitPong(reader, conn); err != nil {
+		return fmt.Errorf("发布消息到NATS失败: %w", err)
+	}
+	return nil
+}
+
+// connect 连接服务器并完成认证
+// 服务器要求 TLS 或地址为 tls:// 时在收到 INFO 后升级为 TLS 连接
+func (p *natsPublisher) connect(ctx context.Context) (net.Conn, *bufio.Reader, *natsServerInfo, error) {
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("连接NATS服务器失败: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("读取NATS服务器信息失败: %w", err)
+	}
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("NATS服务器返回了无效的信息: %s", line)
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("解析NATS服务器信息失败: %w", err)
+	}
+
+	useTLS := p.useTLS || info.TLSRequired
+	if useTLS {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: p.serverName})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, nil, nil, fmt.Errorf("NATS TLS 握手失败: %w", err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	options, _ := json.Marshal(natsConnectOptions{
+		TLSRequired: useTLS,
+		Name:        "lemon-tree-core",
+		Lang:        "go",
+		Version:     "1.0.0",
+		Protocol:    1,
+		Headers:     info.Headers,
+		User:        p.username,
+		Pass:        p.password,
+		AuthToken:   p.token,
+	})
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", options); err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("连接NATS服务器失败: %w", err)
+	}
+	if err := natsWaitPong(reader, conn); err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("NATS认证失败: %w", err)
+	}
+	return conn, reader, &info, nil
+}
+
+// natsWaitPong 等待服务器返回 PONG，期间响应服务器的 PING，服务器返回 -ERR 时返回错误
+func natsWaitPong(reader *bufio.Reader, writer io.Writer) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := io.WriteString(writer, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS服务器返回错误: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/service"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// newBackupApplicationCommand 创建 backup-application 子命令
+// 以 JSON 格式导出应用的全部配置，可用 restore-application 恢复到另一个数据库
+func newBackupApplicationCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "backup-application <id>",
+		Short: "备份应用配置",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("无效的应用ID: %w", err)
+			}
+
+			return runWithServices(func(applicationBackupService service.ApplicationBackupService) error {
+				backup, err := applicationBackupService.BackupApplication(context.Background(), applicationID)
+				if err != nil {
+					return err
+				}
+				data, err := json.MarshalIndent(backup, "", "  ")
+				if err != nil {
+					return fmt.Errorf("序列化备份数据失败: %w", err)
+				}
+
+				// 未指定输出文件时写入标准输出
+				if output == "" {
+					_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+					return err
+				}
+				// 备份中包含明文密钥，文件只允许当前用户读写
+				if err := os.WriteFile(output, data, 0600); err != nil {
+					return fmt.Errorf("写入备份文件失败: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "应用已备份到 %s\n", output)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "备份文件路径，默认输出到标准输出")
+	return cmd
+}
+
+// newRestoreApplicationCommand 创建 restore-application 子命令
+// 按原有ID恢复 backup-application 导出的应用配置，应用已存在时失败
+func newRestoreApplicationCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore-application <file>",
+		Short: "恢复应用配置",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("读取备份文件失败: %w", err)
+			}
+			var backup models.ApplicationBackup
+			if err := json.Unmarshal(data, &backup); err != nil {
+				return fmt.Errorf("解析备份文件失败: %w", err)
+			}
+
+			return runWithServices(func(applicationBackupService service.ApplicationBackupService) error {
+				if err := applicationBackupService.RestoreApplication(context.Background(), &backup); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "应用已恢复: %s (%s)，共 %d 个智能体\n", backup.Application.Name, backup.Application.ID, len(backup.ChatAgents))
+				return nil
+			})
+		},
+	}
+}
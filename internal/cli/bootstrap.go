@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// newBootstrapCommand 创建 bootstrap 子命令
+// 在空数据库上创建默认管理员、默认应用和常用供应商定义
+func newBootstrapCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "bootstrap",
+		Short: "首次启动初始化",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWithServices(func(bootstrapService service.BootstrapService) error {
+				result, err := bootstrapService.Bootstrap(context.Background())
+				if err != nil {
+					return fmt.Errorf("初始化失败: %w", err)
+				}
+
+				out := cmd.OutOrStdout()
+				if result.AdminUserCreated {
+					fmt.Fprintf(out, "已创建管理员用户: %s\n", result.AdminNumber)
+					if result.GeneratedAdminPassword != nil {
+						fmt.Fprintf(out, "管理员初始密码（仅显示一次）: %s\n", *result.GeneratedAdminPassword)
+					}
+				}
+				if result.ApplicationCreated {
+					fmt.Fprintf(out, "已创建默认应用: %s\n", *result.ApplicationID)
+				}
+				fmt.Fprintf(out, "已新增 %d 个供应商定义\n", result.ProviderDefinesCreated)
+				return nil
+			})
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/core"
+
+	"github.com/spf13/cobra"
+)
+
+// newCheckDiCommand 创建 check-di 子命令
+// 校验依赖注入容器能否完整解析，用于构建流水线中的启动检查
+func newCheckDiCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-di",
+		Short: "校验依赖注入容器",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := core.ValidateContainer(); err != nil {
+				return fmt.Errorf("依赖注入容器校验失败: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "依赖注入容器校验通过")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/service"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newCleanupUploadsCommand 创建 cleanup-uploads 子命令
+// 立即清理超过保留时间仍未被任何记录引用的上传文件
+func newCleanupUploadsCommand() *cobra.Command {
+	var ttlHours int
+
+	cmd := &cobra.Command{
+		Use:   "cleanup-uploads",
+		Short: "清理未被引用的上传文件",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWithServices(func(workspaceUploadService service.WorkspaceUploadService, cfg *config.Config) error {
+				hours := cfg.Upload.OrphanTTLHours
+				if cmd.Flags().Changed("ttl-hours") {
+					hours = ttlHours
+				}
+				removed, err := workspaceUploadService.CleanupOrphanedUploads(context.Background(), time.Duration(hours)*time.Hour)
+				if err != nil {
+					return fmt.Errorf("清理上传文件失败: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "已删除 %d 个未被引用的文件\n", removed)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().IntVar(&ttlHours, "ttl-hours", 0, "文件保留时间（小时），默认使用 UPLOAD_ORPHAN_TTL_HOURS")
+	return cmd
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// newCreateAdminUserCommand 创建 create-admin-user 子命令
+// 创建可登录管理后台的系统用户
+func newCreateAdminUserCommand() *cobra.Command {
+	var name, number, email, password string
+
+	cmd := &cobra.Command{
+		Use:   "create-admin-user",
+		Short: "创建管理员用户",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if number == "" || password == "" {
+				return errors.New("账号和密码不能为空")
+			}
+			if name == "" {
+				name = number
+			}
+
+			return runWithServices(func(userService service.UserService) error {
+				user := &models.SystemUser{
+					Name:     name,
+					Number:   number,
+					Email:    email,
+					Password: password,
+				}
+				if err := userService.SaveUser(context.Background(), user); err != nil {
+					return fmt.Errorf("创建管理员用户失败: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "管理员用户已创建: %s (%s)\n", user.Number, user.ID)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "用户名字，默认与账号相同")
+	cmd.Flags().StringVar(&number, "number", "", "用户账号（必填）")
+	cmd.Flags().StringVar(&email, "email", "", "用户邮箱")
+	cmd.Flags().StringVar(&password, "password", "", "用户密码（必填）")
+	return cmd
+}
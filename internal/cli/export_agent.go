@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// newExportAgentCommand 创建 export-agent 子命令
+// 以 JSON 格式导出智能体配置及其MCP工具设置
+func newExportAgentCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export-agent <id>",
+		Short: "导出智能体配置",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("无效的智能体ID: %w", err)
+			}
+
+			return runWithServices(func(
+				chatAgentService service.ChatAgentService,
+				chatAgentMcpServerToolService service.ChatAgentMcpServerToolService,
+			) error {
+				ctx := context.Background()
+				agent, err := chatAgentService.GetChatAgentByID(ctx, agentID)
+				if err != nil {
+					return err
+				}
+				toolSettings, err := chatAgentMcpServerToolService.GetChatAgentMcpServerToolSettings(ctx, agentID)
+				if err != nil {
+					return fmt.Errorf("获取MCP工具设置失败: %w", err)
+				}
+
+				export := dto.ChatAgentExportDto{
+					ChatAgent:       converter.ChatAgentModelToChatAgentDto(agent),
+					McpToolSettings: toolSettings,
+					ExportedAt:      time.Now().Unix(),
+				}
+				data, err := json.MarshalIndent(export, "", "  ")
+				if err != nil {
+					return fmt.Errorf("序列化导出数据失败: %w", err)
+				}
+
+				// 未指定输出文件时写入标准输出
+				if output == "" {
+					_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+					return err
+				}
+				if err := os.WriteFile(output, data, 0644); err != nil {
+					return fmt.Errorf("写入导出文件失败: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "智能体已导出到 %s\n", output)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "导出文件路径，默认输出到标准输出")
+	return cmd
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// newLoadProviderPresetsCommand 创建 load-provider-presets 子命令
+// 将内置的供应商目录写入数据库
+func newLoadProviderPresetsCommand() *cobra.Command {
+	var overwrite bool
+
+	cmd := &cobra.Command{
+		Use:   "load-provider-presets",
+		Short: "加载内置的大语言模型供应商定义",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWithServices(func(llmProviderDefineService service.LlmProviderDefineService) error {
+				result, err := llmProviderDefineService.LoadLlmProviderDefinePresets(context.Background(), overwrite)
+				if err != nil {
+					return fmt.Errorf("加载供应商定义失败: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "新增 %d 个，更新 %d 个，跳过 %d 个\n", result.Created, result.Updated, result.Skipped)
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "覆盖同类型的已有定义")
+	return cmd
+}
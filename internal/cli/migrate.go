@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// newMigrateCommand 创建 migrate 子命令
+// 连接数据库并执行表结构迁移
+func newMigrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "执行数据库表结构迁移",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// 数据库连接创建时会自动迁移表结构
+			return runWithServices(func(db *gorm.DB) {
+				fmt.Fprintln(cmd.OutOrStdout(), "数据库迁移完成")
+			})
+		},
+	}
+}
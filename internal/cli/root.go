@@ -0,0 +1,68 @@
+// Package cli 提供命令行工具功能
+// 负责解析命令行参数，启动服务或执行运维管理命令
+package cli
+
+import (
+	"context"
+	"lemon-tree-core/internal/core"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// commandTimeout 管理命令执行超时时间
+const commandTimeout = 5 * time.Minute
+
+// NewRootCommand 创建根命令
+// 未指定子命令时启动服务，与 serve 子命令行为一致
+// 返回：根命令实例
+func NewRootCommand() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:           "lemon-tree-core",
+		Short:         "Lemon Tree Core 服务及管理工具",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe()
+		},
+	}
+
+	rootCmd.AddCommand(
+		newServeCommand(),
+		newMigrateCommand(),
+		newBootstrapCommand(),
+		newCheckDiCommand(),
+		newCreateAdminUserCommand(),
+		newLoadProviderPresetsCommand(),
+		newSyncMcpToolsCommand(),
+		newExportAgentCommand(),
+		newBackupApplicationCommand(),
+		newRestoreApplicationCommand(),
+		newCleanupUploadsCommand(),
+	)
+	return rootCmd
+}
+
+// Execute 执行命令行
+// 返回：错误信息
+func Execute() error {
+	return NewRootCommand().Execute()
+}
+
+// runWithServices 使用轻量依赖注入容器执行管理命令
+// 容器只初始化配置、数据库、Repository 和 Service，不启动 HTTP 服务器
+// 参数：invoke - 需要执行的函数，参数由容器注入，返回错误时命令失败
+// 返回：错误信息
+func runWithServices(invoke interface{}) error {
+	app := core.NewCommandContainer(invoke)
+	if err := app.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	return app.Stop(ctx)
+}
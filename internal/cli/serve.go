@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/core"
+
+	"github.com/spf13/cobra"
+)
+
+// newServeCommand 创建 serve 子命令
+// 启动 HTTP 服务器（以及启用时的 gRPC 服务器）
+func newServeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "启动服务",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe()
+		},
+	}
+}
+
+// runServe 启动服务并阻塞直到收到退出信号
+// 返回：错误信息
+func runServe() error {
+	// 创建依赖注入容器
+	// 配置所有组件的依赖关系和生命周期
+	app := core.NewContainer()
+
+	// 启动应用程序
+	// 开始依赖注入容器的生命周期管理
+	if err := app.Start(context.Background()); err != nil {
+		return fmt.Errorf("启动应用失败: %w", err)
+	}
+
+	// 等待应用程序结束
+	// 阻塞直到应用程序被终止，然后执行停止钩子
+	<-app.Done()
+	return app.Stop(context.Background())
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// newSyncMcpToolsCommand 创建 sync-mcp-tools 子命令
+// 连接指定的MCP服务器并同步其工具列表
+func newSyncMcpToolsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync-mcp-tools <configID>",
+		Short: "同步MCP服务器的工具列表",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("无效的MCP配置ID: %w", err)
+			}
+
+			return runWithServices(func(mcpConfigService service.ApplicationMcpServerConfigService) error {
+				tools, err := mcpConfigService.SyncMcpServerTools(context.Background(), configID)
+				if err != nil {
+					return fmt.Errorf("同步MCP工具失败: %w", err)
+				}
+				for _, tool := range tools {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", tool.ID, tool.Name)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "已同步 %d 个工具\n", len(tools))
+				return nil
+			})
+		},
+	}
+}
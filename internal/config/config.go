@@ -5,36 +5,97 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
-	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
 // Config 应用程序的主配置结构体
 // 包含服务器配置、数据库配置和AI客户端配置
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`   // 服务器配置
-	Database DatabaseConfig `mapstructure:"database"` // 数据库配置
-	AI       AIConfig       `mapstructure:"ai"`       // AI客户端配置
+	Server       ServerConfig       `mapstructure:"server"`        // 服务器配置
+	Log          LogConfig          `mapstructure:"log"`           // 日志配置
+	CORS         CORSConfig         `mapstructure:"cors"`          // 跨域配置
+	Database     DatabaseConfig     `mapstructure:"database"`      // 数据库配置
+	AI           AIConfig           `mapstructure:"ai"`            // AI客户端配置
+	Grpc         GrpcConfig         `mapstructure:"grpc"`          // gRPC服务配置
+	Bootstrap    BootstrapConfig    `mapstructure:"bootstrap"`     // 首次启动初始化配置
+	Upload       UploadConfig       `mapstructure:"upload"`        // 上传文件清理配置
+	Export       ExportConfig       `mapstructure:"export"`        // 会话导出配置
+	Batch        BatchConfig        `mapstructure:"batch"`         // 批量推理配置
+	Evaluation   EvaluationConfig   `mapstructure:"evaluation"`    // 评测配置
+	Deletion     DeletionConfig     `mapstructure:"deletion"`      // 应用删除配置
+	LlmLimiter   LlmLimiterConfig   `mapstructure:"llm_limiter"`   // 模型提供商并发限制配置
+	Metrics      MetricsConfig      `mapstructure:"metrics"`       // 监控指标配置
+	ChatStream   ChatStreamConfig   `mapstructure:"chat_stream"`   // 流式回复配置
+	McpStdio     McpStdioConfig     `mapstructure:"mcp_stdio"`     // stdio MCP服务执行限制配置
+	McpOAuth     McpOAuthConfig     `mapstructure:"mcp_oauth"`     // MCP服务OAuth授权配置
+	Session      SessionConfig      `mapstructure:"session"`       // 系统用户登录会话配置
+	Oidc         OidcConfig         `mapstructure:"oidc"`          // 系统用户 OIDC 单点登录配置
+	Password     PasswordConfig     `mapstructure:"password"`      // 系统用户密码强度配置
+	Mail         MailConfig         `mapstructure:"mail"`          // 邮件发送配置
+	ChatWidget   ChatWidgetConfig   `mapstructure:"chat_widget"`   // 嵌入式聊天窗口配置
+	ApiKey       ApiKeyConfig       `mapstructure:"api_key"`       // 智能体 API Key 访问控制配置
+	MessageSpool MessageSpoolConfig `mapstructure:"message_spool"` // 消息保存失败重试配置
+	EventOutbox  EventOutboxConfig  `mapstructure:"event_outbox"`  // 会话事件推送配置
+	EventBroker  EventBrokerConfig  `mapstructure:"event_broker"`  // 会话事件消息队列配置
+	Cluster      ClusterConfig      `mapstructure:"cluster"`       // 集群模式配置
+	Archive      ArchiveConfig      `mapstructure:"archive"`       // 会话归档配置
+	FeatureFlag  FeatureFlagConfig  `mapstructure:"feature_flag"`  // 功能开关配置
 }
 
 // ServerConfig 服务器配置结构体
 // 定义服务器的端口和运行模式
 type ServerConfig struct {
-	Port string `mapstructure:"port"` // 服务器监听端口，如 ":8080"
-	Mode string `mapstructure:"mode"` // 服务器运行模式，如 "debug" 或 "release"
+	Port           string   `mapstructure:"port"`            // 服务器监听端口，如 ":8080"
+	Mode           string   `mapstructure:"mode"`            // 服务器运行模式，如 "debug" 或 "release"
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // 受信任的代理IP或网段，为空时不信任任何代理
+	MaxBodySize    int64    `mapstructure:"max_body_size"`   // 请求体大小上限（字节），0 表示不限制
+	MaxUploadSize  int64    `mapstructure:"max_upload_size"` // 上传接口的请求体大小上限（字节），0 表示不限制
+
+	TLSCertFile      string   `mapstructure:"tls_cert_file"`      // TLS 证书文件路径
+	TLSKeyFile       string   `mapstructure:"tls_key_file"`       // TLS 私钥文件路径
+	AutocertDomains  []string `mapstructure:"autocert_domains"`   // 通过 Let's Encrypt 自动申请证书的域名，设置后忽略证书文件
+	AutocertCacheDir string   `mapstructure:"autocert_cache_dir"` // 自动申请的证书缓存目录
+	AutocertEmail    string   `mapstructure:"autocert_email"`     // Let's Encrypt 账号邮箱（可选）
+	HTTPRedirectPort string   `mapstructure:"http_redirect_port"` // 启用 TLS 时监听的 HTTP 端口，将请求重定向到 HTTPS，为空时不监听
+
+	StaticCacheMaxAge int `mapstructure:"static_cache_max_age"` // 工作区公共文件静态路由的浏览器缓存时间（秒）
+}
+
+// TLSEnabled 是否由服务自身终止 TLS
+func (c ServerConfig) TLSEnabled() bool {
+	return len(c.AutocertDomains) > 0 || (c.TLSCertFile != "" && c.TLSKeyFile != "")
+}
+
+// LogConfig 日志配置结构体
+// 定义日志记录器输出的最低级别
+type LogConfig struct {
+	Level string `mapstructure:"level"` // 日志级别：debug、info、warn、error
+}
+
+// CORSConfig 跨域配置结构体
+// 定义允许跨域访问的来源、请求头和方法
+type CORSConfig struct {
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`   // 允许的来源，"*" 表示允许所有来源
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`   // 允许的请求头
+	AllowedMethods   []string `mapstructure:"allowed_methods"`   // 允许的 HTTP 方法
+	AllowCredentials bool     `mapstructure:"allow_credentials"` // 是否允许携带 Cookie 等凭证
+	MaxAge           int      `mapstructure:"max_age"`           // 预检请求结果缓存时间（秒），0 表示不缓存
 }
 
 // DatabaseConfig 数据库配置结构体
 // 定义数据库连接的相关参数
 type DatabaseConfig struct {
-	Host     string `mapstructure:"host"`     // 数据库主机地址
-	Port     string `mapstructure:"port"`     // 数据库端口号
-	Username string `mapstructure:"username"` // 数据库用户名
-	Password string `mapstructure:"password"` // 数据库密码
-	Database string `mapstructure:"database"` // 数据库名称
-	Charset  string `mapstructure:"charset"`  // 数据库字符集
+	Driver     string `mapstructure:"driver"`      // 数据库驱动：mysql 或 sqlite，sqlite 用于本地开发和集成测试
+	Host       string `mapstructure:"host"`        // 数据库主机地址
+	Port       string `mapstructure:"port"`        // 数据库端口号
+	Username   string `mapstructure:"username"`    // 数据库用户名
+	Password   string `mapstructure:"password"`    // 数据库密码
+	Database   string `mapstructure:"database"`    // 数据库名称，sqlite 驱动时为数据库文件路径，:memory: 表示内存数据库
+	Charset    string `mapstructure:"charset"`     // 数据库字符集
+	ReplicaDSN string `mapstructure:"replica_dsn"` // 只读副本连接字符串，mysql 驱动时为完整的 DSN，sqlite 驱动时为数据库文件路径，为空时不使用只读副本
 }
 
 // AIConfig AI客户端配置结构体
@@ -46,6 +107,219 @@ type AIConfig struct {
 	Model   string `mapstructure:"model"`    // 模型名称（可选）
 }
 
+// GrpcConfig gRPC服务配置结构体
+// 定义gRPC服务是否启用及监听端口
+type GrpcConfig struct {
+	Enabled bool   `mapstructure:"enabled"` // 是否启用gRPC服务
+	Port    string `mapstructure:"port"`    // gRPC服务监听端口，如 ":9090"
+}
+
+// BootstrapConfig 首次启动初始化配置结构体
+// 定义是否在启动时自动初始化以及默认管理员信息
+type BootstrapConfig struct {
+	OnStartup     bool   `mapstructure:"on_startup"`     // 是否在服务启动时自动执行初始化
+	AdminNumber   string `mapstructure:"admin_number"`   // 默认管理员账号
+	AdminEmail    string `mapstructure:"admin_email"`    // 默认管理员邮箱
+	AdminPassword string `mapstructure:"admin_password"` // 默认管理员密码，为空时自动生成并输出一次
+}
+
+// UploadConfig 上传文件清理配置结构体
+// 定义未被引用的上传文件的保留时间和清理间隔
+type UploadConfig struct {
+	OrphanTTLHours         int `mapstructure:"orphan_ttl_hours"`         // 上传后未被任何记录引用的文件保留时间（小时）
+	CleanupIntervalMinutes int `mapstructure:"cleanup_interval_minutes"` // 清理任务（包括已删除附件的文件清理）执行间隔（分钟），0 表示不清理
+}
+
+// ExportConfig 会话导出配置结构体
+// 定义导出 PDF 会话记录时使用的字体
+type ExportConfig struct {
+	PdfFontPath string `mapstructure:"pdf_font_path"` // PDF 使用的 TrueType（.ttf）字体文件路径，需包含中文字形，为空时不支持导出 PDF
+}
+
+// BatchConfig 批量推理配置结构体
+// 定义后台处理批量推理任务的执行间隔和全局并发上限
+type BatchConfig struct {
+	IntervalSeconds int `mapstructure:"interval_seconds"` // 检查待处理任务的间隔（秒），0 表示不处理批量推理任务
+	MaxConcurrency  int `mapstructure:"max_concurrency"`  // 单个任务同时处理的提示词数量上限，任务设置的并发数超过时按该值执行
+}
+
+// EvaluationConfig 评测配置结构体
+// 定义后台执行评测运行的间隔和并发数
+type EvaluationConfig struct {
+	IntervalSeconds int `mapstructure:"interval_seconds"` // 检查待执行评测运行的间隔（秒），0 表示不执行评测
+	Concurrency     int `mapstructure:"concurrency"`      // 单次评测运行同时运行的用例数量
+}
+
+// DeletionConfig 应用删除配置结构体
+// 定义后台清理已删除应用数据的间隔和每批删除的记录数
+type DeletionConfig struct {
+	IntervalSeconds int `mapstructure:"interval_seconds"` // 检查待执行删除任务的间隔（秒），0 表示不清理已删除应用的数据
+	BatchSize       int `mapstructure:"batch_size"`       // 每批删除的记录数，分批删除避免长时间锁表
+}
+
+// LlmLimiterConfig 模型提供商并发限制配置结构体
+// 定义提供商默认的并发请求上限和排队等待时间
+type LlmLimiterConfig struct {
+	DefaultMaxConcurrency int `mapstructure:"default_max_concurrency"` // 提供商未设置并发上限时使用的上限，0 表示不限制
+	QueueTimeoutSeconds   int `mapstructure:"queue_timeout_seconds"`   // 请求排队等待的最长时间（秒），0 表示一直等待
+}
+
+// MetricsConfig 监控指标配置结构体
+// 定义是否提供 Prometheus 格式的监控指标接口及其访问令牌
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"` // 是否提供 /metrics 接口
+	Token   string `mapstructure:"token"`   // 访问令牌，设置后请求需携带 Authorization: Bearer <令牌>
+}
+
+// ChatStreamConfig 流式回复配置结构体
+// 定义流式回复事件的保存时间，用于网络中断后续传
+type ChatStreamConfig struct {
+	ResumeWindowSeconds int `mapstructure:"resume_window_seconds"` // 回复结束后事件的保存时间（秒），0 表示不保存，不支持续传
+}
+
+// McpStdioConfig stdio MCP服务执行限制配置结构体
+// 定义允许启动的命令和子进程继承的环境变量，各MCP配置的工作目录、资源限制等在配置中单独设置
+type McpStdioConfig struct {
+	AllowedCommands []string `mapstructure:"allowed_commands"` // 允许启动的命令的绝对路径，为空时不限制
+	InheritEnv      []string `mapstructure:"inherit_env"`      // 子进程从服务进程继承的环境变量名，其余环境变量（如数据库密码）不传递
+}
+
+// McpOAuthConfig MCP服务OAuth授权配置结构体
+// 定义授权回调地址、授权完成后的跳转地址以及加密保存客户端密钥和令牌使用的密钥
+type McpOAuthConfig struct {
+	EncryptionKey string `mapstructure:"encryption_key"` // 加密客户端密钥和令牌使用的密钥，为空时不支持OAuth授权
+	RedirectURL   string `mapstructure:"redirect_url"`   // 授权回调地址，需指向本服务的 /api/v1/application-mcp-server-configs/oauth/callback
+	CompleteURL   string `mapstructure:"complete_url"`   // 授权完成后浏览器跳转的地址，附带 config_id、status 和 error 参数，为空时直接显示授权结果
+}
+
+// SessionConfig 系统用户登录会话配置结构体
+// 定义访问令牌和刷新令牌的有效期、后台清理过期登录会话的间隔
+type SessionConfig struct {
+	AccessTokenTTLMinutes  int `mapstructure:"access_token_ttl_minutes"` // 访问令牌有效期（分钟）
+	RefreshTokenTTLHours   int `mapstructure:"refresh_token_ttl_hours"`  // 刷新令牌有效期（小时），每次刷新重新计算
+	CleanupIntervalMinutes int `mapstructure:"cleanup_interval_minutes"` // 清理过期登录会话的间隔（分钟），0 表示不清理
+}
+
+// OidcConfig 系统用户 OIDC 单点登录配置结构体
+// 定义身份提供方、客户端、回调地址以及外部身份映射为系统用户的规则
+type OidcConfig struct {
+	Issuer        string   `mapstructure:"issuer"`         // 身份提供方的 Issuer 地址，为空时不启用单点登录
+	ClientID      string   `mapstructure:"client_id"`      // 在身份提供方登记的客户端ID
+	ClientSecret  string   `mapstructure:"client_secret"`  // 客户端密钥，公共客户端可为空
+	RedirectURL   string   `mapstructure:"redirect_url"`   // 登录回调地址，需指向本服务的 /api/v1/users/oidc/callback
+	CompleteURL   string   `mapstructure:"complete_url"`   // 登录完成后浏览器跳转的前端地址，令牌附带在地址的 # 片段中，为空时直接返回JSON
+	Scopes        []string `mapstructure:"scopes"`         // 请求的授权范围
+	UsernameClaim string   `mapstructure:"username_claim"` // 作为系统用户账号的声明，缺失时依次使用邮箱和 sub
+	AutoProvision bool     `mapstructure:"auto_provision"` // 首次登录的外部身份是否自动创建系统用户
+	RoleClaim     string   `mapstructure:"role_claim"`     // 角色声明名称，如 groups、roles
+	AllowedRoles  []string `mapstructure:"allowed_roles"`  // 允许登录的角色，为空时不限制；外部身份的角色声明需包含其中之一
+}
+
+// PasswordConfig 系统用户密码强度配置结构体
+// 定义用户修改密码时新密码需要满足的规则
+type PasswordConfig struct {
+	MinLength        int  `mapstructure:"min_length"`        // 最小长度（按字符计算）
+	RequireUppercase bool `mapstructure:"require_uppercase"` // 是否必须包含大写字母
+	RequireLowercase bool `mapstructure:"require_lowercase"` // 是否必须包含小写字母
+	RequireDigit     bool `mapstructure:"require_digit"`     // 是否必须包含数字
+	RequireSymbol    bool `mapstructure:"require_symbol"`    // 是否必须包含字母和数字以外的符号
+}
+
+// MailConfig 邮件发送配置结构体
+// 定义 SMTP 服务器、发件人、邮件模板目录以及邀请和重置密码链接
+type MailConfig struct {
+	SMTPHost                string `mapstructure:"smtp_host"`                  // SMTP 服务器地址，为空时不发送邮件
+	SMTPPort                int    `mapstructure:"smtp_port"`                  // SMTP 服务器端口，465 使用 TLS 连接，其他端口在服务器支持时使用 STARTTLS
+	SMTPUsername            string `mapstructure:"smtp_username"`              // SMTP 认证用户名，为空时不认证
+	SMTPPassword            string `mapstructure:"smtp_password"`              // SMTP 认证密码
+	From                    string `mapstructure:"from"`                       // 发件人，如 "Lemon Tree <noreply@example.com>"
+	TemplateDir             string `mapstructure:"template_dir"`               // 自定义邮件模板目录，目录中存在同名模板时覆盖内置模板
+	InviteURL               string `mapstructure:"invite_url"`                 // 接受邀请页面地址，邮件中的链接附带 token 参数
+	ResetPasswordURL        string `mapstructure:"reset_password_url"`         // 重置密码页面地址，邮件中的链接附带 token 参数
+	InviteTTLHours          int    `mapstructure:"invite_ttl_hours"`           // 邀请链接有效期（小时）
+	ResetPasswordTTLMinutes int    `mapstructure:"reset_password_ttl_minutes"` // 重置密码链接有效期（分钟）
+}
+
+// ChatWidgetConfig 嵌入式聊天窗口配置结构体
+// 定义浏览器直接使用的窗口令牌的有效期和 CSRF 校验
+type ChatWidgetConfig struct {
+	TokenTTLMinutes int  `mapstructure:"token_ttl_minutes"` // 窗口令牌的默认有效期和最长有效期（分钟）
+	CSRFEnabled     bool `mapstructure:"csrf_enabled"`      // 使用窗口令牌发起修改数据的请求时是否校验与窗口令牌绑定的 CSRF 令牌
+}
+
+// ApiKeyConfig 智能体 API Key 访问控制配置结构体
+// 定义来源IP不满足网段限制的访问拒绝记录的保留时间
+type ApiKeyConfig struct {
+	RejectionRetentionDays int `mapstructure:"rejection_retention_days"` // 访问拒绝记录保留天数，0 表示不清理
+}
+
+// MessageSpoolConfig 消息保存失败重试配置结构体
+// 保存聊天消息失败时先写入本地暂存目录，由后台任务按退避间隔重试写入数据库
+type MessageSpoolConfig struct {
+	Dir                  string `mapstructure:"dir"`                    // 暂存目录，为空时不暂存，保存失败的消息只记录日志
+	RetryIntervalSeconds int    `mapstructure:"retry_interval_seconds"` // 检查待重试消息的间隔（秒），也是首次重试的退避间隔，0 表示不重试
+	MaxAttempts          int    `mapstructure:"max_attempts"`           // 最多重试次数，超过后放弃重试并标记会话存在消息缺失
+}
+
+// EventOutboxConfig 会话事件推送配置结构体
+// 会话事件与消息在同一事务中写入发件箱，后台任务推送到应用订阅的 Webhook，失败时按指数退避重试
+type EventOutboxConfig struct {
+	IntervalSeconds       int `mapstructure:"interval_seconds"`        // 检查待推送事件的间隔（秒），0 表示不推送事件
+	BatchSize             int `mapstructure:"batch_size"`              // 每次处理的事件和推送记录数量
+	MaxAttempts           int `mapstructure:"max_attempts"`            // 每条推送记录最多推送次数，超过后标记为推送失败
+	RequestTimeoutSeconds int `mapstructure:"request_timeout_seconds"` // 推送请求的超时时间（秒）
+	RetentionDays         int `mapstructure:"retention_days"`          // 事件和已结束的推送记录保留天数，0 表示不清理
+}
+
+// EventBrokerConfig 会话事件消息队列配置结构体
+// 发件箱中的会话事件除推送到 Webhook 外，还可以发布到 NATS 或 Kafka（通过 Kafka REST Proxy），供下游系统订阅
+type EventBrokerConfig struct {
+	Type           string `mapstructure:"type"`            // 消息队列类型：nats 或 kafka，为空时不发布
+	Url            string `mapstructure:"url"`             // NATS 服务器地址，如 nats://127.0.0.1:4222；Kafka 为 REST Proxy 地址，如 http://127.0.0.1:8082
+	Topic          string `mapstructure:"topic"`           // NATS 为主题前缀，实际主题为 前缀.事件类型；Kafka 为主题名称
+	Username       string `mapstructure:"username"`        // 认证用户名，NATS 只设置用户名时作为令牌使用
+	Password       string `mapstructure:"password"`        // 认证密码
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"` // 每次发布的超时时间（秒）
+}
+
+// ClusterConfig 集群模式配置结构体
+// 多个实例共用同一个数据库时开启：后台任务通过数据库锁同时只在一个实例上执行，流式回复事件写入数据库，可以在任意实例续传
+type ClusterConfig struct {
+	Enabled                         bool   `mapstructure:"enabled"`                            // 是否开启集群模式
+	InstanceID                      string `mapstructure:"instance_id"`                        // 实例ID，用于标识锁的持有者，为空时使用主机名和随机后缀
+	LockTTLSeconds                  int    `mapstructure:"lock_ttl_seconds"`                   // 分布式锁的租约时间（秒），持有锁的实例异常退出后超过该时间由其他实例接管
+	StreamFlushIntervalMilliseconds int    `mapstructure:"stream_flush_interval_milliseconds"` // 流式回复事件写入数据库的间隔，也是其他实例续传时查询新事件的间隔（毫秒）
+}
+
+// ArchiveConfig 会话归档配置结构体
+// 超过应用数据保留天数的会话导出为 JSONL 文件写入应用的存储（S3 或文件系统）后从数据库删除，可以按需恢复
+type ArchiveConfig struct {
+	IntervalMinutes int `mapstructure:"interval_minutes"` // 检查需要归档的会话的间隔（分钟），0 表示不归档
+	BatchSize       int `mapstructure:"batch_size"`       // 每批归档的会话数量
+}
+
+// FeatureFlagConfig 功能开关配置结构体
+// 风险较高的功能按应用逐步开放：应用单独设置的开关优先，其余应用按灰度比例根据应用ID决定是否开启
+type FeatureFlagConfig struct {
+	Rollouts                     map[string]int `mapstructure:"rollouts"`                        // 各功能开关的全局灰度比例（0-100），未配置的开关使用默认比例
+	ExperimentalLlmProviderTypes []string       `mapstructure:"experimental_llm_provider_types"` // 试验中的模型供应商类型，只有开启了 experimental_llm_providers 开关的应用可以使用
+}
+
+// 服务器和跨域配置的默认值
+const (
+	defaultMaxBodySize   = 10 << 20  // 默认请求体大小上限 10MB
+	defaultMaxUploadSize = 100 << 20 // 默认上传接口请求体大小上限 100MB
+)
+
+var (
+	// defaultCORSAllowedHeaders 默认允许的请求头
+	defaultCORSAllowedHeaders = []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With", "X-Request-ID", "lemon-ai-api-key", "lemon-ai-widget-token"}
+	// defaultCORSAllowedMethods 默认允许的 HTTP 方法
+	defaultCORSAllowedMethods = []string{"POST", "OPTIONS", "GET", "PUT", "DELETE"}
+	// defaultMcpStdioInheritEnv 默认传递给 stdio MCP服务的环境变量
+	defaultMcpStdioInheritEnv = []string{"PATH", "HOME", "LANG", "LC_ALL", "TZ", "TMPDIR"}
+)
+
 // AppConfig 全局配置变量
 // 用于在整个应用程序中访问配置信息
 var AppConfig *Config
@@ -55,7 +329,9 @@ var AppConfig *Config
 // 返回配置对象，如果加载失败则程序退出
 func LoadConfig() *Config {
 	// 加载 .env 文件（如果存在）
-	if err := godotenv.Load(); err != nil {
+	if found, err := loadEnvFile(); err != nil {
+		log.Printf("Failed to read .env file: %v", err)
+	} else if !found {
 		// 如果 .env 文件不存在，继续使用环境变量
 		log.Println("No .env file found, using environment variables")
 	}
@@ -69,18 +345,48 @@ func LoadConfig() *Config {
 	setDefaults()
 
 	// 创建配置对象
-	AppConfig = &Config{
+	AppConfig = buildConfig()
+	return AppConfig
+}
+
+// buildConfig 根据当前的环境变量创建配置对象
+func buildConfig() *Config {
+	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", ":8080"),
-			Mode: getEnv("SERVER_MODE", "debug"),
+			Port:           getEnv("SERVER_PORT", ":8080"),
+			Mode:           getEnv("SERVER_MODE", "debug"),
+			TrustedProxies: getEnvList("SERVER_TRUSTED_PROXIES", nil),
+			MaxBodySize:    getEnvInt64("SERVER_MAX_BODY_SIZE", defaultMaxBodySize),
+			MaxUploadSize:  getEnvInt64("SERVER_MAX_UPLOAD_SIZE", defaultMaxUploadSize),
+
+			TLSCertFile:      getEnv("SERVER_TLS_CERT_FILE", ""),
+			TLSKeyFile:       getEnv("SERVER_TLS_KEY_FILE", ""),
+			AutocertDomains:  getEnvList("SERVER_AUTOCERT_DOMAINS", nil),
+			AutocertCacheDir: getEnv("SERVER_AUTOCERT_CACHE_DIR", "autocert-cache"),
+			AutocertEmail:    getEnv("SERVER_AUTOCERT_EMAIL", ""),
+			HTTPRedirectPort: getEnv("SERVER_HTTP_REDIRECT_PORT", ""),
+
+			StaticCacheMaxAge: int(getEnvInt64("SERVER_STATIC_CACHE_MAX_AGE", 86400)),
+		},
+		Log: LogConfig{
+			Level: strings.ToLower(getEnv("LOG_LEVEL", "info")),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", []string{"*"}),
+			AllowedHeaders:   getEnvList("CORS_ALLOWED_HEADERS", defaultCORSAllowedHeaders),
+			AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS", defaultCORSAllowedMethods),
+			AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", true),
+			MaxAge:           int(getEnvInt64("CORS_MAX_AGE", 0)),
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
-			Username: getEnv("DB_USERNAME", "root"),
-			Password: getEnv("DB_PASSWORD", ""),
-			Database: getEnv("DB_DATABASE", "lemon_tree_core"),
-			Charset:  getEnv("DB_CHARSET", "utf8mb4"),
+			Driver:     getEnv("DB_DRIVER", "mysql"),
+			Host:       getEnv("DB_HOST", "localhost"),
+			Port:       getEnv("DB_PORT", "3306"),
+			Username:   getEnv("DB_USERNAME", "root"),
+			Password:   getEnv("DB_PASSWORD", ""),
+			Database:   getEnv("DB_DATABASE", "lemon_tree_core"),
+			Charset:    getEnv("DB_CHARSET", "utf8mb4"),
+			ReplicaDSN: getEnv("DB_REPLICA_DSN", ""),
 		},
 		AI: AIConfig{
 			Type:    getEnv("AI_TYPE", "openai"),
@@ -88,9 +394,133 @@ func LoadConfig() *Config {
 			BaseURL: getEnv("AI_BASE_URL", ""),
 			Model:   getEnv("AI_MODEL", ""),
 		},
+		Grpc: GrpcConfig{
+			Enabled: getEnvBool("GRPC_ENABLED", false),
+			Port:    getEnv("GRPC_PORT", ":9090"),
+		},
+		Bootstrap: BootstrapConfig{
+			OnStartup:     getEnvBool("BOOTSTRAP_ON_STARTUP", false),
+			AdminNumber:   getEnv("BOOTSTRAP_ADMIN_NUMBER", "admin"),
+			AdminEmail:    getEnv("BOOTSTRAP_ADMIN_EMAIL", "admin@localhost"),
+			AdminPassword: getEnv("BOOTSTRAP_ADMIN_PASSWORD", ""),
+		},
+		Upload: UploadConfig{
+			OrphanTTLHours:         int(getEnvInt64("UPLOAD_ORPHAN_TTL_HOURS", 24)),
+			CleanupIntervalMinutes: int(getEnvInt64("UPLOAD_CLEANUP_INTERVAL_MINUTES", 60)),
+		},
+		Export: ExportConfig{
+			PdfFontPath: getEnv("EXPORT_PDF_FONT_PATH", ""),
+		},
+		Batch: BatchConfig{
+			IntervalSeconds: int(getEnvInt64("BATCH_INTERVAL_SECONDS", 10)),
+			MaxConcurrency:  int(getEnvInt64("BATCH_MAX_CONCURRENCY", 4)),
+		},
+		Evaluation: EvaluationConfig{
+			IntervalSeconds: int(getEnvInt64("EVALUATION_INTERVAL_SECONDS", 10)),
+			Concurrency:     int(getEnvInt64("EVALUATION_CONCURRENCY", 2)),
+		},
+		Deletion: DeletionConfig{
+			IntervalSeconds: int(getEnvInt64("DELETION_INTERVAL_SECONDS", 10)),
+			BatchSize:       int(getEnvInt64("DELETION_BATCH_SIZE", 500)),
+		},
+		LlmLimiter: LlmLimiterConfig{
+			DefaultMaxConcurrency: int(getEnvInt64("LLM_PROVIDER_MAX_CONCURRENCY", 0)),
+			QueueTimeoutSeconds:   int(getEnvInt64("LLM_PROVIDER_QUEUE_TIMEOUT_SECONDS", 60)),
+		},
+		Metrics: MetricsConfig{
+			Enabled: getEnvBool("METRICS_ENABLED", true),
+			Token:   getEnv("METRICS_TOKEN", ""),
+		},
+		ChatStream: ChatStreamConfig{
+			ResumeWindowSeconds: int(getEnvInt64("CHAT_STREAM_RESUME_WINDOW_SECONDS", 300)),
+		},
+		McpStdio: McpStdioConfig{
+			AllowedCommands: getEnvList("MCP_STDIO_ALLOWED_COMMANDS", nil),
+			InheritEnv:      getEnvList("MCP_STDIO_INHERIT_ENV", defaultMcpStdioInheritEnv),
+		},
+		McpOAuth: McpOAuthConfig{
+			EncryptionKey: getEnv("MCP_OAUTH_ENCRYPTION_KEY", ""),
+			RedirectURL:   getEnv("MCP_OAUTH_REDIRECT_URL", ""),
+			CompleteURL:   getEnv("MCP_OAUTH_COMPLETE_URL", ""),
+		},
+		Session: SessionConfig{
+			AccessTokenTTLMinutes:  int(getEnvInt64("SESSION_ACCESS_TOKEN_TTL_MINUTES", 60)),
+			RefreshTokenTTLHours:   int(getEnvInt64("SESSION_REFRESH_TOKEN_TTL_HOURS", 720)),
+			CleanupIntervalMinutes: int(getEnvInt64("SESSION_CLEANUP_INTERVAL_MINUTES", 60)),
+		},
+		Oidc: OidcConfig{
+			Issuer:        getEnv("OIDC_ISSUER", ""),
+			ClientID:      getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret:  getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:   getEnv("OIDC_REDIRECT_URL", ""),
+			CompleteURL:   getEnv("OIDC_COMPLETE_URL", ""),
+			Scopes:        getEnvList("OIDC_SCOPES", []string{"openid", "profile", "email"}),
+			UsernameClaim: getEnv("OIDC_USERNAME_CLAIM", "preferred_username"),
+			AutoProvision: getEnvBool("OIDC_AUTO_PROVISION", true),
+			RoleClaim:     getEnv("OIDC_ROLE_CLAIM", "groups"),
+			AllowedRoles:  getEnvList("OIDC_ALLOWED_ROLES", nil),
+		},
+		Password: PasswordConfig{
+			MinLength:        int(getEnvInt64("PASSWORD_MIN_LENGTH", 8)),
+			RequireUppercase: getEnvBool("PASSWORD_REQUIRE_UPPERCASE", false),
+			RequireLowercase: getEnvBool("PASSWORD_REQUIRE_LOWERCASE", false),
+			RequireDigit:     getEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+			RequireSymbol:    getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		},
+		Mail: MailConfig{
+			SMTPHost:                getEnv("MAIL_SMTP_HOST", ""),
+			SMTPPort:                int(getEnvInt64("MAIL_SMTP_PORT", 587)),
+			SMTPUsername:            getEnv("MAIL_SMTP_USERNAME", ""),
+			SMTPPassword:            getEnv("MAIL_SMTP_PASSWORD", ""),
+			From:                    getEnv("MAIL_FROM", ""),
+			TemplateDir:             getEnv("MAIL_TEMPLATE_DIR", ""),
+			InviteURL:               getEnv("MAIL_INVITE_URL", ""),
+			ResetPasswordURL:        getEnv("MAIL_RESET_PASSWORD_URL", ""),
+			InviteTTLHours:          int(getEnvInt64("MAIL_INVITE_TTL_HOURS", 72)),
+			ResetPasswordTTLMinutes: int(getEnvInt64("MAIL_RESET_PASSWORD_TTL_MINUTES", 30)),
+		},
+		ChatWidget: ChatWidgetConfig{
+			TokenTTLMinutes: int(getEnvInt64("CHAT_WIDGET_TOKEN_TTL_MINUTES", 60)),
+			CSRFEnabled:     getEnvBool("CHAT_WIDGET_CSRF_ENABLED", true),
+		},
+		ApiKey: ApiKeyConfig{
+			RejectionRetentionDays: int(getEnvInt64("API_KEY_REJECTION_RETENTION_DAYS", 90)),
+		},
+		MessageSpool: MessageSpoolConfig{
+			Dir:                  getEnv("MESSAGE_SPOOL_DIR", "message-spool"),
+			RetryIntervalSeconds: int(getEnvInt64("MESSAGE_SPOOL_RETRY_INTERVAL_SECONDS", 10)),
+			MaxAttempts:          int(getEnvInt64("MESSAGE_SPOOL_MAX_ATTEMPTS", 20)),
+		},
+		EventOutbox: EventOutboxConfig{
+			IntervalSeconds:       int(getEnvInt64("EVENT_OUTBOX_INTERVAL_SECONDS", 5)),
+			BatchSize:             int(getEnvInt64("EVENT_OUTBOX_BATCH_SIZE", 100)),
+			MaxAttempts:           int(getEnvInt64("EVENT_OUTBOX_MAX_ATTEMPTS", 10)),
+			RequestTimeoutSeconds: int(getEnvInt64("EVENT_OUTBOX_REQUEST_TIMEOUT_SECONDS", 10)),
+			RetentionDays:         int(getEnvInt64("EVENT_OUTBOX_RETENTION_DAYS", 7)),
+		},
+		EventBroker: EventBrokerConfig{
+			Type:           getEnv("EVENT_BROKER_TYPE", ""),
+			Url:            getEnv("EVENT_BROKER_URL", ""),
+			Topic:          getEnv("EVENT_BROKER_TOPIC", "lemon.conversation-events"),
+			Username:       getEnv("EVENT_BROKER_USERNAME", ""),
+			Password:       getEnv("EVENT_BROKER_PASSWORD", ""),
+			TimeoutSeconds: int(getEnvInt64("EVENT_BROKER_TIMEOUT_SECONDS", 10)),
+		},
+		Cluster: ClusterConfig{
+			Enabled:                         getEnvBool("CLUSTER_ENABLED", false),
+			InstanceID:                      getEnv("CLUSTER_INSTANCE_ID", ""),
+			LockTTLSeconds:                  int(getEnvInt64("CLUSTER_LOCK_TTL_SECONDS", 60)),
+			StreamFlushIntervalMilliseconds: int(getEnvInt64("CLUSTER_STREAM_FLUSH_INTERVAL_MILLISECONDS", 500)),
+		},
+		Archive: ArchiveConfig{
+			IntervalMinutes: int(getEnvInt64("ARCHIVE_INTERVAL_MINUTES", 60)),
+			BatchSize:       int(getEnvInt64("ARCHIVE_BATCH_SIZE", 100)),
+		},
+		FeatureFlag: FeatureFlagConfig{
+			Rollouts:                     getEnvPercentMap("FEATURE_FLAGS"),
+			ExperimentalLlmProviderTypes: getEnvList("FEATURE_FLAG_EXPERIMENTAL_LLM_PROVIDER_TYPES", nil),
+		},
 	}
-
-	return AppConfig
 }
 
 // setDefaults 设置默认配置值
@@ -98,6 +528,17 @@ func setDefaults() {
 	// 服务器默认配置
 	viper.SetDefault("server.port", ":8080")
 	viper.SetDefault("server.mode", "debug")
+	viper.SetDefault("server.max_body_size", defaultMaxBodySize)
+	viper.SetDefault("server.max_upload_size", defaultMaxUploadSize)
+	viper.SetDefault("server.autocert_cache_dir", "autocert-cache")
+	viper.SetDefault("server.static_cache_max_age", 86400)
+
+	// 跨域默认配置
+	viper.SetDefault("cors.allowed_origins", []string{"*"})
+	viper.SetDefault("cors.allowed_headers", defaultCORSAllowedHeaders)
+	viper.SetDefault("cors.allowed_methods", defaultCORSAllowedMethods)
+	viper.SetDefault("cors.allow_credentials", true)
+	viper.SetDefault("cors.max_age", 0)
 
 	// 数据库默认配置
 	viper.SetDefault("database.host", "localhost")
@@ -112,6 +553,20 @@ func setDefaults() {
 	viper.SetDefault("ai.api_key", "")
 	viper.SetDefault("ai.base_url", "")
 	viper.SetDefault("ai.model", "")
+
+	// gRPC服务默认配置
+	viper.SetDefault("grpc.enabled", false)
+	viper.SetDefault("grpc.port", ":9090")
+
+	// 首次启动初始化默认配置
+	viper.SetDefault("bootstrap.on_startup", false)
+	viper.SetDefault("bootstrap.admin_number", "admin")
+	viper.SetDefault("bootstrap.admin_email", "admin@localhost")
+	viper.SetDefault("bootstrap.admin_password", "")
+
+	// 上传文件清理默认配置
+	viper.SetDefault("upload.orphan_ttl_hours", 24)
+	viper.SetDefault("upload.cleanup_interval_minutes", 60)
 }
 
 // getEnv 获取环境变量，如果不存在则返回默认值
@@ -121,3 +576,59 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvBool 获取布尔类型的环境变量，如果不存在或无法解析则返回默认值
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvInt64 获取整数类型的环境变量，如果不存在或无法解析则返回默认值
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList 获取逗号分隔的列表类型环境变量，如果不存在则返回默认值
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvPercentMap 获取逗号分隔的 名称=百分比 格式的环境变量，省略百分比时为 100
+// 百分比无法解析或超出 0-100 的项会被忽略
+func getEnvPercentMap(key string) map[string]int {
+	result := make(map[string]int)
+	for _, item := range getEnvList(key, nil) {
+		name, value, found := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		percent := 100
+		if found {
+			parsed, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || parsed < 0 || parsed > 100 {
+				log.Printf("Ignoring invalid %s entry: %s", key, item)
+				continue
+			}
+			percent = parsed
+		}
+		result[name] = percent
+	}
+	return result
+}
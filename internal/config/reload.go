@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/joho/godotenv"
+)
+
+// LogLevels 支持的日志级别
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// envFileKeys 从 .env 文件加载的环境变量名
+// 进程启动时已存在的环境变量优先于 .env 文件，不会被覆盖，重新加载时也只更新这些变量
+var (
+	envFileMu   sync.Mutex
+	envFileKeys = make(map[string]bool)
+)
+
+// loadEnvFile 加载工作目录下的 .env 文件
+// 文件中的变量写入进程环境，已由 .env 文件写入的变量更新为文件中的新值，文件中已删除的变量一并删除
+// 返回：文件是否存在和错误信息
+func loadEnvFile() (bool, error) {
+	values, err := godotenv.Read()
+	found := err == nil
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	envFileMu.Lock()
+	defer envFileMu.Unlock()
+	for key := range envFileKeys {
+		if _, ok := values[key]; !ok {
+			os.Unsetenv(key)
+			delete(envFileKeys, key)
+		}
+	}
+	for key, value := range values {
+		if !envFileKeys[key] {
+			if _, exists := os.LookupEnv(key); exists {
+				continue
+			}
+			envFileKeys[key] = true
+		}
+		os.Setenv(key, value)
+	}
+	return found, nil
+}
+
+// Reloader 配置重新加载器
+// 重新读取 .env 文件后创建新的配置对象并通知注册的组件，进程环境变量在运行期间无法从外部修改，因此只有 .env 文件中的修改会生效
+// 只有注册了回调的配置（日志级别、跨域、模型提供商并发限制、功能开关）会更新，监听端口、数据库连接等结构性配置仍需重启服务；
+// 启动时创建的 Config 对象保持不变，组件在回调中更新各自持有的设置
+type Reloader struct {
+	mu        sync.Mutex
+	listeners []func(newConfig *Config)
+}
+
+// NewReloader 创建配置重新加载器
+func NewReloader() *Reloader {
+	return &Reloader{}
+}
+
+// OnReload 注册配置重新加载后的回调
+// 回调在 Reload 中按注册顺序同步执行，不能阻塞
+func (r *Reloader) OnReload(listener func(newConfig *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, listener)
+}
+
+// Reload 重新加载配置并通知已注册的组件
+// 返回：新的配置；.env 文件无法读取或新配置校验不通过时不通知任何组件并返回错误
+func (r *Reloader) Reload() (*Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := loadEnvFile(); err != nil {
+		return nil, fmt.Errorf("读取 .env 文件失败: %w", err)
+	}
+	newConfig := buildConfig()
+	if !slices.Contains(LogLevels, newConfig.Log.Level) {
+		return nil, fmt.Errorf("不支持的日志级别: %s", newConfig.Log.Level)
+	}
+	for _, listener := range r.listeners {
+		listener(newConfig)
+	}
+	return newConfig, nil
+}
@@ -3,6 +3,7 @@
 package converter
 
 import (
+	"encoding/json"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/models"
 
@@ -17,18 +18,12 @@ func ApplicationModelToApplicationDto(application *models.Application) *dto.Appl
 		return nil
 	}
 
-	var deletedAt *int64
-	if !application.DeletedAt.Time.IsZero() {
-		timestamp := application.DeletedAt.Time.UnixMilli()
-		deletedAt = &timestamp
-	}
-
 	return &dto.ApplicationDto{
 		BaseModelDto: dto.BaseModelDto{
 			ID:        application.ID,
-			CreatedAt: application.CreatedAt.UnixMilli(),
-			UpdatedAt: application.UpdatedAt.UnixMilli(),
-			DeletedAt: deletedAt,
+			CreatedAt: timeToMilli(application.CreatedAt),
+			UpdatedAt: timeToMilli(application.UpdatedAt),
+			DeletedAt: deletedAtToMilli(application.DeletedAt),
 		},
 		Name:        application.Name,
 		Description: application.Description,
@@ -52,10 +47,10 @@ func ApplicationModelListToApplicationDtoList(applications []*models.Application
 
 // ApplicationSaveDtoToApplicationModel 将 ApplicationSaveDto 转换为 Application 模型
 // 参数：applicationDto - 应用保存DTO
-// 返回：*Application - 应用模型
-func ApplicationSaveDtoToApplicationModel(applicationDto *dto.ApplicationSaveDto) *models.Application {
+// 返回：*Application - 应用模型，ID 不是有效的UUID时返回参数错误
+func ApplicationSaveDtoToApplicationModel(applicationDto *dto.ApplicationSaveDto) (*models.Application, error) {
 	if applicationDto == nil {
-		return nil
+		return nil, nil
 	}
 
 	application := &models.Application{
@@ -64,13 +59,13 @@ func ApplicationSaveDtoToApplicationModel(applicationDto *dto.ApplicationSaveDto
 	}
 
 	// 如果提供了ID，则解析UUID
-	if applicationDto.ID != "" {
-		if id, err := uuid.Parse(applicationDto.ID); err == nil {
-			application.ID = id
-		}
+	fields := &uuidFields{}
+	application.ID = fields.parse("id", applicationDto.ID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
-	return application
+	return application, nil
 }
 
 // ApplicationQueryDtoToApplicationModel 将 ApplicationQueryDto 转换为 Application 模型（用于查询）
@@ -86,3 +81,96 @@ func ApplicationQueryDtoToApplicationModel(applicationDto *dto.ApplicationQueryD
 		Description: applicationDto.Description,
 	}
 }
+
+// ApplicationDeletionJobModelToApplicationDeletionJobDto 将应用删除任务模型转换为DTO
+// 参数：job - 应用删除任务模型
+// 返回：应用删除任务DTO
+func ApplicationDeletionJobModelToApplicationDeletionJobDto(job *models.ApplicationDeletionJob) *dto.ApplicationDeletionJobDto {
+	if job == nil {
+		return nil
+	}
+
+	deletedCounts := make(map[string]int64)
+	if job.DeletedCounts != "" {
+		_ = json.Unmarshal([]byte(job.DeletedCounts), &deletedCounts)
+	}
+	var progress float64
+	if job.TotalSteps > 0 {
+		progress = float64(job.CompletedSteps) / float64(job.TotalSteps)
+	}
+
+	return &dto.ApplicationDeletionJobDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        job.ID,
+			CreatedAt: timeToMilli(job.CreatedAt),
+			UpdatedAt: timeToMilli(job.UpdatedAt),
+		},
+		ApplicationID:  job.ApplicationID.String(),
+		Status:         job.Status,
+		TotalSteps:     job.TotalSteps,
+		CompletedSteps: job.CompletedSteps,
+		CurrentStep:    job.CurrentStep,
+		Progress:       progress,
+		DeletedCounts:  deletedCounts,
+		ErrorMessage:   job.ErrorMessage,
+		StartedAt:      optionalTimeToMilli(job.StartedAt),
+		FinishedAt:     optionalTimeToMilli(job.FinishedAt),
+	}
+}
+
+// ApplicationSettingModelToApplicationSettingDto 将应用设置模型转换为DTO
+// 参数：setting - 应用设置模型
+// 返回：应用设置DTO
+func ApplicationSettingModelToApplicationSettingDto(setting *models.ApplicationSetting) *dto.ApplicationSettingDto {
+	if setting == nil {
+		return nil
+	}
+
+	settingDto := &dto.ApplicationSettingDto{
+		ApplicationID:     setting.ApplicationID.String(),
+		DisplayName:       setting.DisplayName,
+		LogoUrl:           setting.LogoUrl,
+		ThemeColor:        setting.ThemeColor,
+		DefaultLanguage:   setting.DefaultLanguage,
+		DataRetentionDays: setting.DataRetentionDays,
+		BlockedPatterns:   applicationBlockedPatternsToList(setting.BlockedPatterns),
+		BlockedReply:      setting.BlockedReply,
+	}
+	if !setting.UpdatedAt.IsZero() {
+		settingDto.UpdatedAt = optionalTimeToMilli(&setting.UpdatedAt)
+	}
+	return settingDto
+}
+
+// ApplicationSettingSaveDtoToApplicationSettingModel 将应用设置保存DTO转换为模型
+// 参数：applicationID - 应用ID，settingDto - 应用设置保存DTO
+// 返回：应用设置模型
+func ApplicationSettingSaveDtoToApplicationSettingModel(applicationID uuid.UUID, settingDto *dto.ApplicationSettingSaveDto) *models.ApplicationSetting {
+	setting := &models.ApplicationSetting{
+		ApplicationID:     applicationID,
+		DisplayName:       settingDto.DisplayName,
+		LogoUrl:           settingDto.LogoUrl,
+		ThemeColor:        settingDto.ThemeColor,
+		DefaultLanguage:   settingDto.DefaultLanguage,
+		DataRetentionDays: settingDto.DataRetentionDays,
+		BlockedReply:      settingDto.BlockedReply,
+	}
+	if len(settingDto.BlockedPatterns) > 0 {
+		if blockedPatterns, err := json.Marshal(settingDto.BlockedPatterns); err == nil {
+			setting.BlockedPatterns = string(blockedPatterns)
+		}
+	}
+	return setting
+}
+
+// applicationBlockedPatternsToList 解析用户输入拦截规则列表，未配置或内容无效时返回空列表
+func applicationBlockedPatternsToList(blockedPatterns string) []string {
+	patterns := []string{}
+	if blockedPatterns == "" {
+		return patterns
+	}
+	if err := json.Unmarshal([]byte(blockedPatterns), &patterns); err != nil {
+		return []string{}
+	}
+	return patterns
+}
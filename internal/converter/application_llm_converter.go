@@ -33,30 +33,25 @@ func ApplicationLlmModelToApplicationLlmDto(applicationLlm *models.ApplicationLl
 		BillingCurrency:       applicationLlm.BillingCurrency,
 		BillingPriceInput:     applicationLlm.BillingPriceInput,
 		BillingPriceOutput:    applicationLlm.BillingPriceOutput,
-		CreatedAt:             applicationLlm.CreatedAt.String(),
-		UpdatedAt:             applicationLlm.UpdatedAt.String(),
+		CreatedAt:             timeToMilli(applicationLlm.CreatedAt),
+		UpdatedAt:             timeToMilli(applicationLlm.UpdatedAt),
 	}
 }
 
 // ApplicationLlmDtoToApplicationLlmModel 将 ApplicationLlmDto 转换为 ApplicationLlm 模型
 // 参数：applicationLlmDto - 应用模型DTO
-// 返回：应用模型
-func ApplicationLlmDtoToApplicationLlmModel(applicationLlmDto *dto.ApplicationLlmDto) *models.ApplicationLlm {
+// 返回：应用模型，ID 字段不是有效的UUID时返回参数错误
+func ApplicationLlmDtoToApplicationLlmModel(applicationLlmDto *dto.ApplicationLlmDto) (*models.ApplicationLlm, error) {
 	if applicationLlmDto == nil {
-		return nil
+		return nil, nil
 	}
 
 	// 解析UUID字符串
-	var applicationID, llmProviderID uuid.UUID
-	if applicationLlmDto.ApplicationID != "" {
-		if parsedID, err := uuid.Parse(applicationLlmDto.ApplicationID); err == nil {
-			applicationID = parsedID
-		}
-	}
-	if applicationLlmDto.LlmProviderID != "" {
-		if parsedID, err := uuid.Parse(applicationLlmDto.LlmProviderID); err == nil {
-			llmProviderID = parsedID
-		}
+	fields := &uuidFields{}
+	applicationID := fields.parse("application_id", applicationLlmDto.ApplicationID)
+	llmProviderID := fields.parse("llm_provider_id", applicationLlmDto.LlmProviderID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
 	return &models.ApplicationLlm{
@@ -74,33 +69,24 @@ func ApplicationLlmDtoToApplicationLlmModel(applicationLlmDto *dto.ApplicationLl
 		BillingCurrency:       applicationLlmDto.BillingCurrency,
 		BillingPriceInput:     applicationLlmDto.BillingPriceInput,
 		BillingPriceOutput:    applicationLlmDto.BillingPriceOutput,
-	}
+	}, nil
 }
 
 // SaveApplicationLlmRequestToApplicationLlmModel 将 SaveApplicationLlmRequest 转换为 ApplicationLlm 模型
 // 参数：saveRequest - 保存应用模型请求
-// 返回：应用模型
-func SaveApplicationLlmRequestToApplicationLlmModel(saveRequest *dto.SaveApplicationLlmRequest) *models.ApplicationLlm {
+// 返回：应用模型，ID 字段不是有效的UUID时返回参数错误
+func SaveApplicationLlmRequestToApplicationLlmModel(saveRequest *dto.SaveApplicationLlmRequest) (*models.ApplicationLlm, error) {
 	if saveRequest == nil {
-		return nil
+		return nil, nil
 	}
 
 	// 解析UUID字符串
-	var id, applicationID, llmProviderID uuid.UUID
-	if saveRequest.ID != nil && *saveRequest.ID != "" {
-		if parsedID, err := uuid.Parse(*saveRequest.ID); err == nil {
-			id = parsedID
-		}
-	}
-	if saveRequest.ApplicationID != "" {
-		if parsedID, err := uuid.Parse(saveRequest.ApplicationID); err == nil {
-			applicationID = parsedID
-		}
-	}
-	if saveRequest.LlmProviderID != "" {
-		if parsedID, err := uuid.Parse(saveRequest.LlmProviderID); err == nil {
-			llmProviderID = parsedID
-		}
+	fields := &uuidFields{}
+	id := fields.parseOptional("id", saveRequest.ID)
+	applicationID := fields.parse("application_id", saveRequest.ApplicationID)
+	llmProviderID := fields.parse("llm_provider_id", saveRequest.LlmProviderID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
 	applicationLlm := &models.ApplicationLlm{
@@ -125,7 +111,7 @@ func SaveApplicationLlmRequestToApplicationLlmModel(saveRequest *dto.SaveApplica
 		applicationLlm.ID = id
 	}
 
-	return applicationLlm
+	return applicationLlm, nil
 }
 
 // ApplicationLlmModelListToApplicationLlmDtoList 将 ApplicationLlm 模型列表转换为 ApplicationLlmDto 列表
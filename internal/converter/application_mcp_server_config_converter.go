@@ -5,8 +5,6 @@ package converter
 import (
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/models"
-
-	"github.com/google/uuid"
 )
 
 // ApplicationMcpServerConfigModelToApplicationMcpServerConfigDto 将模型转换为DTO
@@ -15,21 +13,32 @@ import (
 // 返回：DTO对象
 func ApplicationMcpServerConfigModelToApplicationMcpServerConfigDto(model *models.ApplicationMcpServerConfig) dto.ApplicationMcpServerConfigDto {
 	return dto.ApplicationMcpServerConfigDto{
-		ID:                   model.ID.String(),
-		ApplicationID:        model.ApplicationID.String(),
-		ConfigID:             model.ConfigID,
-		Name:                 model.Name,
-		Description:          model.Description,
-		Version:              model.Version,
-		McpServerConnectType: model.McpServerConnectType,
-		McpServerTimeout:     model.McpServerTimeout,
-		McpServerUrl:         model.McpServerUrl,
-		McpServerHeader:      model.McpServerHeader,
-		McpServerCommand:     model.McpServerCommand,
-		McpServerArgs:        model.McpServerArgs,
-		McpServerEnv:         model.McpServerEnv,
-		CreatedAt:            model.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:            model.UpdatedAt.Format("2006-01-02 15:04:05"),
+		ID:                        model.ID.String(),
+		ApplicationID:             model.ApplicationID.String(),
+		ConfigID:                  model.ConfigID,
+		Name:                      model.Name,
+		Description:               model.Description,
+		Version:                   model.Version,
+		McpServerConnectType:      model.McpServerConnectType,
+		McpServerTimeout:          model.McpServerTimeout,
+		McpServerUrl:              model.McpServerUrl,
+		McpServerHeader:           model.McpServerHeader,
+		McpServerAuthType:         model.McpServerAuthType,
+		McpOauthClientID:          model.McpOauthClientID,
+		McpOauthClientSecretSet:   model.McpOauthClientSecret != "",
+		McpOauthScopes:            model.McpOauthScopes,
+		McpOauthServerMetadataURL: model.McpOauthServerMetadataURL,
+		McpServerCommand:          model.McpServerCommand,
+		McpServerArgs:             model.McpServerArgs,
+		McpServerEnv:              model.McpServerEnv,
+		McpServerWorkDir:          model.McpServerWorkDir,
+		McpServerCpuLimit:         model.McpServerCpuLimit,
+		McpServerMemoryLimit:      model.McpServerMemoryLimit,
+		McpServerMaxRuntime:       model.McpServerMaxRuntime,
+		McpServerUserNamespace:    model.McpServerUserNamespace,
+		McpServerDisableNetwork:   model.McpServerDisableNetwork,
+		CreatedAt:                 timeToMilli(model.CreatedAt),
+		UpdatedAt:                 timeToMilli(model.UpdatedAt),
 	}
 }
 
@@ -48,35 +57,47 @@ func ApplicationMcpServerConfigModelListToApplicationMcpServerConfigDtoList(mode
 // SaveApplicationMcpServerConfigRequestToApplicationMcpServerConfigModel 将保存请求转换为模型
 // 将前端保存请求转换为数据库模型
 // 参数：request - 保存请求
-// 返回：数据库模型
-func SaveApplicationMcpServerConfigRequestToApplicationMcpServerConfigModel(request *dto.SaveApplicationMcpServerConfigRequest) *models.ApplicationMcpServerConfig {
-	applicationID, _ := uuid.Parse(request.ApplicationID)
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveApplicationMcpServerConfigRequestToApplicationMcpServerConfigModel(request *dto.SaveApplicationMcpServerConfigRequest) (*models.ApplicationMcpServerConfig, error) {
 	model := &models.ApplicationMcpServerConfig{
-		Name:                 request.Name,
-		ApplicationID:        applicationID,
-		ConfigID:             request.ConfigID,
-		Description:          request.Description,
-		Version:              request.Version,
-		McpServerConnectType: request.McpServerConnectType,
-		McpServerTimeout:     request.McpServerTimeout,
-		McpServerUrl:         request.McpServerUrl,
-		McpServerHeader:      request.McpServerHeader,
-		McpServerCommand:     request.McpServerCommand,
-		McpServerArgs:        request.McpServerArgs,
-		McpServerEnv:         request.McpServerEnv,
+		Name:                      request.Name,
+		ConfigID:                  request.ConfigID,
+		Description:               request.Description,
+		Version:                   request.Version,
+		McpServerConnectType:      request.McpServerConnectType,
+		McpServerTimeout:          request.McpServerTimeout,
+		McpServerUrl:              request.McpServerUrl,
+		McpServerHeader:           request.McpServerHeader,
+		McpServerAuthType:         request.McpServerAuthType,
+		McpOauthClientID:          request.McpOauthClientID,
+		McpOauthClientSecret:      request.McpOauthClientSecret,
+		McpOauthScopes:            request.McpOauthScopes,
+		McpOauthServerMetadataURL: request.McpOauthServerMetadataURL,
+		McpServerCommand:          request.McpServerCommand,
+		McpServerArgs:             request.McpServerArgs,
+		McpServerEnv:              request.McpServerEnv,
+		McpServerWorkDir:          request.McpServerWorkDir,
+		McpServerCpuLimit:         request.McpServerCpuLimit,
+		McpServerMemoryLimit:      request.McpServerMemoryLimit,
+		McpServerMaxRuntime:       request.McpServerMaxRuntime,
+		McpServerUserNamespace:    request.McpServerUserNamespace,
+		McpServerDisableNetwork:   request.McpServerDisableNetwork,
 	}
 
-	// 解析应用ID
-	if applicationID, err := uuid.Parse(request.ApplicationID); err == nil {
-		model.ApplicationID = applicationID
+	// 解析应用ID，如果有ID则一并解析（用于更新操作）
+	fields := &uuidFields{}
+	model.ApplicationID = fields.parse("application_id", request.ApplicationID)
+	model.ID = fields.parseOptional("id", request.ID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
-	// 如果有ID，则解析ID（用于更新操作）
-	if request.ID != nil && *request.ID != "" {
-		if id, err := uuid.Parse(*request.ID); err == nil {
-			model.ID = id
-		}
-	}
+	return model, nil
+}
 
-	return model
+// SaveApplicationMcpServerConfigRequestToUpdateFields 获取保存请求中部分更新的模型字段名
+// 参数：request - 保存请求
+// 返回：模型字段名列表，未指定部分更新时为空；包含未知字段时返回参数错误
+func SaveApplicationMcpServerConfigRequestToUpdateFields(request *dto.SaveApplicationMcpServerConfigRequest) ([]string, error) {
+	return updateFieldNames(request, request.UpdateFields, "id")
 }
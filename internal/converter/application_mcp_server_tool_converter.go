@@ -19,8 +19,8 @@ func ApplicationMcpServerToolModelToApplicationMcpServerToolDto(model *models.Ap
 		Name:                         model.Name,
 		Title:                        model.Title,
 		Description:                  model.Description,
-		CreatedAt:                    model.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:                    model.UpdatedAt.Format("2006-01-02 15:04:05"),
+		CreatedAt:                    timeToMilli(model.CreatedAt),
+		UpdatedAt:                    timeToMilli(model.UpdatedAt),
 	}
 }
 
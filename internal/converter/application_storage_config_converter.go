@@ -5,8 +5,6 @@ package converter
 import (
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/models"
-
-	"github.com/google/uuid"
 )
 
 // ApplicationStorageConfigModelToApplicationStorageConfigDto 将模型转换为DTO
@@ -25,16 +23,16 @@ func ApplicationStorageConfigModelToApplicationStorageConfigDto(model *models.Ap
 		SecretId:      model.SecretId,
 		SecretKey:     model.SecretKey,
 		KeyPrefix:     model.KeyPrefix,
-		CreatedAt:     model.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:     model.UpdatedAt.Format("2006-01-02 15:04:05"),
+		CreatedAt:     timeToMilli(model.CreatedAt),
+		UpdatedAt:     timeToMilli(model.UpdatedAt),
 	}
 }
 
 // SaveApplicationStorageConfigRequestToApplicationStorageConfigModel 将保存请求转换为模型
 // 将前端保存请求转换为数据库模型
 // 参数：request - 保存请求
-// 返回：数据库模型
-func SaveApplicationStorageConfigRequestToApplicationStorageConfigModel(request *dto.SaveApplicationStorageConfigRequest) *models.ApplicationStorageConfig {
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveApplicationStorageConfigRequestToApplicationStorageConfigModel(request *dto.SaveApplicationStorageConfigRequest) (*models.ApplicationStorageConfig, error) {
 	model := &models.ApplicationStorageConfig{
 		Type:       request.Type,
 		RootPath:   request.RootPath,
@@ -46,17 +44,13 @@ func SaveApplicationStorageConfigRequestToApplicationStorageConfigModel(request
 		KeyPrefix:  request.KeyPrefix,
 	}
 
-	// 解析应用ID
-	if applicationID, err := uuid.Parse(request.ApplicationID); err == nil {
-		model.ApplicationID = applicationID
-	}
-
-	// 如果有ID，则解析ID（用于更新操作）
-	if request.ID != nil && *request.ID != "" {
-		if id, err := uuid.Parse(*request.ID); err == nil {
-			model.ID = id
-		}
+	// 解析应用ID，如果有ID则一并解析（用于更新操作）
+	fields := &uuidFields{}
+	model.ApplicationID = fields.parse("application_id", request.ApplicationID)
+	model.ID = fields.parseOptional("id", request.ID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
-	return model
+	return model, nil
 }
@@ -0,0 +1,86 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationToolBundleModelToApplicationToolBundleDto 将应用工具集模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func ApplicationToolBundleModelToApplicationToolBundleDto(model *models.ApplicationToolBundle) dto.ApplicationToolBundleDto {
+	return dto.ApplicationToolBundleDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID: model.ApplicationID.String(),
+		Name:          model.Name,
+		Description:   model.Description,
+	}
+}
+
+// ApplicationToolBundleModelListToApplicationToolBundleDtoList 将应用工具集模型列表转换为DTO列表
+// 参数：bundles - 数据库模型列表
+// 返回：DTO列表
+func ApplicationToolBundleModelListToApplicationToolBundleDtoList(bundles []*models.ApplicationToolBundle) []dto.ApplicationToolBundleDto {
+	dtos := make([]dto.ApplicationToolBundleDto, 0, len(bundles))
+	for _, model := range bundles {
+		dtos = append(dtos, ApplicationToolBundleModelToApplicationToolBundleDto(model))
+	}
+	return dtos
+}
+
+// ApplicationToolBundleDetailToApplicationToolBundleDto 将工具集及其包含的工具和关联的智能体转换为DTO
+// 参数：model - 数据库模型，toolIDs - 包含的MCP工具ID，chatAgentIDs - 关联的智能体ID
+// 返回：DTO对象
+func ApplicationToolBundleDetailToApplicationToolBundleDto(model *models.ApplicationToolBundle, toolIDs, chatAgentIDs []uuid.UUID) dto.ApplicationToolBundleDto {
+	bundleDto := ApplicationToolBundleModelToApplicationToolBundleDto(model)
+	bundleDto.ToolIDs = uuidListToStringList(toolIDs)
+	bundleDto.ChatAgentIDs = uuidListToStringList(chatAgentIDs)
+	return bundleDto
+}
+
+// SaveApplicationToolBundleRequestToApplicationToolBundleModel 将保存请求转换为模型和MCP工具ID
+// 参数：request - 保存请求
+// 返回：数据库模型和MCP工具ID，ID 字段不是有效的UUID时返回参数错误
+func SaveApplicationToolBundleRequestToApplicationToolBundleModel(request *dto.SaveApplicationToolBundleRequest) (*models.ApplicationToolBundle, []uuid.UUID, error) {
+	bundle := &models.ApplicationToolBundle{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	fields := &uuidFields{}
+	bundle.ID = fields.parseOptional("id", request.ID)
+	bundle.ApplicationID = fields.parse("application_id", request.ApplicationID)
+	toolIDs := fields.parseList("tool_ids", request.ToolIDs)
+	if fields.err != nil {
+		return nil, nil, fields.err
+	}
+	return bundle, toolIDs, nil
+}
+
+// ApplicationToolBundleChatAgentsRequestToChatAgentIDs 解析关联或取消关联智能体请求中的智能体ID
+// 参数：request - 关联或取消关联智能体请求
+// 返回：智能体ID列表，不是有效的UUID时返回参数错误
+func ApplicationToolBundleChatAgentsRequestToChatAgentIDs(request *dto.ApplicationToolBundleChatAgentsRequest) ([]uuid.UUID, error) {
+	fields := &uuidFields{}
+	chatAgentIDs := fields.parseList("chat_agent_ids", request.ChatAgentIDs)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return chatAgentIDs, nil
+}
+
+// uuidListToStringList 将UUID列表转换为字符串列表
+func uuidListToStringList(ids []uuid.UUID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	return values
+}
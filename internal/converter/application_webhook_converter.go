@@ -0,0 +1,107 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"encoding/json"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// ApplicationWebhookModelToApplicationWebhookDto 将应用 Webhook 模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func ApplicationWebhookModelToApplicationWebhookDto(model *models.ApplicationWebhook) dto.ApplicationWebhookDto {
+	return dto.ApplicationWebhookDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID: model.ApplicationID.String(),
+		Name:          model.Name,
+		Url:           model.Url,
+		Secret:        model.Secret,
+		EventTypes:    applicationWebhookEventTypesToList(model.EventTypes),
+		Enabled:       model.Enabled,
+	}
+}
+
+// ApplicationWebhookModelListToApplicationWebhookDtoList 将应用 Webhook 模型列表转换为DTO列表
+// 参数：webhooks - 数据库模型列表
+// 返回：DTO列表
+func ApplicationWebhookModelListToApplicationWebhookDtoList(webhooks []*models.ApplicationWebhook) []dto.ApplicationWebhookDto {
+	dtos := make([]dto.ApplicationWebhookDto, 0, len(webhooks))
+	for _, model := range webhooks {
+		dtos = append(dtos, ApplicationWebhookModelToApplicationWebhookDto(model))
+	}
+	return dtos
+}
+
+// SaveApplicationWebhookRequestToApplicationWebhookModel 将保存请求转换为模型
+// 参数：request - 保存请求
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveApplicationWebhookRequestToApplicationWebhookModel(request *dto.SaveApplicationWebhookRequest) (*models.ApplicationWebhook, error) {
+	webhook := &models.ApplicationWebhook{
+		Name:    request.Name,
+		Url:     request.Url,
+		Enabled: request.Enabled == nil || *request.Enabled,
+	}
+	if len(request.EventTypes) > 0 {
+		if eventTypes, err := json.Marshal(request.EventTypes); err == nil {
+			webhook.EventTypes = string(eventTypes)
+		}
+	}
+	fields := &uuidFields{}
+	webhook.ID = fields.parseOptional("id", request.ID)
+	webhook.ApplicationID = fields.parse("application_id", request.ApplicationID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return webhook, nil
+}
+
+// ApplicationWebhookDeliveryListToDtoList 将 Webhook 推送记录列表转换为DTO列表
+// 参数：deliveries - 数据库模型列表
+// 返回：DTO列表
+func ApplicationWebhookDeliveryListToDtoList(deliveries []*models.ApplicationWebhookDelivery) []dto.ApplicationWebhookDeliveryDto {
+	dtos := make([]dto.ApplicationWebhookDeliveryDto, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dtos = append(dtos, ApplicationWebhookDeliveryModelToDto(delivery))
+	}
+	return dtos
+}
+
+// ApplicationWebhookDeliveryModelToDto 将 Webhook 推送记录模型转换为DTO
+// 参数：delivery - 数据库模型
+// 返回：DTO对象
+func ApplicationWebhookDeliveryModelToDto(delivery *models.ApplicationWebhookDelivery) dto.ApplicationWebhookDeliveryDto {
+	return dto.ApplicationWebhookDeliveryDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        delivery.ID,
+			CreatedAt: timeToMilli(delivery.CreatedAt),
+			UpdatedAt: timeToMilli(delivery.UpdatedAt),
+		},
+		WebhookID:      delivery.WebhookID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  timeToMilli(delivery.NextAttemptAt),
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    optionalTimeToMilli(delivery.DeliveredAt),
+	}
+}
+
+// applicationWebhookEventTypesToList 解析订阅的事件类型列表，未配置或内容无效时返回空列表
+func applicationWebhookEventTypesToList(eventTypes string) []string {
+	list := []string{}
+	if eventTypes == "" {
+		return list
+	}
+	if err := json.Unmarshal([]byte(eventTypes), &list); err != nil {
+		return []string{}
+	}
+	return list
+}
@@ -0,0 +1,86 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// BatchInferenceJobModelToBatchInferenceJobDto 将批量推理任务模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func BatchInferenceJobModelToBatchInferenceJobDto(model *models.BatchInferenceJob) dto.BatchInferenceJobDto {
+	return dto.BatchInferenceJobDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID:  model.ApplicationID.String(),
+		ChatAgentID:    model.ChatAgentID.String(),
+		Name:           model.Name,
+		SystemPrompt:   model.SystemPrompt,
+		Concurrency:    model.Concurrency,
+		Status:         model.Status,
+		TotalCount:     model.TotalCount,
+		CompletedCount: model.CompletedCount,
+		FailedCount:    model.FailedCount,
+		ErrorMessage:   model.ErrorMessage,
+		StartedAt:      optionalTimeToMilli(model.StartedAt),
+		FinishedAt:     optionalTimeToMilli(model.FinishedAt),
+	}
+}
+
+// BatchInferenceJobModelListToBatchInferenceJobDtoList 将批量推理任务模型列表转换为DTO列表
+// 参数：jobs - 数据库模型列表
+// 返回：DTO列表
+func BatchInferenceJobModelListToBatchInferenceJobDtoList(jobs []*models.BatchInferenceJob) []dto.BatchInferenceJobDto {
+	dtos := make([]dto.BatchInferenceJobDto, 0, len(jobs))
+	for _, model := range jobs {
+		dtos = append(dtos, BatchInferenceJobModelToBatchInferenceJobDto(model))
+	}
+	return dtos
+}
+
+// BatchInferenceItemModelListToBatchInferenceItemDtoList 将批量推理提示词模型列表转换为DTO列表
+// 参数：items - 数据库模型列表
+// 返回：DTO列表
+func BatchInferenceItemModelListToBatchInferenceItemDtoList(items []*models.BatchInferenceItem) []dto.BatchInferenceItemDto {
+	dtos := make([]dto.BatchInferenceItemDto, 0, len(items))
+	for _, model := range items {
+		dtos = append(dtos, dto.BatchInferenceItemDto{
+			BaseModelDto: dto.BaseModelDto{
+				ID:        model.ID,
+				CreatedAt: timeToMilli(model.CreatedAt),
+				UpdatedAt: timeToMilli(model.UpdatedAt),
+			},
+			JobID:          model.JobID.String(),
+			ItemIndex:      model.ItemIndex,
+			Prompt:         model.Prompt,
+			Status:         model.Status,
+			Answer:         model.Answer,
+			ConversationID: model.ConversationID,
+			ErrorMessage:   model.ErrorMessage,
+			DurationMs:     model.DurationMs,
+		})
+	}
+	return dtos
+}
+
+// CreateBatchInferenceJobRequestToBatchInferenceJobModel 将创建请求转换为模型
+// 参数：request - 创建请求
+// 返回：数据库模型，智能体ID不是有效的UUID时返回参数错误
+func CreateBatchInferenceJobRequestToBatchInferenceJobModel(request *dto.CreateBatchInferenceJobRequest) (*models.BatchInferenceJob, error) {
+	job := &models.BatchInferenceJob{
+		Name:         request.Name,
+		SystemPrompt: request.SystemPrompt,
+		Concurrency:  request.Concurrency,
+	}
+	fields := &uuidFields{}
+	job.ChatAgentID = fields.parse("chat_agent_id", request.ChatAgentID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return job, nil
+}
@@ -0,0 +1,73 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentAnswerRuleModelToChatAgentAnswerRuleDto 将预制答案规则模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func ChatAgentAnswerRuleModelToChatAgentAnswerRuleDto(model *models.ChatAgentAnswerRule) dto.ChatAgentAnswerRuleDto {
+	embeddingModelID := ""
+	if model.EmbeddingModelID != uuid.Nil {
+		embeddingModelID = model.EmbeddingModelID.String()
+	}
+	return dto.ChatAgentAnswerRuleDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID:      model.ApplicationID.String(),
+		ChatAgentID:        model.ChatAgentID.String(),
+		Name:               model.Name,
+		MatchType:          model.MatchType,
+		Pattern:            model.Pattern,
+		Answer:             model.Answer,
+		Priority:           model.Priority,
+		Enabled:            model.Enabled,
+		EmbeddingModelID:   embeddingModelID,
+		EmbeddingThreshold: model.EmbeddingThreshold,
+	}
+}
+
+// ChatAgentAnswerRuleModelListToChatAgentAnswerRuleDtoList 将预制答案规则模型列表转换为DTO列表
+// 参数：rules - 数据库模型列表
+// 返回：DTO列表
+func ChatAgentAnswerRuleModelListToChatAgentAnswerRuleDtoList(rules []*models.ChatAgentAnswerRule) []dto.ChatAgentAnswerRuleDto {
+	dtos := make([]dto.ChatAgentAnswerRuleDto, 0, len(rules))
+	for _, model := range rules {
+		dtos = append(dtos, ChatAgentAnswerRuleModelToChatAgentAnswerRuleDto(model))
+	}
+	return dtos
+}
+
+// SaveChatAgentAnswerRuleRequestToChatAgentAnswerRuleModel 将保存请求转换为模型
+// 参数：request - 保存请求
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveChatAgentAnswerRuleRequestToChatAgentAnswerRuleModel(request *dto.SaveChatAgentAnswerRuleRequest) (*models.ChatAgentAnswerRule, error) {
+	rule := &models.ChatAgentAnswerRule{
+		Name:      request.Name,
+		MatchType: request.MatchType,
+		Pattern:   request.Pattern,
+		Answer:    request.Answer,
+		Priority:  request.Priority,
+		Enabled:   request.Enabled,
+	}
+	fields := &uuidFields{}
+	rule.ID = fields.parseOptional("id", request.ID)
+	rule.ChatAgentID = fields.parse("chat_agent_id", request.ChatAgentID)
+	rule.EmbeddingModelID = fields.parse("embedding_model_id", request.EmbeddingModelID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	if request.EmbeddingThreshold != nil {
+		rule.EmbeddingThreshold = *request.EmbeddingThreshold
+	}
+	return rule, nil
+}
@@ -3,8 +3,10 @@
 package converter
 
 import (
+	"encoding/json"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/service"
 
 	"github.com/google/uuid"
 )
@@ -31,8 +33,35 @@ func ChatAgentModelToChatAgentDto(model *models.ChatAgent) dto.ChatAgentDto {
 		EnableMaxOutputTokenCountLimit: model.EnableMaxOutputTokenCountLimit,
 		MaxOutputTokenCountLimit:       model.MaxOutputTokenCountLimit,
 		DefaultStreamable:              model.DefaultStreamable,
-		CreatedAt:                      model.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:                      model.UpdatedAt.Format("2006-01-02 15:04:05"),
+		MaintenanceMode:                model.MaintenanceMode,
+		MaintenanceAnswer:              model.MaintenanceAnswer,
+		EnableAvailabilitySchedule:     model.EnableAvailabilitySchedule,
+		AvailabilitySchedule:           chatAgentAvailabilityScheduleToDto(model.AvailabilitySchedule),
+		UnavailableAnswer:              model.UnavailableAnswer,
+		WelcomeMessage:                 model.WelcomeMessage,
+		SuggestedQuestions:             chatAgentSuggestedQuestionsToList(model.SuggestedQuestions),
+		EnableFollowUpSuggestions:      model.EnableFollowUpSuggestions,
+		EnableTranslation:              model.EnableTranslation,
+		TranslationModelID:             chatAgentOptionalModelIDToString(model.TranslationModelID),
+		ReplyLanguage:                  model.ReplyLanguage,
+		InputPreprocessConfig:          chatAgentInputPreprocessConfigToDto(model.InputPreprocessConfig),
+		KnowledgeBaseIDs:               chatAgentKnowledgeBaseIDsToList(model.KnowledgeBaseIDs),
+		KnowledgeRetrievalTopK:         model.KnowledgeRetrievalTopK,
+		KnowledgeRetrievalMinScore:     model.KnowledgeRetrievalMinScore,
+		KnowledgeRetrievalMode:         model.KnowledgeRetrievalMode,
+		KnowledgeRerankModelID:         chatAgentOptionalModelIDToString(model.KnowledgeRerankModelID),
+		KnowledgeRerankTopK:            model.KnowledgeRerankTopK,
+		ToolOutputMaxLength:            model.ToolOutputMaxLength,
+		ToolOutputOverflowMode:         model.ToolOutputOverflowMode,
+		EnableConversationVariables:    model.EnableConversationVariables,
+		InjectedMessageRole:            model.InjectedMessageRole,
+		AllowedOrigins:                 chatAgentAllowedOriginsToList(model.AllowedOrigins),
+		MaxUserMessageLength:           model.MaxUserMessageLength,
+		MaxAnswerDurationSeconds:       model.MaxAnswerDurationSeconds,
+		MaxAnswerOutputTokens:          model.MaxAnswerOutputTokens,
+		Version:                        model.Version,
+		CreatedAt:                      timeToMilli(model.CreatedAt),
+		UpdatedAt:                      timeToMilli(model.UpdatedAt),
 	}
 }
 
@@ -51,8 +80,8 @@ func ChatAgentModelListToChatAgentDtoList(models []*models.ChatAgent) []dto.Chat
 // SaveChatAgentRequestToChatAgentModel 将保存请求转换为模型
 // 将前端保存请求转换为数据库模型
 // 参数：request - 保存请求
-// 返回：数据库模型
-func SaveChatAgentRequestToChatAgentModel(request *dto.SaveChatAgentRequest) *models.ChatAgent {
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveChatAgentRequestToChatAgentModel(request *dto.SaveChatAgentRequest) (*models.ChatAgent, error) {
 	model := &models.ChatAgent{
 		Name:                           request.Name,
 		Description:                    request.Description,
@@ -66,29 +95,226 @@ func SaveChatAgentRequestToChatAgentModel(request *dto.SaveChatAgentRequest) *mo
 		EnableMaxOutputTokenCountLimit: request.EnableMaxOutputTokenCountLimit,
 		MaxOutputTokenCountLimit:       request.MaxOutputTokenCountLimit,
 		DefaultStreamable:              request.DefaultStreamable,
+		MaintenanceMode:                request.MaintenanceMode,
+		MaintenanceAnswer:              request.MaintenanceAnswer,
+		EnableAvailabilitySchedule:     request.EnableAvailabilitySchedule,
+		UnavailableAnswer:              request.UnavailableAnswer,
+		WelcomeMessage:                 request.WelcomeMessage,
+		EnableFollowUpSuggestions:      request.EnableFollowUpSuggestions,
+		EnableTranslation:              request.EnableTranslation,
+		ReplyLanguage:                  request.ReplyLanguage,
+		KnowledgeRetrievalTopK:         request.KnowledgeRetrievalTopK,
+		KnowledgeRetrievalMinScore:     request.KnowledgeRetrievalMinScore,
+		KnowledgeRetrievalMode:         request.KnowledgeRetrievalMode,
+		KnowledgeRerankTopK:            request.KnowledgeRerankTopK,
+		ToolOutputMaxLength:            request.ToolOutputMaxLength,
+		ToolOutputOverflowMode:         request.ToolOutputOverflowMode,
+		MaxUserMessageLength:           request.MaxUserMessageLength,
+		MaxAnswerDurationSeconds:       request.MaxAnswerDurationSeconds,
+		MaxAnswerOutputTokens:          request.MaxAnswerOutputTokens,
+		EnableConversationVariables:    request.EnableConversationVariables,
+		InjectedMessageRole:            request.InjectedMessageRole,
+		Version:                        request.Version,
 	}
 
-	// 解析应用ID
-	if applicationID, err := uuid.Parse(request.ApplicationID); err == nil {
-		model.ApplicationID = applicationID
+	// 序列化服务时间配置
+	if request.AvailabilitySchedule != nil {
+		if schedule, err := json.Marshal(request.AvailabilitySchedule); err == nil {
+			model.AvailabilitySchedule = string(schedule)
+		}
+	}
+
+	// 序列化推荐问题列表
+	if len(request.SuggestedQuestions) > 0 {
+		if suggestedQuestions, err := json.Marshal(request.SuggestedQuestions); err == nil {
+			model.SuggestedQuestions = string(suggestedQuestions)
+		}
 	}
 
-	// 解析聊天模型ID
-	if chatModelID, err := uuid.Parse(request.ChatModelID); err == nil {
-		model.ChatModelID = chatModelID
+	// 序列化用户输入预处理配置
+	if request.InputPreprocessConfig != nil {
+		if preprocessConfig, err := json.Marshal(request.InputPreprocessConfig); err == nil {
+			model.InputPreprocessConfig = string(preprocessConfig)
+		}
 	}
 
-	// 解析会话命名模型ID
-	if conversationNamingModelID, err := uuid.Parse(request.ConversationNamingModelID); err == nil {
-		model.ConversationNamingModelID = conversationNamingModelID
+	// 序列化绑定的知识库ID列表
+	if len(request.KnowledgeBaseIDs) > 0 {
+		if knowledgeBaseIDs, err := json.Marshal(request.KnowledgeBaseIDs); err == nil {
+			model.KnowledgeBaseIDs = string(knowledgeBaseIDs)
+		}
 	}
 
-	// 如果有ID，则解析ID（用于更新操作）
-	if request.ID != nil && *request.ID != "" {
-		if id, err := uuid.Parse(*request.ID); err == nil {
-			model.ID = id
+	// 序列化允许嵌入聊天窗口的来源列表
+	if len(request.AllowedOrigins) > 0 {
+		if allowedOrigins, err := json.Marshal(request.AllowedOrigins); err == nil {
+			model.AllowedOrigins = string(allowedOrigins)
 		}
 	}
 
-	return model
+	// 解析应用ID和各模型ID，如果有ID则一并解析（用于更新操作）
+	fields := &uuidFields{}
+	model.ApplicationID = fields.parse("application_id", request.ApplicationID)
+	model.ChatModelID = fields.parse("chat_model_id", request.ChatModelID)
+	model.ConversationNamingModelID = fields.parse("conversation_naming_model_id", request.ConversationNamingModelID)
+	model.TranslationModelID = fields.parse("translation_model_id", request.TranslationModelID)
+	model.KnowledgeRerankModelID = fields.parse("knowledge_rerank_model_id", request.KnowledgeRerankModelID)
+	model.ID = fields.parseOptional("id", request.ID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+
+	return model, nil
+}
+
+// SaveChatAgentRequestToUpdateFields 获取保存请求中部分更新的模型字段名
+// 版本号用于乐观锁校验，不能作为部分更新的字段
+// 参数：request - 保存请求
+// 返回：模型字段名列表，未指定部分更新时为空；包含未知字段时返回参数错误
+func SaveChatAgentRequestToUpdateFields(request *dto.SaveChatAgentRequest) ([]string, error) {
+	return updateFieldNames(request, request.UpdateFields, "id", "version")
+}
+
+// chatAgentAvailabilityScheduleToDto 解析服务时间配置，未配置或内容无效时返回空
+func chatAgentAvailabilityScheduleToDto(schedule string) *dto.ChatAgentAvailabilityScheduleDto {
+	if schedule == "" {
+		return nil
+	}
+	var result dto.ChatAgentAvailabilityScheduleDto
+	if err := json.Unmarshal([]byte(schedule), &result); err != nil {
+		return nil
+	}
+	return &result
+}
+
+// chatAgentInputPreprocessConfigToDto 解析用户输入预处理配置，未配置或内容无效时返回空
+func chatAgentInputPreprocessConfigToDto(preprocessConfig string) *dto.ChatAgentInputPreprocessConfigDto {
+	if preprocessConfig == "" {
+		return nil
+	}
+	var result dto.ChatAgentInputPreprocessConfigDto
+	if err := json.Unmarshal([]byte(preprocessConfig), &result); err != nil {
+		return nil
+	}
+	return &result
+}
+
+// chatAgentOptionalModelIDToString 转换可选的模型ID，未配置时返回空字符串
+func chatAgentOptionalModelIDToString(modelID uuid.UUID) string {
+	if modelID == uuid.Nil {
+		return ""
+	}
+	return modelID.String()
+}
+
+// chatAgentSuggestedQuestionsToList 解析推荐问题列表，未配置或内容无效时返回空列表
+func chatAgentSuggestedQuestionsToList(suggestedQuestions string) []string {
+	questions := []string{}
+	if suggestedQuestions == "" {
+		return questions
+	}
+	if err := json.Unmarshal([]byte(suggestedQuestions), &questions); err != nil {
+		return []string{}
+	}
+	return questions
+}
+
+// chatAgentKnowledgeBaseIDsToList 解析绑定的知识库ID列表，未配置或内容无效时返回空列表
+func chatAgentKnowledgeBaseIDsToList(knowledgeBaseIDs string) []string {
+	ids := []string{}
+	if knowledgeBaseIDs == "" {
+		return ids
+	}
+	if err := json.Unmarshal([]byte(knowledgeBaseIDs), &ids); err != nil {
+		return []string{}
+	}
+	return ids
+}
+
+// ChatAgentToolUsageListToDtoList 将工具使用统计列表转换为DTO列表
+// 参数：usages - 工具使用统计列表
+// 返回：DTO列表
+func ChatAgentToolUsageListToDtoList(usages []*service.ChatAgentToolUsage) []dto.ChatAgentToolUsageDto {
+	dtoList := make([]dto.ChatAgentToolUsageDto, len(usages))
+	for i, usage := range usages {
+		dtoList[i] = dto.ChatAgentToolUsageDto{
+			ToolType:      usage.ToolType,
+			ToolName:      usage.ToolName,
+			FunctionName:  usage.FunctionName,
+			Enabled:       usage.Enabled,
+			CallCount:     usage.CallCount,
+			SuccessCount:  usage.SuccessCount,
+			SuccessRate:   usage.SuccessRate,
+			AvgDurationMs: usage.AvgDurationMs,
+		}
+		if usage.McpServerToolID != uuid.Nil {
+			dtoList[i].McpServerConfigID = usage.McpServerConfigID.String()
+			dtoList[i].McpServerToolID = usage.McpServerToolID.String()
+		}
+	}
+	return dtoList
+}
+
+// chatAgentAllowedOriginsToList 解析允许嵌入聊天窗口的来源列表，未配置或内容无效时返回空列表
+func chatAgentAllowedOriginsToList(allowedOrigins string) []string {
+	origins := []string{}
+	if allowedOrigins == "" {
+		return origins
+	}
+	if err := json.Unmarshal([]byte(allowedOrigins), &origins); err != nil {
+		return []string{}
+	}
+	return origins
+}
+
+// ChatAgentApiKeyModelListToDtoList 将 API Key 列表转换为DTO列表，不包含 Key 和 Secret
+func ChatAgentApiKeyModelListToDtoList(apiKeys []*models.ChatAgentApiKey) []dto.ChatAgentApiKeyDto {
+	dtoList := make([]dto.ChatAgentApiKeyDto, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		dtoList[i] = ChatAgentApiKeyModelToDto(apiKey)
+	}
+	return dtoList
+}
+
+// ChatAgentApiKeyModelToDto 将 API Key 转换为DTO，不包含 Key 和 Secret
+func ChatAgentApiKeyModelToDto(apiKey *models.ChatAgentApiKey) dto.ChatAgentApiKeyDto {
+	return dto.ChatAgentApiKeyDto{
+		ID:           apiKey.ID.String(),
+		Name:         apiKey.Name,
+		Description:  apiKey.Description,
+		ChatAgentID:  apiKey.ChatAgentID.String(),
+		AllowedCidrs: chatAgentApiKeyCidrsToList(apiKey.AllowedCidrs),
+		DeniedCidrs:  chatAgentApiKeyCidrsToList(apiKey.DeniedCidrs),
+		CreatedAt:    timeToMilli(apiKey.CreatedAt),
+		UpdatedAt:    timeToMilli(apiKey.UpdatedAt),
+	}
+}
+
+// chatAgentApiKeyCidrsToList 解析 API Key 的网段列表，未配置或内容无效时返回空列表
+func chatAgentApiKeyCidrsToList(cidrs string) []string {
+	list := []string{}
+	if cidrs == "" {
+		return list
+	}
+	if err := json.Unmarshal([]byte(cidrs), &list); err != nil {
+		return []string{}
+	}
+	return list
+}
+
+// ChatAgentApiKeyRejectionListToDtoList 将 API Key 访问拒绝记录列表转换为DTO列表
+func ChatAgentApiKeyRejectionListToDtoList(rejections []*models.ChatAgentApiKeyRejection) []dto.ChatAgentApiKeyRejectionDto {
+	dtoList := make([]dto.ChatAgentApiKeyRejectionDto, len(rejections))
+	for i, rejection := range rejections {
+		dtoList[i] = dto.ChatAgentApiKeyRejectionDto{
+			ID:        rejection.ID.String(),
+			ApiKeyID:  rejection.ApiKeyID.String(),
+			ClientIP:  rejection.ClientIP,
+			Reason:    rejection.Reason,
+			Path:      rejection.Path,
+			UserAgent: rejection.UserAgent,
+			CreatedAt: timeToMilli(rejection.CreatedAt),
+		}
+	}
+	return dtoList
 }
@@ -0,0 +1,45 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// ConversationArchiveModelToDto 将会话归档记录模型转换为DTO
+// 参数：archive - 数据库模型
+// 返回：DTO对象
+func ConversationArchiveModelToDto(archive *models.ConversationArchive) dto.ConversationArchiveDto {
+	return dto.ConversationArchiveDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        archive.ID,
+			CreatedAt: timeToMilli(archive.CreatedAt),
+			UpdatedAt: timeToMilli(archive.UpdatedAt),
+		},
+		ApplicationID:         archive.ApplicationID.String(),
+		ChatAgentID:           archive.ChatAgentID.String(),
+		ServiceUserID:         archive.ServiceUserID,
+		ConversationID:        archive.ConversationID.String(),
+		Title:                 archive.Title,
+		MessageCount:          archive.MessageCount,
+		ConversationCreatedAt: timeToMilli(archive.ConversationCreatedAt),
+		LastMessageAt:         optionalTimeToMilli(archive.LastMessageAt),
+		StorageType:           archive.StorageType,
+		ObjectKey:             archive.ObjectKey,
+		Status:                archive.Status,
+		ArchivedAt:            timeToMilli(archive.ArchivedAt),
+		RestoredAt:            optionalTimeToMilli(archive.RestoredAt),
+	}
+}
+
+// ConversationArchiveListToDtoList 将会话归档记录模型列表转换为DTO列表
+// 参数：archives - 数据库模型列表
+// 返回：DTO列表
+func ConversationArchiveListToDtoList(archives []*models.ConversationArchive) []dto.ConversationArchiveDto {
+	dtos := make([]dto.ConversationArchiveDto, 0, len(archives))
+	for _, archive := range archives {
+		dtos = append(dtos, ConversationArchiveModelToDto(archive))
+	}
+	return dtos
+}
@@ -0,0 +1,31 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// ConversationVariableModelToDto 将会话变量模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func ConversationVariableModelToDto(model *models.ChatAgentConversationVariable) dto.ConversationVariableDto {
+	return dto.ConversationVariableDto{
+		Name:      model.Name,
+		Value:     model.Value,
+		Source:    model.Source,
+		UpdatedAt: timeToMilli(model.UpdatedAt),
+	}
+}
+
+// ConversationVariableModelListToDtoList 将会话变量模型列表转换为DTO列表
+// 参数：variables - 数据库模型列表
+// 返回：DTO列表
+func ConversationVariableModelListToDtoList(variables []*models.ChatAgentConversationVariable) []dto.ConversationVariableDto {
+	result := make([]dto.ConversationVariableDto, 0, len(variables))
+	for _, variable := range variables {
+		result = append(result, ConversationVariableModelToDto(variable))
+	}
+	return result
+}
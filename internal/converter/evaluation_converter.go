@@ -0,0 +1,256 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"encoding/json"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/service"
+
+	"github.com/google/uuid"
+)
+
+// EvaluationSetModelToEvaluationSetDto 将评测集模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func EvaluationSetModelToEvaluationSetDto(model *models.EvaluationSet) dto.EvaluationSetDto {
+	return dto.EvaluationSetDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID: model.ApplicationID.String(),
+		ChatAgentID:   model.ChatAgentID.String(),
+		Name:          model.Name,
+		Description:   model.Description,
+	}
+}
+
+// EvaluationSetModelListToEvaluationSetDtoList 将评测集模型列表转换为DTO列表
+// 参数：sets - 数据库模型列表
+// 返回：DTO列表
+func EvaluationSetModelListToEvaluationSetDtoList(sets []*models.EvaluationSet) []dto.EvaluationSetDto {
+	dtos := make([]dto.EvaluationSetDto, 0, len(sets))
+	for _, model := range sets {
+		dtos = append(dtos, EvaluationSetModelToEvaluationSetDto(model))
+	}
+	return dtos
+}
+
+// SaveEvaluationSetRequestToEvaluationSetModel 将保存请求转换为模型
+// 参数：request - 保存请求
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveEvaluationSetRequestToEvaluationSetModel(request *dto.SaveEvaluationSetRequest) (*models.EvaluationSet, error) {
+	set := &models.EvaluationSet{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	fields := &uuidFields{}
+	set.ID = fields.parseOptional("id", request.ID)
+	set.ChatAgentID = fields.parse("chat_agent_id", request.ChatAgentID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return set, nil
+}
+
+// EvaluationCaseModelToEvaluationCaseDto 将评测用例模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func EvaluationCaseModelToEvaluationCaseDto(model *models.EvaluationCase) dto.EvaluationCaseDto {
+	assertions := []dto.EvaluationAssertionDto{}
+	if model.Assertions != "" {
+		json.Unmarshal([]byte(model.Assertions), &assertions)
+	}
+	return dto.EvaluationCaseDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		SetID:          model.SetID.String(),
+		Name:           model.Name,
+		Input:          model.Input,
+		ExpectedTraits: model.ExpectedTraits,
+		Assertions:     assertions,
+		Enabled:        model.Enabled,
+	}
+}
+
+// EvaluationCaseModelListToEvaluationCaseDtoList 将评测用例模型列表转换为DTO列表
+// 参数：cases - 数据库模型列表
+// 返回：DTO列表
+func EvaluationCaseModelListToEvaluationCaseDtoList(cases []*models.EvaluationCase) []dto.EvaluationCaseDto {
+	dtos := make([]dto.EvaluationCaseDto, 0, len(cases))
+	for _, model := range cases {
+		dtos = append(dtos, EvaluationCaseModelToEvaluationCaseDto(model))
+	}
+	return dtos
+}
+
+// SaveEvaluationCaseRequestToEvaluationCaseModel 将保存请求转换为模型
+// 未指定是否参与评测时默认参与
+// 参数：request - 保存请求
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveEvaluationCaseRequestToEvaluationCaseModel(request *dto.SaveEvaluationCaseRequest) (*models.EvaluationCase, error) {
+	evaluationCase := &models.EvaluationCase{
+		Name:           request.Name,
+		Input:          request.Input,
+		ExpectedTraits: request.ExpectedTraits,
+		Enabled:        true,
+	}
+	fields := &uuidFields{}
+	evaluationCase.ID = fields.parseOptional("id", request.ID)
+	evaluationCase.SetID = fields.parse("set_id", request.SetID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	if len(request.Assertions) > 0 {
+		if assertions, err := json.Marshal(request.Assertions); err == nil {
+			evaluationCase.Assertions = string(assertions)
+		}
+	}
+	if request.Enabled != nil {
+		evaluationCase.Enabled = *request.Enabled
+	}
+	return evaluationCase, nil
+}
+
+// EvaluationRunModelToEvaluationRunDto 将评测运行模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func EvaluationRunModelToEvaluationRunDto(model *models.EvaluationRun) dto.EvaluationRunDto {
+	runDto := dto.EvaluationRunDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID: model.ApplicationID.String(),
+		SetID:         model.SetID.String(),
+		ChatAgentID:   model.ChatAgentID.String(),
+		PromptVersion: model.PromptVersion,
+		SystemPrompt:  model.SystemPrompt,
+		Status:        model.Status,
+		TotalCount:    model.TotalCount,
+		PassedCount:   model.PassedCount,
+		FailedCount:   model.FailedCount,
+		AverageScore:  model.AverageScore,
+		ErrorMessage:  model.ErrorMessage,
+		StartedAt:     optionalTimeToMilli(model.StartedAt),
+		FinishedAt:    optionalTimeToMilli(model.FinishedAt),
+	}
+	if model.JudgeModelID != uuid.Nil {
+		runDto.JudgeModelID = model.JudgeModelID.String()
+	}
+	if model.TotalCount > 0 {
+		runDto.PassRate = float64(model.PassedCount) / float64(model.TotalCount)
+	}
+	return runDto
+}
+
+// EvaluationRunModelListToEvaluationRunDtoList 将评测运行模型列表转换为DTO列表
+// 参数：runs - 数据库模型列表
+// 返回：DTO列表
+func EvaluationRunModelListToEvaluationRunDtoList(runs []*models.EvaluationRun) []dto.EvaluationRunDto {
+	dtos := make([]dto.EvaluationRunDto, 0, len(runs))
+	for _, model := range runs {
+		dtos = append(dtos, EvaluationRunModelToEvaluationRunDto(model))
+	}
+	return dtos
+}
+
+// CreateEvaluationRunRequestToEvaluationRunModel 将创建请求转换为模型
+// 参数：request - 创建请求
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func CreateEvaluationRunRequestToEvaluationRunModel(request *dto.CreateEvaluationRunRequest) (*models.EvaluationRun, error) {
+	run := &models.EvaluationRun{
+		PromptVersion: request.PromptVersion,
+		SystemPrompt:  request.SystemPrompt,
+	}
+	fields := &uuidFields{}
+	run.SetID = fields.parse("set_id", request.SetID)
+	run.ChatAgentID = fields.parseOptional("chat_agent_id", request.ChatAgentID)
+	run.JudgeModelID = fields.parseOptional("judge_model_id", request.JudgeModelID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return run, nil
+}
+
+// EvaluationResultModelListToEvaluationResultDtoList 将评测结果模型列表转换为DTO列表
+// 参数：results - 数据库模型列表
+// 返回：DTO列表
+func EvaluationResultModelListToEvaluationResultDtoList(results []*models.EvaluationResult) []dto.EvaluationResultDto {
+	dtos := make([]dto.EvaluationResultDto, 0, len(results))
+	for _, model := range results {
+		assertionResults := []dto.EvaluationAssertionResultDto{}
+		if model.AssertionResults != "" {
+			json.Unmarshal([]byte(model.AssertionResults), &assertionResults)
+		}
+		dtos = append(dtos, dto.EvaluationResultDto{
+			BaseModelDto: dto.BaseModelDto{
+				ID:        model.ID,
+				CreatedAt: timeToMilli(model.CreatedAt),
+				UpdatedAt: timeToMilli(model.UpdatedAt),
+			},
+			RunID:            model.RunID.String(),
+			CaseID:           model.CaseID.String(),
+			CaseIndex:        model.CaseIndex,
+			CaseName:         model.CaseName,
+			Input:            model.Input,
+			ExpectedTraits:   model.ExpectedTraits,
+			Status:           model.Status,
+			Answer:           model.Answer,
+			ConversationID:   model.ConversationID,
+			Score:            model.Score,
+			AssertionResults: assertionResults,
+			JudgeReason:      model.JudgeReason,
+			ErrorMessage:     model.ErrorMessage,
+			DurationMs:       model.DurationMs,
+		})
+	}
+	return dtos
+}
+
+// EvaluationRunComparisonToEvaluationRunComparisonResponse 将评测运行对比转换为响应
+// 参数：comparison - 业务逻辑层返回的对比结果
+// 返回：对比响应
+func EvaluationRunComparisonToEvaluationRunComparisonResponse(comparison *service.EvaluationRunComparison) dto.EvaluationRunComparisonResponse {
+	baseRun := EvaluationRunModelToEvaluationRunDto(comparison.BaseRun)
+	targetRun := EvaluationRunModelToEvaluationRunDto(comparison.TargetRun)
+	response := dto.EvaluationRunComparisonResponse{
+		BaseRun:           baseRun,
+		TargetRun:         targetRun,
+		PassRateDelta:     targetRun.PassRate - baseRun.PassRate,
+		AverageScoreDelta: targetRun.AverageScore - baseRun.AverageScore,
+		Cases:             make([]dto.EvaluationCaseComparisonDto, 0, len(comparison.Cases)),
+	}
+	for _, caseComparison := range comparison.Cases {
+		caseDto := dto.EvaluationCaseComparisonDto{
+			CaseID:   caseComparison.CaseID.String(),
+			CaseName: caseComparison.CaseName,
+			Input:    caseComparison.Input,
+			Change:   caseComparison.Change,
+		}
+		if base := caseComparison.Base; base != nil {
+			caseDto.BaseStatus = base.Status
+			caseDto.BaseScore = &base.Score
+		}
+		if target := caseComparison.Target; target != nil {
+			caseDto.TargetStatus = target.Status
+			caseDto.TargetScore = &target.Score
+		}
+		switch caseComparison.Change {
+		case define.EvaluationCaseChangeImproved:
+			response.ImprovedCount++
+		case define.EvaluationCaseChangeRegressed:
+			response.RegressedCount++
+		}
+		response.Cases = append(response.Cases, caseDto)
+	}
+	return response
+}
@@ -0,0 +1,90 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// KnowledgeBaseModelToKnowledgeBaseDto 将知识库模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func KnowledgeBaseModelToKnowledgeBaseDto(model *models.KnowledgeBase) dto.KnowledgeBaseDto {
+	return dto.KnowledgeBaseDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID:    model.ApplicationID.String(),
+		Name:             model.Name,
+		Description:      model.Description,
+		EmbeddingModelID: model.EmbeddingModelID.String(),
+		ChunkSize:        model.ChunkSize,
+		ChunkOverlap:     model.ChunkOverlap,
+	}
+}
+
+// KnowledgeBaseModelListToKnowledgeBaseDtoList 将知识库模型列表转换为DTO列表
+// 参数：knowledgeBases - 数据库模型列表
+// 返回：DTO列表
+func KnowledgeBaseModelListToKnowledgeBaseDtoList(knowledgeBases []*models.KnowledgeBase) []dto.KnowledgeBaseDto {
+	dtos := make([]dto.KnowledgeBaseDto, 0, len(knowledgeBases))
+	for _, model := range knowledgeBases {
+		dtos = append(dtos, KnowledgeBaseModelToKnowledgeBaseDto(model))
+	}
+	return dtos
+}
+
+// SaveKnowledgeBaseRequestToKnowledgeBaseModel 将保存请求转换为模型
+// 参数：request - 保存请求
+// 返回：数据库模型，ID 字段不是有效的UUID时返回参数错误
+func SaveKnowledgeBaseRequestToKnowledgeBaseModel(request *dto.SaveKnowledgeBaseRequest) (*models.KnowledgeBase, error) {
+	knowledgeBase := &models.KnowledgeBase{
+		Name:         request.Name,
+		Description:  request.Description,
+		ChunkSize:    request.ChunkSize,
+		ChunkOverlap: request.ChunkOverlap,
+	}
+	fields := &uuidFields{}
+	knowledgeBase.ID = fields.parseOptional("id", request.ID)
+	knowledgeBase.ApplicationID = fields.parse("application_id", request.ApplicationID)
+	knowledgeBase.EmbeddingModelID = fields.parse("embedding_model_id", request.EmbeddingModelID)
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return knowledgeBase, nil
+}
+
+// KnowledgeDocumentModelToKnowledgeDocumentDto 将知识库文档模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func KnowledgeDocumentModelToKnowledgeDocumentDto(model *models.KnowledgeDocument) dto.KnowledgeDocumentDto {
+	return dto.KnowledgeDocumentDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID:   model.ApplicationID.String(),
+		KnowledgeBaseID: model.KnowledgeBaseID.String(),
+		Name:            model.Name,
+		SourceType:      model.SourceType,
+		ContentHash:     model.ContentHash,
+		Status:          model.Status,
+		ErrorMessage:    model.ErrorMessage,
+		ChunkCount:      model.ChunkCount,
+	}
+}
+
+// KnowledgeDocumentModelListToKnowledgeDocumentDtoList 将知识库文档模型列表转换为DTO列表
+// 参数：documents - 数据库模型列表
+// 返回：DTO列表
+func KnowledgeDocumentModelListToKnowledgeDocumentDtoList(documents []*models.KnowledgeDocument) []dto.KnowledgeDocumentDto {
+	dtos := make([]dto.KnowledgeDocumentDto, 0, len(documents))
+	for _, model := range documents {
+		dtos = append(dtos, KnowledgeDocumentModelToKnowledgeDocumentDto(model))
+	}
+	return dtos
+}
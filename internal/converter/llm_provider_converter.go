@@ -18,44 +18,46 @@ func LlmProviderModelToLlmProviderDto(llmProvider *models.ApplicationLlmProvider
 	}
 
 	return &dto.LlmProviderDto{
-		ID:            llmProvider.ID.String(),
-		Name:          llmProvider.Name,
-		Description:   llmProvider.Description,
-		Type:          llmProvider.Type,
-		IconUrl:       llmProvider.IconUrl,
-		ApplicationID: llmProvider.ApplicationID.String(),
-		ApiUrl:        llmProvider.ApiUrl,
-		ApiKey:        llmProvider.ApiKey,
-		CreatedAt:     llmProvider.CreatedAt,
-		UpdatedAt:     llmProvider.UpdatedAt,
+		ID:             llmProvider.ID.String(),
+		Name:           llmProvider.Name,
+		Description:    llmProvider.Description,
+		Type:           llmProvider.Type,
+		IconUrl:        llmProvider.IconUrl,
+		ApplicationID:  llmProvider.ApplicationID.String(),
+		ApiUrl:         llmProvider.ApiUrl,
+		ApiKey:         llmProvider.ApiKey,
+		MaxConcurrency: llmProvider.MaxConcurrency,
+		Version:        llmProvider.Version,
+		CreatedAt:      timeToMilli(llmProvider.CreatedAt),
+		UpdatedAt:      timeToMilli(llmProvider.UpdatedAt),
 	}
 }
 
 // LlmProviderDtoToLlmProviderModel 将 LlmProviderDto 转换为 ApplicationLlmProvider 模型
 // 参数：llmProviderDto - 大语言模型提供商DTO
-// 返回：大语言模型提供商模型
-func LlmProviderDtoToLlmProviderModel(llmProviderDto *dto.LlmProviderDto) *models.ApplicationLlmProvider {
+// 返回：大语言模型提供商模型，应用ID不是有效的UUID时返回参数错误
+func LlmProviderDtoToLlmProviderModel(llmProviderDto *dto.LlmProviderDto) (*models.ApplicationLlmProvider, error) {
 	if llmProviderDto == nil {
-		return nil
+		return nil, nil
 	}
 
 	// 解析UUID字符串
-	var applicationID uuid.UUID
-	if llmProviderDto.ApplicationID != "" {
-		if parsedID, err := uuid.Parse(llmProviderDto.ApplicationID); err == nil {
-			applicationID = parsedID
-		}
+	fields := &uuidFields{}
+	applicationID := fields.parse("application_id", llmProviderDto.ApplicationID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
 	return &models.ApplicationLlmProvider{
-		Name:          llmProviderDto.Name,
-		Description:   llmProviderDto.Description,
-		Type:          llmProviderDto.Type,
-		IconUrl:       llmProviderDto.IconUrl,
-		ApplicationID: applicationID,
-		ApiUrl:        llmProviderDto.ApiUrl,
-		ApiKey:        llmProviderDto.ApiKey,
-	}
+		Name:           llmProviderDto.Name,
+		Description:    llmProviderDto.Description,
+		Type:           llmProviderDto.Type,
+		IconUrl:        llmProviderDto.IconUrl,
+		ApplicationID:  applicationID,
+		ApiUrl:         llmProviderDto.ApiUrl,
+		ApiKey:         llmProviderDto.ApiKey,
+		MaxConcurrency: llmProviderDto.MaxConcurrency,
+	}, nil
 }
 
 // LlmProviderModelListToLlmProviderDtoList 将 ApplicationLlmProvider 模型列表转换为 LlmProviderDto 列表
@@ -75,50 +77,49 @@ func LlmProviderModelListToLlmProviderDtoList(llmProviders []*models.Application
 
 // LlmProviderDtoListToLlmProviderModelList 将 LlmProviderDto 列表转换为 ApplicationLlmProvider 模型列表
 // 参数：llmProviderDtos - 大语言模型提供商DTO列表
-// 返回：大语言模型提供商模型列表
-func LlmProviderDtoListToLlmProviderModelList(llmProviderDtos []*dto.LlmProviderDto) []*models.ApplicationLlmProvider {
+// 返回：大语言模型提供商模型列表，任一元素转换失败时返回该错误
+func LlmProviderDtoListToLlmProviderModelList(llmProviderDtos []*dto.LlmProviderDto) ([]*models.ApplicationLlmProvider, error) {
 	if llmProviderDtos == nil {
-		return nil
+		return nil, nil
 	}
 
 	result := make([]*models.ApplicationLlmProvider, len(llmProviderDtos))
 	for i, llmProviderDto := range llmProviderDtos {
-		result[i] = LlmProviderDtoToLlmProviderModel(llmProviderDto)
+		llmProvider, err := LlmProviderDtoToLlmProviderModel(llmProviderDto)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = llmProvider
 	}
-	return result
+	return result, nil
 }
 
 // LlmProviderSaveDtoToLlmProviderModel 将 LlmProviderSaveDto 转换为 ApplicationLlmProvider 模型
 // 参数：llmProviderSaveDto - 大语言模型提供商保存DTO
-// 返回：大语言模型提供商模型
-func LlmProviderSaveDtoToLlmProviderModel(llmProviderSaveDto *dto.LlmProviderSaveDto) *models.ApplicationLlmProvider {
+// 返回：大语言模型提供商模型，ID 字段不是有效的UUID时返回参数错误
+func LlmProviderSaveDtoToLlmProviderModel(llmProviderSaveDto *dto.LlmProviderSaveDto) (*models.ApplicationLlmProvider, error) {
 	if llmProviderSaveDto == nil {
-		return nil
+		return nil, nil
 	}
 
 	// 解析UUID字符串
-	var id uuid.UUID
-	if llmProviderSaveDto.ID != "" {
-		if parsedID, err := uuid.Parse(llmProviderSaveDto.ID); err == nil {
-			id = parsedID
-		}
-	}
-
-	var applicationID uuid.UUID
-	if llmProviderSaveDto.ApplicationID != "" {
-		if parsedID, err := uuid.Parse(llmProviderSaveDto.ApplicationID); err == nil {
-			applicationID = parsedID
-		}
+	fields := &uuidFields{}
+	id := fields.parse("id", llmProviderSaveDto.ID)
+	applicationID := fields.parse("application_id", llmProviderSaveDto.ApplicationID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
 	llmProvider := &models.ApplicationLlmProvider{
-		Name:          llmProviderSaveDto.Name,
-		Description:   llmProviderSaveDto.Description,
-		Type:          llmProviderSaveDto.Type,
-		IconUrl:       llmProviderSaveDto.IconUrl,
-		ApplicationID: applicationID,
-		ApiUrl:        llmProviderSaveDto.ApiUrl,
-		ApiKey:        llmProviderSaveDto.ApiKey,
+		Name:           llmProviderSaveDto.Name,
+		Description:    llmProviderSaveDto.Description,
+		Type:           llmProviderSaveDto.Type,
+		IconUrl:        llmProviderSaveDto.IconUrl,
+		ApplicationID:  applicationID,
+		ApiUrl:         llmProviderSaveDto.ApiUrl,
+		ApiKey:         llmProviderSaveDto.ApiKey,
+		MaxConcurrency: llmProviderSaveDto.MaxConcurrency,
+		Version:        llmProviderSaveDto.Version,
 	}
 
 	// 设置ID字段（如果存在）
@@ -126,23 +127,22 @@ func LlmProviderSaveDtoToLlmProviderModel(llmProviderSaveDto *dto.LlmProviderSav
 		llmProvider.ID = id
 	}
 
-	return llmProvider
+	return llmProvider, nil
 }
 
 // LlmProviderQueryDtoToLlmProviderModel 将 LlmProviderQueryDto 转换为 ApplicationLlmProvider 模型
 // 参数：llmProviderQueryDto - 大语言模型提供商查询DTO
-// 返回：大语言模型提供商模型（用于查询）
-func LlmProviderQueryDtoToLlmProviderModel(llmProviderQueryDto *dto.LlmProviderQueryDto) *models.ApplicationLlmProvider {
+// 返回：大语言模型提供商模型（用于查询），应用ID不是有效的UUID时返回参数错误
+func LlmProviderQueryDtoToLlmProviderModel(llmProviderQueryDto *dto.LlmProviderQueryDto) (*models.ApplicationLlmProvider, error) {
 	if llmProviderQueryDto == nil {
-		return nil
+		return nil, nil
 	}
 
 	// 解析UUID字符串
-	var applicationID uuid.UUID
-	if llmProviderQueryDto.ApplicationID != "" {
-		if parsedID, err := uuid.Parse(llmProviderQueryDto.ApplicationID); err == nil {
-			applicationID = parsedID
-		}
+	fields := &uuidFields{}
+	applicationID := fields.parse("application_id", llmProviderQueryDto.ApplicationID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
 	return &models.ApplicationLlmProvider{
@@ -153,5 +153,13 @@ func LlmProviderQueryDtoToLlmProviderModel(llmProviderQueryDto *dto.LlmProviderQ
 		ApplicationID: applicationID,
 		ApiUrl:        llmProviderQueryDto.ApiUrl,
 		ApiKey:        llmProviderQueryDto.ApiKey,
-	}
+	}, nil
+}
+
+// LlmProviderSaveDtoToUpdateFields 获取保存请求中部分更新的模型字段名
+// 版本号用于乐观锁校验，不能作为部分更新的字段
+// 参数：llmProviderSaveDto - 大语言模型提供商保存DTO
+// 返回：模型字段名列表，未指定部分更新时为空；包含未知字段时返回参数错误
+func LlmProviderSaveDtoToUpdateFields(llmProviderSaveDto *dto.LlmProviderSaveDto) ([]string, error) {
+	return updateFieldNames(llmProviderSaveDto, llmProviderSaveDto.UpdateFields, "id", "version")
 }
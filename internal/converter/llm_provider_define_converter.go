@@ -0,0 +1,47 @@
+// Package converter 提供数据转换功能
+// 用于在不同层之间转换数据格式，如模型与DTO之间的转换
+package converter
+
+import (
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// LlmProviderDefineModelToDto 将 LlmProviderDefine 模型转换为 LlmProviderDefineDto
+// 参数：providerDefine - 供应商定义模型
+// 返回：供应商定义DTO
+func LlmProviderDefineModelToDto(providerDefine *models.LlmProviderDefine) dto.LlmProviderDefineDto {
+	return dto.LlmProviderDefineDto{
+		ID:            providerDefine.ID.String(),
+		Name:          providerDefine.Name,
+		Description:   providerDefine.Description,
+		Type:          providerDefine.Type,
+		IconUrl:       providerDefine.IconUrl,
+		DefaultApiUrl: providerDefine.DefaultApiUrl,
+	}
+}
+
+// LlmProviderDefineModelListToDtoList 将 LlmProviderDefine 模型列表转换为DTO列表
+// 参数：providerDefines - 供应商定义模型列表
+// 返回：供应商定义DTO列表
+func LlmProviderDefineModelListToDtoList(providerDefines []*models.LlmProviderDefine) []dto.LlmProviderDefineDto {
+	dtos := make([]dto.LlmProviderDefineDto, 0, len(providerDefines))
+	for _, providerDefine := range providerDefines {
+		dtos = append(dtos, LlmProviderDefineModelToDto(providerDefine))
+	}
+	return dtos
+}
+
+// LlmProviderDefinePresetToDto 将内置供应商定义转换为DTO
+// 参数：preset - 内置供应商定义
+// 返回：供应商定义DTO
+func LlmProviderDefinePresetToDto(preset define.LlmProviderDefinePreset) dto.LlmProviderDefineDto {
+	return dto.LlmProviderDefineDto{
+		Name:          preset.Name,
+		Description:   preset.Description,
+		Type:          preset.Type,
+		IconUrl:       preset.IconUrl,
+		DefaultApiUrl: preset.DefaultApiUrl,
+	}
+}
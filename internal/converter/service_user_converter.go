@@ -0,0 +1,64 @@
+// Package converter 提供数据转换功能
+// 负责在不同层之间转换数据格式，如模型到DTO的转换
+package converter
+
+import (
+	"encoding/json"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// ServiceUserModelToServiceUserDto 将业务侧用户模型转换为DTO
+// 参数：model - 数据库模型
+// 返回：DTO对象
+func ServiceUserModelToServiceUserDto(model *models.ServiceUser) dto.ServiceUserDto {
+	return dto.ServiceUserDto{
+		BaseModelDto: dto.BaseModelDto{
+			ID:        model.ID,
+			CreatedAt: timeToMilli(model.CreatedAt),
+			UpdatedAt: timeToMilli(model.UpdatedAt),
+		},
+		ApplicationID: model.ApplicationID.String(),
+		ServiceUserID: model.ServiceUserID,
+		DisplayName:   model.DisplayName,
+		AvatarUrl:     model.AvatarUrl,
+		Metadata:      serviceUserMetadataToMap(model.Metadata),
+	}
+}
+
+// ServiceUserModelListToServiceUserDtoList 将业务侧用户模型列表转换为DTO列表
+// 参数：serviceUsers - 数据库模型列表
+// 返回：DTO列表
+func ServiceUserModelListToServiceUserDtoList(serviceUsers []*models.ServiceUser) []dto.ServiceUserDto {
+	dtos := make([]dto.ServiceUserDto, 0, len(serviceUsers))
+	for _, model := range serviceUsers {
+		dtos = append(dtos, ServiceUserModelToServiceUserDto(model))
+	}
+	return dtos
+}
+
+// ServiceUserModelToServiceUserInfoDto 将业务侧用户模型转换为会话列表中附带的用户信息
+// 参数：model - 数据库模型，为空时返回空
+// 返回：用户信息DTO
+func ServiceUserModelToServiceUserInfoDto(model *models.ServiceUser) *dto.ServiceUserInfoDto {
+	if model == nil {
+		return nil
+	}
+	return &dto.ServiceUserInfoDto{
+		DisplayName: model.DisplayName,
+		AvatarUrl:   model.AvatarUrl,
+		Metadata:    serviceUserMetadataToMap(model.Metadata),
+	}
+}
+
+// serviceUserMetadataToMap 解析业务侧用户元数据，内容无效时返回空
+func serviceUserMetadataToMap(metadata string) map[string]interface{} {
+	if metadata == "" {
+		return nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &result); err != nil {
+		return nil
+	}
+	return result
+}
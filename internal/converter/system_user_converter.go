@@ -17,18 +17,12 @@ func SystemUserModelToSystemUserDto(user *models.SystemUser) *dto.SystemUserDto
 		return nil
 	}
 
-	var deletedAt *int64
-	if !user.DeletedAt.Time.IsZero() {
-		timestamp := user.DeletedAt.Time.UnixMilli()
-		deletedAt = &timestamp
-	}
-
 	return &dto.SystemUserDto{
 		BaseModelDto: dto.BaseModelDto{
 			ID:        user.ID,
-			CreatedAt: user.CreatedAt.UnixMilli(),
-			UpdatedAt: user.UpdatedAt.UnixMilli(),
-			DeletedAt: deletedAt,
+			CreatedAt: timeToMilli(user.CreatedAt),
+			UpdatedAt: timeToMilli(user.UpdatedAt),
+			DeletedAt: deletedAtToMilli(user.DeletedAt),
 		},
 		Name:   user.Name,
 		Number: user.Number,
@@ -53,10 +47,10 @@ func SystemUserModelListToSystemUserDtoList(users []*models.SystemUser) []*dto.S
 
 // SystemUserSaveDtoToSystemUserModel 将 SystemUserSaveDto 转换为 SystemUser 模型
 // 参数：userDto - 系统用户保存DTO
-// 返回：*SystemUser - 系统用户模型
-func SystemUserSaveDtoToSystemUserModel(userDto *dto.SystemUserSaveDto) *models.SystemUser {
+// 返回：*SystemUser - 系统用户模型，ID 不是有效的UUID时返回参数错误
+func SystemUserSaveDtoToSystemUserModel(userDto *dto.SystemUserSaveDto) (*models.SystemUser, error) {
 	if userDto == nil {
-		return nil
+		return nil, nil
 	}
 
 	user := &models.SystemUser{
@@ -67,11 +61,30 @@ func SystemUserSaveDtoToSystemUserModel(userDto *dto.SystemUserSaveDto) *models.
 	}
 
 	// 如果提供了ID，则解析UUID
-	if userDto.ID != "" {
-		if id, err := uuid.Parse(userDto.ID); err == nil {
-			user.ID = id
-		}
+	fields := &uuidFields{}
+	user.ID = fields.parse("id", userDto.ID)
+	if fields.err != nil {
+		return nil, fields.err
 	}
 
-	return user
+	return user, nil
+}
+
+// SystemUserSessionModelToSystemUserSessionDto 将 SystemUserSession 模型转换为 SystemUserSessionDto
+// 参数：session - 登录会话模型，currentID - 当前请求使用的会话ID
+// 返回：*SystemUserSessionDto - 登录会话DTO
+func SystemUserSessionModelToSystemUserSessionDto(session *models.SystemUserSession, currentID uuid.UUID) *dto.SystemUserSessionDto {
+	if session == nil {
+		return nil
+	}
+
+	return &dto.SystemUserSessionDto{
+		ID:             session.ID.String(),
+		CreatedAt:      timeToMilli(session.CreatedAt),
+		LoginExpiredAt: timeToMilli(session.LoginExpiredAt),
+		ExpiredAt:      optionalTimeToMilli(session.RefreshExpiredAt),
+		IPAddress:      session.IPAddress,
+		UserAgent:      session.UserAgent,
+		Current:        session.ID == currentID,
+	}
 }
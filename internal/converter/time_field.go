@@ -0,0 +1,33 @@
+// Package converter 提供模型与DTO之间的转换功能
+// 负责将内部模型转换为前端DTO，以及将DTO转换为内部模型
+package converter
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DTO 中的时间统一使用 Unix 13位毫秒时间戳（int64），可选时间使用 *int64，未设置时为空
+
+// timeToMilli 将时间转换为毫秒时间戳
+func timeToMilli(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// optionalTimeToMilli 将可选时间转换为毫秒时间戳，时间为空时返回空
+func optionalTimeToMilli(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	milli := t.UnixMilli()
+	return &milli
+}
+
+// deletedAtToMilli 将软删除时间转换为毫秒时间戳，未删除时返回空
+func deletedAtToMilli(deletedAt gorm.DeletedAt) *int64 {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return optionalTimeToMilli(&deletedAt.Time)
+}
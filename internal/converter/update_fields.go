@@ -0,0 +1,49 @@
+// Package converter 提供模型与DTO之间的转换功能
+// 负责将内部模型转换为前端DTO，以及将DTO转换为内部模型
+package converter
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"reflect"
+	"strings"
+)
+
+// updateFieldNames 将保存请求中部分更新的字段列表转换为模型的字段名
+// 字段列表使用请求中的 JSON 字段名，保存请求与模型中对应字段的字段名一致
+// 参数：request - 保存请求结构体指针，fields - 部分更新的字段列表，excluded - 不允许部分更新的 JSON 字段名
+// 返回：模型字段名列表（去重），列表为空时返回空；包含未知或不允许更新的字段时返回参数错误
+func updateFieldNames(request any, fields []string, excluded ...string) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	requestType := reflect.TypeOf(request).Elem()
+	fieldNames := make(map[string]string, requestType.NumField())
+	for i := 0; i < requestType.NumField(); i++ {
+		field := requestType.Field(i)
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "" || jsonName == "-" {
+			continue
+		}
+		fieldNames[jsonName] = field.Name
+	}
+	for _, name := range excluded {
+		delete(fieldNames, name)
+	}
+	delete(fieldNames, "update_fields")
+
+	result := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		name, ok := fieldNames[field]
+		if !ok {
+			return nil, apperror.Newf(apperror.CodeInvalidArgument, "update_fields 包含不支持部分更新的字段: %s", field).
+				WithDetails(map[string]string{"field": "update_fields", "value": field})
+		}
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
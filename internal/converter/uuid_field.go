@@ -0,0 +1,52 @@
+// Package converter 提供模型与DTO之间的转换功能
+// 负责将内部模型转换为前端DTO，以及将DTO转换为内部模型
+package converter
+
+import (
+	"lemon-tree-core/internal/apperror"
+
+	"github.com/google/uuid"
+)
+
+// uuidFields 解析请求DTO中的 UUID 字段
+// 依次解析多个字段，只记录第一个解析失败的字段，转换结束后通过 err 统一返回
+type uuidFields struct {
+	err error
+}
+
+// parse 解析 UUID 字段，值为空时返回 uuid.Nil
+// 参数：field - 字段名（与请求中的 JSON 字段名一致），value - 字段值
+func (f *uuidFields) parse(field, value string) uuid.UUID {
+	if value == "" || f.err != nil {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		f.err = apperror.Newf(apperror.CodeInvalidArgument, "%s 不是有效的UUID: %s", field, value).
+			WithDetails(map[string]string{"field": field}).
+			WithCause(err)
+		return uuid.Nil
+	}
+	return id
+}
+
+// parseOptional 解析可选的 UUID 字段，指针为空或值为空时返回 uuid.Nil
+// 参数：field - 字段名（与请求中的 JSON 字段名一致），value - 字段值
+func (f *uuidFields) parseOptional(field string, value *string) uuid.UUID {
+	if value == nil {
+		return uuid.Nil
+	}
+	return f.parse(field, *value)
+}
+
+// parseList 解析 UUID 列表字段，忽略空值
+// 参数：field - 字段名（与请求中的 JSON 字段名一致），values - 字段值列表
+func (f *uuidFields) parseList(field string, values []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		if id := f.parse(field, value); id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,54 @@
+// Package di 提供依赖注入容器功能
+// 使用 Uber FX 框架管理应用程序的依赖关系
+// 负责组件的生命周期管理和依赖注入
+package core
+
+import (
+	"context"
+	"lemon-tree-core/internal/service"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// WatchConfigReloadSignal 监听 SIGHUP 信号重新加载配置
+// 收到信号时与调用 POST /api/v1/config/reload 效果相同，重新加载失败时保持原有配置并记录日志
+// 参数：lifecycle - FX 生命周期管理器，configReloadService - 配置重新加载服务，logger - 日志记录器
+func WatchConfigReloadSignal(
+	lifecycle fx.Lifecycle,
+	configReloadService service.ConfigReloadService,
+	logger *zap.Logger,
+) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	lifecycle.Append(fx.Hook{
+		// OnStart 在应用程序启动时开始监听信号
+		OnStart: func(context.Context) error {
+			signal.Notify(signals, syscall.SIGHUP)
+			go func() {
+				for {
+					select {
+					case <-signals:
+						logger.Info("Received SIGHUP, reloading config")
+						if _, err := configReloadService.ReloadConfig(context.Background()); err != nil {
+							logger.Error("Failed to reload config", zap.Error(err))
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		// OnStop 在应用程序停止时停止监听信号
+		OnStop: func(context.Context) error {
+			signal.Stop(signals)
+			close(done)
+			return nil
+		},
+	})
+}
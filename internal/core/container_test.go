@@ -0,0 +1,10 @@
+package core
+
+import "testing"
+
+// TestValidateContainer 校验依赖注入容器中所有组件的依赖关系能够完整解析
+func TestValidateContainer(t *testing.T) {
+	if err := ValidateContainer(); err != nil {
+		t.Fatalf("依赖注入容器校验失败: %v", err)
+	}
+}
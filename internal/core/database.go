@@ -4,33 +4,33 @@ package core
 
 import (
 	"fmt"
+	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/config"
 	"lemon-tree-core/internal/models"
 	"log"
 	"os"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
+// sqliteMemoryDatabase sqlite 内存数据库的名称
+const sqliteMemoryDatabase = ":memory:"
+
 // NewDatabase 创建数据库连接
-// 根据配置信息建立 MySQL 数据库连接
+// 根据配置信息建立 MySQL 或 SQLite 数据库连接
 // 配置 GORM 日志和自动迁移表结构
 // 参数：config - 应用程序配置
 // 返回：GORM 数据库连接实例和错误信息
 func NewDatabase(config *config.Config) (*gorm.DB, error) {
-	// 构建数据库连接字符串（DSN）
-	// 格式：username:password@tcp(host:port)/database?charset=charset&parseTime=True&loc=Local
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
-		config.Database.Username, // 数据库用户名
-		config.Database.Password, // 数据库密码
-		config.Database.Host,     // 数据库主机地址
-		config.Database.Port,     // 数据库端口号
-		config.Database.Database, // 数据库名称
-		config.Database.Charset,  // 数据库字符集
-	)
+	dialector, err := newDialector(config)
+	if err != nil {
+		return nil, err
+	}
 
 	// 配置 GORM 日志记录器
 	// 使用结构化日志记录 SQL 查询和错误信息
@@ -44,18 +44,60 @@ func NewDatabase(config *config.Config) (*gorm.DB, error) {
 		},
 	)
 
-	// 使用 MySQL 驱动打开数据库连接
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+	// 使用配置的驱动打开数据库连接
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: newLogger, // 使用配置的日志记录器
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// 自动迁移表结构
-	// 根据模型定义自动创建或更新数据库表
-	// 迁移所有模型对应的表
-	if err := db.AutoMigrate(
+	// 内存数据库的每个连接都是独立的数据库，只保留一个连接使各处看到同一份数据
+	if config.Database.Driver == "sqlite" && config.Database.Database == sqliteMemoryDatabase {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database connection: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	}
+
+	// 自动迁移表结构和联合索引，补全已有数据
+	if err := MigrateDatabase(db); err != nil {
+		return nil, err
+	}
+
+	// 注册只读副本，迁移完成后注册，表结构变更只在主库执行
+	if err := registerReadReplica(db, config); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// MigrateDatabase 迁移数据库表结构
+// 根据模型定义自动创建或更新数据库表，补充创建模型标签无法声明的联合索引，并为已有数据补全新增的字段
+// 参数：db - 数据库连接
+// 返回：错误信息
+func MigrateDatabase(db *gorm.DB) error {
+	if err := db.AutoMigrate(MigrationModels()...); err != nil {
+		return fmt.Errorf("failed to auto migrate: %w", err)
+	}
+
+	// 补充创建模型标签无法声明的联合索引
+	if err := migrateCompositeIndexes(db); err != nil {
+		return err
+	}
+
+	// 为已有数据补全新增的字段
+	return migrateDataBackfills(db)
+}
+
+// MigrationModels 返回需要自动迁移的全部模型
+// 新增数据表时在此登记
+func MigrationModels() []any {
+	return []any{
 		&models.Application{},                            // 应用表
 		&models.SystemUser{},                             // 系统用户表
 		&models.SystemUserSession{},                      // 系统用户会话表
@@ -63,6 +105,7 @@ func NewDatabase(config *config.Config) (*gorm.DB, error) {
 		&models.ChatAgent{},                              // 聊天智能体表
 		&models.ChatAgentConversation{},                  // 聊天智能体会话表
 		&models.ChatAgentMessage{},                       // 聊天智能体消息表
+		&models.ChatAgentMessageRequest{},                // 聊天智能体消息请求ID表
 		&models.ChatAgentAttachment{},                    // 聊天智能体附件表
 		&models.ChatAgentApiKey{},                        // 聊天智能体API Key表
 		&models.ChatConversation{},                       // 聊天会话表
@@ -72,9 +115,95 @@ func NewDatabase(config *config.Config) (*gorm.DB, error) {
 		&models.ApplicationMcpServerConfig{},             // 应用MCP服务器配置表
 		&models.ApplicationMcpServerTool{},               // 应用MCP服务器工具表
 		&models.ChatAgentMcpServerTool{},                 // 聊天智能体MCP服务器工具配置表
-	); err != nil {
-		return nil, fmt.Errorf("failed to auto migrate: %w", err)
+		&models.LlmProviderDefine{},                      // 大语言模型供应商定义表
+		&models.WorkspaceUpload{},                        // 工作区上传文件表
+		&models.ServiceUser{},                            // 业务侧用户表
+		&models.ChatAgentAnswerRule{},                    // 聊天智能体预制答案规则表
+		&models.KnowledgeBase{},                          // 知识库表
+		&models.KnowledgeDocument{},                      // 知识库文档表
+		&models.KnowledgeChunk{},                         // 知识库文档片段表
+		&models.BatchInferenceJob{},                      // 批量推理任务表
+		&models.BatchInferenceItem{},                     // 批量推理任务提示词表
+		&models.EvaluationSet{},                          // 评测集表
+		&models.EvaluationCase{},                         // 评测用例表
+		&models.EvaluationRun{},                          // 评测运行表
+		&models.EvaluationResult{},                       // 评测结果表
+		&models.ApplicationDeletionJob{},                 // 应用删除任务表
+		&models.ChatAgentToolCall{},                      // 智能体工具调用记录表
+		&models.ChatAgentConversationVariable{},          // 会话变量表
+		&models.ApplicationMcpServerOauthToken{},         // MCP服务OAuth令牌表
+		&models.ApplicationToolBundle{},                  // 应用工具集表
+		&models.ApplicationToolBundleTool{},              // 应用工具集工具表
+		&models.ChatAgentToolBundle{},                    // 聊天智能体工具集关联表
+		&models.SystemUserIdentity{},                     // 系统用户外部身份表
+		&models.SystemUserOidcLogin{},                    // OIDC 单点登录请求表
+		&models.SystemUserActionToken{},                  // 系统用户一次性令牌表
+		&models.ApplicationSetting{},                     // 应用设置表
+		&models.ChatAgentWidgetToken{},                   // 嵌入式聊天窗口令牌表
+		&models.ChatAgentApiKeyRejection{},               // API Key 访问拒绝记录表
+		&models.ConversationEvent{},                      // 会话事件表
+		&models.ApplicationWebhook{},                     // 应用 Webhook 表
+		&models.ApplicationWebhookDelivery{},             // Webhook 推送记录表
+		&models.ClusterLock{},                            // 集群分布式锁表
+		&models.ChatStream{},                             // 集群模式流式回复表
+		&models.ChatStreamEvent{},                        // 集群模式流式回复事件表
+		&models.ApplicationFeatureFlag{},                 // 应用功能开关表
+		&models.ConversationArchive{},                    // 会话归档记录表
 	}
+}
 
-	return db, nil
+// registerReadReplica 配置了只读副本时注册 dbresolver 插件
+// 只读副本注册为具名解析器，只有通过 base.UseReadReplica 指定的查询在副本执行，其余查询和写操作仍使用主库连接
+// 参数：db - 主库连接，config - 应用程序配置
+// 返回：错误信息
+func registerReadReplica(db *gorm.DB, config *config.Config) error {
+	replicaDSN := config.Database.ReplicaDSN
+	if replicaDSN == "" {
+		return nil
+	}
+
+	var replica gorm.Dialector
+	switch config.Database.Driver {
+	case "", "mysql":
+		replica = mysql.Open(replicaDSN)
+	case "sqlite":
+		replica = sqlite.Open(replicaDSN + "?_pragma=busy_timeout(5000)")
+	default:
+		return fmt.Errorf("unsupported database driver: %s", config.Database.Driver)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas:          []gorm.Dialector{replica},
+		Policy:            dbresolver.RandomPolicy{},
+		TraceResolverMode: true, // SQL 日志中标注查询在主库还是副本执行
+	}, base.ReadReplicaResolver)
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+	return nil
+}
+
+// newDialector 根据配置的数据库驱动创建 GORM 方言
+// 参数：config - 应用程序配置
+// 返回：GORM 方言和错误信息
+func newDialector(config *config.Config) (gorm.Dialector, error) {
+	switch config.Database.Driver {
+	case "", "mysql":
+		// 构建数据库连接字符串（DSN）
+		// 格式：username:password@tcp(host:port)/database?charset=charset&parseTime=True&loc=Local
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=True&loc=Local",
+			config.Database.Username, // 数据库用户名
+			config.Database.Password, // 数据库密码
+			config.Database.Host,     // 数据库主机地址
+			config.Database.Port,     // 数据库端口号
+			config.Database.Database, // 数据库名称
+			config.Database.Charset,  // 数据库字符集
+		)
+		return mysql.Open(dsn), nil
+	case "sqlite":
+		// 数据库名称即数据库文件路径，开启外键约束和忙等待，避免并发写入时立即失败
+		return sqlite.Open(config.Database.Database + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", config.Database.Driver)
+	}
 }
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// dataBackfill 自动迁移新增字段后需要为已有数据补全的内容
+type dataBackfill struct {
+	Name string                  // 补全任务名称，用于错误信息
+	Run  func(db *gorm.DB) error // 补全逻辑，每次启动都会执行，需保证重复执行无副作用
+}
+
+// dataBackfills 自动迁移后执行的数据补全列表
+var dataBackfills = []dataBackfill{
+	{Name: "conversation last_message_at", Run: backfillConversationLastMessageAt},
+	{Name: "system user session refresh_expired_at", Run: backfillSessionRefreshExpiredAt},
+}
+
+// migrateDataBackfills 依次执行数据补全
+// 参数：db - GORM 数据库连接实例
+// 返回：错误信息
+func migrateDataBackfills(db *gorm.DB) error {
+	for _, backfill := range dataBackfills {
+		if err := backfill.Run(db); err != nil {
+			return fmt.Errorf("failed to backfill %s: %w", backfill.Name, err)
+		}
+	}
+	return nil
+}
+
+// backfillConversationLastMessageAt 为新增最后消息时间字段前创建的会话补全该字段
+// 取会话最后一条普通消息的时间，没有消息时取会话的创建时间
+func backfillConversationLastMessageAt(db *gorm.DB) error {
+	lastMessageAt := db.Model(&models.ChatAgentMessage{}).
+		Select("MAX(created_at)").
+		Where("conversation_id = ltc_chat_agent_conversation.id AND type = ?", "message")
+	return db.Model(&models.ChatAgentConversation{}).
+		Where("last_message_at IS NULL").
+		UpdateColumn("last_message_at", gorm.Expr("COALESCE((?), created_at)", lastMessageAt)).Error
+}
+
+// backfillSessionRefreshExpiredAt 为引入刷新令牌前创建的登录会话补全会话过期时间
+// 这些会话没有刷新令牌，会话过期时间即访问令牌的过期时间；包含已软删除的会话，以便过期后被清理
+func backfillSessionRefreshExpiredAt(db *gorm.DB) error {
+	return db.Unscoped().Model(&models.SystemUserSession{}).
+		Where("refresh_expired_at IS NULL").
+		UpdateColumn("refresh_expired_at", gorm.Expr("login_expired_at")).Error
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// compositeIndex 需要在自动迁移后补充创建的联合索引
+// 联合索引包含 BaseModel 中的 ID、创建时间等字段，或创建前需要先清理数据时无法通过模型标签声明，在此统一维护
+type compositeIndex struct {
+	Model   interface{}             // 索引所属的模型
+	Name    string                  // 索引名称
+	Columns []string                // 索引字段，按顺序组成联合索引
+	Unique  bool                    // 是否唯一索引
+	Prepare func(db *gorm.DB) error // 创建索引前执行的数据清理（可选），仅在索引不存在时执行
+}
+
+// compositeIndexes 自动迁移后补充创建的联合索引列表
+var compositeIndexes = []compositeIndex{
+	// 会话消息列表按创建时间和ID做游标分页
+	{Model: &models.ChatAgentMessage{}, Name: "idx_chat_agent_message_conversation_created", Columns: []string{"conversation_id", "created_at", "id"}},
+	// 会话列表按智能体和业务侧用户过滤，按创建时间排序
+	{Model: &models.ChatAgentConversation{}, Name: "idx_chat_agent_conversation_agent_user_created", Columns: []string{"chat_agent_id", "service_user_id", "created_at"}},
+	// 同一MCP配置下的工具名称唯一，工具调用按配置和名称查找
+	{
+		Model:   &models.ApplicationMcpServerTool{},
+		Name:    "idx_application_mcp_server_tool_config_name",
+		Columns: []string{"application_mcp_server_config_id", "name"},
+		Unique:  true,
+		Prepare: purgeDeletedMcpServerTools,
+	},
+	// 智能体对同一MCP工具只有一条配置，保存工具配置时按此索引 upsert
+	{
+		Model:   &models.ChatAgentMcpServerTool{},
+		Name:    "idx_chat_agent_mcp_server_tool_agent_tool",
+		Columns: []string{"chat_agent_id", "application_mcp_server_tool_id"},
+		Unique:  true,
+		Prepare: dedupeChatAgentMcpServerTools,
+	},
+}
+
+// migrateCompositeIndexes 创建尚不存在的联合索引
+// 参数：db - GORM 数据库连接实例
+// 返回：错误信息
+func migrateCompositeIndexes(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, index := range compositeIndexes {
+		if migrator.HasIndex(index.Model, index.Name) {
+			continue
+		}
+		if index.Prepare != nil {
+			if err := index.Prepare(db); err != nil {
+				return fmt.Errorf("failed to prepare index %s: %w", index.Name, err)
+			}
+		}
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(index.Model); err != nil {
+			return fmt.Errorf("failed to parse model for index %s: %w", index.Name, err)
+		}
+		quotedColumns := make([]string, 0, len(index.Columns))
+		for _, column := range index.Columns {
+			quotedColumns = append(quotedColumns, stmt.Quote(column))
+		}
+		createIndex := "CREATE INDEX"
+		if index.Unique {
+			createIndex = "CREATE UNIQUE INDEX"
+		}
+		sql := fmt.Sprintf("%s %s ON %s (%s)", createIndex,
+			stmt.Quote(index.Name), stmt.Quote(stmt.Schema.Table), strings.Join(quotedColumns, ","))
+		if err := db.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index.Name, err)
+		}
+	}
+	return nil
+}
+
+// purgeDeletedMcpServerTools 物理删除已软删除的MCP工具
+// 同步工具时删除后又重新出现的工具会留下同名的软删除记录，需先清理才能创建唯一索引
+func purgeDeletedMcpServerTools(db *gorm.DB) error {
+	return db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.ApplicationMcpServerTool{}).Error
+}
+
+// dedupeChatAgentMcpServerTools 清理智能体重复的MCP工具配置
+// 物理删除已软删除的配置；并发保存产生的重复配置只保留最后更新的一条
+func dedupeChatAgentMcpServerTools(db *gorm.DB) error {
+	if err := db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.ChatAgentMcpServerTool{}).Error; err != nil {
+		return err
+	}
+
+	var settings []*models.ChatAgentMcpServerTool
+	if err := db.Select("id", "chat_agent_id", "application_mcp_server_tool_id").
+		Order("updated_at DESC").Order("id DESC").
+		Find(&settings).Error; err != nil {
+		return err
+	}
+	kept := make(map[[2]uuid.UUID]bool, len(settings))
+	var duplicateIDs []uuid.UUID
+	for _, setting := range settings {
+		key := [2]uuid.UUID{setting.ChatAgentID, setting.ApplicationMcpServerToolID}
+		if kept[key] {
+			duplicateIDs = append(duplicateIDs, setting.ID)
+			continue
+		}
+		kept[key] = true
+	}
+	if len(duplicateIDs) == 0 {
+		return nil
+	}
+	return db.Unscoped().Where("id IN ?", duplicateIDs).Delete(&models.ChatAgentMcpServerTool{}).Error
+}
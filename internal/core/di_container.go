@@ -5,18 +5,25 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"lemon-tree-core/internal/broker"
 	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/grpcapi"
 	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/job"
+	"lemon-tree-core/internal/mailer"
+	"lemon-tree-core/internal/manager"
 	"lemon-tree-core/internal/repository"
 	"lemon-tree-core/internal/router"
 	"lemon-tree-core/internal/service"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
-	"gorm.io/gorm"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 // NewContainer 创建依赖注入容器
@@ -25,12 +32,61 @@ import (
 // 返回配置完成的 FX 应用程序实例
 func NewContainer() *fx.App {
 	return fx.New(
+		coreOptions(),
+		webOptions(),
+	)
+}
+
+// ValidateContainer 校验依赖注入容器
+// 检查所有组件的依赖关系能否完整解析，不会调用构造函数，也不会连接数据库
+// 返回：依赖缺失或循环依赖时的错误信息
+func ValidateContainer() error {
+	return fx.ValidateApp(
+		coreOptions(),
+		webOptions(),
+		fx.NopLogger,
+	)
+}
+
+// NewCommandContainer 创建命令行工具使用的轻量依赖注入容器
+// 只注册基础设施、Repository 和 Service 层组件，不启动 HTTP 和 gRPC 服务器
+// 参数：invokes - 容器启动时执行的函数，可注入已注册的任意组件
+// 返回：配置完成的 FX 应用程序实例
+func NewCommandContainer(invokes ...interface{}) *fx.App {
+	return fx.New(
+		coreOptions(),
+		fx.NopLogger,
+		fx.Invoke(invokes...),
+	)
+}
+
+// NewHandlerContainer 创建只包含 HTTP 处理层的依赖注入容器
+// 注册基础设施、Repository、Service、Handler 层组件和路由，不执行首次启动初始化，也不启动 HTTP、gRPC 服务器和定时任务，
+// 用于集成测试在进程内通过路由引擎调用接口
+// 参数：targets - 需要从容器中取出的组件指针，如 *gin.Engine、*gorm.DB
+// 返回：配置完成的 FX 应用程序实例
+func NewHandlerContainer(targets ...interface{}) *fx.App {
+	return fx.New(
+		coreOptions(),
+		handlerOptions(),
+		fx.NopLogger,
+		fx.Populate(targets...),
+	)
+}
+
+// coreOptions 基础组件选项
+// 包含基础设施、Repository 层和 Service 层的提供者
+func coreOptions() fx.Option {
+	return fx.Options(
 		// 基础设施提供者（Infrastructure Providers）
 		// 包含配置、数据库、日志等基础组件
 		fx.Provide(
-			config.LoadConfig, // 加载配置文件
-			NewDatabase,       // 创建数据库连接
-			NewLogger,         // 创建日志记录器
+			config.LoadConfig,   // 加载配置文件
+			config.NewReloader,  // 创建配置重新加载器
+			NewDatabase,         // 创建数据库连接
+			NewLogger,           // 创建日志记录器
+			mailer.NewMailer,    // 创建邮件发送器
+			broker.NewPublisher, // 创建消息队列发布器
 		),
 
 		// Repository 层提供者（Repository Providers）
@@ -52,6 +108,37 @@ func NewContainer() *fx.App {
 			repository.NewApplicationMcpServerConfigRepository,             // 创建 ApplicationMcpServerConfig Repository
 			repository.NewApplicationMcpServerToolRepository,               // 创建 ApplicationMcpServerTool Repository
 			repository.NewChatAgentMcpServerToolRepository,                 // 创建 ChatAgentMcpServerTool Repository
+			repository.NewLlmProviderDefineRepository,                      // 创建 LlmProviderDefine Repository
+			repository.NewWorkspaceUploadRepository,                        // 创建 WorkspaceUpload Repository
+			repository.NewServiceUserRepository,                            // 创建 ServiceUser Repository
+			repository.NewChatAgentAnswerRuleRepository,                    // 创建 ChatAgentAnswerRule Repository
+			repository.NewChatAgentToolCallRepository,                      // 创建 ChatAgentToolCall Repository
+			repository.NewChatAgentConversationVariableRepository,          // 创建 ChatAgentConversationVariable Repository
+			repository.NewKnowledgeBaseRepository,                          // 创建 KnowledgeBase Repository
+			repository.NewKnowledgeDocumentRepository,                      // 创建 KnowledgeDocument Repository
+			repository.NewKnowledgeChunkRepository,                         // 创建 KnowledgeChunk Repository
+			repository.NewBatchInferenceJobRepository,                      // 创建 BatchInferenceJob Repository
+			repository.NewBatchInferenceItemRepository,                     // 创建 BatchInferenceItem Repository
+			repository.NewEvaluationSetRepository,                          // 创建 EvaluationSet Repository
+			repository.NewEvaluationCaseRepository,                         // 创建 EvaluationCase Repository
+			repository.NewEvaluationRunRepository,                          // 创建 EvaluationRun Repository
+			repository.NewEvaluationResultRepository,                       // 创建 EvaluationResult Repository
+			repository.NewApplicationDeletionJobRepository,                 // 创建 ApplicationDeletionJob Repository
+			repository.NewApplicationMcpServerOauthTokenRepository,         // 创建 ApplicationMcpServerOauthToken Repository
+			repository.NewApplicationToolBundleRepository,                  // 创建 ApplicationToolBundle Repository
+			repository.NewSystemUserIdentityRepository,                     // 创建 SystemUserIdentity Repository
+			repository.NewSystemUserOidcLoginRepository,                    // 创建 SystemUserOidcLogin Repository
+			repository.NewSystemUserActionTokenRepository,                  // 创建 SystemUserActionToken Repository
+			repository.NewApplicationSettingRepository,                     // 创建 ApplicationSetting Repository
+			repository.NewApplicationFeatureFlagRepository,                 // 创建 ApplicationFeatureFlag Repository
+			repository.NewChatAgentWidgetTokenRepository,                   // 创建 ChatAgentWidgetToken Repository
+			repository.NewChatAgentApiKeyRejectionRepository,               // 创建 ChatAgentApiKeyRejection Repository
+			repository.NewConversationEventRepository,                      // 创建 ConversationEvent Repository
+			repository.NewApplicationWebhookRepository,                     // 创建 ApplicationWebhook Repository
+			repository.NewApplicationWebhookDeliveryRepository,             // 创建 ApplicationWebhookDelivery Repository
+			repository.NewClusterLockRepository,                            // 创建 ClusterLock Repository
+			repository.NewChatStreamRepository,                             // 创建 ChatStream Repository
+			repository.NewConversationArchiveRepository,                    // 创建 ConversationArchive Repository
 		),
 
 		// Service 层提供者（Service Providers）
@@ -62,13 +149,42 @@ func NewContainer() *fx.App {
 			service.NewApplicationLlmService,           // 创建 ApplicationLlm Service
 			service.NewChatAgentService,                // 创建 ChatAgent Service
 			service.NewApplicationStorageConfigService, // 创建 ApplicationStorageConfig Service
+			service.NewLlmProviderDefineService,        // 创建 LlmProviderDefine Service
+			service.NewBootstrapService,                // 创建 Bootstrap Service
+			service.NewWorkspaceUploadService,          // 创建 WorkspaceUpload Service
+			service.NewServiceUserService,              // 创建 ServiceUser Service
+			service.NewConversationMonitorService,      // 创建 ConversationMonitor Service
+			service.NewConversationPresenceService,     // 创建 ConversationPresence Service
+			service.NewConversationExportService,       // 创建 ConversationExport Service
+			service.NewConversationReplayService,       // 创建 ConversationReplay Service
+			service.NewChatAgentAnswerRuleService,      // 创建 ChatAgentAnswerRule Service
+			service.NewKnowledgeBaseService,            // 创建 KnowledgeBase Service
+			service.NewBatchInferenceService,           // 创建 BatchInference Service
+			service.NewEvaluationService,               // 创建 Evaluation Service
+			service.NewLlmProviderLimiter,              // 创建 LlmProviderLimiter
+			service.NewChatStreamResumeService,         // 创建 ChatStreamResume Service
+			service.NewChatAgentToolUsageService,       // 创建 ChatAgentToolUsage Service
+			service.NewConversationVariableService,     // 创建 ConversationVariable Service
+			service.NewMcpOauthService,                 // 创建 McpOauth Service
+			service.NewApplicationToolBundleService,    // 创建 ApplicationToolBundle Service
+			service.NewOidcLoginService,                // 创建 OidcLogin Service
+			service.NewApplicationSettingService,       // 创建 ApplicationSetting Service
+			service.NewFeatureFlagService,              // 创建 FeatureFlag Service
+			service.NewChatWidgetTokenService,          // 创建 ChatWidgetToken Service
+			service.NewChatAgentApiKeyService,          // 创建 ChatAgentApiKey Service
+			service.NewChatMessagePersistenceService,   // 创建 ChatMessagePersistence Service
+			service.NewApplicationWebhookService,       // 创建 ApplicationWebhook Service
+			service.NewConversationEventService,        // 创建 ConversationEvent Service
+			service.NewClusterService,                  // 创建 Cluster Service
+			service.NewConversationArchiveService,      // 创建 ConversationArchive Service
+			service.NewApplicationBackupService,        // 创建 ApplicationBackup Service
+			service.NewConfigReloadService,             // 创建 ConfigReload Service
 			// ApplicationMcpServerConfigService 需要两个 repository，所以单独提供
 			func(applicationMcpServerConfigRepo repository.ApplicationMcpServerConfigRepository, applicationMcpServerToolRepo repository.ApplicationMcpServerToolRepository) service.ApplicationMcpServerConfigService {
 				return service.NewApplicationMcpServerConfigService(applicationMcpServerConfigRepo, applicationMcpServerToolRepo)
 			},
 			// ChatAgentConversationService 需要多个 repository，所以单独提供
 			func(
-				db *gorm.DB,
 				conversationRepo repository.ChatAgentConversationRepository,
 				messageRepo repository.ChatAgentMessageRepository,
 				attachmentRepo repository.ChatAgentAttachmentRepository,
@@ -78,10 +194,20 @@ func NewContainer() *fx.App {
 				mcpConfigRepo repository.ApplicationMcpServerConfigRepository,
 				mcpToolRepo repository.ApplicationMcpServerToolRepository,
 				chatAgentMcpServerToolRepo repository.ChatAgentMcpServerToolRepository,
+				toolBundleRepo repository.ApplicationToolBundleRepository,
 				llmProviderRepo repository.LlmProviderRepository,
+				monitorService service.ConversationMonitorService,
+				answerRuleService service.ChatAgentAnswerRuleService,
+				knowledgeBaseService service.KnowledgeBaseService,
+				providerLimiter service.LlmProviderLimiter,
+				presenceService service.ConversationPresenceService,
+				toolUsageService service.ChatAgentToolUsageService,
+				variableService service.ConversationVariableService,
+				settingService service.ApplicationSettingService,
+				persistenceService service.ChatMessagePersistenceService,
+				featureFlagService service.FeatureFlagService,
 			) service.ChatAgentConversationService {
 				return service.NewChatAgentConversationService(
-					db,
 					conversationRepo,
 					messageRepo,
 					attachmentRepo,
@@ -91,7 +217,18 @@ func NewContainer() *fx.App {
 					mcpConfigRepo,
 					mcpToolRepo,
 					chatAgentMcpServerToolRepo,
+					toolBundleRepo,
 					llmProviderRepo,
+					monitorService,
+					answerRuleService,
+					knowledgeBaseService,
+					providerLimiter,
+					presenceService,
+					toolUsageService,
+					variableService,
+					settingService,
+					persistenceService,
+					featureFlagService,
 				)
 			},
 			// 未来可以在这里添加更多 Service
@@ -110,16 +247,27 @@ func NewContainer() *fx.App {
 				applicationMcpServerToolRepo repository.ApplicationMcpServerToolRepository,
 				applicationMcpServerConfigRepo repository.ApplicationMcpServerConfigRepository,
 				chatAgentRepo repository.ChatAgentRepository,
+				toolBundleRepo repository.ApplicationToolBundleRepository,
 			) service.ChatAgentMcpServerToolService {
 				return service.NewChatAgentMcpServerToolService(
 					chatAgentMcpServerToolRepo,
 					applicationMcpServerToolRepo,
 					applicationMcpServerConfigRepo,
 					chatAgentRepo,
+					toolBundleRepo,
 				)
 			},
 		),
 
+		// MCP客户端从数据库读取OAuth令牌，服务和命令行工具都需要注册
+		fx.Invoke(manager.RegisterMcpOauthTokenRepository),
+	)
+}
+
+// handlerOptions HTTP 处理层选项
+// 包含 Handler 层和路由的提供者
+func handlerOptions() fx.Option {
+	return fx.Options(
 		// Handler 层提供者（Handler Providers）
 		// 包含所有 HTTP 请求处理层的组件
 		fx.Provide(
@@ -136,6 +284,18 @@ func NewContainer() *fx.App {
 			handler.NewApplicationStorageConfigHandler,   // 创建 ApplicationStorageConfig Handler
 			handler.NewResourceHandler,                   // 创建 Resource Handler
 			handler.NewChatAgentMcpServerToolHandler,     // 创建 ChatAgentMcpServerTool Handler
+			handler.NewLlmProviderDefineHandler,          // 创建 LlmProviderDefine Handler
+			handler.NewServiceUserHandler,                // 创建 ServiceUser Handler
+			handler.NewConversationMonitorHandler,        // 创建 ConversationMonitor Handler
+			handler.NewChatAgentAnswerRuleHandler,        // 创建 ChatAgentAnswerRule Handler
+			handler.NewKnowledgeBaseHandler,              // 创建 KnowledgeBase Handler
+			handler.NewBatchInferenceHandler,             // 创建 BatchInference Handler
+			handler.NewEvaluationHandler,                 // 创建 Evaluation Handler
+			handler.NewMetricsHandler,                    // 创建 Metrics Handler
+			handler.NewApplicationToolBundleHandler,      // 创建 ApplicationToolBundle Handler
+			handler.NewApplicationWebhookHandler,         // 创建 ApplicationWebhook Handler
+			handler.NewConversationArchiveHandler,        // 创建 ConversationArchive Handler
+			handler.NewConfigHandler,                     // 创建 Config Handler
 			// 未来可以在这里添加更多 Handler
 			// handler.NewOrderHandler,
 		),
@@ -145,26 +305,71 @@ func NewContainer() *fx.App {
 		fx.Provide(
 			router.NewRouterManager, // 创建路由管理器
 			// 创建 Gin 引擎实例
-			func(rm *router.RouterManager) *gin.Engine {
+			func(rm *router.RouterManager) (*gin.Engine, error) {
 				return rm.SetupAllRoutes()
 			},
 		),
+	)
+}
+
+// webOptions Web 服务选项
+// 包含 HTTP 处理层、gRPC 服务、定时任务和服务器启动钩子
+func webOptions() fx.Option {
+	return fx.Options(
+		handlerOptions(),
+
+		// gRPC 层提供者（gRPC Providers）
+		// 包含 gRPC 服务实现和认证组件
+		fx.Provide(
+			grpcapi.NewChatServer,             // 创建 Chat gRPC Server
+			grpcapi.NewChatAgentAuthenticator, // 创建智能体认证器
+		),
+
+		// 后台任务提供者（Job Providers）
+		// 包含定时任务调度器
+		fx.Provide(
+			job.NewScheduler, // 创建定时任务调度器
+			// 定时任务调度器通过集群服务获取任务锁
+			func(clusterService service.ClusterService) job.Locker {
+				return clusterService
+			},
+		),
 
 		// 启动钩子（Invokes）
 		// 在应用程序启动时执行的函数
+		fx.Invoke(RunBootstrap),
+		fx.Invoke(RegisterJobs),
 		fx.Invoke(StartServer),
+		fx.Invoke(StartGrpcServer),
+		fx.Invoke(WatchConfigReloadSignal),
 	)
 }
 
 // NewLogger 创建日志记录器
-// 使用 Zap 库创建生产环境的日志记录器
+// 使用 Zap 库创建生产环境的日志记录器，日志级别可以通过重新加载配置修改
+// 参数：cfg - 应用程序配置，reloader - 配置重新加载器
 // 返回 Zap 日志记录器实例和错误信息
-func NewLogger() (*zap.Logger, error) {
-	return zap.NewProduction()
+func NewLogger(cfg *config.Config, reloader *config.Reloader) (*zap.Logger, error) {
+	if !slices.Contains(config.LogLevels, cfg.Log.Level) {
+		return nil, fmt.Errorf("不支持的日志级别: %s", cfg.Log.Level)
+	}
+	level := zap.NewAtomicLevel()
+	if err := level.UnmarshalText([]byte(cfg.Log.Level)); err != nil {
+		return nil, err
+	}
+	reloader.OnReload(func(newConfig *config.Config) {
+		// 新配置的日志级别已在重新加载时校验
+		level.UnmarshalText([]byte(newConfig.Log.Level))
+	})
+
+	loggerConfig := zap.NewProductionConfig()
+	loggerConfig.Level = level
+	return loggerConfig.Build()
 }
 
 // StartServer 启动服务器
 // 配置 HTTP 服务器的启动和关闭逻辑
+// 配置证书文件或自动证书域名时由服务自身终止 TLS（同时启用 HTTP/2），并可选监听 HTTP 端口重定向到 HTTPS
 // 使用 FX 的生命周期管理功能
 // 参数：lifecycle - FX 生命周期管理器，router - Gin 路由引擎，config - 应用程序配置，logger - 日志记录器
 func StartServer(
@@ -173,23 +378,61 @@ func StartServer(
 	config *config.Config,
 	logger *zap.Logger,
 ) {
+	serverConfig := config.Server
+
 	// 创建 HTTP 服务器实例
 	server := &http.Server{
-		Addr:    config.Server.Port, // 服务器监听地址
-		Handler: router,             // HTTP 请求处理器
+		Addr:    serverConfig.Port, // 服务器监听地址
+		Handler: router,            // HTTP 请求处理器
+	}
+
+	// 启用 TLS 时创建 HTTP 重定向服务器
+	var redirectServer *http.Server
+	var certManager *autocert.Manager
+	if serverConfig.TLSEnabled() {
+		redirectHandler := newHTTPSRedirectHandler(serverConfig.Port)
+		if len(serverConfig.AutocertDomains) > 0 {
+			certManager = newAutocertManager(serverConfig)
+			server.TLSConfig = certManager.TLSConfig()
+			// HTTP 端口同时用于响应 Let's Encrypt 的 HTTP-01 验证
+			redirectHandler = certManager.HTTPHandler(redirectHandler)
+		}
+		if serverConfig.HTTPRedirectPort != "" {
+			redirectServer = &http.Server{
+				Addr:    serverConfig.HTTPRedirectPort,
+				Handler: redirectHandler,
+			}
+		}
 	}
 
 	// 配置应用程序生命周期钩子
 	lifecycle.Append(fx.Hook{
 		// OnStart 在应用程序启动时执行
 		OnStart: func(context.Context) error {
-			logger.Info("Starting server", zap.String("port", config.Server.Port))
+			logger.Info("Starting server", zap.String("port", serverConfig.Port), zap.Bool("tls", serverConfig.TLSEnabled()))
 			// 在后台 goroutine 中启动服务器
 			go func() {
-				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				var err error
+				switch {
+				case certManager != nil:
+					err = server.ListenAndServeTLS("", "")
+				case serverConfig.TLSEnabled():
+					err = server.ListenAndServeTLS(serverConfig.TLSCertFile, serverConfig.TLSKeyFile)
+				default:
+					err = server.ListenAndServe()
+				}
+				if err != nil && err != http.ErrServerClosed {
 					logger.Fatal("Failed to start server", zap.Error(err))
 				}
 			}()
+			if redirectServer != nil {
+				logger.Info("Starting HTTP redirect server", zap.String("port", serverConfig.HTTPRedirectPort))
+				go func() {
+					if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+						logger.Fatal("Failed to start HTTP redirect server", zap.Error(err))
+					}
+				}()
+			}
 			return nil
 		},
 		// OnStop 在应用程序停止时执行
@@ -198,8 +441,42 @@ func StartServer(
 			// 设置关闭超时时间
 			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 			defer cancel()
+			if redirectServer != nil {
+				if err := redirectServer.Shutdown(ctx); err != nil {
+					logger.Warn("Failed to stop HTTP redirect server", zap.Error(err))
+				}
+			}
 			// 优雅关闭服务器
 			return server.Shutdown(ctx)
 		},
 	})
 }
+
+// RunBootstrap 启动时执行首次启动初始化
+// 仅在配置启用时执行，自动生成的管理员密码只在日志中输出一次
+// 参数：bootstrapService - 首次启动初始化服务，config - 应用程序配置，logger - 日志记录器
+// 返回：错误信息
+func RunBootstrap(bootstrapService service.BootstrapService, config *config.Config, logger *zap.Logger) error {
+	if !config.Bootstrap.OnStartup {
+		return nil
+	}
+
+	result, err := bootstrapService.Bootstrap(context.Background())
+	if err != nil {
+		return err
+	}
+	if result.AdminUserCreated {
+		fields := []zap.Field{zap.String("number", result.AdminNumber)}
+		if result.GeneratedAdminPassword != nil {
+			fields = append(fields, zap.String("password", *result.GeneratedAdminPassword))
+		}
+		logger.Warn("Bootstrap created admin user", fields...)
+	}
+	if result.ApplicationCreated {
+		logger.Info("Bootstrap created default application", zap.Stringp("application_id", result.ApplicationID))
+	}
+	if result.ProviderDefinesCreated > 0 {
+		logger.Info("Bootstrap created llm provider defines", zap.Int("count", result.ProviderDefinesCreated))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package di 提供依赖注入容器功能
+// 使用 Uber FX 框架管理应用程序的依赖关系
+// 负责组件的生命周期管理和依赖注入
+package core
+
+import (
+	"context"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/grpcapi"
+	"lemon-tree-core/internal/grpcapi/chatv1"
+	"net"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// StartGrpcServer 启动 gRPC 服务器
+// 仅在配置启用时监听独立端口，与 HTTP 服务器共享业务逻辑层
+// 参数：lifecycle - FX 生命周期管理器，chatServer - 聊天服务 gRPC 实现，authenticator - 智能体认证器，config - 应用程序配置，logger - 日志记录器
+func StartGrpcServer(
+	lifecycle fx.Lifecycle,
+	chatServer *grpcapi.ChatServer,
+	authenticator *grpcapi.ChatAgentAuthenticator,
+	config *config.Config,
+	logger *zap.Logger,
+) {
+	if !config.Grpc.Enabled {
+		return
+	}
+
+	// 创建 gRPC 服务器实例并注册服务
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
+	chatv1.RegisterChatServiceServer(server, chatServer)
+
+	lifecycle.Append(fx.Hook{
+		// OnStart 在应用程序启动时执行
+		OnStart: func(context.Context) error {
+			listener, err := net.Listen("tcp", config.Grpc.Port)
+			if err != nil {
+				return err
+			}
+			logger.Info("Starting gRPC server", zap.String("port", config.Grpc.Port))
+			// 在后台 goroutine 中启动服务器
+			go func() {
+				if err := server.Serve(listener); err != nil {
+					logger.Error("gRPC server stopped", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		// OnStop 在应用程序停止时执行
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping gRPC server")
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			// 超时后强制关闭，避免长连接流阻塞退出
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				server.Stop()
+			}
+			return nil
+		},
+	})
+}
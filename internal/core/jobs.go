@@ -0,0 +1,126 @@
+// Package di 提供依赖注入容器功能
+// 使用 Uber FX 框架管理应用程序的依赖关系
+// 负责组件的生命周期管理和依赖注入
+package core
+
+import (
+	"context"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/job"
+	"lemon-tree-core/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RegisterJobs 注册后台定时任务
+// 参数：scheduler - 定时任务调度器，workspaceUploadService - 工作区上传文件服务，batchInferenceService - 批量推理服务，evaluationService - 评测服务，chatAgentService - 智能体服务，applicationService - 应用服务，userService - 用户服务，chatWidgetTokenService - 嵌入式聊天窗口令牌服务，chatAgentApiKeyService - API Key 服务，chatMessagePersistenceService - 消息保存服务，conversationEventService - 会话事件服务，chatStreamResumeService - 流式回复续传服务，conversationArchiveService - 会话归档服务，config - 应用程序配置，logger - 日志记录器
+func RegisterJobs(
+	scheduler *job.Scheduler,
+	workspaceUploadService service.WorkspaceUploadService,
+	batchInferenceService service.BatchInferenceService,
+	evaluationService service.EvaluationService,
+	chatAgentService service.ChatAgentService,
+	applicationService service.ApplicationService,
+	userService service.UserService,
+	chatWidgetTokenService service.ChatWidgetTokenService,
+	chatAgentApiKeyService service.ChatAgentApiKeyService,
+	chatMessagePersistenceService service.ChatMessagePersistenceService,
+	conversationEventService service.ConversationEventService,
+	chatStreamResumeService service.ChatStreamResumeService,
+	conversationArchiveService service.ConversationArchiveService,
+	config *config.Config,
+	logger *zap.Logger,
+) {
+	orphanTTL := time.Duration(config.Upload.OrphanTTLHours) * time.Hour
+	scheduler.Register(job.Job{
+		Name:     "cleanup-orphaned-uploads",
+		Interval: time.Duration(config.Upload.CleanupIntervalMinutes) * time.Minute,
+		Run: func(ctx context.Context) error {
+			removed, err := workspaceUploadService.CleanupOrphanedUploads(ctx, orphanTTL)
+			if removed > 0 {
+				logger.Info("Removed orphaned uploads", zap.Int("count", removed))
+			}
+			return err
+		},
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "purge-deleted-attachment-files",
+		Interval: time.Duration(config.Upload.CleanupIntervalMinutes) * time.Minute,
+		Run: func(ctx context.Context) error {
+			purged, err := chatAgentService.PurgeDeletedAttachmentFiles(ctx)
+			if purged > 0 {
+				logger.Info("Purged deleted attachment files", zap.Int("count", purged))
+			}
+			return err
+		},
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "process-batch-inference-jobs",
+		Interval: time.Duration(config.Batch.IntervalSeconds) * time.Second,
+		Run:      batchInferenceService.ProcessPendingJobs,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "process-evaluation-runs",
+		Interval: time.Duration(config.Evaluation.IntervalSeconds) * time.Second,
+		Run:      evaluationService.ProcessPendingRuns,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "process-application-deletion-jobs",
+		Interval: time.Duration(config.Deletion.IntervalSeconds) * time.Second,
+		Run:      applicationService.ProcessDeletionJobs,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "cleanup-expired-sessions",
+		Interval: time.Duration(config.Session.CleanupIntervalMinutes) * time.Minute,
+		Run:      userService.CleanupExpiredSessions,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "cleanup-expired-widget-tokens",
+		Interval: time.Duration(config.Session.CleanupIntervalMinutes) * time.Minute,
+		Run:      chatWidgetTokenService.CleanupExpiredTokens,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "cleanup-api-key-rejections",
+		Interval: time.Duration(config.Session.CleanupIntervalMinutes) * time.Minute,
+		Run:      chatAgentApiKeyService.CleanupRejections,
+	})
+
+	scheduler.Register(job.Job{
+		Name:        "retry-spooled-messages",
+		Interval:    time.Duration(config.MessageSpool.RetryIntervalSeconds) * time.Second,
+		Run:         chatMessagePersistenceService.RetryPending,
+		PerInstance: true,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "process-conversation-events",
+		Interval: time.Duration(config.EventOutbox.IntervalSeconds) * time.Second,
+		Run:      conversationEventService.ProcessEvents,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "cleanup-conversation-events",
+		Interval: time.Duration(config.Session.CleanupIntervalMinutes) * time.Minute,
+		Run:      conversationEventService.CleanupEvents,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "cleanup-chat-streams",
+		Interval: time.Duration(config.Session.CleanupIntervalMinutes) * time.Minute,
+		Run:      chatStreamResumeService.CleanupStreams,
+	})
+
+	scheduler.Register(job.Job{
+		Name:     "archive-conversations",
+		Interval: time.Duration(config.Archive.IntervalMinutes) * time.Minute,
+		Run:      conversationArchiveService.ArchiveConversations,
+	})
+}
@@ -0,0 +1,43 @@
+// Package di 提供依赖注入容器功能
+// 使用 Uber FX 框架管理应用程序的依赖关系
+// 负责组件的生命周期管理和依赖注入
+package core
+
+import (
+	"lemon-tree-core/internal/config"
+	"net"
+	"net/http"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// newAutocertManager 创建 Let's Encrypt 自动证书管理器
+// 仅为配置中的域名申请证书，证书缓存在本地目录中，重启后复用
+// 参数：serverConfig - 服务器配置
+// 返回：自动证书管理器实例
+func newAutocertManager(serverConfig config.ServerConfig) *autocert.Manager {
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(serverConfig.AutocertDomains...),
+		Cache:      autocert.DirCache(serverConfig.AutocertCacheDir),
+		Email:      serverConfig.AutocertEmail,
+	}
+}
+
+// newHTTPSRedirectHandler 创建 HTTP 到 HTTPS 的重定向处理器
+// 保留请求的主机名、路径和查询参数，HTTPS 端口不是 443 时在地址中带上端口
+// 参数：httpsPort - HTTPS 服务监听地址，如 ":443"
+// 返回：HTTP 请求处理器
+func newHTTPSRedirectHandler(httpsPort string) http.Handler {
+	_, port, _ := net.SplitHostPort(httpsPort)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
@@ -1,7 +1,24 @@
 package define
 
 const (
-	AppContextKeyCurrentUser        = "app_context_key_current_user"
-	AppContextKeyCurrentChatAgent   = "app_context_key_current_chat_agent"
-	AppContextKeyCurrentApplication = "app_context_key_current_application"
+	AppContextKeyCurrentUser         = "app_context_key_current_user"
+	AppContextKeyCurrentChatAgent    = "app_context_key_current_chat_agent"
+	AppContextKeyCurrentApplication  = "app_context_key_current_application"
+	AppContextKeyCurrentWidgetToken  = "app_context_key_current_widget_token"   // 使用窗口令牌认证时的窗口令牌记录
+	AppContextKeyWidgetServiceUserID = "app_context_key_widget_service_user_id" // 使用窗口令牌认证时令牌绑定的业务侧用户ID
+)
+
+const (
+	AppContextKeyRequestID = "app_context_key_request_id" // 当前HTTP请求的请求ID
+)
+
+const (
+	HeaderRequestID   = "X-Request-ID"          // 请求ID请求头/响应头
+	HeaderApiKey      = "lemon-ai-api-key"      // 智能体ApiKey请求头
+	HeaderWidgetToken = "lemon-ai-widget-token" // 嵌入式聊天窗口令牌请求头
+	HeaderCSRFToken   = "X-CSRF-Token"          // 与窗口令牌绑定的 CSRF 令牌请求头
+)
+
+const (
+	AppContextKeyApiVersion = "app_context_key_api_version" // 当前请求的API版本
 )
@@ -0,0 +1,7 @@
+package define
+
+const (
+	ApplicationDeletionJobStatusPending   = "pending"   // 等待处理：应用已删除，关联数据尚未开始清理
+	ApplicationDeletionJobStatusRunning   = "running"   // 执行中：正在按步骤分批删除关联数据，出错时下次继续执行
+	ApplicationDeletionJobStatusCompleted = "completed" // 已完成：全部关联数据均已删除
+)
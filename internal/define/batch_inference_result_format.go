@@ -0,0 +1,6 @@
+package define
+
+const (
+	BatchInferenceResultFormatJsonl = "jsonl" // JSON Lines：每行一条结果
+	BatchInferenceResultFormatCsv   = "csv"   // CSV：带表头，便于在表格软件中查看
+)
@@ -0,0 +1,17 @@
+package define
+
+const (
+	BatchInferenceJobStatusPending   = "pending"   // 等待处理：任务已提交，尚未开始执行
+	BatchInferenceJobStatusRunning   = "running"   // 执行中：正在逐条处理提示词
+	BatchInferenceJobStatusCompleted = "completed" // 已完成：全部提示词均已处理（单条可能失败）
+	BatchInferenceJobStatusFailed    = "failed"    // 执行失败：智能体或应用不可用，失败原因见错误信息
+	BatchInferenceJobStatusCancelled = "cancelled" // 已取消：未处理的提示词不再执行
+)
+
+const (
+	BatchInferenceItemStatusPending   = "pending"   // 等待处理
+	BatchInferenceItemStatusRunning   = "running"   // 处理中
+	BatchInferenceItemStatusCompleted = "completed" // 已完成：已生成回答
+	BatchInferenceItemStatusFailed    = "failed"    // 处理失败：失败原因见错误信息
+	BatchInferenceItemStatusCancelled = "cancelled" // 已取消：任务取消时尚未处理
+)
@@ -0,0 +1,7 @@
+package define
+
+const (
+	ChatAgentAnswerRuleMatchTypeKeyword   = "keyword"   // 关键词匹配：用户消息包含任一关键词即命中（不区分大小写）
+	ChatAgentAnswerRuleMatchTypeRegex     = "regex"     // 正则匹配：用户消息匹配正则表达式即命中
+	ChatAgentAnswerRuleMatchTypeEmbedding = "embedding" // 语义匹配：用户消息与示例问题的向量余弦相似度达到阈值即命中
+)
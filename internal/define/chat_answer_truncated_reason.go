@@ -0,0 +1,6 @@
+package define
+
+const (
+	ChatAnswerTruncatedReasonDuration     = "duration"      // 回答生成时间超过智能体设置的上限
+	ChatAnswerTruncatedReasonOutputTokens = "output_tokens" // 回答的输出Token数超过智能体设置的上限
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	ChatConversationVariableSourceApi   = "api"   // 业务侧通过接口设置
+	ChatConversationVariableSourceModel = "model" // 模型调用内部工具设置
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	ChatDeltaChunkModeRaw          = "raw"           // 原样透传模型返回的增量内容（默认）
+	ChatDeltaChunkModeMarkdownSafe = "markdown_safe" // 缓冲增量内容，在Markdown安全边界处（非代码块内部）再输出
+)
@@ -0,0 +1,15 @@
+package define
+
+const (
+	ChatErrorCodeProviderAuth        = "provider_auth_failed"    // 模型供应商的 API Key 无效或没有权限
+	ChatErrorCodeProviderQuota       = "provider_quota_exceeded" // 模型供应商的账户额度已用完
+	ChatErrorCodeProviderRateLimited = "provider_rate_limited"   // 模型供应商限流
+	ChatErrorCodeProviderBusy        = "provider_busy"           // 模型供应商的并发名额已满，排队超时
+	ChatErrorCodeProviderUnavailable = "provider_unavailable"    // 模型供应商服务暂不可用或网络不通
+	ChatErrorCodeModelNotFound       = "model_not_found"         // 模型不存在或没有使用权限
+	ChatErrorCodeContextTooLong      = "context_too_long"        // 消息超过模型的上下文长度
+	ChatErrorCodeContentFiltered     = "content_filtered"        // 内容被模型供应商的安全策略拦截
+	ChatErrorCodeProviderError       = "provider_error"          // 模型供应商返回的其他错误
+	ChatErrorCodeConfigError         = "config_error"            // 智能体的模型配置有误或供应商不可用
+	ChatErrorCodeInternal            = "internal_error"          // 服务内部错误
+)
@@ -0,0 +1,17 @@
+package define
+
+// 注入消息：由服务端注入给模型的上下文（知识库参考资料、会话变量等），作为单独的消息交给模型并保存，
+// 不出现在面向业务侧用户的消息列表和 Webhook 事件中，管理后台导出会话时可查看，便于排查模型回答的依据
+const ChatMessageTypeInjected = "injected" // 注入消息的消息类型
+
+// 注入消息使用的角色
+const (
+	ChatInjectedMessageRoleSystem    = "system"    // 以 system 角色注入（默认），兼容全部模型
+	ChatInjectedMessageRoleDeveloper = "developer" // 以 developer 角色注入，适用于支持该角色的模型
+)
+
+// 注入消息的来源
+const (
+	ChatInjectedMessageSourceKnowledgeRetrieval    = "knowledge_retrieval"    // 知识库检索到的参考资料
+	ChatInjectedMessageSourceConversationVariables = "conversation_variables" // 会话变量
+)
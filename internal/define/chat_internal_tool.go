@@ -0,0 +1,7 @@
+package define
+
+const (
+	ChatInternalToolNamePrefix  = "__lai__"      // 内部工具名称前缀，用于区分内部工具与MCP工具
+	ChatInternalToolTranslate   = "translate"    // 翻译内部工具
+	ChatInternalToolSetVariable = "set_variable" // 设置会话变量内部工具
+)
@@ -0,0 +1,5 @@
+package define
+
+const (
+	ChatReplyLanguageAuto = "auto" // 跟随用户消息识别出的语言回复
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	ChatToolOutputOverflowModeTruncate  = "truncate"  // 截断超出长度上限的工具结果（默认）
+	ChatToolOutputOverflowModeSummarize = "summarize" // 使用会话命名模型摘要超出长度上限的工具结果
+)
@@ -0,0 +1,8 @@
+package define
+
+const (
+	ChatToolOutputRendererTable = "table" // 以表格展示工具结果
+	ChatToolOutputRendererImage = "image" // 以图片展示工具结果
+	ChatToolOutputRendererFile  = "file"  // 以文件下载展示工具结果
+	ChatToolOutputRendererJson  = "json"  // 以格式化的JSON展示工具结果
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	ChatToolTypeMcp      = "mcp"      // MCP工具
+	ChatToolTypeInternal = "internal" // 内部工具
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	ConversationArchiveStatusArchived = "archived" // 已归档：会话数据已写入冷存储并从数据库删除
+	ConversationArchiveStatusRestored = "restored" // 已恢复：会话数据已从冷存储恢复到数据库，归档文件仍保留
+)
@@ -0,0 +1,17 @@
+package define
+
+// 会话事件类型，通过 Webhook 推送给业务系统
+const (
+	ConversationEventTypeConversationCreated = "conversation.created"     // 创建会话
+	ConversationEventTypeMessageCreated      = "message.created"          // 保存普通消息或拦截消息
+	ConversationEventTypeToolCallCreated     = "tool_call.created"        // 保存工具调用消息
+	ConversationEventTypeToolCallOutput      = "tool_call_output.created" // 保存工具调用结果消息
+)
+
+// ConversationEventTypes 全部会话事件类型，Webhook 订阅的事件类型必须在其中
+var ConversationEventTypes = []string{
+	ConversationEventTypeConversationCreated,
+	ConversationEventTypeMessageCreated,
+	ConversationEventTypeToolCallCreated,
+	ConversationEventTypeToolCallOutput,
+}
@@ -0,0 +1,6 @@
+package define
+
+const (
+	ConversationExportFormatJson = "json" // JSON：会话信息和全部消息
+	ConversationExportFormatPdf  = "pdf"  // PDF：带智能体名称和头像的会话记录
+)
@@ -0,0 +1,8 @@
+package define
+
+const (
+	EvaluationAssertionTypeContains    = "contains"     // 回答包含指定文本（不区分大小写）
+	EvaluationAssertionTypeNotContains = "not_contains" // 回答不包含指定文本（不区分大小写）
+	EvaluationAssertionTypeRegex       = "regex"        // 回答匹配正则表达式
+	EvaluationAssertionTypeNotRegex    = "not_regex"    // 回答不匹配正则表达式
+)
@@ -0,0 +1,9 @@
+package define
+
+const (
+	EvaluationCaseChangeImproved  = "improved"  // 变好：由未通过变为通过，或通过状态不变但得分提高
+	EvaluationCaseChangeRegressed = "regressed" // 变差：由通过变为未通过，或通过状态不变但得分降低
+	EvaluationCaseChangeUnchanged = "unchanged" // 不变：通过状态和得分均未变化
+	EvaluationCaseChangeAdded     = "added"     // 新增：用例只在对比运行中
+	EvaluationCaseChangeRemoved   = "removed"   // 移除：用例只在基准运行中
+)
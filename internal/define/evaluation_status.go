@@ -0,0 +1,18 @@
+package define
+
+const (
+	EvaluationRunStatusPending   = "pending"   // 等待执行：评测已提交，尚未开始
+	EvaluationRunStatusRunning   = "running"   // 执行中：正在逐条运行用例并评分
+	EvaluationRunStatusCompleted = "completed" // 已完成：全部用例均已评分
+	EvaluationRunStatusFailed    = "failed"    // 执行失败：智能体或评测模型不可用，失败原因见错误信息
+	EvaluationRunStatusCancelled = "cancelled" // 已取消：未运行的用例不再执行
+)
+
+const (
+	EvaluationResultStatusPending   = "pending"   // 等待运行
+	EvaluationResultStatusRunning   = "running"   // 运行中
+	EvaluationResultStatusPassed    = "passed"    // 通过：全部断言通过且评测模型判定通过
+	EvaluationResultStatusFailed    = "failed"    // 未通过：存在未通过的断言或评测模型判定未通过
+	EvaluationResultStatusError     = "error"     // 运行出错：智能体或评测模型调用失败，计入未通过
+	EvaluationResultStatusCancelled = "cancelled" // 已取消：评测取消时尚未运行
+)
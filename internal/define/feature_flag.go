@@ -0,0 +1,15 @@
+package define
+
+const (
+	FeatureFlagKnowledgeRetrieval       = "knowledge_retrieval"        // 知识库检索：回答前检索智能体绑定的知识库并注入引用
+	FeatureFlagParallelToolCalls        = "parallel_tool_calls"        // 并行工具调用：模型一次返回多个工具调用时并行执行
+	FeatureFlagExperimentalLlmProviders = "experimental_llm_providers" // 试验供应商：允许使用配置为试验中的模型供应商类型
+)
+
+// FeatureFlagDefaultRollouts 各功能开关的默认灰度比例（0-100）
+// 未通过 FEATURE_FLAGS 配置且应用未单独设置时使用，已上线的功能默认全部开启，风险较高的新功能默认关闭
+var FeatureFlagDefaultRollouts = map[string]int{
+	FeatureFlagKnowledgeRetrieval:       100,
+	FeatureFlagParallelToolCalls:        0,
+	FeatureFlagExperimentalLlmProviders: 0,
+}
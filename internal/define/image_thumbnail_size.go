@@ -0,0 +1,4 @@
+package define
+
+// ImageThumbnailSizes 头像、图标上传时生成的标准缩略图尺寸（像素，按最长边缩放）
+var ImageThumbnailSizes = []int{64, 128, 512}
@@ -0,0 +1,6 @@
+package define
+
+const (
+	KnowledgeDocumentSourceTypeText = "text" // 文本：通过接口直接提交的文本内容
+	KnowledgeDocumentSourceTypeFile = "file" // 上传文件：从上传的文本文件读取内容
+)
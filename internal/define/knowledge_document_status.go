@@ -0,0 +1,8 @@
+package define
+
+const (
+	KnowledgeDocumentStatusPending    = "pending"    // 等待处理：文档已保存，尚未开始切分和生成嵌入向量
+	KnowledgeDocumentStatusProcessing = "processing" // 处理中：正在切分文档并生成嵌入向量
+	KnowledgeDocumentStatusCompleted  = "completed"  // 已完成：片段已更新，可以被检索
+	KnowledgeDocumentStatusFailed     = "failed"     // 处理失败：保留上一次成功处理的片段，失败原因见错误信息
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	KnowledgeRetrievalModeVector = "vector" // 向量检索：按问题与片段嵌入向量的余弦相似度检索
+	KnowledgeRetrievalModeHybrid = "hybrid" // 混合检索：同时进行向量检索和 BM25 关键词检索，按倒数排名融合结果
+)
@@ -0,0 +1,78 @@
+package define
+
+// LlmProviderDefinePreset 内置的大语言模型供应商定义
+type LlmProviderDefinePreset struct {
+	Name          string // 供应商名称
+	Description   string // 供应商描述
+	Type          string // 供应商类型
+	IconUrl       string // 供应商图标URL
+	DefaultApiUrl string // 默认API URL
+}
+
+// LlmProviderDefinePresets 内置的常用供应商定义目录
+// 首次启动初始化时写入，也可通过接口或命令行重新加载
+var LlmProviderDefinePresets = []LlmProviderDefinePreset{
+	{
+		Name:          "OpenAI",
+		Description:   "OpenAI 官方接口",
+		Type:          "openai",
+		IconUrl:       "https://openai.com/favicon.ico",
+		DefaultApiUrl: "https://api.openai.com/v1",
+	},
+	{
+		Name:          "Azure OpenAI",
+		Description:   "微软 Azure OpenAI 服务，API URL 需替换为实际的资源地址",
+		Type:          "azure",
+		IconUrl:       "https://azure.microsoft.com/favicon.ico",
+		DefaultApiUrl: "https://{resource}.openai.azure.com/openai/v1",
+	},
+	{
+		Name:          "Anthropic",
+		Description:   "Anthropic Claude（OpenAI 兼容接口）",
+		Type:          "anthropic",
+		IconUrl:       "https://www.anthropic.com/favicon.ico",
+		DefaultApiUrl: "https://api.anthropic.com/v1",
+	},
+	{
+		Name:          "Gemini",
+		Description:   "Google Gemini（OpenAI 兼容接口）",
+		Type:          "gemini",
+		IconUrl:       "https://www.gstatic.com/lamda/images/gemini_favicon_f069958c85030456e93de685481c559f160ea06b.png",
+		DefaultApiUrl: "https://generativelanguage.googleapis.com/v1beta/openai",
+	},
+	{
+		Name:          "DeepSeek",
+		Description:   "DeepSeek 开放平台（OpenAI 兼容接口）",
+		Type:          "deepseek",
+		IconUrl:       "https://www.deepseek.com/favicon.ico",
+		DefaultApiUrl: "https://api.deepseek.com/v1",
+	},
+	{
+		Name:          "Moonshot",
+		Description:   "月之暗面 Kimi 开放平台（OpenAI 兼容接口）",
+		Type:          "moonshot",
+		IconUrl:       "https://www.moonshot.cn/favicon.ico",
+		DefaultApiUrl: "https://api.moonshot.cn/v1",
+	},
+	{
+		Name:          "Ollama",
+		Description:   "本地部署的 Ollama（OpenAI 兼容接口）",
+		Type:          "ollama",
+		IconUrl:       "https://ollama.com/public/ollama.png",
+		DefaultApiUrl: "http://localhost:11434/v1",
+	},
+	{
+		Name:          "火山引擎",
+		Description:   "火山引擎方舟大模型平台（OpenAI 兼容接口）",
+		Type:          "volcengine",
+		IconUrl:       "https://www.volcengine.com/favicon.ico",
+		DefaultApiUrl: "https://ark.cn-beijing.volces.com/api/v3",
+	},
+	{
+		Name:          "Mock",
+		Description:   "模拟提供商，不调用外部接口，返回固定的回答、工具调用、嵌入向量和重排结果，用于本地开发和集成测试",
+		Type:          "mock",
+		IconUrl:       "",
+		DefaultApiUrl: "",
+	},
+}
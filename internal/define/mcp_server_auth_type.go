@@ -0,0 +1,6 @@
+package define
+
+const (
+	McpServerAuthTypeNone  = ""      // 不需要授权，或通过请求头自行携带凭证（默认）
+	McpServerAuthTypeOAuth = "oauth" // 通过 OAuth 授权流程获取访问令牌，仅 sse / streamable-http 连接方式支持
+)
@@ -0,0 +1,6 @@
+package define
+
+const (
+	MessageReceiptStatusDelivered = "delivered" // 已送达：回复已到达业务侧用户的客户端
+	MessageReceiptStatusRead      = "read"      // 已读：业务侧用户已查看回复，同时视为已送达
+)
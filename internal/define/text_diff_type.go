@@ -0,0 +1,8 @@
+package define
+
+const (
+	TextDiffTypeEqual   = "equal"   // 两侧内容相同
+	TextDiffTypeChanged = "changed" // 两侧内容不同
+	TextDiffTypeRemoved = "removed" // 只在原始内容中出现
+	TextDiffTypeAdded   = "added"   // 只在新内容中出现
+)
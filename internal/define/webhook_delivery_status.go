@@ -0,0 +1,7 @@
+package define
+
+const (
+	WebhookDeliveryStatusPending   = "pending"   // 等待推送或等待重试
+	WebhookDeliveryStatusDelivered = "delivered" // 推送成功：Webhook 地址返回 2xx
+	WebhookDeliveryStatusFailed    = "failed"    // 推送失败：重试次数耗尽或 Webhook 已删除
+)
@@ -4,3 +4,13 @@ const (
 	WorkspaceDirNameLlmProviderIcon = "/application_llm_provider_icon/"
 	WorkspaceDirNameChatAgentAvatar = "/chat_agent_avatar/"
 )
+
+// StaticRoutePrefix 工作区公共文件静态访问路由前缀
+// 上传接口返回的 file_path 拼接该前缀即可直接访问，如 /public/chat_agent_avatar/xxx.png
+const StaticRoutePrefix = "/public"
+
+// WorkspaceStaticDirNames 允许通过静态路由只读访问的工作区公共目录
+var WorkspaceStaticDirNames = []string{
+	WorkspaceDirNameLlmProviderIcon,
+	WorkspaceDirNameChatAgentAvatar,
+}
@@ -23,3 +23,59 @@ type ApplicationQueryDto struct {
 	Name        string `json:"name,omitempty"`        // 应用名称（模糊查询）
 	Description string `json:"description,omitempty"` // 应用描述（模糊查询）
 }
+
+// ApplicationDeletionJobDto 应用删除任务数据传输对象
+// 用于查询删除应用后关联数据的清理进度
+type ApplicationDeletionJobDto struct {
+	BaseModelDto
+	ApplicationID  string           `json:"application_id"`  // 被删除的应用ID
+	Status         string           `json:"status"`          // 任务状态：pending running completed
+	TotalSteps     int              `json:"total_steps"`     // 删除步骤总数
+	CompletedSteps int              `json:"completed_steps"` // 已完成的步骤数
+	CurrentStep    string           `json:"current_step"`    // 正在执行的步骤，已完成时为空
+	Progress       float64          `json:"progress"`        // 完成进度（0-1），按步骤计算
+	DeletedCounts  map[string]int64 `json:"deleted_counts"`  // 各步骤已删除的记录数
+	ErrorMessage   string           `json:"error_message"`   // 最近一次执行失败的原因，下次执行时重试
+	StartedAt      *int64           `json:"started_at"`      // 开始执行时间（时间戳）
+	FinishedAt     *int64           `json:"finished_at"`     // 结束时间（时间戳）
+}
+
+// ApplicationSettingDto 应用设置DTO
+// 应用未保存过设置时返回默认值，updated_at 为空
+type ApplicationSettingDto struct {
+	ApplicationID     string   `json:"application_id"`      // 所属应用ID
+	DisplayName       string   `json:"display_name"`        // 对外展示名称，为空时使用应用名称
+	LogoUrl           string   `json:"logo_url"`            // Logo地址
+	ThemeColor        string   `json:"theme_color"`         // 主题色，#RRGGBB格式
+	DefaultLanguage   string   `json:"default_language"`    // 默认界面语言，如 zh、en
+	DataRetentionDays int      `json:"data_retention_days"` // 会话数据保留天数，0表示永久保留
+	BlockedPatterns   []string `json:"blocked_patterns"`    // 用户输入拦截规则，正则表达式列表，忽略大小写匹配
+	BlockedReply      string   `json:"blocked_reply"`       // 用户输入被拦截时的回复，为空时使用默认回复
+	UpdatedAt         *int64   `json:"updated_at"`          // 更新时间（时间戳），未保存过设置时为空
+}
+
+// ApplicationSettingSaveDto 应用设置保存DTO
+// 整体覆盖应用设置，未填写的字段恢复为默认值
+type ApplicationSettingSaveDto struct {
+	DisplayName       string   `json:"display_name"`        // 对外展示名称
+	LogoUrl           string   `json:"logo_url"`            // Logo地址，http 或 https
+	ThemeColor        string   `json:"theme_color"`         // 主题色，#RRGGBB格式
+	DefaultLanguage   string   `json:"default_language"`    // 默认界面语言
+	DataRetentionDays int      `json:"data_retention_days"` // 会话数据保留天数，0表示永久保留
+	BlockedPatterns   []string `json:"blocked_patterns"`    // 用户输入拦截规则，正则表达式列表，普通关键词直接填写即可
+	BlockedReply      string   `json:"blocked_reply"`       // 用户输入被拦截时的回复，为空时使用默认回复
+}
+
+// ApplicationFeatureFlagDto 应用功能开关状态DTO
+// 应用单独设置了开关时以应用设置为准，否则按全局灰度比例决定
+type ApplicationFeatureFlagDto struct {
+	Flag           string `json:"flag"`            // 功能开关名称
+	Enabled        bool   `json:"enabled"`         // 对该应用是否开启
+	Overridden     bool   `json:"overridden"`      // 是否为应用单独设置的值
+	RolloutPercent int    `json:"rollout_percent"` // 全局灰度比例（0-100）
+}
+
+// ApplicationFeatureFlagSaveDto 应用功能开关设置DTO
+type ApplicationFeatureFlagSaveDto struct {
+	Enabled *bool `json:"enabled" binding:"required"` // 是否开启
+}
@@ -21,8 +21,8 @@ type ApplicationLlmDto struct {
 	BillingCurrency       string  `json:"billing_currency"`
 	BillingPriceInput     float64 `json:"billing_price_input"`
 	BillingPriceOutput    float64 `json:"billing_price_output"`
-	CreatedAt             string  `json:"created_at"`
-	UpdatedAt             string  `json:"updated_at"`
+	CreatedAt             int64   `json:"created_at"` // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt             int64   `json:"updated_at"` // 更新时间，Unix 13位毫秒时间戳
 }
 
 // SaveApplicationLlmRequest 保存应用模型请求
@@ -5,39 +5,62 @@ package dto
 // ApplicationMcpServerConfigDto ApplicationMCP配置 数据传输对象
 // 用于在业务逻辑层和HTTP处理层之间传递数据
 type ApplicationMcpServerConfigDto struct {
-	ID                   string `json:"id"`                      // 主键ID
-	ApplicationID        string `json:"application_id"`          // 所属应用ID
-	ConfigID             string `json:"config_id"`               // 配置ID
-	Name                 string `json:"name"`                    // 名称
-	Description          string `json:"description"`             // 描述
-	Version              string `json:"version"`                 // 版本
-	McpServerConnectType string `json:"mcp_server_connect_type"` // MCP服务连接方式
-	McpServerTimeout     int    `json:"mcp_server_timeout"`      // MCP服务超时时间
-	McpServerUrl         string `json:"mcp_server_url"`          // MCP服务URL
-	McpServerHeader      string `json:"mcp_server_header"`       // MCP服务请求头
-	McpServerCommand     string `json:"mcp_server_command"`      // MCP服务命令
-	McpServerArgs        string `json:"mcp_server_args"`         // MCP服务参数
-	McpServerEnv         string `json:"mcp_server_env"`          // MCP服务环境变量
-	CreatedAt            string `json:"created_at"`              // 创建时间
-	UpdatedAt            string `json:"updated_at"`              // 更新时间
+	ID                        string `json:"id"`                            // 主键ID
+	ApplicationID             string `json:"application_id"`                // 所属应用ID
+	ConfigID                  string `json:"config_id"`                     // 配置ID
+	Name                      string `json:"name"`                          // 名称
+	Description               string `json:"description"`                   // 描述
+	Version                   string `json:"version"`                       // 版本
+	McpServerConnectType      string `json:"mcp_server_connect_type"`       // MCP服务连接方式
+	McpServerTimeout          int    `json:"mcp_server_timeout"`            // MCP服务超时时间
+	McpServerUrl              string `json:"mcp_server_url"`                // MCP服务URL
+	McpServerHeader           string `json:"mcp_server_header"`             // MCP服务请求头
+	McpServerAuthType         string `json:"mcp_server_auth_type"`          // MCP服务授权方式：空 不需要授权，oauth OAuth授权
+	McpOauthClientID          string `json:"mcp_oauth_client_id"`           // OAuth客户端ID
+	McpOauthClientSecretSet   bool   `json:"mcp_oauth_client_secret_set"`   // 是否已保存OAuth客户端密钥，密钥本身不返回
+	McpOauthScopes            string `json:"mcp_oauth_scopes"`              // OAuth授权范围，空格分隔
+	McpOauthServerMetadataURL string `json:"mcp_oauth_server_metadata_url"` // OAuth授权服务器元数据地址
+	McpServerCommand          string `json:"mcp_server_command"`            // MCP服务命令
+	McpServerArgs             string `json:"mcp_server_args"`               // MCP服务参数
+	McpServerEnv              string `json:"mcp_server_env"`                // MCP服务环境变量，每行一个 KEY=VALUE
+	McpServerWorkDir          string `json:"mcp_server_work_dir"`           // MCP服务工作目录
+	McpServerCpuLimit         int    `json:"mcp_server_cpu_limit"`          // MCP服务进程的CPU时间上限（秒），0 表示不限制
+	McpServerMemoryLimit      int    `json:"mcp_server_memory_limit"`       // MCP服务进程的虚拟内存上限（MB），0 表示不限制
+	McpServerMaxRuntime       int    `json:"mcp_server_max_runtime"`        // MCP服务进程的最长运行时间（秒），0 表示不限制
+	McpServerUserNamespace    bool   `json:"mcp_server_user_namespace"`     // 是否在独立的用户命名空间中运行，仅 Linux 支持
+	McpServerDisableNetwork   bool   `json:"mcp_server_disable_network"`    // 是否禁止网络访问，需同时启用用户命名空间
+	CreatedAt                 int64  `json:"created_at"`                    // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt                 int64  `json:"updated_at"`                    // 更新时间，Unix 13位毫秒时间戳
 }
 
 // SaveApplicationMcpServerConfigRequest 保存应用MCP配置请求
 // 用于接收前端保存MCP配置的请求数据
 type SaveApplicationMcpServerConfigRequest struct {
-	ID                   *string `json:"id,omitempty"`            // 主键ID，为空时新增，有值时更新
-	ApplicationID        string  `json:"application_id"`          // 所属应用ID
-	ConfigID             string  `json:"config_id"`               // 配置ID
-	Name                 string  `json:"name"`                    // 名称
-	Description          string  `json:"description"`             // 描述
-	Version              string  `json:"version"`                 // 版本
-	McpServerConnectType string  `json:"mcp_server_connect_type"` // MCP服务连接方式
-	McpServerTimeout     int     `json:"mcp_server_timeout"`      // MCP服务超时时间
-	McpServerUrl         string  `json:"mcp_server_url"`          // MCP服务URL
-	McpServerHeader      string  `json:"mcp_server_header"`       // MCP服务请求头
-	McpServerCommand     string  `json:"mcp_server_command"`      // MCP服务命令
-	McpServerArgs        string  `json:"mcp_server_args"`         // MCP服务参数
-	McpServerEnv         string  `json:"mcp_server_env"`          // MCP服务环境变量
+	ID                        *string  `json:"id,omitempty"`                  // 主键ID，为空时新增，有值时更新
+	ApplicationID             string   `json:"application_id"`                // 所属应用ID
+	ConfigID                  string   `json:"config_id"`                     // 配置ID
+	Name                      string   `json:"name"`                          // 名称
+	Description               string   `json:"description"`                   // 描述
+	Version                   string   `json:"version"`                       // 版本
+	McpServerConnectType      string   `json:"mcp_server_connect_type"`       // MCP服务连接方式
+	McpServerTimeout          int      `json:"mcp_server_timeout"`            // MCP服务超时时间
+	McpServerUrl              string   `json:"mcp_server_url"`                // MCP服务URL
+	McpServerHeader           string   `json:"mcp_server_header"`             // MCP服务请求头
+	McpServerAuthType         string   `json:"mcp_server_auth_type"`          // MCP服务授权方式：空 不需要授权，oauth OAuth授权（仅 sse / streamable-http 支持）
+	McpOauthClientID          string   `json:"mcp_oauth_client_id"`           // OAuth客户端ID，为空时首次授权通过动态注册获取
+	McpOauthClientSecret      string   `json:"mcp_oauth_client_secret"`       // OAuth客户端密钥，加密保存；完整更新时为空表示保持当前密钥
+	McpOauthScopes            string   `json:"mcp_oauth_scopes"`              // OAuth授权范围，空格分隔
+	McpOauthServerMetadataURL string   `json:"mcp_oauth_server_metadata_url"` // OAuth授权服务器元数据地址，为空时根据MCP服务URL自动发现
+	McpServerCommand          string   `json:"mcp_server_command"`            // MCP服务命令
+	McpServerArgs             string   `json:"mcp_server_args"`               // MCP服务参数
+	McpServerEnv              string   `json:"mcp_server_env"`                // MCP服务环境变量，每行一个 KEY=VALUE
+	McpServerWorkDir          string   `json:"mcp_server_work_dir"`           // MCP服务工作目录，需为绝对路径，为空时使用服务进程的工作目录
+	McpServerCpuLimit         int      `json:"mcp_server_cpu_limit"`          // MCP服务进程的CPU时间上限（秒），0 表示不限制
+	McpServerMemoryLimit      int      `json:"mcp_server_memory_limit"`       // MCP服务进程的虚拟内存上限（MB），0 表示不限制
+	McpServerMaxRuntime       int      `json:"mcp_server_max_runtime"`        // MCP服务进程的最长运行时间（秒），0 表示不限制
+	McpServerUserNamespace    bool     `json:"mcp_server_user_namespace"`     // 是否在独立的用户命名空间中运行，仅 Linux 支持
+	McpServerDisableNetwork   bool     `json:"mcp_server_disable_network"`    // 是否禁止网络访问，需同时启用用户命名空间
+	UpdateFields              []string `json:"update_fields,omitempty"`       // 部分更新的字段列表（JSON 字段名），更新时提供则只修改列出的字段，其余字段保持不变
 }
 
 // ApplicationMcpServerConfigListResponse ApplicationMCP配置列表响应
@@ -51,3 +74,62 @@ type ApplicationMcpServerConfigListResponse struct {
 type SingleApplicationMcpServerConfigResponse struct {
 	ApplicationMcpServerConfig ApplicationMcpServerConfigDto `json:"application_mcp_server_config"` // MCP配置
 }
+
+// ApplicationMcpServerConfigDeletionDto 删除MCP配置的结果
+// 配置下的工具和智能体的工具设置随配置一起删除
+type ApplicationMcpServerConfigDeletionDto struct {
+	DeletedToolCount      int64    `json:"deleted_tool_count"`       // 删除的工具数量
+	DeletedAgentToolCount int64    `json:"deleted_agent_tool_count"` // 删除的智能体工具设置数量
+	AffectedChatAgentIDs  []string `json:"affected_chat_agent_ids"`  // 工具设置被删除的智能体ID
+}
+
+// McpOauthStatusDto MCP配置的OAuth授权状态
+type McpOauthStatusDto struct {
+	ConfigID    string `json:"config_id"`   // MCP配置ID
+	Authorized  bool   `json:"authorized"`  // 是否已保存令牌
+	Refreshable bool   `json:"refreshable"` // 是否有刷新令牌，访问令牌过期后可自动续期
+	Scope       string `json:"scope"`       // 授权服务器返回的授权范围
+	ExpiresAt   int64  `json:"expires_at"`  // 访问令牌过期时间，Unix 13位毫秒时间戳，0 表示不过期或未授权
+	Pending     bool   `json:"pending"`     // 是否有未完成且未过期的授权请求
+}
+
+// McpServerJsonEntry 标准 mcpServers JSON 中的单个MCP服务
+// 有 command 时为 stdio 连接，有 url 时按 type 区分 sse 和 streamable-http
+type McpServerJsonEntry struct {
+	Type        string            `json:"type,omitempty"`        // 连接方式：stdio、sse、http（streamable-http），为空时根据 command 和 url 推断
+	Command     string            `json:"command,omitempty"`     // stdio 启动命令
+	Args        []string          `json:"args,omitempty"`        // stdio 命令参数
+	Env         map[string]string `json:"env,omitempty"`         // stdio 环境变量
+	Cwd         string            `json:"cwd,omitempty"`         // stdio 工作目录
+	URL         string            `json:"url,omitempty"`         // sse / streamable-http 服务URL
+	ServerURL   string            `json:"serverUrl,omitempty"`   // 部分客户端使用的服务URL字段，url 为空时使用
+	Headers     map[string]string `json:"headers,omitempty"`     // sse / streamable-http 请求头
+	Timeout     int               `json:"timeout,omitempty"`     // 超时时间（秒）
+	Description string            `json:"description,omitempty"` // 描述，为空时使用默认描述
+	Disabled    bool              `json:"disabled,omitempty"`    // 是否已禁用，禁用的服务不导入
+}
+
+// ImportMcpServersRequest 从 mcpServers JSON 导入MCP配置请求
+// 请求体为标准 mcpServers JSON 加上所属应用ID
+type ImportMcpServersRequest struct {
+	ApplicationID string                        `json:"application_id"` // 所属应用ID
+	McpServers    map[string]McpServerJsonEntry `json:"mcpServers"`     // MCP服务，键为服务名称
+	SkipExisting  bool                          `json:"skip_existing"`  // 应用中已有同名配置时是否跳过，为 false 时整个导入失败
+}
+
+// ExportMcpServersDto 导出的标准 mcpServers JSON
+type ExportMcpServersDto struct {
+	McpServers map[string]McpServerJsonEntry `json:"mcpServers"` // MCP服务，键为服务名称
+}
+
+// ImportMcpServersResultDto 导入MCP配置的结果
+type ImportMcpServersResultDto struct {
+	Created []ApplicationMcpServerConfigDto `json:"created"` // 新建的MCP配置
+	Skipped []ImportMcpServerSkippedDto     `json:"skipped"` // 跳过的MCP服务
+}
+
+// ImportMcpServerSkippedDto 导入时跳过的MCP服务
+type ImportMcpServerSkippedDto struct {
+	Name   string `json:"name"`   // 服务名称
+	Reason string `json:"reason"` // 跳过原因：disabled 已禁用，exists 已有同名配置
+}
@@ -11,6 +11,6 @@ type ApplicationMcpServerToolDto struct {
 	Name                         string `json:"name"`                             // 名称
 	Title                        string `json:"title"`                            // 工具标题
 	Description                  string `json:"description"`                      // 描述
-	CreatedAt                    string `json:"created_at"`                       // 创建时间
-	UpdatedAt                    string `json:"updated_at"`                       // 更新时间
+	CreatedAt                    int64  `json:"created_at"`                       // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt                    int64  `json:"updated_at"`                       // 更新时间，Unix 13位毫秒时间戳
 }
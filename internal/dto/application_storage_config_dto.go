@@ -17,8 +17,8 @@ type ApplicationStorageConfigDto struct {
 	SecretId   string `json:"secret_id"`   // S3存储安全ID
 	SecretKey  string `json:"secret_key"`  // S3存储密钥
 	KeyPrefix  string `json:"key_prefix"`  // S3存储文件key前缀
-	CreatedAt  string `json:"created_at"`  // 创建时间
-	UpdatedAt  string `json:"updated_at"`  // 更新时间
+	CreatedAt  int64  `json:"created_at"`  // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt  int64  `json:"updated_at"`  // 更新时间，Unix 13位毫秒时间戳
 }
 
 // SaveApplicationStorageConfigRequest 保存应用存储配置请求
@@ -0,0 +1,27 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// ApplicationToolBundleDto 应用工具集数据传输对象
+type ApplicationToolBundleDto struct {
+	BaseModelDto
+	ApplicationID string   `json:"application_id"`           // 所属应用ID
+	Name          string   `json:"name"`                     // 工具集名称
+	Description   string   `json:"description"`              // 描述
+	ToolIDs       []string `json:"tool_ids,omitempty"`       // 包含的MCP工具ID，仅在获取单个工具集时返回
+	ChatAgentIDs  []string `json:"chat_agent_ids,omitempty"` // 关联的智能体ID，仅在获取单个工具集时返回
+}
+
+// SaveApplicationToolBundleRequest 保存应用工具集请求
+type SaveApplicationToolBundleRequest struct {
+	ID            *string  `json:"id,omitempty"`   // 主键ID（更新时提供）
+	ApplicationID string   `json:"application_id"` // 所属应用ID
+	Name          string   `json:"name"`           // 工具集名称，同一应用内唯一
+	Description   string   `json:"description"`    // 描述
+	ToolIDs       []string `json:"tool_ids"`       // 包含的MCP工具ID，保存时整体替换
+}
+
+// ApplicationToolBundleChatAgentsRequest 关联或取消关联智能体请求
+type ApplicationToolBundleChatAgentsRequest struct {
+	ChatAgentIDs []string `json:"chat_agent_ids"` // 智能体ID列表
+}
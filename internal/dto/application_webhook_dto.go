@@ -0,0 +1,80 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+import "encoding/json"
+
+// ApplicationWebhookDto 应用 Webhook 数据传输对象
+type ApplicationWebhookDto struct {
+	BaseModelDto
+	ApplicationID string   `json:"application_id"` // 所属应用ID
+	Name          string   `json:"name"`           // 名称
+	Url           string   `json:"url"`            // 推送地址
+	Secret        string   `json:"secret"`         // 签名密钥，用于校验请求头 X-Lemon-Signature
+	EventTypes    []string `json:"event_types"`    // 订阅的事件类型，为空表示订阅全部事件
+	Enabled       bool     `json:"enabled"`        // 是否启用
+}
+
+// SaveApplicationWebhookRequest 保存应用 Webhook 请求
+type SaveApplicationWebhookRequest struct {
+	ID               *string  `json:"id,omitempty"`      // 主键ID（更新时提供）
+	ApplicationID    string   `json:"application_id"`    // 所属应用ID
+	Name             string   `json:"name"`              // 名称
+	Url              string   `json:"url"`               // 推送地址，http 或 https
+	EventTypes       []string `json:"event_types"`       // 订阅的事件类型，为空表示订阅全部事件
+	Enabled          *bool    `json:"enabled"`           // 是否启用，不填时为启用
+	RegenerateSecret bool     `json:"regenerate_secret"` // 更新时是否重新生成签名密钥，新增时总是生成
+}
+
+// ApplicationWebhookDeliveryDto Webhook 推送记录数据传输对象
+type ApplicationWebhookDeliveryDto struct {
+	BaseModelDto
+	WebhookID      string `json:"webhook_id"`       // Webhook ID
+	EventID        string `json:"event_id"`         // 会话事件ID，与请求头 X-Lemon-Event-Id 一致
+	EventType      string `json:"event_type"`       // 事件类型
+	Status         string `json:"status"`           // 推送状态：pending 等待推送，delivered 推送成功，failed 推送失败
+	Attempts       int    `json:"attempts"`         // 已推送次数
+	NextAttemptAt  int64  `json:"next_attempt_at"`  // 下次推送时间（时间戳），仅在等待推送时有意义
+	LastStatusCode int    `json:"last_status_code"` // 最近一次推送的HTTP状态码，请求失败时为0
+	LastError      string `json:"last_error"`       // 最近一次推送失败的原因
+	DeliveredAt    *int64 `json:"delivered_at"`     // 推送成功时间（时间戳）
+}
+
+// ConversationEventDto 推送给 Webhook 的会话事件
+// 同一事件可能推送多次，接收方应按 id 去重
+type ConversationEventDto struct {
+	ID             string          `json:"id"`              // 事件ID
+	Type           string          `json:"type"`            // 事件类型：conversation.created、message.created、tool_call.created、tool_call_output.created
+	ApplicationID  string          `json:"application_id"`  // 所属应用ID
+	ChatAgentID    string          `json:"chat_agent_id"`   // 所属智能体ID
+	ConversationID string          `json:"conversation_id"` // 所属会话ID
+	CreatedAt      int64           `json:"created_at"`      // 事件发生时间（时间戳）
+	Data           json.RawMessage `json:"data"`            // 事件数据，conversation.created 为 ConversationEventConversationData，其他为 ConversationEventMessageData
+}
+
+// ConversationEventConversationData 会话创建事件的数据
+type ConversationEventConversationData struct {
+	ID            string `json:"id"`              // 会话ID
+	Title         string `json:"title"`           // 会话标题
+	ServiceUserID string `json:"service_user_id"` // 业务侧用户ID
+	CreatedAt     int64  `json:"created_at"`      // 创建时间（时间戳）
+}
+
+// ConversationEventMessageData 消息保存事件的数据
+type ConversationEventMessageData struct {
+	ID                    string `json:"id"`                                // 消息ID
+	RequestID             string `json:"request_id"`                        // 请求ID
+	Type                  string `json:"type"`                              // 消息类型：message、blocked、function_call、function_call_output
+	Role                  string `json:"role"`                              // 消息角色
+	Content               string `json:"content"`                           // 消息内容
+	Language              string `json:"language,omitempty"`                // 消息语言
+	TruncatedReason       string `json:"truncated_reason,omitempty"`        // 回答截断原因
+	FunctionCallID        string `json:"function_call_id,omitempty"`        // 函数调用ID
+	FunctionCallName      string `json:"function_call_name,omitempty"`      // 函数调用名称
+	FunctionCallArguments string `json:"function_call_arguments,omitempty"` // 函数调用参数
+	FunctionCallOutput    string `json:"function_call_output,omitempty"`    // 函数调用返回值
+	PromptTokenCount      int    `json:"prompt_token_count"`                // 提示词token数
+	CompletionTokenCount  int    `json:"completion_token_count"`            // 回复token数
+	TotalTokenCount       int    `json:"total_token_count"`                 // 总token数
+	CreatedAt             int64  `json:"created_at"`                        // 创建时间（时间戳）
+}
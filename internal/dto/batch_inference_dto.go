@@ -0,0 +1,58 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// BatchInferenceJobDto 批量推理任务数据传输对象
+type BatchInferenceJobDto struct {
+	BaseModelDto
+	ApplicationID  string `json:"application_id"`  // 所属应用ID
+	ChatAgentID    string `json:"chat_agent_id"`   // 执行任务的智能体ID
+	Name           string `json:"name"`            // 任务名称
+	SystemPrompt   string `json:"system_prompt"`   // 附加的系统提示词
+	Concurrency    int    `json:"concurrency"`     // 同时处理的提示词数量
+	Status         string `json:"status"`          // 任务状态：pending running completed failed cancelled
+	TotalCount     int    `json:"total_count"`     // 提示词总数
+	CompletedCount int    `json:"completed_count"` // 已成功处理的数量
+	FailedCount    int    `json:"failed_count"`    // 处理失败的数量
+	ErrorMessage   string `json:"error_message"`   // 任务执行失败的原因
+	StartedAt      *int64 `json:"started_at"`      // 开始执行时间（时间戳）
+	FinishedAt     *int64 `json:"finished_at"`     // 结束时间（时间戳）
+}
+
+// BatchInferenceItemDto 批量推理提示词及处理结果
+type BatchInferenceItemDto struct {
+	BaseModelDto
+	JobID          string `json:"job_id"`          // 所属任务ID
+	ItemIndex      int    `json:"item_index"`      // 提示词在提交内容中的序号，从0开始
+	Prompt         string `json:"prompt"`          // 提示词
+	Status         string `json:"status"`          // 处理状态：pending running completed failed cancelled
+	Answer         string `json:"answer"`          // 智能体的回答
+	ConversationID string `json:"conversation_id"` // 处理时创建的会话ID
+	ErrorMessage   string `json:"error_message"`   // 处理失败的原因
+	DurationMs     int64  `json:"duration_ms"`     // 处理耗时（毫秒）
+}
+
+// CreateBatchInferenceJobRequest 创建批量推理任务请求
+type CreateBatchInferenceJobRequest struct {
+	ChatAgentID  string   `json:"chat_agent_id"` // 执行任务的智能体ID
+	Name         string   `json:"name"`          // 任务名称（可选）
+	SystemPrompt string   `json:"system_prompt"` // 附加的系统提示词（可选），与聊天接口的系统提示词含义相同
+	Concurrency  int      `json:"concurrency"`   // 同时处理的提示词数量（可选），默认2，最大8，同时受服务端全局上限限制
+	Prompts      []string `json:"prompts"`       // 提示词列表，最多1000条，每条提示词在独立的会话中发送
+}
+
+// BatchInferenceJobListResponse 批量推理任务列表响应
+type BatchInferenceJobListResponse struct {
+	Jobs     []BatchInferenceJobDto `json:"jobs"`      // 任务列表
+	Total    int64                  `json:"total"`     // 符合条件的总数量
+	Page     int                    `json:"page"`      // 当前页码
+	PageSize int                    `json:"page_size"` // 每页大小
+}
+
+// BatchInferenceItemListResponse 批量推理提示词列表响应
+type BatchInferenceItemListResponse struct {
+	Items    []BatchInferenceItemDto `json:"items"`     // 提示词列表，按序号排列
+	Total    int64                   `json:"total"`     // 符合条件的总数量
+	Page     int                     `json:"page"`      // 当前页码
+	PageSize int                     `json:"page_size"` // 每页大小
+}
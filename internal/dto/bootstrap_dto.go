@@ -0,0 +1,13 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// BootstrapResult 首次启动初始化结果
+type BootstrapResult struct {
+	AdminUserCreated       bool    `json:"admin_user_created"`       // 是否创建了管理员用户
+	AdminNumber            string  `json:"admin_number"`             // 管理员账号
+	GeneratedAdminPassword *string `json:"generated_admin_password"` // 自动生成的管理员密码，仅在未配置密码时返回一次
+	ApplicationCreated     bool    `json:"application_created"`      // 是否创建了默认应用
+	ApplicationID          *string `json:"application_id"`           // 默认应用ID
+	ProviderDefinesCreated int     `json:"provider_defines_created"` // 新增的供应商定义数量
+}
@@ -0,0 +1,44 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// ChatAgentAnswerRuleDto 预制答案规则数据传输对象
+type ChatAgentAnswerRuleDto struct {
+	BaseModelDto
+	ApplicationID      string  `json:"application_id"`      // 所属应用ID
+	ChatAgentID        string  `json:"chat_agent_id"`       // 所属智能体ID
+	Name               string  `json:"name"`                // 规则名称
+	MatchType          string  `json:"match_type"`          // 匹配方式：keyword 关键词，regex 正则，embedding 语义相似度
+	Pattern            string  `json:"pattern"`             // 匹配内容：关键词（换行或逗号分隔）、正则表达式或示例问题
+	Answer             string  `json:"answer"`              // 预制答案
+	Priority           int     `json:"priority"`            // 优先级，数值越大越先匹配
+	Enabled            bool    `json:"enabled"`             // 是否启用
+	EmbeddingModelID   string  `json:"embedding_model_id"`  // 语义匹配使用的嵌入模型ID
+	EmbeddingThreshold float64 `json:"embedding_threshold"` // 语义匹配的相似度阈值（0-1）
+}
+
+// SaveChatAgentAnswerRuleRequest 保存预制答案规则请求
+type SaveChatAgentAnswerRuleRequest struct {
+	ID                 *string  `json:"id,omitempty"`        // 主键ID（更新时提供）
+	ChatAgentID        string   `json:"chat_agent_id"`       // 所属智能体ID
+	Name               string   `json:"name"`                // 规则名称
+	MatchType          string   `json:"match_type"`          // 匹配方式：keyword 关键词，regex 正则，embedding 语义相似度
+	Pattern            string   `json:"pattern"`             // 匹配内容：关键词（换行或逗号分隔）、正则表达式或示例问题
+	Answer             string   `json:"answer"`              // 预制答案
+	Priority           int      `json:"priority"`            // 优先级，数值越大越先匹配
+	Enabled            bool     `json:"enabled"`             // 是否启用
+	EmbeddingModelID   string   `json:"embedding_model_id"`  // 语义匹配使用的嵌入模型ID（语义匹配时必填）
+	EmbeddingThreshold *float64 `json:"embedding_threshold"` // 语义匹配的相似度阈值（可选，默认0.85）
+}
+
+// TestChatAgentAnswerRuleRequest 测试预制答案规则请求
+type TestChatAgentAnswerRuleRequest struct {
+	ChatAgentID string `json:"chat_agent_id"` // 智能体ID
+	UserMessage string `json:"user_message"`  // 用于测试的用户消息
+}
+
+// TestChatAgentAnswerRuleResponse 测试预制答案规则响应
+type TestChatAgentAnswerRuleResponse struct {
+	Matched bool                    `json:"matched"` // 是否命中规则
+	Rule    *ChatAgentAnswerRuleDto `json:"rule"`    // 命中的规则，未命中时为空
+}
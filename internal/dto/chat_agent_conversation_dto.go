@@ -18,11 +18,46 @@ type FunctionCallDto struct {
 // ChatMessageResponseEventDto 聊天消息响应事件
 // 用于流式返回聊天消息更新
 type ChatMessageResponseEventDto struct {
-	ConversationID string       `json:"conversation_id"`     // 会话ID
-	RequestID      string       `json:"request_id"`          // 请求ID
-	MessageType    string       `json:"message_type"`        // 消息类型：answer answer_delta 消息回复，tool_call tool_call_processing tool_call_end 工具调用
-	Content        string       `json:"content"`             // 内容：answer，内容为消息内容；当消息类型为tool_call时，内容为调用的工具名字
-	ToolCall       *ToolCallDto `json:"tool_call,omitempty"` // 工具调用信息
+	ConversationID string            `json:"conversation_id"`          // 会话ID
+	RequestID      string            `json:"request_id"`               // 请求ID
+	MessageType    string            `json:"message_type"`             // 消息类型：answer answer_delta 消息回复，tool_call tool_call_processing tool_call_end 工具调用，attachment_created 工具生成的文件，citations 知识库引用，suggestions 追问建议，truncated 回答超过生成时间或输出Token上限被截断（内容为截断原因，随后的 answer 为截断后的回答），blocked 用户消息命中应用的输入拦截规则（内容为拦截回复，随后的 answer 内容相同），error 处理出错（内容为错误提示）
+	Content        string            `json:"content"`                  // 内容：answer，内容为消息内容；当消息类型为tool_call时，内容为调用的工具名字
+	ToolCall       *ToolCallDto      `json:"tool_call,omitempty"`      // 工具调用信息
+	Citations      []ChatCitationDto `json:"citations,omitempty"`      // 知识库引用，仅在消息类型为citations时返回
+	Suggestions    []string          `json:"suggestions,omitempty"`    // 追问建议，仅在消息类型为suggestions时返回
+	QueuePosition  int               `json:"queue_position,omitempty"` // 排队位置，从1开始，仅在消息类型为queued时返回
+	// 错误码，仅在消息类型为 error 时返回，如 provider_auth_failed、provider_quota_exceeded、context_too_long、content_filtered，前端可据此给出处理建议
+	ErrorCode string `json:"error_code,omitempty"`
+	// 工具结果的渲染方式（table、image、file、json），仅在消息类型为 tool_result 或 tool_call_end 且智能体为工具设置了渲染方式时返回
+	OutputRenderer string `json:"output_renderer,omitempty"`
+	// 工具结果，仅在消息类型为 tool_call_end 且返回了渲染方式时返回，供聊天界面渲染
+	ToolOutput string `json:"tool_output,omitempty"`
+	// 工具生成的文件保存成的附件，仅在消息类型为 attachment_created 时返回，此时内容为生成文件的工具名字
+	Attachment *ChatToolAttachmentDto `json:"attachment,omitempty"`
+}
+
+// ChatToolAttachmentDto 工具生成的文件保存成的会话附件
+type ChatToolAttachmentDto struct {
+	ID             string `json:"id"`              // 附件ID
+	FileName       string `json:"file_name"`       // 文件名
+	MimeType       string `json:"mime_type"`       // MIME类型
+	FileSize       int64  `json:"file_size"`       // 文件大小（字节）
+	AttachmentType string `json:"attachment_type"` // 附件类型：document、image 或 other
+	DownloadURL    string `json:"download_url"`    // 下载地址，请求时需携带与聊天接口相同的认证信息
+}
+
+// ChatCitationDto 回答引用的知识库片段
+// 回答前注入提示词的每个片段对应一条引用，编号与回答中的 [编号] 标注一致
+type ChatCitationDto struct {
+	Index           int     `json:"index"`             // 引用编号，从1开始
+	KnowledgeBaseID string  `json:"knowledge_base_id"` // 知识库ID
+	DocumentID      string  `json:"document_id"`       // 文档ID
+	DocumentName    string  `json:"document_name"`     // 文档名称
+	ChunkID         string  `json:"chunk_id"`          // 片段ID
+	ChunkIndex      int     `json:"chunk_index"`       // 片段在文档中的序号
+	Content         string  `json:"content"`           // 片段内容
+	Score           float64 `json:"score"`             // 与问题的相似度
+	Cited           bool    `json:"cited"`             // 回答中是否标注了该引用
 }
 
 // ChatMessageUseToolDto 聊天消息使用工具
@@ -37,10 +72,58 @@ type ChatUserSendMessageRequest struct {
 	SystemPrompt         string                  `json:"system_prompt"`           // 系统提示词
 	UserMessage          string                  `json:"user_message"`            // 用户消息
 	PredefinedAnswer     *string                 `json:"predefined_answer"`       // 预制答案（可选）
-	UsedMcpToolList      []ChatMessageUseToolDto `json:"used_mcp_tool_list"`      // 使用的MCP工具列表
+	UsedMcpToolList      []ChatMessageUseToolDto `json:"used_mcp_tool_list"`      // 使用的MCP工具列表（可选），为空时使用智能体启用的全部工具，只能指定智能体已启用的工具
 	UsedInternalToolList []string                `json:"used_internal_tool_list"` // 使用的内部工具列表
 	ConversationID       *string                 `json:"conversation_id"`         // 会话ID（可选）
 	Attachments          []string                `json:"attachments"`             // 附件ID列表（可选）
+	RequestID            *string                 `json:"request_id"`              // 客户端指定的请求ID（可选），同一会话内必须唯一，用于重复请求检测
+	DeltaChunkMode       *string                 `json:"delta_chunk_mode"`        // 增量输出分块模式（可选）：raw 原样输出（默认），markdown_safe 在Markdown安全边界处合并输出
+}
+
+// ChatBootstrapResponse 聊天首屏信息响应
+// 嵌入页面打开时用于渲染智能体信息、欢迎语和推荐问题
+type ChatBootstrapResponse struct {
+	ChatAgentID        string                `json:"chat_agent_id"`       // 智能体ID
+	Name               string                `json:"name"`                // 智能体名称
+	Description        string                `json:"description"`         // 智能体描述
+	AvatarUrl          string                `json:"avatar_url"`          // 智能体头像URL
+	WelcomeMessage     string                `json:"welcome_message"`     // 欢迎语
+	SuggestedQuestions []string              `json:"suggested_questions"` // 推荐问题列表
+	DefaultStreamable  bool                  `json:"default_streamable"`  // 是否默认流式返回
+	Available          bool                  `json:"available"`           // 当前是否可用（未处于维护模式且在服务时间内）
+	UnavailableMessage string                `json:"unavailable_message"` // 不可用时的提示，可用时为空
+	Branding           ChatBootstrapBranding `json:"branding"`            // 所属应用的品牌展示信息
+}
+
+// ChatBootstrapBranding 聊天首屏的应用品牌展示信息
+// 来自应用设置，供嵌入式聊天窗口展示
+type ChatBootstrapBranding struct {
+	DisplayName     string `json:"display_name"`     // 应用展示名称，未设置时为应用名称
+	LogoUrl         string `json:"logo_url"`         // Logo地址，未设置时为空
+	ThemeColor      string `json:"theme_color"`      // 主题色，#RRGGBB格式，未设置时为空
+	DefaultLanguage string `json:"default_language"` // 默认界面语言，未设置时为空
+}
+
+// ChatWidgetTokenRequest 创建嵌入式聊天窗口令牌请求
+type ChatWidgetTokenRequest struct {
+	ServiceUserID string `json:"service_user_id" binding:"required"` // 业务侧用户ID，窗口令牌只能访问该用户的数据
+	Origin        string `json:"origin" binding:"required"`          // 允许访问的来源，即嵌入聊天窗口的网页来源，如 https://www.example.com
+	TtlMinutes    int    `json:"ttl_minutes"`                        // 有效期（分钟），为空时使用服务配置的有效期，不能超过该有效期
+}
+
+// ChatWidgetTokenResponse 创建嵌入式聊天窗口令牌响应
+type ChatWidgetTokenResponse struct {
+	WidgetToken   string `json:"widget_token"`    // 窗口令牌，浏览器通过 lemon-ai-widget-token 请求头携带
+	ServiceUserID string `json:"service_user_id"` // 窗口令牌绑定的业务侧用户ID
+	Origin        string `json:"origin"`          // 允许访问的来源
+	ExpiresAt     int64  `json:"expires_at"`      // 过期时间，Unix 13位毫秒时间戳
+}
+
+// CursorPageInfo 游标分页信息
+type CursorPageInfo struct {
+	HasMore    bool    `json:"has_more"`    // 是否还有更多数据
+	NextCursor *string `json:"next_cursor"` // 下一页游标，作为下次请求的 last_id 传入，没有更多数据时为空
+	TotalCount *int64  `json:"total_count"` // 符合条件的总数量，仅在请求 include_total 时返回
 }
 
 // GetConversationListRequest 获取会话列表请求
@@ -48,31 +131,153 @@ type GetConversationListRequest struct {
 	ServiceUserID string  `json:"service_user_id"` // 业务侧用户ID
 	LastID        *string `json:"last_id"`         // 最后一个会话的ID，用于游标分页
 	Size          *int    `json:"size"`            // 返回数量
-	Sort          *string `json:"sort"`            // 排序方式，默认按创建时间倒序
+	Sort          *string `json:"sort"`            // 排序方式：last_message_at 按最后一条消息的时间倒序（默认），created_at 按创建时间倒序
+	IncludeTotal  bool    `json:"include_total"`   // 是否查询总数量
 }
 
 // ConversationInfoDto 会话信息
 type ConversationInfoDto struct {
-	ID            string `json:"id"`              // 会话ID
-	Title         string `json:"title"`           // 会话标题
-	ApplicationID string `json:"application_id"`  // 应用ID
-	ServiceUserID string `json:"service_user_id"` // 业务侧用户ID
-	CreatedAt     *int64 `json:"created_at"`      // 创建时间（时间戳）
-	UpdatedAt     *int64 `json:"updated_at"`      // 更新时间（时间戳）
+	ID            string              `json:"id"`                     // 会话ID
+	Title         string              `json:"title"`                  // 会话标题
+	ApplicationID string              `json:"application_id"`         // 应用ID
+	ServiceUserID string              `json:"service_user_id"`        // 业务侧用户ID
+	ServiceUser   *ServiceUserInfoDto `json:"service_user,omitempty"` // 已登记的业务侧用户信息
+	CreatedAt     *int64              `json:"created_at"`             // 创建时间（时间戳）
+	UpdatedAt     *int64              `json:"updated_at"`             // 更新时间（时间戳）
+	// 会话累计的token用量和费用，费用按模型的计费价格（每百万Token）计算
+	PromptTokenCount     int64   `json:"prompt_token_count"`     // 累计提示词token数
+	CompletionTokenCount int64   `json:"completion_token_count"` // 累计回复token数
+	TotalTokenCount      int64   `json:"total_token_count"`      // 累计总token数
+	Cost                 float64 `json:"cost"`                   // 累计费用
+	// 会话列表中附带的消息摘要，便于聊天界面渲染侧边栏，会话还没有消息时为空
+	*ConversationSummaryDto
+}
+
+// ConversationSummaryDto 会话的消息摘要
+type ConversationSummaryDto struct {
+	LastMessagePreview string `json:"last_message_preview"` // 最后一条消息预览
+	LastMessageRole    string `json:"last_message_role"`    // 最后一条消息角色
+	LastMessageAt      int64  `json:"last_message_at"`      // 最后一条消息创建时间（时间戳）
+	MessageCount       int64  `json:"message_count"`        // 消息数量（不含工具调用消息）
+	UnreadCount        int64  `json:"unread_count"`         // 未读的助手回复数量
 }
 
 // GetConversationListResponse 获取会话列表响应
 type GetConversationListResponse struct {
-	Conversations []ConversationInfoDto `json:"conversations"` // 会话列表
-	TotalCount    int                   `json:"total_count"`   // 总数量
+	Conversations  []ConversationInfoDto `json:"conversations"` // 会话列表
+	CursorPageInfo                       // 分页信息
+}
+
+// AdminConversationListRequest 管理后台获取会话列表请求
+type AdminConversationListRequest struct {
+	ChatAgentID        string `json:"chat_agent_id"`        // 智能体ID
+	ServiceUserID      string `json:"service_user_id"`      // 业务侧用户ID（可选）
+	CreatedAfter       *int64 `json:"created_after"`        // 只返回晚于该时间创建的会话（毫秒时间戳，可选）
+	CreatedBefore      *int64 `json:"created_before"`       // 只返回早于该时间创建的会话（毫秒时间戳，可选）
+	HasErrors          *bool  `json:"has_errors"`           // 是否发生过错误（可选）
+	HasPersistenceGaps *bool  `json:"has_persistence_gaps"` // 是否存在保存失败且放弃重试的消息（可选）
+	MinTotalTokens     *int64 `json:"min_total_tokens"`     // 最小token用量（可选）
+	MaxTotalTokens     *int64 `json:"max_total_tokens"`     // 最大token用量（可选）
+	Page               int    `json:"page"`                 // 页码（从1开始）
+	PageSize           int    `json:"page_size"`            // 每页大小
+}
+
+// AdminConversationInfoDto 管理后台会话信息
+type AdminConversationInfoDto struct {
+	ConversationInfoDto
+	ChatAgentID          string `json:"chat_agent_id"`           // 智能体ID
+	MessageCount         int64  `json:"message_count"`           // 消息数量（不含工具调用消息）
+	ErrorCount           int    `json:"error_count"`             // 处理消息时发生错误的次数
+	LastErrorAt          *int64 `json:"last_error_at"`           // 最后一次发生错误的时间（时间戳）
+	PersistenceGapCount  int    `json:"persistence_gap_count"`   // 保存失败且放弃重试的消息数量，大于0表示会话记录存在缺失
+	LastPersistenceGapAt *int64 `json:"last_persistence_gap_at"` // 最后一次放弃重试保存消息的时间（时间戳）
+}
+
+// AdminConversationListResponse 管理后台获取会话列表响应
+type AdminConversationListResponse struct {
+	Conversations []AdminConversationInfoDto `json:"conversations"` // 会话列表
+	Total         int64                      `json:"total"`         // 符合条件的总数量
+	Page          int                        `json:"page"`          // 页码
+	PageSize      int                        `json:"page_size"`     // 每页大小
+}
+
+// ConversationExportResponse 导出会话记录响应（JSON 格式）
+type ConversationExportResponse struct {
+	ChatAgentID        string               `json:"chat_agent_id"`         // 智能体ID
+	ChatAgentName      string               `json:"chat_agent_name"`       // 智能体名称
+	ChatAgentAvatarUrl string               `json:"chat_agent_avatar_url"` // 智能体头像URL
+	Conversation       ConversationInfoDto  `json:"conversation"`          // 会话信息
+	Messages           []ChatMessageInfoDto `json:"messages"`              // 全部消息（包含工具调用消息），按创建时间正序
+	ExportedAt         int64                `json:"exported_at"`           // 导出时间（时间戳）
+}
+
+// ReplayConversationRequest 会话回放请求
+// 未指定的配置项使用智能体当前的配置；原会话的系统提示词由业务侧每次发送时提供，未保存在会话中，未指定时使用智能体配置的系统提示词
+type ReplayConversationRequest struct {
+	SystemPrompt       *string  `json:"system_prompt,omitempty"`     // 系统提示词（可选）
+	ChatModelID        *string  `json:"chat_model_id,omitempty"`     // 聊天模型ID（可选），必须是当前应用已启用的模型
+	Temperature        *float64 `json:"temperature,omitempty"`       // 模型温度（可选），0-2
+	TopP               *float64 `json:"top_p,omitempty"`             // 模型TopP（可选），0-1
+	MaxOutputTokens    *int     `json:"max_output_tokens,omitempty"` // 最大输出Token数量（可选），0 表示不限制
+	UseOriginalHistory bool     `json:"use_original_history"`        // 是否使用原始回答作为后续消息的历史，默认使用回放产生的回答
+}
+
+// ConversationReplayDiffRowDto 回答并排对比的一行
+type ConversationReplayDiffRowDto struct {
+	Type     string `json:"type"`     // 行类型：equal 相同，changed 不同，removed 只在原始回答中，added 只在回放回答中
+	Original string `json:"original"` // 原始回答的行
+	Replay   string `json:"replay"`   // 回放回答的行
+}
+
+// ConversationReplayTurnDto 一条用户消息的回放结果
+type ConversationReplayTurnDto struct {
+	RequestID      string                         `json:"request_id"`      // 原始请求ID
+	UserMessage    string                         `json:"user_message"`    // 用户消息
+	OriginalAnswer string                         `json:"original_answer"` // 原始回答
+	ReplayAnswer   string                         `json:"replay_answer"`   // 回放得到的回答
+	Changed        bool                           `json:"changed"`         // 回答是否发生变化
+	ErrorMessage   string                         `json:"error_message"`   // 回放出错的原因
+	DurationMs     int64                          `json:"duration_ms"`     // 回放耗时（毫秒）
+	Diff           []ConversationReplayDiffRowDto `json:"diff"`            // 原始回答与回放回答的逐行并排对比
+}
+
+// ConversationReplayResponse 会话回放响应
+type ConversationReplayResponse struct {
+	ChatAgentID        string                      `json:"chat_agent_id"`        // 智能体ID
+	ConversationID     string                      `json:"conversation_id"`      // 回放的会话ID
+	ChatModelID        string                      `json:"chat_model_id"`        // 回放使用的聊天模型ID
+	ChatModelName      string                      `json:"chat_model_name"`      // 回放使用的聊天模型名称
+	SystemPrompt       string                      `json:"system_prompt"`        // 回放使用的系统提示词
+	Temperature        float64                     `json:"temperature"`          // 回放使用的模型温度
+	TopP               float64                     `json:"top_p"`                // 回放使用的模型TopP
+	MaxOutputTokens    int                         `json:"max_output_tokens"`    // 回放使用的最大输出Token数量
+	UseOriginalHistory bool                        `json:"use_original_history"` // 是否使用原始回答作为历史
+	TotalTurnCount     int                         `json:"total_turn_count"`     // 会话中的用户消息总数
+	ReplayedTurnCount  int                         `json:"replayed_turn_count"`  // 回放的用户消息数量，超出上限的消息不回放
+	ChangedTurnCount   int                         `json:"changed_turn_count"`   // 回答发生变化的用户消息数量
+	Turns              []ConversationReplayTurnDto `json:"turns"`                // 各用户消息的回放结果
+}
+
+// GetConversationResponse 获取单个会话响应
+type GetConversationResponse struct {
+	Conversation         ConversationInfoDto `json:"conversation"`            // 会话信息
+	MessageCount         int64               `json:"message_count"`           // 消息数量（不含工具调用消息）
+	LastMessagePreview   *string             `json:"last_message_preview"`    // 最后一条消息预览
+	LastMessageRole      *string             `json:"last_message_role"`       // 最后一条消息角色
+	LastMessageCreatedAt *int64              `json:"last_message_created_at"` // 最后一条消息创建时间（时间戳）
+	TotalTokenCount      int64               `json:"total_token_count"`       // 会话累计token用量
 }
 
 // GetChatMessageListRequest 获取聊天消息列表请求
 type GetChatMessageListRequest struct {
 	ConversationID string  `json:"conversation_id"` // 会话ID
+	ServiceUserID  string  `json:"service_user_id"` // 业务侧用户ID，需为会话所属用户
 	LastID         *string `json:"last_id"`         // 最后一个消息的ID，用于游标分页
 	Size           *int    `json:"size"`            // 返回数量
-	Sort           *string `json:"sort"`            // 排序方式，默认按创建时间倒序
+	Sort           *string `json:"sort"`            // 排序方式：asc 按创建时间正序，desc 按创建时间倒序（默认）
+	CreatedBefore  *int64  `json:"created_before"`  // 只返回早于该时间创建的消息（毫秒时间戳，可选）
+	CreatedAfter   *int64  `json:"created_after"`   // 只返回晚于该时间创建的消息（毫秒时间戳，可选）
+	IncludeTotal   bool    `json:"include_total"`   // 是否查询总数量
 }
 
 // ChatMessageAttachmentInfoDto 聊天附件信息
@@ -90,22 +295,31 @@ type ChatMessageInfoDto struct {
 	Type                  string                         `json:"type"`                    // 消息类型
 	Role                  *string                        `json:"role"`                    // 消息角色
 	Content               *string                        `json:"content"`                 // 消息内容
+	Language              string                         `json:"language"`                // 消息语言（仅用户消息，未识别时为空）
+	TruncatedReason       string                         `json:"truncated_reason"`        // 回答截断原因（仅助手消息）：duration 超过生成时间上限，output_tokens 超过输出Token上限，未截断时为空
 	FunctionCallID        *string                        `json:"function_call_id"`        // 函数调用ID
 	FunctionCallName      *string                        `json:"function_call_name"`      // 函数调用名称
 	FunctionCallArguments *string                        `json:"function_call_arguments"` // 函数调用参数
 	FunctionCallOutput    *string                        `json:"function_call_output"`    // 函数调用返回值
+	FunctionCallLimited   bool                           `json:"function_call_limited"`   // 函数调用返回值是否因超过长度上限被截断或摘要
+	OutputRenderer        string                         `json:"output_renderer"`         // 函数调用返回值的渲染方式，为空时按原始内容展示
 	PromptTokenCount      int                            `json:"prompt_token_count"`      // 提示词token数
 	CompletionTokenCount  int                            `json:"completion_token_count"`  // 回复token数
 	TotalTokenCount       int                            `json:"total_token_count"`       // 总token数
+	Cost                  float64                        `json:"cost"`                    // 模型调用费用
 	CreatedAt             *int64                         `json:"created_at"`              // 创建时间（时间戳）
 	UpdatedAt             *int64                         `json:"updated_at"`              // 更新时间（时间戳）
 	AttachmentInfoList    []ChatMessageAttachmentInfoDto `json:"attachment_info_list"`    // 附件信息列表
+	Citations             []ChatCitationDto              `json:"citations"`               // 知识库引用（仅助手消息）
+	Suggestions           []string                       `json:"suggestions"`             // 追问建议（仅助手消息）
+	DeliveredAt           *int64                         `json:"delivered_at"`            // 送达时间（时间戳，仅助手消息，未送达时为空）
+	ReadAt                *int64                         `json:"read_at"`                 // 已读时间（时间戳，仅助手消息，未读时为空）
 }
 
 // GetChatMessageListResponse 获取聊天消息列表响应
 type GetChatMessageListResponse struct {
-	Messages   []ChatMessageInfoDto `json:"messages"`    // 消息列表
-	TotalCount int                  `json:"total_count"` // 总数量
+	Messages       []ChatMessageInfoDto `json:"messages"` // 消息列表
+	CursorPageInfo                      // 分页信息
 }
 
 // DeleteConversationRequest 删除会话请求
@@ -137,6 +351,23 @@ type RenameConversationResponse struct {
 	NewTitle       *string `json:"new_title"`       // 新标题
 }
 
+// UpdateMessageReceiptsRequest 上报消息送达和已读状态请求
+// message_ids 和 up_to_message_id 至少指定一个，只标记助手回复
+type UpdateMessageReceiptsRequest struct {
+	ConversationID string   `json:"conversation_id" binding:"required"`             // 会话ID
+	ServiceUserID  string   `json:"service_user_id" binding:"required"`             // 业务侧用户ID，需为会话所属用户
+	Status         string   `json:"status" binding:"required,oneof=delivered read"` // 状态：delivered 已送达，read 已读（同时视为已送达）
+	MessageIDs     []string `json:"message_ids"`                                    // 标记指定的消息
+	UpToMessageID  string   `json:"up_to_message_id"`                               // 标记该消息及之前创建的全部回复
+}
+
+// UpdateMessageReceiptsResponse 上报消息送达和已读状态响应
+type UpdateMessageReceiptsResponse struct {
+	ConversationID string `json:"conversation_id"` // 会话ID
+	UpdatedCount   int64  `json:"updated_count"`   // 本次更新的消息数量，已标记过的消息不计入
+	UnreadCount    int64  `json:"unread_count"`    // 会话中未读的助手回复数量
+}
+
 // UploadAttachmentResponse 上传附件响应
 type UploadAttachmentResponse struct {
 	Success          bool    `json:"success"`           // 是否成功
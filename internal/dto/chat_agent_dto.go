@@ -5,45 +5,125 @@ package dto
 // ChatAgentDto 智能体数据传输对象
 // 用于在业务逻辑层和HTTP处理层之间传递数据
 type ChatAgentDto struct {
-	ID                             string  `json:"id"`                                  // 主键ID
-	Name                           string  `json:"name"`                                // Agent名称
-	Description                    string  `json:"description"`                         // Agent描述
-	ApplicationID                  string  `json:"application_id"`                      // 所属应用ID
-	AvatarUrl                      string  `json:"avatar_url"`                          // Agent的头像URL
-	ChatSystemPrompt               string  `json:"system_prompt"`                       // 系统提示
-	ChatModelID                    string  `json:"chat_model_id"`                       // 聊天模型ID
-	ConversationNamingPrompt       string  `json:"conversation_naming_prompt"`          // 会话命名提示词
-	ConversationNamingModelID      string  `json:"conversation_naming_model_id"`        // 会话命名模型ID
-	ModelParamTemperature          float64 `json:"model_temperature"`                   // 模型温度
-	ModelParamTopP                 float64 `json:"model_top_p"`                         // 模型TopP
-	EnableContextLengthLimit       bool    `json:"enable_context_length_limit"`         // 是否启用上下文长度限制
-	ContextLengthLimit             int     `json:"context_length_limit"`                // 上下文长度限制
-	EnableMaxOutputTokenCountLimit bool    `json:"enable_max_output_token_count_limit"` // 是否启用最大输出Token数量限制
-	MaxOutputTokenCountLimit       int     `json:"max_output_token_count_limit"`        // 最大输出Token数量
-	DefaultStreamable              bool    `json:"default_streamable"`                  // 是否默认流式返回
-	CreatedAt                      string  `json:"created_at"`                          // 创建时间
-	UpdatedAt                      string  `json:"updated_at"`                          // 更新时间
+	ID                             string                             `json:"id"`                                  // 主键ID
+	Name                           string                             `json:"name"`                                // Agent名称
+	Description                    string                             `json:"description"`                         // Agent描述
+	ApplicationID                  string                             `json:"application_id"`                      // 所属应用ID
+	AvatarUrl                      string                             `json:"avatar_url"`                          // Agent的头像URL
+	ChatSystemPrompt               string                             `json:"system_prompt"`                       // 系统提示
+	ChatModelID                    string                             `json:"chat_model_id"`                       // 聊天模型ID
+	ConversationNamingPrompt       string                             `json:"conversation_naming_prompt"`          // 会话命名提示词
+	ConversationNamingModelID      string                             `json:"conversation_naming_model_id"`        // 会话命名模型ID
+	ModelParamTemperature          float64                            `json:"model_temperature"`                   // 模型温度
+	ModelParamTopP                 float64                            `json:"model_top_p"`                         // 模型TopP
+	EnableContextLengthLimit       bool                               `json:"enable_context_length_limit"`         // 是否启用上下文长度限制
+	ContextLengthLimit             int                                `json:"context_length_limit"`                // 上下文长度限制
+	EnableMaxOutputTokenCountLimit bool                               `json:"enable_max_output_token_count_limit"` // 是否启用最大输出Token数量限制
+	MaxOutputTokenCountLimit       int                                `json:"max_output_token_count_limit"`        // 最大输出Token数量
+	DefaultStreamable              bool                               `json:"default_streamable"`                  // 是否默认流式返回
+	MaintenanceMode                bool                               `json:"maintenance_mode"`                    // 是否处于维护模式
+	MaintenanceAnswer              string                             `json:"maintenance_answer"`                  // 维护模式下的预制答案
+	EnableAvailabilitySchedule     bool                               `json:"enable_availability_schedule"`        // 是否启用服务时间
+	AvailabilitySchedule           *ChatAgentAvailabilityScheduleDto  `json:"availability_schedule"`               // 服务时间配置
+	UnavailableAnswer              string                             `json:"unavailable_answer"`                  // 服务时间外的预制答案
+	WelcomeMessage                 string                             `json:"welcome_message"`                     // 欢迎语
+	SuggestedQuestions             []string                           `json:"suggested_questions"`                 // 推荐问题列表
+	EnableFollowUpSuggestions      bool                               `json:"enable_follow_up_suggestions"`        // 是否生成追问建议
+	EnableTranslation              bool                               `json:"enable_translation"`                  // 是否启用翻译工具
+	TranslationModelID             string                             `json:"translation_model_id"`                // 翻译使用的模型ID
+	ReplyLanguage                  string                             `json:"reply_language"`                      // 强制回复语言
+	InputPreprocessConfig          *ChatAgentInputPreprocessConfigDto `json:"input_preprocess_config"`             // 用户输入预处理配置
+	KnowledgeBaseIDs               []string                           `json:"knowledge_base_ids"`                  // 绑定的知识库ID列表
+	KnowledgeRetrievalTopK         int                                `json:"knowledge_retrieval_top_k"`           // 每次检索注入的最大片段数
+	KnowledgeRetrievalMinScore     float64                            `json:"knowledge_retrieval_min_score"`       // 检索片段的最低相似度
+	KnowledgeRetrievalMode         string                             `json:"knowledge_retrieval_mode"`            // 检索方式
+	KnowledgeRerankModelID         string                             `json:"knowledge_rerank_model_id"`           // 重排模型ID
+	KnowledgeRerankTopK            int                                `json:"knowledge_rerank_top_k"`              // 交给重排模型的候选片段数
+	ToolOutputMaxLength            int                                `json:"tool_output_max_length"`              // 提供给模型的工具结果最大字符数
+	ToolOutputOverflowMode         string                             `json:"tool_output_overflow_mode"`           // 工具结果超长时的处理方式
+	EnableConversationVariables    bool                               `json:"enable_conversation_variables"`       // 是否允许模型设置会话变量
+	InjectedMessageRole            string                             `json:"injected_message_role"`               // 注入消息使用的角色
+	AllowedOrigins                 []string                           `json:"allowed_origins"`                     // 允许嵌入聊天窗口的来源列表
+	MaxUserMessageLength           int                                `json:"max_user_message_length"`             // 用户消息最大字符数
+	MaxAnswerDurationSeconds       int                                `json:"max_answer_duration_seconds"`         // 单次回答的最长生成时间（秒）
+	MaxAnswerOutputTokens          int                                `json:"max_answer_output_tokens"`            // 单次回答的输出Token总数上限
+	Version                        int64                              `json:"version"`                             // 数据版本号，保存时原样带回
+	CreatedAt                      int64                              `json:"created_at"`                          // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt                      int64                              `json:"updated_at"`                          // 更新时间，Unix 13位毫秒时间戳
 }
 
 // SaveChatAgentRequest 保存智能体请求
 // 用于前端保存智能体的请求数据
 type SaveChatAgentRequest struct {
-	ID                             *string `json:"id,omitempty"`                        // 主键ID（更新时提供）
-	Name                           string  `json:"name"`                                // Agent名称
-	Description                    string  `json:"description"`                         // Agent描述
-	ApplicationID                  string  `json:"application_id"`                      // 所属应用ID
-	AvatarUrl                      string  `json:"avatar_url"`                          // Agent的头像URL
-	ChatSystemPrompt               string  `json:"system_prompt"`                       // 系统提示
-	ChatModelID                    string  `json:"chat_model_id"`                       // 聊天模型ID
-	ConversationNamingPrompt       string  `json:"conversation_naming_prompt"`          // 会话命名提示词
-	ConversationNamingModelID      string  `json:"conversation_naming_model_id"`        // 会话命名模型ID
-	ModelParamTemperature          float64 `json:"model_temperature"`                   // 模型温度
-	ModelParamTopP                 float64 `json:"model_top_p"`                         // 模型TopP
-	EnableContextLengthLimit       bool    `json:"enable_context_length_limit"`         // 是否启用上下文长度限制
-	ContextLengthLimit             int     `json:"context_length_limit"`                // 上下文长度限制
-	EnableMaxOutputTokenCountLimit bool    `json:"enable_max_output_token_count_limit"` // 是否启用最大输出Token数量限制
-	MaxOutputTokenCountLimit       int     `json:"max_output_token_count_limit"`        // 最大输出Token数量
-	DefaultStreamable              bool    `json:"default_streamable"`                  // 是否默认流式返回
+	ID                             *string                            `json:"id,omitempty"`                        // 主键ID（更新时提供）
+	Name                           string                             `json:"name"`                                // Agent名称
+	Description                    string                             `json:"description"`                         // Agent描述
+	ApplicationID                  string                             `json:"application_id"`                      // 所属应用ID
+	AvatarUrl                      string                             `json:"avatar_url"`                          // Agent的头像URL
+	ChatSystemPrompt               string                             `json:"system_prompt"`                       // 系统提示
+	ChatModelID                    string                             `json:"chat_model_id"`                       // 聊天模型ID
+	ConversationNamingPrompt       string                             `json:"conversation_naming_prompt"`          // 会话命名提示词
+	ConversationNamingModelID      string                             `json:"conversation_naming_model_id"`        // 会话命名模型ID
+	ModelParamTemperature          float64                            `json:"model_temperature"`                   // 模型温度
+	ModelParamTopP                 float64                            `json:"model_top_p"`                         // 模型TopP
+	EnableContextLengthLimit       bool                               `json:"enable_context_length_limit"`         // 是否启用上下文长度限制
+	ContextLengthLimit             int                                `json:"context_length_limit"`                // 上下文长度限制
+	EnableMaxOutputTokenCountLimit bool                               `json:"enable_max_output_token_count_limit"` // 是否启用最大输出Token数量限制
+	MaxOutputTokenCountLimit       int                                `json:"max_output_token_count_limit"`        // 最大输出Token数量
+	DefaultStreamable              bool                               `json:"default_streamable"`                  // 是否默认流式返回
+	MaintenanceMode                bool                               `json:"maintenance_mode"`                    // 是否处于维护模式
+	MaintenanceAnswer              string                             `json:"maintenance_answer"`                  // 维护模式下的预制答案，为空时使用默认答案
+	EnableAvailabilitySchedule     bool                               `json:"enable_availability_schedule"`        // 是否启用服务时间
+	AvailabilitySchedule           *ChatAgentAvailabilityScheduleDto  `json:"availability_schedule"`               // 服务时间配置
+	UnavailableAnswer              string                             `json:"unavailable_answer"`                  // 服务时间外的预制答案，为空时使用默认答案
+	WelcomeMessage                 string                             `json:"welcome_message"`                     // 欢迎语
+	SuggestedQuestions             []string                           `json:"suggested_questions"`                 // 推荐问题列表
+	EnableFollowUpSuggestions      bool                               `json:"enable_follow_up_suggestions"`        // 是否在回答完成后使用会话命名模型生成追问建议
+	EnableTranslation              bool                               `json:"enable_translation"`                  // 是否启用翻译工具，启用后模型可调用翻译内部工具
+	TranslationModelID             string                             `json:"translation_model_id"`                // 翻译使用的模型ID（启用翻译工具时必填）
+	ReplyLanguage                  string                             `json:"reply_language"`                      // 强制回复语言：为空时不限制，auto 跟随用户消息语言，其他值为语言代码（如 zh、en）或语言名称
+	InputPreprocessConfig          *ChatAgentInputPreprocessConfigDto `json:"input_preprocess_config"`             // 用户输入预处理配置，为空时使用默认配置
+	KnowledgeBaseIDs               []string                           `json:"knowledge_base_ids"`                  // 绑定的知识库ID列表，回答前从这些知识库检索相关片段
+	KnowledgeRetrievalTopK         int                                `json:"knowledge_retrieval_top_k"`           // 每次检索注入的最大片段数，0 表示使用默认值 5
+	KnowledgeRetrievalMinScore     float64                            `json:"knowledge_retrieval_min_score"`       // 检索片段的最低相似度（0-1），低于该值的片段不注入
+	KnowledgeRetrievalMode         string                             `json:"knowledge_retrieval_mode"`            // 检索方式：vector 向量检索，hybrid 向量与关键词混合检索（最低相似度只作用于向量检索），为空时使用向量检索
+	KnowledgeRerankModelID         string                             `json:"knowledge_rerank_model_id"`           // 重排模型ID（需具备重排能力），为空时不重排
+	KnowledgeRerankTopK            int                                `json:"knowledge_rerank_top_k"`              // 交给重排模型的候选片段数，0 表示使用默认值 20；重排后保留 knowledge_retrieval_top_k 个
+	ToolOutputMaxLength            int                                `json:"tool_output_max_length"`              // 提供给模型的工具结果最大字符数（不超过20000），0 表示不限制；原始结果保存在消息记录中
+	ToolOutputOverflowMode         string                             `json:"tool_output_overflow_mode"`           // 工具结果超长时的处理方式：truncate 截断，summarize 使用会话命名模型摘要（失败时截断），为空时截断
+	EnableConversationVariables    bool                               `json:"enable_conversation_variables"`       // 是否允许模型调用内部工具设置会话变量，会话变量可在系统提示词中以 {{变量名}} 引用，并自动填入工具调用参数
+	InjectedMessageRole            string                             `json:"injected_message_role"`               // 知识库参考资料、会话变量等注入给模型的上下文使用的消息角色：system、developer（需模型支持），为空时使用 system
+	AllowedOrigins                 []string                           `json:"allowed_origins"`                     // 允许嵌入聊天窗口的来源列表，如 https://www.example.com；窗口令牌只能为列表中的来源创建，为空时不能创建窗口令牌
+	MaxUserMessageLength           int                                `json:"max_user_message_length"`             // 用户消息最大字符数，超过时拒绝请求，0 表示不限制
+	MaxAnswerDurationSeconds       int                                `json:"max_answer_duration_seconds"`         // 单次回答（含工具调用）的最长生成时间（秒），超过时截断回答，0 表示不限制
+	MaxAnswerOutputTokens          int                                `json:"max_answer_output_tokens"`            // 单次回答（含工具调用后的多轮模型调用）的输出Token总数上限，超过时截断回答，0 表示不限制
+	Version                        int64                              `json:"version"`                             // 数据版本号（更新时提供），与当前版本不一致时返回冲突，也可通过 If-Match 请求头提供
+	UpdateFields                   []string                           `json:"update_fields,omitempty"`             // 部分更新的字段列表（JSON 字段名），更新时提供则只修改列出的字段，其余字段保持不变
+}
+
+// ChatAgentAvailabilityScheduleDto 智能体服务时间配置
+type ChatAgentAvailabilityScheduleDto struct {
+	Timezone string                           `json:"timezone"` // 时区，如 Asia/Shanghai，为空时使用服务器时区
+	Windows  []ChatAgentAvailabilityWindowDto `json:"windows"`  // 服务时间段列表，满足任意一个即可用
+}
+
+// ChatAgentAvailabilityWindowDto 智能体服务时间段
+// 结束时间早于开始时间时表示跨越午夜，如 22:00-06:00
+type ChatAgentAvailabilityWindowDto struct {
+	Weekdays []int  `json:"weekdays"` // 生效的星期（0 表示周日，1-6 表示周一至周六），为空时每天生效
+	Start    string `json:"start"`    // 开始时间，格式 HH:MM
+	End      string `json:"end"`      // 结束时间，格式 HH:MM
+}
+
+// ChatAgentInputPreprocessConfigDto 智能体用户输入预处理配置
+// 未配置时默认去除 /no_think、/think 指令、清理首尾空白并识别语言
+type ChatAgentInputPreprocessConfigDto struct {
+	StripCommands   []string `json:"strip_commands"`   // 从消息首尾去除的指令（如 /no_think），去除后保存的消息不含指令，调用模型时仍会带上
+	TrimWhitespace  bool     `json:"trim_whitespace"`  // 是否清理首尾空白并统一换行符
+	DetectLanguage  bool     `json:"detect_language"`  // 是否识别消息语言
+	ProfanityFilter bool     `json:"profanity_filter"` // 是否启用敏感词过滤
+	ProfanityWords  []string `json:"profanity_words"`  // 敏感词列表（不区分大小写）
+	ProfanityMask   string   `json:"profanity_mask"`   // 敏感词替换字符，为空时使用 *
 }
 
 // ChatAgentListResponse 智能体列表响应
@@ -60,3 +140,84 @@ type ChatAgentListResponse struct {
 type SingleChatAgentResponse struct {
 	ChatAgent ChatAgentDto `json:"chat_agent"` // 智能体数据
 }
+
+// ChatAgentExportDto 智能体导出数据
+// 用于导出智能体配置及其MCP工具设置
+type ChatAgentExportDto struct {
+	ChatAgent       ChatAgentDto                       `json:"chat_agent"`        // 智能体数据
+	McpToolSettings []ChatAgentMcpServerToolSettingDto `json:"mcp_tool_settings"` // MCP工具设置列表
+	ExportedAt      int64                              `json:"exported_at"`       // 导出时间（时间戳）
+}
+
+// ChatAgentDeletionDto 删除智能体的结果
+// 试运行时为将要删除的关联数据数量，否则为实际删除的数量
+type ChatAgentDeletionDto struct {
+	ChatAgentID          string `json:"chat_agent_id"`         // 智能体ID
+	DryRun               bool   `json:"dry_run"`               // 是否为试运行，试运行不会删除任何数据
+	Conversations        int64  `json:"conversations"`         // 会话数量
+	Messages             int64  `json:"messages"`              // 消息数量
+	MessageRequests      int64  `json:"message_requests"`      // 已登记的客户端请求ID数量
+	Attachments          int64  `json:"attachments"`           // 附件数量，附件文件由后台任务清理
+	McpServerTools       int64  `json:"mcp_server_tools"`      // MCP工具设置数量
+	ApiKeys              int64  `json:"api_keys"`              // API Key数量
+	AnswerRules          int64  `json:"answer_rules"`          // 回答规则数量
+	ToolCalls            int64  `json:"tool_calls"`            // 工具调用记录数量
+	Variables            int64  `json:"variables"`             // 会话变量数量
+	ToolBundles          int64  `json:"tool_bundles"`          // 工具集关联数量，工具集本身不删除
+	WidgetTokens         int64  `json:"widget_tokens"`         // 网页挂件令牌数量
+	ApiKeyRejections     int64  `json:"api_key_rejections"`    // API Key拒绝记录数量
+	ChatStreams          int64  `json:"chat_streams"`          // 流式回复数量
+	ChatStreamEvents     int64  `json:"chat_stream_events"`    // 流式回复事件数量
+	ConversationEvents   int64  `json:"conversation_events"`   // 会话事件数量
+	ConversationArchives int64  `json:"conversation_archives"` // 会话归档数量
+	BatchInferenceJobs   int64  `json:"batch_inference_jobs"`  // 批量推理任务数量
+	BatchInferenceItems  int64  `json:"batch_inference_items"` // 批量推理条目数量
+	EvaluationSets       int64  `json:"evaluation_sets"`       // 评测集数量
+	EvaluationCases      int64  `json:"evaluation_cases"`      // 评测用例数量
+	EvaluationRuns       int64  `json:"evaluation_runs"`       // 评测运行数量
+	EvaluationResults    int64  `json:"evaluation_results"`    // 评测结果数量
+}
+
+// ChatAgentToolUsageDto 智能体单个工具的使用统计
+type ChatAgentToolUsageDto struct {
+	ToolType          string  `json:"tool_type"`                      // 工具类型：mcp MCP工具，internal 内部工具
+	ToolName          string  `json:"tool_name"`                      // 工具名称
+	FunctionName      string  `json:"function_name"`                  // 提供给模型的工具名称
+	McpServerConfigID string  `json:"mcp_server_config_id,omitempty"` // MCP工具所属的MCP配置ID
+	McpServerToolID   string  `json:"mcp_server_tool_id,omitempty"`   // MCP工具ID，可用于关闭该工具
+	Enabled           bool    `json:"enabled"`                        // 智能体当前是否启用该工具
+	CallCount         int64   `json:"call_count"`                     // 调用次数
+	SuccessCount      int64   `json:"success_count"`                  // 调用成功次数
+	SuccessRate       float64 `json:"success_rate"`                   // 调用成功率（0-1）
+	AvgDurationMs     float64 `json:"avg_duration_ms"`                // 平均耗时（毫秒）
+}
+
+// ChatAgentApiKeyDto 智能体 API Key 数据传输对象，不包含 Key 和 Secret
+type ChatAgentApiKeyDto struct {
+	ID           string   `json:"id"`            // 主键ID
+	Name         string   `json:"name"`          // Key名称
+	Description  string   `json:"description"`   // Key描述
+	ChatAgentID  string   `json:"chat_agent_id"` // 智能体ID
+	AllowedCidrs []string `json:"allowed_cidrs"` // 允许访问的IP网段，为空时不限制
+	DeniedCidrs  []string `json:"denied_cidrs"`  // 拒绝访问的IP网段，优先于允许的网段
+	CreatedAt    int64    `json:"created_at"`    // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt    int64    `json:"updated_at"`    // 更新时间，Unix 13位毫秒时间戳
+}
+
+// SaveChatAgentApiKeyIPRulesRequest 保存 API Key 来源IP网段限制请求
+// 网段可以是 CIDR 网段（如 10.0.0.0/8）或单个IP，两个列表都为空时不限制来源IP
+type SaveChatAgentApiKeyIPRulesRequest struct {
+	AllowedCidrs []string `json:"allowed_cidrs"` // 允许访问的IP网段
+	DeniedCidrs  []string `json:"denied_cidrs"`  // 拒绝访问的IP网段
+}
+
+// ChatAgentApiKeyRejectionDto API Key 访问被拒绝的审计记录
+type ChatAgentApiKeyRejectionDto struct {
+	ID        string `json:"id"`         // 主键ID
+	ApiKeyID  string `json:"api_key_id"` // API Key ID
+	ClientIP  string `json:"client_ip"`  // 来源IP
+	Reason    string `json:"reason"`     // 拒绝原因：denied 在拒绝列表中，not_allowed 不在允许列表中，invalid_ip 无法识别来源IP
+	Path      string `json:"path"`       // 请求路径或gRPC方法
+	UserAgent string `json:"user_agent"` // 客户端标识
+	CreatedAt int64  `json:"created_at"` // 访问时间，Unix 13位毫秒时间戳
+}
@@ -3,10 +3,15 @@
 package dto
 
 // ChatAgentMcpServerToolSettingDto 聊天智能体MCP工具设置
+// 覆盖字段只影响该智能体提供给模型的工具定义，为空时使用MCP服务器提供的内容
 type ChatAgentMcpServerToolSettingDto struct {
-	ID                         string `json:"id"`                             // 配置ID
-	ApplicationMcpServerToolID string `json:"application_mcp_server_tool_id"` // 应用MCP工具ID
-	Enabled                    bool   `json:"enabled"`                        // 是否启用
+	ID                            string            `json:"id"`                                        // 配置ID
+	ApplicationMcpServerToolID    string            `json:"application_mcp_server_tool_id"`            // 应用MCP工具ID
+	Enabled                       bool              `json:"enabled"`                                   // 是否启用
+	TitleOverride                 string            `json:"title_override"`                            // 覆盖的工具标题
+	DescriptionOverride           string            `json:"description_override"`                      // 覆盖的工具描述
+	ParameterDescriptionOverrides map[string]string `json:"parameter_description_overrides,omitempty"` // 覆盖的参数描述，键为参数名称
+	OutputRenderer                string            `json:"output_renderer"`                           // 工具结果渲染方式：table 表格，image 图片，file 文件，json JSON，为空时按原始内容展示
 }
 
 // ChatAgentAvailableMcpServerToolDto 聊天智能体可用的MCP工具
@@ -16,7 +21,14 @@ type ChatAgentAvailableMcpServerToolDto struct {
 	Name                         string `json:"name"`                             // 工具名称
 	Title                        string `json:"title"`                            // 工具标题
 	Description                  string `json:"description"`                      // 工具描述
-	Enabled                      bool   `json:"enabled"`                          // 是否启用
+	Enabled                      bool   `json:"enabled"`                          // 智能体自身是否启用
+	// 通过关联的工具集启用该工具时为工具集名称，此时无论 enabled 是否为 true 工具都会提供给模型
+	Bundles []string `json:"bundles,omitempty"`
+	// 智能体对工具定义的覆盖，为空时不覆盖
+	TitleOverride                 string            `json:"title_override"`
+	DescriptionOverride           string            `json:"description_override"`
+	ParameterDescriptionOverrides map[string]string `json:"parameter_description_overrides,omitempty"`
+	OutputRenderer                string            `json:"output_renderer"` // 工具结果渲染方式
 }
 
 // McpServerConfigDto MCP服务配置信息
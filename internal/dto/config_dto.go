@@ -0,0 +1,13 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// ConfigReloadDto 重新加载配置的结果
+// 列出重新加载后生效的配置
+type ConfigReloadDto struct {
+	LogLevel                 string   `json:"log_level"`                   // 日志级别
+	CorsAllowedOrigins       []string `json:"cors_allowed_origins"`        // 跨域允许的来源
+	LlmDefaultMaxConcurrency int      `json:"llm_default_max_concurrency"` // 模型提供商默认并发上限，0 表示不限制
+	LlmQueueTimeoutSeconds   int      `json:"llm_queue_timeout_seconds"`   // 模型提供商排队等待的最长时间（秒），0 表示一直等待
+	ReloadedAt               int64    `json:"reloaded_at"`                 // 重新加载的时间（时间戳）
+}
@@ -0,0 +1,21 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// ConversationArchiveDto 会话归档记录数据传输对象
+type ConversationArchiveDto struct {
+	BaseModelDto
+	ApplicationID         string `json:"application_id"`          // 所属应用ID
+	ChatAgentID           string `json:"chat_agent_id"`           // 所属智能体ID
+	ServiceUserID         string `json:"service_user_id"`         // 业务侧的用户ID
+	ConversationID        string `json:"conversation_id"`         // 归档的会话ID，恢复后会话使用同一个ID
+	Title                 string `json:"title"`                   // 会话标题
+	MessageCount          int    `json:"message_count"`           // 归档的消息数量（含工具调用消息）
+	ConversationCreatedAt int64  `json:"conversation_created_at"` // 会话的创建时间（时间戳）
+	LastMessageAt         *int64 `json:"last_message_at"`         // 会话最后一条普通消息的时间（时间戳）
+	StorageType           string `json:"storage_type"`            // 归档时使用的存储类型：s3、file_system
+	ObjectKey             string `json:"object_key"`              // 归档文件的对象键，S3 存储时不含存储配置的前缀
+	Status                string `json:"status"`                  // 状态：archived 已归档，restored 已恢复
+	ArchivedAt            int64  `json:"archived_at"`             // 最近一次归档的时间（时间戳）
+	RestoredAt            *int64 `json:"restored_at"`             // 最近一次恢复的时间（时间戳）
+}
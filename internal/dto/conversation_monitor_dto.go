@@ -0,0 +1,32 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// 会话监控事件类型
+const (
+	ConversationMonitorEventConversationCreated = "conversation_created" // 新建会话
+	ConversationMonitorEventMessage             = "message"              // 新增普通消息
+	ConversationMonitorEventToolCall            = "tool_call"            // 发起工具调用
+	ConversationMonitorEventToolCallOutput      = "tool_call_output"     // 工具调用返回
+	ConversationMonitorEventInjectedMessage     = "injected_message"     // 注入给模型的上下文
+	ConversationMonitorEventError               = "error"                // 处理消息出错
+)
+
+// ConversationMonitorEventDto 会话监控事件
+// 管理后台实时监控智能体会话时推送的事件
+type ConversationMonitorEventDto struct {
+	EventType             string `json:"event_type"`                        // 事件类型
+	ChatAgentID           string `json:"chat_agent_id"`                     // 智能体ID
+	ConversationID        string `json:"conversation_id"`                   // 会话ID
+	ServiceUserID         string `json:"service_user_id,omitempty"`         // 业务侧用户ID（新建会话时）
+	RequestID             string `json:"request_id,omitempty"`              // 请求ID
+	MessageID             string `json:"message_id,omitempty"`              // 消息ID
+	Role                  string `json:"role,omitempty"`                    // 消息角色
+	Content               string `json:"content,omitempty"`                 // 消息内容、会话标题或错误信息
+	Language              string `json:"language,omitempty"`                // 用户消息识别出的语言
+	FunctionCallName      string `json:"function_call_name,omitempty"`      // 函数调用名称
+	FunctionCallArguments string `json:"function_call_arguments,omitempty"` // 函数调用参数
+	FunctionCallOutput    string `json:"function_call_output,omitempty"`    // 函数调用返回值
+	TotalTokenCount       int    `json:"total_token_count,omitempty"`       // 总token数
+	CreatedAt             int64  `json:"created_at"`                        // 事件时间（毫秒时间戳）
+}
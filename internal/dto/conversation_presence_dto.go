@@ -0,0 +1,34 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// 会话状态事件类型
+const (
+	ConversationPresenceEventState           = "state"            // 订阅时的当前状态
+	ConversationPresenceEventUserTyping      = "user_typing"      // 用户开始输入
+	ConversationPresenceEventUserStopTyping  = "user_stop_typing" // 用户停止输入或输入状态过期
+	ConversationPresenceEventAgentGenerating = "agent_generating" // 智能体开始生成回复
+	ConversationPresenceEventAgentFinished   = "agent_finished"   // 智能体回复输出结束
+)
+
+// UpdateTypingStatusRequest 上报用户输入状态请求
+// 输入状态在一段时间后自动过期，用户持续输入时客户端需定期重复上报
+type UpdateTypingStatusRequest struct {
+	ConversationID string `json:"conversation_id" binding:"required"` // 会话ID
+	ServiceUserID  string `json:"service_user_id" binding:"required"` // 业务侧用户ID，需为会话所属用户
+	DeviceID       string `json:"device_id" binding:"max=64"`         // 设备ID，用于区分同一用户的多个设备（可选）
+	Typing         bool   `json:"typing"`                             // 是否正在输入
+}
+
+// ConversationPresenceEventDto 会话状态事件
+// 每个事件都包含事件发生后的完整状态，客户端以最新事件的状态为准
+type ConversationPresenceEventDto struct {
+	EventType       string   `json:"event_type"`           // 事件类型
+	ConversationID  string   `json:"conversation_id"`      // 会话ID
+	DeviceID        string   `json:"device_id,omitempty"`  // 触发事件的设备ID（输入事件）
+	RequestID       string   `json:"request_id,omitempty"` // 触发事件的请求ID（生成回复事件）
+	TypingDeviceIDs []string `json:"typing_device_ids"`    // 正在输入的设备ID列表，设备可据此忽略自身的输入状态
+	UserTyping      bool     `json:"user_typing"`          // 是否有设备正在输入
+	AgentGenerating bool     `json:"agent_generating"`     // 智能体是否正在生成回复
+	CreatedAt       int64    `json:"created_at"`           // 事件时间（毫秒时间戳）
+}
@@ -0,0 +1,20 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// SetConversationVariableRequest 设置会话变量请求
+// 变量已存在时覆盖变量值
+type SetConversationVariableRequest struct {
+	ConversationID string `json:"conversation_id" binding:"required"` // 会话ID
+	ServiceUserID  string `json:"service_user_id" binding:"required"` // 业务侧用户ID，需为会话所属用户
+	Name           string `json:"name" binding:"required"`            // 变量名称，以字母或下划线开头，只能包含字母、数字和下划线，不超过64个字符
+	Value          string `json:"value"`                              // 变量值，不超过4096个字符
+}
+
+// ConversationVariableDto 会话变量
+type ConversationVariableDto struct {
+	Name      string `json:"name"`       // 变量名称
+	Value     string `json:"value"`      // 变量值
+	Source    string `json:"source"`     // 最后一次设置的来源：api 业务侧接口，model 模型调用内部工具
+	UpdatedAt int64  `json:"updated_at"` // 更新时间，Unix 13位毫秒时间戳
+}
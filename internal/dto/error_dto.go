@@ -0,0 +1,13 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// ErrorResponse 标准错误响应
+// 所有接口出错时统一返回该结构
+type ErrorResponse struct {
+	Code      string      `json:"code"`              // 错误码，如 INVALID_ARGUMENT、NOT_FOUND
+	Message   string      `json:"message"`           // 错误信息
+	Details   interface{} `json:"details,omitempty"` // 错误详情（可选）
+	RequestID string      `json:"request_id"`        // 请求ID，用于排查问题
+	Error     string      `json:"error,omitempty"`   // 错误信息（仅 v1 接口返回，兼容旧版客户端，与 message 相同）
+}
@@ -0,0 +1,142 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// EvaluationSetDto 评测集数据传输对象
+type EvaluationSetDto struct {
+	BaseModelDto
+	ApplicationID string `json:"application_id"` // 所属应用ID
+	ChatAgentID   string `json:"chat_agent_id"`  // 默认评测的智能体ID
+	Name          string `json:"name"`           // 评测集名称
+	Description   string `json:"description"`    // 评测集描述
+}
+
+// SaveEvaluationSetRequest 保存评测集请求
+type SaveEvaluationSetRequest struct {
+	ID          *string `json:"id,omitempty"`  // 主键ID（更新时提供）
+	ChatAgentID string  `json:"chat_agent_id"` // 默认评测的智能体ID（更新时忽略）
+	Name        string  `json:"name"`          // 评测集名称
+	Description string  `json:"description"`   // 评测集描述
+}
+
+// EvaluationAssertionDto 评测断言
+type EvaluationAssertionDto struct {
+	Type    string `json:"type"`    // 断言类型：contains 包含，not_contains 不包含，regex 匹配正则，not_regex 不匹配正则
+	Pattern string `json:"pattern"` // 文本或正则表达式
+}
+
+// EvaluationAssertionResultDto 评测断言的检查结果
+type EvaluationAssertionResultDto struct {
+	Type    string `json:"type"`    // 断言类型
+	Pattern string `json:"pattern"` // 文本或正则表达式
+	Passed  bool   `json:"passed"`  // 是否通过
+}
+
+// EvaluationCaseDto 评测用例数据传输对象
+type EvaluationCaseDto struct {
+	BaseModelDto
+	SetID          string                   `json:"set_id"`          // 所属评测集ID
+	Name           string                   `json:"name"`            // 用例名称
+	Input          string                   `json:"input"`           // 发送给智能体的用户消息
+	ExpectedTraits string                   `json:"expected_traits"` // 期望回答具备的特征，由评测模型判断
+	Assertions     []EvaluationAssertionDto `json:"assertions"`      // 断言列表
+	Enabled        bool                     `json:"enabled"`         // 是否参与评测
+}
+
+// SaveEvaluationCaseRequest 保存评测用例请求
+type SaveEvaluationCaseRequest struct {
+	ID             *string                  `json:"id,omitempty"`      // 主键ID（更新时提供）
+	SetID          string                   `json:"set_id"`            // 所属评测集ID
+	Name           string                   `json:"name"`              // 用例名称（可选）
+	Input          string                   `json:"input"`             // 发送给智能体的用户消息
+	ExpectedTraits string                   `json:"expected_traits"`   // 期望回答具备的特征（可选），与断言至少配置一项
+	Assertions     []EvaluationAssertionDto `json:"assertions"`        // 断言列表（可选），与期望特征至少配置一项
+	Enabled        *bool                    `json:"enabled,omitempty"` // 是否参与评测（可选），默认参与
+}
+
+// CreateEvaluationRunRequest 创建评测运行请求
+type CreateEvaluationRunRequest struct {
+	SetID         string  `json:"set_id"`                   // 评测集ID
+	ChatAgentID   *string `json:"chat_agent_id,omitempty"`  // 被评测的智能体ID（可选），默认使用评测集的智能体，必须属于同一应用
+	PromptVersion string  `json:"prompt_version"`           // 系统提示词版本标识，用于比较报告
+	SystemPrompt  string  `json:"system_prompt"`            // 本次评测使用的系统提示词，与聊天接口的系统提示词含义相同
+	JudgeModelID  *string `json:"judge_model_id,omitempty"` // 评测模型ID（可选），用例配置了期望特征时必须提供
+}
+
+// EvaluationRunDto 评测运行数据传输对象
+type EvaluationRunDto struct {
+	BaseModelDto
+	ApplicationID string  `json:"application_id"` // 所属应用ID
+	SetID         string  `json:"set_id"`         // 评测集ID
+	ChatAgentID   string  `json:"chat_agent_id"`  // 被评测的智能体ID
+	PromptVersion string  `json:"prompt_version"` // 系统提示词版本标识
+	SystemPrompt  string  `json:"system_prompt"`  // 本次评测使用的系统提示词
+	JudgeModelID  string  `json:"judge_model_id"` // 评测模型ID，未使用时为空
+	Status        string  `json:"status"`         // 运行状态：pending running completed failed cancelled
+	TotalCount    int     `json:"total_count"`    // 用例总数
+	PassedCount   int     `json:"passed_count"`   // 通过的用例数
+	FailedCount   int     `json:"failed_count"`   // 未通过（含运行出错）的用例数
+	PassRate      float64 `json:"pass_rate"`      // 通过率（0-1），按用例总数计算
+	AverageScore  float64 `json:"average_score"`  // 已评分用例的平均得分（0-1）
+	ErrorMessage  string  `json:"error_message"`  // 运行失败的原因
+	StartedAt     *int64  `json:"started_at"`     // 开始执行时间（时间戳）
+	FinishedAt    *int64  `json:"finished_at"`    // 结束时间（时间戳）
+}
+
+// EvaluationResultDto 评测结果数据传输对象
+type EvaluationResultDto struct {
+	BaseModelDto
+	RunID            string                         `json:"run_id"`            // 所属评测运行ID
+	CaseID           string                         `json:"case_id"`           // 评测用例ID
+	CaseIndex        int                            `json:"case_index"`        // 用例在本次运行中的序号
+	CaseName         string                         `json:"case_name"`         // 用例名称
+	Input            string                         `json:"input"`             // 用户消息
+	ExpectedTraits   string                         `json:"expected_traits"`   // 期望特征
+	Status           string                         `json:"status"`            // 结果状态：pending running passed failed error cancelled
+	Answer           string                         `json:"answer"`            // 智能体的回答
+	ConversationID   string                         `json:"conversation_id"`   // 运行时创建的会话ID
+	Score            float64                        `json:"score"`             // 得分（0-1）
+	AssertionResults []EvaluationAssertionResultDto `json:"assertion_results"` // 各断言的检查结果
+	JudgeReason      string                         `json:"judge_reason"`      // 评测模型给出的判断理由
+	ErrorMessage     string                         `json:"error_message"`     // 运行出错的原因
+	DurationMs       int64                          `json:"duration_ms"`       // 智能体回答耗时（毫秒）
+}
+
+// EvaluationRunListResponse 评测运行列表响应
+type EvaluationRunListResponse struct {
+	Runs     []EvaluationRunDto `json:"runs"`      // 运行列表
+	Total    int64              `json:"total"`     // 符合条件的总数量
+	Page     int                `json:"page"`      // 当前页码
+	PageSize int                `json:"page_size"` // 每页大小
+}
+
+// EvaluationResultListResponse 评测结果列表响应
+type EvaluationResultListResponse struct {
+	Results  []EvaluationResultDto `json:"results"`   // 结果列表，按用例序号排列
+	Total    int64                 `json:"total"`     // 符合条件的总数量
+	Page     int                   `json:"page"`      // 当前页码
+	PageSize int                   `json:"page_size"` // 每页大小
+}
+
+// EvaluationCaseComparisonDto 两次评测运行中同一用例的结果对比
+type EvaluationCaseComparisonDto struct {
+	CaseID       string   `json:"case_id"`       // 评测用例ID
+	CaseName     string   `json:"case_name"`     // 用例名称
+	Input        string   `json:"input"`         // 用户消息
+	BaseStatus   string   `json:"base_status"`   // 基准运行的结果状态，用例不在基准运行中时为空
+	BaseScore    *float64 `json:"base_score"`    // 基准运行的得分
+	TargetStatus string   `json:"target_status"` // 对比运行的结果状态，用例不在对比运行中时为空
+	TargetScore  *float64 `json:"target_score"`  // 对比运行的得分
+	Change       string   `json:"change"`        // 变化：improved 变好，regressed 变差，unchanged 不变，added 新增，removed 移除
+}
+
+// EvaluationRunComparisonResponse 评测运行对比响应
+type EvaluationRunComparisonResponse struct {
+	BaseRun           EvaluationRunDto              `json:"base_run"`            // 基准运行
+	TargetRun         EvaluationRunDto              `json:"target_run"`          // 对比运行
+	PassRateDelta     float64                       `json:"pass_rate_delta"`     // 通过率变化（对比运行减基准运行）
+	AverageScoreDelta float64                       `json:"average_score_delta"` // 平均得分变化（对比运行减基准运行）
+	ImprovedCount     int                           `json:"improved_count"`      // 变好的用例数
+	RegressedCount    int                           `json:"regressed_count"`     // 变差的用例数
+	Cases             []EvaluationCaseComparisonDto `json:"cases"`               // 各用例的对比
+}
@@ -0,0 +1,54 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// KnowledgeBaseDto 知识库数据传输对象
+type KnowledgeBaseDto struct {
+	BaseModelDto
+	ApplicationID    string `json:"application_id"`     // 所属应用ID
+	Name             string `json:"name"`               // 知识库名称
+	Description      string `json:"description"`        // 知识库描述
+	EmbeddingModelID string `json:"embedding_model_id"` // 嵌入模型ID
+	ChunkSize        int    `json:"chunk_size"`         // 片段最大字符数，0 表示使用默认值
+	ChunkOverlap     int    `json:"chunk_overlap"`      // 超长段落切分时相邻片段重叠的字符数
+}
+
+// SaveKnowledgeBaseRequest 保存知识库请求
+type SaveKnowledgeBaseRequest struct {
+	ID               *string `json:"id,omitempty"`       // 主键ID（更新时提供）
+	ApplicationID    string  `json:"application_id"`     // 所属应用ID（更新时忽略）
+	Name             string  `json:"name"`               // 知识库名称
+	Description      string  `json:"description"`        // 知识库描述
+	EmbeddingModelID string  `json:"embedding_model_id"` // 嵌入模型ID，已有文档时不能更换
+	ChunkSize        int     `json:"chunk_size"`         // 片段最大字符数，0 表示使用默认值；修改后需要重建索引
+	ChunkOverlap     int     `json:"chunk_overlap"`      // 超长段落切分时相邻片段重叠的字符数，不能超过片段大小的一半
+}
+
+// KnowledgeDocumentDto 知识库文档数据传输对象
+type KnowledgeDocumentDto struct {
+	BaseModelDto
+	ApplicationID   string `json:"application_id"`    // 所属应用ID
+	KnowledgeBaseID string `json:"knowledge_base_id"` // 所属知识库ID
+	Name            string `json:"name"`              // 文档名称
+	SourceType      string `json:"source_type"`       // 来源：text 文本，file 上传文件
+	ContentHash     string `json:"content_hash"`      // 文档内容的SHA-256
+	Status          string `json:"status"`            // 处理状态：pending processing completed failed
+	ErrorMessage    string `json:"error_message"`     // 处理失败的原因
+	ChunkCount      int    `json:"chunk_count"`       // 片段数量
+}
+
+// SaveKnowledgeTextDocumentRequest 保存文本文档请求
+type SaveKnowledgeTextDocumentRequest struct {
+	KnowledgeBaseID string  `json:"knowledge_base_id"`     // 所属知识库ID
+	DocumentID      *string `json:"document_id,omitempty"` // 文档ID（替换已有文档内容时提供）
+	Name            string  `json:"name"`                  // 文档名称
+	Content         string  `json:"content"`               // 文档内容，保存后在后台切分为片段
+}
+
+// KnowledgeDocumentListResponse 知识库文档列表响应
+type KnowledgeDocumentListResponse struct {
+	Documents []KnowledgeDocumentDto `json:"documents"` // 文档列表
+	Total     int64                  `json:"total"`     // 符合条件的总数量
+	Page      int                    `json:"page"`      // 当前页码
+	PageSize  int                    `json:"page_size"` // 每页大小
+}
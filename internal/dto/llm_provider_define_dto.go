@@ -0,0 +1,20 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传输数据，避免直接暴露内部模型
+package dto
+
+// LlmProviderDefineDto 大语言模型供应商定义数据传输对象
+type LlmProviderDefineDto struct {
+	ID            string `json:"id,omitempty"`    // 供应商定义ID，内置目录中为空
+	Name          string `json:"name"`            // 供应商名称
+	Description   string `json:"description"`     // 供应商描述
+	Type          string `json:"type"`            // 供应商类型
+	IconUrl       string `json:"icon_url"`        // 供应商图标URL
+	DefaultApiUrl string `json:"default_api_url"` // 默认API URL
+}
+
+// LoadLlmProviderDefinePresetsResponse 加载内置供应商定义响应
+type LoadLlmProviderDefinePresetsResponse struct {
+	Created int `json:"created"` // 新增数量
+	Updated int `json:"updated"` // 覆盖更新数量
+	Skipped int `json:"skipped"` // 已存在而跳过的数量
+}
@@ -2,36 +2,37 @@
 // 用于在不同层之间传输数据，避免直接暴露内部模型
 package dto
 
-import (
-	"time"
-)
-
 // LlmProviderDto 大语言模型提供商数据传输对象
 // 用于向前端返回提供商信息
 type LlmProviderDto struct {
-	ID            string    `json:"id"`             // 提供商ID
-	Name          string    `json:"name"`           // 提供商名称
-	Description   string    `json:"description"`    // 提供商描述
-	Type          string    `json:"type"`           // 提供商类型
-	IconUrl       string    `json:"icon_url"`       // 提供商图标URL
-	ApplicationID string    `json:"application_id"` // 所属应用ID
-	ApiUrl        string    `json:"api_url"`        // API URL
-	ApiKey        string    `json:"api_key"`        // API Key
-	CreatedAt     time.Time `json:"created_at"`     // 创建时间
-	UpdatedAt     time.Time `json:"updated_at"`     // 更新时间
+	ID             string `json:"id"`              // 提供商ID
+	Name           string `json:"name"`            // 提供商名称
+	Description    string `json:"description"`     // 提供商描述
+	Type           string `json:"type"`            // 提供商类型
+	IconUrl        string `json:"icon_url"`        // 提供商图标URL
+	ApplicationID  string `json:"application_id"`  // 所属应用ID
+	ApiUrl         string `json:"api_url"`         // API URL
+	ApiKey         string `json:"api_key"`         // API Key
+	MaxConcurrency int    `json:"max_concurrency"` // 同时处理的请求数上限，0 表示使用全局默认值
+	Version        int64  `json:"version"`         // 数据版本号，保存时原样带回
+	CreatedAt      int64  `json:"created_at"`      // 创建时间，Unix 13位毫秒时间戳
+	UpdatedAt      int64  `json:"updated_at"`      // 更新时间，Unix 13位毫秒时间戳
 }
 
 // LlmProviderSaveDto 大语言模型提供商保存数据传输对象
 // 用于接收前端提交的提供商信息
 type LlmProviderSaveDto struct {
-	ID            string `json:"id"`             // 提供商ID（更新时必填）
-	Name          string `json:"name"`           // 提供商名称
-	Description   string `json:"description"`    // 提供商描述
-	Type          string `json:"type"`           // 提供商类型
-	IconUrl       string `json:"icon_url"`       // 提供商图标URL
-	ApplicationID string `json:"application_id"` // 所属应用ID
-	ApiUrl        string `json:"api_url"`        // API URL
-	ApiKey        string `json:"api_key"`        // API Key
+	ID             string   `json:"id"`                              // 提供商ID（更新时必填）
+	Name           string   `json:"name"`                            // 提供商名称
+	Description    string   `json:"description"`                     // 提供商描述
+	Type           string   `json:"type"`                            // 提供商类型
+	IconUrl        string   `json:"icon_url"`                        // 提供商图标URL
+	ApplicationID  string   `json:"application_id"`                  // 所属应用ID
+	ApiUrl         string   `json:"api_url"`                         // API URL
+	ApiKey         string   `json:"api_key"`                         // API Key
+	MaxConcurrency int      `json:"max_concurrency" binding:"min=0"` // 同时处理的请求数上限，0 表示使用全局默认值
+	Version        int64    `json:"version" binding:"min=0"`         // 数据版本号（更新时提供），与当前版本不一致时返回冲突，也可通过 If-Match 请求头提供
+	UpdateFields   []string `json:"update_fields,omitempty"`         // 部分更新的字段列表（JSON 字段名），更新时提供则只修改列出的字段，其余字段保持不变
 }
 
 // LlmProviderQueryDto 大语言模型提供商查询数据传输对象
@@ -0,0 +1,29 @@
+// Package dto 提供数据传输对象定义
+// 用于在不同层之间传递数据，确保数据格式的一致性
+package dto
+
+// ServiceUserDto 业务侧用户数据传输对象
+type ServiceUserDto struct {
+	BaseModelDto
+	ApplicationID string                 `json:"application_id"`  // 所属应用ID
+	ServiceUserID string                 `json:"service_user_id"` // 业务侧用户ID
+	DisplayName   string                 `json:"display_name"`    // 展示名称
+	AvatarUrl     string                 `json:"avatar_url"`      // 头像URL
+	Metadata      map[string]interface{} `json:"metadata"`        // 业务侧自定义元数据
+}
+
+// UpsertServiceUserRequest 登记业务侧用户请求
+// 用户不存在时创建，存在时只更新请求中提供的字段
+type UpsertServiceUserRequest struct {
+	ServiceUserID string                 `json:"service_user_id"` // 业务侧用户ID
+	DisplayName   *string                `json:"display_name"`    // 展示名称（可选）
+	AvatarUrl     *string                `json:"avatar_url"`      // 头像URL（可选）
+	Metadata      map[string]interface{} `json:"metadata"`        // 业务侧自定义元数据（可选），提供时整体替换
+}
+
+// ServiceUserInfoDto 会话列表中附带的业务侧用户信息
+type ServiceUserInfoDto struct {
+	DisplayName string                 `json:"display_name"` // 展示名称
+	AvatarUrl   string                 `json:"avatar_url"`   // 头像URL
+	Metadata    map[string]interface{} `json:"metadata"`     // 业务侧自定义元数据
+}
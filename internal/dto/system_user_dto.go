@@ -27,3 +27,46 @@ type SystemUserSaveDto struct {
 	Email    string `json:"email" binding:"required"`     // 用户邮箱
 	Password string `json:"password" binding:"omitempty"` // 用户密码
 }
+
+// SystemUserSessionDto 用户登录会话DTO
+// 不包含会话Token，避免在会话列表中泄露
+type SystemUserSessionDto struct {
+	ID             string `json:"id"`               // 会话ID
+	CreatedAt      int64  `json:"created_at"`       // 登录时间（时间戳）
+	LoginExpiredAt int64  `json:"login_expired_at"` // 访问令牌过期时间（时间戳）
+	ExpiredAt      *int64 `json:"expired_at"`       // 会话过期时间（时间戳），即刷新令牌过期时间
+	IPAddress      string `json:"ip_address"`       // 登录IP
+	UserAgent      string `json:"user_agent"`       // 登录时的User-Agent
+	Current        bool   `json:"current"`          // 是否为当前请求使用的会话
+}
+
+// SystemUserRefreshDto 刷新令牌请求DTO
+type SystemUserRefreshDto struct {
+	RefreshToken string `json:"refresh_token" binding:"required"` // 登录或上次刷新得到的刷新令牌
+}
+
+// SystemUserChangePasswordDto 修改密码请求DTO
+type SystemUserChangePasswordDto struct {
+	CurrentPassword string `json:"current_password" binding:"required"` // 当前密码
+	NewPassword     string `json:"new_password" binding:"required"`     // 新密码
+}
+
+// SystemUserInviteDto 邀请用户请求DTO
+// 被邀请的用户通过邮件中的链接设置初始密码
+type SystemUserInviteDto struct {
+	Name   string `json:"name" binding:"required"`        // 用户名字
+	Number string `json:"number" binding:"required"`      // 用户账号
+	Email  string `json:"email" binding:"required,email"` // 用户邮箱，接收邀请邮件
+}
+
+// SystemUserPasswordTokenDto 通过邮件链接设置密码的请求DTO
+// 用于接受邀请和重置密码
+type SystemUserPasswordTokenDto struct {
+	Token    string `json:"token" binding:"required"`    // 邮件链接中的令牌
+	Password string `json:"password" binding:"required"` // 新密码
+}
+
+// SystemUserForgotPasswordDto 找回密码请求DTO
+type SystemUserForgotPasswordDto struct {
+	Email string `json:"email" binding:"required,email"` // 用户邮箱
+}
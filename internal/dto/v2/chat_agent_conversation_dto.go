@@ -0,0 +1,21 @@
+// Package v2 提供第二版API的数据传输对象定义
+// 与 v1 结构不兼容的响应DTO在此定义，并通过映射函数由 v1 DTO 转换得到
+package v2
+
+import (
+	"lemon-tree-core/internal/dto"
+)
+
+// GetConversationListResponse 获取会话列表响应
+// 与 v1 相比，分页信息不再平铺在响应中，而是放在 page_info 字段
+type GetConversationListResponse struct {
+	Conversations []dto.ConversationInfoDto `json:"conversations"` // 会话列表
+	PageInfo      dto.CursorPageInfo        `json:"page_info"`     // 分页信息
+}
+
+// GetChatMessageListResponse 获取聊天消息列表响应
+// 与 v1 相比，分页信息不再平铺在响应中，而是放在 page_info 字段
+type GetChatMessageListResponse struct {
+	Messages []dto.ChatMessageInfoDto `json:"messages"`  // 消息列表
+	PageInfo dto.CursorPageInfo       `json:"page_info"` // 分页信息
+}
@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"lemon-tree-core/internal/apiversion"
+	"lemon-tree-core/internal/dto"
+)
+
+// RegisterMappers 注册 v1 响应DTO到 v2 响应结构的映射函数
+func RegisterMappers() {
+	apiversion.RegisterMapper(apiversion.V2, dto.GetConversationListResponse{}, func(payload interface{}) interface{} {
+		response := payload.(dto.GetConversationListResponse)
+		return GetConversationListResponse{
+			Conversations: response.Conversations,
+			PageInfo:      response.CursorPageInfo,
+		}
+	})
+
+	apiversion.RegisterMapper(apiversion.V2, dto.GetChatMessageListResponse{}, func(payload interface{}) interface{} {
+		response := payload.(dto.GetChatMessageListResponse)
+		return GetChatMessageListResponse{
+			Messages: response.Messages,
+			PageInfo: response.CursorPageInfo,
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Package grpcapi 提供 gRPC 接口层功能
+// 负责 gRPC 请求认证、参数转换、调用业务逻辑和返回响应
+package grpcapi
+
+import (
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/service"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// metadataKeyApiKey 携带智能体 ApiKey 的 metadata 键，与 HTTP 请求头一致
+const metadataKeyApiKey = "lemon-ai-api-key"
+
+// ChatAgentAuthenticator 智能体认证器
+// 验证 metadata 中的 ApiKey，并将智能体和应用信息写入上下文
+type ChatAgentAuthenticator struct {
+	chatAgentService   service.ChatAgentService   // ChatAgent 服务
+	applicationService service.ApplicationService // Application 服务
+}
+
+// NewChatAgentAuthenticator 创建智能体认证器实例
+// 参数：chatAgentService - ChatAgent 服务，applicationService - Application 服务
+func NewChatAgentAuthenticator(chatAgentService service.ChatAgentService, applicationService service.ApplicationService) *ChatAgentAuthenticator {
+	return &ChatAgentAuthenticator{
+		chatAgentService:   chatAgentService,
+		applicationService: applicationService,
+	}
+}
+
+// UnaryInterceptor 一元调用认证拦截器
+func (a *ChatAgentAuthenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		authCtx, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(authCtx, req)
+	}
+}
+
+// StreamInterceptor 流式调用认证拦截器
+func (a *ChatAgentAuthenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authCtx, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedServerStream{ServerStream: ss, ctx: authCtx})
+	}
+}
+
+// authenticate 验证 ApiKey 并返回携带智能体和应用信息的上下文
+func (a *ChatAgentAuthenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	apiKeys := md.Get(metadataKeyApiKey)
+	if len(apiKeys) == 0 || apiKeys[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "缺少 Lemon AI ApiKey")
+	}
+
+	// 验证Api Key和来源IP获取ChatAgent，来源IP不满足网段限制时返回 PermissionDenied
+	access := service.ApiKeyAccess{ClientIP: peerIP(ctx)}
+	access.Path, _ = grpc.Method(ctx)
+	if userAgents := md.Get("user-agent"); len(userAgents) > 0 {
+		access.UserAgent = userAgents[0]
+	}
+	chatAgent, err := a.chatAgentService.GetChatAgentByApiKey(ctx, apiKeys[0], access)
+	if err != nil {
+		return nil, toStatusError(apperror.Wrap(apperror.CodeUnauthorized, "", err))
+	}
+	application, err := a.applicationService.GetApplicationByID(ctx, chatAgent.ApplicationID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	ctx = context.WithValue(ctx, define.AppContextKeyCurrentChatAgent, chatAgent)
+	ctx = context.WithValue(ctx, define.AppContextKeyCurrentApplication, application)
+	return ctx, nil
+}
+
+// peerIP 获取连接对端的IP地址，无法获取时返回空字符串
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// authenticatedServerStream 携带认证上下文的服务端流
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context // 认证后的上下文
+}
+
+// Context 返回认证后的上下文
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,261 @@
+// Package grpcapi 提供 gRPC 接口层功能
+// 负责 gRPC 请求认证、参数转换、调用业务逻辑和返回响应
+package grpcapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/grpcapi/chatv1"
+	"lemon-tree-core/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxEventSize 单个消息事件的最大字节数
+const maxEventSize = 16 * 1024 * 1024
+
+// ChatServer 聊天服务 gRPC 实现
+// 复用 HTTP 接口的业务逻辑层，将 SSE 事件转换为 gRPC 流消息
+type ChatServer struct {
+	chatv1.UnimplementedChatServiceServer
+	chatAgentConversationService service.ChatAgentConversationService // 聊天会话 业务逻辑层接口
+}
+
+// NewChatServer 创建聊天服务 gRPC 实现实例
+// 参数：chatAgentConversationService - 聊天会话 业务逻辑层接口
+func NewChatServer(chatAgentConversationService service.ChatAgentConversationService) *ChatServer {
+	return &ChatServer{
+		chatAgentConversationService: chatAgentConversationService,
+	}
+}
+
+// SendMessage 发送消息，服务端流式返回消息事件
+func (s *ChatServer) SendMessage(req *chatv1.SendMessageRequest, stream chatv1.ChatService_SendMessageServer) error {
+	ctx := stream.Context()
+
+	sendReq := &dto.ChatUserSendMessageRequest{
+		ServiceUserID:        req.GetServiceUserId(),
+		SystemPrompt:         req.GetSystemPrompt(),
+		UserMessage:          req.GetUserMessage(),
+		PredefinedAnswer:     req.PredefinedAnswer,
+		UsedInternalToolList: req.GetUsedInternalToolList(),
+		ConversationID:       req.ConversationId,
+		Attachments:          req.GetAttachments(),
+		RequestID:            req.RequestId,
+		DeltaChunkMode:       req.DeltaChunkMode,
+	}
+	for _, tool := range req.GetUsedMcpToolList() {
+		sendReq.UsedMcpToolList = append(sendReq.UsedMcpToolList, dto.ChatMessageUseToolDto{
+			ApplicationMcpConfigID: tool.GetApplicationMcpConfigId(),
+			ToolName:               tool.GetToolName(),
+		})
+	}
+
+	// 调用业务逻辑层处理消息
+	var events io.Reader
+	var err error
+	if req.PredefinedAnswer != nil {
+		if req.GetPredefinedAnswer() == "" {
+			return status.Error(codes.InvalidArgument, "预制答案不能为空")
+		}
+		events, err = s.chatAgentConversationService.UserSendMessagePredefinedAnswer(ctx, sendReq, req.GetStreamable())
+	} else {
+		events, err = s.chatAgentConversationService.UserSendMessage(ctx, sendReq, req.GetStreamable())
+	}
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	// 解析 SSE 事件并逐条发送
+	return readSseEvents(events, func(event *dto.ChatMessageResponseEventDto) error {
+		message := &chatv1.ChatMessageEvent{
+			ConversationId: event.ConversationID,
+			RequestId:      event.RequestID,
+			MessageType:    event.MessageType,
+			Content:        event.Content,
+		}
+		// 知识库引用和追问建议以JSON数组的形式放在内容中返回
+		if len(event.Citations) > 0 {
+			citationsJSON, _ := json.Marshal(event.Citations)
+			message.Content = string(citationsJSON)
+		}
+		if len(event.Suggestions) > 0 {
+			suggestionsJSON, _ := json.Marshal(event.Suggestions)
+			message.Content = string(suggestionsJSON)
+		}
+		if event.ToolCall != nil {
+			message.ToolCall = &chatv1.ToolCall{
+				Id:        event.ToolCall.ID,
+				Type:      event.ToolCall.Type,
+				Name:      event.ToolCall.Function.Name,
+				Arguments: event.ToolCall.Function.Arguments,
+			}
+		}
+		return stream.Send(message)
+	})
+}
+
+// ListConversations 获取会话列表
+func (s *ChatServer) ListConversations(ctx context.Context, req *chatv1.ListConversationsRequest) (*chatv1.ListConversationsResponse, error) {
+	if req.GetServiceUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "service_user_id 参数不能为空")
+	}
+
+	listReq := &dto.GetConversationListRequest{
+		ServiceUserID: req.GetServiceUserId(),
+		IncludeTotal:  req.GetIncludeTotal(),
+	}
+	if req.GetLastId() != "" {
+		lastID := req.GetLastId()
+		listReq.LastID = &lastID
+	}
+	if req.GetSize() > 0 {
+		size := int(req.GetSize())
+		listReq.Size = &size
+	}
+
+	// 调用业务逻辑层获取会话列表
+	conversations, pageInfo, err := s.chatAgentConversationService.GetConversationList(ctx, listReq)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &chatv1.ListConversationsResponse{
+		HasMore:    pageInfo.HasMore,
+		TotalCount: pageInfo.TotalCount,
+	}
+	if pageInfo.NextCursor != nil {
+		response.NextCursor = *pageInfo.NextCursor
+	}
+	for _, conv := range conversations {
+		response.Conversations = append(response.Conversations, &chatv1.Conversation{
+			Id:            conv.ID.String(),
+			Title:         conv.Title,
+			ApplicationId: conv.ApplicationID.String(),
+			ServiceUserId: conv.ServiceUserID,
+			CreatedAt:     conv.CreatedAt.UnixMilli(),
+			UpdatedAt:     conv.UpdatedAt.UnixMilli(),
+		})
+	}
+	return response, nil
+}
+
+// UploadAttachment 上传附件，客户端流式发送文件信息和文件分片
+// 第一条消息必须为文件信息，后续消息为文件内容分片
+func (s *ChatServer) UploadAttachment(stream chatv1.ChatService_UploadAttachmentServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "读取文件信息失败")
+	}
+	info := first.GetInfo()
+	if info == nil || info.GetFilename() == "" {
+		return status.Error(codes.InvalidArgument, "第一条消息必须包含文件信息")
+	}
+
+	// 通过管道将文件分片转发给业务逻辑层
+	reader, writer := io.Pipe()
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				writer.Close()
+				return
+			}
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+			if _, err := writer.Write(msg.GetChunk()); err != nil {
+				return
+			}
+		}
+	}()
+
+	result, err := s.chatAgentConversationService.UploadAttachment(stream.Context(), reader, info.GetFilename(), info.GetSize())
+	// 业务逻辑层未读取完全部分片时，关闭管道以结束接收协程
+	reader.Close()
+	if err != nil {
+		return toStatusError(err)
+	}
+	if !result.Success {
+		message := "上传附件失败"
+		if result.Error != nil {
+			message = *result.Error
+		}
+		return status.Error(codes.InvalidArgument, message)
+	}
+
+	response := &chatv1.UploadAttachmentResponse{}
+	if result.AttachmentID != nil {
+		response.AttachmentId = *result.AttachmentID
+	}
+	if result.OriginalFileName != nil {
+		response.OriginalFilename = *result.OriginalFileName
+	}
+	if result.FileSize != nil {
+		response.FileSize = *result.FileSize
+	}
+	if result.AttachmentType != nil {
+		response.AttachmentType = *result.AttachmentType
+	}
+	return stream.SendAndClose(response)
+}
+
+// readSseEvents 解析 SSE 事件流
+// 逐行读取 "data: " 开头的事件并反序列化后交给回调处理
+// 参数：r - SSE 事件流，handle - 事件处理回调
+func readSseEvents(r io.Reader, handle func(event *dto.ChatMessageResponseEventDto) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEventSize)
+	for scanner.Scan() {
+		data, ok := bytes.CutPrefix(scanner.Bytes(), []byte("data: "))
+		if !ok || len(data) == 0 {
+			continue
+		}
+		var event dto.ChatMessageResponseEventDto
+		if err := json.Unmarshal(data, &event); err != nil {
+			return status.Errorf(codes.Internal, "解析消息事件失败: %v", err)
+		}
+		if err := handle(&event); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return status.Errorf(codes.Internal, "读取消息事件失败: %v", err)
+	}
+	return nil
+}
+
+// toStatusError 将业务错误转换为 gRPC 状态错误
+func toStatusError(err error) error {
+	appErr := apperror.From(err)
+	var code codes.Code
+	switch appErr.Code {
+	case apperror.CodeInvalidArgument:
+		code = codes.InvalidArgument
+	case apperror.CodeUnauthorized:
+		code = codes.Unauthenticated
+	case apperror.CodeForbidden:
+		code = codes.PermissionDenied
+	case apperror.CodeNotFound:
+		code = codes.NotFound
+	case apperror.CodeConflict:
+		code = codes.AlreadyExists
+	case apperror.CodePayloadTooLarge:
+		code = codes.ResourceExhausted
+	case apperror.CodeTooManyRequests:
+		code = codes.ResourceExhausted
+	case apperror.CodeServiceUnavailable:
+		code = codes.Unavailable
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, appErr.Message)
+}
@@ -0,0 +1,930 @@
+// 聊天服务 gRPC 接口定义
+// 供内部服务直接集成聊天能力，无需解析 HTTP/SSE
+// 认证方式：在 metadata 中携带 lemon-ai-api-key，与 HTTP 接口的请求头一致
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: chat/v1/chat.proto
+
+package chatv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UseTool 使用的MCP工具
+type UseTool struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ApplicationMcpConfigId string                 `protobuf:"bytes,1,opt,name=application_mcp_config_id,json=applicationMcpConfigId,proto3" json:"application_mcp_config_id,omitempty"` // 应用mcp配置ID
+	ToolName               string                 `protobuf:"bytes,2,opt,name=tool_name,json=toolName,proto3" json:"tool_name,omitempty"`                                               // 工具名称
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UseTool) Reset() {
+	*x = UseTool{}
+	mi := &file_chat_v1_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UseTool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UseTool) ProtoMessage() {}
+
+func (x *UseTool) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UseTool.ProtoReflect.Descriptor instead.
+func (*UseTool) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UseTool) GetApplicationMcpConfigId() string {
+	if x != nil {
+		return x.ApplicationMcpConfigId
+	}
+	return ""
+}
+
+func (x *UseTool) GetToolName() string {
+	if x != nil {
+		return x.ToolName
+	}
+	return ""
+}
+
+// SendMessageRequest 发送消息请求
+type SendMessageRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ServiceUserId        string                 `protobuf:"bytes,1,opt,name=service_user_id,json=serviceUserId,proto3" json:"service_user_id,omitempty"`                        // 业务侧用户ID
+	SystemPrompt         string                 `protobuf:"bytes,2,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`                             // 系统提示词
+	UserMessage          string                 `protobuf:"bytes,3,opt,name=user_message,json=userMessage,proto3" json:"user_message,omitempty"`                                // 用户消息
+	PredefinedAnswer     *string                `protobuf:"bytes,4,opt,name=predefined_answer,json=predefinedAnswer,proto3,oneof" json:"predefined_answer,omitempty"`           // 预制答案（可选），设置后直接返回预制答案
+	UsedMcpToolList      []*UseTool             `protobuf:"bytes,5,rep,name=used_mcp_tool_list,json=usedMcpToolList,proto3" json:"used_mcp_tool_list,omitempty"`                // 使用的MCP工具列表
+	UsedInternalToolList []string               `protobuf:"bytes,6,rep,name=used_internal_tool_list,json=usedInternalToolList,proto3" json:"used_internal_tool_list,omitempty"` // 使用的内部工具列表
+	ConversationId       *string                `protobuf:"bytes,7,opt,name=conversation_id,json=conversationId,proto3,oneof" json:"conversation_id,omitempty"`                 // 会话ID（可选）
+	Attachments          []string               `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`                                                   // 附件ID列表（可选）
+	RequestId            *string                `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3,oneof" json:"request_id,omitempty"`                                // 客户端指定的请求ID（可选）
+	Streamable           bool                   `protobuf:"varint,10,opt,name=streamable,proto3" json:"streamable,omitempty"`                                                   // 是否返回增量内容（answer_delta）
+	DeltaChunkMode       *string                `protobuf:"bytes,11,opt,name=delta_chunk_mode,json=deltaChunkMode,proto3,oneof" json:"delta_chunk_mode,omitempty"`              // 增量输出分块模式（可选）：raw、markdown_safe
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendMessageRequest) GetServiceUserId() string {
+	if x != nil {
+		return x.ServiceUserId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetUserMessage() string {
+	if x != nil {
+		return x.UserMessage
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetPredefinedAnswer() string {
+	if x != nil && x.PredefinedAnswer != nil {
+		return *x.PredefinedAnswer
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetUsedMcpToolList() []*UseTool {
+	if x != nil {
+		return x.UsedMcpToolList
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetUsedInternalToolList() []string {
+	if x != nil {
+		return x.UsedInternalToolList
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetConversationId() string {
+	if x != nil && x.ConversationId != nil {
+		return *x.ConversationId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetAttachments() []string {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetRequestId() string {
+	if x != nil && x.RequestId != nil {
+		return *x.RequestId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetStreamable() bool {
+	if x != nil {
+		return x.Streamable
+	}
+	return false
+}
+
+func (x *SendMessageRequest) GetDeltaChunkMode() string {
+	if x != nil && x.DeltaChunkMode != nil {
+		return *x.DeltaChunkMode
+	}
+	return ""
+}
+
+// ToolCall 工具调用信息
+type ToolCall struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`               // 工具调用ID
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`           // 工具调用类型
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`           // 函数名称
+	Arguments     string                 `protobuf:"bytes,4,opt,name=arguments,proto3" json:"arguments,omitempty"` // 函数参数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
+	mi := &file_chat_v1_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolCall) ProtoMessage() {}
+
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ToolCall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ToolCall) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ToolCall) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolCall) GetArguments() string {
+	if x != nil {
+		return x.Arguments
+	}
+	return ""
+}
+
+// ChatMessageEvent 消息事件，与 HTTP 接口的 SSE 事件一致
+type ChatMessageEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"` // 会话ID
+	RequestId      string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                // 请求ID
+	MessageType    string                 `protobuf:"bytes,3,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`          // 消息类型：answer、answer_delta、tool_call、tool_call_processing、tool_call_end、tool_result、error 等
+	Content        string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                                     // 内容
+	ToolCall       *ToolCall              `protobuf:"bytes,5,opt,name=tool_call,json=toolCall,proto3" json:"tool_call,omitempty"`                   // 工具调用信息（可选）
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChatMessageEvent) Reset() {
+	*x = ChatMessageEvent{}
+	mi := &file_chat_v1_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessageEvent) ProtoMessage() {}
+
+func (x *ChatMessageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessageEvent.ProtoReflect.Descriptor instead.
+func (*ChatMessageEvent) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChatMessageEvent) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ChatMessageEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ChatMessageEvent) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *ChatMessageEvent) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessageEvent) GetToolCall() *ToolCall {
+	if x != nil {
+		return x.ToolCall
+	}
+	return nil
+}
+
+// ListConversationsRequest 获取会话列表请求
+type ListConversationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceUserId string                 `protobuf:"bytes,1,opt,name=service_user_id,json=serviceUserId,proto3" json:"service_user_id,omitempty"` // 业务侧用户ID
+	LastId        string                 `protobuf:"bytes,2,opt,name=last_id,json=lastId,proto3" json:"last_id,omitempty"`                        // 最后一个会话的ID，用于游标分页
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                                         // 返回数量
+	IncludeTotal  bool                   `protobuf:"varint,4,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`     // 是否查询总数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationsRequest) Reset() {
+	*x = ListConversationsRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsRequest) ProtoMessage() {}
+
+func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsRequest.ProtoReflect.Descriptor instead.
+func (*ListConversationsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListConversationsRequest) GetServiceUserId() string {
+	if x != nil {
+		return x.ServiceUserId
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetLastId() string {
+	if x != nil {
+		return x.LastId
+	}
+	return ""
+}
+
+func (x *ListConversationsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListConversationsRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+// Conversation 会话信息
+type Conversation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                              // 会话ID
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                        // 会话标题
+	ApplicationId string                 `protobuf:"bytes,3,opt,name=application_id,json=applicationId,proto3" json:"application_id,omitempty"`   // 应用ID
+	ServiceUserId string                 `protobuf:"bytes,4,opt,name=service_user_id,json=serviceUserId,proto3" json:"service_user_id,omitempty"` // 业务侧用户ID
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`              // 创建时间（毫秒时间戳）
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`              // 更新时间（毫秒时间戳）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conversation) Reset() {
+	*x = Conversation{}
+	mi := &file_chat_v1_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conversation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversation) ProtoMessage() {}
+
+func (x *Conversation) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
+func (*Conversation) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Conversation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Conversation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Conversation) GetApplicationId() string {
+	if x != nil {
+		return x.ApplicationId
+	}
+	return ""
+}
+
+func (x *Conversation) GetServiceUserId() string {
+	if x != nil {
+		return x.ServiceUserId
+	}
+	return ""
+}
+
+func (x *Conversation) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Conversation) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ListConversationsResponse 获取会话列表响应
+type ListConversationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversations []*Conversation        `protobuf:"bytes,1,rep,name=conversations,proto3" json:"conversations,omitempty"`                    // 会话列表
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`                // 是否还有更多数据
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`        // 下一页游标
+	TotalCount    *int64                 `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3,oneof" json:"total_count,omitempty"` // 总数量，仅在请求 include_total 时返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConversationsResponse) Reset() {
+	*x = ListConversationsResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConversationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsResponse) ProtoMessage() {}
+
+func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsResponse.ProtoReflect.Descriptor instead.
+func (*ListConversationsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListConversationsResponse) GetConversations() []*Conversation {
+	if x != nil {
+		return x.Conversations
+	}
+	return nil
+}
+
+func (x *ListConversationsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListConversationsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListConversationsResponse) GetTotalCount() int64 {
+	if x != nil && x.TotalCount != nil {
+		return *x.TotalCount
+	}
+	return 0
+}
+
+// AttachmentInfo 附件文件信息
+type AttachmentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"` // 文件名
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`        // 文件大小（字节）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AttachmentInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// UploadAttachmentRequest 上传附件请求
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAttachmentRequest_Info
+	//	*UploadAttachmentRequest_Chunk
+	Payload       isUploadAttachmentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UploadAttachmentRequest) GetPayload() isUploadAttachmentRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetInfo() *AttachmentInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Payload interface {
+	isUploadAttachmentRequest_Payload()
+}
+
+type UploadAttachmentRequest_Info struct {
+	Info *AttachmentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"` // 文件信息，必须是第一条消息
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"` // 文件内容分片
+}
+
+func (*UploadAttachmentRequest_Info) isUploadAttachmentRequest_Payload() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Payload() {}
+
+// UploadAttachmentResponse 上传附件响应
+type UploadAttachmentResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId     string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`             // 附件ID
+	OriginalFilename string                 `protobuf:"bytes,2,opt,name=original_filename,json=originalFilename,proto3" json:"original_filename,omitempty"` // 原始文件名
+	FileSize         int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`                        // 文件大小
+	AttachmentType   string                 `protobuf:"bytes,4,opt,name=attachment_type,json=attachmentType,proto3" json:"attachment_type,omitempty"`       // 附件类型
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UploadAttachmentResponse) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *UploadAttachmentResponse) GetOriginalFilename() string {
+	if x != nil {
+		return x.OriginalFilename
+	}
+	return ""
+}
+
+func (x *UploadAttachmentResponse) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *UploadAttachmentResponse) GetAttachmentType() string {
+	if x != nil {
+		return x.AttachmentType
+	}
+	return ""
+}
+
+var File_chat_v1_chat_proto protoreflect.FileDescriptor
+
+const file_chat_v1_chat_proto_rawDesc = "" +
+	"\n" +
+	"\x12chat/v1/chat.proto\x12\x11lemontree.chat.v1\"a\n" +
+	"\aUseTool\x129\n" +
+	"\x19application_mcp_config_id\x18\x01 \x01(\tR\x16applicationMcpConfigId\x12\x1b\n" +
+	"\ttool_name\x18\x02 \x01(\tR\btoolName\"\xc7\x04\n" +
+	"\x12SendMessageRequest\x12&\n" +
+	"\x0fservice_user_id\x18\x01 \x01(\tR\rserviceUserId\x12#\n" +
+	"\rsystem_prompt\x18\x02 \x01(\tR\fsystemPrompt\x12!\n" +
+	"\fuser_message\x18\x03 \x01(\tR\vuserMessage\x120\n" +
+	"\x11predefined_answer\x18\x04 \x01(\tH\x00R\x10predefinedAnswer\x88\x01\x01\x12G\n" +
+	"\x12used_mcp_tool_list\x18\x05 \x03(\v2\x1a.lemontree.chat.v1.UseToolR\x0fusedMcpToolList\x125\n" +
+	"\x17used_internal_tool_list\x18\x06 \x03(\tR\x14usedInternalToolList\x12,\n" +
+	"\x0fconversation_id\x18\a \x01(\tH\x01R\x0econversationId\x88\x01\x01\x12 \n" +
+	"\vattachments\x18\b \x03(\tR\vattachments\x12\"\n" +
+	"\n" +
+	"request_id\x18\t \x01(\tH\x02R\trequestId\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"streamable\x18\n" +
+	" \x01(\bR\n" +
+	"streamable\x12-\n" +
+	"\x10delta_chunk_mode\x18\v \x01(\tH\x03R\x0edeltaChunkMode\x88\x01\x01B\x14\n" +
+	"\x12_predefined_answerB\x12\n" +
+	"\x10_conversation_idB\r\n" +
+	"\v_request_idB\x13\n" +
+	"\x11_delta_chunk_mode\"`\n" +
+	"\bToolCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
+	"\targuments\x18\x04 \x01(\tR\targuments\"\xd1\x01\n" +
+	"\x10ChatMessageEvent\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12!\n" +
+	"\fmessage_type\x18\x03 \x01(\tR\vmessageType\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x128\n" +
+	"\ttool_call\x18\x05 \x01(\v2\x1b.lemontree.chat.v1.ToolCallR\btoolCall\"\x94\x01\n" +
+	"\x18ListConversationsRequest\x12&\n" +
+	"\x0fservice_user_id\x18\x01 \x01(\tR\rserviceUserId\x12\x17\n" +
+	"\alast_id\x18\x02 \x01(\tR\x06lastId\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12#\n" +
+	"\rinclude_total\x18\x04 \x01(\bR\fincludeTotal\"\xc1\x01\n" +
+	"\fConversation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12%\n" +
+	"\x0eapplication_id\x18\x03 \x01(\tR\rapplicationId\x12&\n" +
+	"\x0fservice_user_id\x18\x04 \x01(\tR\rserviceUserId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"\xd4\x01\n" +
+	"\x19ListConversationsResponse\x12E\n" +
+	"\rconversations\x18\x01 \x03(\v2\x1f.lemontree.chat.v1.ConversationR\rconversations\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12$\n" +
+	"\vtotal_count\x18\x04 \x01(\x03H\x00R\n" +
+	"totalCount\x88\x01\x01B\x0e\n" +
+	"\f_total_count\"@\n" +
+	"\x0eAttachmentInfo\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"u\n" +
+	"\x17UploadAttachmentRequest\x127\n" +
+	"\x04info\x18\x01 \x01(\v2!.lemontree.chat.v1.AttachmentInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xb2\x01\n" +
+	"\x18UploadAttachmentResponse\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\x12+\n" +
+	"\x11original_filename\x18\x02 \x01(\tR\x10originalFilename\x12\x1b\n" +
+	"\tfile_size\x18\x03 \x01(\x03R\bfileSize\x12'\n" +
+	"\x0fattachment_type\x18\x04 \x01(\tR\x0eattachmentType2\xc9\x02\n" +
+	"\vChatService\x12[\n" +
+	"\vSendMessage\x12%.lemontree.chat.v1.SendMessageRequest\x1a#.lemontree.chat.v1.ChatMessageEvent0\x01\x12n\n" +
+	"\x11ListConversations\x12+.lemontree.chat.v1.ListConversationsRequest\x1a,.lemontree.chat.v1.ListConversationsResponse\x12m\n" +
+	"\x10UploadAttachment\x12*.lemontree.chat.v1.UploadAttachmentRequest\x1a+.lemontree.chat.v1.UploadAttachmentResponse(\x01B0Z.lemon-tree-core/internal/grpcapi/chatv1;chatv1b\x06proto3"
+
+var (
+	file_chat_v1_chat_proto_rawDescOnce sync.Once
+	file_chat_v1_chat_proto_rawDescData []byte
+)
+
+func file_chat_v1_chat_proto_rawDescGZIP() []byte {
+	file_chat_v1_chat_proto_rawDescOnce.Do(func() {
+		file_chat_v1_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chat_v1_chat_proto_rawDesc), len(file_chat_v1_chat_proto_rawDesc)))
+	})
+	return file_chat_v1_chat_proto_rawDescData
+}
+
+var file_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_chat_v1_chat_proto_goTypes = []any{
+	(*UseTool)(nil),                   // 0: lemontree.chat.v1.UseTool
+	(*SendMessageRequest)(nil),        // 1: lemontree.chat.v1.SendMessageRequest
+	(*ToolCall)(nil),                  // 2: lemontree.chat.v1.ToolCall
+	(*ChatMessageEvent)(nil),          // 3: lemontree.chat.v1.ChatMessageEvent
+	(*ListConversationsRequest)(nil),  // 4: lemontree.chat.v1.ListConversationsRequest
+	(*Conversation)(nil),              // 5: lemontree.chat.v1.Conversation
+	(*ListConversationsResponse)(nil), // 6: lemontree.chat.v1.ListConversationsResponse
+	(*AttachmentInfo)(nil),            // 7: lemontree.chat.v1.AttachmentInfo
+	(*UploadAttachmentRequest)(nil),   // 8: lemontree.chat.v1.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),  // 9: lemontree.chat.v1.UploadAttachmentResponse
+}
+var file_chat_v1_chat_proto_depIdxs = []int32{
+	0, // 0: lemontree.chat.v1.SendMessageRequest.used_mcp_tool_list:type_name -> lemontree.chat.v1.UseTool
+	2, // 1: lemontree.chat.v1.ChatMessageEvent.tool_call:type_name -> lemontree.chat.v1.ToolCall
+	5, // 2: lemontree.chat.v1.ListConversationsResponse.conversations:type_name -> lemontree.chat.v1.Conversation
+	7, // 3: lemontree.chat.v1.UploadAttachmentRequest.info:type_name -> lemontree.chat.v1.AttachmentInfo
+	1, // 4: lemontree.chat.v1.ChatService.SendMessage:input_type -> lemontree.chat.v1.SendMessageRequest
+	4, // 5: lemontree.chat.v1.ChatService.ListConversations:input_type -> lemontree.chat.v1.ListConversationsRequest
+	8, // 6: lemontree.chat.v1.ChatService.UploadAttachment:input_type -> lemontree.chat.v1.UploadAttachmentRequest
+	3, // 7: lemontree.chat.v1.ChatService.SendMessage:output_type -> lemontree.chat.v1.ChatMessageEvent
+	6, // 8: lemontree.chat.v1.ChatService.ListConversations:output_type -> lemontree.chat.v1.ListConversationsResponse
+	9, // 9: lemontree.chat.v1.ChatService.UploadAttachment:output_type -> lemontree.chat.v1.UploadAttachmentResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_chat_v1_chat_proto_init() }
+func file_chat_v1_chat_proto_init() {
+	if File_chat_v1_chat_proto != nil {
+		return
+	}
+	file_chat_v1_chat_proto_msgTypes[1].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[6].OneofWrappers = []any{}
+	file_chat_v1_chat_proto_msgTypes[8].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Info)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_v1_chat_proto_rawDesc), len(file_chat_v1_chat_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_chat_v1_chat_proto_goTypes,
+		DependencyIndexes: file_chat_v1_chat_proto_depIdxs,
+		MessageInfos:      file_chat_v1_chat_proto_msgTypes,
+	}.Build()
+	File_chat_v1_chat_proto = out.File
+	file_chat_v1_chat_proto_goTypes = nil
+	file_chat_v1_chat_proto_depIdxs = nil
+}
@@ -3,9 +3,11 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,15 +18,19 @@ import (
 // 处理 Application 相关的所有 HTTP 请求
 // 相当于 Java Spring Boot 中的 Controller
 type ApplicationHandler struct {
-	appService service.ApplicationService // Application 业务逻辑层接口
+	appService         service.ApplicationService        // Application 业务逻辑层接口
+	settingService     service.ApplicationSettingService // 应用设置 业务逻辑层接口
+	featureFlagService service.FeatureFlagService        // 功能开关 业务逻辑层接口
 }
 
 // NewApplicationHandler 创建 Application Handler 实例
 // 返回 ApplicationHandler 的实例
-// 参数：appService - Application 业务逻辑层接口
-func NewApplicationHandler(appService service.ApplicationService) *ApplicationHandler {
+// 参数：appService - Application 业务逻辑层接口，settingService - 应用设置 业务逻辑层接口，featureFlagService - 功能开关 业务逻辑层接口
+func NewApplicationHandler(appService service.ApplicationService, settingService service.ApplicationSettingService, featureFlagService service.FeatureFlagService) *ApplicationHandler {
 	return &ApplicationHandler{
-		appService: appService,
+		appService:         appService,
+		settingService:     settingService,
+		featureFlagService: featureFlagService,
 	}
 }
 
@@ -38,20 +44,20 @@ func (h *ApplicationHandler) GetApplicationByID(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取应用
 	application, err := h.appService.GetApplicationByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		c.Error(apperror.New(apperror.CodeNotFound, "应用不存在"))
 		return
 	}
 
 	// 转换为DTO返回
 	applicationDto := converter.ApplicationModelToApplicationDto(application)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application": applicationDto,
 	})
 }
@@ -63,13 +69,13 @@ func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
 	// 调用业务逻辑层获取所有应用
 	applications, err := h.appService.GetAllApplications(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	applicationDtos := converter.ApplicationModelListToApplicationDtoList(applications)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"applications": applicationDtos,
 	})
 }
@@ -81,22 +87,26 @@ func (h *ApplicationHandler) SaveApplication(c *gin.Context) {
 	// 绑定 JSON 请求体到 ApplicationSaveDto 结构体
 	var applicationSaveDto dto.ApplicationSaveDto
 	if err := c.ShouldBindJSON(&applicationSaveDto); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	application := converter.ApplicationSaveDtoToApplicationModel(&applicationSaveDto)
+	application, err := converter.ApplicationSaveDtoToApplicationModel(&applicationSaveDto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// 调用业务逻辑层保存应用
 	if err := h.appService.SaveApplication(c.Request.Context(), application); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO返回
 	applicationDto := converter.ApplicationModelToApplicationDto(application)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application": applicationDto,
 	})
 }
@@ -108,7 +118,7 @@ func (h *ApplicationHandler) QueryApplications(c *gin.Context) {
 	// 绑定 JSON 请求体到 ApplicationQueryDto 结构体作为查询条件
 	var queryDto dto.ApplicationQueryDto
 	if err := c.ShouldBindJSON(&queryDto); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
@@ -118,20 +128,21 @@ func (h *ApplicationHandler) QueryApplications(c *gin.Context) {
 	// 调用业务逻辑层查询应用
 	applications, err := h.appService.QueryApplications(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	applicationDtos := converter.ApplicationModelListToApplicationDtoList(applications)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"applications": applicationDtos,
 	})
 }
 
 // DeleteApplication 删除应用
 // 处理 DELETE /api/v1/applications/:id 请求
-// 删除指定的应用（软删除）
+// 应用立即软删除，智能体、会话、模型、MCP配置等关联数据由后台任务分批删除
+// 返回 202 和删除任务，可通过 GET /api/v1/applications/:id/deletion 查询进度
 func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 	// 从 URL 参数中获取 ID
 	idStr := c.Param("id")
@@ -139,16 +150,182 @@ func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层删除应用
-	if err := h.appService.DeleteApplication(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	job, err := h.appService.DeleteApplication(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 返回删除任务，关联数据在后台继续删除
+	utils.JsonResponse(c, http.StatusAccepted, gin.H{
+		"message":      "Application deleted successfully",
+		"deletion_job": converter.ApplicationDeletionJobModelToApplicationDeletionJobDto(job),
+	})
+}
+
+// GetApplicationDeletionJob 获取应用的删除进度
+// 处理 GET /api/v1/applications/:id/deletion 请求
+// 返回应用最近的删除任务
+func (h *ApplicationHandler) GetApplicationDeletionJob(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	job, err := h.appService.GetDeletionJob(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"deletion_job": converter.ApplicationDeletionJobModelToApplicationDeletionJobDto(job),
+	})
+}
+
+// GetApplicationSetting 获取应用设置
+// 处理 GET /api/v1/applications/:id/settings 请求
+// 应用未保存过设置时返回默认值
+func (h *ApplicationHandler) GetApplicationSetting(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
-	// 返回删除成功的响应
-	c.JSON(http.StatusOK, gin.H{"message": "Application deleted successfully"})
+	setting, err := h.settingService.GetApplicationSetting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"setting": converter.ApplicationSettingModelToApplicationSettingDto(setting),
+	})
+}
+
+// SaveApplicationSetting 保存应用设置
+// 处理 POST /api/v1/applications/:id/settings 请求
+// 整体覆盖应用设置，不存在则创建
+func (h *ApplicationHandler) SaveApplicationSetting(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	var settingSaveDto dto.ApplicationSettingSaveDto
+	if err := c.ShouldBindJSON(&settingSaveDto); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	setting := converter.ApplicationSettingSaveDtoToApplicationSettingModel(id, &settingSaveDto)
+	if err := h.settingService.SaveApplicationSetting(c.Request.Context(), setting); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"setting": converter.ApplicationSettingModelToApplicationSettingDto(setting),
+	})
+}
+
+// DeleteApplicationSetting 删除应用设置
+// 处理 DELETE /api/v1/applications/:id/settings 请求
+// 删除后应用设置恢复为默认值
+func (h *ApplicationHandler) DeleteApplicationSetting(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.settingService.DeleteApplicationSetting(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "Application setting deleted successfully",
+	})
+}
+
+// GetApplicationFeatureFlags 获取应用的功能开关
+// 处理 GET /api/v1/applications/:id/feature-flags 请求
+// 返回全部功能开关对应用的状态，包括应用单独设置的值和全局灰度比例
+func (h *ApplicationHandler) GetApplicationFeatureFlags(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	featureFlags, err := h.featureFlagService.ListApplicationFeatureFlags(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"feature_flags": featureFlags,
+	})
+}
+
+// SaveApplicationFeatureFlag 为应用单独设置功能开关
+// 处理 POST /api/v1/applications/:id/feature-flags/:flag 请求
+// 应用单独设置的值优先于全局灰度比例
+func (h *ApplicationHandler) SaveApplicationFeatureFlag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	var saveDto dto.ApplicationFeatureFlagSaveDto
+	if err := c.ShouldBindJSON(&saveDto); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.featureFlagService.SetApplicationFeatureFlag(ctx, id, c.Param("flag"), *saveDto.Enabled); err != nil {
+		c.Error(err)
+		return
+	}
+	featureFlags, err := h.featureFlagService.ListApplicationFeatureFlags(ctx, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"feature_flags": featureFlags,
+	})
+}
+
+// DeleteApplicationFeatureFlag 删除应用单独设置的功能开关
+// 处理 DELETE /api/v1/applications/:id/feature-flags/:flag 请求
+// 删除后该功能开关恢复按全局灰度比例判断
+func (h *ApplicationHandler) DeleteApplicationFeatureFlag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.featureFlagService.DeleteApplicationFeatureFlag(c.Request.Context(), id, c.Param("flag")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "Application feature flag deleted successfully",
+	})
 }
@@ -3,9 +3,11 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -38,22 +40,26 @@ func (h *ApplicationLlmHandler) SaveApplicationLlm(c *gin.Context) {
 	// 绑定 JSON 请求体到 SaveApplicationLlmRequest 结构体
 	var saveRequest dto.SaveApplicationLlmRequest
 	if err := c.ShouldBindJSON(&saveRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	applicationLlm := converter.SaveApplicationLlmRequestToApplicationLlmModel(&saveRequest)
+	applicationLlm, err := converter.SaveApplicationLlmRequestToApplicationLlmModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// 调用业务逻辑层保存模型
 	if err := h.applicationLlmService.SaveApplicationLlm(c.Request.Context(), applicationLlm); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO返回
 	applicationLlmDto := converter.ApplicationLlmModelToApplicationLlmDto(applicationLlm)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_llm": applicationLlmDto,
 	})
 }
@@ -68,31 +74,31 @@ func (h *ApplicationLlmHandler) UpdateEnabledStatus(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 绑定 JSON 请求体到 UpdateEnabledStatusRequest 结构体
 	var updateRequest dto.UpdateEnabledStatusRequest
 	if err := c.ShouldBindJSON(&updateRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 验证ID一致性
 	if updateRequest.ID != idStr {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID in URL and request body do not match"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "URL中的ID与请求体中的ID不一致"))
 		return
 	}
 
 	// 调用业务逻辑层更新启用状态
 	if err := h.applicationLlmService.UpdateEnabledStatus(c.Request.Context(), id, updateRequest.Enabled); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 返回更新成功的响应
-	c.JSON(http.StatusOK, gin.H{"message": "Model enabled status updated successfully"})
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "Model enabled status updated successfully"})
 }
 
 // GetModelsByProviderID 根据提供商ID获取模型列表
@@ -105,20 +111,20 @@ func (h *ApplicationLlmHandler) GetModelsByProviderID(c *gin.Context) {
 	// 解析 UUID 格式的提供商 ID
 	providerID, err := uuid.Parse(providerIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid provider UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的提供商UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取指定提供商下的模型
 	models, err := h.applicationLlmService.GetModelsByProviderID(c.Request.Context(), providerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	modelDtos := converter.ApplicationLlmModelListToApplicationLlmDtoList(models)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_llm": modelDtos,
 	})
 }
@@ -133,30 +139,30 @@ func (h *ApplicationLlmHandler) FetchAndSaveModels(c *gin.Context) {
 	// 解析 UUID 格式的提供商 ID
 	providerID, err := uuid.Parse(providerIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid provider UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的提供商UUID格式"))
 		return
 	}
 
 	// 获取提供商信息
 	provider, err := h.llmProviderService.GetLlmProviderByID(c.Request.Context(), providerID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get provider: " + err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInternal, "获取LLM提供商失败", err))
 		return
 	}
 
 	if provider == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Provider not found"})
+		c.Error(apperror.New(apperror.CodeNotFound, "LLM提供商不存在"))
 		return
 	}
 
 	// 调用业务逻辑层获取并保存模型
 	if err := h.applicationLlmService.FetchAndSaveModels(c.Request.Context(), provider); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch and save models: " + err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInternal, "拉取并保存模型失败", err))
 		return
 	}
 
 	// 返回成功响应
-	c.JSON(http.StatusOK, gin.H{"message": "Models fetched and saved successfully"})
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "Models fetched and saved successfully"})
 }
 
 // GetModelsByApplicationID 根据应用ID获取模型列表
@@ -169,20 +175,20 @@ func (h *ApplicationLlmHandler) GetModelsByApplicationID(c *gin.Context) {
 	// 解析 UUID 格式的应用 ID
 	applicationID, err := uuid.Parse(applicationIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取指定应用下的模型
 	models, err := h.applicationLlmService.GetModelsByApplicationID(c.Request.Context(), applicationID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	modelDtos := converter.ApplicationLlmModelListToApplicationLlmDtoList(models)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_llm": modelDtos,
 	})
 }
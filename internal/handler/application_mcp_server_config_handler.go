@@ -3,10 +3,14 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -17,47 +21,108 @@ import (
 // 相当于 Java Spring Boot 中的 Controller
 type ApplicationMcpServerConfigHandler struct {
 	applicationMcpServerConfigService service.ApplicationMcpServerConfigService // ApplicationMCP配置 业务逻辑层接口
+	mcpOauthService                   service.McpOauthService                   // MCP服务OAuth授权 业务逻辑层接口
+	config                            *config.Config                            // 应用程序配置
 }
 
 // NewApplicationMcpServerConfigHandler 创建 ApplicationMCP配置 Handler 实例
 // 返回 ApplicationMcpServerConfigHandler 的实例
-// 参数：applicationMcpServerConfigService - ApplicationMCP配置 业务逻辑层接口
-func NewApplicationMcpServerConfigHandler(applicationMcpServerConfigService service.ApplicationMcpServerConfigService) *ApplicationMcpServerConfigHandler {
+// 参数：applicationMcpServerConfigService - ApplicationMCP配置 业务逻辑层接口，mcpOauthService - MCP服务OAuth授权 业务逻辑层接口，config - 应用程序配置
+func NewApplicationMcpServerConfigHandler(applicationMcpServerConfigService service.ApplicationMcpServerConfigService, mcpOauthService service.McpOauthService, config *config.Config) *ApplicationMcpServerConfigHandler {
 	return &ApplicationMcpServerConfigHandler{
 		applicationMcpServerConfigService: applicationMcpServerConfigService,
+		mcpOauthService:                   mcpOauthService,
+		config:                            config,
 	}
 }
 
 // SaveApplicationMcpServerConfig 保存应用MCP配置信息
 // 处理 POST /api/v1/application-mcp-server-configs/save 请求
 // 如果配置存在则更新，不存在则创建
+// 更新时可通过 update_fields 指定只修改的字段，未列出的字段（如请求头、环境变量中的密钥）保持不变
 func (h *ApplicationMcpServerConfigHandler) SaveApplicationMcpServerConfig(c *gin.Context) {
 	// 绑定 JSON 请求体到 SaveApplicationMcpServerConfigRequest 结构体
 	var saveRequest dto.SaveApplicationMcpServerConfigRequest
 	if err := c.ShouldBindJSON(&saveRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	config := converter.SaveApplicationMcpServerConfigRequestToApplicationMcpServerConfigModel(&saveRequest)
+	config, err := converter.SaveApplicationMcpServerConfigRequestToApplicationMcpServerConfigModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	updateFields, err := converter.SaveApplicationMcpServerConfigRequestToUpdateFields(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// 调用业务逻辑层保存配置
-	if err := h.applicationMcpServerConfigService.SaveApplicationMcpServerConfig(c.Request.Context(), config); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.applicationMcpServerConfigService.SaveApplicationMcpServerConfig(c.Request.Context(), config, updateFields); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO返回
 	configDto := converter.ApplicationMcpServerConfigModelToApplicationMcpServerConfigDto(config)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_mcp_server_config": configDto,
 	})
 }
 
+// ImportMcpServers 从标准 mcpServers JSON 导入MCP配置
+// 处理 POST /api/v1/application-mcp-server-configs/import 请求
+// 请求体为 mcpServers JSON 加上 application_id，stdio 服务导入命令、参数和环境变量，sse / http 服务导入URL和请求头
+func (h *ApplicationMcpServerConfigHandler) ImportMcpServers(c *gin.Context) {
+	var importRequest dto.ImportMcpServersRequest
+	if err := c.ShouldBindJSON(&importRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	applicationID, err := uuid.Parse(importRequest.ApplicationID)
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+
+	configs, skipped, err := h.applicationMcpServerConfigService.ImportMcpServers(c.Request.Context(), applicationID, importRequest.McpServers, importRequest.SkipExisting)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.ImportMcpServersResultDto{
+		Created: converter.ApplicationMcpServerConfigModelListToApplicationMcpServerConfigDtoList(configs),
+		Skipped: skipped,
+	})
+}
+
+// ExportMcpServers 导出应用的MCP配置为标准 mcpServers JSON
+// 处理 GET /api/v1/application-mcp-server-configs/application/:applicationId/export 请求
+// 默认掩码环境变量和请求头的值，mask_secrets=false 时导出原值；返回的 JSON 可直接用于导入
+func (h *ApplicationMcpServerConfigHandler) ExportMcpServers(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+	maskSecrets := c.DefaultQuery("mask_secrets", "true") != "false"
+
+	servers, err := h.applicationMcpServerConfigService.ExportMcpServers(c.Request.Context(), applicationID, maskSecrets)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.ExportMcpServersDto{McpServers: servers})
+}
+
 // DeleteApplicationMcpServerConfig 删除MCP配置
 // 处理 DELETE /api/v1/application-mcp-server-configs/:id 请求
-// 根据ID删除指定的MCP配置记录
+// 同时删除配置下的工具和智能体的工具设置，返回受影响的智能体
 func (h *ApplicationMcpServerConfigHandler) DeleteApplicationMcpServerConfig(c *gin.Context) {
 	// 从 URL 参数中获取 ID
 	idStr := c.Param("id")
@@ -65,18 +130,19 @@ func (h *ApplicationMcpServerConfigHandler) DeleteApplicationMcpServerConfig(c *
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层删除配置
-	if err := h.applicationMcpServerConfigService.DeleteApplicationMcpServerConfig(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	deletion, err := h.applicationMcpServerConfigService.DeleteApplicationMcpServerConfig(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	// 返回删除成功的响应
-	c.JSON(http.StatusOK, gin.H{"message": "MCP配置删除成功"})
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "MCP配置删除成功", "deletion": deletion})
 }
 
 // GetMcpServerConfigsByApplicationID 根据应用ID获取MCP配置列表
@@ -89,20 +155,20 @@ func (h *ApplicationMcpServerConfigHandler) GetMcpServerConfigsByApplicationID(c
 	// 解析 UUID 格式的应用 ID
 	applicationID, err := uuid.Parse(applicationIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取指定应用下的MCP配置
 	configs, err := h.applicationMcpServerConfigService.GetMcpServerConfigsByApplicationID(c.Request.Context(), applicationID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	configDtos := converter.ApplicationMcpServerConfigModelListToApplicationMcpServerConfigDtoList(configs)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_mcp_server_configs": configDtos,
 	})
 }
@@ -117,20 +183,20 @@ func (h *ApplicationMcpServerConfigHandler) GetMcpServerTools(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取工具列表
 	tools, err := h.applicationMcpServerConfigService.GetMcpServerTools(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	toolDtos := converter.ApplicationMcpServerToolModelListToApplicationMcpServerToolDtoList(tools)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"tools": toolDtos,
 	})
 }
@@ -145,21 +211,120 @@ func (h *ApplicationMcpServerConfigHandler) SyncMcpServerTools(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层同步工具列表
 	tools, err := h.applicationMcpServerConfigService.SyncMcpServerTools(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	toolDtos := converter.ApplicationMcpServerToolModelListToApplicationMcpServerToolDtoList(tools)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"tools":   toolDtos,
 		"message": "工具列表同步成功",
 	})
 }
+
+// AuthorizeMcpOauth 发起MCP服务的OAuth授权
+// 处理 GET /api/v1/application-mcp-server-configs/:id/oauth/authorize 请求
+// 在浏览器中打开，重定向到授权服务器的授权页面；请求携带 redirect=false 时返回授权地址
+func (h *ApplicationMcpServerConfigHandler) AuthorizeMcpOauth(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	authorizationURL, err := h.mcpOauthService.StartAuthorization(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if c.Query("redirect") == "false" {
+		utils.JsonResponse(c, http.StatusOK, gin.H{"authorization_url": authorizationURL})
+		return
+	}
+	c.Redirect(http.StatusFound, authorizationURL)
+}
+
+// McpOauthCallback 处理授权服务器的回调
+// 处理 GET /api/v1/application-mcp-server-configs/oauth/callback 请求
+// 配置了授权完成跳转地址时重定向到该地址并附带授权结果，否则直接显示授权结果
+func (h *ApplicationMcpServerConfigHandler) McpOauthCallback(c *gin.Context) {
+	var configID string
+	var callbackErr error
+	if errorCode := c.Query("error"); errorCode != "" {
+		callbackErr = apperror.Newf(apperror.CodeInvalidArgument, "授权服务器拒绝了授权: %s %s", errorCode, c.Query("error_description"))
+	} else {
+		mcpConfig, err := h.mcpOauthService.CompleteAuthorization(c.Request.Context(), c.Query("state"), c.Query("code"))
+		if err != nil {
+			callbackErr = err
+		} else {
+			configID = mcpConfig.ID.String()
+		}
+	}
+
+	if completeURL := h.config.McpOAuth.CompleteURL; completeURL != "" {
+		target, err := url.Parse(completeURL)
+		if err != nil {
+			c.Error(apperror.Wrap(apperror.CodeInternal, "授权完成跳转地址无效", err))
+			return
+		}
+		query := target.Query()
+		query.Set("config_id", configID)
+		if callbackErr != nil {
+			query.Set("status", "error")
+			query.Set("error", callbackErr.Error())
+		} else {
+			query.Set("status", "success")
+		}
+		target.RawQuery = query.Encode()
+		c.Redirect(http.StatusFound, target.String())
+		return
+	}
+
+	if callbackErr != nil {
+		c.Error(callbackErr)
+		return
+	}
+	c.String(http.StatusOK, "MCP服务授权成功，可以关闭此页面")
+}
+
+// GetMcpOauthStatus 获取MCP配置的OAuth授权状态
+// 处理 GET /api/v1/application-mcp-server-configs/:id/oauth 请求
+func (h *ApplicationMcpServerConfigHandler) GetMcpOauthStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	status, err := h.mcpOauthService.GetAuthorizationStatus(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{"oauth_status": status})
+}
+
+// RevokeMcpOauth 删除MCP配置保存的OAuth令牌
+// 处理 DELETE /api/v1/application-mcp-server-configs/:id/oauth 请求
+func (h *ApplicationMcpServerConfigHandler) RevokeMcpOauth(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.mcpOauthService.RevokeAuthorization(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "OAuth授权已撤销"})
+}
@@ -3,9 +3,11 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -35,22 +37,26 @@ func (h *ApplicationStorageConfigHandler) SaveApplicationStorageConfig(c *gin.Co
 	// 绑定 JSON 请求体到 SaveApplicationStorageConfigRequest 结构体
 	var saveRequest dto.SaveApplicationStorageConfigRequest
 	if err := c.ShouldBindJSON(&saveRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	config := converter.SaveApplicationStorageConfigRequestToApplicationStorageConfigModel(&saveRequest)
+	config, err := converter.SaveApplicationStorageConfigRequestToApplicationStorageConfigModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// 调用业务逻辑层保存存储配置
 	if err := h.applicationStorageConfigService.SaveApplicationStorageConfig(c.Request.Context(), config); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO返回
 	configDto := converter.ApplicationStorageConfigModelToApplicationStorageConfigDto(config)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_storage_config": configDto,
 	})
 }
@@ -65,20 +71,20 @@ func (h *ApplicationStorageConfigHandler) GetApplicationStorageConfigByApplicati
 	// 解析 UUID 格式的应用 ID
 	applicationID, err := uuid.Parse(applicationIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取指定应用的存储配置
 	config, err := h.applicationStorageConfigService.GetApplicationStorageConfigByApplicationID(c.Request.Context(), applicationID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 如果配置不存在，返回空对象
 	if config == nil {
-		c.JSON(http.StatusOK, gin.H{
+		utils.JsonResponse(c, http.StatusOK, gin.H{
 			"application_storage_config": nil,
 		})
 		return
@@ -86,7 +92,7 @@ func (h *ApplicationStorageConfigHandler) GetApplicationStorageConfigByApplicati
 
 	// 转换为DTO返回
 	configDto := converter.ApplicationStorageConfigModelToApplicationStorageConfigDto(config)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"application_storage_config": configDto,
 	})
 }
@@ -0,0 +1,183 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ApplicationToolBundleHandler 应用工具集 控制器
+// 处理 应用工具集 相关的所有 HTTP 请求
+type ApplicationToolBundleHandler struct {
+	toolBundleService service.ApplicationToolBundleService // 应用工具集 业务逻辑层接口
+}
+
+// NewApplicationToolBundleHandler 创建 应用工具集 Handler 实例
+// 参数：toolBundleService - 应用工具集 业务逻辑层接口
+func NewApplicationToolBundleHandler(toolBundleService service.ApplicationToolBundleService) *ApplicationToolBundleHandler {
+	return &ApplicationToolBundleHandler{
+		toolBundleService: toolBundleService,
+	}
+}
+
+// SaveToolBundle 保存工具集
+// 处理 POST /api/v1/application-tool-bundles/save 请求
+func (h *ApplicationToolBundleHandler) SaveToolBundle(c *gin.Context) {
+	var saveRequest dto.SaveApplicationToolBundleRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	bundle, toolIDs, err := converter.SaveApplicationToolBundleRequestToApplicationToolBundleModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.toolBundleService.SaveToolBundle(c.Request.Context(), bundle, toolIDs); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondToolBundle(c, bundle.ID)
+}
+
+// DeleteToolBundle 删除工具集
+// 处理 DELETE /api/v1/application-tool-bundles/:id 请求
+func (h *ApplicationToolBundleHandler) DeleteToolBundle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.toolBundleService.DeleteToolBundle(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "工具集删除成功"})
+}
+
+// GetToolBundle 获取工具集详情，包含工具ID和关联的智能体ID
+// 处理 GET /api/v1/application-tool-bundles/:id 请求
+func (h *ApplicationToolBundleHandler) GetToolBundle(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	h.respondToolBundle(c, id)
+}
+
+// GetToolBundlesByApplicationID 获取应用的工具集列表
+// 处理 GET /api/v1/application-tool-bundles/application/:applicationId 请求
+func (h *ApplicationToolBundleHandler) GetToolBundlesByApplicationID(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+
+	bundles, err := h.toolBundleService.GetToolBundlesByApplicationID(c.Request.Context(), applicationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"tool_bundles": converter.ApplicationToolBundleModelListToApplicationToolBundleDtoList(bundles),
+	})
+}
+
+// GetToolBundlesByChatAgentID 获取智能体关联的工具集列表
+// 处理 GET /api/v1/application-tool-bundles/chat-agent/:chatAgentId 请求
+func (h *ApplicationToolBundleHandler) GetToolBundlesByChatAgentID(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+
+	bundles, err := h.toolBundleService.GetToolBundlesByChatAgentID(c.Request.Context(), chatAgentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"tool_bundles": converter.ApplicationToolBundleModelListToApplicationToolBundleDtoList(bundles),
+	})
+}
+
+// AttachChatAgents 将工具集关联到多个智能体
+// 处理 POST /api/v1/application-tool-bundles/:id/attach 请求
+func (h *ApplicationToolBundleHandler) AttachChatAgents(c *gin.Context) {
+	id, chatAgentIDs, ok := h.bindChatAgentsRequest(c)
+	if !ok {
+		return
+	}
+	if err := h.toolBundleService.AttachChatAgents(c.Request.Context(), id, chatAgentIDs); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondToolBundle(c, id)
+}
+
+// DetachChatAgents 取消工具集与多个智能体的关联
+// 处理 POST /api/v1/application-tool-bundles/:id/detach 请求
+func (h *ApplicationToolBundleHandler) DetachChatAgents(c *gin.Context) {
+	id, chatAgentIDs, ok := h.bindChatAgentsRequest(c)
+	if !ok {
+		return
+	}
+	if err := h.toolBundleService.DetachChatAgents(c.Request.Context(), id, chatAgentIDs); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondToolBundle(c, id)
+}
+
+// bindChatAgentsRequest 解析工具集ID和请求中的智能体ID，解析失败时写入错误并返回 false
+func (h *ApplicationToolBundleHandler) bindChatAgentsRequest(c *gin.Context) (uuid.UUID, []uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return uuid.Nil, nil, false
+	}
+	var request dto.ApplicationToolBundleChatAgentsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return uuid.Nil, nil, false
+	}
+	chatAgentIDs, err := converter.ApplicationToolBundleChatAgentsRequestToChatAgentIDs(&request)
+	if err != nil {
+		c.Error(err)
+		return uuid.Nil, nil, false
+	}
+	return id, chatAgentIDs, true
+}
+
+// respondToolBundle 返回工具集详情
+func (h *ApplicationToolBundleHandler) respondToolBundle(c *gin.Context, id uuid.UUID) {
+	bundle, toolIDs, chatAgentIDs, err := h.toolBundleService.GetToolBundle(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"tool_bundle": converter.ApplicationToolBundleDetailToApplicationToolBundleDto(bundle, toolIDs, chatAgentIDs),
+	})
+}
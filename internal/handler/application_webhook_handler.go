@@ -0,0 +1,163 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ApplicationWebhookHandler 应用 Webhook 控制器
+// 处理 应用 Webhook 相关的所有 HTTP 请求
+type ApplicationWebhookHandler struct {
+	webhookService service.ApplicationWebhookService // 应用 Webhook 业务逻辑层接口
+}
+
+// NewApplicationWebhookHandler 创建 应用 Webhook Handler 实例
+// 参数：webhookService - 应用 Webhook 业务逻辑层接口
+func NewApplicationWebhookHandler(webhookService service.ApplicationWebhookService) *ApplicationWebhookHandler {
+	return &ApplicationWebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// SaveWebhook 保存 Webhook
+// 处理 POST /api/v1/application-webhooks/save 请求
+func (h *ApplicationWebhookHandler) SaveWebhook(c *gin.Context) {
+	var saveRequest dto.SaveApplicationWebhookRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	webhook, err := converter.SaveApplicationWebhookRequestToApplicationWebhookModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.webhookService.SaveWebhook(c.Request.Context(), webhook, saveRequest.RegenerateSecret); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"webhook": converter.ApplicationWebhookModelToApplicationWebhookDto(webhook),
+	})
+}
+
+// DeleteWebhook 删除 Webhook
+// 处理 DELETE /api/v1/application-webhooks/:id 请求
+func (h *ApplicationWebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "Webhook删除成功"})
+}
+
+// GetWebhook 获取 Webhook 详情
+// 处理 GET /api/v1/application-webhooks/:id 请求
+func (h *ApplicationWebhookHandler) GetWebhook(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"webhook": converter.ApplicationWebhookModelToApplicationWebhookDto(webhook),
+	})
+}
+
+// GetWebhooksByApplicationID 获取应用的 Webhook 列表
+// 处理 GET /api/v1/application-webhooks/application/:applicationId 请求
+func (h *ApplicationWebhookHandler) GetWebhooksByApplicationID(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+
+	webhooks, err := h.webhookService.GetWebhooksByApplicationID(c.Request.Context(), applicationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"webhooks": converter.ApplicationWebhookModelListToApplicationWebhookDtoList(webhooks),
+	})
+}
+
+// GetDeliveries 获取 Webhook 的推送记录
+// 处理 GET /api/v1/application-webhooks/:id/deliveries 请求
+// 按创建时间倒序返回，支持按 status 筛选和分页
+func (h *ApplicationWebhookHandler) GetDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	deliveries, total, err := h.webhookService.GetDeliveries(c.Request.Context(), id, c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"deliveries": converter.ApplicationWebhookDeliveryListToDtoList(deliveries),
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+	})
+}
+
+// RetryDelivery 重新推送推送记录
+// 处理 POST /api/v1/application-webhooks/deliveries/:deliveryId/retry 请求
+func (h *ApplicationWebhookHandler) RetryDelivery(c *gin.Context) {
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的推送记录UUID格式"))
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(c.Request.Context(), deliveryID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"delivery": converter.ApplicationWebhookDeliveryModelToDto(delivery),
+	})
+}
@@ -0,0 +1,237 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BatchInferenceHandler 批量推理 控制器
+// 处理 批量推理 相关的所有 HTTP 请求
+type BatchInferenceHandler struct {
+	batchInferenceService service.BatchInferenceService // 批量推理 业务逻辑层接口
+}
+
+// NewBatchInferenceHandler 创建 批量推理 Handler 实例
+// 参数：batchInferenceService - 批量推理 业务逻辑层接口
+func NewBatchInferenceHandler(batchInferenceService service.BatchInferenceService) *BatchInferenceHandler {
+	return &BatchInferenceHandler{
+		batchInferenceService: batchInferenceService,
+	}
+}
+
+// CreateJob 创建批量推理任务
+// 处理 POST /api/v1/batch-inference-jobs 请求
+func (h *BatchInferenceHandler) CreateJob(c *gin.Context) {
+	var createRequest dto.CreateBatchInferenceJobRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	job, err := converter.CreateBatchInferenceJobRequestToBatchInferenceJobModel(&createRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.batchInferenceService.CreateJob(c.Request.Context(), job, createRequest.Prompts); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"job": converter.BatchInferenceJobModelToBatchInferenceJobDto(job),
+	})
+}
+
+// UploadJob 上传提示词文件创建批量推理任务
+// 处理 POST /api/v1/batch-inference-jobs/upload 请求
+// 表单字段：chat_agent_id、name、system_prompt、concurrency 与 JSON 创建请求含义相同，file 为提示词文件
+func (h *BatchInferenceHandler) UploadJob(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.PostForm("chat_agent_id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+	concurrency := 0
+	if concurrencyStr := c.PostForm("concurrency"); concurrencyStr != "" {
+		if concurrency, err = strconv.Atoi(concurrencyStr); err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "并发数必须是整数"))
+			return
+		}
+	}
+
+	// 获取上传的文件
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "请选择要上传的文件").WithCause(err))
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInternal, "打开上传文件失败").WithCause(err))
+		return
+	}
+	defer src.Close()
+
+	prompts, err := h.batchInferenceService.ParsePromptFile(file.Filename, src)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	job := &models.BatchInferenceJob{
+		ChatAgentID:  chatAgentID,
+		Name:         c.PostForm("name"),
+		SystemPrompt: c.PostForm("system_prompt"),
+		Concurrency:  concurrency,
+	}
+	if err := h.batchInferenceService.CreateJob(c.Request.Context(), job, prompts); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"job": converter.BatchInferenceJobModelToBatchInferenceJobDto(job),
+	})
+}
+
+// GetJob 获取批量推理任务
+// 处理 GET /api/v1/batch-inference-jobs/:id 请求
+func (h *BatchInferenceHandler) GetJob(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	job, err := h.batchInferenceService.GetJob(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"job": converter.BatchInferenceJobModelToBatchInferenceJobDto(job),
+	})
+}
+
+// GetJobsByChatAgentID 获取智能体的批量推理任务列表
+// 处理 GET /api/v1/batch-inference-jobs/chat-agent/:chatAgentId 请求
+func (h *BatchInferenceHandler) GetJobsByChatAgentID(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+
+	// 获取分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	jobs, total, err := h.batchInferenceService.GetJobsByChatAgentID(c.Request.Context(), chatAgentID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.BatchInferenceJobListResponse{
+		Jobs:     converter.BatchInferenceJobModelListToBatchInferenceJobDtoList(jobs),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// GetJobItems 获取批量推理任务的提示词及处理结果
+// 处理 GET /api/v1/batch-inference-jobs/:id/items 请求
+// 可通过 status 参数只返回指定处理状态的提示词
+func (h *BatchInferenceHandler) GetJobItems(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	// 获取分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	items, total, err := h.batchInferenceService.GetJobItems(c.Request.Context(), id, c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.BatchInferenceItemListResponse{
+		Items:    converter.BatchInferenceItemModelListToBatchInferenceItemDtoList(items),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// CancelJob 取消批量推理任务
+// 处理 POST /api/v1/batch-inference-jobs/:id/cancel 请求
+func (h *BatchInferenceHandler) CancelJob(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	job, err := h.batchInferenceService.CancelJob(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"job": converter.BatchInferenceJobModelToBatchInferenceJobDto(job),
+	})
+}
+
+// DownloadJobResults 下载批量推理任务的结果
+// 处理 GET /api/v1/batch-inference-jobs/:id/download 请求
+// format 参数指定结果格式：jsonl（默认）或 csv，任务结束后才能下载
+func (h *BatchInferenceHandler) DownloadJobResults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	format := c.DefaultQuery("format", define.BatchInferenceResultFormatJsonl)
+	result, err := h.batchInferenceService.ExportJobResults(c.Request.Context(), id, format)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, result.FileName))
+	c.Data(http.StatusOK, result.ContentType, result.Content)
+}
@@ -0,0 +1,118 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ChatAgentAnswerRuleHandler 预制答案规则 控制器
+// 处理 预制答案规则 相关的所有 HTTP 请求
+type ChatAgentAnswerRuleHandler struct {
+	answerRuleService service.ChatAgentAnswerRuleService // 预制答案规则 业务逻辑层接口
+}
+
+// NewChatAgentAnswerRuleHandler 创建 预制答案规则 Handler 实例
+// 参数：answerRuleService - 预制答案规则 业务逻辑层接口
+func NewChatAgentAnswerRuleHandler(answerRuleService service.ChatAgentAnswerRuleService) *ChatAgentAnswerRuleHandler {
+	return &ChatAgentAnswerRuleHandler{
+		answerRuleService: answerRuleService,
+	}
+}
+
+// SaveAnswerRule 保存预制答案规则
+// 处理 POST /api/v1/chat-agent-answer-rules/save 请求
+func (h *ChatAgentAnswerRuleHandler) SaveAnswerRule(c *gin.Context) {
+	var saveRequest dto.SaveChatAgentAnswerRuleRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	rule, err := converter.SaveChatAgentAnswerRuleRequestToChatAgentAnswerRuleModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.answerRuleService.SaveAnswerRule(c.Request.Context(), rule); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"answer_rule": converter.ChatAgentAnswerRuleModelToChatAgentAnswerRuleDto(rule),
+	})
+}
+
+// DeleteAnswerRule 删除预制答案规则
+// 处理 DELETE /api/v1/chat-agent-answer-rules/:id 请求
+func (h *ChatAgentAnswerRuleHandler) DeleteAnswerRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.answerRuleService.DeleteAnswerRule(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "预制答案规则删除成功"})
+}
+
+// GetAnswerRulesByChatAgentID 获取智能体的预制答案规则列表
+// 处理 GET /api/v1/chat-agent-answer-rules/chat-agent/:chatAgentId 请求
+func (h *ChatAgentAnswerRuleHandler) GetAnswerRulesByChatAgentID(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+
+	rules, err := h.answerRuleService.GetAnswerRulesByChatAgentID(c.Request.Context(), chatAgentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"answer_rules": converter.ChatAgentAnswerRuleModelListToChatAgentAnswerRuleDtoList(rules),
+	})
+}
+
+// TestAnswerRule 测试用户消息命中的预制答案规则
+// 处理 POST /api/v1/chat-agent-answer-rules/test 请求
+func (h *ChatAgentAnswerRuleHandler) TestAnswerRule(c *gin.Context) {
+	var testRequest dto.TestChatAgentAnswerRuleRequest
+	if err := c.ShouldBindJSON(&testRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	chatAgentID, err := uuid.Parse(testRequest.ChatAgentID)
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+
+	rule, err := h.answerRuleService.MatchAnswerRule(c.Request.Context(), chatAgentID, testRequest.UserMessage)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.TestChatAgentAnswerRuleResponse{Matched: rule != nil}
+	if rule != nil {
+		ruleDto := converter.ChatAgentAnswerRuleModelToChatAgentAnswerRuleDto(rule)
+		response.Rule = &ruleDto
+	}
+	utils.JsonResponse(c, http.StatusOK, response)
+}
@@ -3,16 +3,24 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/define"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // ChatAgentConversationHandler 聊天会话 控制器
@@ -20,14 +28,31 @@ import (
 // 相当于 Java Spring Boot 中的 Controller
 type ChatAgentConversationHandler struct {
 	chatAgentConversationService service.ChatAgentConversationService // 聊天会话 业务逻辑层接口
+	serviceUserService           service.ServiceUserService           // 业务侧用户 业务逻辑层接口
+	conversationExportService    service.ConversationExportService    // 会话导出 业务逻辑层接口
+	conversationReplayService    service.ConversationReplayService    // 会话回放 业务逻辑层接口
+	chatStreamResumeService      service.ChatStreamResumeService      // 流式回复续传 业务逻辑层接口
+	presenceService              service.ConversationPresenceService  // 会话状态 业务逻辑层接口
+	variableService              service.ConversationVariableService  // 会话变量 业务逻辑层接口
+	widgetTokenService           service.ChatWidgetTokenService       // 嵌入式聊天窗口令牌 业务逻辑层接口
 }
 
 // NewChatAgentConversationHandler 创建 聊天会话 Handler 实例
 // 返回 ChatAgentConversationHandler 的实例
-// 参数：chatAgentConversationService - 聊天会话 业务逻辑层接口
-func NewChatAgentConversationHandler(chatAgentConversationService service.ChatAgentConversationService) *ChatAgentConversationHandler {
+// 参数：chatAgentConversationService - 聊天会话 业务逻辑层接口，serviceUserService - 业务侧用户 业务逻辑层接口，conversationExportService - 会话导出 业务逻辑层接口，conversationReplayService - 会话回放 业务逻辑层接口，chatStreamResumeService - 流式回复续传 业务逻辑层接口，presenceService - 会话状态 业务逻辑层接口，variableService - 会话变量 业务逻辑层接口，widgetTokenService - 嵌入式聊天窗口令牌 业务逻辑层接口
+func NewChatAgentConversationHandler(chatAgentConversationService service.ChatAgentConversationService, serviceUserService service.ServiceUserService,
+	conversationExportService service.ConversationExportService, conversationReplayService service.ConversationReplayService,
+	chatStreamResumeService service.ChatStreamResumeService, presenceService service.ConversationPresenceService,
+	variableService service.ConversationVariableService, widgetTokenService service.ChatWidgetTokenService) *ChatAgentConversationHandler {
 	return &ChatAgentConversationHandler{
 		chatAgentConversationService: chatAgentConversationService,
+		serviceUserService:           serviceUserService,
+		conversationExportService:    conversationExportService,
+		conversationReplayService:    conversationReplayService,
+		chatStreamResumeService:      chatStreamResumeService,
+		presenceService:              presenceService,
+		variableService:              variableService,
+		widgetTokenService:           widgetTokenService,
 	}
 }
 
@@ -35,13 +60,18 @@ func NewChatAgentConversationHandler(chatAgentConversationService service.ChatAg
 // 处理 GET /api/v1/chat-agent-conversations/conversation-list 请求
 func (h *ChatAgentConversationHandler) GetConversationList(c *gin.Context) {
 	// 获取查询参数
-	serviceUserID := c.Query("service_user_id")
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	if serviceUserID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "service_user_id 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
 		return
 	}
 
 	lastID := c.Query("last_id")
+	sort := c.Query("sort")
 	sizeStr := c.DefaultQuery("size", "10")
 
 	// 解析size参数
@@ -53,49 +83,324 @@ func (h *ChatAgentConversationHandler) GetConversationList(c *gin.Context) {
 	// 从上下文获取智能体信息（通过中间件设置）
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
 	// 调用业务逻辑层获取会话列表
-	conversations, err := h.chatAgentConversationService.GetConversationList(
+	conversations, pageInfo, err := h.chatAgentConversationService.GetConversationList(
 		c.Request.Context(),
-		serviceUserID,
-		lastID,
-		size,
+		&dto.GetConversationListRequest{
+			ServiceUserID: serviceUserID,
+			LastID:        &lastID,
+			Size:          &size,
+			Sort:          &sort,
+			IncludeTotal:  c.Query("include_total") == "true",
+		},
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	// 转换为响应格式
-	conversationList := make([]dto.ConversationInfoDto, 0, len(conversations))
+	// 转换为响应格式，附带已登记的业务侧用户信息
+	conversationList, err := h.convertConversationListToDto(c, conversations)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.GetConversationListResponse{
+		Conversations:  conversationList,
+		CursorPageInfo: *pageInfo,
+	}
+
+	utils.JsonResponse(c, http.StatusOK, response)
+}
+
+// GetAdminConversationList 管理后台获取智能体下全部业务侧用户的会话列表
+// 处理 GET /api/v1/chat-agents/:chatAgentID/conversations 请求
+// 支持 service_user_id、created_after、created_before（毫秒时间戳）、has_errors、has_persistence_gaps、min_total_tokens、max_total_tokens 筛选
+func (h *ChatAgentConversationHandler) GetAdminConversationList(c *gin.Context) {
+	req := dto.AdminConversationListRequest{
+		ChatAgentID:   c.Param("chatAgentID"),
+		ServiceUserID: c.Query("service_user_id"),
+	}
+
+	// 解析分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	req.Page = page
+	req.PageSize = pageSize
+
+	// 解析筛选参数
+	for name, target := range map[string]**int64{
+		"created_after":    &req.CreatedAfter,
+		"created_before":   &req.CreatedBefore,
+		"min_total_tokens": &req.MinTotalTokens,
+		"max_total_tokens": &req.MaxTotalTokens,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.Error(apperror.Newf(apperror.CodeInvalidArgument, "%s 参数格式错误", name))
+			return
+		}
+		*target = &parsed
+	}
+	for name, target := range map[string]**bool{
+		"has_errors":           &req.HasErrors,
+		"has_persistence_gaps": &req.HasPersistenceGaps,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.Error(apperror.Newf(apperror.CodeInvalidArgument, "%s 参数格式错误", name))
+			return
+		}
+		*target = &parsed
+	}
+
+	conversations, total, err := h.chatAgentConversationService.ListConversationsWithStats(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 转换为响应格式，附带已登记的业务侧用户信息
+	conversationModels := make([]*models.ChatAgentConversation, 0, len(conversations))
 	for _, conv := range conversations {
-		createdAt := conv.CreatedAt.UnixMilli()
-		updatedAt := conv.UpdatedAt.UnixMilli()
-		conversationList = append(conversationList, dto.ConversationInfoDto{
-			ID:            conv.ID.String(),
-			Title:         conv.Title,
-			ApplicationID: conv.ApplicationID.String(),
-			ServiceUserID: conv.ServiceUserID,
-			CreatedAt:     &createdAt,
-			UpdatedAt:     &updatedAt,
+		conversationModels = append(conversationModels, &conv.ChatAgentConversation)
+	}
+	conversationInfoList, err := h.convertConversationListToDto(c, conversationModels)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	conversationList := make([]dto.AdminConversationInfoDto, 0, len(conversations))
+	for i, conv := range conversations {
+		var lastErrorAt *int64
+		if conv.LastErrorAt != nil {
+			lastErrorAtMilli := conv.LastErrorAt.UnixMilli()
+			lastErrorAt = &lastErrorAtMilli
+		}
+		var lastPersistenceGapAt *int64
+		if conv.LastPersistenceGapAt != nil {
+			lastPersistenceGapAtMilli := conv.LastPersistenceGapAt.UnixMilli()
+			lastPersistenceGapAt = &lastPersistenceGapAtMilli
+		}
+		conversationList = append(conversationList, dto.AdminConversationInfoDto{
+			ConversationInfoDto:  conversationInfoList[i],
+			ChatAgentID:          conv.ChatAgentID.String(),
+			MessageCount:         conv.MessageCount,
+			ErrorCount:           conv.ErrorCount,
+			LastErrorAt:          lastErrorAt,
+			PersistenceGapCount:  conv.PersistenceGapCount,
+			LastPersistenceGapAt: lastPersistenceGapAt,
 		})
 	}
 
-	response := dto.GetConversationListResponse{
+	utils.JsonResponse(c, http.StatusOK, dto.AdminConversationListResponse{
 		Conversations: conversationList,
-		TotalCount:    len(conversationList),
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+	})
+}
+
+// ExportConversation 管理后台导出会话记录
+// 处理 GET /api/v1/chat-agents/:chatAgentID/conversations/:conversationID/export 请求
+// format 参数为 json（默认）时返回会话信息和全部消息，为 pdf 时下载带智能体名称和头像的 PDF 会话记录
+func (h *ChatAgentConversationHandler) ExportConversation(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+	conversationID, err := uuid.Parse(c.Param("conversationID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的会话UUID格式"))
+		return
+	}
+	format := c.DefaultQuery("format", define.ConversationExportFormatJson)
+	if format != define.ConversationExportFormatJson && format != define.ConversationExportFormatPdf {
+		c.Error(apperror.Newf(apperror.CodeInvalidArgument, "不支持的导出格式: %s", format))
+		return
+	}
+
+	transcript, err := h.conversationExportService.GetConversationTranscript(c.Request.Context(), chatAgentID, conversationID)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	if format == define.ConversationExportFormatPdf {
+		data, err := h.conversationExportService.RenderTranscriptPdf(transcript)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="conversation-%s.pdf"`, conversationID))
+		c.Data(http.StatusOK, "application/pdf", data)
+		return
+	}
+
+	conversationInfoList, err := h.convertConversationListToDto(c, []*models.ChatAgentConversation{transcript.Conversation})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, dto.ConversationExportResponse{
+		ChatAgentID:        transcript.ChatAgent.ID.String(),
+		ChatAgentName:      transcript.ChatAgent.Name,
+		ChatAgentAvatarUrl: transcript.ChatAgent.AvatarUrl,
+		Conversation:       conversationInfoList[0],
+		Messages:           convertChatMessageListToDto(transcript.Messages),
+		ExportedAt:         time.Now().UnixMilli(),
+	})
+}
+
+// ReplayConversation 管理后台使用修改后的配置回放会话
+// 处理 POST /api/v1/chat-agents/:chatAgentID/conversations/:conversationID/replay 请求
+// 逐条重新回答会话中的用户消息并与原始回答并排对比，不调用工具，也不修改原会话
+func (h *ChatAgentConversationHandler) ReplayConversation(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+	conversationID, err := uuid.Parse(c.Param("conversationID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的会话UUID格式"))
+		return
+	}
+	var replayRequest dto.ReplayConversationRequest
+	if err := c.ShouldBindJSON(&replayRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	options := &service.ConversationReplayOptions{
+		SystemPrompt:       replayRequest.SystemPrompt,
+		Temperature:        replayRequest.Temperature,
+		TopP:               replayRequest.TopP,
+		MaxOutputTokens:    replayRequest.MaxOutputTokens,
+		UseOriginalHistory: replayRequest.UseOriginalHistory,
+	}
+	if replayRequest.ChatModelID != nil && *replayRequest.ChatModelID != "" {
+		if options.ChatModelID, err = uuid.Parse(*replayRequest.ChatModelID); err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的聊天模型UUID格式"))
+			return
+		}
+	}
+
+	replay, err := h.conversationReplayService.ReplayConversation(c.Request.Context(), chatAgentID, conversationID, options)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := dto.ConversationReplayResponse{
+		ChatAgentID:        replay.ChatAgent.ID.String(),
+		ConversationID:     replay.Conversation.ID.String(),
+		ChatModelID:        replay.ChatModel.ID.String(),
+		ChatModelName:      replay.ChatModel.Name,
+		SystemPrompt:       replay.SystemPrompt,
+		Temperature:        replay.Temperature,
+		TopP:               replay.TopP,
+		MaxOutputTokens:    replay.MaxOutputTokens,
+		UseOriginalHistory: replay.UseOriginalHistory,
+		TotalTurnCount:     replay.TotalTurnCount,
+		ReplayedTurnCount:  len(replay.Turns),
+		Turns:              make([]dto.ConversationReplayTurnDto, 0, len(replay.Turns)),
+	}
+	for _, turn := range replay.Turns {
+		turnDto := dto.ConversationReplayTurnDto{
+			RequestID:      turn.RequestID,
+			UserMessage:    turn.UserMessage,
+			OriginalAnswer: turn.OriginalAnswer,
+			ReplayAnswer:   turn.ReplayAnswer,
+			Changed:        turn.ErrorMessage == "" && turn.ReplayAnswer != turn.OriginalAnswer,
+			ErrorMessage:   turn.ErrorMessage,
+			DurationMs:     turn.DurationMs,
+			Diff:           make([]dto.ConversationReplayDiffRowDto, 0, len(turn.Diff)),
+		}
+		for _, row := range turn.Diff {
+			turnDto.Diff = append(turnDto.Diff, dto.ConversationReplayDiffRowDto{
+				Type:     row.Type,
+				Original: row.Left,
+				Replay:   row.Right,
+			})
+		}
+		if turnDto.Changed {
+			response.ChangedTurnCount++
+		}
+		response.Turns = append(response.Turns, turnDto)
+	}
+	utils.JsonResponse(c, http.StatusOK, response)
+}
+
+// convertConversationListToDto 将会话列表转换为响应DTO
+// 按应用批量查询会话所属的业务侧用户，已登记的用户信息附带在会话中
+// 同时批量查询会话的最后一条消息预览、消息数量和未读回复数量
+func (h *ChatAgentConversationHandler) convertConversationListToDto(c *gin.Context, conversations []*models.ChatAgentConversation) ([]dto.ConversationInfoDto, error) {
+	serviceUserIDsByApp := make(map[uuid.UUID][]string)
+	conversationIDs := make([]uuid.UUID, 0, len(conversations))
+	for _, conv := range conversations {
+		serviceUserIDsByApp[conv.ApplicationID] = append(serviceUserIDsByApp[conv.ApplicationID], conv.ServiceUserID)
+		conversationIDs = append(conversationIDs, conv.ID)
+	}
+	serviceUsersByApp := make(map[uuid.UUID]map[string]*models.ServiceUser, len(serviceUserIDsByApp))
+	for applicationID, serviceUserIDs := range serviceUserIDsByApp {
+		serviceUsers, err := h.serviceUserService.GetServiceUserMap(c.Request.Context(), applicationID, serviceUserIDs)
+		if err != nil {
+			return nil, err
+		}
+		serviceUsersByApp[applicationID] = serviceUsers
+	}
+	summaries, err := h.chatAgentConversationService.GetConversationSummaries(c.Request.Context(), conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	conversationList := make([]dto.ConversationInfoDto, 0, len(conversations))
+	for _, conv := range conversations {
+		createdAt := conv.CreatedAt.UnixMilli()
+		updatedAt := conv.UpdatedAt.UnixMilli()
+		conversationList = append(conversationList, dto.ConversationInfoDto{
+			ID:                     conv.ID.String(),
+			Title:                  conv.Title,
+			ApplicationID:          conv.ApplicationID.String(),
+			ServiceUserID:          conv.ServiceUserID,
+			ServiceUser:            converter.ServiceUserModelToServiceUserInfoDto(serviceUsersByApp[conv.ApplicationID][conv.ServiceUserID]),
+			CreatedAt:              &createdAt,
+			UpdatedAt:              &updatedAt,
+			PromptTokenCount:       conv.PromptTokenCount,
+			CompletionTokenCount:   conv.CompletionTokenCount,
+			TotalTokenCount:        conv.TotalTokenCount,
+			Cost:                   conv.Cost,
+			ConversationSummaryDto: summaries[conv.ID],
+		})
+	}
+	return conversationList, nil
 }
 
 // GetChatMessageList 获取聊天消息列表
@@ -104,7 +409,17 @@ func (h *ChatAgentConversationHandler) GetChatMessageList(c *gin.Context) {
 	// 获取查询参数
 	conversationID := c.Query("conversation_id")
 	if conversationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation_id 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if serviceUserID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
 		return
 	}
 
@@ -120,49 +435,168 @@ func (h *ChatAgentConversationHandler) GetChatMessageList(c *gin.Context) {
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
 	// 调用业务逻辑层获取消息列表
-	messages, err := h.chatAgentConversationService.GetChatMessageList(
+	req := &dto.GetChatMessageListRequest{
+		ConversationID: conversationID,
+		ServiceUserID:  serviceUserID,
+		LastID:         &lastID,
+		Size:           &size,
+		IncludeTotal:   c.Query("include_total") == "true",
+	}
+
+	// 解析排序方式和创建时间范围
+	if sort := c.Query("sort"); sort != "" {
+		req.Sort = &sort
+	}
+	if createdBeforeStr := c.Query("created_before"); createdBeforeStr != "" {
+		createdBefore, err := strconv.ParseInt(createdBeforeStr, 10, 64)
+		if err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "created_before 参数必须是毫秒时间戳"))
+			return
+		}
+		req.CreatedBefore = &createdBefore
+	}
+	if createdAfterStr := c.Query("created_after"); createdAfterStr != "" {
+		createdAfter, err := strconv.ParseInt(createdAfterStr, 10, 64)
+		if err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "created_after 参数必须是毫秒时间戳"))
+			return
+		}
+		req.CreatedAfter = &createdAfter
+	}
+
+	messages, pageInfo, err := h.chatAgentConversationService.GetChatMessageList(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 转换为响应格式
+	messageList := convertChatMessageListToDto(messages)
+
+	response := dto.GetChatMessageListResponse{
+		Messages:       messageList,
+		CursorPageInfo: *pageInfo,
+	}
+
+	utils.JsonResponse(c, http.StatusOK, response)
+}
+
+// GetChatMessageListByRequestID 根据请求ID获取消息列表
+// 处理 GET /api/v1/chat-agent-conversations/message-by-request-id 请求
+func (h *ChatAgentConversationHandler) GetChatMessageListByRequestID(c *gin.Context) {
+	// 获取查询参数
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if serviceUserID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
+		return
+	}
+
+	requestID := c.Query("request_id")
+	if requestID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "request_id 参数不能为空"))
+		return
+	}
+
+	// 从上下文获取智能体信息
+	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
+	if !exists {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
+		return
+	}
+
+	_, ok := chatAgentValue.(*models.ChatAgent)
+	if !ok {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
+		return
+	}
+
+	// 调用业务逻辑层获取消息列表
+	messages, err := h.chatAgentConversationService.GetChatMessageListByRequestID(
 		c.Request.Context(),
+		serviceUserID,
 		conversationID,
-		lastID,
-		size,
+		requestID,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	// 转换为响应格式
+	// 同一请求的消息一次性全部返回，无需分页
+	totalCount := int64(len(messages))
+	response := dto.GetChatMessageListResponse{
+		Messages: convertChatMessageListToDto(messages),
+		CursorPageInfo: dto.CursorPageInfo{
+			TotalCount: &totalCount,
+		},
+	}
+
+	utils.JsonResponse(c, http.StatusOK, response)
+}
+
+// convertChatMessageListToDto 将消息模型列表转换为响应格式
+// 参数：messages - 消息模型列表
+// 返回：消息信息DTO列表
+func convertChatMessageListToDto(messages []*models.ChatAgentMessage) []dto.ChatMessageInfoDto {
 	messageList := make([]dto.ChatMessageInfoDto, 0, len(messages))
 	for _, msg := range messages {
-		attachmentInfoList := make([]dto.ChatMessageAttachmentInfoDto, 0)
-		if msg.AttachmentsInfo != "" {
-			// 解析附件信息JSON
-			var attachmentDictList []map[string]interface{}
-			if err := json.Unmarshal([]byte(msg.AttachmentsInfo), &attachmentDictList); err == nil {
-				for _, item := range attachmentDictList {
-					if id, ok := item["id"].(string); ok {
-						if name, ok := item["name"].(string); ok {
-							attachmentInfoList = append(attachmentInfoList, dto.ChatMessageAttachmentInfoDto{
-								ID:   id,
-								Name: name,
-							})
-						}
-					}
-				}
+		// 附件由 LoadAttachments 按消息ID加载
+		attachmentInfoList := make([]dto.ChatMessageAttachmentInfoDto, 0, len(msg.Attachments))
+		for _, attachment := range msg.Attachments {
+			attachmentInfoList = append(attachmentInfoList, dto.ChatMessageAttachmentInfoDto{
+				ID:   attachment.ID.String(),
+				Name: attachment.OriginalFileName,
+			})
+		}
+
+		// 解析知识库引用JSON
+		citations := make([]dto.ChatCitationDto, 0)
+		if msg.Citations != "" {
+			if err := json.Unmarshal([]byte(msg.Citations), &citations); err != nil {
+				citations = make([]dto.ChatCitationDto, 0)
+			}
+		}
+
+		// 解析追问建议JSON
+		suggestions := make([]string, 0)
+		if msg.Suggestions != "" {
+			if err := json.Unmarshal([]byte(msg.Suggestions), &suggestions); err != nil {
+				suggestions = make([]string, 0)
 			}
 		}
 
+		// 送达和已读时间
+		var deliveredAt, readAt *int64
+		if msg.DeliveredAt != nil {
+			deliveredAtMilli := msg.DeliveredAt.UnixMilli()
+			deliveredAt = &deliveredAtMilli
+		}
+		if msg.ReadAt != nil {
+			readAtMilli := msg.ReadAt.UnixMilli()
+			readAt = &readAtMilli
+		}
+
 		createdAt := msg.CreatedAt.UnixMilli()
 		updatedAt := msg.UpdatedAt.UnixMilli()
 		messageList = append(messageList, dto.ChatMessageInfoDto{
@@ -173,25 +607,29 @@ func (h *ChatAgentConversationHandler) GetChatMessageList(c *gin.Context) {
 			Type:                  msg.Type,
 			Role:                  &msg.Role,
 			Content:               &msg.Content,
+			Language:              msg.Language,
+			TruncatedReason:       msg.TruncatedReason,
 			FunctionCallID:        &msg.FunctionCallID,
 			FunctionCallName:      &msg.FunctionCallName,
 			FunctionCallArguments: &msg.FunctionCallArguments,
 			FunctionCallOutput:    &msg.FunctionCallOutput,
+			FunctionCallLimited:   msg.FunctionCallRawOutput != "",
+			OutputRenderer:        msg.FunctionCallOutputRenderer,
 			PromptTokenCount:      msg.PromptTokenCount,
 			CompletionTokenCount:  msg.CompletionTokenCount,
 			TotalTokenCount:       msg.TotalTokenCount,
+			Cost:                  msg.Cost,
 			CreatedAt:             &createdAt,
 			UpdatedAt:             &updatedAt,
 			AttachmentInfoList:    attachmentInfoList,
+			Citations:             citations,
+			Suggestions:           suggestions,
+			DeliveredAt:           deliveredAt,
+			ReadAt:                readAt,
 		})
 	}
 
-	response := dto.GetChatMessageListResponse{
-		Messages:   messageList,
-		TotalCount: len(messageList),
-	}
-
-	c.JSON(http.StatusOK, response)
+	return messageList
 }
 
 // SendMessage 自然语言对话
@@ -200,20 +638,26 @@ func (h *ChatAgentConversationHandler) SendMessage(c *gin.Context) {
 	// 绑定JSON请求体
 	var req dto.ChatUserSendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	req.ServiceUserID = serviceUserID
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
@@ -224,7 +668,7 @@ func (h *ChatAgentConversationHandler) SendMessage(c *gin.Context) {
 		false, // 非流式
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -251,31 +695,35 @@ func (h *ChatAgentConversationHandler) SendMessageStreamable(c *gin.Context) {
 	// 绑定JSON请求体
 	var req dto.ChatUserSendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	req.ServiceUserID = serviceUserID
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
-	// 调用业务逻辑层处理消息
-	stream, err := h.chatAgentConversationService.UserSendMessage(
-		c.Request.Context(),
-		&req,
-		true, // 流式
-	)
+	// 调用业务逻辑层处理消息，保存输出的事件以便网络中断后续传
+	stream, err := h.chatStreamResumeService.Start(c.Request.Context(), req.ServiceUserID, func(ctx context.Context) (io.Reader, error) {
+		return h.chatAgentConversationService.UserSendMessage(ctx, &req, true)
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -302,26 +750,32 @@ func (h *ChatAgentConversationHandler) SendMessagePredefined(c *gin.Context) {
 	// 绑定JSON请求体
 	var req dto.ChatUserSendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req.ServiceUserID = serviceUserID
 
 	// 验证预制答案
 	if req.PredefinedAnswer == nil || *req.PredefinedAnswer == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "预制答案不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "预制答案不能为空"))
 		return
 	}
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
@@ -332,7 +786,7 @@ func (h *ChatAgentConversationHandler) SendMessagePredefined(c *gin.Context) {
 		false, // 非流式
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -359,37 +813,290 @@ func (h *ChatAgentConversationHandler) SendMessagePredefinedStreamable(c *gin.Co
 	// 绑定JSON请求体
 	var req dto.ChatUserSendMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req.ServiceUserID = serviceUserID
 
 	// 验证预制答案
 	if req.PredefinedAnswer == nil || *req.PredefinedAnswer == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "预制答案不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "预制答案不能为空"))
 		return
 	}
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
-	// 调用业务逻辑层处理消息
-	stream, err := h.chatAgentConversationService.UserSendMessagePredefinedAnswer(
-		c.Request.Context(),
-		&req,
-		true, // 流式
-	)
+	// 调用业务逻辑层处理消息，保存输出的事件以便网络中断后续传
+	stream, err := h.chatStreamResumeService.Start(c.Request.Context(), req.ServiceUserID, func(ctx context.Context) (io.Reader, error) {
+		return h.chatAgentConversationService.UserSendMessagePredefinedAnswer(ctx, &req, true)
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	// 设置响应头
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	// 流式返回响应
+	c.Stream(func(w io.Writer) bool {
+		buffer := make([]byte, 1024)
+		n, err := stream.Read(buffer)
+		if err != nil {
+			return false
+		}
+		w.Write(buffer[:n])
+		return true
+	})
+}
+
+// UpdateMessageReceipts 上报消息送达和已读状态
+// 处理 POST /api/v1/chat-agent-conversations/message-receipts 请求
+func (h *ChatAgentConversationHandler) UpdateMessageReceipts(c *gin.Context) {
+	var req dto.UpdateMessageReceiptsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req.ServiceUserID = serviceUserID
+
+	response, err := h.chatAgentConversationService.UpdateMessageReceipts(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, response)
+}
+
+// UpdateTypingStatus 上报用户输入状态
+// 处理 POST /api/v1/chat-agent-conversations/typing 请求
+// 输入状态约 8 秒后自动过期，用户持续输入时客户端需定期重复上报
+func (h *ChatAgentConversationHandler) UpdateTypingStatus(c *gin.Context) {
+	var req dto.UpdateTypingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req.ServiceUserID = serviceUserID
+
+	if err := h.presenceService.UpdateUserTyping(c.Request.Context(), &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "上报成功",
+	})
+}
+
+// GetConversationVariables 获取会话变量列表
+// 处理 GET /api/v1/chat-agent-conversations/conversation-variables 请求
+func (h *ChatAgentConversationHandler) GetConversationVariables(c *gin.Context) {
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	variables, err := h.variableService.ListVariables(c.Request.Context(), serviceUserID, conversationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"variables": converter.ConversationVariableModelListToDtoList(variables),
+	})
+}
+
+// SetConversationVariable 设置会话变量
+// 处理 PUT /api/v1/chat-agent-conversations/conversation-variable 请求
+// 变量已存在时覆盖变量值
+func (h *ChatAgentConversationHandler) SetConversationVariable(c *gin.Context) {
+	var req dto.SetConversationVariableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	serviceUserID, err := resolveServiceUserID(c, req.ServiceUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req.ServiceUserID = serviceUserID
+
+	variable, err := h.variableService.SetVariable(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"variable": converter.ConversationVariableModelToDto(variable),
+	})
+}
+
+// DeleteConversationVariable 删除会话变量
+// 处理 DELETE /api/v1/chat-agent-conversations/conversation-variable 请求
+func (h *ChatAgentConversationHandler) DeleteConversationVariable(c *gin.Context) {
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+	name := c.Query("name")
+	if name == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "name 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.variableService.DeleteVariable(c.Request.Context(), serviceUserID, conversationID, name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "删除成功",
+	})
+}
+
+// StreamPresenceEvents 实时推送会话状态
+// 处理 GET /api/v1/chat-agent-conversations/presence-events 请求
+// 以 SSE 格式推送当前状态以及用户输入、智能体生成回复的状态变化，直到客户端断开
+func (h *ChatAgentConversationHandler) StreamPresenceEvents(c *gin.Context) {
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	events, unsubscribe, err := h.presenceService.Subscribe(c.Request.Context(), conversationID, serviceUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(monitorHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// 设置响应头
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			return true
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			eventJSON, err := json.Marshal(event)
+			if err != nil {
+				return true
+			}
+			fmt.Fprintf(w, "data: %s\n\n", eventJSON)
+			return true
+		}
+	})
+}
+
+// StreamResume 续传流式回复
+// 处理 GET /api/v1/chat-agent-conversations/stream-resume 请求
+// 网络中断后重新连接，输出 last_event_id 之后的事件，回复仍在生成时继续输出直到结束
+// 需要传入回复所属的 conversation_id 和发送消息的 service_user_id，与登记的不一致时拒绝
+// 未指定 last_event_id 时使用 Last-Event-ID 请求头，都未指定时从头输出
+func (h *ChatAgentConversationHandler) StreamResume(c *gin.Context) {
+	requestID := c.Query("request_id")
+	if requestID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "request_id 参数不能为空"))
+		return
+	}
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if serviceUserID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
+		return
+	}
+
+	lastEventID := 0
+	lastEventIDValue := c.Query("last_event_id")
+	if lastEventIDValue == "" {
+		lastEventIDValue = c.GetHeader("Last-Event-ID")
+	}
+	if lastEventIDValue != "" {
+		var err error
+		lastEventID, err = strconv.Atoi(lastEventIDValue)
+		if err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "last_event_id 参数格式错误"))
+			return
+		}
+	}
+
+	stream, err := h.chatStreamResumeService.Resume(c.Request.Context(), conversationID, serviceUserID, requestID, lastEventID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -416,27 +1123,33 @@ func (h *ChatAgentConversationHandler) UploadAttachment(c *gin.Context) {
 	// 获取上传的文件
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请选择要上传的文件"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "请选择要上传的文件").WithCause(err))
+		return
+	}
+
+	// 使用窗口令牌上传时，表单中的业务侧用户需与令牌绑定的一致
+	if _, err := resolveServiceUserID(c, c.PostForm("service_user_id")); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
 	// 打开文件
 	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "打开文件失败"})
+		c.Error(apperror.New(apperror.CodeInternal, "打开文件失败"))
 		return
 	}
 	defer src.Close()
@@ -449,11 +1162,167 @@ func (h *ChatAgentConversationHandler) UploadAttachment(c *gin.Context) {
 		file.Size,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, result)
+}
+
+// DownloadAttachment 下载聊天附件
+// 处理 GET /api/v1/chat-agent-conversations/attachment-download 请求
+// 可下载上传的附件和工具生成的文件；传入 service_user_id 时校验附件所属会话的归属
+func (h *ChatAgentConversationHandler) DownloadAttachment(c *gin.Context) {
+	attachmentID := c.Query("attachment_id")
+	if attachmentID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "attachment_id 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	attachment, err := h.chatAgentConversationService.GetAttachment(c.Request.Context(), serviceUserID, attachmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if _, err := os.Stat(attachment.FilePath); err != nil {
+		c.Error(apperror.New(apperror.CodeNotFound, "附件文件不存在"))
+		return
+	}
+
+	c.Header("Content-Type", attachment.MimeType)
+	c.FileAttachment(attachment.FilePath, attachment.OriginalFileName)
+}
+
+// GetChatBootstrap 获取聊天首屏信息
+// 处理 GET /api/v1/chat-agent-conversations/bootstrap 请求
+// 返回智能体信息、欢迎语、推荐问题、当前是否可用和所属应用的品牌展示信息，供嵌入页面渲染首屏
+func (h *ChatAgentConversationHandler) GetChatBootstrap(c *gin.Context) {
+	result, err := h.chatAgentConversationService.GetChatBootstrap(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, result)
+}
+
+// CreateWidgetToken 创建嵌入式聊天窗口令牌
+// 处理 POST /api/v1/chat/widget-token 请求
+// 业务服务端使用ApiKey为指定的业务侧用户换取短期的窗口令牌交给浏览器，浏览器通过 lemon-ai-widget-token 请求头调用聊天接口
+func (h *ChatAgentConversationHandler) CreateWidgetToken(c *gin.Context) {
+	var req dto.ChatWidgetTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	token, err := h.widgetTokenService.CreateToken(c.Request.Context(), req.ServiceUserID, req.Origin, req.TtlMinutes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.ChatWidgetTokenResponse{
+		WidgetToken:   token.Token,
+		ServiceUserID: token.ServiceUserID,
+		Origin:        token.Origin,
+		ExpiresAt:     token.ExpiresAt.UnixMilli(),
+	})
+}
+
+// resolveServiceUserID 获取请求操作的业务侧用户ID
+// 使用窗口令牌认证时只能操作令牌绑定的业务侧用户：未传入时使用令牌绑定的用户，传入其他用户时拒绝
+// 使用ApiKey认证时原样返回请求中的业务侧用户ID
+func resolveServiceUserID(c *gin.Context, serviceUserID string) (string, error) {
+	boundServiceUserID := c.GetString(define.AppContextKeyWidgetServiceUserID)
+	if boundServiceUserID == "" {
+		return serviceUserID, nil
+	}
+	if serviceUserID != "" && serviceUserID != boundServiceUserID {
+		return "", apperror.New(apperror.CodeForbidden, "窗口令牌只能访问绑定的业务侧用户的数据")
+	}
+	return boundServiceUserID, nil
+}
+
+// GetCSRFToken 获取CSRF令牌
+// 处理 GET /api/v1/chat/csrf-token 请求
+// 令牌与请求使用的窗口令牌绑定，窗口发起修改数据的请求时通过 X-CSRF-Token 请求头回传，服务端重新计算校验
+// 不使用 Cookie，浏览器阻止第三方 Cookie 时同样可用；使用 ApiKey 认证的请求不需要CSRF令牌
+func (h *ChatAgentConversationHandler) GetCSRFToken(c *gin.Context) {
+	widgetTokenValue, exists := c.Get(define.AppContextKeyCurrentWidgetToken)
+	if !exists {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "只有使用窗口令牌认证的请求需要CSRF令牌"))
+		return
+	}
+	widgetToken, ok := widgetTokenValue.(*models.ChatAgentWidgetToken)
+	if !ok {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "窗口令牌信息类型错误"))
+		return
+	}
+
+	token, err := h.widgetTokenService.CreateCSRFToken(widgetToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"csrf_token": token,
+	})
+}
+
+// GetConversation 获取单个会话详情
+// 处理 GET /api/v1/chat-agent-conversations/conversation 请求
+func (h *ChatAgentConversationHandler) GetConversation(c *gin.Context) {
+	// 获取查询参数
+	conversationID := c.Query("conversation_id")
+	if conversationID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
+		return
+	}
+
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if serviceUserID == "" {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
+		return
+	}
+
+	// 从上下文获取智能体信息
+	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
+	if !exists {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
+		return
+	}
+
+	_, ok := chatAgentValue.(*models.ChatAgent)
+	if !ok {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
+		return
+	}
+
+	// 调用业务逻辑层获取会话详情
+	result, err := h.chatAgentConversationService.GetConversation(
+		c.Request.Context(),
+		serviceUserID,
+		conversationID,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, result)
 }
 
 // DeleteConversation 删除会话
@@ -462,26 +1331,30 @@ func (h *ChatAgentConversationHandler) DeleteConversation(c *gin.Context) {
 	// 获取查询参数
 	conversationID := c.Query("conversation_id")
 	if conversationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation_id 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
 		return
 	}
 
-	serviceUserID := c.Query("service_user_id")
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	if serviceUserID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "service_user_id 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
 		return
 	}
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
@@ -492,11 +1365,11 @@ func (h *ChatAgentConversationHandler) DeleteConversation(c *gin.Context) {
 		conversationID,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	utils.JsonResponse(c, http.StatusOK, result)
 }
 
 // RenameConversationTitle 重命名会话
@@ -505,32 +1378,36 @@ func (h *ChatAgentConversationHandler) RenameConversationTitle(c *gin.Context) {
 	// 获取查询参数
 	conversationID := c.Query("conversation_id")
 	if conversationID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation_id 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "conversation_id 参数不能为空"))
 		return
 	}
 
 	newTitle := c.Query("new_title")
 	if newTitle == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "new_title 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "new_title 参数不能为空"))
 		return
 	}
 
-	serviceUserID := c.Query("service_user_id")
+	serviceUserID, err := resolveServiceUserID(c, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	if serviceUserID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "service_user_id 参数不能为空"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空"))
 		return
 	}
 
 	// 从上下文获取智能体信息
 	chatAgentValue, exists := c.Get(define.AppContextKeyCurrentChatAgent)
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息未找到"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息未找到"))
 		return
 	}
 
 	_, ok := chatAgentValue.(*models.ChatAgent)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "智能体信息类型错误"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "智能体信息类型错误"))
 		return
 	}
 
@@ -542,9 +1419,9 @@ func (h *ChatAgentConversationHandler) RenameConversationTitle(c *gin.Context) {
 		newTitle,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	utils.JsonResponse(c, http.StatusOK, result)
 }
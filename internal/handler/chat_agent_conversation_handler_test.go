@@ -0,0 +1,551 @@
+package handler_test
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/testsupport"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetConversationList(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	for i := range 3 {
+		testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", fmt.Sprintf("会话%d", i))
+	}
+	testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-b", "其他用户的会话")
+
+	t.Run("requires service_user_id", func(t *testing.T) {
+		recorder := server.Do(t, http.MethodGet, "/api/v1/chat/conversation-list", nil, agent.Header())
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d, body: %s", recorder.Code, http.StatusBadRequest, recorder.Body.String())
+		}
+	})
+
+	t.Run("requires api key", func(t *testing.T) {
+		recorder := server.Do(t, http.MethodGet, "/api/v1/chat/conversation-list?service_user_id=user-a", nil, nil)
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d, body: %s", recorder.Code, http.StatusUnauthorized, recorder.Body.String())
+		}
+	})
+
+	t.Run("pages through the user's conversations", func(t *testing.T) {
+		seen := map[string]bool{}
+		lastID := ""
+		for page := 0; ; page++ {
+			query := url.Values{"service_user_id": {"user-a"}, "size": {"2"}, "last_id": {lastID}}
+			recorder := server.Do(t, http.MethodGet, "/api/v1/chat/conversation-list?"+query.Encode(), nil, agent.Header())
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+			}
+
+			var response dto.GetConversationListResponse
+			testsupport.DecodeJSON(t, recorder, &response)
+			for _, conversation := range response.Conversations {
+				if conversation.ServiceUserID != "user-a" {
+					t.Errorf("conversation %s belongs to %q, want user-a", conversation.ID, conversation.ServiceUserID)
+				}
+				if seen[conversation.ID] {
+					t.Errorf("conversation %s returned twice", conversation.ID)
+				}
+				seen[conversation.ID] = true
+			}
+			if !response.HasMore {
+				break
+			}
+			if page > 3 || response.NextCursor == nil {
+				t.Fatalf("pagination did not terminate, next cursor: %v", response.NextCursor)
+			}
+			lastID = *response.NextCursor
+		}
+		if len(seen) != 3 {
+			t.Errorf("got %d conversations, want 3", len(seen))
+		}
+	})
+}
+
+func TestGetChatMessageList(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	conversation := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "会话")
+	for i := range 3 {
+		testsupport.CreateMessage(t, server.DB, conversation, fmt.Sprintf("request-%d", i), "user", fmt.Sprintf("消息%d", i))
+	}
+	otherAgent := testsupport.CreateChatAgent(t, server.DB)
+
+	tests := []struct {
+		name       string
+		query      url.Values
+		header     http.Header
+		wantStatus int
+	}{
+		{
+			name:       "owner",
+			query:      url.Values{"conversation_id": {conversation.ID.String()}, "service_user_id": {"user-a"}},
+			header:     agent.Header(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing service_user_id",
+			query:      url.Values{"conversation_id": {conversation.ID.String()}},
+			header:     agent.Header(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "other service user",
+			query:      url.Values{"conversation_id": {conversation.ID.String()}, "service_user_id": {"user-b"}},
+			header:     agent.Header(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "other chat agent",
+			query:      url.Values{"conversation_id": {conversation.ID.String()}, "service_user_id": {"user-a"}},
+			header:     otherAgent.Header(),
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := server.Do(t, http.MethodGet, "/api/v1/chat/message-list?"+tt.query.Encode(), nil, tt.header)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response dto.GetChatMessageListResponse
+			testsupport.DecodeJSON(t, recorder, &response)
+			if len(response.Messages) != 3 {
+				t.Errorf("got %d messages, want 3", len(response.Messages))
+			}
+		})
+	}
+
+	t.Run("pages through the messages", func(t *testing.T) {
+		var contents []string
+		lastID := ""
+		for page := 0; ; page++ {
+			query := url.Values{
+				"conversation_id": {conversation.ID.String()},
+				"service_user_id": {"user-a"},
+				"sort":            {"asc"},
+				"size":            {"2"},
+				"last_id":         {lastID},
+			}
+			recorder := server.Do(t, http.MethodGet, "/api/v1/chat/message-list?"+query.Encode(), nil, agent.Header())
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+			}
+
+			var response dto.GetChatMessageListResponse
+			testsupport.DecodeJSON(t, recorder, &response)
+			for _, message := range response.Messages {
+				contents = append(contents, *message.Content)
+			}
+			if !response.HasMore {
+				break
+			}
+			if page > 3 || response.NextCursor == nil {
+				t.Fatalf("pagination did not terminate, next cursor: %v", response.NextCursor)
+			}
+			lastID = *response.NextCursor
+		}
+		if want := []string{"消息0", "消息1", "消息2"}; !slices.Equal(contents, want) {
+			t.Errorf("messages = %v, want %v", contents, want)
+		}
+	})
+}
+
+func TestGetConversation(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	conversation := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "会话")
+	otherAgent := testsupport.CreateChatAgent(t, server.DB)
+
+	tests := []struct {
+		name          string
+		serviceUserID string
+		header        http.Header
+		wantStatus    int
+	}{
+		{name: "owner", serviceUserID: "user-a", header: agent.Header(), wantStatus: http.StatusOK},
+		{name: "missing service_user_id", header: agent.Header(), wantStatus: http.StatusBadRequest},
+		{name: "other service user", serviceUserID: "user-b", header: agent.Header(), wantStatus: http.StatusForbidden},
+		{name: "other chat agent", serviceUserID: "user-a", header: otherAgent.Header(), wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"conversation_id": {conversation.ID.String()}}
+			if tt.serviceUserID != "" {
+				query.Set("service_user_id", tt.serviceUserID)
+			}
+			recorder := server.Do(t, http.MethodGet, "/api/v1/chat/conversation?"+query.Encode(), nil, tt.header)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetChatMessageListByRequestID(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	conversation := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "会话")
+	testsupport.CreateMessage(t, server.DB, conversation, "request-1", "user", "问题")
+	testsupport.CreateMessage(t, server.DB, conversation, "request-1", "assistant", "回答")
+	testsupport.CreateMessage(t, server.DB, conversation, "request-2", "user", "另一个问题")
+	otherAgent := testsupport.CreateChatAgent(t, server.DB)
+
+	tests := []struct {
+		name          string
+		serviceUserID string
+		header        http.Header
+		wantStatus    int
+	}{
+		{name: "owner", serviceUserID: "user-a", header: agent.Header(), wantStatus: http.StatusOK},
+		{name: "missing service_user_id", header: agent.Header(), wantStatus: http.StatusBadRequest},
+		{name: "other service user", serviceUserID: "user-b", header: agent.Header(), wantStatus: http.StatusForbidden},
+		{name: "other chat agent", serviceUserID: "user-a", header: otherAgent.Header(), wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"conversation_id": {conversation.ID.String()}, "request_id": {"request-1"}}
+			if tt.serviceUserID != "" {
+				query.Set("service_user_id", tt.serviceUserID)
+			}
+			recorder := server.Do(t, http.MethodGet, "/api/v1/chat/message-by-request-id?"+query.Encode(), nil, tt.header)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response dto.GetChatMessageListResponse
+			testsupport.DecodeJSON(t, recorder, &response)
+			var contents []string
+			for _, message := range response.Messages {
+				contents = append(contents, *message.Content)
+			}
+			if want := []string{"问题", "回答"}; !slices.Equal(contents, want) {
+				t.Errorf("messages = %v, want %v", contents, want)
+			}
+		})
+	}
+}
+
+func TestSendMessageDuplicateRequestID(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	conversation := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "会话")
+
+	// 另一个请求已登记请求ID但尚未保存消息
+	claim := &models.ChatAgentMessageRequest{
+		ApplicationID:  conversation.ApplicationID,
+		ChatAgentID:    conversation.ChatAgentID,
+		ConversationID: conversation.ID,
+		RequestID:      "claimed",
+	}
+	if err := server.DB.Create(claim).Error; err != nil {
+		t.Fatalf("登记请求ID失败: %v", err)
+	}
+
+	send := func(requestID string) int {
+		conversationID := conversation.ID.String()
+		answer := "固定答案"
+		body := dto.ChatUserSendMessageRequest{
+			ServiceUserID:    "user-a",
+			UserMessage:      "你好",
+			PredefinedAnswer: &answer,
+			ConversationID:   &conversationID,
+			RequestID:        &requestID,
+		}
+		return server.Do(t, http.MethodPost, "/api/v1/chat/send-message-predefined", body, agent.Header()).Code
+	}
+
+	if status := send("claimed"); status != http.StatusConflict {
+		t.Errorf("claimed request_id: status = %d, want %d", status, http.StatusConflict)
+	}
+	if status := send("fresh"); status != http.StatusOK {
+		t.Fatalf("fresh request_id: status = %d, want %d", status, http.StatusOK)
+	}
+	if status := send("fresh"); status != http.StatusConflict {
+		t.Errorf("repeated request_id: status = %d, want %d", status, http.StatusConflict)
+	}
+}
+
+func TestDeleteConversation(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	conversation := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "会话")
+	other := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "另一个会话")
+
+	// 通过发送消息登记请求ID并保存消息
+	for _, target := range []*models.ChatAgentConversation{conversation, other} {
+		conversationID := target.ID.String()
+		answer := "固定答案"
+		requestID := "request-1"
+		body := dto.ChatUserSendMessageRequest{
+			ServiceUserID:    "user-a",
+			UserMessage:      "你好",
+			PredefinedAnswer: &answer,
+			ConversationID:   &conversationID,
+			RequestID:        &requestID,
+		}
+		if recorder := server.Do(t, http.MethodPost, "/api/v1/chat/send-message-predefined", body, agent.Header()); recorder.Code != http.StatusOK {
+			t.Fatalf("send message: status = %d, body: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+
+	query := url.Values{"conversation_id": {conversation.ID.String()}, "service_user_id": {"user-a"}}
+	recorder := server.Do(t, http.MethodDelete, "/api/v1/chat/conversation?"+query.Encode(), nil, agent.Header())
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var response dto.DeleteConversationResponse
+	testsupport.DecodeJSON(t, recorder, &response)
+	if !response.Success {
+		t.Fatalf("DeleteConversation() = %+v, want success", response)
+	}
+
+	count := func(model any, conversationID any) int64 {
+		var n int64
+		if err := server.DB.Model(model).Where("conversation_id = ?", conversationID).Count(&n).Error; err != nil {
+			t.Fatalf("统计 %T 失败: %v", model, err)
+		}
+		return n
+	}
+	if n := count(&models.ChatAgentMessageRequest{}, conversation.ID); n != 0 {
+		t.Errorf("deleted conversation has %d request id claims, want 0", n)
+	}
+	if n := count(&models.ChatAgentMessage{}, conversation.ID); n != 0 {
+		t.Errorf("deleted conversation has %d messages, want 0", n)
+	}
+	if n := count(&models.ChatAgentMessageRequest{}, other.ID); n != 1 {
+		t.Errorf("other conversation has %d request id claims, want 1", n)
+	}
+}
+
+func TestStreamResume(t *testing.T) {
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	conversation := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "会话")
+	other := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "另一个会话")
+
+	conversationID := conversation.ID.String()
+	answer := "固定答案"
+	requestID := "request-1"
+	body := dto.ChatUserSendMessageRequest{
+		ServiceUserID:    "user-a",
+		UserMessage:      "你好",
+		PredefinedAnswer: &answer,
+		ConversationID:   &conversationID,
+		RequestID:        &requestID,
+	}
+	if recorder := server.Do(t, http.MethodPost, "/api/v1/chat/send-message-predefined-streamable", body, agent.Header()); recorder.Code != http.StatusOK {
+		t.Fatalf("send message: status = %d, body: %s", recorder.Code, recorder.Body.String())
+	}
+
+	tests := []struct {
+		name           string
+		conversationID string
+		serviceUserID  string
+		wantStatus     int
+	}{
+		{name: "owner", conversationID: conversationID, serviceUserID: "user-a", wantStatus: http.StatusOK},
+		{name: "missing conversation_id", serviceUserID: "user-a", wantStatus: http.StatusBadRequest},
+		{name: "missing service_user_id", conversationID: conversationID, wantStatus: http.StatusBadRequest},
+		{name: "other service user", conversationID: conversationID, serviceUserID: "user-b", wantStatus: http.StatusForbidden},
+		{name: "other conversation", conversationID: other.ID.String(), serviceUserID: "user-a", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"request_id": {requestID}}
+			if tt.conversationID != "" {
+				query.Set("conversation_id", tt.conversationID)
+			}
+			if tt.serviceUserID != "" {
+				query.Set("service_user_id", tt.serviceUserID)
+			}
+			recorder := server.Do(t, http.MethodGet, "/api/v1/chat/stream-resume?"+query.Encode(), nil, agent.Header())
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && !strings.Contains(recorder.Body.String(), answer) {
+				t.Errorf("resumed stream = %q, want it to contain %q", recorder.Body.String(), answer)
+			}
+		})
+	}
+}
+
+func TestWidgetTokenCSRF(t *testing.T) {
+	const origin = "https://www.example.com"
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	if err := server.DB.Model(agent.ChatAgent).Update("allowed_origins", `["`+origin+`"]`).Error; err != nil {
+		t.Fatalf("设置允许的来源失败: %v", err)
+	}
+
+	// 业务服务端使用 API Key 换取窗口令牌，之后的请求都不携带 Cookie
+	createWidgetToken := func() string {
+		recorder := server.Do(t, http.MethodPost, "/api/v1/chat/widget-token", dto.ChatWidgetTokenRequest{ServiceUserID: "user-a", Origin: origin}, agent.Header())
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("create widget token: status = %d, body: %s", recorder.Code, recorder.Body.String())
+		}
+		var response dto.ChatWidgetTokenResponse
+		testsupport.DecodeJSON(t, recorder, &response)
+		return response.WidgetToken
+	}
+	widgetHeader := func(widgetToken, csrfToken string) http.Header {
+		header := http.Header{define.HeaderWidgetToken: {widgetToken}, "Origin": {origin}}
+		if csrfToken != "" {
+			header.Set(define.HeaderCSRFToken, csrfToken)
+		}
+		return header
+	}
+	getCSRFToken := func(widgetToken string) string {
+		recorder := server.Do(t, http.MethodGet, "/api/v1/chat/csrf-token", nil, widgetHeader(widgetToken, ""))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("get csrf token: status = %d, body: %s", recorder.Code, recorder.Body.String())
+		}
+		if cookies := recorder.Result().Cookies(); len(cookies) != 0 {
+			t.Errorf("csrf token response sets cookies: %v", cookies)
+		}
+		var response struct {
+			CSRFToken string `json:"csrf_token"`
+		}
+		testsupport.DecodeJSON(t, recorder, &response)
+		return response.CSRFToken
+	}
+
+	widgetToken := createWidgetToken()
+	csrfToken := getCSRFToken(widgetToken)
+	if again := getCSRFToken(widgetToken); again != csrfToken {
+		t.Errorf("csrf token changed between requests: %q != %q", again, csrfToken)
+	}
+	otherCSRFToken := getCSRFToken(createWidgetToken())
+
+	tests := []struct {
+		name       string
+		csrfToken  string
+		wantStatus int
+	}{
+		{name: "bound token", csrfToken: csrfToken, wantStatus: http.StatusOK},
+		{name: "missing token", wantStatus: http.StatusForbidden},
+		{name: "token of another widget token", csrfToken: otherCSRFToken, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer := "固定答案"
+			body := dto.ChatUserSendMessageRequest{ServiceUserID: "user-a", UserMessage: "你好", PredefinedAnswer: &answer}
+			recorder := server.Do(t, http.MethodPost, "/api/v1/chat/send-message-predefined", body, widgetHeader(widgetToken, tt.csrfToken))
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+		})
+	}
+
+	t.Run("api key does not need csrf token", func(t *testing.T) {
+		recorder := server.Do(t, http.MethodGet, "/api/v1/chat/csrf-token", nil, agent.Header())
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d, body: %s", recorder.Code, http.StatusBadRequest, recorder.Body.String())
+		}
+	})
+}
+
+func TestWidgetTokenServiceUser(t *testing.T) {
+	const origin = "https://www.example.com"
+	server := testsupport.NewServer(t)
+	agent := testsupport.CreateChatAgent(t, server.DB)
+	if err := server.DB.Model(agent.ChatAgent).Update("allowed_origins", `["`+origin+`"]`).Error; err != nil {
+		t.Fatalf("设置允许的来源失败: %v", err)
+	}
+	own := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-a", "自己的会话")
+	others := testsupport.CreateConversation(t, server.DB, agent.ChatAgent, "user-b", "其他用户的会话")
+
+	if recorder := server.Do(t, http.MethodPost, "/api/v1/chat/widget-token", dto.ChatWidgetTokenRequest{Origin: origin}, agent.Header()); recorder.Code != http.StatusBadRequest {
+		t.Errorf("create widget token without service_user_id: status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+
+	// 窗口令牌绑定 user-a
+	recorder := server.Do(t, http.MethodPost, "/api/v1/chat/widget-token", dto.ChatWidgetTokenRequest{ServiceUserID: "user-a", Origin: origin}, agent.Header())
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("create widget token: status = %d, body: %s", recorder.Code, recorder.Body.String())
+	}
+	var tokenResponse dto.ChatWidgetTokenResponse
+	testsupport.DecodeJSON(t, recorder, &tokenResponse)
+	header := http.Header{define.HeaderWidgetToken: {tokenResponse.WidgetToken}, "Origin": {origin}}
+	recorder = server.Do(t, http.MethodGet, "/api/v1/chat/csrf-token", nil, header)
+	var csrfResponse struct {
+		CSRFToken string `json:"csrf_token"`
+	}
+	testsupport.DecodeJSON(t, recorder, &csrfResponse)
+	header.Set(define.HeaderCSRFToken, csrfResponse.CSRFToken)
+
+	query := func(conversation *models.ChatAgentConversation, serviceUserID string) string {
+		values := url.Values{"conversation_id": {conversation.ID.String()}}
+		if serviceUserID != "" {
+			values.Set("service_user_id", serviceUserID)
+		}
+		return values.Encode()
+	}
+	answer := "固定答案"
+	othersID := others.ID.String()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       any
+		wantStatus int
+	}{
+		{name: "list own conversations", method: http.MethodGet, path: "/api/v1/chat/conversation-list", wantStatus: http.StatusOK},
+		{name: "list other user's conversations", method: http.MethodGet, path: "/api/v1/chat/conversation-list?service_user_id=user-b", wantStatus: http.StatusForbidden},
+		{name: "get own conversation", method: http.MethodGet, path: "/api/v1/chat/conversation?" + query(own, ""), wantStatus: http.StatusOK},
+		{name: "get other user's conversation", method: http.MethodGet, path: "/api/v1/chat/conversation?" + query(others, ""), wantStatus: http.StatusForbidden},
+		{name: "get as other user", method: http.MethodGet, path: "/api/v1/chat/conversation?" + query(others, "user-b"), wantStatus: http.StatusForbidden},
+		{name: "delete as other user", method: http.MethodDelete, path: "/api/v1/chat/conversation?" + query(others, "user-b"), wantStatus: http.StatusForbidden},
+		{name: "rename as other user", method: http.MethodPut, path: "/api/v1/chat/conversation-title?new_title=x&" + query(others, "user-b"), wantStatus: http.StatusForbidden},
+		{name: "variables as other user", method: http.MethodGet, path: "/api/v1/chat/conversation-variables?" + query(others, "user-b"), wantStatus: http.StatusForbidden},
+		{name: "stream resume as other user", method: http.MethodGet, path: "/api/v1/chat/stream-resume?request_id=r&" + query(others, "user-b"), wantStatus: http.StatusForbidden},
+		{name: "download as other user", method: http.MethodGet, path: "/api/v1/chat/attachment-download?attachment_id=" + othersID + "&service_user_id=user-b", wantStatus: http.StatusForbidden},
+		{
+			name:       "send as other user",
+			method:     http.MethodPost,
+			path:       "/api/v1/chat/send-message-predefined",
+			body:       dto.ChatUserSendMessageRequest{ServiceUserID: "user-b", UserMessage: "你好", PredefinedAnswer: &answer},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "send without service user",
+			method:     http.MethodPost,
+			path:       "/api/v1/chat/send-message-predefined",
+			body:       dto.ChatUserSendMessageRequest{UserMessage: "你好", PredefinedAnswer: &answer},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := server.Do(t, tt.method, tt.path, tt.body, header)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+		})
+	}
+
+	// 未传入业务侧用户时使用令牌绑定的用户，新会话属于 user-a
+	var count int64
+	if err := server.DB.Model(&models.ChatAgentConversation{}).Where("service_user_id = ?", "user-a").Count(&count).Error; err != nil {
+		t.Fatalf("统计会话失败: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("user-a has %d conversations, want 2", count)
+	}
+}
@@ -3,15 +3,18 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/define"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -21,48 +24,77 @@ import (
 // 处理 智能体 相关的所有 HTTP 请求
 // 相当于 Java Spring Boot 中的 Controller
 type ChatAgentHandler struct {
-	chatAgentService service.ChatAgentService // 智能体 业务逻辑层接口
+	chatAgentService       service.ChatAgentService          // 智能体 业务逻辑层接口
+	workspaceUploadService service.WorkspaceUploadService    // 工作区上传文件 业务逻辑层接口
+	toolUsageService       service.ChatAgentToolUsageService // 工具使用统计 业务逻辑层接口
+	apiKeyService          service.ChatAgentApiKeyService    // API Key 业务逻辑层接口
 }
 
 // NewChatAgentHandler 创建 智能体 Handler 实例
 // 返回 ChatAgentHandler 的实例
-// 参数：chatAgentService - 智能体 业务逻辑层接口
-func NewChatAgentHandler(chatAgentService service.ChatAgentService) *ChatAgentHandler {
+// 参数：chatAgentService - 智能体 业务逻辑层接口，workspaceUploadService - 工作区上传文件 业务逻辑层接口，toolUsageService - 工具使用统计 业务逻辑层接口，
+// apiKeyService - API Key 业务逻辑层接口
+func NewChatAgentHandler(chatAgentService service.ChatAgentService, workspaceUploadService service.WorkspaceUploadService,
+	toolUsageService service.ChatAgentToolUsageService, apiKeyService service.ChatAgentApiKeyService) *ChatAgentHandler {
 	return &ChatAgentHandler{
-		chatAgentService: chatAgentService,
+		chatAgentService:       chatAgentService,
+		workspaceUploadService: workspaceUploadService,
+		toolUsageService:       toolUsageService,
+		apiKeyService:          apiKeyService,
 	}
 }
 
 // SaveChatAgent 保存智能体信息
 // 处理 POST /api/v1/chat-agents/save 请求
 // 如果智能体存在则更新，不存在则创建
+// 更新时可通过请求体的 version 或 If-Match 请求头提供数据版本号，版本不一致时返回 409
+// 更新时可通过 update_fields 指定只修改的字段，未列出的字段保持不变
 func (h *ChatAgentHandler) SaveChatAgent(c *gin.Context) {
 	// 绑定 JSON 请求体到 SaveChatAgentRequest 结构体
 	var saveRequest dto.SaveChatAgentRequest
 	if err := c.ShouldBindJSON(&saveRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	agent := converter.SaveChatAgentRequestToChatAgentModel(&saveRequest)
+	agent, err := converter.SaveChatAgentRequestToChatAgentModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	updateFields, err := converter.SaveChatAgentRequestToUpdateFields(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := resolveExpectedVersion(c, agent.Version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	agent.Version = version
 
 	// 调用业务逻辑层保存智能体
-	if err := h.chatAgentService.SaveChatAgent(c.Request.Context(), agent); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.chatAgentService.SaveChatAgent(c.Request.Context(), agent, updateFields); err != nil {
+		c.Error(err)
 		return
 	}
 
+	setVersionETag(c, agent.Version)
+
 	// 转换为DTO返回
 	agentDto := converter.ChatAgentModelToChatAgentDto(agent)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"chat_agent": agentDto,
 	})
 }
 
 // DeleteChatAgent 删除智能体
 // 处理 DELETE /api/v1/chat-agents/:id 请求
-// 根据ID删除指定的智能体记录
+// 同时删除智能体的会话、消息、附件、MCP工具设置、API Key、回答规则、工具调用记录、会话变量、
+// 网页挂件令牌、流式回复、会话事件、会话归档、批量推理任务、评测集和评测运行
+// 查询参数 dry_run=true 时只返回将要删除的数据数量，不做任何修改
 func (h *ChatAgentHandler) DeleteChatAgent(c *gin.Context) {
 	// 从 URL 参数中获取 ID
 	idStr := c.Param("id")
@@ -70,18 +102,173 @@ func (h *ChatAgentHandler) DeleteChatAgent(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "dry_run 参数格式错误"))
+			return
+		}
+	}
+
 	// 调用业务逻辑层删除智能体
-	if err := h.chatAgentService.DeleteChatAgent(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	deletion, err := h.chatAgentService.DeleteChatAgent(c.Request.Context(), id, dryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 返回删除结果
+	message := "智能体删除成功"
+	if dryRun {
+		message = "试运行完成，未删除任何数据"
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": message, "deletion": deletion})
+}
+
+// GetChatAgentByID 根据ID获取智能体
+// 处理 GET /api/v1/chat-agents/:chatAgentID 请求
+// 以数据版本号设置 ETag 响应头，客户端保存时可通过 If-Match 带回
+func (h *ChatAgentHandler) GetChatAgentByID(c *gin.Context) {
+	// 从 URL 参数中获取 ID
+	id, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	// 调用业务逻辑层获取智能体
+	agent, err := h.chatAgentService.GetChatAgentByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 转换为DTO返回
+	setVersionETag(c, agent.Version)
+	agentDto := converter.ChatAgentModelToChatAgentDto(agent)
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"chat_agent": agentDto,
+	})
+}
+
+// GetChatAgentToolUsage 获取智能体的工具使用统计
+// 处理 GET /api/v1/chat-agents/:chatAgentID/tool-usage 请求
+// 查询参数 called_after、called_before 为毫秒时间戳，限定统计的调用时间范围，不传时统计全部调用
+// 返回各工具的调用次数、成功率和平均耗时，已启用但未被调用的工具排在最后
+func (h *ChatAgentHandler) GetChatAgentToolUsage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	var calledAfter, calledBefore *time.Time
+	for name, target := range map[string]**time.Time{
+		"called_after":  &calledAfter,
+		"called_before": &calledBefore,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		milli, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.Error(apperror.Newf(apperror.CodeInvalidArgument, "%s 参数格式错误", name))
+			return
+		}
+		parsed := time.UnixMilli(milli)
+		*target = &parsed
+	}
+
+	usages, err := h.toolUsageService.GetToolUsage(c.Request.Context(), id, calledAfter, calledBefore)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{"tool_usage": converter.ChatAgentToolUsageListToDtoList(usages)})
+}
+
+// GetChatAgentApiKeys 获取智能体的 API Key 列表
+// 处理 GET /api/v1/chat-agents/:chatAgentID/api-keys 请求
+// 返回 API Key 的名称和来源IP网段限制，不返回 Key 和 Secret
+func (h *ChatAgentHandler) GetChatAgentApiKeys(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	apiKeys, err := h.apiKeyService.GetApiKeysByChatAgentID(c.Request.Context(), chatAgentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{"api_keys": converter.ChatAgentApiKeyModelListToDtoList(apiKeys)})
+}
+
+// SaveChatAgentApiKeyIPRules 保存 API Key 的来源IP网段限制
+// 处理 POST /api/v1/chat-agents/:chatAgentID/api-keys/:apiKeyID/ip-rules 请求
+// 请求体中的网段列表整体覆盖现有设置，两个列表都为空时取消限制
+func (h *ChatAgentHandler) SaveChatAgentApiKeyIPRules(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+	apiKeyID, err := uuid.Parse(c.Param("apiKeyID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的API Key UUID格式"))
+		return
+	}
+
+	var request dto.SaveChatAgentApiKeyIPRulesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	apiKey, err := h.apiKeyService.SaveApiKeyIPRules(c.Request.Context(), chatAgentID, apiKeyID, request.AllowedCidrs, request.DeniedCidrs)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{"api_key": converter.ChatAgentApiKeyModelToDto(apiKey)})
+}
+
+// GetChatAgentApiKeyRejections 获取智能体的 API Key 访问拒绝记录
+// 处理 GET /api/v1/chat-agents/:chatAgentID/api-key-rejections 请求
+// 按访问时间倒序返回来源IP不满足网段限制的访问，支持分页
+func (h *ChatAgentHandler) GetChatAgentApiKeyRejections(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
-	// 返回删除成功的响应
-	c.JSON(http.StatusOK, gin.H{"message": "智能体删除成功"})
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	rejections, total, err := h.apiKeyService.GetRejectionsByChatAgentID(c.Request.Context(), chatAgentID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"rejections": converter.ChatAgentApiKeyRejectionListToDtoList(rejections),
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+	})
 }
 
 // GetChatAgentsByApplicationID 根据应用ID获取智能体列表
@@ -94,7 +281,7 @@ func (h *ChatAgentHandler) GetChatAgentsByApplicationID(c *gin.Context) {
 	// 解析 UUID 格式的应用 ID
 	applicationID, err := uuid.Parse(applicationIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
 		return
 	}
 
@@ -115,13 +302,13 @@ func (h *ChatAgentHandler) GetChatAgentsByApplicationID(c *gin.Context) {
 	// 调用业务逻辑层获取指定应用下的智能体
 	agents, total, err := h.chatAgentService.GetChatAgentsByApplicationID(c.Request.Context(), applicationID, page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	agentDtos := converter.ChatAgentModelListToChatAgentDtoList(agents)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"chat_agents": agentDtos,
 		"total":       total,
 		"page":        page,
@@ -136,46 +323,35 @@ func (h *ChatAgentHandler) UploadChatAgentAvatar(c *gin.Context) {
 	// 获取上传的文件
 	file, err := c.FormFile("avatar")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请选择要上传的图片文件"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "请选择要上传的图片文件").WithCause(err))
 		return
 	}
 
 	// 验证文件类型
 	contentType := file.Header.Get("Content-Type")
 	if !strings.HasPrefix(contentType, "image/") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "只支持图片文件上传"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "只支持图片文件上传"))
 		return
 	}
 
 	// 验证文件大小（限制为 5MB）
 	if file.Size > 5*1024*1024 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "图片文件大小不能超过 5MB"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "图片文件大小不能超过 5MB"))
 		return
 	}
 
-	// 生成临时文件名
-	tempID := uuid.New()
-
-	// 确定文件扩展名
-	var ext string
+	// 验证图片格式
 	switch contentType {
-	case "image/jpeg", "image/jpg":
-		ext = ".jpg"
-	case "image/png":
-		ext = ".png"
-	case "image/gif":
-		ext = ".gif"
-	case "image/webp":
-		ext = ".webp"
+	case "image/jpeg", "image/jpg", "image/png", "image/gif", "image/webp":
 	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "不支持的图片格式"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "不支持的图片格式"))
 		return
 	}
 
 	// 获取工作区路径
 	workspacePath := os.Getenv("WORKSPACE_PUBLIC_PATH")
 	if workspacePath == "" {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "环境变量 WORKSPACE_PUBLIC_PATH 未设置"})
+		c.Error(apperror.New(apperror.CodeInternal, "环境变量 WORKSPACE_PUBLIC_PATH 未设置"))
 		return
 	}
 
@@ -184,28 +360,40 @@ func (h *ChatAgentHandler) UploadChatAgentAvatar(c *gin.Context) {
 
 	// 确保目录存在
 	if err := os.MkdirAll(saveDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建目录失败"})
+		c.Error(apperror.New(apperror.CodeInternal, "创建目录失败"))
 		return
 	}
 
-	// 构建文件路径
-	fileName := tempID.String() + ext
-	filePath := filepath.Join(saveDir, fileName)
+	// 打开上传的文件
+	src, err := file.Open()
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInternal, "打开文件失败"))
+		return
+	}
+	defer src.Close()
+
+	// 转换为 WebP 保存，并生成标准尺寸缩略图
+	fileName, err := utils.SaveImageAsWebp(src, saveDir, uuid.New().String())
+	if err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "保存图片失败", err))
+		return
+	}
 
-	// 保存文件
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "保存文件失败"})
+	// 记录待确认的上传文件，未被智能体引用时由定时任务清理
+	filePath := define.WorkspaceDirNameChatAgentAvatar + fileName
+	if err := h.workspaceUploadService.TrackUpload(c.Request.Context(), filePath); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInternal, "记录上传文件失败", err))
 		return
 	}
 
 	// 返回文件信息
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"message": "头像上传成功",
 		"data": gin.H{
 			"file_name": fileName,
-			"file_path": define.WorkspaceDirNameChatAgentAvatar + fileName,
+			"file_path": filePath,
 			"file_size": file.Size,
-			"mime_type": contentType,
+			"mime_type": "image/webp",
 		},
 	})
 }
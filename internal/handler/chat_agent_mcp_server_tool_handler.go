@@ -5,6 +5,7 @@ package handler
 import (
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -41,7 +42,7 @@ func (h *ChatAgentMcpServerToolHandler) SaveChatAgentMcpServerToolSettings(c *gi
 	chatAgentIDStr := c.Param("chatAgentID")
 	chatAgentID, err := uuid.Parse(chatAgentIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.SaveChatAgentMcpServerToolSettingsResponse{
+		utils.JsonResponse(c, http.StatusBadRequest, dto.SaveChatAgentMcpServerToolSettingsResponse{
 			Success: false,
 			Message: "无效的聊天智能体ID",
 		})
@@ -51,7 +52,7 @@ func (h *ChatAgentMcpServerToolHandler) SaveChatAgentMcpServerToolSettings(c *gi
 	// 从请求体获取工具设置
 	var req dto.SaveChatAgentMcpServerToolSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.SaveChatAgentMcpServerToolSettingsResponse{
+		utils.JsonResponse(c, http.StatusBadRequest, dto.SaveChatAgentMcpServerToolSettingsResponse{
 			Success: false,
 			Message: "请求参数错误: " + err.Error(),
 		})
@@ -61,14 +62,14 @@ func (h *ChatAgentMcpServerToolHandler) SaveChatAgentMcpServerToolSettings(c *gi
 	// 调用业务逻辑层
 	err = h.chatAgentMcpServerToolService.SaveChatAgentMcpServerToolSettings(c.Request.Context(), chatAgentID, req.ToolSettings)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.SaveChatAgentMcpServerToolSettingsResponse{
+		utils.JsonResponse(c, http.StatusInternalServerError, dto.SaveChatAgentMcpServerToolSettingsResponse{
 			Success: false,
 			Message: "保存工具设置失败: " + err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SaveChatAgentMcpServerToolSettingsResponse{
+	utils.JsonResponse(c, http.StatusOK, dto.SaveChatAgentMcpServerToolSettingsResponse{
 		Success: true,
 		Message: "保存成功",
 	})
@@ -88,7 +89,7 @@ func (h *ChatAgentMcpServerToolHandler) SaveChatAgentMcpServerToolSettings(c *gi
 func (h *ChatAgentMcpServerToolHandler) GetChatAgentMcpServerToolSettings(c *gin.Context) {
 	chatAgentIDStr := c.Param("chatAgentID")
 	if chatAgentIDStr == "" {
-		c.JSON(http.StatusBadRequest, dto.GetChatAgentMcpServerToolSettingsResponse{
+		utils.JsonResponse(c, http.StatusBadRequest, dto.GetChatAgentMcpServerToolSettingsResponse{
 			Success: false,
 			Message: "聊天智能体ID不能为空",
 		})
@@ -98,7 +99,7 @@ func (h *ChatAgentMcpServerToolHandler) GetChatAgentMcpServerToolSettings(c *gin
 	// 验证聊天智能体ID
 	chatAgentID, err := uuid.Parse(chatAgentIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.GetChatAgentMcpServerToolSettingsResponse{
+		utils.JsonResponse(c, http.StatusBadRequest, dto.GetChatAgentMcpServerToolSettingsResponse{
 			Success: false,
 			Message: "无效的聊天智能体ID",
 		})
@@ -108,14 +109,14 @@ func (h *ChatAgentMcpServerToolHandler) GetChatAgentMcpServerToolSettings(c *gin
 	// 调用业务逻辑层
 	settings, err := h.chatAgentMcpServerToolService.GetChatAgentMcpServerToolSettings(c.Request.Context(), chatAgentID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.GetChatAgentMcpServerToolSettingsResponse{
+		utils.JsonResponse(c, http.StatusInternalServerError, dto.GetChatAgentMcpServerToolSettingsResponse{
 			Success: false,
 			Message: "获取工具设置失败: " + err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.GetChatAgentMcpServerToolSettingsResponse{
+	utils.JsonResponse(c, http.StatusOK, dto.GetChatAgentMcpServerToolSettingsResponse{
 		Success: true,
 		Data:    settings,
 		Message: "获取成功",
@@ -136,7 +137,7 @@ func (h *ChatAgentMcpServerToolHandler) GetChatAgentMcpServerToolSettings(c *gin
 func (h *ChatAgentMcpServerToolHandler) GetChatAgentAvailableMcpServerTools(c *gin.Context) {
 	chatAgentIDStr := c.Param("chatAgentID")
 	if chatAgentIDStr == "" {
-		c.JSON(http.StatusBadRequest, dto.GetChatAgentAvailableMcpServerToolsResponse{
+		utils.JsonResponse(c, http.StatusBadRequest, dto.GetChatAgentAvailableMcpServerToolsResponse{
 			Success: false,
 			Message: "聊天智能体ID不能为空",
 		})
@@ -146,7 +147,7 @@ func (h *ChatAgentMcpServerToolHandler) GetChatAgentAvailableMcpServerTools(c *g
 	// 验证聊天智能体ID
 	chatAgentID, err := uuid.Parse(chatAgentIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.GetChatAgentAvailableMcpServerToolsResponse{
+		utils.JsonResponse(c, http.StatusBadRequest, dto.GetChatAgentAvailableMcpServerToolsResponse{
 			Success: false,
 			Message: "无效的聊天智能体ID",
 		})
@@ -156,14 +157,14 @@ func (h *ChatAgentMcpServerToolHandler) GetChatAgentAvailableMcpServerTools(c *g
 	// 调用业务逻辑层
 	tools, err := h.chatAgentMcpServerToolService.GetChatAgentAvailableMcpServerTools(c.Request.Context(), chatAgentID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.GetChatAgentAvailableMcpServerToolsResponse{
+		utils.JsonResponse(c, http.StatusInternalServerError, dto.GetChatAgentAvailableMcpServerToolsResponse{
 			Success: false,
 			Message: "获取可用工具失败: " + err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.GetChatAgentAvailableMcpServerToolsResponse{
+	utils.JsonResponse(c, http.StatusOK, dto.GetChatAgentAvailableMcpServerToolsResponse{
 		Success: true,
 		Data:    tools,
 		Message: "获取成功",
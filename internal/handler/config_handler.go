@@ -0,0 +1,39 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigHandler 服务配置控制器
+// 处理 服务配置 相关的所有 HTTP 请求
+type ConfigHandler struct {
+	configReloadService service.ConfigReloadService // 配置重新加载业务逻辑层接口
+}
+
+// NewConfigHandler 创建 服务配置 Handler 实例
+// 参数：configReloadService - 配置重新加载业务逻辑层接口
+func NewConfigHandler(configReloadService service.ConfigReloadService) *ConfigHandler {
+	return &ConfigHandler{
+		configReloadService: configReloadService,
+	}
+}
+
+// ReloadConfig 重新加载配置
+// 处理 POST /api/v1/config/reload 请求
+// 与向服务进程发送 SIGHUP 信号效果相同，返回重新加载后生效的配置
+func (h *ConfigHandler) ReloadConfig(c *gin.Context) {
+	result, err := h.configReloadService.ReloadConfig(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"config": result,
+	})
+}
@@ -0,0 +1,99 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ConversationArchiveHandler 会话归档控制器
+// 处理 会话归档 相关的所有 HTTP 请求
+type ConversationArchiveHandler struct {
+	archiveService service.ConversationArchiveService // 会话归档业务逻辑层接口
+}
+
+// NewConversationArchiveHandler 创建 会话归档 Handler 实例
+// 参数：archiveService - 会话归档业务逻辑层接口
+func NewConversationArchiveHandler(archiveService service.ConversationArchiveService) *ConversationArchiveHandler {
+	return &ConversationArchiveHandler{
+		archiveService: archiveService,
+	}
+}
+
+// GetArchivesByChatAgentID 获取智能体的会话归档记录
+// 处理 GET /api/v1/conversation-archives/chat-agent/:chatAgentId 请求
+// 按最近一次归档时间倒序返回，支持按 service_user_id、status 筛选和分页
+func (h *ConversationArchiveHandler) GetArchivesByChatAgentID(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	archives, total, err := h.archiveService.GetArchives(c.Request.Context(), chatAgentID, c.Query("service_user_id"), c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"archives":  converter.ConversationArchiveListToDtoList(archives),
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// GetArchive 获取会话归档记录详情
+// 处理 GET /api/v1/conversation-archives/:id 请求
+func (h *ConversationArchiveHandler) GetArchive(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	archive, err := h.archiveService.GetArchive(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"archive": converter.ConversationArchiveModelToDto(archive),
+	})
+}
+
+// RestoreArchive 从冷存储恢复归档的会话
+// 处理 POST /api/v1/conversation-archives/:id/restore 请求
+func (h *ConversationArchiveHandler) RestoreArchive(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	archive, err := h.archiveService.RestoreArchive(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"archive": converter.ConversationArchiveModelToDto(archive),
+	})
+}
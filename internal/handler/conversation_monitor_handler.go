@@ -0,0 +1,82 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// monitorHeartbeatInterval 监控流心跳间隔，避免代理因连接空闲而断开
+const monitorHeartbeatInterval = 15 * time.Second
+
+// ConversationMonitorHandler 会话监控 控制器
+// 处理管理后台实时监控智能体会话的请求
+type ConversationMonitorHandler struct {
+	monitorService   service.ConversationMonitorService // 会话监控 业务逻辑层接口
+	chatAgentService service.ChatAgentService           // 智能体 业务逻辑层接口
+}
+
+// NewConversationMonitorHandler 创建 会话监控 Handler 实例
+// 返回 ConversationMonitorHandler 的实例
+// 参数：monitorService - 会话监控 业务逻辑层接口，chatAgentService - 智能体 业务逻辑层接口
+func NewConversationMonitorHandler(monitorService service.ConversationMonitorService, chatAgentService service.ChatAgentService) *ConversationMonitorHandler {
+	return &ConversationMonitorHandler{
+		monitorService:   monitorService,
+		chatAgentService: chatAgentService,
+	}
+}
+
+// StreamConversationEvents 实时推送智能体的会话事件
+// 处理 GET /api/v1/chat-agents/:chatAgentID/conversations/events 请求
+// 以 SSE 格式推送新建会话、消息、工具调用和错误事件，直到客户端断开
+func (h *ConversationMonitorHandler) StreamConversationEvents(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentID"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+	if _, err := h.chatAgentService.GetChatAgentByID(c.Request.Context(), chatAgentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	events, unsubscribe := h.monitorService.Subscribe(chatAgentID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(monitorHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// 设置响应头
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			return true
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			eventJSON, err := json.Marshal(event)
+			if err != nil {
+				return true
+			}
+			fmt.Fprintf(w, "data: %s\n\n", eventJSON)
+			return true
+		}
+	})
+}
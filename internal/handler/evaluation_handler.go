@@ -0,0 +1,330 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EvaluationHandler 评测 控制器
+// 处理 评测集、评测用例和评测运行 相关的所有 HTTP 请求
+type EvaluationHandler struct {
+	evaluationService service.EvaluationService // 评测 业务逻辑层接口
+}
+
+// NewEvaluationHandler 创建 评测 Handler 实例
+// 参数：evaluationService - 评测 业务逻辑层接口
+func NewEvaluationHandler(evaluationService service.EvaluationService) *EvaluationHandler {
+	return &EvaluationHandler{
+		evaluationService: evaluationService,
+	}
+}
+
+// SaveSet 保存评测集
+// 处理 POST /api/v1/evaluation-sets/save 请求
+func (h *EvaluationHandler) SaveSet(c *gin.Context) {
+	var saveRequest dto.SaveEvaluationSetRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	set, err := converter.SaveEvaluationSetRequestToEvaluationSetModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.evaluationService.SaveSet(c.Request.Context(), set); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"set": converter.EvaluationSetModelToEvaluationSetDto(set),
+	})
+}
+
+// DeleteSet 删除评测集
+// 处理 DELETE /api/v1/evaluation-sets/:id 请求
+func (h *EvaluationHandler) DeleteSet(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.evaluationService.DeleteSet(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "删除成功",
+	})
+}
+
+// GetSet 获取评测集
+// 处理 GET /api/v1/evaluation-sets/:id 请求
+func (h *EvaluationHandler) GetSet(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	set, err := h.evaluationService.GetSet(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"set": converter.EvaluationSetModelToEvaluationSetDto(set),
+	})
+}
+
+// GetSetsByChatAgentID 获取智能体的全部评测集
+// 处理 GET /api/v1/evaluation-sets/chat-agent/:chatAgentId 请求
+func (h *EvaluationHandler) GetSetsByChatAgentID(c *gin.Context) {
+	chatAgentID, err := uuid.Parse(c.Param("chatAgentId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的智能体UUID格式"))
+		return
+	}
+
+	sets, err := h.evaluationService.GetSetsByChatAgentID(c.Request.Context(), chatAgentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"sets": converter.EvaluationSetModelListToEvaluationSetDtoList(sets),
+	})
+}
+
+// SaveCase 保存评测用例
+// 处理 POST /api/v1/evaluation-cases/save 请求
+func (h *EvaluationHandler) SaveCase(c *gin.Context) {
+	var saveRequest dto.SaveEvaluationCaseRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	evaluationCase, err := converter.SaveEvaluationCaseRequestToEvaluationCaseModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.evaluationService.SaveCase(c.Request.Context(), evaluationCase); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"case": converter.EvaluationCaseModelToEvaluationCaseDto(evaluationCase),
+	})
+}
+
+// DeleteCase 删除评测用例
+// 处理 DELETE /api/v1/evaluation-cases/:id 请求
+func (h *EvaluationHandler) DeleteCase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.evaluationService.DeleteCase(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "删除成功",
+	})
+}
+
+// GetCasesBySetID 获取评测集的全部用例
+// 处理 GET /api/v1/evaluation-cases/set/:setId 请求
+func (h *EvaluationHandler) GetCasesBySetID(c *gin.Context) {
+	setID, err := uuid.Parse(c.Param("setId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的评测集UUID格式"))
+		return
+	}
+
+	cases, err := h.evaluationService.GetCasesBySetID(c.Request.Context(), setID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"cases": converter.EvaluationCaseModelListToEvaluationCaseDtoList(cases),
+	})
+}
+
+// CreateRun 创建评测运行
+// 处理 POST /api/v1/evaluation-runs 请求
+func (h *EvaluationHandler) CreateRun(c *gin.Context) {
+	var createRequest dto.CreateEvaluationRunRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	run, err := converter.CreateEvaluationRunRequestToEvaluationRunModel(&createRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.evaluationService.CreateRun(c.Request.Context(), run); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"run": converter.EvaluationRunModelToEvaluationRunDto(run),
+	})
+}
+
+// GetRun 获取评测运行
+// 处理 GET /api/v1/evaluation-runs/:id 请求
+func (h *EvaluationHandler) GetRun(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	run, err := h.evaluationService.GetRun(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"run": converter.EvaluationRunModelToEvaluationRunDto(run),
+	})
+}
+
+// GetRunsBySetID 获取评测集的运行列表
+// 处理 GET /api/v1/evaluation-runs/set/:setId 请求
+func (h *EvaluationHandler) GetRunsBySetID(c *gin.Context) {
+	setID, err := uuid.Parse(c.Param("setId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的评测集UUID格式"))
+		return
+	}
+
+	// 获取分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	runs, total, err := h.evaluationService.GetRunsBySetID(c.Request.Context(), setID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.EvaluationRunListResponse{
+		Runs:     converter.EvaluationRunModelListToEvaluationRunDtoList(runs),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// GetRunResults 获取评测运行的结果
+// 处理 GET /api/v1/evaluation-runs/:id/results 请求
+// 可通过 status 参数只返回指定状态的结果
+func (h *EvaluationHandler) GetRunResults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	// 获取分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	results, total, err := h.evaluationService.GetRunResults(c.Request.Context(), id, c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.EvaluationResultListResponse{
+		Results:  converter.EvaluationResultModelListToEvaluationResultDtoList(results),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// CancelRun 取消评测运行
+// 处理 POST /api/v1/evaluation-runs/:id/cancel 请求
+func (h *EvaluationHandler) CancelRun(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	run, err := h.evaluationService.CancelRun(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"run": converter.EvaluationRunModelToEvaluationRunDto(run),
+	})
+}
+
+// CompareRuns 对比两次评测运行
+// 处理 GET /api/v1/evaluation-runs/compare?base_run_id=&target_run_id= 请求
+func (h *EvaluationHandler) CompareRuns(c *gin.Context) {
+	baseRunID, err := uuid.Parse(c.Query("base_run_id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的基准运行UUID格式"))
+		return
+	}
+	targetRunID, err := uuid.Parse(c.Query("target_run_id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的对比运行UUID格式"))
+		return
+	}
+
+	comparison, err := h.evaluationService.CompareRuns(c.Request.Context(), baseRunID, targetRunID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, converter.EvaluationRunComparisonToEvaluationRunComparisonResponse(comparison))
+}
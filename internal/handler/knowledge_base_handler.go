@@ -0,0 +1,254 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// KnowledgeBaseHandler 知识库 控制器
+// 处理 知识库 相关的所有 HTTP 请求
+type KnowledgeBaseHandler struct {
+	knowledgeBaseService service.KnowledgeBaseService // 知识库 业务逻辑层接口
+}
+
+// NewKnowledgeBaseHandler 创建 知识库 Handler 实例
+// 参数：knowledgeBaseService - 知识库 业务逻辑层接口
+func NewKnowledgeBaseHandler(knowledgeBaseService service.KnowledgeBaseService) *KnowledgeBaseHandler {
+	return &KnowledgeBaseHandler{
+		knowledgeBaseService: knowledgeBaseService,
+	}
+}
+
+// SaveKnowledgeBase 保存知识库
+// 处理 POST /api/v1/knowledge-bases/save 请求
+func (h *KnowledgeBaseHandler) SaveKnowledgeBase(c *gin.Context) {
+	var saveRequest dto.SaveKnowledgeBaseRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	knowledgeBase, err := converter.SaveKnowledgeBaseRequestToKnowledgeBaseModel(&saveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.knowledgeBaseService.SaveKnowledgeBase(c.Request.Context(), knowledgeBase); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"knowledge_base": converter.KnowledgeBaseModelToKnowledgeBaseDto(knowledgeBase),
+	})
+}
+
+// DeleteKnowledgeBase 删除知识库
+// 处理 DELETE /api/v1/knowledge-bases/:id 请求
+func (h *KnowledgeBaseHandler) DeleteKnowledgeBase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.knowledgeBaseService.DeleteKnowledgeBase(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "知识库删除成功"})
+}
+
+// GetKnowledgeBasesByApplicationID 获取应用下的知识库列表
+// 处理 GET /api/v1/knowledge-bases/application/:applicationId 请求
+func (h *KnowledgeBaseHandler) GetKnowledgeBasesByApplicationID(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+
+	knowledgeBases, err := h.knowledgeBaseService.GetKnowledgeBasesByApplicationID(c.Request.Context(), applicationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"knowledge_bases": converter.KnowledgeBaseModelListToKnowledgeBaseDtoList(knowledgeBases),
+	})
+}
+
+// ReindexKnowledgeBase 重建知识库全部文档的索引
+// 处理 POST /api/v1/knowledge-bases/:id/reindex 请求
+func (h *KnowledgeBaseHandler) ReindexKnowledgeBase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	documentCount, err := h.knowledgeBaseService.ReindexKnowledgeBase(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{"document_count": documentCount})
+}
+
+// SaveTextDocument 保存文本文档
+// 处理 POST /api/v1/knowledge-documents/text 请求
+// 请求中包含文档ID时替换该文档的内容，否则新增文档
+func (h *KnowledgeBaseHandler) SaveTextDocument(c *gin.Context) {
+	var saveRequest dto.SaveKnowledgeTextDocumentRequest
+	if err := c.ShouldBindJSON(&saveRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+	knowledgeBaseID, err := uuid.Parse(saveRequest.KnowledgeBaseID)
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的知识库UUID格式"))
+		return
+	}
+	documentID := uuid.Nil
+	if saveRequest.DocumentID != nil && *saveRequest.DocumentID != "" {
+		if documentID, err = uuid.Parse(*saveRequest.DocumentID); err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的文档UUID格式"))
+			return
+		}
+	}
+
+	document, err := h.knowledgeBaseService.SaveTextDocument(c.Request.Context(), knowledgeBaseID, documentID, saveRequest.Name, saveRequest.Content)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"document": converter.KnowledgeDocumentModelToKnowledgeDocumentDto(document),
+	})
+}
+
+// UploadDocument 上传文档文件
+// 处理 POST /api/v1/knowledge-documents/upload 请求
+// 表单参数：knowledge_base_id - 知识库ID，document_id - 文档ID（替换已有文档内容时提供），file - 文档文件
+func (h *KnowledgeBaseHandler) UploadDocument(c *gin.Context) {
+	knowledgeBaseID, err := uuid.Parse(c.PostForm("knowledge_base_id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的知识库UUID格式"))
+		return
+	}
+	documentID := uuid.Nil
+	if documentIDStr := c.PostForm("document_id"); documentIDStr != "" {
+		if documentID, err = uuid.Parse(documentIDStr); err != nil {
+			c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的文档UUID格式"))
+			return
+		}
+	}
+
+	// 获取上传的文件
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "请选择要上传的文件").WithCause(err))
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInternal, "打开上传文件失败").WithCause(err))
+		return
+	}
+	defer src.Close()
+
+	document, err := h.knowledgeBaseService.UploadDocument(c.Request.Context(), knowledgeBaseID, documentID, src, file.Filename)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"document": converter.KnowledgeDocumentModelToKnowledgeDocumentDto(document),
+	})
+}
+
+// ReindexDocument 重新处理文档
+// 处理 POST /api/v1/knowledge-documents/:id/reindex 请求
+func (h *KnowledgeBaseHandler) ReindexDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	document, err := h.knowledgeBaseService.ReindexDocument(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"document": converter.KnowledgeDocumentModelToKnowledgeDocumentDto(document),
+	})
+}
+
+// DeleteDocument 删除文档
+// 处理 DELETE /api/v1/knowledge-documents/:id 请求
+func (h *KnowledgeBaseHandler) DeleteDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
+		return
+	}
+
+	if err := h.knowledgeBaseService.DeleteDocument(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "文档删除成功"})
+}
+
+// GetDocumentsByKnowledgeBaseID 获取知识库下的文档列表
+// 处理 GET /api/v1/knowledge-documents/knowledge-base/:knowledgeBaseId 请求
+// 支持 status 参数按处理状态过滤
+func (h *KnowledgeBaseHandler) GetDocumentsByKnowledgeBaseID(c *gin.Context) {
+	knowledgeBaseID, err := uuid.Parse(c.Param("knowledgeBaseId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的知识库UUID格式"))
+		return
+	}
+
+	// 获取分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	documents, total, err := h.knowledgeBaseService.GetDocumentsByKnowledgeBaseID(c.Request.Context(), knowledgeBaseID, c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, dto.KnowledgeDocumentListResponse{
+		Documents: converter.KnowledgeDocumentModelListToKnowledgeDocumentDtoList(documents),
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+	})
+}
@@ -0,0 +1,70 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LlmProviderDefineHandler LlmProviderDefine 控制器
+// 处理供应商定义及内置供应商目录相关的 HTTP 请求
+type LlmProviderDefineHandler struct {
+	llmProviderDefineService service.LlmProviderDefineService // LlmProviderDefine 业务逻辑层接口
+}
+
+// NewLlmProviderDefineHandler 创建 LlmProviderDefine Handler 实例
+// 参数：llmProviderDefineService - LlmProviderDefine 业务逻辑层接口
+func NewLlmProviderDefineHandler(llmProviderDefineService service.LlmProviderDefineService) *LlmProviderDefineHandler {
+	return &LlmProviderDefineHandler{
+		llmProviderDefineService: llmProviderDefineService,
+	}
+}
+
+// GetAllLlmProviderDefines 获取所有供应商定义
+// 处理 GET /api/v1/llm-provider-defines 请求
+func (h *LlmProviderDefineHandler) GetAllLlmProviderDefines(c *gin.Context) {
+	providerDefines, err := h.llmProviderDefineService.GetAllLlmProviderDefines(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"llm_provider_defines": converter.LlmProviderDefineModelListToDtoList(providerDefines),
+	})
+}
+
+// GetLlmProviderDefinePresets 获取内置供应商目录
+// 处理 GET /api/v1/llm-provider-defines/presets 请求
+func (h *LlmProviderDefineHandler) GetLlmProviderDefinePresets(c *gin.Context) {
+	presets := h.llmProviderDefineService.GetLlmProviderDefinePresets()
+	presetDtos := make([]dto.LlmProviderDefineDto, 0, len(presets))
+	for _, preset := range presets {
+		presetDtos = append(presetDtos, converter.LlmProviderDefinePresetToDto(preset))
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"presets": presetDtos,
+	})
+}
+
+// LoadLlmProviderDefinePresets 加载内置供应商目录
+// 处理 POST /api/v1/llm-provider-defines/load-presets 请求
+// 查询参数 overwrite=true 时覆盖同类型的已有定义
+func (h *LlmProviderDefineHandler) LoadLlmProviderDefinePresets(c *gin.Context) {
+	overwrite := c.Query("overwrite") == "true"
+
+	result, err := h.llmProviderDefineService.LoadLlmProviderDefinePresets(c.Request.Context(), overwrite)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, result)
+}
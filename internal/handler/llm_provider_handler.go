@@ -3,10 +3,12 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/define"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -20,15 +22,17 @@ import (
 // 处理 LlmProvider 相关的所有 HTTP 请求
 // 相当于 Java Spring Boot 中的 Controller
 type LlmProviderHandler struct {
-	llmProviderService service.LlmProviderService // LlmProvider 业务逻辑层接口
+	llmProviderService     service.LlmProviderService     // LlmProvider 业务逻辑层接口
+	workspaceUploadService service.WorkspaceUploadService // 工作区上传文件 业务逻辑层接口
 }
 
 // NewLlmProviderHandler 创建 LlmProvider Handler 实例
 // 返回 LlmProviderHandler 的实例
-// 参数：llmProviderService - LlmProvider 业务逻辑层接口
-func NewLlmProviderHandler(llmProviderService service.LlmProviderService) *LlmProviderHandler {
+// 参数：llmProviderService - LlmProvider 业务逻辑层接口，workspaceUploadService - 工作区上传文件 业务逻辑层接口
+func NewLlmProviderHandler(llmProviderService service.LlmProviderService, workspaceUploadService service.WorkspaceUploadService) *LlmProviderHandler {
 	return &LlmProviderHandler{
-		llmProviderService: llmProviderService,
+		llmProviderService:     llmProviderService,
+		workspaceUploadService: workspaceUploadService,
 	}
 }
 
@@ -42,20 +46,21 @@ func (h *LlmProviderHandler) GetLlmProviderByID(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取提供商
 	llmProvider, err := h.llmProviderService.GetLlmProviderByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "LlmProvider not found"})
+		c.Error(apperror.New(apperror.CodeNotFound, "LLM提供商不存在"))
 		return
 	}
 
 	// 转换为DTO返回
+	setVersionETag(c, llmProvider.Version)
 	llmProviderDto := converter.LlmProviderModelToLlmProviderDto(llmProvider)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"llm_provider": llmProviderDto,
 	})
 }
@@ -67,13 +72,13 @@ func (h *LlmProviderHandler) GetAllLlmProviders(c *gin.Context) {
 	// 调用业务逻辑层获取所有提供商
 	llmProviders, err := h.llmProviderService.GetAllLlmProviders(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	llmProviderDtos := converter.LlmProviderModelListToLlmProviderDtoList(llmProviders)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"llm_providers": llmProviderDtos,
 	})
 }
@@ -81,26 +86,45 @@ func (h *LlmProviderHandler) GetAllLlmProviders(c *gin.Context) {
 // SaveLlmProvider 保存大语言模型提供商（upsert）
 // 处理 POST /api/v1/llm-providers/save 请求
 // 如果提供商存在则更新，不存在则创建
+// 更新时可通过请求体的 version 或 If-Match 请求头提供数据版本号，版本不一致时返回 409
+// 更新时可通过 update_fields 指定只修改的字段，未列出的字段保持不变
 func (h *LlmProviderHandler) SaveLlmProvider(c *gin.Context) {
 	// 绑定 JSON 请求体到 LlmProviderSaveDto 结构体
 	var llmProviderSaveDto dto.LlmProviderSaveDto
 	if err := c.ShouldBindJSON(&llmProviderSaveDto); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	llmProvider := converter.LlmProviderSaveDtoToLlmProviderModel(&llmProviderSaveDto)
+	llmProvider, err := converter.LlmProviderSaveDtoToLlmProviderModel(&llmProviderSaveDto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	updateFields, err := converter.LlmProviderSaveDtoToUpdateFields(&llmProviderSaveDto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := resolveExpectedVersion(c, llmProvider.Version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	llmProvider.Version = version
 
 	// 调用业务逻辑层保存提供商
-	if err := h.llmProviderService.SaveLlmProvider(c.Request.Context(), llmProvider); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.llmProviderService.SaveLlmProvider(c.Request.Context(), llmProvider, updateFields); err != nil {
+		c.Error(err)
 		return
 	}
 
+	setVersionETag(c, llmProvider.Version)
+
 	// 转换为DTO返回
 	llmProviderDto := converter.LlmProviderModelToLlmProviderDto(llmProvider)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"llm_provider": llmProviderDto,
 	})
 }
@@ -112,23 +136,27 @@ func (h *LlmProviderHandler) QueryLlmProviders(c *gin.Context) {
 	// 绑定 JSON 请求体到 LlmProviderQueryDto 结构体作为查询条件
 	var queryDto dto.LlmProviderQueryDto
 	if err := c.ShouldBindJSON(&queryDto); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 转换为模型
-	query := converter.LlmProviderQueryDtoToLlmProviderModel(&queryDto)
+	query, err := converter.LlmProviderQueryDtoToLlmProviderModel(&queryDto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// 调用业务逻辑层查询提供商
 	llmProviders, err := h.llmProviderService.QueryLlmProviders(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	llmProviderDtos := converter.LlmProviderModelListToLlmProviderDtoList(llmProviders)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"llm_providers": llmProviderDtos,
 	})
 }
@@ -143,18 +171,18 @@ func (h *LlmProviderHandler) DeleteLlmProvider(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层删除提供商
 	if err := h.llmProviderService.DeleteLlmProvider(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 返回删除成功的响应
-	c.JSON(http.StatusOK, gin.H{"message": "LlmProvider deleted successfully"})
+	utils.JsonResponse(c, http.StatusOK, gin.H{"message": "LlmProvider deleted successfully"})
 }
 
 // GetLlmProvidersByApplicationID 根据应用ID获取大语言模型提供商列表
@@ -167,20 +195,20 @@ func (h *LlmProviderHandler) GetLlmProvidersByApplicationID(c *gin.Context) {
 	// 解析 UUID 格式的应用 ID
 	applicationID, err := uuid.Parse(applicationIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application UUID format"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取指定应用下的提供商
 	llmProviders, err := h.llmProviderService.GetLlmProvidersByApplicationID(c.Request.Context(), applicationID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	llmProviderDtos := converter.LlmProviderModelListToLlmProviderDtoList(llmProviders)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"llm_providers": llmProviderDtos,
 	})
 }
@@ -192,46 +220,35 @@ func (h *LlmProviderHandler) UploadLlmProviderIcon(c *gin.Context) {
 	// 获取上传的文件
 	file, err := c.FormFile("icon")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请选择要上传的图片文件"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "请选择要上传的图片文件").WithCause(err))
 		return
 	}
 
 	// 验证文件类型
 	contentType := file.Header.Get("Content-Type")
 	if !strings.HasPrefix(contentType, "image/") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "只支持图片文件上传"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "只支持图片文件上传"))
 		return
 	}
 
 	// 验证文件大小（限制为 5MB）
 	if file.Size > 5*1024*1024 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "图片文件大小不能超过 5MB"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "图片文件大小不能超过 5MB"))
 		return
 	}
 
-	// 生成临时文件名
-	tempID := uuid.New()
-
-	// 确定文件扩展名
-	var ext string
+	// 验证图片格式
 	switch contentType {
-	case "image/jpeg", "image/jpg":
-		ext = ".jpg"
-	case "image/png":
-		ext = ".png"
-	case "image/gif":
-		ext = ".gif"
-	case "image/webp":
-		ext = ".webp"
+	case "image/jpeg", "image/jpg", "image/png", "image/gif", "image/webp":
 	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "不支持的图片格式"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "不支持的图片格式"))
 		return
 	}
 
 	// 获取工作区路径
 	workspacePath := os.Getenv("WORKSPACE_PUBLIC_PATH")
 	if workspacePath == "" {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "环境变量 WORKSPACE_PUBLIC_PATH 未设置"})
+		c.Error(apperror.New(apperror.CodeInternal, "环境变量 WORKSPACE_PUBLIC_PATH 未设置"))
 		return
 	}
 
@@ -240,28 +257,40 @@ func (h *LlmProviderHandler) UploadLlmProviderIcon(c *gin.Context) {
 
 	// 确保目录存在
 	if err := os.MkdirAll(saveDir, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建目录失败"})
+		c.Error(apperror.New(apperror.CodeInternal, "创建目录失败"))
+		return
+	}
+
+	// 打开上传的文件
+	src, err := file.Open()
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInternal, "打开文件失败"))
 		return
 	}
+	defer src.Close()
 
-	// 构建文件路径
-	fileName := tempID.String() + ext
-	filePath := filepath.Join(saveDir, fileName)
+	// 转换为 WebP 保存，并生成标准尺寸缩略图
+	fileName, err := utils.SaveImageAsWebp(src, saveDir, uuid.New().String())
+	if err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "保存图片失败", err))
+		return
+	}
 
-	// 保存文件
-	if err := c.SaveUploadedFile(file, filePath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "保存文件失败"})
+	// 记录待确认的上传文件，未被提供商引用时由定时任务清理
+	filePath := define.WorkspaceDirNameLlmProviderIcon + fileName
+	if err := h.workspaceUploadService.TrackUpload(c.Request.Context(), filePath); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInternal, "记录上传文件失败", err))
 		return
 	}
 
 	// 返回文件信息
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"message": "图片上传成功",
 		"data": gin.H{
 			"file_name": fileName,
-			"file_path": define.WorkspaceDirNameLlmProviderIcon + fileName,
+			"file_path": filePath,
 			"file_size": file.Size,
-			"mime_type": contentType,
+			"mime_type": "image/webp",
 		},
 	})
 }
@@ -0,0 +1,87 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/service"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandler 监控指标 控制器
+// 以 Prometheus 文本格式输出模型提供商的并发和排队指标
+type MetricsHandler struct {
+	providerLimiter service.LlmProviderLimiter // 模型提供商并发限制器
+	token           string                     // 访问令牌，为空时不校验
+}
+
+// NewMetricsHandler 创建 监控指标 Handler 实例
+// 参数：providerLimiter - 模型提供商并发限制器，config - 应用程序配置
+func NewMetricsHandler(providerLimiter service.LlmProviderLimiter, config *config.Config) *MetricsHandler {
+	return &MetricsHandler{
+		providerLimiter: providerLimiter,
+		token:           config.Metrics.Token,
+	}
+}
+
+// metricFamily 一组同名指标
+type metricFamily struct {
+	name   string
+	help   string
+	kind   string // 指标类型：gauge 或 counter
+	sample func(stats service.LlmProviderLimiterStats) float64
+}
+
+// llmProviderMetricFamilies 模型提供商并发和排队指标
+var llmProviderMetricFamilies = []metricFamily{
+	{"lemon_llm_provider_concurrency_limit", "模型提供商的并发请求上限，0 表示不限制", "gauge",
+		func(stats service.LlmProviderLimiterStats) float64 { return float64(stats.Limit) }},
+	{"lemon_llm_provider_active_requests", "模型提供商正在处理的请求数", "gauge",
+		func(stats service.LlmProviderLimiterStats) float64 { return float64(stats.Active) }},
+	{"lemon_llm_provider_queued_requests", "模型提供商正在排队的请求数", "gauge",
+		func(stats service.LlmProviderLimiterStats) float64 { return float64(stats.Queued) }},
+	{"lemon_llm_provider_queue_waits_total", "模型提供商累计排队的请求数", "counter",
+		func(stats service.LlmProviderLimiterStats) float64 { return float64(stats.WaitsTotal) }},
+	{"lemon_llm_provider_queue_timeouts_total", "模型提供商累计排队超时的请求数", "counter",
+		func(stats service.LlmProviderLimiterStats) float64 { return float64(stats.TimeoutTotal) }},
+}
+
+// Metrics 输出监控指标
+// 处理 GET /metrics 请求
+// 配置了访问令牌时需携带 Authorization: Bearer <令牌>
+func (h *MetricsHandler) Metrics(c *gin.Context) {
+	if h.token != "" {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			c.Error(apperror.New(apperror.CodeUnauthorized, "监控指标访问令牌无效"))
+			return
+		}
+	}
+
+	stats := h.providerLimiter.Stats()
+	var builder strings.Builder
+	for _, family := range llmProviderMetricFamilies {
+		fmt.Fprintf(&builder, "# HELP %s %s\n", family.name, family.help)
+		fmt.Fprintf(&builder, "# TYPE %s %s\n", family.name, family.kind)
+		for _, providerStats := range stats {
+			fmt.Fprintf(&builder, "%s{provider_id=\"%s\",provider_name=\"%s\"} %g\n",
+				family.name, providerStats.ProviderID.String(), escapeMetricLabel(providerStats.ProviderName), family.sample(providerStats))
+		}
+	}
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(builder.String()))
+}
+
+// metricLabelReplacer Prometheus 文本格式标签值的转义规则
+var metricLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeMetricLabel 转义标签值中的反斜杠、双引号和换行
+func escapeMetricLabel(value string) string {
+	return metricLabelReplacer.Replace(value)
+}
@@ -0,0 +1,32 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resolveExpectedVersion 获取保存请求期望的数据版本号
+// If-Match 请求头优先于请求体中的版本号，请求头格式为 "<版本号>"（可带 W/ 前缀）
+// 都未提供时返回0，表示不校验版本号
+func resolveExpectedVersion(c *gin.Context, bodyVersion int64) (int64, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return bodyVersion, nil
+	}
+	value := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, apperror.New(apperror.CodeInvalidArgument, "If-Match 请求头格式无效，应为数据版本号")
+	}
+	return version, nil
+}
+
+// setVersionETag 以数据版本号设置 ETag 响应头，客户端可在保存时通过 If-Match 带回
+func setVersionETag(c *gin.Context, version int64) {
+	c.Header("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
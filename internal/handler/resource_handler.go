@@ -4,9 +4,13 @@ package handler
 
 import (
 	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/utils"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -25,17 +29,18 @@ func NewResourceHandler() *ResourceHandler {
 // DownloadFile 下载文件
 // 处理 GET /api/v1/resources/download 请求
 // 根据子路径下载 WORKSPACE_PUBLIC_PATH 下的文件
+// 图片文件可通过 size 参数获取标准尺寸的 WebP 缩略图
 func (h *ResourceHandler) DownloadFile(c *gin.Context) {
 	// 从查询参数获取子路径
 	subPath := c.Query("path")
 	if subPath == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 path 参数"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "缺少 path 参数"))
 		return
 	}
 
 	// 安全检查：防止路径遍历攻击
 	if strings.Contains(subPath, "..") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的文件路径"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的文件路径"))
 		return
 	}
 
@@ -47,7 +52,7 @@ func (h *ResourceHandler) DownloadFile(c *gin.Context) {
 	// 获取工作区公共路径
 	workspacePublicPath := os.Getenv("WORKSPACE_PUBLIC_PATH")
 	if workspacePublicPath == "" {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "环境变量 WORKSPACE_PUBLIC_PATH 未设置"})
+		c.Error(apperror.New(apperror.CodeInternal, "环境变量 WORKSPACE_PUBLIC_PATH 未设置"))
 		return
 	}
 
@@ -57,18 +62,18 @@ func (h *ResourceHandler) DownloadFile(c *gin.Context) {
 	// 安全检查：确保文件路径在工作区公共目录内
 	absWorkspacePath, err := filepath.Abs(workspacePublicPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法解析工作区路径"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法解析工作区路径"))
 		return
 	}
 
 	absFilePath, err := filepath.Abs(fullPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法解析文件路径"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法解析文件路径"))
 		return
 	}
 
 	if !strings.HasPrefix(absFilePath, absWorkspacePath) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "访问路径超出允许范围"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "访问路径超出允许范围"))
 		return
 	}
 
@@ -76,19 +81,34 @@ func (h *ResourceHandler) DownloadFile(c *gin.Context) {
 	fileInfo, err := os.Stat(fullPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "文件不存在"})
+			c.Error(apperror.New(apperror.CodeNotFound, "文件不存在"))
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "无法访问文件"})
+			c.Error(apperror.New(apperror.CodeInternal, "无法访问文件"))
 		}
 		return
 	}
 
 	// 检查是否为目录
 	if fileInfo.IsDir() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "不能下载目录"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "不能下载目录"))
 		return
 	}
 
+	// 指定尺寸时返回图片缩略图，缩略图不存在时按需生成
+	if sizeParam := c.Query("size"); sizeParam != "" {
+		size, err := strconv.Atoi(sizeParam)
+		if err != nil || !utils.IsValidThumbnailSize(size) {
+			c.Error(apperror.Newf(apperror.CodeInvalidArgument, "不支持的图片尺寸，可选值：%v", define.ImageThumbnailSizes))
+			return
+		}
+		thumbnailPath, err := utils.EnsureThumbnail(fullPath, size)
+		if err != nil {
+			c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "生成缩略图失败", err))
+			return
+		}
+		fullPath = thumbnailPath
+	}
+
 	// 设置响应头
 	filename := filepath.Base(fullPath)
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
@@ -110,7 +130,7 @@ func (h *ResourceHandler) ListFiles(c *gin.Context) {
 
 	// 安全检查：防止路径遍历攻击
 	if strings.Contains(subPath, "..") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的目录路径"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的目录路径"))
 		return
 	}
 
@@ -122,7 +142,7 @@ func (h *ResourceHandler) ListFiles(c *gin.Context) {
 	// 获取工作区公共路径
 	workspacePublicPath := os.Getenv("WORKSPACE_PUBLIC_PATH")
 	if workspacePublicPath == "" {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "环境变量 WORKSPACE_PUBLIC_PATH 未设置"})
+		c.Error(apperror.New(apperror.CodeInternal, "环境变量 WORKSPACE_PUBLIC_PATH 未设置"))
 		return
 	}
 
@@ -132,18 +152,18 @@ func (h *ResourceHandler) ListFiles(c *gin.Context) {
 	// 安全检查：确保目录路径在工作区公共目录内
 	absWorkspacePath, err := filepath.Abs(workspacePublicPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法解析工作区路径"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法解析工作区路径"))
 		return
 	}
 
 	absDirPath, err := filepath.Abs(fullPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法解析目录路径"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法解析目录路径"))
 		return
 	}
 
 	if !strings.HasPrefix(absDirPath, absWorkspacePath) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "访问路径超出允许范围"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "访问路径超出允许范围"))
 		return
 	}
 
@@ -151,23 +171,23 @@ func (h *ResourceHandler) ListFiles(c *gin.Context) {
 	dirInfo, err := os.Stat(fullPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "目录不存在"})
+			c.Error(apperror.New(apperror.CodeNotFound, "目录不存在"))
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "无法访问目录"})
+			c.Error(apperror.New(apperror.CodeInternal, "无法访问目录"))
 		}
 		return
 	}
 
 	// 检查是否为目录
 	if !dirInfo.IsDir() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "指定路径不是目录"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "指定路径不是目录"))
 		return
 	}
 
 	// 读取目录内容
 	entries, err := os.ReadDir(fullPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法读取目录内容"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法读取目录内容"))
 		return
 	}
 
@@ -192,7 +212,7 @@ func (h *ResourceHandler) ListFiles(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"path":  subPath,
 		"files": files,
 	})
@@ -205,13 +225,13 @@ func (h *ResourceHandler) GetFileInfo(c *gin.Context) {
 	// 从查询参数获取子路径
 	subPath := c.Query("path")
 	if subPath == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少 path 参数"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "缺少 path 参数"))
 		return
 	}
 
 	// 安全检查：防止路径遍历攻击
 	if strings.Contains(subPath, "..") {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的文件路径"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的文件路径"))
 		return
 	}
 
@@ -223,7 +243,7 @@ func (h *ResourceHandler) GetFileInfo(c *gin.Context) {
 	// 获取工作区公共路径
 	workspacePublicPath := os.Getenv("WORKSPACE_PUBLIC_PATH")
 	if workspacePublicPath == "" {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "环境变量 WORKSPACE_PUBLIC_PATH 未设置"})
+		c.Error(apperror.New(apperror.CodeInternal, "环境变量 WORKSPACE_PUBLIC_PATH 未设置"))
 		return
 	}
 
@@ -233,18 +253,18 @@ func (h *ResourceHandler) GetFileInfo(c *gin.Context) {
 	// 安全检查：确保文件路径在工作区公共目录内
 	absWorkspacePath, err := filepath.Abs(workspacePublicPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法解析工作区路径"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法解析工作区路径"))
 		return
 	}
 
 	absFilePath, err := filepath.Abs(fullPath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法解析文件路径"})
+		c.Error(apperror.New(apperror.CodeInternal, "无法解析文件路径"))
 		return
 	}
 
 	if !strings.HasPrefix(absFilePath, absWorkspacePath) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "访问路径超出允许范围"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "访问路径超出允许范围"))
 		return
 	}
 
@@ -252,9 +272,9 @@ func (h *ResourceHandler) GetFileInfo(c *gin.Context) {
 	fileInfo, err := os.Stat(fullPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "文件不存在"})
+			c.Error(apperror.New(apperror.CodeNotFound, "文件不存在"))
 		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "无法访问文件"})
+			c.Error(apperror.New(apperror.CodeInternal, "无法访问文件"))
 		}
 		return
 	}
@@ -268,7 +288,7 @@ func (h *ResourceHandler) GetFileInfo(c *gin.Context) {
 	// 获取文件扩展名
 	ext := filepath.Ext(fileInfo.Name())
 
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"name":          fileInfo.Name(),
 		"path":          subPath,
 		"type":          fileType,
@@ -0,0 +1,160 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/converter"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ServiceUserHandler 业务侧用户 控制器
+// 处理 业务侧用户 相关的所有 HTTP 请求
+// 相当于 Java Spring Boot 中的 Controller
+type ServiceUserHandler struct {
+	serviceUserService service.ServiceUserService // 业务侧用户 业务逻辑层接口
+}
+
+// NewServiceUserHandler 创建 业务侧用户 Handler 实例
+// 返回 ServiceUserHandler 的实例
+// 参数：serviceUserService - 业务侧用户 业务逻辑层接口
+func NewServiceUserHandler(serviceUserService service.ServiceUserService) *ServiceUserHandler {
+	return &ServiceUserHandler{
+		serviceUserService: serviceUserService,
+	}
+}
+
+// UpsertServiceUser 登记业务侧用户（管理后台）
+// 处理 POST /api/v1/service-users/application/:applicationId/upsert 请求
+func (h *ServiceUserHandler) UpsertServiceUser(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+	h.upsertServiceUser(c, applicationID)
+}
+
+// GetServiceUser 获取业务侧用户（管理后台）
+// 处理 GET /api/v1/service-users/application/:applicationId/user?service_user_id= 请求
+func (h *ServiceUserHandler) GetServiceUser(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+	h.getServiceUser(c, applicationID)
+}
+
+// GetServiceUsersByApplicationID 根据应用ID获取业务侧用户列表（分页）
+// 处理 GET /api/v1/service-users/application/:applicationId 请求
+// 支持 keyword 参数按业务侧用户ID或展示名称搜索
+func (h *ServiceUserHandler) GetServiceUsersByApplicationID(c *gin.Context) {
+	applicationID, err := uuid.Parse(c.Param("applicationId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的应用UUID格式"))
+		return
+	}
+
+	// 获取分页参数
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	serviceUsers, total, err := h.serviceUserService.GetServiceUsersByApplicationID(c.Request.Context(), applicationID, c.Query("keyword"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"service_users": converter.ServiceUserModelListToServiceUserDtoList(serviceUsers),
+		"total":         total,
+		"page":          page,
+		"page_size":     pageSize,
+	})
+}
+
+// UpsertCurrentServiceUser 登记业务侧用户（业务侧）
+// 处理 POST /api/v1/chat/service-user 请求
+// 使用智能体 API Key 认证，登记到智能体所属的应用
+func (h *ServiceUserHandler) UpsertCurrentServiceUser(c *gin.Context) {
+	application, ok := currentApplication(c)
+	if !ok {
+		return
+	}
+	h.upsertServiceUser(c, application.ID)
+}
+
+// GetCurrentServiceUser 获取业务侧用户（业务侧）
+// 处理 GET /api/v1/chat/service-user?service_user_id= 请求
+// 使用智能体 API Key 认证，查询智能体所属应用下的用户
+func (h *ServiceUserHandler) GetCurrentServiceUser(c *gin.Context) {
+	application, ok := currentApplication(c)
+	if !ok {
+		return
+	}
+	h.getServiceUser(c, application.ID)
+}
+
+// upsertServiceUser 登记指定应用下的业务侧用户并返回
+func (h *ServiceUserHandler) upsertServiceUser(c *gin.Context, applicationID uuid.UUID) {
+	var req dto.UpsertServiceUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
+		return
+	}
+
+	serviceUser, err := h.serviceUserService.UpsertServiceUser(c.Request.Context(), applicationID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"service_user": converter.ServiceUserModelToServiceUserDto(serviceUser),
+	})
+}
+
+// getServiceUser 获取指定应用下的业务侧用户并返回
+func (h *ServiceUserHandler) getServiceUser(c *gin.Context, applicationID uuid.UUID) {
+	serviceUser, err := h.serviceUserService.GetServiceUser(c.Request.Context(), applicationID, c.Query("service_user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"service_user": converter.ServiceUserModelToServiceUserDto(serviceUser),
+	})
+}
+
+// currentApplication 获取智能体认证中间件设置的当前应用
+// 获取失败时记录错误并返回 false
+func currentApplication(c *gin.Context) (*models.Application, bool) {
+	applicationValue, exists := c.Get(define.AppContextKeyCurrentApplication)
+	if !exists {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "应用信息未找到"))
+		return nil, false
+	}
+	application, ok := applicationValue.(*models.Application)
+	if !ok {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "应用信息类型错误"))
+		return nil, false
+	}
+	return application, true
+}
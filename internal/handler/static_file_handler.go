@@ -0,0 +1,58 @@
+// Package handler 提供 HTTP 请求处理层功能
+// 负责处理 HTTP 请求、参数验证、调用业务逻辑和返回响应
+package handler
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StaticFileHandler 静态文件处理器
+// 只读地提供工作区公共目录下的文件，支持 ETag、Last-Modified 协商缓存和 Range 请求
+type StaticFileHandler struct {
+	root   string // 静态文件根目录
+	maxAge int    // 浏览器缓存时间（秒）
+}
+
+// NewStaticFileHandler 创建静态文件处理器实例
+// 参数：root - 静态文件根目录，maxAge - 浏览器缓存时间（秒）
+func NewStaticFileHandler(root string, maxAge int) *StaticFileHandler {
+	return &StaticFileHandler{
+		root:   root,
+		maxAge: maxAge,
+	}
+}
+
+// ServeFile 提供静态文件
+// 路由需包含 *filepath 通配参数，目录和不存在的文件均返回 404
+func (h *StaticFileHandler) ServeFile(c *gin.Context) {
+	// 清理路径，防止路径遍历攻击
+	subPath := path.Clean("/" + c.Param("filepath"))
+	fullPath := filepath.Join(h.root, filepath.FromSlash(subPath))
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeNotFound, "文件不存在"))
+		return
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil || fileInfo.IsDir() {
+		c.Error(apperror.New(apperror.CodeNotFound, "文件不存在"))
+		return
+	}
+
+	// 设置缓存响应头，ETag 由文件大小和修改时间生成
+	// http.ServeContent 会根据 If-None-Match / If-Modified-Since 返回 304
+	c.Header("ETag", fmt.Sprintf(`W/"%x-%x"`, fileInfo.Size(), fileInfo.ModTime().UnixNano()))
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", h.maxAge))
+
+	http.ServeContent(c.Writer, c.Request, fileInfo.Name(), fileInfo.ModTime(), file)
+}
@@ -3,11 +3,16 @@
 package handler
 
 import (
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
 	"lemon-tree-core/internal/converter"
 	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/service"
 	"lemon-tree-core/internal/utils"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,15 +21,19 @@ import (
 // UserHandler User 控制器
 // 处理 User 相关的所有 HTTP 请求
 type UserHandler struct {
-	userService service.UserService // User 业务逻辑层接口
+	userService      service.UserService      // User 业务逻辑层接口
+	oidcLoginService service.OidcLoginService // OIDC 单点登录 业务逻辑层接口
+	config           *config.Config           // 应用程序配置
 }
 
 // NewUserHandler 创建 User Handler 实例
 // 返回 UserHandler 的实例
-// 参数：userService - User 业务逻辑层接口
-func NewUserHandler(userService service.UserService) *UserHandler {
+// 参数：userService - User 业务逻辑层接口，oidcLoginService - OIDC 单点登录 业务逻辑层接口，config - 应用程序配置
+func NewUserHandler(userService service.UserService, oidcLoginService service.OidcLoginService, config *config.Config) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:      userService,
+		oidcLoginService: oidcLoginService,
+		config:           config,
 	}
 }
 
@@ -36,20 +45,120 @@ func (h *UserHandler) Login(c *gin.Context) {
 	var loginRequest dto.SystemUserLoginDto
 
 	if err := c.ShouldBindJSON(&loginRequest); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误: " + err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
 		return
 	}
 
 	// 调用业务逻辑层进行登录
-	user, token, err := h.userService.Login(c.Request.Context(), loginRequest.Number, loginRequest.Password)
+	user, tokens, err := h.userService.Login(c.Request.Context(), loginRequest.Number, loginRequest.Password, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeUnauthorized, "", err))
 		return
 	}
 
 	// 转换为DTO返回
-	userDto := converter.SystemUserModelToSystemUserDto(user)
-	utils.JsonResponse(c, http.StatusOK, gin.H{"user": userDto, "token": token})
+	utils.JsonResponse(c, http.StatusOK, sessionTokensResponse(user, tokens))
+}
+
+// Refresh 刷新令牌
+// 处理 POST /api/v1/users/refresh 请求
+// 使用刷新令牌换取新的访问令牌和刷新令牌，旧的刷新令牌随即失效
+func (h *UserHandler) Refresh(c *gin.Context) {
+	var refreshRequest dto.SystemUserRefreshDto
+	if err := c.ShouldBindJSON(&refreshRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
+		return
+	}
+
+	user, tokens, err := h.userService.RefreshSession(c.Request.Context(), refreshRequest.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, sessionTokensResponse(user, tokens))
+}
+
+// GetOidcStatus 获取单点登录是否可用
+// 处理 GET /api/v1/users/oidc 请求
+// 登录页据此决定是否显示单点登录入口
+func (h *UserHandler) GetOidcStatus(c *gin.Context) {
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"enabled": h.oidcLoginService.Enabled(),
+	})
+}
+
+// OidcLogin 发起单点登录
+// 处理 GET /api/v1/users/oidc/login 请求
+// 默认重定向到身份提供方的授权页面，redirect=false 时返回授权地址
+func (h *UserHandler) OidcLogin(c *gin.Context) {
+	authorizationURL, err := h.oidcLoginService.StartLogin(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if c.Query("redirect") == "false" {
+		utils.JsonResponse(c, http.StatusOK, gin.H{"authorization_url": authorizationURL})
+		return
+	}
+	c.Redirect(http.StatusFound, authorizationURL)
+}
+
+// OidcCallback 处理身份提供方的回调
+// 处理 GET /api/v1/users/oidc/callback 请求
+// 配置了登录完成跳转地址时重定向到该地址，令牌放在 # 片段中避免出现在服务端日志里；否则直接返回登录结果
+func (h *UserHandler) OidcCallback(c *gin.Context) {
+	var user *models.SystemUser
+	var tokens *service.SessionTokens
+	var callbackErr error
+	if errorCode := c.Query("error"); errorCode != "" {
+		callbackErr = apperror.Newf(apperror.CodeUnauthorized, "身份提供方拒绝了登录: %s %s", errorCode, c.Query("error_description"))
+	} else {
+		user, tokens, callbackErr = h.oidcLoginService.CompleteLogin(c.Request.Context(), c.Query("state"), c.Query("code"), c.ClientIP(), c.Request.UserAgent())
+	}
+
+	if completeURL := h.config.Oidc.CompleteURL; completeURL != "" {
+		target, err := url.Parse(completeURL)
+		if err != nil {
+			c.Error(apperror.Wrap(apperror.CodeInternal, "登录完成跳转地址无效", err))
+			return
+		}
+		if callbackErr != nil {
+			query := target.Query()
+			query.Set("status", "error")
+			query.Set("error", callbackErr.Error())
+			target.RawQuery = query.Encode()
+		} else {
+			fragment := url.Values{}
+			fragment.Set("token", tokens.AccessToken)
+			fragment.Set("expires_at", strconv.FormatInt(tokens.AccessTokenExpiresAt.UnixMilli(), 10))
+			fragment.Set("refresh_token", tokens.RefreshToken)
+			fragment.Set("refresh_expires_at", strconv.FormatInt(tokens.RefreshTokenExpiresAt.UnixMilli(), 10))
+			target.Fragment = ""
+			target.RawFragment = fragment.Encode()
+		}
+		c.Redirect(http.StatusFound, target.String())
+		return
+	}
+
+	if callbackErr != nil {
+		c.Error(callbackErr)
+		return
+	}
+	utils.JsonResponse(c, http.StatusOK, sessionTokensResponse(user, tokens))
+}
+
+// sessionTokensResponse 登录和刷新令牌的响应内容
+// token 为访问令牌，保留原字段名兼容已有客户端
+func sessionTokensResponse(user *models.SystemUser, tokens *service.SessionTokens) gin.H {
+	return gin.H{
+		"user":               converter.SystemUserModelToSystemUserDto(user),
+		"token":              tokens.AccessToken,
+		"expires_at":         tokens.AccessTokenExpiresAt.UnixMilli(),
+		"refresh_token":      tokens.RefreshToken,
+		"refresh_expires_at": tokens.RefreshTokenExpiresAt.UnixMilli(),
+	}
 }
 
 // SaveUser 保存用户（创建或更新）
@@ -59,22 +168,26 @@ func (h *UserHandler) SaveUser(c *gin.Context) {
 	// 绑定用户信息
 	var userSaveDto dto.SystemUserSaveDto
 	if err := c.ShouldBindJSON(&userSaveDto); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误: " + err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
 		return
 	}
 
 	// 转换为模型
-	user := converter.SystemUserSaveDtoToSystemUserModel(&userSaveDto)
+	user, err := converter.SystemUserSaveDtoToSystemUserModel(&userSaveDto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	// 调用业务逻辑层保存用户
 	if err := h.userService.SaveUser(c.Request.Context(), user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO返回
 	userDto := converter.SystemUserModelToSystemUserDto(user)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"user": userDto,
 	})
 }
@@ -86,13 +199,13 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 	// 调用业务逻辑层获取所有用户
 	users, err := h.userService.GetAllUsers(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 转换为DTO列表返回
 	userDtos := converter.SystemUserModelListToSystemUserDtoList(users)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"users": userDtos,
 	})
 }
@@ -107,20 +220,20 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的用户ID格式"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的用户ID格式"))
 		return
 	}
 
 	// 调用业务逻辑层获取用户
 	user, err := h.userService.GetUserByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "用户不存在"})
+		c.Error(apperror.New(apperror.CodeNotFound, "用户不存在"))
 		return
 	}
 
 	// 转换为DTO返回
 	userDto := converter.SystemUserModelToSystemUserDto(user)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"user": userDto,
 	})
 }
@@ -132,13 +245,13 @@ func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 	// 调用业务逻辑层获取当前用户
 	user, err := h.userService.GetCurrentUser(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeUnauthorized, "", err))
 		return
 	}
 
 	// 转换为DTO返回
 	userDto := converter.SystemUserModelToSystemUserDto(user)
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"user": userDto,
 	})
 }
@@ -148,25 +261,19 @@ func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 // 删除用户的会话记录
 func (h *UserHandler) Logout(c *gin.Context) {
 	// 从请求头中获取Token
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "缺少认证Token"})
+	token, ok := requestToken(c)
+	if !ok {
 		return
 	}
 
-	// 移除Bearer前缀（如果存在）
-	if len(token) > 7 && token[:7] == "Bearer " {
-		token = token[7:]
-	}
-
 	// 调用业务逻辑层登出
 	if err := h.userService.Logout(c.Request.Context(), token); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	// 返回登出成功的响应
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"message": "success",
 	})
 }
@@ -181,18 +288,211 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	// 解析 UUID 格式的 ID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的用户ID格式"})
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的用户ID格式"))
 		return
 	}
 
 	// 调用业务逻辑层删除用户
 	if err := h.userService.DeleteUser(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "", err))
 		return
 	}
 
 	// 返回删除成功的响应
-	c.JSON(http.StatusOK, gin.H{
+	utils.JsonResponse(c, http.StatusOK, gin.H{
 		"message": "用户删除成功",
 	})
 }
+
+// ChangePassword 修改当前用户的密码
+// 处理 POST /api/v1/users/change-password 请求
+// 验证当前密码后修改，当前会话保持登录，其他登录会话全部注销
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	token, ok := requestToken(c)
+	if !ok {
+		return
+	}
+
+	var changeRequest dto.SystemUserChangePasswordDto
+	if err := c.ShouldBindJSON(&changeRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
+		return
+	}
+
+	if err := h.userService.ChangePassword(c.Request.Context(), token, changeRequest.CurrentPassword, changeRequest.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "success",
+	})
+}
+
+// InviteUser 邀请用户
+// 处理 POST /api/v1/users/invite 请求
+// 创建未设置密码的用户，并发送附带设置密码链接的邀请邮件
+func (h *UserHandler) InviteUser(c *gin.Context) {
+	var inviteRequest dto.SystemUserInviteDto
+	if err := c.ShouldBindJSON(&inviteRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
+		return
+	}
+
+	user := &models.SystemUser{
+		Name:   inviteRequest.Name,
+		Number: inviteRequest.Number,
+		Email:  inviteRequest.Email,
+	}
+	if err := h.userService.InviteUser(c.Request.Context(), user); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"user": converter.SystemUserModelToSystemUserDto(user),
+	})
+}
+
+// AcceptInvite 接受邀请
+// 处理 POST /api/v1/users/invite/accept 请求
+// 使用邀请邮件中的令牌设置初始密码
+func (h *UserHandler) AcceptInvite(c *gin.Context) {
+	var acceptRequest dto.SystemUserPasswordTokenDto
+	if err := c.ShouldBindJSON(&acceptRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
+		return
+	}
+
+	if err := h.userService.AcceptInvite(c.Request.Context(), acceptRequest.Token, acceptRequest.Password); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "success",
+	})
+}
+
+// ForgotPassword 找回密码
+// 处理 POST /api/v1/users/password/forgot 请求
+// 向邮箱发送重置密码链接，邮箱未注册时同样返回成功
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var forgotRequest dto.SystemUserForgotPasswordDto
+	if err := c.ShouldBindJSON(&forgotRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
+		return
+	}
+
+	if err := h.userService.RequestPasswordReset(c.Request.Context(), forgotRequest.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "success",
+	})
+}
+
+// ResetPassword 重置密码
+// 处理 POST /api/v1/users/password/reset 请求
+// 使用找回密码邮件中的令牌设置新密码，该用户的全部登录会话随即失效
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var resetRequest dto.SystemUserPasswordTokenDto
+	if err := c.ShouldBindJSON(&resetRequest); err != nil {
+		c.Error(apperror.Wrap(apperror.CodeInvalidArgument, "请求参数错误", err))
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), resetRequest.Token, resetRequest.Password); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "success",
+	})
+}
+
+// ListSessions 获取当前用户的登录会话
+// 处理 GET /api/v1/users/sessions 请求
+// 返回未过期的会话，标记当前请求使用的会话
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	token, ok := requestToken(c)
+	if !ok {
+		return
+	}
+
+	sessions, current, err := h.userService.ListSessions(c.Request.Context(), token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sessionDtos := make([]*dto.SystemUserSessionDto, 0, len(sessions))
+	for _, session := range sessions {
+		sessionDtos = append(sessionDtos, converter.SystemUserSessionModelToSystemUserSessionDto(session, current.ID))
+	}
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"sessions": sessionDtos,
+	})
+}
+
+// RevokeSession 注销当前用户的指定登录会话
+// 处理 DELETE /api/v1/users/sessions/:sessionId 请求
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	token, ok := requestToken(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		c.Error(apperror.New(apperror.CodeInvalidArgument, "无效的会话ID格式"))
+		return
+	}
+
+	if err := h.userService.RevokeSession(c.Request.Context(), token, sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"message": "success",
+	})
+}
+
+// RevokeOtherSessions 注销当前用户除当前会话外的全部登录会话
+// 处理 POST /api/v1/users/sessions/revoke-others 请求
+func (h *UserHandler) RevokeOtherSessions(c *gin.Context) {
+	token, ok := requestToken(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.userService.RevokeOtherSessions(c.Request.Context(), token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	utils.JsonResponse(c, http.StatusOK, gin.H{
+		"revoked_count": revoked,
+	})
+}
+
+// requestToken 从请求头中获取用户Token，移除Bearer前缀
+// 缺少Token时记录错误并返回 false
+func requestToken(c *gin.Context) (string, bool) {
+	token := c.GetHeader("Authorization")
+	if token == "" {
+		c.Error(apperror.New(apperror.CodeUnauthorized, "缺少认证Token"))
+		return "", false
+	}
+
+	// 移除Bearer前缀（如果存在）
+	if len(token) > 7 && token[:7] == "Bearer " {
+		token = token[7:]
+	}
+	return token, true
+}
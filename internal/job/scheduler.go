@@ -0,0 +1,145 @@
+// Package job 提供后台定时任务功能
+// 负责按固定间隔执行清理、同步等后台任务，并随应用程序生命周期启动和停止
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Job 定时任务
+type Job struct {
+	Name        string                          // 任务名称，用于日志，也是集群模式下任务锁的名称
+	Interval    time.Duration                   // 执行间隔
+	Run         func(ctx context.Context) error // 任务执行函数
+	PerInstance bool                            // 是否在每个实例上都执行，处理本地文件等实例独有资源的任务需要设置
+}
+
+// Locker 分布式锁
+// 集群模式下保证同一任务同时只在一个实例上执行，未开启集群模式时总是获取成功
+type Locker interface {
+	// TryLock 尝试获取锁，锁已被其他实例持有时返回 false
+	// 锁失效时取消返回的上下文，unlock 释放锁
+	TryLock(ctx context.Context, name string) (lockCtx context.Context, unlock func(), acquired bool, err error)
+}
+
+// Scheduler 定时任务调度器
+// 每个任务在独立的 goroutine 中按间隔执行，同一任务不会并发执行
+type Scheduler struct {
+	jobs   []Job              // 已注册的任务
+	locker Locker             // 分布式锁
+	logger *zap.Logger        // 日志记录器
+	cancel context.CancelFunc // 停止所有任务
+	wg     sync.WaitGroup     // 等待任务退出
+}
+
+// NewScheduler 创建定时任务调度器
+// 调度器随应用程序启动而开始执行任务，随应用程序停止而等待正在执行的任务结束
+// 参数：lifecycle - FX 生命周期管理器，locker - 分布式锁，logger - 日志记录器
+// 返回：定时任务调度器实例
+func NewScheduler(lifecycle fx.Lifecycle, locker Locker, logger *zap.Logger) *Scheduler {
+	scheduler := &Scheduler{locker: locker, logger: logger}
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			scheduler.start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return scheduler.stop(ctx)
+		},
+	})
+	return scheduler
+}
+
+// Register 注册定时任务
+// 需要在应用程序启动前调用，间隔小于等于 0 的任务不会执行
+// 参数：job - 定时任务
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		s.logger.Info("Job disabled", zap.String("job", job.Name))
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// start 启动所有已注册的任务
+func (s *Scheduler) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// stop 停止所有任务并等待正在执行的任务结束
+func (s *Scheduler) stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop 按间隔循环执行任务
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.logger.Info("Job scheduled", zap.String("job", job.Name), zap.Duration("interval", job.Interval))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, job)
+		}
+	}
+}
+
+// runOnce 执行一次任务，捕获 panic 避免影响其他任务
+// 不是每个实例都执行的任务需要先获取任务锁，其他实例正在执行时跳过本次执行
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Job panicked", zap.String("job", job.Name), zap.Any("panic", r))
+		}
+	}()
+
+	if !job.PerInstance {
+		lockCtx, unlock, acquired, err := s.locker.TryLock(ctx, "job:"+job.Name)
+		if err != nil {
+			s.logger.Error("Job lock failed", zap.String("job", job.Name), zap.Error(err))
+			return
+		}
+		if !acquired {
+			s.logger.Debug("Job skipped, running on another instance", zap.String("job", job.Name))
+			return
+		}
+		defer unlock()
+		ctx = lockCtx
+	}
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Error("Job failed", zap.String("job", job.Name), zap.Error(err))
+		return
+	}
+	s.logger.Debug("Job finished", zap.String("job", job.Name), zap.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,177 @@
+// Package mailer 提供邮件发送功能
+// 通过 SMTP 发送使用模板渲染的邮件，未配置 SMTP 服务器时发送会返回 ErrNotConfigured
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured 未配置 SMTP 服务器或发件人
+var ErrNotConfigured = errors.New("未配置邮件服务")
+
+// smtpTimeout 连接和发送邮件的超时时间
+const smtpTimeout = 30 * time.Second
+
+// Message 待发送的邮件
+type Message struct {
+	To       string // 收件人邮箱
+	Subject  string // 主题
+	HTMLBody string // HTML 正文
+}
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	// Enabled 是否已配置邮件服务
+	Enabled() bool
+
+	// Send 发送邮件
+	Send(ctx context.Context, message *Message) error
+
+	// SendTemplate 使用模板渲染主题和正文后发送邮件
+	// 参数：name - 模板名称，如 invite、reset_password；data - 模板数据
+	SendTemplate(ctx context.Context, to, name string, data interface{}) error
+}
+
+// smtpMailer 通过 SMTP 发送邮件的 Mailer 实现
+type smtpMailer struct {
+	config    config.MailConfig
+	templates *templateSet
+}
+
+// NewMailer 根据配置创建 Mailer
+// 参数：config - 应用程序配置
+func NewMailer(config *config.Config) Mailer {
+	return &smtpMailer{
+		config:    config.Mail,
+		templates: newTemplateSet(config.Mail.TemplateDir),
+	}
+}
+
+// Enabled 是否已配置邮件服务
+func (m *smtpMailer) Enabled() bool {
+	return m.config.SMTPHost != "" && m.config.From != ""
+}
+
+// SendTemplate 使用模板渲染主题和正文后发送邮件
+func (m *smtpMailer) SendTemplate(ctx context.Context, to, name string, data interface{}) error {
+	subject, body, err := m.templates.render(name, data)
+	if err != nil {
+		return err
+	}
+	return m.Send(ctx, &Message{To: to, Subject: subject, HTMLBody: body})
+}
+
+// Send 发送邮件
+// 465 端口使用 TLS 连接，其他端口在服务器支持时升级为 STARTTLS
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	if !m.Enabled() {
+		return ErrNotConfigured
+	}
+	from, err := mail.ParseAddress(m.config.From)
+	if err != nil {
+		return fmt.Errorf("发件人地址无效: %w", err)
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("收件人地址无效: %w", err)
+	}
+	data, err := buildMessage(from, to, message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+	address := net.JoinHostPort(m.config.SMTPHost, strconv.Itoa(m.config.SMTPPort))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("连接SMTP服务器失败: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	tlsConfig := &tls.Config{ServerName: m.config.SMTPHost}
+	if m.config.SMTPPort == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	client, err := smtp.NewClient(conn, m.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("连接SMTP服务器失败: %w", err)
+	}
+	defer client.Close()
+
+	if m.config.SMTPPort != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("SMTP STARTTLS 失败: %w", err)
+			}
+		}
+	}
+	if m.config.SMTPUsername != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.config.SMTPUsername, m.config.SMTPPassword, m.config.SMTPHost)); err != nil {
+			return fmt.Errorf("SMTP认证失败: %w", err)
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("SMTP设置发件人失败: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP设置收件人失败: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP发送邮件内容失败: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return fmt.Errorf("SMTP发送邮件内容失败: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP发送邮件内容失败: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage 生成 MIME 格式的邮件内容，正文使用 base64 编码
+func buildMessage(from, to *mail.Address, message *Message) ([]byte, error) {
+	messageID := make([]byte, 16)
+	if _, err := rand.Read(messageID); err != nil {
+		return nil, err
+	}
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from.String() + "\r\n")
+	buf.WriteString("To: " + to.String() + "\r\n")
+	buf.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", message.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("Message-ID: <" + hex.EncodeToString(messageID) + "@" + domain + ">\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(message.HTMLBody))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes(), nil
+}
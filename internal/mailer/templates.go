@@ -0,0 +1,142 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+// 内置邮件模板名称
+const (
+	TemplateInvite        = "invite"         // 用户邀请，模板数据为 InviteData
+	TemplateResetPassword = "reset_password" // 重置密码，模板数据为 ResetPasswordData
+)
+
+// InviteData 用户邀请邮件的模板数据
+type InviteData struct {
+	Name        string    // 被邀请用户的名字
+	Number      string    // 被邀请用户的登录账号
+	InviterName string    // 邀请人的名字
+	Link        string    // 接受邀请并设置密码的链接
+	ExpiresAt   time.Time // 链接过期时间
+}
+
+// ResetPasswordData 重置密码邮件的模板数据
+type ResetPasswordData struct {
+	Name      string    // 用户名字
+	Number    string    // 用户登录账号
+	Link      string    // 重置密码的链接
+	ExpiresAt time.Time // 链接过期时间
+}
+
+// defaultTemplates 内置邮件模板，每个模板包含主题和 HTML 正文
+var defaultTemplates = map[string]struct {
+	Subject string
+	Body    string
+}{
+	TemplateInvite: {
+		Subject: `您已被邀请加入 Lemon Tree`,
+		Body: `<p>{{.Name}}，您好：</p>
+<p>{{if .InviterName}}{{.InviterName}} {{end}}邀请您加入 Lemon Tree，登录账号为 <b>{{.Number}}</b>。</p>
+<p>请在 {{.ExpiresAt.Format "2006-01-02 15:04"}} 前点击下面的链接设置密码：</p>
+<p><a href="{{.Link}}">{{.Link}}</a></p>
+<p>如果这不是您预期的邮件，请忽略。</p>`,
+	},
+	TemplateResetPassword: {
+		Subject: `重置您的 Lemon Tree 密码`,
+		Body: `<p>{{.Name}}，您好：</p>
+<p>我们收到了重置账号 <b>{{.Number}}</b> 密码的请求。</p>
+<p>请在 {{.ExpiresAt.Format "2006-01-02 15:04"}} 前点击下面的链接设置新密码：</p>
+<p><a href="{{.Link}}">{{.Link}}</a></p>
+<p>如果您没有申请重置密码，请忽略本邮件，您的密码不会改变。</p>`,
+	},
+}
+
+// templateSet 邮件模板集合
+// 自定义模板目录中存在 {name}.subject.tmpl、{name}.html.tmpl 时覆盖对应的内置模板，首次使用时加载
+type templateSet struct {
+	dir string
+
+	mu       sync.Mutex
+	subjects map[string]*texttemplate.Template
+	bodies   map[string]*htmltemplate.Template
+}
+
+// newTemplateSet 创建邮件模板集合
+func newTemplateSet(dir string) *templateSet {
+	return &templateSet{
+		dir:      dir,
+		subjects: make(map[string]*texttemplate.Template),
+		bodies:   make(map[string]*htmltemplate.Template),
+	}
+}
+
+// render 渲染模板的主题和正文
+func (t *templateSet) render(name string, data interface{}) (string, string, error) {
+	subjectTemplate, bodyTemplate, err := t.load(name)
+	if err != nil {
+		return "", "", err
+	}
+	var subject, body bytes.Buffer
+	if err := subjectTemplate.Execute(&subject, data); err != nil {
+		return "", "", fmt.Errorf("渲染邮件主题失败: %w", err)
+	}
+	if err := bodyTemplate.Execute(&body, data); err != nil {
+		return "", "", fmt.Errorf("渲染邮件正文失败: %w", err)
+	}
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
+
+// load 加载并缓存模板
+func (t *templateSet) load(name string) (*texttemplate.Template, *htmltemplate.Template, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if subject, ok := t.subjects[name]; ok {
+		return subject, t.bodies[name], nil
+	}
+
+	defaults, ok := defaultTemplates[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("邮件模板不存在: %s", name)
+	}
+	subjectSource, err := t.readOverride(name+".subject.tmpl", defaults.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	bodySource, err := t.readOverride(name+".html.tmpl", defaults.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	subject, err := texttemplate.New(name + ".subject").Parse(subjectSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析邮件主题模板失败: %w", err)
+	}
+	body, err := htmltemplate.New(name + ".html").Parse(bodySource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析邮件正文模板失败: %w", err)
+	}
+	t.subjects[name] = subject
+	t.bodies[name] = body
+	return subject, body, nil
+}
+
+// readOverride 读取自定义模板目录中的模板，不存在时使用内置模板
+func (t *templateSet) readOverride(fileName, defaultSource string) (string, error) {
+	if t.dir == "" {
+		return defaultSource, nil
+	}
+	content, err := os.ReadFile(filepath.Join(t.dir, fileName))
+	if os.IsNotExist(err) {
+		return defaultSource, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("读取邮件模板失败: %w", err)
+	}
+	return string(content), nil
+}
@@ -16,22 +16,47 @@ func GetMcpClient(ctx context.Context, config *models.ApplicationMcpServerConfig
 	var c *client.Client
 	var err error
 
+	// OAuth 授权的MCP服务由传输层自动携带访问令牌，过期时使用刷新令牌续期
+	var oauthConfig *transport.OAuthConfig
+	if UsesMcpOauth(config) {
+		mcpOauthConfig, err := NewMcpOauthConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		oauthConfig = &mcpOauthConfig
+	}
+
 	switch config.McpServerConnectType {
 	case "streamable-http":
-		httpTransport, err := transport.NewStreamableHTTP(config.McpServerUrl)
+		var options []transport.StreamableHTTPCOption
+		if oauthConfig != nil {
+			options = append(options, transport.WithHTTPOAuth(*oauthConfig))
+		}
+		httpTransport, err := transport.NewStreamableHTTP(config.McpServerUrl, options...)
 		if err != nil {
 			return nil, fmt.Errorf("创建Streamable HTTP传输失败: %w", err)
 		}
 		c = client.NewClient(httpTransport)
 	case "sse":
-		sse, err := transport.NewSSE(config.McpServerUrl)
+		var options []transport.ClientOption
+		if oauthConfig != nil {
+			options = append(options, transport.WithOAuth(*oauthConfig))
+		}
+		sse, err := transport.NewSSE(config.McpServerUrl, options...)
 		if err != nil {
 			return nil, fmt.Errorf("创建SSE传输失败: %w", err)
 		}
 		c = client.NewClient(sse)
 	case "stdio":
-		studio := transport.NewStdio(config.McpServerCommand, []string{config.McpServerEnv}, "")
-		c = client.NewClient(studio)
+		stdio, err := newStdioTransport(config)
+		if err != nil {
+			return nil, fmt.Errorf("创建stdio传输失败: %w", err)
+		}
+		// stdio 传输需要先启动子进程
+		if err := stdio.Start(ctx); err != nil {
+			return nil, fmt.Errorf("启动MCP服务进程失败: %w", err)
+		}
+		c = client.NewClient(stdio)
 	default:
 		return nil, fmt.Errorf("不支持的连接方式: %s", config.McpServerConnectType)
 	}
@@ -46,6 +71,9 @@ func GetMcpClient(ctx context.Context, config *models.ApplicationMcpServerConfig
 	initRequest.Params.Capabilities = mcp.ClientCapabilities{}
 
 	serverInfo, err := c.Initialize(ctx, initRequest)
+	if client.IsOAuthAuthorizationRequiredError(err) {
+		return nil, fmt.Errorf("初始化MCP客户端失败: %w", ErrMcpOauthAuthorizationRequired)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("初始化MCP客户端失败: %w", err)
 	}
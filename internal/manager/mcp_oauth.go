@@ -0,0 +1,160 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/utils"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// ErrMcpOauthAuthorizationRequired MCP服务需要完成OAuth授权
+// 尚未授权、令牌已过期且无法刷新时返回，需在管理后台重新发起授权
+var ErrMcpOauthAuthorizationRequired = errors.New("MCP服务需要完成OAuth授权")
+
+// mcpOauthTokenRepo 保存OAuth令牌的数据访问层，服务启动时注册
+var (
+	mcpOauthTokenRepoMu sync.RWMutex
+	mcpOauthTokenRepo   repository.ApplicationMcpServerOauthTokenRepository
+)
+
+// RegisterMcpOauthTokenRepository 注册保存MCP服务OAuth令牌的数据访问层
+// 创建使用OAuth授权的MCP客户端时从中读取令牌，刷新后的令牌也写回其中
+// 参数：repo - MCP服务OAuth令牌 数据访问层接口
+func RegisterMcpOauthTokenRepository(repo repository.ApplicationMcpServerOauthTokenRepository) {
+	mcpOauthTokenRepoMu.Lock()
+	defer mcpOauthTokenRepoMu.Unlock()
+	mcpOauthTokenRepo = repo
+}
+
+// NewMcpOauthConfig 根据MCP配置创建OAuth配置
+// 客户端密钥解密后使用，令牌通过已注册的数据访问层读写
+// 参数：mcpConfig - MCP配置
+// 返回：OAuth配置，未配置加密密钥或回调地址、客户端密钥无法解密时返回错误
+func NewMcpOauthConfig(mcpConfig *models.ApplicationMcpServerConfig) (transport.OAuthConfig, error) {
+	oauthConfig := mcpOauthAppConfig()
+	if oauthConfig.EncryptionKey == "" {
+		return transport.OAuthConfig{}, errors.New("未配置 MCP_OAUTH_ENCRYPTION_KEY，不支持OAuth授权")
+	}
+	if oauthConfig.RedirectURL == "" {
+		return transport.OAuthConfig{}, errors.New("未配置 MCP_OAUTH_REDIRECT_URL，不支持OAuth授权")
+	}
+	clientSecret, err := DecryptMcpOauthSecret(mcpConfig.McpOauthClientSecret)
+	if err != nil {
+		return transport.OAuthConfig{}, fmt.Errorf("解密OAuth客户端密钥失败: %w", err)
+	}
+
+	mcpOauthTokenRepoMu.RLock()
+	repo := mcpOauthTokenRepo
+	mcpOauthTokenRepoMu.RUnlock()
+	if repo == nil {
+		return transport.OAuthConfig{}, errors.New("未注册OAuth令牌存储")
+	}
+
+	return transport.OAuthConfig{
+		ClientID:              mcpConfig.McpOauthClientID,
+		ClientSecret:          clientSecret,
+		RedirectURI:           oauthConfig.RedirectURL,
+		Scopes:                strings.Fields(mcpConfig.McpOauthScopes),
+		TokenStore:            &mcpOauthTokenStore{repo: repo, mcpConfig: mcpConfig},
+		AuthServerMetadataURL: mcpConfig.McpOauthServerMetadataURL,
+		PKCEEnabled:           true,
+	}, nil
+}
+
+// UsesMcpOauth MCP配置是否通过OAuth授权访问MCP服务
+func UsesMcpOauth(mcpConfig *models.ApplicationMcpServerConfig) bool {
+	return mcpConfig.McpServerAuthType == define.McpServerAuthTypeOAuth &&
+		(mcpConfig.McpServerConnectType == "sse" || mcpConfig.McpServerConnectType == "streamable-http")
+}
+
+// EncryptMcpOauthSecret 加密需要保存的OAuth客户端密钥、令牌等敏感信息
+func EncryptMcpOauthSecret(plaintext string) (string, error) {
+	return utils.EncryptSecret(mcpOauthAppConfig().EncryptionKey, plaintext)
+}
+
+// DecryptMcpOauthSecret 解密 EncryptMcpOauthSecret 加密的内容
+func DecryptMcpOauthSecret(ciphertext string) (string, error) {
+	return utils.DecryptSecret(mcpOauthAppConfig().EncryptionKey, ciphertext)
+}
+
+// mcpOauthAppConfig 获取服务配置中的OAuth授权配置
+func mcpOauthAppConfig() config.McpOAuthConfig {
+	if config.AppConfig == nil {
+		return config.McpOAuthConfig{}
+	}
+	return config.AppConfig.McpOAuth
+}
+
+// mcpOauthTokenStore 数据库保存的MCP服务OAuth令牌
+// 实现 transport.TokenStore，访问令牌和刷新令牌加密保存
+type mcpOauthTokenStore struct {
+	repo      repository.ApplicationMcpServerOauthTokenRepository // 数据访问层
+	mcpConfig *models.ApplicationMcpServerConfig                  // 令牌所属的MCP配置
+}
+
+// GetToken 读取并解密MCP配置的令牌，尚未授权时返回错误
+func (s *mcpOauthTokenStore) GetToken() (*transport.Token, error) {
+	record, err := s.repo.GetByConfigID(context.Background(), s.mcpConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || (record.AccessToken == "" && record.RefreshToken == "") {
+		return nil, ErrMcpOauthAuthorizationRequired
+	}
+	accessToken, err := DecryptMcpOauthSecret(record.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("解密访问令牌失败: %w", err)
+	}
+	refreshToken, err := DecryptMcpOauthSecret(record.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("解密刷新令牌失败: %w", err)
+	}
+	token := &transport.Token{
+		AccessToken:  accessToken,
+		TokenType:    record.TokenType,
+		RefreshToken: refreshToken,
+		Scope:        record.Scope,
+	}
+	if record.ExpiresAt != nil {
+		token.ExpiresAt = *record.ExpiresAt
+	}
+	return token, nil
+}
+
+// SaveToken 加密保存授权或刷新得到的令牌，记录不存在时创建
+func (s *mcpOauthTokenStore) SaveToken(token *transport.Token) error {
+	ctx := context.Background()
+	record, err := s.repo.GetByConfigID(ctx, s.mcpConfig.ID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = &models.ApplicationMcpServerOauthToken{
+			ApplicationID:                s.mcpConfig.ApplicationID,
+			ApplicationMcpServerConfigID: s.mcpConfig.ID,
+		}
+	}
+
+	if record.AccessToken, err = EncryptMcpOauthSecret(token.AccessToken); err != nil {
+		return fmt.Errorf("加密访问令牌失败: %w", err)
+	}
+	if record.RefreshToken, err = EncryptMcpOauthSecret(token.RefreshToken); err != nil {
+		return fmt.Errorf("加密刷新令牌失败: %w", err)
+	}
+	record.TokenType = token.TokenType
+	record.Scope = token.Scope
+	record.ExpiresAt = nil
+	if !token.ExpiresAt.IsZero() {
+		expiresAt := token.ExpiresAt
+		record.ExpiresAt = &expiresAt
+	}
+	return s.repo.Save(ctx, record)
+}
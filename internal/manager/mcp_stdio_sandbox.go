@@ -0,0 +1,203 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// stdioEnvNamePattern 环境变量名的格式
+var stdioEnvNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// stdioBlockedEnvPrefixes 不允许在MCP配置中设置的环境变量前缀
+// 动态链接器的环境变量可以向允许启动的命令注入任意代码，绕过命令允许列表
+var stdioBlockedEnvPrefixes = []string{"LD_", "DYLD_"}
+
+// stdioShellPath 设置资源限制时用于包装命令的 shell
+const stdioShellPath = "/bin/sh"
+
+// ValidateStdioConfig 校验 stdio MCP服务的命令、参数、环境变量和执行限制
+// 保存配置和启动MCP服务时调用，命令需在服务配置的允许列表中
+func ValidateStdioConfig(mcpConfig *models.ApplicationMcpServerConfig) error {
+	if _, err := resolveStdioCommand(mcpConfig.McpServerCommand); err != nil {
+		return err
+	}
+	if _, err := parseStdioArgs(mcpConfig.McpServerArgs); err != nil {
+		return err
+	}
+	if _, err := parseStdioEnv(mcpConfig.McpServerEnv); err != nil {
+		return err
+	}
+	if mcpConfig.McpServerWorkDir != "" && !filepath.IsAbs(mcpConfig.McpServerWorkDir) {
+		return fmt.Errorf("MCP服务工作目录必须为绝对路径")
+	}
+	if mcpConfig.McpServerCpuLimit < 0 || mcpConfig.McpServerMemoryLimit < 0 || mcpConfig.McpServerMaxRuntime < 0 {
+		return fmt.Errorf("MCP服务的资源限制不能为负数")
+	}
+	if mcpConfig.McpServerDisableNetwork && !mcpConfig.McpServerUserNamespace {
+		return fmt.Errorf("禁止网络访问需要同时启用用户命名空间")
+	}
+	if mcpConfig.McpServerUserNamespace && !stdioUserNamespaceSupported {
+		return fmt.Errorf("当前系统不支持在用户命名空间中运行MCP服务")
+	}
+	return nil
+}
+
+// StdioArgs 获取 stdio MCP服务的命令参数
+// 参数为 JSON 字符串数组或空白字符分隔的文本，格式无效时返回错误
+func StdioArgs(mcpConfig *models.ApplicationMcpServerConfig) ([]string, error) {
+	return parseStdioArgs(mcpConfig.McpServerArgs)
+}
+
+// newStdioTransport 按MCP配置的执行限制创建 stdio 传输
+func newStdioTransport(mcpConfig *models.ApplicationMcpServerConfig) (*transport.Stdio, error) {
+	if err := ValidateStdioConfig(mcpConfig); err != nil {
+		return nil, err
+	}
+	command, _ := resolveStdioCommand(mcpConfig.McpServerCommand)
+	args, _ := parseStdioArgs(mcpConfig.McpServerArgs)
+	env, _ := parseStdioEnv(mcpConfig.McpServerEnv)
+
+	commandFunc := func(ctx context.Context, command string, env []string, args []string) (*exec.Cmd, error) {
+		if mcpConfig.McpServerMaxRuntime > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(mcpConfig.McpServerMaxRuntime)*time.Second)
+			// 进程随上下文结束，上下文超时或父上下文结束时计时器自动释放，无需手动取消
+			_ = cancel
+		}
+
+		name, args := wrapStdioResourceLimits(command, args, mcpConfig.McpServerCpuLimit, mcpConfig.McpServerMemoryLimit)
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Env = append(stdioInheritedEnv(), env...)
+		cmd.Dir = mcpConfig.McpServerWorkDir
+		if err := applyStdioNamespaces(cmd, mcpConfig.McpServerUserNamespace, mcpConfig.McpServerDisableNetwork); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	}
+	return transport.NewStdioWithOptions(command, env, args, transport.WithCommandFunc(commandFunc)), nil
+}
+
+// resolveStdioCommand 解析命令的绝对路径并校验是否在允许列表中
+// 允许列表为空时不限制；命令和允许列表中的路径为符号链接时按链接指向的文件比较
+func resolveStdioCommand(command string) (string, error) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return "", fmt.Errorf("MCP服务命令不能为空")
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return "", fmt.Errorf("找不到MCP服务命令 %s: %w", command, err)
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return "", fmt.Errorf("解析MCP服务命令路径失败: %w", err)
+	}
+
+	allowedCommands := stdioConfig().AllowedCommands
+	if len(allowedCommands) == 0 {
+		return path, nil
+	}
+	resolved := resolveSymlinks(path)
+	for _, allowed := range allowedCommands {
+		if allowed == path || resolveSymlinks(allowed) == resolved {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("MCP服务命令 %s 不在允许启动的命令列表中", path)
+}
+
+// resolveSymlinks 解析路径中的符号链接，解析失败时返回原路径
+func resolveSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// parseStdioArgs 解析命令参数
+// 以 [ 开头时按 JSON 字符串数组解析，参数中可以包含空格；否则按空白字符分隔
+func parseStdioArgs(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") {
+		return strings.Fields(value), nil
+	}
+	var args []string
+	if err := json.Unmarshal([]byte(value), &args); err != nil {
+		return nil, fmt.Errorf("MCP服务参数不是有效的 JSON 字符串数组: %w", err)
+	}
+	return args, nil
+}
+
+// parseStdioEnv 解析MCP配置中的环境变量
+// 每行一个 KEY=VALUE，忽略空行和 # 开头的注释行
+func parseStdioEnv(value string) ([]string, error) {
+	var env []string
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, _, ok := strings.Cut(line, "=")
+		if !ok || !stdioEnvNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("MCP服务环境变量格式应为 KEY=VALUE: %s", line)
+		}
+		for _, prefix := range stdioBlockedEnvPrefixes {
+			if strings.HasPrefix(strings.ToUpper(name), prefix) {
+				return nil, fmt.Errorf("不允许设置环境变量 %s", name)
+			}
+		}
+		env = append(env, line)
+	}
+	return env, nil
+}
+
+// stdioInheritedEnv 获取子进程从服务进程继承的环境变量
+func stdioInheritedEnv() []string {
+	inheritEnv := stdioConfig().InheritEnv
+	var env []string
+	for _, item := range os.Environ() {
+		name, _, _ := strings.Cut(item, "=")
+		if slices.Contains(inheritEnv, name) {
+			env = append(env, item)
+		}
+	}
+	return env
+}
+
+// wrapStdioResourceLimits 设置了CPU时间或内存上限时通过 shell 的 ulimit 限制子进程的资源
+// 返回实际启动的命令和参数，未设置限制时原样返回
+func wrapStdioResourceLimits(command string, args []string, cpuLimit, memoryLimit int) (string, []string) {
+	var limits []string
+	if cpuLimit > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -t %d", cpuLimit))
+	}
+	if memoryLimit > 0 {
+		// ulimit -v 的单位为 KB
+		limits = append(limits, fmt.Sprintf("ulimit -v %d", memoryLimit*1024))
+	}
+	if len(limits) == 0 {
+		return command, args
+	}
+	// $0 为命令，$@ 为参数，避免参数经过 shell 解析
+	script := strings.Join(limits, " && ") + ` && exec "$0" "$@"`
+	return stdioShellPath, append([]string{"-c", script, command}, args...)
+}
+
+// stdioConfig 获取 stdio MCP服务执行限制配置，未加载配置时不限制命令且不继承环境变量
+func stdioConfig() config.McpStdioConfig {
+	if config.AppConfig == nil {
+		return config.McpStdioConfig{}
+	}
+	return config.AppConfig.McpStdio
+}
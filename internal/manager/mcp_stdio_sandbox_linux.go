@@ -0,0 +1,34 @@
+//go:build linux
+
+package manager
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// stdioUserNamespaceSupported 当前系统是否支持在用户命名空间中运行MCP服务
+const stdioUserNamespaceSupported = true
+
+// applyStdioNamespaces 在独立的用户命名空间中运行子进程
+// 子进程中的用户映射为 nobody，没有服务进程用户的权限；disableNetwork 为 true 时同时创建独立的网络命名空间，子进程只有回环网卡，无法访问外部网络
+func applyStdioNamespaces(cmd *exec.Cmd, userNamespace, disableNetwork bool) error {
+	if !userNamespace {
+		return nil
+	}
+	cloneflags := uintptr(syscall.CLONE_NEWUSER)
+	if disableNetwork {
+		cloneflags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  cloneflags,
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 65534, HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 65534, HostID: os.Getgid(), Size: 1}},
+		// 非特权进程设置组映射前需禁用 setgroups
+		GidMappingsEnableSetgroups: false,
+		// 服务进程退出时结束子进程
+		Pdeathsig: syscall.SIGKILL,
+	}
+	return nil
+}
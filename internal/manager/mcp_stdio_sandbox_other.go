@@ -0,0 +1,19 @@
+//go:build !linux
+
+package manager
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// stdioUserNamespaceSupported 当前系统是否支持在用户命名空间中运行MCP服务
+const stdioUserNamespaceSupported = false
+
+// applyStdioNamespaces 用户命名空间仅 Linux 支持，其他系统要求启用时返回错误
+func applyStdioNamespaces(cmd *exec.Cmd, userNamespace, disableNetwork bool) error {
+	if userNamespace {
+		return fmt.Errorf("当前系统不支持在用户命名空间中运行MCP服务")
+	}
+	return nil
+}
@@ -4,9 +4,13 @@ package middleware
 
 import (
 	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
 	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/service"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,7 +25,7 @@ func UserAuthMiddleware(userService service.UserService) gin.HandlerFunc {
 		// 从请求头中获取Token
 		token := c.GetHeader("Authorization")
 		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "缺少认证Token"})
+			c.Error(apperror.New(apperror.CodeUnauthorized, "缺少认证Token"))
 			c.Abort()
 			return
 		}
@@ -34,7 +38,7 @@ func UserAuthMiddleware(userService service.UserService) gin.HandlerFunc {
 		// 验证Token并获取当前用户
 		user, err := userService.GetUserByToken(c.Request.Context(), token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			c.Error(apperror.Wrap(apperror.CodeUnauthorized, "", err))
 			c.Abort()
 			return
 		}
@@ -50,41 +54,100 @@ func UserAuthMiddleware(userService service.UserService) gin.HandlerFunc {
 }
 
 // ChatAgentAuthMiddleware 认证中间件
-// 验证请求中的ApiKey，确认是哪个应用
+// 验证请求中的ApiKey，确认是哪个应用；来源IP不满足 ApiKey 的网段限制时返回 403
 // 返回 Gin 中间件函数
 func ChatAgentAuthMiddleware(chatAgentService service.ChatAgentService, applicationService service.ApplicationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从请求头中获取Token
-		apiKey := c.GetHeader("lemon-ai-api-key")
+		apiKey := c.GetHeader(define.HeaderApiKey)
 		if apiKey == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Lemon AI ApiKey Not Found"})
+			c.Error(apperror.New(apperror.CodeUnauthorized, "缺少 Lemon AI ApiKey"))
 			c.Abort()
 			return
 		}
 
-		// 验证Api Key获取ChatAgent
-		chatAgent, err := chatAgentService.GetChatAgentByApiKey(c.Request.Context(), apiKey)
+		// 验证Api Key和来源IP获取ChatAgent
+		chatAgent, err := chatAgentService.GetChatAgentByApiKey(c.Request.Context(), apiKey, service.ApiKeyAccess{
+			ClientIP:  c.ClientIP(),
+			Path:      c.Request.URL.Path,
+			UserAgent: c.Request.UserAgent(),
+		})
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			c.Error(apperror.Wrap(apperror.CodeUnauthorized, "", err))
 			c.Abort()
 			return
 		}
-		application, getAppErr := applicationService.GetApplicationByID(c.Request.Context(), chatAgent.ApplicationID)
+		setChatAgentContext(c, chatAgent, applicationService)
+	}
+}
+
+// ChatWidgetAuthMiddleware 聊天接口认证中间件
+// 业务服务端使用ApiKey认证，嵌入网页的聊天窗口使用窗口令牌认证
+// 窗口令牌只能从创建时指定、且仍在智能体来源列表中的来源使用，修改数据的请求按配置校验与窗口令牌绑定的 CSRF 令牌
+// 窗口令牌绑定的业务侧用户写入上下文，聊天接口只允许访问该用户的数据
+// 返回 Gin 中间件函数
+func ChatWidgetAuthMiddleware(chatAgentService service.ChatAgentService, applicationService service.ApplicationService,
+	widgetTokenService service.ChatWidgetTokenService, widgetConfig config.ChatWidgetConfig) gin.HandlerFunc {
+	apiKeyAuth := ChatAgentAuthMiddleware(chatAgentService, applicationService)
+	return func(c *gin.Context) {
+		widgetToken := c.GetHeader(define.HeaderWidgetToken)
+		if widgetToken == "" || c.GetHeader(define.HeaderApiKey) != "" {
+			apiKeyAuth(c)
+			return
+		}
 
-		if getAppErr != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": getAppErr.Error()})
+		token, chatAgent, err := widgetTokenService.Authenticate(c.Request.Context(), widgetToken, requestOrigin(c))
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if widgetConfig.CSRFEnabled && !isSafeMethod(c.Request.Method) &&
+			!widgetTokenService.VerifyCSRFToken(token, c.GetHeader(define.HeaderCSRFToken)) {
+			c.Error(apperror.New(apperror.CodeForbidden, "CSRF令牌缺失或不匹配，请先获取CSRF令牌"))
 			c.Abort()
 			return
 		}
+		c.Set(define.AppContextKeyCurrentWidgetToken, token)
+		c.Set(define.AppContextKeyWidgetServiceUserID, token.ServiceUserID)
+		setChatAgentContext(c, chatAgent, applicationService)
+	}
+}
 
-		// 将用户信息存储到上下文中，供后续处理器使用
-		c.Set(define.AppContextKeyCurrentChatAgent, chatAgent)
-		c.Set(define.AppContextKeyCurrentApplication, application)
-		ctx := c.Request.Context()
-		ctx = context.WithValue(ctx, define.AppContextKeyCurrentChatAgent, chatAgent)
-		ctx = context.WithValue(ctx, define.AppContextKeyCurrentApplication, application)
-		c.Request = c.Request.WithContext(ctx)
-		// 继续处理下一个中间件或路由处理器
-		c.Next()
+// requestOrigin 获取请求的来源
+// 优先使用 Origin 请求头，缺少时从 Referer 请求头中取协议、主机和端口
+func requestOrigin(c *gin.Context) string {
+	if origin := c.GetHeader("Origin"); origin != "" && origin != "null" {
+		return origin
+	}
+	referer, err := url.Parse(c.GetHeader("Referer"))
+	if err != nil || referer.Scheme == "" || referer.Host == "" {
+		return ""
 	}
+	return referer.Scheme + "://" + referer.Host
+}
+
+// isSafeMethod 是否为不修改数据的请求方法
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// setChatAgentContext 将认证得到的智能体和所属应用写入上下文，并继续处理请求
+func setChatAgentContext(c *gin.Context, chatAgent *models.ChatAgent, applicationService service.ApplicationService) {
+	application, err := applicationService.GetApplicationByID(c.Request.Context(), chatAgent.ApplicationID)
+	if err != nil {
+		c.Error(apperror.Wrap(apperror.CodeUnauthorized, "", err))
+		c.Abort()
+		return
+	}
+
+	// 将用户信息存储到上下文中，供后续处理器使用
+	c.Set(define.AppContextKeyCurrentChatAgent, chatAgent)
+	c.Set(define.AppContextKeyCurrentApplication, application)
+	ctx := c.Request.Context()
+	ctx = context.WithValue(ctx, define.AppContextKeyCurrentChatAgent, chatAgent)
+	ctx = context.WithValue(ctx, define.AppContextKeyCurrentApplication, application)
+	c.Request = c.Request.WithContext(ctx)
+	// 继续处理下一个中间件或路由处理器
+	c.Next()
 }
@@ -0,0 +1,59 @@
+// Package middleware 提供 HTTP 中间件功能
+package middleware
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodySizeLimitMiddleware 请求体大小限制中间件
+// 全局注册时设置默认上限，在单个路由上再次注册时覆盖该路由的上限（如上传接口）
+// 读取请求体超过上限时返回 *http.MaxBytesError，由错误转换中间件转换为 413 响应
+// 参数：limit - 请求体大小上限（字节），小于等于 0 表示不限制
+// 返回 Gin 中间件函数
+func BodySizeLimitMiddleware(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 已被外层中间件包装时只调整上限，避免外层较小的上限覆盖路由级别的设置
+		if body, ok := c.Request.Body.(*limitedBody); ok {
+			body.limit = limit
+		} else if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = &limitedBody{
+				ReadCloser:    c.Request.Body,
+				limit:         limit,
+				contentLength: c.Request.ContentLength,
+			}
+		}
+		c.Next()
+	}
+}
+
+// limitedBody 可调整上限的请求体读取器
+type limitedBody struct {
+	io.ReadCloser
+	limit         int64 // 大小上限（字节），小于等于 0 表示不限制
+	contentLength int64 // 请求头声明的长度，未知时为 -1
+	read          int64 // 已读取的字节数
+}
+
+// Read 读取请求体，超过上限时返回 *http.MaxBytesError
+// 请求头声明的长度已超过上限时不读取任何内容直接返回错误
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.limit <= 0 {
+		return b.ReadCloser.Read(p)
+	}
+	if b.contentLength > b.limit || b.read > b.limit {
+		return 0, &http.MaxBytesError{Limit: b.limit}
+	}
+	// 最多多读一个字节，用于判断是否超过上限
+	if remaining := b.limit - b.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n, &http.MaxBytesError{Limit: b.limit}
+	}
+	return n, err
+}
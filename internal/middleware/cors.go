@@ -3,34 +3,87 @@
 package middleware
 
 import (
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
 	"github.com/gin-gonic/gin"
 )
 
+// corsPolicy 根据跨域配置预先计算的响应头
+type corsPolicy struct {
+	allowAllOrigins  bool     // 是否允许所有来源
+	allowedOrigins   []string // 允许的来源
+	allowedHeaders   string   // Access-Control-Allow-Headers 的值
+	allowedMethods   string   // Access-Control-Allow-Methods 的值
+	allowCredentials bool     // 是否允许携带凭证
+	maxAge           string   // Access-Control-Max-Age 的值，为空时不设置
+}
+
+// newCORSPolicy 根据跨域配置创建跨域策略
+func newCORSPolicy(corsConfig config.CORSConfig) *corsPolicy {
+	policy := &corsPolicy{
+		allowAllOrigins:  slices.Contains(corsConfig.AllowedOrigins, "*"),
+		allowedOrigins:   corsConfig.AllowedOrigins,
+		allowedHeaders:   strings.Join(corsConfig.AllowedHeaders, ", "),
+		allowedMethods:   strings.Join(corsConfig.AllowedMethods, ", "),
+		allowCredentials: corsConfig.AllowCredentials,
+	}
+	if corsConfig.MaxAge > 0 {
+		policy.maxAge = strconv.Itoa(corsConfig.MaxAge)
+	}
+	return policy
+}
+
 // CORSMiddleware CORS 中间件
 // 处理跨域资源共享（Cross-Origin Resource Sharing）
-// 允许来自不同域的前端应用访问 API
+// 按配置允许来自不同域的前端应用访问 API，重新加载配置后新的跨域配置对之后的请求生效
+// 参数：corsConfig - 跨域配置，reloader - 配置重新加载器
 // 返回 Gin 中间件函数
-func CORSMiddleware() gin.HandlerFunc {
+func CORSMiddleware(corsConfig config.CORSConfig, reloader *config.Reloader) gin.HandlerFunc {
+	var current atomic.Pointer[corsPolicy]
+	current.Store(newCORSPolicy(corsConfig))
+	reloader.OnReload(func(newConfig *config.Config) {
+		current.Store(newCORSPolicy(newConfig.CORS))
+	})
+
 	return func(c *gin.Context) {
+		policy := current.Load()
+		origin := c.GetHeader("Origin")
+
 		// 设置允许的源（Origin）
-		// "*" 表示允许所有域，生产环境建议设置具体的域名
-		c.Header("Access-Control-Allow-Origin", "*")
+		// 允许所有来源且不携带凭证时返回 "*"，否则回显请求来源（浏览器不接受 "*" 与凭证同时出现）
+		switch {
+		case policy.allowAllOrigins && (!policy.allowCredentials || origin == ""):
+			c.Header("Access-Control-Allow-Origin", "*")
+		case origin != "" && (policy.allowAllOrigins || slices.Contains(policy.allowedOrigins, origin)):
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		}
 
 		// 设置是否允许发送 Cookie
-		c.Header("Access-Control-Allow-Credentials", "true")
-
-		// 设置允许的请求头
-		// 包含常用的 HTTP 请求头
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		if policy.allowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
 
-		// 设置允许的 HTTP 方法
-		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		// 设置允许的请求头和 HTTP 方法
+		c.Header("Access-Control-Allow-Headers", policy.allowedHeaders)
+		c.Header("Access-Control-Allow-Methods", policy.allowedMethods)
+		// 允许前端读取请求ID响应头
+		c.Header("Access-Control-Expose-Headers", define.HeaderRequestID)
+		if policy.maxAge != "" {
+			c.Header("Access-Control-Max-Age", policy.maxAge)
+		}
 
 		// 处理预检请求（Preflight Request）
 		// OPTIONS 请求是浏览器在发送实际请求前的预检请求
-		if c.Request.Method == "OPTIONS" {
+		if c.Request.Method == http.MethodOptions {
 			// 返回 204 状态码，表示预检请求成功
-			c.AbortWithStatus(204)
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
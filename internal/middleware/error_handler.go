@@ -0,0 +1,68 @@
+// Package middleware 提供 HTTP 中间件功能
+package middleware
+
+import (
+	"errors"
+	"lemon-tree-core/internal/apiversion"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ErrorHandlerMiddleware 错误转换中间件
+// 处理器和中间件通过 c.Error 记录错误后直接返回，由本中间件统一转换为标准错误响应
+// 业务错误按错误码映射HTTP状态码，其他错误视为服务器内部错误
+// 参数：logger - Zap 日志记录器
+// 返回 Gin 中间件函数
+func ErrorHandlerMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		// 没有错误或者响应已经写出（如流式响应）时无需处理
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperror.From(err)
+		// 读取请求体时超过大小限制，统一返回 413
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			appErr = apperror.Newf(apperror.CodePayloadTooLarge, "请求内容超过大小限制（%d 字节）", maxBytesErr.Limit)
+		}
+		status := appErr.HTTPStatus()
+		if status >= 500 {
+			logger.Error("Request failed",
+				zap.String("code", string(appErr.Code)),                              // 错误码
+				zap.Error(appErr.Unwrap()),                                           // 底层错误
+				zap.String("message", appErr.Message),                                // 错误信息
+				zap.String("path", c.Request.URL.Path),                               // 请求路径
+				zap.String("method", c.Request.Method),                               // HTTP 方法
+				zap.String("request_id", c.GetString(define.AppContextKeyRequestID)), // 请求ID
+			)
+		}
+
+		c.JSON(status, NewErrorResponse(c, appErr))
+	}
+}
+
+// NewErrorResponse 构建标准错误响应
+// v1 接口额外返回兼容旧版客户端的 error 字段，v2 及以后的接口只返回标准字段
+// 参数：c - Gin 上下文；appErr - 业务错误
+// 返回：标准错误响应
+func NewErrorResponse(c *gin.Context, appErr *apperror.Error) dto.ErrorResponse {
+	response := dto.ErrorResponse{
+		Code:      string(appErr.Code),
+		Message:   appErr.Message,
+		Details:   appErr.Details,
+		RequestID: c.GetString(define.AppContextKeyRequestID),
+	}
+	if apiversion.FromContext(c) == apiversion.V1 {
+		response.Error = appErr.Message
+	}
+	return response
+}
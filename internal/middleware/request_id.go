@@ -0,0 +1,30 @@
+// Package middleware 提供 HTTP 中间件功能
+package middleware
+
+import (
+	"context"
+	"lemon-tree-core/internal/define"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDMiddleware 请求ID中间件
+// 优先使用客户端传入的 X-Request-ID，未传入时生成新的请求ID
+// 请求ID写入上下文和响应头，用于日志关联和错误排查
+// 返回 Gin 中间件函数
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(define.HeaderRequestID)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(define.AppContextKeyRequestID, requestID)
+		ctx := context.WithValue(c.Request.Context(), define.AppContextKeyRequestID, requestID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(define.HeaderRequestID, requestID)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationBackup 应用配置备份
+// 不对应数据库表，包含恢复应用配置所需的全部记录，记录保留原有ID，恢复后智能体ID、API Key 等对外标识保持不变
+// 会话、消息、知识库文档等运行数据以及 OAuth 令牌不在备份范围内
+type ApplicationBackup struct {
+	Version                 int                                       `json:"version"`                     // 备份格式版本
+	CreatedAt               time.Time                                 `json:"created_at"`                  // 备份时间
+	Application             *Application                              `json:"application"`                 // 应用
+	Settings                []*ApplicationSetting                     `json:"settings"`                    // 应用设置
+	FeatureFlags            []*ApplicationFeatureFlag                 `json:"feature_flags"`               // 应用单独设置的功能开关
+	StorageConfigs          []*ApplicationStorageConfig               `json:"storage_configs"`             // 存储配置
+	NetSearchConfigs        []*ApplicationInternalToolNetSearchConfig `json:"net_search_configs"`          // 联网搜索配置
+	LlmProviders            []*ApplicationLlmProvider                 `json:"llm_providers"`               // 大语言模型供应商
+	Llms                    []*ApplicationLlm                         `json:"llms"`                        // 大语言模型
+	McpServerConfigs        []*ApplicationMcpServerConfig             `json:"mcp_server_configs"`          // MCP服务配置
+	McpServerTools          []*ApplicationMcpServerTool               `json:"mcp_server_tools"`            // MCP服务工具
+	ToolBundles             []*ApplicationToolBundle                  `json:"tool_bundles"`                // 工具集
+	ToolBundleTools         []*ApplicationToolBundleTool              `json:"tool_bundle_tools"`           // 工具集包含的工具
+	KnowledgeBases          []*KnowledgeBase                          `json:"knowledge_bases"`             // 知识库，只包含设置，文档需要重新导入
+	ChatAgents              []*ChatAgent                              `json:"chat_agents"`                 // 智能体
+	ChatAgentMcpServerTools []*ChatAgentMcpServerTool                 `json:"chat_agent_mcp_server_tools"` // 智能体的MCP工具设置
+	ChatAgentToolBundles    []*ChatAgentToolBundle                    `json:"chat_agent_tool_bundles"`     // 智能体绑定的工具集
+	ChatAgentAnswerRules    []*ChatAgentAnswerRule                    `json:"chat_agent_answer_rules"`     // 智能体的固定回答规则
+	ChatAgentApiKeys        []*ChatAgentApiKey                        `json:"chat_agent_api_keys"`         // 智能体的 API Key
+	Webhooks                []*ApplicationWebhook                     `json:"webhooks"`                    // 应用 Webhook
+	// 以下为模型 JSON 中不输出的字段，按记录ID保存
+	McpOauthClientSecrets map[uuid.UUID]string `json:"mcp_oauth_client_secrets"` // MCP服务的 OAuth 客户端密钥明文，恢复时使用目标环境的密钥重新加密
+	AnswerRuleEmbeddings  map[uuid.UUID]string `json:"answer_rule_embeddings"`   // 固定回答规则示例问题的嵌入向量缓存
+}
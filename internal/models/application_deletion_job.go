@@ -0,0 +1,30 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationDeletionJob 应用删除任务
+// 应用删除后由后台任务按步骤分批删除其智能体、会话、模型、MCP配置等关联数据，数据量较大时可查询进度
+type ApplicationDeletionJob struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;index:idx_application_deletion_job_app;comment:被删除的应用ID"`
+	Status         string     `json:"status" gorm:"type:varchar(16);not null;default:'pending';index:idx_application_deletion_job_status;comment:任务状态：pending running completed"`
+	TotalSteps     int        `json:"total_steps" gorm:"type:int;not null;default:0;comment:删除步骤总数"`
+	CompletedSteps int        `json:"completed_steps" gorm:"type:int;not null;default:0;comment:已完成的步骤数"`
+	CurrentStep    string     `json:"current_step" gorm:"type:varchar(64);not null;default:'';comment:正在执行的步骤"`
+	DeletedCounts  string     `json:"deleted_counts" gorm:"type:text;comment:各步骤已删除的记录数（JSON对象）"`
+	ErrorMessage   string     `json:"error_message" gorm:"type:text;comment:最近一次执行失败的原因，下次执行时重试"`
+	StartedAt      *time.Time `json:"started_at" gorm:"comment:开始执行时间"`
+	FinishedAt     *time.Time `json:"finished_at" gorm:"comment:结束时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationDeletionJob) TableName() string {
+	return "ltc_application_deletion_job"
+}
@@ -0,0 +1,23 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationFeatureFlag 应用功能开关
+// 应用对单个功能开关的设置，优先于全局灰度比例；没有记录的开关按全局灰度比例决定是否开启
+type ApplicationFeatureFlag struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;uniqueIndex:idx_application_feature_flag,priority:1;comment:所属应用ID"`
+	Flag           string    `json:"flag" gorm:"type:varchar(64);not null;uniqueIndex:idx_application_feature_flag,priority:2;comment:功能开关名称"`
+	Enabled        bool      `json:"enabled" gorm:"not null;comment:是否开启"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationFeatureFlag) TableName() string {
+	return "ltc_application_feature_flag"
+}
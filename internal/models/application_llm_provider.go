@@ -17,6 +17,8 @@ type ApplicationLlmProvider struct {
 	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
 	ApiUrl         string    `json:"api_url" gorm:"type:varchar(512);not null;comment:大语言模型供应商API URL"`
 	ApiKey         string    `json:"api_key" gorm:"type:varchar(512);not null;comment:大语言模型供应商API Key"`
+	MaxConcurrency int       `json:"max_concurrency" gorm:"type:int;not null;default:0;comment:同时处理的请求数上限，0表示使用全局默认值"` // 超出上限的请求排队等待
+	Version        int64     `json:"version" gorm:"type:bigint;not null;default:1;comment:数据版本号"`                      // 每次更新加1，保存时校验以避免覆盖他人的修改
 }
 
 // TableName 指定数据库表名
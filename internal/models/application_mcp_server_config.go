@@ -10,8 +10,8 @@ import (
 // ApplicationMcpServerConfig 应用mcp配置
 type ApplicationMcpServerConfig struct {
 	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
-	ConfigID       string    `json:"config_id" gorm:"type:varchar(64);not null;comment:配置ID"`
-	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ConfigID       string    `json:"config_id" gorm:"type:varchar(64);not null;uniqueIndex:idx_application_mcp_server_config_config_id;comment:配置ID"`
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;index:idx_application_mcp_server_config_application;comment:所属应用ID"`
 	Name           string    `json:"name" gorm:"type:varchar(64);not null;comment:名称"`
 	Description    string    `json:"description" gorm:"type:varchar(512);not null;comment:描述"`
 	Version        string    `json:"version" gorm:"type:varchar(64);not null;comment:版本"`
@@ -21,10 +21,23 @@ type ApplicationMcpServerConfig struct {
 	// sse / streamable-http使用
 	McpServerUrl    string `json:"mcp_server_url" gorm:"type:varchar(512);not null;comment:MCP服务URL"`
 	McpServerHeader string `json:"mcp_server_header" gorm:"type:text;not null;comment:MCP服务请求头"`
+	// sse / streamable-http 的授权方式，oauth 时通过授权流程获取访问令牌并自动携带
+	McpServerAuthType         string `json:"mcp_server_auth_type" gorm:"type:varchar(16);not null;default:'';comment:MCP服务授权方式：空 不需要授权，oauth OAuth授权"`
+	McpOauthClientID          string `json:"mcp_oauth_client_id" gorm:"type:varchar(255);not null;default:'';comment:OAuth客户端ID，为空时首次授权通过动态注册获取"`
+	McpOauthClientSecret      string `json:"-" gorm:"type:text;comment:OAuth客户端密钥（加密保存）"`
+	McpOauthScopes            string `json:"mcp_oauth_scopes" gorm:"type:varchar(512);not null;default:'';comment:OAuth授权范围，空格分隔"`
+	McpOauthServerMetadataURL string `json:"mcp_oauth_server_metadata_url" gorm:"type:varchar(512);not null;default:'';comment:OAuth授权服务器元数据地址，为空时根据MCP服务URL自动发现"`
 	// stdio 使用
 	McpServerCommand string `json:"mcp_server_command" gorm:"type:varchar(512);not null;comment:MCP服务命令"`
 	McpServerArgs    string `json:"mcp_server_args" gorm:"type:varchar(512);not null;comment:MCP服务参数"`
 	McpServerEnv     string `json:"mcp_server_env" gorm:"type:varchar(512);not null;comment:MCP服务环境变量"`
+	// stdio 执行限制，命令需在服务配置的允许列表中
+	McpServerWorkDir        string `json:"mcp_server_work_dir" gorm:"type:varchar(512);not null;default:'';comment:MCP服务工作目录，为空时使用服务进程的工作目录"`
+	McpServerCpuLimit       int    `json:"mcp_server_cpu_limit" gorm:"type:int;not null;default:0;comment:MCP服务进程的CPU时间上限（秒），0 表示不限制"`
+	McpServerMemoryLimit    int    `json:"mcp_server_memory_limit" gorm:"type:int;not null;default:0;comment:MCP服务进程的虚拟内存上限（MB），0 表示不限制"`
+	McpServerMaxRuntime     int    `json:"mcp_server_max_runtime" gorm:"type:int;not null;default:0;comment:MCP服务进程的最长运行时间（秒），0 表示不限制"`
+	McpServerUserNamespace  bool   `json:"mcp_server_user_namespace" gorm:"type:tinyint(1);not null;default:0;comment:是否在独立的用户命名空间中运行，仅 Linux 支持"`
+	McpServerDisableNetwork bool   `json:"mcp_server_disable_network" gorm:"type:tinyint(1);not null;default:0;comment:是否禁止网络访问，需同时启用用户命名空间"`
 }
 
 // TableName 指定数据库表名
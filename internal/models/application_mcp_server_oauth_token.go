@@ -0,0 +1,32 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationMcpServerOauthToken MCP服务的OAuth令牌
+// 每个MCP配置一条记录，保存授权得到的令牌和进行中的授权请求，令牌和 PKCE 校验码加密保存
+type ApplicationMcpServerOauthToken struct {
+	base.BaseModel                          // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID                uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ApplicationMcpServerConfigID uuid.UUID  `json:"application_mcp_server_config_id" gorm:"type:char(36);not null;uniqueIndex:idx_mcp_server_oauth_token_config;comment:所属MCP配置ID"`
+	AccessToken                  string     `json:"-" gorm:"type:text;comment:访问令牌（加密保存）"`
+	RefreshToken                 string     `json:"-" gorm:"type:text;comment:刷新令牌（加密保存）"`
+	TokenType                    string     `json:"token_type" gorm:"type:varchar(32);not null;default:'';comment:令牌类型"`
+	Scope                        string     `json:"scope" gorm:"type:varchar(512);not null;default:'';comment:授权服务器返回的授权范围"`
+	ExpiresAt                    *time.Time `json:"expires_at" gorm:"type:datetime;comment:访问令牌过期时间，为空时不过期"`
+	// 进行中的授权请求，回调时按 state 查找并校验
+	PendingState        string     `json:"-" gorm:"type:varchar(128);not null;default:'';index:idx_mcp_server_oauth_token_state;comment:进行中的授权请求的 state"`
+	PendingCodeVerifier string     `json:"-" gorm:"type:text;comment:进行中的授权请求的 PKCE 校验码（加密保存）"`
+	PendingExpiresAt    *time.Time `json:"-" gorm:"type:datetime;comment:进行中的授权请求的过期时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationMcpServerOauthToken) TableName() string {
+	return "ltc_application_mcp_server_oauth_token"
+}
@@ -0,0 +1,28 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationSetting 应用设置
+// 每个应用最多一条记录，保存应用的品牌展示信息、数据保留策略和用户输入拦截规则，未保存时使用默认值
+type ApplicationSetting struct {
+	base.BaseModel              // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID     uuid.UUID `json:"application_id" gorm:"type:char(36);not null;uniqueIndex:idx_application_setting_application;comment:所属应用ID"`
+	DisplayName       string    `json:"display_name" gorm:"type:varchar(64);not null;default:'';comment:对外展示名称，为空时使用应用名称"`
+	LogoUrl           string    `json:"logo_url" gorm:"type:varchar(512);not null;default:'';comment:Logo地址"`
+	ThemeColor        string    `json:"theme_color" gorm:"type:varchar(16);not null;default:'';comment:主题色，#RRGGBB格式"`
+	DefaultLanguage   string    `json:"default_language" gorm:"type:varchar(32);not null;default:'';comment:默认界面语言"`
+	DataRetentionDays int       `json:"data_retention_days" gorm:"not null;default:0;comment:会话数据保留天数，0表示永久保留"`
+	BlockedPatterns   string    `json:"blocked_patterns" gorm:"type:text;comment:用户输入拦截规则，JSON数组格式的正则表达式列表，忽略大小写匹配"`
+	BlockedReply      string    `json:"blocked_reply" gorm:"type:varchar(512);not null;default:'';comment:用户输入被拦截时的回复，为空时使用默认回复"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationSetting) TableName() string {
+	return "ltc_application_setting"
+}
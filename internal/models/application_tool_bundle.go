@@ -0,0 +1,23 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationToolBundle 应用级工具集
+// 将多个MCP工具组合为命名的工具集（如"客服工具"），关联到智能体后工具集中的工具对智能体启用，修改工具集对所有关联的智能体生效
+type ApplicationToolBundle struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;index:idx_application_tool_bundle_application;comment:所属应用ID"`
+	Name           string    `json:"name" gorm:"type:varchar(64);not null;comment:工具集名称，同一应用内唯一"`
+	Description    string    `json:"description" gorm:"type:varchar(512);not null;default:'';comment:描述"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationToolBundle) TableName() string {
+	return "ltc_application_tool_bundle"
+}
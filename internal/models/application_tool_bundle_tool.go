@@ -0,0 +1,22 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationToolBundleTool 工具集包含的MCP工具
+type ApplicationToolBundleTool struct {
+	base.BaseModel                       // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID              uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	BundleID                   uuid.UUID `json:"bundle_id" gorm:"type:char(36);not null;index:idx_application_tool_bundle_tool_bundle;comment:所属工具集ID"`
+	ApplicationMcpServerToolID uuid.UUID `json:"application_mcp_server_tool_id" gorm:"type:char(36);not null;comment:MCP工具ID"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationToolBundleTool) TableName() string {
+	return "ltc_application_tool_bundle_tool"
+}
@@ -0,0 +1,26 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationWebhook 应用的 Webhook 订阅
+// 应用下的会话事件按订阅的事件类型推送到 Webhook 地址，请求体使用密钥签名
+type ApplicationWebhook struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;index:idx_application_webhook_application;comment:所属应用ID"`
+	Name           string    `json:"name" gorm:"type:varchar(64);not null;comment:名称"`
+	Url            string    `json:"url" gorm:"type:varchar(1024);not null;comment:推送地址，http 或 https"`
+	Secret         string    `json:"secret" gorm:"type:varchar(128);not null;comment:签名密钥"`
+	EventTypes     string    `json:"event_types" gorm:"type:text;comment:订阅的事件类型（JSON数组），为空表示订阅全部事件"`
+	Enabled        bool      `json:"enabled" gorm:"not null;default:true;comment:是否启用，停用后不再生成新的推送记录"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationWebhook) TableName() string {
+	return "ltc_application_webhook"
+}
@@ -0,0 +1,31 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationWebhookDelivery 会话事件推送到 Webhook 的记录
+// 推送失败时按指数退避重试，重试次数耗尽后标记为失败，可在管理后台手动重新推送
+type ApplicationWebhookDelivery struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	WebhookID      uuid.UUID  `json:"webhook_id" gorm:"type:char(36);not null;index:idx_application_webhook_delivery_webhook;comment:Webhook ID"`
+	EventID        uuid.UUID  `json:"event_id" gorm:"type:char(36);not null;comment:会话事件ID"`
+	EventType      string     `json:"event_type" gorm:"type:varchar(64);not null;comment:事件类型"`
+	Status         string     `json:"status" gorm:"type:varchar(16);not null;default:'pending';index:idx_application_webhook_delivery_due,priority:1;comment:推送状态：pending、delivered、failed"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_application_webhook_delivery_due,priority:2;comment:下次推送时间"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0;comment:已推送次数"`
+	LastStatusCode int        `json:"last_status_code" gorm:"not null;default:0;comment:最近一次推送的HTTP状态码，请求失败时为0"`
+	LastError      string     `json:"last_error" gorm:"type:varchar(512);not null;default:'';comment:最近一次推送失败的原因"`
+	DeliveredAt    *time.Time `json:"delivered_at" gorm:"comment:推送成功时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ApplicationWebhookDelivery) TableName() string {
+	return "ltc_application_webhook_delivery"
+}
@@ -0,0 +1,28 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// BatchInferenceItem 批量推理任务中的单条提示词及其结果
+// 每条提示词在独立的会话中发送，便于事后在会话记录中查看完整过程
+type BatchInferenceItem struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	JobID          uuid.UUID `json:"job_id" gorm:"type:char(36);not null;index:idx_batch_inference_item_job;comment:所属任务ID"`
+	ItemIndex      int       `json:"item_index" gorm:"type:int;not null;comment:提示词在提交内容中的序号，从0开始"`
+	Prompt         string    `json:"prompt" gorm:"type:text;not null;comment:提示词"`
+	Status         string    `json:"status" gorm:"type:varchar(16);not null;default:'pending';comment:处理状态：pending running completed failed cancelled"`
+	Answer         string    `json:"answer" gorm:"type:mediumtext;comment:智能体的回答"`
+	ConversationID string    `json:"conversation_id" gorm:"type:varchar(36);not null;default:'';comment:处理时创建的会话ID"`
+	ErrorMessage   string    `json:"error_message" gorm:"type:text;comment:处理失败的原因"`
+	DurationMs     int64     `json:"duration_ms" gorm:"type:bigint;not null;default:0;comment:处理耗时（毫秒）"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (BatchInferenceItem) TableName() string {
+	return "ltc_batch_inference_item"
+}
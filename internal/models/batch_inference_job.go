@@ -0,0 +1,33 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BatchInferenceJob 批量推理任务
+// 一批提示词提交后由后台任务按并发上限逐条发送给智能体，用于效果评估和历史数据回填
+type BatchInferenceJob struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID  `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_batch_inference_job_agent;comment:执行任务的智能体ID"`
+	Name           string     `json:"name" gorm:"type:varchar(128);not null;default:'';comment:任务名称"`
+	SystemPrompt   string     `json:"system_prompt" gorm:"type:text;comment:附加的系统提示词"`
+	Concurrency    int        `json:"concurrency" gorm:"type:int;not null;default:1;comment:同时处理的提示词数量"`
+	Status         string     `json:"status" gorm:"type:varchar(16);not null;default:'pending';index:idx_batch_inference_job_status;comment:任务状态：pending running completed failed cancelled"`
+	TotalCount     int        `json:"total_count" gorm:"type:int;not null;default:0;comment:提示词总数"`
+	CompletedCount int        `json:"completed_count" gorm:"type:int;not null;default:0;comment:已成功处理的数量"`
+	FailedCount    int        `json:"failed_count" gorm:"type:int;not null;default:0;comment:处理失败的数量"`
+	ErrorMessage   string     `json:"error_message" gorm:"type:text;comment:任务执行失败的原因"`
+	StartedAt      *time.Time `json:"started_at" gorm:"comment:开始执行时间"`
+	FinishedAt     *time.Time `json:"finished_at" gorm:"comment:结束时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (BatchInferenceJob) TableName() string {
+	return "ltc_batch_inference_job"
+}
@@ -27,6 +27,47 @@ type ChatAgent struct {
 	MaxOutputTokenCountLimit       int       `json:"max_output_token_count_limit" gorm:"type:int;not null;comment:最大输出Token数量"`
 	// 这个流式返回只是针对默认的Lemon Tree UI界面，通过API访问时可以通过传参来控制是否流式返回
 	DefaultStreamable bool `json:"default_streamable" gorm:"type:tinyint(1);not null;comment:是否默认流式返回"`
+	// 可用性设置，不可用时直接返回预制答案，不调用模型
+	MaintenanceMode            bool   `json:"maintenance_mode" gorm:"type:tinyint(1);not null;default:0;comment:是否处于维护模式"`
+	MaintenanceAnswer          string `json:"maintenance_answer" gorm:"type:text;comment:维护模式下的预制答案"`
+	EnableAvailabilitySchedule bool   `json:"enable_availability_schedule" gorm:"type:tinyint(1);not null;default:0;comment:是否启用服务时间"`
+	AvailabilitySchedule       string `json:"availability_schedule" gorm:"type:text;comment:服务时间配置（JSON）"`
+	UnavailableAnswer          string `json:"unavailable_answer" gorm:"type:text;comment:服务时间外的预制答案"`
+	// 首屏设置，嵌入页面打开时展示的欢迎语和推荐问题
+	WelcomeMessage     string `json:"welcome_message" gorm:"type:text;comment:欢迎语"`
+	SuggestedQuestions string `json:"suggested_questions" gorm:"type:text;comment:推荐问题列表（JSON数组）"`
+	// 回答完成后是否使用会话命名模型生成追问建议
+	EnableFollowUpSuggestions bool `json:"enable_follow_up_suggestions" gorm:"type:tinyint(1);not null;default:0;comment:是否生成追问建议"`
+	// 翻译工具设置，启用后模型可调用翻译内部工具，使用指定的翻译模型翻译文本
+	EnableTranslation  bool      `json:"enable_translation" gorm:"type:tinyint(1);not null;default:0;comment:是否启用翻译工具"`
+	TranslationModelID uuid.UUID `json:"translation_model_id" gorm:"type:char(36);comment:翻译使用的模型ID"`
+	// 强制回复语言：为空时不限制，auto 跟随用户消息语言，其他值为语言代码（如 zh、en）或语言名称
+	ReplyLanguage string `json:"reply_language" gorm:"type:varchar(32);not null;default:'';comment:强制回复语言"`
+	// 用户输入预处理配置，为空时使用默认配置
+	InputPreprocessConfig string `json:"input_preprocess_config" gorm:"type:text;comment:用户输入预处理配置（JSON）"`
+	// 知识库检索设置，绑定知识库后回答前检索相关片段注入提示词，并要求模型标注引用
+	KnowledgeBaseIDs           string  `json:"knowledge_base_ids" gorm:"type:text;comment:绑定的知识库ID列表（JSON数组）"`
+	KnowledgeRetrievalTopK     int     `json:"knowledge_retrieval_top_k" gorm:"type:int;not null;default:0;comment:每次检索注入的最大片段数，0 表示使用默认值"`
+	KnowledgeRetrievalMinScore float64 `json:"knowledge_retrieval_min_score" gorm:"type:decimal(5,4);not null;default:0;comment:检索片段的最低相似度（0-1）"`
+	KnowledgeRetrievalMode     string  `json:"knowledge_retrieval_mode" gorm:"type:varchar(16);not null;default:'';comment:检索方式：vector 向量检索，hybrid 混合检索，为空时使用向量检索"`
+	// 知识库检索重排设置，配置重排模型后先按向量相似度取出候选片段，再由重排模型选出最相关的片段注入
+	KnowledgeRerankModelID uuid.UUID `json:"knowledge_rerank_model_id" gorm:"type:char(36);comment:重排模型ID，为空时不重排"`
+	KnowledgeRerankTopK    int       `json:"knowledge_rerank_top_k" gorm:"type:int;not null;default:0;comment:交给重排模型的候选片段数，0 表示使用默认值"`
+	// 工具结果长度限制，工具返回的内容超过上限时截断或摘要后再提供给模型，原始内容保存在消息记录中
+	ToolOutputMaxLength    int    `json:"tool_output_max_length" gorm:"type:int;not null;default:0;comment:提供给模型的工具结果最大字符数，0 表示不限制"`
+	ToolOutputOverflowMode string `json:"tool_output_overflow_mode" gorm:"type:varchar(16);not null;default:'';comment:工具结果超长时的处理方式：truncate 截断，summarize 摘要，为空时截断"`
+	// 知识库参考资料、会话变量等注入给模型的上下文使用的消息角色，支持 developer 角色的模型可设置为 developer
+	InjectedMessageRole string `json:"injected_message_role" gorm:"type:varchar(16);not null;default:'';comment:注入消息使用的角色：system、developer，为空时使用 system"`
+	// 请求和回答限制，超过用户消息长度上限时拒绝请求，回答超过时长或输出Token上限时截断并通知调用者
+	MaxUserMessageLength     int `json:"max_user_message_length" gorm:"type:int;not null;default:0;comment:用户消息最大字符数，0 表示不限制"`
+	MaxAnswerDurationSeconds int `json:"max_answer_duration_seconds" gorm:"type:int;not null;default:0;comment:单次回答（含工具调用）的最长生成时间（秒），0 表示不限制"`
+	MaxAnswerOutputTokens    int `json:"max_answer_output_tokens" gorm:"type:int;not null;default:0;comment:单次回答（含工具调用后的多轮模型调用）的输出Token总数上限，0 表示不限制"`
+	// 会话变量设置，启用后模型可调用内部工具设置会话变量；通过接口设置的变量不受此限制
+	EnableConversationVariables bool `json:"enable_conversation_variables" gorm:"type:tinyint(1);not null;default:0;comment:是否允许模型设置会话变量"`
+	// 嵌入式聊天窗口允许的来源，为空时不能创建窗口令牌
+	AllowedOrigins string `json:"allowed_origins" gorm:"type:text;comment:允许嵌入聊天窗口的来源列表（JSON数组）"`
+	// 数据版本号，每次更新加1，保存时校验以避免覆盖他人的修改
+	Version int64 `json:"version" gorm:"type:bigint;not null;default:1;comment:数据版本号"`
 }
 
 // TableName 指定数据库表名
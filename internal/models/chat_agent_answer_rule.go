@@ -0,0 +1,31 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentAnswerRule 智能体预制答案规则
+// 用户消息命中规则时直接返回预制答案，不调用模型
+type ChatAgentAnswerRule struct {
+	base.BaseModel               // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID      uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID        uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_agent_answer_rule_agent;comment:所属的聊天智能体ID"`
+	Name               string    `json:"name" gorm:"type:varchar(128);not null;comment:规则名称"`
+	MatchType          string    `json:"match_type" gorm:"type:varchar(32);not null;comment:匹配方式：keyword 关键词，regex 正则，embedding 语义相似度"`
+	Pattern            string    `json:"pattern" gorm:"type:text;not null;comment:匹配内容：关键词（换行或逗号分隔）、正则表达式或示例问题"`
+	Answer             string    `json:"answer" gorm:"type:text;not null;comment:预制答案"`
+	Priority           int       `json:"priority" gorm:"type:int;not null;default:0;comment:优先级，数值越大越先匹配"`
+	Enabled            bool      `json:"enabled" gorm:"type:tinyint(1);not null;comment:是否启用"`
+	EmbeddingModelID   uuid.UUID `json:"embedding_model_id" gorm:"type:char(36);comment:语义匹配使用的嵌入模型ID"`
+	EmbeddingThreshold float64   `json:"embedding_threshold" gorm:"type:decimal(5,4);not null;default:0;comment:语义匹配的相似度阈值（0-1）"`
+	Embedding          string    `json:"-" gorm:"type:mediumtext;comment:示例问题的嵌入向量缓存（JSON数组）"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentAnswerRule) TableName() string {
+	return "ltc_chat_agent_answer_rule"
+}
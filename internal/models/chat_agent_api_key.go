@@ -12,9 +12,11 @@ type ChatAgentApiKey struct {
 	Name           string    `json:"name" gorm:"type:varchar(64);not null;comment:Key名称"`
 	Description    string    `json:"description" gorm:"type:varchar(512);not null;comment:Key描述"`
 	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:应用ID"`
-	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:智能体ID"`
-	ApiKey         string    `json:"api_key" gorm:"type:varchar(512);not null;comment:API Key"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_agent_api_key_agent;comment:智能体ID"`
+	ApiKey         string    `json:"api_key" gorm:"type:varchar(512);not null;uniqueIndex:idx_chat_agent_api_key_key;comment:API Key"`
 	ApiSecret      string    `json:"api_secret" gorm:"type:varchar(512);not null;comment:API Secret"`
+	AllowedCidrs   string    `json:"allowed_cidrs" gorm:"type:text;comment:允许访问的IP网段列表（JSON数组），为空时不限制"`
+	DeniedCidrs    string    `json:"denied_cidrs" gorm:"type:text;comment:拒绝访问的IP网段列表（JSON数组），优先于允许列表"`
 }
 
 // TableName 指定数据库表名
@@ -0,0 +1,34 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// API Key 访问被拒绝的原因
+const (
+	ChatAgentApiKeyRejectionReasonDenied     = "denied"      // 来源IP在拒绝列表中
+	ChatAgentApiKeyRejectionReasonNotAllowed = "not_allowed" // 来源IP不在允许列表中
+	ChatAgentApiKeyRejectionReasonInvalidIP  = "invalid_ip"  // 无法识别来源IP
+)
+
+// ChatAgentApiKeyRejection API Key 访问被拒绝的审计记录
+// 来源IP不满足 API Key 的网段限制时记录一条，按配置的保留天数定期清理
+type ChatAgentApiKeyRejection struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_agent_api_key_rejection_agent;comment:智能体ID"`
+	ApiKeyID       uuid.UUID `json:"api_key_id" gorm:"type:char(36);not null;comment:API Key ID"`
+	ClientIP       string    `json:"client_ip" gorm:"type:varchar(64);not null;comment:来源IP"`
+	Reason         string    `json:"reason" gorm:"type:varchar(32);not null;comment:拒绝原因：denied、not_allowed、invalid_ip"`
+	Path           string    `json:"path" gorm:"type:varchar(512);not null;default:'';comment:请求路径或gRPC方法"`
+	UserAgent      string    `json:"user_agent" gorm:"type:varchar(512);not null;default:'';comment:客户端标识"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentApiKeyRejection) TableName() string {
+	return "ltc_chat_agent_api_key_rejection"
+}
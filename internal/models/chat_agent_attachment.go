@@ -12,8 +12,8 @@ type ChatAgentAttachment struct {
 	Title          string    `json:"title" gorm:"type:varchar(64);not null;comment:会话标题"`
 	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
 	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属Chat Agent ID"`
-	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;comment:所属会话ID"`
-	MessageID      uuid.UUID `json:"message_id" gorm:"type:char(36);not null;comment:所属消息ID"`
+	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;index:idx_chat_agent_attachment_conversation;comment:所属会话ID"`
+	MessageID      uuid.UUID `json:"message_id" gorm:"type:char(36);not null;index:idx_chat_agent_attachment_message;comment:所属消息ID"`
 
 	// 文件信息
 	OriginalFileName string `json:"original_file_name" gorm:"type:varchar(256);not null;comment:原始文件名"`
@@ -3,17 +3,32 @@ package models
 
 import (
 	"lemon-tree-core/internal/base"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // ChatAgentConversation 聊天智能体的会话
 type ChatAgentConversation struct {
-	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
-	Title          string    `json:"title" gorm:"type:varchar(64);not null;comment:会话标题"`
-	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
-	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属Chat Agent ID"`
-	ServiceUserID  string    `json:"service_user_id" gorm:"type:varchar(256);not null;comment:业务侧的用户ID"`
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	Title          string     `json:"title" gorm:"type:varchar(64);not null;comment:会话标题"`
+	ApplicationID  uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID  `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_agent_conversation_agent_user_active,priority:1;comment:所属Chat Agent ID"`
+	ServiceUserID  string     `json:"service_user_id" gorm:"type:varchar(256);not null;index:idx_chat_agent_conversation_agent_user_active,priority:2;comment:业务侧的用户ID"`
+	ErrorCount     int        `json:"error_count" gorm:"type:int;not null;default:0;comment:处理消息时发生错误的次数"`
+	LastErrorAt    *time.Time `json:"last_error_at" gorm:"comment:最后一次发生错误的时间"`
+	// 保存失败且重试次数耗尽的消息数量，大于0表示会话记录存在缺失，需要运维排查
+	PersistenceGapCount  int        `json:"persistence_gap_count" gorm:"type:int;not null;default:0;comment:保存失败且放弃重试的消息数量"`
+	LastPersistenceGapAt *time.Time `json:"last_persistence_gap_at" gorm:"comment:最后一次放弃重试保存消息的时间"`
+	ActiveRequestID      string     `json:"active_request_id" gorm:"type:varchar(64);not null;default:'';comment:正在处理的消息请求ID，为空表示空闲"`
+	ActiveRequestAt      *time.Time `json:"active_request_at" gorm:"comment:开始处理当前消息请求的时间"`
+	// 最后一条普通消息的时间，创建会话时为创建时间，会话列表默认按此倒序排列
+	LastMessageAt *time.Time `json:"last_message_at" gorm:"index:idx_chat_agent_conversation_agent_user_active,priority:3;comment:最后一条普通消息的时间"`
+	// 会话累计的token用量和费用，保存带有token用量的消息时在同一事务中累加，查询时不需要汇总消息
+	PromptTokenCount     int64   `json:"prompt_token_count" gorm:"type:bigint;not null;default:0;comment:累计提示词token数"`
+	CompletionTokenCount int64   `json:"completion_token_count" gorm:"type:bigint;not null;default:0;comment:累计回复token数"`
+	TotalTokenCount      int64   `json:"total_token_count" gorm:"type:bigint;not null;default:0;comment:累计总token数"`
+	Cost                 float64 `json:"cost" gorm:"type:decimal(20,6);not null;default:0;comment:累计费用，按模型的计费价格计算"`
 }
 
 // TableName 指定数据库表名
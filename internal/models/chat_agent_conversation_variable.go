@@ -0,0 +1,26 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentConversationVariable 会话变量
+// 业务侧通过接口或模型通过内部工具设置，后续的工具调用参数和系统提示词模板中可以引用，同一会话内变量名称唯一
+type ChatAgentConversationVariable struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属的聊天智能体ID"`
+	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;index:idx_chat_agent_conversation_variable_conversation;comment:所属会话ID"`
+	Name           string    `json:"name" gorm:"type:varchar(64);not null;comment:变量名称"`
+	Value          string    `json:"value" gorm:"type:text;not null;comment:变量值"`
+	Source         string    `json:"source" gorm:"type:varchar(16);not null;default:'';comment:最后一次设置的来源：api 业务侧接口，model 模型调用内部工具"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentConversationVariable) TableName() string {
+	return "ltc_chat_agent_conversation_variable"
+}
@@ -8,11 +8,18 @@ import (
 )
 
 // ChatAgentMcpServerTool ChatAgentMcp配置
+// 覆盖字段只影响该智能体提供给模型的工具定义，不修改MCP服务器上的工具
 type ChatAgentMcpServerTool struct {
 	base.BaseModel                       // 继承基础模型，包含 ID、时间戳等通用字段
 	ChatAgentID                uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属的聊天智能体ID"`
 	ApplicationMcpServerToolID uuid.UUID `json:"application_mcp_server_tool_id" gorm:"type:char(36);not null;comment:所属的mcp服务工具ID"`
 	Enabled                    bool      `json:"enabled" gorm:"type:tinyint(1);not null;comment:是否启用"`
+	TitleOverride              string    `json:"title_override" gorm:"type:varchar(128);not null;default:'';comment:覆盖的工具标题，为空时不覆盖"`
+	DescriptionOverride        string    `json:"description_override" gorm:"type:text;comment:覆盖的工具描述，为空时使用MCP服务器提供的描述"`
+	// 覆盖的参数描述，JSON对象，键为参数名称，值为参数描述
+	ParameterDescriptionOverrides string `json:"parameter_description_overrides" gorm:"type:text;comment:覆盖的参数描述（JSON对象）"`
+	// 工具结果的渲染方式，随工具结果返回给聊天界面，为空时按原始内容展示
+	OutputRenderer string `json:"output_renderer" gorm:"type:varchar(16);not null;default:'';comment:工具结果渲染方式：table 表格，image 图片，file 文件，json JSON"`
 }
 
 // TableName 指定数据库表名
@@ -4,6 +4,7 @@ package models
 import (
 	"github.com/google/uuid"
 	"lemon-tree-core/internal/base"
+	"time"
 )
 
 // ChatAgentMessage 聊天智能体的聊天具体消息
@@ -12,29 +13,55 @@ type ChatAgentMessage struct {
 	Title          string    `json:"title" gorm:"type:varchar(64);not null;comment:会话标题"`
 	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
 	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属Chat Agent ID"`
-	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;comment:所属会话ID"`
-	RequestID      string    `json:"request_id" gorm:"type:varchar(64);not null;comment:请求ID，同一条消息相关的子消息请求ID一致"`
-	// 消息类型： message 普通消息 function_call 函数调用 function_call_output 函数调用返回值
+	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;index:idx_chat_agent_message_conversation_request;comment:所属会话ID"`
+	RequestID      string    `json:"request_id" gorm:"type:varchar(64);not null;index:idx_chat_agent_message_conversation_request;comment:请求ID，同一条消息相关的子消息请求ID一致"`
+	// 消息类型： message 普通消息 function_call 函数调用 function_call_output 函数调用返回值 blocked 命中输入拦截规则的用户消息和拦截回复
+	// injected 注入给模型的上下文（角色为 system 或 developer），不出现在面向业务侧用户的消息列表中
 	Type string `json:"type" gorm:"type:varchar(32);not null;comment:消息类型"`
 
 	// 下面字段仅在type为message时有用
 	Role    string `json:"role" gorm:"type:varchar(32);not null;comment:消息角色"`
 	Content string `json:"content" gorm:"type:text;not null;comment:消息内容"`
+	// 用户消息经预处理识别出的语言，未识别时为空
+	Language string `json:"language" gorm:"type:varchar(16);not null;default:'';comment:消息语言"`
+	// 助手回答超过智能体的生成时间或输出Token上限被截断时的原因，未截断时为空
+	TruncatedReason string `json:"truncated_reason" gorm:"type:varchar(32);not null;default:'';comment:回答截断原因：duration、output_tokens"`
+	// 注入消息的来源，仅在type为injected时有值
+	InjectedSource string `json:"injected_source" gorm:"type:varchar(32);not null;default:'';comment:注入消息的来源：knowledge_retrieval、conversation_variables"`
 
 	// 下面字段仅在消息类型是function_call 和 function_call_output时有用
 	FunctionCallID        string `json:"function_call_id" gorm:"type:varchar(64);not null;comment:函数调用ID"`
 	FunctionCallName      string `json:"function_call_name" gorm:"type:varchar(128);not null;comment:函数调用名称"`
 	FunctionCallArguments string `json:"function_call_arguments" gorm:"type:text;not null;comment:函数调用参数"`
 	FunctionCallOutput    string `json:"function_call_output" gorm:"type:text;not null;comment:函数调用返回值"`
+	// 工具返回的原始内容，超过智能体的工具结果长度上限被截断或摘要时保存，此时 FunctionCallOutput 为提供给模型的内容
+	FunctionCallRawOutput string `json:"function_call_raw_output" gorm:"type:longtext;comment:函数调用的原始返回值"`
+	// 工具结果的渲染方式，取自调用时智能体的工具设置，为空时按原始内容展示
+	FunctionCallOutputRenderer string `json:"function_call_output_renderer" gorm:"type:varchar(16);not null;default:'';comment:函数调用返回值的渲染方式"`
 
 	// token数统计，在type是message，且role是system 和 user时都为0，或者function_call_output时为0，其他情况下有值
-	// 总之就是在服务器端回复的消息才有值
+	// 总之就是在服务器端回复的消息才有值：一次模型调用的用量记录在该次调用产生的助手回复或第一条工具调用消息上
 	PromptTokenCount     int `json:"prompt_token_count" gorm:"type:int;not null;comment:提示词token数"`
 	CompletionTokenCount int `json:"completion_token_count" gorm:"type:int;not null;comment:回复token数"`
 	TotalTokenCount      int `json:"total_token_count" gorm:"type:int;not null;comment:总token数"`
+	// 模型调用的费用，按调用时模型的计费价格计算，与token数记录在同一条消息上
+	Cost float64 `json:"cost" gorm:"type:decimal(20,6);not null;default:0;comment:模型调用费用"`
 
-	// 附件消息 {id: 'xxx', name: 'xxx.docx'}[]这种格式的json
-	AttachmentsInfo string `json:"attachments_info" gorm:"type:text;not null;comment:附件信息"`
+	// 助手回答引用的知识库片段，JSON数组，未检索知识库时为空
+	Citations string `json:"citations" gorm:"type:mediumtext;comment:知识库引用（JSON数组）"`
+
+	// 助手回答后生成的追问建议，JSON字符串数组，未生成时为空
+	Suggestions string `json:"suggestions" gorm:"type:text;comment:追问建议（JSON数组）"`
+
+	// 助手回复的送达和已读时间，由会话所属业务侧用户的客户端上报，多端据此同步已读状态
+	DeliveredAt *time.Time `json:"delivered_at" gorm:"comment:送达时间"`
+	ReadAt      *time.Time `json:"read_at" gorm:"comment:已读时间"`
+
+	// 已废弃：附件通过 ChatAgentAttachment.MessageID 关联到消息，不再写入和读取，保留列兼容已有数据
+	AttachmentsInfo string `json:"attachments_info" gorm:"type:text;not null;comment:附件信息（已废弃）"`
+
+	// 消息的附件，不保存到消息表，由 ChatAgentMessageRepository.LoadAttachments 按 MessageID 加载
+	Attachments []*ChatAgentAttachment `json:"-" gorm:"-"`
 }
 
 // TableName 指定数据库表名
@@ -0,0 +1,24 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentMessageRequest 会话中已使用的客户端请求ID
+// 客户端指定请求ID时先登记一条记录，(会话ID, 请求ID) 唯一索引保证并发发送同一请求ID时只有一个请求成功
+type ChatAgentMessageRequest struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属智能体ID"`
+	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;uniqueIndex:idx_chat_agent_message_request,priority:1;comment:所属会话ID"`
+	RequestID      string    `json:"request_id" gorm:"type:varchar(64);not null;uniqueIndex:idx_chat_agent_message_request,priority:2;comment:客户端指定的请求ID"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentMessageRequest) TableName() string {
+	return "ltc_chat_agent_message_request"
+}
@@ -0,0 +1,23 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentToolBundle 智能体关联的工具集
+// 工具集中的工具与智能体自身启用的MCP工具合并后提供给模型
+type ChatAgentToolBundle struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_agent_tool_bundle_chat_agent;comment:所属的聊天智能体ID"`
+	BundleID       uuid.UUID `json:"bundle_id" gorm:"type:char(36);not null;index:idx_chat_agent_tool_bundle_bundle;comment:工具集ID"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentToolBundle) TableName() string {
+	return "ltc_chat_agent_tool_bundle"
+}
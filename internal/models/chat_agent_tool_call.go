@@ -0,0 +1,29 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentToolCall 智能体的工具调用记录
+// 每次模型调用MCP工具或内部工具时记录一条，用于统计工具的调用次数、成功率和耗时
+type ChatAgentToolCall struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_agent_tool_call_agent;comment:所属的聊天智能体ID"`
+	ConversationID uuid.UUID `json:"conversation_id" gorm:"type:char(36);not null;comment:所属会话ID"`
+	RequestID      string    `json:"request_id" gorm:"type:varchar(64);not null;comment:请求ID"`
+	ToolType       string    `json:"tool_type" gorm:"type:varchar(16);not null;comment:工具类型：mcp MCP工具，internal 内部工具"`
+	ToolName       string    `json:"tool_name" gorm:"type:varchar(128);not null;comment:提供给模型的工具名称（函数名称）"`
+	Success        bool      `json:"success" gorm:"type:tinyint(1);not null;comment:是否调用成功"`
+	DurationMs     int64     `json:"duration_ms" gorm:"type:bigint;not null;default:0;comment:调用耗时（毫秒）"`
+	ErrorMessage   string    `json:"error_message" gorm:"type:varchar(512);not null;default:'';comment:调用失败的原因"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentToolCall) TableName() string {
+	return "ltc_chat_agent_tool_call"
+}
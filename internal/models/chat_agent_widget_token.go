@@ -0,0 +1,30 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatAgentWidgetToken 嵌入式聊天窗口令牌
+// 业务服务端使用 ApiKey 为指定的业务侧用户换取，浏览器直接使用，只能从创建时指定的来源（Origin）访问，只保存令牌的 sha256 摘要
+// 令牌只能访问绑定的业务侧用户的会话和附件
+// 修改数据的请求需要回传由 CSRFKey 对令牌ID签名得到的 CSRF 令牌，CSRF 令牌与窗口令牌绑定，不依赖 Cookie
+type ChatAgentWidgetToken struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;comment:智能体ID"`
+	ServiceUserID  string    `json:"service_user_id" gorm:"type:varchar(256);not null;default:'';comment:绑定的业务侧用户ID"`
+	Origin         string    `json:"origin" gorm:"type:varchar(255);not null;comment:允许访问的来源，如 https://www.example.com"`
+	TokenHash      string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_chat_agent_widget_token_hash;comment:令牌摘要"`
+	CSRFKey        string    `json:"-" gorm:"type:varchar(64);not null;default:'';comment:签发CSRF令牌使用的密钥"`
+	ExpiredAt      time.Time `json:"expired_at" gorm:"type:datetime;not null;index:idx_chat_agent_widget_token_expired;comment:过期时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatAgentWidgetToken) TableName() string {
+	return "ltc_chat_agent_widget_token"
+}
@@ -0,0 +1,43 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChatStream 集群模式下保存的流式回复
+// 生成回复的实例定期写入事件，客户端断线后连接到其他实例时从数据库续传
+type ChatStream struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ChatAgentID    uuid.UUID  `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_chat_stream_request;comment:所属智能体ID"`
+	ConversationID uuid.UUID  `json:"conversation_id" gorm:"type:char(36);not null;comment:所属会话ID"`
+	ServiceUserID  string     `json:"service_user_id" gorm:"type:varchar(256);not null;default:'';comment:发送消息的业务侧用户ID"`
+	RequestID      string     `json:"request_id" gorm:"type:varchar(64);not null;index:idx_chat_stream_request;comment:请求ID"`
+	InstanceID     string     `json:"instance_id" gorm:"type:varchar(128);not null;comment:生成回复的实例ID"`
+	EventCount     int        `json:"event_count" gorm:"not null;default:0;comment:已写入的事件数量"`
+	Done           bool       `json:"done" gorm:"not null;default:false;comment:回复是否已结束"`
+	FinishedAt     *time.Time `json:"finished_at" gorm:"index:idx_chat_stream_finished;comment:回复结束时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatStream) TableName() string {
+	return "ltc_chat_stream"
+}
+
+// ChatStreamEvent 流式回复的一个事件
+type ChatStreamEvent struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	StreamID       uuid.UUID `json:"stream_id" gorm:"type:char(36);not null;uniqueIndex:idx_chat_stream_event_seq;comment:所属流式回复ID"`
+	Seq            int       `json:"seq" gorm:"not null;uniqueIndex:idx_chat_stream_event_seq;comment:事件编号，从1开始"`
+	Data           string    `json:"data" gorm:"type:mediumtext;comment:事件内容（JSON）"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ChatStreamEvent) TableName() string {
+	return "ltc_chat_stream_event"
+}
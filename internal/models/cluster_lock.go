@@ -0,0 +1,22 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+)
+
+// ClusterLock 集群分布式锁
+// 集群模式下多个实例通过同一行记录竞争锁，持有者在租约到期前续约，异常退出后租约到期由其他实例接管
+type ClusterLock struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	Name           string    `json:"name" gorm:"type:varchar(128);not null;uniqueIndex:idx_cluster_lock_name;comment:锁名称"`
+	Owner          string    `json:"owner" gorm:"type:varchar(128);not null;comment:持有锁的实例ID"`
+	ExpiresAt      time.Time `json:"expires_at" gorm:"type:datetime;not null;comment:租约到期时间，到期后其他实例可以获取"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ClusterLock) TableName() string {
+	return "ltc_cluster_lock"
+}
@@ -0,0 +1,35 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationArchive 会话归档记录
+// 超过应用数据保留天数的会话导出为 JSONL 文件写入应用的存储（S3 或文件系统）后从数据库删除，归档记录保留会话的摘要信息，用于查找和按需恢复
+// 同一会话恢复后再次归档时复用同一条记录和同一个归档文件
+type ConversationArchive struct {
+	base.BaseModel                   // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID         uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;index:idx_conversation_archive_application;comment:所属应用ID"`
+	ChatAgentID           uuid.UUID  `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_conversation_archive_agent_user,priority:1;comment:所属智能体ID"`
+	ServiceUserID         string     `json:"service_user_id" gorm:"type:varchar(256);not null;index:idx_conversation_archive_agent_user,priority:2;comment:业务侧的用户ID"`
+	ConversationID        uuid.UUID  `json:"conversation_id" gorm:"type:char(36);not null;uniqueIndex:idx_conversation_archive_conversation;comment:归档的会话ID"`
+	Title                 string     `json:"title" gorm:"type:varchar(64);not null;default:'';comment:会话标题"`
+	MessageCount          int        `json:"message_count" gorm:"type:int;not null;default:0;comment:归档的消息数量（含工具调用消息）"`
+	ConversationCreatedAt time.Time  `json:"conversation_created_at" gorm:"not null;comment:会话的创建时间"`
+	LastMessageAt         *time.Time `json:"last_message_at" gorm:"comment:会话最后一条普通消息的时间"`
+	StorageType           string     `json:"storage_type" gorm:"type:varchar(64);not null;comment:归档时使用的存储类型：s3、file_system"`
+	ObjectKey             string     `json:"object_key" gorm:"type:varchar(512);not null;comment:归档文件的对象键"`
+	Status                string     `json:"status" gorm:"type:varchar(16);not null;comment:状态：archived 已归档，restored 已恢复"`
+	ArchivedAt            time.Time  `json:"archived_at" gorm:"not null;comment:最近一次归档的时间"`
+	RestoredAt            *time.Time `json:"restored_at" gorm:"comment:最近一次恢复的时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ConversationArchive) TableName() string {
+	return "ltc_conversation_archive"
+}
@@ -0,0 +1,28 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationEvent 会话事件发件箱
+// 与会话、消息在同一事务中写入，后台任务按写入顺序为订阅的 Webhook 生成推送记录，并在配置了消息队列时发布到消息队列，保证事件至少推送一次
+type ConversationEvent struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID  `json:"chat_agent_id" gorm:"type:char(36);not null;comment:所属智能体ID"`
+	ConversationID uuid.UUID  `json:"conversation_id" gorm:"type:char(36);not null;comment:所属会话ID"`
+	EventType      string     `json:"event_type" gorm:"type:varchar(64);not null;comment:事件类型"`
+	Payload        string     `json:"payload" gorm:"type:mediumtext;comment:事件数据（JSON）"`
+	DispatchedAt   *time.Time `json:"dispatched_at" gorm:"index:idx_conversation_event_dispatched;comment:生成推送记录的时间，为空表示待处理"`
+	PublishedAt    *time.Time `json:"published_at" gorm:"index:idx_conversation_event_published;comment:发布到消息队列的时间，为空表示未发布"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ConversationEvent) TableName() string {
+	return "ltc_conversation_event"
+}
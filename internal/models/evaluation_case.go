@@ -0,0 +1,26 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// EvaluationCase 评测用例
+// 期望特征由评测模型判断回答是否满足，断言按规则直接检查回答内容，两者至少配置一项
+type EvaluationCase struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	SetID          uuid.UUID `json:"set_id" gorm:"type:char(36);not null;index:idx_evaluation_case_set;comment:所属评测集ID"`
+	Name           string    `json:"name" gorm:"type:varchar(128);not null;default:'';comment:用例名称"`
+	Input          string    `json:"input" gorm:"type:text;not null;comment:发送给智能体的用户消息"`
+	ExpectedTraits string    `json:"expected_traits" gorm:"type:text;comment:期望回答具备的特征，由评测模型判断"`
+	Assertions     string    `json:"assertions" gorm:"type:text;comment:断言列表（JSON）"`
+	Enabled        bool      `json:"enabled" gorm:"type:tinyint(1);not null;comment:是否参与评测"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (EvaluationCase) TableName() string {
+	return "ltc_evaluation_case"
+}
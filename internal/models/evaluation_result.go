@@ -0,0 +1,35 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// EvaluationResult 评测运行中单个用例的结果
+// 创建运行时保存用例内容的快照，之后修改用例不影响已有报告
+type EvaluationResult struct {
+	base.BaseModel             // 继承基础模型，包含 ID、时间戳等通用字段
+	RunID            uuid.UUID `json:"run_id" gorm:"type:char(36);not null;index:idx_evaluation_result_run;comment:所属评测运行ID"`
+	CaseID           uuid.UUID `json:"case_id" gorm:"type:char(36);not null;comment:评测用例ID"`
+	CaseIndex        int       `json:"case_index" gorm:"type:int;not null;default:0;comment:用例在本次运行中的序号，从0开始"`
+	CaseName         string    `json:"case_name" gorm:"type:varchar(128);not null;default:'';comment:用例名称快照"`
+	Input            string    `json:"input" gorm:"type:text;not null;comment:用户消息快照"`
+	ExpectedTraits   string    `json:"expected_traits" gorm:"type:text;comment:期望特征快照"`
+	Assertions       string    `json:"assertions" gorm:"type:text;comment:断言列表快照（JSON）"`
+	Status           string    `json:"status" gorm:"type:varchar(16);not null;default:'pending';comment:结果状态：pending running passed failed error cancelled"`
+	Answer           string    `json:"answer" gorm:"type:mediumtext;comment:智能体的回答"`
+	ConversationID   string    `json:"conversation_id" gorm:"type:varchar(36);not null;default:'';comment:运行时创建的会话ID"`
+	Score            float64   `json:"score" gorm:"not null;default:0;comment:得分（0-1）"`
+	AssertionResults string    `json:"assertion_results" gorm:"type:text;comment:各断言的检查结果（JSON）"`
+	JudgeReason      string    `json:"judge_reason" gorm:"type:text;comment:评测模型给出的判断理由"`
+	ErrorMessage     string    `json:"error_message" gorm:"type:text;comment:运行出错的原因"`
+	DurationMs       int64     `json:"duration_ms" gorm:"type:bigint;not null;default:0;comment:智能体回答耗时（毫秒）"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (EvaluationResult) TableName() string {
+	return "ltc_evaluation_result"
+}
@@ -0,0 +1,35 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EvaluationRun 评测运行
+// 使用指定的系统提示词版本运行评测集中的全部启用用例，汇总通过率和平均得分
+type EvaluationRun struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID  `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	SetID          uuid.UUID  `json:"set_id" gorm:"type:char(36);not null;index:idx_evaluation_run_set;comment:评测集ID"`
+	ChatAgentID    uuid.UUID  `json:"chat_agent_id" gorm:"type:char(36);not null;comment:被评测的智能体ID"`
+	PromptVersion  string     `json:"prompt_version" gorm:"type:varchar(64);not null;default:'';comment:系统提示词版本标识，用于比较报告"`
+	SystemPrompt   string     `json:"system_prompt" gorm:"type:text;comment:本次评测使用的系统提示词"`
+	JudgeModelID   uuid.UUID  `json:"judge_model_id" gorm:"type:char(36);comment:判断期望特征使用的评测模型ID，为空时只检查断言"`
+	Status         string     `json:"status" gorm:"type:varchar(16);not null;default:'pending';index:idx_evaluation_run_status;comment:运行状态：pending running completed failed cancelled"`
+	TotalCount     int        `json:"total_count" gorm:"type:int;not null;default:0;comment:用例总数"`
+	PassedCount    int        `json:"passed_count" gorm:"type:int;not null;default:0;comment:通过的用例数"`
+	FailedCount    int        `json:"failed_count" gorm:"type:int;not null;default:0;comment:未通过（含运行出错）的用例数"`
+	AverageScore   float64    `json:"average_score" gorm:"not null;default:0;comment:已评分用例的平均得分（0-1）"`
+	ErrorMessage   string     `json:"error_message" gorm:"type:text;comment:运行失败的原因"`
+	StartedAt      *time.Time `json:"started_at" gorm:"comment:开始执行时间"`
+	FinishedAt     *time.Time `json:"finished_at" gorm:"comment:结束时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (EvaluationRun) TableName() string {
+	return "ltc_evaluation_run"
+}
@@ -0,0 +1,24 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// EvaluationSet 评测集
+// 一组针对智能体的标准测试用例，用于比较不同系统提示词版本的回答效果
+type EvaluationSet struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	ChatAgentID    uuid.UUID `json:"chat_agent_id" gorm:"type:char(36);not null;index:idx_evaluation_set_agent;comment:默认评测的智能体ID"`
+	Name           string    `json:"name" gorm:"type:varchar(128);not null;comment:评测集名称"`
+	Description    string    `json:"description" gorm:"type:varchar(512);not null;default:'';comment:评测集描述"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (EvaluationSet) TableName() string {
+	return "ltc_evaluation_set"
+}
@@ -0,0 +1,27 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeBase 知识库
+// 知识库中的文档切分为片段并生成嵌入向量，供智能体回答时检索引用
+type KnowledgeBase struct {
+	base.BaseModel             // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID    uuid.UUID `json:"application_id" gorm:"type:char(36);not null;index:idx_knowledge_base_application;comment:所属应用ID"`
+	Name             string    `json:"name" gorm:"type:varchar(128);not null;comment:知识库名称"`
+	Description      string    `json:"description" gorm:"type:varchar(512);not null;default:'';comment:知识库描述"`
+	EmbeddingModelID uuid.UUID `json:"embedding_model_id" gorm:"type:char(36);not null;comment:生成片段嵌入向量使用的嵌入模型ID"`
+	// 切分设置，修改后需要重建索引才会对已有文档生效
+	ChunkSize    int `json:"chunk_size" gorm:"type:int;not null;default:0;comment:片段最大字符数，0 表示使用默认值"`
+	ChunkOverlap int `json:"chunk_overlap" gorm:"type:int;not null;default:0;comment:超长段落切分时相邻片段重叠的字符数"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (KnowledgeBase) TableName() string {
+	return "ltc_knowledge_base"
+}
@@ -0,0 +1,27 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeChunk 知识库文档片段
+// 检索时按片段的嵌入向量与用户问题计算相似度
+type KnowledgeChunk struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID   uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	KnowledgeBaseID uuid.UUID `json:"knowledge_base_id" gorm:"type:char(36);not null;index:idx_knowledge_chunk_base;comment:所属知识库ID"`
+	DocumentID      uuid.UUID `json:"document_id" gorm:"type:char(36);not null;index:idx_knowledge_chunk_document;comment:所属文档ID"`
+	ChunkIndex      int       `json:"chunk_index" gorm:"type:int;not null;comment:片段在文档中的序号，从0开始"`
+	Content         string    `json:"content" gorm:"type:text;not null;comment:片段内容"`
+	ContentHash     string    `json:"content_hash" gorm:"type:char(64);not null;default:'';comment:片段内容的SHA-256，替换文档时用于复用嵌入向量"`
+	Embedding       []byte    `json:"-" gorm:"type:mediumblob;comment:片段的嵌入向量（float32小端序）"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (KnowledgeChunk) TableName() string {
+	return "ltc_knowledge_chunk"
+}
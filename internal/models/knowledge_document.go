@@ -0,0 +1,29 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeDocument 知识库文档
+// 文档内容切分为多个片段保存在 KnowledgeChunk 中，切分和生成嵌入向量在后台异步处理
+type KnowledgeDocument struct {
+	base.BaseModel            // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID   uuid.UUID `json:"application_id" gorm:"type:char(36);not null;comment:所属应用ID"`
+	KnowledgeBaseID uuid.UUID `json:"knowledge_base_id" gorm:"type:char(36);not null;index:idx_knowledge_document_base;comment:所属知识库ID"`
+	Name            string    `json:"name" gorm:"type:varchar(255);not null;comment:文档名称"`
+	SourceType      string    `json:"source_type" gorm:"type:varchar(16);not null;default:'text';comment:来源：text 文本，file 上传文件"`
+	Content         string    `json:"-" gorm:"type:longtext;comment:文档文本内容"`
+	ContentHash     string    `json:"content_hash" gorm:"type:char(64);not null;default:'';comment:文档内容的SHA-256"`
+	Status          string    `json:"status" gorm:"type:varchar(16);not null;default:'pending';comment:处理状态：pending processing completed failed"`
+	ErrorMessage    string    `json:"error_message" gorm:"type:text;comment:处理失败的原因"`
+	ChunkCount      int       `json:"chunk_count" gorm:"type:int;not null;default:0;comment:片段数量"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (KnowledgeDocument) TableName() string {
+	return "ltc_knowledge_document"
+}
@@ -0,0 +1,21 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import "lemon-tree-core/internal/base"
+
+// LlmProviderDefine 大语言模型供应商定义结构体
+// 全局共享的供应商模板，创建应用供应商时用于预填类型、图标和默认API URL
+type LlmProviderDefine struct {
+	base.BaseModel        // 继承基础模型，包含 ID、时间戳等通用字段
+	Name           string `json:"name" gorm:"type:varchar(64);not null;comment:供应商名称"`
+	Description    string `json:"description" gorm:"type:varchar(512);not null;comment:供应商描述"`
+	Type           string `json:"type" gorm:"type:varchar(64);not null;uniqueIndex:idx_llm_provider_define_type;comment:供应商类型"`
+	IconUrl        string `json:"icon_url" gorm:"type:varchar(512);not null;comment:供应商图标URL"`
+	DefaultApiUrl  string `json:"default_api_url" gorm:"type:varchar(512);not null;comment:默认API URL"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (LlmProviderDefine) TableName() string {
+	return "ltc_llm_provider_define"
+}
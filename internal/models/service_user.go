@@ -0,0 +1,25 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// ServiceUser 业务侧用户
+// 记录业务侧用户ID对应的展示信息，便于管理后台识别会话所属用户
+type ServiceUser struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	ApplicationID  uuid.UUID `json:"application_id" gorm:"type:char(36);not null;uniqueIndex:idx_service_user_app_user;comment:所属应用ID"`
+	ServiceUserID  string    `json:"service_user_id" gorm:"type:varchar(256);not null;uniqueIndex:idx_service_user_app_user;comment:业务侧的用户ID"`
+	DisplayName    string    `json:"display_name" gorm:"type:varchar(128);not null;default:'';comment:展示名称"`
+	AvatarUrl      string    `json:"avatar_url" gorm:"type:varchar(512);not null;default:'';comment:头像URL"`
+	Metadata       string    `json:"metadata" gorm:"type:text;comment:业务侧自定义元数据（JSON对象）"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (ServiceUser) TableName() string {
+	return "ltc_service_user"
+}
@@ -0,0 +1,31 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 系统用户一次性令牌的用途
+const (
+	SystemUserActionTokenPurposeInvite        = "invite"         // 接受邀请并设置初始密码
+	SystemUserActionTokenPurposeResetPassword = "reset_password" // 找回密码
+)
+
+// SystemUserActionToken 系统用户的一次性令牌
+// 通过邮件中的链接发送给用户，只保存令牌的 sha256 摘要，使用后立即删除
+type SystemUserActionToken struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	UserID         uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index:idx_system_user_action_token_user;comment:系统用户ID"`
+	Purpose        string    `json:"purpose" gorm:"type:varchar(32);not null;comment:用途：invite-接受邀请，reset_password-找回密码"`
+	TokenHash      string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_system_user_action_token_hash;comment:令牌摘要"`
+	ExpiredAt      time.Time `json:"expired_at" gorm:"type:datetime;not null;comment:过期时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (SystemUserActionToken) TableName() string {
+	return "ltc_system_user_action_token"
+}
@@ -0,0 +1,24 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+
+	"github.com/google/uuid"
+)
+
+// SystemUserIdentity 系统用户的外部身份
+// 通过 OIDC 单点登录的身份按 Issuer 和 sub 唯一对应一个系统用户
+type SystemUserIdentity struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	UserID         uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index:idx_system_user_identity_user;comment:系统用户ID"`
+	Issuer         string    `json:"issuer" gorm:"type:varchar(255);not null;uniqueIndex:idx_system_user_identity_subject,priority:1;comment:身份提供方 Issuer"`
+	Subject        string    `json:"subject" gorm:"type:varchar(255);not null;uniqueIndex:idx_system_user_identity_subject,priority:2;comment:身份提供方中的用户标识 sub"`
+	Email          string    `json:"email" gorm:"type:varchar(128);not null;default:'';comment:最近一次登录时的邮箱"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (SystemUserIdentity) TableName() string {
+	return "ltc_system_user_identity"
+}
@@ -0,0 +1,23 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import (
+	"lemon-tree-core/internal/base"
+	"time"
+)
+
+// SystemUserOidcLogin 进行中的 OIDC 单点登录请求
+// 回调时按 state 查找并校验，使用一次后删除
+type SystemUserOidcLogin struct {
+	base.BaseModel           // 继承基础模型，包含 ID、时间戳等通用字段
+	State          string    `json:"-" gorm:"type:varchar(128);not null;uniqueIndex:idx_system_user_oidc_login_state;comment:登录请求的 state"`
+	Nonce          string    `json:"-" gorm:"type:varchar(128);not null;comment:ID令牌中需要回传的 nonce"`
+	CodeVerifier   string    `json:"-" gorm:"type:varchar(128);not null;comment:PKCE 校验码"`
+	ExpiredAt      time.Time `json:"expired_at" gorm:"type:datetime;not null;comment:登录请求过期时间"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (SystemUserOidcLogin) TableName() string {
+	return "ltc_system_user_oidc_login"
+}
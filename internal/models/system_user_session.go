@@ -11,9 +11,16 @@ import (
 type SystemUserSession struct {
 	base.BaseModel // 继承基础模型，包含 ID、时间戳等通用字段
 	// Token生成算法：sha256(随机UUID_用户ID_13位毫秒unix时间戳)
-	Token          string    `json:"token" gorm:"type:varchar(512);not null;comment:Token"`
-	UserID         uuid.UUID `json:"user_id" gorm:"type:char(36);not null;comment:用户ID"`
-	LoginExpiredAt time.Time `json:"login_expired_at" gorm:"type:datetime;not null;comment:登录过期时间"`
+	Token          string    `json:"token" gorm:"type:varchar(512);not null;uniqueIndex:idx_system_user_session_token;comment:Token"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index:idx_system_user_session_user;comment:用户ID"`
+	LoginExpiredAt time.Time `json:"login_expired_at" gorm:"type:datetime;not null;comment:访问令牌过期时间"`
+	// 刷新令牌只保存 sha256 摘要，每次刷新轮换；上一个刷新令牌的摘要用于识别被盗用的旧令牌
+	RefreshTokenHash         string     `json:"-" gorm:"type:varchar(64);not null;default:'';index:idx_system_user_session_refresh;comment:刷新令牌摘要"`
+	PreviousRefreshTokenHash string     `json:"-" gorm:"type:varchar(64);not null;default:'';index:idx_system_user_session_previous_refresh;comment:上一个刷新令牌摘要"`
+	RefreshExpiredAt         *time.Time `json:"refresh_expired_at" gorm:"type:datetime;comment:刷新令牌过期时间，即会话的过期时间"`
+	// 登录时的客户端信息，用于用户在会话列表中辨认登录设备
+	IPAddress string `json:"ip_address" gorm:"type:varchar(64);not null;default:'';comment:登录IP"`
+	UserAgent string `json:"user_agent" gorm:"type:varchar(512);not null;default:'';comment:登录时的User-Agent"`
 }
 
 // TableName 指定数据库表名
@@ -0,0 +1,17 @@
+// Package models 提供应用程序的数据模型定义
+package models
+
+import "lemon-tree-core/internal/base"
+
+// WorkspaceUpload 待确认的工作区上传文件
+// 头像、图标等文件在保存实体前上传，记录上传的文件以便清理未被任何记录引用的文件
+type WorkspaceUpload struct {
+	base.BaseModel        // 继承基础模型，包含 ID、时间戳等通用字段
+	FilePath       string `json:"file_path" gorm:"type:varchar(512);not null;index;comment:相对工作区公共目录的文件路径"`
+}
+
+// TableName 指定数据库表名
+// 返回该模型对应的数据库表名
+func (WorkspaceUpload) TableName() string {
+	return "ltc_workspace_upload"
+}
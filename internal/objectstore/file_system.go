@@ -0,0 +1,71 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/models"
+	"os"
+	"path/filepath"
+)
+
+// fileSystemStore 把对象保存为根路径下文件的 Store 实现
+// 对象键中的 / 对应子目录
+type fileSystemStore struct {
+	rootPath string // 根路径
+}
+
+// newFileSystemStore 根据存储配置创建文件系统 Store
+func newFileSystemStore(storageConfig *models.ApplicationStorageConfig) (Store, error) {
+	if storageConfig.RootPath == "" {
+		return nil, fmt.Errorf("文件系统存储类型下，根路径不能为空")
+	}
+	return &fileSystemStore{rootPath: storageConfig.RootPath}, nil
+}
+
+// Put 写入对象
+// 先写入同目录的临时文件再重命名，写入中断时不会留下不完整的对象
+func (s *fileSystemStore) Put(ctx context.Context, key string, data []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	path := filepath.Join(s.rootPath, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建存储目录失败: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-"+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("创建存储文件失败: %w", err)
+	}
+	tempPath := file.Name()
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return fmt.Errorf("写入存储文件失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("写入存储文件失败: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("写入存储文件失败: %w", err)
+	}
+	return nil
+}
+
+// Get 读取对象
+func (s *fileSystemStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.rootPath, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取存储文件失败: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,57 @@
+// Package objectstore 提供对象存储读写功能
+// 按应用的存储配置把数据写入本地文件系统或 S3 兼容的对象存储，用于会话归档等需要长期保存的数据
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/models"
+	"strings"
+)
+
+// ErrNotFound 对象不存在
+var ErrNotFound = errors.New("对象不存在")
+
+// 支持的存储类型，与应用存储配置的类型一致
+const (
+	TypeFileSystem = "file_system" // 本地文件系统
+	TypeS3         = "s3"          // S3 兼容的对象存储
+)
+
+// Store 对象存储接口
+// 对象键使用 / 分隔，不以 / 开头
+type Store interface {
+	// Put 写入对象，已存在时覆盖
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get 读取对象，对象不存在时返回 ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// New 根据应用的存储配置创建 Store
+// 参数：storageConfig - 应用存储配置
+// 返回：存储类型不支持或配置不完整时返回错误
+func New(storageConfig *models.ApplicationStorageConfig) (Store, error) {
+	switch storageConfig.Type {
+	case TypeFileSystem:
+		return newFileSystemStore(storageConfig)
+	case TypeS3:
+		return newS3Store(storageConfig)
+	default:
+		return nil, fmt.Errorf("不支持的存储类型: %s", storageConfig.Type)
+	}
+}
+
+// validateKey 校验对象键，不允许为空、以 / 开头或包含 . 和 .. 路径段
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("无效的对象键: %s", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("无效的对象键: %s", key)
+		}
+	}
+	return nil
+}
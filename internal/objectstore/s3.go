@@ -0,0 +1,182 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/models"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// s3RequestTimeout 单次请求的超时时间
+const s3RequestTimeout = 5 * time.Minute
+
+// s3ResponsePreview 请求失败时记录的响应内容长度
+const s3ResponsePreview = 512
+
+// s3Store 通过 S3 REST 接口读写对象的 Store 实现
+// 使用路径形式的地址 {endpoint}/{bucket}/{key} 和 AWS Signature Version 4 签名，兼容 AWS S3、MinIO 和各云厂商的 S3 兼容接口
+type s3Store struct {
+	scheme     string       // 请求协议，endpoint 未指定时使用 https
+	host       string       // endpoint 的主机名和端口
+	region     string       // 存储桶区域
+	bucket     string       // 存储桶名称
+	accessKey  string       // 访问密钥ID
+	secretKey  string       // 访问密钥
+	keyPrefix  string       // 对象键前缀，不含首尾的 /
+	httpClient *http.Client // 请求使用的 HTTP 客户端
+}
+
+// newS3Store 根据存储配置创建 S3 Store
+func newS3Store(storageConfig *models.ApplicationStorageConfig) (Store, error) {
+	endpoint := storageConfig.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("S3 Endpoint 无效: %s", storageConfig.Endpoint)
+	}
+	if storageConfig.Region == "" || storageConfig.BucketName == "" || storageConfig.SecretId == "" || storageConfig.SecretKey == "" {
+		return nil, fmt.Errorf("S3存储配置不完整")
+	}
+	return &s3Store{
+		scheme:     parsed.Scheme,
+		host:       parsed.Host,
+		region:     storageConfig.Region,
+		bucket:     storageConfig.BucketName,
+		accessKey:  storageConfig.SecretId,
+		secretKey:  storageConfig.SecretKey,
+		keyPrefix:  strings.Trim(storageConfig.KeyPrefix, "/"),
+		httpClient: &http.Client{Timeout: s3RequestTimeout},
+	}, nil
+}
+
+// Put 写入对象
+func (s *s3Store) Put(ctx context.Context, key string, data []byte) error {
+	response, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return s.responseError(response)
+	}
+	return nil
+}
+
+// Get 读取对象
+func (s *s3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	response, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, s.responseError(response)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取S3对象失败: %w", err)
+	}
+	return data, nil
+}
+
+// do 发送签名后的请求
+func (s *s3Store) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	objectKey := key
+	if s.keyPrefix != "" {
+		objectKey = s.keyPrefix + "/" + key
+	}
+	path := "/" + s3EscapePath(s.bucket) + "/" + s3EscapePath(objectKey)
+
+	request, err := http.NewRequestWithContext(ctx, method, s.scheme+"://"+s.host+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("创建S3请求失败: %w", err)
+	}
+	// 请求地址已经过转义，设置 Opaque 避免标准库按自己的规则重新转义
+	request.URL.Opaque = "//" + s.host + path
+	request.ContentLength = int64(len(body))
+	s.sign(request, path, body, time.Now().UTC())
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("请求S3失败: %w", err)
+	}
+	return response, nil
+}
+
+// sign 使用 AWS Signature Version 4 为请求签名
+// 参与签名的请求头为 host、x-amz-content-sha256 和 x-amz-date
+func (s *s3Store) sign(request *http.Request, path string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	request.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		path,
+		"",
+		"host:" + s.host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// responseError 根据失败的响应生成错误，附带响应内容的开头部分
+func (s *s3Store) responseError(response *http.Response) error {
+	content, _ := io.ReadAll(io.LimitReader(response.Body, s3ResponsePreview))
+	return fmt.Errorf("S3 返回 HTTP %d: %s", response.StatusCode, string(content))
+}
+
+// s3EscapePath 按 S3 签名规则转义路径，除 / 和 RFC 3986 非保留字符外全部转义
+func s3EscapePath(path string) string {
+	var builder strings.Builder
+	for _, b := range []byte(path) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+// sha256Hex 计算内容的 SHA-256 并返回十六进制字符串
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
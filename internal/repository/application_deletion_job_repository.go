@@ -0,0 +1,107 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationDeletionJobRepository 应用删除任务 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ApplicationDeletionJobRepository interface {
+	base.BaseRepository[models.ApplicationDeletionJob] // 继承基础仓库接口
+
+	// GetEarliestByStatuses 获取处于指定状态中最早创建的任务，没有时返回空
+	GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.ApplicationDeletionJob, error)
+
+	// GetLatestByApplicationID 获取应用最近创建的删除任务，没有时返回空
+	GetLatestByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationDeletionJob, error)
+
+	// UpdateStatus 当任务处于 fromStatuses 之一时更新状态
+	// startedAt、finishedAt 为空时不更新对应字段；返回是否更新成功
+	UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error)
+
+	// UpdateProgress 更新任务的进度和各步骤已删除的记录数
+	UpdateProgress(ctx context.Context, id uuid.UUID, completedSteps int, currentStep, deletedCounts string) error
+
+	// UpdateErrorMessage 记录最近一次执行失败的原因，为空时清除
+	UpdateErrorMessage(ctx context.Context, id uuid.UUID, errorMessage string) error
+}
+
+// applicationDeletionJobRepository 应用删除任务 数据访问层实现
+type applicationDeletionJobRepository struct {
+	base.BaseRepository[models.ApplicationDeletionJob]          // 组合基础仓库实现
+	db                                                 *gorm.DB // 数据库连接
+}
+
+// NewApplicationDeletionJobRepository 创建 应用删除任务 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewApplicationDeletionJobRepository(db *gorm.DB) ApplicationDeletionJobRepository {
+	return &applicationDeletionJobRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationDeletionJob](db),
+		db:             db,
+	}
+}
+
+// GetEarliestByStatuses 获取处于指定状态中最早创建的任务，没有时返回空
+func (r *applicationDeletionJobRepository) GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.ApplicationDeletionJob, error) {
+	var job models.ApplicationDeletionJob
+	err := r.db.WithContext(ctx).Where("status IN ?", statuses).Order("created_at ASC").First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetLatestByApplicationID 获取应用最近创建的删除任务，没有时返回空
+func (r *applicationDeletionJobRepository) GetLatestByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	var job models.ApplicationDeletionJob
+	err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).Order("created_at DESC").First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateStatus 当任务处于 fromStatuses 之一时更新状态
+// startedAt、finishedAt 为空时不更新对应字段；返回是否更新成功
+func (r *applicationDeletionJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if startedAt != nil {
+		updates["started_at"] = *startedAt
+	}
+	if finishedAt != nil {
+		updates["finished_at"] = *finishedAt
+	}
+	result := r.db.WithContext(ctx).Model(&models.ApplicationDeletionJob{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateProgress 更新任务的进度和各步骤已删除的记录数
+func (r *applicationDeletionJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, completedSteps int, currentStep, deletedCounts string) error {
+	return r.db.WithContext(ctx).Model(&models.ApplicationDeletionJob{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"completed_steps": completedSteps, "current_step": currentStep, "deleted_counts": deletedCounts}).Error
+}
+
+// UpdateErrorMessage 记录最近一次执行失败的原因，为空时清除
+func (r *applicationDeletionJobRepository) UpdateErrorMessage(ctx context.Context, id uuid.UUID, errorMessage string) error {
+	return r.db.WithContext(ctx).Model(&models.ApplicationDeletionJob{}).Where("id = ?", id).
+		Update("error_message", errorMessage).Error
+}
@@ -0,0 +1,77 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationFeatureFlagRepository ApplicationFeatureFlag 数据访问层接口
+// 定义了 ApplicationFeatureFlag 模型的所有数据操作接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ApplicationFeatureFlagRepository interface {
+	base.BaseRepository[models.ApplicationFeatureFlag] // 继承基础仓库接口
+
+	// GetByApplicationID 获取应用单独设置的全部功能开关
+	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationFeatureFlag, error)
+
+	// GetByApplicationAndFlag 获取应用对指定功能开关的设置，不存在时返回 nil
+	GetByApplicationAndFlag(ctx context.Context, applicationID uuid.UUID, flag string) (*models.ApplicationFeatureFlag, error)
+
+	// DeleteByApplicationAndFlag 删除应用对指定功能开关的设置
+	// 物理删除，重新设置时不会与已删除记录的唯一索引冲突
+	DeleteByApplicationAndFlag(ctx context.Context, applicationID uuid.UUID, flag string) error
+}
+
+// applicationFeatureFlagRepository ApplicationFeatureFlag 数据访问层实现
+// 实现了 ApplicationFeatureFlagRepository 接口的所有方法
+// 通过组合 baseRepository 来复用基础功能
+type applicationFeatureFlagRepository struct {
+	base.BaseRepository[models.ApplicationFeatureFlag]          // 组合基础仓库实现
+	db                                                 *gorm.DB // 数据库连接
+}
+
+// NewApplicationFeatureFlagRepository 创建 ApplicationFeatureFlag Repository 实例
+// 返回 ApplicationFeatureFlagRepository 接口的实现
+// 参数：db - GORM 数据库连接实例
+func NewApplicationFeatureFlagRepository(db *gorm.DB) ApplicationFeatureFlagRepository {
+	return &applicationFeatureFlagRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationFeatureFlag](db),
+		db:             db,
+	}
+}
+
+// GetByApplicationID 获取应用单独设置的全部功能开关
+// 参数：ctx - 上下文，applicationID - 应用ID
+// 返回：功能开关设置列表（按名称排序）和错误信息
+func (r *applicationFeatureFlagRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationFeatureFlag, error) {
+	var flags []*models.ApplicationFeatureFlag
+	err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).Order("flag ASC").Find(&flags).Error
+	return flags, err
+}
+
+// GetByApplicationAndFlag 获取应用对指定功能开关的设置
+// 参数：ctx - 上下文，applicationID - 应用ID，flag - 功能开关名称
+// 返回：功能开关设置和错误信息，未找到时返回 nil
+func (r *applicationFeatureFlagRepository) GetByApplicationAndFlag(ctx context.Context, applicationID uuid.UUID, flag string) (*models.ApplicationFeatureFlag, error) {
+	var featureFlag models.ApplicationFeatureFlag
+	err := r.db.WithContext(ctx).Where("application_id = ? AND flag = ?", applicationID, flag).First(&featureFlag).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &featureFlag, nil
+}
+
+// DeleteByApplicationAndFlag 删除应用对指定功能开关的设置
+// 参数：ctx - 上下文，applicationID - 应用ID，flag - 功能开关名称
+func (r *applicationFeatureFlagRepository) DeleteByApplicationAndFlag(ctx context.Context, applicationID uuid.UUID, flag string) error {
+	return r.db.WithContext(ctx).Unscoped().Where("application_id = ? AND flag = ?", applicationID, flag).Delete(&models.ApplicationFeatureFlag{}).Error
+}
@@ -16,6 +16,9 @@ type ApplicationMcpServerConfigRepository interface {
 	// Create 创建新的 ApplicationMCP配置 记录
 	Create(ctx context.Context, config *models.ApplicationMcpServerConfig) error
 
+	// BatchCreate 在同一事务中创建多个 ApplicationMCP配置 记录
+	BatchCreate(ctx context.Context, configs []*models.ApplicationMcpServerConfig) error
+
 	// GetByID 根据ID获取 ApplicationMCP配置 记录
 	GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationMcpServerConfig, error)
 
@@ -25,12 +28,23 @@ type ApplicationMcpServerConfigRepository interface {
 	// Delete 删除 ApplicationMCP配置 记录
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// DeleteWithDependents 在同一事务中删除MCP配置、配置下的工具、智能体的工具设置、工具集中的工具和OAuth令牌
+	// 配置不存在时返回 gorm.ErrRecordNotFound
+	DeleteWithDependents(ctx context.Context, id uuid.UUID) (*McpServerConfigDependents, error)
+
 	// GetByApplicationID 根据应用ID获取 ApplicationMCP配置 列表
 	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationMcpServerConfig, error)
 
 	GetByConfigID(ctx context.Context, configID string) (*models.ApplicationMcpServerConfig, error)
 }
 
+// McpServerConfigDependents 删除MCP配置时一并删除的关联数据
+type McpServerConfigDependents struct {
+	ToolCount          int64       // 删除的工具数量
+	AgentToolCount     int64       // 删除的智能体工具设置数量
+	AffectedChatAgents []uuid.UUID // 工具设置被删除的智能体ID
+}
+
 // applicationMcpServerConfigRepository ApplicationMCP配置 数据访问层实现
 // 实现 ApplicationMcpServerConfigRepository 接口
 type applicationMcpServerConfigRepository struct {
@@ -54,6 +68,18 @@ func (r *applicationMcpServerConfigRepository) Create(ctx context.Context, confi
 	return r.db.WithContext(ctx).Create(config).Error
 }
 
+// BatchCreate 在同一事务中创建多个 ApplicationMCP配置 记录
+// 参数：ctx - 上下文，configs - ApplicationMCP配置 模型列表
+// 返回：错误信息
+func (r *applicationMcpServerConfigRepository) BatchCreate(ctx context.Context, configs []*models.ApplicationMcpServerConfig) error {
+	if len(configs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(configs, 100).Error
+	})
+}
+
 // GetByID 根据ID获取 ApplicationMCP配置 记录
 // 从数据库中查询指定ID的 ApplicationMCP配置 记录
 // 参数：ctx - 上下文，id - ApplicationMCP配置 ID
@@ -80,7 +106,7 @@ func (r *applicationMcpServerConfigRepository) Update(ctx context.Context, confi
 // 参数：ctx - 上下文，id - ApplicationMCP配置 ID
 // 返回：错误信息
 func (r *applicationMcpServerConfigRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.ApplicationMcpServerConfig{}, id).Error
+	return r.db.WithContext(ctx).Delete(&models.ApplicationMcpServerConfig{}, "id = ?", id).Error
 }
 
 // GetByApplicationID 根据应用ID获取 ApplicationMCP配置 列表
@@ -108,3 +134,61 @@ func (r *applicationMcpServerConfigRepository) GetByConfigID(ctx context.Context
 	}
 	return &config, nil
 }
+
+// DeleteWithDependents 在同一事务中删除MCP配置、配置下的工具、智能体的工具设置、工具集中的工具和OAuth令牌
+// 任一步骤失败时全部回滚
+// 参数：ctx - 上下文，id - ApplicationMCP配置 ID
+// 返回：删除的关联数据和错误信息
+func (r *applicationMcpServerConfigRepository) DeleteWithDependents(ctx context.Context, id uuid.UUID) (*McpServerConfigDependents, error) {
+	dependents := &McpServerConfigDependents{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var toolIDs []uuid.UUID
+		if err := tx.Model(&models.ApplicationMcpServerTool{}).
+			Where("application_mcp_server_config_id = ?", id).
+			Pluck("id", &toolIDs).Error; err != nil {
+			return err
+		}
+
+		if len(toolIDs) > 0 {
+			if err := tx.Model(&models.ChatAgentMcpServerTool{}).
+				Where("application_mcp_server_tool_id IN ?", toolIDs).
+				Distinct().Pluck("chat_agent_id", &dependents.AffectedChatAgents).Error; err != nil {
+				return err
+			}
+			result := tx.Where("application_mcp_server_tool_id IN ?", toolIDs).Delete(&models.ChatAgentMcpServerTool{})
+			if result.Error != nil {
+				return result.Error
+			}
+			dependents.AgentToolCount = result.RowsAffected
+
+			if err := tx.Unscoped().Where("application_mcp_server_tool_id IN ?", toolIDs).
+				Delete(&models.ApplicationToolBundleTool{}).Error; err != nil {
+				return err
+			}
+
+			result = tx.Where("id IN ?", toolIDs).Delete(&models.ApplicationMcpServerTool{})
+			if result.Error != nil {
+				return result.Error
+			}
+			dependents.ToolCount = result.RowsAffected
+		}
+
+		if err := tx.Unscoped().Where("application_mcp_server_config_id = ?", id).
+			Delete(&models.ApplicationMcpServerOauthToken{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.ApplicationMcpServerConfig{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dependents, nil
+}
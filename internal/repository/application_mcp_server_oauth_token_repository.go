@@ -0,0 +1,90 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationMcpServerOauthTokenRepository MCP服务OAuth令牌 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ApplicationMcpServerOauthTokenRepository interface {
+	base.BaseRepository[models.ApplicationMcpServerOauthToken] // 继承基础仓库接口
+
+	// GetByConfigID 获取MCP配置的OAuth令牌，不存在时返回 nil
+	GetByConfigID(ctx context.Context, configID uuid.UUID) (*models.ApplicationMcpServerOauthToken, error)
+
+	// GetByPendingState 根据进行中的授权请求的 state 获取OAuth令牌记录，不存在时返回 nil
+	GetByPendingState(ctx context.Context, state string) (*models.ApplicationMcpServerOauthToken, error)
+
+	// DeleteByConfigID 物理删除MCP配置的OAuth令牌
+	DeleteByConfigID(ctx context.Context, configID uuid.UUID) error
+}
+
+// applicationMcpServerOauthTokenRepository MCP服务OAuth令牌 数据访问层实现
+type applicationMcpServerOauthTokenRepository struct {
+	base.BaseRepository[models.ApplicationMcpServerOauthToken]          // 组合基础仓库实现
+	db                                                         *gorm.DB // 数据库连接
+}
+
+// NewApplicationMcpServerOauthTokenRepository 创建 MCP服务OAuth令牌 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewApplicationMcpServerOauthTokenRepository(db *gorm.DB) ApplicationMcpServerOauthTokenRepository {
+	return &applicationMcpServerOauthTokenRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationMcpServerOauthToken](db),
+		db:             db,
+	}
+}
+
+// GetByConfigID 获取MCP配置的OAuth令牌
+// 参数：ctx - 上下文，configID - MCP配置ID
+// 返回：OAuth令牌（不存在时为 nil）和错误信息
+func (r *applicationMcpServerOauthTokenRepository) GetByConfigID(ctx context.Context, configID uuid.UUID) (*models.ApplicationMcpServerOauthToken, error) {
+	var token models.ApplicationMcpServerOauthToken
+	err := r.db.WithContext(ctx).
+		Where("application_mcp_server_config_id = ?", configID).
+		First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByPendingState 根据进行中的授权请求的 state 获取OAuth令牌记录
+// 参数：ctx - 上下文，state - 授权请求的 state
+// 返回：OAuth令牌（不存在时为 nil）和错误信息
+func (r *applicationMcpServerOauthTokenRepository) GetByPendingState(ctx context.Context, state string) (*models.ApplicationMcpServerOauthToken, error) {
+	if state == "" {
+		return nil, nil
+	}
+	var token models.ApplicationMcpServerOauthToken
+	err := r.db.WithContext(ctx).
+		Where("pending_state = ?", state).
+		First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteByConfigID 物理删除MCP配置的OAuth令牌
+// 令牌记录按MCP配置唯一，物理删除避免软删除的记录占用唯一索引
+// 参数：ctx - 上下文，configID - MCP配置ID
+// 返回：错误信息
+func (r *applicationMcpServerOauthTokenRepository) DeleteByConfigID(ctx context.Context, configID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("application_mcp_server_config_id = ?", configID).
+		Delete(&models.ApplicationMcpServerOauthToken{}).Error
+}
@@ -86,11 +86,12 @@ func (r *applicationMcpServerToolRepository) Update(ctx context.Context, tool *m
 }
 
 // Delete 删除 ApplicationMCP工具 记录
-// 从数据库中删除指定ID的 ApplicationMCP工具 记录
+// 从数据库中物理删除指定ID的 ApplicationMCP工具 记录
+// 同一MCP配置下工具名称唯一，工具在同步时被删除后可能重新出现，物理删除避免软删除的记录占用唯一索引
 // 参数：ctx - 上下文，id - ApplicationMCP工具 ID
 // 返回：错误信息
 func (r *applicationMcpServerToolRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.ApplicationMcpServerTool{}, id).Error
+	return r.db.WithContext(ctx).Unscoped().Delete(&models.ApplicationMcpServerTool{}, "id = ?", id).Error
 }
 
 // GetByApplicationMcpServerConfigID 根据MCP配置ID获取工具列表
@@ -3,9 +3,13 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -14,13 +18,101 @@ import (
 // 继承自 BaseRepository，包含基本的增删改查功能
 type ApplicationRepository interface {
 	base.BaseRepository[models.Application] // 继承基础仓库接口
+
+	// DeleteDependentsBatch 软删除应用在指定步骤的一批关联数据
+	// step 为 ApplicationDeletionSteps 中的步骤名称，返回本批删除的记录数，为 0 时表示该步骤已完成
+	DeleteDependentsBatch(ctx context.Context, applicationID uuid.UUID, step string, limit int) (int64, error)
+
+	// GetBackup 读取应用的全部配置记录，应用不存在时返回 nil
+	// 返回的备份只填充记录，不含版本、备份时间和模型 JSON 中不输出的字段
+	GetBackup(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationBackup, error)
+
+	// RestoreBackup 在同一事务中按原有ID写入备份中的全部记录
+	RestoreBackup(ctx context.Context, backup *models.ApplicationBackup) error
+}
+
+// applicationBackupBatchSize 恢复备份时每批写入的记录数量
+const applicationBackupBatchSize = 100
+
+// applicationDeletionStep 应用删除的一个步骤
+// 每个步骤删除一张表中属于应用的记录，scope 用于筛选这些记录
+type applicationDeletionStep struct {
+	name  string
+	model any
+	scope func(db *gorm.DB, applicationID uuid.UUID) *gorm.DB
+}
+
+// byApplicationID 按 application_id 筛选记录
+func byApplicationID(db *gorm.DB, applicationID uuid.UUID) *gorm.DB {
+	return db.Where("application_id = ?", applicationID)
+}
+
+// byParentApplicationID 按上级记录所属的应用筛选没有 application_id 字段的记录
+// 上级记录可能已在之前的步骤中删除，子查询不排除已删除的上级记录
+func byParentApplicationID(column, parentTable string) func(db *gorm.DB, applicationID uuid.UUID) *gorm.DB {
+	return func(db *gorm.DB, applicationID uuid.UUID) *gorm.DB {
+		return db.Where(fmt.Sprintf("%s IN (SELECT id FROM %s WHERE application_id = ?)", column, parentTable), applicationID)
+	}
+}
+
+// applicationDeletionSteps 应用删除的全部步骤
+// 先删除下级数据再删除上级数据，任务中断后从未完成的步骤继续执行
+var applicationDeletionSteps = []applicationDeletionStep{
+	{"chat_agent_mcp_server_tools", &models.ChatAgentMcpServerTool{}, byParentApplicationID("chat_agent_id", "ltc_chat_agent")},
+	{"chat_agent_tool_bundles", &models.ChatAgentToolBundle{}, byApplicationID},
+	{"tool_bundle_tools", &models.ApplicationToolBundleTool{}, byApplicationID},
+	{"tool_bundles", &models.ApplicationToolBundle{}, byApplicationID},
+	{"chat_agent_attachments", &models.ChatAgentAttachment{}, byApplicationID},
+	{"chat_agent_messages", &models.ChatAgentMessage{}, byApplicationID},
+	{"chat_agent_message_requests", &models.ChatAgentMessageRequest{}, byApplicationID},
+	{"chat_agent_conversations", &models.ChatAgentConversation{}, byApplicationID},
+	{"chat_agent_api_keys", &models.ChatAgentApiKey{}, byApplicationID},
+	{"chat_agent_widget_tokens", &models.ChatAgentWidgetToken{}, byApplicationID},
+	{"chat_agent_api_key_rejections", &models.ChatAgentApiKeyRejection{}, byApplicationID},
+	{"application_webhook_deliveries", &models.ApplicationWebhookDelivery{}, byApplicationID},
+	{"application_webhooks", &models.ApplicationWebhook{}, byApplicationID},
+	{"conversation_events", &models.ConversationEvent{}, byApplicationID},
+	{"conversation_archives", &models.ConversationArchive{}, byApplicationID},
+	{"chat_agent_answer_rules", &models.ChatAgentAnswerRule{}, byApplicationID},
+	{"chat_agent_tool_calls", &models.ChatAgentToolCall{}, byApplicationID},
+	{"chat_agent_conversation_variables", &models.ChatAgentConversationVariable{}, byApplicationID},
+	{"batch_inference_items", &models.BatchInferenceItem{}, byParentApplicationID("job_id", "ltc_batch_inference_job")},
+	{"batch_inference_jobs", &models.BatchInferenceJob{}, byApplicationID},
+	{"evaluation_results", &models.EvaluationResult{}, byParentApplicationID("run_id", "ltc_evaluation_run")},
+	{"evaluation_runs", &models.EvaluationRun{}, byApplicationID},
+	{"evaluation_cases", &models.EvaluationCase{}, byParentApplicationID("set_id", "ltc_evaluation_set")},
+	{"evaluation_sets", &models.EvaluationSet{}, byApplicationID},
+	{"knowledge_chunks", &models.KnowledgeChunk{}, byApplicationID},
+	{"knowledge_documents", &models.KnowledgeDocument{}, byApplicationID},
+	{"knowledge_bases", &models.KnowledgeBase{}, byApplicationID},
+	{"chat_agents", &models.ChatAgent{}, byApplicationID},
+	{"service_users", &models.ServiceUser{}, byApplicationID},
+	{"mcp_server_oauth_tokens", &models.ApplicationMcpServerOauthToken{}, byApplicationID},
+	{"mcp_server_tools", &models.ApplicationMcpServerTool{}, byApplicationID},
+	{"mcp_server_configs", &models.ApplicationMcpServerConfig{}, byApplicationID},
+	{"llm_models", &models.ApplicationLlm{}, byApplicationID},
+	{"llm_providers", &models.ApplicationLlmProvider{}, byApplicationID},
+	{"storage_configs", &models.ApplicationStorageConfig{}, byApplicationID},
+	{"net_search_configs", &models.ApplicationInternalToolNetSearchConfig{}, byApplicationID},
+	{"settings", &models.ApplicationSetting{}, byApplicationID},
+	{"feature_flags", &models.ApplicationFeatureFlag{}, byApplicationID},
+}
+
+// ApplicationDeletionSteps 应用删除的全部步骤名称，按执行顺序排列
+func ApplicationDeletionSteps() []string {
+	names := make([]string, 0, len(applicationDeletionSteps))
+	for _, step := range applicationDeletionSteps {
+		names = append(names, step.name)
+	}
+	return names
 }
 
 // applicationRepository Application 数据访问层实现
 // 实现了 ApplicationRepository 接口的所有方法
 // 通过组合 baseRepository 来复用基础功能
 type applicationRepository struct {
-	base.BaseRepository[models.Application] // 组合基础仓库实现
+	base.BaseRepository[models.Application]          // 组合基础仓库实现
+	db                                      *gorm.DB // 数据库连接
 }
 
 // NewApplicationRepository 创建 Application Repository 实例
@@ -29,5 +121,139 @@ type applicationRepository struct {
 func NewApplicationRepository(db *gorm.DB) ApplicationRepository {
 	return &applicationRepository{
 		BaseRepository: base.NewBaseRepository[models.Application](db),
+		db:             db,
+	}
+}
+
+// DeleteDependentsBatch 软删除应用在指定步骤的一批关联数据
+// 先查询一批记录ID再按ID删除，避免单条语句删除大量记录时长时间锁表
+// 参数：ctx - 上下文，applicationID - 应用ID，step - 步骤名称，limit - 每批删除的记录数
+// 返回：本批删除的记录数和错误信息
+func (r *applicationRepository) DeleteDependentsBatch(ctx context.Context, applicationID uuid.UUID, step string, limit int) (int64, error) {
+	for _, deletionStep := range applicationDeletionSteps {
+		if deletionStep.name != step {
+			continue
+		}
+		var ids []uuid.UUID
+		query := deletionStep.scope(r.db.WithContext(ctx).Model(deletionStep.model), applicationID)
+		if err := query.Limit(limit).Pluck("id", &ids).Error; err != nil {
+			return 0, err
+		}
+		if len(ids) == 0 {
+			return 0, nil
+		}
+		result := r.db.WithContext(ctx).Delete(deletionStep.model, "id IN ?", ids)
+		return result.RowsAffected, result.Error
+	}
+	return 0, fmt.Errorf("未知的应用删除步骤: %s", step)
+}
+
+// GetBackup 读取应用的全部配置记录
+// 参数：ctx - 上下文，applicationID - 应用ID
+// 返回：应用配置备份（应用不存在时为 nil）和错误信息
+func (r *applicationRepository) GetBackup(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationBackup, error) {
+	db := r.db.WithContext(ctx)
+	var application models.Application
+	if err := db.Where("id = ?", applicationID).First(&application).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	backup := &models.ApplicationBackup{Application: &application}
+	queries := []any{
+		&backup.Settings,
+		&backup.FeatureFlags,
+		&backup.StorageConfigs,
+		&backup.NetSearchConfigs,
+		&backup.LlmProviders,
+		&backup.Llms,
+		&backup.McpServerConfigs,
+		&backup.McpServerTools,
+		&backup.ToolBundles,
+		&backup.ToolBundleTools,
+		&backup.KnowledgeBases,
+		&backup.ChatAgents,
+		&backup.ChatAgentToolBundles,
+		&backup.ChatAgentAnswerRules,
+		&backup.ChatAgentApiKeys,
+		&backup.Webhooks,
+	}
+	for _, records := range queries {
+		if err := db.Where("application_id = ?", applicationID).Order("created_at ASC").Order("id ASC").Find(records).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	// 智能体的MCP工具设置没有 application_id 字段，按智能体筛选
+	if len(backup.ChatAgents) > 0 {
+		chatAgentIDs := make([]uuid.UUID, 0, len(backup.ChatAgents))
+		for _, chatAgent := range backup.ChatAgents {
+			chatAgentIDs = append(chatAgentIDs, chatAgent.ID)
+		}
+		if err := db.Where("chat_agent_id IN ?", chatAgentIDs).Order("created_at ASC").Order("id ASC").Find(&backup.ChatAgentMcpServerTools).Error; err != nil {
+			return nil, err
+		}
+	}
+	return backup, nil
+}
+
+// RestoreBackup 按原有ID写入备份中的全部记录
+// 任一记录写入失败（如ID已存在）时整个事务回滚
+// 参数：ctx - 上下文，backup - 应用配置备份
+// 返回：错误信息
+func (r *applicationRepository) RestoreBackup(ctx context.Context, backup *models.ApplicationBackup) error {
+	// 写入时零值的 enabled 会被字段默认值 true 替换，先记下已停用的 Webhook，写入后单独更新
+	var disabledWebhookIDs []uuid.UUID
+	for _, webhook := range backup.Webhooks {
+		if !webhook.Enabled {
+			disabledWebhookIDs = append(disabledWebhookIDs, webhook.ID)
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(backup.Application).Error; err != nil {
+			return err
+		}
+		inserts := []func() error{
+			func() error { return createBackupRecords(tx, backup.Settings) },
+			func() error { return createBackupRecords(tx, backup.FeatureFlags) },
+			func() error { return createBackupRecords(tx, backup.StorageConfigs) },
+			func() error { return createBackupRecords(tx, backup.NetSearchConfigs) },
+			func() error { return createBackupRecords(tx, backup.LlmProviders) },
+			func() error { return createBackupRecords(tx, backup.Llms) },
+			func() error { return createBackupRecords(tx, backup.McpServerConfigs) },
+			func() error { return createBackupRecords(tx, backup.McpServerTools) },
+			func() error { return createBackupRecords(tx, backup.ToolBundles) },
+			func() error { return createBackupRecords(tx, backup.ToolBundleTools) },
+			func() error { return createBackupRecords(tx, backup.KnowledgeBases) },
+			func() error { return createBackupRecords(tx, backup.ChatAgents) },
+			func() error { return createBackupRecords(tx, backup.ChatAgentMcpServerTools) },
+			func() error { return createBackupRecords(tx, backup.ChatAgentToolBundles) },
+			func() error { return createBackupRecords(tx, backup.ChatAgentAnswerRules) },
+			func() error { return createBackupRecords(tx, backup.ChatAgentApiKeys) },
+			func() error { return createBackupRecords(tx, backup.Webhooks) },
+		}
+		for _, insert := range inserts {
+			if err := insert(); err != nil {
+				return err
+			}
+		}
+
+		if len(disabledWebhookIDs) > 0 {
+			if err := tx.Model(&models.ApplicationWebhook{}).Where("id IN ?", disabledWebhookIDs).Update("enabled", false).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// createBackupRecords 分批写入备份中的一组记录
+func createBackupRecords[T any](tx *gorm.DB, records []*T) error {
+	if len(records) == 0 {
+		return nil
 	}
+	return tx.CreateInBatches(records, applicationBackupBatchSize).Error
 }
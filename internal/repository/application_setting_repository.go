@@ -0,0 +1,77 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationSettingRepository ApplicationSetting 数据访问层接口
+// 定义了 ApplicationSetting 模型的所有数据操作接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ApplicationSettingRepository interface {
+	base.BaseRepository[models.ApplicationSetting] // 继承基础仓库接口
+
+	// GetByApplicationID 根据应用ID获取应用设置，不存在时返回 nil
+	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationSetting, error)
+
+	// GetWithDataRetention 获取设置了数据保留天数的应用设置
+	GetWithDataRetention(ctx context.Context) ([]*models.ApplicationSetting, error)
+
+	// DeleteByApplicationID 删除应用设置
+	// 物理删除，重新保存时不会与已删除记录的唯一索引冲突
+	DeleteByApplicationID(ctx context.Context, applicationID uuid.UUID) error
+}
+
+// applicationSettingRepository ApplicationSetting 数据访问层实现
+// 实现了 ApplicationSettingRepository 接口的所有方法
+// 通过组合 baseRepository 来复用基础功能
+type applicationSettingRepository struct {
+	base.BaseRepository[models.ApplicationSetting]          // 组合基础仓库实现
+	db                                             *gorm.DB // 数据库连接
+}
+
+// NewApplicationSettingRepository 创建 ApplicationSetting Repository 实例
+// 返回 ApplicationSettingRepository 接口的实现
+// 参数：db - GORM 数据库连接实例
+func NewApplicationSettingRepository(db *gorm.DB) ApplicationSettingRepository {
+	return &applicationSettingRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationSetting](db),
+		db:             db,
+	}
+}
+
+// GetByApplicationID 根据应用ID获取应用设置
+// 参数：ctx - 上下文，applicationID - 应用ID
+// 返回：应用设置和错误信息，未找到时返回 nil
+func (r *applicationSettingRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationSetting, error) {
+	var setting models.ApplicationSetting
+	err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// DeleteByApplicationID 删除应用设置
+// 参数：ctx - 上下文，applicationID - 应用ID
+func (r *applicationSettingRepository) DeleteByApplicationID(ctx context.Context, applicationID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("application_id = ?", applicationID).Delete(&models.ApplicationSetting{}).Error
+}
+
+// GetWithDataRetention 获取设置了数据保留天数的应用设置
+// 参数：ctx - 上下文
+// 返回：应用设置列表和错误信息
+func (r *applicationSettingRepository) GetWithDataRetention(ctx context.Context) ([]*models.ApplicationSetting, error) {
+	var settings []*models.ApplicationSetting
+	err := r.db.WithContext(ctx).Where("data_retention_days > 0").Find(&settings).Error
+	return settings, err
+}
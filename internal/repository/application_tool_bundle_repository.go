@@ -0,0 +1,245 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationToolBundleRepository 应用工具集 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能，并管理工具集包含的工具和关联的智能体
+type ApplicationToolBundleRepository interface {
+	base.BaseRepository[models.ApplicationToolBundle] // 继承基础仓库接口
+
+	// GetByApplicationID 获取应用的全部工具集，按名称排序
+	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationToolBundle, error)
+
+	// GetByApplicationIDAndName 根据名称获取应用的工具集，不存在时返回 nil
+	GetByApplicationIDAndName(ctx context.Context, applicationID uuid.UUID, name string) (*models.ApplicationToolBundle, error)
+
+	// GetToolIDs 获取工具集包含的MCP工具ID
+	GetToolIDs(ctx context.Context, bundleID uuid.UUID) ([]uuid.UUID, error)
+
+	// SaveWithTools 保存工具集并替换其包含的MCP工具
+	SaveWithTools(ctx context.Context, bundle *models.ApplicationToolBundle, toolIDs []uuid.UUID) error
+
+	// GetChatAgentIDs 获取关联了工具集的智能体ID
+	GetChatAgentIDs(ctx context.Context, bundleID uuid.UUID) ([]uuid.UUID, error)
+
+	// AttachChatAgents 将工具集关联到智能体，已关联的智能体跳过
+	AttachChatAgents(ctx context.Context, bundle *models.ApplicationToolBundle, chatAgentIDs []uuid.UUID) error
+
+	// DetachChatAgents 取消工具集与智能体的关联
+	DetachChatAgents(ctx context.Context, bundleID uuid.UUID, chatAgentIDs []uuid.UUID) error
+
+	// GetByChatAgentID 获取智能体关联的工具集，按名称排序
+	GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationToolBundle, error)
+
+	// GetToolIDsByChatAgentID 获取智能体通过工具集获得的MCP工具ID，按工具ID映射到所属工具集名称
+	GetToolIDsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) (map[uuid.UUID][]string, error)
+
+	// DeleteWithDependents 删除工具集及其包含的工具和智能体关联
+	DeleteWithDependents(ctx context.Context, bundleID uuid.UUID) error
+}
+
+// applicationToolBundleRepository 应用工具集 数据访问层实现
+type applicationToolBundleRepository struct {
+	base.BaseRepository[models.ApplicationToolBundle]          // 组合基础仓库实现
+	db                                                *gorm.DB // 数据库连接
+}
+
+// NewApplicationToolBundleRepository 创建 应用工具集 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewApplicationToolBundleRepository(db *gorm.DB) ApplicationToolBundleRepository {
+	return &applicationToolBundleRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationToolBundle](db),
+		db:             db,
+	}
+}
+
+// GetByApplicationID 获取应用的全部工具集，按名称排序
+func (r *applicationToolBundleRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	var bundles []*models.ApplicationToolBundle
+	if err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).
+		Order("name ASC").Find(&bundles).Error; err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// GetByApplicationIDAndName 根据名称获取应用的工具集，不存在时返回 nil
+func (r *applicationToolBundleRepository) GetByApplicationIDAndName(ctx context.Context, applicationID uuid.UUID, name string) (*models.ApplicationToolBundle, error) {
+	var bundle models.ApplicationToolBundle
+	err := r.db.WithContext(ctx).Where("application_id = ? AND name = ?", applicationID, name).First(&bundle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// GetToolIDs 获取工具集包含的MCP工具ID
+// 同步MCP服务时已删除的工具不返回
+func (r *applicationToolBundleRepository) GetToolIDs(ctx context.Context, bundleID uuid.UUID) ([]uuid.UUID, error) {
+	var toolIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.ApplicationToolBundleTool{}).
+		Where("bundle_id = ?", bundleID).
+		Where("application_mcp_server_tool_id IN (?)", r.existingToolIDs()).
+		Order("created_at ASC").
+		Pluck("application_mcp_server_tool_id", &toolIDs).Error; err != nil {
+		return nil, err
+	}
+	return toolIDs, nil
+}
+
+// SaveWithTools 保存工具集并替换其包含的MCP工具
+// 在同一事务中保存工具集、物理删除原有的工具记录并写入新的工具记录
+func (r *applicationToolBundleRepository) SaveWithTools(ctx context.Context, bundle *models.ApplicationToolBundle, toolIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(bundle).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("bundle_id = ?", bundle.ID).
+			Delete(&models.ApplicationToolBundleTool{}).Error; err != nil {
+			return err
+		}
+		if len(toolIDs) == 0 {
+			return nil
+		}
+		tools := make([]*models.ApplicationToolBundleTool, 0, len(toolIDs))
+		for _, toolID := range toolIDs {
+			tools = append(tools, &models.ApplicationToolBundleTool{
+				ApplicationID:              bundle.ApplicationID,
+				BundleID:                   bundle.ID,
+				ApplicationMcpServerToolID: toolID,
+			})
+		}
+		return tx.CreateInBatches(tools, 100).Error
+	})
+}
+
+// GetChatAgentIDs 获取关联了工具集的智能体ID
+func (r *applicationToolBundleRepository) GetChatAgentIDs(ctx context.Context, bundleID uuid.UUID) ([]uuid.UUID, error) {
+	var chatAgentIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.ChatAgentToolBundle{}).
+		Where("bundle_id = ?", bundleID).Order("created_at ASC").
+		Pluck("chat_agent_id", &chatAgentIDs).Error; err != nil {
+		return nil, err
+	}
+	return chatAgentIDs, nil
+}
+
+// AttachChatAgents 将工具集关联到智能体，已关联的智能体跳过
+func (r *applicationToolBundleRepository) AttachChatAgents(ctx context.Context, bundle *models.ApplicationToolBundle, chatAgentIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var attachedIDs []uuid.UUID
+		if err := tx.Model(&models.ChatAgentToolBundle{}).
+			Where("bundle_id = ? AND chat_agent_id IN ?", bundle.ID, chatAgentIDs).
+			Pluck("chat_agent_id", &attachedIDs).Error; err != nil {
+			return err
+		}
+		attached := make(map[uuid.UUID]bool, len(attachedIDs))
+		for _, id := range attachedIDs {
+			attached[id] = true
+		}
+
+		var attachments []*models.ChatAgentToolBundle
+		for _, chatAgentID := range chatAgentIDs {
+			if attached[chatAgentID] {
+				continue
+			}
+			attached[chatAgentID] = true
+			attachments = append(attachments, &models.ChatAgentToolBundle{
+				ApplicationID: bundle.ApplicationID,
+				ChatAgentID:   chatAgentID,
+				BundleID:      bundle.ID,
+			})
+		}
+		if len(attachments) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(attachments, 100).Error
+	})
+}
+
+// DetachChatAgents 取消工具集与智能体的关联
+// 关联记录物理删除，重新关联时不受软删除记录影响
+func (r *applicationToolBundleRepository) DetachChatAgents(ctx context.Context, bundleID uuid.UUID, chatAgentIDs []uuid.UUID) error {
+	if len(chatAgentIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().
+		Where("bundle_id = ? AND chat_agent_id IN ?", bundleID, chatAgentIDs).
+		Delete(&models.ChatAgentToolBundle{}).Error
+}
+
+// GetByChatAgentID 获取智能体关联的工具集，按名称排序
+func (r *applicationToolBundleRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	var bundles []*models.ApplicationToolBundle
+	if err := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&models.ChatAgentToolBundle{}).
+			Select("bundle_id").Where("chat_agent_id = ?", chatAgentID)).
+		Order("name ASC").Find(&bundles).Error; err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// GetToolIDsByChatAgentID 获取智能体通过工具集获得的MCP工具ID
+// 返回：MCP工具ID到所属工具集名称列表的映射，同一工具可能来自多个工具集
+func (r *applicationToolBundleRepository) GetToolIDsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) (map[uuid.UUID][]string, error) {
+	bundles, err := r.GetByChatAgentID(ctx, chatAgentID)
+	if err != nil {
+		return nil, err
+	}
+	toolBundles := make(map[uuid.UUID][]string)
+	if len(bundles) == 0 {
+		return toolBundles, nil
+	}
+
+	bundleNames := make(map[uuid.UUID]string, len(bundles))
+	bundleIDs := make([]uuid.UUID, 0, len(bundles))
+	for _, bundle := range bundles {
+		bundleNames[bundle.ID] = bundle.Name
+		bundleIDs = append(bundleIDs, bundle.ID)
+	}
+	var tools []*models.ApplicationToolBundleTool
+	if err := r.db.WithContext(ctx).Where("bundle_id IN ?", bundleIDs).
+		Where("application_mcp_server_tool_id IN (?)", r.existingToolIDs()).
+		Find(&tools).Error; err != nil {
+		return nil, err
+	}
+	for _, tool := range tools {
+		toolBundles[tool.ApplicationMcpServerToolID] = append(toolBundles[tool.ApplicationMcpServerToolID], bundleNames[tool.BundleID])
+	}
+	return toolBundles, nil
+}
+
+// DeleteWithDependents 删除工具集及其包含的工具和智能体关联
+func (r *applicationToolBundleRepository) DeleteWithDependents(ctx context.Context, bundleID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("bundle_id = ?", bundleID).
+			Delete(&models.ChatAgentToolBundle{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("bundle_id = ?", bundleID).
+			Delete(&models.ApplicationToolBundleTool{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ApplicationToolBundle{}, "id = ?", bundleID).Error
+	})
+}
+
+// existingToolIDs 未删除的MCP工具ID子查询
+// 同步MCP服务时删除的工具仍可能留在工具集中，读取时过滤
+func (r *applicationToolBundleRepository) existingToolIDs() *gorm.DB {
+	return r.db.Model(&models.ApplicationMcpServerTool{}).Select("id")
+}
@@ -0,0 +1,107 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationWebhookDeliveryRepository Webhook 推送记录 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ApplicationWebhookDeliveryRepository interface {
+	base.BaseRepository[models.ApplicationWebhookDelivery] // 继承基础仓库接口
+
+	// GetDue 获取处于指定状态且到达推送时间的记录，按推送时间正序
+	GetDue(ctx context.Context, status string, now time.Time, limit int) ([]*models.ApplicationWebhookDelivery, error)
+
+	// Claim 将推送记录的下次推送时间推迟到 leaseUntil，避免多个实例同时推送
+	// 记录的状态或下次推送时间已被其他实例修改时返回 false
+	Claim(ctx context.Context, delivery *models.ApplicationWebhookDelivery, leaseUntil time.Time) (bool, error)
+
+	// GetByWebhookIDWithPagination 分页获取 Webhook 的推送记录，按创建时间倒序，status 为空时不筛选状态
+	GetByWebhookIDWithPagination(ctx context.Context, webhookID uuid.UUID, status string, page, pageSize int) ([]*models.ApplicationWebhookDelivery, int64, error)
+
+	// DeleteBefore 物理删除指定时间之前创建且处于指定状态之一的推送记录，返回删除的数量
+	DeleteBefore(ctx context.Context, before time.Time, statuses []string) (int64, error)
+}
+
+// applicationWebhookDeliveryRepository Webhook 推送记录 数据访问层实现
+type applicationWebhookDeliveryRepository struct {
+	base.BaseRepository[models.ApplicationWebhookDelivery]          // 组合基础仓库实现
+	db                                                     *gorm.DB // 数据库连接
+}
+
+// NewApplicationWebhookDeliveryRepository 创建 Webhook 推送记录 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewApplicationWebhookDeliveryRepository(db *gorm.DB) ApplicationWebhookDeliveryRepository {
+	return &applicationWebhookDeliveryRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationWebhookDelivery](db),
+		db:             db,
+	}
+}
+
+// GetDue 获取处于指定状态且到达推送时间的记录
+// 参数：ctx - 上下文，status - 推送状态，now - 当前时间，limit - 返回数量
+// 返回：推送记录列表和错误信息
+func (r *applicationWebhookDeliveryRepository) GetDue(ctx context.Context, status string, now time.Time, limit int) ([]*models.ApplicationWebhookDelivery, error) {
+	var deliveries []*models.ApplicationWebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", status, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Claim 将推送记录的下次推送时间推迟到 leaseUntil
+// 参数：ctx - 上下文，delivery - 推送记录，leaseUntil - 推送超时后重新推送的时间
+// 返回：是否领取成功和错误信息
+func (r *applicationWebhookDeliveryRepository) Claim(ctx context.Context, delivery *models.ApplicationWebhookDelivery, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ApplicationWebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, delivery.Status, delivery.NextAttemptAt).
+		UpdateColumn("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	delivery.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+// GetByWebhookIDWithPagination 分页获取 Webhook 的推送记录
+// 参数：ctx - 上下文，webhookID - Webhook ID，status - 推送状态（可选），page - 页码，pageSize - 每页数量
+// 返回：推送记录列表、总数量和错误信息
+func (r *applicationWebhookDeliveryRepository) GetByWebhookIDWithPagination(ctx context.Context, webhookID uuid.UUID, status string, page, pageSize int) ([]*models.ApplicationWebhookDelivery, int64, error) {
+	var deliveries []*models.ApplicationWebhookDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.ApplicationWebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// DeleteBefore 物理删除指定时间之前创建且处于指定状态之一的推送记录
+// 参数：ctx - 上下文，before - 截止时间，statuses - 推送状态列表
+// 返回：删除的数量和错误信息
+func (r *applicationWebhookDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time, statuses []string) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("created_at < ? AND status IN ?", before, statuses).
+		Delete(&models.ApplicationWebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
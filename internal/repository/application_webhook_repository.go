@@ -0,0 +1,75 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApplicationWebhookRepository 应用 Webhook 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ApplicationWebhookRepository interface {
+	base.BaseRepository[models.ApplicationWebhook] // 继承基础仓库接口
+
+	// GetByApplicationID 获取应用的全部 Webhook，按创建时间正序
+	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error)
+
+	// GetEnabledByApplicationID 获取应用已启用的 Webhook
+	GetEnabledByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error)
+
+	// GetByIDUnscoped 根据ID获取 Webhook，包含已删除的记录，不存在时返回 nil
+	GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error)
+}
+
+// applicationWebhookRepository 应用 Webhook 数据访问层实现
+type applicationWebhookRepository struct {
+	base.BaseRepository[models.ApplicationWebhook]          // 组合基础仓库实现
+	db                                             *gorm.DB // 数据库连接
+}
+
+// NewApplicationWebhookRepository 创建 应用 Webhook Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewApplicationWebhookRepository(db *gorm.DB) ApplicationWebhookRepository {
+	return &applicationWebhookRepository{
+		BaseRepository: base.NewBaseRepository[models.ApplicationWebhook](db),
+		db:             db,
+	}
+}
+
+// GetByApplicationID 获取应用的全部 Webhook
+// 参数：ctx - 上下文，applicationID - 应用ID
+// 返回：Webhook 列表和错误信息
+func (r *applicationWebhookRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error) {
+	var webhooks []*models.ApplicationWebhook
+	err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).Order("created_at ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// GetEnabledByApplicationID 获取应用已启用的 Webhook
+// 参数：ctx - 上下文，applicationID - 应用ID
+// 返回：Webhook 列表和错误信息
+func (r *applicationWebhookRepository) GetEnabledByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error) {
+	var webhooks []*models.ApplicationWebhook
+	err := r.db.WithContext(ctx).Where("application_id = ? AND enabled = ?", applicationID, true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+// GetByIDUnscoped 根据ID获取 Webhook，包含已删除的记录
+// 参数：ctx - 上下文，id - Webhook ID
+// 返回：Webhook 和错误信息，未找到时返回 nil
+func (r *applicationWebhookRepository) GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error) {
+	var webhook models.ApplicationWebhook
+	err := r.db.WithContext(ctx).Unscoped().Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
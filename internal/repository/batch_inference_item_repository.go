@@ -0,0 +1,154 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// batchInferenceItemBatchSize 批量写入提示词时每批的记录数
+const batchInferenceItemBatchSize = 200
+
+// BatchInferenceItemRepository 批量推理任务提示词 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type BatchInferenceItemRepository interface {
+	base.BaseRepository[models.BatchInferenceItem] // 继承基础仓库接口
+
+	// BatchCreate 批量创建提示词
+	BatchCreate(ctx context.Context, items []*models.BatchInferenceItem) error
+
+	// GetByJobIDWithPagination 获取任务的提示词列表（分页），按序号排列
+	// status 不为空时只返回该处理状态的提示词
+	GetByJobIDWithPagination(ctx context.Context, jobID uuid.UUID, status string, page, pageSize int) ([]*models.BatchInferenceItem, int64, error)
+
+	// GetAllByJobID 获取任务的全部提示词，按序号排列
+	GetAllByJobID(ctx context.Context, jobID uuid.UUID) ([]*models.BatchInferenceItem, error)
+
+	// GetByJobIDAndStatus 按序号获取任务中处于指定状态的前 limit 条提示词
+	GetByJobIDAndStatus(ctx context.Context, jobID uuid.UUID, status string, limit int) ([]*models.BatchInferenceItem, error)
+
+	// UpdateStatusByJobID 将任务中处于 fromStatus 的提示词全部更新为 status
+	UpdateStatusByJobID(ctx context.Context, jobID uuid.UUID, fromStatus, status string) error
+
+	// UpdateStatusByIDs 更新指定提示词的处理状态
+	UpdateStatusByIDs(ctx context.Context, ids []uuid.UUID, status string) error
+
+	// UpdateResult 更新提示词的处理结果
+	UpdateResult(ctx context.Context, item *models.BatchInferenceItem) error
+
+	// CountByJobIDAndStatus 统计任务中各处理状态的提示词数量
+	CountByJobIDAndStatus(ctx context.Context, jobID uuid.UUID) (map[string]int, error)
+}
+
+// batchInferenceItemRepository 批量推理任务提示词 数据访问层实现
+type batchInferenceItemRepository struct {
+	base.BaseRepository[models.BatchInferenceItem]          // 组合基础仓库实现
+	db                                             *gorm.DB // 数据库连接
+}
+
+// NewBatchInferenceItemRepository 创建 批量推理任务提示词 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewBatchInferenceItemRepository(db *gorm.DB) BatchInferenceItemRepository {
+	return &batchInferenceItemRepository{
+		BaseRepository: base.NewBaseRepository[models.BatchInferenceItem](db),
+		db:             db,
+	}
+}
+
+// BatchCreate 批量创建提示词
+func (r *batchInferenceItemRepository) BatchCreate(ctx context.Context, items []*models.BatchInferenceItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(items, batchInferenceItemBatchSize).Error
+}
+
+// GetByJobIDWithPagination 获取任务的提示词列表（分页），按序号排列
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，jobID - 任务ID，status - 处理状态（为空时不过滤），page - 页码（从1开始），pageSize - 每页大小
+// 返回：提示词列表、总数量和错误信息
+func (r *batchInferenceItemRepository) GetByJobIDWithPagination(ctx context.Context, jobID uuid.UUID, status string, page, pageSize int) ([]*models.BatchInferenceItem, int64, error) {
+	var items []*models.BatchInferenceItem
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(base.UseReadReplica).Model(&models.BatchInferenceItem{}).Where("job_id = ?", jobID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("item_index ASC").Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// GetAllByJobID 获取任务的全部提示词，按序号排列
+func (r *batchInferenceItemRepository) GetAllByJobID(ctx context.Context, jobID uuid.UUID) ([]*models.BatchInferenceItem, error) {
+	var items []*models.BatchInferenceItem
+	if err := r.db.WithContext(ctx).Where("job_id = ?", jobID).Order("item_index ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetByJobIDAndStatus 按序号获取任务中处于指定状态的前 limit 条提示词
+func (r *batchInferenceItemRepository) GetByJobIDAndStatus(ctx context.Context, jobID uuid.UUID, status string, limit int) ([]*models.BatchInferenceItem, error) {
+	var items []*models.BatchInferenceItem
+	if err := r.db.WithContext(ctx).Where("job_id = ? AND status = ?", jobID, status).
+		Order("item_index ASC").Limit(limit).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateStatusByJobID 将任务中处于 fromStatus 的提示词全部更新为 status
+func (r *batchInferenceItemRepository) UpdateStatusByJobID(ctx context.Context, jobID uuid.UUID, fromStatus, status string) error {
+	return r.db.WithContext(ctx).Model(&models.BatchInferenceItem{}).
+		Where("job_id = ? AND status = ?", jobID, fromStatus).Update("status", status).Error
+}
+
+// UpdateStatusByIDs 更新指定提示词的处理状态
+func (r *batchInferenceItemRepository) UpdateStatusByIDs(ctx context.Context, ids []uuid.UUID, status string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.BatchInferenceItem{}).Where("id IN ?", ids).Update("status", status).Error
+}
+
+// UpdateResult 更新提示词的处理结果
+func (r *batchInferenceItemRepository) UpdateResult(ctx context.Context, item *models.BatchInferenceItem) error {
+	return r.db.WithContext(ctx).Model(&models.BatchInferenceItem{}).Where("id = ?", item.ID).
+		Updates(map[string]interface{}{
+			"status":          item.Status,
+			"answer":          item.Answer,
+			"conversation_id": item.ConversationID,
+			"error_message":   item.ErrorMessage,
+			"duration_ms":     item.DurationMs,
+		}).Error
+}
+
+// CountByJobIDAndStatus 统计任务中各处理状态的提示词数量
+func (r *batchInferenceItemRepository) CountByJobIDAndStatus(ctx context.Context, jobID uuid.UUID) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Model(&models.BatchInferenceItem{}).
+		Select("status, COUNT(*) AS count").Where("job_id = ?", jobID).Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,119 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BatchInferenceJobRepository 批量推理任务 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+// 任务状态和进度由后台任务和取消接口同时修改，只通过条件更新修改相关字段，避免互相覆盖
+type BatchInferenceJobRepository interface {
+	base.BaseRepository[models.BatchInferenceJob] // 继承基础仓库接口
+
+	// GetByChatAgentIDWithPagination 获取智能体的批量推理任务列表（分页），按创建时间倒序
+	GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.BatchInferenceJob, int64, error)
+
+	// GetEarliestByStatuses 获取处于指定状态中最早创建的任务，没有时返回空
+	GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.BatchInferenceJob, error)
+
+	// UpdateStatus 当任务处于 fromStatuses 之一时更新状态
+	// startedAt、finishedAt 为空时不更新对应字段；返回是否更新成功
+	UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error)
+
+	// UpdateFailed 将处于 fromStatuses 之一的任务标记为执行失败，并记录失败原因
+	UpdateFailed(ctx context.Context, id uuid.UUID, fromStatuses []string, status, errorMessage string, finishedAt time.Time) (bool, error)
+
+	// UpdateProgress 更新任务的成功和失败数量
+	UpdateProgress(ctx context.Context, id uuid.UUID, completedCount, failedCount int) error
+}
+
+// batchInferenceJobRepository 批量推理任务 数据访问层实现
+type batchInferenceJobRepository struct {
+	base.BaseRepository[models.BatchInferenceJob]          // 组合基础仓库实现
+	db                                            *gorm.DB // 数据库连接
+}
+
+// NewBatchInferenceJobRepository 创建 批量推理任务 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewBatchInferenceJobRepository(db *gorm.DB) BatchInferenceJobRepository {
+	return &batchInferenceJobRepository{
+		BaseRepository: base.NewBaseRepository[models.BatchInferenceJob](db),
+		db:             db,
+	}
+}
+
+// GetByChatAgentIDWithPagination 获取智能体的批量推理任务列表（分页），按创建时间倒序
+// 参数：ctx - 上下文，chatAgentID - 智能体ID，page - 页码（从1开始），pageSize - 每页大小
+// 返回：任务列表、总数量和错误信息
+func (r *batchInferenceJobRepository) GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.BatchInferenceJob, int64, error) {
+	var jobs []*models.BatchInferenceJob
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.BatchInferenceJob{}).Where("chat_agent_id = ?", chatAgentID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+// GetEarliestByStatuses 获取处于指定状态中最早创建的任务，没有时返回空
+func (r *batchInferenceJobRepository) GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.BatchInferenceJob, error) {
+	var job models.BatchInferenceJob
+	err := r.db.WithContext(ctx).Where("status IN ?", statuses).Order("created_at ASC").First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateStatus 当任务处于 fromStatuses 之一时更新状态
+// startedAt、finishedAt 为空时不更新对应字段；返回是否更新成功
+func (r *batchInferenceJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if startedAt != nil {
+		updates["started_at"] = *startedAt
+	}
+	if finishedAt != nil {
+		updates["finished_at"] = *finishedAt
+	}
+	result := r.db.WithContext(ctx).Model(&models.BatchInferenceJob{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateFailed 将处于 fromStatuses 之一的任务标记为执行失败，并记录失败原因
+func (r *batchInferenceJobRepository) UpdateFailed(ctx context.Context, id uuid.UUID, fromStatuses []string, status, errorMessage string, finishedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.BatchInferenceJob{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).
+		Updates(map[string]interface{}{"status": status, "error_message": errorMessage, "finished_at": finishedAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateProgress 更新任务的成功和失败数量
+func (r *batchInferenceJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, completedCount, failedCount int) error {
+	return r.db.WithContext(ctx).Model(&models.BatchInferenceJob{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"completed_count": completedCount, "failed_count": failedCount}).Error
+}
@@ -0,0 +1,59 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatAgentAnswerRuleRepository 预制答案规则 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ChatAgentAnswerRuleRepository interface {
+	base.BaseRepository[models.ChatAgentAnswerRule] // 继承基础仓库接口
+
+	// GetByChatAgentID 获取智能体的全部规则，按优先级倒序
+	GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error)
+
+	// GetEnabledByChatAgentID 获取智能体已启用的规则，按优先级倒序
+	GetEnabledByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error)
+}
+
+// chatAgentAnswerRuleRepository 预制答案规则 数据访问层实现
+type chatAgentAnswerRuleRepository struct {
+	base.BaseRepository[models.ChatAgentAnswerRule]          // 组合基础仓库实现
+	db                                              *gorm.DB // 数据库连接
+}
+
+// NewChatAgentAnswerRuleRepository 创建 预制答案规则 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewChatAgentAnswerRuleRepository(db *gorm.DB) ChatAgentAnswerRuleRepository {
+	return &chatAgentAnswerRuleRepository{
+		BaseRepository: base.NewBaseRepository[models.ChatAgentAnswerRule](db),
+		db:             db,
+	}
+}
+
+// GetByChatAgentID 获取智能体的全部规则，按优先级倒序
+func (r *chatAgentAnswerRuleRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error) {
+	var rules []*models.ChatAgentAnswerRule
+	if err := r.db.WithContext(ctx).Where("chat_agent_id = ?", chatAgentID).
+		Order("priority DESC").Order("created_at ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetEnabledByChatAgentID 获取智能体已启用的规则，按优先级倒序
+func (r *chatAgentAnswerRuleRepository) GetEnabledByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error) {
+	var rules []*models.ChatAgentAnswerRule
+	if err := r.db.WithContext(ctx).Where("chat_agent_id = ? AND enabled = ?", chatAgentID, true).
+		Order("priority DESC").Order("created_at ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
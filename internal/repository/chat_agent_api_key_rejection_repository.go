@@ -0,0 +1,68 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatAgentApiKeyRejectionRepository API Key 访问拒绝记录 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ChatAgentApiKeyRejectionRepository interface {
+	base.BaseRepository[models.ChatAgentApiKeyRejection] // 继承基础仓库接口
+
+	// GetByChatAgentIDWithPagination 分页获取智能体的访问拒绝记录，按时间倒序
+	GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.ChatAgentApiKeyRejection, int64, error)
+
+	// DeleteBefore 物理删除指定时间之前的访问拒绝记录，返回删除的数量
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// chatAgentApiKeyRejectionRepository API Key 访问拒绝记录 数据访问层实现
+type chatAgentApiKeyRejectionRepository struct {
+	base.BaseRepository[models.ChatAgentApiKeyRejection]          // 组合基础仓库实现
+	db                                                   *gorm.DB // 数据库连接
+}
+
+// NewChatAgentApiKeyRejectionRepository 创建 API Key 访问拒绝记录 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewChatAgentApiKeyRejectionRepository(db *gorm.DB) ChatAgentApiKeyRejectionRepository {
+	return &chatAgentApiKeyRejectionRepository{
+		BaseRepository: base.NewBaseRepository[models.ChatAgentApiKeyRejection](db),
+		db:             db,
+	}
+}
+
+// GetByChatAgentIDWithPagination 分页获取智能体的访问拒绝记录
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，chatAgentID - 智能体ID，page - 页码，pageSize - 每页数量
+// 返回：访问拒绝记录列表、总数量和错误信息
+func (r *chatAgentApiKeyRejectionRepository) GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.ChatAgentApiKeyRejection, int64, error) {
+	var rejections []*models.ChatAgentApiKeyRejection
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(base.UseReadReplica).Model(&models.ChatAgentApiKeyRejection{}).Where("chat_agent_id = ?", chatAgentID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&rejections).Error; err != nil {
+		return nil, 0, err
+	}
+	return rejections, total, nil
+}
+
+// DeleteBefore 物理删除指定时间之前的访问拒绝记录
+// 参数：ctx - 上下文，before - 截止时间
+// 返回：删除的数量和错误信息
+func (r *chatAgentApiKeyRejectionRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("created_at < ?", before).
+		Delete(&models.ChatAgentApiKeyRejection{})
+	return result.RowsAffected, result.Error
+}
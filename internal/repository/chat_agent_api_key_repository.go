@@ -7,6 +7,7 @@ import (
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +17,9 @@ import (
 type ChatAgentApiKeyRepository interface {
 	base.BaseRepository[models.ChatAgentApiKey]                                      // 继承基础仓库接口
 	GetByApiKey(ctx context.Context, apiKey string) (*models.ChatAgentApiKey, error) // 通过 apiKey 获取 ChatAgentApiKey
+
+	// GetByChatAgentID 获取智能体的全部 API Key，按创建时间排序
+	GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentApiKey, error)
 }
 
 // chatAgentApiKeyRepository ChatAgentApiKey 数据访问层实现
@@ -40,9 +44,20 @@ func NewChatAgentApiKeyRepository(db *gorm.DB) ChatAgentApiKeyRepository {
 // 参数：ctx - 上下文对象, apiKey - 待查询的 apiKey
 func (r *chatAgentApiKeyRepository) GetByApiKey(ctx context.Context, apiKey string) (*models.ChatAgentApiKey, error) {
 	var chatAgentApiKey models.ChatAgentApiKey
-	err := r.db.WithContext(ctx).Where("api_key = ? AND deleted_at IS NULL", apiKey).First(&chatAgentApiKey).Error
+	err := r.db.WithContext(ctx).Where("api_key = ?", apiKey).First(&chatAgentApiKey).Error
 	if err != nil {
 		return nil, err
 	}
 	return &chatAgentApiKey, nil
 }
+
+// GetByChatAgentID 获取智能体的全部 API Key
+// 参数：ctx - 上下文对象, chatAgentID - 智能体ID
+func (r *chatAgentApiKeyRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentApiKey, error) {
+	var apiKeys []*models.ChatAgentApiKey
+	err := r.db.WithContext(ctx).Where("chat_agent_id = ?", chatAgentID).Order("created_at ASC").Find(&apiKeys).Error
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
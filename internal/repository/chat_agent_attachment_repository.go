@@ -3,9 +3,11 @@
 package repository
 
 import (
+	"context"
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -14,13 +16,23 @@ import (
 // 继承自 BaseRepository，包含基本的增删改查功能
 type ChatAgentAttachmentRepository interface {
 	base.BaseRepository[models.ChatAgentAttachment] // 继承基础仓库接口
+
+	// ListByMessageIDs 获取智能体下指定消息的全部附件
+	ListByMessageIDs(ctx context.Context, chatAgentID uuid.UUID, messageIDs []uuid.UUID) ([]*models.ChatAgentAttachment, error)
+
+	// ListDeletedWithFiles 获取已软删除但文件尚未清理的附件
+	ListDeletedWithFiles(ctx context.Context, limit int) ([]*models.ChatAgentAttachment, error)
+
+	// ClearFilePaths 清空附件的文件路径，标记文件已清理
+	ClearFilePaths(ctx context.Context, id uuid.UUID) error
 }
 
 // chatAgentAttachmentRepository ChatAgentAttachment 数据访问层实现
 // 实现了 ChatAgentAttachmentRepository 接口的所有方法
 // 通过组合 baseRepository 来复用基础功能
 type chatAgentAttachmentRepository struct {
-	base.BaseRepository[models.ChatAgentAttachment] // 组合基础仓库实现
+	base.BaseRepository[models.ChatAgentAttachment]          // 组合基础仓库实现
+	db                                              *gorm.DB // 数据库连接
 }
 
 // NewChatAgentAttachmentRepository 创建 ChatAgentAttachment Repository 实例
@@ -29,5 +41,44 @@ type chatAgentAttachmentRepository struct {
 func NewChatAgentAttachmentRepository(db *gorm.DB) ChatAgentAttachmentRepository {
 	return &chatAgentAttachmentRepository{
 		BaseRepository: base.NewBaseRepository[models.ChatAgentAttachment](db),
+		db:             db,
+	}
+}
+
+// ListByMessageIDs 获取智能体下指定消息的全部附件
+// 参数：ctx - 上下文，chatAgentID - 智能体ID，messageIDs - 消息ID列表
+// 返回：附件列表和错误信息
+func (r *chatAgentAttachmentRepository) ListByMessageIDs(ctx context.Context, chatAgentID uuid.UUID, messageIDs []uuid.UUID) ([]*models.ChatAgentAttachment, error) {
+	var attachments []*models.ChatAgentAttachment
+	if len(messageIDs) == 0 {
+		return attachments, nil
 	}
+	err := r.db.WithContext(ctx).
+		Where("chat_agent_id = ? AND message_id IN ?", chatAgentID, messageIDs).
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// ListDeletedWithFiles 获取已软删除但文件尚未清理的附件
+// 按删除时间从早到晚排序
+// 参数：ctx - 上下文，limit - 最大返回数量
+// 返回：附件列表和错误信息
+func (r *chatAgentAttachmentRepository) ListDeletedWithFiles(ctx context.Context, limit int) ([]*models.ChatAgentAttachment, error) {
+	var attachments []*models.ChatAgentAttachment
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND (file_path <> '' OR markdown_path <> '')").
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// ClearFilePaths 清空附件的文件路径，标记文件已清理
+// 已软删除的附件同样适用
+// 参数：ctx - 上下文，id - 附件ID
+// 返回：错误信息
+func (r *chatAgentAttachmentRepository) ClearFilePaths(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.ChatAgentAttachment{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"file_path": "", "markdown_path": ""}).Error
 }
@@ -3,9 +3,12 @@
 package repository
 
 import (
+	"context"
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -14,13 +17,77 @@ import (
 // 继承自 BaseRepository，包含基本的增删改查功能
 type ChatAgentConversationRepository interface {
 	base.BaseRepository[models.ChatAgentConversation] // 继承基础仓库接口
+
+	// CreateWithEvent 在同一事务中创建会话和会话事件，保证事件与会话同时写入
+	CreateWithEvent(ctx context.Context, conversation *models.ChatAgentConversation, event *models.ConversationEvent) error
+
+	// DeleteWithMessageRequests 在同一事务中删除会话及其登记的消息请求ID
+	DeleteWithMessageRequests(ctx context.Context, id uuid.UUID) error
+
+	// IncrementErrorCount 增加会话的错误次数并记录最后一次错误时间
+	IncrementErrorCount(ctx context.Context, id uuid.UUID) error
+
+	// IncrementPersistenceGapCount 增加会话放弃重试保存的消息数量并记录时间
+	IncrementPersistenceGapCount(ctx context.Context, id uuid.UUID) error
+
+	// UpdateTitle 更新会话标题，只更新标题字段，避免覆盖并发修改的其他字段
+	UpdateTitle(ctx context.Context, id uuid.UUID, title string) error
+
+	// TryStartRequest 登记会话正在处理的消息请求
+	// 会话空闲或已有请求开始于 staleBefore 之前（视为已失效）时登记成功并返回 true
+	TryStartRequest(ctx context.Context, id uuid.UUID, requestID string, staleBefore time.Time) (bool, error)
+
+	// FinishRequest 清除会话正在处理的消息请求，仅当登记的仍是该请求时清除
+	FinishRequest(ctx context.Context, id uuid.UUID, requestID string) error
+
+	// ListWithStats 按管理后台的筛选条件查询会话及其消息统计（分页）
+	ListWithStats(ctx context.Context, query *ChatAgentConversationStatsQuery) ([]*ChatAgentConversationWithStats, int64, error)
+
+	// UpdateLastMessageAt 更新会话最后一条普通消息的时间，只会向后推进
+	UpdateLastMessageAt(ctx context.Context, id uuid.UUID, at time.Time) error
+
+	// ListByServiceUser 游标分页查询业务侧用户在智能体下的会话，默认按最后一条消息的时间倒序
+	ListByServiceUser(ctx context.Context, query *ChatAgentConversationListQuery) ([]*models.ChatAgentConversation, error)
+
+	// GetInactiveBefore 获取应用中在 before 之前就不再活跃的会话，按最后活跃时间正序
+	GetInactiveBefore(ctx context.Context, applicationID uuid.UUID, before time.Time, limit int) ([]*models.ChatAgentConversation, error)
+}
+
+// ChatAgentConversationListQuery 业务侧用户会话列表查询条件
+type ChatAgentConversationListQuery struct {
+	ChatAgentID     uuid.UUID                     // 所属智能体ID
+	ServiceUserID   string                        // 业务侧用户ID
+	SortByCreatedAt bool                          // 是否按创建时间倒序排列，默认按最后一条消息的时间倒序
+	Cursor          *models.ChatAgentConversation // 游标会话，返回排在其之后的会话（可选）
+	Limit           int                           // 返回数量，小于等于0时不限制
+}
+
+// ChatAgentConversationStatsQuery 管理后台会话列表查询条件
+type ChatAgentConversationStatsQuery struct {
+	ChatAgentID        uuid.UUID  // 所属智能体ID
+	ServiceUserID      string     // 业务侧用户ID（可选）
+	CreatedAfter       *time.Time // 只返回晚于该时间创建的会话（可选）
+	CreatedBefore      *time.Time // 只返回早于该时间创建的会话（可选）
+	HasErrors          *bool      // 是否发生过错误（可选）
+	HasPersistenceGaps *bool      // 是否存在放弃重试保存的消息（可选）
+	MinTotalTokens     *int64     // 最小token用量（可选）
+	MaxTotalTokens     *int64     // 最大token用量（可选）
+	Page               int        // 页码（从1开始）
+	PageSize           int        // 每页大小
+}
+
+// ChatAgentConversationWithStats 带消息统计的会话，token用量和费用为会话上的累计值
+type ChatAgentConversationWithStats struct {
+	models.ChatAgentConversation
+	MessageCount int64 `gorm:"column:message_count"` // 普通消息数量（不含工具调用消息）
 }
 
 // chatAgentConversationRepository ChatAgentConversation 数据访问层实现
 // 实现了 ChatAgentConversationRepository 接口的所有方法
 // 通过组合 baseRepository 来复用基础功能
 type chatAgentConversationRepository struct {
-	base.BaseRepository[models.ChatAgentConversation] // 组合基础仓库实现
+	base.BaseRepository[models.ChatAgentConversation]          // 组合基础仓库实现
+	db                                                *gorm.DB // 数据库连接
 }
 
 // NewChatAgentConversationRepository 创建 ChatAgentConversation Repository 实例
@@ -29,5 +96,217 @@ type chatAgentConversationRepository struct {
 func NewChatAgentConversationRepository(db *gorm.DB) ChatAgentConversationRepository {
 	return &chatAgentConversationRepository{
 		BaseRepository: base.NewBaseRepository[models.ChatAgentConversation](db),
+		db:             db,
 	}
 }
+
+// IncrementErrorCount 增加会话的错误次数并记录最后一次错误时间
+// 参数：ctx - 上下文，id - 会话ID
+// 返回：错误信息
+func (r *chatAgentConversationRepository) IncrementErrorCount(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.ChatAgentConversation{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"error_count":   gorm.Expr("error_count + 1"),
+			"last_error_at": time.Now(),
+		}).Error
+}
+
+// IncrementPersistenceGapCount 增加会话放弃重试保存的消息数量并记录时间
+// 参数：ctx - 上下文，id - 会话ID
+// 返回：错误信息
+func (r *chatAgentConversationRepository) IncrementPersistenceGapCount(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.ChatAgentConversation{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"persistence_gap_count":   gorm.Expr("persistence_gap_count + 1"),
+			"last_persistence_gap_at": time.Now(),
+		}).Error
+}
+
+// UpdateTitle 更新会话标题
+// 参数：ctx - 上下文，id - 会话ID，title - 新标题
+// 返回：错误信息
+func (r *chatAgentConversationRepository) UpdateTitle(ctx context.Context, id uuid.UUID, title string) error {
+	return r.db.WithContext(ctx).Model(&models.ChatAgentConversation{}).
+		Where("id = ?", id).
+		Update("title", title).Error
+}
+
+// TryStartRequest 登记会话正在处理的消息请求
+// 通过条件更新保证同一时刻只有一个请求登记成功
+// 参数：ctx - 上下文，id - 会话ID，requestID - 请求ID，staleBefore - 早于该时间开始的请求视为已失效
+// 返回：是否登记成功和错误信息
+func (r *chatAgentConversationRepository) TryStartRequest(ctx context.Context, id uuid.UUID, requestID string, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ChatAgentConversation{}).
+		Where("id = ?", id).
+		Where("active_request_id = '' OR active_request_at IS NULL OR active_request_at < ?", staleBefore).
+		UpdateColumns(map[string]interface{}{
+			"active_request_id": requestID,
+			"active_request_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FinishRequest 清除会话正在处理的消息请求
+// 参数：ctx - 上下文，id - 会话ID，requestID - 请求ID
+// 返回：错误信息
+func (r *chatAgentConversationRepository) FinishRequest(ctx context.Context, id uuid.UUID, requestID string) error {
+	return r.db.WithContext(ctx).Model(&models.ChatAgentConversation{}).
+		Where("id = ? AND active_request_id = ?", id, requestID).
+		UpdateColumns(map[string]interface{}{
+			"active_request_id": "",
+			"active_request_at": nil,
+		}).Error
+}
+
+// ListWithStats 按管理后台的筛选条件查询会话及其消息统计（分页）
+// 消息数量通过子查询汇总，token用量使用会话上的累计值，按创建时间倒序排列
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，query - 查询条件
+// 返回：会话列表、符合条件的总数量和错误信息
+func (r *chatAgentConversationRepository) ListWithStats(ctx context.Context, query *ChatAgentConversationStatsQuery) ([]*ChatAgentConversationWithStats, int64, error) {
+	stats := r.db.Model(&models.ChatAgentMessage{}).
+		Select("conversation_id, SUM(CASE WHEN type = ? THEN 1 ELSE 0 END) AS message_count", "message").
+		Where("chat_agent_id = ?", query.ChatAgentID).
+		Group("conversation_id")
+
+	// 使用表别名查询时 GORM 不会自动排除已软删除的记录，需要显式过滤
+	db := r.db.WithContext(ctx).Scopes(base.UseReadReplica).
+		Table("ltc_chat_agent_conversation AS c").
+		Joins("LEFT JOIN (?) AS s ON s.conversation_id = c.id", stats).
+		Where("c.chat_agent_id = ? AND c.deleted_at IS NULL", query.ChatAgentID)
+
+	if query.ServiceUserID != "" {
+		db = db.Where("c.service_user_id = ?", query.ServiceUserID)
+	}
+	if query.CreatedAfter != nil {
+		db = db.Where("c.created_at > ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		db = db.Where("c.created_at < ?", *query.CreatedBefore)
+	}
+	if query.HasErrors != nil {
+		if *query.HasErrors {
+			db = db.Where("c.error_count > 0")
+		} else {
+			db = db.Where("c.error_count = 0")
+		}
+	}
+	if query.HasPersistenceGaps != nil {
+		if *query.HasPersistenceGaps {
+			db = db.Where("c.persistence_gap_count > 0")
+		} else {
+			db = db.Where("c.persistence_gap_count = 0")
+		}
+	}
+	if query.MinTotalTokens != nil {
+		db = db.Where("c.total_token_count >= ?", *query.MinTotalTokens)
+	}
+	if query.MaxTotalTokens != nil {
+		db = db.Where("c.total_token_count <= ?", *query.MaxTotalTokens)
+	}
+
+	// 获取总数
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取分页数据
+	var conversations []*ChatAgentConversationWithStats
+	err := db.Select("c.*, COALESCE(s.message_count, 0) AS message_count").
+		Order("c.created_at DESC").Order("c.id DESC").
+		Offset((query.Page - 1) * query.PageSize).
+		Limit(query.PageSize).
+		Scan(&conversations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return conversations, total, nil
+}
+
+// UpdateLastMessageAt 更新会话最后一条普通消息的时间
+// 只在新时间晚于已记录的时间时更新，并发写入消息时不会倒退；不修改会话的更新时间
+// 参数：ctx - 上下文，id - 会话ID，at - 消息时间
+// 返回：错误信息
+func (r *chatAgentConversationRepository) UpdateLastMessageAt(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.ChatAgentConversation{}).
+		Where("id = ?", id).
+		Where("last_message_at IS NULL OR last_message_at < ?", at).
+		UpdateColumn("last_message_at", at).Error
+}
+
+// ListByServiceUser 游标分页查询业务侧用户在智能体下的会话
+// 排序字段相同时按ID排序，保证翻页时顺序稳定
+// 参数：ctx - 上下文，query - 查询条件
+// 返回：会话列表和错误信息
+func (r *chatAgentConversationRepository) ListByServiceUser(ctx context.Context, query *ChatAgentConversationListQuery) ([]*models.ChatAgentConversation, error) {
+	db := r.db.WithContext(ctx).
+		Where("chat_agent_id = ? AND service_user_id = ?", query.ChatAgentID, query.ServiceUserID)
+
+	sortColumn := "last_message_at"
+	if query.SortByCreatedAt {
+		sortColumn = "created_at"
+	}
+	if query.Cursor != nil {
+		cursorAt := query.Cursor.CreatedAt
+		if !query.SortByCreatedAt && query.Cursor.LastMessageAt != nil {
+			cursorAt = *query.Cursor.LastMessageAt
+		}
+		db = db.Where("("+sortColumn+" < ? OR ("+sortColumn+" = ? AND id < ?))", cursorAt, cursorAt, query.Cursor.ID)
+	}
+	db = db.Order(sortColumn + " DESC").Order("id DESC")
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var conversations []*models.ChatAgentConversation
+	if err := db.Find(&conversations).Error; err != nil {
+		return nil, err
+	}
+	return conversations, nil
+}
+
+// CreateWithEvent 在同一事务中创建会话和会话事件
+// 参数：ctx - 上下文，conversation - 会话，event - 会话事件
+// 返回：错误信息
+func (r *chatAgentConversationRepository) CreateWithEvent(ctx context.Context, conversation *models.ChatAgentConversation, event *models.ConversationEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(conversation).Error; err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+}
+
+// DeleteWithMessageRequests 在同一事务中删除会话及其登记的消息请求ID
+// 参数：ctx - 上下文，id - 会话ID
+// 返回：错误信息
+func (r *chatAgentConversationRepository) DeleteWithMessageRequests(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("conversation_id = ?", id).Delete(&models.ChatAgentMessageRequest{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ChatAgentConversation{}, "id = ?", id).Error
+	})
+}
+
+// GetInactiveBefore 获取应用中在 before 之前就不再活跃的会话
+// 最后一条消息的时间（没有消息时为创建时间）和更新时间都早于 before，且没有正在处理的请求（或请求已早于 before 开始）
+// 参数：ctx - 上下文，applicationID - 应用ID，before - 截止时间，limit - 返回的最大数量
+// 返回：会话列表和错误信息
+func (r *chatAgentConversationRepository) GetInactiveBefore(ctx context.Context, applicationID uuid.UUID, before time.Time, limit int) ([]*models.ChatAgentConversation, error) {
+	var conversations []*models.ChatAgentConversation
+	err := r.db.WithContext(ctx).
+		Where("application_id = ?", applicationID).
+		Where("COALESCE(last_message_at, created_at) < ? AND updated_at < ?", before, before).
+		Where("active_request_id = ? OR active_request_at < ?", "", before).
+		Order("COALESCE(last_message_at, created_at) ASC").Order("id ASC").
+		Limit(limit).
+		Find(&conversations).Error
+	return conversations, err
+}
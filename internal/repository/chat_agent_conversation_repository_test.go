@@ -0,0 +1,71 @@
+package repository_test
+
+import (
+	"context"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/testsupport"
+	"testing"
+	"time"
+)
+
+func TestChatAgentConversationRepositoryListByServiceUser(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	repo := repository.NewChatAgentConversationRepository(db)
+	agent := testsupport.CreateChatAgent(t, db)
+	ctx := context.Background()
+
+	// 最后一条消息的时间与创建顺序相反，按最后一条消息排序时最早创建的会话排在最前
+	base := time.Now()
+	var created []*models.ChatAgentConversation
+	for i := range 3 {
+		conversation := testsupport.CreateConversation(t, db, agent.ChatAgent, "user-a", "会话")
+		if err := repo.UpdateLastMessageAt(ctx, conversation.ID, base.Add(time.Duration(3-i)*time.Minute)); err != nil {
+			t.Fatalf("UpdateLastMessageAt() error = %v", err)
+		}
+		created = append(created, conversation)
+	}
+	testsupport.CreateConversation(t, db, agent.ChatAgent, "user-b", "其他用户的会话")
+
+	tests := []struct {
+		name            string
+		sortByCreatedAt bool
+		want            []*models.ChatAgentConversation
+	}{
+		{name: "last message at", want: created},
+		{name: "created at", sortByCreatedAt: true, want: []*models.ChatAgentConversation{created[2], created[1], created[0]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &repository.ChatAgentConversationListQuery{
+				ChatAgentID:     agent.ChatAgent.ID,
+				ServiceUserID:   "user-a",
+				SortByCreatedAt: tt.sortByCreatedAt,
+				Limit:           2,
+			}
+
+			// 逐页读取，上一页的最后一条作为游标
+			var got []*models.ChatAgentConversation
+			for range len(tt.want) {
+				page, err := repo.ListByServiceUser(ctx, query)
+				if err != nil {
+					t.Fatalf("ListByServiceUser() error = %v", err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				got = append(got, page...)
+				query.Cursor = page[len(page)-1]
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d conversations, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].ID != tt.want[i].ID {
+					t.Errorf("conversation %d = %s, want %s", i, got[i].ID, tt.want[i].ID)
+				}
+			}
+		})
+	}
+}
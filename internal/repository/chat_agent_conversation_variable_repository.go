@@ -0,0 +1,95 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatAgentConversationVariableRepository 会话变量 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ChatAgentConversationVariableRepository interface {
+	base.BaseRepository[models.ChatAgentConversationVariable] // 继承基础仓库接口
+
+	// ListByConversationID 获取会话的全部变量，按变量名称排序
+	ListByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.ChatAgentConversationVariable, error)
+
+	// GetByConversationIDAndName 根据变量名称获取会话变量，不存在时返回 nil
+	GetByConversationIDAndName(ctx context.Context, conversationID uuid.UUID, name string) (*models.ChatAgentConversationVariable, error)
+
+	// CountByConversationID 统计会话的变量数量
+	CountByConversationID(ctx context.Context, conversationID uuid.UUID) (int64, error)
+
+	// DeleteByConversationID 删除会话的全部变量
+	DeleteByConversationID(ctx context.Context, conversationID uuid.UUID) error
+}
+
+// chatAgentConversationVariableRepository 会话变量 数据访问层实现
+type chatAgentConversationVariableRepository struct {
+	base.BaseRepository[models.ChatAgentConversationVariable]          // 组合基础仓库实现
+	db                                                        *gorm.DB // 数据库连接
+}
+
+// NewChatAgentConversationVariableRepository 创建 会话变量 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewChatAgentConversationVariableRepository(db *gorm.DB) ChatAgentConversationVariableRepository {
+	return &chatAgentConversationVariableRepository{
+		BaseRepository: base.NewBaseRepository[models.ChatAgentConversationVariable](db),
+		db:             db,
+	}
+}
+
+// ListByConversationID 获取会话的全部变量
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：变量列表和错误信息
+func (r *chatAgentConversationVariableRepository) ListByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.ChatAgentConversationVariable, error) {
+	var variables []*models.ChatAgentConversationVariable
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ?", conversationID).
+		Order("name ASC").
+		Find(&variables).Error
+	return variables, err
+}
+
+// GetByConversationIDAndName 根据变量名称获取会话变量
+// 参数：ctx - 上下文，conversationID - 会话ID，name - 变量名称
+// 返回：会话变量（不存在时为 nil）和错误信息
+func (r *chatAgentConversationVariableRepository) GetByConversationIDAndName(ctx context.Context, conversationID uuid.UUID, name string) (*models.ChatAgentConversationVariable, error) {
+	var variable models.ChatAgentConversationVariable
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND name = ?", conversationID, name).
+		First(&variable).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &variable, nil
+}
+
+// CountByConversationID 统计会话的变量数量
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：变量数量和错误信息
+func (r *chatAgentConversationVariableRepository) CountByConversationID(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ChatAgentConversationVariable{}).
+		Where("conversation_id = ?", conversationID).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteByConversationID 删除会话的全部变量
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：错误信息
+func (r *chatAgentConversationVariableRepository) DeleteByConversationID(ctx context.Context, conversationID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("conversation_id = ?", conversationID).
+		Delete(&models.ChatAgentConversationVariable{}).Error
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ChatAgentMcpServerToolRepository ChatAgentMcpServerTool 数据访问层接口
@@ -39,6 +40,9 @@ type ChatAgentMcpServerToolRepository interface {
 
 	// DeleteByChatAgentID 根据ChatAgentID删除所有相关记录
 	DeleteByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) error
+
+	// ReplaceByChatAgentID 在同一事务中用给定的工具配置替换智能体的全部工具配置
+	ReplaceByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, settings []*models.ChatAgentMcpServerTool) error
 }
 
 // chatAgentMcpServerToolRepository ChatAgentMcpServerTool 数据访问层实现
@@ -78,7 +82,7 @@ func (r *chatAgentMcpServerToolRepository) Update(ctx context.Context, chatAgent
 
 // DeleteByID 根据ID删除 ChatAgentMcpServerTool 记录
 func (r *chatAgentMcpServerToolRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.ChatAgentMcpServerTool{}, id).Error
+	return r.db.WithContext(ctx).Delete(&models.ChatAgentMcpServerTool{}, "id = ?", id).Error
 }
 
 // GetByChatAgentID 根据ChatAgentID获取所有工具配置
@@ -130,3 +134,37 @@ func (r *chatAgentMcpServerToolRepository) BatchUpdate(ctx context.Context, chat
 func (r *chatAgentMcpServerToolRepository) DeleteByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) error {
 	return r.db.WithContext(ctx).Where("chat_agent_id = ?", chatAgentID).Delete(&models.ChatAgentMcpServerTool{}).Error
 }
+
+// ReplaceByChatAgentID 在同一事务中用给定的工具配置替换智能体的全部工具配置
+// 按 (chat_agent_id, application_mcp_server_tool_id) 唯一索引批量 upsert，已存在的配置（包括已软删除的）原地更新，
+// 不在给定列表中的配置物理删除，并发保存时不会产生重复记录
+// 参数：ctx - 上下文，chatAgentID - 聊天智能体ID，settings - 新的工具配置（同一工具只能出现一次）
+// 返回：错误信息
+func (r *chatAgentMcpServerToolRepository) ReplaceByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, settings []*models.ChatAgentMcpServerTool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		toolIDs := make([]uuid.UUID, 0, len(settings))
+		for _, setting := range settings {
+			setting.ChatAgentID = chatAgentID
+			toolIDs = append(toolIDs, setting.ApplicationMcpServerToolID)
+		}
+
+		deleteQuery := tx.Unscoped().Where("chat_agent_id = ?", chatAgentID)
+		if len(toolIDs) > 0 {
+			deleteQuery = deleteQuery.Where("application_mcp_server_tool_id NOT IN ?", toolIDs)
+		}
+		if err := deleteQuery.Delete(&models.ChatAgentMcpServerTool{}).Error; err != nil {
+			return err
+		}
+		if len(settings) == 0 {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "chat_agent_id"}, {Name: "application_mcp_server_tool_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"enabled", "title_override", "description_override", "parameter_description_overrides",
+				"output_renderer", "updated_at", "deleted_at",
+			}),
+		}).CreateInBatches(settings, 100).Error
+	})
+}
@@ -3,10 +3,14 @@
 package repository
 
 import (
+	"context"
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ChatAgentMessageRepository ChatAgentMessage 数据访问层接口
@@ -14,13 +18,96 @@ import (
 // 继承自 BaseRepository，包含基本的增删改查功能
 type ChatAgentMessageRepository interface {
 	base.BaseRepository[models.ChatAgentMessage] // 继承基础仓库接口
+
+	// CreateWithEvent 在同一事务中创建消息和会话事件，保证事件与消息同时写入；event 为 nil 时只创建消息
+	// 消息带有token用量时同时累加到会话，会话的累计用量与消息保持一致
+	CreateWithEvent(ctx context.Context, message *models.ChatAgentMessage, event *models.ConversationEvent) error
+
+	// GetByConversationIDAndRequestID 根据会话ID和请求ID获取消息列表（按创建时间正序）
+	GetByConversationIDAndRequestID(ctx context.Context, conversationID uuid.UUID, requestID string) ([]*models.ChatAgentMessage, error)
+
+	// ExistsByConversationIDAndRequestID 判断会话中是否已存在指定请求ID的消息
+	ExistsByConversationIDAndRequestID(ctx context.Context, conversationID uuid.UUID, requestID string) (bool, error)
+
+	// ClaimRequestID 登记会话中客户端指定的请求ID，请求ID已被登记时返回 false
+	ClaimRequestID(ctx context.Context, request *models.ChatAgentMessageRequest) (bool, error)
+
+	// ListByConversation 按条件分页查询会话中的普通消息
+	ListByConversation(ctx context.Context, query *ChatAgentMessageListQuery) ([]*models.ChatAgentMessage, error)
+
+	// GetListCursor 获取会话中作为分页游标的消息，只包含ID和创建时间，不存在时返回 nil
+	GetListCursor(ctx context.Context, conversationID, messageID uuid.UUID) (*models.ChatAgentMessage, error)
+
+	// CountByConversation 按条件统计会话中的普通消息数量（忽略游标和数量限制）
+	CountByConversation(ctx context.Context, query *ChatAgentMessageListQuery) (int64, error)
+
+	// GetConversationMessageStats 统计会话的消息数量，token用量已累计在会话上
+	GetConversationMessageStats(ctx context.Context, conversationID uuid.UUID) (*ConversationMessageStats, error)
+
+	// GetLastMessageByConversationID 获取会话中最后一条普通消息
+	GetLastMessageByConversationID(ctx context.Context, conversationID uuid.UUID) (*models.ChatAgentMessage, error)
+
+	// GetAllByConversationID 获取会话中的全部消息（包含工具调用消息，按创建时间正序）
+	GetAllByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.ChatAgentMessage, error)
+
+	// UpdateSuggestions 只更新消息的追问建议，避免覆盖其他并发更新的字段
+	UpdateSuggestions(ctx context.Context, id uuid.UUID, suggestions string) error
+
+	// MarkReceipts 标记会话中助手回复的送达或已读时间，返回更新的消息数量
+	MarkReceipts(ctx context.Context, query *ChatAgentMessageReceiptQuery) (int64, error)
+
+	// CountUnread 统计会话中未读的助手回复数量
+	CountUnread(ctx context.Context, conversationID uuid.UUID) (int64, error)
+
+	// LoadAttachments 一次查询加载消息的附件，填充到各消息的 Attachments
+	LoadAttachments(ctx context.Context, messages []*models.ChatAgentMessage) error
+
+	// GetConversationSummaries 批量统计会话的最后一条普通消息、消息数量和未读回复数量，没有消息的会话不在结果中
+	GetConversationSummaries(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID]*ConversationMessageSummary, error)
+}
+
+// ChatAgentMessageReceiptQuery 助手回复送达和已读的标记条件
+// MessageIDs 和 CreatedUntil 至少指定一个，同时指定时需同时满足
+type ChatAgentMessageReceiptQuery struct {
+	ConversationID uuid.UUID   // 所属会话ID
+	MessageIDs     []uuid.UUID // 只标记指定的消息（可选）
+	CreatedUntil   *time.Time  // 只标记不晚于该时间创建的消息（可选）
+	Read           bool        // 是否标记为已读，否则标记为送达
+	At             time.Time   // 送达或已读时间
+}
+
+// ChatAgentMessageListQuery 会话消息列表查询条件
+type ChatAgentMessageListQuery struct {
+	ChatAgentID    uuid.UUID                // 所属智能体ID
+	ConversationID uuid.UUID                // 所属会话ID
+	Cursor         *models.ChatAgentMessage // 游标消息，返回排在其之后的消息（可选）
+	Ascending      bool                     // 是否按创建时间正序排列，默认倒序
+	CreatedBefore  *time.Time               // 只返回早于该时间创建的消息（可选）
+	CreatedAfter   *time.Time               // 只返回晚于该时间创建的消息（可选）
+	Limit          int                      // 返回数量，小于等于0时不限制
+}
+
+// ConversationMessageStats 会话消息统计结果
+type ConversationMessageStats struct {
+	MessageCount int64 `gorm:"column:message_count"` // 普通消息数量（不含工具调用消息）
+}
+
+// ConversationMessageSummary 会话列表展示需要的消息摘要
+type ConversationMessageSummary struct {
+	ConversationID     uuid.UUID `gorm:"column:conversation_id"`      // 会话ID
+	LastMessageContent string    `gorm:"column:last_message_content"` // 最后一条普通消息的内容
+	LastMessageRole    string    `gorm:"column:last_message_role"`    // 最后一条普通消息的角色
+	LastMessageAt      time.Time `gorm:"column:last_message_at"`      // 最后一条普通消息的创建时间
+	MessageCount       int64     `gorm:"column:message_count"`        // 普通消息数量（不含工具调用消息）
+	UnreadCount        int64     `gorm:"column:unread_count"`         // 未读的助手回复数量
 }
 
 // chatAgentMessageRepository ChatAgentMessage 数据访问层实现
 // 实现了 ChatAgentMessageRepository 接口的所有方法
 // 通过组合 baseRepository 来复用基础功能
 type chatAgentMessageRepository struct {
-	base.BaseRepository[models.ChatAgentMessage] // 组合基础仓库实现
+	base.BaseRepository[models.ChatAgentMessage]          // 组合基础仓库实现
+	db                                           *gorm.DB // 数据库连接
 }
 
 // NewChatAgentMessageRepository 创建 ChatAgentMessage Repository 实例
@@ -29,5 +116,317 @@ type chatAgentMessageRepository struct {
 func NewChatAgentMessageRepository(db *gorm.DB) ChatAgentMessageRepository {
 	return &chatAgentMessageRepository{
 		BaseRepository: base.NewBaseRepository[models.ChatAgentMessage](db),
+		db:             db,
+	}
+}
+
+// GetByConversationIDAndRequestID 根据会话ID和请求ID获取消息列表
+// 同一请求产生的用户消息、工具调用消息和助手回复按创建时间正序返回
+// 参数：ctx - 上下文，conversationID - 会话ID，requestID - 请求ID
+// 返回：消息列表和错误信息
+func (r *chatAgentMessageRepository) GetByConversationIDAndRequestID(ctx context.Context, conversationID uuid.UUID, requestID string) ([]*models.ChatAgentMessage, error) {
+	var messages []*models.ChatAgentMessage
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND request_id = ?", conversationID, requestID).
+		Order("created_at ASC").
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ExistsByConversationIDAndRequestID 判断会话中是否已存在指定请求ID的消息
+// 参数：ctx - 上下文，conversationID - 会话ID，requestID - 请求ID
+// 返回：是否存在和错误信息
+func (r *chatAgentMessageRepository) ExistsByConversationIDAndRequestID(ctx context.Context, conversationID uuid.UUID, requestID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ChatAgentMessage{}).
+		Where("conversation_id = ? AND request_id = ?", conversationID, requestID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ClaimRequestID 登记会话中客户端指定的请求ID
+// 依赖 (conversation_id, request_id) 唯一索引，并发登记同一请求ID时只有一个成功
+// 参数：ctx - 上下文，request - 要登记的请求ID
+// 返回：是否登记成功和错误信息
+func (r *chatAgentMessageRepository) ClaimRequestID(ctx context.Context, request *models.ChatAgentMessageRequest) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "conversation_id"}, {Name: "request_id"}}, DoNothing: true}).
+		Create(request)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListByConversation 按条件分页查询会话中的普通消息
+// 排序以创建时间为主、ID为辅，保证游标分页在创建时间相同时也不会遗漏或重复
+// 游标条件和排序与 (conversation_id, created_at, id) 联合索引一致，翻页不需要排序整个会话
+// 参数：ctx - 上下文，query - 查询条件
+// 返回：消息列表和错误信息
+func (r *chatAgentMessageRepository) ListByConversation(ctx context.Context, query *ChatAgentMessageListQuery) ([]*models.ChatAgentMessage, error) {
+	db := r.conversationMessageScope(ctx, query)
+
+	// 处理游标
+	if query.Cursor != nil {
+		if query.Ascending {
+			db = db.Where("(created_at > ? OR (created_at = ? AND id > ?))", query.Cursor.CreatedAt, query.Cursor.CreatedAt, query.Cursor.ID)
+		} else {
+			db = db.Where("(created_at < ? OR (created_at = ? AND id < ?))", query.Cursor.CreatedAt, query.Cursor.CreatedAt, query.Cursor.ID)
+		}
+	}
+
+	// 处理排序
+	if query.Ascending {
+		db = db.Order("created_at ASC").Order("id ASC")
+	} else {
+		db = db.Order("created_at DESC").Order("id DESC")
+	}
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var messages []*models.ChatAgentMessage
+	if err := db.Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// GetListCursor 获取会话中作为分页游标的消息
+// 只查询游标比较需要的ID和创建时间，避免读取消息内容等大字段
+// 参数：ctx - 上下文，conversationID - 会话ID，messageID - 游标消息ID
+// 返回：游标消息（不存在时为 nil）和错误信息
+func (r *chatAgentMessageRepository) GetListCursor(ctx context.Context, conversationID, messageID uuid.UUID) (*models.ChatAgentMessage, error) {
+	var messages []*models.ChatAgentMessage
+	err := r.db.WithContext(ctx).
+		Select("id", "created_at").
+		Where("conversation_id = ? AND id = ?", conversationID, messageID).
+		Limit(1).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
 	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	return messages[0], nil
+}
+
+// CountByConversation 按条件统计会话中的普通消息数量
+// 参数：ctx - 上下文，query - 查询条件（游标、排序和数量限制不参与统计）
+// 返回：消息数量和错误信息
+func (r *chatAgentMessageRepository) CountByConversation(ctx context.Context, query *ChatAgentMessageListQuery) (int64, error) {
+	var count int64
+	if err := r.conversationMessageScope(ctx, query).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// conversationMessageScope 构建会话普通消息的基础查询条件
+func (r *chatAgentMessageRepository) conversationMessageScope(ctx context.Context, query *ChatAgentMessageListQuery) *gorm.DB {
+	db := r.db.WithContext(ctx).Model(&models.ChatAgentMessage{}).
+		Where("chat_agent_id = ? AND conversation_id = ? AND type = ?", query.ChatAgentID, query.ConversationID, "message")
+	if query.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *query.CreatedBefore)
+	}
+	if query.CreatedAfter != nil {
+		db = db.Where("created_at > ?", *query.CreatedAfter)
+	}
+	return db
+}
+
+// GetConversationMessageStats 统计会话的消息数量
+// 只统计普通消息，token用量和费用从会话的累计值获取
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：统计结果和错误信息
+func (r *chatAgentMessageRepository) GetConversationMessageStats(ctx context.Context, conversationID uuid.UUID) (*ConversationMessageStats, error) {
+	var stats ConversationMessageStats
+	err := r.db.WithContext(ctx).Model(&models.ChatAgentMessage{}).
+		Select("COALESCE(SUM(CASE WHEN type = ? THEN 1 ELSE 0 END), 0) AS message_count", "message").
+		Where("conversation_id = ?", conversationID).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetLastMessageByConversationID 获取会话中最后一条普通消息
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：最后一条消息，会话没有消息时返回 nil
+func (r *chatAgentMessageRepository) GetLastMessageByConversationID(ctx context.Context, conversationID uuid.UUID) (*models.ChatAgentMessage, error) {
+	var messages []*models.ChatAgentMessage
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND type = ?", conversationID, "message").
+		Order("created_at DESC").
+		Limit(1).
+		Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	return messages[0], nil
+}
+
+// GetAllByConversationID 获取会话中的全部消息
+// 包含普通消息和工具调用消息，按创建时间正序返回，用于导出会话记录
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：消息列表和错误信息
+func (r *chatAgentMessageRepository) GetAllByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.ChatAgentMessage, error) {
+	var messages []*models.ChatAgentMessage
+	if err := r.db.WithContext(ctx).
+		Where("conversation_id = ?", conversationID).
+		Order("created_at ASC").Order("id ASC").
+		Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// UpdateSuggestions 只更新消息的追问建议
+// 参数：ctx - 上下文，id - 消息ID，suggestions - 追问建议（JSON数组）
+// 返回：错误信息
+func (r *chatAgentMessageRepository) UpdateSuggestions(ctx context.Context, id uuid.UUID, suggestions string) error {
+	return r.db.WithContext(ctx).Model(&models.ChatAgentMessage{}).
+		Where("id = ?", id).
+		Update("suggestions", suggestions).Error
+}
+
+// MarkReceipts 标记会话中助手回复的送达或已读时间
+// 已有时间的消息保持不变，多端重复上报不会改变最早的时间；标记已读时未送达的消息同时标记为送达
+// 参数：ctx - 上下文，query - 标记条件
+// 返回：更新的消息数量和错误信息
+func (r *chatAgentMessageRepository) MarkReceipts(ctx context.Context, query *ChatAgentMessageReceiptQuery) (int64, error) {
+	db := r.db.WithContext(ctx).Model(&models.ChatAgentMessage{}).
+		Where("conversation_id = ? AND type = ? AND role = ?", query.ConversationID, "message", "assistant")
+	if len(query.MessageIDs) > 0 {
+		db = db.Where("id IN ?", query.MessageIDs)
+	}
+	if query.CreatedUntil != nil {
+		db = db.Where("created_at <= ?", *query.CreatedUntil)
+	}
+
+	var result *gorm.DB
+	if query.Read {
+		result = db.Where("read_at IS NULL").Updates(map[string]interface{}{
+			"read_at":      query.At,
+			"delivered_at": gorm.Expr("COALESCE(delivered_at, ?)", query.At),
+		})
+	} else {
+		result = db.Where("delivered_at IS NULL").Update("delivered_at", query.At)
+	}
+	return result.RowsAffected, result.Error
+}
+
+// CountUnread 统计会话中未读的助手回复数量
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：未读数量和错误信息
+func (r *chatAgentMessageRepository) CountUnread(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.ChatAgentMessage{}).
+		Where("conversation_id = ? AND type = ? AND role = ? AND read_at IS NULL", conversationID, "message", "assistant").
+		Count(&count).Error
+	return count, err
+}
+
+// LoadAttachments 一次查询加载消息的附件，填充到各消息的 Attachments
+// 附件按上传时间正序排列，没有附件的消息 Attachments 为空
+// 参数：ctx - 上下文，messages - 消息列表
+// 返回：错误信息
+func (r *chatAgentMessageRepository) LoadAttachments(ctx context.Context, messages []*models.ChatAgentMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	messageMap := make(map[uuid.UUID]*models.ChatAgentMessage, len(messages))
+	messageIDs := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		message.Attachments = nil
+		messageMap[message.ID] = message
+		messageIDs = append(messageIDs, message.ID)
+	}
+
+	var attachments []*models.ChatAgentAttachment
+	if err := r.db.WithContext(ctx).
+		Where("message_id IN ?", messageIDs).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if message, ok := messageMap[attachment.MessageID]; ok {
+			message.Attachments = append(message.Attachments, attachment)
+		}
+	}
+	return nil
+}
+
+// GetConversationSummaries 批量统计会话的最后一条普通消息、消息数量和未读回复数量
+// 使用窗口函数在一次查询中按会话分区，取每个会话最新的一条普通消息并附带分区内的统计
+// 参数：ctx - 上下文，conversationIDs - 会话ID列表
+// 返回：以会话ID为键的消息摘要和错误信息
+func (r *chatAgentMessageRepository) GetConversationSummaries(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID]*ConversationMessageSummary, error) {
+	result := make(map[uuid.UUID]*ConversationMessageSummary, len(conversationIDs))
+	if len(conversationIDs) == 0 {
+		return result, nil
+	}
+
+	ranked := r.db.Model(&models.ChatAgentMessage{}).
+		Select("conversation_id, content, role, created_at, "+
+			"ROW_NUMBER() OVER (PARTITION BY conversation_id ORDER BY created_at DESC, id DESC) AS row_num, "+
+			"COUNT(*) OVER (PARTITION BY conversation_id) AS message_count, "+
+			"SUM(CASE WHEN role = ? AND read_at IS NULL THEN 1 ELSE 0 END) OVER (PARTITION BY conversation_id) AS unread_count", "assistant").
+		Where("conversation_id IN ? AND type = ?", conversationIDs, "message")
+
+	var summaries []*ConversationMessageSummary
+	err := r.db.WithContext(ctx).
+		Table("(?) AS m", ranked).
+		Select("conversation_id, content AS last_message_content, role AS last_message_role, created_at AS last_message_at, message_count, unread_count").
+		Where("row_num = 1").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		result[summary.ConversationID] = summary
+	}
+	return result, nil
+}
+
+// CreateWithEvent 在同一事务中创建消息和会话事件
+// 消息带有token用量或费用时累加到会话，不修改会话的更新时间
+// 参数：ctx - 上下文，message - 消息，event - 会话事件（为 nil 时只创建消息）
+// 返回：错误信息
+func (r *chatAgentMessageRepository) CreateWithEvent(ctx context.Context, message *models.ChatAgentMessage, event *models.ConversationEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		if message.TotalTokenCount > 0 || message.Cost > 0 {
+			err := tx.Model(&models.ChatAgentConversation{}).
+				Where("id = ?", message.ConversationID).
+				UpdateColumns(map[string]interface{}{
+					"prompt_token_count":     gorm.Expr("prompt_token_count + ?", message.PromptTokenCount),
+					"completion_token_count": gorm.Expr("completion_token_count + ?", message.CompletionTokenCount),
+					"total_token_count":      gorm.Expr("total_token_count + ?", message.TotalTokenCount),
+					"cost":                   gorm.Expr("cost + ?", message.Cost),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if event == nil {
+			return nil
+		}
+		return tx.Create(event).Error
+	})
 }
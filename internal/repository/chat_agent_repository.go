@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
 
@@ -19,6 +20,103 @@ type ChatAgentRepository interface {
 
 	// GetByApplicationIDWithPagination 根据应用ID获取智能体列表（分页）
 	GetByApplicationIDWithPagination(ctx context.Context, applicationID uuid.UUID, page, pageSize int) ([]*models.ChatAgent, int64, error)
+
+	// UpdateWithVersion 按版本号更新智能体（乐观锁）
+	// 仅当数据库中的版本号等于 expectedVersion 时更新，更新后版本号加1
+	UpdateWithVersion(ctx context.Context, agent *models.ChatAgent, expectedVersion int64) (bool, error)
+
+	// CountDependents 统计智能体的关联数据数量
+	CountDependents(ctx context.Context, id uuid.UUID) (*ChatAgentDependents, error)
+
+	// DeleteWithDependents 在同一事务中软删除智能体及其全部关联数据
+	// 智能体不存在时返回 gorm.ErrRecordNotFound，返回实际删除的关联数据数量
+	DeleteWithDependents(ctx context.Context, id uuid.UUID) (*ChatAgentDependents, error)
+}
+
+// ChatAgentDependents 智能体关联数据的数量
+type ChatAgentDependents struct {
+	Conversations        int64 // 会话数量
+	Messages             int64 // 消息数量
+	MessageRequests      int64 // 已登记的客户端请求ID数量
+	Attachments          int64 // 附件数量
+	McpServerTools       int64 // MCP工具设置数量
+	ApiKeys              int64 // API Key数量
+	AnswerRules          int64 // 回答规则数量
+	ToolCalls            int64 // 工具调用记录数量
+	Variables            int64 // 会话变量数量
+	ToolBundles          int64 // 工具集关联数量
+	WidgetTokens         int64 // 网页挂件令牌数量
+	ApiKeyRejections     int64 // API Key拒绝记录数量
+	ChatStreams          int64 // 流式回复数量
+	ChatStreamEvents     int64 // 流式回复事件数量
+	ConversationEvents   int64 // 会话事件数量
+	ConversationArchives int64 // 会话归档数量
+	BatchInferenceJobs   int64 // 批量推理任务数量
+	BatchInferenceItems  int64 // 批量推理条目数量
+	EvaluationSets       int64 // 评测集数量
+	EvaluationCases      int64 // 评测用例数量
+	EvaluationRuns       int64 // 评测运行数量
+	EvaluationResults    int64 // 评测结果数量
+}
+
+// chatAgentDependentTable 关联数据的模型和对应的计数字段
+// scope 用于筛选属于智能体的记录
+type chatAgentDependentTable struct {
+	model any
+	count *int64
+	scope func(db *gorm.DB, chatAgentID uuid.UUID) *gorm.DB
+}
+
+// byChatAgentID 按 chat_agent_id 筛选记录
+func byChatAgentID(db *gorm.DB, chatAgentID uuid.UUID) *gorm.DB {
+	return db.Where("chat_agent_id = ?", chatAgentID)
+}
+
+// byParentChatAgentID 按上级记录所属的智能体筛选没有 chat_agent_id 字段的记录
+// 下级记录先于上级记录删除，子查询不排除已删除的上级记录
+func byParentChatAgentID(column, parentTable string) func(db *gorm.DB, chatAgentID uuid.UUID) *gorm.DB {
+	return func(db *gorm.DB, chatAgentID uuid.UUID) *gorm.DB {
+		return db.Where(fmt.Sprintf("%s IN (SELECT id FROM %s WHERE chat_agent_id = ?)", column, parentTable), chatAgentID)
+	}
+}
+
+// dependentTables 全部关联数据表，先删除下级数据再删除上级数据
+// 新增带有 chat_agent_id 字段的表时需要加入此列表，否则删除智能体后会留下孤儿数据
+func (d *ChatAgentDependents) dependentTables() []chatAgentDependentTable {
+	return []chatAgentDependentTable{
+		{&models.ChatAgentConversation{}, &d.Conversations, byChatAgentID},
+		{&models.ChatAgentMessage{}, &d.Messages, byChatAgentID},
+		{&models.ChatAgentMessageRequest{}, &d.MessageRequests, byChatAgentID},
+		{&models.ChatAgentAttachment{}, &d.Attachments, byChatAgentID},
+		{&models.ChatAgentMcpServerTool{}, &d.McpServerTools, byChatAgentID},
+		{&models.ChatAgentApiKey{}, &d.ApiKeys, byChatAgentID},
+		{&models.ChatAgentAnswerRule{}, &d.AnswerRules, byChatAgentID},
+		{&models.ChatAgentToolCall{}, &d.ToolCalls, byChatAgentID},
+		{&models.ChatAgentConversationVariable{}, &d.Variables, byChatAgentID},
+		{&models.ChatAgentToolBundle{}, &d.ToolBundles, byChatAgentID},
+		{&models.ChatAgentWidgetToken{}, &d.WidgetTokens, byChatAgentID},
+		{&models.ChatAgentApiKeyRejection{}, &d.ApiKeyRejections, byChatAgentID},
+		{&models.ChatStreamEvent{}, &d.ChatStreamEvents, byParentChatAgentID("stream_id", "ltc_chat_stream")},
+		{&models.ChatStream{}, &d.ChatStreams, byChatAgentID},
+		{&models.ConversationEvent{}, &d.ConversationEvents, byChatAgentID},
+		{&models.ConversationArchive{}, &d.ConversationArchives, byChatAgentID},
+		{&models.BatchInferenceItem{}, &d.BatchInferenceItems, byParentChatAgentID("job_id", "ltc_batch_inference_job")},
+		{&models.BatchInferenceJob{}, &d.BatchInferenceJobs, byChatAgentID},
+		{&models.EvaluationResult{}, &d.EvaluationResults, byParentChatAgentID("run_id", "ltc_evaluation_run")},
+		{&models.EvaluationRun{}, &d.EvaluationRuns, byChatAgentID},
+		{&models.EvaluationCase{}, &d.EvaluationCases, byParentChatAgentID("set_id", "ltc_evaluation_set")},
+		{&models.EvaluationSet{}, &d.EvaluationSets, byChatAgentID},
+	}
+}
+
+// ChatAgentDependentModels 删除智能体时一并删除的全部关联数据模型，按删除顺序排列
+func ChatAgentDependentModels() []any {
+	tables := (&ChatAgentDependents{}).dependentTables()
+	dependentModels := make([]any, 0, len(tables))
+	for _, table := range tables {
+		dependentModels = append(dependentModels, table.model)
+	}
+	return dependentModels
 }
 
 // chatAgentRepository ChatAgent 数据访问层实现
@@ -62,3 +160,64 @@ func (r *chatAgentRepository) GetByApplicationIDWithPagination(ctx context.Conte
 
 	return agents, total, nil
 }
+
+// UpdateWithVersion 按版本号更新智能体（乐观锁）
+// 更新全部字段（创建时间除外），更新成功时 agent 的版本号同步为新版本号
+// 参数：ctx - 上下文，agent - 要更新的智能体，expectedVersion - 期望的当前版本号
+// 返回：是否更新成功（版本号不一致时为 false）和错误信息
+func (r *chatAgentRepository) UpdateWithVersion(ctx context.Context, agent *models.ChatAgent, expectedVersion int64) (bool, error) {
+	agent.Version = expectedVersion + 1
+	result := r.db.WithContext(ctx).Model(&models.ChatAgent{}).
+		Where("id = ? AND version = ?", agent.ID, expectedVersion).
+		Select("*").Omit("id", "created_at", "deleted_at").
+		Updates(agent)
+	if result.Error != nil || result.RowsAffected == 0 {
+		agent.Version = expectedVersion
+		return false, result.Error
+	}
+	return true, nil
+}
+
+// CountDependents 统计智能体的关联数据数量
+// 已软删除的关联数据不计入
+// 参数：ctx - 上下文，id - 智能体ID
+// 返回：关联数据数量和错误信息
+func (r *chatAgentRepository) CountDependents(ctx context.Context, id uuid.UUID) (*ChatAgentDependents, error) {
+	dependents := &ChatAgentDependents{}
+	for _, table := range dependents.dependentTables() {
+		if err := table.scope(r.db.WithContext(ctx).Model(table.model), id).Count(table.count).Error; err != nil {
+			return nil, err
+		}
+	}
+	return dependents, nil
+}
+
+// DeleteWithDependents 在同一事务中软删除智能体及其全部关联数据
+// 任一步骤失败时全部回滚，附件文件不在事务中删除，由后台任务清理
+// 参数：ctx - 上下文，id - 智能体ID
+// 返回：实际删除的关联数据数量和错误信息
+func (r *chatAgentRepository) DeleteWithDependents(ctx context.Context, id uuid.UUID) (*ChatAgentDependents, error) {
+	dependents := &ChatAgentDependents{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range dependents.dependentTables() {
+			result := table.scope(tx, id).Delete(table.model)
+			if result.Error != nil {
+				return result.Error
+			}
+			*table.count = result.RowsAffected
+		}
+
+		result := tx.Delete(&models.ChatAgent{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dependents, nil
+}
@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"context"
+	"lemon-tree-core/internal/core"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/testsupport"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// parseTable 解析模型的表结构
+func parseTable(t *testing.T, cache *sync.Map, model any) *schema.Schema {
+	t.Helper()
+	parsed, err := schema.Parse(model, cache, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("解析模型 %T 失败: %v", model, err)
+	}
+	return parsed
+}
+
+func TestChatAgentDependentModelsCoverMigratedTables(t *testing.T) {
+	cache := &sync.Map{}
+	chatAgentTable := parseTable(t, cache, &models.ChatAgent{}).Table
+
+	dependentTables := map[string]bool{}
+	for _, model := range repository.ChatAgentDependentModels() {
+		dependentTables[parseTable(t, cache, model).Table] = true
+	}
+
+	for _, model := range core.MigrationModels() {
+		parsed := parseTable(t, cache, model)
+		if parsed.Table == chatAgentTable || parsed.LookUpField("chat_agent_id") == nil {
+			continue
+		}
+		if !dependentTables[parsed.Table] {
+			t.Errorf("表 %s 有 chat_agent_id 字段，但删除智能体时不会删除", parsed.Table)
+		}
+	}
+}
+
+func TestChatAgentRepositoryDeleteWithDependents(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	repo := repository.NewChatAgentRepository(db)
+	agent := testsupport.CreateChatAgent(t, db)
+	other := testsupport.CreateChatAgent(t, db)
+	ctx := context.Background()
+
+	// 两个智能体各有一组会话、消息和带下级记录的数据，删除一个智能体不应影响另一个
+	for _, fixture := range []*testsupport.ChatAgentFixture{agent, other} {
+		conversation := testsupport.CreateConversation(t, db, fixture.ChatAgent, "user-a", "会话")
+		testsupport.CreateMessage(t, db, conversation, "request-1", "user", "你好")
+
+		stream := &models.ChatStream{ChatAgentID: fixture.ChatAgent.ID, RequestID: "request-1", InstanceID: "test"}
+		createRecord(t, db, stream)
+		createRecord(t, db, &models.ChatStreamEvent{StreamID: stream.ID, Seq: 1})
+
+		set := &models.EvaluationSet{ApplicationID: fixture.Application.ID, ChatAgentID: fixture.ChatAgent.ID, Name: "评测集"}
+		createRecord(t, db, set)
+		createRecord(t, db, &models.EvaluationCase{SetID: set.ID})
+	}
+
+	counted, err := repo.CountDependents(ctx, agent.ChatAgent.ID)
+	if err != nil {
+		t.Fatalf("CountDependents() error = %v", err)
+	}
+	deleted, err := repo.DeleteWithDependents(ctx, agent.ChatAgent.ID)
+	if err != nil {
+		t.Fatalf("DeleteWithDependents() error = %v", err)
+	}
+	if *counted != *deleted {
+		t.Errorf("CountDependents() = %+v, DeleteWithDependents() = %+v", *counted, *deleted)
+	}
+
+	want := repository.ChatAgentDependents{
+		Conversations:    1,
+		Messages:         1,
+		ApiKeys:          1,
+		ChatStreams:      1,
+		ChatStreamEvents: 1,
+		EvaluationSets:   1,
+		EvaluationCases:  1,
+	}
+	if *deleted != want {
+		t.Errorf("DeleteWithDependents() = %+v, want %+v", *deleted, want)
+	}
+
+	// 另一个智能体的数据保持不变
+	remaining, err := repo.CountDependents(ctx, other.ChatAgent.ID)
+	if err != nil {
+		t.Fatalf("CountDependents() error = %v", err)
+	}
+	if *remaining != want {
+		t.Errorf("other chat agent dependents = %+v, want %+v", *remaining, want)
+	}
+
+	if _, err := repo.DeleteWithDependents(ctx, agent.ChatAgent.ID); err != gorm.ErrRecordNotFound {
+		t.Errorf("second DeleteWithDependents() error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+}
+
+// createRecord 保存测试数据，失败时终止测试
+func createRecord(t *testing.T, db *gorm.DB, value any) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("创建测试数据失败: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatAgentToolCallRepository 工具调用记录 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ChatAgentToolCallRepository interface {
+	base.BaseRepository[models.ChatAgentToolCall] // 继承基础仓库接口
+
+	// GetUsageStats 按工具汇总智能体的调用统计，按调用次数倒序
+	// calledAfter、calledBefore 为空时不限制调用时间
+	GetUsageStats(ctx context.Context, chatAgentID uuid.UUID, calledAfter, calledBefore *time.Time) ([]*ChatAgentToolUsageStats, error)
+}
+
+// ChatAgentToolUsageStats 单个工具的调用统计
+type ChatAgentToolUsageStats struct {
+	ToolType      string  `gorm:"column:tool_type"`       // 工具类型
+	ToolName      string  `gorm:"column:tool_name"`       // 提供给模型的工具名称
+	CallCount     int64   `gorm:"column:call_count"`      // 调用次数
+	SuccessCount  int64   `gorm:"column:success_count"`   // 调用成功次数
+	AvgDurationMs float64 `gorm:"column:avg_duration_ms"` // 平均耗时（毫秒）
+}
+
+// chatAgentToolCallRepository 工具调用记录 数据访问层实现
+type chatAgentToolCallRepository struct {
+	base.BaseRepository[models.ChatAgentToolCall]          // 组合基础仓库实现
+	db                                            *gorm.DB // 数据库连接
+}
+
+// NewChatAgentToolCallRepository 创建 工具调用记录 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewChatAgentToolCallRepository(db *gorm.DB) ChatAgentToolCallRepository {
+	return &chatAgentToolCallRepository{
+		BaseRepository: base.NewBaseRepository[models.ChatAgentToolCall](db),
+		db:             db,
+	}
+}
+
+// GetUsageStats 按工具汇总智能体的调用统计
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，chatAgentID - 智能体ID，calledAfter - 只统计晚于该时间的调用（可选），calledBefore - 只统计早于该时间的调用（可选）
+// 返回：各工具的调用统计和错误信息
+func (r *chatAgentToolCallRepository) GetUsageStats(ctx context.Context, chatAgentID uuid.UUID, calledAfter, calledBefore *time.Time) ([]*ChatAgentToolUsageStats, error) {
+	db := r.db.WithContext(ctx).Scopes(base.UseReadReplica).Model(&models.ChatAgentToolCall{}).
+		Select("tool_type, tool_name, COUNT(*) AS call_count, "+
+			"SUM(CASE WHEN success = ? THEN 1 ELSE 0 END) AS success_count, "+
+			"AVG(duration_ms) AS avg_duration_ms", true).
+		Where("chat_agent_id = ?", chatAgentID)
+	if calledAfter != nil {
+		db = db.Where("created_at > ?", *calledAfter)
+	}
+	if calledBefore != nil {
+		db = db.Where("created_at < ?", *calledBefore)
+	}
+
+	var stats []*ChatAgentToolUsageStats
+	if err := db.Group("tool_type, tool_name").Order("call_count DESC").Order("tool_name ASC").Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
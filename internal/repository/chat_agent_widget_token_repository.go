@@ -0,0 +1,68 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ChatAgentWidgetTokenRepository 嵌入式聊天窗口令牌 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ChatAgentWidgetTokenRepository interface {
+	base.BaseRepository[models.ChatAgentWidgetToken] // 继承基础仓库接口
+
+	// GetByTokenHash 根据令牌摘要获取窗口令牌，不存在时返回 nil
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.ChatAgentWidgetToken, error)
+
+	// DeleteExpired 物理删除已过期的窗口令牌，返回删除的数量
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// chatAgentWidgetTokenRepository 嵌入式聊天窗口令牌 数据访问层实现
+type chatAgentWidgetTokenRepository struct {
+	base.BaseRepository[models.ChatAgentWidgetToken]          // 组合基础仓库实现
+	db                                               *gorm.DB // 数据库连接
+}
+
+// NewChatAgentWidgetTokenRepository 创建 嵌入式聊天窗口令牌 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewChatAgentWidgetTokenRepository(db *gorm.DB) ChatAgentWidgetTokenRepository {
+	return &chatAgentWidgetTokenRepository{
+		BaseRepository: base.NewBaseRepository[models.ChatAgentWidgetToken](db),
+		db:             db,
+	}
+}
+
+// GetByTokenHash 根据令牌摘要获取窗口令牌
+// 参数：ctx - 上下文，tokenHash - 令牌摘要
+// 返回：窗口令牌（不存在时为 nil）和错误信息
+func (r *chatAgentWidgetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.ChatAgentWidgetToken, error) {
+	if tokenHash == "" {
+		return nil, nil
+	}
+	var token models.ChatAgentWidgetToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteExpired 物理删除已过期的窗口令牌
+// 参数：ctx - 上下文
+// 返回：删除的数量和错误信息
+func (r *chatAgentWidgetTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("expired_at < ?", time.Now()).
+		Delete(&models.ChatAgentWidgetToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,124 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatStreamRepository 集群模式流式回复 数据访问层接口
+type ChatStreamRepository interface {
+	base.BaseRepository[models.ChatStream] // 继承基础仓库接口
+
+	// GetLatest 获取智能体下请求ID最新的流式回复，不存在时返回 nil
+	GetLatest(ctx context.Context, chatAgentID uuid.UUID, requestID string) (*models.ChatStream, error)
+
+	// AppendEvents 在同一事务中写入事件并更新流式回复的事件数量和结束状态
+	// 没有新事件时只更新流式回复的更新时间，用于表明生成回复的实例仍在运行
+	AppendEvents(ctx context.Context, stream *models.ChatStream, events []*models.ChatStreamEvent) error
+
+	// GetEvents 按编号顺序获取编号大于 afterSeq 的事件
+	GetEvents(ctx context.Context, streamID uuid.UUID, afterSeq, limit int) ([]*models.ChatStreamEvent, error)
+
+	// DeleteExpired 物理删除结束时间早于 finishedBefore 或更新时间早于 staleBefore 的流式回复及其事件，返回删除的流式回复数量
+	DeleteExpired(ctx context.Context, finishedBefore, staleBefore time.Time, limit int) (int64, error)
+}
+
+// chatStreamRepository 集群模式流式回复 数据访问层实现
+type chatStreamRepository struct {
+	base.BaseRepository[models.ChatStream]          // 组合基础仓库实现
+	db                                     *gorm.DB // 数据库连接
+}
+
+// NewChatStreamRepository 创建 集群模式流式回复 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewChatStreamRepository(db *gorm.DB) ChatStreamRepository {
+	return &chatStreamRepository{
+		BaseRepository: base.NewBaseRepository[models.ChatStream](db),
+		db:             db,
+	}
+}
+
+// GetLatest 获取智能体下请求ID最新的流式回复
+// 参数：ctx - 上下文，chatAgentID - 智能体ID，requestID - 请求ID
+// 返回：流式回复和错误信息，不存在时返回 nil, nil
+func (r *chatStreamRepository) GetLatest(ctx context.Context, chatAgentID uuid.UUID, requestID string) (*models.ChatStream, error) {
+	var stream models.ChatStream
+	err := r.db.WithContext(ctx).
+		Where("chat_agent_id = ? AND request_id = ?", chatAgentID, requestID).
+		Order("created_at DESC").
+		First(&stream).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stream, nil
+}
+
+// AppendEvents 在同一事务中写入事件并更新流式回复
+// 参数：ctx - 上下文，stream - 流式回复，事件数量和结束状态为写入后的值；events - 新事件
+// 返回：错误信息
+func (r *chatStreamRepository) AppendEvents(ctx context.Context, stream *models.ChatStream, events []*models.ChatStreamEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			if err := tx.CreateInBatches(events, 100).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.ChatStream{}).
+			Where("id = ?", stream.ID).
+			Updates(map[string]any{
+				"event_count": stream.EventCount,
+				"done":        stream.Done,
+				"finished_at": stream.FinishedAt,
+				"updated_at":  time.Now(),
+			}).Error
+	})
+}
+
+// GetEvents 按编号顺序获取编号大于 afterSeq 的事件
+// 参数：ctx - 上下文，streamID - 流式回复ID，afterSeq - 已读取的最大编号，limit - 返回数量
+// 返回：事件列表和错误信息
+func (r *chatStreamRepository) GetEvents(ctx context.Context, streamID uuid.UUID, afterSeq, limit int) ([]*models.ChatStreamEvent, error) {
+	var events []*models.ChatStreamEvent
+	err := r.db.WithContext(ctx).
+		Where("stream_id = ? AND seq > ?", streamID, afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// DeleteExpired 物理删除已过期的流式回复及其事件
+// 参数：ctx - 上下文，finishedBefore - 结束时间截止时间，staleBefore - 未结束的流式回复的更新时间截止时间，limit - 每次删除的数量
+// 返回：删除的流式回复数量和错误信息
+func (r *chatStreamRepository) DeleteExpired(ctx context.Context, finishedBefore, staleBefore time.Time, limit int) (int64, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.ChatStream{}).
+		Where("(done = ? AND finished_at < ?) OR (done = ? AND updated_at < ?)", true, finishedBefore, false, staleBefore).
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	var deleted int64
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("stream_id IN ?", ids).Delete(&models.ChatStreamEvent{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.ChatStream{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
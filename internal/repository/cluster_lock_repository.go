@@ -0,0 +1,86 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClusterLockRepository 集群分布式锁 数据访问层接口
+type ClusterLockRepository interface {
+	base.BaseRepository[models.ClusterLock] // 继承基础仓库接口
+
+	// TryAcquire 尝试获取锁，锁未被持有、租约已到期或已由 owner 持有时获取成功并设置租约到期时间
+	TryAcquire(ctx context.Context, name, owner string, expiresAt time.Time) (bool, error)
+
+	// Renew 续约 owner 持有的锁，锁已被其他实例接管时返回 false
+	Renew(ctx context.Context, name, owner string, expiresAt time.Time) (bool, error)
+
+	// Release 释放 owner 持有的锁，锁已被其他实例接管时不做处理
+	Release(ctx context.Context, name, owner string) error
+}
+
+// clusterLockRepository 集群分布式锁 数据访问层实现
+type clusterLockRepository struct {
+	base.BaseRepository[models.ClusterLock]          // 组合基础仓库实现
+	db                                      *gorm.DB // 数据库连接
+}
+
+// NewClusterLockRepository 创建 集群分布式锁 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewClusterLockRepository(db *gorm.DB) ClusterLockRepository {
+	return &clusterLockRepository{
+		BaseRepository: base.NewBaseRepository[models.ClusterLock](db),
+		db:             db,
+	}
+}
+
+// TryAcquire 尝试获取锁
+// 先接管已到期的锁，锁记录不存在时创建，并发创建时只有一个实例成功
+// 参数：ctx - 上下文，name - 锁名称，owner - 实例ID，expiresAt - 租约到期时间
+// 返回：是否获取成功和错误信息
+func (r *clusterLockRepository) TryAcquire(ctx context.Context, name, owner string, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ClusterLock{}).
+		Where("name = ? AND (owner = ? OR expires_at < ?)", name, owner, time.Now()).
+		Updates(map[string]any{"owner": owner, "expires_at": expiresAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	lock := &models.ClusterLock{Name: name, Owner: owner, ExpiresAt: expiresAt}
+	result = r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(lock)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Renew 续约 owner 持有的锁
+// 参数：ctx - 上下文，name - 锁名称，owner - 实例ID，expiresAt - 新的租约到期时间
+// 返回：是否仍持有锁和错误信息
+func (r *clusterLockRepository) Renew(ctx context.Context, name, owner string, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ClusterLock{}).
+		Where("name = ? AND owner = ?", name, owner).
+		Updates(map[string]any{"expires_at": expiresAt})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Release 释放 owner 持有的锁，将租约到期时间设为当前时间
+// 参数：ctx - 上下文，name - 锁名称，owner - 实例ID
+// 返回：错误信息
+func (r *clusterLockRepository) Release(ctx context.Context, name, owner string) error {
+	return r.db.WithContext(ctx).Model(&models.ClusterLock{}).
+		Where("name = ? AND owner = ?", name, owner).
+		Update("expires_at", time.Now()).Error
+}
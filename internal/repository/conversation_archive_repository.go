@@ -0,0 +1,188 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// conversationArchiveBatchSize 恢复会话时每批写入的消息数量
+const conversationArchiveBatchSize = 100
+
+// errConversationChanged 归档过程中会话被修改，回滚删除
+var errConversationChanged = errors.New("conversation changed during archiving")
+
+// ConversationArchiveRepository 会话归档记录 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ConversationArchiveRepository interface {
+	base.BaseRepository[models.ConversationArchive] // 继承基础仓库接口
+
+	// GetByConversationID 根据会话ID获取归档记录，不存在时返回 nil
+	GetByConversationID(ctx context.Context, conversationID uuid.UUID) (*models.ConversationArchive, error)
+
+	// GetByChatAgentIDWithPagination 分页获取智能体的归档记录，按最近一次归档时间倒序，serviceUserID、status 为空时不筛选
+	GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, serviceUserID, status string, page, pageSize int) ([]*models.ConversationArchive, int64, error)
+
+	// ArchiveConversation 在同一事务中物理删除会话及其消息和变量，并保存归档记录
+	// conversation 为导出时读取的会话，会话在导出后被修改或消息数量不再是 messageCount 时不删除并返回 false
+	ArchiveConversation(ctx context.Context, archive *models.ConversationArchive, conversation *models.ChatAgentConversation, messageCount int) (bool, error)
+
+	// RestoreConversation 在同一事务中写回会话及其消息和变量，并将归档记录更新为 status
+	// 归档记录已不是 fromStatus 时（如已被并发恢复）不写入并返回 false
+	RestoreConversation(ctx context.Context, archive *models.ConversationArchive, fromStatus, status string, conversation *models.ChatAgentConversation, messages []*models.ChatAgentMessage, variables []*models.ChatAgentConversationVariable) (bool, error)
+}
+
+// conversationArchiveRepository 会话归档记录 数据访问层实现
+type conversationArchiveRepository struct {
+	base.BaseRepository[models.ConversationArchive]          // 组合基础仓库实现
+	db                                              *gorm.DB // 数据库连接
+}
+
+// NewConversationArchiveRepository 创建 会话归档记录 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewConversationArchiveRepository(db *gorm.DB) ConversationArchiveRepository {
+	return &conversationArchiveRepository{
+		BaseRepository: base.NewBaseRepository[models.ConversationArchive](db),
+		db:             db,
+	}
+}
+
+// GetByConversationID 根据会话ID获取归档记录
+// 参数：ctx - 上下文，conversationID - 会话ID
+// 返回：归档记录（不存在时为 nil）和错误信息
+func (r *conversationArchiveRepository) GetByConversationID(ctx context.Context, conversationID uuid.UUID) (*models.ConversationArchive, error) {
+	var archive models.ConversationArchive
+	err := r.db.WithContext(ctx).Where("conversation_id = ?", conversationID).First(&archive).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+// GetByChatAgentIDWithPagination 分页获取智能体的归档记录
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，chatAgentID - 智能体ID，serviceUserID - 业务侧用户ID（可选），status - 状态（可选），page - 页码，pageSize - 每页数量
+// 返回：归档记录列表、总数量和错误信息
+func (r *conversationArchiveRepository) GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, serviceUserID, status string, page, pageSize int) ([]*models.ConversationArchive, int64, error) {
+	var archives []*models.ConversationArchive
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(base.UseReadReplica).Model(&models.ConversationArchive{}).Where("chat_agent_id = ?", chatAgentID)
+	if serviceUserID != "" {
+		query = query.Where("service_user_id = ?", serviceUserID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("archived_at DESC").Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&archives).Error; err != nil {
+		return nil, 0, err
+	}
+	return archives, total, nil
+}
+
+// ArchiveConversation 物理删除会话及其消息和变量，并保存归档记录
+// 以导出时读取的更新时间、最后消息时间和正在处理的请求作为条件删除会话，导出后会话有新的请求或修改时删除不到记录，整个事务回滚
+// 参数：ctx - 上下文，archive - 归档记录（ID 为空时新增），conversation - 导出时读取的会话，messageCount - 导出的消息数量
+// 返回：是否已归档和错误信息
+func (r *conversationArchiveRepository) ArchiveConversation(ctx context.Context, archive *models.ConversationArchive, conversation *models.ChatAgentConversation, messageCount int) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleteConversation := tx.Unscoped().
+			Where("id = ? AND updated_at = ? AND active_request_id = ?", conversation.ID, conversation.UpdatedAt, conversation.ActiveRequestID)
+		if conversation.LastMessageAt == nil {
+			deleteConversation = deleteConversation.Where("last_message_at IS NULL")
+		} else {
+			deleteConversation = deleteConversation.Where("last_message_at = ?", *conversation.LastMessageAt)
+		}
+		result := deleteConversation.Delete(&models.ChatAgentConversation{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errConversationChanged
+		}
+
+		var count int64
+		if err := tx.Model(&models.ChatAgentMessage{}).Where("conversation_id = ?", conversation.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(messageCount) {
+			return errConversationChanged
+		}
+
+		if err := tx.Unscoped().Where("conversation_id = ?", conversation.ID).Delete(&models.ChatAgentMessage{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("conversation_id = ?", conversation.ID).Delete(&models.ChatAgentConversationVariable{}).Error; err != nil {
+			return err
+		}
+		// 恢复后消息中的请求ID仍会被校验，请求ID登记记录无需归档
+		if err := tx.Unscoped().Where("conversation_id = ?", conversation.ID).Delete(&models.ChatAgentMessageRequest{}).Error; err != nil {
+			return err
+		}
+		if archive.ID == uuid.Nil {
+			return tx.Create(archive).Error
+		}
+		return tx.Save(archive).Error
+	})
+	if errors.Is(err, errConversationChanged) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RestoreConversation 写回会话及其消息和变量，并更新归档记录的状态
+// 参数：ctx - 上下文，archive - 归档记录，fromStatus - 归档记录当前应处的状态，status - 恢复后的状态，conversation - 会话，messages - 消息列表，variables - 会话变量列表
+// 返回：是否已恢复和错误信息
+func (r *conversationArchiveRepository) RestoreConversation(ctx context.Context, archive *models.ConversationArchive, fromStatus, status string, conversation *models.ChatAgentConversation, messages []*models.ChatAgentMessage, variables []*models.ChatAgentConversationVariable) (bool, error) {
+	restoredAt := time.Now()
+	var restored bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ConversationArchive{}).
+			Where("id = ? AND status = ?", archive.ID, fromStatus).
+			Updates(map[string]interface{}{"status": status, "restored_at": restoredAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(conversation).Error; err != nil {
+			return err
+		}
+		if len(messages) > 0 {
+			if err := tx.CreateInBatches(messages, conversationArchiveBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(variables) > 0 {
+			if err := tx.Create(variables).Error; err != nil {
+				return err
+			}
+		}
+		restored = true
+		return nil
+	})
+	if err != nil || !restored {
+		return false, err
+	}
+	archive.Status = status
+	archive.RestoredAt = &restoredAt
+	return true, nil
+}
@@ -0,0 +1,124 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConversationEventRepository 会话事件发件箱 数据访问层接口
+// 事件由会话和消息的数据访问层在同一事务中写入，这里只负责读取、标记和清理
+type ConversationEventRepository interface {
+	base.BaseRepository[models.ConversationEvent] // 继承基础仓库接口
+
+	// GetUndispatched 按写入顺序获取待处理的事件
+	GetUndispatched(ctx context.Context, limit int) ([]*models.ConversationEvent, error)
+
+	// MarkDispatched 在同一事务中标记事件已处理并创建推送记录
+	// 事件已被其他实例处理时不创建推送记录，返回 false
+	MarkDispatched(ctx context.Context, event *models.ConversationEvent, deliveries []*models.ApplicationWebhookDelivery) (bool, error)
+
+	// GetUnpublished 按写入顺序获取未发布到消息队列的事件
+	GetUnpublished(ctx context.Context, limit int) ([]*models.ConversationEvent, error)
+
+	// MarkPublished 标记事件已发布到消息队列
+	MarkPublished(ctx context.Context, ids []uuid.UUID, publishedAt time.Time) error
+
+	// DeleteBefore 物理删除指定时间之前写入的事件，返回删除的数量
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// conversationEventRepository 会话事件发件箱 数据访问层实现
+type conversationEventRepository struct {
+	base.BaseRepository[models.ConversationEvent]          // 组合基础仓库实现
+	db                                            *gorm.DB // 数据库连接
+}
+
+// NewConversationEventRepository 创建 会话事件发件箱 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewConversationEventRepository(db *gorm.DB) ConversationEventRepository {
+	return &conversationEventRepository{
+		BaseRepository: base.NewBaseRepository[models.ConversationEvent](db),
+		db:             db,
+	}
+}
+
+// GetUndispatched 按写入顺序获取待处理的事件
+// 参数：ctx - 上下文，limit - 返回数量
+// 返回：事件列表和错误信息
+func (r *conversationEventRepository) GetUndispatched(ctx context.Context, limit int) ([]*models.ConversationEvent, error) {
+	var events []*models.ConversationEvent
+	err := r.db.WithContext(ctx).
+		Where("dispatched_at IS NULL").
+		Order("created_at ASC").Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// MarkDispatched 在同一事务中标记事件已处理并创建推送记录
+// 参数：ctx - 上下文，event - 事件，deliveries - 推送记录
+// 返回：是否由本次调用完成标记和错误信息
+func (r *conversationEventRepository) MarkDispatched(ctx context.Context, event *models.ConversationEvent, deliveries []*models.ApplicationWebhookDelivery) (bool, error) {
+	dispatched := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ConversationEvent{}).
+			Where("id = ? AND dispatched_at IS NULL", event.ID).
+			UpdateColumn("dispatched_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if len(deliveries) > 0 {
+			if err := tx.Create(&deliveries).Error; err != nil {
+				return err
+			}
+		}
+		dispatched = true
+		return nil
+	})
+	return dispatched, err
+}
+
+// GetUnpublished 按写入顺序获取未发布到消息队列的事件
+// 参数：ctx - 上下文，limit - 返回数量
+// 返回：事件列表和错误信息
+func (r *conversationEventRepository) GetUnpublished(ctx context.Context, limit int) ([]*models.ConversationEvent, error) {
+	var events []*models.ConversationEvent
+	err := r.db.WithContext(ctx).
+		Where("published_at IS NULL").
+		Order("created_at ASC").Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// MarkPublished 标记事件已发布到消息队列
+// 参数：ctx - 上下文，ids - 事件ID列表，publishedAt - 发布时间
+// 返回：错误信息
+func (r *conversationEventRepository) MarkPublished(ctx context.Context, ids []uuid.UUID, publishedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.ConversationEvent{}).
+		Where("id IN ?", ids).
+		UpdateColumn("published_at", publishedAt).Error
+}
+
+// DeleteBefore 物理删除指定时间之前写入的事件
+// 参数：ctx - 上下文，before - 截止时间
+// 返回：删除的数量和错误信息
+func (r *conversationEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("created_at < ?", before).
+		Delete(&models.ConversationEvent{})
+	return result.RowsAffected, result.Error
+}
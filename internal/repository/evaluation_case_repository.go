@@ -0,0 +1,65 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EvaluationCaseRepository 评测用例 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type EvaluationCaseRepository interface {
+	base.BaseRepository[models.EvaluationCase] // 继承基础仓库接口
+
+	// GetBySetID 获取评测集的全部用例，按创建时间正序
+	GetBySetID(ctx context.Context, setID uuid.UUID) ([]*models.EvaluationCase, error)
+
+	// CountBySetID 统计评测集的用例数量
+	CountBySetID(ctx context.Context, setID uuid.UUID) (int64, error)
+
+	// DeleteBySetID 删除评测集的全部用例
+	DeleteBySetID(ctx context.Context, setID uuid.UUID) error
+}
+
+// evaluationCaseRepository 评测用例 数据访问层实现
+type evaluationCaseRepository struct {
+	base.BaseRepository[models.EvaluationCase]          // 组合基础仓库实现
+	db                                         *gorm.DB // 数据库连接
+}
+
+// NewEvaluationCaseRepository 创建 评测用例 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewEvaluationCaseRepository(db *gorm.DB) EvaluationCaseRepository {
+	return &evaluationCaseRepository{
+		BaseRepository: base.NewBaseRepository[models.EvaluationCase](db),
+		db:             db,
+	}
+}
+
+// GetBySetID 获取评测集的全部用例，按创建时间正序
+func (r *evaluationCaseRepository) GetBySetID(ctx context.Context, setID uuid.UUID) ([]*models.EvaluationCase, error) {
+	var cases []*models.EvaluationCase
+	if err := r.db.WithContext(ctx).Where("set_id = ?", setID).Order("created_at ASC").Find(&cases).Error; err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+// CountBySetID 统计评测集的用例数量
+func (r *evaluationCaseRepository) CountBySetID(ctx context.Context, setID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.EvaluationCase{}).Where("set_id = ?", setID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteBySetID 删除评测集的全部用例
+func (r *evaluationCaseRepository) DeleteBySetID(ctx context.Context, setID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("set_id = ?", setID).Delete(&models.EvaluationCase{}).Error
+}
@@ -0,0 +1,148 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// evaluationResultBatchSize 批量写入评测结果时每批的记录数
+const evaluationResultBatchSize = 200
+
+// EvaluationResultRepository 评测结果 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type EvaluationResultRepository interface {
+	base.BaseRepository[models.EvaluationResult] // 继承基础仓库接口
+
+	// BatchCreate 批量创建评测结果
+	BatchCreate(ctx context.Context, results []*models.EvaluationResult) error
+
+	// GetByRunIDWithPagination 获取运行的评测结果列表（分页），按用例序号排列
+	// status 不为空时只返回该状态的结果
+	GetByRunIDWithPagination(ctx context.Context, runID uuid.UUID, status string, page, pageSize int) ([]*models.EvaluationResult, int64, error)
+
+	// GetAllByRunID 获取运行的全部评测结果，按用例序号排列
+	GetAllByRunID(ctx context.Context, runID uuid.UUID) ([]*models.EvaluationResult, error)
+
+	// GetByRunIDAndStatus 按用例序号获取运行中处于指定状态的前 limit 条结果
+	GetByRunIDAndStatus(ctx context.Context, runID uuid.UUID, status string, limit int) ([]*models.EvaluationResult, error)
+
+	// UpdateStatusByRunID 将运行中处于 fromStatus 的结果全部更新为 status
+	UpdateStatusByRunID(ctx context.Context, runID uuid.UUID, fromStatus, status string) error
+
+	// UpdateStatusByIDs 更新指定结果的状态
+	UpdateStatusByIDs(ctx context.Context, ids []uuid.UUID, status string) error
+
+	// UpdateResult 更新用例的运行和评分结果
+	UpdateResult(ctx context.Context, result *models.EvaluationResult) error
+
+	// DeleteByRunIDs 删除多个运行的全部评测结果
+	DeleteByRunIDs(ctx context.Context, runIDs []uuid.UUID) error
+}
+
+// evaluationResultRepository 评测结果 数据访问层实现
+type evaluationResultRepository struct {
+	base.BaseRepository[models.EvaluationResult]          // 组合基础仓库实现
+	db                                           *gorm.DB // 数据库连接
+}
+
+// NewEvaluationResultRepository 创建 评测结果 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewEvaluationResultRepository(db *gorm.DB) EvaluationResultRepository {
+	return &evaluationResultRepository{
+		BaseRepository: base.NewBaseRepository[models.EvaluationResult](db),
+		db:             db,
+	}
+}
+
+// BatchCreate 批量创建评测结果
+func (r *evaluationResultRepository) BatchCreate(ctx context.Context, results []*models.EvaluationResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(results, evaluationResultBatchSize).Error
+}
+
+// GetByRunIDWithPagination 获取运行的评测结果列表（分页），按用例序号排列
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，runID - 评测运行ID，status - 结果状态（为空时不过滤），page - 页码（从1开始），pageSize - 每页大小
+// 返回：评测结果列表、总数量和错误信息
+func (r *evaluationResultRepository) GetByRunIDWithPagination(ctx context.Context, runID uuid.UUID, status string, page, pageSize int) ([]*models.EvaluationResult, int64, error) {
+	var results []*models.EvaluationResult
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(base.UseReadReplica).Model(&models.EvaluationResult{}).Where("run_id = ?", runID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("case_index ASC").Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// GetAllByRunID 获取运行的全部评测结果，按用例序号排列
+func (r *evaluationResultRepository) GetAllByRunID(ctx context.Context, runID uuid.UUID) ([]*models.EvaluationResult, error) {
+	var results []*models.EvaluationResult
+	if err := r.db.WithContext(ctx).Where("run_id = ?", runID).Order("case_index ASC").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetByRunIDAndStatus 按用例序号获取运行中处于指定状态的前 limit 条结果
+func (r *evaluationResultRepository) GetByRunIDAndStatus(ctx context.Context, runID uuid.UUID, status string, limit int) ([]*models.EvaluationResult, error) {
+	var results []*models.EvaluationResult
+	if err := r.db.WithContext(ctx).Where("run_id = ? AND status = ?", runID, status).
+		Order("case_index ASC").Limit(limit).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// UpdateStatusByRunID 将运行中处于 fromStatus 的结果全部更新为 status
+func (r *evaluationResultRepository) UpdateStatusByRunID(ctx context.Context, runID uuid.UUID, fromStatus, status string) error {
+	return r.db.WithContext(ctx).Model(&models.EvaluationResult{}).
+		Where("run_id = ? AND status = ?", runID, fromStatus).Update("status", status).Error
+}
+
+// UpdateStatusByIDs 更新指定结果的状态
+func (r *evaluationResultRepository) UpdateStatusByIDs(ctx context.Context, ids []uuid.UUID, status string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.EvaluationResult{}).Where("id IN ?", ids).Update("status", status).Error
+}
+
+// UpdateResult 更新用例的运行和评分结果
+func (r *evaluationResultRepository) UpdateResult(ctx context.Context, result *models.EvaluationResult) error {
+	return r.db.WithContext(ctx).Model(&models.EvaluationResult{}).Where("id = ?", result.ID).
+		Updates(map[string]interface{}{
+			"status":            result.Status,
+			"answer":            result.Answer,
+			"conversation_id":   result.ConversationID,
+			"score":             result.Score,
+			"assertion_results": result.AssertionResults,
+			"judge_reason":      result.JudgeReason,
+			"error_message":     result.ErrorMessage,
+			"duration_ms":       result.DurationMs,
+		}).Error
+}
+
+// DeleteByRunIDs 删除多个运行的全部评测结果
+func (r *evaluationResultRepository) DeleteByRunIDs(ctx context.Context, runIDs []uuid.UUID) error {
+	if len(runIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("run_id IN ?", runIDs).Delete(&models.EvaluationResult{}).Error
+}
@@ -0,0 +1,152 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EvaluationRunRepository 评测运行 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+// 运行状态和汇总结果由后台任务和取消接口同时修改，只通过条件更新修改相关字段，避免互相覆盖
+type EvaluationRunRepository interface {
+	base.BaseRepository[models.EvaluationRun] // 继承基础仓库接口
+
+	// GetBySetIDWithPagination 获取评测集的运行列表（分页），按创建时间倒序
+	GetBySetIDWithPagination(ctx context.Context, setID uuid.UUID, page, pageSize int) ([]*models.EvaluationRun, int64, error)
+
+	// GetEarliestByStatuses 获取处于指定状态中最早创建的运行，没有时返回空
+	GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.EvaluationRun, error)
+
+	// CountBySetIDAndStatuses 统计评测集中处于指定状态的运行数量
+	CountBySetIDAndStatuses(ctx context.Context, setID uuid.UUID, statuses []string) (int64, error)
+
+	// GetIDsBySetID 获取评测集全部运行的ID
+	GetIDsBySetID(ctx context.Context, setID uuid.UUID) ([]uuid.UUID, error)
+
+	// UpdateStatus 当运行处于 fromStatuses 之一时更新状态
+	// startedAt、finishedAt 为空时不更新对应字段；返回是否更新成功
+	UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error)
+
+	// UpdateFailed 将处于 fromStatuses 之一的运行标记为执行失败，并记录失败原因
+	UpdateFailed(ctx context.Context, id uuid.UUID, fromStatuses []string, status, errorMessage string, finishedAt time.Time) (bool, error)
+
+	// UpdateSummary 更新运行的通过数、未通过数和平均得分
+	UpdateSummary(ctx context.Context, id uuid.UUID, passedCount, failedCount int, averageScore float64) error
+
+	// DeleteBySetID 删除评测集的全部运行
+	DeleteBySetID(ctx context.Context, setID uuid.UUID) error
+}
+
+// evaluationRunRepository 评测运行 数据访问层实现
+type evaluationRunRepository struct {
+	base.BaseRepository[models.EvaluationRun]          // 组合基础仓库实现
+	db                                        *gorm.DB // 数据库连接
+}
+
+// NewEvaluationRunRepository 创建 评测运行 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewEvaluationRunRepository(db *gorm.DB) EvaluationRunRepository {
+	return &evaluationRunRepository{
+		BaseRepository: base.NewBaseRepository[models.EvaluationRun](db),
+		db:             db,
+	}
+}
+
+// GetBySetIDWithPagination 获取评测集的运行列表（分页），按创建时间倒序
+// 参数：ctx - 上下文，setID - 评测集ID，page - 页码（从1开始），pageSize - 每页大小
+// 返回：运行列表、总数量和错误信息
+func (r *evaluationRunRepository) GetBySetIDWithPagination(ctx context.Context, setID uuid.UUID, page, pageSize int) ([]*models.EvaluationRun, int64, error) {
+	var runs []*models.EvaluationRun
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.EvaluationRun{}).Where("set_id = ?", setID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// GetEarliestByStatuses 获取处于指定状态中最早创建的运行，没有时返回空
+func (r *evaluationRunRepository) GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.EvaluationRun, error) {
+	var run models.EvaluationRun
+	err := r.db.WithContext(ctx).Where("status IN ?", statuses).Order("created_at ASC").First(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// CountBySetIDAndStatuses 统计评测集中处于指定状态的运行数量
+func (r *evaluationRunRepository) CountBySetIDAndStatuses(ctx context.Context, setID uuid.UUID, statuses []string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.EvaluationRun{}).
+		Where("set_id = ? AND status IN ?", setID, statuses).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetIDsBySetID 获取评测集全部运行的ID
+func (r *evaluationRunRepository) GetIDsBySetID(ctx context.Context, setID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.EvaluationRun{}).Where("set_id = ?", setID).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateStatus 当运行处于 fromStatuses 之一时更新状态
+// startedAt、finishedAt 为空时不更新对应字段；返回是否更新成功
+func (r *evaluationRunRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if startedAt != nil {
+		updates["started_at"] = *startedAt
+	}
+	if finishedAt != nil {
+		updates["finished_at"] = *finishedAt
+	}
+	result := r.db.WithContext(ctx).Model(&models.EvaluationRun{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateFailed 将处于 fromStatuses 之一的运行标记为执行失败，并记录失败原因
+func (r *evaluationRunRepository) UpdateFailed(ctx context.Context, id uuid.UUID, fromStatuses []string, status, errorMessage string, finishedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.EvaluationRun{}).
+		Where("id = ? AND status IN ?", id, fromStatuses).
+		Updates(map[string]interface{}{"status": status, "error_message": errorMessage, "finished_at": finishedAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateSummary 更新运行的通过数、未通过数和平均得分
+func (r *evaluationRunRepository) UpdateSummary(ctx context.Context, id uuid.UUID, passedCount, failedCount int, averageScore float64) error {
+	return r.db.WithContext(ctx).Model(&models.EvaluationRun{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"passed_count": passedCount, "failed_count": failedCount, "average_score": averageScore}).Error
+}
+
+// DeleteBySetID 删除评测集的全部运行
+func (r *evaluationRunRepository) DeleteBySetID(ctx context.Context, setID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("set_id = ?", setID).Delete(&models.EvaluationRun{}).Error
+}
@@ -0,0 +1,45 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EvaluationSetRepository 评测集 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type EvaluationSetRepository interface {
+	base.BaseRepository[models.EvaluationSet] // 继承基础仓库接口
+
+	// GetByChatAgentID 获取智能体的全部评测集，按创建时间倒序
+	GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.EvaluationSet, error)
+}
+
+// evaluationSetRepository 评测集 数据访问层实现
+type evaluationSetRepository struct {
+	base.BaseRepository[models.EvaluationSet]          // 组合基础仓库实现
+	db                                        *gorm.DB // 数据库连接
+}
+
+// NewEvaluationSetRepository 创建 评测集 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewEvaluationSetRepository(db *gorm.DB) EvaluationSetRepository {
+	return &evaluationSetRepository{
+		BaseRepository: base.NewBaseRepository[models.EvaluationSet](db),
+		db:             db,
+	}
+}
+
+// GetByChatAgentID 获取智能体的全部评测集，按创建时间倒序
+func (r *evaluationSetRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.EvaluationSet, error) {
+	var sets []*models.EvaluationSet
+	if err := r.db.WithContext(ctx).Where("chat_agent_id = ?", chatAgentID).Order("created_at DESC").Find(&sets).Error; err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
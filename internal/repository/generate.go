@@ -0,0 +1,4 @@
+package repository
+
+// 为全部数据访问层接口生成 gomock 模拟实现，供单元测试使用，接口变更后重新执行 go generate ./...
+//go:generate go tool mockgen -destination=mock_repository/mock_repository.go -package=mock_repository lemon-tree-core/internal/repository ApplicationDeletionJobRepository,ApplicationFeatureFlagRepository,ApplicationInternalToolNetSearchConfigRepository,ApplicationLlmRepository,ApplicationMcpServerConfigRepository,ApplicationMcpServerOauthTokenRepository,ApplicationMcpServerToolRepository,ApplicationRepository,ApplicationSettingRepository,ApplicationStorageConfigRepository,ApplicationToolBundleRepository,ApplicationWebhookDeliveryRepository,ApplicationWebhookRepository,BatchInferenceItemRepository,BatchInferenceJobRepository,ChatAgentAnswerRuleRepository,ChatAgentApiKeyRejectionRepository,ChatAgentApiKeyRepository,ChatAgentAttachmentRepository,ChatAgentConversationRepository,ChatAgentConversationVariableRepository,ChatAgentMcpServerToolRepository,ChatAgentMessageRepository,ChatAgentRepository,ChatAgentToolCallRepository,ChatAgentWidgetTokenRepository,ChatConversationRepository,ChatStreamRepository,ClusterLockRepository,ConversationArchiveRepository,ConversationEventRepository,EvaluationCaseRepository,EvaluationResultRepository,EvaluationRunRepository,EvaluationSetRepository,KnowledgeBaseRepository,KnowledgeChunkRepository,KnowledgeDocumentRepository,LlmProviderDefineRepository,LlmProviderRepository,ServiceUserRepository,SystemUserActionTokenRepository,SystemUserIdentityRepository,SystemUserOidcLoginRepository,SystemUserRepository,SystemUserSessionRepository,WorkspaceUploadRepository
@@ -0,0 +1,61 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KnowledgeBaseRepository 知识库 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type KnowledgeBaseRepository interface {
+	base.BaseRepository[models.KnowledgeBase] // 继承基础仓库接口
+
+	// GetByApplicationID 获取应用下的全部知识库，按创建时间倒序
+	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.KnowledgeBase, error)
+
+	// GetByIDs 根据ID列表获取知识库
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.KnowledgeBase, error)
+}
+
+// knowledgeBaseRepository 知识库 数据访问层实现
+type knowledgeBaseRepository struct {
+	base.BaseRepository[models.KnowledgeBase]          // 组合基础仓库实现
+	db                                        *gorm.DB // 数据库连接
+}
+
+// NewKnowledgeBaseRepository 创建 知识库 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewKnowledgeBaseRepository(db *gorm.DB) KnowledgeBaseRepository {
+	return &knowledgeBaseRepository{
+		BaseRepository: base.NewBaseRepository[models.KnowledgeBase](db),
+		db:             db,
+	}
+}
+
+// GetByApplicationID 获取应用下的全部知识库，按创建时间倒序
+func (r *knowledgeBaseRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.KnowledgeBase, error) {
+	var knowledgeBases []*models.KnowledgeBase
+	if err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).
+		Order("created_at DESC").Find(&knowledgeBases).Error; err != nil {
+		return nil, err
+	}
+	return knowledgeBases, nil
+}
+
+// GetByIDs 根据ID列表获取知识库
+func (r *knowledgeBaseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.KnowledgeBase, error) {
+	var knowledgeBases []*models.KnowledgeBase
+	if len(ids) == 0 {
+		return knowledgeBases, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&knowledgeBases).Error; err != nil {
+		return nil, err
+	}
+	return knowledgeBases, nil
+}
@@ -0,0 +1,102 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// knowledgeChunkBatchSize 批量写入片段时每批的记录数
+const knowledgeChunkBatchSize = 100
+
+// KnowledgeChunkRepository 知识库文档片段 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+// 片段是可以从文档重新生成的派生数据，删除时直接物理删除，避免嵌入向量长期占用空间
+type KnowledgeChunkRepository interface {
+	base.BaseRepository[models.KnowledgeChunk] // 继承基础仓库接口
+
+	// BatchCreate 批量创建片段
+	BatchCreate(ctx context.Context, chunks []*models.KnowledgeChunk) error
+
+	// GetByKnowledgeBaseIDs 获取多个知识库下的全部片段（包含嵌入向量）
+	GetByKnowledgeBaseIDs(ctx context.Context, knowledgeBaseIDs []uuid.UUID) ([]*models.KnowledgeChunk, error)
+
+	// GetByDocumentID 获取文档的全部片段（包含嵌入向量），按序号排列
+	GetByDocumentID(ctx context.Context, documentID uuid.UUID) ([]*models.KnowledgeChunk, error)
+
+	// DeleteByIDs 根据ID列表删除片段
+	DeleteByIDs(ctx context.Context, ids []uuid.UUID) error
+
+	// DeleteByDocumentID 删除文档的全部片段
+	DeleteByDocumentID(ctx context.Context, documentID uuid.UUID) error
+
+	// DeleteByKnowledgeBaseID 删除知识库下的全部片段
+	DeleteByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) error
+}
+
+// knowledgeChunkRepository 知识库文档片段 数据访问层实现
+type knowledgeChunkRepository struct {
+	base.BaseRepository[models.KnowledgeChunk]          // 组合基础仓库实现
+	db                                         *gorm.DB // 数据库连接
+}
+
+// NewKnowledgeChunkRepository 创建 知识库文档片段 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewKnowledgeChunkRepository(db *gorm.DB) KnowledgeChunkRepository {
+	return &knowledgeChunkRepository{
+		BaseRepository: base.NewBaseRepository[models.KnowledgeChunk](db),
+		db:             db,
+	}
+}
+
+// BatchCreate 批量创建片段
+func (r *knowledgeChunkRepository) BatchCreate(ctx context.Context, chunks []*models.KnowledgeChunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(chunks, knowledgeChunkBatchSize).Error
+}
+
+// GetByKnowledgeBaseIDs 获取多个知识库下的全部片段（包含嵌入向量）
+func (r *knowledgeChunkRepository) GetByKnowledgeBaseIDs(ctx context.Context, knowledgeBaseIDs []uuid.UUID) ([]*models.KnowledgeChunk, error) {
+	var chunks []*models.KnowledgeChunk
+	if len(knowledgeBaseIDs) == 0 {
+		return chunks, nil
+	}
+	if err := r.db.WithContext(ctx).Where("knowledge_base_id IN ?", knowledgeBaseIDs).Find(&chunks).Error; err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// GetByDocumentID 获取文档的全部片段（包含嵌入向量），按序号排列
+func (r *knowledgeChunkRepository) GetByDocumentID(ctx context.Context, documentID uuid.UUID) ([]*models.KnowledgeChunk, error) {
+	var chunks []*models.KnowledgeChunk
+	if err := r.db.WithContext(ctx).Where("document_id = ?", documentID).Order("chunk_index ASC").Find(&chunks).Error; err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// DeleteByIDs 根据ID列表删除片段
+func (r *knowledgeChunkRepository) DeleteByIDs(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Delete(&models.KnowledgeChunk{}).Error
+}
+
+// DeleteByDocumentID 删除文档的全部片段
+func (r *knowledgeChunkRepository) DeleteByDocumentID(ctx context.Context, documentID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("document_id = ?", documentID).Delete(&models.KnowledgeChunk{}).Error
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的全部片段
+func (r *knowledgeChunkRepository) DeleteByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("knowledge_base_id = ?", knowledgeBaseID).Delete(&models.KnowledgeChunk{}).Error
+}
@@ -0,0 +1,127 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KnowledgeDocumentRepository 知识库文档 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type KnowledgeDocumentRepository interface {
+	base.BaseRepository[models.KnowledgeDocument] // 继承基础仓库接口
+
+	// GetByIDs 根据ID列表获取文档（不包含文档内容）
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.KnowledgeDocument, error)
+
+	// GetByKnowledgeBaseIDWithPagination 获取知识库下的文档列表（分页，不包含文档内容）
+	// status 不为空时只返回该处理状态的文档
+	GetByKnowledgeBaseIDWithPagination(ctx context.Context, knowledgeBaseID uuid.UUID, status string, page, pageSize int) ([]*models.KnowledgeDocument, int64, error)
+
+	// GetIDsByKnowledgeBaseID 获取知识库下全部文档的ID
+	GetIDsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) ([]uuid.UUID, error)
+
+	// UpdateStatus 更新文档的处理状态和错误信息
+	UpdateStatus(ctx context.Context, id uuid.UUID, status, errorMessage string) error
+
+	// UpdateProcessResult 更新文档处理成功后的状态和片段数量，并清空错误信息
+	UpdateProcessResult(ctx context.Context, id uuid.UUID, status string, chunkCount int) error
+
+	// CountByKnowledgeBaseID 统计知识库下的文档数量
+	CountByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) (int64, error)
+
+	// DeleteByKnowledgeBaseID 删除知识库下的全部文档
+	DeleteByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) error
+}
+
+// knowledgeDocumentRepository 知识库文档 数据访问层实现
+type knowledgeDocumentRepository struct {
+	base.BaseRepository[models.KnowledgeDocument]          // 组合基础仓库实现
+	db                                            *gorm.DB // 数据库连接
+}
+
+// NewKnowledgeDocumentRepository 创建 知识库文档 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewKnowledgeDocumentRepository(db *gorm.DB) KnowledgeDocumentRepository {
+	return &knowledgeDocumentRepository{
+		BaseRepository: base.NewBaseRepository[models.KnowledgeDocument](db),
+		db:             db,
+	}
+}
+
+// GetByIDs 根据ID列表获取文档（不包含文档内容）
+func (r *knowledgeDocumentRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.KnowledgeDocument, error) {
+	var documents []*models.KnowledgeDocument
+	if len(ids) == 0 {
+		return documents, nil
+	}
+	if err := r.db.WithContext(ctx).Omit("content").Where("id IN ?", ids).Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// GetByKnowledgeBaseIDWithPagination 获取知识库下的文档列表（分页，不包含文档内容）
+// 参数：ctx - 上下文，knowledgeBaseID - 知识库ID，status - 处理状态（为空时不过滤），page - 页码（从1开始），pageSize - 每页大小
+// 返回：文档列表、总数量和错误信息
+func (r *knowledgeDocumentRepository) GetByKnowledgeBaseIDWithPagination(ctx context.Context, knowledgeBaseID uuid.UUID, status string, page, pageSize int) ([]*models.KnowledgeDocument, int64, error) {
+	var documents []*models.KnowledgeDocument
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.KnowledgeDocument{}).Where("knowledge_base_id = ?", knowledgeBaseID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Omit("content").Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&documents).Error; err != nil {
+		return nil, 0, err
+	}
+	return documents, total, nil
+}
+
+// GetIDsByKnowledgeBaseID 获取知识库下全部文档的ID
+func (r *knowledgeDocumentRepository) GetIDsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.KnowledgeDocument{}).
+		Where("knowledge_base_id = ?", knowledgeBaseID).Order("created_at ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateStatus 更新文档的处理状态和错误信息
+// 只更新状态相关字段，避免覆盖同时保存的文档内容
+func (r *knowledgeDocumentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status, errorMessage string) error {
+	return r.db.WithContext(ctx).Model(&models.KnowledgeDocument{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "error_message": errorMessage}).Error
+}
+
+// UpdateProcessResult 更新文档处理成功后的状态和片段数量，并清空错误信息
+func (r *knowledgeDocumentRepository) UpdateProcessResult(ctx context.Context, id uuid.UUID, status string, chunkCount int) error {
+	return r.db.WithContext(ctx).Model(&models.KnowledgeDocument{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "error_message": "", "chunk_count": chunkCount}).Error
+}
+
+// CountByKnowledgeBaseID 统计知识库下的文档数量
+func (r *knowledgeDocumentRepository) CountByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.KnowledgeDocument{}).
+		Where("knowledge_base_id = ?", knowledgeBaseID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteByKnowledgeBaseID 删除知识库下的全部文档
+func (r *knowledgeDocumentRepository) DeleteByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("knowledge_base_id = ?", knowledgeBaseID).Delete(&models.KnowledgeDocument{}).Error
+}
@@ -20,6 +20,10 @@ type LlmProviderRepository interface {
 	// GetByApplicationID 根据应用ID获取大语言模型提供商列表
 	// 返回指定应用下的所有提供商
 	GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationLlmProvider, error)
+
+	// UpdateWithVersion 按版本号更新提供商（乐观锁）
+	// 仅当数据库中的版本号等于 expectedVersion 时更新，更新后版本号加1
+	UpdateWithVersion(ctx context.Context, llmProvider *models.ApplicationLlmProvider, expectedVersion int64) (bool, error)
 }
 
 // llmProviderRepository ApplicationLlmProvider 数据访问层实现
@@ -47,3 +51,20 @@ func (r *llmProviderRepository) GetByApplicationID(ctx context.Context, applicat
 	err := r.db.WithContext(ctx).Where("application_id = ?", applicationID).Find(&llmProviders).Error
 	return llmProviders, err
 }
+
+// UpdateWithVersion 按版本号更新提供商（乐观锁）
+// 更新全部字段（创建时间除外），更新成功时 llmProvider 的版本号同步为新版本号
+// 参数：ctx - 上下文，llmProvider - 要更新的提供商，expectedVersion - 期望的当前版本号
+// 返回：是否更新成功（版本号不一致时为 false）和错误信息
+func (r *llmProviderRepository) UpdateWithVersion(ctx context.Context, llmProvider *models.ApplicationLlmProvider, expectedVersion int64) (bool, error) {
+	llmProvider.Version = expectedVersion + 1
+	result := r.db.WithContext(ctx).Model(&models.ApplicationLlmProvider{}).
+		Where("id = ? AND version = ?", llmProvider.ID, expectedVersion).
+		Select("*").Omit("id", "created_at", "deleted_at").
+		Updates(llmProvider)
+	if result.Error != nil || result.RowsAffected == 0 {
+		llmProvider.Version = expectedVersion
+		return false, result.Error
+	}
+	return true, nil
+}
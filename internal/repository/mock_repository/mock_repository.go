@@ -0,0 +1,11133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lemon-tree-core/internal/repository (interfaces: ApplicationDeletionJobRepository,ApplicationFeatureFlagRepository,ApplicationInternalToolNetSearchConfigRepository,ApplicationLlmRepository,ApplicationMcpServerConfigRepository,ApplicationMcpServerOauthTokenRepository,ApplicationMcpServerToolRepository,ApplicationRepository,ApplicationSettingRepository,ApplicationStorageConfigRepository,ApplicationToolBundleRepository,ApplicationWebhookDeliveryRepository,ApplicationWebhookRepository,BatchInferenceItemRepository,BatchInferenceJobRepository,ChatAgentAnswerRuleRepository,ChatAgentApiKeyRejectionRepository,ChatAgentApiKeyRepository,ChatAgentAttachmentRepository,ChatAgentConversationRepository,ChatAgentConversationVariableRepository,ChatAgentMcpServerToolRepository,ChatAgentMessageRepository,ChatAgentRepository,ChatAgentToolCallRepository,ChatAgentWidgetTokenRepository,ChatConversationRepository,ChatStreamRepository,ClusterLockRepository,ConversationArchiveRepository,ConversationEventRepository,EvaluationCaseRepository,EvaluationResultRepository,EvaluationRunRepository,EvaluationSetRepository,KnowledgeBaseRepository,KnowledgeChunkRepository,KnowledgeDocumentRepository,LlmProviderDefineRepository,LlmProviderRepository,ServiceUserRepository,SystemUserActionTokenRepository,SystemUserIdentityRepository,SystemUserOidcLoginRepository,SystemUserRepository,SystemUserSessionRepository,WorkspaceUploadRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_repository/mock_repository.go -package=mock_repository lemon-tree-core/internal/repository ApplicationDeletionJobRepository,ApplicationFeatureFlagRepository,ApplicationInternalToolNetSearchConfigRepository,ApplicationLlmRepository,ApplicationMcpServerConfigRepository,ApplicationMcpServerOauthTokenRepository,ApplicationMcpServerToolRepository,ApplicationRepository,ApplicationSettingRepository,ApplicationStorageConfigRepository,ApplicationToolBundleRepository,ApplicationWebhookDeliveryRepository,ApplicationWebhookRepository,BatchInferenceItemRepository,BatchInferenceJobRepository,ChatAgentAnswerRuleRepository,ChatAgentApiKeyRejectionRepository,ChatAgentApiKeyRepository,ChatAgentAttachmentRepository,ChatAgentConversationRepository,ChatAgentConversationVariableRepository,ChatAgentMcpServerToolRepository,ChatAgentMessageRepository,ChatAgentRepository,ChatAgentToolCallRepository,ChatAgentWidgetTokenRepository,ChatConversationRepository,ChatStreamRepository,ClusterLockRepository,ConversationArchiveRepository,ConversationEventRepository,EvaluationCaseRepository,EvaluationResultRepository,EvaluationRunRepository,EvaluationSetRepository,KnowledgeBaseRepository,KnowledgeChunkRepository,KnowledgeDocumentRepository,LlmProviderDefineRepository,LlmProviderRepository,ServiceUserRepository,SystemUserActionTokenRepository,SystemUserIdentityRepository,SystemUserOidcLoginRepository,SystemUserRepository,SystemUserSessionRepository,WorkspaceUploadRepository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	base "lemon-tree-core/internal/base"
+	models "lemon-tree-core/internal/models"
+	repository "lemon-tree-core/internal/repository"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApplicationDeletionJobRepository is a mock of ApplicationDeletionJobRepository interface.
+type MockApplicationDeletionJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationDeletionJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationDeletionJobRepositoryMockRecorder is the mock recorder for MockApplicationDeletionJobRepository.
+type MockApplicationDeletionJobRepositoryMockRecorder struct {
+	mock *MockApplicationDeletionJobRepository
+}
+
+// NewMockApplicationDeletionJobRepository creates a new mock instance.
+func NewMockApplicationDeletionJobRepository(ctrl *gomock.Controller) *MockApplicationDeletionJobRepository {
+	mock := &MockApplicationDeletionJobRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationDeletionJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationDeletionJobRepository) EXPECT() *MockApplicationDeletionJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationDeletionJobRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationDeletionJobRepository) Create(ctx context.Context, entity *models.ApplicationDeletionJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationDeletionJobRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationDeletionJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).GetByID), ctx, id)
+}
+
+// GetEarliestByStatuses mocks base method.
+func (m *MockApplicationDeletionJobRepository) GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEarliestByStatuses", ctx, statuses)
+	ret0, _ := ret[0].(*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarliestByStatuses indicates an expected call of GetEarliestByStatuses.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) GetEarliestByStatuses(ctx, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarliestByStatuses", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).GetEarliestByStatuses), ctx, statuses)
+}
+
+// GetLatestByApplicationID mocks base method.
+func (m *MockApplicationDeletionJobRepository) GetLatestByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByApplicationID indicates an expected call of GetLatestByApplicationID.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) GetLatestByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByApplicationID", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).GetLatestByApplicationID), ctx, applicationID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationDeletionJobRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationDeletionJobRepository) ListAll(ctx context.Context) ([]*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationDeletionJobRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationDeletionJobRepository) Query(ctx context.Context, query *models.ApplicationDeletionJob) ([]*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationDeletionJobRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationDeletionJobRepository) Save(ctx context.Context, entity *models.ApplicationDeletionJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationDeletionJobRepository) Update(ctx context.Context, entity *models.ApplicationDeletionJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateErrorMessage mocks base method.
+func (m *MockApplicationDeletionJobRepository) UpdateErrorMessage(ctx context.Context, id uuid.UUID, errorMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateErrorMessage", ctx, id, errorMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateErrorMessage indicates an expected call of UpdateErrorMessage.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) UpdateErrorMessage(ctx, id, errorMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateErrorMessage", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).UpdateErrorMessage), ctx, id, errorMessage)
+}
+
+// UpdateProgress mocks base method.
+func (m *MockApplicationDeletionJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, completedSteps int, currentStep, deletedCounts string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", ctx, id, completedSteps, currentStep, deletedCounts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) UpdateProgress(ctx, id, completedSteps, currentStep, deletedCounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).UpdateProgress), ctx, id, completedSteps, currentStep, deletedCounts)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockApplicationDeletionJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, fromStatuses, status, startedAt, finishedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockApplicationDeletionJobRepositoryMockRecorder) UpdateStatus(ctx, id, fromStatuses, status, startedAt, finishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockApplicationDeletionJobRepository)(nil).UpdateStatus), ctx, id, fromStatuses, status, startedAt, finishedAt)
+}
+
+// MockApplicationFeatureFlagRepository is a mock of ApplicationFeatureFlagRepository interface.
+type MockApplicationFeatureFlagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationFeatureFlagRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationFeatureFlagRepositoryMockRecorder is the mock recorder for MockApplicationFeatureFlagRepository.
+type MockApplicationFeatureFlagRepositoryMockRecorder struct {
+	mock *MockApplicationFeatureFlagRepository
+}
+
+// NewMockApplicationFeatureFlagRepository creates a new mock instance.
+func NewMockApplicationFeatureFlagRepository(ctrl *gomock.Controller) *MockApplicationFeatureFlagRepository {
+	mock := &MockApplicationFeatureFlagRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationFeatureFlagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationFeatureFlagRepository) EXPECT() *MockApplicationFeatureFlagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationFeatureFlagRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationFeatureFlagRepository) Create(ctx context.Context, entity *models.ApplicationFeatureFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByApplicationAndFlag mocks base method.
+func (m *MockApplicationFeatureFlagRepository) DeleteByApplicationAndFlag(ctx context.Context, applicationID uuid.UUID, flag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByApplicationAndFlag", ctx, applicationID, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByApplicationAndFlag indicates an expected call of DeleteByApplicationAndFlag.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) DeleteByApplicationAndFlag(ctx, applicationID, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByApplicationAndFlag", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).DeleteByApplicationAndFlag), ctx, applicationID, flag)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationFeatureFlagRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationAndFlag mocks base method.
+func (m *MockApplicationFeatureFlagRepository) GetByApplicationAndFlag(ctx context.Context, applicationID uuid.UUID, flag string) (*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationAndFlag", ctx, applicationID, flag)
+	ret0, _ := ret[0].(*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationAndFlag indicates an expected call of GetByApplicationAndFlag.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) GetByApplicationAndFlag(ctx, applicationID, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationAndFlag", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).GetByApplicationAndFlag), ctx, applicationID, flag)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationFeatureFlagRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationFeatureFlagRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationFeatureFlagRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationFeatureFlagRepository) ListAll(ctx context.Context) ([]*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationFeatureFlagRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationFeatureFlagRepository) Query(ctx context.Context, query *models.ApplicationFeatureFlag) ([]*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationFeatureFlagRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationFeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationFeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationFeatureFlagRepository) Save(ctx context.Context, entity *models.ApplicationFeatureFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationFeatureFlagRepository) Update(ctx context.Context, entity *models.ApplicationFeatureFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationFeatureFlagRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationFeatureFlagRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationInternalToolNetSearchConfigRepository is a mock of ApplicationInternalToolNetSearchConfigRepository interface.
+type MockApplicationInternalToolNetSearchConfigRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder is the mock recorder for MockApplicationInternalToolNetSearchConfigRepository.
+type MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder struct {
+	mock *MockApplicationInternalToolNetSearchConfigRepository
+}
+
+// NewMockApplicationInternalToolNetSearchConfigRepository creates a new mock instance.
+func NewMockApplicationInternalToolNetSearchConfigRepository(ctrl *gomock.Controller) *MockApplicationInternalToolNetSearchConfigRepository {
+	mock := &MockApplicationInternalToolNetSearchConfigRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) EXPECT() *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) Create(ctx context.Context, entity *models.ApplicationInternalToolNetSearchConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationInternalToolNetSearchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationInternalToolNetSearchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) ListAll(ctx context.Context) ([]*models.ApplicationInternalToolNetSearchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationInternalToolNetSearchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationInternalToolNetSearchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationInternalToolNetSearchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) Query(ctx context.Context, query *models.ApplicationInternalToolNetSearchConfig) ([]*models.ApplicationInternalToolNetSearchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationInternalToolNetSearchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationInternalToolNetSearchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationInternalToolNetSearchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) Save(ctx context.Context, entity *models.ApplicationInternalToolNetSearchConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationInternalToolNetSearchConfigRepository) Update(ctx context.Context, entity *models.ApplicationInternalToolNetSearchConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationInternalToolNetSearchConfigRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationInternalToolNetSearchConfigRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationLlmRepository is a mock of ApplicationLlmRepository interface.
+type MockApplicationLlmRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLlmRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationLlmRepositoryMockRecorder is the mock recorder for MockApplicationLlmRepository.
+type MockApplicationLlmRepositoryMockRecorder struct {
+	mock *MockApplicationLlmRepository
+}
+
+// NewMockApplicationLlmRepository creates a new mock instance.
+func NewMockApplicationLlmRepository(ctrl *gomock.Controller) *MockApplicationLlmRepository {
+	mock := &MockApplicationLlmRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationLlmRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLlmRepository) EXPECT() *MockApplicationLlmRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationLlmRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationLlmRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationLlmRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationLlmRepository) Create(ctx context.Context, entity *models.ApplicationLlm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationLlmRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationLlmRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationLlmRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationLlmRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationLlmRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByProviderID mocks base method.
+func (m *MockApplicationLlmRepository) DeleteByProviderID(ctx context.Context, providerID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByProviderID", ctx, providerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByProviderID indicates an expected call of DeleteByProviderID.
+func (mr *MockApplicationLlmRepositoryMockRecorder) DeleteByProviderID(ctx, providerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByProviderID", reflect.TypeOf((*MockApplicationLlmRepository)(nil).DeleteByProviderID), ctx, providerID)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationLlmRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationLlmRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationLlmRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationLlmRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationLlmRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationLlmRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByProviderID mocks base method.
+func (m *MockApplicationLlmRepository) GetByProviderID(ctx context.Context, providerID uuid.UUID) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProviderID", ctx, providerID)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProviderID indicates an expected call of GetByProviderID.
+func (mr *MockApplicationLlmRepositoryMockRecorder) GetByProviderID(ctx, providerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProviderID", reflect.TypeOf((*MockApplicationLlmRepository)(nil).GetByProviderID), ctx, providerID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationLlmRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationLlmRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationLlmRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationLlmRepository) ListAll(ctx context.Context) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationLlmRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationLlmRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationLlmRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationLlmRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationLlmRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationLlmRepository) Query(ctx context.Context, query *models.ApplicationLlm) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationLlmRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationLlmRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationLlmRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationLlmRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationLlmRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationLlmRepository) Save(ctx context.Context, entity *models.ApplicationLlm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationLlmRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationLlmRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationLlmRepository) Update(ctx context.Context, entity *models.ApplicationLlm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationLlmRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationLlmRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationMcpServerConfigRepository is a mock of ApplicationMcpServerConfigRepository interface.
+type MockApplicationMcpServerConfigRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationMcpServerConfigRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationMcpServerConfigRepositoryMockRecorder is the mock recorder for MockApplicationMcpServerConfigRepository.
+type MockApplicationMcpServerConfigRepositoryMockRecorder struct {
+	mock *MockApplicationMcpServerConfigRepository
+}
+
+// NewMockApplicationMcpServerConfigRepository creates a new mock instance.
+func NewMockApplicationMcpServerConfigRepository(ctrl *gomock.Controller) *MockApplicationMcpServerConfigRepository {
+	mock := &MockApplicationMcpServerConfigRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationMcpServerConfigRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationMcpServerConfigRepository) EXPECT() *MockApplicationMcpServerConfigRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchCreate mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) BatchCreate(ctx context.Context, configs []*models.ApplicationMcpServerConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, configs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) BatchCreate(ctx, configs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).BatchCreate), ctx, configs)
+}
+
+// Create mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) Create(ctx context.Context, config *models.ApplicationMcpServerConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) Create(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).Create), ctx, config)
+}
+
+// Delete mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteWithDependents mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) DeleteWithDependents(ctx context.Context, id uuid.UUID) (*repository.McpServerConfigDependents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithDependents", ctx, id)
+	ret0, _ := ret[0].(*repository.McpServerConfigDependents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithDependents indicates an expected call of DeleteWithDependents.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) DeleteWithDependents(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithDependents", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).DeleteWithDependents), ctx, id)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationMcpServerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByConfigID mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) GetByConfigID(ctx context.Context, configID string) (*models.ApplicationMcpServerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConfigID", ctx, configID)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConfigID indicates an expected call of GetByConfigID.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) GetByConfigID(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConfigID", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).GetByConfigID), ctx, configID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationMcpServerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).GetByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockApplicationMcpServerConfigRepository) Update(ctx context.Context, config *models.ApplicationMcpServerConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationMcpServerConfigRepositoryMockRecorder) Update(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationMcpServerConfigRepository)(nil).Update), ctx, config)
+}
+
+// MockApplicationMcpServerOauthTokenRepository is a mock of ApplicationMcpServerOauthTokenRepository interface.
+type MockApplicationMcpServerOauthTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationMcpServerOauthTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationMcpServerOauthTokenRepositoryMockRecorder is the mock recorder for MockApplicationMcpServerOauthTokenRepository.
+type MockApplicationMcpServerOauthTokenRepositoryMockRecorder struct {
+	mock *MockApplicationMcpServerOauthTokenRepository
+}
+
+// NewMockApplicationMcpServerOauthTokenRepository creates a new mock instance.
+func NewMockApplicationMcpServerOauthTokenRepository(ctrl *gomock.Controller) *MockApplicationMcpServerOauthTokenRepository {
+	mock := &MockApplicationMcpServerOauthTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationMcpServerOauthTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationMcpServerOauthTokenRepository) EXPECT() *MockApplicationMcpServerOauthTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) Create(ctx context.Context, entity *models.ApplicationMcpServerOauthToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByConfigID mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) DeleteByConfigID(ctx context.Context, configID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByConfigID", ctx, configID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByConfigID indicates an expected call of DeleteByConfigID.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) DeleteByConfigID(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByConfigID", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).DeleteByConfigID), ctx, configID)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByConfigID mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) GetByConfigID(ctx context.Context, configID uuid.UUID) (*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConfigID", ctx, configID)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConfigID indicates an expected call of GetByConfigID.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) GetByConfigID(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConfigID", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).GetByConfigID), ctx, configID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByPendingState mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) GetByPendingState(ctx context.Context, state string) (*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPendingState", ctx, state)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPendingState indicates an expected call of GetByPendingState.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) GetByPendingState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPendingState", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).GetByPendingState), ctx, state)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) ListAll(ctx context.Context) ([]*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) Query(ctx context.Context, query *models.ApplicationMcpServerOauthToken) ([]*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationMcpServerOauthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerOauthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) Save(ctx context.Context, entity *models.ApplicationMcpServerOauthToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationMcpServerOauthTokenRepository) Update(ctx context.Context, entity *models.ApplicationMcpServerOauthToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationMcpServerOauthTokenRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationMcpServerOauthTokenRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationMcpServerToolRepository is a mock of ApplicationMcpServerToolRepository interface.
+type MockApplicationMcpServerToolRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationMcpServerToolRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationMcpServerToolRepositoryMockRecorder is the mock recorder for MockApplicationMcpServerToolRepository.
+type MockApplicationMcpServerToolRepositoryMockRecorder struct {
+	mock *MockApplicationMcpServerToolRepository
+}
+
+// NewMockApplicationMcpServerToolRepository creates a new mock instance.
+func NewMockApplicationMcpServerToolRepository(ctrl *gomock.Controller) *MockApplicationMcpServerToolRepository {
+	mock := &MockApplicationMcpServerToolRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationMcpServerToolRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationMcpServerToolRepository) EXPECT() *MockApplicationMcpServerToolRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchCreate mocks base method.
+func (m *MockApplicationMcpServerToolRepository) BatchCreate(ctx context.Context, tools []*models.ApplicationMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, tools)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) BatchCreate(ctx, tools any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).BatchCreate), ctx, tools)
+}
+
+// Create mocks base method.
+func (m *MockApplicationMcpServerToolRepository) Create(ctx context.Context, tool *models.ApplicationMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tool)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) Create(ctx, tool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).Create), ctx, tool)
+}
+
+// Delete mocks base method.
+func (m *MockApplicationMcpServerToolRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteByApplicationMcpServerConfigID mocks base method.
+func (m *MockApplicationMcpServerToolRepository) DeleteByApplicationMcpServerConfigID(ctx context.Context, configID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByApplicationMcpServerConfigID", ctx, configID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByApplicationMcpServerConfigID indicates an expected call of DeleteByApplicationMcpServerConfigID.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) DeleteByApplicationMcpServerConfigID(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByApplicationMcpServerConfigID", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).DeleteByApplicationMcpServerConfigID), ctx, configID)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationMcpServerToolRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByApplicationMcpServerConfigID mocks base method.
+func (m *MockApplicationMcpServerToolRepository) GetByApplicationMcpServerConfigID(ctx context.Context, configID uuid.UUID) ([]*models.ApplicationMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationMcpServerConfigID", ctx, configID)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationMcpServerConfigID indicates an expected call of GetByApplicationMcpServerConfigID.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) GetByApplicationMcpServerConfigID(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationMcpServerConfigID", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).GetByApplicationMcpServerConfigID), ctx, configID)
+}
+
+// GetByConfigIDAndName mocks base method.
+func (m *MockApplicationMcpServerToolRepository) GetByConfigIDAndName(ctx context.Context, configID uuid.UUID, name string) (*models.ApplicationMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConfigIDAndName", ctx, configID, name)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConfigIDAndName indicates an expected call of GetByConfigIDAndName.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) GetByConfigIDAndName(ctx, configID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConfigIDAndName", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).GetByConfigIDAndName), ctx, configID, name)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationMcpServerToolRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).GetByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockApplicationMcpServerToolRepository) Update(ctx context.Context, tool *models.ApplicationMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, tool)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationMcpServerToolRepositoryMockRecorder) Update(ctx, tool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationMcpServerToolRepository)(nil).Update), ctx, tool)
+}
+
+// MockApplicationRepository is a mock of ApplicationRepository interface.
+type MockApplicationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationRepositoryMockRecorder is the mock recorder for MockApplicationRepository.
+type MockApplicationRepositoryMockRecorder struct {
+	mock *MockApplicationRepository
+}
+
+// NewMockApplicationRepository creates a new mock instance.
+func NewMockApplicationRepository(ctrl *gomock.Controller) *MockApplicationRepository {
+	mock := &MockApplicationRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationRepository) EXPECT() *MockApplicationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationRepository) Create(ctx context.Context, entity *models.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteDependentsBatch mocks base method.
+func (m *MockApplicationRepository) DeleteDependentsBatch(ctx context.Context, applicationID uuid.UUID, step string, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDependentsBatch", ctx, applicationID, step, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDependentsBatch indicates an expected call of DeleteDependentsBatch.
+func (mr *MockApplicationRepositoryMockRecorder) DeleteDependentsBatch(ctx, applicationID, step, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDependentsBatch", reflect.TypeOf((*MockApplicationRepository)(nil).DeleteDependentsBatch), ctx, applicationID, step, limit)
+}
+
+// GetBackup mocks base method.
+func (m *MockApplicationRepository) GetBackup(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationBackup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackup", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBackup indicates an expected call of GetBackup.
+func (mr *MockApplicationRepositoryMockRecorder) GetBackup(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackup", reflect.TypeOf((*MockApplicationRepository)(nil).GetBackup), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationRepository) ListAll(ctx context.Context) ([]*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationRepository) Query(ctx context.Context, query *models.Application) ([]*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// RestoreBackup mocks base method.
+func (m *MockApplicationRepository) RestoreBackup(ctx context.Context, backup *models.ApplicationBackup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBackup", ctx, backup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreBackup indicates an expected call of RestoreBackup.
+func (mr *MockApplicationRepositoryMockRecorder) RestoreBackup(ctx, backup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBackup", reflect.TypeOf((*MockApplicationRepository)(nil).RestoreBackup), ctx, backup)
+}
+
+// Save mocks base method.
+func (m *MockApplicationRepository) Save(ctx context.Context, entity *models.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationRepository) Update(ctx context.Context, entity *models.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationSettingRepository is a mock of ApplicationSettingRepository interface.
+type MockApplicationSettingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationSettingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationSettingRepositoryMockRecorder is the mock recorder for MockApplicationSettingRepository.
+type MockApplicationSettingRepositoryMockRecorder struct {
+	mock *MockApplicationSettingRepository
+}
+
+// NewMockApplicationSettingRepository creates a new mock instance.
+func NewMockApplicationSettingRepository(ctrl *gomock.Controller) *MockApplicationSettingRepository {
+	mock := &MockApplicationSettingRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationSettingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationSettingRepository) EXPECT() *MockApplicationSettingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationSettingRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationSettingRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationSettingRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationSettingRepository) Create(ctx context.Context, entity *models.ApplicationSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationSettingRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationSettingRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByApplicationID mocks base method.
+func (m *MockApplicationSettingRepository) DeleteByApplicationID(ctx context.Context, applicationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByApplicationID indicates an expected call of DeleteByApplicationID.
+func (mr *MockApplicationSettingRepositoryMockRecorder) DeleteByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByApplicationID", reflect.TypeOf((*MockApplicationSettingRepository)(nil).DeleteByApplicationID), ctx, applicationID)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationSettingRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationSettingRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationSettingRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationSettingRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationSettingRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationSettingRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationSettingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationSettingRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationSettingRepository)(nil).GetByID), ctx, id)
+}
+
+// GetWithDataRetention mocks base method.
+func (m *MockApplicationSettingRepository) GetWithDataRetention(ctx context.Context) ([]*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithDataRetention", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithDataRetention indicates an expected call of GetWithDataRetention.
+func (mr *MockApplicationSettingRepositoryMockRecorder) GetWithDataRetention(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithDataRetention", reflect.TypeOf((*MockApplicationSettingRepository)(nil).GetWithDataRetention), ctx)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationSettingRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationSettingRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationSettingRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationSettingRepository) ListAll(ctx context.Context) ([]*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationSettingRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationSettingRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationSettingRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationSettingRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationSettingRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationSettingRepository) Query(ctx context.Context, query *models.ApplicationSetting) ([]*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationSettingRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationSettingRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationSettingRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationSettingRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationSettingRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationSettingRepository) Save(ctx context.Context, entity *models.ApplicationSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationSettingRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationSettingRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationSettingRepository) Update(ctx context.Context, entity *models.ApplicationSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationSettingRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationSettingRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationStorageConfigRepository is a mock of ApplicationStorageConfigRepository interface.
+type MockApplicationStorageConfigRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationStorageConfigRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationStorageConfigRepositoryMockRecorder is the mock recorder for MockApplicationStorageConfigRepository.
+type MockApplicationStorageConfigRepositoryMockRecorder struct {
+	mock *MockApplicationStorageConfigRepository
+}
+
+// NewMockApplicationStorageConfigRepository creates a new mock instance.
+func NewMockApplicationStorageConfigRepository(ctrl *gomock.Controller) *MockApplicationStorageConfigRepository {
+	mock := &MockApplicationStorageConfigRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationStorageConfigRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationStorageConfigRepository) EXPECT() *MockApplicationStorageConfigRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationStorageConfigRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationStorageConfigRepository) Create(ctx context.Context, entity *models.ApplicationStorageConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationStorageConfigRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationStorageConfigRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationStorageConfigRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationStorageConfigRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationStorageConfigRepository) ListAll(ctx context.Context) ([]*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationStorageConfigRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationStorageConfigRepository) Query(ctx context.Context, query *models.ApplicationStorageConfig) ([]*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationStorageConfigRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationStorageConfigRepository) Save(ctx context.Context, entity *models.ApplicationStorageConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationStorageConfigRepository) Update(ctx context.Context, entity *models.ApplicationStorageConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationStorageConfigRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationStorageConfigRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationToolBundleRepository is a mock of ApplicationToolBundleRepository interface.
+type MockApplicationToolBundleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationToolBundleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationToolBundleRepositoryMockRecorder is the mock recorder for MockApplicationToolBundleRepository.
+type MockApplicationToolBundleRepositoryMockRecorder struct {
+	mock *MockApplicationToolBundleRepository
+}
+
+// NewMockApplicationToolBundleRepository creates a new mock instance.
+func NewMockApplicationToolBundleRepository(ctrl *gomock.Controller) *MockApplicationToolBundleRepository {
+	mock := &MockApplicationToolBundleRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationToolBundleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationToolBundleRepository) EXPECT() *MockApplicationToolBundleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AttachChatAgents mocks base method.
+func (m *MockApplicationToolBundleRepository) AttachChatAgents(ctx context.Context, bundle *models.ApplicationToolBundle, chatAgentIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachChatAgents", ctx, bundle, chatAgentIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachChatAgents indicates an expected call of AttachChatAgents.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) AttachChatAgents(ctx, bundle, chatAgentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachChatAgents", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).AttachChatAgents), ctx, bundle, chatAgentIDs)
+}
+
+// Count mocks base method.
+func (m *MockApplicationToolBundleRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationToolBundleRepository) Create(ctx context.Context, entity *models.ApplicationToolBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationToolBundleRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteWithDependents mocks base method.
+func (m *MockApplicationToolBundleRepository) DeleteWithDependents(ctx context.Context, bundleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithDependents", ctx, bundleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWithDependents indicates an expected call of DeleteWithDependents.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) DeleteWithDependents(ctx, bundleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithDependents", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).DeleteWithDependents), ctx, bundleID)
+}
+
+// DetachChatAgents mocks base method.
+func (m *MockApplicationToolBundleRepository) DetachChatAgents(ctx context.Context, bundleID uuid.UUID, chatAgentIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachChatAgents", ctx, bundleID, chatAgentIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachChatAgents indicates an expected call of DetachChatAgents.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) DetachChatAgents(ctx, bundleID, chatAgentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachChatAgents", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).DetachChatAgents), ctx, bundleID, chatAgentIDs)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationToolBundleRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByApplicationIDAndName mocks base method.
+func (m *MockApplicationToolBundleRepository) GetByApplicationIDAndName(ctx context.Context, applicationID uuid.UUID, name string) (*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationIDAndName", ctx, applicationID, name)
+	ret0, _ := ret[0].(*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationIDAndName indicates an expected call of GetByApplicationIDAndName.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetByApplicationIDAndName(ctx, applicationID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationIDAndName", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetByApplicationIDAndName), ctx, applicationID, name)
+}
+
+// GetByChatAgentID mocks base method.
+func (m *MockApplicationToolBundleRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatAgentID indicates an expected call of GetByChatAgentID.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentID", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetByChatAgentID), ctx, chatAgentID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationToolBundleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetByID), ctx, id)
+}
+
+// GetChatAgentIDs mocks base method.
+func (m *MockApplicationToolBundleRepository) GetChatAgentIDs(ctx context.Context, bundleID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentIDs", ctx, bundleID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAgentIDs indicates an expected call of GetChatAgentIDs.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetChatAgentIDs(ctx, bundleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentIDs", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetChatAgentIDs), ctx, bundleID)
+}
+
+// GetToolIDs mocks base method.
+func (m *MockApplicationToolBundleRepository) GetToolIDs(ctx context.Context, bundleID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToolIDs", ctx, bundleID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToolIDs indicates an expected call of GetToolIDs.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetToolIDs(ctx, bundleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToolIDs", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetToolIDs), ctx, bundleID)
+}
+
+// GetToolIDsByChatAgentID mocks base method.
+func (m *MockApplicationToolBundleRepository) GetToolIDsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) (map[uuid.UUID][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToolIDsByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].(map[uuid.UUID][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToolIDsByChatAgentID indicates an expected call of GetToolIDsByChatAgentID.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) GetToolIDsByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToolIDsByChatAgentID", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).GetToolIDsByChatAgentID), ctx, chatAgentID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationToolBundleRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationToolBundleRepository) ListAll(ctx context.Context) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationToolBundleRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationToolBundleRepository) Query(ctx context.Context, query *models.ApplicationToolBundle) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationToolBundleRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationToolBundleRepository) Save(ctx context.Context, entity *models.ApplicationToolBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).Save), ctx, entity)
+}
+
+// SaveWithTools mocks base method.
+func (m *MockApplicationToolBundleRepository) SaveWithTools(ctx context.Context, bundle *models.ApplicationToolBundle, toolIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWithTools", ctx, bundle, toolIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWithTools indicates an expected call of SaveWithTools.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) SaveWithTools(ctx, bundle, toolIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWithTools", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).SaveWithTools), ctx, bundle, toolIDs)
+}
+
+// Update mocks base method.
+func (m *MockApplicationToolBundleRepository) Update(ctx context.Context, entity *models.ApplicationToolBundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationToolBundleRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationToolBundleRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationWebhookDeliveryRepository is a mock of ApplicationWebhookDeliveryRepository interface.
+type MockApplicationWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationWebhookDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockApplicationWebhookDeliveryRepository.
+type MockApplicationWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockApplicationWebhookDeliveryRepository
+}
+
+// NewMockApplicationWebhookDeliveryRepository creates a new mock instance.
+func NewMockApplicationWebhookDeliveryRepository(ctrl *gomock.Controller) *MockApplicationWebhookDeliveryRepository {
+	mock := &MockApplicationWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationWebhookDeliveryRepository) EXPECT() *MockApplicationWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) Claim(ctx context.Context, delivery *models.ApplicationWebhookDelivery, leaseUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, delivery, leaseUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) Claim(ctx, delivery, leaseUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).Claim), ctx, delivery, leaseUntil)
+}
+
+// Count mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) Create(ctx context.Context, entity *models.ApplicationWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time, statuses []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before, statuses)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) DeleteBefore(ctx, before, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).DeleteBefore), ctx, before, statuses)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByWebhookIDWithPagination mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) GetByWebhookIDWithPagination(ctx context.Context, webhookID uuid.UUID, status string, page, pageSize int) ([]*models.ApplicationWebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWebhookIDWithPagination", ctx, webhookID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByWebhookIDWithPagination indicates an expected call of GetByWebhookIDWithPagination.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) GetByWebhookIDWithPagination(ctx, webhookID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWebhookIDWithPagination", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).GetByWebhookIDWithPagination), ctx, webhookID, status, page, pageSize)
+}
+
+// GetDue mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) GetDue(ctx context.Context, status string, now time.Time, limit int) ([]*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, status, now, limit)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) GetDue(ctx, status, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).GetDue), ctx, status, now, limit)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) ListAll(ctx context.Context) ([]*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) Query(ctx context.Context, query *models.ApplicationWebhookDelivery) ([]*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) Save(ctx context.Context, entity *models.ApplicationWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationWebhookDeliveryRepository) Update(ctx context.Context, entity *models.ApplicationWebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationWebhookDeliveryRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationWebhookDeliveryRepository)(nil).Update), ctx, entity)
+}
+
+// MockApplicationWebhookRepository is a mock of ApplicationWebhookRepository interface.
+type MockApplicationWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationWebhookRepositoryMockRecorder is the mock recorder for MockApplicationWebhookRepository.
+type MockApplicationWebhookRepositoryMockRecorder struct {
+	mock *MockApplicationWebhookRepository
+}
+
+// NewMockApplicationWebhookRepository creates a new mock instance.
+func NewMockApplicationWebhookRepository(ctrl *gomock.Controller) *MockApplicationWebhookRepository {
+	mock := &MockApplicationWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockApplicationWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationWebhookRepository) EXPECT() *MockApplicationWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockApplicationWebhookRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockApplicationWebhookRepository) Create(ctx context.Context, entity *models.ApplicationWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockApplicationWebhookRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockApplicationWebhookRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockApplicationWebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDUnscoped mocks base method.
+func (m *MockApplicationWebhookRepository) GetByIDUnscoped(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDUnscoped", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDUnscoped indicates an expected call of GetByIDUnscoped.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) GetByIDUnscoped(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDUnscoped", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).GetByIDUnscoped), ctx, id)
+}
+
+// GetEnabledByApplicationID mocks base method.
+func (m *MockApplicationWebhookRepository) GetEnabledByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledByApplicationID indicates an expected call of GetEnabledByApplicationID.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) GetEnabledByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledByApplicationID", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).GetEnabledByApplicationID), ctx, applicationID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockApplicationWebhookRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockApplicationWebhookRepository) ListAll(ctx context.Context) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockApplicationWebhookRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockApplicationWebhookRepository) Query(ctx context.Context, query *models.ApplicationWebhook) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockApplicationWebhookRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockApplicationWebhookRepository) Save(ctx context.Context, entity *models.ApplicationWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockApplicationWebhookRepository) Update(ctx context.Context, entity *models.ApplicationWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApplicationWebhookRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApplicationWebhookRepository)(nil).Update), ctx, entity)
+}
+
+// MockBatchInferenceItemRepository is a mock of BatchInferenceItemRepository interface.
+type MockBatchInferenceItemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchInferenceItemRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchInferenceItemRepositoryMockRecorder is the mock recorder for MockBatchInferenceItemRepository.
+type MockBatchInferenceItemRepositoryMockRecorder struct {
+	mock *MockBatchInferenceItemRepository
+}
+
+// NewMockBatchInferenceItemRepository creates a new mock instance.
+func NewMockBatchInferenceItemRepository(ctrl *gomock.Controller) *MockBatchInferenceItemRepository {
+	mock := &MockBatchInferenceItemRepository{ctrl: ctrl}
+	mock.recorder = &MockBatchInferenceItemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchInferenceItemRepository) EXPECT() *MockBatchInferenceItemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchCreate mocks base method.
+func (m *MockBatchInferenceItemRepository) BatchCreate(ctx context.Context, items []*models.BatchInferenceItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) BatchCreate(ctx, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).BatchCreate), ctx, items)
+}
+
+// Count mocks base method.
+func (m *MockBatchInferenceItemRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).Count), ctx, conditions)
+}
+
+// CountByJobIDAndStatus mocks base method.
+func (m *MockBatchInferenceItemRepository) CountByJobIDAndStatus(ctx context.Context, jobID uuid.UUID) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByJobIDAndStatus", ctx, jobID)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByJobIDAndStatus indicates an expected call of CountByJobIDAndStatus.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) CountByJobIDAndStatus(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByJobIDAndStatus", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).CountByJobIDAndStatus), ctx, jobID)
+}
+
+// Create mocks base method.
+func (m *MockBatchInferenceItemRepository) Create(ctx context.Context, entity *models.BatchInferenceItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockBatchInferenceItemRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetAllByJobID mocks base method.
+func (m *MockBatchInferenceItemRepository) GetAllByJobID(ctx context.Context, jobID uuid.UUID) ([]*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByJobID", ctx, jobID)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByJobID indicates an expected call of GetAllByJobID.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) GetAllByJobID(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByJobID", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).GetAllByJobID), ctx, jobID)
+}
+
+// GetByID mocks base method.
+func (m *MockBatchInferenceItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByJobIDAndStatus mocks base method.
+func (m *MockBatchInferenceItemRepository) GetByJobIDAndStatus(ctx context.Context, jobID uuid.UUID, status string, limit int) ([]*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByJobIDAndStatus", ctx, jobID, status, limit)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByJobIDAndStatus indicates an expected call of GetByJobIDAndStatus.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) GetByJobIDAndStatus(ctx, jobID, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByJobIDAndStatus", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).GetByJobIDAndStatus), ctx, jobID, status, limit)
+}
+
+// GetByJobIDWithPagination mocks base method.
+func (m *MockBatchInferenceItemRepository) GetByJobIDWithPagination(ctx context.Context, jobID uuid.UUID, status string, page, pageSize int) ([]*models.BatchInferenceItem, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByJobIDWithPagination", ctx, jobID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByJobIDWithPagination indicates an expected call of GetByJobIDWithPagination.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) GetByJobIDWithPagination(ctx, jobID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByJobIDWithPagination", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).GetByJobIDWithPagination), ctx, jobID, status, page, pageSize)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockBatchInferenceItemRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockBatchInferenceItemRepository) ListAll(ctx context.Context) ([]*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockBatchInferenceItemRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockBatchInferenceItemRepository) Query(ctx context.Context, query *models.BatchInferenceItem) ([]*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockBatchInferenceItemRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.BatchInferenceItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockBatchInferenceItemRepository) Save(ctx context.Context, entity *models.BatchInferenceItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockBatchInferenceItemRepository) Update(ctx context.Context, entity *models.BatchInferenceItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateResult mocks base method.
+func (m *MockBatchInferenceItemRepository) UpdateResult(ctx context.Context, item *models.BatchInferenceItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResult", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateResult indicates an expected call of UpdateResult.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) UpdateResult(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResult", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).UpdateResult), ctx, item)
+}
+
+// UpdateStatusByIDs mocks base method.
+func (m *MockBatchInferenceItemRepository) UpdateStatusByIDs(ctx context.Context, ids []uuid.UUID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByIDs", ctx, ids, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByIDs indicates an expected call of UpdateStatusByIDs.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) UpdateStatusByIDs(ctx, ids, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByIDs", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).UpdateStatusByIDs), ctx, ids, status)
+}
+
+// UpdateStatusByJobID mocks base method.
+func (m *MockBatchInferenceItemRepository) UpdateStatusByJobID(ctx context.Context, jobID uuid.UUID, fromStatus, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByJobID", ctx, jobID, fromStatus, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByJobID indicates an expected call of UpdateStatusByJobID.
+func (mr *MockBatchInferenceItemRepositoryMockRecorder) UpdateStatusByJobID(ctx, jobID, fromStatus, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByJobID", reflect.TypeOf((*MockBatchInferenceItemRepository)(nil).UpdateStatusByJobID), ctx, jobID, fromStatus, status)
+}
+
+// MockBatchInferenceJobRepository is a mock of BatchInferenceJobRepository interface.
+type MockBatchInferenceJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchInferenceJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchInferenceJobRepositoryMockRecorder is the mock recorder for MockBatchInferenceJobRepository.
+type MockBatchInferenceJobRepositoryMockRecorder struct {
+	mock *MockBatchInferenceJobRepository
+}
+
+// NewMockBatchInferenceJobRepository creates a new mock instance.
+func NewMockBatchInferenceJobRepository(ctrl *gomock.Controller) *MockBatchInferenceJobRepository {
+	mock := &MockBatchInferenceJobRepository{ctrl: ctrl}
+	mock.recorder = &MockBatchInferenceJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchInferenceJobRepository) EXPECT() *MockBatchInferenceJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockBatchInferenceJobRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockBatchInferenceJobRepository) Create(ctx context.Context, entity *models.BatchInferenceJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockBatchInferenceJobRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByChatAgentIDWithPagination mocks base method.
+func (m *MockBatchInferenceJobRepository) GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.BatchInferenceJob, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentIDWithPagination", ctx, chatAgentID, page, pageSize)
+	ret0, _ := ret[0].([]*models.BatchInferenceJob)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByChatAgentIDWithPagination indicates an expected call of GetByChatAgentIDWithPagination.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) GetByChatAgentIDWithPagination(ctx, chatAgentID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentIDWithPagination", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).GetByChatAgentIDWithPagination), ctx, chatAgentID, page, pageSize)
+}
+
+// GetByID mocks base method.
+func (m *MockBatchInferenceJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).GetByID), ctx, id)
+}
+
+// GetEarliestByStatuses mocks base method.
+func (m *MockBatchInferenceJobRepository) GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEarliestByStatuses", ctx, statuses)
+	ret0, _ := ret[0].(*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarliestByStatuses indicates an expected call of GetEarliestByStatuses.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) GetEarliestByStatuses(ctx, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarliestByStatuses", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).GetEarliestByStatuses), ctx, statuses)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockBatchInferenceJobRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockBatchInferenceJobRepository) ListAll(ctx context.Context) ([]*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockBatchInferenceJobRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockBatchInferenceJobRepository) Query(ctx context.Context, query *models.BatchInferenceJob) ([]*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockBatchInferenceJobRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockBatchInferenceJobRepository) Save(ctx context.Context, entity *models.BatchInferenceJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockBatchInferenceJobRepository) Update(ctx context.Context, entity *models.BatchInferenceJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateFailed mocks base method.
+func (m *MockBatchInferenceJobRepository) UpdateFailed(ctx context.Context, id uuid.UUID, fromStatuses []string, status, errorMessage string, finishedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFailed", ctx, id, fromStatuses, status, errorMessage, finishedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFailed indicates an expected call of UpdateFailed.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) UpdateFailed(ctx, id, fromStatuses, status, errorMessage, finishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFailed", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).UpdateFailed), ctx, id, fromStatuses, status, errorMessage, finishedAt)
+}
+
+// UpdateProgress mocks base method.
+func (m *MockBatchInferenceJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, completedCount, failedCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", ctx, id, completedCount, failedCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) UpdateProgress(ctx, id, completedCount, failedCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).UpdateProgress), ctx, id, completedCount, failedCount)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockBatchInferenceJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, fromStatuses, status, startedAt, finishedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockBatchInferenceJobRepositoryMockRecorder) UpdateStatus(ctx, id, fromStatuses, status, startedAt, finishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockBatchInferenceJobRepository)(nil).UpdateStatus), ctx, id, fromStatuses, status, startedAt, finishedAt)
+}
+
+// MockChatAgentAnswerRuleRepository is a mock of ChatAgentAnswerRuleRepository interface.
+type MockChatAgentAnswerRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentAnswerRuleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentAnswerRuleRepositoryMockRecorder is the mock recorder for MockChatAgentAnswerRuleRepository.
+type MockChatAgentAnswerRuleRepositoryMockRecorder struct {
+	mock *MockChatAgentAnswerRuleRepository
+}
+
+// NewMockChatAgentAnswerRuleRepository creates a new mock instance.
+func NewMockChatAgentAnswerRuleRepository(ctrl *gomock.Controller) *MockChatAgentAnswerRuleRepository {
+	mock := &MockChatAgentAnswerRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentAnswerRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentAnswerRuleRepository) EXPECT() *MockChatAgentAnswerRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) Create(ctx context.Context, entity *models.ChatAgentAnswerRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByChatAgentID mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatAgentID indicates an expected call of GetByChatAgentID.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) GetByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentID", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).GetByChatAgentID), ctx, chatAgentID)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).GetByID), ctx, id)
+}
+
+// GetEnabledByChatAgentID mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) GetEnabledByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledByChatAgentID indicates an expected call of GetEnabledByChatAgentID.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) GetEnabledByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledByChatAgentID", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).GetEnabledByChatAgentID), ctx, chatAgentID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) ListAll(ctx context.Context) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) Query(ctx context.Context, query *models.ChatAgentAnswerRule) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) Save(ctx context.Context, entity *models.ChatAgentAnswerRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentAnswerRuleRepository) Update(ctx context.Context, entity *models.ChatAgentAnswerRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentAnswerRuleRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentAnswerRuleRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatAgentApiKeyRejectionRepository is a mock of ChatAgentApiKeyRejectionRepository interface.
+type MockChatAgentApiKeyRejectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentApiKeyRejectionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentApiKeyRejectionRepositoryMockRecorder is the mock recorder for MockChatAgentApiKeyRejectionRepository.
+type MockChatAgentApiKeyRejectionRepositoryMockRecorder struct {
+	mock *MockChatAgentApiKeyRejectionRepository
+}
+
+// NewMockChatAgentApiKeyRejectionRepository creates a new mock instance.
+func NewMockChatAgentApiKeyRejectionRepository(ctrl *gomock.Controller) *MockChatAgentApiKeyRejectionRepository {
+	mock := &MockChatAgentApiKeyRejectionRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentApiKeyRejectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentApiKeyRejectionRepository) EXPECT() *MockChatAgentApiKeyRejectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) Create(ctx context.Context, entity *models.ChatAgentApiKeyRejection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) DeleteBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).DeleteBefore), ctx, before)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByChatAgentIDWithPagination mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.ChatAgentApiKeyRejection, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentIDWithPagination", ctx, chatAgentID, page, pageSize)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByChatAgentIDWithPagination indicates an expected call of GetByChatAgentIDWithPagination.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) GetByChatAgentIDWithPagination(ctx, chatAgentID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentIDWithPagination", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).GetByChatAgentIDWithPagination), ctx, chatAgentID, page, pageSize)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentApiKeyRejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) ListAll(ctx context.Context) ([]*models.ChatAgentApiKeyRejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentApiKeyRejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) Query(ctx context.Context, query *models.ChatAgentApiKeyRejection) ([]*models.ChatAgentApiKeyRejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentApiKeyRejection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) Save(ctx context.Context, entity *models.ChatAgentApiKeyRejection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentApiKeyRejectionRepository) Update(ctx context.Context, entity *models.ChatAgentApiKeyRejection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentApiKeyRejectionRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentApiKeyRejectionRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatAgentApiKeyRepository is a mock of ChatAgentApiKeyRepository interface.
+type MockChatAgentApiKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentApiKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentApiKeyRepositoryMockRecorder is the mock recorder for MockChatAgentApiKeyRepository.
+type MockChatAgentApiKeyRepositoryMockRecorder struct {
+	mock *MockChatAgentApiKeyRepository
+}
+
+// NewMockChatAgentApiKeyRepository creates a new mock instance.
+func NewMockChatAgentApiKeyRepository(ctrl *gomock.Controller) *MockChatAgentApiKeyRepository {
+	mock := &MockChatAgentApiKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentApiKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentApiKeyRepository) EXPECT() *MockChatAgentApiKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentApiKeyRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentApiKeyRepository) Create(ctx context.Context, entity *models.ChatAgentApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentApiKeyRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApiKey mocks base method.
+func (m *MockChatAgentApiKeyRepository) GetByApiKey(ctx context.Context, apiKey string) (*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApiKey", ctx, apiKey)
+	ret0, _ := ret[0].(*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApiKey indicates an expected call of GetByApiKey.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) GetByApiKey(ctx, apiKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApiKey", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).GetByApiKey), ctx, apiKey)
+}
+
+// GetByChatAgentID mocks base method.
+func (m *MockChatAgentApiKeyRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatAgentID indicates an expected call of GetByChatAgentID.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) GetByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentID", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).GetByChatAgentID), ctx, chatAgentID)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentApiKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentApiKeyRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentApiKeyRepository) ListAll(ctx context.Context) ([]*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentApiKeyRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentApiKeyRepository) Query(ctx context.Context, query *models.ChatAgentApiKey) ([]*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentApiKeyRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentApiKeyRepository) Save(ctx context.Context, entity *models.ChatAgentApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentApiKeyRepository) Update(ctx context.Context, entity *models.ChatAgentApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentApiKeyRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentApiKeyRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatAgentAttachmentRepository is a mock of ChatAgentAttachmentRepository interface.
+type MockChatAgentAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentAttachmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentAttachmentRepositoryMockRecorder is the mock recorder for MockChatAgentAttachmentRepository.
+type MockChatAgentAttachmentRepositoryMockRecorder struct {
+	mock *MockChatAgentAttachmentRepository
+}
+
+// NewMockChatAgentAttachmentRepository creates a new mock instance.
+func NewMockChatAgentAttachmentRepository(ctrl *gomock.Controller) *MockChatAgentAttachmentRepository {
+	mock := &MockChatAgentAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentAttachmentRepository) EXPECT() *MockChatAgentAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClearFilePaths mocks base method.
+func (m *MockChatAgentAttachmentRepository) ClearFilePaths(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearFilePaths", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearFilePaths indicates an expected call of ClearFilePaths.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) ClearFilePaths(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFilePaths", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).ClearFilePaths), ctx, id)
+}
+
+// Count mocks base method.
+func (m *MockChatAgentAttachmentRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentAttachmentRepository) Create(ctx context.Context, entity *models.ChatAgentAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentAttachmentRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentAttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentAttachmentRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentAttachmentRepository) ListAll(ctx context.Context) ([]*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).ListAll), ctx)
+}
+
+// ListByMessageIDs mocks base method.
+func (m *MockChatAgentAttachmentRepository) ListByMessageIDs(ctx context.Context, chatAgentID uuid.UUID, messageIDs []uuid.UUID) ([]*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMessageIDs", ctx, chatAgentID, messageIDs)
+	ret0, _ := ret[0].([]*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMessageIDs indicates an expected call of ListByMessageIDs.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) ListByMessageIDs(ctx, chatAgentID, messageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMessageIDs", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).ListByMessageIDs), ctx, chatAgentID, messageIDs)
+}
+
+// ListDeletedWithFiles mocks base method.
+func (m *MockChatAgentAttachmentRepository) ListDeletedWithFiles(ctx context.Context, limit int) ([]*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedWithFiles", ctx, limit)
+	ret0, _ := ret[0].([]*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedWithFiles indicates an expected call of ListDeletedWithFiles.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) ListDeletedWithFiles(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedWithFiles", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).ListDeletedWithFiles), ctx, limit)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentAttachmentRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentAttachmentRepository) Query(ctx context.Context, query *models.ChatAgentAttachment) ([]*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentAttachmentRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentAttachmentRepository) Save(ctx context.Context, entity *models.ChatAgentAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentAttachmentRepository) Update(ctx context.Context, entity *models.ChatAgentAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentAttachmentRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentAttachmentRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatAgentConversationRepository is a mock of ChatAgentConversationRepository interface.
+type MockChatAgentConversationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentConversationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentConversationRepositoryMockRecorder is the mock recorder for MockChatAgentConversationRepository.
+type MockChatAgentConversationRepositoryMockRecorder struct {
+	mock *MockChatAgentConversationRepository
+}
+
+// NewMockChatAgentConversationRepository creates a new mock instance.
+func NewMockChatAgentConversationRepository(ctrl *gomock.Controller) *MockChatAgentConversationRepository {
+	mock := &MockChatAgentConversationRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentConversationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentConversationRepository) EXPECT() *MockChatAgentConversationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentConversationRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentConversationRepository) Create(ctx context.Context, entity *models.ChatAgentConversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).Create), ctx, entity)
+}
+
+// CreateWithEvent mocks base method.
+func (m *MockChatAgentConversationRepository) CreateWithEvent(ctx context.Context, conversation *models.ChatAgentConversation, event *models.ConversationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithEvent", ctx, conversation, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWithEvent indicates an expected call of CreateWithEvent.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) CreateWithEvent(ctx, conversation, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithEvent", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).CreateWithEvent), ctx, conversation, event)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentConversationRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteWithMessageRequests mocks base method.
+func (m *MockChatAgentConversationRepository) DeleteWithMessageRequests(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithMessageRequests", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWithMessageRequests indicates an expected call of DeleteWithMessageRequests.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) DeleteWithMessageRequests(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithMessageRequests", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).DeleteWithMessageRequests), ctx, id)
+}
+
+// FinishRequest mocks base method.
+func (m *MockChatAgentConversationRepository) FinishRequest(ctx context.Context, id uuid.UUID, requestID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRequest", ctx, id, requestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishRequest indicates an expected call of FinishRequest.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) FinishRequest(ctx, id, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRequest", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).FinishRequest), ctx, id, requestID)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentConversationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).GetByID), ctx, id)
+}
+
+// GetInactiveBefore mocks base method.
+func (m *MockChatAgentConversationRepository) GetInactiveBefore(ctx context.Context, applicationID uuid.UUID, before time.Time, limit int) ([]*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInactiveBefore", ctx, applicationID, before, limit)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInactiveBefore indicates an expected call of GetInactiveBefore.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) GetInactiveBefore(ctx, applicationID, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInactiveBefore", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).GetInactiveBefore), ctx, applicationID, before, limit)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentConversationRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// IncrementErrorCount mocks base method.
+func (m *MockChatAgentConversationRepository) IncrementErrorCount(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementErrorCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementErrorCount indicates an expected call of IncrementErrorCount.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) IncrementErrorCount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementErrorCount", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).IncrementErrorCount), ctx, id)
+}
+
+// IncrementPersistenceGapCount mocks base method.
+func (m *MockChatAgentConversationRepository) IncrementPersistenceGapCount(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementPersistenceGapCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementPersistenceGapCount indicates an expected call of IncrementPersistenceGapCount.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) IncrementPersistenceGapCount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementPersistenceGapCount", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).IncrementPersistenceGapCount), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentConversationRepository) ListAll(ctx context.Context) ([]*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).ListAll), ctx)
+}
+
+// ListByServiceUser mocks base method.
+func (m *MockChatAgentConversationRepository) ListByServiceUser(ctx context.Context, query *repository.ChatAgentConversationListQuery) ([]*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByServiceUser", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByServiceUser indicates an expected call of ListByServiceUser.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) ListByServiceUser(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByServiceUser", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).ListByServiceUser), ctx, query)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentConversationRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).ListPage), ctx, query)
+}
+
+// ListWithStats mocks base method.
+func (m *MockChatAgentConversationRepository) ListWithStats(ctx context.Context, query *repository.ChatAgentConversationStatsQuery) ([]*repository.ChatAgentConversationWithStats, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithStats", ctx, query)
+	ret0, _ := ret[0].([]*repository.ChatAgentConversationWithStats)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWithStats indicates an expected call of ListWithStats.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) ListWithStats(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithStats", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).ListWithStats), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentConversationRepository) Query(ctx context.Context, query *models.ChatAgentConversation) ([]*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentConversationRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentConversationRepository) Save(ctx context.Context, entity *models.ChatAgentConversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).Save), ctx, entity)
+}
+
+// TryStartRequest mocks base method.
+func (m *MockChatAgentConversationRepository) TryStartRequest(ctx context.Context, id uuid.UUID, requestID string, staleBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryStartRequest", ctx, id, requestID, staleBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryStartRequest indicates an expected call of TryStartRequest.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) TryStartRequest(ctx, id, requestID, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryStartRequest", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).TryStartRequest), ctx, id, requestID, staleBefore)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentConversationRepository) Update(ctx context.Context, entity *models.ChatAgentConversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateLastMessageAt mocks base method.
+func (m *MockChatAgentConversationRepository) UpdateLastMessageAt(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastMessageAt", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastMessageAt indicates an expected call of UpdateLastMessageAt.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) UpdateLastMessageAt(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastMessageAt", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).UpdateLastMessageAt), ctx, id, at)
+}
+
+// UpdateTitle mocks base method.
+func (m *MockChatAgentConversationRepository) UpdateTitle(ctx context.Context, id uuid.UUID, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTitle", ctx, id, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTitle indicates an expected call of UpdateTitle.
+func (mr *MockChatAgentConversationRepositoryMockRecorder) UpdateTitle(ctx, id, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTitle", reflect.TypeOf((*MockChatAgentConversationRepository)(nil).UpdateTitle), ctx, id, title)
+}
+
+// MockChatAgentConversationVariableRepository is a mock of ChatAgentConversationVariableRepository interface.
+type MockChatAgentConversationVariableRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentConversationVariableRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentConversationVariableRepositoryMockRecorder is the mock recorder for MockChatAgentConversationVariableRepository.
+type MockChatAgentConversationVariableRepositoryMockRecorder struct {
+	mock *MockChatAgentConversationVariableRepository
+}
+
+// NewMockChatAgentConversationVariableRepository creates a new mock instance.
+func NewMockChatAgentConversationVariableRepository(ctrl *gomock.Controller) *MockChatAgentConversationVariableRepository {
+	mock := &MockChatAgentConversationVariableRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentConversationVariableRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentConversationVariableRepository) EXPECT() *MockChatAgentConversationVariableRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentConversationVariableRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).Count), ctx, conditions)
+}
+
+// CountByConversationID mocks base method.
+func (m *MockChatAgentConversationVariableRepository) CountByConversationID(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByConversationID indicates an expected call of CountByConversationID.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) CountByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByConversationID", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).CountByConversationID), ctx, conversationID)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentConversationVariableRepository) Create(ctx context.Context, entity *models.ChatAgentConversationVariable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByConversationID mocks base method.
+func (m *MockChatAgentConversationVariableRepository) DeleteByConversationID(ctx context.Context, conversationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByConversationID indicates an expected call of DeleteByConversationID.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) DeleteByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByConversationID", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).DeleteByConversationID), ctx, conversationID)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentConversationVariableRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByConversationIDAndName mocks base method.
+func (m *MockChatAgentConversationVariableRepository) GetByConversationIDAndName(ctx context.Context, conversationID uuid.UUID, name string) (*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConversationIDAndName", ctx, conversationID, name)
+	ret0, _ := ret[0].(*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConversationIDAndName indicates an expected call of GetByConversationIDAndName.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) GetByConversationIDAndName(ctx, conversationID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConversationIDAndName", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).GetByConversationIDAndName), ctx, conversationID, name)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentConversationVariableRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentConversationVariableRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentConversationVariableRepository) ListAll(ctx context.Context) ([]*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).ListAll), ctx)
+}
+
+// ListByConversationID mocks base method.
+func (m *MockChatAgentConversationVariableRepository) ListByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].([]*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByConversationID indicates an expected call of ListByConversationID.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) ListByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByConversationID", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).ListByConversationID), ctx, conversationID)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentConversationVariableRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentConversationVariableRepository) Query(ctx context.Context, query *models.ChatAgentConversationVariable) ([]*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentConversationVariableRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentConversationVariableRepository) Save(ctx context.Context, entity *models.ChatAgentConversationVariable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentConversationVariableRepository) Update(ctx context.Context, entity *models.ChatAgentConversationVariable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentConversationVariableRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentConversationVariableRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatAgentMcpServerToolRepository is a mock of ChatAgentMcpServerToolRepository interface.
+type MockChatAgentMcpServerToolRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentMcpServerToolRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentMcpServerToolRepositoryMockRecorder is the mock recorder for MockChatAgentMcpServerToolRepository.
+type MockChatAgentMcpServerToolRepositoryMockRecorder struct {
+	mock *MockChatAgentMcpServerToolRepository
+}
+
+// NewMockChatAgentMcpServerToolRepository creates a new mock instance.
+func NewMockChatAgentMcpServerToolRepository(ctrl *gomock.Controller) *MockChatAgentMcpServerToolRepository {
+	mock := &MockChatAgentMcpServerToolRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentMcpServerToolRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentMcpServerToolRepository) EXPECT() *MockChatAgentMcpServerToolRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchCreate mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) BatchCreate(ctx context.Context, chatAgentMcpServerTools []*models.ChatAgentMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, chatAgentMcpServerTools)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) BatchCreate(ctx, chatAgentMcpServerTools any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).BatchCreate), ctx, chatAgentMcpServerTools)
+}
+
+// BatchUpdate mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) BatchUpdate(ctx context.Context, chatAgentMcpServerTools []*models.ChatAgentMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdate", ctx, chatAgentMcpServerTools)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchUpdate indicates an expected call of BatchUpdate.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) BatchUpdate(ctx, chatAgentMcpServerTools any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdate", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).BatchUpdate), ctx, chatAgentMcpServerTools)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) Create(ctx context.Context, chatAgentMcpServerTool *models.ChatAgentMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, chatAgentMcpServerTool)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) Create(ctx, chatAgentMcpServerTool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).Create), ctx, chatAgentMcpServerTool)
+}
+
+// DeleteByChatAgentID mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) DeleteByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByChatAgentID indicates an expected call of DeleteByChatAgentID.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) DeleteByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByChatAgentID", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).DeleteByChatAgentID), ctx, chatAgentID)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByChatAgentID mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ChatAgentMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatAgentID indicates an expected call of GetByChatAgentID.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) GetByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentID", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).GetByChatAgentID), ctx, chatAgentID)
+}
+
+// GetByChatAgentIDAndApplicationMcpServerToolID mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) GetByChatAgentIDAndApplicationMcpServerToolID(ctx context.Context, chatAgentID, applicationMcpServerToolID uuid.UUID) (*models.ChatAgentMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentIDAndApplicationMcpServerToolID", ctx, chatAgentID, applicationMcpServerToolID)
+	ret0, _ := ret[0].(*models.ChatAgentMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatAgentIDAndApplicationMcpServerToolID indicates an expected call of GetByChatAgentIDAndApplicationMcpServerToolID.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) GetByChatAgentIDAndApplicationMcpServerToolID(ctx, chatAgentID, applicationMcpServerToolID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentIDAndApplicationMcpServerToolID", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).GetByChatAgentIDAndApplicationMcpServerToolID), ctx, chatAgentID, applicationMcpServerToolID)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).GetByID), ctx, id)
+}
+
+// ReplaceByChatAgentID mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) ReplaceByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, settings []*models.ChatAgentMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByChatAgentID", ctx, chatAgentID, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByChatAgentID indicates an expected call of ReplaceByChatAgentID.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) ReplaceByChatAgentID(ctx, chatAgentID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByChatAgentID", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).ReplaceByChatAgentID), ctx, chatAgentID, settings)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentMcpServerToolRepository) Update(ctx context.Context, chatAgentMcpServerTool *models.ChatAgentMcpServerTool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, chatAgentMcpServerTool)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentMcpServerToolRepositoryMockRecorder) Update(ctx, chatAgentMcpServerTool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentMcpServerToolRepository)(nil).Update), ctx, chatAgentMcpServerTool)
+}
+
+// MockChatAgentMessageRepository is a mock of ChatAgentMessageRepository interface.
+type MockChatAgentMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentMessageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentMessageRepositoryMockRecorder is the mock recorder for MockChatAgentMessageRepository.
+type MockChatAgentMessageRepositoryMockRecorder struct {
+	mock *MockChatAgentMessageRepository
+}
+
+// NewMockChatAgentMessageRepository creates a new mock instance.
+func NewMockChatAgentMessageRepository(ctrl *gomock.Controller) *MockChatAgentMessageRepository {
+	mock := &MockChatAgentMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentMessageRepository) EXPECT() *MockChatAgentMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimRequestID mocks base method.
+func (m *MockChatAgentMessageRepository) ClaimRequestID(ctx context.Context, request *models.ChatAgentMessageRequest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRequestID", ctx, request)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimRequestID indicates an expected call of ClaimRequestID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) ClaimRequestID(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRequestID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).ClaimRequestID), ctx, request)
+}
+
+// Count mocks base method.
+func (m *MockChatAgentMessageRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).Count), ctx, conditions)
+}
+
+// CountByConversation mocks base method.
+func (m *MockChatAgentMessageRepository) CountByConversation(ctx context.Context, query *repository.ChatAgentMessageListQuery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByConversation", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByConversation indicates an expected call of CountByConversation.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) CountByConversation(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByConversation", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).CountByConversation), ctx, query)
+}
+
+// CountUnread mocks base method.
+func (m *MockChatAgentMessageRepository) CountUnread(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, conversationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) CountUnread(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).CountUnread), ctx, conversationID)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentMessageRepository) Create(ctx context.Context, entity *models.ChatAgentMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).Create), ctx, entity)
+}
+
+// CreateWithEvent mocks base method.
+func (m *MockChatAgentMessageRepository) CreateWithEvent(ctx context.Context, message *models.ChatAgentMessage, event *models.ConversationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithEvent", ctx, message, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWithEvent indicates an expected call of CreateWithEvent.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) CreateWithEvent(ctx, message, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithEvent", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).CreateWithEvent), ctx, message, event)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentMessageRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).DeleteByID), ctx, id)
+}
+
+// ExistsByConversationIDAndRequestID mocks base method.
+func (m *MockChatAgentMessageRepository) ExistsByConversationIDAndRequestID(ctx context.Context, conversationID uuid.UUID, requestID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByConversationIDAndRequestID", ctx, conversationID, requestID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByConversationIDAndRequestID indicates an expected call of ExistsByConversationIDAndRequestID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) ExistsByConversationIDAndRequestID(ctx, conversationID, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByConversationIDAndRequestID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).ExistsByConversationIDAndRequestID), ctx, conversationID, requestID)
+}
+
+// GetAllByConversationID mocks base method.
+func (m *MockChatAgentMessageRepository) GetAllByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByConversationID indicates an expected call of GetAllByConversationID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetAllByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByConversationID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetAllByConversationID), ctx, conversationID)
+}
+
+// GetByConversationIDAndRequestID mocks base method.
+func (m *MockChatAgentMessageRepository) GetByConversationIDAndRequestID(ctx context.Context, conversationID uuid.UUID, requestID string) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConversationIDAndRequestID", ctx, conversationID, requestID)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConversationIDAndRequestID indicates an expected call of GetByConversationIDAndRequestID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetByConversationIDAndRequestID(ctx, conversationID, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConversationIDAndRequestID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetByConversationIDAndRequestID), ctx, conversationID, requestID)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentMessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetByID), ctx, id)
+}
+
+// GetConversationMessageStats mocks base method.
+func (m *MockChatAgentMessageRepository) GetConversationMessageStats(ctx context.Context, conversationID uuid.UUID) (*repository.ConversationMessageStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationMessageStats", ctx, conversationID)
+	ret0, _ := ret[0].(*repository.ConversationMessageStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationMessageStats indicates an expected call of GetConversationMessageStats.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetConversationMessageStats(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationMessageStats", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetConversationMessageStats), ctx, conversationID)
+}
+
+// GetConversationSummaries mocks base method.
+func (m *MockChatAgentMessageRepository) GetConversationSummaries(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID]*repository.ConversationMessageSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationSummaries", ctx, conversationIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]*repository.ConversationMessageSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationSummaries indicates an expected call of GetConversationSummaries.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetConversationSummaries(ctx, conversationIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationSummaries", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetConversationSummaries), ctx, conversationIDs)
+}
+
+// GetLastMessageByConversationID mocks base method.
+func (m *MockChatAgentMessageRepository) GetLastMessageByConversationID(ctx context.Context, conversationID uuid.UUID) (*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastMessageByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastMessageByConversationID indicates an expected call of GetLastMessageByConversationID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetLastMessageByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastMessageByConversationID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetLastMessageByConversationID), ctx, conversationID)
+}
+
+// GetListCursor mocks base method.
+func (m *MockChatAgentMessageRepository) GetListCursor(ctx context.Context, conversationID, messageID uuid.UUID) (*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListCursor", ctx, conversationID, messageID)
+	ret0, _ := ret[0].(*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListCursor indicates an expected call of GetListCursor.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) GetListCursor(ctx, conversationID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListCursor", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).GetListCursor), ctx, conversationID, messageID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentMessageRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentMessageRepository) ListAll(ctx context.Context) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).ListAll), ctx)
+}
+
+// ListByConversation mocks base method.
+func (m *MockChatAgentMessageRepository) ListByConversation(ctx context.Context, query *repository.ChatAgentMessageListQuery) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByConversation", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByConversation indicates an expected call of ListByConversation.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) ListByConversation(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByConversation", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).ListByConversation), ctx, query)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentMessageRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).ListPage), ctx, query)
+}
+
+// LoadAttachments mocks base method.
+func (m *MockChatAgentMessageRepository) LoadAttachments(ctx context.Context, messages []*models.ChatAgentMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAttachments", ctx, messages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadAttachments indicates an expected call of LoadAttachments.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) LoadAttachments(ctx, messages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAttachments", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).LoadAttachments), ctx, messages)
+}
+
+// MarkReceipts mocks base method.
+func (m *MockChatAgentMessageRepository) MarkReceipts(ctx context.Context, query *repository.ChatAgentMessageReceiptQuery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReceipts", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReceipts indicates an expected call of MarkReceipts.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) MarkReceipts(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReceipts", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).MarkReceipts), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentMessageRepository) Query(ctx context.Context, query *models.ChatAgentMessage) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentMessageRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentMessageRepository) Save(ctx context.Context, entity *models.ChatAgentMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentMessageRepository) Update(ctx context.Context, entity *models.ChatAgentMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateSuggestions mocks base method.
+func (m *MockChatAgentMessageRepository) UpdateSuggestions(ctx context.Context, id uuid.UUID, suggestions string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSuggestions", ctx, id, suggestions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSuggestions indicates an expected call of UpdateSuggestions.
+func (mr *MockChatAgentMessageRepositoryMockRecorder) UpdateSuggestions(ctx, id, suggestions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSuggestions", reflect.TypeOf((*MockChatAgentMessageRepository)(nil).UpdateSuggestions), ctx, id, suggestions)
+}
+
+// MockChatAgentRepository is a mock of ChatAgentRepository interface.
+type MockChatAgentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentRepositoryMockRecorder is the mock recorder for MockChatAgentRepository.
+type MockChatAgentRepositoryMockRecorder struct {
+	mock *MockChatAgentRepository
+}
+
+// NewMockChatAgentRepository creates a new mock instance.
+func NewMockChatAgentRepository(ctrl *gomock.Controller) *MockChatAgentRepository {
+	mock := &MockChatAgentRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentRepository) EXPECT() *MockChatAgentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentRepository)(nil).Count), ctx, conditions)
+}
+
+// CountDependents mocks base method.
+func (m *MockChatAgentRepository) CountDependents(ctx context.Context, id uuid.UUID) (*repository.ChatAgentDependents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDependents", ctx, id)
+	ret0, _ := ret[0].(*repository.ChatAgentDependents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDependents indicates an expected call of CountDependents.
+func (mr *MockChatAgentRepositoryMockRecorder) CountDependents(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDependents", reflect.TypeOf((*MockChatAgentRepository)(nil).CountDependents), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentRepository) Create(ctx context.Context, entity *models.ChatAgent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteWithDependents mocks base method.
+func (m *MockChatAgentRepository) DeleteWithDependents(ctx context.Context, id uuid.UUID) (*repository.ChatAgentDependents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWithDependents", ctx, id)
+	ret0, _ := ret[0].(*repository.ChatAgentDependents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWithDependents indicates an expected call of DeleteWithDependents.
+func (mr *MockChatAgentRepositoryMockRecorder) DeleteWithDependents(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWithDependents", reflect.TypeOf((*MockChatAgentRepository)(nil).DeleteWithDependents), ctx, id)
+}
+
+// GetByApplicationIDWithPagination mocks base method.
+func (m *MockChatAgentRepository) GetByApplicationIDWithPagination(ctx context.Context, applicationID uuid.UUID, page, pageSize int) ([]*models.ChatAgent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationIDWithPagination", ctx, applicationID, page, pageSize)
+	ret0, _ := ret[0].([]*models.ChatAgent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByApplicationIDWithPagination indicates an expected call of GetByApplicationIDWithPagination.
+func (mr *MockChatAgentRepositoryMockRecorder) GetByApplicationIDWithPagination(ctx, applicationID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationIDWithPagination", reflect.TypeOf((*MockChatAgentRepository)(nil).GetByApplicationIDWithPagination), ctx, applicationID, page, pageSize)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentRepository) ListAll(ctx context.Context) ([]*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentRepository) Query(ctx context.Context, query *models.ChatAgent) ([]*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentRepository) Save(ctx context.Context, entity *models.ChatAgent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentRepository) Update(ctx context.Context, entity *models.ChatAgent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateWithVersion mocks base method.
+func (m *MockChatAgentRepository) UpdateWithVersion(ctx context.Context, agent *models.ChatAgent, expectedVersion int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithVersion", ctx, agent, expectedVersion)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWithVersion indicates an expected call of UpdateWithVersion.
+func (mr *MockChatAgentRepositoryMockRecorder) UpdateWithVersion(ctx, agent, expectedVersion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithVersion", reflect.TypeOf((*MockChatAgentRepository)(nil).UpdateWithVersion), ctx, agent, expectedVersion)
+}
+
+// MockChatAgentToolCallRepository is a mock of ChatAgentToolCallRepository interface.
+type MockChatAgentToolCallRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentToolCallRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentToolCallRepositoryMockRecorder is the mock recorder for MockChatAgentToolCallRepository.
+type MockChatAgentToolCallRepositoryMockRecorder struct {
+	mock *MockChatAgentToolCallRepository
+}
+
+// NewMockChatAgentToolCallRepository creates a new mock instance.
+func NewMockChatAgentToolCallRepository(ctrl *gomock.Controller) *MockChatAgentToolCallRepository {
+	mock := &MockChatAgentToolCallRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentToolCallRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentToolCallRepository) EXPECT() *MockChatAgentToolCallRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentToolCallRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentToolCallRepository) Create(ctx context.Context, entity *models.ChatAgentToolCall) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentToolCallRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentToolCallRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentToolCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentToolCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).GetByID), ctx, id)
+}
+
+// GetUsageStats mocks base method.
+func (m *MockChatAgentToolCallRepository) GetUsageStats(ctx context.Context, chatAgentID uuid.UUID, calledAfter, calledBefore *time.Time) ([]*repository.ChatAgentToolUsageStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsageStats", ctx, chatAgentID, calledAfter, calledBefore)
+	ret0, _ := ret[0].([]*repository.ChatAgentToolUsageStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsageStats indicates an expected call of GetUsageStats.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) GetUsageStats(ctx, chatAgentID, calledAfter, calledBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsageStats", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).GetUsageStats), ctx, chatAgentID, calledAfter, calledBefore)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentToolCallRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentToolCallRepository) ListAll(ctx context.Context) ([]*models.ChatAgentToolCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentToolCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentToolCallRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentToolCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentToolCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentToolCallRepository) Query(ctx context.Context, query *models.ChatAgentToolCall) ([]*models.ChatAgentToolCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentToolCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentToolCallRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentToolCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentToolCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentToolCallRepository) Save(ctx context.Context, entity *models.ChatAgentToolCall) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentToolCallRepository) Update(ctx context.Context, entity *models.ChatAgentToolCall) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentToolCallRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentToolCallRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatAgentWidgetTokenRepository is a mock of ChatAgentWidgetTokenRepository interface.
+type MockChatAgentWidgetTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentWidgetTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentWidgetTokenRepositoryMockRecorder is the mock recorder for MockChatAgentWidgetTokenRepository.
+type MockChatAgentWidgetTokenRepositoryMockRecorder struct {
+	mock *MockChatAgentWidgetTokenRepository
+}
+
+// NewMockChatAgentWidgetTokenRepository creates a new mock instance.
+func NewMockChatAgentWidgetTokenRepository(ctrl *gomock.Controller) *MockChatAgentWidgetTokenRepository {
+	mock := &MockChatAgentWidgetTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockChatAgentWidgetTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentWidgetTokenRepository) EXPECT() *MockChatAgentWidgetTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) Create(ctx context.Context, entity *models.ChatAgentWidgetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).DeleteExpired), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatAgentWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.ChatAgentWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) GetByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) ListAll(ctx context.Context) ([]*models.ChatAgentWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatAgentWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) Query(ctx context.Context, query *models.ChatAgentWidgetToken) ([]*models.ChatAgentWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatAgentWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) Save(ctx context.Context, entity *models.ChatAgentWidgetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatAgentWidgetTokenRepository) Update(ctx context.Context, entity *models.ChatAgentWidgetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatAgentWidgetTokenRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatAgentWidgetTokenRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatConversationRepository is a mock of ChatConversationRepository interface.
+type MockChatConversationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatConversationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatConversationRepositoryMockRecorder is the mock recorder for MockChatConversationRepository.
+type MockChatConversationRepositoryMockRecorder struct {
+	mock *MockChatConversationRepository
+}
+
+// NewMockChatConversationRepository creates a new mock instance.
+func NewMockChatConversationRepository(ctrl *gomock.Controller) *MockChatConversationRepository {
+	mock := &MockChatConversationRepository{ctrl: ctrl}
+	mock.recorder = &MockChatConversationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatConversationRepository) EXPECT() *MockChatConversationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockChatConversationRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatConversationRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatConversationRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatConversationRepository) Create(ctx context.Context, entity *models.ChatConversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatConversationRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatConversationRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatConversationRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatConversationRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatConversationRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockChatConversationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatConversationRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatConversationRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatConversationRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatConversationRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatConversationRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatConversationRepository) ListAll(ctx context.Context) ([]*models.ChatConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatConversationRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatConversationRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatConversationRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatConversationRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatConversationRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatConversationRepository) Query(ctx context.Context, query *models.ChatConversation) ([]*models.ChatConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatConversationRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatConversationRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatConversationRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatConversationRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatConversationRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatConversationRepository) Save(ctx context.Context, entity *models.ChatConversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatConversationRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatConversationRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatConversationRepository) Update(ctx context.Context, entity *models.ChatConversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatConversationRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatConversationRepository)(nil).Update), ctx, entity)
+}
+
+// MockChatStreamRepository is a mock of ChatStreamRepository interface.
+type MockChatStreamRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatStreamRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChatStreamRepositoryMockRecorder is the mock recorder for MockChatStreamRepository.
+type MockChatStreamRepositoryMockRecorder struct {
+	mock *MockChatStreamRepository
+}
+
+// NewMockChatStreamRepository creates a new mock instance.
+func NewMockChatStreamRepository(ctrl *gomock.Controller) *MockChatStreamRepository {
+	mock := &MockChatStreamRepository{ctrl: ctrl}
+	mock.recorder = &MockChatStreamRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatStreamRepository) EXPECT() *MockChatStreamRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AppendEvents mocks base method.
+func (m *MockChatStreamRepository) AppendEvents(ctx context.Context, stream *models.ChatStream, events []*models.ChatStreamEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendEvents", ctx, stream, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendEvents indicates an expected call of AppendEvents.
+func (mr *MockChatStreamRepositoryMockRecorder) AppendEvents(ctx, stream, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvents", reflect.TypeOf((*MockChatStreamRepository)(nil).AppendEvents), ctx, stream, events)
+}
+
+// Count mocks base method.
+func (m *MockChatStreamRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockChatStreamRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockChatStreamRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockChatStreamRepository) Create(ctx context.Context, entity *models.ChatStream) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChatStreamRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChatStreamRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockChatStreamRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockChatStreamRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockChatStreamRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockChatStreamRepository) DeleteExpired(ctx context.Context, finishedBefore, staleBefore time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, finishedBefore, staleBefore, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockChatStreamRepositoryMockRecorder) DeleteExpired(ctx, finishedBefore, staleBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockChatStreamRepository)(nil).DeleteExpired), ctx, finishedBefore, staleBefore, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockChatStreamRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChatStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockChatStreamRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockChatStreamRepository)(nil).GetByID), ctx, id)
+}
+
+// GetEvents mocks base method.
+func (m *MockChatStreamRepository) GetEvents(ctx context.Context, streamID uuid.UUID, afterSeq, limit int) ([]*models.ChatStreamEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, streamID, afterSeq, limit)
+	ret0, _ := ret[0].([]*models.ChatStreamEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockChatStreamRepositoryMockRecorder) GetEvents(ctx, streamID, afterSeq, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockChatStreamRepository)(nil).GetEvents), ctx, streamID, afterSeq, limit)
+}
+
+// GetLatest mocks base method.
+func (m *MockChatStreamRepository) GetLatest(ctx context.Context, chatAgentID uuid.UUID, requestID string) (*models.ChatStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, chatAgentID, requestID)
+	ret0, _ := ret[0].(*models.ChatStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockChatStreamRepositoryMockRecorder) GetLatest(ctx, chatAgentID, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockChatStreamRepository)(nil).GetLatest), ctx, chatAgentID, requestID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockChatStreamRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockChatStreamRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockChatStreamRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockChatStreamRepository) ListAll(ctx context.Context) ([]*models.ChatStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ChatStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockChatStreamRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockChatStreamRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockChatStreamRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ChatStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockChatStreamRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockChatStreamRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockChatStreamRepository) Query(ctx context.Context, query *models.ChatStream) ([]*models.ChatStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ChatStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockChatStreamRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockChatStreamRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockChatStreamRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ChatStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ChatStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockChatStreamRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockChatStreamRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockChatStreamRepository) Save(ctx context.Context, entity *models.ChatStream) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatStreamRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatStreamRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockChatStreamRepository) Update(ctx context.Context, entity *models.ChatStream) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChatStreamRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChatStreamRepository)(nil).Update), ctx, entity)
+}
+
+// MockClusterLockRepository is a mock of ClusterLockRepository interface.
+type MockClusterLockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterLockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockClusterLockRepositoryMockRecorder is the mock recorder for MockClusterLockRepository.
+type MockClusterLockRepositoryMockRecorder struct {
+	mock *MockClusterLockRepository
+}
+
+// NewMockClusterLockRepository creates a new mock instance.
+func NewMockClusterLockRepository(ctrl *gomock.Controller) *MockClusterLockRepository {
+	mock := &MockClusterLockRepository{ctrl: ctrl}
+	mock.recorder = &MockClusterLockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterLockRepository) EXPECT() *MockClusterLockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockClusterLockRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockClusterLockRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockClusterLockRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockClusterLockRepository) Create(ctx context.Context, entity *models.ClusterLock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockClusterLockRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClusterLockRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockClusterLockRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockClusterLockRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockClusterLockRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockClusterLockRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ClusterLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ClusterLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockClusterLockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockClusterLockRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockClusterLockRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockClusterLockRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockClusterLockRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockClusterLockRepository) ListAll(ctx context.Context) ([]*models.ClusterLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ClusterLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockClusterLockRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockClusterLockRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockClusterLockRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ClusterLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ClusterLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockClusterLockRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockClusterLockRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockClusterLockRepository) Query(ctx context.Context, query *models.ClusterLock) ([]*models.ClusterLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ClusterLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockClusterLockRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockClusterLockRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockClusterLockRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ClusterLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ClusterLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockClusterLockRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockClusterLockRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Release mocks base method.
+func (m *MockClusterLockRepository) Release(ctx context.Context, name, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, name, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockClusterLockRepositoryMockRecorder) Release(ctx, name, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockClusterLockRepository)(nil).Release), ctx, name, owner)
+}
+
+// Renew mocks base method.
+func (m *MockClusterLockRepository) Renew(ctx context.Context, name, owner string, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, name, owner, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockClusterLockRepositoryMockRecorder) Renew(ctx, name, owner, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockClusterLockRepository)(nil).Renew), ctx, name, owner, expiresAt)
+}
+
+// Save mocks base method.
+func (m *MockClusterLockRepository) Save(ctx context.Context, entity *models.ClusterLock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockClusterLockRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockClusterLockRepository)(nil).Save), ctx, entity)
+}
+
+// TryAcquire mocks base method.
+func (m *MockClusterLockRepository) TryAcquire(ctx context.Context, name, owner string, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAcquire", ctx, name, owner, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAcquire indicates an expected call of TryAcquire.
+func (mr *MockClusterLockRepositoryMockRecorder) TryAcquire(ctx, name, owner, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAcquire", reflect.TypeOf((*MockClusterLockRepository)(nil).TryAcquire), ctx, name, owner, expiresAt)
+}
+
+// Update mocks base method.
+func (m *MockClusterLockRepository) Update(ctx context.Context, entity *models.ClusterLock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockClusterLockRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClusterLockRepository)(nil).Update), ctx, entity)
+}
+
+// MockConversationArchiveRepository is a mock of ConversationArchiveRepository interface.
+type MockConversationArchiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationArchiveRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationArchiveRepositoryMockRecorder is the mock recorder for MockConversationArchiveRepository.
+type MockConversationArchiveRepositoryMockRecorder struct {
+	mock *MockConversationArchiveRepository
+}
+
+// NewMockConversationArchiveRepository creates a new mock instance.
+func NewMockConversationArchiveRepository(ctrl *gomock.Controller) *MockConversationArchiveRepository {
+	mock := &MockConversationArchiveRepository{ctrl: ctrl}
+	mock.recorder = &MockConversationArchiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationArchiveRepository) EXPECT() *MockConversationArchiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ArchiveConversation mocks base method.
+func (m *MockConversationArchiveRepository) ArchiveConversation(ctx context.Context, archive *models.ConversationArchive, conversation *models.ChatAgentConversation, messageCount int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveConversation", ctx, archive, conversation, messageCount)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveConversation indicates an expected call of ArchiveConversation.
+func (mr *MockConversationArchiveRepositoryMockRecorder) ArchiveConversation(ctx, archive, conversation, messageCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveConversation", reflect.TypeOf((*MockConversationArchiveRepository)(nil).ArchiveConversation), ctx, archive, conversation, messageCount)
+}
+
+// Count mocks base method.
+func (m *MockConversationArchiveRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockConversationArchiveRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockConversationArchiveRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockConversationArchiveRepository) Create(ctx context.Context, entity *models.ConversationArchive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConversationArchiveRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConversationArchiveRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockConversationArchiveRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockConversationArchiveRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockConversationArchiveRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByChatAgentIDWithPagination mocks base method.
+func (m *MockConversationArchiveRepository) GetByChatAgentIDWithPagination(ctx context.Context, chatAgentID uuid.UUID, serviceUserID, status string, page, pageSize int) ([]*models.ConversationArchive, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentIDWithPagination", ctx, chatAgentID, serviceUserID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.ConversationArchive)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByChatAgentIDWithPagination indicates an expected call of GetByChatAgentIDWithPagination.
+func (mr *MockConversationArchiveRepositoryMockRecorder) GetByChatAgentIDWithPagination(ctx, chatAgentID, serviceUserID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentIDWithPagination", reflect.TypeOf((*MockConversationArchiveRepository)(nil).GetByChatAgentIDWithPagination), ctx, chatAgentID, serviceUserID, status, page, pageSize)
+}
+
+// GetByConversationID mocks base method.
+func (m *MockConversationArchiveRepository) GetByConversationID(ctx context.Context, conversationID uuid.UUID) (*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByConversationID", ctx, conversationID)
+	ret0, _ := ret[0].(*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByConversationID indicates an expected call of GetByConversationID.
+func (mr *MockConversationArchiveRepositoryMockRecorder) GetByConversationID(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByConversationID", reflect.TypeOf((*MockConversationArchiveRepository)(nil).GetByConversationID), ctx, conversationID)
+}
+
+// GetByID mocks base method.
+func (m *MockConversationArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockConversationArchiveRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConversationArchiveRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockConversationArchiveRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockConversationArchiveRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockConversationArchiveRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockConversationArchiveRepository) ListAll(ctx context.Context) ([]*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockConversationArchiveRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockConversationArchiveRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockConversationArchiveRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockConversationArchiveRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockConversationArchiveRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockConversationArchiveRepository) Query(ctx context.Context, query *models.ConversationArchive) ([]*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockConversationArchiveRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockConversationArchiveRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockConversationArchiveRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockConversationArchiveRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockConversationArchiveRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// RestoreConversation mocks base method.
+func (m *MockConversationArchiveRepository) RestoreConversation(ctx context.Context, archive *models.ConversationArchive, fromStatus, status string, conversation *models.ChatAgentConversation, messages []*models.ChatAgentMessage, variables []*models.ChatAgentConversationVariable) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreConversation", ctx, archive, fromStatus, status, conversation, messages, variables)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreConversation indicates an expected call of RestoreConversation.
+func (mr *MockConversationArchiveRepositoryMockRecorder) RestoreConversation(ctx, archive, fromStatus, status, conversation, messages, variables any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreConversation", reflect.TypeOf((*MockConversationArchiveRepository)(nil).RestoreConversation), ctx, archive, fromStatus, status, conversation, messages, variables)
+}
+
+// Save mocks base method.
+func (m *MockConversationArchiveRepository) Save(ctx context.Context, entity *models.ConversationArchive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockConversationArchiveRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockConversationArchiveRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockConversationArchiveRepository) Update(ctx context.Context, entity *models.ConversationArchive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConversationArchiveRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConversationArchiveRepository)(nil).Update), ctx, entity)
+}
+
+// MockConversationEventRepository is a mock of ConversationEventRepository interface.
+type MockConversationEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationEventRepositoryMockRecorder is the mock recorder for MockConversationEventRepository.
+type MockConversationEventRepositoryMockRecorder struct {
+	mock *MockConversationEventRepository
+}
+
+// NewMockConversationEventRepository creates a new mock instance.
+func NewMockConversationEventRepository(ctrl *gomock.Controller) *MockConversationEventRepository {
+	mock := &MockConversationEventRepository{ctrl: ctrl}
+	mock.recorder = &MockConversationEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationEventRepository) EXPECT() *MockConversationEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockConversationEventRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockConversationEventRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockConversationEventRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockConversationEventRepository) Create(ctx context.Context, entity *models.ConversationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConversationEventRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConversationEventRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteBefore mocks base method.
+func (m *MockConversationEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockConversationEventRepositoryMockRecorder) DeleteBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockConversationEventRepository)(nil).DeleteBefore), ctx, before)
+}
+
+// DeleteByID mocks base method.
+func (m *MockConversationEventRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockConversationEventRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockConversationEventRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockConversationEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockConversationEventRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConversationEventRepository)(nil).GetByID), ctx, id)
+}
+
+// GetUndispatched mocks base method.
+func (m *MockConversationEventRepository) GetUndispatched(ctx context.Context, limit int) ([]*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUndispatched", ctx, limit)
+	ret0, _ := ret[0].([]*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUndispatched indicates an expected call of GetUndispatched.
+func (mr *MockConversationEventRepositoryMockRecorder) GetUndispatched(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndispatched", reflect.TypeOf((*MockConversationEventRepository)(nil).GetUndispatched), ctx, limit)
+}
+
+// GetUnpublished mocks base method.
+func (m *MockConversationEventRepository) GetUnpublished(ctx context.Context, limit int) ([]*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpublished", ctx, limit)
+	ret0, _ := ret[0].([]*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpublished indicates an expected call of GetUnpublished.
+func (mr *MockConversationEventRepositoryMockRecorder) GetUnpublished(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpublished", reflect.TypeOf((*MockConversationEventRepository)(nil).GetUnpublished), ctx, limit)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockConversationEventRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockConversationEventRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockConversationEventRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockConversationEventRepository) ListAll(ctx context.Context) ([]*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockConversationEventRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockConversationEventRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockConversationEventRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockConversationEventRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockConversationEventRepository)(nil).ListPage), ctx, query)
+}
+
+// MarkDispatched mocks base method.
+func (m *MockConversationEventRepository) MarkDispatched(ctx context.Context, event *models.ConversationEvent, deliveries []*models.ApplicationWebhookDelivery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDispatched", ctx, event, deliveries)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDispatched indicates an expected call of MarkDispatched.
+func (mr *MockConversationEventRepositoryMockRecorder) MarkDispatched(ctx, event, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDispatched", reflect.TypeOf((*MockConversationEventRepository)(nil).MarkDispatched), ctx, event, deliveries)
+}
+
+// MarkPublished mocks base method.
+func (m *MockConversationEventRepository) MarkPublished(ctx context.Context, ids []uuid.UUID, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, ids, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockConversationEventRepositoryMockRecorder) MarkPublished(ctx, ids, publishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockConversationEventRepository)(nil).MarkPublished), ctx, ids, publishedAt)
+}
+
+// Query mocks base method.
+func (m *MockConversationEventRepository) Query(ctx context.Context, query *models.ConversationEvent) ([]*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockConversationEventRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockConversationEventRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockConversationEventRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ConversationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ConversationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockConversationEventRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockConversationEventRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockConversationEventRepository) Save(ctx context.Context, entity *models.ConversationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockConversationEventRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockConversationEventRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockConversationEventRepository) Update(ctx context.Context, entity *models.ConversationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockConversationEventRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockConversationEventRepository)(nil).Update), ctx, entity)
+}
+
+// MockEvaluationCaseRepository is a mock of EvaluationCaseRepository interface.
+type MockEvaluationCaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvaluationCaseRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEvaluationCaseRepositoryMockRecorder is the mock recorder for MockEvaluationCaseRepository.
+type MockEvaluationCaseRepositoryMockRecorder struct {
+	mock *MockEvaluationCaseRepository
+}
+
+// NewMockEvaluationCaseRepository creates a new mock instance.
+func NewMockEvaluationCaseRepository(ctrl *gomock.Controller) *MockEvaluationCaseRepository {
+	mock := &MockEvaluationCaseRepository{ctrl: ctrl}
+	mock.recorder = &MockEvaluationCaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvaluationCaseRepository) EXPECT() *MockEvaluationCaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockEvaluationCaseRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).Count), ctx, conditions)
+}
+
+// CountBySetID mocks base method.
+func (m *MockEvaluationCaseRepository) CountBySetID(ctx context.Context, setID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySetID", ctx, setID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySetID indicates an expected call of CountBySetID.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) CountBySetID(ctx, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySetID", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).CountBySetID), ctx, setID)
+}
+
+// Create mocks base method.
+func (m *MockEvaluationCaseRepository) Create(ctx context.Context, entity *models.EvaluationCase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockEvaluationCaseRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteBySetID mocks base method.
+func (m *MockEvaluationCaseRepository) DeleteBySetID(ctx context.Context, setID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBySetID", ctx, setID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBySetID indicates an expected call of DeleteBySetID.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) DeleteBySetID(ctx, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySetID", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).DeleteBySetID), ctx, setID)
+}
+
+// GetByID mocks base method.
+func (m *MockEvaluationCaseRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySetID mocks base method.
+func (m *MockEvaluationCaseRepository) GetBySetID(ctx context.Context, setID uuid.UUID) ([]*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySetID", ctx, setID)
+	ret0, _ := ret[0].([]*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySetID indicates an expected call of GetBySetID.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) GetBySetID(ctx, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySetID", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).GetBySetID), ctx, setID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockEvaluationCaseRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockEvaluationCaseRepository) ListAll(ctx context.Context) ([]*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockEvaluationCaseRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockEvaluationCaseRepository) Query(ctx context.Context, query *models.EvaluationCase) ([]*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockEvaluationCaseRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockEvaluationCaseRepository) Save(ctx context.Context, entity *models.EvaluationCase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockEvaluationCaseRepository) Update(ctx context.Context, entity *models.EvaluationCase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEvaluationCaseRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEvaluationCaseRepository)(nil).Update), ctx, entity)
+}
+
+// MockEvaluationResultRepository is a mock of EvaluationResultRepository interface.
+type MockEvaluationResultRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvaluationResultRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEvaluationResultRepositoryMockRecorder is the mock recorder for MockEvaluationResultRepository.
+type MockEvaluationResultRepositoryMockRecorder struct {
+	mock *MockEvaluationResultRepository
+}
+
+// NewMockEvaluationResultRepository creates a new mock instance.
+func NewMockEvaluationResultRepository(ctrl *gomock.Controller) *MockEvaluationResultRepository {
+	mock := &MockEvaluationResultRepository{ctrl: ctrl}
+	mock.recorder = &MockEvaluationResultRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvaluationResultRepository) EXPECT() *MockEvaluationResultRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchCreate mocks base method.
+func (m *MockEvaluationResultRepository) BatchCreate(ctx context.Context, results []*models.EvaluationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockEvaluationResultRepositoryMockRecorder) BatchCreate(ctx, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockEvaluationResultRepository)(nil).BatchCreate), ctx, results)
+}
+
+// Count mocks base method.
+func (m *MockEvaluationResultRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockEvaluationResultRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockEvaluationResultRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockEvaluationResultRepository) Create(ctx context.Context, entity *models.EvaluationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEvaluationResultRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEvaluationResultRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockEvaluationResultRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockEvaluationResultRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEvaluationResultRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByRunIDs mocks base method.
+func (m *MockEvaluationResultRepository) DeleteByRunIDs(ctx context.Context, runIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByRunIDs", ctx, runIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByRunIDs indicates an expected call of DeleteByRunIDs.
+func (mr *MockEvaluationResultRepositoryMockRecorder) DeleteByRunIDs(ctx, runIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByRunIDs", reflect.TypeOf((*MockEvaluationResultRepository)(nil).DeleteByRunIDs), ctx, runIDs)
+}
+
+// GetAllByRunID mocks base method.
+func (m *MockEvaluationResultRepository) GetAllByRunID(ctx context.Context, runID uuid.UUID) ([]*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByRunID", ctx, runID)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByRunID indicates an expected call of GetAllByRunID.
+func (mr *MockEvaluationResultRepositoryMockRecorder) GetAllByRunID(ctx, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByRunID", reflect.TypeOf((*MockEvaluationResultRepository)(nil).GetAllByRunID), ctx, runID)
+}
+
+// GetByID mocks base method.
+func (m *MockEvaluationResultRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockEvaluationResultRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEvaluationResultRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByRunIDAndStatus mocks base method.
+func (m *MockEvaluationResultRepository) GetByRunIDAndStatus(ctx context.Context, runID uuid.UUID, status string, limit int) ([]*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRunIDAndStatus", ctx, runID, status, limit)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRunIDAndStatus indicates an expected call of GetByRunIDAndStatus.
+func (mr *MockEvaluationResultRepositoryMockRecorder) GetByRunIDAndStatus(ctx, runID, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRunIDAndStatus", reflect.TypeOf((*MockEvaluationResultRepository)(nil).GetByRunIDAndStatus), ctx, runID, status, limit)
+}
+
+// GetByRunIDWithPagination mocks base method.
+func (m *MockEvaluationResultRepository) GetByRunIDWithPagination(ctx context.Context, runID uuid.UUID, status string, page, pageSize int) ([]*models.EvaluationResult, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRunIDWithPagination", ctx, runID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByRunIDWithPagination indicates an expected call of GetByRunIDWithPagination.
+func (mr *MockEvaluationResultRepositoryMockRecorder) GetByRunIDWithPagination(ctx, runID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRunIDWithPagination", reflect.TypeOf((*MockEvaluationResultRepository)(nil).GetByRunIDWithPagination), ctx, runID, status, page, pageSize)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockEvaluationResultRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockEvaluationResultRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockEvaluationResultRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockEvaluationResultRepository) ListAll(ctx context.Context) ([]*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockEvaluationResultRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockEvaluationResultRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockEvaluationResultRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockEvaluationResultRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockEvaluationResultRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockEvaluationResultRepository) Query(ctx context.Context, query *models.EvaluationResult) ([]*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockEvaluationResultRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockEvaluationResultRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockEvaluationResultRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.EvaluationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockEvaluationResultRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockEvaluationResultRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockEvaluationResultRepository) Save(ctx context.Context, entity *models.EvaluationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockEvaluationResultRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockEvaluationResultRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockEvaluationResultRepository) Update(ctx context.Context, entity *models.EvaluationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEvaluationResultRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEvaluationResultRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateResult mocks base method.
+func (m *MockEvaluationResultRepository) UpdateResult(ctx context.Context, result *models.EvaluationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResult", ctx, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateResult indicates an expected call of UpdateResult.
+func (mr *MockEvaluationResultRepositoryMockRecorder) UpdateResult(ctx, result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResult", reflect.TypeOf((*MockEvaluationResultRepository)(nil).UpdateResult), ctx, result)
+}
+
+// UpdateStatusByIDs mocks base method.
+func (m *MockEvaluationResultRepository) UpdateStatusByIDs(ctx context.Context, ids []uuid.UUID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByIDs", ctx, ids, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByIDs indicates an expected call of UpdateStatusByIDs.
+func (mr *MockEvaluationResultRepositoryMockRecorder) UpdateStatusByIDs(ctx, ids, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByIDs", reflect.TypeOf((*MockEvaluationResultRepository)(nil).UpdateStatusByIDs), ctx, ids, status)
+}
+
+// UpdateStatusByRunID mocks base method.
+func (m *MockEvaluationResultRepository) UpdateStatusByRunID(ctx context.Context, runID uuid.UUID, fromStatus, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusByRunID", ctx, runID, fromStatus, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusByRunID indicates an expected call of UpdateStatusByRunID.
+func (mr *MockEvaluationResultRepositoryMockRecorder) UpdateStatusByRunID(ctx, runID, fromStatus, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusByRunID", reflect.TypeOf((*MockEvaluationResultRepository)(nil).UpdateStatusByRunID), ctx, runID, fromStatus, status)
+}
+
+// MockEvaluationRunRepository is a mock of EvaluationRunRepository interface.
+type MockEvaluationRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvaluationRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEvaluationRunRepositoryMockRecorder is the mock recorder for MockEvaluationRunRepository.
+type MockEvaluationRunRepositoryMockRecorder struct {
+	mock *MockEvaluationRunRepository
+}
+
+// NewMockEvaluationRunRepository creates a new mock instance.
+func NewMockEvaluationRunRepository(ctrl *gomock.Controller) *MockEvaluationRunRepository {
+	mock := &MockEvaluationRunRepository{ctrl: ctrl}
+	mock.recorder = &MockEvaluationRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvaluationRunRepository) EXPECT() *MockEvaluationRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockEvaluationRunRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockEvaluationRunRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockEvaluationRunRepository)(nil).Count), ctx, conditions)
+}
+
+// CountBySetIDAndStatuses mocks base method.
+func (m *MockEvaluationRunRepository) CountBySetIDAndStatuses(ctx context.Context, setID uuid.UUID, statuses []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySetIDAndStatuses", ctx, setID, statuses)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySetIDAndStatuses indicates an expected call of CountBySetIDAndStatuses.
+func (mr *MockEvaluationRunRepositoryMockRecorder) CountBySetIDAndStatuses(ctx, setID, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySetIDAndStatuses", reflect.TypeOf((*MockEvaluationRunRepository)(nil).CountBySetIDAndStatuses), ctx, setID, statuses)
+}
+
+// Create mocks base method.
+func (m *MockEvaluationRunRepository) Create(ctx context.Context, entity *models.EvaluationRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEvaluationRunRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEvaluationRunRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockEvaluationRunRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockEvaluationRunRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEvaluationRunRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteBySetID mocks base method.
+func (m *MockEvaluationRunRepository) DeleteBySetID(ctx context.Context, setID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBySetID", ctx, setID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBySetID indicates an expected call of DeleteBySetID.
+func (mr *MockEvaluationRunRepositoryMockRecorder) DeleteBySetID(ctx, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySetID", reflect.TypeOf((*MockEvaluationRunRepository)(nil).DeleteBySetID), ctx, setID)
+}
+
+// GetByID mocks base method.
+func (m *MockEvaluationRunRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockEvaluationRunRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEvaluationRunRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySetIDWithPagination mocks base method.
+func (m *MockEvaluationRunRepository) GetBySetIDWithPagination(ctx context.Context, setID uuid.UUID, page, pageSize int) ([]*models.EvaluationRun, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySetIDWithPagination", ctx, setID, page, pageSize)
+	ret0, _ := ret[0].([]*models.EvaluationRun)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBySetIDWithPagination indicates an expected call of GetBySetIDWithPagination.
+func (mr *MockEvaluationRunRepositoryMockRecorder) GetBySetIDWithPagination(ctx, setID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySetIDWithPagination", reflect.TypeOf((*MockEvaluationRunRepository)(nil).GetBySetIDWithPagination), ctx, setID, page, pageSize)
+}
+
+// GetEarliestByStatuses mocks base method.
+func (m *MockEvaluationRunRepository) GetEarliestByStatuses(ctx context.Context, statuses []string) (*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEarliestByStatuses", ctx, statuses)
+	ret0, _ := ret[0].(*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarliestByStatuses indicates an expected call of GetEarliestByStatuses.
+func (mr *MockEvaluationRunRepositoryMockRecorder) GetEarliestByStatuses(ctx, statuses any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarliestByStatuses", reflect.TypeOf((*MockEvaluationRunRepository)(nil).GetEarliestByStatuses), ctx, statuses)
+}
+
+// GetIDsBySetID mocks base method.
+func (m *MockEvaluationRunRepository) GetIDsBySetID(ctx context.Context, setID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsBySetID", ctx, setID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsBySetID indicates an expected call of GetIDsBySetID.
+func (mr *MockEvaluationRunRepositoryMockRecorder) GetIDsBySetID(ctx, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsBySetID", reflect.TypeOf((*MockEvaluationRunRepository)(nil).GetIDsBySetID), ctx, setID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockEvaluationRunRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockEvaluationRunRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockEvaluationRunRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockEvaluationRunRepository) ListAll(ctx context.Context) ([]*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockEvaluationRunRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockEvaluationRunRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockEvaluationRunRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockEvaluationRunRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockEvaluationRunRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockEvaluationRunRepository) Query(ctx context.Context, query *models.EvaluationRun) ([]*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockEvaluationRunRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockEvaluationRunRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockEvaluationRunRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockEvaluationRunRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockEvaluationRunRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockEvaluationRunRepository) Save(ctx context.Context, entity *models.EvaluationRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockEvaluationRunRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockEvaluationRunRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockEvaluationRunRepository) Update(ctx context.Context, entity *models.EvaluationRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEvaluationRunRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEvaluationRunRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateFailed mocks base method.
+func (m *MockEvaluationRunRepository) UpdateFailed(ctx context.Context, id uuid.UUID, fromStatuses []string, status, errorMessage string, finishedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFailed", ctx, id, fromStatuses, status, errorMessage, finishedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFailed indicates an expected call of UpdateFailed.
+func (mr *MockEvaluationRunRepositoryMockRecorder) UpdateFailed(ctx, id, fromStatuses, status, errorMessage, finishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFailed", reflect.TypeOf((*MockEvaluationRunRepository)(nil).UpdateFailed), ctx, id, fromStatuses, status, errorMessage, finishedAt)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockEvaluationRunRepository) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatuses []string, status string, startedAt, finishedAt *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, fromStatuses, status, startedAt, finishedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockEvaluationRunRepositoryMockRecorder) UpdateStatus(ctx, id, fromStatuses, status, startedAt, finishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockEvaluationRunRepository)(nil).UpdateStatus), ctx, id, fromStatuses, status, startedAt, finishedAt)
+}
+
+// UpdateSummary mocks base method.
+func (m *MockEvaluationRunRepository) UpdateSummary(ctx context.Context, id uuid.UUID, passedCount, failedCount int, averageScore float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSummary", ctx, id, passedCount, failedCount, averageScore)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSummary indicates an expected call of UpdateSummary.
+func (mr *MockEvaluationRunRepositoryMockRecorder) UpdateSummary(ctx, id, passedCount, failedCount, averageScore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSummary", reflect.TypeOf((*MockEvaluationRunRepository)(nil).UpdateSummary), ctx, id, passedCount, failedCount, averageScore)
+}
+
+// MockEvaluationSetRepository is a mock of EvaluationSetRepository interface.
+type MockEvaluationSetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvaluationSetRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEvaluationSetRepositoryMockRecorder is the mock recorder for MockEvaluationSetRepository.
+type MockEvaluationSetRepositoryMockRecorder struct {
+	mock *MockEvaluationSetRepository
+}
+
+// NewMockEvaluationSetRepository creates a new mock instance.
+func NewMockEvaluationSetRepository(ctrl *gomock.Controller) *MockEvaluationSetRepository {
+	mock := &MockEvaluationSetRepository{ctrl: ctrl}
+	mock.recorder = &MockEvaluationSetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvaluationSetRepository) EXPECT() *MockEvaluationSetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockEvaluationSetRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockEvaluationSetRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockEvaluationSetRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockEvaluationSetRepository) Create(ctx context.Context, entity *models.EvaluationSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEvaluationSetRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEvaluationSetRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockEvaluationSetRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockEvaluationSetRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockEvaluationSetRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByChatAgentID mocks base method.
+func (m *MockEvaluationSetRepository) GetByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatAgentID indicates an expected call of GetByChatAgentID.
+func (mr *MockEvaluationSetRepositoryMockRecorder) GetByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatAgentID", reflect.TypeOf((*MockEvaluationSetRepository)(nil).GetByChatAgentID), ctx, chatAgentID)
+}
+
+// GetByID mocks base method.
+func (m *MockEvaluationSetRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockEvaluationSetRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockEvaluationSetRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockEvaluationSetRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockEvaluationSetRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockEvaluationSetRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockEvaluationSetRepository) ListAll(ctx context.Context) ([]*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockEvaluationSetRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockEvaluationSetRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockEvaluationSetRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockEvaluationSetRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockEvaluationSetRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockEvaluationSetRepository) Query(ctx context.Context, query *models.EvaluationSet) ([]*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockEvaluationSetRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockEvaluationSetRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockEvaluationSetRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockEvaluationSetRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockEvaluationSetRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockEvaluationSetRepository) Save(ctx context.Context, entity *models.EvaluationSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockEvaluationSetRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockEvaluationSetRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockEvaluationSetRepository) Update(ctx context.Context, entity *models.EvaluationSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockEvaluationSetRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEvaluationSetRepository)(nil).Update), ctx, entity)
+}
+
+// MockKnowledgeBaseRepository is a mock of KnowledgeBaseRepository interface.
+type MockKnowledgeBaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockKnowledgeBaseRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockKnowledgeBaseRepositoryMockRecorder is the mock recorder for MockKnowledgeBaseRepository.
+type MockKnowledgeBaseRepositoryMockRecorder struct {
+	mock *MockKnowledgeBaseRepository
+}
+
+// NewMockKnowledgeBaseRepository creates a new mock instance.
+func NewMockKnowledgeBaseRepository(ctrl *gomock.Controller) *MockKnowledgeBaseRepository {
+	mock := &MockKnowledgeBaseRepository{ctrl: ctrl}
+	mock.recorder = &MockKnowledgeBaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKnowledgeBaseRepository) EXPECT() *MockKnowledgeBaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockKnowledgeBaseRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockKnowledgeBaseRepository) Create(ctx context.Context, entity *models.KnowledgeBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockKnowledgeBaseRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockKnowledgeBaseRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockKnowledgeBaseRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockKnowledgeBaseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockKnowledgeBaseRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockKnowledgeBaseRepository) ListAll(ctx context.Context) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockKnowledgeBaseRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockKnowledgeBaseRepository) Query(ctx context.Context, query *models.KnowledgeBase) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockKnowledgeBaseRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockKnowledgeBaseRepository) Save(ctx context.Context, entity *models.KnowledgeBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockKnowledgeBaseRepository) Update(ctx context.Context, entity *models.KnowledgeBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockKnowledgeBaseRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockKnowledgeBaseRepository)(nil).Update), ctx, entity)
+}
+
+// MockKnowledgeChunkRepository is a mock of KnowledgeChunkRepository interface.
+type MockKnowledgeChunkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockKnowledgeChunkRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockKnowledgeChunkRepositoryMockRecorder is the mock recorder for MockKnowledgeChunkRepository.
+type MockKnowledgeChunkRepositoryMockRecorder struct {
+	mock *MockKnowledgeChunkRepository
+}
+
+// NewMockKnowledgeChunkRepository creates a new mock instance.
+func NewMockKnowledgeChunkRepository(ctrl *gomock.Controller) *MockKnowledgeChunkRepository {
+	mock := &MockKnowledgeChunkRepository{ctrl: ctrl}
+	mock.recorder = &MockKnowledgeChunkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKnowledgeChunkRepository) EXPECT() *MockKnowledgeChunkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchCreate mocks base method.
+func (m *MockKnowledgeChunkRepository) BatchCreate(ctx context.Context, chunks []*models.KnowledgeChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, chunks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) BatchCreate(ctx, chunks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).BatchCreate), ctx, chunks)
+}
+
+// Count mocks base method.
+func (m *MockKnowledgeChunkRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockKnowledgeChunkRepository) Create(ctx context.Context, entity *models.KnowledgeChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByDocumentID mocks base method.
+func (m *MockKnowledgeChunkRepository) DeleteByDocumentID(ctx context.Context, documentID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByDocumentID", ctx, documentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByDocumentID indicates an expected call of DeleteByDocumentID.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) DeleteByDocumentID(ctx, documentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByDocumentID", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).DeleteByDocumentID), ctx, documentID)
+}
+
+// DeleteByID mocks base method.
+func (m *MockKnowledgeChunkRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByIDs mocks base method.
+func (m *MockKnowledgeChunkRepository) DeleteByIDs(ctx context.Context, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByIDs", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByIDs indicates an expected call of DeleteByIDs.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) DeleteByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByIDs", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).DeleteByIDs), ctx, ids)
+}
+
+// DeleteByKnowledgeBaseID mocks base method.
+func (m *MockKnowledgeChunkRepository) DeleteByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByKnowledgeBaseID", ctx, knowledgeBaseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByKnowledgeBaseID indicates an expected call of DeleteByKnowledgeBaseID.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) DeleteByKnowledgeBaseID(ctx, knowledgeBaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByKnowledgeBaseID", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).DeleteByKnowledgeBaseID), ctx, knowledgeBaseID)
+}
+
+// GetByDocumentID mocks base method.
+func (m *MockKnowledgeChunkRepository) GetByDocumentID(ctx context.Context, documentID uuid.UUID) ([]*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDocumentID", ctx, documentID)
+	ret0, _ := ret[0].([]*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDocumentID indicates an expected call of GetByDocumentID.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) GetByDocumentID(ctx, documentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDocumentID", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).GetByDocumentID), ctx, documentID)
+}
+
+// GetByID mocks base method.
+func (m *MockKnowledgeChunkRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByKnowledgeBaseIDs mocks base method.
+func (m *MockKnowledgeChunkRepository) GetByKnowledgeBaseIDs(ctx context.Context, knowledgeBaseIDs []uuid.UUID) ([]*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKnowledgeBaseIDs", ctx, knowledgeBaseIDs)
+	ret0, _ := ret[0].([]*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKnowledgeBaseIDs indicates an expected call of GetByKnowledgeBaseIDs.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) GetByKnowledgeBaseIDs(ctx, knowledgeBaseIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKnowledgeBaseIDs", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).GetByKnowledgeBaseIDs), ctx, knowledgeBaseIDs)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockKnowledgeChunkRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockKnowledgeChunkRepository) ListAll(ctx context.Context) ([]*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockKnowledgeChunkRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockKnowledgeChunkRepository) Query(ctx context.Context, query *models.KnowledgeChunk) ([]*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockKnowledgeChunkRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.KnowledgeChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.KnowledgeChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockKnowledgeChunkRepository) Save(ctx context.Context, entity *models.KnowledgeChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockKnowledgeChunkRepository) Update(ctx context.Context, entity *models.KnowledgeChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockKnowledgeChunkRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockKnowledgeChunkRepository)(nil).Update), ctx, entity)
+}
+
+// MockKnowledgeDocumentRepository is a mock of KnowledgeDocumentRepository interface.
+type MockKnowledgeDocumentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockKnowledgeDocumentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockKnowledgeDocumentRepositoryMockRecorder is the mock recorder for MockKnowledgeDocumentRepository.
+type MockKnowledgeDocumentRepositoryMockRecorder struct {
+	mock *MockKnowledgeDocumentRepository
+}
+
+// NewMockKnowledgeDocumentRepository creates a new mock instance.
+func NewMockKnowledgeDocumentRepository(ctrl *gomock.Controller) *MockKnowledgeDocumentRepository {
+	mock := &MockKnowledgeDocumentRepository{ctrl: ctrl}
+	mock.recorder = &MockKnowledgeDocumentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKnowledgeDocumentRepository) EXPECT() *MockKnowledgeDocumentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockKnowledgeDocumentRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).Count), ctx, conditions)
+}
+
+// CountByKnowledgeBaseID mocks base method.
+func (m *MockKnowledgeDocumentRepository) CountByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByKnowledgeBaseID", ctx, knowledgeBaseID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByKnowledgeBaseID indicates an expected call of CountByKnowledgeBaseID.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) CountByKnowledgeBaseID(ctx, knowledgeBaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByKnowledgeBaseID", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).CountByKnowledgeBaseID), ctx, knowledgeBaseID)
+}
+
+// Create mocks base method.
+func (m *MockKnowledgeDocumentRepository) Create(ctx context.Context, entity *models.KnowledgeDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockKnowledgeDocumentRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByKnowledgeBaseID mocks base method.
+func (m *MockKnowledgeDocumentRepository) DeleteByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByKnowledgeBaseID", ctx, knowledgeBaseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByKnowledgeBaseID indicates an expected call of DeleteByKnowledgeBaseID.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) DeleteByKnowledgeBaseID(ctx, knowledgeBaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByKnowledgeBaseID", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).DeleteByKnowledgeBaseID), ctx, knowledgeBaseID)
+}
+
+// GetByID mocks base method.
+func (m *MockKnowledgeDocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockKnowledgeDocumentRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).GetByIDs), ctx, ids)
+}
+
+// GetByKnowledgeBaseIDWithPagination mocks base method.
+func (m *MockKnowledgeDocumentRepository) GetByKnowledgeBaseIDWithPagination(ctx context.Context, knowledgeBaseID uuid.UUID, status string, page, pageSize int) ([]*models.KnowledgeDocument, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKnowledgeBaseIDWithPagination", ctx, knowledgeBaseID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByKnowledgeBaseIDWithPagination indicates an expected call of GetByKnowledgeBaseIDWithPagination.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) GetByKnowledgeBaseIDWithPagination(ctx, knowledgeBaseID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKnowledgeBaseIDWithPagination", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).GetByKnowledgeBaseIDWithPagination), ctx, knowledgeBaseID, status, page, pageSize)
+}
+
+// GetIDsByKnowledgeBaseID mocks base method.
+func (m *MockKnowledgeDocumentRepository) GetIDsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDsByKnowledgeBaseID", ctx, knowledgeBaseID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDsByKnowledgeBaseID indicates an expected call of GetIDsByKnowledgeBaseID.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) GetIDsByKnowledgeBaseID(ctx, knowledgeBaseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDsByKnowledgeBaseID", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).GetIDsByKnowledgeBaseID), ctx, knowledgeBaseID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockKnowledgeDocumentRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockKnowledgeDocumentRepository) ListAll(ctx context.Context) ([]*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockKnowledgeDocumentRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockKnowledgeDocumentRepository) Query(ctx context.Context, query *models.KnowledgeDocument) ([]*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockKnowledgeDocumentRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockKnowledgeDocumentRepository) Save(ctx context.Context, entity *models.KnowledgeDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockKnowledgeDocumentRepository) Update(ctx context.Context, entity *models.KnowledgeDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateProcessResult mocks base method.
+func (m *MockKnowledgeDocumentRepository) UpdateProcessResult(ctx context.Context, id uuid.UUID, status string, chunkCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProcessResult", ctx, id, status, chunkCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProcessResult indicates an expected call of UpdateProcessResult.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) UpdateProcessResult(ctx, id, status, chunkCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProcessResult", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).UpdateProcessResult), ctx, id, status, chunkCount)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockKnowledgeDocumentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status, errorMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status, errorMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockKnowledgeDocumentRepositoryMockRecorder) UpdateStatus(ctx, id, status, errorMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockKnowledgeDocumentRepository)(nil).UpdateStatus), ctx, id, status, errorMessage)
+}
+
+// MockLlmProviderDefineRepository is a mock of LlmProviderDefineRepository interface.
+type MockLlmProviderDefineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLlmProviderDefineRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLlmProviderDefineRepositoryMockRecorder is the mock recorder for MockLlmProviderDefineRepository.
+type MockLlmProviderDefineRepositoryMockRecorder struct {
+	mock *MockLlmProviderDefineRepository
+}
+
+// NewMockLlmProviderDefineRepository creates a new mock instance.
+func NewMockLlmProviderDefineRepository(ctrl *gomock.Controller) *MockLlmProviderDefineRepository {
+	mock := &MockLlmProviderDefineRepository{ctrl: ctrl}
+	mock.recorder = &MockLlmProviderDefineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLlmProviderDefineRepository) EXPECT() *MockLlmProviderDefineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockLlmProviderDefineRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockLlmProviderDefineRepository) Create(ctx context.Context, entity *models.LlmProviderDefine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockLlmProviderDefineRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockLlmProviderDefineRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByType mocks base method.
+func (m *MockLlmProviderDefineRepository) GetByType(ctx context.Context, providerType string) (*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByType", ctx, providerType)
+	ret0, _ := ret[0].(*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByType indicates an expected call of GetByType.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) GetByType(ctx, providerType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByType", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).GetByType), ctx, providerType)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockLlmProviderDefineRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockLlmProviderDefineRepository) ListAll(ctx context.Context) ([]*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockLlmProviderDefineRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockLlmProviderDefineRepository) Query(ctx context.Context, query *models.LlmProviderDefine) ([]*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockLlmProviderDefineRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockLlmProviderDefineRepository) Save(ctx context.Context, entity *models.LlmProviderDefine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockLlmProviderDefineRepository) Update(ctx context.Context, entity *models.LlmProviderDefine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLlmProviderDefineRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLlmProviderDefineRepository)(nil).Update), ctx, entity)
+}
+
+// MockLlmProviderRepository is a mock of LlmProviderRepository interface.
+type MockLlmProviderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLlmProviderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLlmProviderRepositoryMockRecorder is the mock recorder for MockLlmProviderRepository.
+type MockLlmProviderRepositoryMockRecorder struct {
+	mock *MockLlmProviderRepository
+}
+
+// NewMockLlmProviderRepository creates a new mock instance.
+func NewMockLlmProviderRepository(ctrl *gomock.Controller) *MockLlmProviderRepository {
+	mock := &MockLlmProviderRepository{ctrl: ctrl}
+	mock.recorder = &MockLlmProviderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLlmProviderRepository) EXPECT() *MockLlmProviderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockLlmProviderRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockLlmProviderRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockLlmProviderRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockLlmProviderRepository) Create(ctx context.Context, entity *models.ApplicationLlmProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLlmProviderRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLlmProviderRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockLlmProviderRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockLlmProviderRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockLlmProviderRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationID mocks base method.
+func (m *MockLlmProviderRepository) GetByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByApplicationID indicates an expected call of GetByApplicationID.
+func (mr *MockLlmProviderRepositoryMockRecorder) GetByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationID", reflect.TypeOf((*MockLlmProviderRepository)(nil).GetByApplicationID), ctx, applicationID)
+}
+
+// GetByID mocks base method.
+func (m *MockLlmProviderRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLlmProviderRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLlmProviderRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockLlmProviderRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockLlmProviderRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockLlmProviderRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockLlmProviderRepository) ListAll(ctx context.Context) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockLlmProviderRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockLlmProviderRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockLlmProviderRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockLlmProviderRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockLlmProviderRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockLlmProviderRepository) Query(ctx context.Context, query *models.ApplicationLlmProvider) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockLlmProviderRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockLlmProviderRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockLlmProviderRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockLlmProviderRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockLlmProviderRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockLlmProviderRepository) Save(ctx context.Context, entity *models.ApplicationLlmProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockLlmProviderRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLlmProviderRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockLlmProviderRepository) Update(ctx context.Context, entity *models.ApplicationLlmProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLlmProviderRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLlmProviderRepository)(nil).Update), ctx, entity)
+}
+
+// UpdateWithVersion mocks base method.
+func (m *MockLlmProviderRepository) UpdateWithVersion(ctx context.Context, llmProvider *models.ApplicationLlmProvider, expectedVersion int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithVersion", ctx, llmProvider, expectedVersion)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWithVersion indicates an expected call of UpdateWithVersion.
+func (mr *MockLlmProviderRepositoryMockRecorder) UpdateWithVersion(ctx, llmProvider, expectedVersion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithVersion", reflect.TypeOf((*MockLlmProviderRepository)(nil).UpdateWithVersion), ctx, llmProvider, expectedVersion)
+}
+
+// MockServiceUserRepository is a mock of ServiceUserRepository interface.
+type MockServiceUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceUserRepositoryMockRecorder is the mock recorder for MockServiceUserRepository.
+type MockServiceUserRepositoryMockRecorder struct {
+	mock *MockServiceUserRepository
+}
+
+// NewMockServiceUserRepository creates a new mock instance.
+func NewMockServiceUserRepository(ctrl *gomock.Controller) *MockServiceUserRepository {
+	mock := &MockServiceUserRepository{ctrl: ctrl}
+	mock.recorder = &MockServiceUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceUserRepository) EXPECT() *MockServiceUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockServiceUserRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockServiceUserRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockServiceUserRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockServiceUserRepository) Create(ctx context.Context, entity *models.ServiceUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceUserRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceUserRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockServiceUserRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockServiceUserRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockServiceUserRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByApplicationIDWithPagination mocks base method.
+func (m *MockServiceUserRepository) GetByApplicationIDWithPagination(ctx context.Context, applicationID uuid.UUID, keyword string, page, pageSize int) ([]*models.ServiceUser, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByApplicationIDWithPagination", ctx, applicationID, keyword, page, pageSize)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByApplicationIDWithPagination indicates an expected call of GetByApplicationIDWithPagination.
+func (mr *MockServiceUserRepositoryMockRecorder) GetByApplicationIDWithPagination(ctx, applicationID, keyword, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByApplicationIDWithPagination", reflect.TypeOf((*MockServiceUserRepository)(nil).GetByApplicationIDWithPagination), ctx, applicationID, keyword, page, pageSize)
+}
+
+// GetByID mocks base method.
+func (m *MockServiceUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockServiceUserRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByServiceUserID mocks base method.
+func (m *MockServiceUserRepository) GetByServiceUserID(ctx context.Context, applicationID uuid.UUID, serviceUserID string) (*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByServiceUserID", ctx, applicationID, serviceUserID)
+	ret0, _ := ret[0].(*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByServiceUserID indicates an expected call of GetByServiceUserID.
+func (mr *MockServiceUserRepositoryMockRecorder) GetByServiceUserID(ctx, applicationID, serviceUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByServiceUserID", reflect.TypeOf((*MockServiceUserRepository)(nil).GetByServiceUserID), ctx, applicationID, serviceUserID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockServiceUserRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockServiceUserRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockServiceUserRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockServiceUserRepository) ListAll(ctx context.Context) ([]*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockServiceUserRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockServiceUserRepository)(nil).ListAll), ctx)
+}
+
+// ListByServiceUserIDs mocks base method.
+func (m *MockServiceUserRepository) ListByServiceUserIDs(ctx context.Context, applicationID uuid.UUID, serviceUserIDs []string) ([]*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByServiceUserIDs", ctx, applicationID, serviceUserIDs)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByServiceUserIDs indicates an expected call of ListByServiceUserIDs.
+func (mr *MockServiceUserRepositoryMockRecorder) ListByServiceUserIDs(ctx, applicationID, serviceUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByServiceUserIDs", reflect.TypeOf((*MockServiceUserRepository)(nil).ListByServiceUserIDs), ctx, applicationID, serviceUserIDs)
+}
+
+// ListPage mocks base method.
+func (m *MockServiceUserRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockServiceUserRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockServiceUserRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockServiceUserRepository) Query(ctx context.Context, query *models.ServiceUser) ([]*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockServiceUserRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockServiceUserRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockServiceUserRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockServiceUserRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockServiceUserRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockServiceUserRepository) Save(ctx context.Context, entity *models.ServiceUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockServiceUserRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockServiceUserRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockServiceUserRepository) Update(ctx context.Context, entity *models.ServiceUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceUserRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockServiceUserRepository)(nil).Update), ctx, entity)
+}
+
+// MockSystemUserActionTokenRepository is a mock of SystemUserActionTokenRepository interface.
+type MockSystemUserActionTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemUserActionTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemUserActionTokenRepositoryMockRecorder is the mock recorder for MockSystemUserActionTokenRepository.
+type MockSystemUserActionTokenRepositoryMockRecorder struct {
+	mock *MockSystemUserActionTokenRepository
+}
+
+// NewMockSystemUserActionTokenRepository creates a new mock instance.
+func NewMockSystemUserActionTokenRepository(ctrl *gomock.Controller) *MockSystemUserActionTokenRepository {
+	mock := &MockSystemUserActionTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockSystemUserActionTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemUserActionTokenRepository) EXPECT() *MockSystemUserActionTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSystemUserActionTokenRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockSystemUserActionTokenRepository) Create(ctx context.Context, entity *models.SystemUserActionToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSystemUserActionTokenRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockSystemUserActionTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) DeleteByUserID(ctx, userID, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).DeleteByUserID), ctx, userID, purpose)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSystemUserActionTokenRepository) DeleteExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).DeleteExpired), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockSystemUserActionTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SystemUserActionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SystemUserActionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockSystemUserActionTokenRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockSystemUserActionTokenRepository) ListAll(ctx context.Context) ([]*models.SystemUserActionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.SystemUserActionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockSystemUserActionTokenRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.SystemUserActionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserActionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockSystemUserActionTokenRepository) Query(ctx context.Context, query *models.SystemUserActionToken) ([]*models.SystemUserActionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserActionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockSystemUserActionTokenRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.SystemUserActionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.SystemUserActionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockSystemUserActionTokenRepository) Save(ctx context.Context, entity *models.SystemUserActionToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).Save), ctx, entity)
+}
+
+// TakeByTokenHash mocks base method.
+func (m *MockSystemUserActionTokenRepository) TakeByTokenHash(ctx context.Context, purpose, tokenHash string) (*models.SystemUserActionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeByTokenHash", ctx, purpose, tokenHash)
+	ret0, _ := ret[0].(*models.SystemUserActionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeByTokenHash indicates an expected call of TakeByTokenHash.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) TakeByTokenHash(ctx, purpose, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeByTokenHash", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).TakeByTokenHash), ctx, purpose, tokenHash)
+}
+
+// Update mocks base method.
+func (m *MockSystemUserActionTokenRepository) Update(ctx context.Context, entity *models.SystemUserActionToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSystemUserActionTokenRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSystemUserActionTokenRepository)(nil).Update), ctx, entity)
+}
+
+// MockSystemUserIdentityRepository is a mock of SystemUserIdentityRepository interface.
+type MockSystemUserIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemUserIdentityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemUserIdentityRepositoryMockRecorder is the mock recorder for MockSystemUserIdentityRepository.
+type MockSystemUserIdentityRepositoryMockRecorder struct {
+	mock *MockSystemUserIdentityRepository
+}
+
+// NewMockSystemUserIdentityRepository creates a new mock instance.
+func NewMockSystemUserIdentityRepository(ctrl *gomock.Controller) *MockSystemUserIdentityRepository {
+	mock := &MockSystemUserIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockSystemUserIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemUserIdentityRepository) EXPECT() *MockSystemUserIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSystemUserIdentityRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockSystemUserIdentityRepository) Create(ctx context.Context, entity *models.SystemUserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSystemUserIdentityRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockSystemUserIdentityRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockSystemUserIdentityRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SystemUserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SystemUserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySubject mocks base method.
+func (m *MockSystemUserIdentityRepository) GetBySubject(ctx context.Context, issuer, subject string) (*models.SystemUserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySubject", ctx, issuer, subject)
+	ret0, _ := ret[0].(*models.SystemUserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySubject indicates an expected call of GetBySubject.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) GetBySubject(ctx, issuer, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySubject", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).GetBySubject), ctx, issuer, subject)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockSystemUserIdentityRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockSystemUserIdentityRepository) ListAll(ctx context.Context) ([]*models.SystemUserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.SystemUserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockSystemUserIdentityRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.SystemUserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockSystemUserIdentityRepository) Query(ctx context.Context, query *models.SystemUserIdentity) ([]*models.SystemUserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockSystemUserIdentityRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.SystemUserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.SystemUserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockSystemUserIdentityRepository) Save(ctx context.Context, entity *models.SystemUserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockSystemUserIdentityRepository) Update(ctx context.Context, entity *models.SystemUserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSystemUserIdentityRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSystemUserIdentityRepository)(nil).Update), ctx, entity)
+}
+
+// MockSystemUserOidcLoginRepository is a mock of SystemUserOidcLoginRepository interface.
+type MockSystemUserOidcLoginRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemUserOidcLoginRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemUserOidcLoginRepositoryMockRecorder is the mock recorder for MockSystemUserOidcLoginRepository.
+type MockSystemUserOidcLoginRepositoryMockRecorder struct {
+	mock *MockSystemUserOidcLoginRepository
+}
+
+// NewMockSystemUserOidcLoginRepository creates a new mock instance.
+func NewMockSystemUserOidcLoginRepository(ctrl *gomock.Controller) *MockSystemUserOidcLoginRepository {
+	mock := &MockSystemUserOidcLoginRepository{ctrl: ctrl}
+	mock.recorder = &MockSystemUserOidcLoginRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemUserOidcLoginRepository) EXPECT() *MockSystemUserOidcLoginRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSystemUserOidcLoginRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockSystemUserOidcLoginRepository) Create(ctx context.Context, entity *models.SystemUserOidcLogin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSystemUserOidcLoginRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSystemUserOidcLoginRepository) DeleteExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).DeleteExpired), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockSystemUserOidcLoginRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SystemUserOidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SystemUserOidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockSystemUserOidcLoginRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockSystemUserOidcLoginRepository) ListAll(ctx context.Context) ([]*models.SystemUserOidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.SystemUserOidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockSystemUserOidcLoginRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.SystemUserOidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserOidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockSystemUserOidcLoginRepository) Query(ctx context.Context, query *models.SystemUserOidcLogin) ([]*models.SystemUserOidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserOidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockSystemUserOidcLoginRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.SystemUserOidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.SystemUserOidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockSystemUserOidcLoginRepository) Save(ctx context.Context, entity *models.SystemUserOidcLogin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).Save), ctx, entity)
+}
+
+// TakeByState mocks base method.
+func (m *MockSystemUserOidcLoginRepository) TakeByState(ctx context.Context, state string) (*models.SystemUserOidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeByState", ctx, state)
+	ret0, _ := ret[0].(*models.SystemUserOidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeByState indicates an expected call of TakeByState.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) TakeByState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeByState", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).TakeByState), ctx, state)
+}
+
+// Update mocks base method.
+func (m *MockSystemUserOidcLoginRepository) Update(ctx context.Context, entity *models.SystemUserOidcLogin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSystemUserOidcLoginRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSystemUserOidcLoginRepository)(nil).Update), ctx, entity)
+}
+
+// MockSystemUserRepository is a mock of SystemUserRepository interface.
+type MockSystemUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemUserRepositoryMockRecorder is the mock recorder for MockSystemUserRepository.
+type MockSystemUserRepositoryMockRecorder struct {
+	mock *MockSystemUserRepository
+}
+
+// NewMockSystemUserRepository creates a new mock instance.
+func NewMockSystemUserRepository(ctrl *gomock.Controller) *MockSystemUserRepository {
+	mock := &MockSystemUserRepository{ctrl: ctrl}
+	mock.recorder = &MockSystemUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemUserRepository) EXPECT() *MockSystemUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSystemUserRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSystemUserRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSystemUserRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockSystemUserRepository) Create(ctx context.Context, entity *models.SystemUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSystemUserRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSystemUserRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSystemUserRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSystemUserRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSystemUserRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByEmail mocks base method.
+func (m *MockSystemUserRepository) GetByEmail(ctx context.Context, email string) (*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockSystemUserRepositoryMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockSystemUserRepository)(nil).GetByEmail), ctx, email)
+}
+
+// GetByID mocks base method.
+func (m *MockSystemUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSystemUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSystemUserRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByNumber mocks base method.
+func (m *MockSystemUserRepository) GetByNumber(ctx context.Context, number string) (*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNumber", ctx, number)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNumber indicates an expected call of GetByNumber.
+func (mr *MockSystemUserRepositoryMockRecorder) GetByNumber(ctx, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNumber", reflect.TypeOf((*MockSystemUserRepository)(nil).GetByNumber), ctx, number)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockSystemUserRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockSystemUserRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockSystemUserRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockSystemUserRepository) ListAll(ctx context.Context) ([]*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockSystemUserRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockSystemUserRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockSystemUserRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockSystemUserRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockSystemUserRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockSystemUserRepository) Query(ctx context.Context, query *models.SystemUser) ([]*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSystemUserRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSystemUserRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockSystemUserRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockSystemUserRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockSystemUserRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockSystemUserRepository) Save(ctx context.Context, entity *models.SystemUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSystemUserRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSystemUserRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockSystemUserRepository) Update(ctx context.Context, entity *models.SystemUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSystemUserRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSystemUserRepository)(nil).Update), ctx, entity)
+}
+
+// MockSystemUserSessionRepository is a mock of SystemUserSessionRepository interface.
+type MockSystemUserSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSystemUserSessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSystemUserSessionRepositoryMockRecorder is the mock recorder for MockSystemUserSessionRepository.
+type MockSystemUserSessionRepositoryMockRecorder struct {
+	mock *MockSystemUserSessionRepository
+}
+
+// NewMockSystemUserSessionRepository creates a new mock instance.
+func NewMockSystemUserSessionRepository(ctrl *gomock.Controller) *MockSystemUserSessionRepository {
+	mock := &MockSystemUserSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSystemUserSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSystemUserSessionRepository) EXPECT() *MockSystemUserSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSystemUserSessionRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).Count), ctx, conditions)
+}
+
+// Create mocks base method.
+func (m *MockSystemUserSessionRepository) Create(ctx context.Context, entity *models.SystemUserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSystemUserSessionRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).DeleteByID), ctx, id)
+}
+
+// DeleteByUserID mocks base method.
+func (m *MockSystemUserSessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) DeleteByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).DeleteByUserID), ctx, userID)
+}
+
+// DeleteByUserIDExcept mocks base method.
+func (m *MockSystemUserSessionRepository) DeleteByUserIDExcept(ctx context.Context, userID, keepID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIDExcept", ctx, userID, keepID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUserIDExcept indicates an expected call of DeleteByUserIDExcept.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) DeleteByUserIDExcept(ctx, userID, keepID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDExcept", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).DeleteByUserIDExcept), ctx, userID, keepID)
+}
+
+// DeleteExpiredSessions mocks base method.
+func (m *MockSystemUserSessionRepository) DeleteExpiredSessions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSessions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredSessions indicates an expected call of DeleteExpiredSessions.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) DeleteExpiredSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).DeleteExpiredSessions), ctx)
+}
+
+// GetActiveByUserID mocks base method.
+func (m *MockSystemUserSessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByUserID indicates an expected call of GetActiveByUserID.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) GetActiveByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByUserID", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).GetActiveByUserID), ctx, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockSystemUserSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByRefreshTokenHash mocks base method.
+func (m *MockSystemUserSessionRepository) GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRefreshTokenHash", ctx, refreshTokenHash)
+	ret0, _ := ret[0].(*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRefreshTokenHash indicates an expected call of GetByRefreshTokenHash.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) GetByRefreshTokenHash(ctx, refreshTokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRefreshTokenHash", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).GetByRefreshTokenHash), ctx, refreshTokenHash)
+}
+
+// GetByToken mocks base method.
+func (m *MockSystemUserSessionRepository) GetByToken(ctx context.Context, token string) (*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", ctx, token)
+	ret0, _ := ret[0].(*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) GetByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).GetByToken), ctx, token)
+}
+
+// GetByUserID mocks base method.
+func (m *MockSystemUserSessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) GetByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).GetByUserID), ctx, userID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockSystemUserSessionRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockSystemUserSessionRepository) ListAll(ctx context.Context) ([]*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).ListAll), ctx)
+}
+
+// ListPage mocks base method.
+func (m *MockSystemUserSessionRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockSystemUserSessionRepository) Query(ctx context.Context, query *models.SystemUserSession) ([]*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockSystemUserSessionRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// RotateTokens mocks base method.
+func (m *MockSystemUserSessionRepository) RotateTokens(ctx context.Context, session *models.SystemUserSession, oldRefreshTokenHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateTokens", ctx, session, oldRefreshTokenHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateTokens indicates an expected call of RotateTokens.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) RotateTokens(ctx, session, oldRefreshTokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateTokens", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).RotateTokens), ctx, session, oldRefreshTokenHash)
+}
+
+// Save mocks base method.
+func (m *MockSystemUserSessionRepository) Save(ctx context.Context, entity *models.SystemUserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockSystemUserSessionRepository) Update(ctx context.Context, entity *models.SystemUserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSystemUserSessionRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSystemUserSessionRepository)(nil).Update), ctx, entity)
+}
+
+// MockWorkspaceUploadRepository is a mock of WorkspaceUploadRepository interface.
+type MockWorkspaceUploadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceUploadRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceUploadRepositoryMockRecorder is the mock recorder for MockWorkspaceUploadRepository.
+type MockWorkspaceUploadRepositoryMockRecorder struct {
+	mock *MockWorkspaceUploadRepository
+}
+
+// NewMockWorkspaceUploadRepository creates a new mock instance.
+func NewMockWorkspaceUploadRepository(ctrl *gomock.Controller) *MockWorkspaceUploadRepository {
+	mock := &MockWorkspaceUploadRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceUploadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceUploadRepository) EXPECT() *MockWorkspaceUploadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockWorkspaceUploadRepository) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conditions)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) Count(ctx, conditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).Count), ctx, conditions)
+}
+
+// CountFileReferences mocks base method.
+func (m *MockWorkspaceUploadRepository) CountFileReferences(ctx context.Context, filePath string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFileReferences", ctx, filePath)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFileReferences indicates an expected call of CountFileReferences.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) CountFileReferences(ctx, filePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFileReferences", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).CountFileReferences), ctx, filePath)
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceUploadRepository) Create(ctx context.Context, entity *models.WorkspaceUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).Create), ctx, entity)
+}
+
+// DeleteByID mocks base method.
+func (m *MockWorkspaceUploadRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).DeleteByID), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWorkspaceUploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WorkspaceUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.WorkspaceUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).GetByID), ctx, id)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockWorkspaceUploadRepository) HardDeleteByID(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) HardDeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).HardDeleteByID), ctx, id)
+}
+
+// ListAll mocks base method.
+func (m *MockWorkspaceUploadRepository) ListAll(ctx context.Context) ([]*models.WorkspaceUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", ctx)
+	ret0, _ := ret[0].([]*models.WorkspaceUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) ListAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).ListAll), ctx)
+}
+
+// ListCreatedBefore mocks base method.
+func (m *MockWorkspaceUploadRepository) ListCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*models.WorkspaceUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCreatedBefore", ctx, before, limit)
+	ret0, _ := ret[0].([]*models.WorkspaceUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCreatedBefore indicates an expected call of ListCreatedBefore.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) ListCreatedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCreatedBefore", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).ListCreatedBefore), ctx, before, limit)
+}
+
+// ListPage mocks base method.
+func (m *MockWorkspaceUploadRepository) ListPage(ctx context.Context, query *base.PageQuery) ([]*models.WorkspaceUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, query)
+	ret0, _ := ret[0].([]*models.WorkspaceUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) ListPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).ListPage), ctx, query)
+}
+
+// Query mocks base method.
+func (m *MockWorkspaceUploadRepository) Query(ctx context.Context, query *models.WorkspaceUpload) ([]*models.WorkspaceUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].([]*models.WorkspaceUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) Query(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).Query), ctx, query)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockWorkspaceUploadRepository) QueryBySpec(ctx context.Context, spec *base.QuerySpec) ([]*models.WorkspaceUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].([]*models.WorkspaceUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).QueryBySpec), ctx, spec)
+}
+
+// Save mocks base method.
+func (m *MockWorkspaceUploadRepository) Save(ctx context.Context, entity *models.WorkspaceUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) Save(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).Save), ctx, entity)
+}
+
+// Update mocks base method.
+func (m *MockWorkspaceUploadRepository) Update(ctx context.Context, entity *models.WorkspaceUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWorkspaceUploadRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWorkspaceUploadRepository)(nil).Update), ctx, entity)
+}
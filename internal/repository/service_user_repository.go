@@ -0,0 +1,102 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceUserRepository ServiceUser 数据访问层接口
+// 定义了 ServiceUser 模型的所有数据操作接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type ServiceUserRepository interface {
+	base.BaseRepository[models.ServiceUser] // 继承基础仓库接口
+
+	// GetByServiceUserID 根据应用ID和业务侧用户ID获取业务侧用户
+	GetByServiceUserID(ctx context.Context, applicationID uuid.UUID, serviceUserID string) (*models.ServiceUser, error)
+
+	// ListByServiceUserIDs 根据应用ID和业务侧用户ID列表批量获取业务侧用户
+	ListByServiceUserIDs(ctx context.Context, applicationID uuid.UUID, serviceUserIDs []string) ([]*models.ServiceUser, error)
+
+	// GetByApplicationIDWithPagination 根据应用ID获取业务侧用户列表（分页）
+	GetByApplicationIDWithPagination(ctx context.Context, applicationID uuid.UUID, keyword string, page, pageSize int) ([]*models.ServiceUser, int64, error)
+}
+
+// serviceUserRepository ServiceUser 数据访问层实现
+// 实现了 ServiceUserRepository 接口的所有方法
+// 通过组合 baseRepository 来复用基础功能
+type serviceUserRepository struct {
+	base.BaseRepository[models.ServiceUser]          // 组合基础仓库实现
+	db                                      *gorm.DB // 数据库连接
+}
+
+// NewServiceUserRepository 创建 ServiceUser Repository 实例
+// 返回 ServiceUserRepository 接口的实现
+// 参数：db - GORM 数据库连接实例
+func NewServiceUserRepository(db *gorm.DB) ServiceUserRepository {
+	return &serviceUserRepository{
+		BaseRepository: base.NewBaseRepository[models.ServiceUser](db),
+		db:             db,
+	}
+}
+
+// GetByServiceUserID 根据应用ID和业务侧用户ID获取业务侧用户
+// 参数：ctx - 上下文，applicationID - 应用ID，serviceUserID - 业务侧用户ID
+// 返回：业务侧用户和错误信息，不存在时返回 gorm.ErrRecordNotFound
+func (r *serviceUserRepository) GetByServiceUserID(ctx context.Context, applicationID uuid.UUID, serviceUserID string) (*models.ServiceUser, error) {
+	var serviceUser models.ServiceUser
+	if err := r.db.WithContext(ctx).
+		Where("application_id = ? AND service_user_id = ?", applicationID, serviceUserID).
+		First(&serviceUser).Error; err != nil {
+		return nil, err
+	}
+	return &serviceUser, nil
+}
+
+// ListByServiceUserIDs 根据应用ID和业务侧用户ID列表批量获取业务侧用户
+// 参数：ctx - 上下文，applicationID - 应用ID，serviceUserIDs - 业务侧用户ID列表
+// 返回：业务侧用户列表和错误信息，未登记的用户不包含在结果中
+func (r *serviceUserRepository) ListByServiceUserIDs(ctx context.Context, applicationID uuid.UUID, serviceUserIDs []string) ([]*models.ServiceUser, error) {
+	var serviceUsers []*models.ServiceUser
+	if len(serviceUserIDs) == 0 {
+		return serviceUsers, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("application_id = ? AND service_user_id IN ?", applicationID, serviceUserIDs).
+		Find(&serviceUsers).Error
+	return serviceUsers, err
+}
+
+// GetByApplicationIDWithPagination 根据应用ID获取业务侧用户列表（分页）
+// keyword 不为空时按业务侧用户ID或展示名称模糊匹配
+// 配置了只读副本时在副本执行，结果可能因复制延迟略有滞后
+// 参数：ctx - 上下文，applicationID - 应用ID，keyword - 搜索关键字，page - 页码（从1开始），pageSize - 每页大小
+// 返回：业务侧用户列表、总数量和错误信息
+func (r *serviceUserRepository) GetByApplicationIDWithPagination(ctx context.Context, applicationID uuid.UUID, keyword string, page, pageSize int) ([]*models.ServiceUser, int64, error) {
+	var serviceUsers []*models.ServiceUser
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(base.UseReadReplica).Model(&models.ServiceUser{}).Where("application_id = ?", applicationID)
+	if keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("service_user_id LIKE ? OR display_name LIKE ?", like, like)
+	}
+
+	// 获取总数
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取分页数据
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Order("created_at DESC").Find(&serviceUsers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return serviceUsers, total, nil
+}
@@ -0,0 +1,92 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SystemUserActionTokenRepository 系统用户一次性令牌 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type SystemUserActionTokenRepository interface {
+	base.BaseRepository[models.SystemUserActionToken] // 继承基础仓库接口
+
+	// TakeByTokenHash 根据令牌摘要和用途取出令牌并物理删除，不存在时返回 nil
+	TakeByTokenHash(ctx context.Context, purpose, tokenHash string) (*models.SystemUserActionToken, error)
+
+	// DeleteByUserID 物理删除用户指定用途的令牌，purpose 为空时删除全部用途
+	DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error
+
+	// DeleteExpired 物理删除已过期的令牌
+	DeleteExpired(ctx context.Context) error
+}
+
+// systemUserActionTokenRepository 系统用户一次性令牌 数据访问层实现
+type systemUserActionTokenRepository struct {
+	base.BaseRepository[models.SystemUserActionToken]          // 组合基础仓库实现
+	db                                                *gorm.DB // 数据库连接
+}
+
+// NewSystemUserActionTokenRepository 创建 系统用户一次性令牌 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewSystemUserActionTokenRepository(db *gorm.DB) SystemUserActionTokenRepository {
+	return &systemUserActionTokenRepository{
+		BaseRepository: base.NewBaseRepository[models.SystemUserActionToken](db),
+		db:             db,
+	}
+}
+
+// TakeByTokenHash 根据令牌摘要和用途取出令牌并物理删除
+// 只有删除成功的令牌才返回，同一令牌的并发请求只有一个能取到
+// 参数：ctx - 上下文，purpose - 令牌用途，tokenHash - 令牌摘要
+// 返回：令牌（不存在时为 nil）和错误信息
+func (r *systemUserActionTokenRepository) TakeByTokenHash(ctx context.Context, purpose, tokenHash string) (*models.SystemUserActionToken, error) {
+	if tokenHash == "" {
+		return nil, nil
+	}
+	var token models.SystemUserActionToken
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND purpose = ?", tokenHash, purpose).
+		First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ?", token.ID).Delete(&models.SystemUserActionToken{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// DeleteByUserID 物理删除用户指定用途的令牌
+// 参数：ctx - 上下文，userID - 系统用户ID，purpose - 令牌用途，为空时删除全部用途
+// 返回：错误信息
+func (r *systemUserActionTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose string) error {
+	query := r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID)
+	if purpose != "" {
+		query = query.Where("purpose = ?", purpose)
+	}
+	return query.Delete(&models.SystemUserActionToken{}).Error
+}
+
+// DeleteExpired 物理删除已过期的令牌
+// 参数：ctx - 上下文
+// 返回：错误信息
+func (r *systemUserActionTokenRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("expired_at < ?", time.Now()).
+		Delete(&models.SystemUserActionToken{}).Error
+}
@@ -0,0 +1,67 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SystemUserIdentityRepository 系统用户外部身份 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type SystemUserIdentityRepository interface {
+	base.BaseRepository[models.SystemUserIdentity] // 继承基础仓库接口
+
+	// GetBySubject 根据身份提供方和用户标识获取外部身份，不存在时返回 nil
+	GetBySubject(ctx context.Context, issuer, subject string) (*models.SystemUserIdentity, error)
+
+	// DeleteByUserID 物理删除系统用户关联的全部外部身份
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// systemUserIdentityRepository 系统用户外部身份 数据访问层实现
+type systemUserIdentityRepository struct {
+	base.BaseRepository[models.SystemUserIdentity]          // 组合基础仓库实现
+	db                                             *gorm.DB // 数据库连接
+}
+
+// NewSystemUserIdentityRepository 创建 系统用户外部身份 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewSystemUserIdentityRepository(db *gorm.DB) SystemUserIdentityRepository {
+	return &systemUserIdentityRepository{
+		BaseRepository: base.NewBaseRepository[models.SystemUserIdentity](db),
+		db:             db,
+	}
+}
+
+// GetBySubject 根据身份提供方和用户标识获取外部身份
+// 参数：ctx - 上下文，issuer - 身份提供方 Issuer，subject - 用户标识 sub
+// 返回：外部身份（不存在时为 nil）和错误信息
+func (r *systemUserIdentityRepository) GetBySubject(ctx context.Context, issuer, subject string) (*models.SystemUserIdentity, error) {
+	var identity models.SystemUserIdentity
+	err := r.db.WithContext(ctx).
+		Where("issuer = ? AND subject = ?", issuer, subject).
+		First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// DeleteByUserID 物理删除系统用户关联的全部外部身份
+// 外部身份按 Issuer 和 sub 唯一，物理删除避免软删除的记录占用唯一索引
+// 参数：ctx - 上下文，userID - 系统用户ID
+// 返回：错误信息
+func (r *systemUserIdentityRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&models.SystemUserIdentity{}).Error
+}
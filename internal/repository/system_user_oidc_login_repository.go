@@ -0,0 +1,75 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SystemUserOidcLoginRepository 进行中的 OIDC 单点登录请求 数据访问层接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type SystemUserOidcLoginRepository interface {
+	base.BaseRepository[models.SystemUserOidcLogin] // 继承基础仓库接口
+
+	// TakeByState 根据 state 取出登录请求并物理删除，不存在时返回 nil
+	TakeByState(ctx context.Context, state string) (*models.SystemUserOidcLogin, error)
+
+	// DeleteExpired 物理删除已过期的登录请求
+	DeleteExpired(ctx context.Context) error
+}
+
+// systemUserOidcLoginRepository 进行中的 OIDC 单点登录请求 数据访问层实现
+type systemUserOidcLoginRepository struct {
+	base.BaseRepository[models.SystemUserOidcLogin]          // 组合基础仓库实现
+	db                                              *gorm.DB // 数据库连接
+}
+
+// NewSystemUserOidcLoginRepository 创建 OIDC 单点登录请求 Repository 实例
+// 参数：db - GORM 数据库连接实例
+func NewSystemUserOidcLoginRepository(db *gorm.DB) SystemUserOidcLoginRepository {
+	return &systemUserOidcLoginRepository{
+		BaseRepository: base.NewBaseRepository[models.SystemUserOidcLogin](db),
+		db:             db,
+	}
+}
+
+// TakeByState 根据 state 取出登录请求并物理删除
+// 只有删除成功的请求才返回，同一 state 的并发回调只有一个能取到
+// 参数：ctx - 上下文，state - 登录请求的 state
+// 返回：登录请求（不存在时为 nil）和错误信息
+func (r *systemUserOidcLoginRepository) TakeByState(ctx context.Context, state string) (*models.SystemUserOidcLogin, error) {
+	if state == "" {
+		return nil, nil
+	}
+	var login models.SystemUserOidcLogin
+	err := r.db.WithContext(ctx).Where("state = ?", state).First(&login).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ?", login.ID).Delete(&models.SystemUserOidcLogin{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &login, nil
+}
+
+// DeleteExpired 物理删除已过期的登录请求
+// 参数：ctx - 上下文
+// 返回：错误信息
+func (r *systemUserOidcLoginRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("expired_at < ?", time.Now()).
+		Delete(&models.SystemUserOidcLogin{}).Error
+}
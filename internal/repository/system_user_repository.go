@@ -43,7 +43,7 @@ func NewSystemUserRepository(db *gorm.DB) SystemUserRepository {
 // 返回：用户对象和错误信息
 func (r *systemUserRepository) GetByNumber(ctx context.Context, number string) (*models.SystemUser, error) {
 	var user models.SystemUser
-	err := r.db.WithContext(ctx).Where("number = ? AND deleted_at IS NULL", number).First(&user).Error
+	err := r.db.WithContext(ctx).Where("number = ?", number).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func (r *systemUserRepository) GetByNumber(ctx context.Context, number string) (
 // 返回：用户对象和错误信息
 func (r *systemUserRepository) GetByEmail(ctx context.Context, email string) (*models.SystemUser, error) {
 	var user models.SystemUser
-	err := r.db.WithContext(ctx).Where("email = ? AND deleted_at IS NULL", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -4,8 +4,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"lemon-tree-core/internal/base"
 	"lemon-tree-core/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -15,11 +17,17 @@ import (
 // 定义了 SystemUserSession 模型的所有数据操作接口
 // 继承自 BaseRepository，包含基本的增删改查功能
 type SystemUserSessionRepository interface {
-	base.BaseRepository[models.SystemUserSession]                                           // 继承基础仓库接口
-	GetByToken(ctx context.Context, token string) (*models.SystemUserSession, error)        // 根据Token获取会话
-	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SystemUserSession, error) // 根据用户ID获取会话列表
-	DeleteExpiredSessions(ctx context.Context) error                                        // 删除过期会话
-	DeleteByUserID(ctx context.Context, userID uuid.UUID) error                             // 根据用户ID删除所有会话
+	base.BaseRepository[models.SystemUserSession]                                                 // 继承基础仓库接口
+	GetByToken(ctx context.Context, token string) (*models.SystemUserSession, error)              // 根据Token获取会话
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SystemUserSession, error)       // 根据用户ID获取会话列表
+	DeleteExpiredSessions(ctx context.Context) error                                              // 删除过期会话
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error                                   // 根据用户ID删除所有会话
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SystemUserSession, error) // 获取用户未过期的会话列表
+	DeleteByUserIDExcept(ctx context.Context, userID, keepID uuid.UUID) (int64, error)            // 删除用户除指定会话外的全部会话
+	// GetByRefreshTokenHash 根据当前或上一个刷新令牌的摘要获取会话，不存在时返回 nil
+	GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*models.SystemUserSession, error)
+	// RotateTokens 轮换会话的访问令牌和刷新令牌，刷新令牌摘要已被其他请求轮换时返回 false
+	RotateTokens(ctx context.Context, session *models.SystemUserSession, oldRefreshTokenHash string) (bool, error)
 }
 
 // systemUserSessionRepository SystemUserSession 数据访问层实现
@@ -64,11 +72,15 @@ func (r *systemUserSessionRepository) GetByUserID(ctx context.Context, userID uu
 }
 
 // DeleteExpiredSessions 删除过期会话
-// 删除所有已过期的会话记录
+// 物理删除刷新令牌已过期的会话记录（包括已软删除的），过期会话没有保留价值
+// 支持刷新令牌之前创建的会话没有刷新令牌过期时间，以访问令牌过期时间作为会话的过期时间
 // 参数：ctx - 上下文
 // 返回：错误信息
 func (r *systemUserSessionRepository) DeleteExpiredSessions(ctx context.Context) error {
-	return r.db.WithContext(ctx).Where("login_expired_at < NOW()").Delete(&models.SystemUserSession{}).Error
+	now := time.Now()
+	return r.db.WithContext(ctx).Unscoped().
+		Where("refresh_expired_at < ? OR (refresh_expired_at IS NULL AND login_expired_at < ?)", now, now).
+		Delete(&models.SystemUserSession{}).Error
 }
 
 // DeleteByUserID 根据用户ID删除所有会话
@@ -78,3 +90,69 @@ func (r *systemUserSessionRepository) DeleteExpiredSessions(ctx context.Context)
 func (r *systemUserSessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.SystemUserSession{}).Error
 }
+
+// GetActiveByUserID 获取用户未过期的会话列表
+// 访问令牌过期但仍可刷新的会话也属于未过期的会话，按登录时间倒序返回
+// 没有刷新令牌过期时间的旧会话以访问令牌过期时间判断，与 DeleteExpiredSessions 一致
+// 参数：ctx - 上下文，userID - 用户ID
+// 返回：会话列表和错误信息
+func (r *systemUserSessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SystemUserSession, error) {
+	var sessions []*models.SystemUserSession
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("refresh_expired_at > ? OR (refresh_expired_at IS NULL AND login_expired_at > ?)", now, now).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// DeleteByUserIDExcept 删除用户除指定会话外的全部会话
+// 用于“退出其他设备”，保留当前使用的会话
+// 参数：ctx - 上下文，userID - 用户ID，keepID - 保留的会话ID
+// 返回：删除的会话数量和错误信息
+func (r *systemUserSessionRepository) DeleteByUserIDExcept(ctx context.Context, userID, keepID uuid.UUID) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND id <> ?", userID, keepID).
+		Delete(&models.SystemUserSession{})
+	return result.RowsAffected, result.Error
+}
+
+// GetByRefreshTokenHash 根据当前或上一个刷新令牌的摘要获取会话
+// 匹配上一个刷新令牌时由调用方判断为旧令牌被重复使用
+// 参数：ctx - 上下文，refreshTokenHash - 刷新令牌摘要
+// 返回：会话对象（不存在时为 nil）和错误信息
+func (r *systemUserSessionRepository) GetByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (*models.SystemUserSession, error) {
+	if refreshTokenHash == "" {
+		return nil, nil
+	}
+	var session models.SystemUserSession
+	err := r.db.WithContext(ctx).
+		Where("refresh_token_hash = ? OR previous_refresh_token_hash = ?", refreshTokenHash, refreshTokenHash).
+		First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateTokens 轮换会话的访问令牌和刷新令牌
+// 以旧刷新令牌摘要作为条件更新，同一刷新令牌并发刷新时只有一个请求成功
+// 参数：ctx - 上下文，session - 已设置新令牌的会话，oldRefreshTokenHash - 本次使用的刷新令牌摘要
+// 返回：是否轮换成功和错误信息
+func (r *systemUserSessionRepository) RotateTokens(ctx context.Context, session *models.SystemUserSession, oldRefreshTokenHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.SystemUserSession{}).
+		Where("id = ? AND refresh_token_hash = ?", session.ID, oldRefreshTokenHash).
+		Updates(map[string]interface{}{
+			"token":                       session.Token,
+			"login_expired_at":            session.LoginExpiredAt,
+			"refresh_token_hash":          session.RefreshTokenHash,
+			"previous_refresh_token_hash": session.PreviousRefreshTokenHash,
+			"refresh_expired_at":          session.RefreshExpiredAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,64 @@
+package repository_test
+
+import (
+	"context"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/testsupport"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSystemUserSessionRepositoryExpiry(t *testing.T) {
+	db := testsupport.NewDatabase(t)
+	repo := repository.NewSystemUserSessionRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	// 刷新令牌之前创建的旧会话没有刷新令牌过期时间，以访问令牌过期时间判断是否过期
+	sessions := map[string]*models.SystemUserSession{
+		"active":         {LoginExpiredAt: past, RefreshExpiredAt: &future},
+		"expired":        {LoginExpiredAt: past, RefreshExpiredAt: &past},
+		"legacy active":  {LoginExpiredAt: future},
+		"legacy expired": {LoginExpiredAt: past},
+	}
+	for name, session := range sessions {
+		session.Token = name
+		session.UserID = userID
+		createRecord(t, db, session)
+	}
+
+	activeTokens := func() []string {
+		t.Helper()
+		active, err := repo.GetActiveByUserID(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetActiveByUserID() error = %v", err)
+		}
+		var tokens []string
+		for _, session := range active {
+			tokens = append(tokens, session.Token)
+		}
+		slices.Sort(tokens)
+		return tokens
+	}
+	want := []string{"active", "legacy active"}
+	if got := activeTokens(); !slices.Equal(got, want) {
+		t.Errorf("GetActiveByUserID() = %v, want %v", got, want)
+	}
+
+	if err := repo.DeleteExpiredSessions(ctx); err != nil {
+		t.Fatalf("DeleteExpiredSessions() error = %v", err)
+	}
+	var remaining []string
+	if err := db.Unscoped().Model(&models.SystemUserSession{}).Order("token").Pluck("token", &remaining).Error; err != nil {
+		t.Fatalf("查询会话失败: %v", err)
+	}
+	if !slices.Equal(remaining, want) {
+		t.Errorf("sessions after DeleteExpiredSessions() = %v, want %v", remaining, want)
+	}
+}
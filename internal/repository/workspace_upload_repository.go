@@ -0,0 +1,81 @@
+// Package repository 提供数据访问层功能
+// 负责与数据库的交互，实现数据持久化操作
+package repository
+
+import (
+	"context"
+	"lemon-tree-core/internal/base"
+	"lemon-tree-core/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkspaceUploadRepository WorkspaceUpload 数据访问层接口
+// 定义了 WorkspaceUpload 模型的所有数据操作接口
+// 继承自 BaseRepository，包含基本的增删改查功能
+type WorkspaceUploadRepository interface {
+	base.BaseRepository[models.WorkspaceUpload] // 继承基础仓库接口
+
+	// ListCreatedBefore 获取指定时间之前创建的上传记录
+	ListCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*models.WorkspaceUpload, error)
+
+	// CountFileReferences 统计引用指定文件的记录数量
+	// 检查智能体头像和供应商图标
+	CountFileReferences(ctx context.Context, filePath string) (int64, error)
+}
+
+// workspaceUploadRepository WorkspaceUpload 数据访问层实现
+// 实现了 WorkspaceUploadRepository 接口的所有方法
+// 通过组合 baseRepository 来复用基础功能
+type workspaceUploadRepository struct {
+	base.BaseRepository[models.WorkspaceUpload]          // 组合基础仓库实现
+	db                                          *gorm.DB // 数据库连接
+}
+
+// NewWorkspaceUploadRepository 创建 WorkspaceUpload Repository 实例
+// 返回 WorkspaceUploadRepository 接口的实现
+// 参数：db - GORM 数据库连接实例
+func NewWorkspaceUploadRepository(db *gorm.DB) WorkspaceUploadRepository {
+	return &workspaceUploadRepository{
+		BaseRepository: base.NewBaseRepository[models.WorkspaceUpload](db),
+		db:             db,
+	}
+}
+
+// ListCreatedBefore 获取指定时间之前创建的上传记录
+// 参数：ctx - 上下文，before - 截止时间，limit - 最大返回数量
+// 返回：上传记录列表和错误信息
+func (r *workspaceUploadRepository) ListCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*models.WorkspaceUpload, error) {
+	var uploads []*models.WorkspaceUpload
+	err := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
+
+// CountFileReferences 统计引用指定文件的记录数量
+// 记录中保存的可能是相对路径或拼接了域名的完整URL，按后缀匹配；已软删除的记录不计入
+// 参数：ctx - 上下文，filePath - 相对工作区公共目录的文件路径
+// 返回：引用数量和错误信息
+func (r *workspaceUploadRepository) CountFileReferences(ctx context.Context, filePath string) (int64, error) {
+	pattern := "%" + filePath
+
+	var agentCount int64
+	if err := r.db.WithContext(ctx).Model(&models.ChatAgent{}).
+		Where("avatar_url LIKE ?", pattern).
+		Count(&agentCount).Error; err != nil {
+		return 0, err
+	}
+
+	var providerCount int64
+	if err := r.db.WithContext(ctx).Model(&models.ApplicationLlmProvider{}).
+		Where("icon_url LIKE ?", pattern).
+		Count(&providerCount).Error; err != nil {
+		return 0, err
+	}
+
+	return agentCount + providerCount, nil
+}
@@ -19,6 +19,16 @@ func SetupApplicationMcpServerConfigRoutes(api *gin.RouterGroup, handler *handle
 		// 保存应用MCP配置信息，如果存在则更新，不存在则创建
 		applicationMcpServerConfigs.POST("/save", handler.SaveApplicationMcpServerConfig)
 
+		// 从标准 mcpServers JSON 导入MCP配置
+		// POST /api/v1/application-mcp-server-configs/import
+		// 批量创建MCP配置，任一服务无效时不创建任何配置
+		applicationMcpServerConfigs.POST("/import", handler.ImportMcpServers)
+
+		// 导出应用的MCP配置为标准 mcpServers JSON
+		// GET /api/v1/application-mcp-server-configs/application/:applicationId/export
+		// 默认掩码环境变量和请求头的值，mask_secrets=false 时导出原值
+		applicationMcpServerConfigs.GET("/application/:applicationId/export", handler.ExportMcpServers)
+
 		// 删除MCP配置
 		// DELETE /api/v1/application-mcp-server-configs/:id
 		// 删除指定的MCP配置
@@ -38,5 +48,24 @@ func SetupApplicationMcpServerConfigRoutes(api *gin.RouterGroup, handler *handle
 		// POST /api/v1/application-mcp-server-configs/:id/sync-tools
 		// 同步指定MCP服务器的工具列表到数据库
 		applicationMcpServerConfigs.POST("/:id/sync-tools", handler.SyncMcpServerTools)
+
+		// 发起MCP服务的OAuth授权
+		// GET /api/v1/application-mcp-server-configs/:id/oauth/authorize
+		// 重定向到授权服务器的授权页面，redirect=false 时返回授权地址
+		applicationMcpServerConfigs.GET("/:id/oauth/authorize", handler.AuthorizeMcpOauth)
+
+		// OAuth授权回调
+		// GET /api/v1/application-mcp-server-configs/oauth/callback
+		// 授权服务器重定向回来的地址，需与 MCP_OAUTH_REDIRECT_URL 一致
+		applicationMcpServerConfigs.GET("/oauth/callback", handler.McpOauthCallback)
+
+		// 获取MCP配置的OAuth授权状态
+		// GET /api/v1/application-mcp-server-configs/:id/oauth
+		applicationMcpServerConfigs.GET("/:id/oauth", handler.GetMcpOauthStatus)
+
+		// 撤销MCP配置的OAuth授权
+		// DELETE /api/v1/application-mcp-server-configs/:id/oauth
+		// 删除保存的令牌，之后需重新授权
+		applicationMcpServerConfigs.DELETE("/:id/oauth", handler.RevokeMcpOauth)
 	}
 }
@@ -43,8 +43,43 @@ func SetupApplicationRoutes(api *gin.RouterGroup, appHandler *handler.Applicatio
 
 			// 删除应用
 			// DELETE /api/v1/applications/:id
-			// 删除指定的应用（软删除）
+			// 删除指定的应用（软删除），关联数据由后台任务分批删除
 			applications.DELETE("/:id", appHandler.DeleteApplication)
+
+			// 获取应用删除进度
+			// GET /api/v1/applications/:id/deletion
+			// 返回应用最近的删除任务及各步骤已删除的记录数
+			applications.GET("/:id/deletion", appHandler.GetApplicationDeletionJob)
+
+			// 获取应用设置
+			// GET /api/v1/applications/:id/settings
+			// 返回应用的品牌展示信息和数据保留策略，未保存过时返回默认值
+			authenticated.GET("/:id/settings", appHandler.GetApplicationSetting)
+
+			// 保存应用设置
+			// POST /api/v1/applications/:id/settings
+			// 整体覆盖应用设置，不存在则创建
+			authenticated.POST("/:id/settings", appHandler.SaveApplicationSetting)
+
+			// 删除应用设置
+			// DELETE /api/v1/applications/:id/settings
+			// 应用设置恢复为默认值
+			authenticated.DELETE("/:id/settings", appHandler.DeleteApplicationSetting)
+
+			// 获取应用的功能开关
+			// GET /api/v1/applications/:id/feature-flags
+			// 返回全部功能开关对应用的状态和全局灰度比例
+			authenticated.GET("/:id/feature-flags", appHandler.GetApplicationFeatureFlags)
+
+			// 为应用单独设置功能开关
+			// POST /api/v1/applications/:id/feature-flags/:flag
+			// 应用单独设置的值优先于全局灰度比例
+			authenticated.POST("/:id/feature-flags/:flag", appHandler.SaveApplicationFeatureFlag)
+
+			// 删除应用单独设置的功能开关
+			// DELETE /api/v1/applications/:id/feature-flags/:flag
+			// 恢复按全局灰度比例判断
+			authenticated.DELETE("/:id/feature-flags/:flag", appHandler.DeleteApplicationFeatureFlag)
 		}
 	}
 }
@@ -0,0 +1,48 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupApplicationToolBundleRoutes 设置应用工具集模块的路由
+// 参数：api - API 路由组，handler - 应用工具集处理器，userService - 用户服务
+func SetupApplicationToolBundleRoutes(api *gin.RouterGroup, handler *handler.ApplicationToolBundleHandler, userService service.UserService) {
+	toolBundles := api.Group("/application-tool-bundles")
+	toolBundles.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存工具集
+		// POST /api/v1/application-tool-bundles/save
+		// 如果请求中包含ID则更新，否则新增；包含的工具整体替换，对所有关联的智能体生效
+		toolBundles.POST("/save", handler.SaveToolBundle)
+
+		// 删除工具集
+		// DELETE /api/v1/application-tool-bundles/:id
+		toolBundles.DELETE("/:id", handler.DeleteToolBundle)
+
+		// 获取应用的工具集列表
+		// GET /api/v1/application-tool-bundles/application/:applicationId
+		toolBundles.GET("/application/:applicationId", handler.GetToolBundlesByApplicationID)
+
+		// 获取智能体关联的工具集列表
+		// GET /api/v1/application-tool-bundles/chat-agent/:chatAgentId
+		toolBundles.GET("/chat-agent/:chatAgentId", handler.GetToolBundlesByChatAgentID)
+
+		// 获取工具集详情
+		// GET /api/v1/application-tool-bundles/:id
+		toolBundles.GET("/:id", handler.GetToolBundle)
+
+		// 将工具集关联到多个智能体
+		// POST /api/v1/application-tool-bundles/:id/attach
+		toolBundles.POST("/:id/attach", handler.AttachChatAgents)
+
+		// 取消工具集与多个智能体的关联
+		// POST /api/v1/application-tool-bundles/:id/detach
+		toolBundles.POST("/:id/detach", handler.DetachChatAgents)
+	}
+}
@@ -0,0 +1,44 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupApplicationWebhookRoutes 设置应用 Webhook 模块的路由
+// 参数：api - API 路由组，handler - 应用 Webhook 处理器，userService - 用户服务
+func SetupApplicationWebhookRoutes(api *gin.RouterGroup, handler *handler.ApplicationWebhookHandler, userService service.UserService) {
+	webhooks := api.Group("/application-webhooks")
+	webhooks.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存 Webhook
+		// POST /api/v1/application-webhooks/save
+		// 如果请求中包含ID则更新，否则新增并生成签名密钥
+		webhooks.POST("/save", handler.SaveWebhook)
+
+		// 删除 Webhook
+		// DELETE /api/v1/application-webhooks/:id
+		webhooks.DELETE("/:id", handler.DeleteWebhook)
+
+		// 获取应用的 Webhook 列表
+		// GET /api/v1/application-webhooks/application/:applicationId
+		webhooks.GET("/application/:applicationId", handler.GetWebhooksByApplicationID)
+
+		// 重新推送推送记录
+		// POST /api/v1/application-webhooks/deliveries/:deliveryId/retry
+		webhooks.POST("/deliveries/:deliveryId/retry", handler.RetryDelivery)
+
+		// 获取 Webhook 详情
+		// GET /api/v1/application-webhooks/:id
+		webhooks.GET("/:id", handler.GetWebhook)
+
+		// 获取 Webhook 的推送记录
+		// GET /api/v1/application-webhooks/:id/deliveries?status=failed&page=1&page_size=20
+		webhooks.GET("/:id/deliveries", handler.GetDeliveries)
+	}
+}
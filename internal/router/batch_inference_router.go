@@ -0,0 +1,52 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupBatchInferenceRoutes 设置批量推理模块的路由
+// 参数：api - API 路由组，handler - 批量推理处理器，userService - 用户服务，maxUploadSize - 上传接口的请求体大小上限
+func SetupBatchInferenceRoutes(api *gin.RouterGroup, handler *handler.BatchInferenceHandler, userService service.UserService, maxUploadSize int64) {
+	batchInferenceJobs := api.Group("/batch-inference-jobs")
+	batchInferenceJobs.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 创建批量推理任务
+		// POST /api/v1/batch-inference-jobs
+		// 提交智能体和提示词数组，任务在后台异步处理
+		batchInferenceJobs.POST("", handler.CreateJob)
+
+		// 上传提示词文件创建批量推理任务
+		// POST /api/v1/batch-inference-jobs/upload
+		// 支持 .txt、.jsonl、.json 和 .csv 文件
+		batchInferenceJobs.POST("/upload", middleware.BodySizeLimitMiddleware(maxUploadSize), handler.UploadJob)
+
+		// 获取智能体的批量推理任务列表
+		// GET /api/v1/batch-inference-jobs/chat-agent/:chatAgentId
+		batchInferenceJobs.GET("/chat-agent/:chatAgentId", handler.GetJobsByChatAgentID)
+
+		// 获取批量推理任务
+		// GET /api/v1/batch-inference-jobs/:id
+		// 返回任务状态和处理进度
+		batchInferenceJobs.GET("/:id", handler.GetJob)
+
+		// 获取批量推理任务的提示词及处理结果
+		// GET /api/v1/batch-inference-jobs/:id/items
+		batchInferenceJobs.GET("/:id/items", handler.GetJobItems)
+
+		// 下载批量推理任务的结果
+		// GET /api/v1/batch-inference-jobs/:id/download?format=jsonl|csv
+		// 任务结束后才能下载
+		batchInferenceJobs.GET("/:id/download", handler.DownloadJobResults)
+
+		// 取消批量推理任务
+		// POST /api/v1/batch-inference-jobs/:id/cancel
+		// 正在处理的提示词会处理完成，其余提示词不再执行
+		batchInferenceJobs.POST("/:id/cancel", handler.CancelJob)
+	}
+}
@@ -0,0 +1,37 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupChatAgentAnswerRuleRoutes 设置预制答案规则模块的路由
+// 参数：api - API 路由组，handler - 预制答案规则处理器，userService - 用户服务
+func SetupChatAgentAnswerRuleRoutes(api *gin.RouterGroup, handler *handler.ChatAgentAnswerRuleHandler, userService service.UserService) {
+	answerRules := api.Group("/chat-agent-answer-rules")
+	answerRules.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存预制答案规则
+		// POST /api/v1/chat-agent-answer-rules/save
+		// 如果请求中包含ID则更新，否则新增
+		answerRules.POST("/save", handler.SaveAnswerRule)
+
+		// 删除预制答案规则
+		// DELETE /api/v1/chat-agent-answer-rules/:id
+		answerRules.DELETE("/:id", handler.DeleteAnswerRule)
+
+		// 获取智能体的预制答案规则列表
+		// GET /api/v1/chat-agent-answer-rules/chat-agent/:chatAgentId
+		answerRules.GET("/chat-agent/:chatAgentId", handler.GetAnswerRulesByChatAgentID)
+
+		// 测试预制答案规则
+		// POST /api/v1/chat-agent-answer-rules/test
+		// 返回用户消息按优先级命中的第一条已启用规则
+		answerRules.POST("/test", handler.TestAnswerRule)
+	}
+}
@@ -0,0 +1,41 @@
+// Package router 提供路由管理功能
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupChatAgentConversationAdminRoutes 设置管理后台聊天会话模块的路由
+// 与面向业务侧的 /chat 路由不同，使用用户认证，可查看智能体下全部业务侧用户的会话
+// 参数：api - API 路由组，handler - ChatAgentConversation 处理器，monitorHandler - ConversationMonitor 处理器，userService - User 服务
+func SetupChatAgentConversationAdminRoutes(api *gin.RouterGroup, handler *handler.ChatAgentConversationHandler,
+	monitorHandler *handler.ConversationMonitorHandler, userService service.UserService) {
+	// 智能体会话管理路由组
+	chatAgentConversations := api.Group("/chat-agents/:chatAgentID/conversations")
+	chatAgentConversations.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 获取智能体下全部业务侧用户的会话列表（分页）
+		// GET /api/v1/chat-agents/:chatAgentID/conversations
+		// 支持按创建时间、业务侧用户、是否发生错误和token用量筛选
+		chatAgentConversations.GET("", handler.GetAdminConversationList)
+
+		// 实时监控智能体的会话事件
+		// GET /api/v1/chat-agents/:chatAgentID/conversations/events
+		// 以 SSE 格式推送新建会话、消息、工具调用和错误事件
+		chatAgentConversations.GET("/events", monitorHandler.StreamConversationEvents)
+
+		// 导出会话记录
+		// GET /api/v1/chat-agents/:chatAgentID/conversations/:conversationID/export
+		// format=json（默认）返回会话信息和全部消息，format=pdf 下载带智能体名称、头像、消息时间和工具调用摘要的 PDF
+		chatAgentConversations.GET("/:conversationID/export", handler.ExportConversation)
+
+		// 使用修改后的智能体配置回放会话
+		// POST /api/v1/chat-agents/:chatAgentID/conversations/:conversationID/replay
+		// 在沙盒中逐条重新回答用户消息，返回原始回答与新回答的并排对比，不调用工具，也不修改原会话
+		chatAgentConversations.POST("/:conversationID/replay", handler.ReplayConversation)
+	}
+}
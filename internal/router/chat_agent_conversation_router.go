@@ -3,6 +3,7 @@
 package router
 
 import (
+	"lemon-tree-core/internal/config"
 	"lemon-tree-core/internal/handler"
 	"lemon-tree-core/internal/middleware"
 	"lemon-tree-core/internal/service"
@@ -12,13 +13,35 @@ import (
 
 // SetupChatAgentConversationRoutes 设置聊天会话模块的路由
 // 配置 ChatAgentConversation 相关的所有 HTTP 路由
-// 参数：api - API 路由组，handler - ChatAgentConversation 处理器，chatAgentService - ChatAgent 服务
+// 参数：api - API 路由组，handler - ChatAgentConversation 处理器，chatAgentService - ChatAgent 服务，applicationService - Application 服务，widgetTokenService - 嵌入式聊天窗口令牌服务，widgetConfig - 嵌入式聊天窗口配置，maxUploadSize - 上传接口的请求体大小上限
 func SetupChatAgentConversationRoutes(api *gin.RouterGroup, handler *handler.ChatAgentConversationHandler,
-	chatAgentService service.ChatAgentService, applicationService service.ApplicationService) {
+	chatAgentService service.ChatAgentService, applicationService service.ApplicationService,
+	widgetTokenService service.ChatWidgetTokenService, widgetConfig config.ChatWidgetConfig, maxUploadSize int64) {
+	// 仅业务服务端可调用的路由组，只接受ApiKey认证
+	chatServer := api.Group("/chat")
+	chatServer.Use(middleware.ChatAgentAuthMiddleware(chatAgentService, applicationService))
+	{
+		// 创建嵌入式聊天窗口令牌
+		// POST /api/v1/chat/widget-token
+		// 使用ApiKey换取只能从指定来源使用的短期令牌，供网页中的聊天窗口直接调用聊天接口
+		chatServer.POST("/widget-token", handler.CreateWidgetToken)
+	}
+
 	// 聊天会话路由组
+	// 同时接受ApiKey和窗口令牌认证
 	chatAgentConversations := api.Group("/chat")
-	chatAgentConversations.Use(middleware.ChatAgentAuthMiddleware(chatAgentService, applicationService))
+	chatAgentConversations.Use(middleware.ChatWidgetAuthMiddleware(chatAgentService, applicationService, widgetTokenService, widgetConfig))
 	{
+		// 获取CSRF令牌
+		// GET /api/v1/chat/csrf-token
+		// 令牌与窗口令牌绑定，使用窗口令牌发起修改数据的请求时通过 X-CSRF-Token 请求头回传
+		chatAgentConversations.GET("/csrf-token", handler.GetCSRFToken)
+
+		// 获取聊天首屏信息
+		// GET /api/v1/chat-agent-conversations/bootstrap
+		// 获取智能体信息、欢迎语、推荐问题、当前是否可用和应用品牌展示信息
+		chatAgentConversations.GET("/bootstrap", handler.GetChatBootstrap)
+
 		// 获取会话列表
 		// GET /api/v1/chat-agent-conversations/conversation-list
 		// 获取指定智能体的会话列表
@@ -29,6 +52,16 @@ func SetupChatAgentConversationRoutes(api *gin.RouterGroup, handler *handler.Cha
 		// 获取指定会话的消息列表
 		chatAgentConversations.GET("/message-list", handler.GetChatMessageList)
 
+		// 根据请求ID获取消息列表
+		// GET /api/v1/chat-agent-conversations/message-by-request-id
+		// 获取指定会话中同一请求ID产生的全部消息
+		chatAgentConversations.GET("/message-by-request-id", handler.GetChatMessageListByRequestID)
+
+		// 上报消息送达和已读状态
+		// POST /api/v1/chat-agent-conversations/message-receipts
+		// 标记助手回复已送达或已读，多端通过消息列表中的送达和已读时间同步状态
+		chatAgentConversations.POST("/message-receipts", handler.UpdateMessageReceipts)
+
 		// 发送消息（非流式）
 		// POST /api/v1/chat-agent-conversations/send-message
 		// 发送消息并等待完整回复
@@ -49,10 +82,50 @@ func SetupChatAgentConversationRoutes(api *gin.RouterGroup, handler *handler.Cha
 		// 发送预制答案并流式返回回复
 		chatAgentConversations.POST("/send-message-predefined-streamable", handler.SendMessagePredefinedStreamable)
 
+		// 续传流式回复
+		// GET /api/v1/chat-agent-conversations/stream-resume?conversation_id=&service_user_id=&request_id=&last_event_id=
+		// 网络中断后重新连接，补发中断后的事件并继续接收剩余的回复
+		chatAgentConversations.GET("/stream-resume", handler.StreamResume)
+
+		// 上报用户输入状态
+		// POST /api/v1/chat-agent-conversations/typing
+		// 同一会话的其他设备通过会话状态事件收到用户正在输入
+		chatAgentConversations.POST("/typing", handler.UpdateTypingStatus)
+
+		// 实时推送会话状态
+		// GET /api/v1/chat-agent-conversations/presence-events?conversation_id=&service_user_id=
+		// 以 SSE 格式推送用户输入和智能体生成回复的状态，订阅时首先推送当前状态
+		chatAgentConversations.GET("/presence-events", handler.StreamPresenceEvents)
+
+		// 获取会话变量列表
+		// GET /api/v1/chat-agent-conversations/conversation-variables?conversation_id=&service_user_id=
+		// 获取会话中通过接口或模型设置的变量
+		chatAgentConversations.GET("/conversation-variables", handler.GetConversationVariables)
+
+		// 设置会话变量
+		// PUT /api/v1/chat-agent-conversations/conversation-variable
+		// 设置的变量在后续的工具调用参数和系统提示词模板中引用
+		chatAgentConversations.PUT("/conversation-variable", handler.SetConversationVariable)
+
+		// 删除会话变量
+		// DELETE /api/v1/chat-agent-conversations/conversation-variable?conversation_id=&service_user_id=&name=
+		// 删除会话中指定名称的变量
+		chatAgentConversations.DELETE("/conversation-variable", handler.DeleteConversationVariable)
+
 		// 上传附件
 		// POST /api/v1/chat-agent-conversations/upload-attachment
 		// 上传聊天附件文件
-		chatAgentConversations.POST("/upload-attachment", handler.UploadAttachment)
+		chatAgentConversations.POST("/upload-attachment", middleware.BodySizeLimitMiddleware(maxUploadSize), handler.UploadAttachment)
+
+		// 下载附件
+		// GET /api/v1/chat-agent-conversations/attachment-download?attachment_id=&service_user_id=
+		// 下载上传的附件或工具生成的文件
+		chatAgentConversations.GET("/attachment-download", handler.DownloadAttachment)
+
+		// 获取会话详情
+		// GET /api/v1/chat-agent-conversations/conversation
+		// 获取指定会话的信息、消息数量、最后一条消息预览和token用量
+		chatAgentConversations.GET("/conversation", handler.GetConversation)
 
 		// 删除会话
 		// DELETE /api/v1/chat-agent-conversations/conversation
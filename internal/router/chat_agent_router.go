@@ -3,14 +3,15 @@ package router
 
 import (
 	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupChatAgentRoutes 设置智能体模块的路由
 // 配置 ChatAgent 相关的所有 HTTP 路由
-// 参数：api - API 路由组，handler - ChatAgent 处理器
-func SetupChatAgentRoutes(api *gin.RouterGroup, handler *handler.ChatAgentHandler) {
+// 参数：api - API 路由组，handler - ChatAgent 处理器，maxUploadSize - 上传接口的请求体大小上限
+func SetupChatAgentRoutes(api *gin.RouterGroup, handler *handler.ChatAgentHandler, maxUploadSize int64) {
 	// 智能体路由组
 	chatAgents := api.Group("/chat-agents")
 	{
@@ -21,7 +22,7 @@ func SetupChatAgentRoutes(api *gin.RouterGroup, handler *handler.ChatAgentHandle
 
 		// 删除智能体
 		// DELETE /api/v1/chat-agents/:id
-		// 删除指定的智能体
+		// 删除指定的智能体及其关联数据，?dry_run=true 时只返回将要删除的数据数量
 		chatAgents.DELETE("/:id", handler.DeleteChatAgent)
 
 		// 根据应用ID获取智能体列表（分页）
@@ -29,9 +30,34 @@ func SetupChatAgentRoutes(api *gin.RouterGroup, handler *handler.ChatAgentHandle
 		// 根据应用ID获取该应用下的所有智能体列表
 		chatAgents.GET("/application/:applicationId", handler.GetChatAgentsByApplicationID)
 
+		// 根据ID获取智能体
+		// GET /api/v1/chat-agents/:chatAgentID
+		// 返回智能体信息，ETag 响应头为数据版本号，保存时可通过 If-Match 带回
+		chatAgents.GET("/:chatAgentID", handler.GetChatAgentByID)
+
+		// 获取智能体的工具使用统计
+		// GET /api/v1/chat-agents/:chatAgentID/tool-usage
+		// 按工具统计调用次数、成功率和平均耗时，包含已启用但未被调用的工具，便于移除不常用的工具
+		chatAgents.GET("/:chatAgentID/tool-usage", handler.GetChatAgentToolUsage)
+
+		// 获取智能体的 API Key 列表
+		// GET /api/v1/chat-agents/:chatAgentID/api-keys
+		// 返回 API Key 的名称和来源IP网段限制，不返回 Key 和 Secret
+		chatAgents.GET("/:chatAgentID/api-keys", handler.GetChatAgentApiKeys)
+
+		// 保存 API Key 的来源IP网段限制
+		// POST /api/v1/chat-agents/:chatAgentID/api-keys/:apiKeyID/ip-rules
+		// 设置允许和拒绝的IP网段，拒绝优先，允许列表为空时不限制
+		chatAgents.POST("/:chatAgentID/api-keys/:apiKeyID/ip-rules", handler.SaveChatAgentApiKeyIPRules)
+
+		// 获取智能体的 API Key 访问拒绝记录（分页）
+		// GET /api/v1/chat-agents/:chatAgentID/api-key-rejections
+		// 审计来源IP不满足网段限制而被拒绝的访问
+		chatAgents.GET("/:chatAgentID/api-key-rejections", handler.GetChatAgentApiKeyRejections)
+
 		// 上传智能体头像
 		// POST /api/v1/chat-agents/upload-avatar
 		// 上传智能体头像文件
-		chatAgents.POST("/upload-avatar", handler.UploadChatAgentAvatar)
+		chatAgents.POST("/upload-avatar", middleware.BodySizeLimitMiddleware(maxUploadSize), handler.UploadChatAgentAvatar)
 	}
 }
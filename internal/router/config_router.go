@@ -0,0 +1,25 @@
+// Package router 提供路由管理功能
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupConfigRoutes 设置服务配置模块的路由
+// 配置 Config 相关的所有 HTTP 路由
+// 参数：api - API 路由组，handler - 服务配置处理器，userService - User 服务
+func SetupConfigRoutes(api *gin.RouterGroup, handler *handler.ConfigHandler, userService service.UserService) {
+	// 服务配置路由组
+	configs := api.Group("/config")
+	configs.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 重新加载配置
+		// POST /api/v1/config/reload
+		// 重新读取 .env 文件，更新日志级别、跨域和模型提供商并发限制，无需重启服务
+		configs.POST("/reload", handler.ReloadConfig)
+	}
+}
@@ -0,0 +1,31 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupConversationArchiveRoutes 设置会话归档模块的路由
+// 参数：api - API 路由组，handler - 会话归档处理器，userService - 用户服务
+func SetupConversationArchiveRoutes(api *gin.RouterGroup, handler *handler.ConversationArchiveHandler, userService service.UserService) {
+	archives := api.Group("/conversation-archives")
+	archives.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 获取智能体的会话归档记录
+		// GET /api/v1/conversation-archives/chat-agent/:chatAgentId?service_user_id=xxx&status=archived&page=1&page_size=20
+		archives.GET("/chat-agent/:chatAgentId", handler.GetArchivesByChatAgentID)
+
+		// 获取会话归档记录详情
+		// GET /api/v1/conversation-archives/:id
+		archives.GET("/:id", handler.GetArchive)
+
+		// 从冷存储恢复归档的会话
+		// POST /api/v1/conversation-archives/:id/restore
+		archives.POST("/:id/restore", handler.RestoreArchive)
+	}
+}
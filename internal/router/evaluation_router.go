@@ -0,0 +1,86 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupEvaluationRoutes 设置评测模块的路由
+// 参数：api - API 路由组，handler - 评测处理器，userService - 用户服务
+func SetupEvaluationRoutes(api *gin.RouterGroup, handler *handler.EvaluationHandler, userService service.UserService) {
+	evaluationSets := api.Group("/evaluation-sets")
+	evaluationSets.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存评测集
+		// POST /api/v1/evaluation-sets/save
+		// 如果ID为空则新增，否则更新
+		evaluationSets.POST("/save", handler.SaveSet)
+
+		// 获取智能体的全部评测集
+		// GET /api/v1/evaluation-sets/chat-agent/:chatAgentId
+		evaluationSets.GET("/chat-agent/:chatAgentId", handler.GetSetsByChatAgentID)
+
+		// 获取评测集
+		// GET /api/v1/evaluation-sets/:id
+		evaluationSets.GET("/:id", handler.GetSet)
+
+		// 删除评测集
+		// DELETE /api/v1/evaluation-sets/:id
+		// 同时删除评测集的全部用例、运行和结果
+		evaluationSets.DELETE("/:id", handler.DeleteSet)
+	}
+
+	evaluationCases := api.Group("/evaluation-cases")
+	evaluationCases.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存评测用例
+		// POST /api/v1/evaluation-cases/save
+		// 如果ID为空则新增，否则更新
+		evaluationCases.POST("/save", handler.SaveCase)
+
+		// 获取评测集的全部用例
+		// GET /api/v1/evaluation-cases/set/:setId
+		evaluationCases.GET("/set/:setId", handler.GetCasesBySetID)
+
+		// 删除评测用例
+		// DELETE /api/v1/evaluation-cases/:id
+		evaluationCases.DELETE("/:id", handler.DeleteCase)
+	}
+
+	evaluationRuns := api.Group("/evaluation-runs")
+	evaluationRuns.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 创建评测运行
+		// POST /api/v1/evaluation-runs
+		// 使用指定的系统提示词版本运行评测集的全部启用用例，在后台异步执行
+		evaluationRuns.POST("", handler.CreateRun)
+
+		// 对比两次评测运行
+		// GET /api/v1/evaluation-runs/compare?base_run_id=&target_run_id=
+		// 按用例列出通过状态和得分的变化
+		evaluationRuns.GET("/compare", handler.CompareRuns)
+
+		// 获取评测集的运行列表
+		// GET /api/v1/evaluation-runs/set/:setId
+		evaluationRuns.GET("/set/:setId", handler.GetRunsBySetID)
+
+		// 获取评测运行
+		// GET /api/v1/evaluation-runs/:id
+		// 返回运行状态、通过率和平均得分
+		evaluationRuns.GET("/:id", handler.GetRun)
+
+		// 获取评测运行的结果
+		// GET /api/v1/evaluation-runs/:id/results
+		evaluationRuns.GET("/:id/results", handler.GetRunResults)
+
+		// 取消评测运行
+		// POST /api/v1/evaluation-runs/:id/cancel
+		// 正在运行的用例会运行完成，其余用例不再执行
+		evaluationRuns.POST("/:id/cancel", handler.CancelRun)
+	}
+}
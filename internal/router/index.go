@@ -3,9 +3,14 @@
 package router
 
 import (
+	"fmt"
+	"lemon-tree-core/internal/apiversion"
+	"lemon-tree-core/internal/config"
+	v2dto "lemon-tree-core/internal/dto/v2"
 	"lemon-tree-core/internal/handler"
 	middleware2 "lemon-tree-core/internal/middleware"
 	"lemon-tree-core/internal/service"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -25,16 +30,31 @@ type RouterManager struct {
 	applicationStorageConfigHandler   *handler.ApplicationStorageConfigHandler   // ApplicationStorageConfig 处理器
 	resourceHandler                   *handler.ResourceHandler                   // Resource 处理器
 	chatAgentMcpServerToolHandler     *handler.ChatAgentMcpServerToolHandler     // ChatAgentMcpServerTool 处理器
+	llmProviderDefineHandler          *handler.LlmProviderDefineHandler          // LlmProviderDefine 处理器
+	serviceUserHandler                *handler.ServiceUserHandler                // ServiceUser 处理器
+	conversationMonitorHandler        *handler.ConversationMonitorHandler        // ConversationMonitor 处理器
+	chatAgentAnswerRuleHandler        *handler.ChatAgentAnswerRuleHandler        // ChatAgentAnswerRule 处理器
+	knowledgeBaseHandler              *handler.KnowledgeBaseHandler              // KnowledgeBase 处理器
+	batchInferenceHandler             *handler.BatchInferenceHandler             // BatchInference 处理器
+	evaluationHandler                 *handler.EvaluationHandler                 // Evaluation 处理器
+	metricsHandler                    *handler.MetricsHandler                    // Metrics 处理器
+	applicationToolBundleHandler      *handler.ApplicationToolBundleHandler      // ApplicationToolBundle 处理器
+	applicationWebhookHandler         *handler.ApplicationWebhookHandler         // ApplicationWebhook 处理器
+	conversationArchiveHandler        *handler.ConversationArchiveHandler        // ConversationArchive 处理器
+	configHandler                     *handler.ConfigHandler                     // Config 处理器
 	userService                       service.UserService                        // User 服务
 	chatAgentService                  service.ChatAgentService                   // ChatAgent 服务
 	applicationService                service.ApplicationService                 // Application 服务
+	chatWidgetTokenService            service.ChatWidgetTokenService             // 嵌入式聊天窗口令牌 服务
+	config                            *config.Config                             // 应用程序配置
+	reloader                          *config.Reloader                           // 配置重新加载器
 	logger                            *zap.Logger                                // 日志记录器
 }
 
 // NewRouterManager 创建路由管理器实例
 // 返回 RouterManager 的实例
-// 参数：appHandler - Application 处理器，userHandler - User 处理器，llmProviderHandler - LlmProvider 处理器，applicationLlmHandler - ApplicationLLM 处理器，applicationMcpServerConfigHandler - ApplicationMCP配置 处理器，chatAgentHandler - ChatAgent 处理器，chatAgentConversationHandler - ChatAgentConversation 处理器，applicationStorageConfigHandler - ApplicationStorageConfig 处理器，resourceHandler - Resource 处理器，chatAgentMcpServerToolHandler - ChatAgentMcpServerTool 处理器，userService - User 服务，chatAgentService - ChatAgent 服务，applicationService - Application 服务，logger - 日志记录器
-func NewRouterManager(appHandler *handler.ApplicationHandler, userHandler *handler.UserHandler, llmProviderHandler *handler.LlmProviderHandler, applicationLlmHandler *handler.ApplicationLlmHandler, applicationMcpServerConfigHandler *handler.ApplicationMcpServerConfigHandler, chatAgentHandler *handler.ChatAgentHandler, chatAgentConversationHandler *handler.ChatAgentConversationHandler, applicationStorageConfigHandler *handler.ApplicationStorageConfigHandler, resourceHandler *handler.ResourceHandler, chatAgentMcpServerToolHandler *handler.ChatAgentMcpServerToolHandler, userService service.UserService, chatAgentService service.ChatAgentService, applicationService service.ApplicationService, logger *zap.Logger) *RouterManager {
+// 参数：appHandler - Application 处理器，userHandler - User 处理器，llmProviderHandler - LlmProvider 处理器，applicationLlmHandler - ApplicationLLM 处理器，applicationMcpServerConfigHandler - ApplicationMCP配置 处理器，chatAgentHandler - ChatAgent 处理器，chatAgentConversationHandler - ChatAgentConversation 处理器，applicationStorageConfigHandler - ApplicationStorageConfig 处理器，resourceHandler - Resource 处理器，chatAgentMcpServerToolHandler - ChatAgentMcpServerTool 处理器，llmProviderDefineHandler - LlmProviderDefine 处理器，serviceUserHandler - ServiceUser 处理器，conversationMonitorHandler - ConversationMonitor 处理器，chatAgentAnswerRuleHandler - ChatAgentAnswerRule 处理器，knowledgeBaseHandler - KnowledgeBase 处理器，batchInferenceHandler - BatchInference 处理器，evaluationHandler - Evaluation 处理器，metricsHandler - Metrics 处理器，applicationToolBundleHandler - ApplicationToolBundle 处理器，applicationWebhookHandler - ApplicationWebhook 处理器，conversationArchiveHandler - ConversationArchive 处理器，configHandler - Config 处理器，userService - User 服务，chatAgentService - ChatAgent 服务，applicationService - Application 服务，chatWidgetTokenService - 嵌入式聊天窗口令牌 服务，config - 应用程序配置，reloader - 配置重新加载器，logger - 日志记录器
+func NewRouterManager(appHandler *handler.ApplicationHandler, userHandler *handler.UserHandler, llmProviderHandler *handler.LlmProviderHandler, applicationLlmHandler *handler.ApplicationLlmHandler, applicationMcpServerConfigHandler *handler.ApplicationMcpServerConfigHandler, chatAgentHandler *handler.ChatAgentHandler, chatAgentConversationHandler *handler.ChatAgentConversationHandler, applicationStorageConfigHandler *handler.ApplicationStorageConfigHandler, resourceHandler *handler.ResourceHandler, chatAgentMcpServerToolHandler *handler.ChatAgentMcpServerToolHandler, llmProviderDefineHandler *handler.LlmProviderDefineHandler, serviceUserHandler *handler.ServiceUserHandler, conversationMonitorHandler *handler.ConversationMonitorHandler, chatAgentAnswerRuleHandler *handler.ChatAgentAnswerRuleHandler, knowledgeBaseHandler *handler.KnowledgeBaseHandler, batchInferenceHandler *handler.BatchInferenceHandler, evaluationHandler *handler.EvaluationHandler, metricsHandler *handler.MetricsHandler, applicationToolBundleHandler *handler.ApplicationToolBundleHandler, applicationWebhookHandler *handler.ApplicationWebhookHandler, conversationArchiveHandler *handler.ConversationArchiveHandler, configHandler *handler.ConfigHandler, userService service.UserService, chatAgentService service.ChatAgentService, applicationService service.ApplicationService, chatWidgetTokenService service.ChatWidgetTokenService, config *config.Config, reloader *config.Reloader, logger *zap.Logger) *RouterManager {
 	return &RouterManager{
 		appHandler:                        appHandler,
 		userHandler:                       userHandler,
@@ -46,66 +66,145 @@ func NewRouterManager(appHandler *handler.ApplicationHandler, userHandler *handl
 		applicationStorageConfigHandler:   applicationStorageConfigHandler,
 		resourceHandler:                   resourceHandler,
 		chatAgentMcpServerToolHandler:     chatAgentMcpServerToolHandler,
+		llmProviderDefineHandler:          llmProviderDefineHandler,
+		serviceUserHandler:                serviceUserHandler,
+		conversationMonitorHandler:        conversationMonitorHandler,
+		chatAgentAnswerRuleHandler:        chatAgentAnswerRuleHandler,
+		knowledgeBaseHandler:              knowledgeBaseHandler,
+		batchInferenceHandler:             batchInferenceHandler,
+		evaluationHandler:                 evaluationHandler,
+		metricsHandler:                    metricsHandler,
+		applicationToolBundleHandler:      applicationToolBundleHandler,
+		applicationWebhookHandler:         applicationWebhookHandler,
+		conversationArchiveHandler:        conversationArchiveHandler,
+		configHandler:                     configHandler,
 		userService:                       userService,
 		chatAgentService:                  chatAgentService,
 		applicationService:                applicationService,
+		chatWidgetTokenService:            chatWidgetTokenService,
+		config:                            config,
+		reloader:                          reloader,
 		logger:                            logger,
 	}
 }
 
 // SetupAllRoutes 设置所有路由
 // 配置中间件、API 路由组和各模块的路由
-// 返回配置完成的 Gin 引擎实例
-func (rm *RouterManager) SetupAllRoutes() *gin.Engine {
+// 返回配置完成的 Gin 引擎实例和错误信息
+func (rm *RouterManager) SetupAllRoutes() (*gin.Engine, error) {
 	// 创建新的 Gin 引擎实例
 	r := gin.New()
 
+	// 设置受信任的代理，只有来自这些代理的请求才会使用 X-Forwarded-For 等请求头解析客户端IP
+	if err := r.SetTrustedProxies(rm.config.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("受信任代理配置无效: %w", err)
+	}
+
 	// 添加中间件
+	// 请求ID中间件：为每个请求分配请求ID
+	r.Use(middleware2.RequestIDMiddleware())
 	// 恢复中间件：处理 panic 并记录错误
 	r.Use(middleware2.RecoveryMiddleware(rm.logger))
 	// 日志中间件：记录 HTTP 请求日志
 	r.Use(middleware2.LoggerMiddleware(rm.logger))
 	// CORS 中间件：处理跨域请求
-	r.Use(middleware2.CORSMiddleware())
+	r.Use(middleware2.CORSMiddleware(rm.config.CORS, rm.reloader))
+	// 请求体大小限制中间件：上传接口在路由上单独覆盖上限
+	r.Use(middleware2.BodySizeLimitMiddleware(rm.config.Server.MaxBodySize))
+	// 错误转换中间件：将处理器记录的错误统一转换为标准错误响应
+	r.Use(middleware2.ErrorHandlerMiddleware(rm.logger))
+
+	// 工作区公共文件静态路由
+	// 未配置工作区公共目录时不挂载
+	if workspacePublicPath := os.Getenv("WORKSPACE_PUBLIC_PATH"); workspacePublicPath != "" {
+		SetupStaticRoutes(r, workspacePublicPath, rm.config.Server.StaticCacheMaxAge)
+	}
+
+	// 监控指标路由
+	if rm.config.Metrics.Enabled {
+		SetupMetricsRoutes(r, rm.metricsHandler)
+	}
+
+	// 注册 v2 响应DTO映射
+	v2dto.RegisterMappers()
 
 	// API 路由组
-	// 所有 API 路由都以 /api/v1 为前缀
-	api := r.Group("/api/v1")
-	{
-		// 注册各个模块的路由
-		// 设置 Application 模块的路由
-		SetupApplicationRoutes(api, rm.appHandler, rm.userService)
+	// 各版本共用同一组处理器，响应DTO按版本映射输出
+	// v1：/api/v1 前缀，保持原有响应结构
+	rm.setupModuleRoutes(r.Group("/api/v1", apiversion.Middleware(apiversion.V1)))
+	// v2：/api/v2 前缀，使用新版响应结构（如不含兼容字段的错误响应、page_info 分页信息）
+	rm.setupModuleRoutes(r.Group("/api/v2", apiversion.Middleware(apiversion.V2)))
 
-		// 设置 User 模块的路由
-		SetupUserRoutes(api, rm.userHandler, rm.userService)
+	return r, nil
+}
 
-		// 设置 LlmProvider 模块的路由
-		SetupLlmProviderRoutes(api, rm.llmProviderHandler)
+// setupModuleRoutes 在指定版本的路由组下注册各模块路由
+// 参数：api - 版本路由组
+func (rm *RouterManager) setupModuleRoutes(api *gin.RouterGroup) {
+	// 注册各个模块的路由
+	// 设置 Application 模块的路由
+	SetupApplicationRoutes(api, rm.appHandler, rm.userService)
 
-		// 设置 ApplicationLLM 模块的路由
-		SetupApplicationLlmRoutes(api, rm.applicationLlmHandler)
+	// 设置 User 模块的路由
+	SetupUserRoutes(api, rm.userHandler, rm.userService)
 
-		// 设置 ApplicationMCP配置 模块的路由
-		SetupApplicationMcpServerConfigRoutes(api, rm.applicationMcpServerConfigHandler)
+	// 设置 LlmProvider 模块的路由
+	SetupLlmProviderRoutes(api, rm.llmProviderHandler, rm.config.Server.MaxUploadSize)
 
-		// 设置 ChatAgent 模块的路由
-		SetupChatAgentRoutes(api, rm.chatAgentHandler)
+	// 设置 ApplicationLLM 模块的路由
+	SetupApplicationLlmRoutes(api, rm.applicationLlmHandler)
 
-		// 设置 ChatAgentConversation 模块的路由
-		SetupChatAgentConversationRoutes(api, rm.chatAgentConversationHandler, rm.chatAgentService, rm.applicationService)
+	// 设置 ApplicationMCP配置 模块的路由
+	SetupApplicationMcpServerConfigRoutes(api, rm.applicationMcpServerConfigHandler)
 
-		// 设置 ApplicationStorageConfig 模块的路由
-		SetupApplicationStorageConfigRoutes(api, rm.applicationStorageConfigHandler)
+	// 设置 ChatAgent 模块的路由
+	SetupChatAgentRoutes(api, rm.chatAgentHandler, rm.config.Server.MaxUploadSize)
 
-		// 设置 Resource 模块的路由
-		SetupResourceRoutes(api, rm.resourceHandler)
+	// 设置 ChatAgentConversation 模块的路由
+	SetupChatAgentConversationRoutes(api, rm.chatAgentConversationHandler, rm.chatAgentService, rm.applicationService, rm.chatWidgetTokenService, rm.config.ChatWidget, rm.config.Server.MaxUploadSize)
 
-		// 设置 ChatAgentMcpServerTool 模块的路由
-		SetupChatAgentMcpServerToolRoutes(api, rm.chatAgentMcpServerToolHandler, rm.userService)
+	// 设置管理后台 ChatAgentConversation 模块的路由
+	SetupChatAgentConversationAdminRoutes(api, rm.chatAgentConversationHandler, rm.conversationMonitorHandler, rm.userService)
 
-		// 未来可以在这里添加更多模块的路由
-		// SetupAuthRoutes(api, authHandler)
-	}
+	// 设置 ApplicationStorageConfig 模块的路由
+	SetupApplicationStorageConfigRoutes(api, rm.applicationStorageConfigHandler)
+
+	// 设置 Resource 模块的路由
+	SetupResourceRoutes(api, rm.resourceHandler)
+
+	// 设置 ChatAgentMcpServerTool 模块的路由
+	SetupChatAgentMcpServerToolRoutes(api, rm.chatAgentMcpServerToolHandler, rm.userService)
+
+	// 设置 LlmProviderDefine 模块的路由
+	SetupLlmProviderDefineRoutes(api, rm.llmProviderDefineHandler, rm.userService)
+
+	// 设置 ServiceUser 模块的路由
+	SetupServiceUserRoutes(api, rm.serviceUserHandler, rm.userService, rm.chatAgentService, rm.applicationService)
+
+	// 设置 ChatAgentAnswerRule 模块的路由
+	SetupChatAgentAnswerRuleRoutes(api, rm.chatAgentAnswerRuleHandler, rm.userService)
+
+	// 设置 KnowledgeBase 模块的路由
+	SetupKnowledgeBaseRoutes(api, rm.knowledgeBaseHandler, rm.userService, rm.config.Server.MaxUploadSize)
+
+	// 设置 BatchInference 模块的路由
+	SetupBatchInferenceRoutes(api, rm.batchInferenceHandler, rm.userService, rm.config.Server.MaxUploadSize)
+
+	// 设置 Evaluation 模块的路由
+	SetupEvaluationRoutes(api, rm.evaluationHandler, rm.userService)
+
+	// 设置 ApplicationToolBundle 模块的路由
+	SetupApplicationToolBundleRoutes(api, rm.applicationToolBundleHandler, rm.userService)
+
+	// 设置 ApplicationWebhook 模块的路由
+	SetupApplicationWebhookRoutes(api, rm.applicationWebhookHandler, rm.userService)
+
+	// 设置 ConversationArchive 模块的路由
+	SetupConversationArchiveRoutes(api, rm.conversationArchiveHandler, rm.userService)
+
+	// 设置 Config 模块的路由
+	SetupConfigRoutes(api, rm.configHandler, rm.userService)
 
-	return r
+	// 未来可以在这里添加更多模块的路由
+	// SetupAuthRoutes(api, authHandler)
 }
@@ -0,0 +1,68 @@
+// Package router 提供路由管理功能
+// 负责设置和管理 HTTP 路由，包括中间件配置和模块路由注册
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupKnowledgeBaseRoutes 设置知识库模块的路由
+// 参数：api - API 路由组，handler - 知识库处理器，userService - 用户服务，maxUploadSize - 上传接口的请求体大小上限
+func SetupKnowledgeBaseRoutes(api *gin.RouterGroup, handler *handler.KnowledgeBaseHandler, userService service.UserService, maxUploadSize int64) {
+	knowledgeBases := api.Group("/knowledge-bases")
+	knowledgeBases.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存知识库
+		// POST /api/v1/knowledge-bases/save
+		// 如果请求中包含ID则更新，否则新增
+		knowledgeBases.POST("/save", handler.SaveKnowledgeBase)
+
+		// 删除知识库
+		// DELETE /api/v1/knowledge-bases/:id
+		// 同时删除知识库下的全部文档和片段
+		knowledgeBases.DELETE("/:id", handler.DeleteKnowledgeBase)
+
+		// 获取应用下的知识库列表
+		// GET /api/v1/knowledge-bases/application/:applicationId
+		knowledgeBases.GET("/application/:applicationId", handler.GetKnowledgeBasesByApplicationID)
+
+		// 重建知识库索引
+		// POST /api/v1/knowledge-bases/:id/reindex
+		// 修改切分设置后重新切分知识库的全部文档
+		knowledgeBases.POST("/:id/reindex", handler.ReindexKnowledgeBase)
+	}
+
+	// 知识库文档路由组
+	knowledgeDocuments := api.Group("/knowledge-documents")
+	knowledgeDocuments.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 保存文本文档
+		// POST /api/v1/knowledge-documents/text
+		// 如果请求中包含文档ID则替换文档内容，否则新增；保存后在后台切分并生成嵌入向量
+		knowledgeDocuments.POST("/text", handler.SaveTextDocument)
+
+		// 上传文档文件
+		// POST /api/v1/knowledge-documents/upload
+		// 支持 txt、md、markdown、csv、json 文件，表单中包含文档ID时替换文档内容
+		knowledgeDocuments.POST("/upload", middleware.BodySizeLimitMiddleware(maxUploadSize), handler.UploadDocument)
+
+		// 重新处理文档
+		// POST /api/v1/knowledge-documents/:id/reindex
+		// 重新切分文档并重新生成全部片段的嵌入向量
+		knowledgeDocuments.POST("/:id/reindex", handler.ReindexDocument)
+
+		// 删除文档
+		// DELETE /api/v1/knowledge-documents/:id
+		// 同时删除文档的全部片段
+		knowledgeDocuments.DELETE("/:id", handler.DeleteDocument)
+
+		// 获取知识库下的文档列表
+		// GET /api/v1/knowledge-documents/knowledge-base/:knowledgeBaseId
+		// 支持 page、page_size 分页和 status 处理状态过滤
+		knowledgeDocuments.GET("/knowledge-base/:knowledgeBaseId", handler.GetDocumentsByKnowledgeBaseID)
+	}
+}
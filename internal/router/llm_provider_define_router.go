@@ -0,0 +1,35 @@
+// Package router 提供路由管理功能
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupLlmProviderDefineRoutes 设置供应商定义模块的路由
+// 配置 LlmProviderDefine 相关的所有 HTTP 路由
+// 参数：api - API 路由组，handler - LlmProviderDefine 处理器，userService - User 服务
+func SetupLlmProviderDefineRoutes(api *gin.RouterGroup, handler *handler.LlmProviderDefineHandler, userService service.UserService) {
+	// 供应商定义路由组
+	llmProviderDefines := api.Group("/llm-provider-defines")
+	llmProviderDefines.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 获取所有供应商定义
+		// GET /api/v1/llm-provider-defines
+		// 获取数据库中已保存的供应商定义列表
+		llmProviderDefines.GET("", handler.GetAllLlmProviderDefines)
+
+		// 获取内置供应商目录
+		// GET /api/v1/llm-provider-defines/presets
+		// 获取内置的常用供应商定义，包含图标和默认API URL
+		llmProviderDefines.GET("/presets", handler.GetLlmProviderDefinePresets)
+
+		// 加载内置供应商目录
+		// POST /api/v1/llm-provider-defines/load-presets
+		// 将内置目录写入数据库，overwrite=true 时覆盖已有定义
+		llmProviderDefines.POST("/load-presets", handler.LoadLlmProviderDefinePresets)
+	}
+}
@@ -3,14 +3,15 @@ package router
 
 import (
 	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupLlmProviderRoutes 设置大语言模型提供商模块的路由
 // 配置 LlmProvider 相关的所有 HTTP 路由
-// 参数：api - API 路由组，handler - LlmProvider 处理器
-func SetupLlmProviderRoutes(api *gin.RouterGroup, handler *handler.LlmProviderHandler) {
+// 参数：api - API 路由组，handler - LlmProvider 处理器，maxUploadSize - 上传接口的请求体大小上限
+func SetupLlmProviderRoutes(api *gin.RouterGroup, handler *handler.LlmProviderHandler, maxUploadSize int64) {
 	// 大语言模型提供商路由组
 	llmProviders := api.Group("/llm-providers")
 	{
@@ -32,7 +33,7 @@ func SetupLlmProviderRoutes(api *gin.RouterGroup, handler *handler.LlmProviderHa
 		// 上传提供商图标
 		// POST /api/v1/llm-providers/upload-icon
 		// 上传提供商图标文件
-		llmProviders.POST("/upload-icon", handler.UploadLlmProviderIcon)
+		llmProviders.POST("/upload-icon", middleware.BodySizeLimitMiddleware(maxUploadSize), handler.UploadLlmProviderIcon)
 
 		// 动态查询提供商
 		// POST /api/v1/llm-providers/query
@@ -0,0 +1,18 @@
+// Package router 提供路由管理功能
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupMetricsRoutes 设置监控指标的路由
+// 监控指标供 Prometheus 等采集系统抓取，挂载在根路径下，不区分 API 版本
+// 参数：r - Gin 引擎，handler - 监控指标处理器
+func SetupMetricsRoutes(r *gin.Engine, handler *handler.MetricsHandler) {
+	// 获取监控指标
+	// GET /metrics
+	// 配置了访问令牌时需携带 Authorization: Bearer <令牌>
+	r.GET("/metrics", handler.Metrics)
+}
@@ -16,7 +16,7 @@ func SetupResourceRoutes(api *gin.RouterGroup, handler *handler.ResourceHandler)
 	{
 		// 下载文件
 		// GET /api/v1/resources/download
-		// 下载指定的资源文件
+		// 下载指定的资源文件，图片可通过 size 参数获取标准尺寸缩略图
 		resources.GET("/download", handler.DownloadFile)
 
 		// 列出目录文件
@@ -0,0 +1,49 @@
+// Package router 提供路由管理功能
+package router
+
+import (
+	"lemon-tree-core/internal/handler"
+	"lemon-tree-core/internal/middleware"
+	"lemon-tree-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupServiceUserRoutes 设置业务侧用户模块的路由
+// 管理后台路由使用用户认证，业务侧路由使用智能体 API Key 认证
+// 参数：api - API 路由组，handler - ServiceUser 处理器，userService - User 服务，chatAgentService - ChatAgent 服务，applicationService - Application 服务
+func SetupServiceUserRoutes(api *gin.RouterGroup, handler *handler.ServiceUserHandler, userService service.UserService,
+	chatAgentService service.ChatAgentService, applicationService service.ApplicationService) {
+	// 管理后台路由组
+	serviceUsers := api.Group("/service-users")
+	serviceUsers.Use(middleware.UserAuthMiddleware(userService))
+	{
+		// 根据应用ID获取业务侧用户列表（分页）
+		// GET /api/v1/service-users/application/:applicationId
+		// 支持 keyword 参数搜索
+		serviceUsers.GET("/application/:applicationId", handler.GetServiceUsersByApplicationID)
+
+		// 获取业务侧用户
+		// GET /api/v1/service-users/application/:applicationId/user?service_user_id=
+		serviceUsers.GET("/application/:applicationId/user", handler.GetServiceUser)
+
+		// 登记业务侧用户
+		// POST /api/v1/service-users/application/:applicationId/upsert
+		// 用户不存在时创建，存在时只更新请求中提供的字段
+		serviceUsers.POST("/application/:applicationId/upsert", handler.UpsertServiceUser)
+	}
+
+	// 业务侧路由组
+	chatServiceUsers := api.Group("/chat")
+	chatServiceUsers.Use(middleware.ChatAgentAuthMiddleware(chatAgentService, applicationService))
+	{
+		// 获取业务侧用户
+		// GET /api/v1/chat/service-user?service_user_id=
+		chatServiceUsers.GET("/service-user", handler.GetCurrentServiceUser)
+
+		// 登记业务侧用户
+		// POST /api/v1/chat/service-user
+		// 业务系统可在用户首次对话前或资料变更时调用
+		chatServiceUsers.POST("/service-user", handler.UpsertCurrentServiceUser)
+	}
+}
@@ -0,0 +1,26 @@
+// Package router 提供路由管理功能
+package router
+
+import (
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/handler"
+	"path"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupStaticRoutes 设置工作区公共文件的静态路由
+// 将头像、供应商图标等公共目录以只读方式挂载到 /public 下，不区分 API 版本
+// 参数：r - Gin 引擎，workspacePublicPath - 工作区公共目录，maxAge - 浏览器缓存时间（秒）
+func SetupStaticRoutes(r *gin.Engine, workspacePublicPath string, maxAge int) {
+	static := r.Group(define.StaticRoutePrefix)
+	for _, dirName := range define.WorkspaceStaticDirNames {
+		// 每个目录单独挂载，未列出的目录不对外提供
+		// GET /public/chat_agent_avatar/*filepath
+		staticHandler := handler.NewStaticFileHandler(filepath.Join(workspacePublicPath, dirName), maxAge)
+		routePath := path.Join(dirName, "*filepath")
+		static.GET(routePath, staticHandler.ServeFile)
+		static.HEAD(routePath, staticHandler.ServeFile)
+	}
+}
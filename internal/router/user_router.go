@@ -22,6 +22,40 @@ func SetupUserRoutes(api *gin.RouterGroup, userHandler *handler.UserHandler, use
 		// 用户登录，验证账号密码，返回Token
 		users.POST("/login", userHandler.Login)
 
+		// 刷新令牌（无需认证，访问令牌过期后调用）
+		// POST /api/v1/users/refresh
+		// 使用刷新令牌换取新的访问令牌和刷新令牌
+		users.POST("/refresh", userHandler.Refresh)
+
+		// 获取单点登录是否可用（无需认证）
+		// GET /api/v1/users/oidc
+		users.GET("/oidc", userHandler.GetOidcStatus)
+
+		// 发起单点登录（无需认证）
+		// GET /api/v1/users/oidc/login
+		// 重定向到身份提供方的授权页面
+		users.GET("/oidc/login", userHandler.OidcLogin)
+
+		// 单点登录回调（无需认证）
+		// GET /api/v1/users/oidc/callback
+		// 校验身份并映射为系统用户，创建登录会话
+		users.GET("/oidc/callback", userHandler.OidcCallback)
+
+		// 接受邀请（无需认证）
+		// POST /api/v1/users/invite/accept
+		// 使用邀请邮件中的令牌设置初始密码
+		users.POST("/invite/accept", userHandler.AcceptInvite)
+
+		// 找回密码（无需认证）
+		// POST /api/v1/users/password/forgot
+		// 向邮箱发送重置密码链接
+		users.POST("/password/forgot", userHandler.ForgotPassword)
+
+		// 重置密码（无需认证）
+		// POST /api/v1/users/password/reset
+		// 使用找回密码邮件中的令牌设置新密码
+		users.POST("/password/reset", userHandler.ResetPassword)
+
 		// 需要认证的路由组
 		authenticated := users.Group("")
 		authenticated.Use(middleware.UserAuthMiddleware(userService))
@@ -51,6 +85,31 @@ func SetupUserRoutes(api *gin.RouterGroup, userHandler *handler.UserHandler, use
 			// 用户登出，删除会话记录
 			authenticated.POST("/logout", userHandler.Logout)
 
+			// 邀请用户
+			// POST /api/v1/users/invite
+			// 创建未设置密码的用户，并发送附带设置密码链接的邀请邮件
+			authenticated.POST("/invite", userHandler.InviteUser)
+
+			// 修改当前用户的密码
+			// POST /api/v1/users/change-password
+			// 需要提供当前密码，修改后注销当前会话以外的全部登录会话
+			authenticated.POST("/change-password", userHandler.ChangePassword)
+
+			// 获取当前用户的登录会话
+			// GET /api/v1/users/sessions
+			// 返回未过期的登录会话及其登录IP和User-Agent，标记当前使用的会话
+			authenticated.GET("/sessions", userHandler.ListSessions)
+
+			// 注销其他设备
+			// POST /api/v1/users/sessions/revoke-others
+			// 注销当前用户除当前会话外的全部登录会话
+			authenticated.POST("/sessions/revoke-others", userHandler.RevokeOtherSessions)
+
+			// 注销指定登录会话
+			// DELETE /api/v1/users/sessions/:sessionId
+			// 只能注销自己的会话，注销当前会话等同于登出
+			authenticated.DELETE("/sessions/:sessionId", userHandler.RevokeSession)
+
 			// 删除用户
 			// DELETE /api/v1/users/:id
 			// 删除指定用户及其所有会话记录
@@ -0,0 +1,109 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/manager"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// applicationBackupVersion 应用配置备份的格式版本
+const applicationBackupVersion = 1
+
+// ApplicationBackupService 应用配置备份 业务逻辑层接口
+// 导出应用的全部配置并恢复到另一个数据库，用于灾难恢复和在环境之间迁移应用
+type ApplicationBackupService interface {
+	// BackupApplication 导出应用的全部配置
+	// 备份中包含供应商 API Key、MCP服务 OAuth 客户端密钥等明文密钥，需要妥善保管
+	BackupApplication(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationBackup, error)
+
+	// RestoreApplication 按原有ID恢复备份中的应用配置，应用已存在时返回冲突错误
+	RestoreApplication(ctx context.Context, backup *models.ApplicationBackup) error
+}
+
+// applicationBackupService 应用配置备份 业务逻辑层实现
+type applicationBackupService struct {
+	appRepo repository.ApplicationRepository // 应用数据访问层
+}
+
+// NewApplicationBackupService 创建 应用配置备份 服务实例
+// 返回 ApplicationBackupService 接口的实现
+func NewApplicationBackupService(appRepo repository.ApplicationRepository) ApplicationBackupService {
+	return &applicationBackupService{appRepo: appRepo}
+}
+
+// BackupApplication 导出应用的全部配置
+// MCP服务的 OAuth 客户端密钥解密后写入备份，恢复到使用不同加密密钥的环境时可以重新加密
+func (s *applicationBackupService) BackupApplication(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationBackup, error) {
+	backup, err := s.appRepo.GetBackup(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("读取应用配置失败: %w", err)
+	}
+	if backup == nil {
+		return nil, apperror.New(apperror.CodeNotFound, "应用不存在")
+	}
+	backup.Version = applicationBackupVersion
+	backup.CreatedAt = time.Now()
+
+	backup.McpOauthClientSecrets = make(map[uuid.UUID]string)
+	for _, mcpConfig := range backup.McpServerConfigs {
+		if mcpConfig.McpOauthClientSecret == "" {
+			continue
+		}
+		secret, err := manager.DecryptMcpOauthSecret(mcpConfig.McpOauthClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("解密MCP服务 %s 的OAuth客户端密钥失败: %w", mcpConfig.Name, err)
+		}
+		backup.McpOauthClientSecrets[mcpConfig.ID] = secret
+	}
+	backup.AnswerRuleEmbeddings = make(map[uuid.UUID]string)
+	for _, rule := range backup.ChatAgentAnswerRules {
+		if rule.Embedding != "" {
+			backup.AnswerRuleEmbeddings[rule.ID] = rule.Embedding
+		}
+	}
+	return backup, nil
+}
+
+// RestoreApplication 按原有ID恢复备份中的应用配置
+// MCP服务的 OAuth 客户端密钥使用当前环境的加密密钥重新加密；MCP服务需要重新完成 OAuth 授权
+func (s *applicationBackupService) RestoreApplication(ctx context.Context, backup *models.ApplicationBackup) error {
+	if backup.Application == nil || backup.Application.ID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "备份中没有应用数据")
+	}
+	if backup.Version < 1 || backup.Version > applicationBackupVersion {
+		return apperror.Newf(apperror.CodeInvalidArgument, "不支持的备份格式版本: %d", backup.Version)
+	}
+	_, err := s.appRepo.GetByID(ctx, backup.Application.ID)
+	if err == nil {
+		return apperror.Newf(apperror.CodeConflict, "应用 %s 已存在", backup.Application.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("查询应用失败: %w", err)
+	}
+
+	for _, mcpConfig := range backup.McpServerConfigs {
+		encrypted, err := manager.EncryptMcpOauthSecret(backup.McpOauthClientSecrets[mcpConfig.ID])
+		if err != nil {
+			return fmt.Errorf("加密MCP服务 %s 的OAuth客户端密钥失败: %w", mcpConfig.Name, err)
+		}
+		mcpConfig.McpOauthClientSecret = encrypted
+	}
+	for _, rule := range backup.ChatAgentAnswerRules {
+		rule.Embedding = backup.AnswerRuleEmbeddings[rule.ID]
+	}
+
+	if err := s.appRepo.RestoreBackup(ctx, backup); err != nil {
+		return fmt.Errorf("写入应用配置失败: %w", err)
+	}
+	return nil
+}
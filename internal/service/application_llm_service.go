@@ -54,8 +54,8 @@ func NewApplicationLlmService(applicationLlmRepo repository.ApplicationLlmReposi
 // FetchAndSaveModels 获取并保存所有模型
 // 从指定的 LLM 提供商获取模型列表并保存到数据库
 func (s *applicationLlmService) FetchAndSaveModels(ctx context.Context, llmProvider *models.ApplicationLlmProvider) error {
-	// 检查提供商是否有必要的配置
-	if llmProvider.ApiUrl == "" || llmProvider.ApiKey == "" {
+	// 检查提供商是否有必要的配置，模拟提供商不调用外部接口
+	if llmProvider.Type != "mock" && (llmProvider.ApiUrl == "" || llmProvider.ApiKey == "") {
 		return fmt.Errorf("提供商缺少必要的配置信息")
 	}
 
@@ -78,6 +78,10 @@ func (s *applicationLlmService) FetchAndSaveModels(ctx context.Context, llmProvi
 		}
 		openaiModels = modelsList.Models
 
+	case "mock":
+		// 模拟提供商，提供固定的聊天、嵌入和重排模型
+		openaiModels = []openai.Model{{ID: "mock-chat"}, {ID: "mock-embedding"}, {ID: "mock-rerank"}}
+
 	case "ollama_api":
 		// Ollama 类型的提供商
 		// Ollama 通常使用本地 API，模型列表可能通过其他方式获取
@@ -123,8 +127,8 @@ func (s *applicationLlmService) FetchAndSaveModels(ctx context.Context, llmProvi
 			AbilityNetwork:        contains(model.ID, "gpt-4") || contains(model.ID, "gpt-3.5"),
 			AbilityTextEmbeddings: contains(model.ID, "text-embedding") || contains(model.ID, "embedding"),
 			AbilityThinking:       contains(model.ID, "gpt-4") || contains(model.ID, "gpt-3.5"),
-			AbilityCallTools:      contains(model.ID, "gpt-4") || contains(model.ID, "gpt-3.5"),
-			AbilityReranking:      contains(model.ID, "text-embedding-3"),
+			AbilityCallTools:      contains(model.ID, "gpt-4") || contains(model.ID, "gpt-3.5") || model.ID == "mock-chat",
+			AbilityReranking:      contains(model.ID, "text-embedding-3") || contains(model.ID, "rerank"),
 			// 设置默认计费信息
 			BillingCurrency:    "USD",
 			BillingPriceInput:  0.0015, // 默认价格，实际应该从配置或API获取
@@ -4,14 +4,22 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/manager"
 	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/repository"
 	"log"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
+	"gorm.io/gorm"
 )
 
 // ApplicationMcpServerConfigService ApplicationMCP配置 业务逻辑层接口
@@ -19,11 +27,12 @@ import (
 type ApplicationMcpServerConfigService interface {
 	// SaveApplicationMcpServerConfig 保存应用MCP配置信息
 	// 如果ID为空则新增，否则更新现有记录
-	SaveApplicationMcpServerConfig(ctx context.Context, config *models.ApplicationMcpServerConfig) error
+	// updateFields 不为空时为部分更新，只修改列出的字段（模型字段名），其余字段（如密钥、环境变量）保持当前值
+	SaveApplicationMcpServerConfig(ctx context.Context, config *models.ApplicationMcpServerConfig, updateFields []string) error
 
 	// DeleteApplicationMcpServerConfig 删除MCP配置
-	// 根据ID删除指定的MCP配置记录
-	DeleteApplicationMcpServerConfig(ctx context.Context, id uuid.UUID) error
+	// 在同一事务中删除配置、配置下的工具和智能体的工具设置，返回受影响的智能体
+	DeleteApplicationMcpServerConfig(ctx context.Context, id uuid.UUID) (*dto.ApplicationMcpServerConfigDeletionDto, error)
 
 	// GetMcpServerConfigsByApplicationID 根据应用ID获取MCP配置列表
 	// 返回指定应用下的所有MCP配置
@@ -36,6 +45,14 @@ type ApplicationMcpServerConfigService interface {
 	// SyncMcpServerTools 同步MCP服务器的工具列表
 	// 从MCP服务器获取工具列表并同步到数据库
 	SyncMcpServerTools(ctx context.Context, configID uuid.UUID) ([]*models.ApplicationMcpServerTool, error)
+
+	// ImportMcpServers 从标准 mcpServers JSON 批量创建MCP配置
+	// 已禁用的服务和（skipExisting 为 true 时）应用中已有同名配置的服务跳过，返回新建的配置和跳过的服务
+	ImportMcpServers(ctx context.Context, applicationID uuid.UUID, servers map[string]dto.McpServerJsonEntry, skipExisting bool) ([]*models.ApplicationMcpServerConfig, []dto.ImportMcpServerSkippedDto, error)
+
+	// ExportMcpServers 导出应用的MCP配置为标准 mcpServers JSON，键为服务名称
+	// maskSecrets 为 true 时环境变量和请求头的值替换为掩码
+	ExportMcpServers(ctx context.Context, applicationID uuid.UUID, maskSecrets bool) (map[string]dto.McpServerJsonEntry, error)
 }
 
 // applicationMcpServerConfigService ApplicationMCP配置 业务逻辑层实现
@@ -58,12 +75,40 @@ func NewApplicationMcpServerConfigService(applicationMcpServerConfigRepo reposit
 
 // SaveApplicationMcpServerConfig 保存应用MCP配置信息
 // 如果ID为空则新增，否则更新现有记录
-func (s *applicationMcpServerConfigService) SaveApplicationMcpServerConfig(ctx context.Context, config *models.ApplicationMcpServerConfig) error {
+func (s *applicationMcpServerConfigService) SaveApplicationMcpServerConfig(ctx context.Context, config *models.ApplicationMcpServerConfig, updateFields []string) error {
+	// OAuth客户端密钥不会返回给前端，完整更新时为空表示保持当前密钥，部分更新时列出即表示修改
+	clientSecretProvided := config.McpOauthClientSecret != ""
+	if len(updateFields) > 0 {
+		clientSecretProvided = slices.Contains(updateFields, "McpOauthClientSecret")
+	}
+
+	// 部分更新：未列出的字段使用当前值，合并后按完整数据校验
+	if len(updateFields) > 0 {
+		if config.ID == uuid.Nil {
+			return apperror.New(apperror.CodeInvalidArgument, "部分更新时必须提供MCP配置ID")
+		}
+		existing, err := s.applicationMcpServerConfigRepo.GetByID(ctx, config.ID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "MCP配置不存在", err)
+		}
+		if err := mergeUpdateFields(config, existing, updateFields); err != nil {
+			return err
+		}
+	}
+
 	// 数据验证
 	if err := s.validateApplicationMcpServerConfig(config); err != nil {
 		return err
 	}
 
+	if clientSecretProvided {
+		encrypted, err := manager.EncryptMcpOauthSecret(config.McpOauthClientSecret)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeServiceUnavailable, "加密OAuth客户端密钥失败，请检查 MCP_OAUTH_ENCRYPTION_KEY 配置", err)
+		}
+		config.McpOauthClientSecret = encrypted
+	}
+
 	if config.ID == uuid.Nil {
 		// 新增：生成新的UUID
 		config.ID = uuid.New()
@@ -77,23 +122,44 @@ func (s *applicationMcpServerConfigService) SaveApplicationMcpServerConfig(ctx c
 		if existing == nil {
 			return fmt.Errorf("MCP配置不存在")
 		}
+		if !clientSecretProvided {
+			config.McpOauthClientSecret = existing.McpOauthClientSecret
+		}
 		return s.applicationMcpServerConfigRepo.Update(ctx, config)
 	}
 }
 
 // DeleteApplicationMcpServerConfig 删除MCP配置
-// 根据ID删除指定的MCP配置记录
-func (s *applicationMcpServerConfigService) DeleteApplicationMcpServerConfig(ctx context.Context, id uuid.UUID) error {
+// 删除后记录受影响的智能体，便于排查智能体工具调用的变化
+func (s *applicationMcpServerConfigService) DeleteApplicationMcpServerConfig(ctx context.Context, id uuid.UUID) (*dto.ApplicationMcpServerConfigDeletionDto, error) {
 	// 检查记录是否存在
 	existing, err := s.applicationMcpServerConfigRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("MCP配置不存在: %w", err)
+		return nil, apperror.Wrap(apperror.CodeNotFound, "MCP配置不存在", err)
 	}
-	if existing == nil {
-		return fmt.Errorf("MCP配置不存在")
+
+	dependents, err := s.applicationMcpServerConfigRepo.DeleteWithDependents(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "MCP配置不存在", err)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "删除MCP配置失败", err)
+	}
+
+	affectedChatAgentIDs := make([]string, 0, len(dependents.AffectedChatAgents))
+	for _, chatAgentID := range dependents.AffectedChatAgents {
+		affectedChatAgentIDs = append(affectedChatAgentIDs, chatAgentID.String())
+	}
+	if len(affectedChatAgentIDs) > 0 {
+		log.Printf("MCP配置 %s（%s）已删除，%d 个智能体的 %d 项工具设置随之删除，受影响的智能体: %s",
+			existing.Name, id, len(affectedChatAgentIDs), dependents.AgentToolCount, strings.Join(affectedChatAgentIDs, ","))
 	}
 
-	return s.applicationMcpServerConfigRepo.Delete(ctx, id)
+	return &dto.ApplicationMcpServerConfigDeletionDto{
+		DeletedToolCount:      dependents.ToolCount,
+		DeletedAgentToolCount: dependents.AgentToolCount,
+		AffectedChatAgentIDs:  affectedChatAgentIDs,
+	}, nil
 }
 
 // GetMcpServerConfigsByApplicationID 根据应用ID获取MCP配置列表
@@ -129,6 +195,10 @@ func (s *applicationMcpServerConfigService) validateApplicationMcpServerConfig(c
 		return fmt.Errorf("所属应用ID不能为空")
 	}
 
+	if config.McpServerAuthType != define.McpServerAuthTypeNone && config.McpServerAuthType != define.McpServerAuthTypeOAuth {
+		return fmt.Errorf("不支持的MCP服务授权方式: %s", config.McpServerAuthType)
+	}
+
 	// 根据连接方式验证必填字段
 	switch config.McpServerConnectType {
 	case "sse", "streamable-http":
@@ -139,6 +209,12 @@ func (s *applicationMcpServerConfigService) validateApplicationMcpServerConfig(c
 		if config.McpServerCommand == "" {
 			return fmt.Errorf("MCP服务命令不能为空")
 		}
+		if config.McpServerAuthType != define.McpServerAuthTypeNone {
+			return fmt.Errorf("stdio 连接方式不支持授权方式: %s", config.McpServerAuthType)
+		}
+		if err := manager.ValidateStdioConfig(config); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("不支持的MCP服务连接方式: %s", config.McpServerConnectType)
 	}
@@ -254,6 +330,7 @@ func (s *applicationMcpServerConfigService) syncMcpToolsToDatabase(ctx context.C
 		if title == "" {
 			title = newTool.Name
 		}
+		inputSchema := mcpToolInputSchema(newTool)
 
 		if existingTool, exists := existingToolsMap[newTool.Name]; exists {
 			// 工具已存在，检查是否需要更新
@@ -266,6 +343,10 @@ func (s *applicationMcpServerConfigService) syncMcpToolsToDatabase(ctx context.C
 				existingTool.Title = title
 				needsUpdate = true
 			}
+			if existingTool.InputSchema != inputSchema {
+				existingTool.InputSchema = inputSchema
+				needsUpdate = true
+			}
 
 			if needsUpdate {
 				if err := s.applicationMcpServerToolRepo.Update(ctx, existingTool); err != nil {
@@ -280,6 +361,7 @@ func (s *applicationMcpServerConfigService) syncMcpToolsToDatabase(ctx context.C
 				Name:                         newTool.Name,
 				Title:                        title,
 				Description:                  newTool.Description,
+				InputSchema:                  inputSchema,
 			}
 
 			if err := s.applicationMcpServerToolRepo.Create(ctx, newToolModel); err != nil {
@@ -299,3 +381,16 @@ func (s *applicationMcpServerConfigService) syncMcpToolsToDatabase(ctx context.C
 
 	return nil
 }
+
+// mcpToolInputSchema 获取MCP工具参数的JSON Schema文本，序列化失败时返回空
+func mcpToolInputSchema(tool mcp.Tool) string {
+	if len(tool.RawInputSchema) > 0 {
+		return string(tool.RawInputSchema)
+	}
+	data, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		log.Printf("序列化工具参数定义失败: %s, error: %v", tool.Name, err)
+		return ""
+	}
+	return string(data)
+}
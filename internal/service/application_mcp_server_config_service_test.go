@@ -0,0 +1,104 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/repository/mock_repository"
+	"lemon-tree-core/internal/service"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestApplicationMcpServerConfigServiceDelete(t *testing.T) {
+	config := &models.ApplicationMcpServerConfig{Name: "搜索服务"}
+	config.ID = uuid.New()
+	affectedChatAgentID := uuid.New()
+
+	tests := []struct {
+		name               string
+		setup              func(configRepo *mock_repository.MockApplicationMcpServerConfigRepository)
+		wantCode           apperror.Code
+		wantToolCount      int64
+		wantAffectedIDs    []string
+		wantAgentToolCount int64
+	}{
+		{
+			name: "config not found",
+			setup: func(configRepo *mock_repository.MockApplicationMcpServerConfigRepository) {
+				configRepo.EXPECT().GetByID(gomock.Any(), config.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: apperror.CodeNotFound,
+		},
+		{
+			name: "deleted concurrently",
+			setup: func(configRepo *mock_repository.MockApplicationMcpServerConfigRepository) {
+				configRepo.EXPECT().GetByID(gomock.Any(), config.ID).Return(config, nil)
+				configRepo.EXPECT().DeleteWithDependents(gomock.Any(), config.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: apperror.CodeNotFound,
+		},
+		{
+			name: "database error",
+			setup: func(configRepo *mock_repository.MockApplicationMcpServerConfigRepository) {
+				configRepo.EXPECT().GetByID(gomock.Any(), config.ID).Return(config, nil)
+				configRepo.EXPECT().DeleteWithDependents(gomock.Any(), config.ID).Return(nil, errors.New("database is down"))
+			},
+			wantCode: apperror.CodeInternal,
+		},
+		{
+			name: "reports affected chat agents",
+			setup: func(configRepo *mock_repository.MockApplicationMcpServerConfigRepository) {
+				configRepo.EXPECT().GetByID(gomock.Any(), config.ID).Return(config, nil)
+				configRepo.EXPECT().DeleteWithDependents(gomock.Any(), config.ID).Return(&repository.McpServerConfigDependents{
+					ToolCount:          3,
+					AgentToolCount:     2,
+					AffectedChatAgents: []uuid.UUID{affectedChatAgentID},
+				}, nil)
+			},
+			wantToolCount:      3,
+			wantAgentToolCount: 2,
+			wantAffectedIDs:    []string{affectedChatAgentID.String()},
+		},
+		{
+			name: "no chat agent uses the config",
+			setup: func(configRepo *mock_repository.MockApplicationMcpServerConfigRepository) {
+				configRepo.EXPECT().GetByID(gomock.Any(), config.ID).Return(config, nil)
+				configRepo.EXPECT().DeleteWithDependents(gomock.Any(), config.ID).Return(&repository.McpServerConfigDependents{ToolCount: 1}, nil)
+			},
+			wantToolCount:   1,
+			wantAffectedIDs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			configRepo := mock_repository.NewMockApplicationMcpServerConfigRepository(ctrl)
+			tt.setup(configRepo)
+			svc := service.NewApplicationMcpServerConfigService(configRepo, mock_repository.NewMockApplicationMcpServerToolRepository(ctrl))
+
+			result, err := svc.DeleteApplicationMcpServerConfig(context.Background(), config.ID)
+			if tt.wantCode != "" {
+				if !apperror.Is(err, tt.wantCode) {
+					t.Fatalf("DeleteApplicationMcpServerConfig() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteApplicationMcpServerConfig() error = %v", err)
+			}
+			if result.DeletedToolCount != tt.wantToolCount || result.DeletedAgentToolCount != tt.wantAgentToolCount {
+				t.Errorf("DeleteApplicationMcpServerConfig() = %+v, want %d tools and %d agent tools", result, tt.wantToolCount, tt.wantAgentToolCount)
+			}
+			if result.AffectedChatAgentIDs == nil || !slices.Equal(result.AffectedChatAgentIDs, tt.wantAffectedIDs) {
+				t.Errorf("AffectedChatAgentIDs = %v, want %v", result.AffectedChatAgentIDs, tt.wantAffectedIDs)
+			}
+		})
+	}
+}
@@ -4,13 +4,22 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
 	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/repository"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// defaultApplicationDeletionBatchSize 未配置每批删除记录数时使用的默认值
+const defaultApplicationDeletionBatchSize = 500
+
 // ApplicationService Application 业务逻辑层接口
 // 定义了 Application 相关的所有业务操作接口
 // 包含业务规则验证和数据处理逻辑
@@ -18,23 +27,37 @@ type ApplicationService interface {
 	GetApplicationByID(ctx context.Context, id uuid.UUID) (*models.Application, error)               // 根据ID获取应用
 	GetAllApplications(ctx context.Context) ([]*models.Application, error)                           // 获取所有应用
 	SaveApplication(ctx context.Context, application *models.Application) error                      // 保存应用（upsert）
-	DeleteApplication(ctx context.Context, id uuid.UUID) error                                       // 删除应用
 	QueryApplications(ctx context.Context, query *models.Application) ([]*models.Application, error) // 动态查询应用
+
+	// DeleteApplication 删除应用
+	// 应用立即软删除，关联数据由后台任务分批删除，返回创建的删除任务
+	DeleteApplication(ctx context.Context, id uuid.UUID) (*models.ApplicationDeletionJob, error)
+
+	// GetDeletionJob 获取应用最近的删除任务，用于查询删除进度
+	GetDeletionJob(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationDeletionJob, error)
+
+	// ProcessDeletionJobs 处理等待中和执行中的删除任务
+	// 由后台定时任务调用，每次按创建时间依次处理，直到没有待处理的任务或上下文取消
+	ProcessDeletionJobs(ctx context.Context) error
 }
 
 // applicationService Application 业务逻辑层实现
 // 实现了 ApplicationService 接口的所有方法
 // 包含业务规则验证和数据处理逻辑
 type applicationService struct {
-	appRepo repository.ApplicationRepository // Application 数据访问层接口
+	appRepo         repository.ApplicationRepository            // Application 数据访问层接口
+	deletionJobRepo repository.ApplicationDeletionJobRepository // 应用删除任务 数据访问层接口
+	config          *config.Config                              // 应用程序配置
 }
 
 // NewApplicationService 创建 Application Service 实例
 // 返回 ApplicationService 接口的实现
-// 参数：appRepo - Application 数据访问层接口
-func NewApplicationService(appRepo repository.ApplicationRepository) ApplicationService {
+// 参数：appRepo - Application 数据访问层接口，deletionJobRepo - 应用删除任务 数据访问层接口，config - 应用程序配置
+func NewApplicationService(appRepo repository.ApplicationRepository, deletionJobRepo repository.ApplicationDeletionJobRepository, config *config.Config) ApplicationService {
 	return &applicationService{
-		appRepo: appRepo,
+		appRepo:         appRepo,
+		deletionJobRepo: deletionJobRepo,
+		config:          config,
 	}
 }
 
@@ -81,13 +104,143 @@ func (s *applicationService) SaveApplication(ctx context.Context, application *m
 }
 
 // DeleteApplication 删除应用
-// 处理删除应用的业务逻辑（软删除）
+// 先创建删除任务再软删除应用，应用删除失败时撤销任务
 // 参数：ctx - 上下文，id - 要删除的应用 UUID
-// 返回：错误信息
-func (s *applicationService) DeleteApplication(ctx context.Context, id uuid.UUID) error {
-	// 这里可以添加业务规则验证逻辑
-	// 例如：检查应用是否存在、验证删除权限等
-	return s.appRepo.DeleteByID(ctx, id)
+// 返回：删除任务和错误信息
+func (s *applicationService) DeleteApplication(ctx context.Context, id uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	if _, err := s.appRepo.GetByID(ctx, id); err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "应用不存在", err)
+	}
+
+	job := &models.ApplicationDeletionJob{
+		ApplicationID: id,
+		Status:        define.ApplicationDeletionJobStatusPending,
+		TotalSteps:    len(repository.ApplicationDeletionSteps()),
+		DeletedCounts: "{}",
+	}
+	job.ID = uuid.New()
+	if err := s.deletionJobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("创建应用删除任务失败: %w", err)
+	}
+	if err := s.appRepo.DeleteByID(ctx, id); err != nil {
+		if cleanupErr := s.deletionJobRepo.HardDeleteByID(ctx, job.ID); cleanupErr != nil {
+			log.Printf("撤销应用删除任务 %s 失败: %v", job.ID, cleanupErr)
+		}
+		return nil, fmt.Errorf("删除应用失败: %w", err)
+	}
+	return job, nil
+}
+
+// GetDeletionJob 获取应用最近的删除任务
+func (s *applicationService) GetDeletionJob(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	job, err := s.deletionJobRepo.GetLatestByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("获取应用删除任务失败: %w", err)
+	}
+	if job == nil {
+		return nil, apperror.New(apperror.CodeNotFound, "应用删除任务不存在")
+	}
+	return job, nil
+}
+
+// ProcessDeletionJobs 处理等待中和执行中的删除任务
+// 执行出错时记录失败原因，任务保持执行中，下次从未完成的步骤继续
+func (s *applicationService) ProcessDeletionJobs(ctx context.Context) error {
+	for ctx.Err() == nil {
+		job, err := s.deletionJobRepo.GetEarliestByStatuses(ctx, []string{define.ApplicationDeletionJobStatusRunning, define.ApplicationDeletionJobStatusPending})
+		if err != nil {
+			return fmt.Errorf("获取待处理的应用删除任务失败: %w", err)
+		}
+		if job == nil {
+			return nil
+		}
+		if err := s.processDeletionJob(ctx, job); err != nil {
+			if updateErr := s.deletionJobRepo.UpdateErrorMessage(ctx, job.ID, err.Error()); updateErr != nil {
+				log.Printf("记录应用删除任务 %s 失败原因失败: %v", job.ID, updateErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// processDeletionJob 执行单个应用删除任务
+// 按步骤顺序分批删除关联数据，每批删除后更新进度
+func (s *applicationService) processDeletionJob(ctx context.Context, job *models.ApplicationDeletionJob) error {
+	if job.Status == define.ApplicationDeletionJobStatusPending {
+		now := time.Now()
+		if _, err := s.deletionJobRepo.UpdateStatus(ctx, job.ID, []string{define.ApplicationDeletionJobStatusPending},
+			define.ApplicationDeletionJobStatusRunning, &now, nil); err != nil {
+			return fmt.Errorf("更新应用删除任务状态失败: %w", err)
+		}
+	}
+
+	deletedCounts := make(map[string]int64)
+	if job.DeletedCounts != "" {
+		if err := json.Unmarshal([]byte(job.DeletedCounts), &deletedCounts); err != nil {
+			return fmt.Errorf("解析应用删除任务进度失败: %w", err)
+		}
+	}
+	batchSize := s.config.Deletion.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultApplicationDeletionBatchSize
+	}
+
+	steps := repository.ApplicationDeletionSteps()
+	log.Printf("开始处理应用删除任务 %s，应用 %s，从第 %d 步继续", job.ID, job.ApplicationID, job.CompletedSteps+1)
+	for index := job.CompletedSteps; index < len(steps); index++ {
+		step := steps[index]
+		for {
+			if ctx.Err() != nil {
+				// 服务停止，下次从当前步骤继续
+				return nil
+			}
+			deleted, err := s.appRepo.DeleteDependentsBatch(ctx, job.ApplicationID, step, batchSize)
+			if err != nil {
+				return fmt.Errorf("删除应用关联数据 %s 失败: %w", step, err)
+			}
+			deletedCounts[step] += deleted
+			if deleted < int64(batchSize) {
+				break
+			}
+			if err := s.updateDeletionProgress(ctx, job.ID, index, step, deletedCounts); err != nil {
+				return err
+			}
+		}
+
+		nextStep := ""
+		if index+1 < len(steps) {
+			nextStep = steps[index+1]
+		}
+		if err := s.updateDeletionProgress(ctx, job.ID, index+1, nextStep, deletedCounts); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	if _, err := s.deletionJobRepo.UpdateStatus(ctx, job.ID, []string{define.ApplicationDeletionJobStatusRunning},
+		define.ApplicationDeletionJobStatusCompleted, nil, &now); err != nil {
+		return fmt.Errorf("更新应用删除任务状态失败: %w", err)
+	}
+	if job.ErrorMessage != "" {
+		if err := s.deletionJobRepo.UpdateErrorMessage(ctx, job.ID, ""); err != nil {
+			return fmt.Errorf("清除应用删除任务失败原因失败: %w", err)
+		}
+	}
+	log.Printf("应用删除任务 %s 已完成", job.ID)
+	return nil
+}
+
+// updateDeletionProgress 更新删除任务的进度
+func (s *applicationService) updateDeletionProgress(ctx context.Context, jobID uuid.UUID, completedSteps int, currentStep string, deletedCounts map[string]int64) error {
+	counts, err := json.Marshal(deletedCounts)
+	if err != nil {
+		return fmt.Errorf("序列化应用删除任务进度失败: %w", err)
+	}
+	if err := s.deletionJobRepo.UpdateProgress(ctx, jobID, completedSteps, currentStep, string(counts)); err != nil {
+		return fmt.Errorf("更新应用删除任务进度失败: %w", err)
+	}
+	return nil
 }
 
 // QueryApplications 动态查询应用
@@ -0,0 +1,238 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// 应用设置字段的长度和取值上限，与数据库字段长度一致
+const (
+	maxApplicationDisplayNameLength = 64
+	maxApplicationLogoUrlLength     = 512
+	maxApplicationDataRetentionDays = 3650
+	maxApplicationBlockedPatterns   = 100
+	maxApplicationBlockedPatternLen = 200
+	maxApplicationBlockedReplyLen   = 512
+)
+
+// defaultApplicationBlockedReply 用户输入被拦截且应用未设置拦截回复时的默认回复
+const defaultApplicationBlockedReply = "抱歉，您的消息包含不允许的内容，无法为您回答。"
+
+// 应用设置字段格式
+var (
+	applicationThemeColorPattern      = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+	applicationDefaultLanguagePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8}){0,3}$`)
+)
+
+// ApplicationSettingService 应用设置 业务逻辑层接口
+// 每个应用最多一条设置，未保存过设置的应用返回默认值
+type ApplicationSettingService interface {
+	// GetApplicationSetting 获取应用设置，未保存过设置时返回默认值（ID 为空）
+	GetApplicationSetting(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationSetting, error)
+
+	// SaveApplicationSetting 保存应用设置，存在则覆盖，不存在则创建
+	SaveApplicationSetting(ctx context.Context, setting *models.ApplicationSetting) error
+
+	// DeleteApplicationSetting 删除应用设置，恢复为默认值
+	DeleteApplicationSetting(ctx context.Context, applicationID uuid.UUID) error
+
+	// MatchBlockedInput 判断用户消息是否命中应用的输入拦截规则
+	// 返回：命中时返回拦截回复和 true，未配置规则或未命中时返回 false
+	MatchBlockedInput(ctx context.Context, applicationID uuid.UUID, message string) (string, bool, error)
+}
+
+// applicationSettingService 应用设置 业务逻辑层实现
+type applicationSettingService struct {
+	settingRepo repository.ApplicationSettingRepository // 应用设置数据访问层
+	appRepo     repository.ApplicationRepository        // 应用数据访问层
+}
+
+// NewApplicationSettingService 创建 应用设置 服务实例
+// 返回 ApplicationSettingService 接口的实现
+// 参数：settingRepo - 应用设置数据访问层，appRepo - 应用数据访问层
+func NewApplicationSettingService(settingRepo repository.ApplicationSettingRepository, appRepo repository.ApplicationRepository) ApplicationSettingService {
+	return &applicationSettingService{
+		settingRepo: settingRepo,
+		appRepo:     appRepo,
+	}
+}
+
+// GetApplicationSetting 获取应用设置
+func (s *applicationSettingService) GetApplicationSetting(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationSetting, error) {
+	setting, err := s.settingRepo.GetByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询应用设置失败", err)
+	}
+	if setting == nil {
+		setting = &models.ApplicationSetting{ApplicationID: applicationID}
+	}
+	return setting, nil
+}
+
+// SaveApplicationSetting 保存应用设置
+// 保留已有记录的ID和创建时间，覆盖其他字段
+func (s *applicationSettingService) SaveApplicationSetting(ctx context.Context, setting *models.ApplicationSetting) error {
+	setting.DisplayName = strings.TrimSpace(setting.DisplayName)
+	setting.LogoUrl = strings.TrimSpace(setting.LogoUrl)
+	setting.ThemeColor = strings.TrimSpace(setting.ThemeColor)
+	setting.DefaultLanguage = strings.TrimSpace(setting.DefaultLanguage)
+	setting.BlockedReply = strings.TrimSpace(setting.BlockedReply)
+	if err := validateApplicationSetting(setting); err != nil {
+		return err
+	}
+	if err := normalizeBlockedPatterns(setting); err != nil {
+		return err
+	}
+	if _, err := s.appRepo.GetByID(ctx, setting.ApplicationID); err != nil {
+		return apperror.New(apperror.CodeNotFound, "应用不存在")
+	}
+
+	existing, err := s.settingRepo.GetByApplicationID(ctx, setting.ApplicationID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "查询应用设置失败", err)
+	}
+	if existing == nil {
+		setting.ID = uuid.New()
+		if err := s.settingRepo.Create(ctx, setting); err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "创建应用设置失败", err)
+		}
+		return nil
+	}
+
+	setting.ID = existing.ID
+	setting.CreatedAt = existing.CreatedAt
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "更新应用设置失败", err)
+	}
+	return nil
+}
+
+// DeleteApplicationSetting 删除应用设置
+func (s *applicationSettingService) DeleteApplicationSetting(ctx context.Context, applicationID uuid.UUID) error {
+	if err := s.settingRepo.DeleteByApplicationID(ctx, applicationID); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "删除应用设置失败", err)
+	}
+	return nil
+}
+
+// MatchBlockedInput 判断用户消息是否命中应用的输入拦截规则
+// 规则保存时已校验，个别规则无法解析时跳过该规则
+func (s *applicationSettingService) MatchBlockedInput(ctx context.Context, applicationID uuid.UUID, message string) (string, bool, error) {
+	setting, err := s.GetApplicationSetting(ctx, applicationID)
+	if err != nil {
+		return "", false, err
+	}
+	patterns, err := parseBlockedPatterns(setting.BlockedPatterns)
+	if err != nil || len(patterns) == 0 {
+		return "", false, nil
+	}
+	for _, pattern := range patterns {
+		re, err := compileBlockedPattern(pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(message) {
+			if setting.BlockedReply != "" {
+				return setting.BlockedReply, true, nil
+			}
+			return defaultApplicationBlockedReply, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// validateApplicationSetting 校验应用设置
+// 字段为空时使用默认值，不为空时校验格式
+func validateApplicationSetting(setting *models.ApplicationSetting) error {
+	if setting.ApplicationID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "所属应用ID不能为空")
+	}
+	if utf8.RuneCountInString(setting.DisplayName) > maxApplicationDisplayNameLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "展示名称长度不能超过%d个字符", maxApplicationDisplayNameLength)
+	}
+	if setting.LogoUrl != "" {
+		if len(setting.LogoUrl) > maxApplicationLogoUrlLength {
+			return apperror.Newf(apperror.CodeInvalidArgument, "Logo地址长度不能超过%d个字符", maxApplicationLogoUrlLength)
+		}
+		logoUrl, err := url.Parse(setting.LogoUrl)
+		if err != nil || (logoUrl.Scheme != "http" && logoUrl.Scheme != "https") || logoUrl.Host == "" {
+			return apperror.New(apperror.CodeInvalidArgument, "Logo地址必须是有效的 http 或 https 地址")
+		}
+	}
+	if setting.ThemeColor != "" && !applicationThemeColorPattern.MatchString(setting.ThemeColor) {
+		return apperror.New(apperror.CodeInvalidArgument, "主题色必须是 #RRGGBB 格式")
+	}
+	if setting.DefaultLanguage != "" && !applicationDefaultLanguagePattern.MatchString(setting.DefaultLanguage) {
+		return apperror.New(apperror.CodeInvalidArgument, "默认语言必须是有效的语言代码，如 zh、en、zh-CN")
+	}
+	if setting.DataRetentionDays < 0 || setting.DataRetentionDays > maxApplicationDataRetentionDays {
+		return apperror.Newf(apperror.CodeInvalidArgument, "数据保留天数必须在0到%d之间，0表示永久保留", maxApplicationDataRetentionDays)
+	}
+	if utf8.RuneCountInString(setting.BlockedReply) > maxApplicationBlockedReplyLen {
+		return apperror.Newf(apperror.CodeInvalidArgument, "拦截回复长度不能超过%d个字符", maxApplicationBlockedReplyLen)
+	}
+	return nil
+}
+
+// normalizeBlockedPatterns 校验并规范化用户输入拦截规则，去除空白和重复的规则
+func normalizeBlockedPatterns(setting *models.ApplicationSetting) error {
+	patterns, err := parseBlockedPatterns(setting.BlockedPatterns)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "输入拦截规则格式错误", err)
+	}
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || containsString(normalized, pattern) {
+			continue
+		}
+		if utf8.RuneCountInString(pattern) > maxApplicationBlockedPatternLen {
+			return apperror.Newf(apperror.CodeInvalidArgument, "输入拦截规则长度不能超过%d个字符", maxApplicationBlockedPatternLen)
+		}
+		if _, err := compileBlockedPattern(pattern); err != nil {
+			return apperror.Newf(apperror.CodeInvalidArgument, "输入拦截规则 %s 不是有效的正则表达式", pattern)
+		}
+		normalized = append(normalized, pattern)
+	}
+	if len(normalized) > maxApplicationBlockedPatterns {
+		return apperror.Newf(apperror.CodeInvalidArgument, "输入拦截规则不能超过%d条", maxApplicationBlockedPatterns)
+	}
+	if len(normalized) == 0 {
+		setting.BlockedPatterns = ""
+		return nil
+	}
+	content, err := json.Marshal(normalized)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "序列化输入拦截规则失败", err)
+	}
+	setting.BlockedPatterns = string(content)
+	return nil
+}
+
+// parseBlockedPatterns 解析用户输入拦截规则列表
+func parseBlockedPatterns(blockedPatterns string) ([]string, error) {
+	patterns := []string{}
+	if blockedPatterns == "" {
+		return patterns, nil
+	}
+	if err := json.Unmarshal([]byte(blockedPatterns), &patterns); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
+// compileBlockedPattern 编译用户输入拦截规则，忽略大小写匹配
+func compileBlockedPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
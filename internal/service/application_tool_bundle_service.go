@@ -0,0 +1,235 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationToolBundleService 应用工具集 业务逻辑层接口
+// 工具集在读取智能体的工具时展开，修改工具集包含的工具对所有关联的智能体立即生效
+type ApplicationToolBundleService interface {
+	// SaveToolBundle 保存工具集及其包含的MCP工具
+	// 如果ID为空则新增，否则更新现有记录；工具必须属于工具集所在的应用
+	SaveToolBundle(ctx context.Context, bundle *models.ApplicationToolBundle, toolIDs []uuid.UUID) error
+
+	// DeleteToolBundle 删除工具集，同时取消与所有智能体的关联
+	DeleteToolBundle(ctx context.Context, id uuid.UUID) error
+
+	// GetToolBundle 获取工具集及其包含的MCP工具ID和关联的智能体ID
+	GetToolBundle(ctx context.Context, id uuid.UUID) (*models.ApplicationToolBundle, []uuid.UUID, []uuid.UUID, error)
+
+	// GetToolBundlesByApplicationID 获取应用的全部工具集
+	GetToolBundlesByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationToolBundle, error)
+
+	// GetToolBundlesByChatAgentID 获取智能体关联的工具集
+	GetToolBundlesByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationToolBundle, error)
+
+	// AttachChatAgents 将工具集关联到多个智能体，智能体必须属于工具集所在的应用
+	AttachChatAgents(ctx context.Context, id uuid.UUID, chatAgentIDs []uuid.UUID) error
+
+	// DetachChatAgents 取消工具集与多个智能体的关联
+	DetachChatAgents(ctx context.Context, id uuid.UUID, chatAgentIDs []uuid.UUID) error
+}
+
+// applicationToolBundleService 应用工具集 业务逻辑层实现
+type applicationToolBundleService struct {
+	toolBundleRepo  repository.ApplicationToolBundleRepository
+	applicationRepo repository.ApplicationRepository
+	mcpToolRepo     repository.ApplicationMcpServerToolRepository
+	chatAgentRepo   repository.ChatAgentRepository
+}
+
+// NewApplicationToolBundleService 创建 应用工具集 服务实例
+// 返回 ApplicationToolBundleService 接口的实现
+func NewApplicationToolBundleService(
+	toolBundleRepo repository.ApplicationToolBundleRepository,
+	applicationRepo repository.ApplicationRepository,
+	mcpToolRepo repository.ApplicationMcpServerToolRepository,
+	chatAgentRepo repository.ChatAgentRepository,
+) ApplicationToolBundleService {
+	return &applicationToolBundleService{
+		toolBundleRepo:  toolBundleRepo,
+		applicationRepo: applicationRepo,
+		mcpToolRepo:     mcpToolRepo,
+		chatAgentRepo:   chatAgentRepo,
+	}
+}
+
+// SaveToolBundle 保存工具集及其包含的MCP工具
+// 工具集名称在应用内唯一，重复的工具ID只保留一个
+func (s *applicationToolBundleService) SaveToolBundle(ctx context.Context, bundle *models.ApplicationToolBundle, toolIDs []uuid.UUID) error {
+	bundle.Name = strings.TrimSpace(bundle.Name)
+	if bundle.Name == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "工具集名称不能为空")
+	}
+
+	if bundle.ID != uuid.Nil {
+		existing, err := s.toolBundleRepo.GetByID(ctx, bundle.ID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "工具集不存在", err)
+		}
+		if existing.ApplicationID != bundle.ApplicationID {
+			return apperror.New(apperror.CodeInvalidArgument, "工具集不属于该应用")
+		}
+		bundle.CreatedAt = existing.CreatedAt
+	} else if _, err := s.applicationRepo.GetByID(ctx, bundle.ApplicationID); err != nil {
+		return referenceLookupError("application_id", bundle.ApplicationID, "应用", err)
+	}
+
+	sameName, err := s.toolBundleRepo.GetByApplicationIDAndName(ctx, bundle.ApplicationID, bundle.Name)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "查询工具集失败", err)
+	}
+	if sameName != nil && sameName.ID != bundle.ID {
+		return apperror.Newf(apperror.CodeConflict, "工具集名称已存在: %s", bundle.Name)
+	}
+
+	uniqueToolIDs := make([]uuid.UUID, 0, len(toolIDs))
+	seen := make(map[uuid.UUID]bool, len(toolIDs))
+	for _, toolID := range toolIDs {
+		if seen[toolID] {
+			continue
+		}
+		seen[toolID] = true
+		tool, err := s.mcpToolRepo.GetByID(ctx, toolID)
+		if err != nil {
+			return referenceLookupError("tool_ids", toolID, "MCP工具", err)
+		}
+		if tool.ApplicationID != bundle.ApplicationID {
+			return invalidReferenceError("tool_ids", toolID, "MCP工具不属于工具集所在的应用")
+		}
+		uniqueToolIDs = append(uniqueToolIDs, toolID)
+	}
+
+	if bundle.ID == uuid.Nil {
+		bundle.ID = uuid.New()
+	}
+	if err := s.toolBundleRepo.SaveWithTools(ctx, bundle, uniqueToolIDs); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "保存工具集失败", err)
+	}
+	return nil
+}
+
+// DeleteToolBundle 删除工具集，同时取消与所有智能体的关联
+func (s *applicationToolBundleService) DeleteToolBundle(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.toolBundleRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "工具集不存在", err)
+	}
+	if err := s.toolBundleRepo.DeleteWithDependents(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "删除工具集失败", err)
+	}
+	return nil
+}
+
+// GetToolBundle 获取工具集及其包含的MCP工具ID和关联的智能体ID
+func (s *applicationToolBundleService) GetToolBundle(ctx context.Context, id uuid.UUID) (*models.ApplicationToolBundle, []uuid.UUID, []uuid.UUID, error) {
+	bundle, err := s.toolBundleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, nil, apperror.Wrap(apperror.CodeNotFound, "工具集不存在", err)
+	}
+	toolIDs, err := s.toolBundleRepo.GetToolIDs(ctx, id)
+	if err != nil {
+		return nil, nil, nil, apperror.Wrap(apperror.CodeInternal, "查询工具集的工具失败", err)
+	}
+	chatAgentIDs, err := s.toolBundleRepo.GetChatAgentIDs(ctx, id)
+	if err != nil {
+		return nil, nil, nil, apperror.Wrap(apperror.CodeInternal, "查询工具集关联的智能体失败", err)
+	}
+	return bundle, toolIDs, chatAgentIDs, nil
+}
+
+// GetToolBundlesByApplicationID 获取应用的全部工具集
+func (s *applicationToolBundleService) GetToolBundlesByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	return s.toolBundleRepo.GetByApplicationID(ctx, applicationID)
+}
+
+// GetToolBundlesByChatAgentID 获取智能体关联的工具集
+func (s *applicationToolBundleService) GetToolBundlesByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	if _, err := s.chatAgentRepo.GetByID(ctx, chatAgentID); err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	return s.toolBundleRepo.GetByChatAgentID(ctx, chatAgentID)
+}
+
+// AttachChatAgents 将工具集关联到多个智能体
+// 任一智能体不存在或不属于工具集所在的应用时不做任何关联
+func (s *applicationToolBundleService) AttachChatAgents(ctx context.Context, id uuid.UUID, chatAgentIDs []uuid.UUID) error {
+	bundle, err := s.toolBundleRepo.GetByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "工具集不存在", err)
+	}
+	if len(chatAgentIDs) == 0 {
+		return apperror.New(apperror.CodeInvalidArgument, "请选择要关联的智能体")
+	}
+	for _, chatAgentID := range chatAgentIDs {
+		chatAgent, err := s.chatAgentRepo.GetByID(ctx, chatAgentID)
+		if err != nil {
+			return referenceLookupError("chat_agent_ids", chatAgentID, "智能体", err)
+		}
+		if chatAgent.ApplicationID != bundle.ApplicationID {
+			return invalidReferenceError("chat_agent_ids", chatAgentID, "智能体不属于工具集所在的应用")
+		}
+	}
+	if err := s.toolBundleRepo.AttachChatAgents(ctx, bundle, chatAgentIDs); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "关联智能体失败", err)
+	}
+	return nil
+}
+
+// DetachChatAgents 取消工具集与多个智能体的关联
+func (s *applicationToolBundleService) DetachChatAgents(ctx context.Context, id uuid.UUID, chatAgentIDs []uuid.UUID) error {
+	if _, err := s.toolBundleRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "工具集不存在", err)
+	}
+	if err := s.toolBundleRepo.DetachChatAgents(ctx, id, chatAgentIDs); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "取消关联智能体失败", err)
+	}
+	return nil
+}
+
+// getEnabledMcpToolIDs 获取智能体提供给模型的MCP工具ID
+// 先是智能体自身启用的工具，再按工具集名称顺序追加关联工具集中的工具，重复的工具只保留一个
+func getEnabledMcpToolIDs(
+	ctx context.Context,
+	chatAgentMcpServerToolRepo repository.ChatAgentMcpServerToolRepository,
+	toolBundleRepo repository.ApplicationToolBundleRepository,
+	chatAgentID uuid.UUID,
+) ([]uuid.UUID, error) {
+	settings, err := chatAgentMcpServerToolRepo.GetByChatAgentID(ctx, chatAgentID)
+	if err != nil {
+		return nil, err
+	}
+	var toolIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, setting := range settings {
+		if setting.Enabled && !seen[setting.ApplicationMcpServerToolID] {
+			seen[setting.ApplicationMcpServerToolID] = true
+			toolIDs = append(toolIDs, setting.ApplicationMcpServerToolID)
+		}
+	}
+
+	bundles, err := toolBundleRepo.GetByChatAgentID(ctx, chatAgentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundle := range bundles {
+		bundleToolIDs, err := toolBundleRepo.GetToolIDs(ctx, bundle.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, toolID := range bundleToolIDs {
+			if !seen[toolID] {
+				seen[toolID] = true
+				toolIDs = append(toolIDs, toolID)
+			}
+		}
+	}
+	return toolIDs, nil
+}
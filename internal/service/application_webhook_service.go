@@ -0,0 +1,253 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// 应用 Webhook 字段的长度和数量上限，与数据库字段长度一致
+const (
+	maxApplicationWebhookCount      = 20
+	maxApplicationWebhookNameLength = 64
+	maxApplicationWebhookUrlLength  = 1024
+)
+
+// ApplicationWebhookService 应用 Webhook 业务逻辑层接口
+// 管理应用订阅会话事件的 Webhook，以及查看和重新推送推送记录
+type ApplicationWebhookService interface {
+	// SaveWebhook 保存 Webhook
+	// 如果ID为空则新增并生成签名密钥，否则更新现有记录，regenerateSecret 为 true 时重新生成签名密钥
+	SaveWebhook(ctx context.Context, webhook *models.ApplicationWebhook, regenerateSecret bool) error
+
+	// DeleteWebhook 删除 Webhook，等待推送的记录在推送时标记为失败
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+
+	// GetWebhook 获取 Webhook
+	GetWebhook(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error)
+
+	// GetWebhooksByApplicationID 获取应用的全部 Webhook
+	GetWebhooksByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error)
+
+	// GetDeliveries 分页获取 Webhook 的推送记录，status 为空时不筛选状态
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, pageSize int) ([]*models.ApplicationWebhookDelivery, int64, error)
+
+	// RetryDelivery 重新推送推送记录，重置已推送次数并立即推送
+	RetryDelivery(ctx context.Context, deliveryID uuid.UUID) (*models.ApplicationWebhookDelivery, error)
+}
+
+// applicationWebhookService 应用 Webhook 业务逻辑层实现
+type applicationWebhookService struct {
+	webhookRepo     repository.ApplicationWebhookRepository         // 应用 Webhook 数据访问层
+	deliveryRepo    repository.ApplicationWebhookDeliveryRepository // Webhook 推送记录数据访问层
+	applicationRepo repository.ApplicationRepository                // 应用数据访问层
+}
+
+// NewApplicationWebhookService 创建 应用 Webhook 服务实例
+// 返回 ApplicationWebhookService 接口的实现
+func NewApplicationWebhookService(
+	webhookRepo repository.ApplicationWebhookRepository,
+	deliveryRepo repository.ApplicationWebhookDeliveryRepository,
+	applicationRepo repository.ApplicationRepository,
+) ApplicationWebhookService {
+	return &applicationWebhookService{
+		webhookRepo:     webhookRepo,
+		deliveryRepo:    deliveryRepo,
+		applicationRepo: applicationRepo,
+	}
+}
+
+// SaveWebhook 保存 Webhook
+// 更新时保留原有的签名密钥，除非要求重新生成
+func (s *applicationWebhookService) SaveWebhook(ctx context.Context, webhook *models.ApplicationWebhook, regenerateSecret bool) error {
+	webhook.Name = strings.TrimSpace(webhook.Name)
+	webhook.Url = strings.TrimSpace(webhook.Url)
+	if err := validateApplicationWebhook(webhook); err != nil {
+		return err
+	}
+	if _, err := s.applicationRepo.GetByID(ctx, webhook.ApplicationID); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "应用不存在", err)
+	}
+
+	if webhook.ID == uuid.Nil {
+		webhooks, err := s.webhookRepo.GetByApplicationID(ctx, webhook.ApplicationID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "查询Webhook失败", err)
+		}
+		if len(webhooks) >= maxApplicationWebhookCount {
+			return apperror.Newf(apperror.CodeInvalidArgument, "每个应用最多创建%d个Webhook", maxApplicationWebhookCount)
+		}
+		secret, err := generateRandomToken()
+		if err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "生成签名密钥失败", err)
+		}
+		webhook.Secret = secret
+		if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "创建Webhook失败", err)
+		}
+		return nil
+	}
+
+	existing, err := s.webhookRepo.GetByID(ctx, webhook.ID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "Webhook不存在", err)
+	}
+	if existing.ApplicationID != webhook.ApplicationID {
+		return apperror.New(apperror.CodeInvalidArgument, "Webhook不属于该应用")
+	}
+	webhook.Secret = existing.Secret
+	if regenerateSecret {
+		secret, err := generateRandomToken()
+		if err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "生成签名密钥失败", err)
+		}
+		webhook.Secret = secret
+	}
+	webhook.CreatedAt = existing.CreatedAt
+	if err := s.webhookRepo.Save(ctx, webhook); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "更新Webhook失败", err)
+	}
+	return nil
+}
+
+// DeleteWebhook 删除 Webhook
+func (s *applicationWebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.webhookRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "Webhook不存在", err)
+	}
+	if err := s.webhookRepo.DeleteByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "删除Webhook失败", err)
+	}
+	return nil
+}
+
+// GetWebhook 获取 Webhook
+func (s *applicationWebhookService) GetWebhook(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "Webhook不存在", err)
+	}
+	return webhook, nil
+}
+
+// GetWebhooksByApplicationID 获取应用的全部 Webhook
+func (s *applicationWebhookService) GetWebhooksByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error) {
+	webhooks, err := s.webhookRepo.GetByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询Webhook失败", err)
+	}
+	return webhooks, nil
+}
+
+// GetDeliveries 分页获取 Webhook 的推送记录
+func (s *applicationWebhookService) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, pageSize int) ([]*models.ApplicationWebhookDelivery, int64, error) {
+	if status != "" && status != define.WebhookDeliveryStatusPending &&
+		status != define.WebhookDeliveryStatusDelivered && status != define.WebhookDeliveryStatusFailed {
+		return nil, 0, apperror.New(apperror.CodeInvalidArgument, "推送状态只能是 pending、delivered 或 failed")
+	}
+	if _, err := s.webhookRepo.GetByID(ctx, webhookID); err != nil {
+		return nil, 0, apperror.Wrap(apperror.CodeNotFound, "Webhook不存在", err)
+	}
+	deliveries, total, err := s.deliveryRepo.GetByWebhookIDWithPagination(ctx, webhookID, status, page, pageSize)
+	if err != nil {
+		return nil, 0, apperror.Wrap(apperror.CodeInternal, "查询推送记录失败", err)
+	}
+	return deliveries, total, nil
+}
+
+// RetryDelivery 重新推送推送记录
+// 推送成功的记录也可以重新推送，接收方按事件ID去重
+func (s *applicationWebhookService) RetryDelivery(ctx context.Context, deliveryID uuid.UUID) (*models.ApplicationWebhookDelivery, error) {
+	delivery, err := s.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "推送记录不存在", err)
+	}
+	if _, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID); err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "Webhook不存在", err)
+	}
+	delivery.Status = define.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.LastError = ""
+	delivery.DeliveredAt = nil
+	if err := s.deliveryRepo.Save(ctx, delivery); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "更新推送记录失败", err)
+	}
+	return delivery, nil
+}
+
+// validateApplicationWebhook 校验 Webhook，并规范化订阅的事件类型，去除重复的类型
+func validateApplicationWebhook(webhook *models.ApplicationWebhook) error {
+	if webhook.ApplicationID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "所属应用ID不能为空")
+	}
+	if webhook.Name == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "Webhook名称不能为空")
+	}
+	if utf8.RuneCountInString(webhook.Name) > maxApplicationWebhookNameLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "Webhook名称长度不能超过%d个字符", maxApplicationWebhookNameLength)
+	}
+	if len(webhook.Url) > maxApplicationWebhookUrlLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "推送地址长度不能超过%d个字符", maxApplicationWebhookUrlLength)
+	}
+	parsed, err := url.Parse(webhook.Url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "推送地址必须是有效的 http 或 https 地址")
+	}
+
+	eventTypes, err := parseWebhookEventTypes(webhook.EventTypes)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "订阅的事件类型格式错误", err)
+	}
+	normalized := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !containsString(define.ConversationEventTypes, eventType) {
+			return apperror.Newf(apperror.CodeInvalidArgument, "不支持的事件类型: %s", eventType)
+		}
+		if !containsString(normalized, eventType) {
+			normalized = append(normalized, eventType)
+		}
+	}
+	if len(normalized) == 0 {
+		webhook.EventTypes = ""
+		return nil
+	}
+	content, err := json.Marshal(normalized)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "序列化订阅的事件类型失败", err)
+	}
+	webhook.EventTypes = string(content)
+	return nil
+}
+
+// parseWebhookEventTypes 解析 Webhook 订阅的事件类型列表
+func parseWebhookEventTypes(eventTypes string) ([]string, error) {
+	list := []string{}
+	if eventTypes == "" {
+		return list, nil
+	}
+	if err := json.Unmarshal([]byte(eventTypes), &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// webhookSubscribes Webhook 是否订阅了事件类型，未设置事件类型时订阅全部事件
+func webhookSubscribes(webhook *models.ApplicationWebhook, eventType string) bool {
+	eventTypes, err := parseWebhookEventTypes(webhook.EventTypes)
+	if err != nil {
+		return false
+	}
+	return len(eventTypes) == 0 || containsString(eventTypes, eventType)
+}
@@ -0,0 +1,578 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 批量推理任务的限制
+const (
+	maxBatchInferenceItemCount       = 1000             // 单个任务的提示词数量上限
+	maxBatchInferencePromptLength    = 32000            // 单条提示词的字符数上限
+	defaultBatchInferenceConcurrency = 2                // 任务未指定并发数时使用的默认值
+	maxBatchInferenceConcurrency     = 8                // 任务可以指定的并发数上限
+	batchInferenceItemTimeout        = 10 * time.Minute // 单条提示词的处理超时时间
+	batchInferenceServiceUserPrefix  = "batch-inference:"
+)
+
+// BatchInferenceJobResult 批量推理任务的结果文件
+type BatchInferenceJobResult struct {
+	FileName    string // 下载文件名
+	ContentType string // 文件类型
+	Content     []byte // 文件内容
+}
+
+// BatchInferenceService 批量推理 业务逻辑层接口
+// 定义 批量推理 相关的业务逻辑方法
+type BatchInferenceService interface {
+	// CreateJob 创建批量推理任务
+	// 任务创建后处于等待处理状态，由后台任务按并发上限逐条处理
+	CreateJob(ctx context.Context, job *models.BatchInferenceJob, prompts []string) error
+
+	// ParsePromptFile 从上传文件中解析提示词
+	// 支持 .txt（每行一条）、.jsonl（每行一个字符串或包含 prompt 字段的对象）、.json（字符串或对象数组）和 .csv（第一列，表头为 prompt 时跳过）
+	ParsePromptFile(fileName string, reader io.Reader) ([]string, error)
+
+	// GetJob 获取批量推理任务
+	GetJob(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error)
+
+	// GetJobsByChatAgentID 获取智能体的批量推理任务列表（分页）
+	GetJobsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.BatchInferenceJob, int64, error)
+
+	// GetJobItems 获取任务的提示词及处理结果（分页）
+	// status 不为空时只返回该处理状态的提示词
+	GetJobItems(ctx context.Context, jobID uuid.UUID, status string, page, pageSize int) ([]*models.BatchInferenceItem, int64, error)
+
+	// CancelJob 取消等待处理或执行中的任务
+	// 正在处理的提示词会处理完成，其余提示词不再执行
+	CancelJob(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error)
+
+	// ExportJobResults 导出已结束任务的全部结果
+	// 参数：format - 结果格式：jsonl 或 csv
+	ExportJobResults(ctx context.Context, id uuid.UUID, format string) (*BatchInferenceJobResult, error)
+
+	// ProcessPendingJobs 处理等待中和执行中的任务
+	// 由后台定时任务调用，每次按创建时间依次处理，直到没有待处理的任务或上下文取消
+	ProcessPendingJobs(ctx context.Context) error
+}
+
+// batchInferenceService 批量推理 业务逻辑层实现
+// 实现 BatchInferenceService 接口
+type batchInferenceService struct {
+	jobRepo             repository.BatchInferenceJobRepository
+	itemRepo            repository.BatchInferenceItemRepository
+	chatAgentRepo       repository.ChatAgentRepository
+	applicationRepo     repository.ApplicationRepository
+	conversationService ChatAgentConversationService // 发送消息，与聊天接口使用同一处理流程
+	config              *config.Config               // 应用程序配置
+}
+
+// NewBatchInferenceService 创建 批量推理 服务实例
+// 返回 BatchInferenceService 接口的实现
+func NewBatchInferenceService(
+	jobRepo repository.BatchInferenceJobRepository,
+	itemRepo repository.BatchInferenceItemRepository,
+	chatAgentRepo repository.ChatAgentRepository,
+	applicationRepo repository.ApplicationRepository,
+	conversationService ChatAgentConversationService,
+	config *config.Config,
+) BatchInferenceService {
+	return &batchInferenceService{
+		jobRepo:             jobRepo,
+		itemRepo:            itemRepo,
+		chatAgentRepo:       chatAgentRepo,
+		applicationRepo:     applicationRepo,
+		conversationService: conversationService,
+		config:              config,
+	}
+}
+
+// CreateJob 创建批量推理任务
+// 任务创建后处于等待处理状态，由后台任务按并发上限逐条处理
+func (s *batchInferenceService) CreateJob(ctx context.Context, job *models.BatchInferenceJob, prompts []string) error {
+	if job.ChatAgentID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "智能体ID不能为空")
+	}
+	if err := validateBatchInferencePrompts(prompts); err != nil {
+		return err
+	}
+	if job.Concurrency == 0 {
+		job.Concurrency = defaultBatchInferenceConcurrency
+	}
+	if job.Concurrency < 1 || job.Concurrency > maxBatchInferenceConcurrency {
+		return apperror.Newf(apperror.CodeInvalidArgument, "并发数必须在1到%d之间", maxBatchInferenceConcurrency)
+	}
+	job.Name = strings.TrimSpace(job.Name)
+	if len([]rune(job.Name)) > 128 {
+		return apperror.New(apperror.CodeInvalidArgument, "任务名称不能超过128个字符")
+	}
+
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, job.ChatAgentID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+
+	job.ID = uuid.New()
+	job.ApplicationID = chatAgent.ApplicationID
+	job.Status = define.BatchInferenceJobStatusPending
+	job.TotalCount = len(prompts)
+	job.CompletedCount = 0
+	job.FailedCount = 0
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return fmt.Errorf("创建批量推理任务失败: %w", err)
+	}
+
+	items := make([]*models.BatchInferenceItem, 0, len(prompts))
+	for i, prompt := range prompts {
+		item := &models.BatchInferenceItem{
+			JobID:     job.ID,
+			ItemIndex: i,
+			Prompt:    strings.TrimSpace(prompt),
+			Status:    define.BatchInferenceItemStatusPending,
+		}
+		item.ID = uuid.New()
+		items = append(items, item)
+	}
+	if err := s.itemRepo.BatchCreate(ctx, items); err != nil {
+		// 提示词保存失败时删除任务，避免留下没有提示词的任务
+		if deleteErr := s.jobRepo.DeleteByID(ctx, job.ID); deleteErr != nil {
+			log.Printf("删除批量推理任务 %s 失败: %v", job.ID, deleteErr)
+		}
+		return fmt.Errorf("保存批量推理提示词失败: %w", err)
+	}
+	return nil
+}
+
+// ParsePromptFile 从上传文件中解析提示词
+// 支持 .txt（每行一条）、.jsonl（每行一个字符串或包含 prompt 字段的对象）、.json（字符串或对象数组）和 .csv（第一列，表头为 prompt 时跳过）
+func (s *batchInferenceService) ParsePromptFile(fileName string, reader io.Reader) ([]string, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\uFEFF"))
+
+	var prompts []string
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".txt":
+		for _, line := range strings.Split(string(content), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				prompts = append(prompts, line)
+			}
+		}
+	case ".jsonl":
+		for i, line := range strings.Split(string(content), "\n") {
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			prompt, err := parseBatchInferencePrompt(json.RawMessage(line))
+			if err != nil {
+				return nil, apperror.Wrap(apperror.CodeInvalidArgument, fmt.Sprintf("第%d行格式无效", i+1), err)
+			}
+			prompts = append(prompts, prompt)
+		}
+	case ".json":
+		var rawPrompts []json.RawMessage
+		if err := json.Unmarshal(content, &rawPrompts); err != nil {
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "JSON 文件必须是提示词数组", err)
+		}
+		for i, raw := range rawPrompts {
+			prompt, err := parseBatchInferencePrompt(raw)
+			if err != nil {
+				return nil, apperror.Wrap(apperror.CodeInvalidArgument, fmt.Sprintf("第%d个提示词格式无效", i+1), err)
+			}
+			prompts = append(prompts, prompt)
+		}
+	case ".csv":
+		csvReader := csv.NewReader(bytes.NewReader(content))
+		csvReader.FieldsPerRecord = -1
+		records, err := csvReader.ReadAll()
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "CSV 文件格式无效", err)
+		}
+		for i, record := range records {
+			if len(record) == 0 {
+				continue
+			}
+			prompt := strings.TrimSpace(record[0])
+			if i == 0 && strings.EqualFold(prompt, "prompt") {
+				continue
+			}
+			if prompt != "" {
+				prompts = append(prompts, prompt)
+			}
+		}
+	default:
+		return nil, apperror.New(apperror.CodeInvalidArgument, "仅支持 .txt、.jsonl、.json 和 .csv 文件")
+	}
+
+	if err := validateBatchInferencePrompts(prompts); err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// GetJob 获取批量推理任务
+func (s *batchInferenceService) GetJob(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "批量推理任务不存在", err)
+	}
+	return job, nil
+}
+
+// GetJobsByChatAgentID 获取智能体的批量推理任务列表（分页）
+func (s *batchInferenceService) GetJobsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.BatchInferenceJob, int64, error) {
+	return s.jobRepo.GetByChatAgentIDWithPagination(ctx, chatAgentID, page, pageSize)
+}
+
+// GetJobItems 获取任务的提示词及处理结果（分页）
+func (s *batchInferenceService) GetJobItems(ctx context.Context, jobID uuid.UUID, status string, page, pageSize int) ([]*models.BatchInferenceItem, int64, error) {
+	if _, err := s.GetJob(ctx, jobID); err != nil {
+		return nil, 0, err
+	}
+	return s.itemRepo.GetByJobIDWithPagination(ctx, jobID, status, page, pageSize)
+}
+
+// CancelJob 取消等待处理或执行中的任务
+// 正在处理的提示词会处理完成，其余提示词不再执行
+func (s *batchInferenceService) CancelJob(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error) {
+	if _, err := s.GetJob(ctx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cancelled, err := s.jobRepo.UpdateStatus(ctx, id,
+		[]string{define.BatchInferenceJobStatusPending, define.BatchInferenceJobStatusRunning},
+		define.BatchInferenceJobStatusCancelled, nil, &now)
+	if err != nil {
+		return nil, fmt.Errorf("取消批量推理任务失败: %w", err)
+	}
+	if !cancelled {
+		return nil, apperror.New(apperror.CodeConflict, "批量推理任务已结束")
+	}
+	if err := s.itemRepo.UpdateStatusByJobID(ctx, id, define.BatchInferenceItemStatusPending, define.BatchInferenceItemStatusCancelled); err != nil {
+		return nil, fmt.Errorf("取消批量推理提示词失败: %w", err)
+	}
+	return s.GetJob(ctx, id)
+}
+
+// ExportJobResults 导出已结束任务的全部结果
+func (s *batchInferenceService) ExportJobResults(ctx context.Context, id uuid.UUID, format string) (*BatchInferenceJobResult, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == define.BatchInferenceJobStatusPending || job.Status == define.BatchInferenceJobStatusRunning {
+		return nil, apperror.New(apperror.CodeConflict, "批量推理任务尚未结束，不能下载结果")
+	}
+
+	items, err := s.itemRepo.GetAllByJobID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("获取批量推理结果失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	result := &BatchInferenceJobResult{}
+	switch format {
+	case define.BatchInferenceResultFormatJsonl:
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		for _, item := range items {
+			if err := encoder.Encode(newBatchInferenceResultRecord(item)); err != nil {
+				return nil, fmt.Errorf("序列化批量推理结果失败: %w", err)
+			}
+		}
+		result.ContentType = "application/x-ndjson; charset=utf-8"
+	case define.BatchInferenceResultFormatCsv:
+		// 写入 UTF-8 BOM，便于表格软件正确识别中文
+		buf.WriteString("\uFEFF")
+		writer := csv.NewWriter(&buf)
+		writer.Write([]string{"index", "prompt", "status", "answer", "error_message", "conversation_id", "duration_ms"})
+		for _, item := range items {
+			writer.Write([]string{
+				strconv.Itoa(item.ItemIndex),
+				item.Prompt,
+				item.Status,
+				item.Answer,
+				item.ErrorMessage,
+				item.ConversationID,
+				strconv.FormatInt(item.DurationMs, 10),
+			})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, fmt.Errorf("生成 CSV 结果失败: %w", err)
+		}
+		result.ContentType = "text/csv; charset=utf-8"
+	default:
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "不支持的结果格式: %s", format)
+	}
+
+	result.FileName = fmt.Sprintf("batch-inference-%s.%s", job.ID, format)
+	result.Content = buf.Bytes()
+	return result, nil
+}
+
+// ProcessPendingJobs 处理等待中和执行中的任务
+// 由后台定时任务调用，每次按创建时间依次处理，直到没有待处理的任务或上下文取消
+func (s *batchInferenceService) ProcessPendingJobs(ctx context.Context) error {
+	for ctx.Err() == nil {
+		job, err := s.jobRepo.GetEarliestByStatuses(ctx, []string{define.BatchInferenceJobStatusRunning, define.BatchInferenceJobStatusPending})
+		if err != nil {
+			return fmt.Errorf("获取待处理的批量推理任务失败: %w", err)
+		}
+		if job == nil {
+			return nil
+		}
+		if err := s.processJob(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processJob 执行单个批量推理任务
+// 执行中的任务可能是服务重启前中断的，先把处理中的提示词重新放回等待队列
+func (s *batchInferenceService) processJob(ctx context.Context, job *models.BatchInferenceJob) error {
+	if job.Status == define.BatchInferenceJobStatusPending {
+		now := time.Now()
+		started, err := s.jobRepo.UpdateStatus(ctx, job.ID, []string{define.BatchInferenceJobStatusPending},
+			define.BatchInferenceJobStatusRunning, &now, nil)
+		if err != nil {
+			return fmt.Errorf("更新批量推理任务状态失败: %w", err)
+		}
+		if !started {
+			// 任务在开始前已被取消
+			return nil
+		}
+	}
+	if err := s.itemRepo.UpdateStatusByJobID(ctx, job.ID, define.BatchInferenceItemStatusRunning, define.BatchInferenceItemStatusPending); err != nil {
+		return fmt.Errorf("重置批量推理提示词状态失败: %w", err)
+	}
+
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, job.ChatAgentID)
+	if err != nil {
+		return s.failJob(ctx, job, "智能体不存在")
+	}
+	application, err := s.applicationRepo.GetByID(ctx, chatAgent.ApplicationID)
+	if err != nil {
+		return s.failJob(ctx, job, "智能体所属应用不存在")
+	}
+	// 与聊天接口一样通过上下文传递当前智能体和应用
+	jobCtx := context.WithValue(ctx, define.AppContextKeyCurrentChatAgent, chatAgent)
+	jobCtx = context.WithValue(jobCtx, define.AppContextKeyCurrentApplication, application)
+
+	concurrency := job.Concurrency
+	if maxConcurrency := s.config.Batch.MaxConcurrency; maxConcurrency > 0 && concurrency > maxConcurrency {
+		concurrency = maxConcurrency
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	log.Printf("开始处理批量推理任务 %s，并发数 %d", job.ID, concurrency)
+	for ctx.Err() == nil {
+		// 每批开始前检查任务是否已被取消
+		current, err := s.jobRepo.GetByID(ctx, job.ID)
+		if err != nil {
+			return fmt.Errorf("获取批量推理任务失败: %w", err)
+		}
+		if current.Status != define.BatchInferenceJobStatusRunning {
+			log.Printf("批量推理任务 %s 已停止，状态: %s", job.ID, current.Status)
+			return nil
+		}
+
+		items, err := s.itemRepo.GetByJobIDAndStatus(ctx, job.ID, define.BatchInferenceItemStatusPending, concurrency)
+		if err != nil {
+			return fmt.Errorf("获取待处理的批量推理提示词失败: %w", err)
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		ids := make([]uuid.UUID, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		if err := s.itemRepo.UpdateStatusByIDs(ctx, ids, define.BatchInferenceItemStatusRunning); err != nil {
+			return fmt.Errorf("更新批量推理提示词状态失败: %w", err)
+		}
+
+		var wg sync.WaitGroup
+		for _, item := range items {
+			wg.Add(1)
+			go func(item *models.BatchInferenceItem) {
+				defer wg.Done()
+				s.processItem(jobCtx, job, item)
+			}(item)
+		}
+		wg.Wait()
+
+		if err := s.refreshJobProgress(ctx, job.ID); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		// 服务停止时保持执行中状态，重启后继续处理
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := s.jobRepo.UpdateStatus(ctx, job.ID, []string{define.BatchInferenceJobStatusRunning},
+		define.BatchInferenceJobStatusCompleted, nil, &now); err != nil {
+		return fmt.Errorf("更新批量推理任务状态失败: %w", err)
+	}
+	log.Printf("批量推理任务 %s 处理完成", job.ID)
+	return nil
+}
+
+// processItem 发送单条提示词并保存处理结果
+// 服务停止导致的中断不保存结果，提示词保持处理中状态，重启后重新处理
+func (s *batchInferenceService) processItem(ctx context.Context, job *models.BatchInferenceJob, item *models.BatchInferenceItem) {
+	itemCtx, cancel := context.WithTimeout(ctx, batchInferenceItemTimeout)
+	defer cancel()
+
+	start := time.Now()
+	answer, conversationID, err := s.sendPrompt(itemCtx, job, item.Prompt)
+	if ctx.Err() != nil {
+		return
+	}
+
+	item.DurationMs = time.Since(start).Milliseconds()
+	item.ConversationID = conversationID
+	if err != nil {
+		item.Status = define.BatchInferenceItemStatusFailed
+		item.ErrorMessage = err.Error()
+		item.Answer = answer
+	} else {
+		item.Status = define.BatchInferenceItemStatusCompleted
+		item.ErrorMessage = ""
+		item.Answer = answer
+	}
+	if err := s.itemRepo.UpdateResult(context.WithoutCancel(ctx), item); err != nil {
+		log.Printf("保存批量推理提示词 %s 的结果失败: %v", item.ID, err)
+	}
+}
+
+// sendPrompt 通过聊天流程发送提示词，返回回答和会话ID
+// 每条提示词使用新的会话，业务侧用户ID为任务标识，便于在会话列表中区分批量推理产生的会话
+func (s *batchInferenceService) sendPrompt(ctx context.Context, job *models.BatchInferenceJob, prompt string) (string, string, error) {
+	stream, err := s.conversationService.UserSendMessage(ctx, &dto.ChatUserSendMessageRequest{
+		ServiceUserID: batchInferenceServiceUserPrefix + job.ID.String(),
+		SystemPrompt:  job.SystemPrompt,
+		UserMessage:   prompt,
+	}, false)
+	if err != nil {
+		return "", "", err
+	}
+
+	return collectChatAnswer(ctx, stream)
+}
+
+// refreshJobProgress 按提示词状态重新统计任务进度
+func (s *batchInferenceService) refreshJobProgress(ctx context.Context, jobID uuid.UUID) error {
+	counts, err := s.itemRepo.CountByJobIDAndStatus(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("统计批量推理进度失败: %w", err)
+	}
+	if err := s.jobRepo.UpdateProgress(ctx, jobID, counts[define.BatchInferenceItemStatusCompleted], counts[define.BatchInferenceItemStatusFailed]); err != nil {
+		return fmt.Errorf("更新批量推理进度失败: %w", err)
+	}
+	return nil
+}
+
+// failJob 将任务标记为执行失败，未处理的提示词标记为已取消
+func (s *batchInferenceService) failJob(ctx context.Context, job *models.BatchInferenceJob, errorMessage string) error {
+	log.Printf("批量推理任务 %s 执行失败: %s", job.ID, errorMessage)
+	if _, err := s.jobRepo.UpdateFailed(ctx, job.ID, []string{define.BatchInferenceJobStatusPending, define.BatchInferenceJobStatusRunning},
+		define.BatchInferenceJobStatusFailed, errorMessage, time.Now()); err != nil {
+		return fmt.Errorf("更新批量推理任务状态失败: %w", err)
+	}
+	if err := s.itemRepo.UpdateStatusByJobID(ctx, job.ID, define.BatchInferenceItemStatusPending, define.BatchInferenceItemStatusCancelled); err != nil {
+		return fmt.Errorf("取消批量推理提示词失败: %w", err)
+	}
+	return nil
+}
+
+// batchInferenceResultRecord 导出为 JSON Lines 时的单条结果
+type batchInferenceResultRecord struct {
+	Index          int    `json:"index"`
+	Prompt         string `json:"prompt"`
+	Status         string `json:"status"`
+	Answer         string `json:"answer"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	DurationMs     int64  `json:"duration_ms"`
+}
+
+// newBatchInferenceResultRecord 将提示词转换为导出结果
+func newBatchInferenceResultRecord(item *models.BatchInferenceItem) batchInferenceResultRecord {
+	return batchInferenceResultRecord{
+		Index:          item.ItemIndex,
+		Prompt:         item.Prompt,
+		Status:         item.Status,
+		Answer:         item.Answer,
+		ErrorMessage:   item.ErrorMessage,
+		ConversationID: item.ConversationID,
+		DurationMs:     item.DurationMs,
+	}
+}
+
+// parseBatchInferencePrompt 解析 JSON 中的单条提示词，支持字符串或包含 prompt 字段的对象
+func parseBatchInferencePrompt(raw json.RawMessage) (string, error) {
+	var prompt string
+	if err := json.Unmarshal(raw, &prompt); err == nil {
+		return prompt, nil
+	}
+	var object struct {
+		Prompt *string `json:"prompt"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return "", err
+	}
+	if object.Prompt == nil {
+		return "", errors.New("缺少 prompt 字段")
+	}
+	return *object.Prompt, nil
+}
+
+// validateBatchInferencePrompts 校验提示词数量和内容
+func validateBatchInferencePrompts(prompts []string) error {
+	if len(prompts) == 0 {
+		return apperror.New(apperror.CodeInvalidArgument, "提示词不能为空")
+	}
+	if len(prompts) > maxBatchInferenceItemCount {
+		return apperror.Newf(apperror.CodeInvalidArgument, "单个任务最多包含%d条提示词", maxBatchInferenceItemCount)
+	}
+	for i, prompt := range prompts {
+		if strings.TrimSpace(prompt) == "" {
+			return apperror.Newf(apperror.CodeInvalidArgument, "第%d条提示词不能为空", i+1)
+		}
+		if len([]rune(prompt)) > maxBatchInferencePromptLength {
+			return apperror.Newf(apperror.CodeInvalidArgument, "第%d条提示词不能超过%d个字符", i+1, maxBatchInferencePromptLength)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+)
+
+// BootstrapService 首次启动初始化 业务逻辑层接口
+// 在空数据库上创建默认管理员、默认应用和常用供应商定义
+type BootstrapService interface {
+	// Bootstrap 执行初始化
+	// 已存在的数据不会被修改，可重复执行
+	Bootstrap(ctx context.Context) (*dto.BootstrapResult, error)
+}
+
+// bootstrapService 首次启动初始化 业务逻辑层实现
+type bootstrapService struct {
+	config                   *config.Config           // 应用程序配置
+	userService              UserService              // 用户服务
+	applicationService       ApplicationService       // 应用服务
+	llmProviderDefineService LlmProviderDefineService // 供应商定义服务
+}
+
+// NewBootstrapService 创建 首次启动初始化 服务实例
+// 参数：config - 应用程序配置，userService - 用户服务，applicationService - 应用服务，llmProviderDefineService - 供应商定义服务
+func NewBootstrapService(
+	config *config.Config,
+	userService UserService,
+	applicationService ApplicationService,
+	llmProviderDefineService LlmProviderDefineService,
+) BootstrapService {
+	return &bootstrapService{
+		config:                   config,
+		userService:              userService,
+		applicationService:       applicationService,
+		llmProviderDefineService: llmProviderDefineService,
+	}
+}
+
+// Bootstrap 执行初始化
+// 仅在没有任何系统用户时创建管理员，仅在没有任何应用时创建默认应用，按类型补齐缺失的供应商定义
+func (s *bootstrapService) Bootstrap(ctx context.Context) (*dto.BootstrapResult, error) {
+	result := &dto.BootstrapResult{}
+
+	if err := s.bootstrapAdminUser(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := s.bootstrapApplication(ctx, result); err != nil {
+		return nil, err
+	}
+	if err := s.bootstrapLlmProviderDefines(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// bootstrapAdminUser 创建默认管理员用户
+// 未配置密码时生成随机密码，通过结果返回一次
+func (s *bootstrapService) bootstrapAdminUser(ctx context.Context, result *dto.BootstrapResult) error {
+	users, err := s.userService.GetAllUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("查询系统用户失败: %w", err)
+	}
+	if len(users) > 0 {
+		return nil
+	}
+
+	password := s.config.Bootstrap.AdminPassword
+	if password == "" {
+		password, err = generatePassword()
+		if err != nil {
+			return fmt.Errorf("生成管理员密码失败: %w", err)
+		}
+		result.GeneratedAdminPassword = &password
+	}
+
+	admin := &models.SystemUser{
+		Name:     s.config.Bootstrap.AdminNumber,
+		Number:   s.config.Bootstrap.AdminNumber,
+		Email:    s.config.Bootstrap.AdminEmail,
+		Password: password,
+	}
+	if err := s.userService.SaveUser(ctx, admin); err != nil {
+		return fmt.Errorf("创建管理员用户失败: %w", err)
+	}
+
+	result.AdminUserCreated = true
+	result.AdminNumber = admin.Number
+	return nil
+}
+
+// bootstrapApplication 创建默认应用
+func (s *bootstrapService) bootstrapApplication(ctx context.Context, result *dto.BootstrapResult) error {
+	applications, err := s.applicationService.GetAllApplications(ctx)
+	if err != nil {
+		return fmt.Errorf("查询应用失败: %w", err)
+	}
+	if len(applications) > 0 {
+		return nil
+	}
+
+	application := &models.Application{
+		Name:        "默认应用",
+		Description: "首次启动时自动创建的默认应用",
+	}
+	if err := s.applicationService.SaveApplication(ctx, application); err != nil {
+		return fmt.Errorf("创建默认应用失败: %w", err)
+	}
+
+	applicationID := application.ID.String()
+	result.ApplicationCreated = true
+	result.ApplicationID = &applicationID
+	return nil
+}
+
+// bootstrapLlmProviderDefines 写入缺失的常用供应商定义
+func (s *bootstrapService) bootstrapLlmProviderDefines(ctx context.Context, result *dto.BootstrapResult) error {
+	loadResult, err := s.llmProviderDefineService.LoadLlmProviderDefinePresets(ctx, false)
+	if err != nil {
+		return err
+	}
+	result.ProviderDefinesCreated = loadResult.Created
+	return nil
+}
+
+// generatePassword 生成随机密码
+func generatePassword() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,249 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/utils"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// defaultAnswerRuleEmbeddingThreshold 语义匹配规则未指定阈值时使用的默认相似度阈值
+const defaultAnswerRuleEmbeddingThreshold = 0.85
+
+// ChatAgentAnswerRuleService 预制答案规则 业务逻辑层接口
+// 定义 预制答案规则 相关的业务逻辑方法
+type ChatAgentAnswerRuleService interface {
+	// SaveAnswerRule 保存预制答案规则
+	// 如果ID为空则新增，否则更新现有记录；语义匹配规则保存时生成示例问题的嵌入向量
+	SaveAnswerRule(ctx context.Context, rule *models.ChatAgentAnswerRule) error
+
+	// DeleteAnswerRule 删除预制答案规则
+	DeleteAnswerRule(ctx context.Context, id uuid.UUID) error
+
+	// GetAnswerRulesByChatAgentID 获取智能体的全部预制答案规则，按优先级倒序
+	GetAnswerRulesByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error)
+
+	// MatchAnswerRule 按优先级匹配智能体已启用的规则
+	// 返回第一条命中的规则，没有命中时返回空
+	MatchAnswerRule(ctx context.Context, chatAgentID uuid.UUID, userMessage string) (*models.ChatAgentAnswerRule, error)
+}
+
+// chatAgentAnswerRuleService 预制答案规则 业务逻辑层实现
+// 实现 ChatAgentAnswerRuleService 接口
+type chatAgentAnswerRuleService struct {
+	answerRuleRepo repository.ChatAgentAnswerRuleRepository
+	chatAgentRepo  repository.ChatAgentRepository
+	embedder       *textEmbedder // 文本嵌入生成器
+}
+
+// NewChatAgentAnswerRuleService 创建 预制答案规则 服务实例
+// 返回 ChatAgentAnswerRuleService 接口的实现
+func NewChatAgentAnswerRuleService(
+	answerRuleRepo repository.ChatAgentAnswerRuleRepository,
+	chatAgentRepo repository.ChatAgentRepository,
+	llmRepo repository.ApplicationLlmRepository,
+	llmProviderRepo repository.LlmProviderRepository,
+) ChatAgentAnswerRuleService {
+	return &chatAgentAnswerRuleService{
+		answerRuleRepo: answerRuleRepo,
+		chatAgentRepo:  chatAgentRepo,
+		embedder:       newTextEmbedder(llmRepo, llmProviderRepo),
+	}
+}
+
+// SaveAnswerRule 保存预制答案规则
+// 如果ID为空则新增，否则更新现有记录；语义匹配规则保存时生成示例问题的嵌入向量
+func (s *chatAgentAnswerRuleService) SaveAnswerRule(ctx context.Context, rule *models.ChatAgentAnswerRule) error {
+	if err := validateAnswerRule(rule); err != nil {
+		return err
+	}
+
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, rule.ChatAgentID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	rule.ApplicationID = chatAgent.ApplicationID
+
+	var existing *models.ChatAgentAnswerRule
+	if rule.ID != uuid.Nil {
+		existing, err = s.answerRuleRepo.GetByID(ctx, rule.ID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "预制答案规则不存在", err)
+		}
+		if existing.ChatAgentID != rule.ChatAgentID {
+			return apperror.New(apperror.CodeInvalidArgument, "预制答案规则不属于该智能体")
+		}
+	}
+
+	// 语义匹配规则在示例问题或嵌入模型变化时重新生成嵌入向量
+	if rule.MatchType == define.ChatAgentAnswerRuleMatchTypeEmbedding {
+		if rule.EmbeddingThreshold == 0 {
+			rule.EmbeddingThreshold = defaultAnswerRuleEmbeddingThreshold
+		}
+		if existing != nil && existing.Embedding != "" && existing.Pattern == rule.Pattern && existing.EmbeddingModelID == rule.EmbeddingModelID {
+			rule.Embedding = existing.Embedding
+		} else {
+			embedding, err := s.embedder.CreateEmbedding(ctx, chatAgent.ApplicationID, rule.EmbeddingModelID, rule.Pattern)
+			if err != nil {
+				return err
+			}
+			embeddingJSON, err := json.Marshal(embedding)
+			if err != nil {
+				return fmt.Errorf("序列化嵌入向量失败: %w", err)
+			}
+			rule.Embedding = string(embeddingJSON)
+		}
+	} else {
+		rule.EmbeddingModelID = uuid.Nil
+		rule.EmbeddingThreshold = 0
+		rule.Embedding = ""
+	}
+
+	if existing == nil {
+		rule.ID = uuid.New()
+		return s.answerRuleRepo.Create(ctx, rule)
+	}
+	rule.CreatedAt = existing.CreatedAt
+	return s.answerRuleRepo.Update(ctx, rule)
+}
+
+// DeleteAnswerRule 删除预制答案规则
+func (s *chatAgentAnswerRuleService) DeleteAnswerRule(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.answerRuleRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "预制答案规则不存在", err)
+	}
+	return s.answerRuleRepo.DeleteByID(ctx, id)
+}
+
+// GetAnswerRulesByChatAgentID 获取智能体的全部预制答案规则，按优先级倒序
+func (s *chatAgentAnswerRuleService) GetAnswerRulesByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error) {
+	return s.answerRuleRepo.GetByChatAgentID(ctx, chatAgentID)
+}
+
+// MatchAnswerRule 按优先级匹配智能体已启用的规则
+// 用户消息的嵌入向量只在遇到语义匹配规则时按嵌入模型生成一次；单条规则匹配失败时跳过该规则
+func (s *chatAgentAnswerRuleService) MatchAnswerRule(ctx context.Context, chatAgentID uuid.UUID, userMessage string) (*models.ChatAgentAnswerRule, error) {
+	message := strings.TrimSpace(userMessage)
+	if message == "" {
+		return nil, nil
+	}
+
+	rules, err := s.answerRuleRepo.GetEnabledByChatAgentID(ctx, chatAgentID)
+	if err != nil {
+		return nil, fmt.Errorf("获取预制答案规则失败: %w", err)
+	}
+
+	messageEmbeddings := make(map[uuid.UUID][]float32)
+	for _, rule := range rules {
+		switch rule.MatchType {
+		case define.ChatAgentAnswerRuleMatchTypeKeyword:
+			if matchAnswerRuleKeywords(rule.Pattern, message) {
+				return rule, nil
+			}
+		case define.ChatAgentAnswerRuleMatchTypeRegex:
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				log.Printf("预制答案规则 %s 的正则表达式无效: %v", rule.ID, err)
+				continue
+			}
+			if re.MatchString(message) {
+				return rule, nil
+			}
+		case define.ChatAgentAnswerRuleMatchTypeEmbedding:
+			var ruleEmbedding []float32
+			if err := json.Unmarshal([]byte(rule.Embedding), &ruleEmbedding); err != nil || len(ruleEmbedding) == 0 {
+				log.Printf("预制答案规则 %s 缺少有效的嵌入向量", rule.ID)
+				continue
+			}
+			messageEmbedding, ok := messageEmbeddings[rule.EmbeddingModelID]
+			if !ok {
+				messageEmbedding, err = s.embedder.CreateEmbedding(ctx, rule.ApplicationID, rule.EmbeddingModelID, message)
+				if err != nil {
+					log.Printf("预制答案规则 %s 生成用户消息嵌入向量失败: %v", rule.ID, err)
+				}
+				// 生成失败时同样记录，避免同一模型的后续规则重复请求
+				messageEmbeddings[rule.EmbeddingModelID] = messageEmbedding
+			}
+			if len(messageEmbedding) > 0 && utils.CosineSimilarity(ruleEmbedding, messageEmbedding) >= rule.EmbeddingThreshold {
+				return rule, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// validateAnswerRule 验证预制答案规则数据
+func validateAnswerRule(rule *models.ChatAgentAnswerRule) error {
+	if rule == nil {
+		return apperror.New(apperror.CodeInvalidArgument, "预制答案规则不能为空")
+	}
+	if rule.ChatAgentID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "智能体ID不能为空")
+	}
+	if strings.TrimSpace(rule.Name) == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "规则名称不能为空")
+	}
+	if strings.TrimSpace(rule.Pattern) == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "匹配内容不能为空")
+	}
+	if strings.TrimSpace(rule.Answer) == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "预制答案不能为空")
+	}
+
+	switch rule.MatchType {
+	case define.ChatAgentAnswerRuleMatchTypeKeyword:
+		if len(splitAnswerRuleKeywords(rule.Pattern)) == 0 {
+			return apperror.New(apperror.CodeInvalidArgument, "关键词不能为空")
+		}
+	case define.ChatAgentAnswerRuleMatchTypeRegex:
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return apperror.Wrap(apperror.CodeInvalidArgument, "正则表达式无效", err)
+		}
+	case define.ChatAgentAnswerRuleMatchTypeEmbedding:
+		if rule.EmbeddingModelID == uuid.Nil {
+			return apperror.New(apperror.CodeInvalidArgument, "语义匹配规则必须指定嵌入模型")
+		}
+		if rule.EmbeddingThreshold < 0 || rule.EmbeddingThreshold > 1 {
+			return apperror.New(apperror.CodeInvalidArgument, "相似度阈值必须在0到1之间")
+		}
+	default:
+		return apperror.Newf(apperror.CodeInvalidArgument, "不支持的匹配方式: %s", rule.MatchType)
+	}
+	return nil
+}
+
+// splitAnswerRuleKeywords 拆分关键词，支持换行、英文逗号和中文逗号分隔
+func splitAnswerRuleKeywords(pattern string) []string {
+	fields := strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ',' || r == '，'
+	})
+	keywords := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if keyword := strings.TrimSpace(field); keyword != "" {
+			keywords = append(keywords, strings.ToLower(keyword))
+		}
+	}
+	return keywords
+}
+
+// matchAnswerRuleKeywords 判断消息是否包含任一关键词（不区分大小写）
+func matchAnswerRuleKeywords(pattern, message string) bool {
+	lowerMessage := strings.ToLower(message)
+	for _, keyword := range splitAnswerRuleKeywords(pattern) {
+		if strings.Contains(lowerMessage, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 网段数量上限和审计记录字段长度上限，字段长度与数据库字段长度一致
+const (
+	maxApiKeyCidrCount               = 100
+	maxApiKeyRejectionPathLength     = 512
+	maxApiKeyRejectionAgentLength    = 512
+	maxApiKeyRejectionClientIPLength = 64
+)
+
+// ApiKeyAccess 使用 API Key 访问的请求信息，用于校验来源IP和记录被拒绝的访问
+type ApiKeyAccess struct {
+	ClientIP  string // 来源IP，HTTP 请求按受信任代理解析，gRPC 请求取连接的对端地址
+	Path      string // 请求路径或 gRPC 方法
+	UserAgent string // 客户端标识
+}
+
+// ChatAgentApiKeyService API Key 业务逻辑层接口
+// 管理 API Key 的来源IP网段限制，并记录被拒绝的访问供审计
+type ChatAgentApiKeyService interface {
+	// GetApiKeysByChatAgentID 获取智能体的全部 API Key
+	GetApiKeysByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentApiKey, error)
+
+	// SaveApiKeyIPRules 保存 API Key 的来源IP网段限制，API Key 不属于该智能体时返回 NotFound 错误
+	// 参数：allowedCidrs - 允许的网段，为空时不限制；deniedCidrs - 拒绝的网段，优先于允许的网段；单个IP视为只包含该IP的网段
+	SaveApiKeyIPRules(ctx context.Context, chatAgentID, apiKeyID uuid.UUID, allowedCidrs, deniedCidrs []string) (*models.ChatAgentApiKey, error)
+
+	// CheckClientIP 校验请求的来源IP是否满足 API Key 的网段限制，不满足时记录审计并返回 Forbidden 错误
+	CheckClientIP(ctx context.Context, apiKey *models.ChatAgentApiKey, access ApiKeyAccess) error
+
+	// GetRejectionsByChatAgentID 分页获取智能体的 API Key 访问拒绝记录，按时间倒序
+	GetRejectionsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.ChatAgentApiKeyRejection, int64, error)
+
+	// CleanupRejections 删除超过保留天数的访问拒绝记录，由后台定时任务调用
+	CleanupRejections(ctx context.Context) error
+}
+
+// chatAgentApiKeyService API Key 业务逻辑层实现
+type chatAgentApiKeyService struct {
+	apiKeyRepo    repository.ChatAgentApiKeyRepository          // API Key 数据访问层
+	rejectionRepo repository.ChatAgentApiKeyRejectionRepository // 访问拒绝记录数据访问层
+	config        config.ApiKeyConfig                           // API Key 访问控制配置
+}
+
+// NewChatAgentApiKeyService 创建 API Key 服务实例
+// 返回 ChatAgentApiKeyService 接口的实现
+// 参数：apiKeyRepo - API Key 数据访问层，rejectionRepo - 访问拒绝记录数据访问层，config - 应用程序配置
+func NewChatAgentApiKeyService(apiKeyRepo repository.ChatAgentApiKeyRepository, rejectionRepo repository.ChatAgentApiKeyRejectionRepository,
+	config *config.Config) ChatAgentApiKeyService {
+	return &chatAgentApiKeyService{
+		apiKeyRepo:    apiKeyRepo,
+		rejectionRepo: rejectionRepo,
+		config:        config.ApiKey,
+	}
+}
+
+// GetApiKeysByChatAgentID 获取智能体的全部 API Key
+func (s *chatAgentApiKeyService) GetApiKeysByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentApiKey, error) {
+	apiKeys, err := s.apiKeyRepo.GetByChatAgentID(ctx, chatAgentID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询API Key失败", err)
+	}
+	return apiKeys, nil
+}
+
+// SaveApiKeyIPRules 保存 API Key 的来源IP网段限制
+// 网段保存为规范格式并去除重复项，保存后立即对新的请求生效
+func (s *chatAgentApiKeyService) SaveApiKeyIPRules(ctx context.Context, chatAgentID, apiKeyID uuid.UUID, allowedCidrs, deniedCidrs []string) (*models.ChatAgentApiKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, apiKeyID)
+	if err != nil || apiKey.ChatAgentID != chatAgentID {
+		return nil, apperror.New(apperror.CodeNotFound, "API Key不存在")
+	}
+	if apiKey.AllowedCidrs, err = normalizeApiKeyCidrs(allowedCidrs, "允许"); err != nil {
+		return nil, err
+	}
+	if apiKey.DeniedCidrs, err = normalizeApiKeyCidrs(deniedCidrs, "拒绝"); err != nil {
+		return nil, err
+	}
+	if err := s.apiKeyRepo.Save(ctx, apiKey); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "保存API Key的IP限制失败", err)
+	}
+	return apiKey, nil
+}
+
+// CheckClientIP 校验请求的来源IP
+// 来源IP在拒绝列表中时拒绝；允许列表不为空且来源IP不在其中时拒绝；两个列表都为空时不限制
+func (s *chatAgentApiKeyService) CheckClientIP(ctx context.Context, apiKey *models.ChatAgentApiKey, access ApiKeyAccess) error {
+	if apiKey.AllowedCidrs == "" && apiKey.DeniedCidrs == "" {
+		return nil
+	}
+	reason := apiKeyRejectionReason(apiKey, net.ParseIP(strings.TrimSpace(access.ClientIP)))
+	if reason == "" {
+		return nil
+	}
+
+	rejection := &models.ChatAgentApiKeyRejection{
+		ApplicationID: apiKey.ApplicationID,
+		ChatAgentID:   apiKey.ChatAgentID,
+		ApiKeyID:      apiKey.ID,
+		ClientIP:      truncateRunes(access.ClientIP, maxApiKeyRejectionClientIPLength),
+		Reason:        reason,
+		Path:          truncateRunes(access.Path, maxApiKeyRejectionPathLength),
+		UserAgent:     truncateRunes(access.UserAgent, maxApiKeyRejectionAgentLength),
+	}
+	// 审计记录保存失败不影响拒绝访问
+	if err := s.rejectionRepo.Create(ctx, rejection); err != nil {
+		log.Printf("保存API Key访问拒绝记录失败: apiKeyID=%s, clientIP=%s, error=%v", apiKey.ID, access.ClientIP, err)
+	}
+	if reason == models.ChatAgentApiKeyRejectionReasonInvalidIP {
+		return apperror.New(apperror.CodeForbidden, "无法识别来源IP，不允许使用该 API Key")
+	}
+	return apperror.Newf(apperror.CodeForbidden, "来源IP %s 不允许使用该 API Key", access.ClientIP)
+}
+
+// GetRejectionsByChatAgentID 分页获取智能体的 API Key 访问拒绝记录
+func (s *chatAgentApiKeyService) GetRejectionsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.ChatAgentApiKeyRejection, int64, error) {
+	rejections, total, err := s.rejectionRepo.GetByChatAgentIDWithPagination(ctx, chatAgentID, page, pageSize)
+	if err != nil {
+		return nil, 0, apperror.Wrap(apperror.CodeInternal, "查询API Key访问拒绝记录失败", err)
+	}
+	return rejections, total, nil
+}
+
+// CleanupRejections 删除超过保留天数的访问拒绝记录，保留天数为0时不清理
+func (s *chatAgentApiKeyService) CleanupRejections(ctx context.Context) error {
+	if s.config.RejectionRetentionDays <= 0 {
+		return nil
+	}
+	before := time.Now().AddDate(0, 0, -s.config.RejectionRetentionDays)
+	if _, err := s.rejectionRepo.DeleteBefore(ctx, before); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "清理API Key访问拒绝记录失败", err)
+	}
+	return nil
+}
+
+// normalizeApiKeyCidrs 校验并规范化网段列表，返回保存使用的 JSON 内容，列表为空时返回空字符串
+// 参数：kind - 列表名称，用于错误提示
+func normalizeApiKeyCidrs(cidrs []string, kind string) (string, error) {
+	normalized := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		ipNet, err := parseApiKeyCidr(cidr)
+		if err != nil {
+			return "", apperror.Newf(apperror.CodeInvalidArgument, "%s的网段 %s 格式错误，应为 IP 地址或 CIDR 网段，如 10.0.0.0/8", kind, cidr)
+		}
+		if !containsString(normalized, ipNet.String()) {
+			normalized = append(normalized, ipNet.String())
+		}
+	}
+	if len(normalized) == 0 {
+		return "", nil
+	}
+	if len(normalized) > maxApiKeyCidrCount {
+		return "", apperror.Newf(apperror.CodeInvalidArgument, "%s的网段不能超过%d个", kind, maxApiKeyCidrCount)
+	}
+	content, err := json.Marshal(normalized)
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "序列化网段列表失败", err)
+	}
+	return string(content), nil
+}
+
+// parseApiKeyCidr 解析网段，单个IP视为只包含该IP的网段
+func parseApiKeyCidr(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		if ip := net.ParseIP(cidr); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+			}
+			return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+		}
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	return ipNet, err
+}
+
+// apiKeyRejectionReason 返回来源IP被拒绝的原因，允许访问时返回空字符串
+func apiKeyRejectionReason(apiKey *models.ChatAgentApiKey, clientIP net.IP) string {
+	if clientIP == nil {
+		return models.ChatAgentApiKeyRejectionReasonInvalidIP
+	}
+	if apiKeyCidrsContain(apiKey.DeniedCidrs, clientIP) {
+		return models.ChatAgentApiKeyRejectionReasonDenied
+	}
+	if apiKey.AllowedCidrs != "" && !apiKeyCidrsContain(apiKey.AllowedCidrs, clientIP) {
+		return models.ChatAgentApiKeyRejectionReasonNotAllowed
+	}
+	return ""
+}
+
+// apiKeyCidrsContain 网段列表中是否有网段包含该IP，列表保存时已规范化，无法解析的网段忽略
+func apiKeyCidrsContain(cidrs string, ip net.IP) bool {
+	if cidrs == "" {
+		return false
+	}
+	var list []string
+	if err := json.Unmarshal([]byte(cidrs), &list); err != nil {
+		return false
+	}
+	for _, cidr := range list {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
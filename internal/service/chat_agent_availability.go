@@ -0,0 +1,162 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"time"
+)
+
+// 智能体不可用时的默认预制答案
+const (
+	defaultMaintenanceAnswer = "智能体正在维护中，请稍后再试。"
+	defaultUnavailableAnswer = "当前不在服务时间内，请在服务时间内再试。"
+)
+
+// resolveUnavailableAnswer 判断智能体当前是否可用
+// 维护模式或启用服务时间且当前不在服务时间内时，返回对应的预制答案
+// 参数：chatAgent - 智能体，now - 当前时间
+// 返回：预制答案和是否不可用
+func resolveUnavailableAnswer(chatAgent *models.ChatAgent, now time.Time) (string, bool) {
+	if chatAgent.MaintenanceMode {
+		return answerOrDefault(chatAgent.MaintenanceAnswer, defaultMaintenanceAnswer), true
+	}
+	if !chatAgent.EnableAvailabilitySchedule {
+		return "", false
+	}
+
+	schedule, err := parseAvailabilitySchedule(chatAgent.AvailabilitySchedule)
+	if err != nil {
+		// 保存时已校验，配置无效时视为可用，避免误拦截
+		return "", false
+	}
+	if isWithinAvailabilitySchedule(schedule, now) {
+		return "", false
+	}
+	return answerOrDefault(chatAgent.UnavailableAnswer, defaultUnavailableAnswer), true
+}
+
+// validateAvailabilitySchedule 校验智能体的可用性设置
+func validateAvailabilitySchedule(chatAgent *models.ChatAgent) error {
+	if !chatAgent.EnableAvailabilitySchedule {
+		return nil
+	}
+	if chatAgent.AvailabilitySchedule == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "启用服务时间时，服务时间配置不能为空")
+	}
+	if _, err := parseAvailabilitySchedule(chatAgent.AvailabilitySchedule); err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "服务时间配置无效", err)
+	}
+	return nil
+}
+
+// availabilitySchedule 解析后的服务时间配置
+type availabilitySchedule struct {
+	location *time.Location       // 时区
+	windows  []availabilityWindow // 服务时间段
+}
+
+// availabilityWindow 解析后的服务时间段
+type availabilityWindow struct {
+	weekdays map[time.Weekday]bool // 生效的星期，为空时每天生效
+	start    int                   // 开始时间（自零点起的分钟数）
+	end      int                   // 结束时间（自零点起的分钟数）
+}
+
+// parseAvailabilitySchedule 解析服务时间配置
+func parseAvailabilitySchedule(raw string) (*availabilitySchedule, error) {
+	var scheduleDto dto.ChatAgentAvailabilityScheduleDto
+	if err := json.Unmarshal([]byte(raw), &scheduleDto); err != nil {
+		return nil, fmt.Errorf("格式错误: %w", err)
+	}
+	if len(scheduleDto.Windows) == 0 {
+		return nil, fmt.Errorf("至少需要一个服务时间段")
+	}
+
+	schedule := &availabilitySchedule{location: time.Local}
+	if scheduleDto.Timezone != "" {
+		location, err := time.LoadLocation(scheduleDto.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("无效的时区 %s: %w", scheduleDto.Timezone, err)
+		}
+		schedule.location = location
+	}
+
+	for _, windowDto := range scheduleDto.Windows {
+		start, err := parseClockMinutes(windowDto.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClockMinutes(windowDto.End)
+		if err != nil {
+			return nil, err
+		}
+		if start == end {
+			return nil, fmt.Errorf("服务时间段的开始时间和结束时间不能相同")
+		}
+		window := availabilityWindow{start: start, end: end}
+		if len(windowDto.Weekdays) > 0 {
+			window.weekdays = make(map[time.Weekday]bool, len(windowDto.Weekdays))
+			for _, weekday := range windowDto.Weekdays {
+				if weekday < 0 || weekday > 6 {
+					return nil, fmt.Errorf("无效的星期 %d，取值范围为0-6", weekday)
+				}
+				window.weekdays[time.Weekday(weekday)] = true
+			}
+		}
+		schedule.windows = append(schedule.windows, window)
+	}
+	return schedule, nil
+}
+
+// parseClockMinutes 将 HH:MM 格式的时间解析为自零点起的分钟数
+func parseClockMinutes(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("无效的时间 %s，格式应为 HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// isWithinAvailabilitySchedule 判断指定时间是否在服务时间内
+// 跨越午夜的时间段在午夜后的部分按开始当天的星期判断
+func isWithinAvailabilitySchedule(schedule *availabilitySchedule, now time.Time) bool {
+	local := now.In(schedule.location)
+	minutes := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+
+	for _, window := range schedule.windows {
+		if window.start < window.end {
+			if window.matches(today) && minutes >= window.start && minutes < window.end {
+				return true
+			}
+			continue
+		}
+		// 跨越午夜的时间段
+		if window.matches(today) && minutes >= window.start {
+			return true
+		}
+		if window.matches(yesterday) && minutes < window.end {
+			return true
+		}
+	}
+	return false
+}
+
+// matches 判断时间段是否在指定星期生效
+func (w availabilityWindow) matches(weekday time.Weekday) bool {
+	return w.weekdays == nil || w.weekdays[weekday]
+}
+
+// answerOrDefault 返回预制答案，为空时返回默认答案
+func answerOrDefault(answer, defaultAnswer string) string {
+	if answer == "" {
+		return defaultAnswer
+	}
+	return answer
+}
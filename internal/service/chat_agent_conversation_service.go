@@ -5,9 +5,11 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/define"
 	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/manager"
@@ -17,28 +19,53 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
-	"gorm.io/gorm"
 )
 
+const (
+	defaultPageSize        = 10  // 列表默认分页大小
+	maxPageSize            = 100 // 列表最大分页大小
+	maxHistoryMessageCount = 100 // 发送消息时携带的最大历史消息数量
+)
+
+// conversationRequestStaleAfter 会话登记的处理中请求的最长有效时间，进程异常退出未释放时超过该时间后允许发送新消息
+const conversationRequestStaleAfter = 10 * time.Minute
+
 // ChatAgentConversationService 聊天会话 业务逻辑层接口
 // 定义 聊天会话 相关的业务逻辑方法
 type ChatAgentConversationService interface {
 	// GetChatMessageList 获取聊天消息列表
-	GetChatMessageList(ctx context.Context, conversationID, lastID string, size int) ([]*models.ChatAgentMessage, error)
+	GetChatMessageList(ctx context.Context, req *dto.GetChatMessageListRequest) ([]*models.ChatAgentMessage, *dto.CursorPageInfo, error)
 
 	// CreateConversation 创建会话
 	CreateConversation(ctx context.Context, serviceUserID, userMessage string) (*models.ChatAgentConversation, error)
 
 	// GetConversationList 获取会话列表
-	GetConversationList(ctx context.Context, serviceUserID, lastID string, size int) ([]*models.ChatAgentConversation, error)
+	GetConversationList(ctx context.Context, req *dto.GetConversationListRequest) ([]*models.ChatAgentConversation, *dto.CursorPageInfo, error)
+
+	// ListConversationsWithStats 管理后台查询智能体下全部业务侧用户的会话
+	// 支持按创建时间、业务侧用户、是否发生错误和token用量筛选
+	ListConversationsWithStats(ctx context.Context, req *dto.AdminConversationListRequest) ([]*repository.ChatAgentConversationWithStats, int64, error)
+
+	// GetConversation 获取单个会话详情，包含消息数量、最后一条消息预览和token用量
+	GetConversation(ctx context.Context, serviceUserID, conversationID string) (*dto.GetConversationResponse, error)
+
+	// GetConversationSummaries 批量获取会话的最后一条消息预览、消息数量和未读回复数量，没有消息的会话不在结果中
+	GetConversationSummaries(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID]*dto.ConversationSummaryDto, error)
 
 	// DeleteConversation 删除会话
 	DeleteConversation(ctx context.Context, serviceUserID, conversationID string) (*dto.DeleteConversationResponse, error)
 
+	// GetChatBootstrap 获取当前智能体的聊天首屏信息
+	// 包含智能体信息、欢迎语、推荐问题和当前是否可用
+	GetChatBootstrap(ctx context.Context) (*dto.ChatBootstrapResponse, error)
+
 	// UserSendMessagePredefinedAnswer 用户发送消息，返回固定答案
 	UserSendMessagePredefinedAnswer(ctx context.Context, req *dto.ChatUserSendMessageRequest, streamable bool) (io.Reader, error)
 
@@ -48,9 +75,19 @@ type ChatAgentConversationService interface {
 	// UploadAttachment 上传聊天附件
 	UploadAttachment(ctx context.Context, file io.Reader, filename string, size int64) (*dto.UploadAttachmentResponse, error)
 
+	// GetAttachment 获取当前智能体的聊天附件，用于下载附件文件
+	// serviceUserID 不为空且附件已关联会话时，校验会话归属于该业务侧用户
+	GetAttachment(ctx context.Context, serviceUserID, attachmentID string) (*models.ChatAgentAttachment, error)
+
 	// RenameConversationTitle 重命名会话标题
 	RenameConversationTitle(ctx context.Context, serviceUserID, conversationID, newTitle string) (*dto.RenameConversationResponse, error)
 
+	// UpdateMessageReceipts 上报助手回复的送达和已读状态
+	UpdateMessageReceipts(ctx context.Context, req *dto.UpdateMessageReceiptsRequest) (*dto.UpdateMessageReceiptsResponse, error)
+
+	// GetChatMessageListByRequestID 根据请求ID获取会话中的消息列表
+	GetChatMessageListByRequestID(ctx context.Context, serviceUserID, conversationID, requestID string) ([]*models.ChatAgentMessage, error)
+
 	// GetChatAgentMcpServerTools 获取聊天智能体启用的MCP工具列表
 	// 根据chatAgentID查询启用的工具，并从MCP服务器获取最新的工具信息
 	GetChatAgentMcpServerTools(ctx context.Context) ([]al_client.Tool, error)
@@ -59,7 +96,6 @@ type ChatAgentConversationService interface {
 // chatAgentConversationService 聊天会话 业务逻辑层实现
 // 实现 ChatAgentConversationService 接口
 type chatAgentConversationService struct {
-	db                         *gorm.DB
 	conversationRepo           repository.ChatAgentConversationRepository
 	messageRepo                repository.ChatAgentMessageRepository
 	attachmentRepo             repository.ChatAgentAttachmentRepository
@@ -69,13 +105,23 @@ type chatAgentConversationService struct {
 	mcpConfigRepo              repository.ApplicationMcpServerConfigRepository
 	mcpToolRepo                repository.ApplicationMcpServerToolRepository
 	chatAgentMcpServerToolRepo repository.ChatAgentMcpServerToolRepository
+	toolBundleRepo             repository.ApplicationToolBundleRepository
 	llmProviderRepo            repository.LlmProviderRepository
+	monitorService             ConversationMonitorService    // 会话监控服务
+	answerRuleService          ChatAgentAnswerRuleService    // 预制答案规则服务
+	knowledgeBaseService       KnowledgeBaseService          // 知识库服务
+	providerLimiter            LlmProviderLimiter            // 模型提供商并发限制器
+	presenceService            ConversationPresenceService   // 会话状态服务
+	toolUsageService           ChatAgentToolUsageService     // 工具使用统计服务
+	variableService            ConversationVariableService   // 会话变量服务
+	settingService             ApplicationSettingService     // 应用设置服务
+	persistenceService         ChatMessagePersistenceService // 消息保存服务
+	featureFlagService         FeatureFlagService            // 功能开关服务
 }
 
 // NewChatAgentConversationService 创建 聊天会话 服务实例
 // 返回 ChatAgentConversationService 接口的实现
 func NewChatAgentConversationService(
-	db *gorm.DB,
 	conversationRepo repository.ChatAgentConversationRepository,
 	messageRepo repository.ChatAgentMessageRepository,
 	attachmentRepo repository.ChatAgentAttachmentRepository,
@@ -85,10 +131,20 @@ func NewChatAgentConversationService(
 	mcpConfigRepo repository.ApplicationMcpServerConfigRepository,
 	mcpToolRepo repository.ApplicationMcpServerToolRepository,
 	chatAgentMcpServerToolRepo repository.ChatAgentMcpServerToolRepository,
+	toolBundleRepo repository.ApplicationToolBundleRepository,
 	llmProviderRepo repository.LlmProviderRepository,
+	monitorService ConversationMonitorService,
+	answerRuleService ChatAgentAnswerRuleService,
+	knowledgeBaseService KnowledgeBaseService,
+	providerLimiter LlmProviderLimiter,
+	presenceService ConversationPresenceService,
+	toolUsageService ChatAgentToolUsageService,
+	variableService ConversationVariableService,
+	settingService ApplicationSettingService,
+	persistenceService ChatMessagePersistenceService,
+	featureFlagService FeatureFlagService,
 ) ChatAgentConversationService {
 	return &chatAgentConversationService{
-		db:                         db,
 		conversationRepo:           conversationRepo,
 		messageRepo:                messageRepo,
 		attachmentRepo:             attachmentRepo,
@@ -98,53 +154,123 @@ func NewChatAgentConversationService(
 		mcpConfigRepo:              mcpConfigRepo,
 		mcpToolRepo:                mcpToolRepo,
 		chatAgentMcpServerToolRepo: chatAgentMcpServerToolRepo,
+		toolBundleRepo:             toolBundleRepo,
 		llmProviderRepo:            llmProviderRepo,
+		monitorService:             monitorService,
+		answerRuleService:          answerRuleService,
+		knowledgeBaseService:       knowledgeBaseService,
+		providerLimiter:            providerLimiter,
+		presenceService:            presenceService,
+		toolUsageService:           toolUsageService,
+		variableService:            variableService,
+		settingService:             settingService,
+		persistenceService:         persistenceService,
+		featureFlagService:         featureFlagService,
 	}
 }
 
 // GetChatMessageList 获取聊天消息列表
-func (s *chatAgentConversationService) GetChatMessageList(ctx context.Context, conversationID, lastID string, size int) ([]*models.ChatAgentMessage, error) {
+// 校验会话归属于当前智能体和业务侧用户，支持正序/倒序游标分页和创建时间范围过滤
+func (s *chatAgentConversationService) GetChatMessageList(ctx context.Context, req *dto.GetChatMessageListRequest) ([]*models.ChatAgentMessage, *dto.CursorPageInfo, error) {
 	_, chatAgent, err := getContextInfo(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("无效的智能体ID: %w", err)
+		return nil, nil, fmt.Errorf("无效的智能体ID: %w", err)
 	}
 
-	convID, err := uuid.Parse(conversationID)
+	if req.ServiceUserID == "" {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
+	}
+
+	convID, err := uuid.Parse(req.ConversationID)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+
+	// 验证会话是否存在且属于该用户和智能体
+	conversation, err := s.conversationRepo.GetByID(ctx, convID)
 	if err != nil {
-		return nil, fmt.Errorf("无效的会话ID: %w", err)
+		return nil, nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID || conversation.ServiceUserID != req.ServiceUserID {
+		return nil, nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
 	}
 
 	// 构建查询条件
-	query := s.db.Where("chat_agent_id = ? AND conversation_id = ? AND type = ? AND deleted_at IS NULL", chatAgent.ID, convID, "message")
+	query := &repository.ChatAgentMessageListQuery{
+		ChatAgentID:    chatAgent.ID,
+		ConversationID: convID,
+	}
 
-	// 处理游标分页
-	if lastID != "" {
-		lastMsgID, err := uuid.Parse(lastID)
-		if err != nil {
-			return nil, fmt.Errorf("无效的last_id: %w", err)
+	// 处理排序方式
+	if req.Sort != nil && *req.Sort != "" {
+		switch *req.Sort {
+		case "asc":
+			query.Ascending = true
+		case "desc":
+		default:
+			return nil, nil, apperror.Newf(apperror.CodeInvalidArgument, "不支持的排序方式: %s", *req.Sort)
 		}
+	}
 
-		// 获取lastID对应消息的创建时间
-		var lastMessage models.ChatAgentMessage
-		if err := s.db.Where("id = ?", lastMsgID).First(&lastMessage).Error; err == nil {
-			// 从该消息的创建时间往前获取更早的消息
-			query = query.Where("created_at < ?", lastMessage.CreatedAt)
+	// 处理创建时间范围
+	if req.CreatedBefore != nil {
+		createdBefore := time.UnixMilli(*req.CreatedBefore)
+		query.CreatedBefore = &createdBefore
+	}
+	if req.CreatedAfter != nil {
+		createdAfter := time.UnixMilli(*req.CreatedAfter)
+		query.CreatedAfter = &createdAfter
+	}
+
+	// 按需查询总数量（不受游标影响）
+	var totalCount *int64
+	if req.IncludeTotal {
+		count, err := s.messageRepo.CountByConversation(ctx, query)
+		if err != nil {
+			return nil, nil, fmt.Errorf("统计消息数量失败: %w", err)
 		}
+		totalCount = &count
 	}
 
-	// 按创建时间倒序排列
-	query = query.Order("created_at DESC")
+	// 处理游标分页，游标消息必须属于同一会话
+	if req.LastID != nil && *req.LastID != "" {
+		lastMsgID, err := uuid.Parse(*req.LastID)
+		if err != nil {
+			return nil, nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的last_id", err)
+		}
+
+		cursor, err := s.messageRepo.GetListCursor(ctx, convID, lastMsgID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("查询游标消息失败: %w", err)
+		}
+		if cursor == nil {
+			return nil, nil, apperror.New(apperror.CodeInvalidArgument, "last_id对应的消息不存在")
+		}
+		query.Cursor = cursor
+	}
 
-	// 限制返回数量
-	query = query.Limit(size)
+	// 多查询一条用于判断是否还有更多数据
+	size := normalizePageSize(req.Size)
+	query.Limit = size + 1
 
 	// 执行查询
-	var messages []*models.ChatAgentMessage
-	if err := query.Find(&messages).Error; err != nil {
-		return nil, fmt.Errorf("查询消息列表失败: %w", err)
+	messages, err := s.messageRepo.ListByConversation(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("查询消息列表失败: %w", err)
 	}
 
-	return messages, nil
+	pageInfo := &dto.CursorPageInfo{TotalCount: totalCount}
+	if len(messages) > size {
+		messages = messages[:size]
+		pageInfo.HasMore = true
+		pageInfo.NextCursor = stringPtr(messages[size-1].ID.String())
+	}
+
+	if err := s.messageRepo.LoadAttachments(ctx, messages); err != nil {
+		return nil, nil, fmt.Errorf("查询消息附件失败: %w", err)
+	}
+
+	return messages, pageInfo, nil
 }
 
 // CreateConversation 创建会话
@@ -155,64 +281,240 @@ func (s *chatAgentConversationService) CreateConversation(ctx context.Context, s
 		return nil, fmt.Errorf("获取上下文信息失败: %w", err)
 	}
 
-	// 过滤消息，去除消息中无需保存的数据，比如qwen中的/no_think
-	filteredUserMessage := userMessage
-	if strings.HasPrefix(userMessage, "/no_think") {
-		filteredUserMessage = userMessage[9:]
-	}
-
+	// 调用方传入的是预处理后的用户消息，已去除 /no_think 等指令
+	// 会话ID和创建时间在写入前确定，会话事件的内容与会话记录保持一致
+	now := time.Now()
 	conversation := &models.ChatAgentConversation{
-		Title:         filteredUserMessage,
+		Title:         userMessage,
 		ApplicationID: application.ID,
 		ChatAgentID:   chatAgent.ID,
 		ServiceUserID: serviceUserID,
+		LastMessageAt: &now,
 	}
+	conversation.ID = uuid.Must(uuid.NewV7())
+	conversation.CreatedAt = now
 
-	if err := s.conversationRepo.Create(ctx, conversation); err != nil {
+	if err := s.conversationRepo.CreateWithEvent(ctx, conversation, newConversationCreatedEvent(conversation)); err != nil {
 		return nil, fmt.Errorf("创建会话失败: %w", err)
 	}
 
+	s.monitorService.Publish(chatAgent.ID, dto.ConversationMonitorEventDto{
+		EventType:      dto.ConversationMonitorEventConversationCreated,
+		ChatAgentID:    chatAgent.ID.String(),
+		ConversationID: conversation.ID.String(),
+		ServiceUserID:  serviceUserID,
+		Content:        conversation.Title,
+		CreatedAt:      conversation.CreatedAt.UnixMilli(),
+	})
+
 	return conversation, nil
 }
 
 // GetConversationList 获取会话列表
-func (s *chatAgentConversationService) GetConversationList(ctx context.Context, serviceUserID, lastID string, size int) ([]*models.ChatAgentConversation, error) {
+// 只返回当前智能体下指定业务侧用户的会话，默认按最后一条消息的时间倒序游标分页，可指定按创建时间倒序
+func (s *chatAgentConversationService) GetConversationList(ctx context.Context, req *dto.GetConversationListRequest) ([]*models.ChatAgentConversation, *dto.CursorPageInfo, error) {
 	_, chatAgent, err := getContextInfo(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("无效的智能体ID: %w", err)
+		return nil, nil, fmt.Errorf("无效的智能体ID: %w", err)
+	}
+
+	if req.ServiceUserID == "" {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
 	}
 
 	// 构建查询条件
-	query := s.db.Where("chat_agent_id = ? AND service_user_id = ? AND deleted_at IS NULL", chatAgent.ID, serviceUserID)
+	conditions := map[string]interface{}{
+		"chat_agent_id":   chatAgent.ID,
+		"service_user_id": req.ServiceUserID,
+	}
+	query := &repository.ChatAgentConversationListQuery{
+		ChatAgentID:   chatAgent.ID,
+		ServiceUserID: req.ServiceUserID,
+	}
 
-	// 处理游标分页
-	if lastID != "" {
-		lastConvID, err := uuid.Parse(lastID)
-		if err != nil {
-			return nil, fmt.Errorf("无效的last_id: %w", err)
+	// 处理排序方式，默认按最后一条消息的时间倒序，最近活跃的会话排在前面
+	if req.Sort != nil && *req.Sort != "" {
+		switch *req.Sort {
+		case "last_message_at":
+		case "created_at":
+			query.SortByCreatedAt = true
+		default:
+			return nil, nil, apperror.Newf(apperror.CodeInvalidArgument, "不支持的排序方式: %s", *req.Sort)
 		}
+	}
 
-		// 获取lastID对应会话的创建时间
-		var lastConversation models.ChatAgentConversation
-		if err := s.db.Where("id = ?", lastConvID).First(&lastConversation).Error; err == nil {
-			// 从该会话的创建时间往前获取更早的会话
-			query = query.Where("created_at < ?", lastConversation.CreatedAt)
+	// 按需查询总数量（不受游标影响）
+	var totalCount *int64
+	if req.IncludeTotal {
+		count, err := s.conversationRepo.Count(ctx, conditions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("统计会话数量失败: %w", err)
 		}
+		totalCount = &count
 	}
 
-	// 按创建时间倒序排列
-	query = query.Order("created_at DESC")
+	// 多查询一条用于判断是否还有更多数据
+	size := normalizePageSize(req.Size)
+	query.Limit = size + 1
+
+	// 处理游标分页，游标会话必须属于同一智能体和用户
+	if req.LastID != nil && *req.LastID != "" {
+		lastConvID, err := uuid.Parse(*req.LastID)
+		if err != nil {
+			return nil, nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的last_id", err)
+		}
 
-	// 限制返回数量
-	query = query.Limit(size)
+		lastConversation, err := s.conversationRepo.GetByID(ctx, lastConvID)
+		if err != nil || lastConversation.ChatAgentID != chatAgent.ID || lastConversation.ServiceUserID != req.ServiceUserID {
+			return nil, nil, apperror.New(apperror.CodeInvalidArgument, "last_id对应的会话不存在")
+		}
+		query.Cursor = lastConversation
+	}
 
 	// 执行查询
-	var conversations []*models.ChatAgentConversation
-	if err := query.Find(&conversations).Error; err != nil {
-		return nil, fmt.Errorf("查询会话列表失败: %w", err)
+	conversations, err := s.conversationRepo.ListByServiceUser(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("查询会话列表失败: %w", err)
+	}
+
+	pageInfo := &dto.CursorPageInfo{TotalCount: totalCount}
+	if len(conversations) > size {
+		conversations = conversations[:size]
+		pageInfo.HasMore = true
+		pageInfo.NextCursor = stringPtr(conversations[size-1].ID.String())
 	}
 
-	return conversations, nil
+	return conversations, pageInfo, nil
+}
+
+// ListConversationsWithStats 管理后台查询智能体下全部业务侧用户的会话
+// 与面向业务侧的会话列表不同，不要求业务侧用户ID，使用页码分页
+func (s *chatAgentConversationService) ListConversationsWithStats(ctx context.Context, req *dto.AdminConversationListRequest) ([]*repository.ChatAgentConversationWithStats, int64, error) {
+	chatAgentID, err := uuid.Parse(req.ChatAgentID)
+	if err != nil {
+		return nil, 0, apperror.Wrap(apperror.CodeInvalidArgument, "无效的智能体ID", err)
+	}
+	if _, err := s.chatAgentRepo.GetByID(ctx, chatAgentID); err != nil {
+		return nil, 0, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	if req.MinTotalTokens != nil && req.MaxTotalTokens != nil && *req.MinTotalTokens > *req.MaxTotalTokens {
+		return nil, 0, apperror.New(apperror.CodeInvalidArgument, "最小token用量不能大于最大token用量")
+	}
+
+	query := &repository.ChatAgentConversationStatsQuery{
+		ChatAgentID:        chatAgentID,
+		ServiceUserID:      req.ServiceUserID,
+		HasErrors:          req.HasErrors,
+		HasPersistenceGaps: req.HasPersistenceGaps,
+		MinTotalTokens:     req.MinTotalTokens,
+		MaxTotalTokens:     req.MaxTotalTokens,
+		Page:               req.Page,
+		PageSize:           normalizePageSize(&req.PageSize),
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if req.CreatedAfter != nil {
+		createdAfter := time.UnixMilli(*req.CreatedAfter)
+		query.CreatedAfter = &createdAfter
+	}
+	if req.CreatedBefore != nil {
+		createdBefore := time.UnixMilli(*req.CreatedBefore)
+		query.CreatedBefore = &createdBefore
+	}
+
+	conversations, total, err := s.conversationRepo.ListWithStats(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("查询会话列表失败: %w", err)
+	}
+	return conversations, total, nil
+}
+
+// GetConversation 获取单个会话详情
+// 校验会话归属于当前智能体和业务侧用户
+func (s *chatAgentConversationService) GetConversation(ctx context.Context, serviceUserID, conversationID string) (*dto.GetConversationResponse, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("无效的智能体ID: %w", err)
+	}
+
+	if serviceUserID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
+	}
+
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+
+	// 验证会话是否存在且属于该用户和智能体
+	conversation, err := s.conversationRepo.GetByID(ctx, convID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID || conversation.ServiceUserID != serviceUserID {
+		return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+	}
+
+	// 统计消息数量和token用量
+	stats, err := s.messageRepo.GetConversationMessageStats(ctx, convID)
+	if err != nil {
+		return nil, fmt.Errorf("统计会话消息失败: %w", err)
+	}
+
+	// 获取最后一条消息
+	lastMessage, err := s.messageRepo.GetLastMessageByConversationID(ctx, convID)
+	if err != nil {
+		return nil, fmt.Errorf("查询最后一条消息失败: %w", err)
+	}
+
+	createdAt := conversation.CreatedAt.UnixMilli()
+	updatedAt := conversation.UpdatedAt.UnixMilli()
+	response := &dto.GetConversationResponse{
+		Conversation: dto.ConversationInfoDto{
+			ID:                   conversation.ID.String(),
+			Title:                conversation.Title,
+			ApplicationID:        conversation.ApplicationID.String(),
+			ServiceUserID:        conversation.ServiceUserID,
+			CreatedAt:            &createdAt,
+			UpdatedAt:            &updatedAt,
+			PromptTokenCount:     conversation.PromptTokenCount,
+			CompletionTokenCount: conversation.CompletionTokenCount,
+			TotalTokenCount:      conversation.TotalTokenCount,
+			Cost:                 conversation.Cost,
+		},
+		MessageCount:    stats.MessageCount,
+		TotalTokenCount: conversation.TotalTokenCount,
+	}
+	if lastMessage != nil {
+		lastMessageCreatedAt := lastMessage.CreatedAt.UnixMilli()
+		response.LastMessagePreview = stringPtr(messagePreview(lastMessage.Content))
+		response.LastMessageRole = stringPtr(lastMessage.Role)
+		response.LastMessageCreatedAt = &lastMessageCreatedAt
+	}
+
+	return response, nil
+}
+
+// GetConversationSummaries 批量获取会话的最后一条消息预览、消息数量和未读回复数量
+// 一次查询统计全部会话，会话列表不需要为每个会话单独查询
+func (s *chatAgentConversationService) GetConversationSummaries(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID]*dto.ConversationSummaryDto, error) {
+	summaries, err := s.messageRepo.GetConversationSummaries(ctx, conversationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("统计会话消息摘要失败: %w", err)
+	}
+
+	result := make(map[uuid.UUID]*dto.ConversationSummaryDto, len(summaries))
+	for conversationID, summary := range summaries {
+		result[conversationID] = &dto.ConversationSummaryDto{
+			LastMessagePreview: messagePreview(summary.LastMessageContent),
+			LastMessageRole:    summary.LastMessageRole,
+			LastMessageAt:      summary.LastMessageAt.UnixMilli(),
+			MessageCount:       summary.MessageCount,
+			UnreadCount:        summary.UnreadCount,
+		}
+	}
+	return result, nil
 }
 
 // DeleteConversation 删除会话
@@ -250,8 +552,8 @@ func (s *chatAgentConversationService) DeleteConversation(ctx context.Context, s
 	}
 
 	// 2. 删除会话相关的所有消息
-	var messages []*models.ChatAgentMessage
-	if err := s.db.Where("chat_agent_id = ? AND conversation_id = ?", chatAgent.ID, convID).Find(&messages).Error; err != nil {
+	messages, err := s.messageRepo.GetAllByConversationID(ctx, convID)
+	if err != nil {
 		return &dto.DeleteConversationResponse{
 			Success: false,
 			Error:   stringPtr(fmt.Sprintf("查询消息失败: %v", err)),
@@ -274,7 +576,7 @@ func (s *chatAgentConversationService) DeleteConversation(ctx context.Context, s
 	// 3. 删除会话相关的所有附件
 	var attachments []*models.ChatAgentAttachment
 	if len(messageIDs) > 0 {
-		if err := s.db.Where("chat_agent_id = ? AND message_id IN ?", chatAgent.ID, messageIDs).Find(&attachments).Error; err != nil {
+		if attachments, err = s.attachmentRepo.ListByMessageIDs(ctx, chatAgent.ID, messageIDs); err != nil {
 			log.Printf("查询附件失败: %v", err)
 		} else {
 			// 删除附件文件和目录
@@ -296,7 +598,13 @@ func (s *chatAgentConversationService) DeleteConversation(ctx context.Context, s
 	}
 
 	// 4. 删除会话本身
-	if err := s.conversationRepo.DeleteByID(ctx, convID); err != nil {
+	// 删除会话变量
+	if err := s.variableService.DeleteConversationVariables(ctx, convID); err != nil {
+		log.Printf("删除会话变量失败: %v", err)
+	}
+
+	// 会话和登记的消息请求ID一起删除，避免残留的请求ID记录
+	if err := s.conversationRepo.DeleteWithMessageRequests(ctx, convID); err != nil {
 		return &dto.DeleteConversationResponse{
 			Success: false,
 			Error:   stringPtr(fmt.Sprintf("删除会话失败: %v", err)),
@@ -311,6 +619,48 @@ func (s *chatAgentConversationService) DeleteConversation(ctx context.Context, s
 	}, nil
 }
 
+// GetChatBootstrap 获取当前智能体的聊天首屏信息
+// 包含智能体信息、欢迎语、推荐问题和当前是否可用
+func (s *chatAgentConversationService) GetChatBootstrap(ctx context.Context) (*dto.ChatBootstrapResponse, error) {
+	application, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取上下文信息失败: %w", err)
+	}
+	setting, err := s.settingService.GetApplicationSetting(ctx, application.ID)
+	if err != nil {
+		return nil, err
+	}
+	displayName := setting.DisplayName
+	if displayName == "" {
+		displayName = application.Name
+	}
+
+	suggestedQuestions, err := parseSuggestedQuestions(chatAgent.SuggestedQuestions)
+	if err != nil {
+		// 保存时已校验，内容无效时不返回推荐问题
+		suggestedQuestions = []string{}
+	}
+	unavailableMessage, unavailable := resolveUnavailableAnswer(chatAgent, time.Now())
+
+	return &dto.ChatBootstrapResponse{
+		ChatAgentID:        chatAgent.ID.String(),
+		Name:               chatAgent.Name,
+		Description:        chatAgent.Description,
+		AvatarUrl:          chatAgent.AvatarUrl,
+		WelcomeMessage:     chatAgent.WelcomeMessage,
+		SuggestedQuestions: suggestedQuestions,
+		DefaultStreamable:  chatAgent.DefaultStreamable,
+		Available:          !unavailable,
+		UnavailableMessage: unavailableMessage,
+		Branding: dto.ChatBootstrapBranding{
+			DisplayName:     displayName,
+			LogoUrl:         setting.LogoUrl,
+			ThemeColor:      setting.ThemeColor,
+			DefaultLanguage: setting.DefaultLanguage,
+		},
+	}, nil
+}
+
 // UserSendMessagePredefinedAnswer 用户发送消息，返回固定答案
 func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx context.Context, req *dto.ChatUserSendMessageRequest, streamable bool) (io.Reader, error) {
 	// 从上下文中获取ApplicationID和ChatAgentID
@@ -319,6 +669,19 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 		return nil, fmt.Errorf("获取上下文信息失败: %w", err)
 	}
 
+	// 校验客户端指定的请求ID格式
+	if err := validateClientRequestID(req.RequestID); err != nil {
+		return nil, err
+	}
+
+	// 用户消息超过智能体设置的长度上限时拒绝请求
+	if err := checkUserMessageLength(chatAgent, req.UserMessage); err != nil {
+		return nil, err
+	}
+
+	// 预处理用户输入，保存清理后的消息内容
+	input := preprocessUserInput(chatAgent, req.UserMessage)
+
 	// 如果conversation_id为空，则认为是新的会话
 	var conversation *models.ChatAgentConversation
 	var conversationIDStr string
@@ -326,7 +689,7 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 
 	if req.ConversationID == nil {
 		// 创建新会话
-		conversation, err = s.CreateConversation(ctx, req.ServiceUserID, req.UserMessage)
+		conversation, err = s.CreateConversation(ctx, req.ServiceUserID, input.Content)
 		if err != nil {
 			return nil, fmt.Errorf("创建会话失败: %w", err)
 		}
@@ -335,13 +698,13 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 		// 根据会话id进行查询，如果找不到那么就创建一个新的会话
 		convID, err := uuid.Parse(*req.ConversationID)
 		if err != nil {
-			return nil, fmt.Errorf("无效的会话ID: %w", err)
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
 		}
 
 		conversation, err = s.conversationRepo.GetByID(ctx, convID)
 		if err != nil || conversation == nil {
 			// 创建新会话
-			conversation, err = s.CreateConversation(ctx, req.ServiceUserID, req.UserMessage)
+			conversation, err = s.CreateConversation(ctx, req.ServiceUserID, input.Content)
 			if err != nil {
 				return nil, fmt.Errorf("创建会话失败: %w", err)
 			}
@@ -349,10 +712,16 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 		} else {
 			conversationIDStr = *req.ConversationID
 			// 是历史会话，查询历史消息
-			messageList, err := s.GetChatMessageList(ctx, conversationIDStr, "", 100)
+			messageList, _, err := s.GetChatMessageList(ctx, &dto.GetChatMessageListRequest{
+				ConversationID: conversationIDStr,
+				ServiceUserID:  req.ServiceUserID,
+				Size:           intPtr(maxHistoryMessageCount),
+			})
 			if err != nil {
 				return nil, fmt.Errorf("获取历史消息失败: %w", err)
 			}
+			// 消息列表按创建时间倒序返回，历史消息需要按时间正序交给模型
+			slices.Reverse(messageList)
 
 			// 构建历史消息列表（为将来的AI对话功能预留）
 			historyMessages = make([]al_client.ChatMessage, 0, len(messageList))
@@ -374,8 +743,17 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 		return nil, fmt.Errorf("会话创建失败")
 	}
 
-	// 生成请求id
-	requestID := uuid.New().String()
+	// 解析增量输出分块模式
+	deltaChunkMode, err := resolveDeltaChunkMode(req.DeltaChunkMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 生成请求id，客户端指定时校验会话内唯一性
+	requestID, err := s.resolveRequestID(ctx, conversation, req.RequestID)
+	if err != nil {
+		return nil, err
+	}
 
 	// 将用户的消息存储到数据库
 	userMessageObj := &models.ChatAgentMessage{
@@ -385,9 +763,10 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 		RequestID:      requestID,
 		Type:           "message",
 		Role:           "user",
-		Content:        req.UserMessage,
+		Content:        input.Content,
+		Language:       input.Language,
 	}
-	if err := s.messageRepo.Create(ctx, userMessageObj); err != nil {
+	if err := s.saveMessage(ctx, userMessageObj); err != nil {
 		return nil, fmt.Errorf("保存用户消息失败: %w", err)
 	}
 
@@ -401,7 +780,7 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 		Role:           "assistant",
 		Content:        *req.PredefinedAnswer,
 	}
-	if err := s.messageRepo.Create(ctx, assistantMessageObj); err != nil {
+	if err := s.saveMessage(ctx, assistantMessageObj); err != nil {
 		return nil, fmt.Errorf("保存助手消息失败: %w", err)
 	}
 
@@ -412,17 +791,12 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 
 		// 将固定的答案用streamable流的形式逐字返回给用户
 		if streamable {
+			writeDelta := newAnswerDeltaWriter(pw, conversationIDStr, requestID, deltaChunkMode)
 			// 流式返回，逐字返回
 			for _, char := range *req.PredefinedAnswer {
-				event := dto.ChatMessageResponseEventDto{
-					ConversationID: conversationIDStr,
-					RequestID:      requestID,
-					MessageType:    "answer_delta",
-					Content:        string(char),
-				}
-				eventJSON, _ := json.Marshal(event)
-				pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+				writeDelta(string(char), false)
 			}
+			writeDelta("", true)
 			// 最后返回完整答案
 			event := dto.ChatMessageResponseEventDto{
 				ConversationID: conversationIDStr,
@@ -448,6 +822,79 @@ func (s *chatAgentConversationService) UserSendMessagePredefinedAnswer(ctx conte
 	return pr, nil
 }
 
+// replyWithPredefinedAnswer 以指定的预制答案回复用户消息
+// 复用预制答案流程保存消息并返回，不调用模型
+func (s *chatAgentConversationService) replyWithPredefinedAnswer(ctx context.Context, req *dto.ChatUserSendMessageRequest, answer string, streamable bool) (io.Reader, error) {
+	predefinedReq := *req
+	predefinedReq.PredefinedAnswer = &answer
+	if predefinedReq.ConversationID != nil && *predefinedReq.ConversationID == "" {
+		predefinedReq.ConversationID = nil
+	}
+	return s.UserSendMessagePredefinedAnswer(ctx, &predefinedReq, streamable)
+}
+
+// replyWithBlockedAnswer 以拦截回复回复命中输入拦截规则的用户消息
+// 用户消息和拦截回复均保存为 blocked 类型的消息，不会作为历史消息交给模型
+func (s *chatAgentConversationService) replyWithBlockedAnswer(ctx context.Context, req *dto.ChatUserSendMessageRequest, input *preprocessedInput, reply string) (io.Reader, error) {
+	application, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取上下文信息失败: %w", err)
+	}
+
+	var conversation *models.ChatAgentConversation
+	if req.ConversationID != nil && *req.ConversationID != "" {
+		convID, err := uuid.Parse(*req.ConversationID)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+		}
+		conversation, _ = s.conversationRepo.GetByID(ctx, convID)
+	}
+	if conversation == nil {
+		conversation, err = s.CreateConversation(ctx, req.ServiceUserID, input.Content)
+		if err != nil {
+			return nil, fmt.Errorf("创建会话失败: %w", err)
+		}
+	}
+	conversationIDStr := conversation.ID.String()
+
+	requestID, err := s.resolveRequestID(ctx, conversation, req.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range []*models.ChatAgentMessage{
+		{Role: "user", Content: input.Content, Language: input.Language},
+		{Role: "assistant", Content: reply},
+	} {
+		message.ApplicationID = application.ID
+		message.ChatAgentID = chatAgent.ID
+		message.ConversationID = conversation.ID
+		message.RequestID = requestID
+		message.Type = "blocked"
+		if err := s.saveMessage(ctx, message); err != nil {
+			return nil, fmt.Errorf("保存拦截消息失败: %w", err)
+		}
+	}
+
+	// 先返回 blocked 事件标记消息被拦截，再返回完整答案，只处理 answer 事件的客户端也能展示拦截回复
+	pr, pw := io.Pipe()
+	go func() {
+		defer pw.Close()
+		for _, messageType := range []string{"blocked", "answer"} {
+			event := dto.ChatMessageResponseEventDto{
+				ConversationID: conversationIDStr,
+				RequestID:      requestID,
+				MessageType:    messageType,
+				Content:        reply,
+			}
+			eventJSON, _ := json.Marshal(event)
+			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+		}
+	}()
+
+	return pr, nil
+}
+
 // UserSendMessage 用户发送消息
 func (s *chatAgentConversationService) UserSendMessage(ctx context.Context, req *dto.ChatUserSendMessageRequest, streamable bool) (io.Reader, error) {
 	// 从上下文中获取ApplicationID和ChatAgentID
@@ -456,14 +903,58 @@ func (s *chatAgentConversationService) UserSendMessage(ctx context.Context, req
 		return nil, fmt.Errorf("获取上下文信息失败: %w", err)
 	}
 
+	// 校验客户端指定的请求ID格式
+	if err := validateClientRequestID(req.RequestID); err != nil {
+		return nil, err
+	}
+
+	// 用户消息超过智能体设置的长度上限时拒绝请求
+	if err := checkUserMessageLength(chatAgent, req.UserMessage); err != nil {
+		return nil, err
+	}
+
+	// 请求指定了MCP工具时只能使用智能体已启用的工具
+	mcpTools, err := s.resolveRequestedMcpTools(ctx, chatAgent.ID, req.UsedMcpToolList)
+	if err != nil {
+		return nil, err
+	}
+
+	// 维护模式或不在服务时间内时，直接返回预制答案，不调用模型
+	if answer, unavailable := resolveUnavailableAnswer(chatAgent, time.Now()); unavailable {
+		return s.replyWithPredefinedAnswer(ctx, req, answer, streamable)
+	}
+
+	// 预处理用户输入：去除 /no_think 等指令、清理空白、过滤敏感词并识别语言
+	input := preprocessUserInput(chatAgent, req.UserMessage)
+	// 回复语言跟随用户时需要识别语言，即使预处理配置未启用语言识别
+	if chatAgent.ReplyLanguage == define.ChatReplyLanguageAuto && input.Language == "" {
+		detectLanguageStep(input)
+	}
+
+	// 用户消息命中应用的输入拦截规则时，返回拦截回复并保存为拦截消息，不调用模型
+	// 使用原始消息匹配，避免敏感词过滤后规则无法命中
+	if reply, blocked, err := s.settingService.MatchBlockedInput(ctx, application.ID, req.UserMessage); err != nil {
+		return nil, err
+	} else if blocked {
+		return s.replyWithBlockedAnswer(ctx, req, input, reply)
+	}
+
+	// 用户消息命中预制答案规则时，直接返回规则答案，不调用模型；匹配出错时继续交给模型处理
+	if rule, err := s.answerRuleService.MatchAnswerRule(ctx, chatAgent.ID, input.Content); err != nil {
+		log.Printf("匹配预制答案规则失败: %v", err)
+	} else if rule != nil {
+		return s.replyWithPredefinedAnswer(ctx, req, rule.Answer, streamable)
+	}
+
 	// 如果conversation_id为空，则认为是新的会话
 	var conversation *models.ChatAgentConversation
 	var conversationIDStr string
 	var historyMessages []al_client.ChatMessage
+	isHistoryConversation := false
 
 	if req.ConversationID == nil || *req.ConversationID == "" {
 		// 创建新会话
-		conversation, err = s.CreateConversation(ctx, req.ServiceUserID, req.UserMessage)
+		conversation, err = s.CreateConversation(ctx, req.ServiceUserID, input.Content)
 		if err != nil {
 			return nil, fmt.Errorf("创建会话失败: %w", err)
 		}
@@ -472,35 +963,19 @@ func (s *chatAgentConversationService) UserSendMessage(ctx context.Context, req
 		// 根据会话id进行查询，如果找不到那么就创建一个新的会话
 		convID, err := uuid.Parse(*req.ConversationID)
 		if err != nil {
-			return nil, fmt.Errorf("无效的会话ID: %w", err)
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
 		}
 
 		conversation, err = s.conversationRepo.GetByID(ctx, convID)
 		if err != nil || conversation == nil {
-			conversation, err = s.CreateConversation(ctx, req.ServiceUserID, req.UserMessage)
+			conversation, err = s.CreateConversation(ctx, req.ServiceUserID, input.Content)
 			if err != nil {
 				return nil, fmt.Errorf("创建会话失败: %w", err)
 			}
 			conversationIDStr = conversation.ID.String()
 		} else {
 			conversationIDStr = *req.ConversationID
-			// 是历史会话，查询历史消息
-			messageList, err := s.GetChatMessageList(ctx, conversationIDStr, "", 100)
-			if err != nil {
-				return nil, fmt.Errorf("获取历史消息失败: %w", err)
-			}
-
-			// 构建历史消息列表
-			historyMessages = make([]al_client.ChatMessage, 0, len(messageList))
-			for _, messageItem := range messageList {
-				// 只处理普通消息类型，跳过函数调用相关消息
-				if messageItem.Type == "message" && messageItem.Role != "" {
-					historyMessages = append(historyMessages, al_client.ChatMessage{
-						Role:    messageItem.Role,
-						Content: messageItem.Content,
-					})
-				}
-			}
+			isHistoryConversation = true
 		}
 	}
 
@@ -508,17 +983,65 @@ func (s *chatAgentConversationService) UserSendMessage(ctx context.Context, req
 		return nil, fmt.Errorf("会话创建失败")
 	}
 
+	// 生成请求id，客户端指定时校验会话内唯一性
+	requestID, err := s.resolveRequestID(ctx, conversation, req.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一会话同时只处理一条消息，避免并发发送导致历史消息交错
+	// 回复流读取结束或客户端断开时释放，处理出错时立即释放
+	finishRequest, err := s.startConversationRequest(ctx, conversation.ID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			finishRequest()
+		}
+	}()
+
+	if isHistoryConversation {
+		// 是历史会话，查询历史消息
+		messageList, _, err := s.GetChatMessageList(ctx, &dto.GetChatMessageListRequest{
+			ConversationID: conversationIDStr,
+			ServiceUserID:  req.ServiceUserID,
+			Size:           intPtr(maxHistoryMessageCount),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取历史消息失败: %w", err)
+		}
+		// 消息列表按创建时间倒序返回，历史消息需要按时间正序交给模型
+		slices.Reverse(messageList)
+
+		// 构建历史消息列表
+		historyMessages = make([]al_client.ChatMessage, 0, len(messageList))
+		for _, messageItem := range messageList {
+			// 只处理普通消息类型，跳过函数调用相关消息
+			if messageItem.Type == "message" && messageItem.Role != "" {
+				historyMessages = append(historyMessages, al_client.ChatMessage{
+					Role:    messageItem.Role,
+					Content: messageItem.Content,
+				})
+			}
+		}
+	}
+
+	// 解析增量输出分块模式
+	deltaChunkMode, err := resolveDeltaChunkMode(req.DeltaChunkMode)
+	if err != nil {
+		return nil, err
+	}
+
 	// 准备工具列表
-	openaiToolsList, err := s.prepareToolsList(ctx, req.UsedMcpToolList, req.UsedInternalToolList)
+	openaiToolsList, err := s.prepareToolsList(ctx, mcpTools, req.UsedInternalToolList)
 	if err != nil {
 		log.Printf("获取工具列表失败: %v", err)
 		// 工具获取失败不影响主流程，使用空工具列表
 		openaiToolsList = []al_client.Tool{}
 	}
 
-	// 生成请求id
-	requestID := uuid.New().String()
-
 	// 将用户的消息存储到数据库
 	userMessageObj := &models.ChatAgentMessage{
 		ApplicationID:  application.ID,
@@ -527,55 +1050,124 @@ func (s *chatAgentConversationService) UserSendMessage(ctx context.Context, req
 		RequestID:      requestID,
 		Type:           "message",
 		Role:           "user",
-		Content:        req.UserMessage,
+		Content:        input.Content,
+		Language:       input.Language,
 	}
-	if err := s.messageRepo.Create(ctx, userMessageObj); err != nil {
+	if err := s.saveMessage(ctx, userMessageObj); err != nil {
 		return nil, fmt.Errorf("保存用户消息失败: %w", err)
 	}
 
 	// 处理附件
 	attachmentsPrompt := ""
 	if len(req.Attachments) > 0 {
+		// 附件通过消息ID关联到用户消息，消息列表按消息ID加载
 		attachmentInfoList, err := s.processMessageAttachments(ctx, req.Attachments, userMessageObj)
 		if err != nil {
 			log.Printf("处理附件失败: %v", err)
 		} else {
-			// 更新消息的附件信息
 			attachmentInfoJSON, _ := json.Marshal(attachmentInfoList)
-			userMessageObj.AttachmentsInfo = string(attachmentInfoJSON)
-			if err := s.messageRepo.Update(ctx, userMessageObj); err != nil {
-				log.Printf("更新消息附件信息失败: %v", err)
-			}
 			attachmentsPrompt = "用户上传的附件文件ID数组：" + string(attachmentInfoJSON) + "\n\n"
 		}
 	}
 
+	// 从绑定的知识库检索相关片段（应用开启了知识库检索开关时），检索失败时不影响主流程
+	var citations []dto.ChatCitationDto
+	if s.featureFlagService.IsEnabled(ctx, chatAgent.ApplicationID, define.FeatureFlagKnowledgeRetrieval) {
+		if retrievalResults, err := s.knowledgeBaseService.Retrieve(ctx, chatAgent, input.Content); err != nil {
+			log.Printf("知识库检索失败: %v", err)
+		} else {
+			citations = buildKnowledgeCitations(retrievalResults)
+		}
+	}
+
+	// 系统提示词中的 {{变量名}} 替换为会话变量的值
+	variables, err := s.variableService.GetVariableValues(ctx, conversation.ID)
+	if err != nil {
+		log.Printf("获取会话变量失败: %v", err)
+	}
+	systemPrompt := renderConversationVariables(req.SystemPrompt, variables)
+
+	// 启用会话变量时告知模型当前的变量，检索到知识库片段时提供参考资料，作为注入消息交给模型并单独保存
+	injections := buildChatInjections(buildConversationVariableInstruction(chatAgent.EnableConversationVariables, variables), citations)
+	s.saveInjectedMessages(ctx, application, chatAgent, conversation.ID, requestID, injections)
+
 	// 构建完整的消息列表
-	messages := make([]al_client.ChatMessage, 0, len(historyMessages)+2)
+	messages := make([]al_client.ChatMessage, 0, len(historyMessages)+len(injections)+2)
 
-	// 添加系统提示词
+	// 添加系统提示词，配置了强制回复语言时追加回复语言指令
 	messages = append(messages, al_client.ChatMessage{
 		Role:    "system",
-		Content: req.SystemPrompt,
+		Content: appendSystemInstruction(systemPrompt, resolveReplyLanguageInstruction(chatAgent, input.Language)),
 	})
 
+	// 添加注入消息
+	messages = append(messages, injectedChatMessages(chatAgent, injections)...)
+
 	// 添加历史消息
 	messages = append(messages, historyMessages...)
 
-	// 添加当前用户消息（包含附件信息）
+	// 添加当前用户消息（包含附件信息），去除的指令需要交给模型
 	messages = append(messages, al_client.ChatMessage{
 		Role:    "user",
-		Content: attachmentsPrompt + req.UserMessage,
+		Content: attachmentsPrompt + input.ModelContent(),
 	})
 
 	log.Printf("开始处理消息，请求id:%s, 请求工具：%v, 工具列表数量：%d", requestID, req.UsedMcpToolList, len(openaiToolsList))
 
-	// 交给AI处理消息
+	// 通知会话的各个设备智能体正在生成回复，回复流读取结束后恢复
+	s.presenceService.SetAgentGenerating(conversation.ID, requestID, true)
+	var finishOnce sync.Once
+	finish := func() {
+		finishOnce.Do(func() {
+			s.presenceService.SetAgentGenerating(conversation.ID, requestID, false)
+			finishRequest()
+		})
+	}
+
+	// 交给AI处理消息，生成时间和输出Token限制在工具调用后的多轮模型调用之间共享
+	limiter := newChatAnswerLimiter(chatAgent, time.Now())
+	var reader io.Reader
 	if streamable {
-		return s.aiProcessStreamable(ctx, conversationIDStr, requestID, messages, openaiToolsList) //openaiToolsList)
+		reader, err = s.aiProcessStreamable(ctx, conversationIDStr, requestID, deltaChunkMode, messages, openaiToolsList, citations, limiter)
 	} else {
-		return s.aiProcess(ctx, conversationIDStr, requestID, messages, openaiToolsList)
+		reader, err = s.aiProcess(ctx, conversationIDStr, requestID, messages, openaiToolsList, citations, limiter, nil)
 	}
+	if err != nil {
+		finish()
+		return nil, err
+	}
+	succeeded = true
+	// 客户端断开后回复流不再被读取，此时同样结束
+	context.AfterFunc(ctx, finish)
+	return &generatingReader{reader: reader, finish: finish}, nil
+}
+
+// startConversationRequest 登记会话正在处理的请求
+// 会话已有未过期的处理中请求时返回冲突错误，错误详情中包含处理中的请求ID
+// 返回：释放登记的函数，可重复调用
+func (s *chatAgentConversationService) startConversationRequest(ctx context.Context, conversationID uuid.UUID, requestID string) (func(), error) {
+	started, err := s.conversationRepo.TryStartRequest(ctx, conversationID, requestID, time.Now().Add(-conversationRequestStaleAfter))
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "登记会话请求失败", err)
+	}
+	if !started {
+		conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+		if err != nil {
+			return nil, apperror.New(apperror.CodeConflict, "会话正在处理其他消息，请稍后重试")
+		}
+		return nil, apperror.New(apperror.CodeConflict, "会话正在处理其他消息，请稍后重试").
+			WithDetails(map[string]string{"in_flight_request_id": conversation.ActiveRequestID})
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// 客户端断开后上下文已取消，仍需释放
+			if err := s.conversationRepo.FinishRequest(context.WithoutCancel(ctx), conversationID, requestID); err != nil {
+				log.Printf("释放会话请求失败: %v", err)
+			}
+		})
+	}, nil
 }
 
 // UploadAttachment 上传聊天附件
@@ -610,41 +1202,17 @@ func (s *chatAgentConversationService) UploadAttachment(ctx context.Context, fil
 	// 生成附件ID
 	attachmentID := uuid.New()
 
-	// 创建存储目录
-	baseStorageDir := "chat_attachment_files"
-	attachmentDir := filepath.Join(baseStorageDir, attachmentID.String())
-	if err := os.MkdirAll(attachmentDir, 0755); err != nil {
-		return &dto.UploadAttachmentResponse{
-			Success: false,
-			Error:   stringPtr(fmt.Sprintf("创建存储目录失败: %v", err)),
-		}, nil
-	}
-
 	// 保存原始文件
-	filePath := filepath.Join(attachmentDir, fmt.Sprintf("file%s", fileExtension))
-	dst, err := os.Create(filePath)
+	filePath, err := writeAttachmentFile(attachmentID, fileExtension, file)
 	if err != nil {
 		return &dto.UploadAttachmentResponse{
 			Success: false,
-			Error:   stringPtr(fmt.Sprintf("创建文件失败: %v", err)),
-		}, nil
-	}
-	defer dst.Close()
-
-	if _, err := io.Copy(dst, file); err != nil {
-		return &dto.UploadAttachmentResponse{
-			Success: false,
-			Error:   stringPtr(fmt.Sprintf("保存文件失败: %v", err)),
+			Error:   stringPtr(err.Error()),
 		}, nil
 	}
 
 	// 确定附件类型
-	attachmentType := "other"
-	if isDocumentFile(fileExtension) {
-		attachmentType = "document"
-	} else if isImageFile(fileExtension) {
-		attachmentType = "image"
-	}
+	attachmentType := attachmentTypeOf(fileExtension)
 
 	// 创建附件记录
 	attachment := &models.ChatAgentAttachment{
@@ -678,6 +1246,110 @@ func (s *chatAgentConversationService) UploadAttachment(ctx context.Context, fil
 	}, nil
 }
 
+// GetAttachment 获取当前智能体的聊天附件
+func (s *chatAgentConversationService) GetAttachment(ctx context.Context, serviceUserID, attachmentID string) (*models.ChatAgentAttachment, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentUUID, err := uuid.Parse(attachmentID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的附件ID", err)
+	}
+	attachment, err := s.attachmentRepo.GetByID(ctx, attachmentUUID)
+	if err != nil || attachment.ChatAgentID != chatAgent.ID {
+		return nil, apperror.New(apperror.CodeNotFound, "附件不存在")
+	}
+	if serviceUserID != "" && attachment.ConversationID != uuid.Nil {
+		conversation, err := s.conversationRepo.GetByID(ctx, attachment.ConversationID)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+		}
+		if conversation.ServiceUserID != serviceUserID {
+			return nil, apperror.New(apperror.CodeForbidden, "无权访问此附件")
+		}
+	}
+	if attachment.FilePath == "" {
+		return nil, apperror.New(apperror.CodeNotFound, "附件文件不存在")
+	}
+	return attachment, nil
+}
+
+// GetChatMessageListByRequestID 根据请求ID获取会话中的消息列表
+// 返回同一请求产生的全部消息（用户消息、工具调用及助手回复），按创建时间正序，不包含注入消息
+func (s *chatAgentConversationService) GetChatMessageListByRequestID(ctx context.Context, serviceUserID, conversationID, requestID string) ([]*models.ChatAgentMessage, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("无效的智能体ID: %w", err)
+	}
+
+	if serviceUserID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
+	}
+
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+
+	// 验证会话是否存在且属于该用户和智能体
+	conversation, err := s.conversationRepo.GetByID(ctx, convID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID || conversation.ServiceUserID != serviceUserID {
+		return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+	}
+
+	messages, err := s.messageRepo.GetByConversationIDAndRequestID(ctx, convID, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("查询消息列表失败: %w", err)
+	}
+	messages = slices.DeleteFunc(messages, func(message *models.ChatAgentMessage) bool {
+		return message.Type == define.ChatMessageTypeInjected
+	})
+	if err := s.messageRepo.LoadAttachments(ctx, messages); err != nil {
+		return nil, fmt.Errorf("查询消息附件失败: %w", err)
+	}
+
+	return messages, nil
+}
+
+// resolveRequestID 确定本次消息处理使用的请求ID
+// 客户端未指定时生成新的请求ID；指定时登记到会话中，并发发送同一请求ID时只有一个请求成功
+// 参数：conversation - 会话；clientRequestID - 客户端指定的请求ID（可选）
+// 返回：请求ID和错误信息
+func (s *chatAgentConversationService) resolveRequestID(ctx context.Context, conversation *models.ChatAgentConversation, clientRequestID *string) (string, error) {
+	if clientRequestID == nil || *clientRequestID == "" {
+		return uuid.New().String(), nil
+	}
+
+	// 引入请求ID登记之前保存的消息同样占用请求ID
+	exists, err := s.messageRepo.ExistsByConversationIDAndRequestID(ctx, conversation.ID, *clientRequestID)
+	if err != nil {
+		return "", fmt.Errorf("校验请求ID失败: %w", err)
+	}
+	if exists {
+		return "", apperror.Newf(apperror.CodeConflict, "请求ID在当前会话中已存在: %s", *clientRequestID)
+	}
+
+	claimed, err := s.messageRepo.ClaimRequestID(ctx, &models.ChatAgentMessageRequest{
+		ApplicationID:  conversation.ApplicationID,
+		ChatAgentID:    conversation.ChatAgentID,
+		ConversationID: conversation.ID,
+		RequestID:      *clientRequestID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("登记请求ID失败: %w", err)
+	}
+	if !claimed {
+		return "", apperror.Newf(apperror.CodeConflict, "请求ID在当前会话中已存在: %s", *clientRequestID)
+	}
+
+	return *clientRequestID, nil
+}
+
 // RenameConversationTitle 重命名会话标题
 func (s *chatAgentConversationService) RenameConversationTitle(ctx context.Context, serviceUserID, conversationID, newTitle string) (*dto.RenameConversationResponse, error) {
 	_, chatAgent, err := getContextInfo(ctx)
@@ -713,19 +1385,83 @@ func (s *chatAgentConversationService) RenameConversationTitle(ctx context.Conte
 	}
 
 	// 2. 更新会话标题
-	conversation.Title = newTitle
-	if err := s.conversationRepo.Update(ctx, conversation); err != nil {
+	if err := s.conversationRepo.UpdateTitle(ctx, conversation.ID, newTitle); err != nil {
 		return &dto.RenameConversationResponse{
 			Success: false,
 			Error:   stringPtr(fmt.Sprintf("重命名会话失败: %v", err)),
 		}, nil
 	}
 
-	return &dto.RenameConversationResponse{
-		Success:        true,
-		Message:        stringPtr("会话重命名成功"),
-		ConversationID: stringPtr(conversationID),
-		NewTitle:       stringPtr(newTitle),
+	return &dto.RenameConversationResponse{
+		Success:        true,
+		Message:        stringPtr("会话重命名成功"),
+		ConversationID: stringPtr(conversationID),
+		NewTitle:       stringPtr(newTitle),
+	}, nil
+}
+
+// UpdateMessageReceipts 上报助手回复的送达和已读状态
+// 校验会话归属于当前智能体和业务侧用户，按消息ID或截止消息标记，已标记过的消息保持最早的时间
+func (s *chatAgentConversationService) UpdateMessageReceipts(ctx context.Context, req *dto.UpdateMessageReceiptsRequest) (*dto.UpdateMessageReceiptsResponse, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	convID, err := uuid.Parse(req.ConversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+	if len(req.MessageIDs) == 0 && req.UpToMessageID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "message_ids 和 up_to_message_id 至少指定一个")
+	}
+
+	// 验证会话是否存在且属于该用户和智能体
+	conversation, err := s.conversationRepo.GetByID(ctx, convID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID || conversation.ServiceUserID != req.ServiceUserID {
+		return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+	}
+
+	query := &repository.ChatAgentMessageReceiptQuery{
+		ConversationID: convID,
+		Read:           req.Status == define.MessageReceiptStatusRead,
+		At:             time.Now(),
+	}
+	for _, messageID := range req.MessageIDs {
+		id, err := uuid.Parse(messageID)
+		if err != nil {
+			return nil, apperror.Newf(apperror.CodeInvalidArgument, "无效的消息ID: %s", messageID)
+		}
+		query.MessageIDs = append(query.MessageIDs, id)
+	}
+	if req.UpToMessageID != "" {
+		upToID, err := uuid.Parse(req.UpToMessageID)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的截止消息ID", err)
+		}
+		upToMessage, err := s.messageRepo.GetByID(ctx, upToID)
+		if err != nil || upToMessage.ConversationID != convID {
+			return nil, apperror.New(apperror.CodeNotFound, "截止消息不存在")
+		}
+		query.CreatedUntil = &upToMessage.CreatedAt
+	}
+
+	updatedCount, err := s.messageRepo.MarkReceipts(ctx, query)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "更新消息状态失败", err)
+	}
+	unreadCount, err := s.messageRepo.CountUnread(ctx, convID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "统计未读消息失败", err)
+	}
+
+	return &dto.UpdateMessageReceiptsResponse{
+		ConversationID: req.ConversationID,
+		UpdatedCount:   updatedCount,
+		UnreadCount:    unreadCount,
 	}, nil
 }
 
@@ -742,12 +1478,96 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+// normalizePageSize 规范化分页大小，未指定或超出范围时使用默认值
+func normalizePageSize(size *int) int {
+	if size == nil || *size < 1 || *size > maxPageSize {
+		return defaultPageSize
+	}
+	return *size
+}
+
+// messagePreview 生成消息内容预览，超过长度时截断并追加省略号
+func messagePreview(content string) string {
+	const maxPreviewLength = 100
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) <= maxPreviewLength {
+		return string(runes)
+	}
+	return string(runes[:maxPreviewLength]) + "..."
+}
+
+// validateClientRequestID 校验客户端指定的请求ID格式
+// 参数：requestID - 客户端指定的请求ID（可选）
+// 返回：格式不合法时返回错误
+func validateClientRequestID(requestID *string) error {
+	if requestID == nil || *requestID == "" {
+		return nil
+	}
+	if len(*requestID) > 64 {
+		return apperror.New(apperror.CodeInvalidArgument, "请求ID长度不能超过64个字符")
+	}
+	for _, r := range *requestID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' || r == ':') {
+			return apperror.New(apperror.CodeInvalidArgument, "请求ID只能包含字母、数字以及 - _ . : 字符")
+		}
+	}
+	return nil
+}
+
+// resolveDeltaChunkMode 解析增量输出分块模式
+// 参数：mode - 请求中指定的分块模式（可选）
+// 返回：分块模式，未指定时为 raw；不支持的模式返回错误
+func resolveDeltaChunkMode(mode *string) (string, error) {
+	if mode == nil || *mode == "" {
+		return define.ChatDeltaChunkModeRaw, nil
+	}
+	switch *mode {
+	case define.ChatDeltaChunkModeRaw, define.ChatDeltaChunkModeMarkdownSafe:
+		return *mode, nil
+	default:
+		return "", apperror.Newf(apperror.CodeInvalidArgument, "不支持的增量分块模式: %s", *mode)
+	}
+}
+
+// newAnswerDeltaWriter 创建answer_delta事件写入函数
+// markdown_safe 模式下增量内容先经过合并器缓冲，在安全边界处才写出
+// 参数：w - 事件输出流；conversationID - 会话ID；requestID - 请求ID；deltaChunkMode - 增量分块模式
+// 返回：写入函数，flush 为 true 时输出缓冲中剩余的全部内容
+func newAnswerDeltaWriter(w io.Writer, conversationID, requestID, deltaChunkMode string) func(delta string, flush bool) {
+	var coalescer *utils.MarkdownDeltaCoalescer
+	if deltaChunkMode == define.ChatDeltaChunkModeMarkdownSafe {
+		coalescer = utils.NewMarkdownDeltaCoalescer()
+	}
+
+	return func(delta string, flush bool) {
+		content := delta
+		if coalescer != nil {
+			content = coalescer.Push(delta)
+			if flush {
+				content += coalescer.Flush()
+			}
+		}
+		if content == "" {
+			return
+		}
+
+		event := dto.ChatMessageResponseEventDto{
+			ConversationID: conversationID,
+			RequestID:      requestID,
+			MessageType:    "answer_delta",
+			Content:        content,
+		}
+		eventJSON, _ := json.Marshal(event)
+		w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+	}
+}
+
 // getContextInfo 从上下文中获取ApplicationID和ChatAgentID
 func getContextInfo(ctx context.Context) (*models.Application, *models.ChatAgent, error) {
 	// 从上下文中获取ChatAgent
 	chatAgentValue := ctx.Value(define.AppContextKeyCurrentChatAgent)
 	if chatAgentValue == nil {
-		return nil, nil, fmt.Errorf("上下文中未找到ChatAgent信息")
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "上下文中未找到ChatAgent信息")
 	}
 
 	chatAgent, ok := chatAgentValue.(*models.ChatAgent)
@@ -758,7 +1578,7 @@ func getContextInfo(ctx context.Context) (*models.Application, *models.ChatAgent
 	// 从上下文中获取Application
 	applicationValue := ctx.Value(define.AppContextKeyCurrentApplication)
 	if applicationValue == nil {
-		return nil, nil, fmt.Errorf("上下文中未找到Application信息")
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "上下文中未找到Application信息")
 	}
 
 	application, ok := applicationValue.(*models.Application)
@@ -769,6 +1589,128 @@ func getContextInfo(ctx context.Context) (*models.Application, *models.ChatAgent
 	return application, chatAgent, nil
 }
 
+// writeErrorEvent 输出错误事件并记录会话的错误次数
+// 错误次数用于管理后台筛选发生过错误的会话，记录失败不影响事件输出
+func (s *chatAgentConversationService) writeErrorEvent(ctx context.Context, w io.Writer, conversationID, requestID, code, content string) {
+	event := dto.ChatMessageResponseEventDto{
+		ConversationID: conversationID,
+		RequestID:      requestID,
+		MessageType:    "error",
+		Content:        content,
+		ErrorCode:      code,
+	}
+	eventJSON, _ := json.Marshal(event)
+	w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return
+	}
+	// 客户端断开后上下文已取消，仍需记录错误
+	if err := s.conversationRepo.IncrementErrorCount(context.WithoutCancel(ctx), convID); err != nil {
+		log.Printf("记录会话错误次数失败: %v", err)
+	}
+
+	if _, chatAgent, err := getContextInfo(ctx); err == nil {
+		s.monitorService.Publish(chatAgent.ID, dto.ConversationMonitorEventDto{
+			EventType:      dto.ConversationMonitorEventError,
+			ChatAgentID:    chatAgent.ID.String(),
+			ConversationID: conversationID,
+			RequestID:      requestID,
+			Content:        content,
+			CreatedAt:      time.Now().UnixMilli(),
+		})
+	}
+}
+
+// writeQueuedEvent 输出排队事件
+// 模型提供商的并发名额已满时输出，告知调用者请求正在排队及排队位置
+func writeQueuedEvent(w io.Writer, conversationID, requestID string, position int) {
+	event := dto.ChatMessageResponseEventDto{
+		ConversationID: conversationID,
+		RequestID:      requestID,
+		MessageType:    "queued",
+		Content:        fmt.Sprintf("模型繁忙，排队中（第%d位）", position),
+		QueuePosition:  position,
+	}
+	eventJSON, _ := json.Marshal(event)
+	w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+}
+
+// saveMessage 保存会话消息并发布会话监控事件
+func (s *chatAgentConversationService) saveMessage(ctx context.Context, message *models.ChatAgentMessage) error {
+	// 写入数据库失败时暂存等待重试，重试写入后再更新会话的最后活跃时间
+	persisted, err := s.persistenceService.Save(ctx, message)
+	if err != nil {
+		return err
+	}
+	// 普通消息和拦截消息更新会话的最后活跃时间，会话列表按此排序
+	if persisted && (message.Type == "message" || message.Type == "blocked") {
+		if err := s.conversationRepo.UpdateLastMessageAt(ctx, message.ConversationID, message.CreatedAt); err != nil {
+			log.Printf("更新会话最后消息时间失败: %v", err)
+		}
+	}
+
+	eventType := dto.ConversationMonitorEventMessage
+	switch message.Type {
+	case "function_call":
+		eventType = dto.ConversationMonitorEventToolCall
+	case "function_call_output":
+		eventType = dto.ConversationMonitorEventToolCallOutput
+	case define.ChatMessageTypeInjected:
+		eventType = dto.ConversationMonitorEventInjectedMessage
+	}
+	s.monitorService.Publish(message.ChatAgentID, dto.ConversationMonitorEventDto{
+		EventType:             eventType,
+		ChatAgentID:           message.ChatAgentID.String(),
+		ConversationID:        message.ConversationID.String(),
+		RequestID:             message.RequestID,
+		MessageID:             message.ID.String(),
+		Role:                  message.Role,
+		Content:               message.Content,
+		Language:              message.Language,
+		FunctionCallName:      message.FunctionCallName,
+		FunctionCallArguments: message.FunctionCallArguments,
+		FunctionCallOutput:    message.FunctionCallOutput,
+		TotalTokenCount:       message.TotalTokenCount,
+		CreatedAt:             message.CreatedAt.UnixMilli(),
+	})
+	return nil
+}
+
+// writeAttachmentFile 保存附件文件
+// 文件保存在 chat_attachment_files/<附件ID>/file<扩展名>，返回文件路径
+func writeAttachmentFile(attachmentID uuid.UUID, fileExtension string, file io.Reader) (string, error) {
+	// 创建存储目录
+	attachmentDir := filepath.Join("chat_attachment_files", attachmentID.String())
+	if err := os.MkdirAll(attachmentDir, 0755); err != nil {
+		return "", fmt.Errorf("创建存储目录失败: %v", err)
+	}
+
+	filePath := filepath.Join(attachmentDir, fmt.Sprintf("file%s", fileExtension))
+	dst, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("创建文件失败: %v", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, file); err != nil {
+		return "", fmt.Errorf("保存文件失败: %v", err)
+	}
+	return filePath, nil
+}
+
+// attachmentTypeOf 根据扩展名确定附件类型：document、image 或 other
+func attachmentTypeOf(ext string) string {
+	if isDocumentFile(ext) {
+		return "document"
+	}
+	if isImageFile(ext) {
+		return "image"
+	}
+	return "other"
+}
+
 func isDocumentFile(ext string) bool {
 	documentExts := []string{".doc", ".docx", ".pdf", ".txt", ".md", ".xls", ".xlsx", ".ppt", ".pptx"}
 	for _, docExt := range documentExts {
@@ -814,25 +1756,39 @@ func getMimeType(ext string) string {
 }
 
 // prepareToolsList 准备工具列表
-func (s *chatAgentConversationService) prepareToolsList(ctx context.Context, usedMcpToolList []dto.ChatMessageUseToolDto, usedInternalToolList []string) ([]al_client.Tool, error) {
-	//_, chatAgent, err := getContextInfo(ctx)
+// mcpTools 为本次请求可以使用的MCP工具
+func (s *chatAgentConversationService) prepareToolsList(ctx context.Context, mcpTools []*models.ApplicationMcpServerTool, usedInternalToolList []string) ([]al_client.Tool, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取上下文信息失败: %w", err)
+	}
 	var openaiToolsList []al_client.Tool
 
 	// 处理MCP工具
-	mcpTools, err := s.GetChatAgentMcpServerTools(ctx)
-	if err != nil {
-		return nil, err
+	openaiToolsList = append(openaiToolsList, s.buildMcpOpenAITools(ctx, chatAgent.ID, mcpTools)...)
+
+	// 启用翻译工具的智能体自动提供翻译内部工具
+	if chatAgent.EnableTranslation {
+		openaiToolsList = append(openaiToolsList, translateToolDefinition())
+	}
+
+	// 启用会话变量的智能体自动提供设置会话变量内部工具
+	if chatAgent.EnableConversationVariables {
+		openaiToolsList = append(openaiToolsList, setVariableToolDefinition())
 	}
-	openaiToolsList = append(openaiToolsList, mcpTools...)
 
 	// 处理内部工具
 	for _, internalToolName := range usedInternalToolList {
+		// 翻译工具和设置会话变量工具由智能体配置决定是否提供
+		if internalToolName == define.ChatInternalToolTranslate || internalToolName == define.ChatInternalToolSetVariable {
+			continue
+		}
 		// 这里需要根据实际的内部工具实现来构建工具定义
 		// 暂时使用简单的实现
 		openaiTool := al_client.Tool{
 			Type: "function",
 			Function: &al_client.FunctionDefinition{
-				Name:        fmt.Sprintf("__lai__%s", internalToolName),
+				Name:        define.ChatInternalToolNamePrefix + internalToolName,
 				Description: fmt.Sprintf("内部工具: %s", internalToolName),
 				Parameters: map[string]interface{}{
 					"type": "object",
@@ -888,7 +1844,8 @@ func (s *chatAgentConversationService) processMessageAttachments(ctx context.Con
 }
 
 // aiProcessStreamable 处理AI消息 - 流式调用AI
-func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context, conversationID, requestID string, messages []al_client.ChatMessage, aiTools []al_client.Tool) (io.Reader, error) {
+// citations 为注入提示词的知识库引用，回答完成后标注并返回
+func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context, conversationID, requestID, deltaChunkMode string, messages []al_client.ChatMessage, aiTools []al_client.Tool, citations []dto.ChatCitationDto, limiter *chatAnswerLimiter) (io.Reader, error) {
 	// 从上下文中获取ChatAgentID
 	application, chatAgent, err := getContextInfo(ctx)
 	if err != nil {
@@ -900,31 +1857,23 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 	go func() {
 		defer pw.Close()
 
+		// 工具调用后生成时间已到或输出Token额度已用完时不再调用模型
+		if reason := limiter.truncatedReason(); reason != "" {
+			s.writeTruncatedAnswer(ctx, pw, application, chatAgent, conversationID, requestID, "", reason, citations, nil)
+			return
+		}
+
 		// 获取应用配置
 		llmProvider, llm, err := s.getChatAgentChatLlmConfig(ctx)
 		if err != nil {
-			event := dto.ChatMessageResponseEventDto{
-				ConversationID: conversationID,
-				RequestID:      requestID,
-				MessageType:    "error",
-				Content:        fmt.Sprintf("获取应用配置失败: %v", err),
-			}
-			eventJSON, _ := json.Marshal(event)
-			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeConfigError, fmt.Sprintf("获取应用配置失败: %v", err))
 			return
 		}
 
 		// 动态创建AI客户端
-		aiClient, err := s.createAIClient(llmProvider)
+		aiClient, err := s.createAIClient(ctx, llmProvider)
 		if err != nil {
-			event := dto.ChatMessageResponseEventDto{
-				ConversationID: conversationID,
-				RequestID:      requestID,
-				MessageType:    "error",
-				Content:        fmt.Sprintf("创建AI客户端失败: %v", err),
-			}
-			eventJSON, _ := json.Marshal(event)
-			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeConfigError, fmt.Sprintf("创建AI客户端失败: %v", err))
 			return
 		}
 
@@ -937,28 +1886,51 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 			Temperature: chatAgent.ModelParamTemperature,
 			TopP:        chatAgent.ModelParamTopP,
 			ToolChoice:  "auto",
+			MaxTokens:   limiter.requestMaxTokens(),
 		}
 
-		// 创建流式请求
-		stream, err := aiClient.SendMessageStream(ctx, req)
+		// 获取提供商的请求名额，名额已满时排队并通知调用者
+		release, err := s.providerLimiter.Acquire(ctx, llmProvider, func(position int) {
+			writeQueuedEvent(pw, conversationID, requestID, position)
+		})
 		if err != nil {
-			event := dto.ChatMessageResponseEventDto{
-				ConversationID: conversationID,
-				RequestID:      requestID,
-				MessageType:    "error",
-				Content:        fmt.Sprintf("AI Process error: %v", err),
+			s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeProviderBusy, fmt.Sprintf("AI处理出错: %v", err))
+			return
+		}
+		defer release()
+
+		// 创建流式请求，生成时间到期时取消
+		streamCtx, cancelStream := limiter.modelContext(ctx)
+		defer cancelStream()
+		stream, err := aiClient.SendMessageStream(streamCtx, req)
+		// 超过模型的上下文长度时裁剪历史消息后重试，后续的工具调用和递归处理使用裁剪后的消息
+		for attempt := 1; err != nil; attempt++ {
+			trimmed, retry := s.recoverContextTooLong(ctx, conversationID, requestID, messages, err, attempt)
+			if !retry {
+				break
 			}
-			eventJSON, _ := json.Marshal(event)
-			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			messages, req.Messages = trimmed, trimmed
+			stream, err = aiClient.SendMessageStream(streamCtx, req)
+		}
+		if err != nil {
+			if reason := limiter.truncatedReason(); reason != "" {
+				s.writeTruncatedAnswer(ctx, pw, application, chatAgent, conversationID, requestID, "", reason, citations, nil)
+				return
+			}
+			s.writeProviderErrorEvent(ctx, pw, conversationID, requestID, err)
 			return
 		}
 		defer stream.Close()
 
 		isNeedAiProcessContinue := false
+		writeDelta := newAnswerDeltaWriter(pw, conversationID, requestID, deltaChunkMode)
 		finalToolCalls := make(map[string]al_client.ToolCall)
 		currentToolCall := al_client.ToolCall{}
 		currentToolCallID := ""
 		answerFullContent := ""
+		truncatedReason := ""
+		var streamErr error        // 读取数据流时供应商返回的错误
+		var usage *al_client.Usage // 供应商在数据流最后返回的token用量
 
 		// 处理AI的返回数据流
 		for {
@@ -967,10 +1939,18 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 				if err == io.EOF {
 					break
 				}
-				log.Printf("处理流式数据时出错: %v", err)
-				continue
+				// 生成时间到期或客户端断开，不再读取
+				if streamCtx.Err() != nil {
+					truncatedReason = limiter.truncatedReason()
+					break
+				}
+				streamErr = err
+				break
 			}
 
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
 			if len(chunk.Choices) == 0 {
 				continue
 			}
@@ -991,6 +1971,7 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 							},
 						}
 						finalToolCalls[toolCall.ID] = currentToolCall
+						limiter.fit(toolCall.Function.Arguments)
 					} else {
 						// 继续构建工具调用
 						if currentToolCallID != "" && currentToolCall.ID == currentToolCallID {
@@ -999,6 +1980,7 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 							}
 							if toolCall.Function.Arguments != "" {
 								currentToolCall.Function.Arguments += toolCall.Function.Arguments
+								limiter.fit(toolCall.Function.Arguments)
 							}
 							finalToolCalls[currentToolCallID] = currentToolCall
 						}
@@ -1006,23 +1988,47 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 				}
 			}
 
-			// 处理正常消息内容
+			// 处理正常消息内容，超出输出Token额度的部分不再输出
 			if choice.Delta.Content != "" {
-				answerFullContent += choice.Delta.Content
-				event := dto.ChatMessageResponseEventDto{
-					ConversationID: conversationID,
-					RequestID:      requestID,
-					MessageType:    "answer_delta",
-					Content:        choice.Delta.Content,
+				content, exhausted := limiter.fit(choice.Delta.Content)
+				answerFullContent += content
+				writeDelta(content, false)
+				if exhausted {
+					truncatedReason = define.ChatAnswerTruncatedReasonOutputTokens
+					break
 				}
-				eventJSON, _ := json.Marshal(event)
-				pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			}
+
+			// 模型因输出Token上限结束回答，或生成时间已到
+			if choice.FinishReason == "length" {
+				truncatedReason = define.ChatAnswerTruncatedReasonOutputTokens
+				if drained := drainStreamUsage(stream); drained != nil {
+					usage = drained
+				}
+				break
+			}
+			if choice.FinishReason == "" && limiter.truncatedReason() == define.ChatAnswerTruncatedReasonDuration {
+				truncatedReason = define.ChatAnswerTruncatedReasonDuration
+				break
+			}
+			// 回答被供应商的安全策略拦截
+			if al_client.IsContentFilterFinishReason(choice.FinishReason) {
+				streamErr = al_client.NewContentFilterError()
+				break
 			}
 
 			// 处理完成原因
 			if choice.FinishReason != "" {
 				if choice.FinishReason == "stop" {
-					// 生成最终消息并保存到数据库
+					// 输出缓冲中剩余的增量内容
+					writeDelta("", true)
+
+					// 读取供应商在完成原因之后返回的token用量
+					if drained := drainStreamUsage(stream); drained != nil {
+						usage = drained
+					}
+
+					// 生成最终消息并保存到数据库，记录本次调用的token用量和费用
 					finalAssistantMessageObj := &models.ChatAgentMessage{
 						ApplicationID:  application.ID,
 						ChatAgentID:    chatAgent.ID,
@@ -1032,9 +2038,12 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 						Role:           "assistant",
 						Content:        answerFullContent,
 					}
+					newChatTokenUsage(llm, usage).apply(finalAssistantMessageObj)
+					// 标注回答实际引用的知识库片段
+					answerCitations := attachCitations(finalAssistantMessageObj, citations)
 
 					// 保存消息
-					if err := s.messageRepo.Create(ctx, finalAssistantMessageObj); err != nil {
+					if err := s.saveMessage(ctx, finalAssistantMessageObj); err != nil {
 						log.Printf("保存助手消息失败: %v", err)
 					}
 
@@ -1047,15 +2056,54 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 					}
 					eventJSON, _ := json.Marshal(event)
 					pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+
+					// 返回知识库引用
+					writeCitationsEvent(pw, conversationID, requestID, answerCitations)
+
+					// 生成追问建议
+					s.writeFollowUpSuggestions(ctx, pw, conversationID, requestID, messages, finalAssistantMessageObj)
 					break
 				}
 			}
 		}
 
-		// 处理工具调用
+		// 输出缓冲中剩余的增量内容
+		writeDelta("", true)
+
+		// 回答被截断时保存已生成的内容，未完成的工具调用不再执行
+		if truncatedReason != "" {
+			release()
+			s.writeTruncatedAnswer(ctx, pw, application, chatAgent, conversationID, requestID, answerFullContent, truncatedReason, citations, newChatTokenUsage(llm, usage))
+			return
+		}
+
+		// 读取数据流出错或回答被拦截时不保存已生成的内容，输出错误事件
+		if streamErr != nil {
+			s.writeProviderErrorEvent(ctx, pw, conversationID, requestID, streamErr)
+			return
+		}
+
+		// 模型已返回完毕，工具调用和后续的递归处理不占用名额
+		release()
+
+		// 处理工具调用，开启并行工具调用时先并行执行全部工具调用，事件和消息仍逐个输出和保存
+		// 本次调用的token用量和费用记录在第一条工具调用消息上
+		roundUsage := newChatTokenUsage(llm, usage)
+		toolCalls := make([]al_client.ToolCall, 0, len(finalToolCalls))
 		for _, toolCall := range finalToolCalls {
+			toolCalls = append(toolCalls, toolCall)
+		}
+		parallelResults := s.callToolsInParallel(ctx, chatAgent, conversationID, requestID, toolCalls, aiTools)
+		for i, toolCall := range toolCalls {
 			isNeedAiProcessContinue = true
 
+			// 将会话变量注入工具调用参数
+			if parallelResults != nil {
+				toolCall = parallelResults[i].toolCall
+			} else {
+				toolCall = s.applyConversationVariables(ctx, conversationID, toolCall, aiTools)
+			}
+
 			// 告诉调用者，有工具调用
 			event := dto.ChatMessageResponseEventDto{
 				ConversationID: conversationID,
@@ -1077,7 +2125,9 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 				FunctionCallName:      toolCall.Function.Name,
 				FunctionCallArguments: toolCall.Function.Arguments,
 			}
-			if err := s.messageRepo.Create(ctx, functionCallMessageObj); err != nil {
+			roundUsage.apply(functionCallMessageObj)
+			roundUsage = nil
+			if err := s.saveMessage(ctx, functionCallMessageObj); err != nil {
 				log.Printf("保存工具调用消息失败: %v", err)
 			}
 
@@ -1092,27 +2142,51 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
 
 			// 调用工具
-			toolResult, err := s.callTool(ctx, chatAgent.ID, toolCall)
+			var toolResult string
+			var err error
+			if parallelResults != nil {
+				toolResult, err = parallelResults[i].output, parallelResults[i].err
+			} else {
+				toolResult, err = s.callTool(ctx, chatAgent, conversationID, requestID, toolCall)
+			}
 			if err != nil {
 				log.Printf("调用工具失败: %v", err)
 				toolResult = "调用工具失败"
 			}
 
+			outputRenderer := s.resolveToolOutputRenderer(ctx, chatAgent.ID, toolCall.Function.Name)
+
+			// 工具结果超过长度上限时截断或摘要后再提供给模型，原始结果保存在消息记录中
+			rawToolResult := ""
+			if limitedToolResult, limited := s.limitToolOutput(ctx, chatAgent, toolCall.Function.Name, toolResult); limited {
+				rawToolResult, toolResult = toolResult, limitedToolResult
+			}
+
 			// 保存工具调用结果到数据库
 			functionCallOutputMessageObj := &models.ChatAgentMessage{
-				ApplicationID:      application.ID,
-				ChatAgentID:        chatAgent.ID,
-				ConversationID:     uuid.MustParse(conversationID),
-				RequestID:          requestID,
-				Type:               "function_call_output",
-				FunctionCallID:     toolCall.ID,
-				FunctionCallName:   toolCall.Function.Name,
-				FunctionCallOutput: toolResult,
+				ApplicationID:         application.ID,
+				ChatAgentID:           chatAgent.ID,
+				ConversationID:        uuid.MustParse(conversationID),
+				RequestID:             requestID,
+				Type:                  "function_call_output",
+				FunctionCallID:        toolCall.ID,
+				FunctionCallName:      toolCall.Function.Name,
+				FunctionCallOutput:    toolResult,
+				FunctionCallRawOutput: rawToolResult,
+				// 工具结果的渲染方式，查看历史消息时聊天界面据此渲染
+				FunctionCallOutputRenderer: outputRenderer,
 			}
-			if err := s.messageRepo.Create(ctx, functionCallOutputMessageObj); err != nil {
+			if err := s.saveMessage(ctx, functionCallOutputMessageObj); err != nil {
 				log.Printf("保存工具调用结果失败: %v", err)
 			}
 
+			// 工具结果引用的文件保存为会话附件，关联到工具结果消息
+			fullToolResult := toolResult
+			if rawToolResult != "" {
+				fullToolResult = rawToolResult
+			}
+			toolAttachments := s.attachToolOutputFiles(ctx, chatAgent, conversationID, functionCallOutputMessageObj, toolCall.Function.Name, fullToolResult)
+
 			// 告诉调用者，工具调用结束
 			event = dto.ChatMessageResponseEventDto{
 				ConversationID: conversationID,
@@ -1120,9 +2194,17 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 				MessageType:    "tool_call_end",
 				Content:        toolCall.Function.Name,
 			}
+			// 设置了渲染方式时附带工具结果，聊天界面可直接渲染
+			if outputRenderer != "" {
+				event.OutputRenderer = outputRenderer
+				event.ToolOutput = toolResult
+			}
 			eventJSON, _ = json.Marshal(event)
 			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
 
+			// 告诉调用者，工具生成的文件已保存为附件
+			writeToolAttachmentEvents(pw, conversationID, requestID, toolCall.Function.Name, toolAttachments)
+
 			// 更新消息列表，添加工具调用和结果
 			messages = append(messages, al_client.ChatMessage{
 				Role:      "assistant",
@@ -1139,16 +2221,9 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 		// 如果需要继续AI处理
 		if isNeedAiProcessContinue {
 			// 递归调用AI处理
-			recursiveReader, err := s.aiProcessStreamable(ctx, conversationID, requestID, messages, aiTools)
+			recursiveReader, err := s.aiProcessStreamable(ctx, conversationID, requestID, deltaChunkMode, messages, aiTools, citations, limiter)
 			if err != nil {
-				event := dto.ChatMessageResponseEventDto{
-					ConversationID: conversationID,
-					RequestID:      requestID,
-					MessageType:    "error",
-					Content:        fmt.Sprintf("递归AI处理出错: %v", err),
-				}
-				eventJSON, _ := json.Marshal(event)
-				pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+				s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeInternal, fmt.Sprintf("递归AI处理出错: %v", err))
 				return
 			}
 
@@ -1161,7 +2236,9 @@ func (s *chatAgentConversationService) aiProcessStreamable(ctx context.Context,
 }
 
 // aiProcess 处理AI消息 - 非流式调用AI
-func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversationID, requestID string, messages []al_client.ChatMessage, aiTools []al_client.Tool) (io.Reader, error) {
+// citations 为注入提示词的知识库引用，回答完成后标注并返回
+// usage 为之前工具调用轮次的token用量和费用，非流式调用不保存工具调用消息，用量累加后记录在最终的助手回复上
+func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversationID, requestID string, messages []al_client.ChatMessage, aiTools []al_client.Tool, citations []dto.ChatCitationDto, limiter *chatAnswerLimiter, usage *chatTokenUsage) (io.Reader, error) {
 	// 从上下文中获取ChatAgentID
 	application, chatAgent, err := getContextInfo(ctx)
 	if err != nil {
@@ -1173,31 +2250,23 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 	go func() {
 		defer pw.Close()
 
+		// 工具调用后生成时间已到或输出Token额度已用完时不再调用模型
+		if reason := limiter.truncatedReason(); reason != "" {
+			s.writeTruncatedAnswer(ctx, pw, application, chatAgent, conversationID, requestID, "", reason, citations, usage)
+			return
+		}
+
 		// 获取应用配置
 		llmProvider, llm, err := s.getChatAgentChatLlmConfig(ctx)
 		if err != nil {
-			event := dto.ChatMessageResponseEventDto{
-				ConversationID: conversationID,
-				RequestID:      requestID,
-				MessageType:    "error",
-				Content:        fmt.Sprintf("获取应用配置失败: %v", err),
-			}
-			eventJSON, _ := json.Marshal(event)
-			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeConfigError, fmt.Sprintf("获取应用配置失败: %v", err))
 			return
 		}
 
 		// 动态创建AI客户端
-		aiClient, err := s.createAIClient(llmProvider)
+		aiClient, err := s.createAIClient(ctx, llmProvider)
 		if err != nil {
-			event := dto.ChatMessageResponseEventDto{
-				ConversationID: conversationID,
-				RequestID:      requestID,
-				MessageType:    "error",
-				Content:        fmt.Sprintf("创建AI客户端失败: %v", err),
-			}
-			eventJSON, _ := json.Marshal(event)
-			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeConfigError, fmt.Sprintf("创建AI客户端失败: %v", err))
 			return
 		}
 
@@ -1210,30 +2279,76 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 			Temperature: chatAgent.ModelParamTemperature,
 			TopP:        chatAgent.ModelParamTopP,
 			ToolChoice:  "auto",
-			MaxTokens:   chatAgent.MaxOutputTokenCountLimit,
+			MaxTokens:   limiter.requestMaxTokens(),
 		}
 
-		// 发送请求
-		response, err := aiClient.SendMessage(ctx, req)
+		// 获取提供商的请求名额，名额已满时排队并通知调用者
+		release, err := s.providerLimiter.Acquire(ctx, llmProvider, func(position int) {
+			writeQueuedEvent(pw, conversationID, requestID, position)
+		})
 		if err != nil {
-			event := dto.ChatMessageResponseEventDto{
-				ConversationID: conversationID,
-				RequestID:      requestID,
-				MessageType:    "error",
-				Content:        fmt.Sprintf("AI处理出错: %v", err),
+			s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeProviderBusy, fmt.Sprintf("AI处理出错: %v", err))
+			return
+		}
+		defer release()
+
+		// 发送请求，生成时间到期时取消
+		callCtx, cancelCall := limiter.modelContext(ctx)
+		response, err := aiClient.SendMessage(callCtx, req)
+		// 超过模型的上下文长度时裁剪历史消息后重试，后续的工具调用和递归处理使用裁剪后的消息
+		for attempt := 1; err != nil; attempt++ {
+			trimmed, retry := s.recoverContextTooLong(ctx, conversationID, requestID, messages, err, attempt)
+			if !retry {
+				break
 			}
-			eventJSON, _ := json.Marshal(event)
-			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+			messages, req.Messages = trimmed, trimmed
+			response, err = aiClient.SendMessage(callCtx, req)
+		}
+		cancelCall()
+		if err != nil {
+			if reason := limiter.truncatedReason(); reason != "" {
+				release()
+				s.writeTruncatedAnswer(ctx, pw, application, chatAgent, conversationID, requestID, "", reason, citations, usage)
+				return
+			}
+			s.writeProviderErrorEvent(ctx, pw, conversationID, requestID, err)
+			return
+		}
+		// 工具调用和后续的递归处理不占用名额
+		release()
+		usage = usage.add(newChatTokenUsage(llm, &response.Usage))
+
+		// 回答被供应商的安全策略拦截
+		if al_client.IsContentFilterFinishReason(response.Choices[0].FinishReason) {
+			s.writeProviderErrorEvent(ctx, pw, conversationID, requestID, al_client.NewContentFilterError())
+			return
+		}
+
+		// 回答超出输出Token额度或模型因输出Token上限结束时截断回答，不再执行工具调用
+		for _, toolCall := range response.Choices[0].Message.ToolCalls {
+			limiter.fit(toolCall.Function.Arguments)
+		}
+		if content, exhausted := limiter.fit(response.Choices[0].Message.Content); exhausted || response.Choices[0].FinishReason == "length" {
+			s.writeTruncatedAnswer(ctx, pw, application, chatAgent, conversationID, requestID, content, define.ChatAnswerTruncatedReasonOutputTokens, citations, usage)
 			return
 		}
 
 		isNeedAiProcessContinue := false
 
-		// 处理工具调用
+		// 处理工具调用，开启并行工具调用时先并行执行全部工具调用，事件仍逐个输出
 		if response.Choices[0].Message.ToolCalls != nil {
-			for _, toolCall := range response.Choices[0].Message.ToolCalls {
+			toolCalls := response.Choices[0].Message.ToolCalls
+			parallelResults := s.callToolsInParallel(ctx, chatAgent, conversationID, requestID, toolCalls, aiTools)
+			for i, toolCall := range toolCalls {
 				isNeedAiProcessContinue = true
 
+				// 将会话变量注入工具调用参数
+				if parallelResults != nil {
+					toolCall = parallelResults[i].toolCall
+				} else {
+					toolCall = s.applyConversationVariables(ctx, conversationID, toolCall, aiTools)
+				}
+
 				// 告诉调用者，有工具调用
 				event := dto.ChatMessageResponseEventDto{
 					ConversationID: conversationID,
@@ -1253,11 +2368,22 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 				pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
 
 				// 调用工具
-				toolResult, err := s.callTool(ctx, chatAgent.ID, toolCall)
+				var toolResult string
+				var err error
+				if parallelResults != nil {
+					toolResult, err = parallelResults[i].output, parallelResults[i].err
+				} else {
+					toolResult, err = s.callTool(ctx, chatAgent, conversationID, requestID, toolCall)
+				}
 				if err != nil {
 					log.Printf("调用工具失败: %v", err)
 					toolResult = fmt.Sprintf("工具调用失败: %v", err)
 				}
+				// 工具结果引用的文件保存为会话附件
+				toolAttachments := s.attachToolOutputFiles(ctx, chatAgent, conversationID, nil, toolCall.Function.Name, toolResult)
+
+				// 工具结果超过长度上限时截断或摘要后再提供给模型
+				toolResult, _ = s.limitToolOutput(ctx, chatAgent, toolCall.Function.Name, toolResult)
 
 				// 告诉调用者，工具调用完成
 				event = dto.ChatMessageResponseEventDto{
@@ -1265,6 +2391,7 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 					RequestID:      requestID,
 					MessageType:    "tool_result",
 					Content:        toolResult,
+					OutputRenderer: s.resolveToolOutputRenderer(ctx, chatAgent.ID, toolCall.Function.Name),
 					ToolCall: &dto.ToolCallDto{
 						ID:   toolCall.ID,
 						Type: toolCall.Type,
@@ -1277,6 +2404,9 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 				eventJSON, _ = json.Marshal(event)
 				pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
 
+				// 告诉调用者，工具生成的文件已保存为附件
+				writeToolAttachmentEvents(pw, conversationID, requestID, toolCall.Function.Name, toolAttachments)
+
 				// 将工具调用和结果添加到消息历史
 				messages = append(messages, al_client.ChatMessage{
 					Role:      "assistant",
@@ -1295,16 +2425,9 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 		// 如果需要继续AI处理
 		if isNeedAiProcessContinue {
 			// 递归调用AI处理
-			recursiveReader, err := s.aiProcess(ctx, conversationID, requestID, messages, aiTools)
+			recursiveReader, err := s.aiProcess(ctx, conversationID, requestID, messages, aiTools, citations, limiter, usage)
 			if err != nil {
-				event := dto.ChatMessageResponseEventDto{
-					ConversationID: conversationID,
-					RequestID:      requestID,
-					MessageType:    "error",
-					Content:        fmt.Sprintf("递归AI处理出错: %v", err),
-				}
-				eventJSON, _ := json.Marshal(event)
-				pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+				s.writeErrorEvent(ctx, pw, conversationID, requestID, define.ChatErrorCodeInternal, fmt.Sprintf("递归AI处理出错: %v", err))
 				return
 			}
 
@@ -1321,8 +2444,11 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 				Role:           "assistant",
 				Content:        response.Choices[0].Message.Content,
 			}
+			usage.apply(assistantMessageObj)
+			// 标注回答实际引用的知识库片段
+			answerCitations := attachCitations(assistantMessageObj, citations)
 
-			if err := s.messageRepo.Create(ctx, assistantMessageObj); err != nil {
+			if err := s.saveMessage(ctx, assistantMessageObj); err != nil {
 				log.Printf("保存助手消息失败: %v", err)
 			}
 
@@ -1335,31 +2461,72 @@ func (s *chatAgentConversationService) aiProcess(ctx context.Context, conversati
 			}
 			eventJSON, _ := json.Marshal(event)
 			pw.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+
+			// 返回知识库引用
+			writeCitationsEvent(pw, conversationID, requestID, answerCitations)
+
+			// 生成追问建议
+			s.writeFollowUpSuggestions(ctx, pw, conversationID, requestID, messages, assistantMessageObj)
 		}
 	}()
 
 	return pr, nil
 }
 
-// callTool 调用工具
-func (s *chatAgentConversationService) callTool(ctx context.Context, agentID uuid.UUID, toolCall al_client.ToolCall) (string, error) {
+// callTool 调用工具并记录调用结果和耗时，用于工具使用统计
+func (s *chatAgentConversationService) callTool(ctx context.Context, chatAgent *models.ChatAgent, conversationID, requestID string, toolCall al_client.ToolCall) (string, error) {
+	start := time.Now()
+	result, err := s.invokeTool(ctx, chatAgent.ID, conversationID, toolCall)
+
+	conversationUUID, _ := uuid.Parse(conversationID)
+	record := &models.ChatAgentToolCall{
+		ApplicationID:  chatAgent.ApplicationID,
+		ChatAgentID:    chatAgent.ID,
+		ConversationID: conversationUUID,
+		RequestID:      requestID,
+		ToolName:       toolCall.Function.Name,
+		Success:        err == nil,
+		DurationMs:     time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		record.ErrorMessage = err.Error()
+	}
+	s.toolUsageService.RecordToolCall(ctx, record)
+
+	// 参数无效时将校验错误作为工具结果返回，模型可以修正参数后重新调用
+	var argumentErr *toolArgumentError
+	if errors.As(err, &argumentErr) {
+		return argumentErr.Error(), nil
+	}
+	return result, err
+}
+
+// invokeTool 调用工具
+// 根据工具名称前缀分发到内部工具或MCP工具，返回JSON格式的调用结果
+func (s *chatAgentConversationService) invokeTool(ctx context.Context, agentID uuid.UUID, conversationID string, toolCall al_client.ToolCall) (string, error) {
 	toolName := toolCall.Function.Name
 	toolArgs := toolCall.Function.Arguments
 
 	var toolCallParams map[string]interface{}
 
 	// 解析 JSON
-	err := json.Unmarshal([]byte(toolArgs), &toolCallParams)
-	if err != nil {
-		fmt.Println("解析 JSON 出错:", err)
+	if strings.TrimSpace(toolArgs) != "" {
+		if err := json.Unmarshal([]byte(toolArgs), &toolCallParams); err != nil {
+			return "", &toolArgumentError{problems: []string{"参数不是有效的JSON对象: " + err.Error()}}
+		}
+	}
+
+	// 按参数定义校验模型提供的参数，避免将无效的参数发送给MCP服务器
+	if err := s.validateToolArguments(ctx, toolName, toolCallParams); err != nil {
 		return "", err
 	}
+
 	var callToolResult any
 	var callToolErr error
 	// 判断是否为内部工具
-	if strings.HasPrefix(toolName, "__lai__") {
+	if strings.HasPrefix(toolName, define.ChatInternalToolNamePrefix) {
 		// 调用内部工具
-		callToolResult, callToolErr = s.callInternalTool(ctx, agentID, toolName, toolCallParams)
+		callToolResult, callToolErr = s.callInternalTool(ctx, agentID, conversationID, toolName, toolCallParams)
 	} else {
 		// 调用MCP工具
 		callToolResult, callToolErr = s.callMcpTool(ctx, agentID, toolName, toolCallParams)
@@ -1369,14 +2536,21 @@ func (s *chatAgentConversationService) callTool(ctx context.Context, agentID uui
 	}
 	jsonBytes, marshalJsonErr := json.Marshal(callToolResult)
 	if marshalJsonErr != nil {
-		fmt.Println("转换 JSON 出错:", err)
+		fmt.Println("转换 JSON 出错:", marshalJsonErr)
 		return "", marshalJsonErr
 	}
 	return string(jsonBytes), nil
 }
 
 // callInternalTool 调用内部工具
-func (s *chatAgentConversationService) callInternalTool(ctx context.Context, agentID uuid.UUID, toolName string, toolArgs map[string]interface{}) (string, error) {
+func (s *chatAgentConversationService) callInternalTool(ctx context.Context, agentID uuid.UUID, conversationID, toolName string, toolArgs map[string]interface{}) (string, error) {
+	switch strings.TrimPrefix(toolName, define.ChatInternalToolNamePrefix) {
+	case define.ChatInternalToolTranslate:
+		return s.callTranslateTool(ctx, toolArgs)
+	case define.ChatInternalToolSetVariable:
+		return s.callSetVariableTool(ctx, conversationID, toolArgs)
+	}
+
 	// 这里需要根据实际的内部工具实现来调用
 	// 暂时返回一个简单的实现
 	log.Printf("调用内部工具: %s, 参数: %s", toolName, toolArgs)
@@ -1456,11 +2630,19 @@ func (s *chatAgentConversationService) getChatAgentNamingLlmConfig(ctx context.C
 }
 
 // createAIClient 根据LLM提供商配置创建AI客户端
-func (s *chatAgentConversationService) createAIClient(llmProvider *models.ApplicationLlmProvider) (al_client.LemonAiClient, error) {
+// 试验中的供应商类型只对开启了 experimental_llm_providers 开关的应用开放
+func (s *chatAgentConversationService) createAIClient(ctx context.Context, llmProvider *models.ApplicationLlmProvider) (al_client.LemonAiClient, error) {
+	if err := s.featureFlagService.CheckLlmProviderType(ctx, llmProvider.ApplicationID, llmProvider.Type); err != nil {
+		return nil, err
+	}
+
 	// 根据LLM提供商类型创建相应的AI客户端
 	switch llmProvider.Type {
 	case "openai_chat_completions_api":
 		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	case "mock":
+		// 模拟客户端，用于本地开发和集成测试
+		return al_client.NewMockClient(), nil
 	case "ollama":
 		// TODO: 实现Ollama客户端
 		return nil, fmt.Errorf("Ollama客户端尚未实现")
@@ -1480,40 +2662,106 @@ func (s *chatAgentConversationService) GetChatAgentMcpServerTools(ctx context.Co
 	if getContextErr != nil {
 		return nil, fmt.Errorf("获取上下文数据失败: %w", getContextErr)
 	}
-	// 1. 查询该聊天智能体启用的MCP工具配置
-	enabledTools, err := s.chatAgentMcpServerToolRepo.GetByChatAgentID(ctx, chatAgent.ID)
+	enabledTools, err := s.getEnabledMcpTools(ctx, chatAgent.ID)
 	if err != nil {
-		return nil, fmt.Errorf("获取聊天智能体MCP工具配置失败: %w", err)
+		return nil, err
 	}
+	return s.buildMcpOpenAITools(ctx, chatAgent.ID, enabledTools), nil
+}
 
-	// 过滤出启用的工具
-	var enabledToolIDs []uuid.UUID
-	for _, tool := range enabledTools {
-		if tool.Enabled {
-			enabledToolIDs = append(enabledToolIDs, tool.ApplicationMcpServerToolID)
+// resolveRequestedMcpTools 获取本次请求可以使用的MCP工具
+// 请求未指定工具时使用智能体启用的全部工具，查询失败时不提供MCP工具，不影响主流程
+// 请求指定了工具时只能使用智能体已启用的工具，包含未启用的工具时返回关联资源无效的错误
+func (s *chatAgentConversationService) resolveRequestedMcpTools(ctx context.Context, chatAgentID uuid.UUID, usedMcpToolList []dto.ChatMessageUseToolDto) ([]*models.ApplicationMcpServerTool, error) {
+	enabledTools, err := s.getEnabledMcpTools(ctx, chatAgentID)
+	if err != nil {
+		if len(usedMcpToolList) == 0 {
+			log.Printf("获取工具列表失败: %v", err)
+			return nil, nil
 		}
+		return nil, apperror.Wrap(apperror.CodeInternal, "获取智能体启用的MCP工具失败", err)
 	}
+	return selectRequestedMcpTools(enabledTools, usedMcpToolList)
+}
 
-	if len(enabledToolIDs) == 0 {
-		return []al_client.Tool{}, nil
+// selectRequestedMcpTools 从智能体启用的MCP工具中选出请求指定的工具
+// 请求按MCP配置ID和工具名称指定工具，未指定时返回全部启用的工具
+func selectRequestedMcpTools(enabledTools []*models.ApplicationMcpServerTool, usedMcpToolList []dto.ChatMessageUseToolDto) ([]*models.ApplicationMcpServerTool, error) {
+	if len(usedMcpToolList) == 0 {
+		return enabledTools, nil
 	}
 
-	// 2. 根据工具ID逐个获取工具信息，并按MCP配置分组
-	configToolsMap := make(map[uuid.UUID][]*models.ApplicationMcpServerTool)
-	for _, toolID := range enabledToolIDs {
+	selectedTools := make([]*models.ApplicationMcpServerTool, 0, len(usedMcpToolList))
+	for _, usedTool := range usedMcpToolList {
+		configID, err := uuid.Parse(usedTool.ApplicationMcpConfigID)
+		if err != nil {
+			return nil, apperror.Newf(apperror.CodeInvalidArgument, "application_mcp_config_id 不是有效的UUID: %s", usedTool.ApplicationMcpConfigID).
+				WithDetails(map[string]string{"field": "used_mcp_tool_list"}).
+				WithCause(err)
+		}
+		index := slices.IndexFunc(enabledTools, func(tool *models.ApplicationMcpServerTool) bool {
+			return tool.ApplicationMcpServerConfigID == configID && tool.Name == usedTool.ToolName
+		})
+		if index < 0 {
+			return nil, invalidReferenceError("used_mcp_tool_list", configID, fmt.Sprintf("智能体未启用MCP工具 %s", usedTool.ToolName))
+		}
+		if !slices.Contains(selectedTools, enabledTools[index]) {
+			selectedTools = append(selectedTools, enabledTools[index])
+		}
+	}
+	return selectedTools, nil
+}
+
+// getEnabledMcpTools 获取聊天智能体启用的MCP工具
+// 工具信息获取失败时跳过该工具
+func (s *chatAgentConversationService) getEnabledMcpTools(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationMcpServerTool, error) {
+	// 智能体自身启用的工具和关联工具集中的工具
+	toolIDs, err := getEnabledMcpToolIDs(ctx, s.chatAgentMcpServerToolRepo, s.toolBundleRepo, chatAgentID)
+	if err != nil {
+		return nil, fmt.Errorf("获取聊天智能体MCP工具配置失败: %w", err)
+	}
+
+	// 根据启用的工具ID逐个获取工具信息
+	var tools []*models.ApplicationMcpServerTool
+	for _, toolID := range toolIDs {
 		tool, err := s.mcpToolRepo.GetByID(ctx, toolID)
 		if err != nil {
 			log.Printf("获取MCP工具信息失败: %v", err)
 			continue
 		}
 		if tool != nil {
-			configToolsMap[tool.ApplicationMcpServerConfigID] = append(configToolsMap[tool.ApplicationMcpServerConfigID], tool)
+			tools = append(tools, tool)
+		}
+	}
+	return tools, nil
+}
+
+// buildMcpOpenAITools 将MCP工具转换为OpenAI工具格式
+// 按MCP配置分组，从MCP服务器获取最新的工具信息；MCP服务器上已不存在的工具不提供
+// 智能体设置了工具标题、描述或参数描述覆盖时使用覆盖后的内容
+func (s *chatAgentConversationService) buildMcpOpenAITools(ctx context.Context, chatAgentID uuid.UUID, tools []*models.ApplicationMcpServerTool) []al_client.Tool {
+	openaiTools := []al_client.Tool{}
+	if len(tools) == 0 {
+		return openaiTools
+	}
+
+	// 智能体的工具设置，查询失败时使用MCP服务器提供的工具定义
+	settingMap := make(map[uuid.UUID]*models.ChatAgentMcpServerTool)
+	if settings, err := s.chatAgentMcpServerToolRepo.GetByChatAgentID(ctx, chatAgentID); err != nil {
+		log.Printf("获取聊天智能体MCP工具配置失败: %v", err)
+	} else {
+		for _, setting := range settings {
+			settingMap[setting.ApplicationMcpServerToolID] = setting
 		}
 	}
 
-	var openaiTools []al_client.Tool
+	// 按MCP配置分组
+	configToolsMap := make(map[uuid.UUID][]*models.ApplicationMcpServerTool)
+	for _, tool := range tools {
+		configToolsMap[tool.ApplicationMcpServerConfigID] = append(configToolsMap[tool.ApplicationMcpServerConfigID], tool)
+	}
 
-	// 4. 为每个MCP配置获取最新的工具信息
+	// 为每个MCP配置获取最新的工具信息
 	for configID, configTools := range configToolsMap {
 		// 获取MCP配置信息
 		config, err := s.mcpConfigRepo.GetByID(ctx, configID)
@@ -1540,12 +2788,13 @@ func (s *chatAgentConversationService) GetChatAgentMcpServerTools(ctx context.Co
 			if mcpTool, exists := mcpToolsMap[tool.Name]; exists {
 				configShortID, _ := utils.ShortUUID(configID.String())
 				openaiTool := s.convertMcpToolToOpenAI(mcpTool, configShortID)
+				overrideMcpToolDefinition(&openaiTool, settingMap[tool.ID])
 				openaiTools = append(openaiTools, openaiTool)
 			}
 		}
 	}
 
-	return openaiTools, nil
+	return openaiTools
 }
 
 // getToolsFromMcpServer 从MCP服务器获取工具列表
@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository/mock_repository"
+	"lemon-tree-core/internal/service"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// conversationServiceMocks 会话服务单元测试使用的模拟依赖
+type conversationServiceMocks struct {
+	conversationRepo *mock_repository.MockChatAgentConversationRepository
+	messageRepo      *mock_repository.MockChatAgentMessageRepository
+}
+
+// newConversationService 创建只依赖会话和消息数据访问层的会话服务
+func newConversationService(t *testing.T) (service.ChatAgentConversationService, *conversationServiceMocks) {
+	ctrl := gomock.NewController(t)
+	mocks := &conversationServiceMocks{
+		conversationRepo: mock_repository.NewMockChatAgentConversationRepository(ctrl),
+		messageRepo:      mock_repository.NewMockChatAgentMessageRepository(ctrl),
+	}
+	svc := service.NewChatAgentConversationService(mocks.conversationRepo, mocks.messageRepo,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return svc, mocks
+}
+
+// chatAgentContext 返回携带当前智能体和所属应用的上下文，与认证中间件写入的一致
+func chatAgentContext(chatAgent *models.ChatAgent) context.Context {
+	application := &models.Application{Name: "测试应用"}
+	application.ID = chatAgent.ApplicationID
+	ctx := context.WithValue(context.Background(), define.AppContextKeyCurrentChatAgent, chatAgent)
+	return context.WithValue(ctx, define.AppContextKeyCurrentApplication, application)
+}
+
+func TestChatAgentConversationServiceGetChatMessageListByRequestID(t *testing.T) {
+	chatAgent := &models.ChatAgent{ApplicationID: uuid.New()}
+	chatAgent.ID = uuid.New()
+	conversation := &models.ChatAgentConversation{ChatAgentID: chatAgent.ID, ApplicationID: chatAgent.ApplicationID, ServiceUserID: "user-a"}
+	conversation.ID = uuid.New()
+	otherAgentConversation := &models.ChatAgentConversation{ChatAgentID: uuid.New(), ServiceUserID: "user-a"}
+	otherAgentConversation.ID = uuid.New()
+
+	messages := []*models.ChatAgentMessage{
+		{RequestID: "request-1", Type: "message", Role: "user", Content: "问题"},
+		{RequestID: "request-1", Type: define.ChatMessageTypeInjected, Role: "system", Content: "注入的上下文"},
+		{RequestID: "request-1", Type: "message", Role: "assistant", Content: "回答"},
+	}
+
+	tests := []struct {
+		name           string
+		serviceUserID  string
+		conversationID string
+		setup          func(mocks *conversationServiceMocks)
+		wantCode       apperror.Code
+		wantContents   []string
+	}{
+		{
+			name:           "missing service user",
+			conversationID: conversation.ID.String(),
+			setup:          func(*conversationServiceMocks) {},
+			wantCode:       apperror.CodeInvalidArgument,
+		},
+		{
+			name:           "invalid conversation id",
+			serviceUserID:  "user-a",
+			conversationID: "not-a-uuid",
+			setup:          func(*conversationServiceMocks) {},
+			wantCode:       apperror.CodeInvalidArgument,
+		},
+		{
+			name:           "conversation not found",
+			serviceUserID:  "user-a",
+			conversationID: conversation.ID.String(),
+			setup: func(mocks *conversationServiceMocks) {
+				mocks.conversationRepo.EXPECT().GetByID(gomock.Any(), conversation.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: apperror.CodeNotFound,
+		},
+		{
+			name:           "other service user",
+			serviceUserID:  "user-b",
+			conversationID: conversation.ID.String(),
+			setup: func(mocks *conversationServiceMocks) {
+				mocks.conversationRepo.EXPECT().GetByID(gomock.Any(), conversation.ID).Return(conversation, nil)
+			},
+			wantCode: apperror.CodeForbidden,
+		},
+		{
+			name:           "other chat agent",
+			serviceUserID:  "user-a",
+			conversationID: otherAgentConversation.ID.String(),
+			setup: func(mocks *conversationServiceMocks) {
+				mocks.conversationRepo.EXPECT().GetByID(gomock.Any(), otherAgentConversation.ID).Return(otherAgentConversation, nil)
+			},
+			wantCode: apperror.CodeForbidden,
+		},
+		{
+			name:           "owner without injected messages",
+			serviceUserID:  "user-a",
+			conversationID: conversation.ID.String(),
+			setup: func(mocks *conversationServiceMocks) {
+				mocks.conversationRepo.EXPECT().GetByID(gomock.Any(), conversation.ID).Return(conversation, nil)
+				mocks.messageRepo.EXPECT().GetByConversationIDAndRequestID(gomock.Any(), conversation.ID, "request-1").
+					Return(slices.Clone(messages), nil)
+				mocks.messageRepo.EXPECT().LoadAttachments(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+			wantContents: []string{"问题", "回答"},
+		},
+		{
+			name:           "database error",
+			serviceUserID:  "user-a",
+			conversationID: conversation.ID.String(),
+			setup: func(mocks *conversationServiceMocks) {
+				mocks.conversationRepo.EXPECT().GetByID(gomock.Any(), conversation.ID).Return(conversation, nil)
+				mocks.messageRepo.EXPECT().GetByConversationIDAndRequestID(gomock.Any(), conversation.ID, "request-1").
+					Return(nil, errors.New("database is down"))
+			},
+			wantCode: apperror.CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mocks := newConversationService(t)
+			tt.setup(mocks)
+
+			got, err := svc.GetChatMessageListByRequestID(chatAgentContext(chatAgent), tt.serviceUserID, tt.conversationID, "request-1")
+			if tt.wantCode != "" {
+				if err == nil || apperror.From(err).Code != tt.wantCode {
+					t.Fatalf("GetChatMessageListByRequestID() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetChatMessageListByRequestID() error = %v", err)
+			}
+			var contents []string
+			for _, message := range got {
+				contents = append(contents, message.Content)
+			}
+			if !slices.Equal(contents, tt.wantContents) {
+				t.Errorf("GetChatMessageListByRequestID() contents = %v, want %v", contents, tt.wantContents)
+			}
+		})
+	}
+}
+
+func TestChatAgentConversationServiceRequiresChatAgentContext(t *testing.T) {
+	svc, _ := newConversationService(t)
+	_, err := svc.GetChatMessageListByRequestID(context.Background(), "user-a", uuid.NewString(), "request-1")
+	if !apperror.Is(err, apperror.CodeUnauthorized) {
+		t.Fatalf("GetChatMessageListByRequestID() error = %v, want code %s", err, apperror.CodeUnauthorized)
+	}
+}
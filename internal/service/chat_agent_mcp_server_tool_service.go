@@ -17,13 +17,14 @@ import (
 // 定义 ChatAgentMcpServerTool 相关的业务逻辑方法
 type ChatAgentMcpServerToolService interface {
 	// SaveChatAgentMcpServerToolSettings 保存聊天智能体的MCP工具设置
+	// 同时保存智能体对工具标题、描述和参数描述的覆盖（只影响提供给模型的工具定义）和工具结果的渲染方式
 	SaveChatAgentMcpServerToolSettings(ctx context.Context, chatAgentID uuid.UUID, toolSettings []dto.ChatAgentMcpServerToolSettingDto) error
 
 	// GetChatAgentMcpServerToolSettings 获取聊天智能体的MCP工具设置
 	GetChatAgentMcpServerToolSettings(ctx context.Context, chatAgentID uuid.UUID) ([]dto.ChatAgentMcpServerToolSettingDto, error)
 
 	// GetChatAgentAvailableMcpServerTools 获取聊天智能体可用的MCP工具列表
-	// 返回指定ChatAgentID下所有MCP工具及其启用状态，按MCP Server分组
+	// 返回指定ChatAgentID下所有MCP工具及其启用状态和启用该工具的工具集，按MCP Server分组
 	GetChatAgentAvailableMcpServerTools(ctx context.Context, chatAgentID uuid.UUID) ([]dto.McpServerToolGroupDto, error)
 }
 
@@ -34,6 +35,7 @@ type chatAgentMcpServerToolService struct {
 	applicationMcpServerToolRepo   repository.ApplicationMcpServerToolRepository
 	applicationMcpServerConfigRepo repository.ApplicationMcpServerConfigRepository
 	chatAgentRepo                  repository.ChatAgentRepository
+	toolBundleRepo                 repository.ApplicationToolBundleRepository
 }
 
 // NewChatAgentMcpServerToolService 创建 ChatAgentMcpServerTool 服务实例
@@ -43,95 +45,70 @@ func NewChatAgentMcpServerToolService(
 	applicationMcpServerToolRepo repository.ApplicationMcpServerToolRepository,
 	applicationMcpServerConfigRepo repository.ApplicationMcpServerConfigRepository,
 	chatAgentRepo repository.ChatAgentRepository,
+	toolBundleRepo repository.ApplicationToolBundleRepository,
 ) ChatAgentMcpServerToolService {
 	return &chatAgentMcpServerToolService{
 		chatAgentMcpServerToolRepo:     chatAgentMcpServerToolRepo,
 		applicationMcpServerToolRepo:   applicationMcpServerToolRepo,
 		applicationMcpServerConfigRepo: applicationMcpServerConfigRepo,
 		chatAgentRepo:                  chatAgentRepo,
+		toolBundleRepo:                 toolBundleRepo,
 	}
 }
 
 // SaveChatAgentMcpServerToolSettings 保存聊天智能体的MCP工具设置
 func (s *chatAgentMcpServerToolService) SaveChatAgentMcpServerToolSettings(ctx context.Context, chatAgentID uuid.UUID, toolSettings []dto.ChatAgentMcpServerToolSettingDto) error {
 	// 验证聊天智能体是否存在
-	_, err := s.chatAgentRepo.GetByID(ctx, chatAgentID)
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, chatAgentID)
 	if err != nil {
 		return fmt.Errorf("聊天智能体不存在: %w", err)
 	}
 
-	// 获取当前智能体的所有工具配置
-	existingSettings, err := s.chatAgentMcpServerToolRepo.GetByChatAgentID(ctx, chatAgentID)
-	if err != nil {
-		return fmt.Errorf("获取现有工具配置失败: %w", err)
-	}
-
-	// 创建现有配置的映射，便于查找
-	existingMap := make(map[uuid.UUID]*models.ChatAgentMcpServerTool)
-	for _, setting := range existingSettings {
-		existingMap[setting.ApplicationMcpServerToolID] = setting
-	}
-
-	// 处理新的工具设置
-	var toCreate []*models.ChatAgentMcpServerTool
-	var toUpdate []*models.ChatAgentMcpServerTool
-	var toDelete []uuid.UUID
-
-	// 记录新设置中的工具ID
-	newToolIDs := make(map[uuid.UUID]bool)
-
-	for _, toolSetting := range toolSettings {
+	// 同一工具在请求中出现多次时以最后一次为准
+	settingMap := make(map[uuid.UUID]*models.ChatAgentMcpServerTool, len(toolSettings))
+	settings := make([]*models.ChatAgentMcpServerTool, 0, len(toolSettings))
+	for i := range toolSettings {
+		toolSetting := &toolSettings[i]
 		toolID, err := uuid.Parse(toolSetting.ApplicationMcpServerToolID)
 		if err != nil {
 			return fmt.Errorf("无效的工具ID: %s", toolSetting.ApplicationMcpServerToolID)
 		}
+		if err := s.validateToolReference(ctx, chatAgent, toolID); err != nil {
+			return err
+		}
 
-		newToolIDs[toolID] = true
-
-		if existingSetting, exists := existingMap[toolID]; exists {
-			// 更新现有配置
-			existingSetting.Enabled = toolSetting.Enabled
-			toUpdate = append(toUpdate, existingSetting)
-		} else {
-			// 创建新配置
-			newSetting := &models.ChatAgentMcpServerTool{
+		setting, exists := settingMap[toolID]
+		if !exists {
+			setting = &models.ChatAgentMcpServerTool{
 				ChatAgentID:                chatAgentID,
 				ApplicationMcpServerToolID: toolID,
-				Enabled:                    toolSetting.Enabled,
 			}
-			toCreate = append(toCreate, newSetting)
+			settingMap[toolID] = setting
+			settings = append(settings, setting)
 		}
-	}
-
-	// 找出需要删除的配置（在新设置中不存在的）
-	for toolID, existingSetting := range existingMap {
-		if !newToolIDs[toolID] {
-			toDelete = append(toDelete, existingSetting.ID)
+		setting.Enabled = toolSetting.Enabled
+		if err := applyMcpToolSettingOverrides(setting, toolSetting); err != nil {
+			return err
 		}
 	}
 
-	// 执行数据库操作
-	// 1. 删除不需要的配置
-	for _, id := range toDelete {
-		if err := s.chatAgentMcpServerToolRepo.DeleteByID(ctx, id); err != nil {
-			return fmt.Errorf("删除工具配置失败: %w", err)
-		}
+	// 按唯一索引批量 upsert，并删除不在新设置中的配置
+	if err := s.chatAgentMcpServerToolRepo.ReplaceByChatAgentID(ctx, chatAgentID, settings); err != nil {
+		return fmt.Errorf("保存工具配置失败: %w", err)
 	}
 
-	// 2. 批量创建新配置
-	if len(toCreate) > 0 {
-		if err := s.chatAgentMcpServerToolRepo.BatchCreate(ctx, toCreate); err != nil {
-			return fmt.Errorf("创建工具配置失败: %w", err)
-		}
-	}
+	return nil
+}
 
-	// 3. 批量更新现有配置
-	if len(toUpdate) > 0 {
-		if err := s.chatAgentMcpServerToolRepo.BatchUpdate(ctx, toUpdate); err != nil {
-			return fmt.Errorf("更新工具配置失败: %w", err)
-		}
+// validateToolReference 校验工具存在且属于智能体所在的应用
+func (s *chatAgentMcpServerToolService) validateToolReference(ctx context.Context, chatAgent *models.ChatAgent, toolID uuid.UUID) error {
+	tool, err := s.applicationMcpServerToolRepo.GetByID(ctx, toolID)
+	if err != nil {
+		return referenceLookupError("application_mcp_server_tool_id", toolID, "MCP工具", err)
+	}
+	if tool.ApplicationID != chatAgent.ApplicationID {
+		return invalidReferenceError("application_mcp_server_tool_id", toolID, "MCP工具不属于智能体所在的应用")
 	}
-
 	return nil
 }
 
@@ -153,9 +130,13 @@ func (s *chatAgentMcpServerToolService) GetChatAgentMcpServerToolSettings(ctx co
 	var result []dto.ChatAgentMcpServerToolSettingDto
 	for _, setting := range settings {
 		result = append(result, dto.ChatAgentMcpServerToolSettingDto{
-			ID:                         setting.ID.String(),
-			ApplicationMcpServerToolID: setting.ApplicationMcpServerToolID.String(),
-			Enabled:                    setting.Enabled,
+			ID:                            setting.ID.String(),
+			ApplicationMcpServerToolID:    setting.ApplicationMcpServerToolID.String(),
+			Enabled:                       setting.Enabled,
+			TitleOverride:                 setting.TitleOverride,
+			DescriptionOverride:           setting.DescriptionOverride,
+			ParameterDescriptionOverrides: decodeMcpToolParameterOverrides(setting),
+			OutputRenderer:                setting.OutputRenderer,
 		})
 	}
 
@@ -183,24 +164,36 @@ func (s *chatAgentMcpServerToolService) GetChatAgentAvailableMcpServerTools(ctx
 	}
 
 	// 创建配置映射
-	settingMap := make(map[uuid.UUID]bool)
+	settingMap := make(map[uuid.UUID]*models.ChatAgentMcpServerTool)
 	for _, setting := range agentSettings {
-		settingMap[setting.ApplicationMcpServerToolID] = setting.Enabled
+		settingMap[setting.ApplicationMcpServerToolID] = setting
+	}
+
+	// 通过关联的工具集启用的工具
+	bundleTools, err := s.toolBundleRepo.GetToolIDsByChatAgentID(ctx, chatAgentID)
+	if err != nil {
+		return nil, fmt.Errorf("获取智能体工具集失败: %w", err)
 	}
 
 	// 按MCP Server分组
 	serverGroups := make(map[uuid.UUID]*dto.McpServerToolGroupDto)
 
 	for _, tool := range allTools {
-		enabled := settingMap[tool.ID] // 如果不存在，默认为false
-
 		toolDto := dto.ChatAgentAvailableMcpServerToolDto{
 			ID:                           tool.ID.String(),
 			ApplicationMcpServerConfigID: tool.ApplicationMcpServerConfigID.String(),
 			Name:                         tool.Name,
 			Title:                        tool.Title,
 			Description:                  tool.Description,
-			Enabled:                      enabled,
+			Bundles:                      bundleTools[tool.ID],
+		}
+		// 没有设置的工具默认未启用
+		if setting, ok := settingMap[tool.ID]; ok {
+			toolDto.Enabled = setting.Enabled
+			toolDto.TitleOverride = setting.TitleOverride
+			toolDto.DescriptionOverride = setting.DescriptionOverride
+			toolDto.ParameterDescriptionOverrides = decodeMcpToolParameterOverrides(setting)
+			toolDto.OutputRenderer = setting.OutputRenderer
 		}
 
 		// 如果该MCP Server还没有分组，创建新分组
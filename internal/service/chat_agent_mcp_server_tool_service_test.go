@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository/mock_repository"
+	"lemon-tree-core/internal/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestChatAgentMcpServerToolServiceSaveSettings(t *testing.T) {
+	chatAgent := &models.ChatAgent{ApplicationID: uuid.New()}
+	chatAgent.ID = uuid.New()
+	tool := &models.ApplicationMcpServerTool{ApplicationID: chatAgent.ApplicationID, Name: "search"}
+	tool.ID = uuid.New()
+	otherAppTool := &models.ApplicationMcpServerTool{ApplicationID: uuid.New(), Name: "search"}
+	otherAppTool.ID = uuid.New()
+	missingToolID := uuid.New()
+
+	tests := []struct {
+		name     string
+		settings []dto.ChatAgentMcpServerToolSettingDto
+		setup    func(toolRepo *mock_repository.MockApplicationMcpServerToolRepository, settingRepo *mock_repository.MockChatAgentMcpServerToolRepository)
+		wantErr  bool
+		wantCode apperror.Code
+	}{
+		{
+			name:     "invalid tool id",
+			settings: []dto.ChatAgentMcpServerToolSettingDto{{ApplicationMcpServerToolID: "not-a-uuid", Enabled: true}},
+			setup: func(*mock_repository.MockApplicationMcpServerToolRepository, *mock_repository.MockChatAgentMcpServerToolRepository) {
+			},
+			wantErr: true,
+		},
+		{
+			name:     "tool not found",
+			settings: []dto.ChatAgentMcpServerToolSettingDto{{ApplicationMcpServerToolID: missingToolID.String(), Enabled: true}},
+			setup: func(toolRepo *mock_repository.MockApplicationMcpServerToolRepository, _ *mock_repository.MockChatAgentMcpServerToolRepository) {
+				toolRepo.EXPECT().GetByID(gomock.Any(), missingToolID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:  true,
+			wantCode: apperror.CodeInvalidReference,
+		},
+		{
+			name:     "tool of another application",
+			settings: []dto.ChatAgentMcpServerToolSettingDto{{ApplicationMcpServerToolID: otherAppTool.ID.String(), Enabled: true}},
+			setup: func(toolRepo *mock_repository.MockApplicationMcpServerToolRepository, _ *mock_repository.MockChatAgentMcpServerToolRepository) {
+				toolRepo.EXPECT().GetByID(gomock.Any(), otherAppTool.ID).Return(otherAppTool, nil)
+			},
+			wantErr:  true,
+			wantCode: apperror.CodeInvalidReference,
+		},
+		{
+			name: "duplicate settings keep the last one",
+			settings: []dto.ChatAgentMcpServerToolSettingDto{
+				{ApplicationMcpServerToolID: tool.ID.String(), Enabled: true},
+				{ApplicationMcpServerToolID: tool.ID.String(), Enabled: false, TitleOverride: "搜索"},
+			},
+			setup: func(toolRepo *mock_repository.MockApplicationMcpServerToolRepository, settingRepo *mock_repository.MockChatAgentMcpServerToolRepository) {
+				toolRepo.EXPECT().GetByID(gomock.Any(), tool.ID).Return(tool, nil).Times(2)
+				settingRepo.EXPECT().ReplaceByChatAgentID(gomock.Any(), chatAgent.ID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, settings []*models.ChatAgentMcpServerTool) error {
+						if len(settings) != 1 {
+							t.Fatalf("got %d settings, want 1", len(settings))
+						}
+						if setting := settings[0]; setting.Enabled || setting.TitleOverride != "搜索" || setting.ApplicationMcpServerToolID != tool.ID {
+							t.Errorf("setting = %+v, want the last one for tool %s", setting, tool.ID)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:     "empty settings clear the chat agent's tools",
+			settings: nil,
+			setup: func(_ *mock_repository.MockApplicationMcpServerToolRepository, settingRepo *mock_repository.MockChatAgentMcpServerToolRepository) {
+				settingRepo.EXPECT().ReplaceByChatAgentID(gomock.Any(), chatAgent.ID, gomock.Len(0)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			agentRepo := mock_repository.NewMockChatAgentRepository(ctrl)
+			toolRepo := mock_repository.NewMockApplicationMcpServerToolRepository(ctrl)
+			settingRepo := mock_repository.NewMockChatAgentMcpServerToolRepository(ctrl)
+			agentRepo.EXPECT().GetByID(gomock.Any(), chatAgent.ID).Return(chatAgent, nil)
+			tt.setup(toolRepo, settingRepo)
+			svc := service.NewChatAgentMcpServerToolService(settingRepo, toolRepo, nil, agentRepo, nil)
+
+			err := svc.SaveChatAgentMcpServerToolSettings(context.Background(), chatAgent.ID, tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveChatAgentMcpServerToolSettings() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantCode != "" && !apperror.Is(err, tt.wantCode) {
+				t.Errorf("SaveChatAgentMcpServerToolSettings() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -4,11 +4,20 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
 	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/repository"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // ChatAgentService 智能体 业务逻辑层接口
@@ -16,49 +25,104 @@ import (
 type ChatAgentService interface {
 	// SaveChatAgent 保存智能体信息
 	// 如果ID为空则新增，否则更新现有记录
-	SaveChatAgent(ctx context.Context, agent *models.ChatAgent) error
+	// 更新时 agent.Version 大于0则校验版本号，与当前版本不一致时返回 Conflict 错误；保存成功后 agent.Version 为新版本号
+	// updateFields 不为空时为部分更新，只修改列出的字段（模型字段名），其余字段保持当前值
+	SaveChatAgent(ctx context.Context, agent *models.ChatAgent, updateFields []string) error
 
 	// DeleteChatAgent 删除智能体
-	// 根据ID删除指定的智能体记录
-	DeleteChatAgent(ctx context.Context, id uuid.UUID) error
+	// 在同一事务中软删除智能体及其会话、消息、附件、MCP工具设置、API Key、回答规则、工具调用记录和会话变量
+	// dryRun 为 true 时只统计将要删除的数据，不做任何修改
+	DeleteChatAgent(ctx context.Context, id uuid.UUID, dryRun bool) (*dto.ChatAgentDeletionDto, error)
+
+	// PurgeDeletedAttachmentFiles 清理已删除附件的文件
+	// 返回清理的附件数量
+	PurgeDeletedAttachmentFiles(ctx context.Context) (int, error)
 
 	// GetChatAgentsByApplicationID 根据应用ID获取智能体列表
 	// 返回指定应用下的所有智能体，支持分页
 	GetChatAgentsByApplicationID(ctx context.Context, applicationID uuid.UUID, page, pageSize int) ([]*models.ChatAgent, int64, error)
 
+	// GetChatAgentByID 根据ID获取智能体
+	// 返回指定ID的智能体
+	GetChatAgentByID(ctx context.Context, id uuid.UUID) (*models.ChatAgent, error)
+
 	// GetChatAgentByApiKey 根据API Key获取聊天智能体
-	// 返回指定API Key的聊天智能体
-	GetChatAgentByApiKey(ctx context.Context, apiKey string) (*models.ChatAgent, error) // 根据API Key获取应用
+	// 返回指定API Key的聊天智能体，来源IP不满足 API Key 的网段限制时返回 Forbidden 错误
+	GetChatAgentByApiKey(ctx context.Context, apiKey string, access ApiKeyAccess) (*models.ChatAgent, error) // 根据API Key获取应用
 }
 
+// 推荐问题数量和长度限制
+const (
+	maxSuggestedQuestionCount  = 10
+	maxSuggestedQuestionLength = 200
+)
+
+// deletedAttachmentPurgeBatchSize 每次清理处理的已删除附件数量
+const deletedAttachmentPurgeBatchSize = 200
+
 // chatAgentService 智能体 业务逻辑层实现
 // 实现 ChatAgentService 接口
 type chatAgentService struct {
-	chatAgentRepo       repository.ChatAgentRepository // 数据访问层接口
-	chatAgentApiKeyRepo repository.ChatAgentApiKeyRepository
+	chatAgentRepo          repository.ChatAgentRepository // 数据访问层接口
+	chatAgentApiKeyRepo    repository.ChatAgentApiKeyRepository
+	attachmentRepo         repository.ChatAgentAttachmentRepository // 附件数据访问层接口
+	applicationRepo        repository.ApplicationRepository         // 应用数据访问层接口，校验所属应用
+	applicationLlmRepo     repository.ApplicationLlmRepository      // 应用模型数据访问层接口，校验引用的模型
+	knowledgeBaseRepo      repository.KnowledgeBaseRepository       // 知识库数据访问层接口，校验绑定的知识库
+	workspaceUploadService WorkspaceUploadService                   // 工作区上传文件 业务逻辑层接口
+	apiKeyService          ChatAgentApiKeyService                   // API Key 业务逻辑层接口，校验来源IP
 }
 
 // NewChatAgentService 创建 智能体 服务实例
 // 返回 ChatAgentService 接口的实现
-// 参数：chatAgentRepo - 智能体 数据访问层接口
-func NewChatAgentService(chatAgentRepo repository.ChatAgentRepository, chatAgentApiKeyRepo repository.ChatAgentApiKeyRepository) ChatAgentService {
+// 参数：chatAgentRepo - 智能体 数据访问层接口，attachmentRepo - 附件数据访问层接口，applicationRepo - 应用数据访问层接口，
+// applicationLlmRepo - 应用模型数据访问层接口，knowledgeBaseRepo - 知识库数据访问层接口，workspaceUploadService - 工作区上传文件 业务逻辑层接口，
+// apiKeyService - API Key 业务逻辑层接口
+func NewChatAgentService(chatAgentRepo repository.ChatAgentRepository, chatAgentApiKeyRepo repository.ChatAgentApiKeyRepository,
+	attachmentRepo repository.ChatAgentAttachmentRepository, applicationRepo repository.ApplicationRepository,
+	applicationLlmRepo repository.ApplicationLlmRepository, knowledgeBaseRepo repository.KnowledgeBaseRepository,
+	workspaceUploadService WorkspaceUploadService, apiKeyService ChatAgentApiKeyService) ChatAgentService {
 	return &chatAgentService{
-		chatAgentRepo:       chatAgentRepo,
-		chatAgentApiKeyRepo: chatAgentApiKeyRepo,
+		chatAgentRepo:          chatAgentRepo,
+		chatAgentApiKeyRepo:    chatAgentApiKeyRepo,
+		attachmentRepo:         attachmentRepo,
+		applicationRepo:        applicationRepo,
+		applicationLlmRepo:     applicationLlmRepo,
+		knowledgeBaseRepo:      knowledgeBaseRepo,
+		workspaceUploadService: workspaceUploadService,
+		apiKeyService:          apiKeyService,
 	}
 }
 
 // SaveChatAgent 保存智能体信息
 // 如果ID为空则新增，否则更新现有记录
-func (s *chatAgentService) SaveChatAgent(ctx context.Context, agent *models.ChatAgent) error {
+func (s *chatAgentService) SaveChatAgent(ctx context.Context, agent *models.ChatAgent, updateFields []string) error {
+	// 部分更新：未列出的字段使用当前值，合并后按完整数据校验
+	if len(updateFields) > 0 {
+		if agent.ID == uuid.Nil {
+			return apperror.New(apperror.CodeInvalidArgument, "部分更新时必须提供智能体ID")
+		}
+		existing, err := s.chatAgentRepo.GetByID(ctx, agent.ID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+		}
+		if err := mergeUpdateFields(agent, existing, updateFields, "Version"); err != nil {
+			return err
+		}
+	}
+
 	// 数据验证
 	if err := s.validateChatAgent(agent); err != nil {
 		return err
 	}
+	if err := s.validateChatAgentReferences(ctx, agent); err != nil {
+		return err
+	}
 
 	if agent.ID == uuid.Nil {
 		// 新增：生成新的UUID
 		agent.ID = uuid.New()
+		agent.Version = 1
 		return s.chatAgentRepo.Create(ctx, agent)
 	} else {
 		// 更新：检查记录是否存在
@@ -69,23 +133,120 @@ func (s *chatAgentService) SaveChatAgent(ctx context.Context, agent *models.Chat
 		if existing == nil {
 			return fmt.Errorf("智能体不存在")
 		}
-		return s.chatAgentRepo.Update(ctx, agent)
+		// 会话、消息等数据按所属应用归档，不允许把智能体移动到其他应用
+		if existing.ApplicationID != agent.ApplicationID {
+			return apperror.New(apperror.CodeInvalidArgument, "不能修改智能体所属的应用")
+		}
+		// 未提供版本号时以读取到的版本为准，仍可避免与并发保存互相覆盖
+		if agent.Version > 0 && agent.Version != existing.Version {
+			return versionConflictError("智能体", existing.Version)
+		}
+		agent.CreatedAt = existing.CreatedAt
+		updated, err := s.chatAgentRepo.UpdateWithVersion(ctx, agent, existing.Version)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return s.chatAgentVersionConflict(ctx, agent.ID)
+		}
+		// 更换头像后旧头像不再被引用，交由定时任务清理
+		if existing.AvatarUrl != agent.AvatarUrl {
+			return s.workspaceUploadService.TrackUpload(ctx, existing.AvatarUrl)
+		}
+		return nil
+	}
+}
+
+// chatAgentVersionConflict 并发保存导致更新失败时，读取最新版本号生成冲突错误
+func (s *chatAgentService) chatAgentVersionConflict(ctx context.Context, id uuid.UUID) error {
+	current, err := s.chatAgentRepo.GetByID(ctx, id)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
 	}
+	return versionConflictError("智能体", current.Version)
 }
 
 // DeleteChatAgent 删除智能体
-// 根据ID删除指定的智能体记录
-func (s *chatAgentService) DeleteChatAgent(ctx context.Context, id uuid.UUID) error {
+// 关联数据随智能体一起软删除，附件文件和头像交由定时任务清理
+func (s *chatAgentService) DeleteChatAgent(ctx context.Context, id uuid.UUID, dryRun bool) (*dto.ChatAgentDeletionDto, error) {
 	// 检查记录是否存在
 	existing, err := s.chatAgentRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("智能体不存在: %w", err)
+		return nil, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
 	}
-	if existing == nil {
-		return fmt.Errorf("智能体不存在")
+
+	var dependents *repository.ChatAgentDependents
+	if dryRun {
+		dependents, err = s.chatAgentRepo.CountDependents(ctx, id)
+	} else {
+		dependents, err = s.chatAgentRepo.DeleteWithDependents(ctx, id)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "删除智能体失败", err)
+	}
+
+	if !dryRun {
+		// 智能体删除后头像不再被引用，交由定时任务清理
+		if err := s.workspaceUploadService.TrackUpload(ctx, existing.AvatarUrl); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.ChatAgentDeletionDto{
+		ChatAgentID:          id.String(),
+		DryRun:               dryRun,
+		Conversations:        dependents.Conversations,
+		Messages:             dependents.Messages,
+		MessageRequests:      dependents.MessageRequests,
+		Attachments:          dependents.Attachments,
+		McpServerTools:       dependents.McpServerTools,
+		ApiKeys:              dependents.ApiKeys,
+		AnswerRules:          dependents.AnswerRules,
+		ToolCalls:            dependents.ToolCalls,
+		Variables:            dependents.Variables,
+		ToolBundles:          dependents.ToolBundles,
+		WidgetTokens:         dependents.WidgetTokens,
+		ApiKeyRejections:     dependents.ApiKeyRejections,
+		ChatStreams:          dependents.ChatStreams,
+		ChatStreamEvents:     dependents.ChatStreamEvents,
+		ConversationEvents:   dependents.ConversationEvents,
+		ConversationArchives: dependents.ConversationArchives,
+		BatchInferenceJobs:   dependents.BatchInferenceJobs,
+		BatchInferenceItems:  dependents.BatchInferenceItems,
+		EvaluationSets:       dependents.EvaluationSets,
+		EvaluationCases:      dependents.EvaluationCases,
+		EvaluationRuns:       dependents.EvaluationRuns,
+		EvaluationResults:    dependents.EvaluationResults,
+	}, nil
+}
+
+// PurgeDeletedAttachmentFiles 清理已删除附件的文件
+// 删除附件所在的存储目录后清空文件路径，附件记录保留为软删除状态
+func (s *chatAgentService) PurgeDeletedAttachmentFiles(ctx context.Context) (int, error) {
+	attachments, err := s.attachmentRepo.ListDeletedWithFiles(ctx, deletedAttachmentPurgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("查询已删除附件失败: %w", err)
 	}
 
-	return s.chatAgentRepo.DeleteByID(ctx, id)
+	purged := 0
+	for _, attachment := range attachments {
+		for _, path := range []string{attachment.FilePath, attachment.MarkdownPath} {
+			if path == "" {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+				return purged, fmt.Errorf("删除附件文件失败: %w", err)
+			}
+		}
+		if err := s.attachmentRepo.ClearFilePaths(ctx, attachment.ID); err != nil {
+			return purged, fmt.Errorf("更新附件记录失败: %w", err)
+		}
+		purged++
+	}
+	return purged, nil
 }
 
 // GetChatAgentsByApplicationID 根据应用ID获取智能体列表
@@ -94,15 +255,27 @@ func (s *chatAgentService) GetChatAgentsByApplicationID(ctx context.Context, app
 	return s.chatAgentRepo.GetByApplicationIDWithPagination(ctx, applicationID, page, pageSize)
 }
 
+// GetChatAgentByID 根据ID获取智能体
+// 返回指定ID的智能体
+func (s *chatAgentService) GetChatAgentByID(ctx context.Context, id uuid.UUID) (*models.ChatAgent, error) {
+	agent, err := s.chatAgentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	return agent, nil
+}
+
 // GetChatAgentByApiKey 根据API Key获取聊天智能体
 // 返回指定API Key的聊天智能体
-func (s *chatAgentService) GetChatAgentByApiKey(ctx context.Context, apiKey string) (*models.ChatAgent, error) {
+func (s *chatAgentService) GetChatAgentByApiKey(ctx context.Context, apiKey string, access ApiKeyAccess) (*models.ChatAgent, error) {
 	apiKeyObj, getApiKeyErr := s.chatAgentApiKeyRepo.GetByApiKey(ctx, apiKey)
 	if getApiKeyErr != nil {
 		return nil, getApiKeyErr
-	} else {
-		return s.chatAgentRepo.GetByID(ctx, apiKeyObj.ChatAgentID)
 	}
+	if err := s.apiKeyService.CheckClientIP(ctx, apiKeyObj, access); err != nil {
+		return nil, err
+	}
+	return s.chatAgentRepo.GetByID(ctx, apiKeyObj.ChatAgentID)
 }
 
 // validateChatAgent 验证智能体数据
@@ -153,5 +326,135 @@ func (s *chatAgentService) validateChatAgent(agent *models.ChatAgent) error {
 		return fmt.Errorf("启用最大输出Token限制时，限制值必须大于0")
 	}
 
+	if err := validateAvailabilitySchedule(agent); err != nil {
+		return err
+	}
+
+	if err := validateInputPreprocessConfig(agent); err != nil {
+		return err
+	}
+
+	if err := validateReplyLanguage(agent); err != nil {
+		return err
+	}
+
+	if err := validateSuggestedQuestions(agent); err != nil {
+		return err
+	}
+
+	if err := normalizeAllowedOrigins(agent); err != nil {
+		return err
+	}
+
+	if agent.EnableTranslation && agent.TranslationModelID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "启用翻译工具时，翻译模型不能为空")
+	}
+
+	if err := validateKnowledgeRetrievalConfig(agent); err != nil {
+		return err
+	}
+
+	if err := validateToolOutputLimit(agent); err != nil {
+		return err
+	}
+
+	if err := validateInjectedMessageRole(agent); err != nil {
+		return err
+	}
+
+	if err := validateChatAnswerLimits(agent); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateChatAgentReferences 校验智能体引用的应用、模型和知识库
+// 引用的资源必须存在且属于智能体所在的应用，否则返回 INVALID_REFERENCE 错误
+func (s *chatAgentService) validateChatAgentReferences(ctx context.Context, agent *models.ChatAgent) error {
+	if _, err := s.applicationRepo.GetByID(ctx, agent.ApplicationID); err != nil {
+		return referenceLookupError("application_id", agent.ApplicationID, "所属应用", err)
+	}
+
+	modelReferences := []struct {
+		field string
+		id    uuid.UUID
+		name  string
+	}{
+		{"chat_model_id", agent.ChatModelID, "聊天模型"},
+		{"conversation_naming_model_id", agent.ConversationNamingModelID, "会话命名模型"},
+		{"translation_model_id", agent.TranslationModelID, "翻译模型"},
+		{"knowledge_rerank_model_id", agent.KnowledgeRerankModelID, "重排模型"},
+	}
+	for _, reference := range modelReferences {
+		// 可选的模型未设置时不校验
+		if reference.id == uuid.Nil {
+			continue
+		}
+		llm, err := s.applicationLlmRepo.GetByID(ctx, reference.id)
+		if err != nil {
+			return referenceLookupError(reference.field, reference.id, reference.name, err)
+		}
+		if llm.ApplicationID != agent.ApplicationID {
+			return invalidReferenceError(reference.field, reference.id, reference.name+"不属于智能体所在的应用")
+		}
+	}
+
+	knowledgeBaseIDs, err := parseKnowledgeBaseIDs(agent.KnowledgeBaseIDs)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "知识库ID列表格式错误", err)
+	}
+	knowledgeBases, err := s.knowledgeBaseRepo.GetByIDs(ctx, knowledgeBaseIDs)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "查询知识库失败", err)
+	}
+	knowledgeBaseApplications := make(map[uuid.UUID]uuid.UUID, len(knowledgeBases))
+	for _, knowledgeBase := range knowledgeBases {
+		knowledgeBaseApplications[knowledgeBase.ID] = knowledgeBase.ApplicationID
+	}
+	for _, id := range knowledgeBaseIDs {
+		applicationID, ok := knowledgeBaseApplications[id]
+		if !ok {
+			return invalidReferenceError("knowledge_base_ids", id, "知识库不存在")
+		}
+		if applicationID != agent.ApplicationID {
+			return invalidReferenceError("knowledge_base_ids", id, "知识库不属于智能体所在的应用")
+		}
+	}
 	return nil
 }
+
+// validateSuggestedQuestions 校验智能体的推荐问题列表
+func validateSuggestedQuestions(agent *models.ChatAgent) error {
+	if agent.SuggestedQuestions == "" {
+		return nil
+	}
+	questions, err := parseSuggestedQuestions(agent.SuggestedQuestions)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "推荐问题列表格式错误", err)
+	}
+	if len(questions) > maxSuggestedQuestionCount {
+		return apperror.Newf(apperror.CodeInvalidArgument, "推荐问题不能超过%d个", maxSuggestedQuestionCount)
+	}
+	for _, question := range questions {
+		if strings.TrimSpace(question) == "" {
+			return apperror.New(apperror.CodeInvalidArgument, "推荐问题不能为空")
+		}
+		if utf8.RuneCountInString(question) > maxSuggestedQuestionLength {
+			return apperror.Newf(apperror.CodeInvalidArgument, "推荐问题长度不能超过%d个字符", maxSuggestedQuestionLength)
+		}
+	}
+	return nil
+}
+
+// parseSuggestedQuestions 解析推荐问题列表
+func parseSuggestedQuestions(suggestedQuestions string) ([]string, error) {
+	questions := []string{}
+	if suggestedQuestions == "" {
+		return questions, nil
+	}
+	if err := json.Unmarshal([]byte(suggestedQuestions), &questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/repository/mock_repository"
+	"lemon-tree-core/internal/service"
+	"lemon-tree-core/internal/service/mock_service"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestChatAgentServiceDeleteChatAgent(t *testing.T) {
+	agent := &models.ChatAgent{Name: "测试智能体", AvatarUrl: "/uploads/avatar.png"}
+	agent.ID = uuid.New()
+	dependents := &repository.ChatAgentDependents{Conversations: 2, Messages: 5, ApiKeys: 1}
+	errDatabase := errors.New("database is down")
+
+	tests := []struct {
+		name     string
+		dryRun   bool
+		setup    func(agentRepo *mock_repository.MockChatAgentRepository, uploadService *mock_service.MockWorkspaceUploadService)
+		wantCode apperror.Code
+	}{
+		{
+			name: "chat agent not found",
+			setup: func(agentRepo *mock_repository.MockChatAgentRepository, _ *mock_service.MockWorkspaceUploadService) {
+				agentRepo.EXPECT().GetByID(gomock.Any(), agent.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: apperror.CodeNotFound,
+		},
+		{
+			name:   "dry run only counts dependents",
+			dryRun: true,
+			setup: func(agentRepo *mock_repository.MockChatAgentRepository, _ *mock_service.MockWorkspaceUploadService) {
+				agentRepo.EXPECT().GetByID(gomock.Any(), agent.ID).Return(agent, nil)
+				agentRepo.EXPECT().CountDependents(gomock.Any(), agent.ID).Return(dependents, nil)
+			},
+		},
+		{
+			name: "delete tracks the avatar for cleanup",
+			setup: func(agentRepo *mock_repository.MockChatAgentRepository, uploadService *mock_service.MockWorkspaceUploadService) {
+				agentRepo.EXPECT().GetByID(gomock.Any(), agent.ID).Return(agent, nil)
+				agentRepo.EXPECT().DeleteWithDependents(gomock.Any(), agent.ID).Return(dependents, nil)
+				uploadService.EXPECT().TrackUpload(gomock.Any(), agent.AvatarUrl).Return(nil)
+			},
+		},
+		{
+			name: "deleted concurrently",
+			setup: func(agentRepo *mock_repository.MockChatAgentRepository, _ *mock_service.MockWorkspaceUploadService) {
+				agentRepo.EXPECT().GetByID(gomock.Any(), agent.ID).Return(agent, nil)
+				agentRepo.EXPECT().DeleteWithDependents(gomock.Any(), agent.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantCode: apperror.CodeNotFound,
+		},
+		{
+			name: "database error",
+			setup: func(agentRepo *mock_repository.MockChatAgentRepository, _ *mock_service.MockWorkspaceUploadService) {
+				agentRepo.EXPECT().GetByID(gomock.Any(), agent.ID).Return(agent, nil)
+				agentRepo.EXPECT().DeleteWithDependents(gomock.Any(), agent.ID).Return(nil, errDatabase)
+			},
+			wantCode: apperror.CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			agentRepo := mock_repository.NewMockChatAgentRepository(ctrl)
+			uploadService := mock_service.NewMockWorkspaceUploadService(ctrl)
+			tt.setup(agentRepo, uploadService)
+			svc := service.NewChatAgentService(agentRepo, nil, nil, nil, nil, nil, uploadService, nil)
+
+			result, err := svc.DeleteChatAgent(context.Background(), agent.ID, tt.dryRun)
+			if tt.wantCode != "" {
+				if !apperror.Is(err, tt.wantCode) {
+					t.Fatalf("DeleteChatAgent() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteChatAgent() error = %v", err)
+			}
+			if result.DryRun != tt.dryRun || result.ChatAgentID != agent.ID.String() {
+				t.Errorf("DeleteChatAgent() = %+v, want dry run %v for %s", result, tt.dryRun, agent.ID)
+			}
+			if result.Conversations != dependents.Conversations || result.Messages != dependents.Messages || result.ApiKeys != dependents.ApiKeys {
+				t.Errorf("DeleteChatAgent() counts = %+v, want %+v", result, dependents)
+			}
+		})
+	}
+}
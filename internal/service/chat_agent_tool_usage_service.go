@@ -0,0 +1,206 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/utils"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxToolCallErrorMessageLength 工具调用记录中失败原因的最大长度，超出部分截断
+const maxToolCallErrorMessageLength = 512
+
+// ChatAgentToolUsage 单个工具的使用统计
+type ChatAgentToolUsage struct {
+	ToolType          string    // 工具类型：mcp 或 internal
+	ToolName          string    // 工具名称，MCP工具为MCP服务器上的名称，内部工具不含前缀
+	FunctionName      string    // 提供给模型的工具名称
+	McpServerConfigID uuid.UUID // MCP工具所属的MCP配置ID，无法对应到已启用的工具时为空
+	McpServerToolID   uuid.UUID // MCP工具ID，无法对应到已启用的工具时为空
+	Enabled           bool      // 智能体当前是否启用该工具
+	CallCount         int64     // 调用次数
+	SuccessCount      int64     // 调用成功次数
+	SuccessRate       float64   // 调用成功率（0-1），没有调用时为0
+	AvgDurationMs     float64   // 平均耗时（毫秒）
+}
+
+// ChatAgentToolUsageService 工具使用统计 业务逻辑层接口
+// 记录智能体每次调用工具的结果和耗时，按工具汇总调用次数、成功率和平均耗时，便于管理员移除不常用的工具以节省提示词token
+type ChatAgentToolUsageService interface {
+	// RecordToolCall 记录一次工具调用
+	// 记录失败只写日志，不影响聊天流程
+	RecordToolCall(ctx context.Context, call *models.ChatAgentToolCall)
+
+	// GetToolUsage 获取智能体的工具使用统计
+	// 包含统计时间范围内调用过的工具和当前已启用但未被调用的工具，按调用次数倒序
+	// calledAfter、calledBefore 为空时不限制调用时间
+	GetToolUsage(ctx context.Context, chatAgentID uuid.UUID, calledAfter, calledBefore *time.Time) ([]*ChatAgentToolUsage, error)
+}
+
+// chatAgentToolUsageService 工具使用统计 业务逻辑层实现
+type chatAgentToolUsageService struct {
+	toolCallRepo               repository.ChatAgentToolCallRepository
+	chatAgentRepo              repository.ChatAgentRepository
+	chatAgentMcpServerToolRepo repository.ChatAgentMcpServerToolRepository
+	toolBundleRepo             repository.ApplicationToolBundleRepository
+	mcpToolRepo                repository.ApplicationMcpServerToolRepository
+}
+
+// NewChatAgentToolUsageService 创建 工具使用统计 服务实例
+// 返回 ChatAgentToolUsageService 接口的实现
+func NewChatAgentToolUsageService(
+	toolCallRepo repository.ChatAgentToolCallRepository,
+	chatAgentRepo repository.ChatAgentRepository,
+	chatAgentMcpServerToolRepo repository.ChatAgentMcpServerToolRepository,
+	toolBundleRepo repository.ApplicationToolBundleRepository,
+	mcpToolRepo repository.ApplicationMcpServerToolRepository,
+) ChatAgentToolUsageService {
+	return &chatAgentToolUsageService{
+		toolCallRepo:               toolCallRepo,
+		chatAgentRepo:              chatAgentRepo,
+		chatAgentMcpServerToolRepo: chatAgentMcpServerToolRepo,
+		toolBundleRepo:             toolBundleRepo,
+		mcpToolRepo:                mcpToolRepo,
+	}
+}
+
+// RecordToolCall 记录一次工具调用
+// 根据工具名称前缀区分内部工具和MCP工具
+func (s *chatAgentToolUsageService) RecordToolCall(ctx context.Context, call *models.ChatAgentToolCall) {
+	call.ToolType = define.ChatToolTypeMcp
+	if strings.HasPrefix(call.ToolName, define.ChatInternalToolNamePrefix) {
+		call.ToolType = define.ChatToolTypeInternal
+	}
+	if runes := []rune(call.ErrorMessage); len(runes) > maxToolCallErrorMessageLength {
+		call.ErrorMessage = string(runes[:maxToolCallErrorMessageLength])
+	}
+	// 聊天请求结束后仍需保存记录
+	if err := s.toolCallRepo.Create(context.WithoutCancel(ctx), call); err != nil {
+		log.Printf("保存工具调用记录失败: %v", err)
+	}
+}
+
+// GetToolUsage 获取智能体的工具使用统计
+func (s *chatAgentToolUsageService) GetToolUsage(ctx context.Context, chatAgentID uuid.UUID, calledAfter, calledBefore *time.Time) ([]*ChatAgentToolUsage, error) {
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, chatAgentID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	if calledAfter != nil && calledBefore != nil && calledAfter.After(*calledBefore) {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "统计开始时间不能晚于结束时间")
+	}
+
+	enabledTools, err := s.getEnabledTools(ctx, chatAgent)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.toolCallRepo.GetUsageStats(ctx, chatAgentID, calledAfter, calledBefore)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询工具调用统计失败", err)
+	}
+
+	usages := make([]*ChatAgentToolUsage, 0, len(stats)+len(enabledTools))
+	for _, stat := range stats {
+		usage, ok := enabledTools[stat.ToolName]
+		if ok {
+			delete(enabledTools, stat.ToolName)
+		} else {
+			usage = newToolUsage(stat.ToolType, stat.ToolName)
+		}
+		usage.CallCount = stat.CallCount
+		usage.SuccessCount = stat.SuccessCount
+		usage.AvgDurationMs = stat.AvgDurationMs
+		if stat.CallCount > 0 {
+			usage.SuccessRate = float64(stat.SuccessCount) / float64(stat.CallCount)
+		}
+		usages = append(usages, usage)
+	}
+
+	// 已启用但未被调用的工具排在最后，是优先考虑移除的对象
+	var unused []*ChatAgentToolUsage
+	for _, usage := range enabledTools {
+		unused = append(unused, usage)
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].FunctionName < unused[j].FunctionName
+	})
+	return append(usages, unused...), nil
+}
+
+// getEnabledTools 获取智能体当前提供给模型的工具，键为提供给模型的工具名称
+// 与聊天接口一致：MCP工具名称为 MCP配置短ID_____工具名称，启用翻译时提供翻译内部工具，启用会话变量时提供设置会话变量内部工具
+// 按请求传入的其他内部工具不属于智能体的配置，不在其中
+func (s *chatAgentToolUsageService) getEnabledTools(ctx context.Context, chatAgent *models.ChatAgent) (map[string]*ChatAgentToolUsage, error) {
+	toolIDs, err := getEnabledMcpToolIDs(ctx, s.chatAgentMcpServerToolRepo, s.toolBundleRepo, chatAgent.ID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "获取智能体MCP工具设置失败", err)
+	}
+
+	tools := make(map[string]*ChatAgentToolUsage)
+	for _, toolID := range toolIDs {
+		tool, err := s.mcpToolRepo.GetByID(ctx, toolID)
+		if err != nil {
+			log.Printf("获取MCP工具信息失败: %v", err)
+			continue
+		}
+		configShortID, err := utils.ShortUUID(tool.ApplicationMcpServerConfigID.String())
+		if err != nil {
+			log.Printf("生成MCP配置短ID失败: %v", err)
+			continue
+		}
+		functionName := fmt.Sprintf("%s_____%s", configShortID, tool.Name)
+		tools[functionName] = &ChatAgentToolUsage{
+			ToolType:          define.ChatToolTypeMcp,
+			ToolName:          tool.Name,
+			FunctionName:      functionName,
+			McpServerConfigID: tool.ApplicationMcpServerConfigID,
+			McpServerToolID:   tool.ID,
+			Enabled:           true,
+		}
+	}
+	if chatAgent.EnableTranslation {
+		functionName := define.ChatInternalToolNamePrefix + define.ChatInternalToolTranslate
+		tools[functionName] = &ChatAgentToolUsage{
+			ToolType:     define.ChatToolTypeInternal,
+			ToolName:     define.ChatInternalToolTranslate,
+			FunctionName: functionName,
+			Enabled:      true,
+		}
+	}
+	if chatAgent.EnableConversationVariables {
+		functionName := define.ChatInternalToolNamePrefix + define.ChatInternalToolSetVariable
+		tools[functionName] = &ChatAgentToolUsage{
+			ToolType:     define.ChatToolTypeInternal,
+			ToolName:     define.ChatInternalToolSetVariable,
+			FunctionName: functionName,
+			Enabled:      true,
+		}
+	}
+	return tools, nil
+}
+
+// newToolUsage 为当前未启用的工具创建统计，从提供给模型的工具名称中解析工具名称
+func newToolUsage(toolType, functionName string) *ChatAgentToolUsage {
+	toolName := functionName
+	if toolType == define.ChatToolTypeInternal {
+		toolName = strings.TrimPrefix(functionName, define.ChatInternalToolNamePrefix)
+	} else if _, name, ok := strings.Cut(functionName, "_____"); ok {
+		toolName = name
+	}
+	return &ChatAgentToolUsage{
+		ToolType:     toolType,
+		ToolName:     toolName,
+		FunctionName: functionName,
+	}
+}
@@ -0,0 +1,56 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/dto"
+	"strings"
+)
+
+// collectChatAnswer 读取非流式发送消息输出的事件，返回最终回答和会话ID
+// 供批量推理、评测等在服务内部发送消息的功能使用；输出 error 事件或没有回答时返回错误
+// 参数：ctx - 发送消息使用的上下文，用于区分超时，stream - UserSendMessage 返回的事件流
+func collectChatAnswer(ctx context.Context, stream io.Reader) (string, string, error) {
+	var answer, conversationID, errorMessage string
+	reader := bufio.NewReader(stream)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: "); ok {
+			var event dto.ChatMessageResponseEventDto
+			if err := json.Unmarshal([]byte(data), &event); err == nil {
+				if event.ConversationID != "" {
+					conversationID = event.ConversationID
+				}
+				switch event.MessageType {
+				case "answer":
+					answer = event.Content
+				case "error":
+					errorMessage = event.Content
+				}
+			}
+		}
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) {
+				return answer, conversationID, fmt.Errorf("读取回复失败: %w", readErr)
+			}
+			break
+		}
+	}
+
+	if errorMessage != "" {
+		return answer, conversationID, errors.New(errorMessage)
+	}
+	if answer == "" {
+		if ctx.Err() != nil {
+			return "", conversationID, fmt.Errorf("处理超时: %w", ctx.Err())
+		}
+		return "", conversationID, errors.New("智能体没有返回回答")
+	}
+	return answer, conversationID, nil
+}
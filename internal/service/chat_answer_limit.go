@@ -0,0 +1,165 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"log"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// 智能体可设置的请求和回答限制的上限
+const (
+	maxUserMessageLengthLimit     = 100000  // 用户消息最大字符数的上限
+	maxAnswerDurationSecondsLimit = 3600    // 回答最长生成时间（秒）的上限
+	maxAnswerOutputTokensLimit    = 1000000 // 回答输出Token总数的上限
+)
+
+// 估算Token数时每个字符计入的份额，4份为1个Token：中日韩文字每个字符约1个Token，其他字符约4个字符1个Token
+const (
+	tokenUnitsPerToken    = 4
+	tokenUnitsWideRune    = 4
+	tokenUnitsDefaultRune = 1
+)
+
+// validateChatAnswerLimits 校验智能体的请求和回答限制配置
+func validateChatAnswerLimits(chatAgent *models.ChatAgent) error {
+	if chatAgent.MaxUserMessageLength < 0 || chatAgent.MaxUserMessageLength > maxUserMessageLengthLimit {
+		return apperror.Newf(apperror.CodeInvalidArgument, "用户消息最大字符数必须在0到%d之间", maxUserMessageLengthLimit)
+	}
+	if chatAgent.MaxAnswerDurationSeconds < 0 || chatAgent.MaxAnswerDurationSeconds > maxAnswerDurationSecondsLimit {
+		return apperror.Newf(apperror.CodeInvalidArgument, "回答最长生成时间必须在0到%d秒之间", maxAnswerDurationSecondsLimit)
+	}
+	if chatAgent.MaxAnswerOutputTokens < 0 || chatAgent.MaxAnswerOutputTokens > maxAnswerOutputTokensLimit {
+		return apperror.Newf(apperror.CodeInvalidArgument, "回答输出Token总数上限必须在0到%d之间", maxAnswerOutputTokensLimit)
+	}
+	return nil
+}
+
+// checkUserMessageLength 校验用户消息长度，超过智能体设置的上限时返回 PayloadTooLarge 错误
+func checkUserMessageLength(chatAgent *models.ChatAgent, message string) error {
+	if chatAgent.MaxUserMessageLength <= 0 {
+		return nil
+	}
+	if length := utf8.RuneCountInString(message); length > chatAgent.MaxUserMessageLength {
+		return apperror.Newf(apperror.CodePayloadTooLarge, "用户消息长度为%d个字符，超过了%d个字符的上限", length, chatAgent.MaxUserMessageLength)
+	}
+	return nil
+}
+
+// chatAnswerLimiter 单次回答的生成时间和输出Token限制
+// 在工具调用后的多轮模型调用之间共享，输出Token数按回答内容和工具调用参数估算
+type chatAnswerLimiter struct {
+	deadline        time.Time // 回答生成截止时间，零值表示不限制
+	perCallMaxToken int       // 单次模型调用的最大输出Token数，0 表示不限制
+	budgetUnits     int       // 输出Token总数上限（按份额计算），0 表示不限制
+	usedUnits       int       // 已输出的Token数（按份额计算）
+}
+
+// newChatAnswerLimiter 按智能体的配置创建回答限制，从当前时间开始计算生成时间
+func newChatAnswerLimiter(chatAgent *models.ChatAgent, now time.Time) *chatAnswerLimiter {
+	limiter := &chatAnswerLimiter{budgetUnits: chatAgent.MaxAnswerOutputTokens * tokenUnitsPerToken}
+	if chatAgent.MaxAnswerDurationSeconds > 0 {
+		limiter.deadline = now.Add(time.Duration(chatAgent.MaxAnswerDurationSeconds) * time.Second)
+	}
+	if chatAgent.EnableMaxOutputTokenCountLimit {
+		limiter.perCallMaxToken = chatAgent.MaxOutputTokenCountLimit
+	}
+	return limiter
+}
+
+// modelContext 返回模型调用使用的上下文，设置了生成时间上限时到期自动取消
+func (l *chatAnswerLimiter) modelContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, l.deadline)
+}
+
+// requestMaxTokens 本轮模型调用的最大输出Token数，取单次调用上限和剩余额度中较小的值，0 表示不限制
+func (l *chatAnswerLimiter) requestMaxTokens() int {
+	maxTokens := l.perCallMaxToken
+	if l.budgetUnits > 0 {
+		remaining := max((l.budgetUnits-l.usedUnits+tokenUnitsPerToken-1)/tokenUnitsPerToken, 1)
+		if maxTokens <= 0 || remaining < maxTokens {
+			maxTokens = remaining
+		}
+	}
+	return maxTokens
+}
+
+// fit 计入模型输出的内容，返回剩余额度内可以输出的部分和内容是否因额度用完被截断
+func (l *chatAnswerLimiter) fit(text string) (string, bool) {
+	if l.budgetUnits <= 0 {
+		return text, false
+	}
+	for i, r := range text {
+		units := tokenUnits(r)
+		if l.usedUnits+units > l.budgetUnits {
+			return text[:i], true
+		}
+		l.usedUnits += units
+	}
+	return text, false
+}
+
+// truncatedReason 回答需要截断的原因，生成时间已到或输出Token额度已用完时返回对应原因，否则返回空字符串
+func (l *chatAnswerLimiter) truncatedReason() string {
+	if !l.deadline.IsZero() && !time.Now().Before(l.deadline) {
+		return define.ChatAnswerTruncatedReasonDuration
+	}
+	if l.budgetUnits > 0 && l.usedUnits >= l.budgetUnits {
+		return define.ChatAnswerTruncatedReasonOutputTokens
+	}
+	return ""
+}
+
+// tokenUnits 估算单个字符计入的Token份额
+func tokenUnits(r rune) int {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return tokenUnitsWideRune
+	}
+	return tokenUnitsDefaultRune
+}
+
+// writeTruncatedAnswer 回答被截断时保存已生成的内容，并依次输出 truncated、answer 和 citations 事件
+// truncated 事件的内容为截断原因；截断的回答不生成追问建议；usage 为已知的token用量和费用，记录在截断的助手消息上，可为 nil
+func (s *chatAgentConversationService) writeTruncatedAnswer(ctx context.Context, w io.Writer, application *models.Application, chatAgent *models.ChatAgent,
+	conversationID, requestID, content, reason string, citations []dto.ChatCitationDto, usage *chatTokenUsage) {
+	assistantMessageObj := &models.ChatAgentMessage{
+		ApplicationID:   application.ID,
+		ChatAgentID:     chatAgent.ID,
+		ConversationID:  uuid.MustParse(conversationID),
+		RequestID:       requestID,
+		Type:            "message",
+		Role:            "assistant",
+		Content:         content,
+		TruncatedReason: reason,
+	}
+	usage.apply(assistantMessageObj)
+	answerCitations := attachCitations(assistantMessageObj, citations)
+	// 生成时间到期时模型调用的上下文已取消，保存消息使用不会被取消的上下文
+	if err := s.saveMessage(context.WithoutCancel(ctx), assistantMessageObj); err != nil {
+		log.Printf("保存截断的助手消息失败: %v", err)
+	}
+
+	for _, event := range []dto.ChatMessageResponseEventDto{
+		{ConversationID: conversationID, RequestID: requestID, MessageType: "truncated", Content: reason},
+		{ConversationID: conversationID, RequestID: requestID, MessageType: "answer", Content: content},
+	} {
+		eventJSON, _ := json.Marshal(event)
+		w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+	}
+	writeCitationsEvent(w, conversationID, requestID, answerCitations)
+}
@@ -0,0 +1,152 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/define"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 超过模型上下文长度时的恢复限制
+const (
+	maxContextTooLongRetries    = 2                // 超过上下文长度时裁剪历史消息后重试的最大次数
+	maxHistorySummaryInput      = 20000            // 交给摘要模型的历史消息最大字符数，超出时保留较新的部分
+	historySummaryTimeout       = 30 * time.Second // 摘要历史消息的超时时间
+	historySummaryMaxLength     = 1000             // 历史消息摘要的最大字符数
+	historySummaryMessagePrefix = "以下是此前对话的摘要，更早的消息因超过模型的上下文长度已省略：\n"
+)
+
+// historySummaryPrompt 摘要历史消息使用的系统提示词
+const historySummaryPrompt = "你将看到一段用户与助手的对话记录。请在%d个字符以内概括对话内容，保留后续回答可能用到的关键事实、用户的需求和偏好、已确认的结论，" +
+	"保持原有的语言，只输出摘要，不要添加任何解释。"
+
+// recoverContextTooLong 模型返回超过上下文长度的错误时裁剪历史消息
+// 丢弃较早的一半历史消息，使用会话命名模型将其摘要后放在历史消息之前，摘要失败时直接丢弃；系统提示词、注入消息和本轮的消息保持不变
+// attempt 为第几次重试，超过 maxContextTooLongRetries 或没有可裁剪的历史消息时不再重试
+// 返回：裁剪后的消息列表和是否应重试
+func (s *chatAgentConversationService) recoverContextTooLong(ctx context.Context, conversationID, requestID string, messages []al_client.ChatMessage, err error, attempt int) ([]al_client.ChatMessage, bool) {
+	providerErr, ok := al_client.AsProviderError(err)
+	if !ok || providerErr.Kind != al_client.ProviderErrorKindContextTooLong || attempt > maxContextTooLongRetries {
+		return messages, false
+	}
+
+	trimmed, dropped := trimHistoryMessages(messages)
+	if len(dropped) == 0 {
+		log.Printf("会话 %s 请求 %s 超过模型的上下文长度，没有可裁剪的历史消息: %v", conversationID, requestID, err)
+		return messages, false
+	}
+
+	// 摘要放在系统提示词和注入消息之后，再次裁剪时保留，新的摘要放在其后
+	summarized := false
+	if summary, err := s.summarizeHistoryMessages(ctx, dropped); err != nil {
+		log.Printf("摘要历史消息失败，直接丢弃: %v", err)
+	} else if summary != "" {
+		start := historyStartIndex(trimmed)
+		trimmed = slices.Insert(trimmed, start, al_client.ChatMessage{
+			Role:    "system",
+			Content: historySummaryMessagePrefix + summary,
+		})
+		summarized = true
+	}
+
+	log.Printf("会话 %s 请求 %s 超过模型的上下文长度，第 %d 次重试：丢弃 %d 条历史消息，已摘要: %t，消息数 %d -> %d",
+		conversationID, requestID, attempt, len(dropped), summarized, len(messages), len(trimmed))
+	return trimmed, true
+}
+
+// historyStartIndex 获取历史消息在消息列表中的起始位置，跳过开头的系统提示词、注入消息和历史消息摘要
+func historyStartIndex(messages []al_client.ChatMessage) int {
+	for i, message := range messages {
+		if message.Role != define.ChatInjectedMessageRoleSystem && message.Role != define.ChatInjectedMessageRoleDeveloper {
+			return i
+		}
+	}
+	return len(messages)
+}
+
+// trimHistoryMessages 丢弃较早的一半历史消息
+// 历史消息为开头的系统消息与本轮用户消息之间的消息；保留的历史消息从用户消息开始，避免工具结果与对应的工具调用分离
+// 返回：裁剪后的消息列表和被丢弃的消息，没有可裁剪的历史消息时被丢弃的消息为空
+func trimHistoryMessages(messages []al_client.ChatMessage) ([]al_client.ChatMessage, []al_client.ChatMessage) {
+	start := historyStartIndex(messages)
+	end := -1
+	for i := len(messages) - 1; i >= start; i-- {
+		if messages[i].Role == "user" {
+			end = i
+			break
+		}
+	}
+	if end <= start {
+		return messages, nil
+	}
+
+	history := messages[start:end]
+	cut := (len(history) + 1) / 2
+	for cut < len(history) && history[cut].Role != "user" {
+		cut++
+	}
+
+	trimmed := make([]al_client.ChatMessage, 0, len(messages)-cut)
+	trimmed = append(trimmed, messages[:start]...)
+	trimmed = append(trimmed, messages[start+cut:]...)
+	return trimmed, history[:cut]
+}
+
+// summarizeHistoryMessages 使用会话命名模型摘要被丢弃的历史消息
+func (s *chatAgentConversationService) summarizeHistoryMessages(ctx context.Context, messages []al_client.ChatMessage) (string, error) {
+	var transcript strings.Builder
+	for _, message := range messages {
+		switch {
+		case message.Role == "system":
+			transcript.WriteString(strings.TrimPrefix(message.Content, historySummaryMessagePrefix))
+		case len(message.ToolCalls) > 0:
+			for _, toolCall := range message.ToolCalls {
+				fmt.Fprintf(&transcript, "助手调用工具 %s: %s\n", toolCall.Function.Name, toolCall.Function.Arguments)
+			}
+			continue
+		case message.Role == "tool":
+			transcript.WriteString("工具结果: " + message.Content)
+		case message.Role == "assistant":
+			transcript.WriteString("助手: " + message.Content)
+		default:
+			transcript.WriteString("用户: " + message.Content)
+		}
+		transcript.WriteString("\n")
+	}
+	input := transcript.String()
+	if runes := []rune(input); len(runes) > maxHistorySummaryInput {
+		input = string(runes[len(runes)-maxHistorySummaryInput:])
+	}
+
+	llmProvider, llm, err := s.getChatAgentNamingLlmConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	aiClient, err := s.createAIClient(ctx, llmProvider)
+	if err != nil {
+		return "", fmt.Errorf("创建AI客户端失败: %w", err)
+	}
+
+	summaryCtx, cancel := context.WithTimeout(ctx, historySummaryTimeout)
+	defer cancel()
+	response, err := aiClient.SendMessage(summaryCtx, al_client.SendMessageRequest{
+		Model: llm.Name,
+		Messages: []al_client.ChatMessage{
+			{Role: "system", Content: fmt.Sprintf(historySummaryPrompt, historySummaryMaxLength)},
+			{Role: "user", Content: input},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("模型未返回结果")
+	}
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,126 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// followUpSuggestionCount 每次回答后生成的追问建议数量
+const followUpSuggestionCount = 3
+
+// followUpSuggestionsPrompt 生成追问建议使用的系统提示词
+const followUpSuggestionsPrompt = "你将看到用户的问题和助手的回答。请站在用户的角度，生成%d个用户接下来最可能继续提出的简短问题，" +
+	"使用与用户问题相同的语言，每个问题不超过30个字。只输出JSON字符串数组，例如 [\"问题1\",\"问题2\",\"问题3\"]，不要输出其他内容。"
+
+// writeFollowUpSuggestions 回答完成后生成追问建议
+// 智能体未启用追问建议时直接返回；生成成功后保存到助手消息并输出 suggestions 事件，失败时只记录日志
+// 参数：w - 事件输出流，messages - 本次请求交给模型的消息列表，assistantMessage - 已保存的助手回答消息
+func (s *chatAgentConversationService) writeFollowUpSuggestions(ctx context.Context, w io.Writer, conversationID, requestID string,
+	messages []al_client.ChatMessage, assistantMessage *models.ChatAgentMessage) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil || !chatAgent.EnableFollowUpSuggestions || strings.TrimSpace(assistantMessage.Content) == "" {
+		return
+	}
+
+	suggestions, err := s.generateFollowUpSuggestions(ctx, lastUserMessageContent(messages), assistantMessage.Content)
+	if err != nil {
+		log.Printf("生成追问建议失败: %v", err)
+		return
+	}
+	if len(suggestions) == 0 {
+		return
+	}
+
+	// 保存到助手消息
+	suggestionsJSON, _ := json.Marshal(suggestions)
+	assistantMessage.Suggestions = string(suggestionsJSON)
+	if assistantMessage.ID != uuid.Nil {
+		if err := s.messageRepo.UpdateSuggestions(ctx, assistantMessage.ID, assistantMessage.Suggestions); err != nil {
+			log.Printf("保存追问建议失败: %v", err)
+		}
+	}
+
+	event := dto.ChatMessageResponseEventDto{
+		ConversationID: conversationID,
+		RequestID:      requestID,
+		MessageType:    "suggestions",
+		Suggestions:    suggestions,
+	}
+	eventJSON, _ := json.Marshal(event)
+	w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+}
+
+// generateFollowUpSuggestions 使用会话命名模型根据问答内容生成追问建议
+func (s *chatAgentConversationService) generateFollowUpSuggestions(ctx context.Context, question, answer string) ([]string, error) {
+	llmProvider, llm, err := s.getChatAgentNamingLlmConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aiClient, err := s.createAIClient(ctx, llmProvider)
+	if err != nil {
+		return nil, fmt.Errorf("创建AI客户端失败: %w", err)
+	}
+
+	response, err := aiClient.SendMessage(ctx, al_client.SendMessageRequest{
+		Model: llm.Name,
+		Messages: []al_client.ChatMessage{
+			{Role: "system", Content: fmt.Sprintf(followUpSuggestionsPrompt, followUpSuggestionCount)},
+			{Role: "user", Content: fmt.Sprintf("用户问题：\n%s\n\n助手回答：\n%s", question, answer)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("模型未返回结果")
+	}
+	return parseFollowUpSuggestions(response.Choices[0].Message.Content), nil
+}
+
+// parseFollowUpSuggestions 解析模型返回的追问建议
+// 优先按JSON数组解析，解析失败时按行拆分，最多返回 followUpSuggestionCount 个
+func parseFollowUpSuggestions(content string) []string {
+	var candidates []string
+	start, end := strings.Index(content, "["), strings.LastIndex(content, "]")
+	if start < 0 || end <= start || json.Unmarshal([]byte(content[start:end+1]), &candidates) != nil {
+		// 按行拆分时去除列表序号等前缀
+		candidates = nil
+		for _, line := range strings.Split(content, "\n") {
+			candidates = append(candidates, strings.TrimLeft(strings.TrimSpace(line), "-*0123456789.、)） "))
+		}
+	}
+
+	suggestions := make([]string, 0, followUpSuggestionCount)
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+		suggestions = append(suggestions, candidate)
+		if len(suggestions) == followUpSuggestionCount {
+			break
+		}
+	}
+	return suggestions
+}
+
+// lastUserMessageContent 获取消息列表中最后一条用户消息的内容
+func lastUserMessageContent(messages []al_client.ChatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// chatInjection 注入给模型的一段上下文
+type chatInjection struct {
+	source  string // 来源，见 define.ChatInjectedMessageSource*
+	content string // 内容
+}
+
+// buildChatInjections 构建本轮注入给模型的上下文，依次为会话变量指令和知识库参考资料，内容为空的不注入
+func buildChatInjections(variableInstruction string, citations []dto.ChatCitationDto) []chatInjection {
+	var injections []chatInjection
+	if variableInstruction != "" {
+		injections = append(injections, chatInjection{source: define.ChatInjectedMessageSourceConversationVariables, content: variableInstruction})
+	}
+	if instruction := buildKnowledgeReferenceInstruction(citations); instruction != "" {
+		injections = append(injections, chatInjection{source: define.ChatInjectedMessageSourceKnowledgeRetrieval, content: instruction})
+	}
+	return injections
+}
+
+// resolveInjectedMessageRole 获取智能体注入消息使用的角色，未设置时使用 system
+func resolveInjectedMessageRole(chatAgent *models.ChatAgent) string {
+	if chatAgent.InjectedMessageRole == "" {
+		return define.ChatInjectedMessageRoleSystem
+	}
+	return chatAgent.InjectedMessageRole
+}
+
+// validateInjectedMessageRole 校验智能体注入消息使用的角色
+func validateInjectedMessageRole(chatAgent *models.ChatAgent) error {
+	switch chatAgent.InjectedMessageRole {
+	case "", define.ChatInjectedMessageRoleSystem, define.ChatInjectedMessageRoleDeveloper:
+		return nil
+	default:
+		return apperror.Newf(apperror.CodeInvalidArgument, "不支持的注入消息角色: %s", chatAgent.InjectedMessageRole)
+	}
+}
+
+// injectedChatMessages 将注入的上下文转换为交给模型的消息，放在系统提示词之后
+func injectedChatMessages(chatAgent *models.ChatAgent, injections []chatInjection) []al_client.ChatMessage {
+	role := resolveInjectedMessageRole(chatAgent)
+	messages := make([]al_client.ChatMessage, 0, len(injections))
+	for _, injection := range injections {
+		messages = append(messages, al_client.ChatMessage{Role: role, Content: injection.content})
+	}
+	return messages
+}
+
+// saveInjectedMessages 保存本轮注入给模型的上下文，便于排查模型回答的依据，保存失败时不影响主流程
+func (s *chatAgentConversationService) saveInjectedMessages(ctx context.Context, application *models.Application, chatAgent *models.ChatAgent,
+	conversationID uuid.UUID, requestID string, injections []chatInjection) {
+	role := resolveInjectedMessageRole(chatAgent)
+	for _, injection := range injections {
+		message := &models.ChatAgentMessage{
+			ApplicationID:  application.ID,
+			ChatAgentID:    chatAgent.ID,
+			ConversationID: conversationID,
+			RequestID:      requestID,
+			Type:           define.ChatMessageTypeInjected,
+			Role:           role,
+			Content:        injection.content,
+			InjectedSource: injection.source,
+		}
+		if err := s.saveMessage(ctx, message); err != nil {
+			log.Printf("保存注入消息失败: %v", err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultInputPreprocessConfig 智能体未配置用户输入预处理时使用的默认配置
+var defaultInputPreprocessConfig = dto.ChatAgentInputPreprocessConfigDto{
+	StripCommands:  []string{"/no_think", "/think"},
+	TrimWhitespace: true,
+	DetectLanguage: true,
+}
+
+// defaultProfanityMask 敏感词默认替换字符
+const defaultProfanityMask = "*"
+
+// preprocessedInput 预处理后的用户输入
+type preprocessedInput struct {
+	Content  string   // 清理后的消息内容，用于保存、会话标题和规则匹配
+	Commands []string // 从消息中去除的指令，按出现顺序排列
+	Language string   // 识别出的语言，未识别时为空
+}
+
+// ModelContent 返回交给模型的消息内容
+// 去除的指令需要转交给模型才能生效，因此重新拼接在消息开头
+func (in *preprocessedInput) ModelContent() string {
+	if len(in.Commands) == 0 {
+		return in.Content
+	}
+	if in.Content == "" {
+		return strings.Join(in.Commands, " ")
+	}
+	return strings.Join(in.Commands, " ") + " " + in.Content
+}
+
+// inputPreprocessStep 用户输入预处理步骤
+type inputPreprocessStep func(input *preprocessedInput)
+
+// preprocessUserInput 按智能体配置对用户消息执行预处理
+// 依次执行指令去除、空白清理、敏感词过滤和语言识别
+// 参数：chatAgent - 智能体，userMessage - 原始用户消息
+// 返回：预处理后的用户输入
+func preprocessUserInput(chatAgent *models.ChatAgent, userMessage string) *preprocessedInput {
+	input := &preprocessedInput{Content: userMessage}
+	for _, step := range buildInputPreprocessChain(resolveInputPreprocessConfig(chatAgent)) {
+		step(input)
+	}
+	return input
+}
+
+// resolveInputPreprocessConfig 获取智能体的用户输入预处理配置
+// 未配置或配置无效时使用默认配置
+func resolveInputPreprocessConfig(chatAgent *models.ChatAgent) *dto.ChatAgentInputPreprocessConfigDto {
+	if chatAgent.InputPreprocessConfig == "" {
+		return &defaultInputPreprocessConfig
+	}
+	var preprocessConfig dto.ChatAgentInputPreprocessConfigDto
+	if err := json.Unmarshal([]byte(chatAgent.InputPreprocessConfig), &preprocessConfig); err != nil {
+		// 保存时已校验，配置无效时使用默认配置
+		return &defaultInputPreprocessConfig
+	}
+	return &preprocessConfig
+}
+
+// buildInputPreprocessChain 根据配置构建预处理步骤链
+func buildInputPreprocessChain(preprocessConfig *dto.ChatAgentInputPreprocessConfigDto) []inputPreprocessStep {
+	var chain []inputPreprocessStep
+	if len(preprocessConfig.StripCommands) > 0 {
+		chain = append(chain, newCommandStripStep(preprocessConfig.StripCommands))
+	}
+	if preprocessConfig.TrimWhitespace {
+		chain = append(chain, trimWhitespaceStep)
+	}
+	if preprocessConfig.ProfanityFilter && len(preprocessConfig.ProfanityWords) > 0 {
+		chain = append(chain, newProfanityFilterStep(preprocessConfig.ProfanityWords, preprocessConfig.ProfanityMask))
+	}
+	if preprocessConfig.DetectLanguage {
+		chain = append(chain, detectLanguageStep)
+	}
+	return chain
+}
+
+// validateInputPreprocessConfig 校验智能体的用户输入预处理配置
+func validateInputPreprocessConfig(chatAgent *models.ChatAgent) error {
+	if chatAgent.InputPreprocessConfig == "" {
+		return nil
+	}
+	var preprocessConfig dto.ChatAgentInputPreprocessConfigDto
+	if err := json.Unmarshal([]byte(chatAgent.InputPreprocessConfig), &preprocessConfig); err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "用户输入预处理配置无效", err)
+	}
+	for _, command := range preprocessConfig.StripCommands {
+		if !strings.HasPrefix(command, "/") || len(command) < 2 || strings.ContainsFunc(command, unicode.IsSpace) {
+			return apperror.Newf(apperror.CodeInvalidArgument, "预处理指令 %q 无效，指令必须以 / 开头且不能包含空白", command)
+		}
+	}
+	if preprocessConfig.ProfanityFilter {
+		if len(preprocessConfig.ProfanityWords) == 0 {
+			return apperror.New(apperror.CodeInvalidArgument, "启用敏感词过滤时，敏感词列表不能为空")
+		}
+		for _, word := range preprocessConfig.ProfanityWords {
+			if strings.TrimSpace(word) == "" {
+				return apperror.New(apperror.CodeInvalidArgument, "敏感词不能为空")
+			}
+		}
+	}
+	if utf8.RuneCountInString(preprocessConfig.ProfanityMask) > 1 {
+		return apperror.New(apperror.CodeInvalidArgument, "敏感词替换字符只能是单个字符")
+	}
+	return nil
+}
+
+// newCommandStripStep 创建指令去除步骤
+// 反复去除消息开头和结尾的指令，指令必须以空白与正文分隔
+func newCommandStripStep(commands []string) inputPreprocessStep {
+	return func(input *preprocessedInput) {
+		content := input.Content
+		for {
+			trimmed := strings.TrimLeftFunc(content, unicode.IsSpace)
+			command, rest, found := cutLeadingCommand(trimmed, commands)
+			if !found {
+				break
+			}
+			input.Commands = append(input.Commands, command)
+			content = rest
+		}
+		for {
+			trimmed := strings.TrimRightFunc(content, unicode.IsSpace)
+			command, rest, found := cutTrailingCommand(trimmed, commands)
+			if !found {
+				break
+			}
+			input.Commands = append(input.Commands, command)
+			content = rest
+		}
+		input.Content = content
+	}
+}
+
+// cutLeadingCommand 去除消息开头的指令
+func cutLeadingCommand(content string, commands []string) (string, string, bool) {
+	for _, command := range commands {
+		rest, ok := strings.CutPrefix(content, command)
+		if !ok {
+			continue
+		}
+		if next, _ := utf8.DecodeRuneInString(rest); rest == "" || unicode.IsSpace(next) {
+			return command, rest, true
+		}
+	}
+	return "", content, false
+}
+
+// cutTrailingCommand 去除消息结尾的指令
+func cutTrailingCommand(content string, commands []string) (string, string, bool) {
+	for _, command := range commands {
+		rest, ok := strings.CutSuffix(content, command)
+		if !ok {
+			continue
+		}
+		if prev, _ := utf8.DecodeLastRuneInString(rest); rest == "" || unicode.IsSpace(prev) {
+			return command, rest, true
+		}
+	}
+	return "", content, false
+}
+
+// trimWhitespaceStep 统一换行符并清理首尾空白
+func trimWhitespaceStep(input *preprocessedInput) {
+	content := strings.ReplaceAll(input.Content, "\r\n", "\n")
+	input.Content = strings.TrimSpace(content)
+}
+
+// newProfanityFilterStep 创建敏感词过滤步骤
+// 敏感词不区分大小写，按字符数替换为同等长度的替换字符
+func newProfanityFilterStep(words []string, mask string) inputPreprocessStep {
+	if mask == "" {
+		mask = defaultProfanityMask
+	}
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	pattern := regexp.MustCompile(fmt.Sprintf("(?i)(%s)", strings.Join(quoted, "|")))
+	return func(input *preprocessedInput) {
+		input.Content = pattern.ReplaceAllStringFunc(input.Content, func(match string) string {
+			return strings.Repeat(mask, utf8.RuneCountInString(match))
+		})
+	}
+}
+
+// detectLanguageStep 根据字符所属文字识别消息语言
+// 仅做粗略识别：包含假名视为日语，否则取占比最多的文字，拉丁字母视为英语
+func detectLanguageStep(input *preprocessedInput) {
+	counts := make(map[string]int)
+	hasKana := false
+	for _, r := range input.Content {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			hasKana = true
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Latin, r):
+			counts["en"]++
+		}
+	}
+	if hasKana {
+		input.Language = "ja"
+		return
+	}
+
+	language, maxCount := "", 0
+	for _, candidate := range []string{"zh", "ko", "ru", "ar", "th", "en"} {
+		// 拉丁字母通常比汉字等文字占用更多字符，按单词粗略折算
+		count := counts[candidate]
+		if candidate == "en" {
+			count /= 4
+		}
+		if count > maxCount {
+			language, maxCount = candidate, count
+		}
+	}
+	if language == "" && counts["en"] > 0 {
+		language = "en"
+	}
+	input.Language = language
+}
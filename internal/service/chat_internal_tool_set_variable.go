@@ -0,0 +1,212 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/define"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// conversationVariablePlaceholderPattern 系统提示词和工具调用参数中引用会话变量的占位符，格式为 {{变量名}}
+var conversationVariablePlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// setVariableToolDefinition 设置会话变量内部工具的定义
+func setVariableToolDefinition() al_client.Tool {
+	return al_client.Tool{
+		Type: "function",
+		Function: &al_client.FunctionDefinition{
+			Name:        define.ChatInternalToolNamePrefix + define.ChatInternalToolSetVariable,
+			Description: "设置会话变量。对话中获得后续流程需要的信息（如订单号、手机号）时调用，变量在整个会话中保留，后续调用工具时会自动填入同名参数，也可以在工具参数中以 {{变量名}} 引用",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "变量名称，以字母或下划线开头，只能包含字母、数字和下划线，建议与需要该值的工具参数同名，如 order_id",
+					},
+					"value": map[string]interface{}{
+						"type":        "string",
+						"description": "变量值",
+					},
+				},
+				"required": []string{"name", "value"},
+			},
+		},
+	}
+}
+
+// callSetVariableTool 调用设置会话变量内部工具
+func (s *chatAgentConversationService) callSetVariableTool(ctx context.Context, conversationID string, toolArgs map[string]interface{}) (string, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("获取上下文信息失败: %w", err)
+	}
+	if !chatAgent.EnableConversationVariables {
+		return "", fmt.Errorf("智能体未启用会话变量")
+	}
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return "", fmt.Errorf("无效的会话ID: %w", err)
+	}
+
+	name, _ := toolArgs["name"].(string)
+	value, ok := toolArgs["value"].(string)
+	if !ok && toolArgs["value"] != nil {
+		// 模型可能以数字或布尔值传入变量值
+		value = fmt.Sprint(toolArgs["value"])
+	}
+	variable, err := s.variableService.SaveVariable(ctx, convID, name, value, define.ChatConversationVariableSourceModel)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("已设置会话变量 %s", variable.Name), nil
+}
+
+// renderConversationVariables 将文本中的 {{变量名}} 占位符替换为会话变量的值，未设置的变量保持原样
+func renderConversationVariables(text string, values map[string]string) string {
+	if len(values) == 0 || !strings.Contains(text, "{{") {
+		return text
+	}
+	return conversationVariablePlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := conversationVariablePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// buildConversationVariableInstruction 构建告知模型当前会话变量的系统指令
+// 智能体未启用会话变量时返回空
+func buildConversationVariableInstruction(enabled bool, values map[string]string) string {
+	if !enabled {
+		return ""
+	}
+	if len(values) == 0 {
+		return "当前会话还没有设置变量。对话中获得后续流程需要的信息时，请调用设置会话变量工具保存。"
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	builder.WriteString("当前会话已设置以下变量，调用工具时会自动填入同名参数，也可以在参数中以 {{变量名}} 引用：\n")
+	for _, name := range names {
+		fmt.Fprintf(&builder, "- %s: %s\n", name, values[name])
+	}
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+// applyConversationVariables 将会话变量注入MCP工具的调用参数
+// 替换字符串参数中的 {{变量名}} 占位符；工具定义中声明了与变量同名的参数而模型未传入时自动填入变量值
+// 内部工具不注入；变量加载或参数解析失败时返回原始的工具调用
+func (s *chatAgentConversationService) applyConversationVariables(ctx context.Context, conversationID string, toolCall al_client.ToolCall, aiTools []al_client.Tool) al_client.ToolCall {
+	if strings.HasPrefix(toolCall.Function.Name, define.ChatInternalToolNamePrefix) {
+		return toolCall
+	}
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return toolCall
+	}
+	values, err := s.variableService.GetVariableValues(ctx, convID)
+	if err != nil {
+		log.Printf("获取会话变量失败: %v", err)
+		return toolCall
+	}
+	if len(values) == 0 {
+		return toolCall
+	}
+
+	args := map[string]interface{}{}
+	if strings.TrimSpace(toolCall.Function.Arguments) != "" {
+		if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
+			return toolCall
+		}
+	}
+	for name, value := range args {
+		args[name] = renderConversationVariablesInValue(value, values)
+	}
+	for name, property := range toolParameterProperties(aiTools, toolCall.Function.Name) {
+		if _, ok := args[name]; ok {
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		if converted, ok := convertVariableToParameterType(value, property); ok {
+			args[name] = converted
+		}
+	}
+
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return toolCall
+	}
+	toolCall.Function.Arguments = string(arguments)
+	return toolCall
+}
+
+// renderConversationVariablesInValue 替换参数值（包括嵌套的对象和数组）中字符串的变量占位符
+func renderConversationVariablesInValue(value interface{}, values map[string]string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return renderConversationVariables(v, values)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = renderConversationVariablesInValue(item, values)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = renderConversationVariablesInValue(item, values)
+		}
+		return v
+	}
+	return value
+}
+
+// toolParameterProperties 获取工具定义中声明的参数，找不到工具时返回空
+func toolParameterProperties(aiTools []al_client.Tool, toolName string) map[string]interface{} {
+	for _, tool := range aiTools {
+		if tool.Function == nil || tool.Function.Name != toolName {
+			continue
+		}
+		properties, _ := tool.Function.Parameters["properties"].(map[string]interface{})
+		return properties
+	}
+	return nil
+}
+
+// convertVariableToParameterType 按参数声明的类型转换变量值
+// 未声明类型或类型为字符串时使用原值，数字和布尔类型无法转换时不填入
+func convertVariableToParameterType(value string, property interface{}) (interface{}, bool) {
+	propertyMap, _ := property.(map[string]interface{})
+	parameterType, _ := propertyMap["type"].(string)
+	switch parameterType {
+	case "", "string":
+		return value, true
+	case "integer":
+		converted, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		return converted, err == nil
+	case "number":
+		converted, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return converted, err == nil
+	case "boolean":
+		converted, err := strconv.ParseBool(strings.TrimSpace(value))
+		return converted, err == nil
+	}
+	return nil, false
+}
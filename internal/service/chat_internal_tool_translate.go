@@ -0,0 +1,89 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/define"
+	"strings"
+)
+
+// translateSystemPrompt 翻译工具使用的系统提示词
+const translateSystemPrompt = "你是一名专业翻译。请将用户提供的文本翻译为%s，保留原文的格式、Markdown标记、代码和专有名词，只输出译文，不要添加任何解释。"
+
+// translateToolDefinition 翻译内部工具的定义
+func translateToolDefinition() al_client.Tool {
+	return al_client.Tool{
+		Type: "function",
+		Function: &al_client.FunctionDefinition{
+			Name:        define.ChatInternalToolNamePrefix + define.ChatInternalToolTranslate,
+			Description: "将文本翻译为指定语言。当知识库或工具返回的内容与用户使用的语言不一致时，可先将用户问题翻译为资料所用语言检索，再将结果翻译为用户的语言",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"text": map[string]interface{}{
+						"type":        "string",
+						"description": "需要翻译的文本",
+					},
+					"target_language": map[string]interface{}{
+						"type":        "string",
+						"description": "目标语言，如 简体中文、English、日本語",
+					},
+				},
+				"required": []string{"text", "target_language"},
+			},
+		},
+	}
+}
+
+// callTranslateTool 调用翻译内部工具
+// 使用智能体配置的翻译模型翻译文本
+func (s *chatAgentConversationService) callTranslateTool(ctx context.Context, toolArgs map[string]interface{}) (string, error) {
+	text, _ := toolArgs["text"].(string)
+	targetLanguage, _ := toolArgs["target_language"].(string)
+	if strings.TrimSpace(text) == "" || strings.TrimSpace(targetLanguage) == "" {
+		return "", fmt.Errorf("翻译工具参数错误: text 和 target_language 不能为空")
+	}
+	return s.translateText(ctx, text, targetLanguage)
+}
+
+// translateText 使用智能体配置的翻译模型将文本翻译为目标语言
+func (s *chatAgentConversationService) translateText(ctx context.Context, text, targetLanguage string) (string, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("获取上下文信息失败: %w", err)
+	}
+	if !chatAgent.EnableTranslation {
+		return "", fmt.Errorf("智能体未启用翻译工具")
+	}
+
+	translationLlm, err := s.llmRepo.GetByID(ctx, chatAgent.TranslationModelID)
+	if err != nil {
+		return "", fmt.Errorf("ChatAgent未配置翻译模型")
+	}
+	translationLlmProvider, err := s.llmProviderRepo.GetByID(ctx, translationLlm.LlmProviderID)
+	if err != nil {
+		return "", fmt.Errorf("ChatAgent未配置翻译模型提供商")
+	}
+	aiClient, err := s.createAIClient(ctx, translationLlmProvider)
+	if err != nil {
+		return "", fmt.Errorf("创建AI客户端失败: %w", err)
+	}
+
+	response, err := aiClient.SendMessage(ctx, al_client.SendMessageRequest{
+		Model: translationLlm.Name,
+		Messages: []al_client.ChatMessage{
+			{Role: "system", Content: fmt.Sprintf(translateSystemPrompt, targetLanguage)},
+			{Role: "user", Content: text},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("翻译失败: %w", err)
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("翻译失败: 模型未返回结果")
+	}
+	return response.Choices[0].Message.Content, nil
+}
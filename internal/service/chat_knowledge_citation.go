@@ -0,0 +1,93 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// knowledgeReferencePrompt 注入知识库参考资料时追加的系统指令
+const knowledgeReferencePrompt = "以下是从知识库中检索到的参考资料，每条资料以 [编号] 开头。" +
+	"回答时请优先依据参考资料，并在使用了资料的句子末尾用对应编号标注来源，例如 [1] 或 [1][2]；" +
+	"参考资料与问题无关时忽略它们，不要编造引用。"
+
+// citationMarkerPattern 回答中的引用标注，如 [1]
+var citationMarkerPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// buildKnowledgeCitations 将检索结果转换为引用列表，编号从1开始
+func buildKnowledgeCitations(results []*KnowledgeRetrievalResult) []dto.ChatCitationDto {
+	citations := make([]dto.ChatCitationDto, 0, len(results))
+	for i, result := range results {
+		citations = append(citations, dto.ChatCitationDto{
+			Index:           i + 1,
+			KnowledgeBaseID: result.Chunk.KnowledgeBaseID.String(),
+			DocumentID:      result.Chunk.DocumentID.String(),
+			DocumentName:    result.DocumentName,
+			ChunkID:         result.Chunk.ID.String(),
+			ChunkIndex:      result.Chunk.ChunkIndex,
+			Content:         result.Chunk.Content,
+			Score:           result.Score,
+		})
+	}
+	return citations
+}
+
+// buildKnowledgeReferenceInstruction 构建注入系统提示词的参考资料指令，没有引用时返回空字符串
+func buildKnowledgeReferenceInstruction(citations []dto.ChatCitationDto) string {
+	if len(citations) == 0 {
+		return ""
+	}
+	var builder strings.Builder
+	builder.WriteString(knowledgeReferencePrompt)
+	for _, citation := range citations {
+		builder.WriteString(fmt.Sprintf("\n\n[%d] 来源：%s\n%s", citation.Index, citation.DocumentName, citation.Content))
+	}
+	return builder.String()
+}
+
+// attachCitations 根据回答内容标注实际引用的片段，并保存到助手消息
+// 返回标注后的引用列表，没有引用时返回空
+func attachCitations(assistantMessage *models.ChatAgentMessage, citations []dto.ChatCitationDto) []dto.ChatCitationDto {
+	if len(citations) == 0 {
+		return nil
+	}
+	citedIndexes := make(map[int]bool)
+	for _, match := range citationMarkerPattern.FindAllStringSubmatch(assistantMessage.Content, -1) {
+		if index, err := strconv.Atoi(match[1]); err == nil {
+			citedIndexes[index] = true
+		}
+	}
+
+	// 复制一份，避免修改工具调用后递归处理时复用的引用列表
+	result := make([]dto.ChatCitationDto, len(citations))
+	for i, citation := range citations {
+		citation.Cited = citedIndexes[citation.Index]
+		result[i] = citation
+	}
+
+	citationsJSON, _ := json.Marshal(result)
+	assistantMessage.Citations = string(citationsJSON)
+	return result
+}
+
+// writeCitationsEvent 输出 citations 事件，没有引用时不输出
+func writeCitationsEvent(w io.Writer, conversationID, requestID string, citations []dto.ChatCitationDto) {
+	if len(citations) == 0 {
+		return
+	}
+	event := dto.ChatMessageResponseEventDto{
+		ConversationID: conversationID,
+		RequestID:      requestID,
+		MessageType:    "citations",
+		Citations:      citations,
+	}
+	eventJSON, _ := json.Marshal(event)
+	w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+}
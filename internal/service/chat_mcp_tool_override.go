@@ -0,0 +1,168 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// 智能体覆盖MCP工具定义的长度限制
+const (
+	maxMcpToolTitleOverrideLength       = 128  // 工具标题的最大长度
+	maxMcpToolDescriptionOverrideLength = 4096 // 工具描述的最大长度
+	maxMcpToolParameterOverrideLength   = 1024 // 单个参数描述的最大长度
+)
+
+// applyMcpToolSettingOverrides 校验工具设置中的覆盖内容和渲染方式并写入模型
+// 覆盖内容去除首尾空白，空的参数描述不保存
+func applyMcpToolSettingOverrides(setting *models.ChatAgentMcpServerTool, toolSetting *dto.ChatAgentMcpServerToolSettingDto) error {
+	switch toolSetting.OutputRenderer {
+	case "", define.ChatToolOutputRendererTable, define.ChatToolOutputRendererImage,
+		define.ChatToolOutputRendererFile, define.ChatToolOutputRendererJson:
+	default:
+		return mcpToolOverrideError("output_renderer", fmt.Sprintf("不支持的工具结果渲染方式: %s", toolSetting.OutputRenderer))
+	}
+
+	title := strings.TrimSpace(toolSetting.TitleOverride)
+	if len([]rune(title)) > maxMcpToolTitleOverrideLength {
+		return mcpToolOverrideError("title_override", fmt.Sprintf("工具标题不能超过%d个字符", maxMcpToolTitleOverrideLength))
+	}
+	description := strings.TrimSpace(toolSetting.DescriptionOverride)
+	if len([]rune(description)) > maxMcpToolDescriptionOverrideLength {
+		return mcpToolOverrideError("description_override", fmt.Sprintf("工具描述不能超过%d个字符", maxMcpToolDescriptionOverrideLength))
+	}
+
+	parameters := make(map[string]string, len(toolSetting.ParameterDescriptionOverrides))
+	for name, parameterDescription := range toolSetting.ParameterDescriptionOverrides {
+		name = strings.TrimSpace(name)
+		parameterDescription = strings.TrimSpace(parameterDescription)
+		if name == "" {
+			return mcpToolOverrideError("parameter_description_overrides", "参数名称不能为空")
+		}
+		if len([]rune(parameterDescription)) > maxMcpToolParameterOverrideLength {
+			return mcpToolOverrideError("parameter_description_overrides", fmt.Sprintf("参数 %s 的描述不能超过%d个字符", name, maxMcpToolParameterOverrideLength))
+		}
+		if parameterDescription != "" {
+			parameters[name] = parameterDescription
+		}
+	}
+
+	setting.OutputRenderer = toolSetting.OutputRenderer
+	setting.TitleOverride = title
+	setting.DescriptionOverride = description
+	setting.ParameterDescriptionOverrides = ""
+	if len(parameters) > 0 {
+		data, err := json.Marshal(parameters)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "序列化参数描述失败", err)
+		}
+		setting.ParameterDescriptionOverrides = string(data)
+	}
+	return nil
+}
+
+// mcpToolOverrideError 生成覆盖内容无效的参数错误
+func mcpToolOverrideError(field, message string) error {
+	return apperror.New(apperror.CodeInvalidArgument, message).WithDetails(map[string]any{"field": field})
+}
+
+// decodeMcpToolParameterOverrides 解析工具设置中保存的参数描述，内容无效时忽略
+func decodeMcpToolParameterOverrides(setting *models.ChatAgentMcpServerTool) map[string]string {
+	if setting.ParameterDescriptionOverrides == "" {
+		return nil
+	}
+	var parameters map[string]string
+	if err := json.Unmarshal([]byte(setting.ParameterDescriptionOverrides), &parameters); err != nil {
+		log.Printf("解析MCP工具参数描述失败: %v", err)
+		return nil
+	}
+	return parameters
+}
+
+// overrideMcpToolDefinition 使用智能体的工具设置覆盖提供给模型的工具定义
+// 标题放在描述的第一行；参数描述只覆盖工具定义中已有的参数，原有的参数定义不会被修改
+func overrideMcpToolDefinition(tool *al_client.Tool, setting *models.ChatAgentMcpServerTool) {
+	if setting == nil || tool.Function == nil {
+		return
+	}
+	if setting.DescriptionOverride != "" {
+		tool.Function.Description = setting.DescriptionOverride
+	}
+	if setting.TitleOverride != "" {
+		tool.Function.Description = strings.TrimSpace(setting.TitleOverride + "\n" + tool.Function.Description)
+	}
+
+	parameterOverrides := decodeMcpToolParameterOverrides(setting)
+	if len(parameterOverrides) == 0 {
+		return
+	}
+	properties, ok := tool.Function.Parameters["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	overridden := make(map[string]interface{}, len(properties))
+	for name, property := range properties {
+		overridden[name] = property
+		description, ok := parameterOverrides[name]
+		if !ok {
+			continue
+		}
+		propertyMap, ok := property.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		copied := make(map[string]interface{}, len(propertyMap)+1)
+		for key, value := range propertyMap {
+			copied[key] = value
+		}
+		copied["description"] = description
+		overridden[name] = copied
+	}
+	tool.Function.Parameters["properties"] = overridden
+}
+
+// resolveToolOutputRenderer 获取智能体为工具设置的结果渲染方式
+// 工具名称格式为 configID_____toolName；内部工具或找不到对应工具设置时返回空
+func (s *chatAgentConversationService) resolveToolOutputRenderer(ctx context.Context, chatAgentID uuid.UUID, toolName string) string {
+	tool := s.findMcpServerTool(ctx, toolName)
+	if tool == nil {
+		return ""
+	}
+	setting, err := s.chatAgentMcpServerToolRepo.GetByChatAgentIDAndApplicationMcpServerToolID(ctx, chatAgentID, tool.ID)
+	if err != nil || setting == nil {
+		return ""
+	}
+	return setting.OutputRenderer
+}
+
+// findMcpServerTool 根据提供给模型的工具名称查找MCP工具
+// 工具名称格式为 configID_____toolName；内部工具或找不到对应工具时返回空
+func (s *chatAgentConversationService) findMcpServerTool(ctx context.Context, toolName string) *models.ApplicationMcpServerTool {
+	if strings.HasPrefix(toolName, define.ChatInternalToolNamePrefix) {
+		return nil
+	}
+	configID, mcpToolName, ok := strings.Cut(toolName, "_____")
+	if !ok {
+		return nil
+	}
+	config, err := s.mcpConfigRepo.GetByConfigID(ctx, configID)
+	if err != nil {
+		return nil
+	}
+	tool, err := s.mcpToolRepo.GetByConfigIDAndName(ctx, config.ID, mcpToolName)
+	if err != nil {
+		return nil
+	}
+	return tool
+}
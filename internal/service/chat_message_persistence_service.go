@@ -0,0 +1,226 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxMessageSpoolRetryDelay 消息重试的最长退避间隔
+const maxMessageSpoolRetryDelay = time.Hour
+
+// messageSpoolFailedDirName 放弃重试的消息在暂存目录中的子目录，供运维排查和手动恢复
+const messageSpoolFailedDirName = "failed"
+
+// ChatMessagePersistenceService 聊天消息保存 业务逻辑层接口
+// 保存消息失败时写入本地暂存目录，后台任务按指数退避重试，避免数据库短暂不可用时丢失会话记录
+type ChatMessagePersistenceService interface {
+	// Save 保存消息，写入数据库失败时暂存到本地等待重试
+	// 返回：写入数据库成功返回 true；暂存成功返回 false 和 nil；暂存也失败时返回错误
+	Save(ctx context.Context, message *models.ChatAgentMessage) (bool, error)
+
+	// RetryPending 重试到期的暂存消息，由后台定时任务调用
+	// 重试次数耗尽时放弃重试，并标记会话存在消息缺失
+	RetryPending(ctx context.Context) error
+}
+
+// spooledMessage 暂存的消息及其重试状态
+type spooledMessage struct {
+	Message     *models.ChatAgentMessage `json:"message"`       // 待保存的消息，ID 和创建时间在暂存前确定，重试后保持不变
+	Attempts    int                      `json:"attempts"`      // 已重试次数
+	NextRetryAt time.Time                `json:"next_retry_at"` // 下次重试时间
+	LastError   string                   `json:"last_error"`    // 最近一次保存失败的原因
+}
+
+// chatMessagePersistenceService 聊天消息保存 业务逻辑层实现
+type chatMessagePersistenceService struct {
+	messageRepo      repository.ChatAgentMessageRepository      // 消息数据访问层
+	conversationRepo repository.ChatAgentConversationRepository // 会话数据访问层
+	config           config.MessageSpoolConfig                  // 消息保存失败重试配置
+}
+
+// NewChatMessagePersistenceService 创建 聊天消息保存 服务实例
+// 返回 ChatMessagePersistenceService 接口的实现
+func NewChatMessagePersistenceService(messageRepo repository.ChatAgentMessageRepository, conversationRepo repository.ChatAgentConversationRepository, config *config.Config) ChatMessagePersistenceService {
+	return &chatMessagePersistenceService{
+		messageRepo:      messageRepo,
+		conversationRepo: conversationRepo,
+		config:           config.MessageSpool,
+	}
+}
+
+// Save 保存消息，写入数据库失败时暂存到本地等待重试
+// 消息与会话事件在同一事务中写入，暂存前确定消息ID和创建时间，调用方可以继续使用消息ID关联附件，重试写入后消息顺序不变
+func (s *chatMessagePersistenceService) Save(ctx context.Context, message *models.ChatAgentMessage) (bool, error) {
+	if message.ID == uuid.Nil {
+		message.ID = uuid.Must(uuid.NewV7())
+	}
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+	err := s.messageRepo.CreateWithEvent(ctx, message, newMessageEvent(message))
+	if err == nil {
+		return true, nil
+	}
+	if s.config.Dir == "" {
+		return false, err
+	}
+
+	entry := &spooledMessage{
+		Message:     message,
+		NextRetryAt: time.Now().Add(s.retryDelay(0)),
+		LastError:   err.Error(),
+	}
+	if spoolErr := s.writeEntry(s.config.Dir, entry); spoolErr != nil {
+		return false, fmt.Errorf("%w（暂存消息失败: %v）", err, spoolErr)
+	}
+	log.Printf("保存消息失败，已暂存等待重试: message_id=%s conversation_id=%s err=%v", message.ID, message.ConversationID, err)
+	return false, nil
+}
+
+// RetryPending 重试到期的暂存消息
+// 重试前确认消息是否已经写入，避免数据库提交成功但返回错误时重复写入
+func (s *chatMessagePersistenceService) RetryPending(ctx context.Context) error {
+	if s.config.Dir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(s.config.Dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("读取消息暂存目录失败: %w", err)
+	}
+
+	now := time.Now()
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		entry, err := readSpooledMessage(file)
+		if err != nil {
+			log.Printf("读取暂存消息失败: file=%s err=%v", file, err)
+			continue
+		}
+		if entry.NextRetryAt.After(now) {
+			continue
+		}
+
+		err = s.persist(ctx, entry.Message)
+		if err == nil {
+			if err := os.Remove(file); err != nil {
+				log.Printf("删除已保存的暂存消息失败: file=%s err=%v", file, err)
+			}
+			continue
+		}
+
+		entry.LastError = err.Error()
+		entry.Attempts++
+		if entry.Attempts >= s.config.MaxAttempts {
+			s.giveUp(ctx, file, entry)
+			continue
+		}
+		entry.NextRetryAt = now.Add(s.retryDelay(entry.Attempts))
+		if err := s.writeEntry(s.config.Dir, entry); err != nil {
+			log.Printf("更新暂存消息失败: file=%s err=%v", file, err)
+		}
+	}
+	return nil
+}
+
+// persist 将暂存的消息写入数据库，消息已存在时视为成功
+// 普通消息和拦截消息写入后推进会话的最后活跃时间
+func (s *chatMessagePersistenceService) persist(ctx context.Context, message *models.ChatAgentMessage) error {
+	_, err := s.messageRepo.GetByID(ctx, message.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err := s.messageRepo.CreateWithEvent(ctx, message, newMessageEvent(message)); err != nil {
+		return err
+	}
+	if message.Type == "message" || message.Type == "blocked" {
+		if err := s.conversationRepo.UpdateLastMessageAt(ctx, message.ConversationID, message.CreatedAt); err != nil {
+			log.Printf("更新会话最后消息时间失败: %v", err)
+		}
+	}
+	return nil
+}
+
+// giveUp 放弃重试暂存的消息：标记会话存在消息缺失，并将消息移到 failed 子目录
+// 标记失败时保留在待重试目录，下次执行时再次标记
+func (s *chatMessagePersistenceService) giveUp(ctx context.Context, file string, entry *spooledMessage) {
+	if err := s.conversationRepo.IncrementPersistenceGapCount(ctx, entry.Message.ConversationID); err != nil {
+		log.Printf("标记会话消息缺失失败: conversation_id=%s err=%v", entry.Message.ConversationID, err)
+		entry.NextRetryAt = time.Now().Add(s.retryDelay(entry.Attempts))
+		if err := s.writeEntry(s.config.Dir, entry); err != nil {
+			log.Printf("更新暂存消息失败: file=%s err=%v", file, err)
+		}
+		return
+	}
+	if err := s.writeEntry(filepath.Join(s.config.Dir, messageSpoolFailedDirName), entry); err != nil {
+		log.Printf("移动放弃重试的暂存消息失败: file=%s err=%v", file, err)
+		return
+	}
+	if err := os.Remove(file); err != nil {
+		log.Printf("删除放弃重试的暂存消息失败: file=%s err=%v", file, err)
+	}
+	log.Printf("消息重试%d次仍保存失败，已放弃重试: message_id=%s conversation_id=%s err=%s",
+		entry.Attempts, entry.Message.ID, entry.Message.ConversationID, entry.LastError)
+}
+
+// retryDelay 计算第 attempts 次重试后的退避间隔，每次翻倍，不超过 maxMessageSpoolRetryDelay
+func (s *chatMessagePersistenceService) retryDelay(attempts int) time.Duration {
+	delay := time.Duration(s.config.RetryIntervalSeconds) * time.Second
+	for i := 0; i < attempts && delay < maxMessageSpoolRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxMessageSpoolRetryDelay)
+}
+
+// writeEntry 将暂存消息写入目录，文件名为消息ID
+// 先写入临时文件再重命名，进程中断时不会留下不完整的文件
+func (s *chatMessagePersistenceService) writeEntry(dir string, entry *spooledMessage) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(dir, entry.Message.ID.String()+".json")
+	tmpFile := strings.TrimSuffix(file, ".json") + ".tmp"
+	if err := os.WriteFile(tmpFile, content, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, file)
+}
+
+// readSpooledMessage 读取暂存消息文件
+func readSpooledMessage(file string) (*spooledMessage, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var entry spooledMessage
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, err
+	}
+	if entry.Message == nil || entry.Message.ID == uuid.Nil {
+		return nil, fmt.Errorf("暂存消息内容无效")
+	}
+	return &entry, nil
+}
@@ -0,0 +1,40 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"sync"
+)
+
+// chatParallelToolCallResult 并行执行的工具调用结果
+type chatParallelToolCallResult struct {
+	toolCall al_client.ToolCall // 注入会话变量后的工具调用
+	output   string             // 工具结果
+	err      error              // 调用失败时的错误
+}
+
+// callToolsInParallel 应用开启了并行工具调用开关且模型一次返回多个工具调用时，并行执行全部工具调用
+// 执行前统一注入会话变量，同一批次中设置会话变量的工具不会影响其他工具的参数；事件输出和消息保存仍由调用方按顺序处理
+// 返回：与 toolCalls 一一对应的结果，未开启开关或只有一个工具调用时返回 nil，由调用方逐个执行
+func (s *chatAgentConversationService) callToolsInParallel(ctx context.Context, chatAgent *models.ChatAgent, conversationID, requestID string, toolCalls []al_client.ToolCall, aiTools []al_client.Tool) []chatParallelToolCallResult {
+	if len(toolCalls) < 2 || !s.featureFlagService.IsEnabled(ctx, chatAgent.ApplicationID, define.FeatureFlagParallelToolCalls) {
+		return nil
+	}
+
+	results := make([]chatParallelToolCallResult, len(toolCalls))
+	var wg sync.WaitGroup
+	for i, toolCall := range toolCalls {
+		results[i].toolCall = s.applyConversationVariables(ctx, conversationID, toolCall, aiTools)
+		wg.Add(1)
+		go func(result *chatParallelToolCallResult) {
+			defer wg.Done()
+			result.output, result.err = s.callTool(ctx, chatAgent, conversationID, requestID, result.toolCall)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,52 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"io"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/define"
+	"log"
+)
+
+// chatProviderErrorReply 模型供应商错误对应的错误码和面向用户的提示
+type chatProviderErrorReply struct {
+	code    string // 错误事件的错误码
+	message string // 错误事件的内容
+}
+
+// chatProviderErrorReplies 各类模型供应商错误的错误码和提示，未列出的类型使用 chatProviderErrorDefaultReply
+var chatProviderErrorReplies = map[al_client.ProviderErrorKind]chatProviderErrorReply{
+	al_client.ProviderErrorKindAuth:           {define.ChatErrorCodeProviderAuth, "模型供应商的 API Key 无效或没有权限，请检查供应商配置"},
+	al_client.ProviderErrorKindQuota:          {define.ChatErrorCodeProviderQuota, "模型供应商的账户额度已用完，请充值或更换 API Key"},
+	al_client.ProviderErrorKindRateLimit:      {define.ChatErrorCodeProviderRateLimited, "模型供应商请求过于频繁，请稍后重试"},
+	al_client.ProviderErrorKindUnavailable:    {define.ChatErrorCodeProviderUnavailable, "模型供应商服务暂不可用，请稍后重试"},
+	al_client.ProviderErrorKindModelNotFound:  {define.ChatErrorCodeModelNotFound, "模型不存在或当前 API Key 没有使用权限，请检查模型配置"},
+	al_client.ProviderErrorKindContextTooLong: {define.ChatErrorCodeContextTooLong, "对话内容超过了模型的上下文长度，请开启新的会话或缩短消息"},
+	al_client.ProviderErrorKindContentFilter:  {define.ChatErrorCodeContentFiltered, "内容被模型供应商的安全策略拦截，请修改后重试"},
+}
+
+// chatProviderErrorDefaultReply 无法识别的模型供应商错误使用的错误码和提示
+var chatProviderErrorDefaultReply = chatProviderErrorReply{define.ChatErrorCodeProviderError, "模型供应商返回错误，请稍后重试"}
+
+// resolveChatProviderErrorReply 获取模型调用失败时输出的错误码和提示
+// 供应商的原始错误信息只记录在日志中，不输出给调用者
+func resolveChatProviderErrorReply(err error) chatProviderErrorReply {
+	providerErr, ok := al_client.AsProviderError(err)
+	if !ok {
+		return chatProviderErrorDefaultReply
+	}
+	if reply, ok := chatProviderErrorReplies[providerErr.Kind]; ok {
+		return reply
+	}
+	return chatProviderErrorDefaultReply
+}
+
+// writeProviderErrorEvent 输出模型调用失败的错误事件
+// 按供应商错误的类型输出对应的错误码和处理建议，原始错误写入日志
+func (s *chatAgentConversationService) writeProviderErrorEvent(ctx context.Context, w io.Writer, conversationID, requestID string, err error) {
+	log.Printf("调用模型失败: conversation=%s request=%s: %v", conversationID, requestID, err)
+	reply := resolveChatProviderErrorReply(err)
+	s.writeErrorEvent(ctx, w, conversationID, requestID, reply.code, reply.message)
+}
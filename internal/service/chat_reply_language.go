@@ -0,0 +1,62 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxReplyLanguageLength 强制回复语言配置的最大长度
+const maxReplyLanguageLength = 32
+
+// replyLanguageNames 语言代码对应的语言名称，用于生成回复语言指令
+var replyLanguageNames = map[string]string{
+	"zh": "简体中文",
+	"en": "English",
+	"ja": "日本語",
+	"ko": "한국어",
+	"ru": "Русский",
+	"ar": "العربية",
+	"th": "ไทย",
+}
+
+// resolveReplyLanguageInstruction 生成强制回复语言的系统指令
+// 智能体未配置回复语言，或跟随用户语言但未识别出语言时返回空
+// 参数：chatAgent - 智能体，detectedLanguage - 用户消息识别出的语言
+func resolveReplyLanguageInstruction(chatAgent *models.ChatAgent, detectedLanguage string) string {
+	language := strings.TrimSpace(chatAgent.ReplyLanguage)
+	if language == define.ChatReplyLanguageAuto {
+		language = detectedLanguage
+	}
+	if language == "" {
+		return ""
+	}
+	if name, ok := replyLanguageNames[language]; ok {
+		language = name
+	}
+	return fmt.Sprintf("无论用户使用何种语言提问，请始终使用%s回复。", language)
+}
+
+// appendSystemInstruction 在系统提示词末尾追加指令
+func appendSystemInstruction(systemPrompt, instruction string) string {
+	if instruction == "" {
+		return systemPrompt
+	}
+	if strings.TrimSpace(systemPrompt) == "" {
+		return instruction
+	}
+	return systemPrompt + "\n\n" + instruction
+}
+
+// validateReplyLanguage 校验智能体的强制回复语言配置
+func validateReplyLanguage(chatAgent *models.ChatAgent) error {
+	if utf8.RuneCountInString(chatAgent.ReplyLanguage) > maxReplyLanguageLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "回复语言长度不能超过%d个字符", maxReplyLanguageLength)
+	}
+	return nil
+}
@@ -0,0 +1,396 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 集群模式下流式回复写入数据库的参数
+const (
+	chatStreamStaleAfter     = time.Minute // 未结束的回复超过该时间没有更新时，视为生成回复的实例已退出
+	chatStreamEventPageSize  = 100         // 续传时每次查询的事件数量
+	chatStreamMaxFlushErrors = 3           // 回复结束后写入数据库连续失败的最多次数，超过后放弃写入
+)
+
+// ChatStreamResumeService 流式回复续传服务
+// 在内存中按请求ID保存流式回复输出的事件，网络中断后客户端可以重新连接并从中断处继续接收，回复不会因连接断开而丢失
+// 集群模式下事件同时定期写入数据库，客户端连接到其他实例时从数据库续传
+type ChatStreamResumeService interface {
+	// Start 开始一次可续传的流式回复
+	// serviceUserID 为发送消息的业务侧用户ID，与回复所属的会话一起登记，续传时校验
+	// send 用于发起回复，传入的上下文不随客户端断开而取消，连接断开后回复继续生成并保存
+	// 返回的流按顺序为每个事件附加 id 字段（从1开始），客户端断开（ctx 取消）后停止输出
+	Start(ctx context.Context, serviceUserID string, send func(ctx context.Context) (io.Reader, error)) (io.Reader, error)
+
+	// Resume 续传流式回复
+	// 输出编号大于 lastEventID 的事件，回复仍在生成时继续输出新的事件直到结束
+	// 请求ID不存在、已过期或不属于当前智能体时返回 NotFound 错误，会话或业务侧用户与登记的不一致时返回 Forbidden 错误
+	Resume(ctx context.Context, conversationID, serviceUserID, requestID string, lastEventID int) (io.Reader, error)
+
+	// CleanupStreams 删除数据库中已过期的流式回复，由后台定时任务调用，未开启集群模式时不做处理
+	CleanupStreams(ctx context.Context) error
+}
+
+// chatStreamResumeService 流式回复续传服务实现
+type chatStreamResumeService struct {
+	mu             sync.Mutex
+	streams        map[string]*chatStream          // 键为智能体ID和请求ID
+	window         time.Duration                   // 回复结束后事件的保存时间，0 表示不保存
+	streamRepo     repository.ChatStreamRepository // 集群模式流式回复数据访问层
+	clusterService ClusterService                  // 集群模式服务
+	flushInterval  time.Duration                   // 集群模式下事件写入数据库和续传查询新事件的间隔
+}
+
+// chatStream 一次流式回复输出的事件
+type chatStream struct {
+	conversationID string        // 回复所属的会话ID，取自第一个事件
+	serviceUserID  string        // 发送消息的业务侧用户ID
+	events         []string      // 事件的 JSON 内容，第 i 个事件的编号为 i+1
+	done           bool          // 回复是否已结束
+	finishedAt     time.Time     // 回复结束时间
+	updated        chan struct{} // 有新事件或回复结束时关闭并替换，用于唤醒等待中的读取
+}
+
+// NewChatStreamResumeService 创建流式回复续传服务
+// 参数：streamRepo - 集群模式流式回复数据访问层，clusterService - 集群模式服务，config - 应用程序配置
+func NewChatStreamResumeService(streamRepo repository.ChatStreamRepository, clusterService ClusterService, config *config.Config) ChatStreamResumeService {
+	flushInterval := time.Duration(config.Cluster.StreamFlushIntervalMilliseconds) * time.Millisecond
+	if flushInterval <= 0 {
+		flushInterval = 500 * time.Millisecond
+	}
+	return &chatStreamResumeService{
+		streams:        make(map[string]*chatStream),
+		window:         time.Duration(config.ChatStream.ResumeWindowSeconds) * time.Second,
+		streamRepo:     streamRepo,
+		clusterService: clusterService,
+		flushInterval:  flushInterval,
+	}
+}
+
+// Start 开始一次可续传的流式回复
+// 未开启续传时直接返回原始的流
+func (s *chatStreamResumeService) Start(ctx context.Context, serviceUserID string, send func(ctx context.Context) (io.Reader, error)) (io.Reader, error) {
+	if s.window <= 0 {
+		return send(ctx)
+	}
+
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := send(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &chatStream{serviceUserID: serviceUserID, updated: make(chan struct{})}
+	go s.record(chatAgent.ID, source, stream)
+	return &chatStreamReader{ctx: ctx, stream: stream, mu: &s.mu}, nil
+}
+
+// Resume 续传流式回复
+// 优先从本实例内存中续传，集群模式下本实例没有该回复时从数据库续传
+// 与会话接口一样，只有回复所属会话的业务侧用户可以续传
+func (s *chatStreamResumeService) Resume(ctx context.Context, conversationID, serviceUserID, requestID string, lastEventID int) (io.Reader, error) {
+	if requestID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "request_id 参数不能为空")
+	}
+	if serviceUserID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "service_user_id 参数不能为空")
+	}
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+	if lastEventID < 0 {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "last_event_id 不能小于0")
+	}
+
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.removeExpired()
+	stream, ok := s.streams[chatStreamKey(chatAgent.ID, requestID)]
+	if ok {
+		if stream.conversationID != convID.String() || stream.serviceUserID != serviceUserID {
+			s.mu.Unlock()
+			return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+		}
+		reader := &chatStreamReader{ctx: ctx, stream: stream, mu: &s.mu, next: min(lastEventID, len(stream.events))}
+		s.mu.Unlock()
+		return reader, nil
+	}
+	s.mu.Unlock()
+
+	if s.clusterService.Enabled() {
+		record, err := s.streamRepo.GetLatest(ctx, chatAgent.ID, requestID)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeInternal, "查询流式回复失败", err)
+		}
+		if record != nil && !(record.Done && record.FinishedAt != nil && time.Since(*record.FinishedAt) > s.window) {
+			if record.ConversationID != convID || record.ServiceUserID != serviceUserID {
+				return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+			}
+			return &chatStreamRecordReader{
+				ctx:           ctx,
+				streamRepo:    s.streamRepo,
+				stream:        record,
+				next:          min(lastEventID, record.EventCount),
+				pollInterval:  s.flushInterval,
+				lastUpdatedAt: record.UpdatedAt,
+			}, nil
+		}
+	}
+	return nil, apperror.New(apperror.CodeNotFound, "流式回复不存在或已过期")
+}
+
+// CleanupStreams 删除数据库中已过期的流式回复
+// 结束时间超过保存时间，或未结束但长时间没有更新（生成回复的实例已退出）的回复会被删除
+func (s *chatStreamResumeService) CleanupStreams(ctx context.Context) error {
+	if !s.clusterService.Enabled() {
+		return nil
+	}
+	now := time.Now()
+	for ctx.Err() == nil {
+		deleted, err := s.streamRepo.DeleteExpired(ctx, now.Add(-s.window), now.Add(-chatStreamStaleAfter), 500)
+		if err != nil {
+			return fmt.Errorf("清理流式回复失败: %w", err)
+		}
+		if deleted == 0 {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// record 读取原始的流并保存事件
+// 事件格式为 "data: <JSON>\n\n"，以第一个事件的请求ID登记，读取结束后标记回复结束
+func (s *chatStreamResumeService) record(chatAgentID uuid.UUID, source io.Reader, stream *chatStream) {
+	defer func() {
+		s.mu.Lock()
+		stream.done = true
+		stream.finishedAt = time.Now()
+		s.notify(stream)
+		s.mu.Unlock()
+	}()
+
+	reader := bufio.NewReader(source)
+	registered := false
+	for {
+		line, err := reader.ReadString('\n')
+		if data, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), "data: "); ok {
+			s.mu.Lock()
+			stream.events = append(stream.events, data)
+			if !registered {
+				var event dto.ChatMessageResponseEventDto
+				if json.Unmarshal([]byte(data), &event) == nil && event.RequestID != "" {
+					// 同一请求ID再次发起时以最新的回复为准
+					stream.conversationID = event.ConversationID
+					s.removeExpired()
+					s.streams[chatStreamKey(chatAgentID, event.RequestID)] = stream
+					registered = true
+					if s.clusterService.Enabled() {
+						go s.persist(chatAgentID, event.RequestID, stream)
+					}
+				}
+			}
+			s.notify(stream)
+			s.mu.Unlock()
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Printf("读取流式回复失败: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// persist 集群模式下定期将回复的事件写入数据库，回复结束并写入全部事件后退出
+// 没有新事件时也更新回复的更新时间，其他实例据此判断生成回复的实例仍在运行
+func (s *chatStreamResumeService) persist(chatAgentID uuid.UUID, requestID string, stream *chatStream) {
+	ctx := context.Background()
+	s.mu.Lock()
+	conversationID, _ := uuid.Parse(stream.conversationID)
+	record := &models.ChatStream{
+		ChatAgentID:    chatAgentID,
+		ConversationID: conversationID,
+		ServiceUserID:  stream.serviceUserID,
+		RequestID:      requestID,
+		InstanceID:     s.clusterService.InstanceID(),
+	}
+	s.mu.Unlock()
+	if err := s.streamRepo.Create(ctx, record); err != nil {
+		log.Printf("保存流式回复失败: request_id=%s err=%v", requestID, err)
+		return
+	}
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	failures := 0
+	for range ticker.C {
+		s.mu.Lock()
+		pending := stream.events[record.EventCount:]
+		done := stream.done
+		finishedAt := stream.finishedAt
+		s.mu.Unlock()
+
+		events := make([]*models.ChatStreamEvent, 0, len(pending))
+		for i, data := range pending {
+			events = append(events, &models.ChatStreamEvent{StreamID: record.ID, Seq: record.EventCount + i + 1, Data: data})
+		}
+		update := *record
+		update.EventCount += len(events)
+		update.Done = done
+		if done {
+			update.FinishedAt = &finishedAt
+		}
+		if err := s.streamRepo.AppendEvents(ctx, &update, events); err != nil {
+			log.Printf("写入流式回复事件失败: request_id=%s err=%v", requestID, err)
+			failures++
+			if done && failures >= chatStreamMaxFlushErrors {
+				return
+			}
+			continue
+		}
+		failures = 0
+		*record = update
+		if done {
+			return
+		}
+	}
+}
+
+// notify 唤醒等待新事件的读取
+// 调用方需持有锁
+func (s *chatStreamResumeService) notify(stream *chatStream) {
+	close(stream.updated)
+	stream.updated = make(chan struct{})
+}
+
+// removeExpired 移除结束时间超过保存时间的回复
+// 调用方需持有锁
+func (s *chatStreamResumeService) removeExpired() {
+	for key, stream := range s.streams {
+		if stream.done && time.Since(stream.finishedAt) > s.window {
+			delete(s.streams, key)
+		}
+	}
+}
+
+// chatStreamKey 生成回复的索引键，不同智能体的请求ID互不影响
+func chatStreamKey(chatAgentID uuid.UUID, requestID string) string {
+	return chatAgentID.String() + "/" + requestID
+}
+
+// chatStreamReader 按顺序读取保存的事件
+// 读完已有事件后等待新的事件，回复结束后返回 io.EOF，上下文取消后返回上下文错误
+type chatStreamReader struct {
+	ctx     context.Context
+	stream  *chatStream
+	mu      *sync.Mutex
+	next    int    // 下一个读取的事件下标
+	pending []byte // 已格式化但未读取完的事件
+}
+
+// Read 读取事件，事件格式为 "id: <编号>\ndata: <JSON>\n\n"
+func (r *chatStreamReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		r.mu.Lock()
+		if r.next < len(r.stream.events) {
+			r.pending = []byte(fmt.Sprintf("id: %d\ndata: %s\n\n", r.next+1, r.stream.events[r.next]))
+			r.next++
+			r.mu.Unlock()
+			break
+		}
+		if r.stream.done {
+			r.mu.Unlock()
+			return 0, io.EOF
+		}
+		updated := r.stream.updated
+		r.mu.Unlock()
+
+		select {
+		case <-updated:
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// chatStreamRecordReader 从数据库按顺序读取其他实例保存的事件
+// 读完已有事件后按间隔查询新的事件，回复结束或生成回复的实例已退出时返回 io.EOF，上下文取消后返回上下文错误
+type chatStreamRecordReader struct {
+	ctx           context.Context
+	streamRepo    repository.ChatStreamRepository
+	stream        *models.ChatStream
+	next          int           // 已读取的最大事件编号
+	pending       []byte        // 已格式化但未读取完的事件
+	pollInterval  time.Duration // 查询新事件的间隔
+	lastUpdatedAt time.Time     // 回复最近一次更新的时间
+}
+
+// Read 读取事件，事件格式与 chatStreamReader 相同
+func (r *chatStreamRecordReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		events, err := r.streamRepo.GetEvents(r.ctx, r.stream.ID, r.next, chatStreamEventPageSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(events) > 0 {
+			var buffer strings.Builder
+			for _, event := range events {
+				fmt.Fprintf(&buffer, "id: %d\ndata: %s\n\n", event.Seq, event.Data)
+				r.next = event.Seq
+			}
+			r.pending = []byte(buffer.String())
+			break
+		}
+
+		if r.stream.Done && r.next >= r.stream.EventCount {
+			return 0, io.EOF
+		}
+		if !r.stream.Done && time.Since(r.lastUpdatedAt) > chatStreamStaleAfter {
+			return 0, io.EOF
+		}
+		select {
+		case <-time.After(r.pollInterval):
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+		stream, err := r.streamRepo.GetByID(r.ctx, r.stream.ID)
+		if err != nil {
+			return 0, err
+		}
+		r.stream = stream
+		r.lastUpdatedAt = stream.UpdatedAt
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
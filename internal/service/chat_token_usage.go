@@ -0,0 +1,80 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/models"
+)
+
+// billingPriceTokenUnit 模型计费价格对应的Token数，价格单位为每百万Token
+const billingPriceTokenUnit = 1000000
+
+// chatTokenUsage 一次模型调用的token用量和费用
+type chatTokenUsage struct {
+	promptTokens     int     // 提示词token数
+	completionTokens int     // 回复token数
+	totalTokens      int     // 总token数
+	cost             float64 // 按模型计费价格计算的费用
+}
+
+// newChatTokenUsage 根据供应商返回的token用量和模型的计费价格计算本次调用的用量和费用
+// 供应商未返回用量时返回 nil
+func newChatTokenUsage(llm *models.ApplicationLlm, usage *al_client.Usage) *chatTokenUsage {
+	if usage == nil || (usage.TotalTokens == 0 && usage.PromptTokens == 0 && usage.CompletionTokens == 0) {
+		return nil
+	}
+	totalTokens := usage.TotalTokens
+	if totalTokens == 0 {
+		totalTokens = usage.PromptTokens + usage.CompletionTokens
+	}
+	return &chatTokenUsage{
+		promptTokens:     usage.PromptTokens,
+		completionTokens: usage.CompletionTokens,
+		totalTokens:      totalTokens,
+		cost: (float64(usage.PromptTokens)*llm.BillingPriceInput +
+			float64(usage.CompletionTokens)*llm.BillingPriceOutput) / billingPriceTokenUnit,
+	}
+}
+
+// apply 将用量和费用记录到消息，保存消息时累加到会话；u 为 nil 时不做处理
+func (u *chatTokenUsage) apply(message *models.ChatAgentMessage) {
+	if u == nil {
+		return
+	}
+	message.PromptTokenCount = u.promptTokens
+	message.CompletionTokenCount = u.completionTokens
+	message.TotalTokenCount = u.totalTokens
+	message.Cost = u.cost
+}
+
+// drainStreamUsage 读取数据流的剩余分块，获取供应商在最后返回的token用量
+// 读取出错或流结束时停止，没有返回用量时为 nil
+func drainStreamUsage(stream al_client.SendMessageStream) *al_client.Usage {
+	var usage *al_client.Usage
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			return usage
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+}
+
+// add 合并两次模型调用的用量和费用，任一方为 nil 时返回另一方
+func (u *chatTokenUsage) add(other *chatTokenUsage) *chatTokenUsage {
+	if u == nil {
+		return other
+	}
+	if other == nil {
+		return u
+	}
+	return &chatTokenUsage{
+		promptTokens:     u.promptTokens + other.promptTokens,
+		completionTokens: u.completionTokens + other.completionTokens,
+		totalTokens:      u.totalTokens + other.totalTokens,
+		cost:             u.cost + other.cost,
+	}
+}
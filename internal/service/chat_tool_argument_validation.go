@@ -0,0 +1,76 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/utils"
+	"log"
+	"strings"
+)
+
+// toolArgumentError 模型提供的工具参数无效
+// 错误信息作为工具结果返回给模型，模型据此修正参数后重新调用
+type toolArgumentError struct {
+	problems []string
+}
+
+// Error 返回提供给模型的错误信息
+func (e *toolArgumentError) Error() string {
+	return "工具参数校验失败，请根据以下错误修正参数后重新调用：\n- " + strings.Join(e.problems, "\n- ")
+}
+
+// validateToolArguments 按工具参数的JSON Schema校验模型提供的参数
+// MCP工具使用同步工具时保存的参数定义，内部工具使用提供给模型的工具定义；没有参数定义时不校验
+func (s *chatAgentConversationService) validateToolArguments(ctx context.Context, toolName string, toolArgs map[string]interface{}) error {
+	schema := s.toolInputSchema(ctx, toolName)
+	if len(schema) == 0 {
+		return nil
+	}
+	// 参数为空对象时同样需要校验必填字段
+	var value interface{} = toolArgs
+	if toolArgs == nil {
+		value = map[string]interface{}{}
+	}
+	if problems := utils.ValidateJSONSchema(schema, value); len(problems) > 0 {
+		return &toolArgumentError{problems: problems}
+	}
+	return nil
+}
+
+// toolInputSchema 获取工具参数的JSON Schema
+// 参数定义无法解析时返回空，不影响工具调用
+func (s *chatAgentConversationService) toolInputSchema(ctx context.Context, toolName string) map[string]interface{} {
+	var data []byte
+	if internalToolName, ok := strings.CutPrefix(toolName, define.ChatInternalToolNamePrefix); ok {
+		var definition map[string]interface{}
+		switch internalToolName {
+		case define.ChatInternalToolTranslate:
+			definition = translateToolDefinition().Function.Parameters
+		case define.ChatInternalToolSetVariable:
+			definition = setVariableToolDefinition().Function.Parameters
+		default:
+			return nil
+		}
+		// 工具定义中的 Go 类型（如 []string）序列化后与解码得到的参数统一
+		var err error
+		if data, err = json.Marshal(definition); err != nil {
+			return nil
+		}
+	} else {
+		tool := s.findMcpServerTool(ctx, toolName)
+		if tool == nil || tool.InputSchema == "" {
+			return nil
+		}
+		data = []byte(tool.InputSchema)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		log.Printf("解析工具参数定义失败: %s, %v", toolName, err)
+		return nil
+	}
+	return schema
+}
@@ -0,0 +1,298 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/manager"
+	"lemon-tree-core/internal/models"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// 工具生成文件的保存限制
+const (
+	maxToolOutputAttachmentSize  = 50 * 1024 * 1024 // 单个文件的最大大小，与上传附件一致
+	maxToolOutputAttachmentCount = 10               // 单次工具调用最多保存的文件数量
+	toolOutputAttachmentTimeout  = 60 * time.Second // 下载或读取单个文件的超时时间
+)
+
+// chatAttachmentDownloadURLPath 聊天附件的下载地址
+const chatAttachmentDownloadURLPath = "/api/v1/chat/attachment-download"
+
+// toolOutputAttachmentHTTPClient 下载工具结果中 http(s) 资源链接使用的客户端
+var toolOutputAttachmentHTTPClient = &http.Client{Timeout: toolOutputAttachmentTimeout}
+
+// toolOutputFile 工具结果中引用的文件
+type toolOutputFile struct {
+	uri      string // 资源URI
+	name     string // 文件名，可能为空
+	mimeType string // MIME类型，可能为空
+	blob     string // 内嵌资源的 base64 内容，资源链接为空
+}
+
+// attachToolOutputFiles 将工具结果引用的文件保存为会话附件
+// 支持MCP资源链接（resource_link）和内嵌的二进制资源（resource），资源链接为 http(s) 地址时直接下载，否则通过MCP服务器读取
+// message 为保存的工具结果消息，为空时附件只关联会话；单个文件保存失败只写日志
+func (s *chatAgentConversationService) attachToolOutputFiles(ctx context.Context, chatAgent *models.ChatAgent, conversationID string, message *models.ChatAgentMessage, toolName, output string) []*dto.ChatToolAttachmentDto {
+	files := parseToolOutputFiles(output)
+	if len(files) == 0 {
+		return nil
+	}
+
+	conversationUUID, _ := uuid.Parse(conversationID)
+	var attachments []*dto.ChatToolAttachmentDto
+	for _, file := range files {
+		data, mimeType, err := s.readToolOutputFile(ctx, toolName, file)
+		if err != nil {
+			log.Printf("获取工具生成的文件失败: %s, %v", file.uri, err)
+			continue
+		}
+
+		attachment, err := s.saveToolOutputAttachment(ctx, chatAgent, conversationUUID, message, file, data, mimeType)
+		if err != nil {
+			log.Printf("保存工具生成的文件失败: %s, %v", file.uri, err)
+			continue
+		}
+		attachments = append(attachments, toolAttachmentDto(attachment))
+	}
+	return attachments
+}
+
+// parseToolOutputFiles 从MCP工具结果中解析引用的文件
+// 工具结果为MCP内容列表的JSON，内部工具的结果或无法解析时返回空
+func parseToolOutputFiles(output string) []toolOutputFile {
+	var contents []map[string]any
+	if err := json.Unmarshal([]byte(output), &contents); err != nil {
+		return nil
+	}
+
+	var files []toolOutputFile
+	for _, contentMap := range contents {
+		if len(files) >= maxToolOutputAttachmentCount {
+			break
+		}
+		content, err := mcp.ParseContent(contentMap)
+		if err != nil {
+			continue
+		}
+		switch content := content.(type) {
+		case mcp.ResourceLink:
+			files = append(files, toolOutputFile{uri: content.URI, name: content.Name, mimeType: content.MIMEType})
+		case mcp.EmbeddedResource:
+			// 文本资源通常是提供给模型阅读的内容，只保存二进制资源
+			if blob, ok := content.Resource.(mcp.BlobResourceContents); ok {
+				files = append(files, toolOutputFile{uri: blob.URI, mimeType: blob.MIMEType, blob: blob.Blob})
+			}
+		}
+	}
+	return files
+}
+
+// readToolOutputFile 获取工具结果引用的文件内容
+// 返回文件内容和MIME类型，超过大小限制时返回错误
+func (s *chatAgentConversationService) readToolOutputFile(ctx context.Context, toolName string, file toolOutputFile) ([]byte, string, error) {
+	if file.blob != "" {
+		data, err := base64.StdEncoding.DecodeString(file.blob)
+		if err != nil {
+			return nil, "", fmt.Errorf("解码内嵌资源失败: %w", err)
+		}
+		if len(data) > maxToolOutputAttachmentSize {
+			return nil, "", fmt.Errorf("文件大小超过限制（最大%dMB）", maxToolOutputAttachmentSize/1024/1024)
+		}
+		return data, file.mimeType, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, toolOutputAttachmentTimeout)
+	defer cancel()
+
+	if strings.HasPrefix(file.uri, "http://") || strings.HasPrefix(file.uri, "https://") {
+		return downloadToolOutputFile(ctx, file)
+	}
+	return s.readMcpResource(ctx, toolName, file)
+}
+
+// downloadToolOutputFile 下载 http(s) 资源链接引用的文件
+func downloadToolOutputFile(ctx context.Context, file toolOutputFile) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.uri, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("创建下载请求失败: %w", err)
+	}
+	resp, err := toolOutputAttachmentHTTPClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("下载文件失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("下载文件失败，状态码: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxToolOutputAttachmentSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("读取文件内容失败: %w", err)
+	}
+	if len(data) > maxToolOutputAttachmentSize {
+		return nil, "", fmt.Errorf("文件大小超过限制（最大%dMB）", maxToolOutputAttachmentSize/1024/1024)
+	}
+
+	mimeType := file.mimeType
+	if mimeType == "" {
+		mimeType, _, _ = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	}
+	return data, mimeType, nil
+}
+
+// readMcpResource 通过生成该文件的MCP服务器读取资源
+// 工具名称格式为 configID_____toolName
+func (s *chatAgentConversationService) readMcpResource(ctx context.Context, toolName string, file toolOutputFile) ([]byte, string, error) {
+	configID, _, ok := strings.Cut(toolName, "_____")
+	if !ok {
+		return nil, "", fmt.Errorf("无效的工具名称格式: %s", toolName)
+	}
+	mcpServerConfig, err := s.mcpConfigRepo.GetByConfigID(ctx, configID)
+	if err != nil {
+		return nil, "", fmt.Errorf("获取MCP配置失败: %w", err)
+	}
+	mcpClient, err := manager.GetMcpClient(ctx, mcpServerConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("创建MCP客户端失败: %w", err)
+	}
+	result, err := mcpClient.ReadResource(ctx, mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: file.uri},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("读取MCP资源失败: %w", err)
+	}
+	if len(result.Contents) == 0 {
+		return nil, "", fmt.Errorf("MCP资源内容为空")
+	}
+
+	var data []byte
+	mimeType := file.mimeType
+	switch contents := result.Contents[0].(type) {
+	case mcp.BlobResourceContents:
+		if data, err = base64.StdEncoding.DecodeString(contents.Blob); err != nil {
+			return nil, "", fmt.Errorf("解码MCP资源失败: %w", err)
+		}
+		if mimeType == "" {
+			mimeType = contents.MIMEType
+		}
+	case mcp.TextResourceContents:
+		data = []byte(contents.Text)
+		if mimeType == "" {
+			mimeType = contents.MIMEType
+		}
+	default:
+		return nil, "", fmt.Errorf("不支持的MCP资源内容")
+	}
+	if len(data) > maxToolOutputAttachmentSize {
+		return nil, "", fmt.Errorf("文件大小超过限制（最大%dMB）", maxToolOutputAttachmentSize/1024/1024)
+	}
+	return data, mimeType, nil
+}
+
+// saveToolOutputAttachment 保存工具生成的文件并创建附件记录
+// 文件名优先使用资源名称，其次使用URI中的文件名；没有扩展名时根据MIME类型推断
+func (s *chatAgentConversationService) saveToolOutputAttachment(ctx context.Context, chatAgent *models.ChatAgent, conversationID uuid.UUID, message *models.ChatAgentMessage, file toolOutputFile, data []byte, mimeType string) (*models.ChatAgentAttachment, error) {
+	filename := toolOutputFileName(file, mimeType)
+	fileExtension := strings.ToLower(path.Ext(filename))
+	if mimeType == "" {
+		mimeType = getMimeType(fileExtension)
+	}
+
+	attachmentID := uuid.New()
+	filePath, err := writeAttachmentFile(attachmentID, fileExtension, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.ChatAgentAttachment{
+		ApplicationID:    chatAgent.ApplicationID,
+		ChatAgentID:      chatAgent.ID,
+		ConversationID:   conversationID,
+		OriginalFileName: filename,
+		FileExtension:    fileExtension,
+		FileSize:         int64(len(data)),
+		MimeType:         mimeType,
+		FilePath:         filePath,
+		AttachmentType:   attachmentTypeOf(fileExtension),
+	}
+	attachment.ID = attachmentID
+	if message != nil {
+		attachment.MessageID = message.ID
+	}
+	// 聊天请求结束后仍需保存记录
+	if err := s.attachmentRepo.Create(context.WithoutCancel(ctx), attachment); err != nil {
+		return nil, fmt.Errorf("保存附件记录失败: %w", err)
+	}
+	return attachment, nil
+}
+
+// toolOutputFileName 确定工具生成文件的文件名
+func toolOutputFileName(file toolOutputFile, mimeType string) string {
+	filename := path.Base(strings.TrimSpace(file.name))
+	if filename == "." || filename == "/" {
+		filename = ""
+	}
+	if filename == "" {
+		if parsed, err := url.Parse(file.uri); err == nil {
+			filename = path.Base(parsed.Path)
+		}
+		if filename == "." || filename == "/" {
+			filename = ""
+		}
+	}
+	if filename == "" {
+		filename = "file"
+	}
+	if path.Ext(filename) == "" && mimeType != "" {
+		if extensions, err := mime.ExtensionsByType(mimeType); err == nil && len(extensions) > 0 {
+			filename += extensions[0]
+		}
+	}
+	if runes := []rune(filename); len(runes) > 256 {
+		filename = string(runes[len(runes)-256:])
+	}
+	return filename
+}
+
+// toolAttachmentDto 生成工具生成文件的附件信息
+func toolAttachmentDto(attachment *models.ChatAgentAttachment) *dto.ChatToolAttachmentDto {
+	return &dto.ChatToolAttachmentDto{
+		ID:             attachment.ID.String(),
+		FileName:       attachment.OriginalFileName,
+		MimeType:       attachment.MimeType,
+		FileSize:       attachment.FileSize,
+		AttachmentType: attachment.AttachmentType,
+		DownloadURL:    chatAttachmentDownloadURLPath + "?attachment_id=" + attachment.ID.String(),
+	}
+}
+
+// writeToolAttachmentEvents 为每个工具生成的文件输出 attachment_created 事件
+func writeToolAttachmentEvents(w io.Writer, conversationID, requestID, toolName string, attachments []*dto.ChatToolAttachmentDto) {
+	for _, attachment := range attachments {
+		event := dto.ChatMessageResponseEventDto{
+			ConversationID: conversationID,
+			RequestID:      requestID,
+			MessageType:    "attachment_created",
+			Content:        toolName,
+			Attachment:     attachment,
+		}
+		eventJSON, _ := json.Marshal(event)
+		w.Write([]byte(fmt.Sprintf("data: %s\n\n", eventJSON)))
+	}
+}
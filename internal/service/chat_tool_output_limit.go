@@ -0,0 +1,106 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 工具结果长度限制
+const (
+	maxToolOutputMaxLength       = 20000            // 智能体可设置的工具结果长度上限，保证截断后的结果可以完整保存
+	maxToolOutputSummaryInput    = 100000           // 交给摘要模型的工具结果最大字符数，超出部分截断后再摘要
+	toolOutputSummaryTimeout     = 60 * time.Second // 摘要工具结果的超时时间
+	toolOutputTruncatedNoticeFmt = "\n\n[工具结果过长，已截断，原始长度 %d 个字符]"
+)
+
+// toolOutputSummaryPrompt 摘要工具结果使用的系统提示词
+const toolOutputSummaryPrompt = "你将看到工具 %s 返回的结果。请在%d个字符以内概括其内容，保留回答用户问题可能用到的关键数据、名称、数字、链接和错误信息，" +
+	"保持原有的语言，只输出摘要，不要添加任何解释。"
+
+// validateToolOutputLimit 校验智能体的工具结果长度限制配置
+func validateToolOutputLimit(chatAgent *models.ChatAgent) error {
+	if chatAgent.ToolOutputMaxLength < 0 || chatAgent.ToolOutputMaxLength > maxToolOutputMaxLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "工具结果长度上限必须在0到%d之间", maxToolOutputMaxLength)
+	}
+	switch chatAgent.ToolOutputOverflowMode {
+	case "", define.ChatToolOutputOverflowModeTruncate, define.ChatToolOutputOverflowModeSummarize:
+		return nil
+	default:
+		return apperror.Newf(apperror.CodeInvalidArgument, "不支持的工具结果超长处理方式: %s", chatAgent.ToolOutputOverflowMode)
+	}
+}
+
+// limitToolOutput 按智能体的配置限制提供给模型的工具结果长度
+// 未超过上限时原样返回；超过时按配置截断或摘要，摘要失败时退回截断
+// 返回提供给模型的结果和是否经过处理，经过处理时调用方应保存原始结果
+func (s *chatAgentConversationService) limitToolOutput(ctx context.Context, chatAgent *models.ChatAgent, toolName, output string) (string, bool) {
+	maxLength := chatAgent.ToolOutputMaxLength
+	if maxLength <= 0 || utf8.RuneCountInString(output) <= maxLength {
+		return output, false
+	}
+
+	if chatAgent.ToolOutputOverflowMode == define.ChatToolOutputOverflowModeSummarize {
+		summary, err := s.summarizeToolOutput(ctx, toolName, output, maxLength)
+		if err == nil && summary != "" {
+			return truncateToolOutput(summary, maxLength), true
+		}
+		log.Printf("摘要工具结果失败，改为截断: %v", err)
+	}
+	return truncateToolOutput(output, maxLength), true
+}
+
+// summarizeToolOutput 使用会话命名模型摘要工具结果
+func (s *chatAgentConversationService) summarizeToolOutput(ctx context.Context, toolName, output string, maxLength int) (string, error) {
+	llmProvider, llm, err := s.getChatAgentNamingLlmConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	aiClient, err := s.createAIClient(ctx, llmProvider)
+	if err != nil {
+		return "", fmt.Errorf("创建AI客户端失败: %w", err)
+	}
+
+	if runes := []rune(output); len(runes) > maxToolOutputSummaryInput {
+		output = string(runes[:maxToolOutputSummaryInput])
+	}
+	summaryCtx, cancel := context.WithTimeout(ctx, toolOutputSummaryTimeout)
+	defer cancel()
+	response, err := aiClient.SendMessage(summaryCtx, al_client.SendMessageRequest{
+		Model: llm.Name,
+		Messages: []al_client.ChatMessage{
+			{Role: "system", Content: fmt.Sprintf(toolOutputSummaryPrompt, toolName, maxLength)},
+			{Role: "user", Content: output},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("模型未返回结果")
+	}
+	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+}
+
+// truncateToolOutput 截断工具结果并注明原始长度，截断后（含说明）不超过 maxLength 个字符
+func truncateToolOutput(output string, maxLength int) string {
+	runes := []rune(output)
+	if len(runes) <= maxLength {
+		return output
+	}
+	notice := fmt.Sprintf(toolOutputTruncatedNoticeFmt, len(runes))
+	keep := maxLength - utf8.RuneCountInString(notice)
+	if keep <= 0 {
+		return string(runes[:maxLength])
+	}
+	return string(runes[:keep]) + notice
+}
@@ -0,0 +1,240 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxAllowedOriginCount 智能体允许嵌入聊天窗口的来源数量上限
+const maxAllowedOriginCount = 50
+
+// ChatWidgetToken 新创建的嵌入式聊天窗口令牌
+type ChatWidgetToken struct {
+	Token         string    // 窗口令牌，只在创建时返回
+	ServiceUserID string    // 绑定的业务侧用户ID
+	Origin        string    // 允许访问的来源
+	ExpiresAt     time.Time // 过期时间
+}
+
+// ChatWidgetTokenService 嵌入式聊天窗口令牌 业务逻辑层接口
+// 业务服务端使用长期有效的 ApiKey 换取短期的窗口令牌交给浏览器，避免 ApiKey 暴露在网页中
+type ChatWidgetTokenService interface {
+	// CreateToken 为上下文中的智能体创建绑定业务侧用户的窗口令牌
+	// 参数：serviceUserID - 令牌绑定的业务侧用户ID，令牌只能访问该用户的数据；origin - 允许访问的来源，如 https://www.example.com；ttlMinutes - 有效期（分钟），0 时使用默认值，不能超过配置的有效期
+	CreateToken(ctx context.Context, serviceUserID, origin string, ttlMinutes int) (*ChatWidgetToken, error)
+
+	// Authenticate 校验窗口令牌和请求来源，返回窗口令牌记录和令牌所属的智能体
+	// 参数：origin - 请求的来源，取自 Origin 请求头，缺少时取自 Referer 请求头
+	Authenticate(ctx context.Context, token, origin string) (*models.ChatAgentWidgetToken, *models.ChatAgent, error)
+
+	// CreateCSRFToken 签发与窗口令牌绑定的 CSRF 令牌
+	// 窗口发起修改数据的请求时通过请求头回传，服务端重新计算校验，不依赖 Cookie
+	CreateCSRFToken(widgetToken *models.ChatAgentWidgetToken) (string, error)
+
+	// VerifyCSRFToken 校验 CSRF 令牌是否由该窗口令牌签发
+	VerifyCSRFToken(widgetToken *models.ChatAgentWidgetToken, csrfToken string) bool
+
+	// CleanupExpiredTokens 删除已过期的窗口令牌，由后台定时任务调用
+	CleanupExpiredTokens(ctx context.Context) error
+}
+
+// chatWidgetTokenService 嵌入式聊天窗口令牌 业务逻辑层实现
+type chatWidgetTokenService struct {
+	tokenRepo     repository.ChatAgentWidgetTokenRepository // 窗口令牌数据访问层
+	chatAgentRepo repository.ChatAgentRepository            // 智能体数据访问层
+	config        config.ChatWidgetConfig                   // 嵌入式聊天窗口配置
+}
+
+// NewChatWidgetTokenService 创建 嵌入式聊天窗口令牌 服务实例
+// 返回 ChatWidgetTokenService 接口的实现
+func NewChatWidgetTokenService(tokenRepo repository.ChatAgentWidgetTokenRepository, chatAgentRepo repository.ChatAgentRepository, config *config.Config) ChatWidgetTokenService {
+	return &chatWidgetTokenService{
+		tokenRepo:     tokenRepo,
+		chatAgentRepo: chatAgentRepo,
+		config:        config.ChatWidget,
+	}
+}
+
+// CreateToken 为上下文中的智能体创建绑定业务侧用户的窗口令牌
+func (s *chatWidgetTokenService) CreateToken(ctx context.Context, serviceUserID, origin string, ttlMinutes int) (*ChatWidgetToken, error) {
+	application, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if serviceUserID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
+	}
+	origin, err = normalizeWidgetOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+	if !isOriginAllowed(chatAgent, origin) {
+		return nil, apperror.Newf(apperror.CodeForbidden, "来源 %s 不在智能体允许嵌入聊天窗口的来源列表中", origin)
+	}
+	if ttlMinutes < 0 || ttlMinutes > s.config.TokenTTLMinutes {
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "窗口令牌有效期必须在1到%d分钟之间", s.config.TokenTTLMinutes)
+	}
+	if ttlMinutes == 0 {
+		ttlMinutes = s.config.TokenTTLMinutes
+	}
+
+	token, err := generateRandomToken()
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "生成窗口令牌失败", err)
+	}
+	csrfKey, err := generateRandomToken()
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "生成CSRF密钥失败", err)
+	}
+	widgetToken := &models.ChatAgentWidgetToken{
+		ApplicationID: application.ID,
+		ChatAgentID:   chatAgent.ID,
+		ServiceUserID: serviceUserID,
+		Origin:        origin,
+		TokenHash:     hashToken(token),
+		CSRFKey:       csrfKey,
+		ExpiredAt:     time.Now().Add(time.Duration(ttlMinutes) * time.Minute),
+	}
+	if err := s.tokenRepo.Create(ctx, widgetToken); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "保存窗口令牌失败", err)
+	}
+	return &ChatWidgetToken{Token: token, ServiceUserID: serviceUserID, Origin: origin, ExpiresAt: widgetToken.ExpiredAt}, nil
+}
+
+// Authenticate 校验窗口令牌和请求来源
+// 来源缺少、与令牌的来源不一致或已从智能体的来源列表中移除时拒绝
+// 升级前创建的窗口令牌没有绑定业务侧用户，需要重新获取窗口令牌
+func (s *chatWidgetTokenService) Authenticate(ctx context.Context, token, origin string) (*models.ChatAgentWidgetToken, *models.ChatAgent, error) {
+	widgetToken, err := s.tokenRepo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInternal, "查询窗口令牌失败", err)
+	}
+	if widgetToken == nil || time.Now().After(widgetToken.ExpiredAt) {
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "窗口令牌无效或已过期")
+	}
+	if widgetToken.ServiceUserID == "" {
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "窗口令牌未绑定业务侧用户，请重新获取窗口令牌")
+	}
+	if origin == "" || !strings.EqualFold(origin, widgetToken.Origin) {
+		return nil, nil, apperror.New(apperror.CodeForbidden, "窗口令牌不允许从当前来源访问")
+	}
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, widgetToken.ChatAgentID)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeUnauthorized, "窗口令牌所属的智能体不存在", err)
+	}
+	if !isOriginAllowed(chatAgent, widgetToken.Origin) {
+		return nil, nil, apperror.New(apperror.CodeForbidden, "窗口令牌的来源已不在智能体允许的来源列表中")
+	}
+	return widgetToken, chatAgent, nil
+}
+
+// CreateCSRFToken 签发与窗口令牌绑定的 CSRF 令牌
+// CSRF 令牌是以窗口令牌的 CSRF 密钥对令牌ID计算的 HMAC，升级前创建的窗口令牌没有密钥，需要重新获取窗口令牌
+func (s *chatWidgetTokenService) CreateCSRFToken(widgetToken *models.ChatAgentWidgetToken) (string, error) {
+	if widgetToken.CSRFKey == "" {
+		return "", apperror.New(apperror.CodeUnauthorized, "窗口令牌不支持CSRF校验，请重新获取窗口令牌")
+	}
+	return signCSRFToken(widgetToken), nil
+}
+
+// VerifyCSRFToken 校验 CSRF 令牌是否由该窗口令牌签发
+func (s *chatWidgetTokenService) VerifyCSRFToken(widgetToken *models.ChatAgentWidgetToken, csrfToken string) bool {
+	if widgetToken.CSRFKey == "" || csrfToken == "" {
+		return false
+	}
+	return hmac.Equal([]byte(csrfToken), []byte(signCSRFToken(widgetToken)))
+}
+
+// signCSRFToken 以窗口令牌的 CSRF 密钥对令牌ID计算 HMAC-SHA256
+func signCSRFToken(widgetToken *models.ChatAgentWidgetToken) string {
+	mac := hmac.New(sha256.New, []byte(widgetToken.CSRFKey))
+	mac.Write([]byte(widgetToken.ID.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CleanupExpiredTokens 删除已过期的窗口令牌
+func (s *chatWidgetTokenService) CleanupExpiredTokens(ctx context.Context) error {
+	if _, err := s.tokenRepo.DeleteExpired(ctx); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "清理过期窗口令牌失败", err)
+	}
+	return nil
+}
+
+// normalizeWidgetOrigin 校验并规范化窗口令牌允许访问的来源
+// 来源只包含协议、主机和端口，与浏览器发送的 Origin 请求头格式一致
+func normalizeWidgetOrigin(origin string) (string, error) {
+	origin = strings.TrimSpace(origin)
+	if origin == "" {
+		return "", apperror.New(apperror.CodeInvalidArgument, "允许访问的来源不能为空")
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		parsed.User != nil || (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", apperror.New(apperror.CodeInvalidArgument, "允许访问的来源必须是 http 或 https 协议加主机名，如 https://www.example.com")
+	}
+	return strings.ToLower(parsed.Scheme + "://" + parsed.Host), nil
+}
+
+// normalizeAllowedOrigins 校验并规范化智能体允许嵌入聊天窗口的来源列表，去除重复的来源
+func normalizeAllowedOrigins(agent *models.ChatAgent) error {
+	origins, err := parseAllowedOrigins(agent.AllowedOrigins)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "允许的来源列表格式错误", err)
+	}
+	if len(origins) == 0 {
+		agent.AllowedOrigins = ""
+		return nil
+	}
+	if len(origins) > maxAllowedOriginCount {
+		return apperror.Newf(apperror.CodeInvalidArgument, "允许的来源不能超过%d个", maxAllowedOriginCount)
+	}
+	normalized := make([]string, 0, len(origins))
+	for _, origin := range origins {
+		origin, err := normalizeWidgetOrigin(origin)
+		if err != nil {
+			return err
+		}
+		if !containsString(normalized, origin) {
+			normalized = append(normalized, origin)
+		}
+	}
+	content, err := json.Marshal(normalized)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "序列化允许的来源列表失败", err)
+	}
+	agent.AllowedOrigins = string(content)
+	return nil
+}
+
+// parseAllowedOrigins 解析智能体允许嵌入聊天窗口的来源列表
+func parseAllowedOrigins(allowedOrigins string) ([]string, error) {
+	origins := []string{}
+	if allowedOrigins == "" {
+		return origins, nil
+	}
+	if err := json.Unmarshal([]byte(allowedOrigins), &origins); err != nil {
+		return nil, err
+	}
+	return origins, nil
+}
+
+// isOriginAllowed 来源是否在智能体允许嵌入聊天窗口的来源列表中，列表保存时已规范化
+func isOriginAllowed(agent *models.ChatAgent, origin string) bool {
+	origins, err := parseAllowedOrigins(agent.AllowedOrigins)
+	if err != nil {
+		return false
+	}
+	return containsString(origins, strings.ToLower(origin))
+}
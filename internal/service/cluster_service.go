@@ -0,0 +1,139 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultClusterLockTTL 未配置租约时间时使用的分布式锁租约时间
+const defaultClusterLockTTL = time.Minute
+
+// ClusterService 集群模式 业务逻辑层接口
+// 提供当前实例的标识和基于数据库的分布式锁，多个实例共用同一个数据库时协调后台任务等只能在一个实例上执行的处理
+type ClusterService interface {
+	// Enabled 是否开启集群模式
+	Enabled() bool
+
+	// InstanceID 当前实例ID
+	InstanceID() string
+
+	// TryLock 尝试获取分布式锁，锁已被其他实例持有时返回 false
+	// 获取成功后在后台按租约时间的三分之一续约，锁被其他实例接管或超过租约时间未能续约时取消返回的上下文
+	// 返回的 unlock 停止续约并释放锁，可重复调用；未开启集群模式时总是获取成功
+	TryLock(ctx context.Context, name string) (lockCtx context.Context, unlock func(), acquired bool, err error)
+}
+
+// clusterService 集群模式 业务逻辑层实现
+type clusterService struct {
+	lockRepo   repository.ClusterLockRepository // 集群分布式锁数据访问层
+	enabled    bool                             // 是否开启集群模式
+	instanceID string                           // 当前实例ID
+	ttl        time.Duration                    // 分布式锁的租约时间
+}
+
+// NewClusterService 创建 集群模式 服务实例
+// 未配置实例ID时使用主机名和随机后缀，同一主机上的多个进程也不会重复
+// 返回 ClusterService 接口的实现
+func NewClusterService(lockRepo repository.ClusterLockRepository, config *config.Config) ClusterService {
+	instanceID := config.Cluster.InstanceID
+	if instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "instance"
+		}
+		suffix := make([]byte, 4)
+		rand.Read(suffix)
+		instanceID = truncateRunes(hostname, 100) + "-" + hex.EncodeToString(suffix)
+	}
+	ttl := time.Duration(config.Cluster.LockTTLSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = defaultClusterLockTTL
+	}
+	return &clusterService{
+		lockRepo:   lockRepo,
+		enabled:    config.Cluster.Enabled,
+		instanceID: instanceID,
+		ttl:        ttl,
+	}
+}
+
+// Enabled 是否开启集群模式
+func (s *clusterService) Enabled() bool {
+	return s.enabled
+}
+
+// InstanceID 当前实例ID
+func (s *clusterService) InstanceID() string {
+	return s.instanceID
+}
+
+// TryLock 尝试获取分布式锁
+func (s *clusterService) TryLock(ctx context.Context, name string) (context.Context, func(), bool, error) {
+	if !s.enabled {
+		return ctx, func() {}, true, nil
+	}
+	acquired, err := s.lockRepo.TryAcquire(ctx, name, s.instanceID, time.Now().Add(s.ttl))
+	if err != nil || !acquired {
+		return nil, nil, false, err
+	}
+
+	lockCtx, cancel := context.WithCancel(ctx)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.keepAlive(context.WithoutCancel(ctx), name, stop, cancel)
+	}()
+
+	var once sync.Once
+	unlock := func() {
+		once.Do(func() {
+			close(stop)
+			wg.Wait()
+			cancel()
+			// 上下文已取消时仍需释放，其他实例不必等待租约到期
+			if err := s.lockRepo.Release(context.WithoutCancel(ctx), name, s.instanceID); err != nil {
+				log.Printf("释放分布式锁失败: name=%s err=%v", name, err)
+			}
+		})
+	}
+	return lockCtx, unlock, true, nil
+}
+
+// keepAlive 定期续约锁，直到 stop 关闭
+// 锁被其他实例接管或超过租约时间未能续约时调用 lost 并停止续约
+func (s *clusterService) keepAlive(ctx context.Context, name string, stop <-chan struct{}, lost context.CancelFunc) {
+	ticker := time.NewTicker(s.ttl / 3)
+	defer ticker.Stop()
+	renewedAt := time.Now()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		renewed, err := s.lockRepo.Renew(ctx, name, s.instanceID, time.Now().Add(s.ttl))
+		if err != nil {
+			log.Printf("续约分布式锁失败: name=%s err=%v", name, err)
+			if time.Since(renewedAt) < s.ttl {
+				continue
+			}
+		} else if renewed {
+			renewedAt = time.Now()
+			continue
+		}
+		log.Printf("分布式锁已失效: name=%s instance_id=%s", name, s.instanceID)
+		lost()
+		return
+	}
+}
@@ -0,0 +1,49 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/dto"
+	"log"
+	"time"
+)
+
+// ConfigReloadService 配置重新加载 业务逻辑层接口
+// 不重启服务、不中断进行中的流式回复，更新日志级别、跨域、模型提供商并发限制和功能开关等非结构性配置
+type ConfigReloadService interface {
+	// ReloadConfig 重新读取 .env 文件并通知各组件更新配置
+	// 集群模式下只对当前实例生效，需要在每个实例上分别执行
+	ReloadConfig(ctx context.Context) (*dto.ConfigReloadDto, error)
+}
+
+// configReloadService 配置重新加载 业务逻辑层实现
+type configReloadService struct {
+	reloader *config.Reloader // 配置重新加载器
+}
+
+// NewConfigReloadService 创建 配置重新加载 服务实例
+// 返回 ConfigReloadService 接口的实现
+func NewConfigReloadService(reloader *config.Reloader) ConfigReloadService {
+	return &configReloadService{reloader: reloader}
+}
+
+// ReloadConfig 重新读取 .env 文件并通知各组件更新配置
+// 新配置无效时保持原有配置不变，返回参数错误
+func (s *configReloadService) ReloadConfig(ctx context.Context) (*dto.ConfigReloadDto, error) {
+	newConfig, err := s.reloader.Reload()
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "重新加载配置失败", err)
+	}
+	log.Printf("配置已重新加载: log_level=%s cors_allowed_origins=%v llm_default_max_concurrency=%d llm_queue_timeout_seconds=%d",
+		newConfig.Log.Level, newConfig.CORS.AllowedOrigins, newConfig.LlmLimiter.DefaultMaxConcurrency, newConfig.LlmLimiter.QueueTimeoutSeconds)
+	return &dto.ConfigReloadDto{
+		LogLevel:                 newConfig.Log.Level,
+		CorsAllowedOrigins:       newConfig.CORS.AllowedOrigins,
+		LlmDefaultMaxConcurrency: newConfig.LlmLimiter.DefaultMaxConcurrency,
+		LlmQueueTimeoutSeconds:   newConfig.LlmLimiter.QueueTimeoutSeconds,
+		ReloadedAt:               time.Now().Unix(),
+	}, nil
+}
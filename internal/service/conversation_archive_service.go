@@ -0,0 +1,359 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/objectstore"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 归档文件格式
+const (
+	conversationArchiveVersion     = 1                       // 归档文件格式版本，写在会话记录行中
+	conversationArchiveKeyPrefix   = "conversation-archives" // 归档文件对象键的前缀
+	conversationArchiveDefaultSize = 100                     // 未配置时每批归档的会话数量
+)
+
+// 归档文件中每一行记录的类型
+const (
+	conversationArchiveRecordConversation = "conversation" // 会话，固定为第一行
+	conversationArchiveRecordMessage      = "message"      // 消息，按创建时间正序
+	conversationArchiveRecordVariable     = "variable"     // 会话变量
+)
+
+// ConversationArchiveService 会话归档 业务逻辑层接口
+// 超过应用数据保留天数的会话导出为 JSONL 文件写入应用的存储后从数据库删除，管理后台可以按需恢复
+type ConversationArchiveService interface {
+	// ArchiveConversations 归档各应用超过数据保留天数的会话，由后台定时任务调用
+	ArchiveConversations(ctx context.Context) error
+
+	// GetArchives 分页获取智能体的归档记录，serviceUserID、status 为空时不筛选
+	GetArchives(ctx context.Context, chatAgentID uuid.UUID, serviceUserID, status string, page, pageSize int) ([]*models.ConversationArchive, int64, error)
+
+	// GetArchive 获取归档记录
+	GetArchive(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error)
+
+	// RestoreArchive 从归档文件恢复会话及其消息和变量，归档文件保留，会话重新计算保留时间
+	RestoreArchive(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error)
+}
+
+// conversationArchiveRecord 归档文件中的一行记录
+type conversationArchiveRecord struct {
+	Type    string          `json:"type"`              // 记录类型：conversation、message、variable
+	Version int             `json:"version,omitempty"` // 归档文件格式版本，只在会话记录中出现
+	Data    json.RawMessage `json:"data"`              // 会话、消息或会话变量，与数据库模型的 JSON 格式一致
+}
+
+// conversationArchiveService 会话归档 业务逻辑层实现
+type conversationArchiveService struct {
+	archiveRepo       repository.ConversationArchiveRepository           // 会话归档记录数据访问层
+	conversationRepo  repository.ChatAgentConversationRepository         // 会话数据访问层
+	messageRepo       repository.ChatAgentMessageRepository              // 消息数据访问层
+	variableRepo      repository.ChatAgentConversationVariableRepository // 会话变量数据访问层
+	settingRepo       repository.ApplicationSettingRepository            // 应用设置数据访问层，读取数据保留天数
+	storageConfigRepo repository.ApplicationStorageConfigRepository      // 应用存储配置数据访问层
+	batchSize         int                                                // 每批归档的会话数量
+}
+
+// NewConversationArchiveService 创建 会话归档 服务实例
+// 返回 ConversationArchiveService 接口的实现
+func NewConversationArchiveService(
+	archiveRepo repository.ConversationArchiveRepository,
+	conversationRepo repository.ChatAgentConversationRepository,
+	messageRepo repository.ChatAgentMessageRepository,
+	variableRepo repository.ChatAgentConversationVariableRepository,
+	settingRepo repository.ApplicationSettingRepository,
+	storageConfigRepo repository.ApplicationStorageConfigRepository,
+	config *config.Config,
+) ConversationArchiveService {
+	batchSize := config.Archive.BatchSize
+	if batchSize <= 0 {
+		batchSize = conversationArchiveDefaultSize
+	}
+	return &conversationArchiveService{
+		archiveRepo:       archiveRepo,
+		conversationRepo:  conversationRepo,
+		messageRepo:       messageRepo,
+		variableRepo:      variableRepo,
+		settingRepo:       settingRepo,
+		storageConfigRepo: storageConfigRepo,
+		batchSize:         batchSize,
+	}
+}
+
+// ArchiveConversations 归档各应用超过数据保留天数的会话
+// 未配置存储的应用跳过；单个会话归档失败时记录日志并继续，下次执行时重试
+func (s *conversationArchiveService) ArchiveConversations(ctx context.Context) error {
+	settings, err := s.settingRepo.GetWithDataRetention(ctx)
+	if err != nil {
+		return fmt.Errorf("查询应用数据保留设置失败: %w", err)
+	}
+	for _, setting := range settings {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		archived, err := s.archiveApplication(ctx, setting)
+		if err != nil {
+			log.Printf("归档应用会话失败: application_id=%s err=%v", setting.ApplicationID, err)
+			continue
+		}
+		if archived > 0 {
+			log.Printf("已归档应用会话: application_id=%s count=%d", setting.ApplicationID, archived)
+		}
+	}
+	return nil
+}
+
+// archiveApplication 分批归档一个应用超过数据保留天数的会话，直到没有需要归档的会话或一批都没有归档成功
+// 返回：归档的会话数量和错误信息
+func (s *conversationArchiveService) archiveApplication(ctx context.Context, setting *models.ApplicationSetting) (int, error) {
+	storageConfig, err := s.storageConfigRepo.GetByApplicationID(ctx, setting.ApplicationID)
+	if err != nil {
+		return 0, fmt.Errorf("查询应用存储配置失败: %w", err)
+	}
+	if storageConfig == nil {
+		return 0, nil
+	}
+	store, err := objectstore.New(storageConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	before := time.Now().AddDate(0, 0, -setting.DataRetentionDays)
+	total := 0
+	for ctx.Err() == nil {
+		conversations, err := s.conversationRepo.GetInactiveBefore(ctx, setting.ApplicationID, before, s.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("查询需要归档的会话失败: %w", err)
+		}
+		archived := 0
+		for _, conversation := range conversations {
+			ok, err := s.archiveConversation(ctx, store, storageConfig.Type, conversation)
+			if err != nil {
+				log.Printf("归档会话失败: conversation_id=%s err=%v", conversation.ID, err)
+				continue
+			}
+			if ok {
+				archived++
+			}
+		}
+		total += archived
+		if len(conversations) < s.batchSize || archived == 0 {
+			break
+		}
+	}
+	return total, nil
+}
+
+// archiveConversation 导出会话及其消息和变量写入存储，然后从数据库删除
+// 写入存储后会话被修改时不删除，已写入的归档文件在下次归档时覆盖
+// 返回：是否已归档和错误信息
+func (s *conversationArchiveService) archiveConversation(ctx context.Context, store objectstore.Store, storageType string, conversation *models.ChatAgentConversation) (bool, error) {
+	messages, err := s.messageRepo.GetAllByConversationID(ctx, conversation.ID)
+	if err != nil {
+		return false, fmt.Errorf("查询会话消息失败: %w", err)
+	}
+	variables, err := s.variableRepo.ListByConversationID(ctx, conversation.ID)
+	if err != nil {
+		return false, fmt.Errorf("查询会话变量失败: %w", err)
+	}
+	content, err := encodeConversationArchive(conversation, messages, variables)
+	if err != nil {
+		return false, err
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s.jsonl", conversationArchiveKeyPrefix, conversation.ApplicationID, conversation.ChatAgentID, conversation.ID)
+	if err := store.Put(ctx, key, content); err != nil {
+		return false, fmt.Errorf("写入归档文件失败: %w", err)
+	}
+
+	archive, err := s.archiveRepo.GetByConversationID(ctx, conversation.ID)
+	if err != nil {
+		return false, fmt.Errorf("查询归档记录失败: %w", err)
+	}
+	if archive == nil {
+		archive = &models.ConversationArchive{ConversationID: conversation.ID}
+	}
+	archive.ApplicationID = conversation.ApplicationID
+	archive.ChatAgentID = conversation.ChatAgentID
+	archive.ServiceUserID = conversation.ServiceUserID
+	archive.Title = conversation.Title
+	archive.MessageCount = len(messages)
+	archive.ConversationCreatedAt = conversation.CreatedAt
+	archive.LastMessageAt = conversation.LastMessageAt
+	archive.StorageType = storageType
+	archive.ObjectKey = key
+	archive.Status = define.ConversationArchiveStatusArchived
+	archive.ArchivedAt = time.Now()
+
+	return s.archiveRepo.ArchiveConversation(ctx, archive, conversation, len(messages))
+}
+
+// GetArchives 分页获取智能体的归档记录
+func (s *conversationArchiveService) GetArchives(ctx context.Context, chatAgentID uuid.UUID, serviceUserID, status string, page, pageSize int) ([]*models.ConversationArchive, int64, error) {
+	if status != "" && status != define.ConversationArchiveStatusArchived && status != define.ConversationArchiveStatusRestored {
+		return nil, 0, apperror.Newf(apperror.CodeInvalidArgument, "不支持的归档状态: %s", status)
+	}
+	return s.archiveRepo.GetByChatAgentIDWithPagination(ctx, chatAgentID, serviceUserID, status, page, pageSize)
+}
+
+// GetArchive 获取归档记录
+func (s *conversationArchiveService) GetArchive(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error) {
+	archive, err := s.archiveRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.New(apperror.CodeNotFound, "归档记录不存在")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// RestoreArchive 从归档文件恢复会话及其消息和变量
+// 会话的更新时间设置为恢复时间，保留天数内不会再次归档；会话上登记的处理中请求清空
+func (s *conversationArchiveService) RestoreArchive(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error) {
+	archive, err := s.GetArchive(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if archive.Status != define.ConversationArchiveStatusArchived {
+		return nil, apperror.New(apperror.CodeConflict, "会话已恢复")
+	}
+
+	storageConfig, err := s.storageConfigRepo.GetByApplicationID(ctx, archive.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("查询应用存储配置失败: %w", err)
+	}
+	if storageConfig == nil {
+		return nil, apperror.New(apperror.CodeConflict, "应用未配置存储，无法读取归档文件")
+	}
+	if storageConfig.Type != archive.StorageType {
+		return nil, apperror.Newf(apperror.CodeConflict, "应用的存储类型已由 %s 变更为 %s，无法读取归档文件", archive.StorageType, storageConfig.Type)
+	}
+	store, err := objectstore.New(storageConfig)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeConflict, "应用的存储配置无效", err)
+	}
+	content, err := store.Get(ctx, archive.ObjectKey)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, apperror.New(apperror.CodeNotFound, "归档文件不存在")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取归档文件失败: %w", err)
+	}
+
+	conversation, messages, variables, err := decodeConversationArchive(content)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.ID != archive.ConversationID {
+		return nil, fmt.Errorf("归档文件中的会话ID %s 与归档记录不一致", conversation.ID)
+	}
+	conversation.ActiveRequestID = ""
+	conversation.ActiveRequestAt = nil
+	conversation.UpdatedAt = time.Now()
+
+	restored, err := s.archiveRepo.RestoreConversation(ctx, archive, define.ConversationArchiveStatusArchived, define.ConversationArchiveStatusRestored, conversation, messages, variables)
+	if err != nil {
+		return nil, fmt.Errorf("恢复会话失败: %w", err)
+	}
+	if !restored {
+		return nil, apperror.New(apperror.CodeConflict, "会话已恢复")
+	}
+	return archive, nil
+}
+
+// encodeConversationArchive 将会话及其消息和变量编码为 JSONL，第一行为会话
+func encodeConversationArchive(conversation *models.ChatAgentConversation, messages []*models.ChatAgentMessage, variables []*models.ChatAgentConversationVariable) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	write := func(recordType string, version int, value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("序列化归档记录失败: %w", err)
+		}
+		return encoder.Encode(conversationArchiveRecord{Type: recordType, Version: version, Data: data})
+	}
+
+	if err := write(conversationArchiveRecordConversation, conversationArchiveVersion, conversation); err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		if err := write(conversationArchiveRecordMessage, 0, message); err != nil {
+			return nil, err
+		}
+	}
+	for _, variable := range variables {
+		if err := write(conversationArchiveRecordVariable, 0, variable); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeConversationArchive 解析 encodeConversationArchive 编码的 JSONL
+// 不认识的记录类型忽略，兼容以后新增的记录类型
+func decodeConversationArchive(content []byte) (*models.ChatAgentConversation, []*models.ChatAgentMessage, []*models.ChatAgentConversationVariable, error) {
+	var conversation *models.ChatAgentConversation
+	var messages []*models.ChatAgentMessage
+	var variables []*models.ChatAgentConversationVariable
+
+	reader := bufio.NewReader(bytes.NewReader(content))
+	for lineNumber := 1; ; lineNumber++ {
+		line, readErr := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var record conversationArchiveRecord
+			err := json.Unmarshal(line, &record)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("解析归档文件第%d行失败: %w", lineNumber, err)
+			}
+			switch record.Type {
+			case conversationArchiveRecordConversation:
+				if record.Version > conversationArchiveVersion {
+					return nil, nil, nil, fmt.Errorf("不支持的归档文件格式版本: %d", record.Version)
+				}
+				conversation = &models.ChatAgentConversation{}
+				err = json.Unmarshal(record.Data, conversation)
+			case conversationArchiveRecordMessage:
+				message := &models.ChatAgentMessage{}
+				messages = append(messages, message)
+				err = json.Unmarshal(record.Data, message)
+			case conversationArchiveRecordVariable:
+				variable := &models.ChatAgentConversationVariable{}
+				variables = append(variables, variable)
+				err = json.Unmarshal(record.Data, variable)
+			}
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("解析归档文件第%d行失败: %w", lineNumber, err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, nil, nil, fmt.Errorf("读取归档文件失败: %w", readErr)
+		}
+	}
+
+	if conversation == nil {
+		return nil, nil, nil, fmt.Errorf("归档文件中没有会话记录")
+	}
+	return conversation, messages, variables, nil
+}
@@ -0,0 +1,400 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/broker"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook 推送的重试间隔和并发数
+const (
+	webhookRetryBaseDelay     = 30 * time.Second // 首次重试的退避间隔，之后每次翻倍
+	webhookRetryMaxDelay      = time.Hour        // 最长退避间隔
+	webhookDeliveryWorkers    = 8                // 同时推送的请求数量
+	maxWebhookErrorLength     = 512              // 推送失败原因的长度上限，与数据库字段长度一致
+	maxWebhookResponsePreview = 256              // 推送失败时记录的响应内容长度
+)
+
+// ConversationEventService 会话事件推送 业务逻辑层接口
+// 会话事件由会话和消息的数据访问层在同一事务中写入发件箱，这里负责推送到应用订阅的 Webhook 和发布到消息队列
+type ConversationEventService interface {
+	// ProcessEvents 发布未发布的事件到消息队列，为待处理的事件生成推送记录，并推送到期的推送记录，由后台定时任务调用
+	ProcessEvents(ctx context.Context) error
+
+	// CleanupEvents 删除超过保留天数的事件和已结束的推送记录，由后台定时任务调用
+	CleanupEvents(ctx context.Context) error
+}
+
+// conversationEventService 会话事件推送 业务逻辑层实现
+type conversationEventService struct {
+	eventRepo    repository.ConversationEventRepository          // 会话事件发件箱数据访问层
+	webhookRepo  repository.ApplicationWebhookRepository         // 应用 Webhook 数据访问层
+	deliveryRepo repository.ApplicationWebhookDeliveryRepository // Webhook 推送记录数据访问层
+	publisher    broker.Publisher                                // 消息队列发布器
+	config       config.EventOutboxConfig                        // 会话事件推送配置
+	httpClient   *http.Client                                    // 推送请求使用的 HTTP 客户端
+}
+
+// NewConversationEventService 创建 会话事件推送 服务实例
+// 返回 ConversationEventService 接口的实现
+func NewConversationEventService(
+	eventRepo repository.ConversationEventRepository,
+	webhookRepo repository.ApplicationWebhookRepository,
+	deliveryRepo repository.ApplicationWebhookDeliveryRepository,
+	publisher broker.Publisher,
+	config *config.Config,
+) ConversationEventService {
+	return &conversationEventService{
+		eventRepo:    eventRepo,
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		publisher:    publisher,
+		config:       config.EventOutbox,
+		httpClient:   &http.Client{Timeout: time.Duration(config.EventOutbox.RequestTimeoutSeconds) * time.Second},
+	}
+}
+
+// ProcessEvents 发布未发布的事件到消息队列，为待处理的事件生成推送记录，并推送到期的推送记录
+// 消息队列不可用时只记录日志，不影响 Webhook 推送
+func (s *conversationEventService) ProcessEvents(ctx context.Context) error {
+	if err := s.publishEvents(ctx); err != nil {
+		log.Printf("发布会话事件到消息队列失败: %v", err)
+	}
+	if err := s.dispatchEvents(ctx); err != nil {
+		return err
+	}
+	return s.deliverDue(ctx)
+}
+
+// CleanupEvents 删除超过保留天数的事件和已结束的推送记录
+// 等待推送的记录不删除，推送时事件已删除则标记为失败
+func (s *conversationEventService) CleanupEvents(ctx context.Context) error {
+	if s.config.RetentionDays <= 0 {
+		return nil
+	}
+	before := time.Now().AddDate(0, 0, -s.config.RetentionDays)
+	if _, err := s.deliveryRepo.DeleteBefore(ctx, before, []string{define.WebhookDeliveryStatusDelivered, define.WebhookDeliveryStatusFailed}); err != nil {
+		return fmt.Errorf("清理Webhook推送记录失败: %w", err)
+	}
+	if _, err := s.eventRepo.DeleteBefore(ctx, before); err != nil {
+		return fmt.Errorf("清理会话事件失败: %w", err)
+	}
+	return nil
+}
+
+// publishEvents 按写入顺序发布一批未发布的事件到消息队列
+// 发布失败时整批事件保持未发布，下次重新发布，消费方可能收到重复的事件
+func (s *conversationEventService) publishEvents(ctx context.Context) error {
+	if !s.publisher.Enabled() {
+		return nil
+	}
+	events, err := s.eventRepo.GetUnpublished(ctx, s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("查询未发布的会话事件失败: %w", err)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]broker.Message, 0, len(events))
+	ids := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		body, err := conversationEventEnvelope(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, broker.Message{
+			ID:    event.ID.String(),
+			Type:  event.EventType,
+			Key:   event.ConversationID.String(),
+			Value: body,
+		})
+		ids = append(ids, event.ID)
+	}
+	if err := s.publisher.Publish(ctx, messages); err != nil {
+		return err
+	}
+	// 已发布的事件必须标记，避免任务取消后重复发布整批事件
+	if err := s.eventRepo.MarkPublished(context.WithoutCancel(ctx), ids, time.Now()); err != nil {
+		return fmt.Errorf("标记会话事件已发布失败: %w", err)
+	}
+	return nil
+}
+
+// dispatchEvents 按写入顺序为待处理的事件生成推送记录
+// 每个事件为应用下已启用且订阅了该事件类型的 Webhook 各生成一条推送记录，没有订阅时只标记为已处理
+func (s *conversationEventService) dispatchEvents(ctx context.Context) error {
+	events, err := s.eventRepo.GetUndispatched(ctx, s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("查询待处理的会话事件失败: %w", err)
+	}
+
+	webhooksByApplication := make(map[uuid.UUID][]*models.ApplicationWebhook)
+	for _, event := range events {
+		webhooks, ok := webhooksByApplication[event.ApplicationID]
+		if !ok {
+			webhooks, err = s.webhookRepo.GetEnabledByApplicationID(ctx, event.ApplicationID)
+			if err != nil {
+				return fmt.Errorf("查询应用的Webhook失败: %w", err)
+			}
+			webhooksByApplication[event.ApplicationID] = webhooks
+		}
+
+		now := time.Now()
+		deliveries := make([]*models.ApplicationWebhookDelivery, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			if !webhookSubscribes(webhook, event.EventType) {
+				continue
+			}
+			deliveries = append(deliveries, &models.ApplicationWebhookDelivery{
+				ApplicationID: event.ApplicationID,
+				WebhookID:     webhook.ID,
+				EventID:       event.ID,
+				EventType:     event.EventType,
+				Status:        define.WebhookDeliveryStatusPending,
+				NextAttemptAt: now,
+			})
+		}
+		if _, err := s.eventRepo.MarkDispatched(ctx, event, deliveries); err != nil {
+			return fmt.Errorf("生成Webhook推送记录失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// deliverDue 推送到期的推送记录
+// 推送前领取记录，推送超时后其他实例可以重新领取，保证至少推送一次
+func (s *conversationEventService) deliverDue(ctx context.Context) error {
+	deliveries, err := s.deliveryRepo.GetDue(ctx, define.WebhookDeliveryStatusPending, time.Now(), s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("查询待推送的记录失败: %w", err)
+	}
+
+	queue := make(chan *models.ApplicationWebhookDelivery)
+	var wg sync.WaitGroup
+	for i := 0; i < webhookDeliveryWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range queue {
+				s.deliver(ctx, delivery)
+			}
+		}()
+	}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- delivery
+	}
+	close(queue)
+	wg.Wait()
+	return nil
+}
+
+// deliver 推送一条推送记录并保存推送结果
+func (s *conversationEventService) deliver(ctx context.Context, delivery *models.ApplicationWebhookDelivery) {
+	leaseUntil := time.Now().Add(s.httpClient.Timeout + webhookRetryBaseDelay)
+	claimed, err := s.deliveryRepo.Claim(ctx, delivery, leaseUntil)
+	if err != nil {
+		log.Printf("领取Webhook推送记录失败: delivery_id=%s err=%v", delivery.ID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	statusCode, err := s.send(ctx, delivery)
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	if err == nil {
+		delivery.Status = define.WebhookDeliveryStatusDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = truncateRunes(err.Error(), maxWebhookErrorLength)
+		var permanentErr *webhookPermanentError
+		if errors.As(err, &permanentErr) || delivery.Attempts >= s.config.MaxAttempts {
+			delivery.Status = define.WebhookDeliveryStatusFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(webhookRetryDelay(delivery.Attempts))
+		}
+	}
+	// 推送结果不受任务取消影响，避免已推送成功的记录被重复推送
+	if err := s.deliveryRepo.Save(context.WithoutCancel(ctx), delivery); err != nil {
+		log.Printf("保存Webhook推送结果失败: delivery_id=%s err=%v", delivery.ID, err)
+	}
+}
+
+// webhookPermanentError 无法通过重试解决的推送错误，如 Webhook 已删除或事件已清理
+type webhookPermanentError struct {
+	message string
+}
+
+// Error 返回错误信息
+func (e *webhookPermanentError) Error() string {
+	return e.message
+}
+
+// send 发送推送请求
+// 返回：HTTP状态码（请求失败时为0）和错误信息，响应状态码为 2xx 时视为推送成功
+func (s *conversationEventService) send(ctx context.Context, delivery *models.ApplicationWebhookDelivery) (int, error) {
+	webhook, err := s.webhookRepo.GetByIDUnscoped(ctx, delivery.WebhookID)
+	if err != nil {
+		return 0, fmt.Errorf("查询Webhook失败: %w", err)
+	}
+	if webhook == nil || webhook.DeletedAt.Valid {
+		return 0, &webhookPermanentError{message: "Webhook已删除"}
+	}
+	event, err := s.eventRepo.GetByID(ctx, delivery.EventID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, &webhookPermanentError{message: "会话事件不存在或已超过保留天数"}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("查询会话事件失败: %w", err)
+	}
+
+	body, err := conversationEventEnvelope(event)
+	if err != nil {
+		return 0, &webhookPermanentError{message: err.Error()}
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, &webhookPermanentError{message: fmt.Sprintf("创建推送请求失败: %v", err)}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "lemon-tree-webhook")
+	request.Header.Set("X-Lemon-Event-Id", event.ID.String())
+	request.Header.Set("X-Lemon-Event-Type", event.EventType)
+	request.Header.Set("X-Lemon-Delivery-Id", delivery.ID.String())
+	request.Header.Set("X-Lemon-Timestamp", timestamp)
+	request.Header.Set("X-Lemon-Signature", signWebhookPayload(webhook.Secret, timestamp, body))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("推送请求失败: %w", err)
+	}
+	defer response.Body.Close()
+	preview, _ := io.ReadAll(io.LimitReader(response.Body, maxWebhookResponsePreview))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("推送地址返回 HTTP %d: %s", response.StatusCode, string(preview))
+	}
+	return response.StatusCode, nil
+}
+
+// conversationEventEnvelope 序列化会话事件，Webhook 请求体和消息队列的消息内容相同
+func conversationEventEnvelope(event *models.ConversationEvent) ([]byte, error) {
+	body, err := json.Marshal(dto.ConversationEventDto{
+		ID:             event.ID.String(),
+		Type:           event.EventType,
+		ApplicationID:  event.ApplicationID.String(),
+		ChatAgentID:    event.ChatAgentID.String(),
+		ConversationID: event.ConversationID.String(),
+		CreatedAt:      event.CreatedAt.UnixMilli(),
+		Data:           json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("序列化会话事件失败: %w", err)
+	}
+	return body, nil
+}
+
+// signWebhookPayload 计算推送请求的签名
+// 签名为 sha256=HMAC-SHA256(密钥, 时间戳 + "." + 请求体) 的十六进制值，接收方可据此校验请求来源并拒绝过期的请求
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryDelay 计算第 attempts 次推送失败后的退避间隔，每次翻倍，不超过 webhookRetryMaxDelay
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookRetryMaxDelay)
+}
+
+// newConversationCreatedEvent 创建会话创建事件，会话的ID和创建时间需要在写入前确定
+func newConversationCreatedEvent(conversation *models.ChatAgentConversation) *models.ConversationEvent {
+	payload, _ := json.Marshal(dto.ConversationEventConversationData{
+		ID:            conversation.ID.String(),
+		Title:         conversation.Title,
+		ServiceUserID: conversation.ServiceUserID,
+		CreatedAt:     conversation.CreatedAt.UnixMilli(),
+	})
+	return &models.ConversationEvent{
+		ApplicationID:  conversation.ApplicationID,
+		ChatAgentID:    conversation.ChatAgentID,
+		ConversationID: conversation.ID,
+		EventType:      define.ConversationEventTypeConversationCreated,
+		Payload:        string(payload),
+	}
+}
+
+// newMessageEvent 创建消息保存事件，消息的ID和创建时间需要在写入前确定
+// 工具调用和工具调用结果消息使用单独的事件类型，注入消息不推送给业务系统，返回 nil
+func newMessageEvent(message *models.ChatAgentMessage) *models.ConversationEvent {
+	if message.Type == define.ChatMessageTypeInjected {
+		return nil
+	}
+	eventType := define.ConversationEventTypeMessageCreated
+	switch message.Type {
+	case "function_call":
+		eventType = define.ConversationEventTypeToolCallCreated
+	case "function_call_output":
+		eventType = define.ConversationEventTypeToolCallOutput
+	}
+	payload, _ := json.Marshal(dto.ConversationEventMessageData{
+		ID:                    message.ID.String(),
+		RequestID:             message.RequestID,
+		Type:                  message.Type,
+		Role:                  message.Role,
+		Content:               message.Content,
+		Language:              message.Language,
+		TruncatedReason:       message.TruncatedReason,
+		FunctionCallID:        message.FunctionCallID,
+		FunctionCallName:      message.FunctionCallName,
+		FunctionCallArguments: message.FunctionCallArguments,
+		FunctionCallOutput:    message.FunctionCallOutput,
+		PromptTokenCount:      message.PromptTokenCount,
+		CompletionTokenCount:  message.CompletionTokenCount,
+		TotalTokenCount:       message.TotalTokenCount,
+		CreatedAt:             message.CreatedAt.UnixMilli(),
+	})
+	return &models.ConversationEvent{
+		ApplicationID:  message.ApplicationID,
+		ChatAgentID:    message.ChatAgentID,
+		ConversationID: message.ConversationID,
+		EventType:      eventType,
+		Payload:        string(payload),
+	}
+}
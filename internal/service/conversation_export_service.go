@@ -0,0 +1,95 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// ConversationTranscript 会话记录
+// 包含会话所属智能体、会话信息和全部消息，用于导出
+type ConversationTranscript struct {
+	ChatAgent    *models.ChatAgent             // 会话所属智能体
+	Conversation *models.ChatAgentConversation // 会话信息
+	Messages     []*models.ChatAgentMessage    // 全部消息（包含工具调用消息），按创建时间正序
+}
+
+// ConversationExportService 会话导出 业务逻辑层接口
+// 定义 会话导出 相关的业务逻辑方法
+type ConversationExportService interface {
+	// GetConversationTranscript 获取智能体下指定会话的完整会话记录
+	GetConversationTranscript(ctx context.Context, chatAgentID, conversationID uuid.UUID) (*ConversationTranscript, error)
+
+	// RenderTranscriptPdf 将会话记录渲染为 PDF
+	// 包含智能体名称和头像、消息时间以及工具调用摘要
+	RenderTranscriptPdf(transcript *ConversationTranscript) ([]byte, error)
+}
+
+// conversationExportService 会话导出 业务逻辑层实现
+// 实现 ConversationExportService 接口
+type conversationExportService struct {
+	conversationRepo repository.ChatAgentConversationRepository
+	messageRepo      repository.ChatAgentMessageRepository
+	chatAgentRepo    repository.ChatAgentRepository
+	config           *config.Config // 应用程序配置
+}
+
+// NewConversationExportService 创建 会话导出 服务实例
+// 返回 ConversationExportService 接口的实现
+func NewConversationExportService(
+	conversationRepo repository.ChatAgentConversationRepository,
+	messageRepo repository.ChatAgentMessageRepository,
+	chatAgentRepo repository.ChatAgentRepository,
+	config *config.Config,
+) ConversationExportService {
+	return &conversationExportService{
+		conversationRepo: conversationRepo,
+		messageRepo:      messageRepo,
+		chatAgentRepo:    chatAgentRepo,
+		config:           config,
+	}
+}
+
+// GetConversationTranscript 获取智能体下指定会话的完整会话记录
+func (s *conversationExportService) GetConversationTranscript(ctx context.Context, chatAgentID, conversationID uuid.UUID) (*ConversationTranscript, error) {
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, chatAgentID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID {
+		return nil, apperror.New(apperror.CodeNotFound, "会话不存在")
+	}
+
+	messages, err := s.messageRepo.GetAllByConversationID(ctx, conversation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取会话消息失败: %w", err)
+	}
+	if err := s.messageRepo.LoadAttachments(ctx, messages); err != nil {
+		return nil, fmt.Errorf("获取消息附件失败: %w", err)
+	}
+	return &ConversationTranscript{
+		ChatAgent:    chatAgent,
+		Conversation: conversation,
+		Messages:     messages,
+	}, nil
+}
+
+// RenderTranscriptPdf 将会话记录渲染为 PDF
+// 包含智能体名称和头像、消息时间以及工具调用摘要；未配置 PDF 字体时返回错误
+func (s *conversationExportService) RenderTranscriptPdf(transcript *ConversationTranscript) ([]byte, error) {
+	if s.config.Export.PdfFontPath == "" {
+		return nil, apperror.New(apperror.CodeServiceUnavailable, "服务器未配置 PDF 字体（EXPORT_PDF_FONT_PATH），暂不支持导出 PDF")
+	}
+	return renderConversationTranscriptPdf(transcript, s.config.Export.PdfFontPath)
+}
@@ -0,0 +1,79 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"lemon-tree-core/internal/dto"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// monitorSubscriberBufferSize 每个订阅者缓冲的事件数量，缓冲满时丢弃新事件
+const monitorSubscriberBufferSize = 256
+
+// ConversationMonitorService 会话监控 业务逻辑层接口
+// 在进程内分发智能体会话事件，供管理后台实时监控
+type ConversationMonitorService interface {
+	// Publish 发布会话事件
+	// 不会阻塞，订阅者处理不及时时丢弃事件
+	Publish(chatAgentID uuid.UUID, event dto.ConversationMonitorEventDto)
+
+	// Subscribe 订阅指定智能体的会话事件
+	// 返回事件通道和取消订阅函数，取消订阅后事件通道关闭
+	Subscribe(chatAgentID uuid.UUID) (<-chan dto.ConversationMonitorEventDto, func())
+}
+
+// conversationMonitorService 会话监控 业务逻辑层实现
+type conversationMonitorService struct {
+	mu          sync.RWMutex                                                    // 保护订阅者列表
+	subscribers map[uuid.UUID]map[chan dto.ConversationMonitorEventDto]struct{} // 按智能体分组的订阅者
+}
+
+// NewConversationMonitorService 创建 会话监控 服务实例
+// 返回 ConversationMonitorService 接口的实现
+func NewConversationMonitorService() ConversationMonitorService {
+	return &conversationMonitorService{
+		subscribers: make(map[uuid.UUID]map[chan dto.ConversationMonitorEventDto]struct{}),
+	}
+}
+
+// Publish 发布会话事件
+// 不会阻塞，订阅者处理不及时时丢弃事件
+func (s *conversationMonitorService) Publish(chatAgentID uuid.UUID, event dto.ConversationMonitorEventDto) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for ch := range s.subscribers[chatAgentID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe 订阅指定智能体的会话事件
+// 返回事件通道和取消订阅函数，取消订阅后事件通道关闭
+func (s *conversationMonitorService) Subscribe(chatAgentID uuid.UUID) (<-chan dto.ConversationMonitorEventDto, func()) {
+	ch := make(chan dto.ConversationMonitorEventDto, monitorSubscriberBufferSize)
+
+	s.mu.Lock()
+	if s.subscribers[chatAgentID] == nil {
+		s.subscribers[chatAgentID] = make(map[chan dto.ConversationMonitorEventDto]struct{})
+	}
+	s.subscribers[chatAgentID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers[chatAgentID], ch)
+			if len(s.subscribers[chatAgentID]) == 0 {
+				delete(s.subscribers, chatAgentID)
+			}
+			s.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
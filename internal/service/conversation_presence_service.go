@@ -0,0 +1,267 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 会话状态的过期时间
+const (
+	presenceTypingTTL     = 8 * time.Second  // 用户输入状态的有效时间，客户端需在此之前重复上报
+	presenceGeneratingTTL = 10 * time.Minute // 智能体生成状态的最长时间，回复流未被读取完时兜底恢复
+)
+
+// presenceSubscriberBufferSize 每个订阅者缓冲的事件数量，缓冲满时丢弃新事件
+const presenceSubscriberBufferSize = 64
+
+// ConversationPresenceService 会话状态 业务逻辑层接口
+// 在进程内同步会话的用户输入状态和智能体生成状态，同一会话的多个设备看到一致的状态
+type ConversationPresenceService interface {
+	// UpdateUserTyping 上报用户输入状态
+	// 校验会话归属于当前智能体和业务侧用户
+	UpdateUserTyping(ctx context.Context, req *dto.UpdateTypingStatusRequest) error
+
+	// Subscribe 订阅会话的状态事件
+	// 校验会话归属于当前智能体和业务侧用户，订阅后首先收到当前状态
+	// 返回事件通道和取消订阅函数，取消订阅后事件通道关闭
+	Subscribe(ctx context.Context, conversationID, serviceUserID string) (<-chan dto.ConversationPresenceEventDto, func(), error)
+
+	// SetAgentGenerating 设置智能体的回复生成状态
+	SetAgentGenerating(conversationID uuid.UUID, requestID string, generating bool)
+}
+
+// conversationPresenceService 会话状态 业务逻辑层实现
+type conversationPresenceService struct {
+	conversationRepo repository.ChatAgentConversationRepository
+	mu               sync.Mutex
+	conversations    map[uuid.UUID]*conversationPresence
+}
+
+// conversationPresence 单个会话的状态和订阅者
+type conversationPresence struct {
+	typing      map[string]*time.Timer // 正在输入的设备，计时器到期时清除
+	generating  map[string]*time.Timer // 正在生成回复的请求，计时器到期时清除
+	subscribers map[chan dto.ConversationPresenceEventDto]struct{}
+}
+
+// NewConversationPresenceService 创建 会话状态 服务实例
+// 返回 ConversationPresenceService 接口的实现
+func NewConversationPresenceService(conversationRepo repository.ChatAgentConversationRepository) ConversationPresenceService {
+	return &conversationPresenceService{
+		conversationRepo: conversationRepo,
+		conversations:    make(map[uuid.UUID]*conversationPresence),
+	}
+}
+
+// UpdateUserTyping 上报用户输入状态
+func (s *conversationPresenceService) UpdateUserTyping(ctx context.Context, req *dto.UpdateTypingStatusRequest) error {
+	conversation, err := s.getOwnedConversation(ctx, req.ConversationID, req.ServiceUserID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	presence := s.presence(conversation.ID)
+	if req.Typing {
+		// 持续输入时只延长有效时间，不重复发布事件
+		if timer, ok := presence.typing[req.DeviceID]; ok {
+			timer.Reset(presenceTypingTTL)
+			return nil
+		}
+		presence.typing[req.DeviceID] = time.AfterFunc(presenceTypingTTL, func() {
+			s.clearTyping(conversation.ID, req.DeviceID)
+		})
+		s.publish(conversation.ID, presence, dto.ConversationPresenceEventDto{
+			EventType: dto.ConversationPresenceEventUserTyping,
+			DeviceID:  req.DeviceID,
+		})
+		return nil
+	}
+
+	if timer, ok := presence.typing[req.DeviceID]; ok {
+		timer.Stop()
+		delete(presence.typing, req.DeviceID)
+		s.publish(conversation.ID, presence, dto.ConversationPresenceEventDto{
+			EventType: dto.ConversationPresenceEventUserStopTyping,
+			DeviceID:  req.DeviceID,
+		})
+	}
+	s.release(conversation.ID, presence)
+	return nil
+}
+
+// Subscribe 订阅会话的状态事件
+func (s *conversationPresenceService) Subscribe(ctx context.Context, conversationID, serviceUserID string) (<-chan dto.ConversationPresenceEventDto, func(), error) {
+	conversation, err := s.getOwnedConversation(ctx, conversationID, serviceUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan dto.ConversationPresenceEventDto, presenceSubscriberBufferSize)
+	s.mu.Lock()
+	presence := s.presence(conversation.ID)
+	presence.subscribers[ch] = struct{}{}
+	ch <- s.buildEvent(conversation.ID, presence, dto.ConversationPresenceEventDto{
+		EventType: dto.ConversationPresenceEventState,
+	})
+	s.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(presence.subscribers, ch)
+			s.release(conversation.ID, presence)
+			s.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe, nil
+}
+
+// SetAgentGenerating 设置智能体的回复生成状态
+func (s *conversationPresenceService) SetAgentGenerating(conversationID uuid.UUID, requestID string, generating bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	presence := s.presence(conversationID)
+	if generating {
+		if _, ok := presence.generating[requestID]; ok {
+			return
+		}
+		presence.generating[requestID] = time.AfterFunc(presenceGeneratingTTL, func() {
+			s.SetAgentGenerating(conversationID, requestID, false)
+		})
+		s.publish(conversationID, presence, dto.ConversationPresenceEventDto{
+			EventType: dto.ConversationPresenceEventAgentGenerating,
+			RequestID: requestID,
+		})
+		return
+	}
+
+	if timer, ok := presence.generating[requestID]; ok {
+		timer.Stop()
+		delete(presence.generating, requestID)
+		s.publish(conversationID, presence, dto.ConversationPresenceEventDto{
+			EventType: dto.ConversationPresenceEventAgentFinished,
+			RequestID: requestID,
+		})
+	}
+	s.release(conversationID, presence)
+}
+
+// clearTyping 输入状态过期时清除并发布停止输入事件
+func (s *conversationPresenceService) clearTyping(conversationID uuid.UUID, deviceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	presence, ok := s.conversations[conversationID]
+	if !ok {
+		return
+	}
+	if _, ok := presence.typing[deviceID]; !ok {
+		return
+	}
+	delete(presence.typing, deviceID)
+	s.publish(conversationID, presence, dto.ConversationPresenceEventDto{
+		EventType: dto.ConversationPresenceEventUserStopTyping,
+		DeviceID:  deviceID,
+	})
+	s.release(conversationID, presence)
+}
+
+// getOwnedConversation 获取归属于当前智能体和业务侧用户的会话
+func (s *conversationPresenceService) getOwnedConversation(ctx context.Context, conversationID, serviceUserID string) (*models.ChatAgentConversation, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+	conversation, err := s.conversationRepo.GetByID(ctx, convID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID || conversation.ServiceUserID != serviceUserID {
+		return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+	}
+	return conversation, nil
+}
+
+// presence 获取会话的状态，不存在时创建
+// 调用方需持有锁
+func (s *conversationPresenceService) presence(conversationID uuid.UUID) *conversationPresence {
+	presence, ok := s.conversations[conversationID]
+	if !ok {
+		presence = &conversationPresence{
+			typing:      make(map[string]*time.Timer),
+			generating:  make(map[string]*time.Timer),
+			subscribers: make(map[chan dto.ConversationPresenceEventDto]struct{}),
+		}
+		s.conversations[conversationID] = presence
+	}
+	return presence
+}
+
+// release 会话没有任何状态和订阅者时移除
+// 调用方需持有锁
+func (s *conversationPresenceService) release(conversationID uuid.UUID, presence *conversationPresence) {
+	if len(presence.typing) == 0 && len(presence.generating) == 0 && len(presence.subscribers) == 0 {
+		delete(s.conversations, conversationID)
+	}
+}
+
+// publish 向会话的全部订阅者发布事件
+// 不会阻塞，订阅者处理不及时时丢弃事件；调用方需持有锁
+func (s *conversationPresenceService) publish(conversationID uuid.UUID, presence *conversationPresence, event dto.ConversationPresenceEventDto) {
+	event = s.buildEvent(conversationID, presence, event)
+	for ch := range presence.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// buildEvent 补充事件的会话ID、当前状态和时间
+// 调用方需持有锁
+func (s *conversationPresenceService) buildEvent(conversationID uuid.UUID, presence *conversationPresence, event dto.ConversationPresenceEventDto) dto.ConversationPresenceEventDto {
+	event.ConversationID = conversationID.String()
+	event.TypingDeviceIDs = make([]string, 0, len(presence.typing))
+	for deviceID := range presence.typing {
+		event.TypingDeviceIDs = append(event.TypingDeviceIDs, deviceID)
+	}
+	sort.Strings(event.TypingDeviceIDs)
+	event.UserTyping = len(presence.typing) > 0
+	event.AgentGenerating = len(presence.generating) > 0
+	event.CreatedAt = time.Now().UnixMilli()
+	return event
+}
+
+// generatingReader 在回复流读取结束时恢复智能体的生成状态
+type generatingReader struct {
+	reader io.Reader
+	once   sync.Once
+	finish func()
+}
+
+// Read 读取回复流，读取出错（包括读取完毕）时恢复生成状态
+func (r *generatingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil {
+		r.once.Do(r.finish)
+	}
+	return n, err
+}
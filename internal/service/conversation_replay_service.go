@@ -0,0 +1,296 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/utils"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 会话回放的限制
+const (
+	maxConversationReplayTurnCount = 20              // 单次回放的用户消息数量上限，超出部分不回放
+	conversationReplayTurnTimeout  = 3 * time.Minute // 单条用户消息的回放超时时间
+)
+
+// ConversationReplayOptions 会话回放使用的智能体配置
+// 未指定的配置项使用智能体当前的配置
+type ConversationReplayOptions struct {
+	SystemPrompt       *string   // 系统提示词
+	ChatModelID        uuid.UUID // 聊天模型ID，为空时使用智能体的聊天模型
+	Temperature        *float64  // 模型温度
+	TopP               *float64  // 模型TopP
+	MaxOutputTokens    *int      // 最大输出Token数量
+	UseOriginalHistory bool      // 是否使用原始回答作为后续消息的历史，默认使用回放产生的回答
+}
+
+// ConversationReplayTurn 一条用户消息的回放结果
+type ConversationReplayTurn struct {
+	RequestID      string              // 原始请求ID
+	UserMessage    string              // 用户消息
+	OriginalAnswer string              // 原始回答，原始请求没有回答时为空
+	ReplayAnswer   string              // 回放得到的回答
+	ErrorMessage   string              // 回放出错的原因
+	DurationMs     int64               // 回放耗时（毫秒）
+	Diff           []utils.TextDiffRow // 原始回答与回放回答的逐行并排对比，回放出错时为空
+}
+
+// ConversationReplay 会话回放结果
+type ConversationReplay struct {
+	ChatAgent          *models.ChatAgent             // 会话所属智能体
+	Conversation       *models.ChatAgentConversation // 回放的会话
+	ChatModel          *models.ApplicationLlm        // 回放使用的聊天模型
+	SystemPrompt       string                        // 回放使用的系统提示词
+	Temperature        float64                       // 回放使用的模型温度
+	TopP               float64                       // 回放使用的模型TopP
+	MaxOutputTokens    int                           // 回放使用的最大输出Token数量
+	UseOriginalHistory bool                          // 是否使用原始回答作为历史
+	TotalTurnCount     int                           // 会话中的用户消息总数
+	Turns              []*ConversationReplayTurn     // 各用户消息的回放结果，按原始顺序排列
+}
+
+// ConversationReplayService 会话回放 业务逻辑层接口
+// 定义 会话回放 相关的业务逻辑方法
+type ConversationReplayService interface {
+	// ReplayConversation 使用修改后的智能体配置重新回答历史会话中的用户消息
+	// 回放在沙盒中进行：不调用工具、不保存任何消息，原会话保持不变
+	ReplayConversation(ctx context.Context, chatAgentID, conversationID uuid.UUID, options *ConversationReplayOptions) (*ConversationReplay, error)
+}
+
+// conversationReplayService 会话回放 业务逻辑层实现
+// 实现 ConversationReplayService 接口
+type conversationReplayService struct {
+	conversationExportService ConversationExportService // 获取完整会话记录
+	knowledgeBaseService      KnowledgeBaseService      // 检索知识库，与聊天接口一致
+	llmRepo                   repository.ApplicationLlmRepository
+	llmProviderRepo           repository.LlmProviderRepository
+	featureFlagService        FeatureFlagService // 功能开关，与聊天接口一致
+}
+
+// NewConversationReplayService 创建 会话回放 服务实例
+// 返回 ConversationReplayService 接口的实现
+func NewConversationReplayService(
+	conversationExportService ConversationExportService,
+	knowledgeBaseService KnowledgeBaseService,
+	llmRepo repository.ApplicationLlmRepository,
+	llmProviderRepo repository.LlmProviderRepository,
+	featureFlagService FeatureFlagService,
+) ConversationReplayService {
+	return &conversationReplayService{
+		conversationExportService: conversationExportService,
+		knowledgeBaseService:      knowledgeBaseService,
+		llmRepo:                   llmRepo,
+		llmProviderRepo:           llmProviderRepo,
+		featureFlagService:        featureFlagService,
+	}
+}
+
+// ReplayConversation 使用修改后的智能体配置重新回答历史会话中的用户消息
+// 回放在沙盒中进行：不调用工具、不保存任何消息，原会话保持不变
+func (s *conversationReplayService) ReplayConversation(ctx context.Context, chatAgentID, conversationID uuid.UUID, options *ConversationReplayOptions) (*ConversationReplay, error) {
+	transcript, err := s.conversationExportService.GetConversationTranscript(ctx, chatAgentID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	turns := extractConversationReplayTurns(transcript.Messages)
+	if len(turns) == 0 {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "会话中没有用户消息")
+	}
+
+	// 复制智能体配置并应用修改，不影响智能体本身
+	chatAgent := *transcript.ChatAgent
+	if options.SystemPrompt != nil {
+		chatAgent.ChatSystemPrompt = *options.SystemPrompt
+	}
+	if options.ChatModelID != uuid.Nil {
+		chatAgent.ChatModelID = options.ChatModelID
+	}
+	if options.Temperature != nil {
+		if *options.Temperature < 0 || *options.Temperature > 2 {
+			return nil, apperror.New(apperror.CodeInvalidArgument, "模型温度必须在0到2之间")
+		}
+		chatAgent.ModelParamTemperature = *options.Temperature
+	}
+	if options.TopP != nil {
+		if *options.TopP < 0 || *options.TopP > 1 {
+			return nil, apperror.New(apperror.CodeInvalidArgument, "模型TopP必须在0到1之间")
+		}
+		chatAgent.ModelParamTopP = *options.TopP
+	}
+	if options.MaxOutputTokens != nil {
+		if *options.MaxOutputTokens < 0 {
+			return nil, apperror.New(apperror.CodeInvalidArgument, "最大输出Token数量不能小于0")
+		}
+		chatAgent.MaxOutputTokenCountLimit = *options.MaxOutputTokens
+	}
+
+	chatModel, err := s.llmRepo.GetByID(ctx, chatAgent.ChatModelID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "聊天模型不存在", err)
+	}
+	if chatModel.ApplicationID != chatAgent.ApplicationID {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "聊天模型不属于当前应用")
+	}
+	if options.ChatModelID != uuid.Nil && !chatModel.Enabled {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "聊天模型未启用")
+	}
+	llmProvider, err := s.llmProviderRepo.GetByID(ctx, chatModel.LlmProviderID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "聊天模型的提供商不存在", err)
+	}
+	if err := s.featureFlagService.CheckLlmProviderType(ctx, llmProvider.ApplicationID, llmProvider.Type); err != nil {
+		return nil, err
+	}
+	client, err := newConversationReplayClient(llmProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &ConversationReplay{
+		ChatAgent:          transcript.ChatAgent,
+		Conversation:       transcript.Conversation,
+		ChatModel:          chatModel,
+		SystemPrompt:       chatAgent.ChatSystemPrompt,
+		Temperature:        chatAgent.ModelParamTemperature,
+		TopP:               chatAgent.ModelParamTopP,
+		MaxOutputTokens:    chatAgent.MaxOutputTokenCountLimit,
+		UseOriginalHistory: options.UseOriginalHistory,
+		TotalTurnCount:     len(turns),
+	}
+	if len(turns) > maxConversationReplayTurnCount {
+		turns = turns[:maxConversationReplayTurnCount]
+	}
+
+	var history []al_client.ChatMessage
+	for _, turn := range turns {
+		if ctx.Err() != nil {
+			return nil, apperror.New(apperror.CodeInternal, "会话回放已中断").WithCause(ctx.Err())
+		}
+		s.replayTurn(ctx, client, &chatAgent, chatModel, history, turn)
+		replay.Turns = append(replay.Turns, turn.ConversationReplayTurn)
+
+		// 回放出错时使用原始回答延续历史，保证后续消息仍有上下文
+		historyAnswer := turn.ReplayAnswer
+		if options.UseOriginalHistory || turn.ErrorMessage != "" {
+			historyAnswer = turn.OriginalAnswer
+		}
+		history = append(history, al_client.ChatMessage{Role: "user", Content: turn.UserMessage})
+		if historyAnswer != "" {
+			history = append(history, al_client.ChatMessage{Role: "assistant", Content: historyAnswer})
+		}
+		if len(history) > maxHistoryMessageCount {
+			history = history[len(history)-maxHistoryMessageCount:]
+		}
+	}
+	return replay, nil
+}
+
+// conversationReplaySourceTurn 从会话记录中提取的用户消息
+type conversationReplaySourceTurn struct {
+	*ConversationReplayTurn
+	language string // 用户消息识别出的语言
+}
+
+// replayTurn 使用修改后的配置回答一条用户消息
+// 与聊天接口一样追加回复语言指令、注入知识库参考资料，但不提供工具
+func (s *conversationReplayService) replayTurn(ctx context.Context, client al_client.LemonAiClient, chatAgent *models.ChatAgent,
+	chatModel *models.ApplicationLlm, history []al_client.ChatMessage, turn *conversationReplaySourceTurn) {
+	turnCtx, cancel := context.WithTimeout(ctx, conversationReplayTurnTimeout)
+	defer cancel()
+
+	var citations []dto.ChatCitationDto
+	if s.featureFlagService.IsEnabled(turnCtx, chatAgent.ApplicationID, define.FeatureFlagKnowledgeRetrieval) {
+		if retrievalResults, err := s.knowledgeBaseService.Retrieve(turnCtx, chatAgent, turn.UserMessage); err != nil {
+			log.Printf("会话回放检索知识库失败: %v", err)
+		} else {
+			citations = buildKnowledgeCitations(retrievalResults)
+		}
+	}
+	systemPrompt := appendSystemInstruction(chatAgent.ChatSystemPrompt, resolveReplyLanguageInstruction(chatAgent, turn.language))
+	injections := buildChatInjections("", citations)
+
+	messages := make([]al_client.ChatMessage, 0, len(history)+len(injections)+2)
+	messages = append(messages, al_client.ChatMessage{Role: "system", Content: systemPrompt})
+	messages = append(messages, injectedChatMessages(chatAgent, injections)...)
+	messages = append(messages, history...)
+	messages = append(messages, al_client.ChatMessage{Role: "user", Content: turn.UserMessage})
+
+	start := time.Now()
+	response, err := client.SendMessage(turnCtx, al_client.SendMessageRequest{
+		Model:       chatModel.Name,
+		Messages:    messages,
+		Temperature: chatAgent.ModelParamTemperature,
+		TopP:        chatAgent.ModelParamTopP,
+		MaxTokens:   chatAgent.MaxOutputTokenCountLimit,
+	})
+	turn.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		turn.ErrorMessage = fmt.Sprintf("AI处理出错: %v", err)
+		return
+	}
+	if len(response.Choices) == 0 {
+		turn.ErrorMessage = "模型没有返回回答"
+		return
+	}
+	turn.ReplayAnswer = response.Choices[0].Message.Content
+	turn.Diff = utils.SideBySideTextDiff(turn.OriginalAnswer, turn.ReplayAnswer)
+}
+
+// extractConversationReplayTurns 从会话消息中按请求提取用户消息和原始回答
+// 同一请求有多条助手消息时取最后一条
+func extractConversationReplayTurns(messages []*models.ChatAgentMessage) []*conversationReplaySourceTurn {
+	var turns []*conversationReplaySourceTurn
+	turnsByRequestID := make(map[string]*conversationReplaySourceTurn)
+	for _, message := range messages {
+		if message.Type != "message" {
+			continue
+		}
+		switch message.Role {
+		case "user":
+			if strings.TrimSpace(message.Content) == "" {
+				continue
+			}
+			turn := &conversationReplaySourceTurn{
+				ConversationReplayTurn: &ConversationReplayTurn{
+					RequestID:   message.RequestID,
+					UserMessage: message.Content,
+				},
+				language: message.Language,
+			}
+			turns = append(turns, turn)
+			turnsByRequestID[message.RequestID] = turn
+		case "assistant":
+			if turn, ok := turnsByRequestID[message.RequestID]; ok {
+				turn.OriginalAnswer = message.Content
+			}
+		}
+	}
+	return turns
+}
+
+// newConversationReplayClient 根据LLM提供商配置创建回放使用的AI客户端
+func newConversationReplayClient(llmProvider *models.ApplicationLlmProvider) (al_client.LemonAiClient, error) {
+	switch llmProvider.Type {
+	case "openai_chat_completions_api":
+		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	case "mock":
+		return al_client.NewMockClient(), nil
+	case "ollama", "volcano_engine":
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "提供商类型 %s 暂不支持会话回放", llmProvider.Type)
+	default:
+		// 默认使用OpenAI
+		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	}
+}
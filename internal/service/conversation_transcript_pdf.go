@@ -0,0 +1,178 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"lemon-tree-core/internal/models"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	transcriptPdfFontFamily     = "transcript" // PDF 注册的字体名称
+	transcriptPdfAvatarSize     = 16.0         // 智能体头像边长（毫米）
+	transcriptPdfMargin         = 15.0         // 页边距（毫米）
+	transcriptToolSummaryLength = 200          // 工具调用参数和返回值摘要的最大字符数
+	transcriptTimeLayout        = "2006-01-02 15:04:05"
+)
+
+// renderConversationTranscriptPdf 将会话记录渲染为 PDF
+// 页眉为智能体头像、名称和会话信息，正文按时间顺序列出消息，工具调用只输出名称和参数、返回值摘要
+func renderConversationTranscriptPdf(transcript *ConversationTranscript, fontPath string) ([]byte, error) {
+	fontBytes, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("读取 PDF 字体失败: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(transcriptPdfMargin, transcriptPdfMargin, transcriptPdfMargin)
+	pdf.SetAutoPageBreak(true, transcriptPdfMargin)
+	pdf.AddUTF8FontFromBytes(transcriptPdfFontFamily, "", fontBytes)
+	pdf.SetTitle(transcript.Conversation.Title, true)
+	pdf.SetCreator(transcript.ChatAgent.Name, true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-transcriptPdfMargin + 5)
+		pdf.SetFont(transcriptPdfFontFamily, "", 8)
+		pdf.SetTextColor(150, 150, 150)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s · 第 %d 页 / 共 {nb} 页", transcript.ChatAgent.Name, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	writeTranscriptPdfHeader(pdf, transcript)
+	for _, message := range transcript.Messages {
+		writeTranscriptPdfMessage(pdf, transcript.ChatAgent, message)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("生成 PDF 失败: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("生成 PDF 失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeTranscriptPdfHeader 输出页眉：智能体头像、名称、会话标题和时间
+func writeTranscriptPdfHeader(pdf *fpdf.Fpdf, transcript *ConversationTranscript) {
+	left, top := transcriptPdfMargin, pdf.GetY()
+	textLeft := left
+	if avatarName, ok := registerTranscriptAvatar(pdf, transcript.ChatAgent.AvatarUrl); ok {
+		pdf.ImageOptions(avatarName, left, top, transcriptPdfAvatarSize, transcriptPdfAvatarSize, false, fpdf.ImageOptions{}, 0, "")
+		textLeft = left + transcriptPdfAvatarSize + 4
+	}
+
+	pdf.SetXY(textLeft, top)
+	pdf.SetFont(transcriptPdfFontFamily, "", 16)
+	pdf.SetTextColor(30, 30, 30)
+	pdf.CellFormat(0, 8, transcript.ChatAgent.Name, "", 1, "L", false, 0, "")
+	pdf.SetX(textLeft)
+	pdf.SetFont(transcriptPdfFontFamily, "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 5, transcript.Conversation.Title, "", 1, "L", false, 0, "")
+	pdf.SetX(textLeft)
+	pdf.SetFont(transcriptPdfFontFamily, "", 8)
+	pdf.CellFormat(0, 5, fmt.Sprintf("用户：%s    创建时间：%s    导出时间：%s",
+		transcript.Conversation.ServiceUserID,
+		transcript.Conversation.CreatedAt.Format(transcriptTimeLayout),
+		time.Now().Format(transcriptTimeLayout)), "", 1, "L", false, 0, "")
+
+	// 页眉与正文之间的分隔线
+	pdf.SetY(max(pdf.GetY(), top+transcriptPdfAvatarSize) + 3)
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(220, 220, 220)
+	pdf.Line(transcriptPdfMargin, pdf.GetY(), pageWidth-transcriptPdfMargin, pdf.GetY())
+	pdf.Ln(4)
+}
+
+// writeTranscriptPdfMessage 输出一条消息
+// 普通消息输出角色、时间和内容，工具调用消息输出摘要，系统消息不输出
+func writeTranscriptPdfMessage(pdf *fpdf.Fpdf, chatAgent *models.ChatAgent, message *models.ChatAgentMessage) {
+	timestamp := message.CreatedAt.Format(transcriptTimeLayout)
+	switch message.Type {
+	case "message":
+		var speaker string
+		switch message.Role {
+		case "user":
+			speaker = "用户"
+			pdf.SetTextColor(22, 119, 255)
+		case "assistant":
+			speaker = chatAgent.Name
+			pdf.SetTextColor(82, 196, 26)
+		default:
+			return
+		}
+		pdf.SetFont(transcriptPdfFontFamily, "", 10)
+		pdf.CellFormat(0, 6, fmt.Sprintf("%s  %s", speaker, timestamp), "", 1, "L", false, 0, "")
+		pdf.SetFont(transcriptPdfFontFamily, "", 10)
+		pdf.SetTextColor(30, 30, 30)
+		pdf.MultiCell(0, 5, message.Content, "", "L", false)
+		pdf.Ln(3)
+	case "function_call":
+		writeTranscriptPdfToolSummary(pdf, fmt.Sprintf("调用工具 %s  %s  参数：%s", message.FunctionCallName, timestamp,
+			summarizeTranscriptText(message.FunctionCallArguments)))
+	case "function_call_output":
+		writeTranscriptPdfToolSummary(pdf, fmt.Sprintf("工具 %s 返回  %s  %s", message.FunctionCallName, timestamp,
+			summarizeTranscriptText(message.FunctionCallOutput)))
+	}
+}
+
+// writeTranscriptPdfToolSummary 以灰色小字输出工具调用摘要
+func writeTranscriptPdfToolSummary(pdf *fpdf.Fpdf, summary string) {
+	pdf.SetFont(transcriptPdfFontFamily, "", 8)
+	pdf.SetTextColor(140, 140, 140)
+	pdf.MultiCell(0, 4, summary, "", "L", false)
+	pdf.Ln(2)
+}
+
+// summarizeTranscriptText 截取工具调用参数或返回值的摘要，合并空白并限制字符数
+func summarizeTranscriptText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= transcriptToolSummaryLength {
+		return text
+	}
+	return string([]rune(text)[:transcriptToolSummaryLength]) + "…"
+}
+
+// registerTranscriptAvatar 注册智能体头像图片
+// 头像保存在工作区公共目录，统一转换为 PNG 后注册；头像不存在或无法解码时不输出头像
+func registerTranscriptAvatar(pdf *fpdf.Fpdf, avatarUrl string) (string, bool) {
+	workspacePath := os.Getenv("WORKSPACE_PUBLIC_PATH")
+	if workspacePath == "" || !isWorkspaceStaticFile(avatarUrl) {
+		return "", false
+	}
+	file, err := os.Open(filepath.Join(workspacePath, filepath.FromSlash(avatarUrl)))
+	if err != nil {
+		log.Printf("读取智能体头像 %s 失败: %v", avatarUrl, err)
+		return "", false
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		log.Printf("解码智能体头像 %s 失败: %v", avatarUrl, err)
+		return "", false
+	}
+	// 转换为 8 位色深，PDF 不支持 16 位色深的 PNG
+	rgba := image.NewNRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, rgba); err != nil {
+		log.Printf("转换智能体头像 %s 失败: %v", avatarUrl, err)
+		return "", false
+	}
+	pdf.RegisterImageOptionsReader(avatarUrl, fpdf.ImageOptions{ImageType: "PNG"}, &buf)
+	return avatarUrl, pdf.Ok()
+}
@@ -0,0 +1,200 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// 会话变量的限制
+const (
+	maxConversationVariableCount       = 50   // 单个会话的最大变量数量
+	maxConversationVariableValueLength = 4096 // 变量值的最大长度
+)
+
+// conversationVariableNamePattern 变量名称的格式：以字母或下划线开头，只能包含字母、数字和下划线，不超过64个字符
+var conversationVariableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// ConversationVariableService 会话变量 业务逻辑层接口
+// 会话变量由业务侧通过接口或模型通过内部工具设置，后续的工具调用参数和系统提示词模板中可以引用
+type ConversationVariableService interface {
+	// ListVariables 获取会话的全部变量
+	// 校验会话归属于当前智能体和业务侧用户
+	ListVariables(ctx context.Context, serviceUserID, conversationID string) ([]*models.ChatAgentConversationVariable, error)
+
+	// SetVariable 设置会话变量，变量已存在时覆盖变量值
+	// 校验会话归属于当前智能体和业务侧用户
+	SetVariable(ctx context.Context, req *dto.SetConversationVariableRequest) (*models.ChatAgentConversationVariable, error)
+
+	// DeleteVariable 删除会话变量
+	// 校验会话归属于当前智能体和业务侧用户
+	DeleteVariable(ctx context.Context, serviceUserID, conversationID, name string) error
+
+	// GetVariableValues 获取会话变量的名称和值
+	// 不校验会话归属，供聊天流程注入变量使用
+	GetVariableValues(ctx context.Context, conversationID uuid.UUID) (map[string]string, error)
+
+	// SaveVariable 保存会话变量，变量已存在时覆盖变量值
+	// 不校验会话归属，供聊天流程中模型调用内部工具使用；source 为设置来源
+	SaveVariable(ctx context.Context, conversationID uuid.UUID, name, value, source string) (*models.ChatAgentConversationVariable, error)
+
+	// DeleteConversationVariables 删除会话的全部变量，删除会话时调用
+	DeleteConversationVariables(ctx context.Context, conversationID uuid.UUID) error
+}
+
+// conversationVariableService 会话变量 业务逻辑层实现
+type conversationVariableService struct {
+	variableRepo     repository.ChatAgentConversationVariableRepository
+	conversationRepo repository.ChatAgentConversationRepository
+}
+
+// NewConversationVariableService 创建 会话变量 服务实例
+// 返回 ConversationVariableService 接口的实现
+func NewConversationVariableService(
+	variableRepo repository.ChatAgentConversationVariableRepository,
+	conversationRepo repository.ChatAgentConversationRepository,
+) ConversationVariableService {
+	return &conversationVariableService{
+		variableRepo:     variableRepo,
+		conversationRepo: conversationRepo,
+	}
+}
+
+// ListVariables 获取会话的全部变量
+func (s *conversationVariableService) ListVariables(ctx context.Context, serviceUserID, conversationID string) ([]*models.ChatAgentConversationVariable, error) {
+	conversation, err := s.getOwnedConversation(ctx, conversationID, serviceUserID)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := s.variableRepo.ListByConversationID(ctx, conversation.ID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询会话变量失败", err)
+	}
+	return variables, nil
+}
+
+// SetVariable 设置会话变量
+func (s *conversationVariableService) SetVariable(ctx context.Context, req *dto.SetConversationVariableRequest) (*models.ChatAgentConversationVariable, error) {
+	conversation, err := s.getOwnedConversation(ctx, req.ConversationID, req.ServiceUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.SaveVariable(ctx, conversation.ID, req.Name, req.Value, define.ChatConversationVariableSourceApi)
+}
+
+// DeleteVariable 删除会话变量
+func (s *conversationVariableService) DeleteVariable(ctx context.Context, serviceUserID, conversationID, name string) error {
+	conversation, err := s.getOwnedConversation(ctx, conversationID, serviceUserID)
+	if err != nil {
+		return err
+	}
+	variable, err := s.variableRepo.GetByConversationIDAndName(ctx, conversation.ID, name)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "查询会话变量失败", err)
+	}
+	if variable == nil {
+		return apperror.New(apperror.CodeNotFound, "会话变量不存在")
+	}
+	if err := s.variableRepo.DeleteByID(ctx, variable.ID); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "删除会话变量失败", err)
+	}
+	return nil
+}
+
+// GetVariableValues 获取会话变量的名称和值
+func (s *conversationVariableService) GetVariableValues(ctx context.Context, conversationID uuid.UUID) (map[string]string, error) {
+	variables, err := s.variableRepo.ListByConversationID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		values[variable.Name] = variable.Value
+	}
+	return values, nil
+}
+
+// SaveVariable 保存会话变量
+func (s *conversationVariableService) SaveVariable(ctx context.Context, conversationID uuid.UUID, name, value, source string) (*models.ChatAgentConversationVariable, error) {
+	application, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if !conversationVariableNamePattern.MatchString(name) {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "变量名称只能包含字母、数字和下划线，以字母或下划线开头，且不超过64个字符").
+			WithDetails(map[string]any{"field": "name"})
+	}
+	if len([]rune(value)) > maxConversationVariableValueLength {
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "变量值不能超过%d个字符", maxConversationVariableValueLength).
+			WithDetails(map[string]any{"field": "value"})
+	}
+
+	variable, err := s.variableRepo.GetByConversationIDAndName(ctx, conversationID, name)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询会话变量失败", err)
+	}
+	if variable != nil {
+		variable.Value = value
+		variable.Source = source
+		if err := s.variableRepo.Update(ctx, variable); err != nil {
+			return nil, apperror.Wrap(apperror.CodeInternal, "保存会话变量失败", err)
+		}
+		return variable, nil
+	}
+
+	count, err := s.variableRepo.CountByConversationID(ctx, conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询会话变量失败", err)
+	}
+	if count >= maxConversationVariableCount {
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "单个会话最多设置%d个变量", maxConversationVariableCount)
+	}
+	variable = &models.ChatAgentConversationVariable{
+		ApplicationID:  application.ID,
+		ChatAgentID:    chatAgent.ID,
+		ConversationID: conversationID,
+		Name:           name,
+		Value:          value,
+		Source:         source,
+	}
+	if err := s.variableRepo.Create(ctx, variable); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "保存会话变量失败", err)
+	}
+	return variable, nil
+}
+
+// DeleteConversationVariables 删除会话的全部变量
+func (s *conversationVariableService) DeleteConversationVariables(ctx context.Context, conversationID uuid.UUID) error {
+	return s.variableRepo.DeleteByConversationID(ctx, conversationID)
+}
+
+// getOwnedConversation 获取归属于当前智能体和业务侧用户的会话
+func (s *conversationVariableService) getOwnedConversation(ctx context.Context, conversationID, serviceUserID string) (*models.ChatAgentConversation, error) {
+	_, chatAgent, err := getContextInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "无效的会话ID", err)
+	}
+	conversation, err := s.conversationRepo.GetByID(ctx, convID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "会话不存在", err)
+	}
+	if conversation.ChatAgentID != chatAgent.ID || conversation.ServiceUserID != serviceUserID {
+		return nil, apperror.New(apperror.CodeForbidden, "无权访问此会话")
+	}
+	return conversation, nil
+}
@@ -0,0 +1,177 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// maxEvaluationAssertionCount 单个用例的断言数量上限
+const maxEvaluationAssertionCount = 20
+
+// evaluationJudgePrompt 评测模型使用的系统提示词
+const evaluationJudgePrompt = "你是一名严格的评测员。你将看到用户的问题、助手的回答以及期望回答具备的特征。" +
+	"请逐条判断回答是否具备全部期望特征，只要有一条不满足就判定为不通过。" +
+	"只输出JSON对象，例如 {\"passed\": true, \"score\": 0.9, \"reason\": \"简要说明\"}，" +
+	"其中 score 为0到1之间的数字，表示满足期望特征的程度，不要输出其他内容。"
+
+// evaluationJudgement 评测模型的判断结果
+type evaluationJudgement struct {
+	Passed bool    `json:"passed"` // 是否具备全部期望特征
+	Score  float64 `json:"score"`  // 满足期望特征的程度（0-1）
+	Reason string  `json:"reason"` // 判断理由
+}
+
+// parseEvaluationAssertions 解析用例保存的断言列表，未配置时返回空
+func parseEvaluationAssertions(assertions string) ([]dto.EvaluationAssertionDto, error) {
+	if strings.TrimSpace(assertions) == "" {
+		return nil, nil
+	}
+	var result []dto.EvaluationAssertionDto
+	if err := json.Unmarshal([]byte(assertions), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateEvaluationAssertions 校验断言类型和内容
+func validateEvaluationAssertions(assertions []dto.EvaluationAssertionDto) error {
+	if len(assertions) > maxEvaluationAssertionCount {
+		return apperror.Newf(apperror.CodeInvalidArgument, "单个用例最多包含%d条断言", maxEvaluationAssertionCount)
+	}
+	for i, assertion := range assertions {
+		if assertion.Pattern == "" {
+			return apperror.Newf(apperror.CodeInvalidArgument, "第%d条断言的内容不能为空", i+1)
+		}
+		switch assertion.Type {
+		case define.EvaluationAssertionTypeContains, define.EvaluationAssertionTypeNotContains:
+		case define.EvaluationAssertionTypeRegex, define.EvaluationAssertionTypeNotRegex:
+			if _, err := regexp.Compile(assertion.Pattern); err != nil {
+				return apperror.Wrap(apperror.CodeInvalidArgument, fmt.Sprintf("第%d条断言的正则表达式无效", i+1), err)
+			}
+		default:
+			return apperror.Newf(apperror.CodeInvalidArgument, "第%d条断言的类型不支持: %s", i+1, assertion.Type)
+		}
+	}
+	return nil
+}
+
+// checkEvaluationAssertions 按断言检查回答内容，返回各断言的检查结果
+// 文本断言不区分大小写；保存时已校验正则表达式，无法编译时视为未通过
+func checkEvaluationAssertions(assertions []dto.EvaluationAssertionDto, answer string) []dto.EvaluationAssertionResultDto {
+	lowerAnswer := strings.ToLower(answer)
+	results := make([]dto.EvaluationAssertionResultDto, 0, len(assertions))
+	for _, assertion := range assertions {
+		passed := false
+		switch assertion.Type {
+		case define.EvaluationAssertionTypeContains:
+			passed = strings.Contains(lowerAnswer, strings.ToLower(assertion.Pattern))
+		case define.EvaluationAssertionTypeNotContains:
+			passed = !strings.Contains(lowerAnswer, strings.ToLower(assertion.Pattern))
+		case define.EvaluationAssertionTypeRegex, define.EvaluationAssertionTypeNotRegex:
+			if re, err := regexp.Compile(assertion.Pattern); err == nil {
+				passed = re.MatchString(answer) == (assertion.Type == define.EvaluationAssertionTypeRegex)
+			}
+		}
+		results = append(results, dto.EvaluationAssertionResultDto{
+			Type:    assertion.Type,
+			Pattern: assertion.Pattern,
+			Passed:  passed,
+		})
+	}
+	return results
+}
+
+// scoreEvaluationResult 汇总断言和评测模型的结果
+// 得分为断言通过比例与评测模型得分的平均值（只计已配置的部分）；全部断言通过且评测模型判定通过时用例通过
+// 参数：assertionResults - 断言检查结果，judgement - 评测模型判断结果，未配置期望特征时为空
+// 返回：是否通过和得分
+func scoreEvaluationResult(assertionResults []dto.EvaluationAssertionResultDto, judgement *evaluationJudgement) (bool, float64) {
+	passed := true
+	var scores []float64
+	if len(assertionResults) > 0 {
+		passedCount := 0
+		for _, result := range assertionResults {
+			if result.Passed {
+				passedCount++
+			}
+		}
+		passed = passedCount == len(assertionResults)
+		scores = append(scores, float64(passedCount)/float64(len(assertionResults)))
+	}
+	if judgement != nil {
+		passed = passed && judgement.Passed
+		scores = append(scores, judgement.Score)
+	}
+	if len(scores) == 0 {
+		return passed, 0
+	}
+	total := 0.0
+	for _, score := range scores {
+		total += score
+	}
+	return passed, total / float64(len(scores))
+}
+
+// judgeEvaluationAnswer 使用评测模型判断回答是否具备期望特征
+func judgeEvaluationAnswer(ctx context.Context, llmProvider *models.ApplicationLlmProvider, judgeLlm *models.ApplicationLlm,
+	input, answer, expectedTraits string) (*evaluationJudgement, error) {
+	client, err := newEvaluationJudgeClient(llmProvider)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.SendMessage(ctx, al_client.SendMessageRequest{
+		Model: judgeLlm.Name,
+		Messages: []al_client.ChatMessage{
+			{Role: "system", Content: evaluationJudgePrompt},
+			{Role: "user", Content: fmt.Sprintf("用户问题：\n%s\n\n助手回答：\n%s\n\n期望特征：\n%s", input, answer, expectedTraits)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("评测模型调用失败: %w", err)
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("评测模型未返回结果")
+	}
+	return parseEvaluationJudgement(response.Choices[0].Message.Content)
+}
+
+// parseEvaluationJudgement 解析评测模型返回的判断结果
+// 模型可能在JSON前后输出说明文字，取第一个 { 到最后一个 } 之间的内容解析
+func parseEvaluationJudgement(content string) (*evaluationJudgement, error) {
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("评测模型返回的内容不是JSON对象: %s", content)
+	}
+	var judgement evaluationJudgement
+	if err := json.Unmarshal([]byte(content[start:end+1]), &judgement); err != nil {
+		return nil, fmt.Errorf("解析评测模型返回的结果失败: %w", err)
+	}
+	judgement.Score = math.Max(0, math.Min(1, judgement.Score))
+	return &judgement, nil
+}
+
+// newEvaluationJudgeClient 根据LLM提供商配置创建评测模型客户端
+func newEvaluationJudgeClient(llmProvider *models.ApplicationLlmProvider) (al_client.LemonAiClient, error) {
+	switch llmProvider.Type {
+	case "openai_chat_completions_api":
+		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	case "mock":
+		return al_client.NewMockClient(), nil
+	case "ollama", "volcano_engine":
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "提供商类型 %s 暂不支持作为评测模型", llmProvider.Type)
+	default:
+		// 默认使用OpenAI
+		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	}
+}
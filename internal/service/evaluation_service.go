@@ -0,0 +1,777 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 评测的限制
+const (
+	maxEvaluationCaseCount           = 500              // 单个评测集的用例数量上限
+	maxEvaluationInputLength         = 32000            // 用例用户消息的字符数上限
+	evaluationCaseTimeout            = 10 * time.Minute // 单个用例的运行超时时间（含评测模型评分）
+	evaluationServiceUserPrefix      = "evaluation:"
+	maxEvaluationPromptVersionLength = 64
+)
+
+// EvaluationCaseComparison 两次评测运行中同一用例的结果对比
+type EvaluationCaseComparison struct {
+	CaseID   uuid.UUID                // 评测用例ID
+	CaseName string                   // 用例名称
+	Input    string                   // 用户消息
+	Base     *models.EvaluationResult // 基准运行的结果，用例不在基准运行中时为空
+	Target   *models.EvaluationResult // 对比运行的结果，用例不在对比运行中时为空
+	Change   string                   // 变化：improved regressed unchanged added removed
+}
+
+// EvaluationRunComparison 评测运行对比
+type EvaluationRunComparison struct {
+	BaseRun   *models.EvaluationRun       // 基准运行
+	TargetRun *models.EvaluationRun       // 对比运行
+	Cases     []*EvaluationCaseComparison // 各用例的对比，按对比运行中的用例顺序排列，移除的用例在最后
+}
+
+// EvaluationService 评测 业务逻辑层接口
+// 定义 评测集、评测用例和评测运行 相关的业务逻辑方法
+type EvaluationService interface {
+	// SaveSet 保存评测集
+	// 如果ID为空则新增，否则更新名称和描述
+	SaveSet(ctx context.Context, set *models.EvaluationSet) error
+
+	// DeleteSet 删除评测集及其全部用例、运行和结果
+	// 有等待中或执行中的运行时不能删除
+	DeleteSet(ctx context.Context, id uuid.UUID) error
+
+	// GetSet 获取评测集
+	GetSet(ctx context.Context, id uuid.UUID) (*models.EvaluationSet, error)
+
+	// GetSetsByChatAgentID 获取智能体的全部评测集
+	GetSetsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.EvaluationSet, error)
+
+	// SaveCase 保存评测用例
+	// 如果ID为空则新增，否则更新现有记录；期望特征和断言至少配置一项
+	SaveCase(ctx context.Context, evaluationCase *models.EvaluationCase) error
+
+	// DeleteCase 删除评测用例，已有的评测结果保留用例快照
+	DeleteCase(ctx context.Context, id uuid.UUID) error
+
+	// GetCasesBySetID 获取评测集的全部用例
+	GetCasesBySetID(ctx context.Context, setID uuid.UUID) ([]*models.EvaluationCase, error)
+
+	// CreateRun 创建评测运行
+	// 保存评测集全部启用用例的快照，由后台任务逐条运行并评分
+	CreateRun(ctx context.Context, run *models.EvaluationRun) error
+
+	// GetRun 获取评测运行
+	GetRun(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error)
+
+	// GetRunsBySetID 获取评测集的运行列表（分页）
+	GetRunsBySetID(ctx context.Context, setID uuid.UUID, page, pageSize int) ([]*models.EvaluationRun, int64, error)
+
+	// GetRunResults 获取评测运行的结果（分页）
+	// status 不为空时只返回该状态的结果
+	GetRunResults(ctx context.Context, runID uuid.UUID, status string, page, pageSize int) ([]*models.EvaluationResult, int64, error)
+
+	// CancelRun 取消等待中或执行中的评测运行
+	CancelRun(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error)
+
+	// CompareRuns 对比同一评测集的两次运行
+	// 用于比较不同系统提示词版本的效果，按用例列出通过状态和得分的变化
+	CompareRuns(ctx context.Context, baseRunID, targetRunID uuid.UUID) (*EvaluationRunComparison, error)
+
+	// ProcessPendingRuns 处理等待中和执行中的评测运行
+	// 由后台定时任务调用，每次按创建时间依次处理，直到没有待处理的运行或上下文取消
+	ProcessPendingRuns(ctx context.Context) error
+}
+
+// evaluationService 评测 业务逻辑层实现
+// 实现 EvaluationService 接口
+type evaluationService struct {
+	setRepo             repository.EvaluationSetRepository
+	caseRepo            repository.EvaluationCaseRepository
+	runRepo             repository.EvaluationRunRepository
+	resultRepo          repository.EvaluationResultRepository
+	chatAgentRepo       repository.ChatAgentRepository
+	applicationRepo     repository.ApplicationRepository
+	llmRepo             repository.ApplicationLlmRepository
+	llmProviderRepo     repository.LlmProviderRepository
+	conversationService ChatAgentConversationService // 发送消息，与聊天接口使用同一处理流程
+	config              *config.Config               // 应用程序配置
+}
+
+// NewEvaluationService 创建 评测 服务实例
+// 返回 EvaluationService 接口的实现
+func NewEvaluationService(
+	setRepo repository.EvaluationSetRepository,
+	caseRepo repository.EvaluationCaseRepository,
+	runRepo repository.EvaluationRunRepository,
+	resultRepo repository.EvaluationResultRepository,
+	chatAgentRepo repository.ChatAgentRepository,
+	applicationRepo repository.ApplicationRepository,
+	llmRepo repository.ApplicationLlmRepository,
+	llmProviderRepo repository.LlmProviderRepository,
+	conversationService ChatAgentConversationService,
+	config *config.Config,
+) EvaluationService {
+	return &evaluationService{
+		setRepo:             setRepo,
+		caseRepo:            caseRepo,
+		runRepo:             runRepo,
+		resultRepo:          resultRepo,
+		chatAgentRepo:       chatAgentRepo,
+		applicationRepo:     applicationRepo,
+		llmRepo:             llmRepo,
+		llmProviderRepo:     llmProviderRepo,
+		conversationService: conversationService,
+		config:              config,
+	}
+}
+
+// SaveSet 保存评测集
+// 如果ID为空则新增，否则更新名称和描述
+func (s *evaluationService) SaveSet(ctx context.Context, set *models.EvaluationSet) error {
+	set.Name = strings.TrimSpace(set.Name)
+	if set.Name == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "评测集名称不能为空")
+	}
+	if len([]rune(set.Name)) > 128 {
+		return apperror.New(apperror.CodeInvalidArgument, "评测集名称不能超过128个字符")
+	}
+	if len([]rune(set.Description)) > 512 {
+		return apperror.New(apperror.CodeInvalidArgument, "评测集描述不能超过512个字符")
+	}
+
+	if set.ID == uuid.Nil {
+		if set.ChatAgentID == uuid.Nil {
+			return apperror.New(apperror.CodeInvalidArgument, "智能体ID不能为空")
+		}
+		chatAgent, err := s.chatAgentRepo.GetByID(ctx, set.ChatAgentID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+		}
+		set.ID = uuid.New()
+		set.ApplicationID = chatAgent.ApplicationID
+		return s.setRepo.Create(ctx, set)
+	}
+
+	// 评测集所属的智能体和应用创建后不能修改
+	existing, err := s.setRepo.GetByID(ctx, set.ID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "评测集不存在", err)
+	}
+	existing.Name = set.Name
+	existing.Description = set.Description
+	if err := s.setRepo.Update(ctx, existing); err != nil {
+		return err
+	}
+	*set = *existing
+	return nil
+}
+
+// DeleteSet 删除评测集及其全部用例、运行和结果
+// 有等待中或执行中的运行时不能删除
+func (s *evaluationService) DeleteSet(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetSet(ctx, id); err != nil {
+		return err
+	}
+	activeCount, err := s.runRepo.CountBySetIDAndStatuses(ctx, id, []string{define.EvaluationRunStatusPending, define.EvaluationRunStatusRunning})
+	if err != nil {
+		return fmt.Errorf("统计评测运行失败: %w", err)
+	}
+	if activeCount > 0 {
+		return apperror.New(apperror.CodeConflict, "评测集有正在执行的运行，请先取消")
+	}
+
+	runIDs, err := s.runRepo.GetIDsBySetID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("获取评测运行失败: %w", err)
+	}
+	if err := s.resultRepo.DeleteByRunIDs(ctx, runIDs); err != nil {
+		return fmt.Errorf("删除评测结果失败: %w", err)
+	}
+	if err := s.runRepo.DeleteBySetID(ctx, id); err != nil {
+		return fmt.Errorf("删除评测运行失败: %w", err)
+	}
+	if err := s.caseRepo.DeleteBySetID(ctx, id); err != nil {
+		return fmt.Errorf("删除评测用例失败: %w", err)
+	}
+	return s.setRepo.DeleteByID(ctx, id)
+}
+
+// GetSet 获取评测集
+func (s *evaluationService) GetSet(ctx context.Context, id uuid.UUID) (*models.EvaluationSet, error) {
+	set, err := s.setRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "评测集不存在", err)
+	}
+	return set, nil
+}
+
+// GetSetsByChatAgentID 获取智能体的全部评测集
+func (s *evaluationService) GetSetsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.EvaluationSet, error) {
+	return s.setRepo.GetByChatAgentID(ctx, chatAgentID)
+}
+
+// SaveCase 保存评测用例
+// 如果ID为空则新增，否则更新现有记录；期望特征和断言至少配置一项
+func (s *evaluationService) SaveCase(ctx context.Context, evaluationCase *models.EvaluationCase) error {
+	if err := validateEvaluationCase(evaluationCase); err != nil {
+		return err
+	}
+	if _, err := s.GetSet(ctx, evaluationCase.SetID); err != nil {
+		return err
+	}
+
+	if evaluationCase.ID == uuid.Nil {
+		count, err := s.caseRepo.CountBySetID(ctx, evaluationCase.SetID)
+		if err != nil {
+			return fmt.Errorf("统计评测用例失败: %w", err)
+		}
+		if count >= maxEvaluationCaseCount {
+			return apperror.Newf(apperror.CodeInvalidArgument, "单个评测集最多包含%d个用例", maxEvaluationCaseCount)
+		}
+		evaluationCase.ID = uuid.New()
+		return s.caseRepo.Create(ctx, evaluationCase)
+	}
+
+	existing, err := s.caseRepo.GetByID(ctx, evaluationCase.ID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "评测用例不存在", err)
+	}
+	if existing.SetID != evaluationCase.SetID {
+		return apperror.New(apperror.CodeInvalidArgument, "评测用例不属于该评测集")
+	}
+	evaluationCase.CreatedAt = existing.CreatedAt
+	return s.caseRepo.Update(ctx, evaluationCase)
+}
+
+// DeleteCase 删除评测用例，已有的评测结果保留用例快照
+func (s *evaluationService) DeleteCase(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.caseRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "评测用例不存在", err)
+	}
+	return s.caseRepo.DeleteByID(ctx, id)
+}
+
+// GetCasesBySetID 获取评测集的全部用例
+func (s *evaluationService) GetCasesBySetID(ctx context.Context, setID uuid.UUID) ([]*models.EvaluationCase, error) {
+	return s.caseRepo.GetBySetID(ctx, setID)
+}
+
+// CreateRun 创建评测运行
+// 保存评测集全部启用用例的快照，由后台任务逐条运行并评分
+func (s *evaluationService) CreateRun(ctx context.Context, run *models.EvaluationRun) error {
+	run.PromptVersion = strings.TrimSpace(run.PromptVersion)
+	if len([]rune(run.PromptVersion)) > maxEvaluationPromptVersionLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "系统提示词版本标识不能超过%d个字符", maxEvaluationPromptVersionLength)
+	}
+	set, err := s.GetSet(ctx, run.SetID)
+	if err != nil {
+		return err
+	}
+
+	// 未指定智能体时评测评测集的默认智能体
+	if run.ChatAgentID == uuid.Nil {
+		run.ChatAgentID = set.ChatAgentID
+	}
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, run.ChatAgentID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "智能体不存在", err)
+	}
+	if chatAgent.ApplicationID != set.ApplicationID {
+		return apperror.New(apperror.CodeInvalidArgument, "智能体与评测集不属于同一应用")
+	}
+	if run.JudgeModelID != uuid.Nil {
+		if _, _, err := s.getJudgeModel(ctx, set.ApplicationID, run.JudgeModelID); err != nil {
+			return err
+		}
+	}
+
+	cases, err := s.caseRepo.GetBySetID(ctx, set.ID)
+	if err != nil {
+		return fmt.Errorf("获取评测用例失败: %w", err)
+	}
+	results := make([]*models.EvaluationResult, 0, len(cases))
+	for _, evaluationCase := range cases {
+		if !evaluationCase.Enabled {
+			continue
+		}
+		if strings.TrimSpace(evaluationCase.ExpectedTraits) != "" && run.JudgeModelID == uuid.Nil {
+			return apperror.Newf(apperror.CodeInvalidArgument, "用例 %q 配置了期望特征，需要指定评测模型", evaluationCase.Name)
+		}
+		result := &models.EvaluationResult{
+			CaseID:         evaluationCase.ID,
+			CaseIndex:      len(results),
+			CaseName:       evaluationCase.Name,
+			Input:          evaluationCase.Input,
+			ExpectedTraits: evaluationCase.ExpectedTraits,
+			Assertions:     evaluationCase.Assertions,
+			Status:         define.EvaluationResultStatusPending,
+		}
+		result.ID = uuid.New()
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return apperror.New(apperror.CodeInvalidArgument, "评测集没有启用的用例")
+	}
+
+	run.ID = uuid.New()
+	run.ApplicationID = set.ApplicationID
+	run.Status = define.EvaluationRunStatusPending
+	run.TotalCount = len(results)
+	run.PassedCount = 0
+	run.FailedCount = 0
+	run.AverageScore = 0
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return fmt.Errorf("创建评测运行失败: %w", err)
+	}
+	for _, result := range results {
+		result.RunID = run.ID
+	}
+	if err := s.resultRepo.BatchCreate(ctx, results); err != nil {
+		// 结果保存失败时删除运行，避免留下没有用例的运行
+		if deleteErr := s.runRepo.DeleteByID(ctx, run.ID); deleteErr != nil {
+			log.Printf("删除评测运行 %s 失败: %v", run.ID, deleteErr)
+		}
+		return fmt.Errorf("保存评测用例快照失败: %w", err)
+	}
+	return nil
+}
+
+// GetRun 获取评测运行
+func (s *evaluationService) GetRun(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error) {
+	run, err := s.runRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "评测运行不存在", err)
+	}
+	return run, nil
+}
+
+// GetRunsBySetID 获取评测集的运行列表（分页）
+func (s *evaluationService) GetRunsBySetID(ctx context.Context, setID uuid.UUID, page, pageSize int) ([]*models.EvaluationRun, int64, error) {
+	return s.runRepo.GetBySetIDWithPagination(ctx, setID, page, pageSize)
+}
+
+// GetRunResults 获取评测运行的结果（分页）
+func (s *evaluationService) GetRunResults(ctx context.Context, runID uuid.UUID, status string, page, pageSize int) ([]*models.EvaluationResult, int64, error) {
+	if _, err := s.GetRun(ctx, runID); err != nil {
+		return nil, 0, err
+	}
+	return s.resultRepo.GetByRunIDWithPagination(ctx, runID, status, page, pageSize)
+}
+
+// CancelRun 取消等待中或执行中的评测运行
+// 正在运行的用例会运行完成，其余用例不再执行
+func (s *evaluationService) CancelRun(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error) {
+	if _, err := s.GetRun(ctx, id); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cancelled, err := s.runRepo.UpdateStatus(ctx, id,
+		[]string{define.EvaluationRunStatusPending, define.EvaluationRunStatusRunning},
+		define.EvaluationRunStatusCancelled, nil, &now)
+	if err != nil {
+		return nil, fmt.Errorf("取消评测运行失败: %w", err)
+	}
+	if !cancelled {
+		return nil, apperror.New(apperror.CodeConflict, "评测运行已结束")
+	}
+	if err := s.resultRepo.UpdateStatusByRunID(ctx, id, define.EvaluationResultStatusPending, define.EvaluationResultStatusCancelled); err != nil {
+		return nil, fmt.Errorf("取消评测用例失败: %w", err)
+	}
+	return s.GetRun(ctx, id)
+}
+
+// CompareRuns 对比同一评测集的两次运行
+// 用于比较不同系统提示词版本的效果，按用例列出通过状态和得分的变化
+func (s *evaluationService) CompareRuns(ctx context.Context, baseRunID, targetRunID uuid.UUID) (*EvaluationRunComparison, error) {
+	baseRun, err := s.GetRun(ctx, baseRunID)
+	if err != nil {
+		return nil, err
+	}
+	targetRun, err := s.GetRun(ctx, targetRunID)
+	if err != nil {
+		return nil, err
+	}
+	if baseRun.SetID != targetRun.SetID {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "只能对比同一评测集的运行")
+	}
+
+	baseResults, err := s.resultRepo.GetAllByRunID(ctx, baseRun.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取评测结果失败: %w", err)
+	}
+	targetResults, err := s.resultRepo.GetAllByRunID(ctx, targetRun.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取评测结果失败: %w", err)
+	}
+
+	baseByCase := make(map[uuid.UUID]*models.EvaluationResult, len(baseResults))
+	for _, result := range baseResults {
+		baseByCase[result.CaseID] = result
+	}
+	comparison := &EvaluationRunComparison{BaseRun: baseRun, TargetRun: targetRun}
+	for _, target := range targetResults {
+		base := baseByCase[target.CaseID]
+		delete(baseByCase, target.CaseID)
+		comparison.Cases = append(comparison.Cases, &EvaluationCaseComparison{
+			CaseID:   target.CaseID,
+			CaseName: target.CaseName,
+			Input:    target.Input,
+			Base:     base,
+			Target:   target,
+			Change:   compareEvaluationResults(base, target),
+		})
+	}
+	for _, base := range baseResults {
+		if _, removed := baseByCase[base.CaseID]; !removed {
+			continue
+		}
+		comparison.Cases = append(comparison.Cases, &EvaluationCaseComparison{
+			CaseID:   base.CaseID,
+			CaseName: base.CaseName,
+			Input:    base.Input,
+			Base:     base,
+			Change:   define.EvaluationCaseChangeRemoved,
+		})
+	}
+	return comparison, nil
+}
+
+// ProcessPendingRuns 处理等待中和执行中的评测运行
+// 由后台定时任务调用，每次按创建时间依次处理，直到没有待处理的运行或上下文取消
+func (s *evaluationService) ProcessPendingRuns(ctx context.Context) error {
+	for ctx.Err() == nil {
+		run, err := s.runRepo.GetEarliestByStatuses(ctx, []string{define.EvaluationRunStatusRunning, define.EvaluationRunStatusPending})
+		if err != nil {
+			return fmt.Errorf("获取待处理的评测运行失败: %w", err)
+		}
+		if run == nil {
+			return nil
+		}
+		if err := s.processRun(ctx, run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processRun 执行单个评测运行
+// 执行中的运行可能是服务重启前中断的，先把运行中的用例重新放回等待队列
+func (s *evaluationService) processRun(ctx context.Context, run *models.EvaluationRun) error {
+	if run.Status == define.EvaluationRunStatusPending {
+		now := time.Now()
+		started, err := s.runRepo.UpdateStatus(ctx, run.ID, []string{define.EvaluationRunStatusPending},
+			define.EvaluationRunStatusRunning, &now, nil)
+		if err != nil {
+			return fmt.Errorf("更新评测运行状态失败: %w", err)
+		}
+		if !started {
+			// 运行在开始前已被取消
+			return nil
+		}
+	}
+	if err := s.resultRepo.UpdateStatusByRunID(ctx, run.ID, define.EvaluationResultStatusRunning, define.EvaluationResultStatusPending); err != nil {
+		return fmt.Errorf("重置评测用例状态失败: %w", err)
+	}
+
+	chatAgent, err := s.chatAgentRepo.GetByID(ctx, run.ChatAgentID)
+	if err != nil {
+		return s.failRun(ctx, run, "智能体不存在")
+	}
+	application, err := s.applicationRepo.GetByID(ctx, chatAgent.ApplicationID)
+	if err != nil {
+		return s.failRun(ctx, run, "智能体所属应用不存在")
+	}
+	var judge *evaluationJudge
+	if run.JudgeModelID != uuid.Nil {
+		llmProvider, judgeLlm, err := s.getJudgeModel(ctx, run.ApplicationID, run.JudgeModelID)
+		if err != nil {
+			return s.failRun(ctx, run, err.Error())
+		}
+		judge = &evaluationJudge{llmProvider: llmProvider, llm: judgeLlm}
+	}
+	// 与聊天接口一样通过上下文传递当前智能体和应用
+	runCtx := context.WithValue(ctx, define.AppContextKeyCurrentChatAgent, chatAgent)
+	runCtx = context.WithValue(runCtx, define.AppContextKeyCurrentApplication, application)
+
+	concurrency := s.config.Evaluation.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	log.Printf("开始执行评测运行 %s，系统提示词版本 %q", run.ID, run.PromptVersion)
+	for ctx.Err() == nil {
+		// 每批开始前检查运行是否已被取消
+		current, err := s.runRepo.GetByID(ctx, run.ID)
+		if err != nil {
+			return fmt.Errorf("获取评测运行失败: %w", err)
+		}
+		if current.Status != define.EvaluationRunStatusRunning {
+			log.Printf("评测运行 %s 已停止，状态: %s", run.ID, current.Status)
+			return nil
+		}
+
+		results, err := s.resultRepo.GetByRunIDAndStatus(ctx, run.ID, define.EvaluationResultStatusPending, concurrency)
+		if err != nil {
+			return fmt.Errorf("获取待运行的评测用例失败: %w", err)
+		}
+		if len(results) == 0 {
+			break
+		}
+
+		ids := make([]uuid.UUID, 0, len(results))
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		if err := s.resultRepo.UpdateStatusByIDs(ctx, ids, define.EvaluationResultStatusRunning); err != nil {
+			return fmt.Errorf("更新评测用例状态失败: %w", err)
+		}
+
+		var wg sync.WaitGroup
+		for _, result := range results {
+			wg.Add(1)
+			go func(result *models.EvaluationResult) {
+				defer wg.Done()
+				s.processResult(runCtx, run, judge, result)
+			}(result)
+		}
+		wg.Wait()
+
+		if err := s.refreshRunSummary(ctx, run.ID); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		// 服务停止时保持执行中状态，重启后继续处理
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := s.runRepo.UpdateStatus(ctx, run.ID, []string{define.EvaluationRunStatusRunning},
+		define.EvaluationRunStatusCompleted, nil, &now); err != nil {
+		return fmt.Errorf("更新评测运行状态失败: %w", err)
+	}
+	log.Printf("评测运行 %s 执行完成", run.ID)
+	return nil
+}
+
+// evaluationJudge 评测运行使用的评测模型
+type evaluationJudge struct {
+	llmProvider *models.ApplicationLlmProvider
+	llm         *models.ApplicationLlm
+}
+
+// processResult 运行单个用例并评分
+// 服务停止导致的中断不保存结果，用例保持运行中状态，重启后重新运行
+func (s *evaluationService) processResult(ctx context.Context, run *models.EvaluationRun, judge *evaluationJudge, result *models.EvaluationResult) {
+	caseCtx, cancel := context.WithTimeout(ctx, evaluationCaseTimeout)
+	defer cancel()
+
+	start := time.Now()
+	stream, err := s.conversationService.UserSendMessage(caseCtx, &dto.ChatUserSendMessageRequest{
+		ServiceUserID: evaluationServiceUserPrefix + run.ID.String(),
+		SystemPrompt:  run.SystemPrompt,
+		UserMessage:   result.Input,
+	}, false)
+	var answer, conversationID string
+	if err == nil {
+		answer, conversationID, err = collectChatAnswer(caseCtx, stream)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Answer = answer
+	result.ConversationID = conversationID
+
+	if err == nil {
+		err = s.scoreResult(caseCtx, judge, result)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		result.Status = define.EvaluationResultStatusError
+		result.ErrorMessage = err.Error()
+		result.Score = 0
+	}
+	if err := s.resultRepo.UpdateResult(context.WithoutCancel(ctx), result); err != nil {
+		log.Printf("保存评测结果 %s 失败: %v", result.ID, err)
+	}
+}
+
+// scoreResult 检查断言并使用评测模型判断期望特征，更新用例的状态和得分
+func (s *evaluationService) scoreResult(ctx context.Context, judge *evaluationJudge, result *models.EvaluationResult) error {
+	assertions, err := parseEvaluationAssertions(result.Assertions)
+	if err != nil {
+		return fmt.Errorf("解析断言失败: %w", err)
+	}
+	assertionResults := checkEvaluationAssertions(assertions, result.Answer)
+	assertionResultsJSON, err := json.Marshal(assertionResults)
+	if err != nil {
+		return fmt.Errorf("序列化断言结果失败: %w", err)
+	}
+	result.AssertionResults = string(assertionResultsJSON)
+
+	var judgement *evaluationJudgement
+	if strings.TrimSpace(result.ExpectedTraits) != "" {
+		if judge == nil {
+			return fmt.Errorf("用例配置了期望特征，但评测运行未指定评测模型")
+		}
+		judgement, err = judgeEvaluationAnswer(ctx, judge.llmProvider, judge.llm, result.Input, result.Answer, result.ExpectedTraits)
+		if err != nil {
+			return err
+		}
+		result.JudgeReason = judgement.Reason
+	}
+
+	passed, score := scoreEvaluationResult(assertionResults, judgement)
+	result.Score = score
+	result.ErrorMessage = ""
+	if passed {
+		result.Status = define.EvaluationResultStatusPassed
+	} else {
+		result.Status = define.EvaluationResultStatusFailed
+	}
+	return nil
+}
+
+// refreshRunSummary 按用例结果重新统计运行的通过数、未通过数和平均得分
+func (s *evaluationService) refreshRunSummary(ctx context.Context, runID uuid.UUID) error {
+	results, err := s.resultRepo.GetAllByRunID(ctx, runID)
+	if err != nil {
+		return fmt.Errorf("获取评测结果失败: %w", err)
+	}
+	passedCount, failedCount, totalScore := 0, 0, 0.0
+	for _, result := range results {
+		switch result.Status {
+		case define.EvaluationResultStatusPassed:
+			passedCount++
+		case define.EvaluationResultStatusFailed, define.EvaluationResultStatusError:
+			failedCount++
+		default:
+			continue
+		}
+		totalScore += result.Score
+	}
+	averageScore := 0.0
+	if scored := passedCount + failedCount; scored > 0 {
+		averageScore = totalScore / float64(scored)
+	}
+	if err := s.runRepo.UpdateSummary(ctx, runID, passedCount, failedCount, averageScore); err != nil {
+		return fmt.Errorf("更新评测运行结果失败: %w", err)
+	}
+	return nil
+}
+
+// failRun 将运行标记为执行失败，未运行的用例标记为已取消
+func (s *evaluationService) failRun(ctx context.Context, run *models.EvaluationRun, errorMessage string) error {
+	log.Printf("评测运行 %s 执行失败: %s", run.ID, errorMessage)
+	if _, err := s.runRepo.UpdateFailed(ctx, run.ID, []string{define.EvaluationRunStatusPending, define.EvaluationRunStatusRunning},
+		define.EvaluationRunStatusFailed, errorMessage, time.Now()); err != nil {
+		return fmt.Errorf("更新评测运行状态失败: %w", err)
+	}
+	if err := s.resultRepo.UpdateStatusByRunID(ctx, run.ID, define.EvaluationResultStatusPending, define.EvaluationResultStatusCancelled); err != nil {
+		return fmt.Errorf("取消评测用例失败: %w", err)
+	}
+	return nil
+}
+
+// getJudgeModel 获取并校验评测模型及其提供商
+func (s *evaluationService) getJudgeModel(ctx context.Context, applicationID, judgeModelID uuid.UUID) (*models.ApplicationLlmProvider, *models.ApplicationLlm, error) {
+	judgeLlm, err := s.llmRepo.GetByID(ctx, judgeModelID)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInvalidArgument, "评测模型不存在", err)
+	}
+	if judgeLlm.ApplicationID != applicationID {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "评测模型不属于当前应用")
+	}
+	if !judgeLlm.Enabled {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "评测模型未启用")
+	}
+	llmProvider, err := s.llmProviderRepo.GetByID(ctx, judgeLlm.LlmProviderID)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInvalidArgument, "评测模型的提供商不存在", err)
+	}
+	return llmProvider, judgeLlm, nil
+}
+
+// validateEvaluationCase 验证评测用例数据
+func validateEvaluationCase(evaluationCase *models.EvaluationCase) error {
+	if evaluationCase.SetID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "评测集ID不能为空")
+	}
+	evaluationCase.Name = strings.TrimSpace(evaluationCase.Name)
+	if len([]rune(evaluationCase.Name)) > 128 {
+		return apperror.New(apperror.CodeInvalidArgument, "用例名称不能超过128个字符")
+	}
+	if strings.TrimSpace(evaluationCase.Input) == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "用户消息不能为空")
+	}
+	if len([]rune(evaluationCase.Input)) > maxEvaluationInputLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "用户消息不能超过%d个字符", maxEvaluationInputLength)
+	}
+
+	assertions, err := parseEvaluationAssertions(evaluationCase.Assertions)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "断言格式无效", err)
+	}
+	if err := validateEvaluationAssertions(assertions); err != nil {
+		return err
+	}
+	if len(assertions) == 0 && strings.TrimSpace(evaluationCase.ExpectedTraits) == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "期望特征和断言至少配置一项")
+	}
+	return nil
+}
+
+// compareEvaluationResults 比较同一用例在两次运行中的结果
+// 未完成评分的结果（等待、运行中、已取消）视为无法比较，记为不变
+func compareEvaluationResults(base, target *models.EvaluationResult) string {
+	if base == nil {
+		return define.EvaluationCaseChangeAdded
+	}
+	if !isEvaluationResultScored(base) || !isEvaluationResultScored(target) {
+		return define.EvaluationCaseChangeUnchanged
+	}
+	basePassed := base.Status == define.EvaluationResultStatusPassed
+	targetPassed := target.Status == define.EvaluationResultStatusPassed
+	switch {
+	case !basePassed && targetPassed:
+		return define.EvaluationCaseChangeImproved
+	case basePassed && !targetPassed:
+		return define.EvaluationCaseChangeRegressed
+	case target.Score > base.Score:
+		return define.EvaluationCaseChangeImproved
+	case target.Score < base.Score:
+		return define.EvaluationCaseChangeRegressed
+	default:
+		return define.EvaluationCaseChangeUnchanged
+	}
+}
+
+// isEvaluationResultScored 判断评测结果是否已完成评分
+func isEvaluationResultScored(result *models.EvaluationResult) bool {
+	switch result.Status {
+	case define.EvaluationResultStatusPassed, define.EvaluationResultStatusFailed, define.EvaluationResultStatusError:
+		return true
+	}
+	return false
+}
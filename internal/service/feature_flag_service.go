@@ -0,0 +1,213 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"log"
+	"slices"
+	"sort"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeatureFlagService 功能开关 业务逻辑层接口
+// 风险较高的功能（知识库检索、并行工具调用、试验中的模型供应商）按应用逐步开放：
+// 应用单独设置的开关优先，其余应用按全局灰度比例根据应用ID稳定地决定是否开启，灰度比例随配置重新加载更新
+type FeatureFlagService interface {
+	// IsEnabled 判断功能开关对应用是否开启
+	// 查询应用设置失败时按全局灰度比例判断，不影响正常请求
+	IsEnabled(ctx context.Context, applicationID uuid.UUID, flag string) bool
+
+	// CheckLlmProviderType 校验应用是否可以使用指定类型的模型供应商
+	// 试验中的供应商类型只对开启了 experimental_llm_providers 开关的应用开放，不可使用时返回禁止访问错误
+	CheckLlmProviderType(ctx context.Context, applicationID uuid.UUID, providerType string) error
+
+	// ListApplicationFeatureFlags 获取全部功能开关对应用的状态，按名称排序
+	ListApplicationFeatureFlags(ctx context.Context, applicationID uuid.UUID) ([]*dto.ApplicationFeatureFlagDto, error)
+
+	// SetApplicationFeatureFlag 为应用单独设置功能开关，存在则覆盖
+	SetApplicationFeatureFlag(ctx context.Context, applicationID uuid.UUID, flag string, enabled bool) error
+
+	// DeleteApplicationFeatureFlag 删除应用单独设置的功能开关，恢复按全局灰度比例判断
+	DeleteApplicationFeatureFlag(ctx context.Context, applicationID uuid.UUID, flag string) error
+}
+
+// featureFlagService 功能开关 业务逻辑层实现
+type featureFlagService struct {
+	flagRepo repository.ApplicationFeatureFlagRepository // 应用功能开关数据访问层
+	appRepo  repository.ApplicationRepository            // 应用数据访问层
+	config   atomic.Pointer[config.FeatureFlagConfig]    // 功能开关配置，随配置重新加载更新
+}
+
+// NewFeatureFlagService 创建 功能开关 服务实例
+// 返回 FeatureFlagService 接口的实现
+// 参数：flagRepo - 应用功能开关数据访问层，appRepo - 应用数据访问层，cfg - 应用程序配置，reloader - 配置重新加载器
+func NewFeatureFlagService(flagRepo repository.ApplicationFeatureFlagRepository, appRepo repository.ApplicationRepository, cfg *config.Config, reloader *config.Reloader) FeatureFlagService {
+	s := &featureFlagService{
+		flagRepo: flagRepo,
+		appRepo:  appRepo,
+	}
+	flagConfig := cfg.FeatureFlag
+	s.config.Store(&flagConfig)
+	reloader.OnReload(func(newConfig *config.Config) {
+		flagConfig := newConfig.FeatureFlag
+		s.config.Store(&flagConfig)
+	})
+	return s
+}
+
+// IsEnabled 判断功能开关对应用是否开启
+func (s *featureFlagService) IsEnabled(ctx context.Context, applicationID uuid.UUID, flag string) bool {
+	featureFlag, err := s.flagRepo.GetByApplicationAndFlag(ctx, applicationID, flag)
+	if err != nil {
+		log.Printf("查询应用 %s 的功能开关 %s 失败，按全局灰度比例判断: %v", applicationID, flag, err)
+	} else if featureFlag != nil {
+		return featureFlag.Enabled
+	}
+	return inFeatureFlagRollout(applicationID, flag, s.rolloutPercent(flag))
+}
+
+// CheckLlmProviderType 校验应用是否可以使用指定类型的模型供应商
+func (s *featureFlagService) CheckLlmProviderType(ctx context.Context, applicationID uuid.UUID, providerType string) error {
+	if !slices.Contains(s.config.Load().ExperimentalLlmProviderTypes, providerType) {
+		return nil
+	}
+	if !s.IsEnabled(ctx, applicationID, define.FeatureFlagExperimentalLlmProviders) {
+		return apperror.Newf(apperror.CodeForbidden, "供应商类型 %s 尚在试验中，未对该应用开放", providerType)
+	}
+	return nil
+}
+
+// ListApplicationFeatureFlags 获取全部功能开关对应用的状态
+func (s *featureFlagService) ListApplicationFeatureFlags(ctx context.Context, applicationID uuid.UUID) ([]*dto.ApplicationFeatureFlagDto, error) {
+	if err := s.ensureApplication(ctx, applicationID); err != nil {
+		return nil, err
+	}
+	featureFlags, err := s.flagRepo.GetByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询应用功能开关失败", err)
+	}
+	overrides := make(map[string]bool, len(featureFlags))
+	for _, featureFlag := range featureFlags {
+		overrides[featureFlag.Flag] = featureFlag.Enabled
+	}
+
+	flags := make([]string, 0, len(define.FeatureFlagDefaultRollouts))
+	for flag := range define.FeatureFlagDefaultRollouts {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+
+	result := make([]*dto.ApplicationFeatureFlagDto, 0, len(flags))
+	for _, flag := range flags {
+		percent := s.rolloutPercent(flag)
+		enabled, overridden := overrides[flag]
+		if !overridden {
+			enabled = inFeatureFlagRollout(applicationID, flag, percent)
+		}
+		result = append(result, &dto.ApplicationFeatureFlagDto{
+			Flag:           flag,
+			Enabled:        enabled,
+			Overridden:     overridden,
+			RolloutPercent: percent,
+		})
+	}
+	return result, nil
+}
+
+// SetApplicationFeatureFlag 为应用单独设置功能开关
+// 保留已有记录的ID和创建时间
+func (s *featureFlagService) SetApplicationFeatureFlag(ctx context.Context, applicationID uuid.UUID, flag string, enabled bool) error {
+	if err := validateFeatureFlag(flag); err != nil {
+		return err
+	}
+	if err := s.ensureApplication(ctx, applicationID); err != nil {
+		return err
+	}
+
+	existing, err := s.flagRepo.GetByApplicationAndFlag(ctx, applicationID, flag)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "查询应用功能开关失败", err)
+	}
+	if existing == nil {
+		featureFlag := &models.ApplicationFeatureFlag{
+			ApplicationID: applicationID,
+			Flag:          flag,
+			Enabled:       enabled,
+		}
+		if err := s.flagRepo.Create(ctx, featureFlag); err != nil {
+			return apperror.Wrap(apperror.CodeInternal, "创建应用功能开关失败", err)
+		}
+		return nil
+	}
+
+	existing.Enabled = enabled
+	if err := s.flagRepo.Save(ctx, existing); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "更新应用功能开关失败", err)
+	}
+	return nil
+}
+
+// DeleteApplicationFeatureFlag 删除应用单独设置的功能开关
+func (s *featureFlagService) DeleteApplicationFeatureFlag(ctx context.Context, applicationID uuid.UUID, flag string) error {
+	if err := validateFeatureFlag(flag); err != nil {
+		return err
+	}
+	if err := s.flagRepo.DeleteByApplicationAndFlag(ctx, applicationID, flag); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "删除应用功能开关失败", err)
+	}
+	return nil
+}
+
+// rolloutPercent 获取功能开关的全局灰度比例，未配置时使用默认比例
+func (s *featureFlagService) rolloutPercent(flag string) int {
+	if percent, ok := s.config.Load().Rollouts[flag]; ok {
+		return percent
+	}
+	return define.FeatureFlagDefaultRollouts[flag]
+}
+
+// ensureApplication 校验应用存在
+func (s *featureFlagService) ensureApplication(ctx context.Context, applicationID uuid.UUID) error {
+	if _, err := s.appRepo.GetByID(ctx, applicationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.New(apperror.CodeNotFound, "应用不存在")
+		}
+		return apperror.Wrap(apperror.CodeInternal, "查询应用失败", err)
+	}
+	return nil
+}
+
+// validateFeatureFlag 校验功能开关名称
+func validateFeatureFlag(flag string) error {
+	if _, ok := define.FeatureFlagDefaultRollouts[flag]; !ok {
+		return apperror.Newf(apperror.CodeInvalidArgument, "不支持的功能开关: %s", flag)
+	}
+	return nil
+}
+
+// inFeatureFlagRollout 判断应用是否在功能开关的灰度范围内
+// 按功能开关名称和应用ID的哈希值分桶，同一应用的结果稳定，提高比例时已开启的应用保持开启
+func inFeatureFlagRollout(applicationID uuid.UUID, flag string, percent int) bool {
+	if percent <= 0 {
+		return false
+	}
+	if percent >= 100 {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(flag))
+	hash.Write(applicationID[:])
+	return int(hash.Sum32()%100) < percent
+}
@@ -0,0 +1,4 @@
+package service
+
+// 为全部业务逻辑层接口生成 gomock 模拟实现，供单元测试使用，接口变更后重新执行 go generate ./...
+//go:generate go tool mockgen -destination=mock_service/mock_service.go -package=mock_service lemon-tree-core/internal/service ApplicationBackupService,ApplicationLlmService,ApplicationMcpServerConfigService,ApplicationService,ApplicationSettingService,ApplicationStorageConfigService,ApplicationToolBundleService,ApplicationWebhookService,BatchInferenceService,BootstrapService,ChatAgentAnswerRuleService,ChatAgentApiKeyService,ChatAgentConversationService,ChatAgentMcpServerToolService,ChatAgentService,ChatAgentToolUsageService,ChatMessagePersistenceService,ChatStreamResumeService,ChatWidgetTokenService,ClusterService,ConfigReloadService,ConversationArchiveService,ConversationEventService,ConversationExportService,ConversationMonitorService,ConversationPresenceService,ConversationReplayService,ConversationVariableService,EvaluationService,FeatureFlagService,KnowledgeBaseService,LlmProviderDefineService,LlmProviderLimiter,LlmProviderService,McpOauthService,OidcLoginService,ServiceUserService,UserService,WorkspaceUploadService
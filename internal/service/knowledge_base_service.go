@@ -0,0 +1,176 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeBaseService 知识库 业务逻辑层接口
+// 定义 知识库 相关的业务逻辑方法
+type KnowledgeBaseService interface {
+	// SaveKnowledgeBase 保存知识库
+	// 如果ID为空则新增，否则更新现有记录；已有文档的知识库不能更换嵌入模型
+	SaveKnowledgeBase(ctx context.Context, knowledgeBase *models.KnowledgeBase) error
+
+	// DeleteKnowledgeBase 删除知识库及其全部文档和片段
+	DeleteKnowledgeBase(ctx context.Context, id uuid.UUID) error
+
+	// GetKnowledgeBasesByApplicationID 获取应用下的全部知识库
+	GetKnowledgeBasesByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.KnowledgeBase, error)
+
+	// ReindexKnowledgeBase 重建知识库全部文档的索引
+	// 修改切分设置后调用，返回开始处理的文档数量
+	ReindexKnowledgeBase(ctx context.Context, id uuid.UUID) (int, error)
+
+	// SaveTextDocument 保存文本文档
+	// documentID 为空时新增文档，否则替换该文档的内容；保存后在后台切分并生成嵌入向量
+	SaveTextDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, name, content string) (*models.KnowledgeDocument, error)
+
+	// UploadDocument 上传文档文件
+	// documentID 为空时新增文档，否则替换该文档的内容；保存后在后台切分并生成嵌入向量
+	UploadDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, file io.Reader, filename string) (*models.KnowledgeDocument, error)
+
+	// ReindexDocument 重新切分文档并重新生成全部片段的嵌入向量
+	ReindexDocument(ctx context.Context, id uuid.UUID) (*models.KnowledgeDocument, error)
+
+	// DeleteDocument 删除文档及其全部片段
+	DeleteDocument(ctx context.Context, id uuid.UUID) error
+
+	// GetDocumentsByKnowledgeBaseID 获取知识库下的文档列表（分页）
+	// status 不为空时只返回该处理状态的文档
+	GetDocumentsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID, status string, page, pageSize int) ([]*models.KnowledgeDocument, int64, error)
+
+	// Retrieve 从智能体绑定的知识库中检索与问题最相关的片段
+	// 未绑定知识库时返回空列表
+	Retrieve(ctx context.Context, chatAgent *models.ChatAgent, query string) ([]*KnowledgeRetrievalResult, error)
+}
+
+// knowledgeBaseService 知识库 业务逻辑层实现
+// 实现 KnowledgeBaseService 接口
+type knowledgeBaseService struct {
+	knowledgeBaseRepo repository.KnowledgeBaseRepository
+	documentRepo      repository.KnowledgeDocumentRepository
+	chunkRepo         repository.KnowledgeChunkRepository
+	embedder          *textEmbedder // 文本嵌入生成器
+	reranker          *textReranker // 文本重排器
+	documentLocks     sync.Map      // 文档处理锁，同一文档的处理任务依次执行
+}
+
+// NewKnowledgeBaseService 创建 知识库 服务实例
+// 返回 KnowledgeBaseService 接口的实现
+func NewKnowledgeBaseService(
+	knowledgeBaseRepo repository.KnowledgeBaseRepository,
+	documentRepo repository.KnowledgeDocumentRepository,
+	chunkRepo repository.KnowledgeChunkRepository,
+	llmRepo repository.ApplicationLlmRepository,
+	llmProviderRepo repository.LlmProviderRepository,
+) KnowledgeBaseService {
+	return &knowledgeBaseService{
+		knowledgeBaseRepo: knowledgeBaseRepo,
+		documentRepo:      documentRepo,
+		chunkRepo:         chunkRepo,
+		embedder:          newTextEmbedder(llmRepo, llmProviderRepo),
+		reranker:          newTextReranker(llmRepo, llmProviderRepo),
+	}
+}
+
+// SaveKnowledgeBase 保存知识库
+// 如果ID为空则新增，否则更新现有记录；已有文档的知识库不能更换嵌入模型
+func (s *knowledgeBaseService) SaveKnowledgeBase(ctx context.Context, knowledgeBase *models.KnowledgeBase) error {
+	if knowledgeBase == nil {
+		return apperror.New(apperror.CodeInvalidArgument, "知识库不能为空")
+	}
+	if strings.TrimSpace(knowledgeBase.Name) == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "知识库名称不能为空")
+	}
+	if knowledgeBase.EmbeddingModelID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "嵌入模型不能为空")
+	}
+	if err := validateKnowledgeChunkConfig(knowledgeBase); err != nil {
+		return err
+	}
+
+	var existing *models.KnowledgeBase
+	if knowledgeBase.ID != uuid.Nil {
+		var err error
+		existing, err = s.knowledgeBaseRepo.GetByID(ctx, knowledgeBase.ID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "知识库不存在", err)
+		}
+		// 所属应用不允许修改
+		knowledgeBase.ApplicationID = existing.ApplicationID
+	}
+	if knowledgeBase.ApplicationID == uuid.Nil {
+		return apperror.New(apperror.CodeInvalidArgument, "所属应用ID不能为空")
+	}
+	if _, err := s.embedder.ValidateModel(ctx, knowledgeBase.ApplicationID, knowledgeBase.EmbeddingModelID); err != nil {
+		return err
+	}
+
+	if existing == nil {
+		knowledgeBase.ID = uuid.New()
+		return s.knowledgeBaseRepo.Create(ctx, knowledgeBase)
+	}
+
+	// 不同嵌入模型生成的向量无法比较，已有文档时不能更换
+	if existing.EmbeddingModelID != knowledgeBase.EmbeddingModelID {
+		count, err := s.documentRepo.CountByKnowledgeBaseID(ctx, existing.ID)
+		if err != nil {
+			return fmt.Errorf("统计知识库文档数量失败: %w", err)
+		}
+		if count > 0 {
+			return apperror.New(apperror.CodeInvalidArgument, "知识库已有文档，不能更换嵌入模型")
+		}
+	}
+	knowledgeBase.CreatedAt = existing.CreatedAt
+	return s.knowledgeBaseRepo.Update(ctx, knowledgeBase)
+}
+
+// DeleteKnowledgeBase 删除知识库及其全部文档和片段
+func (s *knowledgeBaseService) DeleteKnowledgeBase(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.knowledgeBaseRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "知识库不存在", err)
+	}
+	if err := s.chunkRepo.DeleteByKnowledgeBaseID(ctx, id); err != nil {
+		return fmt.Errorf("删除知识库片段失败: %w", err)
+	}
+	if err := s.documentRepo.DeleteByKnowledgeBaseID(ctx, id); err != nil {
+		return fmt.Errorf("删除知识库文档失败: %w", err)
+	}
+	return s.knowledgeBaseRepo.DeleteByID(ctx, id)
+}
+
+// GetKnowledgeBasesByApplicationID 获取应用下的全部知识库
+func (s *knowledgeBaseService) GetKnowledgeBasesByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.KnowledgeBase, error) {
+	return s.knowledgeBaseRepo.GetByApplicationID(ctx, applicationID)
+}
+
+// ReindexKnowledgeBase 重建知识库全部文档的索引
+// 修改切分设置后调用，返回开始处理的文档数量
+func (s *knowledgeBaseService) ReindexKnowledgeBase(ctx context.Context, id uuid.UUID) (int, error) {
+	if _, err := s.knowledgeBaseRepo.GetByID(ctx, id); err != nil {
+		return 0, apperror.Wrap(apperror.CodeNotFound, "知识库不存在", err)
+	}
+	documentIDs, err := s.documentRepo.GetIDsByKnowledgeBaseID(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("获取知识库文档失败: %w", err)
+	}
+	for _, documentID := range documentIDs {
+		if err := s.updateDocumentStatus(ctx, documentID, define.KnowledgeDocumentStatusPending, ""); err != nil {
+			return 0, err
+		}
+		s.startDocumentProcessing(documentID, false)
+	}
+	return len(documentIDs), nil
+}
@@ -0,0 +1,105 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"strings"
+)
+
+const (
+	defaultKnowledgeChunkSize    = 500  // 知识库未配置时的片段最大字符数
+	defaultKnowledgeChunkOverlap = 50   // 知识库未配置时超长段落按窗口切分的重叠字符数
+	minKnowledgeChunkSize        = 100  // 片段最大字符数的下限
+	maxKnowledgeChunkSize        = 4000 // 片段最大字符数的上限
+)
+
+// resolveKnowledgeChunkConfig 获取知识库的切分设置
+// 未配置片段大小时使用默认的片段大小和重叠字符数
+func resolveKnowledgeChunkConfig(knowledgeBase *models.KnowledgeBase) (chunkSize, chunkOverlap int) {
+	if knowledgeBase.ChunkSize <= 0 {
+		return defaultKnowledgeChunkSize, defaultKnowledgeChunkOverlap
+	}
+	return knowledgeBase.ChunkSize, knowledgeBase.ChunkOverlap
+}
+
+// validateKnowledgeChunkConfig 校验知识库的切分设置
+func validateKnowledgeChunkConfig(knowledgeBase *models.KnowledgeBase) error {
+	if knowledgeBase.ChunkSize != 0 && (knowledgeBase.ChunkSize < minKnowledgeChunkSize || knowledgeBase.ChunkSize > maxKnowledgeChunkSize) {
+		return apperror.Newf(apperror.CodeInvalidArgument, "片段大小必须在%d到%d之间", minKnowledgeChunkSize, maxKnowledgeChunkSize)
+	}
+	if knowledgeBase.ChunkOverlap < 0 {
+		return apperror.New(apperror.CodeInvalidArgument, "片段重叠字符数不能为负数")
+	}
+	if knowledgeBase.ChunkSize > 0 && knowledgeBase.ChunkOverlap*2 > knowledgeBase.ChunkSize {
+		return apperror.New(apperror.CodeInvalidArgument, "片段重叠字符数不能超过片段大小的一半")
+	}
+	return nil
+}
+
+// hashKnowledgeContent 计算文档或片段内容的SHA-256
+func hashKnowledgeContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// splitKnowledgeText 将文档内容切分为片段
+// 以空行分隔段落，尽量把相邻段落合并到同一片段；超过片段大小的段落按固定窗口切分，相邻窗口保留重叠内容
+// 参数：content - 文档内容，chunkSize - 片段最大字符数，overlap - 窗口重叠字符数
+// 返回：片段内容列表
+func splitKnowledgeText(content string, chunkSize, overlap int) []string {
+	step := chunkSize - overlap
+	if step <= 0 {
+		step = chunkSize
+	}
+
+	var chunks []string
+	var builder strings.Builder
+	builderLength := 0
+	flush := func() {
+		if text := strings.TrimSpace(builder.String()); text != "" {
+			chunks = append(chunks, text)
+		}
+		builder.Reset()
+		builderLength = 0
+	}
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	for _, paragraph := range strings.Split(content, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		runes := []rune(paragraph)
+
+		// 超长段落单独按窗口切分
+		if len(runes) > chunkSize {
+			flush()
+			for start := 0; start < len(runes); start += step {
+				end := min(start+chunkSize, len(runes))
+				if text := strings.TrimSpace(string(runes[start:end])); text != "" {
+					chunks = append(chunks, text)
+				}
+				if end == len(runes) {
+					break
+				}
+			}
+			continue
+		}
+
+		if builderLength > 0 && builderLength+2+len(runes) > chunkSize {
+			flush()
+		}
+		if builderLength > 0 {
+			builder.WriteString("\n\n")
+			builderLength += 2
+		}
+		builder.WriteString(paragraph)
+		builderLength += len(runes)
+	}
+	flush()
+	return chunks
+}
@@ -0,0 +1,300 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/utils"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	knowledgeEmbeddingBatchSize           = 32       // 生成片段嵌入向量时每次请求的片段数
+	maxKnowledgeDocumentSize              = 20 << 20 // 文档文本内容的大小上限（字节）
+	maxConcurrentKnowledgeDocumentProcess = 4        // 同时处理的文档数量上限
+)
+
+// knowledgeDocumentFileExtensions 支持上传的文档扩展名，目前只支持纯文本格式
+var knowledgeDocumentFileExtensions = map[string]bool{
+	".txt":      true,
+	".md":       true,
+	".markdown": true,
+	".csv":      true,
+	".json":     true,
+}
+
+// knowledgeDocumentProcessSlots 文档处理并发控制，所有知识库共享
+var knowledgeDocumentProcessSlots = make(chan struct{}, maxConcurrentKnowledgeDocumentProcess)
+
+// SaveTextDocument 保存文本文档
+// documentID 为空时新增文档，否则替换该文档的内容；保存后在后台切分并生成嵌入向量
+func (s *knowledgeBaseService) SaveTextDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, name, content string) (*models.KnowledgeDocument, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "文档名称不能为空")
+	}
+	return s.saveDocumentContent(ctx, knowledgeBaseID, documentID, strings.TrimSpace(name), define.KnowledgeDocumentSourceTypeText, content)
+}
+
+// UploadDocument 上传文档文件
+// documentID 为空时新增文档，否则替换该文档的内容，文档名称更新为上传的文件名
+func (s *knowledgeBaseService) UploadDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, file io.Reader, filename string) (*models.KnowledgeDocument, error) {
+	name := filepath.Base(filename)
+	if !knowledgeDocumentFileExtensions[strings.ToLower(filepath.Ext(name))] {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "不支持的文档格式，仅支持 txt、md、markdown、csv、json 文件")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxKnowledgeDocumentSize+1))
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "读取上传文件失败", err)
+	}
+	if len(data) > maxKnowledgeDocumentSize {
+		return nil, apperror.Newf(apperror.CodePayloadTooLarge, "文档大小不能超过%dMB", maxKnowledgeDocumentSize>>20)
+	}
+	if !utf8.Valid(data) {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "文档内容必须是 UTF-8 编码的文本")
+	}
+	// 去除 UTF-8 BOM
+	content := strings.TrimPrefix(string(data), "\uFEFF")
+	return s.saveDocumentContent(ctx, knowledgeBaseID, documentID, name, define.KnowledgeDocumentSourceTypeFile, content)
+}
+
+// saveDocumentContent 保存文档内容并开始后台处理
+// 替换的内容与已处理完成的内容相同时不重新处理
+func (s *knowledgeBaseService) saveDocumentContent(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, name, sourceType, content string) (*models.KnowledgeDocument, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "文档内容不能为空")
+	}
+	if len(content) > maxKnowledgeDocumentSize {
+		return nil, apperror.Newf(apperror.CodePayloadTooLarge, "文档大小不能超过%dMB", maxKnowledgeDocumentSize>>20)
+	}
+	knowledgeBase, err := s.knowledgeBaseRepo.GetByID(ctx, knowledgeBaseID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "知识库不存在", err)
+	}
+	contentHash := hashKnowledgeContent(content)
+
+	if documentID == uuid.Nil {
+		document := &models.KnowledgeDocument{
+			ApplicationID:   knowledgeBase.ApplicationID,
+			KnowledgeBaseID: knowledgeBase.ID,
+			Name:            name,
+			SourceType:      sourceType,
+			Content:         content,
+			ContentHash:     contentHash,
+			Status:          define.KnowledgeDocumentStatusPending,
+		}
+		if err := s.documentRepo.Create(ctx, document); err != nil {
+			return nil, fmt.Errorf("保存文档失败: %w", err)
+		}
+		s.startDocumentProcessing(document.ID, false)
+		return document, nil
+	}
+
+	document, err := s.documentRepo.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "文档不存在", err)
+	}
+	if document.KnowledgeBaseID != knowledgeBase.ID {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "文档不属于该知识库")
+	}
+	unchanged := document.ContentHash == contentHash && document.Status == define.KnowledgeDocumentStatusCompleted
+	document.Name = name
+	document.SourceType = sourceType
+	document.Content = content
+	document.ContentHash = contentHash
+	if !unchanged {
+		document.Status = define.KnowledgeDocumentStatusPending
+		document.ErrorMessage = ""
+	}
+	if err := s.documentRepo.Update(ctx, document); err != nil {
+		return nil, fmt.Errorf("保存文档失败: %w", err)
+	}
+	if !unchanged {
+		s.startDocumentProcessing(document.ID, false)
+	}
+	return document, nil
+}
+
+// ReindexDocument 重新切分文档并重新生成全部片段的嵌入向量
+func (s *knowledgeBaseService) ReindexDocument(ctx context.Context, id uuid.UUID) (*models.KnowledgeDocument, error) {
+	document, err := s.documentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "文档不存在", err)
+	}
+	if err := s.updateDocumentStatus(ctx, document.ID, define.KnowledgeDocumentStatusPending, ""); err != nil {
+		return nil, err
+	}
+	document.Status = define.KnowledgeDocumentStatusPending
+	document.ErrorMessage = ""
+	s.startDocumentProcessing(document.ID, true)
+	return document, nil
+}
+
+// DeleteDocument 删除文档及其全部片段
+func (s *knowledgeBaseService) DeleteDocument(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.documentRepo.GetByID(ctx, id); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "文档不存在", err)
+	}
+	if err := s.chunkRepo.DeleteByDocumentID(ctx, id); err != nil {
+		return fmt.Errorf("删除文档片段失败: %w", err)
+	}
+	return s.documentRepo.DeleteByID(ctx, id)
+}
+
+// GetDocumentsByKnowledgeBaseID 获取知识库下的文档列表（分页）
+// status 不为空时只返回该处理状态的文档
+func (s *knowledgeBaseService) GetDocumentsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID, status string, page, pageSize int) ([]*models.KnowledgeDocument, int64, error) {
+	switch status {
+	case "", define.KnowledgeDocumentStatusPending, define.KnowledgeDocumentStatusProcessing,
+		define.KnowledgeDocumentStatusCompleted, define.KnowledgeDocumentStatusFailed:
+	default:
+		return nil, 0, apperror.Newf(apperror.CodeInvalidArgument, "不支持的文档状态: %s", status)
+	}
+	return s.documentRepo.GetByKnowledgeBaseIDWithPagination(ctx, knowledgeBaseID, status, page, pageSize)
+}
+
+// updateDocumentStatus 更新文档的处理状态
+func (s *knowledgeBaseService) updateDocumentStatus(ctx context.Context, documentID uuid.UUID, status, errorMessage string) error {
+	if err := s.documentRepo.UpdateStatus(ctx, documentID, status, errorMessage); err != nil {
+		return fmt.Errorf("更新文档状态失败: %w", err)
+	}
+	return nil
+}
+
+// startDocumentProcessing 在后台处理文档
+// 同一文档的处理任务依次执行，后执行的任务读取最新的文档内容；处理失败时将文档标记为失败并记录原因
+// 参数：documentID - 文档ID，reembedAll - 是否重新生成全部片段的嵌入向量（否则复用内容未变化片段的嵌入向量）
+func (s *knowledgeBaseService) startDocumentProcessing(documentID uuid.UUID, reembedAll bool) {
+	go func() {
+		knowledgeDocumentProcessSlots <- struct{}{}
+		defer func() { <-knowledgeDocumentProcessSlots }()
+
+		lock, _ := s.documentLocks.LoadOrStore(documentID, &sync.Mutex{})
+		lock.(*sync.Mutex).Lock()
+		defer lock.(*sync.Mutex).Unlock()
+
+		ctx := context.Background()
+		if err := s.processDocument(ctx, documentID, reembedAll); err != nil {
+			log.Printf("处理知识库文档 %s 失败: %v", documentID, err)
+			if err := s.updateDocumentStatus(ctx, documentID, define.KnowledgeDocumentStatusFailed, err.Error()); err != nil {
+				log.Printf("更新知识库文档 %s 状态失败: %v", documentID, err)
+			}
+		}
+	}()
+}
+
+// processDocument 切分文档并更新片段
+// 新片段全部保存成功后才删除旧片段，处理失败时保留上一次成功处理的片段
+func (s *knowledgeBaseService) processDocument(ctx context.Context, documentID uuid.UUID, reembedAll bool) error {
+	document, err := s.documentRepo.GetByID(ctx, documentID)
+	if err != nil {
+		return fmt.Errorf("获取文档失败: %w", err)
+	}
+	knowledgeBase, err := s.knowledgeBaseRepo.GetByID(ctx, document.KnowledgeBaseID)
+	if err != nil {
+		return fmt.Errorf("获取知识库失败: %w", err)
+	}
+	if err := s.updateDocumentStatus(ctx, documentID, define.KnowledgeDocumentStatusProcessing, ""); err != nil {
+		return err
+	}
+
+	chunkSize, chunkOverlap := resolveKnowledgeChunkConfig(knowledgeBase)
+	chunkContents := splitKnowledgeText(document.Content, chunkSize, chunkOverlap)
+	if len(chunkContents) == 0 {
+		return fmt.Errorf("文档没有可用的文本内容")
+	}
+
+	oldChunks, err := s.chunkRepo.GetByDocumentID(ctx, documentID)
+	if err != nil {
+		return fmt.Errorf("获取文档原有片段失败: %w", err)
+	}
+	// 内容未变化的片段复用原有的嵌入向量
+	reusableEmbeddings := make(map[string][]byte)
+	if !reembedAll {
+		for _, chunk := range oldChunks {
+			if chunk.ContentHash != "" && len(chunk.Embedding) > 0 {
+				reusableEmbeddings[chunk.ContentHash] = chunk.Embedding
+			}
+		}
+	}
+
+	chunks := make([]*models.KnowledgeChunk, 0, len(chunkContents))
+	var embedIndexes []int
+	var embedContents []string
+	for i, chunkContent := range chunkContents {
+		chunk := &models.KnowledgeChunk{
+			ApplicationID:   document.ApplicationID,
+			KnowledgeBaseID: document.KnowledgeBaseID,
+			DocumentID:      document.ID,
+			ChunkIndex:      i,
+			Content:         chunkContent,
+			ContentHash:     hashKnowledgeContent(chunkContent),
+		}
+		if embedding, ok := reusableEmbeddings[chunk.ContentHash]; ok {
+			chunk.Embedding = embedding
+		} else {
+			embedIndexes = append(embedIndexes, i)
+			embedContents = append(embedContents, chunkContent)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	embeddings, err := s.createChunkEmbeddings(ctx, knowledgeBase, embedContents)
+	if err != nil {
+		return err
+	}
+	for i, chunkIndex := range embedIndexes {
+		chunks[chunkIndex].Embedding = utils.EncodeFloat32Vector(embeddings[i])
+	}
+
+	if err := s.chunkRepo.BatchCreate(ctx, chunks); err != nil {
+		return fmt.Errorf("保存文档片段失败: %w", err)
+	}
+	oldChunkIDs := make([]uuid.UUID, 0, len(oldChunks))
+	for _, chunk := range oldChunks {
+		oldChunkIDs = append(oldChunkIDs, chunk.ID)
+	}
+	if err := s.chunkRepo.DeleteByIDs(ctx, oldChunkIDs); err != nil {
+		return fmt.Errorf("删除文档原有片段失败: %w", err)
+	}
+
+	// 处理期间文档被删除时，清理刚保存的片段
+	if _, err := s.documentRepo.GetByID(ctx, documentID); err != nil {
+		if err := s.chunkRepo.DeleteByDocumentID(ctx, documentID); err != nil {
+			log.Printf("清理已删除文档 %s 的片段失败: %v", documentID, err)
+		}
+		s.documentLocks.Delete(documentID)
+		return nil
+	}
+
+	if err := s.documentRepo.UpdateProcessResult(ctx, documentID, define.KnowledgeDocumentStatusCompleted, len(chunks)); err != nil {
+		return fmt.Errorf("更新文档状态失败: %w", err)
+	}
+	log.Printf("知识库文档 %s 处理完成，片段 %d 个，新生成嵌入向量 %d 个", documentID, len(chunks), len(embedContents))
+	return nil
+}
+
+// createChunkEmbeddings 分批生成片段的嵌入向量，返回结果与 chunkContents 一一对应
+func (s *knowledgeBaseService) createChunkEmbeddings(ctx context.Context, knowledgeBase *models.KnowledgeBase, chunkContents []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(chunkContents))
+	for start := 0; start < len(chunkContents); start += knowledgeEmbeddingBatchSize {
+		end := min(start+knowledgeEmbeddingBatchSize, len(chunkContents))
+		batch, err := s.embedder.CreateEmbeddings(ctx, knowledgeBase.ApplicationID, knowledgeBase.EmbeddingModelID, chunkContents[start:end])
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return embeddings, nil
+}
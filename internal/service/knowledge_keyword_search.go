@@ -0,0 +1,164 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"lemon-tree-core/internal/models"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	bm25K1                      = 1.2  // BM25 词频饱和参数
+	bm25B                       = 0.75 // BM25 片段长度归一化参数
+	reciprocalRankFusionK       = 60   // 倒数排名融合的平滑常数
+	maxKnowledgeHybridCandidate = 50   // 混合检索时每种检索方式参与融合的最大片段数
+)
+
+// searchKnowledgeChunksByKeyword 使用 BM25 按关键词检索片段
+// 返回与问题至少有一个相同词的片段，按 BM25 分数倒序排列
+func searchKnowledgeChunksByKeyword(chunks []*models.KnowledgeChunk, query string) []*KnowledgeRetrievalResult {
+	queryTerms := uniqueKnowledgeTerms(tokenizeKnowledgeText(query))
+	if len(queryTerms) == 0 || len(chunks) == 0 {
+		return nil
+	}
+
+	// 统计各片段的词频和包含查询词的片段数
+	termFrequencies := make([]map[string]int, len(chunks))
+	documentFrequencies := make(map[string]int, len(queryTerms))
+	chunkLengths := make([]int, len(chunks))
+	totalLength := 0
+	for i, chunk := range chunks {
+		terms := tokenizeKnowledgeText(chunk.Content)
+		frequencies := make(map[string]int)
+		for _, term := range terms {
+			frequencies[term]++
+		}
+		for _, term := range queryTerms {
+			if frequencies[term] > 0 {
+				documentFrequencies[term]++
+			}
+		}
+		termFrequencies[i] = frequencies
+		chunkLengths[i] = len(terms)
+		totalLength += len(terms)
+	}
+	averageLength := float64(totalLength) / float64(len(chunks))
+	if averageLength == 0 {
+		return nil
+	}
+
+	chunkCount := float64(len(chunks))
+	results := make([]*KnowledgeRetrievalResult, 0)
+	for i, chunk := range chunks {
+		score := 0.0
+		for _, term := range queryTerms {
+			frequency := float64(termFrequencies[i][term])
+			if frequency == 0 {
+				continue
+			}
+			documentFrequency := float64(documentFrequencies[term])
+			idf := math.Log(1 + (chunkCount-documentFrequency+0.5)/(documentFrequency+0.5))
+			score += idf * frequency * (bm25K1 + 1) / (frequency + bm25K1*(1-bm25B+bm25B*float64(chunkLengths[i])/averageLength))
+		}
+		if score > 0 {
+			results = append(results, &KnowledgeRetrievalResult{Chunk: chunk, Score: score})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// fuseKnowledgeRetrievalResults 按倒数排名融合多个检索结果
+// 片段的融合分数为其在各结果中排名的 1/(k+排名) 之和，每个结果只取前 maxKnowledgeHybridCandidate 个片段
+func fuseKnowledgeRetrievalResults(resultLists ...[]*KnowledgeRetrievalResult) []*KnowledgeRetrievalResult {
+	fused := make(map[uuid.UUID]*KnowledgeRetrievalResult)
+	order := make([]*KnowledgeRetrievalResult, 0)
+	for _, results := range resultLists {
+		for rank, result := range results {
+			if rank >= maxKnowledgeHybridCandidate {
+				break
+			}
+			score := 1.0 / float64(reciprocalRankFusionK+rank+1)
+			if existing, ok := fused[result.Chunk.ID]; ok {
+				existing.Score += score
+				continue
+			}
+			fusedResult := &KnowledgeRetrievalResult{Chunk: result.Chunk, Score: score}
+			fused[result.Chunk.ID] = fusedResult
+			order = append(order, fusedResult)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Score > order[j].Score
+	})
+	return order
+}
+
+// tokenizeKnowledgeText 将文本切分为检索词
+// 英文和数字按连续字符切分并转为小写，包含 - _ . / 的编号（如订单号、SKU）同时保留完整编号和各部分；
+// 中日文没有空格分隔，按单字和相邻两字切分
+func tokenizeKnowledgeText(text string) []string {
+	var terms []string
+	var word []rune
+	var previousCJK rune
+
+	flushWord := func() {
+		token := strings.Trim(string(word), "-_./")
+		word = word[:0]
+		if token == "" {
+			return
+		}
+		parts := strings.FieldsFunc(token, isKnowledgeTermConnector)
+		if len(parts) > 1 {
+			terms = append(terms, token)
+		}
+		terms = append(terms, parts...)
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flushWord()
+			terms = append(terms, string(r))
+			if previousCJK != 0 {
+				terms = append(terms, string([]rune{previousCJK, r}))
+			}
+			previousCJK = r
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		case isKnowledgeTermConnector(r) && len(word) > 0:
+			word = append(word, r)
+		default:
+			flushWord()
+		}
+		previousCJK = 0
+	}
+	flushWord()
+	return terms
+}
+
+// isKnowledgeTermConnector 判断是否为编号中常见的连接符
+func isKnowledgeTermConnector(r rune) bool {
+	return r == '-' || r == '_' || r == '.' || r == '/'
+}
+
+// uniqueKnowledgeTerms 去除重复的检索词，保持原有顺序
+func uniqueKnowledgeTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	unique := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,223 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/utils"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultKnowledgeRetrievalTopK  = 5   // 智能体未配置时每次检索注入的片段数
+	maxKnowledgeRetrievalTopK      = 20  // 每次检索注入的最大片段数上限
+	defaultKnowledgeRerankTopK     = 20  // 智能体未配置时交给重排模型的候选片段数
+	maxKnowledgeRerankTopK         = 100 // 交给重排模型的候选片段数上限
+	maxChatAgentKnowledgeBaseCount = 10  // 智能体最多绑定的知识库数量
+)
+
+// KnowledgeRetrievalResult 知识库检索结果
+type KnowledgeRetrievalResult struct {
+	Chunk        *models.KnowledgeChunk // 命中的片段
+	DocumentName string                 // 片段所属文档名称
+	Score        float64                // 与问题的相似度；混合检索时为倒数排名融合分数，重排后为重排模型给出的相关性分数
+}
+
+// Retrieve 从智能体绑定的知识库中检索与问题最相关的片段
+// 按余弦相似度倒序返回不低于最低相似度的前 TopK 个片段；问题的嵌入向量按嵌入模型只生成一次
+// 混合检索时同时按 BM25 检索关键词，与向量检索结果按倒数排名融合，提高订单号、SKU 等精确编号的召回
+// 配置了重排模型时先取出前 RerankTopK 个候选片段，再按重排结果返回前 TopK 个片段
+func (s *knowledgeBaseService) Retrieve(ctx context.Context, chatAgent *models.ChatAgent, query string) ([]*KnowledgeRetrievalResult, error) {
+	query = strings.TrimSpace(query)
+	knowledgeBaseIDs, err := parseKnowledgeBaseIDs(chatAgent.KnowledgeBaseIDs)
+	if err != nil || len(knowledgeBaseIDs) == 0 || query == "" {
+		return nil, nil
+	}
+
+	knowledgeBases, err := s.knowledgeBaseRepo.GetByIDs(ctx, knowledgeBaseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取知识库失败: %w", err)
+	}
+	embeddingModelIDs := make(map[uuid.UUID]uuid.UUID, len(knowledgeBases))
+	availableIDs := make([]uuid.UUID, 0, len(knowledgeBases))
+	for _, knowledgeBase := range knowledgeBases {
+		// 只检索同一应用下的知识库
+		if knowledgeBase.ApplicationID != chatAgent.ApplicationID {
+			continue
+		}
+		embeddingModelIDs[knowledgeBase.ID] = knowledgeBase.EmbeddingModelID
+		availableIDs = append(availableIDs, knowledgeBase.ID)
+	}
+	if len(availableIDs) == 0 {
+		return nil, nil
+	}
+
+	chunks, err := s.chunkRepo.GetByKnowledgeBaseIDs(ctx, availableIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取知识库片段失败: %w", err)
+	}
+
+	results := s.searchKnowledgeChunksByVector(ctx, chatAgent, chunks, embeddingModelIDs, query)
+	if chatAgent.KnowledgeRetrievalMode == define.KnowledgeRetrievalModeHybrid {
+		results = fuseKnowledgeRetrievalResults(results, searchKnowledgeChunksByKeyword(chunks, query))
+	}
+
+	topK := chatAgent.KnowledgeRetrievalTopK
+	if topK <= 0 {
+		topK = defaultKnowledgeRetrievalTopK
+	}
+	if chatAgent.KnowledgeRerankModelID != uuid.Nil {
+		results = s.rerankRetrievalResults(ctx, chatAgent, query, results, topK)
+	}
+	if len(results) > topK {
+		results = results[:topK]
+	}
+
+	// 补充片段所属文档名称
+	documentIDs := make([]uuid.UUID, 0, len(results))
+	for _, result := range results {
+		documentIDs = append(documentIDs, result.Chunk.DocumentID)
+	}
+	documents, err := s.documentRepo.GetByIDs(ctx, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("获取知识库文档失败: %w", err)
+	}
+	documentNames := make(map[uuid.UUID]string, len(documents))
+	for _, document := range documents {
+		documentNames[document.ID] = document.Name
+	}
+	for _, result := range results {
+		result.DocumentName = documentNames[result.Chunk.DocumentID]
+	}
+	return results, nil
+}
+
+// searchKnowledgeChunksByVector 按问题与片段嵌入向量的余弦相似度检索片段
+// 返回不低于最低相似度的片段，按相似度倒序排列；问题的嵌入向量按嵌入模型只生成一次
+func (s *knowledgeBaseService) searchKnowledgeChunksByVector(ctx context.Context, chatAgent *models.ChatAgent, chunks []*models.KnowledgeChunk, embeddingModelIDs map[uuid.UUID]uuid.UUID, query string) []*KnowledgeRetrievalResult {
+	queryEmbeddings := make(map[uuid.UUID][]float32)
+	results := make([]*KnowledgeRetrievalResult, 0)
+	for _, chunk := range chunks {
+		embeddingModelID := embeddingModelIDs[chunk.KnowledgeBaseID]
+		queryEmbedding, ok := queryEmbeddings[embeddingModelID]
+		if !ok {
+			var err error
+			queryEmbedding, err = s.embedder.CreateEmbedding(ctx, chatAgent.ApplicationID, embeddingModelID, query)
+			if err != nil {
+				log.Printf("知识库检索生成问题嵌入向量失败: %v", err)
+			}
+			// 生成失败时同样记录，避免同一模型的其他片段重复请求
+			queryEmbeddings[embeddingModelID] = queryEmbedding
+		}
+		if len(queryEmbedding) == 0 {
+			continue
+		}
+
+		chunkEmbedding, err := utils.DecodeFloat32Vector(chunk.Embedding)
+		if err != nil {
+			log.Printf("知识库片段 %s 的嵌入向量无效: %v", chunk.ID, err)
+			continue
+		}
+		score := utils.CosineSimilarity(queryEmbedding, chunkEmbedding)
+		if score < chatAgent.KnowledgeRetrievalMinScore {
+			continue
+		}
+		results = append(results, &KnowledgeRetrievalResult{Chunk: chunk, Score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// rerankRetrievalResults 使用智能体配置的重排模型对候选片段重新排序
+// 候选片段按向量相似度取前 RerankTopK 个；重排失败时保留向量相似度的排序
+func (s *knowledgeBaseService) rerankRetrievalResults(ctx context.Context, chatAgent *models.ChatAgent, query string, results []*KnowledgeRetrievalResult, topK int) []*KnowledgeRetrievalResult {
+	rerankTopK := chatAgent.KnowledgeRerankTopK
+	if rerankTopK <= 0 {
+		rerankTopK = defaultKnowledgeRerankTopK
+	}
+	if len(results) > rerankTopK {
+		results = results[:rerankTopK]
+	}
+	if len(results) == 0 {
+		return results
+	}
+
+	documents := make([]string, 0, len(results))
+	for _, result := range results {
+		documents = append(documents, result.Chunk.Content)
+	}
+	rerankResults, err := s.reranker.Rerank(ctx, chatAgent.ApplicationID, chatAgent.KnowledgeRerankModelID, query, documents, topK)
+	if err != nil {
+		log.Printf("智能体 %s 知识库检索重排失败，使用向量相似度排序: %v", chatAgent.ID, err)
+		return results
+	}
+
+	reranked := make([]*KnowledgeRetrievalResult, 0, len(rerankResults))
+	for _, rerankResult := range rerankResults {
+		result := results[rerankResult.Index]
+		result.Score = rerankResult.RelevanceScore
+		reranked = append(reranked, result)
+	}
+	return reranked
+}
+
+// parseKnowledgeBaseIDs 解析智能体绑定的知识库ID列表
+func parseKnowledgeBaseIDs(knowledgeBaseIDs string) ([]uuid.UUID, error) {
+	if knowledgeBaseIDs == "" {
+		return nil, nil
+	}
+	var idStrings []string
+	if err := json.Unmarshal([]byte(knowledgeBaseIDs), &idStrings); err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(idStrings))
+	for _, idString := range idStrings {
+		id, err := uuid.Parse(idString)
+		if err != nil {
+			return nil, fmt.Errorf("无效的知识库ID %q: %w", idString, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// validateKnowledgeRetrievalConfig 校验智能体的知识库检索设置
+func validateKnowledgeRetrievalConfig(agent *models.ChatAgent) error {
+	knowledgeBaseIDs, err := parseKnowledgeBaseIDs(agent.KnowledgeBaseIDs)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeInvalidArgument, "知识库ID列表格式错误", err)
+	}
+	if len(knowledgeBaseIDs) > maxChatAgentKnowledgeBaseCount {
+		return apperror.Newf(apperror.CodeInvalidArgument, "最多只能绑定%d个知识库", maxChatAgentKnowledgeBaseCount)
+	}
+	if agent.KnowledgeRetrievalTopK < 0 || agent.KnowledgeRetrievalTopK > maxKnowledgeRetrievalTopK {
+		return apperror.Newf(apperror.CodeInvalidArgument, "检索片段数必须在0到%d之间", maxKnowledgeRetrievalTopK)
+	}
+	if agent.KnowledgeRetrievalMinScore < 0 || agent.KnowledgeRetrievalMinScore > 1 {
+		return apperror.New(apperror.CodeInvalidArgument, "检索最低相似度必须在0到1之间")
+	}
+	switch agent.KnowledgeRetrievalMode {
+	case "", define.KnowledgeRetrievalModeVector, define.KnowledgeRetrievalModeHybrid:
+	default:
+		return apperror.Newf(apperror.CodeInvalidArgument, "不支持的检索方式: %s", agent.KnowledgeRetrievalMode)
+	}
+	if agent.KnowledgeRerankTopK < 0 || agent.KnowledgeRerankTopK > maxKnowledgeRerankTopK {
+		return apperror.Newf(apperror.CodeInvalidArgument, "重排候选片段数必须在0到%d之间", maxKnowledgeRerankTopK)
+	}
+	if agent.KnowledgeRerankTopK > 0 && agent.KnowledgeRerankTopK < agent.KnowledgeRetrievalTopK {
+		return apperror.New(apperror.CodeInvalidArgument, "重排候选片段数不能小于检索片段数")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LlmProviderDefineService 大语言模型供应商定义 业务逻辑层接口
+// 管理全局供应商定义及内置的供应商目录
+type LlmProviderDefineService interface {
+	// GetAllLlmProviderDefines 获取所有已保存的供应商定义
+	GetAllLlmProviderDefines(ctx context.Context) ([]*models.LlmProviderDefine, error)
+
+	// GetLlmProviderDefinePresets 获取内置的供应商定义目录
+	GetLlmProviderDefinePresets() []define.LlmProviderDefinePreset
+
+	// LoadLlmProviderDefinePresets 将内置目录写入数据库
+	// overwrite 为 true 时用内置目录覆盖同类型的已有定义，否则跳过
+	LoadLlmProviderDefinePresets(ctx context.Context, overwrite bool) (*dto.LoadLlmProviderDefinePresetsResponse, error)
+}
+
+// llmProviderDefineService 大语言模型供应商定义 业务逻辑层实现
+type llmProviderDefineService struct {
+	llmProviderDefineRepo repository.LlmProviderDefineRepository // 供应商定义数据访问层接口
+}
+
+// NewLlmProviderDefineService 创建 大语言模型供应商定义 服务实例
+// 参数：llmProviderDefineRepo - 供应商定义数据访问层接口
+func NewLlmProviderDefineService(llmProviderDefineRepo repository.LlmProviderDefineRepository) LlmProviderDefineService {
+	return &llmProviderDefineService{
+		llmProviderDefineRepo: llmProviderDefineRepo,
+	}
+}
+
+// GetAllLlmProviderDefines 获取所有已保存的供应商定义
+func (s *llmProviderDefineService) GetAllLlmProviderDefines(ctx context.Context) ([]*models.LlmProviderDefine, error) {
+	return s.llmProviderDefineRepo.ListAll(ctx)
+}
+
+// GetLlmProviderDefinePresets 获取内置的供应商定义目录
+func (s *llmProviderDefineService) GetLlmProviderDefinePresets() []define.LlmProviderDefinePreset {
+	return define.LlmProviderDefinePresets
+}
+
+// LoadLlmProviderDefinePresets 将内置目录写入数据库
+// 按供应商类型匹配已有定义
+func (s *llmProviderDefineService) LoadLlmProviderDefinePresets(ctx context.Context, overwrite bool) (*dto.LoadLlmProviderDefinePresetsResponse, error) {
+	result := &dto.LoadLlmProviderDefinePresetsResponse{}
+
+	for _, preset := range define.LlmProviderDefinePresets {
+		existing, err := s.llmProviderDefineRepo.GetByType(ctx, preset.Type)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("查询供应商定义失败: %w", err)
+		}
+
+		if existing != nil {
+			if !overwrite {
+				result.Skipped++
+				continue
+			}
+			existing.Name = preset.Name
+			existing.Description = preset.Description
+			existing.IconUrl = preset.IconUrl
+			existing.DefaultApiUrl = preset.DefaultApiUrl
+			if err := s.llmProviderDefineRepo.Update(ctx, existing); err != nil {
+				return nil, fmt.Errorf("更新供应商定义失败: %w", err)
+			}
+			result.Updated++
+			continue
+		}
+
+		providerDefine := &models.LlmProviderDefine{
+			Name:          preset.Name,
+			Description:   preset.Description,
+			Type:          preset.Type,
+			IconUrl:       preset.IconUrl,
+			DefaultApiUrl: preset.DefaultApiUrl,
+		}
+		providerDefine.ID = uuid.New()
+		if err := s.llmProviderDefineRepo.Create(ctx, providerDefine); err != nil {
+			return nil, fmt.Errorf("创建供应商定义失败: %w", err)
+		}
+		result.Created++
+	}
+	return result, nil
+}
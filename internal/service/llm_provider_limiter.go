@@ -0,0 +1,204 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import (
+	"container/list"
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LlmProviderLimiter 模型提供商并发限制器
+// 限制同一提供商同时处理的请求数量，超出上限的请求按先后顺序排队，避免突发的聊天请求触发提供商的限流
+type LlmProviderLimiter interface {
+	// Acquire 获取提供商的请求名额
+	// 名额已满时排队等待，开始排队时以排队位置（从1开始）调用一次 onQueued，onQueued 可为 nil
+	// 返回的 release 用于归还名额，可重复调用；排队超时或上下文取消时返回错误
+	Acquire(ctx context.Context, provider *models.ApplicationLlmProvider, onQueued func(position int)) (release func(), err error)
+
+	// Stats 获取各提供商的并发和排队统计，按提供商名称排序
+	Stats() []LlmProviderLimiterStats
+}
+
+// LlmProviderLimiterStats 提供商并发和排队统计
+type LlmProviderLimiterStats struct {
+	ProviderID   uuid.UUID // 提供商ID
+	ProviderName string    // 提供商名称
+	Limit        int       // 并发上限，0 表示不限制
+	Active       int       // 正在处理的请求数
+	Queued       int       // 正在排队的请求数
+	WaitsTotal   int64     // 累计排队的请求数
+	TimeoutTotal int64     // 累计排队超时的请求数
+}
+
+// llmProviderLimiter 模型提供商并发限制器实现
+type llmProviderLimiter struct {
+	mu           sync.Mutex
+	providers    map[uuid.UUID]*llmProviderLimiterState
+	defaultLimit int           // 提供商未设置并发上限时使用的上限，随配置重新加载更新
+	queueTimeout time.Duration // 排队等待的最长时间，0 表示不限制，随配置重新加载更新
+}
+
+// llmProviderLimiterState 单个提供商的并发状态
+type llmProviderLimiterState struct {
+	name         string
+	limit        int
+	active       int
+	waiters      *list.List // 排队中的请求，元素为获得名额时关闭的通道
+	waitsTotal   int64
+	timeoutTotal int64
+}
+
+// NewLlmProviderLimiter 创建模型提供商并发限制器
+// 默认并发上限和排队等待时间随配置重新加载更新
+// 参数：cfg - 应用程序配置，reloader - 配置重新加载器
+func NewLlmProviderLimiter(cfg *config.Config, reloader *config.Reloader) LlmProviderLimiter {
+	limiter := &llmProviderLimiter{
+		providers: make(map[uuid.UUID]*llmProviderLimiterState),
+	}
+	limiter.applyConfig(cfg.LlmLimiter)
+	reloader.OnReload(func(newConfig *config.Config) {
+		limiter.applyConfig(newConfig.LlmLimiter)
+	})
+	return limiter
+}
+
+// applyConfig 更新默认并发上限和排队等待时间
+// 已在排队的请求仍按开始排队时的等待时间计算超时，使用默认上限的提供商在下次获取名额时按新的上限调度
+func (l *llmProviderLimiter) applyConfig(limiterConfig config.LlmLimiterConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultLimit = limiterConfig.DefaultMaxConcurrency
+	l.queueTimeout = time.Duration(limiterConfig.QueueTimeoutSeconds) * time.Second
+}
+
+// Acquire 获取提供商的请求名额
+// 并发上限优先使用提供商的设置，每次获取时刷新，修改提供商设置或重新加载配置后无需重启即可生效
+func (l *llmProviderLimiter) Acquire(ctx context.Context, provider *models.ApplicationLlmProvider, onQueued func(position int)) (func(), error) {
+	l.mu.Lock()
+	limit := provider.MaxConcurrency
+	if limit <= 0 {
+		limit = l.defaultLimit
+	}
+	queueTimeout := l.queueTimeout
+	state, ok := l.providers[provider.ID]
+	if !ok {
+		state = &llmProviderLimiterState{waiters: list.New()}
+		l.providers[provider.ID] = state
+	}
+	state.name = provider.Name
+	state.limit = limit
+	// 上限调大后让排队中的请求先获得名额
+	l.dispatch(state)
+
+	if state.waiters.Len() == 0 && (state.limit <= 0 || state.active < state.limit) {
+		state.active++
+		l.mu.Unlock()
+		return l.releaseFunc(state), nil
+	}
+
+	ready := make(chan struct{})
+	element := state.waiters.PushBack(ready)
+	position := state.waiters.Len()
+	state.waitsTotal++
+	l.mu.Unlock()
+
+	if onQueued != nil {
+		onQueued(position)
+	}
+
+	var timeout <-chan time.Time
+	if queueTimeout > 0 {
+		timer := time.NewTimer(queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-ready:
+		return l.releaseFunc(state), nil
+	case <-ctx.Done():
+		if l.leaveQueue(state, element, ready, false) {
+			l.releaseFunc(state)()
+		}
+		return nil, ctx.Err()
+	case <-timeout:
+		if l.leaveQueue(state, element, ready, true) {
+			return l.releaseFunc(state), nil
+		}
+		return nil, apperror.Newf(apperror.CodeServiceUnavailable, "模型提供商 %s 繁忙，排队超时，请稍后重试", provider.Name)
+	}
+}
+
+// Stats 获取各提供商的并发和排队统计
+func (l *llmProviderLimiter) Stats() []LlmProviderLimiterStats {
+	l.mu.Lock()
+	stats := make([]LlmProviderLimiterStats, 0, len(l.providers))
+	for id, state := range l.providers {
+		stats = append(stats, LlmProviderLimiterStats{
+			ProviderID:   id,
+			ProviderName: state.name,
+			Limit:        state.limit,
+			Active:       state.active,
+			Queued:       state.waiters.Len(),
+			WaitsTotal:   state.waitsTotal,
+			TimeoutTotal: state.timeoutTotal,
+		})
+	}
+	l.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].ProviderName != stats[j].ProviderName {
+			return stats[i].ProviderName < stats[j].ProviderName
+		}
+		return stats[i].ProviderID.String() < stats[j].ProviderID.String()
+	})
+	return stats
+}
+
+// releaseFunc 生成归还名额的函数，多次调用只归还一次
+func (l *llmProviderLimiter) releaseFunc(state *llmProviderLimiterState) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			state.active--
+			l.dispatch(state)
+		})
+	}
+}
+
+// leaveQueue 停止排队
+// 停止前已获得名额时返回 true，由调用方决定继续使用还是归还
+func (l *llmProviderLimiter) leaveQueue(state *llmProviderLimiterState, element *list.Element, ready chan struct{}, timedOut bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-ready:
+		return true
+	default:
+	}
+	state.waiters.Remove(element)
+	if timedOut {
+		state.timeoutTotal++
+	}
+	return false
+}
+
+// dispatch 按排队顺序为等待中的请求分配空闲名额
+// 调用方需持有锁
+func (l *llmProviderLimiter) dispatch(state *llmProviderLimiterState) {
+	for state.waiters.Len() > 0 && (state.limit <= 0 || state.active < state.limit) {
+		ready := state.waiters.Remove(state.waiters.Front()).(chan struct{})
+		state.active++
+		close(ready)
+	}
+}
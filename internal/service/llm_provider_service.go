@@ -3,12 +3,15 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"lemon-tree-core/internal/apperror"
 	"lemon-tree-core/internal/define"
 	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/utils"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,7 +28,9 @@ type LlmProviderService interface {
 
 	// SaveLlmProvider 保存大语言模型提供商（新增或更新）
 	// 如果ID为空则新增，否则更新现有记录
-	SaveLlmProvider(ctx context.Context, llmProvider *models.ApplicationLlmProvider) error
+	// 更新时 llmProvider.Version 大于0则校验版本号，与当前版本不一致时返回 Conflict 错误；保存成功后 llmProvider.Version 为新版本号
+	// updateFields 不为空时为部分更新，只修改列出的字段（模型字段名），其余字段保持当前值
+	SaveLlmProvider(ctx context.Context, llmProvider *models.ApplicationLlmProvider, updateFields []string) error
 
 	// DeleteLlmProvider 删除大语言模型提供商
 	// 根据ID删除指定的提供商
@@ -76,7 +81,21 @@ func (s *llmProviderService) QueryLlmProviders(ctx context.Context, query *model
 
 // SaveLlmProvider 保存大语言模型提供商（新增或更新）
 // 如果ID为空则新增，否则更新现有记录
-func (s *llmProviderService) SaveLlmProvider(ctx context.Context, llmProvider *models.ApplicationLlmProvider) error {
+func (s *llmProviderService) SaveLlmProvider(ctx context.Context, llmProvider *models.ApplicationLlmProvider, updateFields []string) error {
+	// 部分更新：未列出的字段使用当前值，合并后按完整数据校验
+	if len(updateFields) > 0 {
+		if llmProvider.ID == uuid.Nil {
+			return apperror.New(apperror.CodeInvalidArgument, "部分更新时必须提供提供商ID")
+		}
+		existing, err := s.llmProviderRepo.GetByID(ctx, llmProvider.ID)
+		if err != nil {
+			return apperror.Wrap(apperror.CodeNotFound, "提供商不存在", err)
+		}
+		if err := mergeUpdateFields(llmProvider, existing, updateFields, "Version"); err != nil {
+			return err
+		}
+	}
+
 	// 数据验证
 	if err := s.validateLlmProvider(llmProvider); err != nil {
 		return err
@@ -92,17 +111,31 @@ func (s *llmProviderService) SaveLlmProvider(ctx context.Context, llmProvider *m
 	if llmProvider.ID == uuid.Nil {
 		// 新增：生成新的UUID
 		llmProvider.ID = uuid.New()
+		llmProvider.Version = 1
 		err = s.llmProviderRepo.Create(ctx, llmProvider)
 	} else {
 		// 更新：检查记录是否存在
-		existing, err := s.llmProviderRepo.GetByID(ctx, llmProvider.ID)
-		if err != nil {
-			return fmt.Errorf("提供商不存在: %w", err)
+		existing, getErr := s.llmProviderRepo.GetByID(ctx, llmProvider.ID)
+		if getErr != nil {
+			return fmt.Errorf("提供商不存在: %w", getErr)
 		}
 		if existing == nil {
 			return fmt.Errorf("提供商不存在")
 		}
-		err = s.llmProviderRepo.Update(ctx, llmProvider)
+		// 未提供版本号时以读取到的版本为准，仍可避免与并发保存互相覆盖
+		if llmProvider.Version > 0 && llmProvider.Version != existing.Version {
+			return versionConflictError("提供商", existing.Version)
+		}
+		llmProvider.CreatedAt = existing.CreatedAt
+		var updated bool
+		updated, err = s.llmProviderRepo.UpdateWithVersion(ctx, llmProvider, existing.Version)
+		if err == nil && !updated {
+			current, getErr := s.llmProviderRepo.GetByID(ctx, llmProvider.ID)
+			if getErr != nil {
+				return fmt.Errorf("提供商不存在: %w", getErr)
+			}
+			return versionConflictError("提供商", current.Version)
+		}
 	}
 
 	// 如果保存成功且有API配置，尝试获取并保存模型列表
@@ -174,6 +207,15 @@ func (s *llmProviderService) validateLlmProvider(llmProvider *models.Application
 		return fmt.Errorf("所属应用ID不能为空")
 	}
 
+	if llmProvider.MaxConcurrency < 0 {
+		return fmt.Errorf("并发上限不能小于0")
+	}
+
+	// 模拟提供商不调用外部接口，不需要 API URL 和 API Key
+	if llmProvider.Type == "mock" {
+		return nil
+	}
+
 	if llmProvider.ApiUrl == "" {
 		return fmt.Errorf("API URL不能为空")
 	}
@@ -204,17 +246,10 @@ func (s *llmProviderService) handleIconSave(llmProvider *models.ApplicationLlmPr
 	header := parts[0]
 	data := parts[1]
 
-	// 确定文件扩展名
-	var ext string
-	if strings.Contains(header, "image/jpeg") || strings.Contains(header, "image/jpg") {
-		ext = ".jpg"
-	} else if strings.Contains(header, "image/png") {
-		ext = ".png"
-	} else if strings.Contains(header, "image/gif") {
-		ext = ".gif"
-	} else if strings.Contains(header, "image/webp") {
-		ext = ".webp"
-	} else {
+	// 验证图片格式
+	if !strings.Contains(header, "image/jpeg") && !strings.Contains(header, "image/jpg") &&
+		!strings.Contains(header, "image/png") && !strings.Contains(header, "image/gif") &&
+		!strings.Contains(header, "image/webp") {
 		return fmt.Errorf("不支持的图片格式")
 	}
 
@@ -246,19 +281,19 @@ func (s *llmProviderService) handleIconSave(llmProvider *models.ApplicationLlmPr
 			// 检查旧文件是否存在并删除
 			oldFilePath := filepath.Join(workspacePath, existing.IconUrl)
 			if _, err := os.Stat(oldFilePath); err == nil {
-				// 文件存在，删除它
+				// 文件存在，删除它及其缩略图
 				_ = os.Remove(oldFilePath)
+				for _, size := range define.ImageThumbnailSizes {
+					_ = os.Remove(filepath.Join(filepath.Dir(oldFilePath), utils.ThumbnailFileName(filepath.Base(oldFilePath), size)))
+				}
 			}
 		}
 	}
 
-	// 生成新的随机文件名
-	newFileName := uuid.New().String() + ext
-	filePath := filepath.Join(saveDir, newFileName)
-
-	// 保存文件
-	if err := os.WriteFile(filePath, imageData, 0644); err != nil {
-		return fmt.Errorf("保存文件失败: %w", err)
+	// 转换为 WebP 保存，并生成标准尺寸缩略图
+	newFileName, err := utils.SaveImageAsWebp(bytes.NewReader(imageData), saveDir, uuid.New().String())
+	if err != nil {
+		return err
 	}
 
 	// 更新 IconUrl 为相对路径（以 WorkspaceDirNameLlmProviderIcon 开头）
@@ -0,0 +1,233 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/manager"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// mcpOauthPendingTTL 授权请求的有效期，超过后回调不再接受
+const mcpOauthPendingTTL = 10 * time.Minute
+
+// mcpOauthClientName 动态注册OAuth客户端时使用的客户端名称
+const mcpOauthClientName = "Lemon-Tree MCP Client"
+
+// McpOauthService MCP服务OAuth授权 业务逻辑层接口
+// 管理员在浏览器中完成授权，令牌加密保存后由MCP客户端自动携带和刷新
+type McpOauthService interface {
+	// StartAuthorization 发起OAuth授权
+	// 未配置客户端ID时先通过动态注册获取，返回需要在浏览器中打开的授权地址
+	StartAuthorization(ctx context.Context, configID uuid.UUID) (string, error)
+
+	// CompleteAuthorization 处理授权服务器的回调
+	// 按 state 找到进行中的授权请求，使用授权码换取令牌并保存，返回授权的MCP配置
+	CompleteAuthorization(ctx context.Context, state, code string) (*models.ApplicationMcpServerConfig, error)
+
+	// GetAuthorizationStatus 获取MCP配置的OAuth授权状态
+	GetAuthorizationStatus(ctx context.Context, configID uuid.UUID) (*dto.McpOauthStatusDto, error)
+
+	// RevokeAuthorization 删除MCP配置保存的OAuth令牌，之后需重新授权才能访问MCP服务
+	RevokeAuthorization(ctx context.Context, configID uuid.UUID) error
+}
+
+// mcpOauthService MCP服务OAuth授权 业务逻辑层实现
+type mcpOauthService struct {
+	configRepo repository.ApplicationMcpServerConfigRepository     // MCP配置数据访问层
+	tokenRepo  repository.ApplicationMcpServerOauthTokenRepository // OAuth令牌数据访问层
+}
+
+// NewMcpOauthService 创建 MCP服务OAuth授权 服务实例
+// 返回 McpOauthService 接口的实现
+func NewMcpOauthService(
+	configRepo repository.ApplicationMcpServerConfigRepository,
+	tokenRepo repository.ApplicationMcpServerOauthTokenRepository,
+) McpOauthService {
+	return &mcpOauthService{
+		configRepo: configRepo,
+		tokenRepo:  tokenRepo,
+	}
+}
+
+// StartAuthorization 发起OAuth授权
+// 使用 PKCE，校验码加密保存在令牌记录中，回调时取出
+func (s *mcpOauthService) StartAuthorization(ctx context.Context, configID uuid.UUID) (string, error) {
+	mcpConfig, err := s.getOauthConfig(ctx, configID)
+	if err != nil {
+		return "", err
+	}
+	handler, err := newMcpOauthHandler(mcpConfig)
+	if err != nil {
+		return "", err
+	}
+
+	// 未配置客户端ID时动态注册，注册得到的客户端保存到MCP配置中
+	if mcpConfig.McpOauthClientID == "" {
+		if err := handler.RegisterClient(ctx, mcpOauthClientName); err != nil {
+			return "", apperror.Wrap(apperror.CodeInvalidArgument, "MCP服务不支持动态注册OAuth客户端，请填写客户端ID", err)
+		}
+		clientSecret, err := manager.EncryptMcpOauthSecret(handler.GetClientSecret())
+		if err != nil {
+			return "", apperror.Wrap(apperror.CodeInternal, "加密OAuth客户端密钥失败", err)
+		}
+		mcpConfig.McpOauthClientID = handler.GetClientID()
+		mcpConfig.McpOauthClientSecret = clientSecret
+		if err := s.configRepo.Update(ctx, mcpConfig); err != nil {
+			return "", apperror.Wrap(apperror.CodeInternal, "保存OAuth客户端失败", err)
+		}
+	}
+
+	codeVerifier, err := transport.GenerateCodeVerifier()
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "生成PKCE校验码失败", err)
+	}
+	state, err := transport.GenerateState()
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "生成授权请求state失败", err)
+	}
+	authorizationURL, err := handler.GetAuthorizationURL(ctx, state, transport.GenerateCodeChallenge(codeVerifier))
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeServiceUnavailable, "获取OAuth授权服务器信息失败", err)
+	}
+
+	record, err := s.tokenRepo.GetByConfigID(ctx, mcpConfig.ID)
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "查询OAuth令牌失败", err)
+	}
+	if record == nil {
+		record = &models.ApplicationMcpServerOauthToken{
+			ApplicationID:                mcpConfig.ApplicationID,
+			ApplicationMcpServerConfigID: mcpConfig.ID,
+		}
+	}
+	encryptedVerifier, err := manager.EncryptMcpOauthSecret(codeVerifier)
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "加密PKCE校验码失败", err)
+	}
+	pendingExpiresAt := time.Now().Add(mcpOauthPendingTTL)
+	record.PendingState = state
+	record.PendingCodeVerifier = encryptedVerifier
+	record.PendingExpiresAt = &pendingExpiresAt
+	if err := s.tokenRepo.Save(ctx, record); err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "保存授权请求失败", err)
+	}
+
+	return authorizationURL, nil
+}
+
+// CompleteAuthorization 处理授权服务器的回调
+// 授权请求只能使用一次，换取令牌前先清除
+func (s *mcpOauthService) CompleteAuthorization(ctx context.Context, state, code string) (*models.ApplicationMcpServerConfig, error) {
+	record, err := s.tokenRepo.GetByPendingState(ctx, state)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询授权请求失败", err)
+	}
+	if record == nil {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "授权请求不存在或已完成，请重新发起授权")
+	}
+	expired := record.PendingExpiresAt == nil || time.Now().After(*record.PendingExpiresAt)
+	codeVerifier, decryptErr := manager.DecryptMcpOauthSecret(record.PendingCodeVerifier)
+
+	record.PendingState = ""
+	record.PendingCodeVerifier = ""
+	record.PendingExpiresAt = nil
+	if err := s.tokenRepo.Save(ctx, record); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "保存授权请求失败", err)
+	}
+	if expired {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "授权请求已过期，请重新发起授权")
+	}
+	if decryptErr != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "解密PKCE校验码失败", decryptErr)
+	}
+	if code == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "授权回调缺少授权码")
+	}
+
+	mcpConfig, err := s.getOauthConfig(ctx, record.ApplicationMcpServerConfigID)
+	if err != nil {
+		return nil, err
+	}
+	handler, err := newMcpOauthHandler(mcpConfig)
+	if err != nil {
+		return nil, err
+	}
+	handler.SetExpectedState(state)
+	// 换取的令牌通过令牌存储加密保存
+	if err := handler.ProcessAuthorizationResponse(ctx, code, state, codeVerifier); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "使用授权码换取令牌失败", err)
+	}
+	return mcpConfig, nil
+}
+
+// GetAuthorizationStatus 获取MCP配置的OAuth授权状态
+func (s *mcpOauthService) GetAuthorizationStatus(ctx context.Context, configID uuid.UUID) (*dto.McpOauthStatusDto, error) {
+	if _, err := s.getOauthConfig(ctx, configID); err != nil {
+		return nil, err
+	}
+	record, err := s.tokenRepo.GetByConfigID(ctx, configID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询OAuth令牌失败", err)
+	}
+
+	status := &dto.McpOauthStatusDto{ConfigID: configID.String()}
+	if record == nil {
+		return status, nil
+	}
+	status.Authorized = record.AccessToken != "" || record.RefreshToken != ""
+	status.Refreshable = record.RefreshToken != ""
+	status.Scope = record.Scope
+	if record.ExpiresAt != nil {
+		status.ExpiresAt = record.ExpiresAt.UnixMilli()
+	}
+	status.Pending = record.PendingState != "" && record.PendingExpiresAt != nil && time.Now().Before(*record.PendingExpiresAt)
+	return status, nil
+}
+
+// RevokeAuthorization 删除MCP配置保存的OAuth令牌
+func (s *mcpOauthService) RevokeAuthorization(ctx context.Context, configID uuid.UUID) error {
+	if _, err := s.configRepo.GetByID(ctx, configID); err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "MCP配置不存在", err)
+	}
+	if err := s.tokenRepo.DeleteByConfigID(ctx, configID); err != nil {
+		return apperror.Wrap(apperror.CodeInternal, "删除OAuth令牌失败", err)
+	}
+	return nil
+}
+
+// getOauthConfig 获取使用OAuth授权的MCP配置
+func (s *mcpOauthService) getOauthConfig(ctx context.Context, configID uuid.UUID) (*models.ApplicationMcpServerConfig, error) {
+	mcpConfig, err := s.configRepo.GetByID(ctx, configID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "MCP配置不存在", err)
+	}
+	if !manager.UsesMcpOauth(mcpConfig) {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "MCP配置未启用OAuth授权")
+	}
+	return mcpConfig, nil
+}
+
+// newMcpOauthHandler 创建MCP配置的OAuth处理器
+// 授权服务器元数据根据MCP服务URL的地址发现，与传输层的发现方式一致
+func newMcpOauthHandler(mcpConfig *models.ApplicationMcpServerConfig) (*transport.OAuthHandler, error) {
+	oauthConfig, err := manager.NewMcpOauthConfig(mcpConfig)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeServiceUnavailable, err.Error(), err)
+	}
+	serverURL, err := url.Parse(mcpConfig.McpServerUrl)
+	if err != nil || serverURL.Scheme == "" || serverURL.Host == "" {
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "MCP服务URL无效: %s", mcpConfig.McpServerUrl)
+	}
+	handler := transport.NewOAuthHandler(oauthConfig)
+	handler.SetBaseURL(serverURL.Scheme + "://" + serverURL.Host)
+	return handler, nil
+}
@@ -0,0 +1,275 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/manager"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/utils"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// 导入时跳过MCP服务的原因
+const (
+	mcpServerImportSkippedDisabled = "disabled" // 服务已禁用
+	mcpServerImportSkippedExists   = "exists"   // 应用中已有同名配置
+)
+
+// mcpServerImportDefaultDescription 导入的MCP服务未提供描述时使用的描述
+const mcpServerImportDefaultDescription = "从 mcpServers JSON 导入"
+
+// mcpServerImportDefaultVersion 导入的MCP配置的版本
+const mcpServerImportDefaultVersion = "1.0.0"
+
+// mcpServerExportMaskedValue 导出时替换环境变量和请求头值的掩码
+const mcpServerExportMaskedValue = "******"
+
+// ImportMcpServers 从标准 mcpServers JSON 批量创建MCP配置
+// 全部服务校验通过后在同一事务中创建，任一服务无效时不创建任何配置；按服务名称顺序创建
+func (s *applicationMcpServerConfigService) ImportMcpServers(ctx context.Context, applicationID uuid.UUID, servers map[string]dto.McpServerJsonEntry, skipExisting bool) ([]*models.ApplicationMcpServerConfig, []dto.ImportMcpServerSkippedDto, error) {
+	if applicationID == uuid.Nil {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "所属应用ID不能为空")
+	}
+	if len(servers) == 0 {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "mcpServers 中没有MCP服务")
+	}
+
+	existingConfigs, err := s.applicationMcpServerConfigRepo.GetByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInternal, "查询MCP配置失败", err)
+	}
+	existingNames := make(map[string]bool, len(existingConfigs))
+	for _, config := range existingConfigs {
+		existingNames[config.Name] = true
+	}
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var configs []*models.ApplicationMcpServerConfig
+	skipped := []dto.ImportMcpServerSkippedDto{}
+	for _, name := range names {
+		entry := servers[name]
+		if entry.Disabled {
+			skipped = append(skipped, dto.ImportMcpServerSkippedDto{Name: name, Reason: mcpServerImportSkippedDisabled})
+			continue
+		}
+		if existingNames[strings.TrimSpace(name)] {
+			if !skipExisting {
+				return nil, nil, mcpServerImportError(name, "应用中已有同名MCP配置")
+			}
+			skipped = append(skipped, dto.ImportMcpServerSkippedDto{Name: name, Reason: mcpServerImportSkippedExists})
+			continue
+		}
+
+		config, err := mcpServerJsonEntryToConfig(applicationID, name, &entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.validateApplicationMcpServerConfig(config); err != nil {
+			return nil, nil, mcpServerImportError(name, err.Error())
+		}
+		configs = append(configs, config)
+	}
+
+	if err := s.applicationMcpServerConfigRepo.BatchCreate(ctx, configs); err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInternal, "保存MCP配置失败", err)
+	}
+	return configs, skipped, nil
+}
+
+// ExportMcpServers 导出应用的MCP配置为标准 mcpServers JSON
+// 同名配置在名称后追加序号；maskSecrets 为 true 时环境变量和请求头的值替换为掩码，OAuth客户端密钥不导出
+func (s *applicationMcpServerConfigService) ExportMcpServers(ctx context.Context, applicationID uuid.UUID, maskSecrets bool) (map[string]dto.McpServerJsonEntry, error) {
+	configs, err := s.applicationMcpServerConfigRepo.GetByApplicationID(ctx, applicationID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询MCP配置失败", err)
+	}
+
+	servers := make(map[string]dto.McpServerJsonEntry, len(configs))
+	for _, config := range configs {
+		entry, err := mcpServerConfigToJsonEntry(config, maskSecrets)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeInternal, "导出MCP配置 "+config.Name+" 失败", err)
+		}
+		name := config.Name
+		for i := 2; ; i++ {
+			if _, exists := servers[name]; !exists {
+				break
+			}
+			name = fmt.Sprintf("%s-%d", config.Name, i)
+		}
+		servers[name] = *entry
+	}
+	return servers, nil
+}
+
+// mcpServerConfigToJsonEntry 将MCP配置转换为 mcpServers JSON 中的单个服务
+func mcpServerConfigToJsonEntry(config *models.ApplicationMcpServerConfig, maskSecrets bool) (*dto.McpServerJsonEntry, error) {
+	entry := &dto.McpServerJsonEntry{
+		Timeout:     config.McpServerTimeout,
+		Description: config.Description,
+	}
+	switch config.McpServerConnectType {
+	case "stdio":
+		args, err := manager.StdioArgs(config)
+		if err != nil {
+			return nil, err
+		}
+		entry.Type = "stdio"
+		entry.Command = config.McpServerCommand
+		entry.Args = args
+		entry.Cwd = config.McpServerWorkDir
+		entry.Env = splitPairs(config.McpServerEnv, "=", maskSecrets)
+	case "sse":
+		entry.Type = "sse"
+		entry.URL = config.McpServerUrl
+		entry.Headers = splitPairs(config.McpServerHeader, ":", maskSecrets)
+	default:
+		entry.Type = "http"
+		entry.URL = config.McpServerUrl
+		entry.Headers = splitPairs(config.McpServerHeader, ":", maskSecrets)
+	}
+	return entry, nil
+}
+
+// mcpServerJsonEntryToConfig 将 mcpServers JSON 中的单个服务转换为MCP配置
+// 命令参数保存为 JSON 字符串数组，环境变量保存为每行一个 KEY=VALUE，请求头保存为每行一个 Name: Value
+func mcpServerJsonEntryToConfig(applicationID uuid.UUID, name string, entry *dto.McpServerJsonEntry) (*models.ApplicationMcpServerConfig, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "MCP服务名称不能为空")
+	}
+	connectType, err := mcpServerJsonConnectType(entry)
+	if err != nil {
+		return nil, mcpServerImportError(name, err.Error())
+	}
+
+	id := uuid.New()
+	configID, err := utils.ShortUUID(id.String())
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "生成MCP配置ID失败", err)
+	}
+	description := strings.TrimSpace(entry.Description)
+	if description == "" {
+		description = mcpServerImportDefaultDescription
+	}
+	config := &models.ApplicationMcpServerConfig{
+		ConfigID:             configID,
+		ApplicationID:        applicationID,
+		Name:                 name,
+		Description:          description,
+		Version:              mcpServerImportDefaultVersion,
+		McpServerConnectType: connectType,
+		McpServerTimeout:     entry.Timeout,
+	}
+	config.ID = id
+
+	if connectType == "stdio" {
+		config.McpServerCommand = strings.TrimSpace(entry.Command)
+		config.McpServerWorkDir = strings.TrimSpace(entry.Cwd)
+		if len(entry.Args) > 0 {
+			args, err := json.Marshal(entry.Args)
+			if err != nil {
+				return nil, mcpServerImportError(name, "命令参数无效")
+			}
+			config.McpServerArgs = string(args)
+		}
+		config.McpServerEnv = joinSortedPairs(entry.Env, "=")
+		return config, nil
+	}
+
+	config.McpServerUrl = mcpServerJsonURL(entry)
+	config.McpServerHeader = joinSortedPairs(entry.Headers, ": ")
+	return config, nil
+}
+
+// mcpServerJsonConnectType 确定 mcpServers JSON 中服务的连接方式
+// 未指定 type 时有 command 为 stdio；只有 url 时路径以 /sse 结尾为 sse，否则为 streamable-http
+func mcpServerJsonConnectType(entry *dto.McpServerJsonEntry) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(entry.Type)) {
+	case "stdio":
+		return "stdio", nil
+	case "sse":
+		return "sse", nil
+	case "http", "streamable-http", "streamablehttp", "streamable_http":
+		return "streamable-http", nil
+	case "":
+	default:
+		return "", apperror.Newf(apperror.CodeInvalidArgument, "不支持的连接方式: %s", entry.Type)
+	}
+
+	if strings.TrimSpace(entry.Command) != "" {
+		return "stdio", nil
+	}
+	serverURL := mcpServerJsonURL(entry)
+	if serverURL == "" {
+		return "", apperror.New(apperror.CodeInvalidArgument, "缺少 command 或 url")
+	}
+	if parsed, err := url.Parse(serverURL); err == nil && strings.HasSuffix(strings.TrimRight(parsed.Path, "/"), "/sse") {
+		return "sse", nil
+	}
+	return "streamable-http", nil
+}
+
+// mcpServerJsonURL 获取 mcpServers JSON 中服务的URL，url 为空时使用 serverUrl
+func mcpServerJsonURL(entry *dto.McpServerJsonEntry) string {
+	if serverURL := strings.TrimSpace(entry.URL); serverURL != "" {
+		return serverURL
+	}
+	return strings.TrimSpace(entry.ServerURL)
+}
+
+// joinSortedPairs 将键值对按键排序后拼接为每行一项的文本
+func joinSortedPairs(pairs map[string]string, separator string) string {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+separator+pairs[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitPairs 将每行一项的文本拆分为键值对，忽略空行和 # 开头的注释行
+// mask 为 true 时值替换为掩码
+func splitPairs(text, separator string, mask bool) map[string]string {
+	pairs := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, separator)
+		value = strings.TrimSpace(value)
+		if mask && value != "" {
+			value = mcpServerExportMaskedValue
+		}
+		pairs[strings.TrimSpace(key)] = value
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	return pairs
+}
+
+// mcpServerImportError 生成导入单个MCP服务失败的错误，错误详情包含服务名称
+func mcpServerImportError(name, message string) error {
+	return apperror.Newf(apperror.CodeInvalidArgument, "导入MCP服务 %s 失败: %s", name, message).
+		WithDetails(map[string]string{"server": name})
+}
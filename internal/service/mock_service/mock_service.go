@@ -0,0 +1,3777 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lemon-tree-core/internal/service (interfaces: ApplicationBackupService,ApplicationLlmService,ApplicationMcpServerConfigService,ApplicationService,ApplicationSettingService,ApplicationStorageConfigService,ApplicationToolBundleService,ApplicationWebhookService,BatchInferenceService,BootstrapService,ChatAgentAnswerRuleService,ChatAgentApiKeyService,ChatAgentConversationService,ChatAgentMcpServerToolService,ChatAgentService,ChatAgentToolUsageService,ChatMessagePersistenceService,ChatStreamResumeService,ChatWidgetTokenService,ClusterService,ConfigReloadService,ConversationArchiveService,ConversationEventService,ConversationExportService,ConversationMonitorService,ConversationPresenceService,ConversationReplayService,ConversationVariableService,EvaluationService,FeatureFlagService,KnowledgeBaseService,LlmProviderDefineService,LlmProviderLimiter,LlmProviderService,McpOauthService,OidcLoginService,ServiceUserService,UserService,WorkspaceUploadService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_service/mock_service.go -package=mock_service lemon-tree-core/internal/service ApplicationBackupService,ApplicationLlmService,ApplicationMcpServerConfigService,ApplicationService,ApplicationSettingService,ApplicationStorageConfigService,ApplicationToolBundleService,ApplicationWebhookService,BatchInferenceService,BootstrapService,ChatAgentAnswerRuleService,ChatAgentApiKeyService,ChatAgentConversationService,ChatAgentMcpServerToolService,ChatAgentService,ChatAgentToolUsageService,ChatMessagePersistenceService,ChatStreamResumeService,ChatWidgetTokenService,ClusterService,ConfigReloadService,ConversationArchiveService,ConversationEventService,ConversationExportService,ConversationMonitorService,ConversationPresenceService,ConversationReplayService,ConversationVariableService,EvaluationService,FeatureFlagService,KnowledgeBaseService,LlmProviderDefineService,LlmProviderLimiter,LlmProviderService,McpOauthService,OidcLoginService,ServiceUserService,UserService,WorkspaceUploadService
+//
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	io "io"
+	al_client "lemon-tree-core/internal/al_client"
+	define "lemon-tree-core/internal/define"
+	dto "lemon-tree-core/internal/dto"
+	models "lemon-tree-core/internal/models"
+	repository "lemon-tree-core/internal/repository"
+	service "lemon-tree-core/internal/service"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApplicationBackupService is a mock of ApplicationBackupService interface.
+type MockApplicationBackupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationBackupServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationBackupServiceMockRecorder is the mock recorder for MockApplicationBackupService.
+type MockApplicationBackupServiceMockRecorder struct {
+	mock *MockApplicationBackupService
+}
+
+// NewMockApplicationBackupService creates a new mock instance.
+func NewMockApplicationBackupService(ctrl *gomock.Controller) *MockApplicationBackupService {
+	mock := &MockApplicationBackupService{ctrl: ctrl}
+	mock.recorder = &MockApplicationBackupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationBackupService) EXPECT() *MockApplicationBackupServiceMockRecorder {
+	return m.recorder
+}
+
+// BackupApplication mocks base method.
+func (m *MockApplicationBackupService) BackupApplication(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationBackup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupApplication", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackupApplication indicates an expected call of BackupApplication.
+func (mr *MockApplicationBackupServiceMockRecorder) BackupApplication(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupApplication", reflect.TypeOf((*MockApplicationBackupService)(nil).BackupApplication), ctx, applicationID)
+}
+
+// RestoreApplication mocks base method.
+func (m *MockApplicationBackupService) RestoreApplication(ctx context.Context, backup *models.ApplicationBackup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreApplication", ctx, backup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreApplication indicates an expected call of RestoreApplication.
+func (mr *MockApplicationBackupServiceMockRecorder) RestoreApplication(ctx, backup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreApplication", reflect.TypeOf((*MockApplicationBackupService)(nil).RestoreApplication), ctx, backup)
+}
+
+// MockApplicationLlmService is a mock of ApplicationLlmService interface.
+type MockApplicationLlmService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationLlmServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationLlmServiceMockRecorder is the mock recorder for MockApplicationLlmService.
+type MockApplicationLlmServiceMockRecorder struct {
+	mock *MockApplicationLlmService
+}
+
+// NewMockApplicationLlmService creates a new mock instance.
+func NewMockApplicationLlmService(ctrl *gomock.Controller) *MockApplicationLlmService {
+	mock := &MockApplicationLlmService{ctrl: ctrl}
+	mock.recorder = &MockApplicationLlmServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationLlmService) EXPECT() *MockApplicationLlmServiceMockRecorder {
+	return m.recorder
+}
+
+// FetchAndSaveModels mocks base method.
+func (m *MockApplicationLlmService) FetchAndSaveModels(ctx context.Context, llmProvider *models.ApplicationLlmProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAndSaveModels", ctx, llmProvider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchAndSaveModels indicates an expected call of FetchAndSaveModels.
+func (mr *MockApplicationLlmServiceMockRecorder) FetchAndSaveModels(ctx, llmProvider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAndSaveModels", reflect.TypeOf((*MockApplicationLlmService)(nil).FetchAndSaveModels), ctx, llmProvider)
+}
+
+// GetModelsByApplicationID mocks base method.
+func (m *MockApplicationLlmService) GetModelsByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModelsByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModelsByApplicationID indicates an expected call of GetModelsByApplicationID.
+func (mr *MockApplicationLlmServiceMockRecorder) GetModelsByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModelsByApplicationID", reflect.TypeOf((*MockApplicationLlmService)(nil).GetModelsByApplicationID), ctx, applicationID)
+}
+
+// GetModelsByProviderID mocks base method.
+func (m *MockApplicationLlmService) GetModelsByProviderID(ctx context.Context, providerID uuid.UUID) ([]*models.ApplicationLlm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModelsByProviderID", ctx, providerID)
+	ret0, _ := ret[0].([]*models.ApplicationLlm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModelsByProviderID indicates an expected call of GetModelsByProviderID.
+func (mr *MockApplicationLlmServiceMockRecorder) GetModelsByProviderID(ctx, providerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModelsByProviderID", reflect.TypeOf((*MockApplicationLlmService)(nil).GetModelsByProviderID), ctx, providerID)
+}
+
+// SaveApplicationLlm mocks base method.
+func (m *MockApplicationLlmService) SaveApplicationLlm(ctx context.Context, applicationLlm *models.ApplicationLlm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApplicationLlm", ctx, applicationLlm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveApplicationLlm indicates an expected call of SaveApplicationLlm.
+func (mr *MockApplicationLlmServiceMockRecorder) SaveApplicationLlm(ctx, applicationLlm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApplicationLlm", reflect.TypeOf((*MockApplicationLlmService)(nil).SaveApplicationLlm), ctx, applicationLlm)
+}
+
+// UpdateEnabledStatus mocks base method.
+func (m *MockApplicationLlmService) UpdateEnabledStatus(ctx context.Context, id uuid.UUID, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnabledStatus", ctx, id, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEnabledStatus indicates an expected call of UpdateEnabledStatus.
+func (mr *MockApplicationLlmServiceMockRecorder) UpdateEnabledStatus(ctx, id, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnabledStatus", reflect.TypeOf((*MockApplicationLlmService)(nil).UpdateEnabledStatus), ctx, id, enabled)
+}
+
+// MockApplicationMcpServerConfigService is a mock of ApplicationMcpServerConfigService interface.
+type MockApplicationMcpServerConfigService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationMcpServerConfigServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationMcpServerConfigServiceMockRecorder is the mock recorder for MockApplicationMcpServerConfigService.
+type MockApplicationMcpServerConfigServiceMockRecorder struct {
+	mock *MockApplicationMcpServerConfigService
+}
+
+// NewMockApplicationMcpServerConfigService creates a new mock instance.
+func NewMockApplicationMcpServerConfigService(ctrl *gomock.Controller) *MockApplicationMcpServerConfigService {
+	mock := &MockApplicationMcpServerConfigService{ctrl: ctrl}
+	mock.recorder = &MockApplicationMcpServerConfigServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationMcpServerConfigService) EXPECT() *MockApplicationMcpServerConfigServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteApplicationMcpServerConfig mocks base method.
+func (m *MockApplicationMcpServerConfigService) DeleteApplicationMcpServerConfig(ctx context.Context, id uuid.UUID) (*dto.ApplicationMcpServerConfigDeletionDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplicationMcpServerConfig", ctx, id)
+	ret0, _ := ret[0].(*dto.ApplicationMcpServerConfigDeletionDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApplicationMcpServerConfig indicates an expected call of DeleteApplicationMcpServerConfig.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) DeleteApplicationMcpServerConfig(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationMcpServerConfig", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).DeleteApplicationMcpServerConfig), ctx, id)
+}
+
+// ExportMcpServers mocks base method.
+func (m *MockApplicationMcpServerConfigService) ExportMcpServers(ctx context.Context, applicationID uuid.UUID, maskSecrets bool) (map[string]dto.McpServerJsonEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMcpServers", ctx, applicationID, maskSecrets)
+	ret0, _ := ret[0].(map[string]dto.McpServerJsonEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportMcpServers indicates an expected call of ExportMcpServers.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) ExportMcpServers(ctx, applicationID, maskSecrets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMcpServers", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).ExportMcpServers), ctx, applicationID, maskSecrets)
+}
+
+// GetMcpServerConfigsByApplicationID mocks base method.
+func (m *MockApplicationMcpServerConfigService) GetMcpServerConfigsByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationMcpServerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMcpServerConfigsByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMcpServerConfigsByApplicationID indicates an expected call of GetMcpServerConfigsByApplicationID.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) GetMcpServerConfigsByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMcpServerConfigsByApplicationID", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).GetMcpServerConfigsByApplicationID), ctx, applicationID)
+}
+
+// GetMcpServerTools mocks base method.
+func (m *MockApplicationMcpServerConfigService) GetMcpServerTools(ctx context.Context, configID uuid.UUID) ([]*models.ApplicationMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMcpServerTools", ctx, configID)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMcpServerTools indicates an expected call of GetMcpServerTools.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) GetMcpServerTools(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMcpServerTools", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).GetMcpServerTools), ctx, configID)
+}
+
+// ImportMcpServers mocks base method.
+func (m *MockApplicationMcpServerConfigService) ImportMcpServers(ctx context.Context, applicationID uuid.UUID, servers map[string]dto.McpServerJsonEntry, skipExisting bool) ([]*models.ApplicationMcpServerConfig, []dto.ImportMcpServerSkippedDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMcpServers", ctx, applicationID, servers, skipExisting)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerConfig)
+	ret1, _ := ret[1].([]dto.ImportMcpServerSkippedDto)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ImportMcpServers indicates an expected call of ImportMcpServers.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) ImportMcpServers(ctx, applicationID, servers, skipExisting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMcpServers", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).ImportMcpServers), ctx, applicationID, servers, skipExisting)
+}
+
+// SaveApplicationMcpServerConfig mocks base method.
+func (m *MockApplicationMcpServerConfigService) SaveApplicationMcpServerConfig(ctx context.Context, config *models.ApplicationMcpServerConfig, updateFields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApplicationMcpServerConfig", ctx, config, updateFields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveApplicationMcpServerConfig indicates an expected call of SaveApplicationMcpServerConfig.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) SaveApplicationMcpServerConfig(ctx, config, updateFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApplicationMcpServerConfig", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).SaveApplicationMcpServerConfig), ctx, config, updateFields)
+}
+
+// SyncMcpServerTools mocks base method.
+func (m *MockApplicationMcpServerConfigService) SyncMcpServerTools(ctx context.Context, configID uuid.UUID) ([]*models.ApplicationMcpServerTool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncMcpServerTools", ctx, configID)
+	ret0, _ := ret[0].([]*models.ApplicationMcpServerTool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncMcpServerTools indicates an expected call of SyncMcpServerTools.
+func (mr *MockApplicationMcpServerConfigServiceMockRecorder) SyncMcpServerTools(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncMcpServerTools", reflect.TypeOf((*MockApplicationMcpServerConfigService)(nil).SyncMcpServerTools), ctx, configID)
+}
+
+// MockApplicationService is a mock of ApplicationService interface.
+type MockApplicationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationServiceMockRecorder is the mock recorder for MockApplicationService.
+type MockApplicationServiceMockRecorder struct {
+	mock *MockApplicationService
+}
+
+// NewMockApplicationService creates a new mock instance.
+func NewMockApplicationService(ctrl *gomock.Controller) *MockApplicationService {
+	mock := &MockApplicationService{ctrl: ctrl}
+	mock.recorder = &MockApplicationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationService) EXPECT() *MockApplicationServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteApplication mocks base method.
+func (m *MockApplicationService) DeleteApplication(ctx context.Context, id uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplication", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApplication indicates an expected call of DeleteApplication.
+func (mr *MockApplicationServiceMockRecorder) DeleteApplication(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplication", reflect.TypeOf((*MockApplicationService)(nil).DeleteApplication), ctx, id)
+}
+
+// GetAllApplications mocks base method.
+func (m *MockApplicationService) GetAllApplications(ctx context.Context) ([]*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllApplications", ctx)
+	ret0, _ := ret[0].([]*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllApplications indicates an expected call of GetAllApplications.
+func (mr *MockApplicationServiceMockRecorder) GetAllApplications(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllApplications", reflect.TypeOf((*MockApplicationService)(nil).GetAllApplications), ctx)
+}
+
+// GetApplicationByID mocks base method.
+func (m *MockApplicationService) GetApplicationByID(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationByID", ctx, id)
+	ret0, _ := ret[0].(*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationByID indicates an expected call of GetApplicationByID.
+func (mr *MockApplicationServiceMockRecorder) GetApplicationByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationByID", reflect.TypeOf((*MockApplicationService)(nil).GetApplicationByID), ctx, id)
+}
+
+// GetDeletionJob mocks base method.
+func (m *MockApplicationService) GetDeletionJob(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationDeletionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletionJob", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationDeletionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletionJob indicates an expected call of GetDeletionJob.
+func (mr *MockApplicationServiceMockRecorder) GetDeletionJob(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletionJob", reflect.TypeOf((*MockApplicationService)(nil).GetDeletionJob), ctx, applicationID)
+}
+
+// ProcessDeletionJobs mocks base method.
+func (m *MockApplicationService) ProcessDeletionJobs(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDeletionJobs", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDeletionJobs indicates an expected call of ProcessDeletionJobs.
+func (mr *MockApplicationServiceMockRecorder) ProcessDeletionJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDeletionJobs", reflect.TypeOf((*MockApplicationService)(nil).ProcessDeletionJobs), ctx)
+}
+
+// QueryApplications mocks base method.
+func (m *MockApplicationService) QueryApplications(ctx context.Context, query *models.Application) ([]*models.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryApplications", ctx, query)
+	ret0, _ := ret[0].([]*models.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryApplications indicates an expected call of QueryApplications.
+func (mr *MockApplicationServiceMockRecorder) QueryApplications(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryApplications", reflect.TypeOf((*MockApplicationService)(nil).QueryApplications), ctx, query)
+}
+
+// SaveApplication mocks base method.
+func (m *MockApplicationService) SaveApplication(ctx context.Context, application *models.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApplication", ctx, application)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveApplication indicates an expected call of SaveApplication.
+func (mr *MockApplicationServiceMockRecorder) SaveApplication(ctx, application any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApplication", reflect.TypeOf((*MockApplicationService)(nil).SaveApplication), ctx, application)
+}
+
+// MockApplicationSettingService is a mock of ApplicationSettingService interface.
+type MockApplicationSettingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationSettingServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationSettingServiceMockRecorder is the mock recorder for MockApplicationSettingService.
+type MockApplicationSettingServiceMockRecorder struct {
+	mock *MockApplicationSettingService
+}
+
+// NewMockApplicationSettingService creates a new mock instance.
+func NewMockApplicationSettingService(ctrl *gomock.Controller) *MockApplicationSettingService {
+	mock := &MockApplicationSettingService{ctrl: ctrl}
+	mock.recorder = &MockApplicationSettingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationSettingService) EXPECT() *MockApplicationSettingServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteApplicationSetting mocks base method.
+func (m *MockApplicationSettingService) DeleteApplicationSetting(ctx context.Context, applicationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplicationSetting", ctx, applicationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteApplicationSetting indicates an expected call of DeleteApplicationSetting.
+func (mr *MockApplicationSettingServiceMockRecorder) DeleteApplicationSetting(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationSetting", reflect.TypeOf((*MockApplicationSettingService)(nil).DeleteApplicationSetting), ctx, applicationID)
+}
+
+// GetApplicationSetting mocks base method.
+func (m *MockApplicationSettingService) GetApplicationSetting(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationSetting", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationSetting indicates an expected call of GetApplicationSetting.
+func (mr *MockApplicationSettingServiceMockRecorder) GetApplicationSetting(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationSetting", reflect.TypeOf((*MockApplicationSettingService)(nil).GetApplicationSetting), ctx, applicationID)
+}
+
+// MatchBlockedInput mocks base method.
+func (m *MockApplicationSettingService) MatchBlockedInput(ctx context.Context, applicationID uuid.UUID, message string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchBlockedInput", ctx, applicationID, message)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MatchBlockedInput indicates an expected call of MatchBlockedInput.
+func (mr *MockApplicationSettingServiceMockRecorder) MatchBlockedInput(ctx, applicationID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchBlockedInput", reflect.TypeOf((*MockApplicationSettingService)(nil).MatchBlockedInput), ctx, applicationID, message)
+}
+
+// SaveApplicationSetting mocks base method.
+func (m *MockApplicationSettingService) SaveApplicationSetting(ctx context.Context, setting *models.ApplicationSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApplicationSetting", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveApplicationSetting indicates an expected call of SaveApplicationSetting.
+func (mr *MockApplicationSettingServiceMockRecorder) SaveApplicationSetting(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApplicationSetting", reflect.TypeOf((*MockApplicationSettingService)(nil).SaveApplicationSetting), ctx, setting)
+}
+
+// MockApplicationStorageConfigService is a mock of ApplicationStorageConfigService interface.
+type MockApplicationStorageConfigService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationStorageConfigServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationStorageConfigServiceMockRecorder is the mock recorder for MockApplicationStorageConfigService.
+type MockApplicationStorageConfigServiceMockRecorder struct {
+	mock *MockApplicationStorageConfigService
+}
+
+// NewMockApplicationStorageConfigService creates a new mock instance.
+func NewMockApplicationStorageConfigService(ctrl *gomock.Controller) *MockApplicationStorageConfigService {
+	mock := &MockApplicationStorageConfigService{ctrl: ctrl}
+	mock.recorder = &MockApplicationStorageConfigServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationStorageConfigService) EXPECT() *MockApplicationStorageConfigServiceMockRecorder {
+	return m.recorder
+}
+
+// GetApplicationStorageConfigByApplicationID mocks base method.
+func (m *MockApplicationStorageConfigService) GetApplicationStorageConfigByApplicationID(ctx context.Context, applicationID uuid.UUID) (*models.ApplicationStorageConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationStorageConfigByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].(*models.ApplicationStorageConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationStorageConfigByApplicationID indicates an expected call of GetApplicationStorageConfigByApplicationID.
+func (mr *MockApplicationStorageConfigServiceMockRecorder) GetApplicationStorageConfigByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationStorageConfigByApplicationID", reflect.TypeOf((*MockApplicationStorageConfigService)(nil).GetApplicationStorageConfigByApplicationID), ctx, applicationID)
+}
+
+// SaveApplicationStorageConfig mocks base method.
+func (m *MockApplicationStorageConfigService) SaveApplicationStorageConfig(ctx context.Context, config *models.ApplicationStorageConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApplicationStorageConfig", ctx, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveApplicationStorageConfig indicates an expected call of SaveApplicationStorageConfig.
+func (mr *MockApplicationStorageConfigServiceMockRecorder) SaveApplicationStorageConfig(ctx, config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApplicationStorageConfig", reflect.TypeOf((*MockApplicationStorageConfigService)(nil).SaveApplicationStorageConfig), ctx, config)
+}
+
+// MockApplicationToolBundleService is a mock of ApplicationToolBundleService interface.
+type MockApplicationToolBundleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationToolBundleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationToolBundleServiceMockRecorder is the mock recorder for MockApplicationToolBundleService.
+type MockApplicationToolBundleServiceMockRecorder struct {
+	mock *MockApplicationToolBundleService
+}
+
+// NewMockApplicationToolBundleService creates a new mock instance.
+func NewMockApplicationToolBundleService(ctrl *gomock.Controller) *MockApplicationToolBundleService {
+	mock := &MockApplicationToolBundleService{ctrl: ctrl}
+	mock.recorder = &MockApplicationToolBundleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationToolBundleService) EXPECT() *MockApplicationToolBundleServiceMockRecorder {
+	return m.recorder
+}
+
+// AttachChatAgents mocks base method.
+func (m *MockApplicationToolBundleService) AttachChatAgents(ctx context.Context, id uuid.UUID, chatAgentIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachChatAgents", ctx, id, chatAgentIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachChatAgents indicates an expected call of AttachChatAgents.
+func (mr *MockApplicationToolBundleServiceMockRecorder) AttachChatAgents(ctx, id, chatAgentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachChatAgents", reflect.TypeOf((*MockApplicationToolBundleService)(nil).AttachChatAgents), ctx, id, chatAgentIDs)
+}
+
+// DeleteToolBundle mocks base method.
+func (m *MockApplicationToolBundleService) DeleteToolBundle(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToolBundle", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToolBundle indicates an expected call of DeleteToolBundle.
+func (mr *MockApplicationToolBundleServiceMockRecorder) DeleteToolBundle(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToolBundle", reflect.TypeOf((*MockApplicationToolBundleService)(nil).DeleteToolBundle), ctx, id)
+}
+
+// DetachChatAgents mocks base method.
+func (m *MockApplicationToolBundleService) DetachChatAgents(ctx context.Context, id uuid.UUID, chatAgentIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachChatAgents", ctx, id, chatAgentIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachChatAgents indicates an expected call of DetachChatAgents.
+func (mr *MockApplicationToolBundleServiceMockRecorder) DetachChatAgents(ctx, id, chatAgentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachChatAgents", reflect.TypeOf((*MockApplicationToolBundleService)(nil).DetachChatAgents), ctx, id, chatAgentIDs)
+}
+
+// GetToolBundle mocks base method.
+func (m *MockApplicationToolBundleService) GetToolBundle(ctx context.Context, id uuid.UUID) (*models.ApplicationToolBundle, []uuid.UUID, []uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToolBundle", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationToolBundle)
+	ret1, _ := ret[1].([]uuid.UUID)
+	ret2, _ := ret[2].([]uuid.UUID)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetToolBundle indicates an expected call of GetToolBundle.
+func (mr *MockApplicationToolBundleServiceMockRecorder) GetToolBundle(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToolBundle", reflect.TypeOf((*MockApplicationToolBundleService)(nil).GetToolBundle), ctx, id)
+}
+
+// GetToolBundlesByApplicationID mocks base method.
+func (m *MockApplicationToolBundleService) GetToolBundlesByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToolBundlesByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToolBundlesByApplicationID indicates an expected call of GetToolBundlesByApplicationID.
+func (mr *MockApplicationToolBundleServiceMockRecorder) GetToolBundlesByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToolBundlesByApplicationID", reflect.TypeOf((*MockApplicationToolBundleService)(nil).GetToolBundlesByApplicationID), ctx, applicationID)
+}
+
+// GetToolBundlesByChatAgentID mocks base method.
+func (m *MockApplicationToolBundleService) GetToolBundlesByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ApplicationToolBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToolBundlesByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ApplicationToolBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToolBundlesByChatAgentID indicates an expected call of GetToolBundlesByChatAgentID.
+func (mr *MockApplicationToolBundleServiceMockRecorder) GetToolBundlesByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToolBundlesByChatAgentID", reflect.TypeOf((*MockApplicationToolBundleService)(nil).GetToolBundlesByChatAgentID), ctx, chatAgentID)
+}
+
+// SaveToolBundle mocks base method.
+func (m *MockApplicationToolBundleService) SaveToolBundle(ctx context.Context, bundle *models.ApplicationToolBundle, toolIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveToolBundle", ctx, bundle, toolIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveToolBundle indicates an expected call of SaveToolBundle.
+func (mr *MockApplicationToolBundleServiceMockRecorder) SaveToolBundle(ctx, bundle, toolIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveToolBundle", reflect.TypeOf((*MockApplicationToolBundleService)(nil).SaveToolBundle), ctx, bundle, toolIDs)
+}
+
+// MockApplicationWebhookService is a mock of ApplicationWebhookService interface.
+type MockApplicationWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApplicationWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApplicationWebhookServiceMockRecorder is the mock recorder for MockApplicationWebhookService.
+type MockApplicationWebhookServiceMockRecorder struct {
+	mock *MockApplicationWebhookService
+}
+
+// NewMockApplicationWebhookService creates a new mock instance.
+func NewMockApplicationWebhookService(ctrl *gomock.Controller) *MockApplicationWebhookService {
+	mock := &MockApplicationWebhookService{ctrl: ctrl}
+	mock.recorder = &MockApplicationWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApplicationWebhookService) EXPECT() *MockApplicationWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockApplicationWebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockApplicationWebhookServiceMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockApplicationWebhookService)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockApplicationWebhookService) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, pageSize int) ([]*models.ApplicationWebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockApplicationWebhookServiceMockRecorder) GetDeliveries(ctx, webhookID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockApplicationWebhookService)(nil).GetDeliveries), ctx, webhookID, status, page, pageSize)
+}
+
+// GetWebhook mocks base method.
+func (m *MockApplicationWebhookService) GetWebhook(ctx context.Context, id uuid.UUID) (*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockApplicationWebhookServiceMockRecorder) GetWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockApplicationWebhookService)(nil).GetWebhook), ctx, id)
+}
+
+// GetWebhooksByApplicationID mocks base method.
+func (m *MockApplicationWebhookService) GetWebhooksByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksByApplicationID indicates an expected call of GetWebhooksByApplicationID.
+func (mr *MockApplicationWebhookServiceMockRecorder) GetWebhooksByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByApplicationID", reflect.TypeOf((*MockApplicationWebhookService)(nil).GetWebhooksByApplicationID), ctx, applicationID)
+}
+
+// RetryDelivery mocks base method.
+func (m *MockApplicationWebhookService) RetryDelivery(ctx context.Context, deliveryID uuid.UUID) (*models.ApplicationWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDelivery", ctx, deliveryID)
+	ret0, _ := ret[0].(*models.ApplicationWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryDelivery indicates an expected call of RetryDelivery.
+func (mr *MockApplicationWebhookServiceMockRecorder) RetryDelivery(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDelivery", reflect.TypeOf((*MockApplicationWebhookService)(nil).RetryDelivery), ctx, deliveryID)
+}
+
+// SaveWebhook mocks base method.
+func (m *MockApplicationWebhookService) SaveWebhook(ctx context.Context, webhook *models.ApplicationWebhook, regenerateSecret bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhook", ctx, webhook, regenerateSecret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhook indicates an expected call of SaveWebhook.
+func (mr *MockApplicationWebhookServiceMockRecorder) SaveWebhook(ctx, webhook, regenerateSecret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhook", reflect.TypeOf((*MockApplicationWebhookService)(nil).SaveWebhook), ctx, webhook, regenerateSecret)
+}
+
+// MockBatchInferenceService is a mock of BatchInferenceService interface.
+type MockBatchInferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchInferenceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchInferenceServiceMockRecorder is the mock recorder for MockBatchInferenceService.
+type MockBatchInferenceServiceMockRecorder struct {
+	mock *MockBatchInferenceService
+}
+
+// NewMockBatchInferenceService creates a new mock instance.
+func NewMockBatchInferenceService(ctrl *gomock.Controller) *MockBatchInferenceService {
+	mock := &MockBatchInferenceService{ctrl: ctrl}
+	mock.recorder = &MockBatchInferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchInferenceService) EXPECT() *MockBatchInferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelJob mocks base method.
+func (m *MockBatchInferenceService) CancelJob(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJob", ctx, id)
+	ret0, _ := ret[0].(*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockBatchInferenceServiceMockRecorder) CancelJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockBatchInferenceService)(nil).CancelJob), ctx, id)
+}
+
+// CreateJob mocks base method.
+func (m *MockBatchInferenceService) CreateJob(ctx context.Context, job *models.BatchInferenceJob, prompts []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job, prompts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockBatchInferenceServiceMockRecorder) CreateJob(ctx, job, prompts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockBatchInferenceService)(nil).CreateJob), ctx, job, prompts)
+}
+
+// ExportJobResults mocks base method.
+func (m *MockBatchInferenceService) ExportJobResults(ctx context.Context, id uuid.UUID, format string) (*service.BatchInferenceJobResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportJobResults", ctx, id, format)
+	ret0, _ := ret[0].(*service.BatchInferenceJobResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportJobResults indicates an expected call of ExportJobResults.
+func (mr *MockBatchInferenceServiceMockRecorder) ExportJobResults(ctx, id, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportJobResults", reflect.TypeOf((*MockBatchInferenceService)(nil).ExportJobResults), ctx, id, format)
+}
+
+// GetJob mocks base method.
+func (m *MockBatchInferenceService) GetJob(ctx context.Context, id uuid.UUID) (*models.BatchInferenceJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*models.BatchInferenceJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockBatchInferenceServiceMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockBatchInferenceService)(nil).GetJob), ctx, id)
+}
+
+// GetJobItems mocks base method.
+func (m *MockBatchInferenceService) GetJobItems(ctx context.Context, jobID uuid.UUID, status string, page, pageSize int) ([]*models.BatchInferenceItem, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobItems", ctx, jobID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.BatchInferenceItem)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJobItems indicates an expected call of GetJobItems.
+func (mr *MockBatchInferenceServiceMockRecorder) GetJobItems(ctx, jobID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobItems", reflect.TypeOf((*MockBatchInferenceService)(nil).GetJobItems), ctx, jobID, status, page, pageSize)
+}
+
+// GetJobsByChatAgentID mocks base method.
+func (m *MockBatchInferenceService) GetJobsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.BatchInferenceJob, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobsByChatAgentID", ctx, chatAgentID, page, pageSize)
+	ret0, _ := ret[0].([]*models.BatchInferenceJob)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetJobsByChatAgentID indicates an expected call of GetJobsByChatAgentID.
+func (mr *MockBatchInferenceServiceMockRecorder) GetJobsByChatAgentID(ctx, chatAgentID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobsByChatAgentID", reflect.TypeOf((*MockBatchInferenceService)(nil).GetJobsByChatAgentID), ctx, chatAgentID, page, pageSize)
+}
+
+// ParsePromptFile mocks base method.
+func (m *MockBatchInferenceService) ParsePromptFile(fileName string, reader io.Reader) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParsePromptFile", fileName, reader)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParsePromptFile indicates an expected call of ParsePromptFile.
+func (mr *MockBatchInferenceServiceMockRecorder) ParsePromptFile(fileName, reader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParsePromptFile", reflect.TypeOf((*MockBatchInferenceService)(nil).ParsePromptFile), fileName, reader)
+}
+
+// ProcessPendingJobs mocks base method.
+func (m *MockBatchInferenceService) ProcessPendingJobs(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingJobs", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessPendingJobs indicates an expected call of ProcessPendingJobs.
+func (mr *MockBatchInferenceServiceMockRecorder) ProcessPendingJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingJobs", reflect.TypeOf((*MockBatchInferenceService)(nil).ProcessPendingJobs), ctx)
+}
+
+// MockBootstrapService is a mock of BootstrapService interface.
+type MockBootstrapService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBootstrapServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBootstrapServiceMockRecorder is the mock recorder for MockBootstrapService.
+type MockBootstrapServiceMockRecorder struct {
+	mock *MockBootstrapService
+}
+
+// NewMockBootstrapService creates a new mock instance.
+func NewMockBootstrapService(ctrl *gomock.Controller) *MockBootstrapService {
+	mock := &MockBootstrapService{ctrl: ctrl}
+	mock.recorder = &MockBootstrapServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBootstrapService) EXPECT() *MockBootstrapServiceMockRecorder {
+	return m.recorder
+}
+
+// Bootstrap mocks base method.
+func (m *MockBootstrapService) Bootstrap(ctx context.Context) (*dto.BootstrapResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bootstrap", ctx)
+	ret0, _ := ret[0].(*dto.BootstrapResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bootstrap indicates an expected call of Bootstrap.
+func (mr *MockBootstrapServiceMockRecorder) Bootstrap(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bootstrap", reflect.TypeOf((*MockBootstrapService)(nil).Bootstrap), ctx)
+}
+
+// MockChatAgentAnswerRuleService is a mock of ChatAgentAnswerRuleService interface.
+type MockChatAgentAnswerRuleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentAnswerRuleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentAnswerRuleServiceMockRecorder is the mock recorder for MockChatAgentAnswerRuleService.
+type MockChatAgentAnswerRuleServiceMockRecorder struct {
+	mock *MockChatAgentAnswerRuleService
+}
+
+// NewMockChatAgentAnswerRuleService creates a new mock instance.
+func NewMockChatAgentAnswerRuleService(ctrl *gomock.Controller) *MockChatAgentAnswerRuleService {
+	mock := &MockChatAgentAnswerRuleService{ctrl: ctrl}
+	mock.recorder = &MockChatAgentAnswerRuleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentAnswerRuleService) EXPECT() *MockChatAgentAnswerRuleServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAnswerRule mocks base method.
+func (m *MockChatAgentAnswerRuleService) DeleteAnswerRule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnswerRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAnswerRule indicates an expected call of DeleteAnswerRule.
+func (mr *MockChatAgentAnswerRuleServiceMockRecorder) DeleteAnswerRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnswerRule", reflect.TypeOf((*MockChatAgentAnswerRuleService)(nil).DeleteAnswerRule), ctx, id)
+}
+
+// GetAnswerRulesByChatAgentID mocks base method.
+func (m *MockChatAgentAnswerRuleService) GetAnswerRulesByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswerRulesByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswerRulesByChatAgentID indicates an expected call of GetAnswerRulesByChatAgentID.
+func (mr *MockChatAgentAnswerRuleServiceMockRecorder) GetAnswerRulesByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerRulesByChatAgentID", reflect.TypeOf((*MockChatAgentAnswerRuleService)(nil).GetAnswerRulesByChatAgentID), ctx, chatAgentID)
+}
+
+// MatchAnswerRule mocks base method.
+func (m *MockChatAgentAnswerRuleService) MatchAnswerRule(ctx context.Context, chatAgentID uuid.UUID, userMessage string) (*models.ChatAgentAnswerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchAnswerRule", ctx, chatAgentID, userMessage)
+	ret0, _ := ret[0].(*models.ChatAgentAnswerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchAnswerRule indicates an expected call of MatchAnswerRule.
+func (mr *MockChatAgentAnswerRuleServiceMockRecorder) MatchAnswerRule(ctx, chatAgentID, userMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchAnswerRule", reflect.TypeOf((*MockChatAgentAnswerRuleService)(nil).MatchAnswerRule), ctx, chatAgentID, userMessage)
+}
+
+// SaveAnswerRule mocks base method.
+func (m *MockChatAgentAnswerRuleService) SaveAnswerRule(ctx context.Context, rule *models.ChatAgentAnswerRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAnswerRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAnswerRule indicates an expected call of SaveAnswerRule.
+func (mr *MockChatAgentAnswerRuleServiceMockRecorder) SaveAnswerRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAnswerRule", reflect.TypeOf((*MockChatAgentAnswerRuleService)(nil).SaveAnswerRule), ctx, rule)
+}
+
+// MockChatAgentApiKeyService is a mock of ChatAgentApiKeyService interface.
+type MockChatAgentApiKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentApiKeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentApiKeyServiceMockRecorder is the mock recorder for MockChatAgentApiKeyService.
+type MockChatAgentApiKeyServiceMockRecorder struct {
+	mock *MockChatAgentApiKeyService
+}
+
+// NewMockChatAgentApiKeyService creates a new mock instance.
+func NewMockChatAgentApiKeyService(ctrl *gomock.Controller) *MockChatAgentApiKeyService {
+	mock := &MockChatAgentApiKeyService{ctrl: ctrl}
+	mock.recorder = &MockChatAgentApiKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentApiKeyService) EXPECT() *MockChatAgentApiKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckClientIP mocks base method.
+func (m *MockChatAgentApiKeyService) CheckClientIP(ctx context.Context, apiKey *models.ChatAgentApiKey, access service.ApiKeyAccess) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckClientIP", ctx, apiKey, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckClientIP indicates an expected call of CheckClientIP.
+func (mr *MockChatAgentApiKeyServiceMockRecorder) CheckClientIP(ctx, apiKey, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckClientIP", reflect.TypeOf((*MockChatAgentApiKeyService)(nil).CheckClientIP), ctx, apiKey, access)
+}
+
+// CleanupRejections mocks base method.
+func (m *MockChatAgentApiKeyService) CleanupRejections(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupRejections", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupRejections indicates an expected call of CleanupRejections.
+func (mr *MockChatAgentApiKeyServiceMockRecorder) CleanupRejections(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupRejections", reflect.TypeOf((*MockChatAgentApiKeyService)(nil).CleanupRejections), ctx)
+}
+
+// GetApiKeysByChatAgentID mocks base method.
+func (m *MockChatAgentApiKeyService) GetApiKeysByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeysByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeysByChatAgentID indicates an expected call of GetApiKeysByChatAgentID.
+func (mr *MockChatAgentApiKeyServiceMockRecorder) GetApiKeysByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeysByChatAgentID", reflect.TypeOf((*MockChatAgentApiKeyService)(nil).GetApiKeysByChatAgentID), ctx, chatAgentID)
+}
+
+// GetRejectionsByChatAgentID mocks base method.
+func (m *MockChatAgentApiKeyService) GetRejectionsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID, page, pageSize int) ([]*models.ChatAgentApiKeyRejection, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRejectionsByChatAgentID", ctx, chatAgentID, page, pageSize)
+	ret0, _ := ret[0].([]*models.ChatAgentApiKeyRejection)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRejectionsByChatAgentID indicates an expected call of GetRejectionsByChatAgentID.
+func (mr *MockChatAgentApiKeyServiceMockRecorder) GetRejectionsByChatAgentID(ctx, chatAgentID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRejectionsByChatAgentID", reflect.TypeOf((*MockChatAgentApiKeyService)(nil).GetRejectionsByChatAgentID), ctx, chatAgentID, page, pageSize)
+}
+
+// SaveApiKeyIPRules mocks base method.
+func (m *MockChatAgentApiKeyService) SaveApiKeyIPRules(ctx context.Context, chatAgentID, apiKeyID uuid.UUID, allowedCidrs, deniedCidrs []string) (*models.ChatAgentApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveApiKeyIPRules", ctx, chatAgentID, apiKeyID, allowedCidrs, deniedCidrs)
+	ret0, _ := ret[0].(*models.ChatAgentApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveApiKeyIPRules indicates an expected call of SaveApiKeyIPRules.
+func (mr *MockChatAgentApiKeyServiceMockRecorder) SaveApiKeyIPRules(ctx, chatAgentID, apiKeyID, allowedCidrs, deniedCidrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveApiKeyIPRules", reflect.TypeOf((*MockChatAgentApiKeyService)(nil).SaveApiKeyIPRules), ctx, chatAgentID, apiKeyID, allowedCidrs, deniedCidrs)
+}
+
+// MockChatAgentConversationService is a mock of ChatAgentConversationService interface.
+type MockChatAgentConversationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentConversationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentConversationServiceMockRecorder is the mock recorder for MockChatAgentConversationService.
+type MockChatAgentConversationServiceMockRecorder struct {
+	mock *MockChatAgentConversationService
+}
+
+// NewMockChatAgentConversationService creates a new mock instance.
+func NewMockChatAgentConversationService(ctrl *gomock.Controller) *MockChatAgentConversationService {
+	mock := &MockChatAgentConversationService{ctrl: ctrl}
+	mock.recorder = &MockChatAgentConversationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentConversationService) EXPECT() *MockChatAgentConversationServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateConversation mocks base method.
+func (m *MockChatAgentConversationService) CreateConversation(ctx context.Context, serviceUserID, userMessage string) (*models.ChatAgentConversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConversation", ctx, serviceUserID, userMessage)
+	ret0, _ := ret[0].(*models.ChatAgentConversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConversation indicates an expected call of CreateConversation.
+func (mr *MockChatAgentConversationServiceMockRecorder) CreateConversation(ctx, serviceUserID, userMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConversation", reflect.TypeOf((*MockChatAgentConversationService)(nil).CreateConversation), ctx, serviceUserID, userMessage)
+}
+
+// DeleteConversation mocks base method.
+func (m *MockChatAgentConversationService) DeleteConversation(ctx context.Context, serviceUserID, conversationID string) (*dto.DeleteConversationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConversation", ctx, serviceUserID, conversationID)
+	ret0, _ := ret[0].(*dto.DeleteConversationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteConversation indicates an expected call of DeleteConversation.
+func (mr *MockChatAgentConversationServiceMockRecorder) DeleteConversation(ctx, serviceUserID, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConversation", reflect.TypeOf((*MockChatAgentConversationService)(nil).DeleteConversation), ctx, serviceUserID, conversationID)
+}
+
+// GetAttachment mocks base method.
+func (m *MockChatAgentConversationService) GetAttachment(ctx context.Context, serviceUserID, attachmentID string) (*models.ChatAgentAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, serviceUserID, attachmentID)
+	ret0, _ := ret[0].(*models.ChatAgentAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetAttachment(ctx, serviceUserID, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetAttachment), ctx, serviceUserID, attachmentID)
+}
+
+// GetChatAgentMcpServerTools mocks base method.
+func (m *MockChatAgentConversationService) GetChatAgentMcpServerTools(ctx context.Context) ([]al_client.Tool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentMcpServerTools", ctx)
+	ret0, _ := ret[0].([]al_client.Tool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAgentMcpServerTools indicates an expected call of GetChatAgentMcpServerTools.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetChatAgentMcpServerTools(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentMcpServerTools", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetChatAgentMcpServerTools), ctx)
+}
+
+// GetChatBootstrap mocks base method.
+func (m *MockChatAgentConversationService) GetChatBootstrap(ctx context.Context) (*dto.ChatBootstrapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatBootstrap", ctx)
+	ret0, _ := ret[0].(*dto.ChatBootstrapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatBootstrap indicates an expected call of GetChatBootstrap.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetChatBootstrap(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatBootstrap", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetChatBootstrap), ctx)
+}
+
+// GetChatMessageList mocks base method.
+func (m *MockChatAgentConversationService) GetChatMessageList(ctx context.Context, req *dto.GetChatMessageListRequest) ([]*models.ChatAgentMessage, *dto.CursorPageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessageList", ctx, req)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(*dto.CursorPageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChatMessageList indicates an expected call of GetChatMessageList.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetChatMessageList(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessageList", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetChatMessageList), ctx, req)
+}
+
+// GetChatMessageListByRequestID mocks base method.
+func (m *MockChatAgentConversationService) GetChatMessageListByRequestID(ctx context.Context, serviceUserID, conversationID, requestID string) ([]*models.ChatAgentMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMessageListByRequestID", ctx, serviceUserID, conversationID, requestID)
+	ret0, _ := ret[0].([]*models.ChatAgentMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMessageListByRequestID indicates an expected call of GetChatMessageListByRequestID.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetChatMessageListByRequestID(ctx, serviceUserID, conversationID, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMessageListByRequestID", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetChatMessageListByRequestID), ctx, serviceUserID, conversationID, requestID)
+}
+
+// GetConversation mocks base method.
+func (m *MockChatAgentConversationService) GetConversation(ctx context.Context, serviceUserID, conversationID string) (*dto.GetConversationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", ctx, serviceUserID, conversationID)
+	ret0, _ := ret[0].(*dto.GetConversationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetConversation(ctx, serviceUserID, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetConversation), ctx, serviceUserID, conversationID)
+}
+
+// GetConversationList mocks base method.
+func (m *MockChatAgentConversationService) GetConversationList(ctx context.Context, req *dto.GetConversationListRequest) ([]*models.ChatAgentConversation, *dto.CursorPageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationList", ctx, req)
+	ret0, _ := ret[0].([]*models.ChatAgentConversation)
+	ret1, _ := ret[1].(*dto.CursorPageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConversationList indicates an expected call of GetConversationList.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetConversationList(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationList", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetConversationList), ctx, req)
+}
+
+// GetConversationSummaries mocks base method.
+func (m *MockChatAgentConversationService) GetConversationSummaries(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID]*dto.ConversationSummaryDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationSummaries", ctx, conversationIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]*dto.ConversationSummaryDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationSummaries indicates an expected call of GetConversationSummaries.
+func (mr *MockChatAgentConversationServiceMockRecorder) GetConversationSummaries(ctx, conversationIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationSummaries", reflect.TypeOf((*MockChatAgentConversationService)(nil).GetConversationSummaries), ctx, conversationIDs)
+}
+
+// ListConversationsWithStats mocks base method.
+func (m *MockChatAgentConversationService) ListConversationsWithStats(ctx context.Context, req *dto.AdminConversationListRequest) ([]*repository.ChatAgentConversationWithStats, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversationsWithStats", ctx, req)
+	ret0, _ := ret[0].([]*repository.ChatAgentConversationWithStats)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListConversationsWithStats indicates an expected call of ListConversationsWithStats.
+func (mr *MockChatAgentConversationServiceMockRecorder) ListConversationsWithStats(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversationsWithStats", reflect.TypeOf((*MockChatAgentConversationService)(nil).ListConversationsWithStats), ctx, req)
+}
+
+// RenameConversationTitle mocks base method.
+func (m *MockChatAgentConversationService) RenameConversationTitle(ctx context.Context, serviceUserID, conversationID, newTitle string) (*dto.RenameConversationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameConversationTitle", ctx, serviceUserID, conversationID, newTitle)
+	ret0, _ := ret[0].(*dto.RenameConversationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameConversationTitle indicates an expected call of RenameConversationTitle.
+func (mr *MockChatAgentConversationServiceMockRecorder) RenameConversationTitle(ctx, serviceUserID, conversationID, newTitle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameConversationTitle", reflect.TypeOf((*MockChatAgentConversationService)(nil).RenameConversationTitle), ctx, serviceUserID, conversationID, newTitle)
+}
+
+// UpdateMessageReceipts mocks base method.
+func (m *MockChatAgentConversationService) UpdateMessageReceipts(ctx context.Context, req *dto.UpdateMessageReceiptsRequest) (*dto.UpdateMessageReceiptsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessageReceipts", ctx, req)
+	ret0, _ := ret[0].(*dto.UpdateMessageReceiptsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMessageReceipts indicates an expected call of UpdateMessageReceipts.
+func (mr *MockChatAgentConversationServiceMockRecorder) UpdateMessageReceipts(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessageReceipts", reflect.TypeOf((*MockChatAgentConversationService)(nil).UpdateMessageReceipts), ctx, req)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockChatAgentConversationService) UploadAttachment(ctx context.Context, file io.Reader, filename string, size int64) (*dto.UploadAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", ctx, file, filename, size)
+	ret0, _ := ret[0].(*dto.UploadAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockChatAgentConversationServiceMockRecorder) UploadAttachment(ctx, file, filename, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockChatAgentConversationService)(nil).UploadAttachment), ctx, file, filename, size)
+}
+
+// UserSendMessage mocks base method.
+func (m *MockChatAgentConversationService) UserSendMessage(ctx context.Context, req *dto.ChatUserSendMessageRequest, streamable bool) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSendMessage", ctx, req, streamable)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSendMessage indicates an expected call of UserSendMessage.
+func (mr *MockChatAgentConversationServiceMockRecorder) UserSendMessage(ctx, req, streamable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSendMessage", reflect.TypeOf((*MockChatAgentConversationService)(nil).UserSendMessage), ctx, req, streamable)
+}
+
+// UserSendMessagePredefinedAnswer mocks base method.
+func (m *MockChatAgentConversationService) UserSendMessagePredefinedAnswer(ctx context.Context, req *dto.ChatUserSendMessageRequest, streamable bool) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSendMessagePredefinedAnswer", ctx, req, streamable)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSendMessagePredefinedAnswer indicates an expected call of UserSendMessagePredefinedAnswer.
+func (mr *MockChatAgentConversationServiceMockRecorder) UserSendMessagePredefinedAnswer(ctx, req, streamable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSendMessagePredefinedAnswer", reflect.TypeOf((*MockChatAgentConversationService)(nil).UserSendMessagePredefinedAnswer), ctx, req, streamable)
+}
+
+// MockChatAgentMcpServerToolService is a mock of ChatAgentMcpServerToolService interface.
+type MockChatAgentMcpServerToolService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentMcpServerToolServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentMcpServerToolServiceMockRecorder is the mock recorder for MockChatAgentMcpServerToolService.
+type MockChatAgentMcpServerToolServiceMockRecorder struct {
+	mock *MockChatAgentMcpServerToolService
+}
+
+// NewMockChatAgentMcpServerToolService creates a new mock instance.
+func NewMockChatAgentMcpServerToolService(ctrl *gomock.Controller) *MockChatAgentMcpServerToolService {
+	mock := &MockChatAgentMcpServerToolService{ctrl: ctrl}
+	mock.recorder = &MockChatAgentMcpServerToolServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentMcpServerToolService) EXPECT() *MockChatAgentMcpServerToolServiceMockRecorder {
+	return m.recorder
+}
+
+// GetChatAgentAvailableMcpServerTools mocks base method.
+func (m *MockChatAgentMcpServerToolService) GetChatAgentAvailableMcpServerTools(ctx context.Context, chatAgentID uuid.UUID) ([]dto.McpServerToolGroupDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentAvailableMcpServerTools", ctx, chatAgentID)
+	ret0, _ := ret[0].([]dto.McpServerToolGroupDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAgentAvailableMcpServerTools indicates an expected call of GetChatAgentAvailableMcpServerTools.
+func (mr *MockChatAgentMcpServerToolServiceMockRecorder) GetChatAgentAvailableMcpServerTools(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentAvailableMcpServerTools", reflect.TypeOf((*MockChatAgentMcpServerToolService)(nil).GetChatAgentAvailableMcpServerTools), ctx, chatAgentID)
+}
+
+// GetChatAgentMcpServerToolSettings mocks base method.
+func (m *MockChatAgentMcpServerToolService) GetChatAgentMcpServerToolSettings(ctx context.Context, chatAgentID uuid.UUID) ([]dto.ChatAgentMcpServerToolSettingDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentMcpServerToolSettings", ctx, chatAgentID)
+	ret0, _ := ret[0].([]dto.ChatAgentMcpServerToolSettingDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAgentMcpServerToolSettings indicates an expected call of GetChatAgentMcpServerToolSettings.
+func (mr *MockChatAgentMcpServerToolServiceMockRecorder) GetChatAgentMcpServerToolSettings(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentMcpServerToolSettings", reflect.TypeOf((*MockChatAgentMcpServerToolService)(nil).GetChatAgentMcpServerToolSettings), ctx, chatAgentID)
+}
+
+// SaveChatAgentMcpServerToolSettings mocks base method.
+func (m *MockChatAgentMcpServerToolService) SaveChatAgentMcpServerToolSettings(ctx context.Context, chatAgentID uuid.UUID, toolSettings []dto.ChatAgentMcpServerToolSettingDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChatAgentMcpServerToolSettings", ctx, chatAgentID, toolSettings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChatAgentMcpServerToolSettings indicates an expected call of SaveChatAgentMcpServerToolSettings.
+func (mr *MockChatAgentMcpServerToolServiceMockRecorder) SaveChatAgentMcpServerToolSettings(ctx, chatAgentID, toolSettings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChatAgentMcpServerToolSettings", reflect.TypeOf((*MockChatAgentMcpServerToolService)(nil).SaveChatAgentMcpServerToolSettings), ctx, chatAgentID, toolSettings)
+}
+
+// MockChatAgentService is a mock of ChatAgentService interface.
+type MockChatAgentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentServiceMockRecorder is the mock recorder for MockChatAgentService.
+type MockChatAgentServiceMockRecorder struct {
+	mock *MockChatAgentService
+}
+
+// NewMockChatAgentService creates a new mock instance.
+func NewMockChatAgentService(ctrl *gomock.Controller) *MockChatAgentService {
+	mock := &MockChatAgentService{ctrl: ctrl}
+	mock.recorder = &MockChatAgentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentService) EXPECT() *MockChatAgentServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteChatAgent mocks base method.
+func (m *MockChatAgentService) DeleteChatAgent(ctx context.Context, id uuid.UUID, dryRun bool) (*dto.ChatAgentDeletionDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChatAgent", ctx, id, dryRun)
+	ret0, _ := ret[0].(*dto.ChatAgentDeletionDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChatAgent indicates an expected call of DeleteChatAgent.
+func (mr *MockChatAgentServiceMockRecorder) DeleteChatAgent(ctx, id, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChatAgent", reflect.TypeOf((*MockChatAgentService)(nil).DeleteChatAgent), ctx, id, dryRun)
+}
+
+// GetChatAgentByApiKey mocks base method.
+func (m *MockChatAgentService) GetChatAgentByApiKey(ctx context.Context, apiKey string, access service.ApiKeyAccess) (*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentByApiKey", ctx, apiKey, access)
+	ret0, _ := ret[0].(*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAgentByApiKey indicates an expected call of GetChatAgentByApiKey.
+func (mr *MockChatAgentServiceMockRecorder) GetChatAgentByApiKey(ctx, apiKey, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentByApiKey", reflect.TypeOf((*MockChatAgentService)(nil).GetChatAgentByApiKey), ctx, apiKey, access)
+}
+
+// GetChatAgentByID mocks base method.
+func (m *MockChatAgentService) GetChatAgentByID(ctx context.Context, id uuid.UUID) (*models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentByID", ctx, id)
+	ret0, _ := ret[0].(*models.ChatAgent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatAgentByID indicates an expected call of GetChatAgentByID.
+func (mr *MockChatAgentServiceMockRecorder) GetChatAgentByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentByID", reflect.TypeOf((*MockChatAgentService)(nil).GetChatAgentByID), ctx, id)
+}
+
+// GetChatAgentsByApplicationID mocks base method.
+func (m *MockChatAgentService) GetChatAgentsByApplicationID(ctx context.Context, applicationID uuid.UUID, page, pageSize int) ([]*models.ChatAgent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatAgentsByApplicationID", ctx, applicationID, page, pageSize)
+	ret0, _ := ret[0].([]*models.ChatAgent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChatAgentsByApplicationID indicates an expected call of GetChatAgentsByApplicationID.
+func (mr *MockChatAgentServiceMockRecorder) GetChatAgentsByApplicationID(ctx, applicationID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatAgentsByApplicationID", reflect.TypeOf((*MockChatAgentService)(nil).GetChatAgentsByApplicationID), ctx, applicationID, page, pageSize)
+}
+
+// PurgeDeletedAttachmentFiles mocks base method.
+func (m *MockChatAgentService) PurgeDeletedAttachmentFiles(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedAttachmentFiles", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedAttachmentFiles indicates an expected call of PurgeDeletedAttachmentFiles.
+func (mr *MockChatAgentServiceMockRecorder) PurgeDeletedAttachmentFiles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedAttachmentFiles", reflect.TypeOf((*MockChatAgentService)(nil).PurgeDeletedAttachmentFiles), ctx)
+}
+
+// SaveChatAgent mocks base method.
+func (m *MockChatAgentService) SaveChatAgent(ctx context.Context, agent *models.ChatAgent, updateFields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChatAgent", ctx, agent, updateFields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChatAgent indicates an expected call of SaveChatAgent.
+func (mr *MockChatAgentServiceMockRecorder) SaveChatAgent(ctx, agent, updateFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChatAgent", reflect.TypeOf((*MockChatAgentService)(nil).SaveChatAgent), ctx, agent, updateFields)
+}
+
+// MockChatAgentToolUsageService is a mock of ChatAgentToolUsageService interface.
+type MockChatAgentToolUsageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatAgentToolUsageServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatAgentToolUsageServiceMockRecorder is the mock recorder for MockChatAgentToolUsageService.
+type MockChatAgentToolUsageServiceMockRecorder struct {
+	mock *MockChatAgentToolUsageService
+}
+
+// NewMockChatAgentToolUsageService creates a new mock instance.
+func NewMockChatAgentToolUsageService(ctrl *gomock.Controller) *MockChatAgentToolUsageService {
+	mock := &MockChatAgentToolUsageService{ctrl: ctrl}
+	mock.recorder = &MockChatAgentToolUsageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatAgentToolUsageService) EXPECT() *MockChatAgentToolUsageServiceMockRecorder {
+	return m.recorder
+}
+
+// GetToolUsage mocks base method.
+func (m *MockChatAgentToolUsageService) GetToolUsage(ctx context.Context, chatAgentID uuid.UUID, calledAfter, calledBefore *time.Time) ([]*service.ChatAgentToolUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToolUsage", ctx, chatAgentID, calledAfter, calledBefore)
+	ret0, _ := ret[0].([]*service.ChatAgentToolUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToolUsage indicates an expected call of GetToolUsage.
+func (mr *MockChatAgentToolUsageServiceMockRecorder) GetToolUsage(ctx, chatAgentID, calledAfter, calledBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToolUsage", reflect.TypeOf((*MockChatAgentToolUsageService)(nil).GetToolUsage), ctx, chatAgentID, calledAfter, calledBefore)
+}
+
+// RecordToolCall mocks base method.
+func (m *MockChatAgentToolUsageService) RecordToolCall(ctx context.Context, call *models.ChatAgentToolCall) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordToolCall", ctx, call)
+}
+
+// RecordToolCall indicates an expected call of RecordToolCall.
+func (mr *MockChatAgentToolUsageServiceMockRecorder) RecordToolCall(ctx, call any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordToolCall", reflect.TypeOf((*MockChatAgentToolUsageService)(nil).RecordToolCall), ctx, call)
+}
+
+// MockChatMessagePersistenceService is a mock of ChatMessagePersistenceService interface.
+type MockChatMessagePersistenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatMessagePersistenceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatMessagePersistenceServiceMockRecorder is the mock recorder for MockChatMessagePersistenceService.
+type MockChatMessagePersistenceServiceMockRecorder struct {
+	mock *MockChatMessagePersistenceService
+}
+
+// NewMockChatMessagePersistenceService creates a new mock instance.
+func NewMockChatMessagePersistenceService(ctrl *gomock.Controller) *MockChatMessagePersistenceService {
+	mock := &MockChatMessagePersistenceService{ctrl: ctrl}
+	mock.recorder = &MockChatMessagePersistenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatMessagePersistenceService) EXPECT() *MockChatMessagePersistenceServiceMockRecorder {
+	return m.recorder
+}
+
+// RetryPending mocks base method.
+func (m *MockChatMessagePersistenceService) RetryPending(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPending", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryPending indicates an expected call of RetryPending.
+func (mr *MockChatMessagePersistenceServiceMockRecorder) RetryPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPending", reflect.TypeOf((*MockChatMessagePersistenceService)(nil).RetryPending), ctx)
+}
+
+// Save mocks base method.
+func (m *MockChatMessagePersistenceService) Save(ctx context.Context, message *models.ChatAgentMessage) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, message)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockChatMessagePersistenceServiceMockRecorder) Save(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockChatMessagePersistenceService)(nil).Save), ctx, message)
+}
+
+// MockChatStreamResumeService is a mock of ChatStreamResumeService interface.
+type MockChatStreamResumeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatStreamResumeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatStreamResumeServiceMockRecorder is the mock recorder for MockChatStreamResumeService.
+type MockChatStreamResumeServiceMockRecorder struct {
+	mock *MockChatStreamResumeService
+}
+
+// NewMockChatStreamResumeService creates a new mock instance.
+func NewMockChatStreamResumeService(ctrl *gomock.Controller) *MockChatStreamResumeService {
+	mock := &MockChatStreamResumeService{ctrl: ctrl}
+	mock.recorder = &MockChatStreamResumeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatStreamResumeService) EXPECT() *MockChatStreamResumeServiceMockRecorder {
+	return m.recorder
+}
+
+// CleanupStreams mocks base method.
+func (m *MockChatStreamResumeService) CleanupStreams(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupStreams", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupStreams indicates an expected call of CleanupStreams.
+func (mr *MockChatStreamResumeServiceMockRecorder) CleanupStreams(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupStreams", reflect.TypeOf((*MockChatStreamResumeService)(nil).CleanupStreams), ctx)
+}
+
+// Resume mocks base method.
+func (m *MockChatStreamResumeService) Resume(ctx context.Context, conversationID, serviceUserID, requestID string, lastEventID int) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, conversationID, serviceUserID, requestID, lastEventID)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockChatStreamResumeServiceMockRecorder) Resume(ctx, conversationID, serviceUserID, requestID, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockChatStreamResumeService)(nil).Resume), ctx, conversationID, serviceUserID, requestID, lastEventID)
+}
+
+// Start mocks base method.
+func (m *MockChatStreamResumeService) Start(ctx context.Context, serviceUserID string, send func(context.Context) (io.Reader, error)) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, serviceUserID, send)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockChatStreamResumeServiceMockRecorder) Start(ctx, serviceUserID, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockChatStreamResumeService)(nil).Start), ctx, serviceUserID, send)
+}
+
+// MockChatWidgetTokenService is a mock of ChatWidgetTokenService interface.
+type MockChatWidgetTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatWidgetTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockChatWidgetTokenServiceMockRecorder is the mock recorder for MockChatWidgetTokenService.
+type MockChatWidgetTokenServiceMockRecorder struct {
+	mock *MockChatWidgetTokenService
+}
+
+// NewMockChatWidgetTokenService creates a new mock instance.
+func NewMockChatWidgetTokenService(ctrl *gomock.Controller) *MockChatWidgetTokenService {
+	mock := &MockChatWidgetTokenService{ctrl: ctrl}
+	mock.recorder = &MockChatWidgetTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatWidgetTokenService) EXPECT() *MockChatWidgetTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockChatWidgetTokenService) Authenticate(ctx context.Context, token, origin string) (*models.ChatAgentWidgetToken, *models.ChatAgent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token, origin)
+	ret0, _ := ret[0].(*models.ChatAgentWidgetToken)
+	ret1, _ := ret[1].(*models.ChatAgent)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockChatWidgetTokenServiceMockRecorder) Authenticate(ctx, token, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockChatWidgetTokenService)(nil).Authenticate), ctx, token, origin)
+}
+
+// CleanupExpiredTokens mocks base method.
+func (m *MockChatWidgetTokenService) CleanupExpiredTokens(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupExpiredTokens", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupExpiredTokens indicates an expected call of CleanupExpiredTokens.
+func (mr *MockChatWidgetTokenServiceMockRecorder) CleanupExpiredTokens(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupExpiredTokens", reflect.TypeOf((*MockChatWidgetTokenService)(nil).CleanupExpiredTokens), ctx)
+}
+
+// CreateCSRFToken mocks base method.
+func (m *MockChatWidgetTokenService) CreateCSRFToken(widgetToken *models.ChatAgentWidgetToken) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCSRFToken", widgetToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCSRFToken indicates an expected call of CreateCSRFToken.
+func (mr *MockChatWidgetTokenServiceMockRecorder) CreateCSRFToken(widgetToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCSRFToken", reflect.TypeOf((*MockChatWidgetTokenService)(nil).CreateCSRFToken), widgetToken)
+}
+
+// CreateToken mocks base method.
+func (m *MockChatWidgetTokenService) CreateToken(ctx context.Context, serviceUserID, origin string, ttlMinutes int) (*service.ChatWidgetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", ctx, serviceUserID, origin, ttlMinutes)
+	ret0, _ := ret[0].(*service.ChatWidgetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockChatWidgetTokenServiceMockRecorder) CreateToken(ctx, serviceUserID, origin, ttlMinutes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockChatWidgetTokenService)(nil).CreateToken), ctx, serviceUserID, origin, ttlMinutes)
+}
+
+// VerifyCSRFToken mocks base method.
+func (m *MockChatWidgetTokenService) VerifyCSRFToken(widgetToken *models.ChatAgentWidgetToken, csrfToken string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCSRFToken", widgetToken, csrfToken)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VerifyCSRFToken indicates an expected call of VerifyCSRFToken.
+func (mr *MockChatWidgetTokenServiceMockRecorder) VerifyCSRFToken(widgetToken, csrfToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCSRFToken", reflect.TypeOf((*MockChatWidgetTokenService)(nil).VerifyCSRFToken), widgetToken, csrfToken)
+}
+
+// MockClusterService is a mock of ClusterService interface.
+type MockClusterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockClusterServiceMockRecorder is the mock recorder for MockClusterService.
+type MockClusterServiceMockRecorder struct {
+	mock *MockClusterService
+}
+
+// NewMockClusterService creates a new mock instance.
+func NewMockClusterService(ctrl *gomock.Controller) *MockClusterService {
+	mock := &MockClusterService{ctrl: ctrl}
+	mock.recorder = &MockClusterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterService) EXPECT() *MockClusterServiceMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockClusterService) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockClusterServiceMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockClusterService)(nil).Enabled))
+}
+
+// InstanceID mocks base method.
+func (m *MockClusterService) InstanceID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// InstanceID indicates an expected call of InstanceID.
+func (mr *MockClusterServiceMockRecorder) InstanceID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceID", reflect.TypeOf((*MockClusterService)(nil).InstanceID))
+}
+
+// TryLock mocks base method.
+func (m *MockClusterService) TryLock(ctx context.Context, name string) (context.Context, func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, name)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockClusterServiceMockRecorder) TryLock(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockClusterService)(nil).TryLock), ctx, name)
+}
+
+// MockConfigReloadService is a mock of ConfigReloadService interface.
+type MockConfigReloadService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigReloadServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConfigReloadServiceMockRecorder is the mock recorder for MockConfigReloadService.
+type MockConfigReloadServiceMockRecorder struct {
+	mock *MockConfigReloadService
+}
+
+// NewMockConfigReloadService creates a new mock instance.
+func NewMockConfigReloadService(ctrl *gomock.Controller) *MockConfigReloadService {
+	mock := &MockConfigReloadService{ctrl: ctrl}
+	mock.recorder = &MockConfigReloadServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigReloadService) EXPECT() *MockConfigReloadServiceMockRecorder {
+	return m.recorder
+}
+
+// ReloadConfig mocks base method.
+func (m *MockConfigReloadService) ReloadConfig(ctx context.Context) (*dto.ConfigReloadDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadConfig", ctx)
+	ret0, _ := ret[0].(*dto.ConfigReloadDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadConfig indicates an expected call of ReloadConfig.
+func (mr *MockConfigReloadServiceMockRecorder) ReloadConfig(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockConfigReloadService)(nil).ReloadConfig), ctx)
+}
+
+// MockConversationArchiveService is a mock of ConversationArchiveService interface.
+type MockConversationArchiveService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationArchiveServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationArchiveServiceMockRecorder is the mock recorder for MockConversationArchiveService.
+type MockConversationArchiveServiceMockRecorder struct {
+	mock *MockConversationArchiveService
+}
+
+// NewMockConversationArchiveService creates a new mock instance.
+func NewMockConversationArchiveService(ctrl *gomock.Controller) *MockConversationArchiveService {
+	mock := &MockConversationArchiveService{ctrl: ctrl}
+	mock.recorder = &MockConversationArchiveServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationArchiveService) EXPECT() *MockConversationArchiveServiceMockRecorder {
+	return m.recorder
+}
+
+// ArchiveConversations mocks base method.
+func (m *MockConversationArchiveService) ArchiveConversations(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveConversations", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveConversations indicates an expected call of ArchiveConversations.
+func (mr *MockConversationArchiveServiceMockRecorder) ArchiveConversations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveConversations", reflect.TypeOf((*MockConversationArchiveService)(nil).ArchiveConversations), ctx)
+}
+
+// GetArchive mocks base method.
+func (m *MockConversationArchiveService) GetArchive(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchive", ctx, id)
+	ret0, _ := ret[0].(*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchive indicates an expected call of GetArchive.
+func (mr *MockConversationArchiveServiceMockRecorder) GetArchive(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchive", reflect.TypeOf((*MockConversationArchiveService)(nil).GetArchive), ctx, id)
+}
+
+// GetArchives mocks base method.
+func (m *MockConversationArchiveService) GetArchives(ctx context.Context, chatAgentID uuid.UUID, serviceUserID, status string, page, pageSize int) ([]*models.ConversationArchive, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchives", ctx, chatAgentID, serviceUserID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.ConversationArchive)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetArchives indicates an expected call of GetArchives.
+func (mr *MockConversationArchiveServiceMockRecorder) GetArchives(ctx, chatAgentID, serviceUserID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchives", reflect.TypeOf((*MockConversationArchiveService)(nil).GetArchives), ctx, chatAgentID, serviceUserID, status, page, pageSize)
+}
+
+// RestoreArchive mocks base method.
+func (m *MockConversationArchiveService) RestoreArchive(ctx context.Context, id uuid.UUID) (*models.ConversationArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreArchive", ctx, id)
+	ret0, _ := ret[0].(*models.ConversationArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreArchive indicates an expected call of RestoreArchive.
+func (mr *MockConversationArchiveServiceMockRecorder) RestoreArchive(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreArchive", reflect.TypeOf((*MockConversationArchiveService)(nil).RestoreArchive), ctx, id)
+}
+
+// MockConversationEventService is a mock of ConversationEventService interface.
+type MockConversationEventService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationEventServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationEventServiceMockRecorder is the mock recorder for MockConversationEventService.
+type MockConversationEventServiceMockRecorder struct {
+	mock *MockConversationEventService
+}
+
+// NewMockConversationEventService creates a new mock instance.
+func NewMockConversationEventService(ctrl *gomock.Controller) *MockConversationEventService {
+	mock := &MockConversationEventService{ctrl: ctrl}
+	mock.recorder = &MockConversationEventServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationEventService) EXPECT() *MockConversationEventServiceMockRecorder {
+	return m.recorder
+}
+
+// CleanupEvents mocks base method.
+func (m *MockConversationEventService) CleanupEvents(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupEvents", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupEvents indicates an expected call of CleanupEvents.
+func (mr *MockConversationEventServiceMockRecorder) CleanupEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupEvents", reflect.TypeOf((*MockConversationEventService)(nil).CleanupEvents), ctx)
+}
+
+// ProcessEvents mocks base method.
+func (m *MockConversationEventService) ProcessEvents(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessEvents", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessEvents indicates an expected call of ProcessEvents.
+func (mr *MockConversationEventServiceMockRecorder) ProcessEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessEvents", reflect.TypeOf((*MockConversationEventService)(nil).ProcessEvents), ctx)
+}
+
+// MockConversationExportService is a mock of ConversationExportService interface.
+type MockConversationExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationExportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationExportServiceMockRecorder is the mock recorder for MockConversationExportService.
+type MockConversationExportServiceMockRecorder struct {
+	mock *MockConversationExportService
+}
+
+// NewMockConversationExportService creates a new mock instance.
+func NewMockConversationExportService(ctrl *gomock.Controller) *MockConversationExportService {
+	mock := &MockConversationExportService{ctrl: ctrl}
+	mock.recorder = &MockConversationExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationExportService) EXPECT() *MockConversationExportServiceMockRecorder {
+	return m.recorder
+}
+
+// GetConversationTranscript mocks base method.
+func (m *MockConversationExportService) GetConversationTranscript(ctx context.Context, chatAgentID, conversationID uuid.UUID) (*service.ConversationTranscript, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationTranscript", ctx, chatAgentID, conversationID)
+	ret0, _ := ret[0].(*service.ConversationTranscript)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationTranscript indicates an expected call of GetConversationTranscript.
+func (mr *MockConversationExportServiceMockRecorder) GetConversationTranscript(ctx, chatAgentID, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationTranscript", reflect.TypeOf((*MockConversationExportService)(nil).GetConversationTranscript), ctx, chatAgentID, conversationID)
+}
+
+// RenderTranscriptPdf mocks base method.
+func (m *MockConversationExportService) RenderTranscriptPdf(transcript *service.ConversationTranscript) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderTranscriptPdf", transcript)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderTranscriptPdf indicates an expected call of RenderTranscriptPdf.
+func (mr *MockConversationExportServiceMockRecorder) RenderTranscriptPdf(transcript any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderTranscriptPdf", reflect.TypeOf((*MockConversationExportService)(nil).RenderTranscriptPdf), transcript)
+}
+
+// MockConversationMonitorService is a mock of ConversationMonitorService interface.
+type MockConversationMonitorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationMonitorServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationMonitorServiceMockRecorder is the mock recorder for MockConversationMonitorService.
+type MockConversationMonitorServiceMockRecorder struct {
+	mock *MockConversationMonitorService
+}
+
+// NewMockConversationMonitorService creates a new mock instance.
+func NewMockConversationMonitorService(ctrl *gomock.Controller) *MockConversationMonitorService {
+	mock := &MockConversationMonitorService{ctrl: ctrl}
+	mock.recorder = &MockConversationMonitorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationMonitorService) EXPECT() *MockConversationMonitorServiceMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockConversationMonitorService) Publish(chatAgentID uuid.UUID, event dto.ConversationMonitorEventDto) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", chatAgentID, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockConversationMonitorServiceMockRecorder) Publish(chatAgentID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockConversationMonitorService)(nil).Publish), chatAgentID, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockConversationMonitorService) Subscribe(chatAgentID uuid.UUID) (<-chan dto.ConversationMonitorEventDto, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", chatAgentID)
+	ret0, _ := ret[0].(<-chan dto.ConversationMonitorEventDto)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockConversationMonitorServiceMockRecorder) Subscribe(chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockConversationMonitorService)(nil).Subscribe), chatAgentID)
+}
+
+// MockConversationPresenceService is a mock of ConversationPresenceService interface.
+type MockConversationPresenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationPresenceServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationPresenceServiceMockRecorder is the mock recorder for MockConversationPresenceService.
+type MockConversationPresenceServiceMockRecorder struct {
+	mock *MockConversationPresenceService
+}
+
+// NewMockConversationPresenceService creates a new mock instance.
+func NewMockConversationPresenceService(ctrl *gomock.Controller) *MockConversationPresenceService {
+	mock := &MockConversationPresenceService{ctrl: ctrl}
+	mock.recorder = &MockConversationPresenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationPresenceService) EXPECT() *MockConversationPresenceServiceMockRecorder {
+	return m.recorder
+}
+
+// SetAgentGenerating mocks base method.
+func (m *MockConversationPresenceService) SetAgentGenerating(conversationID uuid.UUID, requestID string, generating bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAgentGenerating", conversationID, requestID, generating)
+}
+
+// SetAgentGenerating indicates an expected call of SetAgentGenerating.
+func (mr *MockConversationPresenceServiceMockRecorder) SetAgentGenerating(conversationID, requestID, generating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentGenerating", reflect.TypeOf((*MockConversationPresenceService)(nil).SetAgentGenerating), conversationID, requestID, generating)
+}
+
+// Subscribe mocks base method.
+func (m *MockConversationPresenceService) Subscribe(ctx context.Context, conversationID, serviceUserID string) (<-chan dto.ConversationPresenceEventDto, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, conversationID, serviceUserID)
+	ret0, _ := ret[0].(<-chan dto.ConversationPresenceEventDto)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockConversationPresenceServiceMockRecorder) Subscribe(ctx, conversationID, serviceUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockConversationPresenceService)(nil).Subscribe), ctx, conversationID, serviceUserID)
+}
+
+// UpdateUserTyping mocks base method.
+func (m *MockConversationPresenceService) UpdateUserTyping(ctx context.Context, req *dto.UpdateTypingStatusRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTyping", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserTyping indicates an expected call of UpdateUserTyping.
+func (mr *MockConversationPresenceServiceMockRecorder) UpdateUserTyping(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTyping", reflect.TypeOf((*MockConversationPresenceService)(nil).UpdateUserTyping), ctx, req)
+}
+
+// MockConversationReplayService is a mock of ConversationReplayService interface.
+type MockConversationReplayService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationReplayServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationReplayServiceMockRecorder is the mock recorder for MockConversationReplayService.
+type MockConversationReplayServiceMockRecorder struct {
+	mock *MockConversationReplayService
+}
+
+// NewMockConversationReplayService creates a new mock instance.
+func NewMockConversationReplayService(ctrl *gomock.Controller) *MockConversationReplayService {
+	mock := &MockConversationReplayService{ctrl: ctrl}
+	mock.recorder = &MockConversationReplayServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationReplayService) EXPECT() *MockConversationReplayServiceMockRecorder {
+	return m.recorder
+}
+
+// ReplayConversation mocks base method.
+func (m *MockConversationReplayService) ReplayConversation(ctx context.Context, chatAgentID, conversationID uuid.UUID, options *service.ConversationReplayOptions) (*service.ConversationReplay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayConversation", ctx, chatAgentID, conversationID, options)
+	ret0, _ := ret[0].(*service.ConversationReplay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayConversation indicates an expected call of ReplayConversation.
+func (mr *MockConversationReplayServiceMockRecorder) ReplayConversation(ctx, chatAgentID, conversationID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayConversation", reflect.TypeOf((*MockConversationReplayService)(nil).ReplayConversation), ctx, chatAgentID, conversationID, options)
+}
+
+// MockConversationVariableService is a mock of ConversationVariableService interface.
+type MockConversationVariableService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationVariableServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationVariableServiceMockRecorder is the mock recorder for MockConversationVariableService.
+type MockConversationVariableServiceMockRecorder struct {
+	mock *MockConversationVariableService
+}
+
+// NewMockConversationVariableService creates a new mock instance.
+func NewMockConversationVariableService(ctrl *gomock.Controller) *MockConversationVariableService {
+	mock := &MockConversationVariableService{ctrl: ctrl}
+	mock.recorder = &MockConversationVariableServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationVariableService) EXPECT() *MockConversationVariableServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteConversationVariables mocks base method.
+func (m *MockConversationVariableService) DeleteConversationVariables(ctx context.Context, conversationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConversationVariables", ctx, conversationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConversationVariables indicates an expected call of DeleteConversationVariables.
+func (mr *MockConversationVariableServiceMockRecorder) DeleteConversationVariables(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConversationVariables", reflect.TypeOf((*MockConversationVariableService)(nil).DeleteConversationVariables), ctx, conversationID)
+}
+
+// DeleteVariable mocks base method.
+func (m *MockConversationVariableService) DeleteVariable(ctx context.Context, serviceUserID, conversationID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariable", ctx, serviceUserID, conversationID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariable indicates an expected call of DeleteVariable.
+func (mr *MockConversationVariableServiceMockRecorder) DeleteVariable(ctx, serviceUserID, conversationID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariable", reflect.TypeOf((*MockConversationVariableService)(nil).DeleteVariable), ctx, serviceUserID, conversationID, name)
+}
+
+// GetVariableValues mocks base method.
+func (m *MockConversationVariableService) GetVariableValues(ctx context.Context, conversationID uuid.UUID) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariableValues", ctx, conversationID)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariableValues indicates an expected call of GetVariableValues.
+func (mr *MockConversationVariableServiceMockRecorder) GetVariableValues(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariableValues", reflect.TypeOf((*MockConversationVariableService)(nil).GetVariableValues), ctx, conversationID)
+}
+
+// ListVariables mocks base method.
+func (m *MockConversationVariableService) ListVariables(ctx context.Context, serviceUserID, conversationID string) ([]*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVariables", ctx, serviceUserID, conversationID)
+	ret0, _ := ret[0].([]*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVariables indicates an expected call of ListVariables.
+func (mr *MockConversationVariableServiceMockRecorder) ListVariables(ctx, serviceUserID, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVariables", reflect.TypeOf((*MockConversationVariableService)(nil).ListVariables), ctx, serviceUserID, conversationID)
+}
+
+// SaveVariable mocks base method.
+func (m *MockConversationVariableService) SaveVariable(ctx context.Context, conversationID uuid.UUID, name, value, source string) (*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveVariable", ctx, conversationID, name, value, source)
+	ret0, _ := ret[0].(*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveVariable indicates an expected call of SaveVariable.
+func (mr *MockConversationVariableServiceMockRecorder) SaveVariable(ctx, conversationID, name, value, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveVariable", reflect.TypeOf((*MockConversationVariableService)(nil).SaveVariable), ctx, conversationID, name, value, source)
+}
+
+// SetVariable mocks base method.
+func (m *MockConversationVariableService) SetVariable(ctx context.Context, req *dto.SetConversationVariableRequest) (*models.ChatAgentConversationVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVariable", ctx, req)
+	ret0, _ := ret[0].(*models.ChatAgentConversationVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVariable indicates an expected call of SetVariable.
+func (mr *MockConversationVariableServiceMockRecorder) SetVariable(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVariable", reflect.TypeOf((*MockConversationVariableService)(nil).SetVariable), ctx, req)
+}
+
+// MockEvaluationService is a mock of EvaluationService interface.
+type MockEvaluationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEvaluationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockEvaluationServiceMockRecorder is the mock recorder for MockEvaluationService.
+type MockEvaluationServiceMockRecorder struct {
+	mock *MockEvaluationService
+}
+
+// NewMockEvaluationService creates a new mock instance.
+func NewMockEvaluationService(ctrl *gomock.Controller) *MockEvaluationService {
+	mock := &MockEvaluationService{ctrl: ctrl}
+	mock.recorder = &MockEvaluationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvaluationService) EXPECT() *MockEvaluationServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelRun mocks base method.
+func (m *MockEvaluationService) CancelRun(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRun", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRun indicates an expected call of CancelRun.
+func (mr *MockEvaluationServiceMockRecorder) CancelRun(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRun", reflect.TypeOf((*MockEvaluationService)(nil).CancelRun), ctx, id)
+}
+
+// CompareRuns mocks base method.
+func (m *MockEvaluationService) CompareRuns(ctx context.Context, baseRunID, targetRunID uuid.UUID) (*service.EvaluationRunComparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareRuns", ctx, baseRunID, targetRunID)
+	ret0, _ := ret[0].(*service.EvaluationRunComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareRuns indicates an expected call of CompareRuns.
+func (mr *MockEvaluationServiceMockRecorder) CompareRuns(ctx, baseRunID, targetRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareRuns", reflect.TypeOf((*MockEvaluationService)(nil).CompareRuns), ctx, baseRunID, targetRunID)
+}
+
+// CreateRun mocks base method.
+func (m *MockEvaluationService) CreateRun(ctx context.Context, run *models.EvaluationRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockEvaluationServiceMockRecorder) CreateRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockEvaluationService)(nil).CreateRun), ctx, run)
+}
+
+// DeleteCase mocks base method.
+func (m *MockEvaluationService) DeleteCase(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCase", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCase indicates an expected call of DeleteCase.
+func (mr *MockEvaluationServiceMockRecorder) DeleteCase(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCase", reflect.TypeOf((*MockEvaluationService)(nil).DeleteCase), ctx, id)
+}
+
+// DeleteSet mocks base method.
+func (m *MockEvaluationService) DeleteSet(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSet", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSet indicates an expected call of DeleteSet.
+func (mr *MockEvaluationServiceMockRecorder) DeleteSet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSet", reflect.TypeOf((*MockEvaluationService)(nil).DeleteSet), ctx, id)
+}
+
+// GetCasesBySetID mocks base method.
+func (m *MockEvaluationService) GetCasesBySetID(ctx context.Context, setID uuid.UUID) ([]*models.EvaluationCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCasesBySetID", ctx, setID)
+	ret0, _ := ret[0].([]*models.EvaluationCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCasesBySetID indicates an expected call of GetCasesBySetID.
+func (mr *MockEvaluationServiceMockRecorder) GetCasesBySetID(ctx, setID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCasesBySetID", reflect.TypeOf((*MockEvaluationService)(nil).GetCasesBySetID), ctx, setID)
+}
+
+// GetRun mocks base method.
+func (m *MockEvaluationService) GetRun(ctx context.Context, id uuid.UUID) (*models.EvaluationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockEvaluationServiceMockRecorder) GetRun(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockEvaluationService)(nil).GetRun), ctx, id)
+}
+
+// GetRunResults mocks base method.
+func (m *MockEvaluationService) GetRunResults(ctx context.Context, runID uuid.UUID, status string, page, pageSize int) ([]*models.EvaluationResult, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunResults", ctx, runID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.EvaluationResult)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRunResults indicates an expected call of GetRunResults.
+func (mr *MockEvaluationServiceMockRecorder) GetRunResults(ctx, runID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunResults", reflect.TypeOf((*MockEvaluationService)(nil).GetRunResults), ctx, runID, status, page, pageSize)
+}
+
+// GetRunsBySetID mocks base method.
+func (m *MockEvaluationService) GetRunsBySetID(ctx context.Context, setID uuid.UUID, page, pageSize int) ([]*models.EvaluationRun, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunsBySetID", ctx, setID, page, pageSize)
+	ret0, _ := ret[0].([]*models.EvaluationRun)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRunsBySetID indicates an expected call of GetRunsBySetID.
+func (mr *MockEvaluationServiceMockRecorder) GetRunsBySetID(ctx, setID, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunsBySetID", reflect.TypeOf((*MockEvaluationService)(nil).GetRunsBySetID), ctx, setID, page, pageSize)
+}
+
+// GetSet mocks base method.
+func (m *MockEvaluationService) GetSet(ctx context.Context, id uuid.UUID) (*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSet", ctx, id)
+	ret0, _ := ret[0].(*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSet indicates an expected call of GetSet.
+func (mr *MockEvaluationServiceMockRecorder) GetSet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSet", reflect.TypeOf((*MockEvaluationService)(nil).GetSet), ctx, id)
+}
+
+// GetSetsByChatAgentID mocks base method.
+func (m *MockEvaluationService) GetSetsByChatAgentID(ctx context.Context, chatAgentID uuid.UUID) ([]*models.EvaluationSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetsByChatAgentID", ctx, chatAgentID)
+	ret0, _ := ret[0].([]*models.EvaluationSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetsByChatAgentID indicates an expected call of GetSetsByChatAgentID.
+func (mr *MockEvaluationServiceMockRecorder) GetSetsByChatAgentID(ctx, chatAgentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetsByChatAgentID", reflect.TypeOf((*MockEvaluationService)(nil).GetSetsByChatAgentID), ctx, chatAgentID)
+}
+
+// ProcessPendingRuns mocks base method.
+func (m *MockEvaluationService) ProcessPendingRuns(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingRuns", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessPendingRuns indicates an expected call of ProcessPendingRuns.
+func (mr *MockEvaluationServiceMockRecorder) ProcessPendingRuns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingRuns", reflect.TypeOf((*MockEvaluationService)(nil).ProcessPendingRuns), ctx)
+}
+
+// SaveCase mocks base method.
+func (m *MockEvaluationService) SaveCase(ctx context.Context, evaluationCase *models.EvaluationCase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCase", ctx, evaluationCase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCase indicates an expected call of SaveCase.
+func (mr *MockEvaluationServiceMockRecorder) SaveCase(ctx, evaluationCase any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCase", reflect.TypeOf((*MockEvaluationService)(nil).SaveCase), ctx, evaluationCase)
+}
+
+// SaveSet mocks base method.
+func (m *MockEvaluationService) SaveSet(ctx context.Context, set *models.EvaluationSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSet", ctx, set)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSet indicates an expected call of SaveSet.
+func (mr *MockEvaluationServiceMockRecorder) SaveSet(ctx, set any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSet", reflect.TypeOf((*MockEvaluationService)(nil).SaveSet), ctx, set)
+}
+
+// MockFeatureFlagService is a mock of FeatureFlagService interface.
+type MockFeatureFlagService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeatureFlagServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeatureFlagServiceMockRecorder is the mock recorder for MockFeatureFlagService.
+type MockFeatureFlagServiceMockRecorder struct {
+	mock *MockFeatureFlagService
+}
+
+// NewMockFeatureFlagService creates a new mock instance.
+func NewMockFeatureFlagService(ctrl *gomock.Controller) *MockFeatureFlagService {
+	mock := &MockFeatureFlagService{ctrl: ctrl}
+	mock.recorder = &MockFeatureFlagServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeatureFlagService) EXPECT() *MockFeatureFlagServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckLlmProviderType mocks base method.
+func (m *MockFeatureFlagService) CheckLlmProviderType(ctx context.Context, applicationID uuid.UUID, providerType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLlmProviderType", ctx, applicationID, providerType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckLlmProviderType indicates an expected call of CheckLlmProviderType.
+func (mr *MockFeatureFlagServiceMockRecorder) CheckLlmProviderType(ctx, applicationID, providerType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLlmProviderType", reflect.TypeOf((*MockFeatureFlagService)(nil).CheckLlmProviderType), ctx, applicationID, providerType)
+}
+
+// DeleteApplicationFeatureFlag mocks base method.
+func (m *MockFeatureFlagService) DeleteApplicationFeatureFlag(ctx context.Context, applicationID uuid.UUID, flag string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplicationFeatureFlag", ctx, applicationID, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteApplicationFeatureFlag indicates an expected call of DeleteApplicationFeatureFlag.
+func (mr *MockFeatureFlagServiceMockRecorder) DeleteApplicationFeatureFlag(ctx, applicationID, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationFeatureFlag", reflect.TypeOf((*MockFeatureFlagService)(nil).DeleteApplicationFeatureFlag), ctx, applicationID, flag)
+}
+
+// IsEnabled mocks base method.
+func (m *MockFeatureFlagService) IsEnabled(ctx context.Context, applicationID uuid.UUID, flag string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", ctx, applicationID, flag)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockFeatureFlagServiceMockRecorder) IsEnabled(ctx, applicationID, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockFeatureFlagService)(nil).IsEnabled), ctx, applicationID, flag)
+}
+
+// ListApplicationFeatureFlags mocks base method.
+func (m *MockFeatureFlagService) ListApplicationFeatureFlags(ctx context.Context, applicationID uuid.UUID) ([]*dto.ApplicationFeatureFlagDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplicationFeatureFlags", ctx, applicationID)
+	ret0, _ := ret[0].([]*dto.ApplicationFeatureFlagDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplicationFeatureFlags indicates an expected call of ListApplicationFeatureFlags.
+func (mr *MockFeatureFlagServiceMockRecorder) ListApplicationFeatureFlags(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplicationFeatureFlags", reflect.TypeOf((*MockFeatureFlagService)(nil).ListApplicationFeatureFlags), ctx, applicationID)
+}
+
+// SetApplicationFeatureFlag mocks base method.
+func (m *MockFeatureFlagService) SetApplicationFeatureFlag(ctx context.Context, applicationID uuid.UUID, flag string, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetApplicationFeatureFlag", ctx, applicationID, flag, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetApplicationFeatureFlag indicates an expected call of SetApplicationFeatureFlag.
+func (mr *MockFeatureFlagServiceMockRecorder) SetApplicationFeatureFlag(ctx, applicationID, flag, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetApplicationFeatureFlag", reflect.TypeOf((*MockFeatureFlagService)(nil).SetApplicationFeatureFlag), ctx, applicationID, flag, enabled)
+}
+
+// MockKnowledgeBaseService is a mock of KnowledgeBaseService interface.
+type MockKnowledgeBaseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockKnowledgeBaseServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockKnowledgeBaseServiceMockRecorder is the mock recorder for MockKnowledgeBaseService.
+type MockKnowledgeBaseServiceMockRecorder struct {
+	mock *MockKnowledgeBaseService
+}
+
+// NewMockKnowledgeBaseService creates a new mock instance.
+func NewMockKnowledgeBaseService(ctrl *gomock.Controller) *MockKnowledgeBaseService {
+	mock := &MockKnowledgeBaseService{ctrl: ctrl}
+	mock.recorder = &MockKnowledgeBaseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKnowledgeBaseService) EXPECT() *MockKnowledgeBaseServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteDocument mocks base method.
+func (m *MockKnowledgeBaseService) DeleteDocument(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDocument", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDocument indicates an expected call of DeleteDocument.
+func (mr *MockKnowledgeBaseServiceMockRecorder) DeleteDocument(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDocument", reflect.TypeOf((*MockKnowledgeBaseService)(nil).DeleteDocument), ctx, id)
+}
+
+// DeleteKnowledgeBase mocks base method.
+func (m *MockKnowledgeBaseService) DeleteKnowledgeBase(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKnowledgeBase", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKnowledgeBase indicates an expected call of DeleteKnowledgeBase.
+func (mr *MockKnowledgeBaseServiceMockRecorder) DeleteKnowledgeBase(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKnowledgeBase", reflect.TypeOf((*MockKnowledgeBaseService)(nil).DeleteKnowledgeBase), ctx, id)
+}
+
+// GetDocumentsByKnowledgeBaseID mocks base method.
+func (m *MockKnowledgeBaseService) GetDocumentsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID, status string, page, pageSize int) ([]*models.KnowledgeDocument, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocumentsByKnowledgeBaseID", ctx, knowledgeBaseID, status, page, pageSize)
+	ret0, _ := ret[0].([]*models.KnowledgeDocument)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDocumentsByKnowledgeBaseID indicates an expected call of GetDocumentsByKnowledgeBaseID.
+func (mr *MockKnowledgeBaseServiceMockRecorder) GetDocumentsByKnowledgeBaseID(ctx, knowledgeBaseID, status, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocumentsByKnowledgeBaseID", reflect.TypeOf((*MockKnowledgeBaseService)(nil).GetDocumentsByKnowledgeBaseID), ctx, knowledgeBaseID, status, page, pageSize)
+}
+
+// GetKnowledgeBasesByApplicationID mocks base method.
+func (m *MockKnowledgeBaseService) GetKnowledgeBasesByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.KnowledgeBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKnowledgeBasesByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.KnowledgeBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKnowledgeBasesByApplicationID indicates an expected call of GetKnowledgeBasesByApplicationID.
+func (mr *MockKnowledgeBaseServiceMockRecorder) GetKnowledgeBasesByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKnowledgeBasesByApplicationID", reflect.TypeOf((*MockKnowledgeBaseService)(nil).GetKnowledgeBasesByApplicationID), ctx, applicationID)
+}
+
+// ReindexDocument mocks base method.
+func (m *MockKnowledgeBaseService) ReindexDocument(ctx context.Context, id uuid.UUID) (*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReindexDocument", ctx, id)
+	ret0, _ := ret[0].(*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReindexDocument indicates an expected call of ReindexDocument.
+func (mr *MockKnowledgeBaseServiceMockRecorder) ReindexDocument(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReindexDocument", reflect.TypeOf((*MockKnowledgeBaseService)(nil).ReindexDocument), ctx, id)
+}
+
+// ReindexKnowledgeBase mocks base method.
+func (m *MockKnowledgeBaseService) ReindexKnowledgeBase(ctx context.Context, id uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReindexKnowledgeBase", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReindexKnowledgeBase indicates an expected call of ReindexKnowledgeBase.
+func (mr *MockKnowledgeBaseServiceMockRecorder) ReindexKnowledgeBase(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReindexKnowledgeBase", reflect.TypeOf((*MockKnowledgeBaseService)(nil).ReindexKnowledgeBase), ctx, id)
+}
+
+// Retrieve mocks base method.
+func (m *MockKnowledgeBaseService) Retrieve(ctx context.Context, chatAgent *models.ChatAgent, query string) ([]*service.KnowledgeRetrievalResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retrieve", ctx, chatAgent, query)
+	ret0, _ := ret[0].([]*service.KnowledgeRetrievalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retrieve indicates an expected call of Retrieve.
+func (mr *MockKnowledgeBaseServiceMockRecorder) Retrieve(ctx, chatAgent, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retrieve", reflect.TypeOf((*MockKnowledgeBaseService)(nil).Retrieve), ctx, chatAgent, query)
+}
+
+// SaveKnowledgeBase mocks base method.
+func (m *MockKnowledgeBaseService) SaveKnowledgeBase(ctx context.Context, knowledgeBase *models.KnowledgeBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveKnowledgeBase", ctx, knowledgeBase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveKnowledgeBase indicates an expected call of SaveKnowledgeBase.
+func (mr *MockKnowledgeBaseServiceMockRecorder) SaveKnowledgeBase(ctx, knowledgeBase any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveKnowledgeBase", reflect.TypeOf((*MockKnowledgeBaseService)(nil).SaveKnowledgeBase), ctx, knowledgeBase)
+}
+
+// SaveTextDocument mocks base method.
+func (m *MockKnowledgeBaseService) SaveTextDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, name, content string) (*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTextDocument", ctx, knowledgeBaseID, documentID, name, content)
+	ret0, _ := ret[0].(*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTextDocument indicates an expected call of SaveTextDocument.
+func (mr *MockKnowledgeBaseServiceMockRecorder) SaveTextDocument(ctx, knowledgeBaseID, documentID, name, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTextDocument", reflect.TypeOf((*MockKnowledgeBaseService)(nil).SaveTextDocument), ctx, knowledgeBaseID, documentID, name, content)
+}
+
+// UploadDocument mocks base method.
+func (m *MockKnowledgeBaseService) UploadDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID, file io.Reader, filename string) (*models.KnowledgeDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadDocument", ctx, knowledgeBaseID, documentID, file, filename)
+	ret0, _ := ret[0].(*models.KnowledgeDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDocument indicates an expected call of UploadDocument.
+func (mr *MockKnowledgeBaseServiceMockRecorder) UploadDocument(ctx, knowledgeBaseID, documentID, file, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDocument", reflect.TypeOf((*MockKnowledgeBaseService)(nil).UploadDocument), ctx, knowledgeBaseID, documentID, file, filename)
+}
+
+// MockLlmProviderDefineService is a mock of LlmProviderDefineService interface.
+type MockLlmProviderDefineService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLlmProviderDefineServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLlmProviderDefineServiceMockRecorder is the mock recorder for MockLlmProviderDefineService.
+type MockLlmProviderDefineServiceMockRecorder struct {
+	mock *MockLlmProviderDefineService
+}
+
+// NewMockLlmProviderDefineService creates a new mock instance.
+func NewMockLlmProviderDefineService(ctrl *gomock.Controller) *MockLlmProviderDefineService {
+	mock := &MockLlmProviderDefineService{ctrl: ctrl}
+	mock.recorder = &MockLlmProviderDefineServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLlmProviderDefineService) EXPECT() *MockLlmProviderDefineServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAllLlmProviderDefines mocks base method.
+func (m *MockLlmProviderDefineService) GetAllLlmProviderDefines(ctx context.Context) ([]*models.LlmProviderDefine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllLlmProviderDefines", ctx)
+	ret0, _ := ret[0].([]*models.LlmProviderDefine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllLlmProviderDefines indicates an expected call of GetAllLlmProviderDefines.
+func (mr *MockLlmProviderDefineServiceMockRecorder) GetAllLlmProviderDefines(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLlmProviderDefines", reflect.TypeOf((*MockLlmProviderDefineService)(nil).GetAllLlmProviderDefines), ctx)
+}
+
+// GetLlmProviderDefinePresets mocks base method.
+func (m *MockLlmProviderDefineService) GetLlmProviderDefinePresets() []define.LlmProviderDefinePreset {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLlmProviderDefinePresets")
+	ret0, _ := ret[0].([]define.LlmProviderDefinePreset)
+	return ret0
+}
+
+// GetLlmProviderDefinePresets indicates an expected call of GetLlmProviderDefinePresets.
+func (mr *MockLlmProviderDefineServiceMockRecorder) GetLlmProviderDefinePresets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLlmProviderDefinePresets", reflect.TypeOf((*MockLlmProviderDefineService)(nil).GetLlmProviderDefinePresets))
+}
+
+// LoadLlmProviderDefinePresets mocks base method.
+func (m *MockLlmProviderDefineService) LoadLlmProviderDefinePresets(ctx context.Context, overwrite bool) (*dto.LoadLlmProviderDefinePresetsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadLlmProviderDefinePresets", ctx, overwrite)
+	ret0, _ := ret[0].(*dto.LoadLlmProviderDefinePresetsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadLlmProviderDefinePresets indicates an expected call of LoadLlmProviderDefinePresets.
+func (mr *MockLlmProviderDefineServiceMockRecorder) LoadLlmProviderDefinePresets(ctx, overwrite any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadLlmProviderDefinePresets", reflect.TypeOf((*MockLlmProviderDefineService)(nil).LoadLlmProviderDefinePresets), ctx, overwrite)
+}
+
+// MockLlmProviderLimiter is a mock of LlmProviderLimiter interface.
+type MockLlmProviderLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockLlmProviderLimiterMockRecorder
+	isgomock struct{}
+}
+
+// MockLlmProviderLimiterMockRecorder is the mock recorder for MockLlmProviderLimiter.
+type MockLlmProviderLimiterMockRecorder struct {
+	mock *MockLlmProviderLimiter
+}
+
+// NewMockLlmProviderLimiter creates a new mock instance.
+func NewMockLlmProviderLimiter(ctrl *gomock.Controller) *MockLlmProviderLimiter {
+	mock := &MockLlmProviderLimiter{ctrl: ctrl}
+	mock.recorder = &MockLlmProviderLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLlmProviderLimiter) EXPECT() *MockLlmProviderLimiterMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockLlmProviderLimiter) Acquire(ctx context.Context, provider *models.ApplicationLlmProvider, onQueued func(int)) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, provider, onQueued)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockLlmProviderLimiterMockRecorder) Acquire(ctx, provider, onQueued any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockLlmProviderLimiter)(nil).Acquire), ctx, provider, onQueued)
+}
+
+// Stats mocks base method.
+func (m *MockLlmProviderLimiter) Stats() []service.LlmProviderLimiterStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].([]service.LlmProviderLimiterStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockLlmProviderLimiterMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockLlmProviderLimiter)(nil).Stats))
+}
+
+// MockLlmProviderService is a mock of LlmProviderService interface.
+type MockLlmProviderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLlmProviderServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLlmProviderServiceMockRecorder is the mock recorder for MockLlmProviderService.
+type MockLlmProviderServiceMockRecorder struct {
+	mock *MockLlmProviderService
+}
+
+// NewMockLlmProviderService creates a new mock instance.
+func NewMockLlmProviderService(ctrl *gomock.Controller) *MockLlmProviderService {
+	mock := &MockLlmProviderService{ctrl: ctrl}
+	mock.recorder = &MockLlmProviderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLlmProviderService) EXPECT() *MockLlmProviderServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteLlmProvider mocks base method.
+func (m *MockLlmProviderService) DeleteLlmProvider(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLlmProvider", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLlmProvider indicates an expected call of DeleteLlmProvider.
+func (mr *MockLlmProviderServiceMockRecorder) DeleteLlmProvider(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLlmProvider", reflect.TypeOf((*MockLlmProviderService)(nil).DeleteLlmProvider), ctx, id)
+}
+
+// GetAllLlmProviders mocks base method.
+func (m *MockLlmProviderService) GetAllLlmProviders(ctx context.Context) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllLlmProviders", ctx)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllLlmProviders indicates an expected call of GetAllLlmProviders.
+func (mr *MockLlmProviderServiceMockRecorder) GetAllLlmProviders(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLlmProviders", reflect.TypeOf((*MockLlmProviderService)(nil).GetAllLlmProviders), ctx)
+}
+
+// GetLlmProviderByID mocks base method.
+func (m *MockLlmProviderService) GetLlmProviderByID(ctx context.Context, id uuid.UUID) (*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLlmProviderByID", ctx, id)
+	ret0, _ := ret[0].(*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLlmProviderByID indicates an expected call of GetLlmProviderByID.
+func (mr *MockLlmProviderServiceMockRecorder) GetLlmProviderByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLlmProviderByID", reflect.TypeOf((*MockLlmProviderService)(nil).GetLlmProviderByID), ctx, id)
+}
+
+// GetLlmProvidersByApplicationID mocks base method.
+func (m *MockLlmProviderService) GetLlmProvidersByApplicationID(ctx context.Context, applicationID uuid.UUID) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLlmProvidersByApplicationID", ctx, applicationID)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLlmProvidersByApplicationID indicates an expected call of GetLlmProvidersByApplicationID.
+func (mr *MockLlmProviderServiceMockRecorder) GetLlmProvidersByApplicationID(ctx, applicationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLlmProvidersByApplicationID", reflect.TypeOf((*MockLlmProviderService)(nil).GetLlmProvidersByApplicationID), ctx, applicationID)
+}
+
+// QueryLlmProviders mocks base method.
+func (m *MockLlmProviderService) QueryLlmProviders(ctx context.Context, query *models.ApplicationLlmProvider) ([]*models.ApplicationLlmProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLlmProviders", ctx, query)
+	ret0, _ := ret[0].([]*models.ApplicationLlmProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryLlmProviders indicates an expected call of QueryLlmProviders.
+func (mr *MockLlmProviderServiceMockRecorder) QueryLlmProviders(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLlmProviders", reflect.TypeOf((*MockLlmProviderService)(nil).QueryLlmProviders), ctx, query)
+}
+
+// SaveLlmProvider mocks base method.
+func (m *MockLlmProviderService) SaveLlmProvider(ctx context.Context, llmProvider *models.ApplicationLlmProvider, updateFields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLlmProvider", ctx, llmProvider, updateFields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLlmProvider indicates an expected call of SaveLlmProvider.
+func (mr *MockLlmProviderServiceMockRecorder) SaveLlmProvider(ctx, llmProvider, updateFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLlmProvider", reflect.TypeOf((*MockLlmProviderService)(nil).SaveLlmProvider), ctx, llmProvider, updateFields)
+}
+
+// MockMcpOauthService is a mock of McpOauthService interface.
+type MockMcpOauthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMcpOauthServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMcpOauthServiceMockRecorder is the mock recorder for MockMcpOauthService.
+type MockMcpOauthServiceMockRecorder struct {
+	mock *MockMcpOauthService
+}
+
+// NewMockMcpOauthService creates a new mock instance.
+func NewMockMcpOauthService(ctrl *gomock.Controller) *MockMcpOauthService {
+	mock := &MockMcpOauthService{ctrl: ctrl}
+	mock.recorder = &MockMcpOauthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMcpOauthService) EXPECT() *MockMcpOauthServiceMockRecorder {
+	return m.recorder
+}
+
+// CompleteAuthorization mocks base method.
+func (m *MockMcpOauthService) CompleteAuthorization(ctx context.Context, state, code string) (*models.ApplicationMcpServerConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAuthorization", ctx, state, code)
+	ret0, _ := ret[0].(*models.ApplicationMcpServerConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAuthorization indicates an expected call of CompleteAuthorization.
+func (mr *MockMcpOauthServiceMockRecorder) CompleteAuthorization(ctx, state, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAuthorization", reflect.TypeOf((*MockMcpOauthService)(nil).CompleteAuthorization), ctx, state, code)
+}
+
+// GetAuthorizationStatus mocks base method.
+func (m *MockMcpOauthService) GetAuthorizationStatus(ctx context.Context, configID uuid.UUID) (*dto.McpOauthStatusDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorizationStatus", ctx, configID)
+	ret0, _ := ret[0].(*dto.McpOauthStatusDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorizationStatus indicates an expected call of GetAuthorizationStatus.
+func (mr *MockMcpOauthServiceMockRecorder) GetAuthorizationStatus(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizationStatus", reflect.TypeOf((*MockMcpOauthService)(nil).GetAuthorizationStatus), ctx, configID)
+}
+
+// RevokeAuthorization mocks base method.
+func (m *MockMcpOauthService) RevokeAuthorization(ctx context.Context, configID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAuthorization", ctx, configID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAuthorization indicates an expected call of RevokeAuthorization.
+func (mr *MockMcpOauthServiceMockRecorder) RevokeAuthorization(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAuthorization", reflect.TypeOf((*MockMcpOauthService)(nil).RevokeAuthorization), ctx, configID)
+}
+
+// StartAuthorization mocks base method.
+func (m *MockMcpOauthService) StartAuthorization(ctx context.Context, configID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAuthorization", ctx, configID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAuthorization indicates an expected call of StartAuthorization.
+func (mr *MockMcpOauthServiceMockRecorder) StartAuthorization(ctx, configID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAuthorization", reflect.TypeOf((*MockMcpOauthService)(nil).StartAuthorization), ctx, configID)
+}
+
+// MockOidcLoginService is a mock of OidcLoginService interface.
+type MockOidcLoginService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcLoginServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOidcLoginServiceMockRecorder is the mock recorder for MockOidcLoginService.
+type MockOidcLoginServiceMockRecorder struct {
+	mock *MockOidcLoginService
+}
+
+// NewMockOidcLoginService creates a new mock instance.
+func NewMockOidcLoginService(ctrl *gomock.Controller) *MockOidcLoginService {
+	mock := &MockOidcLoginService{ctrl: ctrl}
+	mock.recorder = &MockOidcLoginServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidcLoginService) EXPECT() *MockOidcLoginServiceMockRecorder {
+	return m.recorder
+}
+
+// CompleteLogin mocks base method.
+func (m *MockOidcLoginService) CompleteLogin(ctx context.Context, state, code, ipAddress, userAgent string) (*models.SystemUser, *service.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLogin", ctx, state, code, ipAddress, userAgent)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(*service.SessionTokens)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompleteLogin indicates an expected call of CompleteLogin.
+func (mr *MockOidcLoginServiceMockRecorder) CompleteLogin(ctx, state, code, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLogin", reflect.TypeOf((*MockOidcLoginService)(nil).CompleteLogin), ctx, state, code, ipAddress, userAgent)
+}
+
+// Enabled mocks base method.
+func (m *MockOidcLoginService) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockOidcLoginServiceMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockOidcLoginService)(nil).Enabled))
+}
+
+// StartLogin mocks base method.
+func (m *MockOidcLoginService) StartLogin(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLogin", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartLogin indicates an expected call of StartLogin.
+func (mr *MockOidcLoginServiceMockRecorder) StartLogin(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLogin", reflect.TypeOf((*MockOidcLoginService)(nil).StartLogin), ctx)
+}
+
+// MockServiceUserService is a mock of ServiceUserService interface.
+type MockServiceUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceUserServiceMockRecorder is the mock recorder for MockServiceUserService.
+type MockServiceUserServiceMockRecorder struct {
+	mock *MockServiceUserService
+}
+
+// NewMockServiceUserService creates a new mock instance.
+func NewMockServiceUserService(ctrl *gomock.Controller) *MockServiceUserService {
+	mock := &MockServiceUserService{ctrl: ctrl}
+	mock.recorder = &MockServiceUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceUserService) EXPECT() *MockServiceUserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetServiceUser mocks base method.
+func (m *MockServiceUserService) GetServiceUser(ctx context.Context, applicationID uuid.UUID, serviceUserID string) (*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceUser", ctx, applicationID, serviceUserID)
+	ret0, _ := ret[0].(*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceUser indicates an expected call of GetServiceUser.
+func (mr *MockServiceUserServiceMockRecorder) GetServiceUser(ctx, applicationID, serviceUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceUser", reflect.TypeOf((*MockServiceUserService)(nil).GetServiceUser), ctx, applicationID, serviceUserID)
+}
+
+// GetServiceUserMap mocks base method.
+func (m *MockServiceUserService) GetServiceUserMap(ctx context.Context, applicationID uuid.UUID, serviceUserIDs []string) (map[string]*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceUserMap", ctx, applicationID, serviceUserIDs)
+	ret0, _ := ret[0].(map[string]*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceUserMap indicates an expected call of GetServiceUserMap.
+func (mr *MockServiceUserServiceMockRecorder) GetServiceUserMap(ctx, applicationID, serviceUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceUserMap", reflect.TypeOf((*MockServiceUserService)(nil).GetServiceUserMap), ctx, applicationID, serviceUserIDs)
+}
+
+// GetServiceUsersByApplicationID mocks base method.
+func (m *MockServiceUserService) GetServiceUsersByApplicationID(ctx context.Context, applicationID uuid.UUID, keyword string, page, pageSize int) ([]*models.ServiceUser, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceUsersByApplicationID", ctx, applicationID, keyword, page, pageSize)
+	ret0, _ := ret[0].([]*models.ServiceUser)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetServiceUsersByApplicationID indicates an expected call of GetServiceUsersByApplicationID.
+func (mr *MockServiceUserServiceMockRecorder) GetServiceUsersByApplicationID(ctx, applicationID, keyword, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceUsersByApplicationID", reflect.TypeOf((*MockServiceUserService)(nil).GetServiceUsersByApplicationID), ctx, applicationID, keyword, page, pageSize)
+}
+
+// UpsertServiceUser mocks base method.
+func (m *MockServiceUserService) UpsertServiceUser(ctx context.Context, applicationID uuid.UUID, req *dto.UpsertServiceUserRequest) (*models.ServiceUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertServiceUser", ctx, applicationID, req)
+	ret0, _ := ret[0].(*models.ServiceUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertServiceUser indicates an expected call of UpsertServiceUser.
+func (mr *MockServiceUserServiceMockRecorder) UpsertServiceUser(ctx, applicationID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertServiceUser", reflect.TypeOf((*MockServiceUserService)(nil).UpsertServiceUser), ctx, applicationID, req)
+}
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvite mocks base method.
+func (m *MockUserService) AcceptInvite(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvite", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvite indicates an expected call of AcceptInvite.
+func (mr *MockUserServiceMockRecorder) AcceptInvite(ctx, token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvite", reflect.TypeOf((*MockUserService)(nil).AcceptInvite), ctx, token, password)
+}
+
+// ChangePassword mocks base method.
+func (m *MockUserService) ChangePassword(ctx context.Context, token, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, token, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserServiceMockRecorder) ChangePassword(ctx, token, currentPassword, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserService)(nil).ChangePassword), ctx, token, currentPassword, newPassword)
+}
+
+// CleanupExpiredSessions mocks base method.
+func (m *MockUserService) CleanupExpiredSessions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupExpiredSessions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanupExpiredSessions indicates an expected call of CleanupExpiredSessions.
+func (mr *MockUserServiceMockRecorder) CleanupExpiredSessions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupExpiredSessions", reflect.TypeOf((*MockUserService)(nil).CleanupExpiredSessions), ctx)
+}
+
+// CreateSession mocks base method.
+func (m *MockUserService) CreateSession(ctx context.Context, user *models.SystemUser, ipAddress, userAgent string) (*service.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, user, ipAddress, userAgent)
+	ret0, _ := ret[0].(*service.SessionTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockUserServiceMockRecorder) CreateSession(ctx, user, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockUserService)(nil).CreateSession), ctx, user, ipAddress, userAgent)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id)
+}
+
+// GetAllUsers mocks base method.
+func (m *MockUserService) GetAllUsers(ctx context.Context) ([]*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsers", ctx)
+	ret0, _ := ret[0].([]*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsers indicates an expected call of GetAllUsers.
+func (mr *MockUserServiceMockRecorder) GetAllUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserService)(nil).GetAllUsers), ctx)
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockUserService) GetCurrentUser(ctx context.Context) (*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", ctx)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockUserServiceMockRecorder) GetCurrentUser(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockUserService)(nil).GetCurrentUser), ctx)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserService) GetUserByID(ctx context.Context, id uuid.UUID) (*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserServiceMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserService)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByToken mocks base method.
+func (m *MockUserService) GetUserByToken(ctx context.Context, token string) (*models.SystemUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByToken", ctx, token)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByToken indicates an expected call of GetUserByToken.
+func (mr *MockUserServiceMockRecorder) GetUserByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByToken", reflect.TypeOf((*MockUserService)(nil).GetUserByToken), ctx, token)
+}
+
+// InviteUser mocks base method.
+func (m *MockUserService) InviteUser(ctx context.Context, user *models.SystemUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteUser indicates an expected call of InviteUser.
+func (mr *MockUserServiceMockRecorder) InviteUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUser", reflect.TypeOf((*MockUserService)(nil).InviteUser), ctx, user)
+}
+
+// ListSessions mocks base method.
+func (m *MockUserService) ListSessions(ctx context.Context, token string) ([]*models.SystemUserSession, *models.SystemUserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, token)
+	ret0, _ := ret[0].([]*models.SystemUserSession)
+	ret1, _ := ret[1].(*models.SystemUserSession)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUserServiceMockRecorder) ListSessions(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUserService)(nil).ListSessions), ctx, token)
+}
+
+// Login mocks base method.
+func (m *MockUserService) Login(ctx context.Context, number, password, ipAddress, userAgent string) (*models.SystemUser, *service.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, number, password, ipAddress, userAgent)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(*service.SessionTokens)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockUserServiceMockRecorder) Login(ctx, number, password, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), ctx, number, password, ipAddress, userAgent)
+}
+
+// Logout mocks base method.
+func (m *MockUserService) Logout(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServiceMockRecorder) Logout(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserService)(nil).Logout), ctx, token)
+}
+
+// RefreshSession mocks base method.
+func (m *MockUserService) RefreshSession(ctx context.Context, refreshToken string) (*models.SystemUser, *service.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, refreshToken)
+	ret0, _ := ret[0].(*models.SystemUser)
+	ret1, _ := ret[1].(*service.SessionTokens)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockUserServiceMockRecorder) RefreshSession(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockUserService)(nil).RefreshSession), ctx, refreshToken)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockUserService) RequestPasswordReset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockUserServiceMockRecorder) RequestPasswordReset(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockUserService)(nil).RequestPasswordReset), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserServiceMockRecorder) ResetPassword(ctx, token, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserService)(nil).ResetPassword), ctx, token, newPassword)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockUserService) RevokeOtherSessions(ctx context.Context, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockUserServiceMockRecorder) RevokeOtherSessions(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockUserService)(nil).RevokeOtherSessions), ctx, token)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUserService) RevokeSession(ctx context.Context, token string, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, token, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUserServiceMockRecorder) RevokeSession(ctx, token, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUserService)(nil).RevokeSession), ctx, token, sessionID)
+}
+
+// SaveUser mocks base method.
+func (m *MockUserService) SaveUser(ctx context.Context, user *models.SystemUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUser indicates an expected call of SaveUser.
+func (mr *MockUserServiceMockRecorder) SaveUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockUserService)(nil).SaveUser), ctx, user)
+}
+
+// MockWorkspaceUploadService is a mock of WorkspaceUploadService interface.
+type MockWorkspaceUploadService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceUploadServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceUploadServiceMockRecorder is the mock recorder for MockWorkspaceUploadService.
+type MockWorkspaceUploadServiceMockRecorder struct {
+	mock *MockWorkspaceUploadService
+}
+
+// NewMockWorkspaceUploadService creates a new mock instance.
+func NewMockWorkspaceUploadService(ctrl *gomock.Controller) *MockWorkspaceUploadService {
+	mock := &MockWorkspaceUploadService{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceUploadServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceUploadService) EXPECT() *MockWorkspaceUploadServiceMockRecorder {
+	return m.recorder
+}
+
+// CleanupOrphanedUploads mocks base method.
+func (m *MockWorkspaceUploadService) CleanupOrphanedUploads(ctx context.Context, olderThan time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOrphanedUploads", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupOrphanedUploads indicates an expected call of CleanupOrphanedUploads.
+func (mr *MockWorkspaceUploadServiceMockRecorder) CleanupOrphanedUploads(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOrphanedUploads", reflect.TypeOf((*MockWorkspaceUploadService)(nil).CleanupOrphanedUploads), ctx, olderThan)
+}
+
+// TrackUpload mocks base method.
+func (m *MockWorkspaceUploadService) TrackUpload(ctx context.Context, filePath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackUpload", ctx, filePath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackUpload indicates an expected call of TrackUpload.
+func (mr *MockWorkspaceUploadServiceMockRecorder) TrackUpload(ctx, filePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackUpload", reflect.TypeOf((*MockWorkspaceUploadService)(nil).TrackUpload), ctx, filePath)
+}
@@ -0,0 +1,270 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// oidcLoginPendingTTL 单点登录请求的有效期，超过后回调不再接受
+const oidcLoginPendingTTL = 10 * time.Minute
+
+// 系统用户账号和名字的长度上限，与数据库字段长度一致
+const (
+	maxSystemUserNumberLength = 64
+	maxSystemUserNameLength   = 64
+)
+
+// OidcLoginService 系统用户 OIDC 单点登录 业务逻辑层接口
+// 外部身份按 Issuer 和 sub 映射为系统用户，首次登录时按已验证邮箱关联已有用户或自动创建用户
+type OidcLoginService interface {
+	// Enabled 是否已配置单点登录
+	Enabled() bool
+
+	// StartLogin 发起单点登录，返回需要在浏览器中打开的授权地址
+	StartLogin(ctx context.Context) (string, error)
+
+	// CompleteLogin 处理身份提供方的回调
+	// 校验 ID令牌并映射为系统用户，创建登录会话
+	CompleteLogin(ctx context.Context, state, code, ipAddress, userAgent string) (*models.SystemUser, *SessionTokens, error)
+}
+
+// oidcLoginService 系统用户 OIDC 单点登录 业务逻辑层实现
+type oidcLoginService struct {
+	userRepo     repository.SystemUserRepository          // 用户数据访问层
+	identityRepo repository.SystemUserIdentityRepository  // 外部身份数据访问层
+	loginRepo    repository.SystemUserOidcLoginRepository // 单点登录请求数据访问层
+	userService  UserService                              // 用户服务，用于创建登录会话
+	config       config.OidcConfig                        // 单点登录配置
+	provider     *oidcProvider                            // 身份提供方客户端
+}
+
+// NewOidcLoginService 创建 OIDC 单点登录 服务实例
+// 返回 OidcLoginService 接口的实现
+func NewOidcLoginService(
+	userRepo repository.SystemUserRepository,
+	identityRepo repository.SystemUserIdentityRepository,
+	loginRepo repository.SystemUserOidcLoginRepository,
+	userService UserService,
+	config *config.Config,
+) OidcLoginService {
+	return &oidcLoginService{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		loginRepo:    loginRepo,
+		userService:  userService,
+		config:       config.Oidc,
+		provider:     newOidcProvider(config.Oidc),
+	}
+}
+
+// Enabled 是否已配置单点登录
+func (s *oidcLoginService) Enabled() bool {
+	return s.config.Issuer != "" && s.config.ClientID != "" && s.config.RedirectURL != ""
+}
+
+// StartLogin 发起单点登录
+// 使用 PKCE 和 nonce，登录请求保存到数据库，多实例部署时回调可由任意实例处理
+func (s *oidcLoginService) StartLogin(ctx context.Context) (string, error) {
+	if !s.Enabled() {
+		return "", apperror.New(apperror.CodeInvalidArgument, "未启用单点登录")
+	}
+
+	codeVerifier := oauth2.GenerateVerifier()
+	state, err := generateRandomToken()
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "生成登录请求state失败", err)
+	}
+	nonce, err := generateRandomToken()
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "生成登录请求nonce失败", err)
+	}
+	authorizationURL, err := s.provider.authorizationURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return "", apperror.Wrap(apperror.CodeServiceUnavailable, "获取身份提供方信息失败", err)
+	}
+
+	// 顺带清理未完成的过期登录请求
+	if err := s.loginRepo.DeleteExpired(ctx); err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "清理过期登录请求失败", err)
+	}
+	login := &models.SystemUserOidcLogin{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiredAt:    time.Now().Add(oidcLoginPendingTTL),
+	}
+	if err := s.loginRepo.Create(ctx, login); err != nil {
+		return "", apperror.Wrap(apperror.CodeInternal, "保存登录请求失败", err)
+	}
+	return authorizationURL, nil
+}
+
+// CompleteLogin 处理身份提供方的回调
+// 登录请求只能使用一次，换取令牌前先删除
+func (s *oidcLoginService) CompleteLogin(ctx context.Context, state, code, ipAddress, userAgent string) (*models.SystemUser, *SessionTokens, error) {
+	if !s.Enabled() {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "未启用单点登录")
+	}
+	login, err := s.loginRepo.TakeByState(ctx, state)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeInternal, "查询登录请求失败", err)
+	}
+	if login == nil {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "登录请求不存在或已完成，请重新登录")
+	}
+	if time.Now().After(login.ExpiredAt) {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "登录请求已过期，请重新登录")
+	}
+	if code == "" {
+		return nil, nil, apperror.New(apperror.CodeInvalidArgument, "登录回调缺少授权码")
+	}
+
+	rawIDToken, err := s.provider.exchangeCode(ctx, code, login.CodeVerifier)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeUnauthorized, "使用授权码换取令牌失败", err)
+	}
+	claims, err := s.provider.verifyIDToken(ctx, rawIDToken, login.Nonce)
+	if err != nil {
+		return nil, nil, apperror.Wrap(apperror.CodeUnauthorized, "ID令牌校验失败", err)
+	}
+	if !s.hasAllowedRole(claims) {
+		return nil, nil, apperror.New(apperror.CodeForbidden, "外部身份没有登录本系统的角色")
+	}
+
+	user, err := s.resolveUser(ctx, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokens, err := s.userService.CreateSession(ctx, user, ipAddress, userAgent)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, tokens, nil
+}
+
+// hasAllowedRole 外部身份的角色声明是否包含允许登录的角色，未配置允许的角色时不限制
+func (s *oidcLoginService) hasAllowedRole(claims map[string]interface{}) bool {
+	if len(s.config.AllowedRoles) == 0 {
+		return true
+	}
+	for _, role := range claimStrings(claims[s.config.RoleClaim]) {
+		if containsString(s.config.AllowedRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveUser 将外部身份映射为系统用户
+// 依次按已关联的外部身份、已验证的邮箱查找用户，都没有时按配置自动创建用户
+func (s *oidcLoginService) resolveUser(ctx context.Context, claims map[string]interface{}) (*models.SystemUser, error) {
+	issuer, _ := claims["iss"].(string)
+	subject, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	emailVerified, _ := claims["email_verified"].(bool)
+
+	identity, err := s.identityRepo.GetBySubject(ctx, issuer, subject)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "查询外部身份失败", err)
+	}
+	if identity != nil {
+		user, err := s.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeForbidden, "外部身份关联的用户不存在", err)
+		}
+		if identity.Email != email {
+			identity.Email = email
+			if err := s.identityRepo.Update(ctx, identity); err != nil {
+				return nil, apperror.Wrap(apperror.CodeInternal, "更新外部身份失败", err)
+			}
+		}
+		return user, nil
+	}
+
+	// 邮箱已由身份提供方验证时关联同邮箱的已有用户
+	var user *models.SystemUser
+	if email != "" && emailVerified {
+		if existing, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+			user = existing
+		}
+	}
+	if user == nil {
+		if !s.config.AutoProvision {
+			return nil, apperror.New(apperror.CodeForbidden, "外部身份未关联系统用户，请联系管理员")
+		}
+		if user, err = s.provisionUser(ctx, claims, subject, email); err != nil {
+			return nil, err
+		}
+	}
+
+	identity = &models.SystemUserIdentity{
+		UserID:  user.ID,
+		Issuer:  issuer,
+		Subject: subject,
+		Email:   email,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "保存外部身份失败", err)
+	}
+	return user, nil
+}
+
+// provisionUser 为首次登录的外部身份创建系统用户
+// 账号取配置的声明，已被占用时追加 sub 摘要
+// 创建的用户密码为空，账号密码登录会直接拒绝密码为空的用户，因此只能通过单点登录登录，管理员为其设置密码后才能使用密码登录
+func (s *oidcLoginService) provisionUser(ctx context.Context, claims map[string]interface{}, subject, email string) (*models.SystemUser, error) {
+	if email != "" {
+		if _, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+			return nil, apperror.New(apperror.CodeConflict, "外部身份的邮箱已被其他用户使用，且未经身份提供方验证")
+		}
+	}
+
+	number := ""
+	if s.config.UsernameClaim != "" {
+		number, _ = claims[s.config.UsernameClaim].(string)
+	}
+	if number == "" {
+		number = email
+	}
+	if number == "" {
+		number = subject
+	}
+	number = truncateRunes(strings.TrimSpace(number), maxSystemUserNumberLength)
+	if _, err := s.userRepo.GetByNumber(ctx, number); err == nil {
+		hash := sha256.Sum256([]byte(subject))
+		suffix := "-" + hex.EncodeToString(hash[:])[:8]
+		number = truncateRunes(number, maxSystemUserNumberLength-len(suffix)) + suffix
+		if _, err := s.userRepo.GetByNumber(ctx, number); err == nil {
+			return nil, apperror.Newf(apperror.CodeConflict, "用户账号已存在: %s", number)
+		}
+	}
+
+	name, _ := claims["name"].(string)
+	if name == "" {
+		name = number
+	}
+	user := &models.SystemUser{
+		Name:         truncateRunes(name, maxSystemUserNameLength),
+		Number:       number,
+		Email:        email,
+		PasswordSalt: uuid.New().String(),
+	}
+	user.ID = uuid.New()
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return nil, fmt.Errorf("创建用户失败: %w", err)
+	}
+	return user, nil
+}
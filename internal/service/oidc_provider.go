@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/config"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// oidcHTTPTimeout 请求身份提供方的超时时间
+	oidcHTTPTimeout = 10 * time.Second
+	// oidcMetadataTTL 身份提供方发现文档的缓存时间，签名公钥由 go-oidc 按 kid 缓存并在密钥轮换时重新拉取
+	oidcMetadataTTL = time.Hour
+)
+
+// oidcSigningAlgs 支持的 ID令牌签名算法
+var oidcSigningAlgs = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.PS256, oidc.PS384, oidc.PS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+}
+
+// oidcProvider OIDC 身份提供方客户端
+// 发现、JWKS 和 ID令牌校验使用 go-oidc，授权地址和换取令牌使用 oauth2，发现结果按 oidcMetadataTTL 缓存
+type oidcProvider struct {
+	config     config.OidcConfig
+	httpClient *http.Client
+
+	mu              sync.Mutex
+	provider        *oidc.Provider
+	providerExpires time.Time
+}
+
+// newOidcProvider 创建 OIDC 身份提供方客户端
+func newOidcProvider(oidcConfig config.OidcConfig) *oidcProvider {
+	return &oidcProvider{
+		config:     oidcConfig,
+		httpClient: &http.Client{Timeout: oidcHTTPTimeout},
+	}
+}
+
+// authorizationURL 生成浏览器跳转的授权地址，使用 PKCE（S256）和 nonce
+func (p *oidcProvider) authorizationURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+// exchangeCode 使用授权码换取令牌，返回 ID令牌
+func (p *oidcProvider) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	token, err := p.oauth2Config(provider).Exchange(p.clientContext(ctx), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return "", err
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return "", errors.New("令牌接口未返回 id_token")
+	}
+	return rawIDToken, nil
+}
+
+// verifyIDToken 校验 ID令牌的签名、签发方、受众、有效期和 nonce，返回令牌中的声明
+func (p *oidcProvider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (map[string]interface{}, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	verifier := provider.Verifier(&oidc.Config{ClientID: p.config.ClientID, SupportedSigningAlgs: oidcSigningAlgs})
+	idToken, err := verifier.Verify(p.clientContext(ctx), rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("ID令牌 nonce 不匹配")
+	}
+	if idToken.Subject == "" {
+		return nil, errors.New("ID令牌缺少 sub")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("ID令牌内容格式错误: %w", err)
+	}
+	if azp, ok := claims["azp"].(string); ok && len(idToken.Audience) > 1 && azp != p.config.ClientID {
+		return nil, errors.New("ID令牌授权方不是当前客户端")
+	}
+	return claims, nil
+}
+
+// discover 获取身份提供方的发现文档，go-oidc 会校验发现文档中的 Issuer 与配置一致
+func (p *oidcProvider) discover(ctx context.Context) (*oidc.Provider, error) {
+	p.mu.Lock()
+	if p.provider != nil && time.Now().Before(p.providerExpires) {
+		provider := p.provider
+		p.mu.Unlock()
+		return provider, nil
+	}
+	p.mu.Unlock()
+
+	// 公钥集合会在之后的请求中使用创建时的上下文拉取 JWKS，不能随本次请求取消
+	provider, err := oidc.NewProvider(p.clientContext(context.WithoutCancel(ctx)), p.config.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("获取身份提供方信息失败: %w", err)
+	}
+
+	p.mu.Lock()
+	p.provider = provider
+	p.providerExpires = time.Now().Add(oidcMetadataTTL)
+	p.mu.Unlock()
+	return provider, nil
+}
+
+// oauth2Config 生成授权码流程的 oauth2 配置
+// 机密客户端使用 client_secret_basic 认证，公共客户端在请求参数中携带 client_id
+func (p *oidcProvider) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	endpoint := provider.Endpoint()
+	endpoint.AuthStyle = oauth2.AuthStyleInParams
+	if p.config.ClientSecret != "" {
+		endpoint.AuthStyle = oauth2.AuthStyleInHeader
+	}
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint:     endpoint,
+	}
+}
+
+// clientContext 返回使用带超时的 HTTP 客户端请求身份提供方的上下文，go-oidc 和 oauth2 都从上下文中获取客户端
+func (p *oidcProvider) clientContext(ctx context.Context) context.Context {
+	return context.WithValue(oidc.ClientContext(ctx, p.httpClient), oauth2.HTTPClient, p.httpClient)
+}
+
+// claimStrings 将字符串或字符串数组类型的声明转换为字符串列表
+func claimStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// containsString 判断列表中是否包含指定字符串
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
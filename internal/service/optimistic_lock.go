@@ -0,0 +1,12 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import "lemon-tree-core/internal/apperror"
+
+// versionConflictError 生成数据版本冲突错误
+// 错误详情中包含当前版本号，客户端可据此重新获取最新数据后再保存
+func versionConflictError(resource string, currentVersion int64) *apperror.Error {
+	return apperror.Newf(apperror.CodeConflict, "%s已被他人修改，请刷新后重试", resource).
+		WithDetails(map[string]int64{"current_version": currentVersion})
+}
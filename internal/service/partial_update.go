@@ -0,0 +1,33 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"lemon-tree-core/internal/apperror"
+	"reflect"
+	"slices"
+)
+
+// mergeUpdateFields 按部分更新的字段列表合并模型
+// 字段列表中的字段保留 updated 的值，其余字段使用 existing 的当前值；嵌入的基础模型字段和 keep 中的字段不参与合并
+// 参数：updated - 保存请求转换得到的模型，existing - 数据库中的当前记录，fields - 部分更新的模型字段名，keep - 保持 updated 值的字段名
+// 返回：字段列表中有模型不存在的字段时返回错误
+func mergeUpdateFields[T any](updated, existing *T, fields []string, keep ...string) error {
+	updatedValue := reflect.ValueOf(updated).Elem()
+	existingValue := reflect.ValueOf(existing).Elem()
+	modelType := updatedValue.Type()
+	for _, name := range fields {
+		if _, ok := modelType.FieldByName(name); !ok {
+			return apperror.Newf(apperror.CodeInternal, "%s 不存在字段 %s", modelType.Name(), name)
+		}
+	}
+
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Anonymous || !field.IsExported() || slices.Contains(fields, field.Name) || slices.Contains(keep, field.Name) {
+			continue
+		}
+		updatedValue.Field(i).Set(existingValue.Field(i))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证和业务规则
+package service
+
+import (
+	"errors"
+	"lemon-tree-core/internal/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// invalidReferenceError 生成关联资源无效的错误
+// 错误详情包含引用的字段名和资源ID，便于调用方定位
+func invalidReferenceError(field string, id uuid.UUID, message string) error {
+	return apperror.New(apperror.CodeInvalidReference, message).WithDetails(map[string]string{
+		"field": field,
+		"id":    id.String(),
+	})
+}
+
+// referenceLookupError 将关联资源的查询错误转换为业务错误
+// 记录不存在时返回关联资源无效的错误，其他错误视为内部错误
+func referenceLookupError(field string, id uuid.UUID, resource string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return invalidReferenceError(field, id, resource+"不存在")
+	}
+	return apperror.Wrap(apperror.CodeInternal, "查询"+resource+"失败", err)
+}
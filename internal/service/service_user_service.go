@@ -0,0 +1,139 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/dto"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceUserService 业务侧用户 业务逻辑层接口
+// 定义 业务侧用户 相关的业务逻辑方法
+type ServiceUserService interface {
+	// UpsertServiceUser 登记业务侧用户
+	// 用户不存在时创建，存在时只更新请求中提供的字段
+	UpsertServiceUser(ctx context.Context, applicationID uuid.UUID, req *dto.UpsertServiceUserRequest) (*models.ServiceUser, error)
+
+	// GetServiceUser 根据业务侧用户ID获取业务侧用户
+	GetServiceUser(ctx context.Context, applicationID uuid.UUID, serviceUserID string) (*models.ServiceUser, error)
+
+	// GetServiceUsersByApplicationID 根据应用ID获取业务侧用户列表
+	// 支持按关键字搜索和分页
+	GetServiceUsersByApplicationID(ctx context.Context, applicationID uuid.UUID, keyword string, page, pageSize int) ([]*models.ServiceUser, int64, error)
+
+	// GetServiceUserMap 批量获取业务侧用户
+	// 返回以业务侧用户ID为键的映射，未登记的用户不包含在结果中
+	GetServiceUserMap(ctx context.Context, applicationID uuid.UUID, serviceUserIDs []string) (map[string]*models.ServiceUser, error)
+}
+
+// serviceUserService 业务侧用户 业务逻辑层实现
+// 实现 ServiceUserService 接口
+type serviceUserService struct {
+	serviceUserRepo repository.ServiceUserRepository // 数据访问层接口
+}
+
+// NewServiceUserService 创建 业务侧用户 服务实例
+// 返回 ServiceUserService 接口的实现
+// 参数：serviceUserRepo - 业务侧用户 数据访问层接口
+func NewServiceUserService(serviceUserRepo repository.ServiceUserRepository) ServiceUserService {
+	return &serviceUserService{
+		serviceUserRepo: serviceUserRepo,
+	}
+}
+
+// UpsertServiceUser 登记业务侧用户
+// 用户不存在时创建，存在时只更新请求中提供的字段
+func (s *serviceUserService) UpsertServiceUser(ctx context.Context, applicationID uuid.UUID, req *dto.UpsertServiceUserRequest) (*models.ServiceUser, error) {
+	if req.ServiceUserID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
+	}
+	if len(req.ServiceUserID) > 256 {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID长度不能超过256个字符")
+	}
+	if req.DisplayName != nil && len([]rune(*req.DisplayName)) > 128 {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "展示名称长度不能超过128个字符")
+	}
+	if req.AvatarUrl != nil && len(*req.AvatarUrl) > 512 {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "头像URL长度不能超过512个字符")
+	}
+
+	serviceUser, err := s.serviceUserRepo.GetByServiceUserID(ctx, applicationID, req.ServiceUserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("查询业务侧用户失败: %w", err)
+	}
+	isNew := serviceUser == nil
+	if isNew {
+		serviceUser = &models.ServiceUser{
+			ApplicationID: applicationID,
+			ServiceUserID: req.ServiceUserID,
+		}
+	}
+
+	if req.DisplayName != nil {
+		serviceUser.DisplayName = *req.DisplayName
+	}
+	if req.AvatarUrl != nil {
+		serviceUser.AvatarUrl = *req.AvatarUrl
+	}
+	if req.Metadata != nil {
+		metadata, err := json.Marshal(req.Metadata)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeInvalidArgument, "元数据格式无效", err)
+		}
+		serviceUser.Metadata = string(metadata)
+	}
+
+	if isNew {
+		err = s.serviceUserRepo.Create(ctx, serviceUser)
+	} else {
+		err = s.serviceUserRepo.Update(ctx, serviceUser)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("保存业务侧用户失败: %w", err)
+	}
+	return serviceUser, nil
+}
+
+// GetServiceUser 根据业务侧用户ID获取业务侧用户
+func (s *serviceUserService) GetServiceUser(ctx context.Context, applicationID uuid.UUID, serviceUserID string) (*models.ServiceUser, error) {
+	if serviceUserID == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "业务侧用户ID不能为空")
+	}
+	serviceUser, err := s.serviceUserRepo.GetByServiceUserID(ctx, applicationID, serviceUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.New(apperror.CodeNotFound, "业务侧用户不存在")
+		}
+		return nil, fmt.Errorf("查询业务侧用户失败: %w", err)
+	}
+	return serviceUser, nil
+}
+
+// GetServiceUsersByApplicationID 根据应用ID获取业务侧用户列表
+// 支持按关键字搜索和分页
+func (s *serviceUserService) GetServiceUsersByApplicationID(ctx context.Context, applicationID uuid.UUID, keyword string, page, pageSize int) ([]*models.ServiceUser, int64, error) {
+	return s.serviceUserRepo.GetByApplicationIDWithPagination(ctx, applicationID, keyword, page, pageSize)
+}
+
+// GetServiceUserMap 批量获取业务侧用户
+// 返回以业务侧用户ID为键的映射，未登记的用户不包含在结果中
+func (s *serviceUserService) GetServiceUserMap(ctx context.Context, applicationID uuid.UUID, serviceUserIDs []string) (map[string]*models.ServiceUser, error) {
+	serviceUsers, err := s.serviceUserRepo.ListByServiceUserIDs(ctx, applicationID, serviceUserIDs)
+	if err != nil {
+		return nil, fmt.Errorf("查询业务侧用户失败: %w", err)
+	}
+	result := make(map[string]*models.ServiceUser, len(serviceUsers))
+	for _, serviceUser := range serviceUsers {
+		result[serviceUser.ServiceUserID] = serviceUser
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// textEmbedder 文本嵌入生成器
+// 使用应用下配置的嵌入模型生成文本嵌入向量，供预制答案规则和知识库共用
+type textEmbedder struct {
+	llmRepo         repository.ApplicationLlmRepository
+	llmProviderRepo repository.LlmProviderRepository
+}
+
+// newTextEmbedder 创建文本嵌入生成器
+func newTextEmbedder(llmRepo repository.ApplicationLlmRepository, llmProviderRepo repository.LlmProviderRepository) *textEmbedder {
+	return &textEmbedder{
+		llmRepo:         llmRepo,
+		llmProviderRepo: llmProviderRepo,
+	}
+}
+
+// ValidateModel 校验嵌入模型属于指定应用且具备文本嵌入能力
+func (e *textEmbedder) ValidateModel(ctx context.Context, applicationID, embeddingModelID uuid.UUID) (*models.ApplicationLlm, error) {
+	embeddingLlm, err := e.llmRepo.GetByID(ctx, embeddingModelID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "嵌入模型不存在", err)
+	}
+	if embeddingLlm.ApplicationID != applicationID {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "嵌入模型不属于当前应用")
+	}
+	if !embeddingLlm.AbilityTextEmbeddings {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "所选模型不支持文本嵌入")
+	}
+	return embeddingLlm, nil
+}
+
+// CreateEmbeddings 生成文本的嵌入向量，返回结果与 texts 一一对应
+func (e *textEmbedder) CreateEmbeddings(ctx context.Context, applicationID, embeddingModelID uuid.UUID, texts []string) ([][]float32, error) {
+	embeddingLlm, err := e.ValidateModel(ctx, applicationID, embeddingModelID)
+	if err != nil {
+		return nil, err
+	}
+	llmProvider, err := e.llmProviderRepo.GetByID(ctx, embeddingLlm.LlmProviderID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "嵌入模型的提供商不存在", err)
+	}
+	client, err := newEmbeddingClient(llmProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.CreateEmbeddings(ctx, al_client.CreateEmbeddingsRequest{
+		Model: embeddingLlm.Name,
+		Input: texts,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeServiceUnavailable, "生成嵌入向量失败", err)
+	}
+	if len(response.Embeddings) != len(texts) {
+		return nil, apperror.New(apperror.CodeServiceUnavailable, "嵌入模型返回的向量数量与文本数量不一致")
+	}
+	return response.Embeddings, nil
+}
+
+// CreateEmbedding 生成单条文本的嵌入向量
+func (e *textEmbedder) CreateEmbedding(ctx context.Context, applicationID, embeddingModelID uuid.UUID, text string) ([]float32, error) {
+	embeddings, err := e.CreateEmbeddings(ctx, applicationID, embeddingModelID, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// newEmbeddingClient 根据LLM提供商配置创建文本嵌入客户端
+func newEmbeddingClient(llmProvider *models.ApplicationLlmProvider) (al_client.LemonAiEmbeddingClient, error) {
+	switch llmProvider.Type {
+	case "openai_chat_completions_api":
+		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	case "mock":
+		return al_client.NewMockClient(), nil
+	case "ollama", "volcano_engine":
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "提供商类型 %s 暂不支持文本嵌入", llmProvider.Type)
+	default:
+		// 默认使用OpenAI
+		return al_client.NewOpenAIChatCompletionsClient(llmProvider.ApiKey), nil
+	}
+}
@@ -0,0 +1,86 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"lemon-tree-core/internal/al_client"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// textReranker 文本重排器
+// 使用应用下配置的重排模型按与问题的相关性对候选文本重新排序
+type textReranker struct {
+	llmRepo         repository.ApplicationLlmRepository
+	llmProviderRepo repository.LlmProviderRepository
+}
+
+// newTextReranker 创建文本重排器
+func newTextReranker(llmRepo repository.ApplicationLlmRepository, llmProviderRepo repository.LlmProviderRepository) *textReranker {
+	return &textReranker{
+		llmRepo:         llmRepo,
+		llmProviderRepo: llmProviderRepo,
+	}
+}
+
+// Rerank 对候选文本重新排序
+// 返回按相关性倒序排列的前 topN 个结果，结果的 Index 为文本在 documents 中的下标
+func (r *textReranker) Rerank(ctx context.Context, applicationID, rerankModelID uuid.UUID, query string, documents []string, topN int) ([]al_client.RerankResult, error) {
+	rerankLlm, err := r.llmRepo.GetByID(ctx, rerankModelID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "重排模型不存在", err)
+	}
+	if rerankLlm.ApplicationID != applicationID {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "重排模型不属于当前应用")
+	}
+	if !rerankLlm.AbilityReranking {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "所选模型不支持重排")
+	}
+	llmProvider, err := r.llmProviderRepo.GetByID(ctx, rerankLlm.LlmProviderID)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidArgument, "重排模型的提供商不存在", err)
+	}
+	client, err := newRerankClient(llmProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Rerank(ctx, al_client.RerankRequest{
+		Model:     rerankLlm.Name,
+		Query:     query,
+		Documents: documents,
+		TopN:      topN,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeServiceUnavailable, "重排失败", err)
+	}
+
+	// 部分提供商不保证返回顺序，按相关性重新排序
+	results := response.Results
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RelevanceScore > results[j].RelevanceScore
+	})
+	if topN > 0 && len(results) > topN {
+		results = results[:topN]
+	}
+	return results, nil
+}
+
+// newRerankClient 根据LLM提供商配置创建重排客户端
+func newRerankClient(llmProvider *models.ApplicationLlmProvider) (al_client.LemonAiRerankClient, error) {
+	switch llmProvider.Type {
+	case "mock":
+		return al_client.NewMockClient(), nil
+	case "ollama", "volcano_engine":
+		return nil, apperror.Newf(apperror.CodeInvalidArgument, "提供商类型 %s 暂不支持重排", llmProvider.Type)
+	}
+	if llmProvider.ApiUrl == "" {
+		return nil, apperror.New(apperror.CodeInvalidArgument, "重排模型的提供商未配置API URL")
+	}
+	return al_client.NewHttpRerankClient(llmProvider.ApiUrl, llmProvider.ApiKey), nil
+}
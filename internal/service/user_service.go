@@ -4,13 +4,20 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"lemon-tree-core/internal/apperror"
+	"lemon-tree-core/internal/config"
 	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/mailer"
 	"lemon-tree-core/internal/models"
 	"lemon-tree-core/internal/repository"
+	"net/url"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -19,34 +26,82 @@ import (
 // 定义了 User 相关的所有业务操作接口
 // 包含用户认证、会话管理和用户信息管理
 type UserService interface {
-	Login(ctx context.Context, number, password string) (*models.SystemUser, string, error) // 用户登录
-	SaveUser(ctx context.Context, user *models.SystemUser) error                            // 保存用户（创建或更新）
-	DeleteUser(ctx context.Context, id uuid.UUID) error                                     // 删除用户
-	GetAllUsers(ctx context.Context) ([]*models.SystemUser, error)                          // 获取所有用户
-	GetUserByID(ctx context.Context, id uuid.UUID) (*models.SystemUser, error)              // 根据ID获取用户详情
-	GetUserByToken(ctx context.Context, token string) (*models.SystemUser, error)           // 根据Token获取当前登录用户
-	GetCurrentUser(ctx context.Context) (*models.SystemUser, error)                         // 获取当前登录用户
-	Logout(ctx context.Context, token string) error                                         // 用户登出
+	Login(ctx context.Context, number, password, ipAddress, userAgent string) (*models.SystemUser, *SessionTokens, error) // 用户登录，记录登录IP和User-Agent
+	RefreshSession(ctx context.Context, refreshToken string) (*models.SystemUser, *SessionTokens, error)                  // 使用刷新令牌换取新的访问令牌和刷新令牌
+	CreateSession(ctx context.Context, user *models.SystemUser, ipAddress, userAgent string) (*SessionTokens, error)      // 为已通过认证的用户创建登录会话
+	SaveUser(ctx context.Context, user *models.SystemUser) error                                                          // 保存用户（创建或更新）
+	DeleteUser(ctx context.Context, id uuid.UUID) error                                                                   // 删除用户
+	GetAllUsers(ctx context.Context) ([]*models.SystemUser, error)                                                        // 获取所有用户
+	GetUserByID(ctx context.Context, id uuid.UUID) (*models.SystemUser, error)                                            // 根据ID获取用户详情
+	GetUserByToken(ctx context.Context, token string) (*models.SystemUser, error)                                         // 根据Token获取当前登录用户
+	GetCurrentUser(ctx context.Context) (*models.SystemUser, error)                                                       // 获取当前登录用户
+	Logout(ctx context.Context, token string) error                                                                       // 用户登出
+	CleanupExpiredSessions(ctx context.Context) error                                                                     // 清理过期的登录会话
+	ListSessions(ctx context.Context, token string) ([]*models.SystemUserSession, *models.SystemUserSession, error)       // 获取当前用户未过期的登录会话和当前使用的会话
+	RevokeSession(ctx context.Context, token string, sessionID uuid.UUID) error                                           // 注销当前用户的指定登录会话
+	ChangePassword(ctx context.Context, token, currentPassword, newPassword string) error                                 // 修改当前用户的密码，注销其他登录会话
+	InviteUser(ctx context.Context, user *models.SystemUser) error                                                        // 创建未设置密码的用户并发送邀请邮件
+	AcceptInvite(ctx context.Context, token, password string) error                                                       // 通过邀请链接设置初始密码
+	RequestPasswordReset(ctx context.Context, email string) error                                                         // 发送找回密码邮件
+	ResetPassword(ctx context.Context, token, newPassword string) error                                                   // 通过找回密码链接设置新密码
+	RevokeOtherSessions(ctx context.Context, token string) (int64, error)                                                 // 注销当前用户除当前会话外的全部登录会话
 }
 
 // userService User 业务逻辑层实现
 // 实现了 UserService 接口的所有方法
 // 包含用户认证、会话管理和用户信息管理
 type userService struct {
-	userRepo    repository.SystemUserRepository        // 用户数据访问层接口
-	sessionRepo repository.SystemUserSessionRepository // 会话数据访问层接口
+	userRepo        repository.SystemUserRepository            // 用户数据访问层接口
+	sessionRepo     repository.SystemUserSessionRepository     // 会话数据访问层接口
+	identityRepo    repository.SystemUserIdentityRepository    // 外部身份数据访问层接口
+	actionTokenRepo repository.SystemUserActionTokenRepository // 一次性令牌数据访问层接口
+	mailer          mailer.Mailer                              // 邮件发送器
+	config          *config.Config                             // 应用程序配置
 }
 
 // NewUserService 创建 User Service 实例
 // 返回 UserService 接口的实现
-// 参数：userRepo - 用户数据访问层接口，sessionRepo - 会话数据访问层接口
-func NewUserService(userRepo repository.SystemUserRepository, sessionRepo repository.SystemUserSessionRepository) UserService {
+// 参数：userRepo - 用户数据访问层接口，sessionRepo - 会话数据访问层接口，identityRepo - 外部身份数据访问层接口，
+// actionTokenRepo - 一次性令牌数据访问层接口，mailer - 邮件发送器，config - 应用程序配置
+func NewUserService(
+	userRepo repository.SystemUserRepository,
+	sessionRepo repository.SystemUserSessionRepository,
+	identityRepo repository.SystemUserIdentityRepository,
+	actionTokenRepo repository.SystemUserActionTokenRepository,
+	mailer mailer.Mailer,
+	config *config.Config,
+) UserService {
 	return &userService{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		identityRepo:    identityRepo,
+		actionTokenRepo: actionTokenRepo,
+		mailer:          mailer,
+		config:          config,
 	}
 }
 
+// SessionTokens 登录或刷新得到的令牌
+// 访问令牌用于请求认证，过期后使用刷新令牌换取新的令牌
+type SessionTokens struct {
+	AccessToken           string    // 访问令牌
+	AccessTokenExpiresAt  time.Time // 访问令牌过期时间
+	RefreshToken          string    // 刷新令牌，只在登录和刷新时返回一次
+	RefreshTokenExpiresAt time.Time // 刷新令牌过期时间
+}
+
+// 登录会话记录的客户端信息长度上限，与数据库字段长度一致
+const (
+	maxSessionIPAddressLength = 64
+	maxSessionUserAgentLength = 512
+)
+
+// 令牌有效期未配置时的默认值
+const (
+	defaultAccessTokenTTL  = time.Hour
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
 // hashPassword 使用SHA256加密密码
 // 格式：SHA256(密码 + '_' + 盐)
 // 参数：password - 原始密码，salt - 密码盐
@@ -58,44 +113,103 @@ func hashPassword(password, salt string) string {
 }
 
 // Login 用户登录
-// 验证用户账号密码，创建会话并返回Token
-// 参数：ctx - 上下文，number - 用户账号，password - 用户密码
-// 返回：用户对象、Token和错误信息
-func (s *userService) Login(ctx context.Context, number, password string) (*models.SystemUser, string, error) {
+// 验证用户账号密码，创建会话并返回访问令牌和刷新令牌
+// 参数：ctx - 上下文，number - 用户账号，password - 用户密码，ipAddress - 登录IP，userAgent - 登录时的User-Agent
+// 返回：用户对象、令牌和错误信息
+func (s *userService) Login(ctx context.Context, number, password, ipAddress, userAgent string) (*models.SystemUser, *SessionTokens, error) {
 	// 根据账号获取用户
 	user, err := s.userRepo.GetByNumber(ctx, number)
 	if err != nil {
-		return nil, "", fmt.Errorf("用户不存在或账号错误")
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "用户不存在或账号错误")
+	}
+
+	// 单点登录自动创建的用户没有密码，不能使用密码登录
+	if user.Password == "" {
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "该用户未设置密码，请使用单点登录")
 	}
 
 	// 验证密码：使用SHA256(密码 + '_' + 盐)进行验证
 	hashedPassword := hashPassword(password, user.PasswordSalt)
 	if user.Password != hashedPassword {
-		return nil, "", fmt.Errorf("密码错误")
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "密码错误")
 	}
 
-	// 生成Token：sha256(随机UUID_用户ID_13位毫秒unix时间戳)
-	randomUUID := uuid.New().String()
-	userID := user.ID.String()
-	timestamp := time.Now().UnixMilli()
-	tokenInput := fmt.Sprintf("%s_%s_%d", randomUUID, userID, timestamp)
+	// 创建会话
+	tokens, err := s.CreateSession(ctx, user, ipAddress, userAgent)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	hash := sha256.Sum256([]byte(tokenInput))
-	token := hex.EncodeToString(hash[:])
+	return user, tokens, nil
+}
 
-	// 创建会话
+// CreateSession 为已通过认证的用户创建登录会话
+// 账号密码登录和单点登录共用
+// 参数：ctx - 上下文，user - 已通过认证的用户，ipAddress - 登录IP，userAgent - 登录时的User-Agent
+// 返回：令牌和错误信息
+func (s *userService) CreateSession(ctx context.Context, user *models.SystemUser, ipAddress, userAgent string) (*SessionTokens, error) {
 	session := &models.SystemUserSession{
-		Token:          token,
-		UserID:         user.ID,
-		LoginExpiredAt: time.Now().Add(24 * time.Hour), // 24小时过期
+		UserID:    user.ID,
+		IPAddress: truncateRunes(ipAddress, maxSessionIPAddressLength),
+		UserAgent: truncateRunes(userAgent, maxSessionUserAgentLength),
+	}
+	tokens, err := s.issueSessionTokens(session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("创建会话失败: %w", err)
+	}
+	return tokens, nil
+}
+
+// RefreshSession 使用刷新令牌换取新的访问令牌和刷新令牌
+// 刷新令牌每次使用后轮换，旧刷新令牌再次使用说明可能已泄露，此时注销整个会话
+// 参数：ctx - 上下文，refreshToken - 刷新令牌
+// 返回：用户对象、新的令牌和错误信息
+func (s *userService) RefreshSession(ctx context.Context, refreshToken string) (*models.SystemUser, *SessionTokens, error) {
+	refreshTokenHash := hashToken(refreshToken)
+	session, err := s.sessionRepo.GetByRefreshTokenHash(ctx, refreshTokenHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取会话失败: %w", err)
+	}
+	if session == nil {
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "无效的刷新令牌")
 	}
 
-	err = s.sessionRepo.Save(ctx, session)
+	// 已轮换的旧刷新令牌被再次使用
+	if session.RefreshTokenHash != refreshTokenHash {
+		if err := s.sessionRepo.DeleteByID(ctx, session.ID); err != nil {
+			return nil, nil, fmt.Errorf("注销登录会话失败: %w", err)
+		}
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "刷新令牌已失效，请重新登录")
+	}
+	if session.RefreshExpiredAt == nil || time.Now().After(*session.RefreshExpiredAt) {
+		s.sessionRepo.DeleteByID(ctx, session.ID)
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "会话已过期，请重新登录")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, session.UserID)
 	if err != nil {
-		return nil, "", fmt.Errorf("创建会话失败: %w", err)
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "用户不存在")
+	}
+
+	session.PreviousRefreshTokenHash = refreshTokenHash
+	tokens, err := s.issueSessionTokens(session)
+	if err != nil {
+		return nil, nil, err
+	}
+	rotated, err := s.sessionRepo.RotateTokens(ctx, session, refreshTokenHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("刷新会话失败: %w", err)
+	}
+	// 同一刷新令牌的并发请求中只有一个成功，其余请求不注销会话
+	if !rotated {
+		return nil, nil, apperror.New(apperror.CodeUnauthorized, "刷新令牌已被使用")
 	}
 
-	return user, token, nil
+	return user, tokens, nil
 }
 
 // SaveUser 保存用户（创建或更新）
@@ -108,20 +222,20 @@ func (s *userService) SaveUser(ctx context.Context, user *models.SystemUser) err
 		// 更新现有用户
 		existingUser, err := s.userRepo.GetByID(ctx, user.ID)
 		if err != nil {
-			return fmt.Errorf("用户不存在: %w", err)
+			return apperror.Wrap(apperror.CodeNotFound, "用户不存在", err)
 		}
 
 		// 验证Number唯一性（如果Number发生变化）
 		if existingUser.Number != user.Number {
 			if _, err := s.userRepo.GetByNumber(ctx, user.Number); err == nil {
-				return fmt.Errorf("用户账号已存在")
+				return apperror.New(apperror.CodeConflict, "用户账号已存在")
 			}
 		}
 
 		// 验证Email唯一性（如果Email发生变化）
 		if existingUser.Email != user.Email {
 			if _, err := s.userRepo.GetByEmail(ctx, user.Email); err == nil {
-				return fmt.Errorf("用户邮箱已存在")
+				return apperror.New(apperror.CodeConflict, "用户邮箱已存在")
 			}
 		}
 
@@ -131,23 +245,36 @@ func (s *userService) SaveUser(ctx context.Context, user *models.SystemUser) err
 		existingUser.Email = user.Email
 
 		// 处理密码（如果提供了新密码，需要加密）
+		passwordChanged := false
 		if user.Password != "" {
-			existingUser.Password = hashPassword(user.Password, existingUser.PasswordSalt)
+			hashedPassword := hashPassword(user.Password, existingUser.PasswordSalt)
+			passwordChanged = hashedPassword != existingUser.Password
+			existingUser.Password = hashedPassword
 		}
 
 		// 保存修改后的existingUser
-		return s.userRepo.Save(ctx, existingUser)
+		if err := s.userRepo.Save(ctx, existingUser); err != nil {
+			return err
+		}
+
+		// 修改密码后使该用户已有的登录会话全部失效，需要使用新密码重新登录
+		if passwordChanged {
+			if err := s.sessionRepo.DeleteByUserID(ctx, existingUser.ID); err != nil {
+				return fmt.Errorf("删除用户会话失败: %w", err)
+			}
+		}
+		return nil
 	} else {
 		// 创建新用户
 
 		// 验证Number唯一性
 		if _, err := s.userRepo.GetByNumber(ctx, user.Number); err == nil {
-			return fmt.Errorf("用户账号已存在")
+			return apperror.New(apperror.CodeConflict, "用户账号已存在")
 		}
 
 		// 验证Email唯一性
 		if _, err := s.userRepo.GetByEmail(ctx, user.Email); err == nil {
-			return fmt.Errorf("用户邮箱已存在")
+			return apperror.New(apperror.CodeConflict, "用户邮箱已存在")
 		}
 
 		user.ID = uuid.New()
@@ -200,14 +327,17 @@ func (s *userService) GetUserByToken(ctx context.Context, token string) (*models
 	// 根据Token获取会话
 	session, err := s.sessionRepo.GetByToken(ctx, token)
 	if err != nil {
-		return nil, fmt.Errorf("无效的Token")
+		return nil, apperror.New(apperror.CodeUnauthorized, "无效的Token")
 	}
 
-	// 检查会话是否过期
+	// 检查访问令牌是否过期，会话仍可刷新时保留会话
 	if time.Now().After(session.LoginExpiredAt) {
-		// 删除过期会话
-		s.sessionRepo.DeleteByID(ctx, session.ID)
-		return nil, fmt.Errorf("会话已过期")
+		if session.RefreshExpiredAt == nil || time.Now().After(*session.RefreshExpiredAt) {
+			// 删除过期会话
+			s.sessionRepo.DeleteByID(ctx, session.ID)
+			return nil, apperror.New(apperror.CodeUnauthorized, "会话已过期")
+		}
+		return nil, apperror.New(apperror.CodeUnauthorized, "访问令牌已过期，请刷新令牌")
 	}
 
 	// 获取用户信息
@@ -227,7 +357,7 @@ func (s *userService) Logout(ctx context.Context, token string) error {
 	// 根据Token获取会话
 	session, err := s.sessionRepo.GetByToken(ctx, token)
 	if err != nil {
-		return fmt.Errorf("无效的Token")
+		return apperror.New(apperror.CodeUnauthorized, "无效的Token")
 	}
 
 	// 删除会话
@@ -242,13 +372,13 @@ func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	// 1. 检查用户是否存在
 	_, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("用户不存在: %w", err)
+		return apperror.Wrap(apperror.CodeNotFound, "用户不存在", err)
 	}
 
 	// 2. 检查是否为当前登录用户（不能删除自己）
 	currentUser, err := s.GetCurrentUser(ctx)
 	if err == nil && currentUser != nil && currentUser.ID == id {
-		return fmt.Errorf("不能删除当前登录的用户")
+		return apperror.New(apperror.CodeInvalidArgument, "不能删除当前登录的用户")
 	}
 
 	// 3. 检查是否为系统最后一个用户
@@ -266,7 +396,7 @@ func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if len(activeUsers) <= 1 {
-		return fmt.Errorf("系统至少需要保留一个用户")
+		return apperror.New(apperror.CodeConflict, "系统至少需要保留一个用户")
 	}
 
 	// 4. 删除用户的所有会话记录
@@ -275,7 +405,15 @@ func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("删除用户会话失败: %w", err)
 	}
 
-	// 5. 删除用户
+	// 5. 删除用户关联的外部身份和未使用的一次性令牌，同一外部身份再次登录时重新关联或创建用户
+	if err := s.identityRepo.DeleteByUserID(ctx, id); err != nil {
+		return fmt.Errorf("删除用户外部身份失败: %w", err)
+	}
+	if err := s.actionTokenRepo.DeleteByUserID(ctx, id, ""); err != nil {
+		return fmt.Errorf("删除用户一次性令牌失败: %w", err)
+	}
+
+	// 6. 删除用户
 	err = s.userRepo.DeleteByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("删除用户失败: %w", err)
@@ -283,3 +421,367 @@ func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// CleanupExpiredSessions 清理过期的登录会话
+// 由后台定时任务调用，物理删除已过期的会话记录，以及邀请、找回密码邮件中已过期的一次性令牌
+// 参数：ctx - 上下文
+// 返回：错误信息
+func (s *userService) CleanupExpiredSessions(ctx context.Context) error {
+	if err := s.sessionRepo.DeleteExpiredSessions(ctx); err != nil {
+		return fmt.Errorf("清理过期会话失败: %w", err)
+	}
+	if err := s.actionTokenRepo.DeleteExpired(ctx); err != nil {
+		return fmt.Errorf("清理过期一次性令牌失败: %w", err)
+	}
+	return nil
+}
+
+// ListSessions 获取当前用户未过期的登录会话
+// 参数：ctx - 上下文，token - 当前请求使用的Token
+// 返回：会话列表（按登录时间倒序）、当前使用的会话和错误信息
+func (s *userService) ListSessions(ctx context.Context, token string) ([]*models.SystemUserSession, *models.SystemUserSession, error) {
+	current, err := s.getSessionByToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessions, err := s.sessionRepo.GetActiveByUserID(ctx, current.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取登录会话失败: %w", err)
+	}
+	return sessions, current, nil
+}
+
+// RevokeSession 注销当前用户的指定登录会话
+// 只能注销自己的会话，注销当前会话等同于登出
+// 参数：ctx - 上下文，token - 当前请求使用的Token，sessionID - 要注销的会话ID
+// 返回：错误信息
+func (s *userService) RevokeSession(ctx context.Context, token string, sessionID uuid.UUID) error {
+	current, err := s.getSessionByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != current.UserID {
+		return apperror.New(apperror.CodeNotFound, "登录会话不存在")
+	}
+	if err := s.sessionRepo.DeleteByID(ctx, session.ID); err != nil {
+		return fmt.Errorf("注销登录会话失败: %w", err)
+	}
+	return nil
+}
+
+// RevokeOtherSessions 注销当前用户除当前会话外的全部登录会话
+// 参数：ctx - 上下文，token - 当前请求使用的Token
+// 返回：注销的会话数量和错误信息
+func (s *userService) RevokeOtherSessions(ctx context.Context, token string) (int64, error) {
+	current, err := s.getSessionByToken(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	revoked, err := s.sessionRepo.DeleteByUserIDExcept(ctx, current.UserID, current.ID)
+	if err != nil {
+		return 0, fmt.Errorf("注销登录会话失败: %w", err)
+	}
+	return revoked, nil
+}
+
+// ChangePassword 修改当前用户的密码
+// 需要验证当前密码，新密码需满足密码强度配置；修改后注销当前会话以外的全部登录会话
+// 参数：ctx - 上下文，token - 当前请求使用的Token，currentPassword - 当前密码，newPassword - 新密码
+// 返回：错误信息
+func (s *userService) ChangePassword(ctx context.Context, token, currentPassword, newPassword string) error {
+	current, err := s.getSessionByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetByID(ctx, current.UserID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "用户不存在", err)
+	}
+
+	if user.Password == "" {
+		return apperror.New(apperror.CodeInvalidArgument, "当前用户未设置密码，请联系管理员设置")
+	}
+	if hashPassword(currentPassword, user.PasswordSalt) != user.Password {
+		return apperror.New(apperror.CodeInvalidArgument, "当前密码错误")
+	}
+	if newPassword == currentPassword {
+		return apperror.New(apperror.CodeInvalidArgument, "新密码不能与当前密码相同")
+	}
+	if err := s.validatePasswordStrength(newPassword); err != nil {
+		return err
+	}
+
+	// 修改密码时更换密码盐
+	user.PasswordSalt = uuid.New().String()
+	user.Password = hashPassword(newPassword, user.PasswordSalt)
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return fmt.Errorf("保存密码失败: %w", err)
+	}
+
+	if _, err := s.sessionRepo.DeleteByUserIDExcept(ctx, user.ID, current.ID); err != nil {
+		return fmt.Errorf("注销登录会话失败: %w", err)
+	}
+	return nil
+}
+
+// InviteUser 创建未设置密码的用户并发送邀请邮件
+// 用户通过邮件中的链接设置初始密码，邮件发送失败时撤销创建的用户
+// 参数：ctx - 上下文，user - 要邀请的用户（名字、账号、邮箱）
+// 返回：错误信息
+func (s *userService) InviteUser(ctx context.Context, user *models.SystemUser) error {
+	if !s.mailer.Enabled() || s.config.Mail.InviteURL == "" {
+		return apperror.New(apperror.CodeServiceUnavailable, "未配置邮件服务或邀请链接地址，无法发送邀请")
+	}
+
+	user.ID = uuid.Nil
+	user.Password = ""
+	if err := s.SaveUser(ctx, user); err != nil {
+		return err
+	}
+
+	inviterName := ""
+	if inviter, err := s.GetCurrentUser(ctx); err == nil && inviter != nil {
+		inviterName = inviter.Name
+	}
+	ttl := time.Duration(s.config.Mail.InviteTTLHours) * time.Hour
+	token, expiresAt, err := s.issueActionToken(ctx, user.ID, models.SystemUserActionTokenPurposeInvite, ttl)
+	if err == nil {
+		err = s.mailer.SendTemplate(ctx, user.Email, mailer.TemplateInvite, &mailer.InviteData{
+			Name:        user.Name,
+			Number:      user.Number,
+			InviterName: inviterName,
+			Link:        actionTokenLink(s.config.Mail.InviteURL, token),
+			ExpiresAt:   expiresAt,
+		})
+	}
+	if err != nil {
+		s.actionTokenRepo.DeleteByUserID(ctx, user.ID, "")
+		s.userRepo.DeleteByID(ctx, user.ID)
+		return apperror.Wrap(apperror.CodeServiceUnavailable, "发送邀请邮件失败", err)
+	}
+	return nil
+}
+
+// AcceptInvite 通过邀请链接设置初始密码
+// 参数：ctx - 上下文，token - 邀请链接中的令牌，password - 初始密码
+// 返回：错误信息
+func (s *userService) AcceptInvite(ctx context.Context, token, password string) error {
+	return s.setPasswordByActionToken(ctx, models.SystemUserActionTokenPurposeInvite, token, password)
+}
+
+// RequestPasswordReset 发送找回密码邮件
+// 邮箱不存在时同样返回成功，避免通过该接口探测已注册的邮箱；同一用户只保留最新的找回密码链接
+// 参数：ctx - 上下文，email - 用户邮箱
+// 返回：错误信息
+func (s *userService) RequestPasswordReset(ctx context.Context, email string) error {
+	if !s.mailer.Enabled() || s.config.Mail.ResetPasswordURL == "" {
+		return apperror.New(apperror.CodeServiceUnavailable, "未配置邮件服务或重置密码链接地址，无法找回密码")
+	}
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+
+	if err := s.actionTokenRepo.DeleteByUserID(ctx, user.ID, models.SystemUserActionTokenPurposeResetPassword); err != nil {
+		return fmt.Errorf("删除旧的找回密码令牌失败: %w", err)
+	}
+	ttl := time.Duration(s.config.Mail.ResetPasswordTTLMinutes) * time.Minute
+	token, expiresAt, err := s.issueActionToken(ctx, user.ID, models.SystemUserActionTokenPurposeResetPassword, ttl)
+	if err != nil {
+		return err
+	}
+	if err := s.mailer.SendTemplate(ctx, user.Email, mailer.TemplateResetPassword, &mailer.ResetPasswordData{
+		Name:      user.Name,
+		Number:    user.Number,
+		Link:      actionTokenLink(s.config.Mail.ResetPasswordURL, token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return apperror.Wrap(apperror.CodeServiceUnavailable, "发送找回密码邮件失败", err)
+	}
+	return nil
+}
+
+// ResetPassword 通过找回密码链接设置新密码
+// 参数：ctx - 上下文，token - 找回密码链接中的令牌，newPassword - 新密码
+// 返回：错误信息
+func (s *userService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	return s.setPasswordByActionToken(ctx, models.SystemUserActionTokenPurposeResetPassword, token, newPassword)
+}
+
+// setPasswordByActionToken 使用一次性令牌设置密码
+// 令牌使用一次后失效，设置后注销该用户的全部登录会话
+func (s *userService) setPasswordByActionToken(ctx context.Context, purpose, token, password string) error {
+	// 先校验密码强度，不满足时令牌仍可继续使用
+	if err := s.validatePasswordStrength(password); err != nil {
+		return err
+	}
+	actionToken, err := s.actionTokenRepo.TakeByTokenHash(ctx, purpose, hashToken(token))
+	if err != nil {
+		return fmt.Errorf("查询一次性令牌失败: %w", err)
+	}
+	if actionToken == nil || time.Now().After(actionToken.ExpiredAt) {
+		return apperror.New(apperror.CodeInvalidArgument, "链接无效或已过期")
+	}
+	user, err := s.userRepo.GetByID(ctx, actionToken.UserID)
+	if err != nil {
+		return apperror.Wrap(apperror.CodeNotFound, "用户不存在", err)
+	}
+
+	user.PasswordSalt = uuid.New().String()
+	user.Password = hashPassword(password, user.PasswordSalt)
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return fmt.Errorf("保存密码失败: %w", err)
+	}
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("删除用户会话失败: %w", err)
+	}
+	return nil
+}
+
+// issueActionToken 为用户生成一次性令牌，数据库中只保存摘要
+// 返回：令牌明文、过期时间和错误信息
+func (s *userService) issueActionToken(ctx context.Context, userID uuid.UUID, purpose string, ttl time.Duration) (string, time.Time, error) {
+	token, err := generateRandomToken()
+	if err != nil {
+		return "", time.Time{}, apperror.Wrap(apperror.CodeInternal, "生成一次性令牌失败", err)
+	}
+	expiresAt := time.Now().Add(ttl)
+	if err := s.actionTokenRepo.Create(ctx, &models.SystemUserActionToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiredAt: expiresAt,
+	}); err != nil {
+		return "", time.Time{}, fmt.Errorf("保存一次性令牌失败: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// actionTokenLink 生成邮件中附带一次性令牌的链接
+func actionTokenLink(pageURL, token string) string {
+	target, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+	query := target.Query()
+	query.Set("token", token)
+	target.RawQuery = query.Encode()
+	return target.String()
+}
+
+// validatePasswordStrength 校验新密码是否满足密码强度配置
+func (s *userService) validatePasswordStrength(password string) error {
+	if s.config == nil {
+		return nil
+	}
+	policy := s.config.Password
+	if policy.MinLength > 0 && utf8.RuneCountInString(password) < policy.MinLength {
+		return apperror.Newf(apperror.CodeInvalidArgument, "密码长度不能少于%d个字符", policy.MinLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if policy.RequireUppercase && !hasUpper {
+		return apperror.New(apperror.CodeInvalidArgument, "密码必须包含大写字母")
+	}
+	if policy.RequireLowercase && !hasLower {
+		return apperror.New(apperror.CodeInvalidArgument, "密码必须包含小写字母")
+	}
+	if policy.RequireDigit && !hasDigit {
+		return apperror.New(apperror.CodeInvalidArgument, "密码必须包含数字")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		return apperror.New(apperror.CodeInvalidArgument, "密码必须包含符号")
+	}
+	return nil
+}
+
+// truncateRunes 截断超过长度上限的字符串，按字符而非字节计算长度
+func truncateRunes(value string, maxLength int) string {
+	runes := []rune(value)
+	if len(runes) <= maxLength {
+		return value
+	}
+	return string(runes[:maxLength])
+}
+
+// getSessionByToken 根据Token获取登录会话
+func (s *userService) getSessionByToken(ctx context.Context, token string) (*models.SystemUserSession, error) {
+	session, err := s.sessionRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, apperror.New(apperror.CodeUnauthorized, "无效的Token")
+	}
+	return session, nil
+}
+
+// issueSessionTokens 为会话生成新的访问令牌和刷新令牌
+// 会话中只保存刷新令牌的摘要，明文只返回给客户端
+func (s *userService) issueSessionTokens(session *models.SystemUserSession) (*SessionTokens, error) {
+	refreshToken, err := generateRandomToken()
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeInternal, "生成刷新令牌失败", err)
+	}
+
+	accessTTL, refreshTTL := defaultAccessTokenTTL, defaultRefreshTokenTTL
+	if s.config != nil && s.config.Session.AccessTokenTTLMinutes > 0 {
+		accessTTL = time.Duration(s.config.Session.AccessTokenTTLMinutes) * time.Minute
+	}
+	if s.config != nil && s.config.Session.RefreshTokenTTLHours > 0 {
+		refreshTTL = time.Duration(s.config.Session.RefreshTokenTTLHours) * time.Hour
+	}
+	// 刷新令牌不应早于访问令牌过期
+	if refreshTTL < accessTTL {
+		refreshTTL = accessTTL
+	}
+
+	now := time.Now()
+	tokens := &SessionTokens{
+		AccessToken:           generateAccessToken(session.UserID),
+		AccessTokenExpiresAt:  now.Add(accessTTL),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: now.Add(refreshTTL),
+	}
+	session.Token = tokens.AccessToken
+	session.LoginExpiredAt = tokens.AccessTokenExpiresAt
+	session.RefreshTokenHash = hashToken(tokens.RefreshToken)
+	session.RefreshExpiredAt = &tokens.RefreshTokenExpiresAt
+	return tokens, nil
+}
+
+// generateAccessToken 生成访问令牌
+// 算法：sha256(随机UUID_用户ID_13位毫秒unix时间戳)
+func generateAccessToken(userID uuid.UUID) string {
+	tokenInput := fmt.Sprintf("%s_%s_%d", uuid.New().String(), userID.String(), time.Now().UnixMilli())
+	hash := sha256.Sum256([]byte(tokenInput))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateRandomToken 生成32字节随机数的令牌，用于刷新令牌和邮件链接中的一次性令牌
+func generateRandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashToken 计算随机令牌的 sha256 摘要，数据库中只保存摘要
+func hashToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,104 @@
+// Package service 提供业务逻辑层功能
+// 负责处理业务逻辑、数据验证、调用数据访问层和返回业务结果
+package service
+
+import (
+	"context"
+	"fmt"
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"lemon-tree-core/internal/repository"
+	"lemon-tree-core/internal/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// orphanedUploadBatchSize 每次清理处理的上传记录数量
+const orphanedUploadBatchSize = 200
+
+// WorkspaceUploadService 工作区上传文件 业务逻辑层接口
+// 跟踪保存实体前上传的文件，并清理未被任何记录引用的文件
+type WorkspaceUploadService interface {
+	// TrackUpload 记录待确认的上传文件
+	// 上传后、实体保存前，或实体不再引用某个文件时调用
+	TrackUpload(ctx context.Context, filePath string) error
+
+	// CleanupOrphanedUploads 清理超过指定时间仍未被引用的上传文件
+	// 返回删除的文件数量
+	CleanupOrphanedUploads(ctx context.Context, olderThan time.Duration) (int, error)
+}
+
+// workspaceUploadService 工作区上传文件 业务逻辑层实现
+type workspaceUploadService struct {
+	workspaceUploadRepo repository.WorkspaceUploadRepository // 上传记录数据访问层接口
+}
+
+// NewWorkspaceUploadService 创建 工作区上传文件 服务实例
+// 参数：workspaceUploadRepo - 上传记录数据访问层接口
+func NewWorkspaceUploadService(workspaceUploadRepo repository.WorkspaceUploadRepository) WorkspaceUploadService {
+	return &workspaceUploadService{
+		workspaceUploadRepo: workspaceUploadRepo,
+	}
+}
+
+// TrackUpload 记录待确认的上传文件
+// 只记录工作区内的相对路径，外部URL和 base64 内容忽略
+func (s *workspaceUploadService) TrackUpload(ctx context.Context, filePath string) error {
+	if !isWorkspaceStaticFile(filePath) {
+		return nil
+	}
+	return s.workspaceUploadRepo.Create(ctx, &models.WorkspaceUpload{FilePath: filePath})
+}
+
+// CleanupOrphanedUploads 清理超过指定时间仍未被引用的上传文件
+// 被引用的文件只删除上传记录，未被引用的文件连同缩略图一起删除
+func (s *workspaceUploadService) CleanupOrphanedUploads(ctx context.Context, olderThan time.Duration) (int, error) {
+	workspacePath := os.Getenv("WORKSPACE_PUBLIC_PATH")
+	if workspacePath == "" {
+		return 0, fmt.Errorf("环境变量 WORKSPACE_PUBLIC_PATH 未设置")
+	}
+
+	uploads, err := s.workspaceUploadRepo.ListCreatedBefore(ctx, time.Now().Add(-olderThan), orphanedUploadBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("查询上传记录失败: %w", err)
+	}
+
+	removed := 0
+	for _, upload := range uploads {
+		referenceCount, err := s.workspaceUploadRepo.CountFileReferences(ctx, upload.FilePath)
+		if err != nil {
+			return removed, fmt.Errorf("查询文件引用失败: %w", err)
+		}
+
+		if referenceCount == 0 {
+			fullPath := filepath.Join(workspacePath, filepath.FromSlash(upload.FilePath))
+			if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+				return removed, fmt.Errorf("删除文件失败: %w", err)
+			}
+			for _, size := range define.ImageThumbnailSizes {
+				_ = os.Remove(filepath.Join(filepath.Dir(fullPath), utils.ThumbnailFileName(filepath.Base(fullPath), size)))
+			}
+			removed++
+		}
+
+		if err := s.workspaceUploadRepo.HardDeleteByID(ctx, upload.ID); err != nil {
+			return removed, fmt.Errorf("删除上传记录失败: %w", err)
+		}
+	}
+	return removed, nil
+}
+
+// isWorkspaceStaticFile 判断是否为工作区公共目录下的文件路径
+func isWorkspaceStaticFile(filePath string) bool {
+	if strings.Contains(filePath, "..") {
+		return false
+	}
+	for _, dirName := range define.WorkspaceStaticDirNames {
+		if strings.HasPrefix(filePath, dirName) && len(filePath) > len(dirName) {
+			return true
+		}
+	}
+	return false
+}
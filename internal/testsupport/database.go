@@ -0,0 +1,40 @@
+// Package testsupport 提供集成测试使用的基础设施
+// 包含 SQLite 内存数据库、进程内运行的完整服务栈和常用的测试数据
+package testsupport
+
+import (
+	"lemon-tree-core/internal/core"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewDatabase 创建已迁移表结构的 SQLite 内存数据库
+// 每次调用得到独立的数据库，测试结束时自动关闭
+// 参数：t - 当前测试
+// 返回：数据库连接
+func NewDatabase(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:?_pragma=foreign_keys(1)"), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("打开内存数据库失败: %v", err)
+	}
+
+	// 内存数据库的每个连接都是独立的数据库，只保留一个连接
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取数据库连接失败: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := core.MigrateDatabase(db); err != nil {
+		t.Fatalf("迁移内存数据库失败: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,98 @@
+package testsupport
+
+import (
+	"lemon-tree-core/internal/define"
+	"lemon-tree-core/internal/models"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatAgentFixture 测试用的智能体及其所属应用和 API Key
+type ChatAgentFixture struct {
+	Application *models.Application // 所属应用
+	ChatAgent   *models.ChatAgent   // 智能体
+	ApiKey      string              // 智能体的 API Key
+}
+
+// Header 返回使用智能体 API Key 认证的请求头
+func (f *ChatAgentFixture) Header() http.Header {
+	return http.Header{define.HeaderApiKey: []string{f.ApiKey}}
+}
+
+// CreateChatAgent 创建应用、智能体和智能体的 API Key
+// 参数：t - 当前测试，db - 数据库连接
+// 返回：创建的测试数据
+func CreateChatAgent(t testing.TB, db *gorm.DB) *ChatAgentFixture {
+	t.Helper()
+
+	application := &models.Application{Name: "测试应用"}
+	mustCreate(t, db, application)
+
+	chatAgent := &models.ChatAgent{
+		Name:          "测试智能体",
+		ApplicationID: application.ID,
+	}
+	mustCreate(t, db, chatAgent)
+
+	apiKey := &models.ChatAgentApiKey{
+		Name:          "测试Key",
+		ApplicationID: application.ID,
+		ChatAgentID:   chatAgent.ID,
+		ApiKey:        uuid.NewString(),
+		ApiSecret:     uuid.NewString(),
+	}
+	mustCreate(t, db, apiKey)
+
+	return &ChatAgentFixture{Application: application, ChatAgent: chatAgent, ApiKey: apiKey.ApiKey}
+}
+
+// CreateConversation 创建属于业务侧用户的会话
+// 参数：t - 当前测试，db - 数据库连接，chatAgent - 所属智能体，serviceUserID - 业务侧用户ID，title - 会话标题
+// 返回：创建的会话
+func CreateConversation(t testing.TB, db *gorm.DB, chatAgent *models.ChatAgent, serviceUserID, title string) *models.ChatAgentConversation {
+	t.Helper()
+
+	// 与创建会话接口一致，以创建时间作为最后一条消息的时间
+	now := time.Now()
+	conversation := &models.ChatAgentConversation{
+		Title:         title,
+		ApplicationID: chatAgent.ApplicationID,
+		ChatAgentID:   chatAgent.ID,
+		ServiceUserID: serviceUserID,
+		LastMessageAt: &now,
+	}
+	mustCreate(t, db, conversation)
+	return conversation
+}
+
+// CreateMessage 在会话中创建一条普通消息
+// 参数：t - 当前测试，db - 数据库连接，conversation - 所属会话，requestID - 请求ID，role - 消息角色，content - 消息内容
+// 返回：创建的消息
+func CreateMessage(t testing.TB, db *gorm.DB, conversation *models.ChatAgentConversation, requestID, role, content string) *models.ChatAgentMessage {
+	t.Helper()
+
+	message := &models.ChatAgentMessage{
+		Title:          conversation.Title,
+		ApplicationID:  conversation.ApplicationID,
+		ChatAgentID:    conversation.ChatAgentID,
+		ConversationID: conversation.ID,
+		RequestID:      requestID,
+		Type:           "message",
+		Role:           role,
+		Content:        content,
+	}
+	mustCreate(t, db, message)
+	return message
+}
+
+// mustCreate 保存测试数据，失败时终止测试
+func mustCreate(t testing.TB, db *gorm.DB, value any) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("创建测试数据失败: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package testsupport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"lemon-tree-core/internal/core"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Server 进程内运行的完整服务栈
+// 使用 SQLite 内存数据库，请求直接交给路由引擎处理，不监听端口
+type Server struct {
+	Engine *gin.Engine // 注册了全部路由的 Gin 引擎
+	DB     *gorm.DB    // 服务使用的内存数据库
+}
+
+// NewServer 创建使用 SQLite 内存数据库的完整服务栈
+// 通过环境变量指定数据库配置，因此不能在并行测试中使用
+// 参数：t - 当前测试
+// 返回：服务栈
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_DATABASE", ":memory:")
+	t.Setenv("SERVER_MODE", gin.TestMode)
+
+	server := &Server{}
+	app := core.NewHandlerContainer(&server.Engine, &server.DB)
+	if err := app.Err(); err != nil {
+		t.Fatalf("创建服务栈失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := server.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return server
+}
+
+// Do 在进程内执行请求
+// 参数：method - 请求方法，path - 请求路径（含查询参数），body - 请求体，非 nil 时编码为 JSON，header - 额外的请求头
+// 返回：记录的响应
+func (s *Server) Do(t testing.TB, method, path string, body any, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("编码请求体失败: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	recorder := &streamRecorder{ResponseRecorder: httptest.NewRecorder()}
+	s.Engine.ServeHTTP(recorder, req)
+	return recorder.ResponseRecorder
+}
+
+// streamRecorder 支持流式响应的 ResponseRecorder
+// Gin 输出 SSE 流时需要 http.CloseNotifier，进程内请求的连接不会断开
+type streamRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+// CloseNotify 返回永不关闭的通知通道
+func (r *streamRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+// DecodeJSON 将响应体解码到 v
+// 参数：t - 当前测试，recorder - 记录的响应，v - 解码目标
+func DecodeJSON(t testing.TB, recorder *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+		t.Fatalf("解码响应失败: %v，响应内容: %s", err, recorder.Body.String())
+	}
+}
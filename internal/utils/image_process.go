@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // 注册 GIF 解码器
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+	"io"
+	"lemon-tree-core/internal/define"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // 注册 WebP 解码器
+)
+
+// SaveImageAsWebp 将上传的图片转换为 WebP 格式保存，并生成标准尺寸的缩略图
+// 缩略图文件名为 "原文件名_尺寸.webp"，与原图保存在同一目录
+// 参数：src - 图片内容（支持 JPEG、PNG、GIF、WebP），saveDir - 保存目录，baseName - 不含扩展名的文件名
+// 返回：保存的原图文件名和错误信息
+func SaveImageAsWebp(src io.Reader, saveDir, baseName string) (string, error) {
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return "", fmt.Errorf("解析图片失败: %w", err)
+	}
+
+	fileName := baseName + ".webp"
+	if err := writeWebp(filepath.Join(saveDir, fileName), img); err != nil {
+		return "", err
+	}
+
+	for _, size := range define.ImageThumbnailSizes {
+		if err := writeWebp(filepath.Join(saveDir, ThumbnailFileName(fileName, size)), resizeImage(img, size)); err != nil {
+			return "", err
+		}
+	}
+	return fileName, nil
+}
+
+// ThumbnailFileName 获取缩略图文件名
+// 参数：fileName - 原图文件名，size - 缩略图尺寸
+// 返回：缩略图文件名，如 abc.png 的 128 尺寸缩略图为 abc_128.webp
+func ThumbnailFileName(fileName string, size int) string {
+	return fmt.Sprintf("%s_%d.webp", strings.TrimSuffix(fileName, filepath.Ext(fileName)), size)
+}
+
+// IsValidThumbnailSize 判断是否为支持的缩略图尺寸
+func IsValidThumbnailSize(size int) bool {
+	return slices.Contains(define.ImageThumbnailSizes, size)
+}
+
+// EnsureThumbnail 获取图片指定尺寸的缩略图路径
+// 缩略图不存在时（如早于缩略图功能上传的图片）根据原图生成并保存
+// 参数：imagePath - 原图路径，size - 缩略图尺寸
+// 返回：缩略图路径和错误信息
+func EnsureThumbnail(imagePath string, size int) (string, error) {
+	thumbnailPath := filepath.Join(filepath.Dir(imagePath), ThumbnailFileName(filepath.Base(imagePath), size))
+	if _, err := os.Stat(thumbnailPath); err == nil {
+		return thumbnailPath, nil
+	}
+
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return "", fmt.Errorf("打开图片失败: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return "", fmt.Errorf("解析图片失败: %w", err)
+	}
+	if err := writeWebp(thumbnailPath, resizeImage(img, size)); err != nil {
+		return "", err
+	}
+	return thumbnailPath, nil
+}
+
+// resizeImage 按最长边等比缩放图片，不放大小于目标尺寸的图片
+func resizeImage(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*size/width)
+		width = size
+	} else {
+		width = max(1, width*size/height)
+		height = size
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+// writeWebp 将图片以 WebP 格式写入文件
+func writeWebp(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if err := nativewebp.Encode(file, img, nil); err != nil {
+		return fmt.Errorf("图片编码失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxJSONSchemaErrorCount 返回的最大校验错误数量，避免错误信息过长
+const maxJSONSchemaErrorCount = 10
+
+// ValidateJSONSchema 按 JSON Schema 校验 JSON 值
+// 支持常用的校验关键字：type、enum、const、required、properties、additionalProperties、items、
+// minItems、maxItems、minLength、maxLength、pattern、minimum、maximum、exclusiveMinimum、exclusiveMaximum、allOf、anyOf、oneOf
+// 不支持的关键字（如 $ref、format）忽略；value 为 encoding/json 解码得到的值
+// 返回校验错误列表，每条错误以 $ 开头的路径标明出错的位置，校验通过时返回空
+func ValidateJSONSchema(schema map[string]interface{}, value interface{}) []string {
+	var errs []string
+	validateJSONSchemaValue(schema, value, "$", &errs)
+	if len(errs) > maxJSONSchemaErrorCount {
+		errs = append(errs[:maxJSONSchemaErrorCount], fmt.Sprintf("另有%d个错误未列出", len(errs)-maxJSONSchemaErrorCount))
+	}
+	return errs
+}
+
+// validateJSONSchemaValue 校验单个值并将错误追加到 errs
+func validateJSONSchemaValue(schema map[string]interface{}, value interface{}, path string, errs *[]string) {
+	if len(schema) == 0 {
+		return
+	}
+	addError := func(format string, args ...interface{}) {
+		*errs = append(*errs, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, schemaType := range types {
+			if matchesJSONType(schemaType, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			addError("类型应为 %s，实际为 %s", strings.Join(types, " 或 "), jsonTypeOf(value))
+			// 类型不符时其余关键字没有意义
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsJSONValue(enum, value) {
+		addError("取值应为 %s 之一", formatJSONValues(enum))
+	}
+	if constant, ok := schema["const"]; ok && !reflect.DeepEqual(normalizeJSONValue(constant), normalizeJSONValue(value)) {
+		addError("取值应为 %s", formatJSONValues([]interface{}{constant}))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		validateJSONSchemaObject(schema, v, path, errs)
+	case []interface{}:
+		if minItems, ok := schemaNumber(schema["minItems"]); ok && float64(len(v)) < minItems {
+			addError("至少需要 %v 个元素", minItems)
+		}
+		if maxItems, ok := schemaNumber(schema["maxItems"]); ok && float64(len(v)) > maxItems {
+			addError("最多允许 %v 个元素", maxItems)
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateJSONSchemaValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case string:
+		length := float64(utf8.RuneCountInString(v))
+		if minLength, ok := schemaNumber(schema["minLength"]); ok && length < minLength {
+			addError("长度不能少于 %v 个字符", minLength)
+		}
+		if maxLength, ok := schemaNumber(schema["maxLength"]); ok && length > maxLength {
+			addError("长度不能超过 %v 个字符", maxLength)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				addError("不符合格式 %s", pattern)
+			}
+		}
+	case float64:
+		if minimum, ok := schemaNumber(schema["minimum"]); ok && v < minimum {
+			addError("不能小于 %v", minimum)
+		}
+		if maximum, ok := schemaNumber(schema["maximum"]); ok && v > maximum {
+			addError("不能大于 %v", maximum)
+		}
+		if exclusiveMinimum, ok := schemaNumber(schema["exclusiveMinimum"]); ok && v <= exclusiveMinimum {
+			addError("必须大于 %v", exclusiveMinimum)
+		}
+		if exclusiveMaximum, ok := schemaNumber(schema["exclusiveMaximum"]); ok && v >= exclusiveMaximum {
+			addError("必须小于 %v", exclusiveMaximum)
+		}
+	}
+
+	validateJSONSchemaCombinators(schema, value, path, errs)
+}
+
+// validateJSONSchemaObject 校验对象的必填字段、字段定义和额外字段
+func validateJSONSchemaObject(schema map[string]interface{}, value map[string]interface{}, path string, errs *[]string) {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, exists := value[name]; !exists {
+					*errs = append(*errs, fmt.Sprintf("%s: 缺少必填字段 %s", path, name))
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	// 按字段名排序，错误信息的顺序保持稳定
+	sort.Strings(names)
+	for _, name := range names {
+		fieldPath := path + "." + name
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			validateJSONSchemaValue(property, value[name], fieldPath, errs)
+			continue
+		}
+		if _, declared := properties[name]; declared {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, fmt.Sprintf("%s: 不允许的字段", fieldPath))
+			}
+		case map[string]interface{}:
+			validateJSONSchemaValue(additional, value[name], fieldPath, errs)
+		}
+	}
+}
+
+// validateJSONSchemaCombinators 校验 allOf、anyOf、oneOf 组合条件
+func validateJSONSchemaCombinators(schema map[string]interface{}, value interface{}, path string, errs *[]string) {
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, item := range allOf {
+			if subSchema, ok := item.(map[string]interface{}); ok {
+				validateJSONSchemaValue(subSchema, value, path, errs)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok && countMatchedSchemas(anyOf, value, path) == 0 {
+		*errs = append(*errs, fmt.Sprintf("%s: 不满足 anyOf 中的任何一个条件", path))
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok && countMatchedSchemas(oneOf, value, path) != 1 {
+		*errs = append(*errs, fmt.Sprintf("%s: 应恰好满足 oneOf 中的一个条件", path))
+	}
+}
+
+// countMatchedSchemas 统计值满足的子 Schema 数量
+func countMatchedSchemas(schemas []interface{}, value interface{}, path string) int {
+	matched := 0
+	for _, item := range schemas {
+		subSchema, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var subErrs []string
+		validateJSONSchemaValue(subSchema, value, path, &subErrs)
+		if len(subErrs) == 0 {
+			matched++
+		}
+	}
+	return matched
+}
+
+// schemaTypes 解析 type 关键字，支持字符串和字符串数组
+func schemaTypes(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, item := range v {
+			if schemaType, ok := item.(string); ok {
+				types = append(types, schemaType)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// schemaNumber 解析数值关键字
+func schemaNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		number, err := v.Float64()
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// matchesJSONType 判断值是否为指定的 JSON 类型
+func matchesJSONType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	}
+	return jsonTypeOf(value) == schemaType
+}
+
+// jsonTypeOf 返回值的 JSON 类型名称
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// containsJSONValue 判断值是否在列表中
+func containsJSONValue(values []interface{}, value interface{}) bool {
+	value = normalizeJSONValue(value)
+	for _, item := range values {
+		if reflect.DeepEqual(normalizeJSONValue(item), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeJSONValue 将 Schema 中以 Go 整数定义的数值统一为 float64，便于与解码得到的值比较
+func normalizeJSONValue(value interface{}) interface{} {
+	if number, ok := schemaNumber(value); ok {
+		return number
+	}
+	return value
+}
+
+// formatJSONValues 将值列表格式化为 JSON 文本
+func formatJSONValues(values []interface{}) string {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		data, _ := json.Marshal(value)
+		items = append(items, string(data))
+	}
+	return strings.Join(items, "、")
+}
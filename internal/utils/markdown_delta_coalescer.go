@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// markdownDeltaSoftFlushSize 非代码块内单行缓冲超过该字节数时，允许在空白处提前输出
+const markdownDeltaSoftFlushSize = 120
+
+// MarkdownDeltaCoalescer Markdown安全增量合并器
+// 缓冲流式增量内容，只在Markdown安全边界（完整的行、且不处于代码块内部）处输出，
+// 避免前端在代码块、行内代码或加粗标记被截断时渲染错乱
+type MarkdownDeltaCoalescer struct {
+	buffer strings.Builder // 尚未输出的内容
+}
+
+// NewMarkdownDeltaCoalescer 创建Markdown安全增量合并器
+func NewMarkdownDeltaCoalescer() *MarkdownDeltaCoalescer {
+	return &MarkdownDeltaCoalescer{}
+}
+
+// Push 写入一段增量内容
+// 参数：delta - 模型返回的增量内容
+// 返回：当前可以安全输出的内容，为空表示需要继续缓冲
+func (c *MarkdownDeltaCoalescer) Push(delta string) string {
+	c.buffer.WriteString(delta)
+	content := c.buffer.String()
+
+	safeEnd := markdownSafeEnd(content)
+	if safeEnd <= 0 {
+		return ""
+	}
+
+	c.buffer.Reset()
+	c.buffer.WriteString(content[safeEnd:])
+	return content[:safeEnd]
+}
+
+// Flush 输出剩余的全部缓冲内容
+// 返回：缓冲区中剩余的内容
+func (c *MarkdownDeltaCoalescer) Flush() string {
+	content := c.buffer.String()
+	c.buffer.Reset()
+	return content
+}
+
+// markdownSafeEnd 计算内容中最后一个Markdown安全边界的位置
+// 参数：content - 缓冲内容（调用方保证起始位置不处于代码块内部）
+// 返回：安全边界的字节偏移，0 表示没有安全边界
+func markdownSafeEnd(content string) int {
+	safeEnd := 0
+	fenceMarker := ""
+	lineStart := 0
+
+	for lineStart < len(content) {
+		newline := strings.IndexByte(content[lineStart:], '\n')
+		if newline < 0 {
+			break
+		}
+		lineEnd := lineStart + newline + 1
+		line := strings.TrimLeft(content[lineStart:lineEnd], " ")
+
+		// 识别代码块的开始与结束
+		if marker := fenceMarkerOf(line); marker != "" {
+			if fenceMarker == "" {
+				fenceMarker = marker
+			} else if strings.HasPrefix(line, fenceMarker) {
+				fenceMarker = ""
+			}
+		}
+
+		if fenceMarker == "" {
+			safeEnd = lineEnd
+		}
+		lineStart = lineEnd
+	}
+
+	// 不在代码块内且当前行过长时，允许在空白处提前输出，避免长段落迟迟不输出
+	if fenceMarker == "" && len(content)-lineStart > markdownDeltaSoftFlushSize {
+		tail := content[lineStart:]
+		if fenceMarkerOf(strings.TrimLeft(tail, " ")) == "" {
+			if end := inlineSafeEnd(tail); end > 0 {
+				safeEnd = lineStart + end
+			}
+		}
+	}
+
+	return safeEnd
+}
+
+// fenceMarkerOf 判断一行是否为代码块围栏
+// 参数：line - 去除前导空格后的行内容
+// 返回：围栏标记（``` 或 ~~~），不是围栏时返回空字符串
+func fenceMarkerOf(line string) string {
+	switch {
+	case strings.HasPrefix(line, "```"):
+		return "```"
+	case strings.HasPrefix(line, "~~~"):
+		return "~~~"
+	default:
+		return ""
+	}
+}
+
+// inlineSafeEnd 计算单行内容中最后一个行内安全边界（空白字符之后）
+// 要求边界之前的行内代码与成组的加粗、斜体标记成对出现；单词内部的 _（如 snake_case_name）不作为强调标记
+// 参数：line - 不含换行符的行内容
+// 返回：安全边界的字节偏移，0 表示没有安全边界
+func inlineSafeEnd(line string) int {
+	safeEnd := 0
+	backticks := 0
+	emphasis := 0
+
+	for i, r := range line {
+		switch r {
+		case '`':
+			backticks++
+		case '*', '_':
+			// 连续的标记（如 ** 或 __）作为一组计数
+			if backticks%2 == 0 && (i == 0 || rune(line[i-1]) != r) &&
+				(r == '*' || !isIntrawordUnderscore(line, i)) {
+				emphasis++
+			}
+		}
+		if unicode.IsSpace(r) && backticks%2 == 0 && emphasis%2 == 0 {
+			safeEnd = i + len(string(r))
+		}
+	}
+
+	return safeEnd
+}
+
+// isIntrawordUnderscore 判断 _ 是否位于单词内部
+// 与 CommonMark 一致，前后紧邻字母或数字的 _ 连续序列不能开始或结束强调
+// 参数：line - 行内容；i - _ 的字节偏移
+func isIntrawordUnderscore(line string, i int) bool {
+	start := i
+	for start > 0 && line[start-1] == '_' {
+		start--
+	}
+	end := i + 1
+	for end < len(line) && line[end] == '_' {
+		end++
+	}
+	if start == 0 || end == len(line) {
+		return false
+	}
+
+	before, _ := utf8.DecodeLastRuneInString(line[:start])
+	after, _ := utf8.DecodeRuneInString(line[end:])
+	return isWordRune(before) && isWordRune(after)
+}
+
+// isWordRune 判断字符是否为字母或数字
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
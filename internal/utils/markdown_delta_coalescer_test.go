@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInlineSafeEnd(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want int
+	}{
+		{name: "plain text", line: "hello world ", want: len("hello world ")},
+		{name: "no whitespace", line: "hello", want: 0},
+		{name: "unclosed bold", line: "see **bold text ", want: len("see ")},
+		{name: "closed bold", line: "see **bold** text ", want: len("see **bold** text ")},
+		{name: "unclosed underscore emphasis", line: "see _italic text ", want: len("see ")},
+		{name: "closed underscore emphasis", line: "see _italic_ text ", want: len("see _italic_ text ")},
+		{name: "underscores inside a word", line: "call snake_case_name now ", want: len("call snake_case_name now ")},
+		{name: "double underscores inside a word", line: "use __init__ or a__b here ", want: len("use __init__ or a__b here ")},
+		{name: "trailing underscore at word end", line: "use name_ ", want: len("use ")},
+		{name: "unclosed inline code", line: "run `go test ./... now", want: len("run ")},
+		{name: "underscore inside inline code", line: "run `a_b` now ", want: len("run `a_b` now ")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inlineSafeEnd(tt.line); got != tt.want {
+				t.Errorf("inlineSafeEnd(%q) = %d, want %d", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownDeltaCoalescerSoftFlushWithSnakeCase(t *testing.T) {
+	coalescer := NewMarkdownDeltaCoalescer()
+	line := strings.Repeat("set snake_case_name to value ", 6)
+
+	flushed := coalescer.Push(line)
+	if flushed == "" {
+		t.Fatalf("Push() did not flush a long line containing snake_case identifiers")
+	}
+	if got := flushed + coalescer.Flush(); got != line {
+		t.Errorf("flushed content = %q, want %q", got, line)
+	}
+}
+
+func TestMarkdownDeltaCoalescerKeepsCodeFenceTogether(t *testing.T) {
+	coalescer := NewMarkdownDeltaCoalescer()
+
+	if got := coalescer.Push("intro\n```go\nfunc main() {\n"); got != "intro\n" {
+		t.Errorf("Push() = %q, want %q", got, "intro\n")
+	}
+	if got := coalescer.Push("}\n```\n"); got != "```go\nfunc main() {\n}\n```\n" {
+		t.Errorf("Push() = %q, want the complete code block", got)
+	}
+}
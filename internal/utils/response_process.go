@@ -3,6 +3,8 @@ package utils
 import (
 	"database/sql"
 	"fmt"
+	"lemon-tree-core/internal/apiversion"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -10,7 +12,7 @@ import (
 	"time"
 )
 
-// ConvertToMapAndReplaceTime 递归转换任意对象为 map，并将 time.Time 替换为 Unix 时间戳
+// ConvertToMapAndReplaceTime 递归转换任意对象为 map，并将 time.Time 替换为 Unix 13位毫秒时间戳，与 DTO 的时间格式一致
 func ConvertToMapAndReplaceTime(v interface{}) interface{} {
 	if v == nil {
 		return nil
@@ -31,7 +33,7 @@ func ConvertToMapAndReplaceTime(v interface{}) interface{} {
 	case reflect.Struct:
 		// Special case: time.Time
 		if t, ok := v.(time.Time); ok {
-			return t.Unix()
+			return t.UnixMilli()
 		}
 
 		// Special case: uuid.UUID
@@ -42,7 +44,7 @@ func ConvertToMapAndReplaceTime(v interface{}) interface{} {
 		// Special case: sql.NullTime
 		if nt, ok := v.(sql.NullTime); ok {
 			if nt.Valid {
-				return nt.Time.Unix()
+				return nt.Time.UnixMilli()
 			}
 			return nil
 		}
@@ -50,7 +52,7 @@ func ConvertToMapAndReplaceTime(v interface{}) interface{} {
 		// Special case: gorm.DeletedAt
 		if da, ok := v.(gorm.DeletedAt); ok {
 			if da.Valid {
-				return da.Time.Unix()
+				return da.Time.UnixMilli()
 			}
 			return nil
 		}
@@ -105,10 +107,9 @@ func ConvertToMapAndReplaceTime(v interface{}) interface{} {
 	}
 }
 
+// JsonResponse 输出JSON响应
+// 响应DTO在输出前按当前请求的API版本映射为对应版本的结构
+// 参数：c - Gin 上下文；code - HTTP状态码；obj - 响应DTO
 func JsonResponse(c *gin.Context, code int, obj interface{}) {
-	//result := gin.H{"body": obj}
-	//fmt.Println("yyy: ", result)
-	//converted := ConvertToMapAndReplaceTime(result)
-	//converted := ConvertToMapAndReplaceTime(obj)
-	c.JSON(code, obj)
+	c.JSON(code, apiversion.Map(apiversion.FromContext(c), obj))
 }
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// EncryptSecret 使用 AES-GCM 加密需要保存到数据库的密钥、令牌等敏感信息
+// 加密密钥由 key 经 SHA-256 派生，结果为 base64 编码的随机数和密文
+// 参数：key - 加密密钥，plaintext - 明文
+// 返回：密文，明文为空时返回空
+func EncryptSecret(key, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	gcm, err := newSecretGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret 解密 EncryptSecret 加密的内容
+// 参数：key - 加密密钥，ciphertext - 密文
+// 返回：明文，密文为空时返回空；密钥不一致或密文被篡改时返回错误
+func DecryptSecret(key, ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	gcm, err := newSecretGCM(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("密文格式错误: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("密文格式错误")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("解密失败: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// newSecretGCM 根据加密密钥创建 AES-256-GCM
+func newSecretGCM(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, errors.New("未配置加密密钥")
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
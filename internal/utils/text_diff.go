@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"lemon-tree-core/internal/define"
+	"strings"
+)
+
+// maxTextDiffLineCount 参与逐行比较的最大行数，超出部分整体视为不同，避免比较耗时过长
+const maxTextDiffLineCount = 2000
+
+// TextDiffRow 并排对比的一行
+type TextDiffRow struct {
+	Type  string // 行类型：equal changed removed added
+	Left  string // 原始内容的行，类型为 added 时为空
+	Right string // 新内容的行，类型为 removed 时为空
+}
+
+// SideBySideTextDiff 逐行比较两段文本，返回并排对比的行
+// 按最长公共子序列对齐相同的行，相邻的删除行和新增行两两配对为 changed 行
+func SideBySideTextDiff(left, right string) []TextDiffRow {
+	leftLines, rightLines := splitDiffLines(left), splitDiffLines(right)
+	var leftTail, rightTail []string
+	if len(leftLines) > maxTextDiffLineCount {
+		leftLines, leftTail = leftLines[:maxTextDiffLineCount], leftLines[maxTextDiffLineCount:]
+	}
+	if len(rightLines) > maxTextDiffLineCount {
+		rightLines, rightTail = rightLines[:maxTextDiffLineCount], rightLines[maxTextDiffLineCount:]
+	}
+
+	// lcs[i][j] 为 leftLines[i:] 与 rightLines[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(leftLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(rightLines)+1)
+	}
+	for i := len(leftLines) - 1; i >= 0; i-- {
+		for j := len(rightLines) - 1; j >= 0; j-- {
+			if leftLines[i] == rightLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var rows []TextDiffRow
+	var removed, added []string
+	i, j := 0, 0
+	for i < len(leftLines) || j < len(rightLines) {
+		switch {
+		case i < len(leftLines) && j < len(rightLines) && leftLines[i] == rightLines[j]:
+			rows = appendChangedRows(rows, removed, added)
+			removed, added = nil, nil
+			rows = append(rows, TextDiffRow{Type: define.TextDiffTypeEqual, Left: leftLines[i], Right: rightLines[j]})
+			i++
+			j++
+		case j < len(rightLines) && (i == len(leftLines) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, rightLines[j])
+			j++
+		default:
+			removed = append(removed, leftLines[i])
+			i++
+		}
+	}
+	removed = append(removed, leftTail...)
+	added = append(added, rightTail...)
+	return appendChangedRows(rows, removed, added)
+}
+
+// appendChangedRows 将连续的删除行和新增行两两配对追加到结果中，多出的行单独作为删除或新增行
+func appendChangedRows(rows []TextDiffRow, removed, added []string) []TextDiffRow {
+	for k := 0; k < len(removed) || k < len(added); k++ {
+		switch {
+		case k < len(removed) && k < len(added):
+			rows = append(rows, TextDiffRow{Type: define.TextDiffTypeChanged, Left: removed[k], Right: added[k]})
+		case k < len(removed):
+			rows = append(rows, TextDiffRow{Type: define.TextDiffTypeRemoved, Left: removed[k]})
+		default:
+			rows = append(rows, TextDiffRow{Type: define.TextDiffTypeAdded, Right: added[k]})
+		}
+	}
+	return rows
+}
+
+// splitDiffLines 按行拆分文本，统一换行符，空文本返回空
+func splitDiffLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// EncodeFloat32Vector 将向量编码为小端序的二进制数据，用于在数据库中紧凑地保存嵌入向量
+func EncodeFloat32Vector(vector []float32) []byte {
+	data := make([]byte, len(vector)*4)
+	for i, value := range vector {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(value))
+	}
+	return data
+}
+
+// DecodeFloat32Vector 解码 EncodeFloat32Vector 编码的二进制向量
+func DecodeFloat32Vector(data []byte) ([]float32, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("向量数据长度无效: %d", len(data))
+	}
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return vector, nil
+}
+
+// CosineSimilarity 计算两个向量的余弦相似度，维度不一致或为零向量时返回0
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}